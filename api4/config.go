@@ -18,6 +18,9 @@ func (api *API) InitConfig() {
 	api.BaseRoutes.ApiRoot.Handle("/config/reload", api.ApiSessionRequired(configReload)).Methods("POST")
 	api.BaseRoutes.ApiRoot.Handle("/config/client", api.ApiHandler(getClientConfig)).Methods("GET")
 	api.BaseRoutes.ApiRoot.Handle("/config/environment", api.ApiSessionRequired(getEnvironmentConfig)).Methods("GET")
+	api.BaseRoutes.ApiRoot.Handle("/config/history", api.ApiSessionRequired(getConfigHistory)).Methods("GET")
+	api.BaseRoutes.ApiRoot.Handle("/config/diff", api.ApiSessionRequired(getConfigDiff)).Methods("GET")
+	api.BaseRoutes.ApiRoot.Handle("/config/history/{revision_id:[A-Za-z0-9]+}/rollback", api.ApiSessionRequired(rollbackConfig)).Methods("POST")
 }
 
 func getConfig(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -149,3 +152,72 @@ func getEnvironmentConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
 	w.Write([]byte(model.StringInterfaceToJson(envConfig)))
 }
+
+func getConfigHistory(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	revisions, err := c.App.GetConfigHistory(c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Write([]byte(model.ConfigRevisionListToJson(revisions)))
+}
+
+func getConfigDiff(c *Context, w http.ResponseWriter, r *http.Request) {
+	from := r.URL.Query().Get("from")
+	if !model.IsValidId(from) {
+		c.SetInvalidUrlParam("from")
+		return
+	}
+
+	to := r.URL.Query().Get("to")
+	if to != "" && !model.IsValidId(to) {
+		c.SetInvalidUrlParam("to")
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	diff, err := c.App.GetConfigDiff(from, to)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Write([]byte(diff.ToJson()))
+}
+
+func rollbackConfig(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireRevisionId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	if *c.App.Config().ExperimentalSettings.RestrictSystemAdmin {
+		c.Err = model.NewAppError("rollbackConfig", "api.restricted_system_admin", nil, "", http.StatusForbidden)
+		return
+	}
+
+	if err := c.App.RollbackConfig(c.Params.RevisionId); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("revision_id=" + c.Params.RevisionId)
+	ReturnStatusOK(w)
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/mattermost/mattermost-server/config"
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -136,6 +137,22 @@ func TestUpdateConfig(t *testing.T) {
 		assert.Equal(t, oldEnableUploads, *cfg.PluginSettings.EnableUploads)
 		assert.Equal(t, oldEnableUploads, *th.App.Config().PluginSettings.EnableUploads)
 	})
+
+	t.Run("Should not be able to add a secret reference", func(t *testing.T) {
+		os.Setenv("TEST_UPDATE_CONFIG_SECRET", "secret")
+		defer os.Unsetenv("TEST_UPDATE_CONFIG_SECRET")
+		os.Setenv(config.SecretReferencesEnvironmentVariable, "true")
+		defer os.Unsetenv(config.SecretReferencesEnvironmentVariable)
+
+		oldSecret := *th.App.Config().GitLabSettings.Secret
+
+		badcfg := cfg.Clone()
+		*badcfg.GitLabSettings.Secret = "env:TEST_UPDATE_CONFIG_SECRET"
+		_, resp = th.SystemAdminClient.UpdateConfig(badcfg)
+		CheckBadRequestStatus(t, resp)
+		CheckErrorMessage(t, resp, "app.save_config.secret_reference.app_error")
+		assert.Equal(t, oldSecret, *th.App.Config().GitLabSettings.Secret)
+	})
 }
 
 func TestUpdateConfigMessageExportSpecialHandling(t *testing.T) {
@@ -356,3 +373,30 @@ func TestGetOldClientConfig(t *testing.T) {
 		}
 	})
 }
+
+func TestConfigHistory(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	_, resp := th.Client.GetConfigHistory(0, 10)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.Client.GetConfigDiff(model.NewId(), "")
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.Client.RollbackConfig(model.NewId())
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.GetConfigDiff("invalid", "")
+	CheckBadRequestStatus(t, resp)
+
+	// The test server keeps its configuration in memory, which has no history.
+	_, resp = th.SystemAdminClient.GetConfigHistory(0, 10)
+	CheckNotImplementedStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.GetConfigDiff(model.NewId(), "")
+	CheckNotImplementedStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.RollbackConfig(model.NewId())
+	CheckNotImplementedStatus(t, resp)
+}
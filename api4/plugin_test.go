@@ -5,6 +5,7 @@ package api4
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
@@ -19,6 +20,7 @@ import (
 	"github.com/mattermost/mattermost-server/utils/fileutils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ed25519"
 )
 
 func TestPlugin(t *testing.T) {
@@ -441,3 +443,157 @@ func findClusterMessages(event string, msgs []*model.ClusterMessage) []*model.Cl
 	}
 	return result
 }
+
+func TestMarketplacePlugins(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	path, _ := fileutils.FindDir("tests")
+	tarData, err := ioutil.ReadFile(filepath.Join(path, "testplugin.tar.gz"))
+	require.Nil(t, err)
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.Nil(t, err)
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, tarData))
+
+	_, otherPrivateKey, err := ed25519.GenerateKey(nil)
+	require.Nil(t, err)
+	otherSignature := base64.StdEncoding.EncodeToString(ed25519.Sign(otherPrivateKey, tarData))
+
+	var catalog []*model.MarketplacePlugin
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/catalog":
+			res.WriteHeader(http.StatusOK)
+			res.Write([]byte(model.MarketplacePluginsToJson(catalog)))
+		case "/testplugin.tar.gz":
+			res.WriteHeader(http.StatusOK)
+			res.Write(tarData)
+		default:
+			res.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer testServer.Close()
+
+	catalog = []*model.MarketplacePlugin{
+		{
+			Id:   "testplugin",
+			Name: "testplugin",
+			Versions: []*model.MarketplacePluginVersion{
+				{Version: "0.1.0", DownloadURL: testServer.URL + "/testplugin.tar.gz", Signature: signature},
+				{Version: "0.2.0", DownloadURL: testServer.URL + "/testplugin.tar.gz", Signature: signature},
+				{Version: "0.3.0", DownloadURL: testServer.URL + "/testplugin.tar.gz", Signature: otherSignature},
+				{Version: "9.0.0", MinServerVersion: "999.0.0", DownloadURL: testServer.URL + "/testplugin.tar.gz", Signature: signature},
+			},
+		},
+	}
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.PluginSettings.Enable = true
+		*cfg.PluginSettings.AllowInsecureDownloadUrl = true
+		*cfg.PluginSettings.EnableMarketplace = false
+		*cfg.PluginSettings.MarketplaceUrl = testServer.URL + "/catalog"
+	})
+	defer os.RemoveAll("plugins/testplugin")
+
+	_, resp := th.SystemAdminClient.GetMarketplacePlugins()
+	CheckNotImplementedStatus(t, resp)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PluginSettings.EnableMarketplace = true })
+
+	_, resp = th.Client.GetMarketplacePlugins()
+	CheckForbiddenStatus(t, resp)
+
+	plugins, resp := th.SystemAdminClient.GetMarketplacePlugins()
+	CheckNoError(t, resp)
+	require.Len(t, plugins, 1)
+	assert.Equal(t, "testplugin", plugins[0].Id)
+	assert.Empty(t, plugins[0].InstalledVersion)
+
+	t.Run("bundles must be signed by a trusted key", func(t *testing.T) {
+		_, resp = th.SystemAdminClient.InstallMarketplacePlugin(&model.MarketplacePluginRequest{Id: "testplugin", Version: "0.1.0"})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	require.Nil(t, th.App.AddTrustedPluginKey("test", []byte(base64.StdEncoding.EncodeToString(publicKey))))
+	defer th.App.DeleteTrustedPluginKey("test")
+
+	t.Run("invalid requests", func(t *testing.T) {
+		_, resp = th.Client.InstallMarketplacePlugin(&model.MarketplacePluginRequest{Id: "testplugin"})
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.InstallMarketplacePlugin(&model.MarketplacePluginRequest{})
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.InstallMarketplacePlugin(&model.MarketplacePluginRequest{Id: "unknown"})
+		CheckNotFoundStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.InstallMarketplacePlugin(&model.MarketplacePluginRequest{Id: "testplugin", Version: "9.0.0"})
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.InstallMarketplacePlugin(&model.MarketplacePluginRequest{Id: "testplugin", Version: "0.3.0"})
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.UpgradeMarketplacePlugin(&model.MarketplacePluginRequest{Id: "testplugin"})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	manifest, resp := th.SystemAdminClient.InstallMarketplacePlugin(&model.MarketplacePluginRequest{Id: "testplugin", Version: "0.1.0"})
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, "testplugin", manifest.Id)
+
+	pluginStored, appErr := th.App.FileExists("./plugins/" + manifest.Id + ".tar.gz")
+	assert.Nil(t, appErr)
+	assert.True(t, pluginStored)
+
+	_, resp = th.SystemAdminClient.InstallMarketplacePlugin(&model.MarketplacePluginRequest{Id: "testplugin", Version: "0.1.0"})
+	CheckBadRequestStatus(t, resp)
+
+	manifest, resp = th.SystemAdminClient.UpgradeMarketplacePlugin(&model.MarketplacePluginRequest{Id: "testplugin"})
+	CheckNoError(t, resp)
+	assert.Equal(t, "testplugin", manifest.Id)
+
+	th.App.RemovePlugin(manifest.Id)
+}
+
+func TestPluginConfigRevisions(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.PluginSettings.Plugins["testplugin"] = map[string]interface{}{"setting": "first"}
+	})
+	th.App.Session = model.Session{UserId: th.SystemAdminUser.Id}
+	cfg := th.App.Config().Clone()
+	cfg.PluginSettings.Plugins["testplugin"] = map[string]interface{}{"setting": "second"}
+	require.Nil(t, th.App.SaveConfig(cfg, true))
+	th.App.Session = model.Session{}
+
+	_, resp := th.Client.GetPluginConfigRevisions("testplugin", 0, 10)
+	CheckForbiddenStatus(t, resp)
+
+	revisions, resp := th.SystemAdminClient.GetPluginConfigRevisions("testplugin", 0, 10)
+	CheckNoError(t, resp)
+	require.Len(t, revisions, 1)
+	assert.Equal(t, th.SystemAdminUser.Id, revisions[0].UserId)
+	assert.Equal(t, "second", revisions[0].Settings["setting"])
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.PluginSettings.Plugins["testplugin"] = map[string]interface{}{"setting": "third"}
+	})
+
+	_, resp = th.Client.RollbackPluginConfig("testplugin", revisions[0].Id)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.RollbackPluginConfig("testplugin", model.NewId())
+	CheckNotFoundStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.RollbackPluginConfig("otherplugin", revisions[0].Id)
+	CheckNotFoundStatus(t, resp)
+
+	ok, resp := th.SystemAdminClient.RollbackPluginConfig("testplugin", revisions[0].Id)
+	CheckNoError(t, resp)
+	assert.True(t, ok)
+	assert.Equal(t, "second", th.App.Config().PluginSettings.Plugins["testplugin"]["setting"])
+}
@@ -30,6 +30,10 @@ func (api *API) InitPost() {
 	api.BaseRoutes.Post.Handle("/patch", api.ApiSessionRequired(patchPost)).Methods("PUT")
 	api.BaseRoutes.Post.Handle("/pin", api.ApiSessionRequired(pinPost)).Methods("POST")
 	api.BaseRoutes.Post.Handle("/unpin", api.ApiSessionRequired(unpinPost)).Methods("POST")
+	api.BaseRoutes.Post.Handle("/ack", api.ApiSessionRequired(getPostAcknowledgementStatus)).Methods("GET")
+	api.BaseRoutes.PostForUser.Handle("/ack", api.ApiSessionRequired(acknowledgePost)).Methods("POST")
+	api.BaseRoutes.PostForUser.Handle("/ack", api.ApiSessionRequired(unacknowledgePost)).Methods("DELETE")
+	api.BaseRoutes.Post.Handle("/read_receipts", api.ApiSessionRequired(getPostReadReceipts)).Methods("GET")
 }
 
 func createPost(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -56,6 +60,11 @@ func createPost(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if post.IsUrgent() && !c.App.SessionHasPermissionToChannel(c.App.Session, post.ChannelId, model.PERMISSION_CREATE_URGENT_POST) {
+		c.SetPermissionError(model.PERMISSION_CREATE_URGENT_POST)
+		return
+	}
+
 	if post.CreateAt != 0 && !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
 		post.CreateAt = 0
 	}
@@ -659,3 +668,104 @@ func getFileInfosForPost(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Header().Set(model.HEADER_ETAG_SERVER, model.GetEtagForFileInfos(infos))
 	w.Write([]byte(model.FileInfosToJson(infos)))
 }
+
+func acknowledgePost(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	if c.Params.UserId != c.App.Session.UserId {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(c.App.Session, c.Params.PostId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	acknowledgement, err := c.App.AcknowledgePost(c.Params.UserId, c.Params.PostId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(acknowledgement.ToJson()))
+}
+
+func unacknowledgePost(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	if c.Params.UserId != c.App.Session.UserId {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(c.App.Session, c.Params.PostId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	if err := c.App.UnacknowledgePost(c.Params.UserId, c.Params.PostId); err != nil {
+		c.Err = err
+		return
+	}
+
+	ReturnStatusOK(w)
+}
+
+func getPostAcknowledgementStatus(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(c.App.Session, c.Params.PostId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	post, err := c.App.GetSinglePost(c.Params.PostId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	status, err := c.App.GetPostAcknowledgementStatus(post)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(status.ToJson()))
+}
+
+func getPostReadReceipts(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(c.App.Session, c.Params.PostId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	post, err := c.App.GetSinglePost(c.Params.PostId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	receipts, err := c.App.GetPostReadReceipts(post, c.App.Session.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(receipts.ToJson()))
+}
@@ -922,6 +922,159 @@ func TestPinPost(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestPostPriorityAndAcknowledgements(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	post := &model.Post{
+		ChannelId: th.BasicChannel.Id,
+		Message:   "urgent announcement",
+		Metadata: &model.PostMetadata{
+			Priority: &model.PostPriority{Priority: model.POST_PRIORITY_URGENT},
+		},
+	}
+
+	_, resp := Client.CreatePost(post)
+	CheckNotImplementedStatus(t, resp)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableUrgentPostPriority = true })
+
+	th.RemovePermissionFromRole(model.PERMISSION_CREATE_URGENT_POST.Id, model.CHANNEL_USER_ROLE_ID)
+	_, resp = Client.CreatePost(post)
+	CheckForbiddenStatus(t, resp)
+	th.AddPermissionToRole(model.PERMISSION_CREATE_URGENT_POST.Id, model.CHANNEL_USER_ROLE_ID)
+
+	rpost, resp := Client.CreatePost(post)
+	CheckNoError(t, resp)
+	require.NotNil(t, rpost.GetPriority())
+	assert.Equal(t, model.POST_PRIORITY_URGENT, rpost.GetPriority().Priority)
+	assert.True(t, rpost.GetPriority().RequestedAck)
+
+	rpost, resp = Client.GetPost(rpost.Id, "")
+	CheckNoError(t, resp)
+	require.NotNil(t, rpost.GetPriority(), "priority should be loaded with the post")
+	assert.True(t, rpost.IsUrgent())
+
+	t.Run("replies can't have a priority", func(t *testing.T) {
+		reply := &model.Post{
+			ChannelId: th.BasicChannel.Id,
+			RootId:    rpost.Id,
+			Message:   "reply",
+			Metadata:  &model.PostMetadata{Priority: &model.PostPriority{Priority: model.POST_PRIORITY_IMPORTANT}},
+		}
+		_, resp = Client.CreatePost(reply)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("posts without requested acknowledgement", func(t *testing.T) {
+		_, resp = Client.AcknowledgePost(th.BasicUser.Id, th.BasicPost.Id)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	th.LoginBasic2()
+
+	ack, resp := Client.AcknowledgePost(th.BasicUser2.Id, rpost.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, th.BasicUser2.Id, ack.UserId)
+	assert.NotZero(t, ack.AcknowledgedAt)
+
+	_, resp = Client.AcknowledgePost(th.BasicUser.Id, rpost.Id)
+	CheckForbiddenStatus(t, resp)
+
+	th.LoginBasic()
+
+	status, resp := Client.GetPostAcknowledgementStatus(rpost.Id)
+	CheckNoError(t, resp)
+	require.Len(t, status.Acknowledgements, 1)
+	assert.Equal(t, th.BasicUser2.Id, status.Acknowledgements[0].UserId)
+	assert.NotContains(t, status.PendingUserIds, th.BasicUser.Id, "the author doesn't need to acknowledge")
+	assert.NotContains(t, status.PendingUserIds, th.BasicUser2.Id)
+
+	rpost, resp = Client.GetPost(rpost.Id, "")
+	CheckNoError(t, resp)
+	require.Len(t, rpost.Metadata.Acknowledgements, 1)
+
+	th.LoginBasic2()
+
+	ok, resp := Client.UnacknowledgePost(th.BasicUser2.Id, rpost.Id)
+	CheckNoError(t, resp)
+	assert.True(t, ok)
+
+	status, resp = Client.GetPostAcknowledgementStatus(rpost.Id)
+	CheckNoError(t, resp)
+	assert.Empty(t, status.Acknowledgements)
+	assert.Contains(t, status.PendingUserIds, th.BasicUser2.Id)
+
+	Client.Logout()
+	_, resp = Client.GetPostAcknowledgementStatus(rpost.Id)
+	CheckUnauthorizedStatus(t, resp)
+}
+
+func TestGetPostReadReceipts(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	dm := th.CreateDmChannel(th.BasicUser2)
+	post := th.CreateMessagePostWithClient(Client, dm, "are you there?")
+
+	receipts, resp := Client.GetPostReadReceipts(post.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, post.Id, receipts.PostId)
+	assert.Empty(t, receipts.ReadBy, "the author shouldn't be counted and the recipient hasn't viewed the channel")
+
+	th.LoginBasic2()
+	_, resp = Client.ViewChannel(th.BasicUser2.Id, &model.ChannelView{ChannelId: dm.Id})
+	CheckNoError(t, resp)
+
+	th.LoginBasic()
+	receipts, resp = Client.GetPostReadReceipts(post.Id)
+	CheckNoError(t, resp)
+	require.Len(t, receipts.ReadBy, 1)
+	assert.Equal(t, th.BasicUser2.Id, receipts.ReadBy[0].UserId)
+	assert.True(t, receipts.ReadBy[0].LastViewedAt >= post.CreateAt)
+
+	t.Run("not available outside of direct and group messages", func(t *testing.T) {
+		_, resp = Client.GetPostReadReceipts(th.BasicPost.Id)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("users that opted out are hidden", func(t *testing.T) {
+		th.LoginBasic2()
+		_, resp = Client.UpdatePreferences(th.BasicUser2.Id, &model.Preferences{{
+			UserId:   th.BasicUser2.Id,
+			Category: model.PREFERENCE_CATEGORY_PRIVACY,
+			Name:     model.PREFERENCE_NAME_READ_RECEIPTS,
+			Value:    model.PREFERENCE_READ_RECEIPTS_HIDDEN,
+		}})
+		CheckNoError(t, resp)
+
+		_, resp = Client.GetPostReadReceipts(post.Id)
+		CheckForbiddenStatus(t, resp)
+
+		th.LoginBasic()
+		receipts, resp = Client.GetPostReadReceipts(post.Id)
+		CheckNoError(t, resp)
+		assert.False(t, receipts.HasRead(th.BasicUser2.Id))
+	})
+
+	t.Run("disabled by the system admin", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableReadReceipts = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableReadReceipts = true })
+
+		th.LoginBasic()
+		_, resp = Client.GetPostReadReceipts(post.Id)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	t.Run("no permission to the channel", func(t *testing.T) {
+		th.LoginTeamAdmin()
+		_, resp = Client.GetPostReadReceipts(post.Id)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
 func TestUnpinPost(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -13,6 +13,9 @@ func (api *API) InitStatus() {
 	api.BaseRoutes.User.Handle("/status", api.ApiSessionRequired(getUserStatus)).Methods("GET")
 	api.BaseRoutes.Users.Handle("/status/ids", api.ApiSessionRequired(getUserStatusesByIds)).Methods("POST")
 	api.BaseRoutes.User.Handle("/status", api.ApiSessionRequired(updateUserStatus)).Methods("PUT")
+	api.BaseRoutes.User.Handle("/status/custom", api.ApiSessionRequired(getUserCustomStatus)).Methods("GET")
+	api.BaseRoutes.User.Handle("/status/custom", api.ApiSessionRequired(updateUserCustomStatus)).Methods("PUT")
+	api.BaseRoutes.User.Handle("/status/custom", api.ApiSessionRequired(removeUserCustomStatus)).Methods("DELETE")
 }
 
 func getUserStatus(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -92,7 +95,15 @@ func updateUserStatus(c *Context, w http.ResponseWriter, r *http.Request) {
 	case "away":
 		c.App.SetStatusAwayIfNeeded(c.Params.UserId, true)
 	case "dnd":
-		c.App.SetStatusDoNotDisturb(c.Params.UserId)
+		if status.DNDEndTime > 0 {
+			if status.DNDEndTime <= model.GetMillis() {
+				c.SetInvalidParam("dnd_end_time")
+				return
+			}
+			c.App.SetStatusDoNotDisturbTimed(c.Params.UserId, status.DNDEndTime)
+		} else {
+			c.App.SetStatusDoNotDisturb(c.Params.UserId)
+		}
 	default:
 		c.SetInvalidParam("status")
 		return
@@ -100,3 +111,70 @@ func updateUserStatus(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	getUserStatus(c, w, r)
 }
+
+func getUserCustomStatus(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	// No permission check required
+
+	customStatus, err := c.App.GetCustomStatus(c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if customStatus == nil {
+		c.Err = model.NewAppError("getUserCustomStatus", "api.custom_status.not_found.app_error", nil, "", http.StatusNotFound)
+		return
+	}
+
+	w.Write([]byte(customStatus.ToJson()))
+}
+
+func updateUserCustomStatus(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	customStatus := model.CustomStatusFromJson(r.Body)
+	if customStatus == nil {
+		c.SetInvalidParam("custom_status")
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(c.App.Session, c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	customStatus, err := c.App.SetCustomStatus(c.Params.UserId, customStatus)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(customStatus.ToJson()))
+}
+
+func removeUserCustomStatus(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(c.App.Session, c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	if err := c.App.RemoveCustomStatus(c.Params.UserId); err != nil {
+		c.Err = err
+		return
+	}
+
+	ReturnStatusOK(w)
+}
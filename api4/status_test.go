@@ -142,6 +142,18 @@ func TestUpdateUserStatus(t *testing.T) {
 		t.Fatal("Should return dnd status")
 	}
 
+	toUpdateUserStatus.DNDEndTime = model.GetMillis() + 60*60*1000
+	updateUserStatus, resp = Client.UpdateUserStatus(th.BasicUser.Id, toUpdateUserStatus)
+	CheckNoError(t, resp)
+	if updateUserStatus.Status != "dnd" || updateUserStatus.DNDEndTime != toUpdateUserStatus.DNDEndTime {
+		t.Fatal("Should return timed dnd status")
+	}
+
+	toUpdateUserStatus.DNDEndTime = model.GetMillis() - 1000
+	_, resp = Client.UpdateUserStatus(th.BasicUser.Id, toUpdateUserStatus)
+	CheckBadRequestStatus(t, resp)
+	toUpdateUserStatus.DNDEndTime = 0
+
 	toUpdateUserStatus.Status = "offline"
 	updateUserStatus, resp = Client.UpdateUserStatus(th.BasicUser.Id, toUpdateUserStatus)
 	CheckNoError(t, resp)
@@ -168,3 +180,51 @@ func TestUpdateUserStatus(t *testing.T) {
 	_, resp = Client.UpdateUserStatus(th.BasicUser2.Id, toUpdateUserStatus)
 	CheckUnauthorizedStatus(t, resp)
 }
+
+func TestUserCustomStatus(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	_, resp := Client.GetUserCustomStatus(th.BasicUser.Id)
+	CheckNotFoundStatus(t, resp)
+
+	customStatus, resp := Client.UpdateUserCustomStatus(th.BasicUser.Id, &model.CustomStatus{Emoji: ":palm_tree:", Text: " On vacation "})
+	CheckNoError(t, resp)
+	if customStatus.Emoji != "palm_tree" || customStatus.Text != "On vacation" {
+		t.Fatal("Should return the trimmed custom status")
+	}
+
+	customStatus, resp = th.Client.GetUserCustomStatus(th.BasicUser.Id)
+	CheckNoError(t, resp)
+	if customStatus.Text != "On vacation" {
+		t.Fatal("Should return the saved custom status")
+	}
+
+	user, resp := th.SystemAdminClient.GetUser(th.BasicUser.Id, "")
+	CheckNoError(t, resp)
+	if user.GetCustomStatus() == nil || user.GetCustomStatus().Text != "On vacation" {
+		t.Fatal("Should include the custom status in the profile")
+	}
+
+	_, resp = Client.UpdateUserCustomStatus(th.BasicUser.Id, &model.CustomStatus{})
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = Client.UpdateUserCustomStatus(th.BasicUser.Id, &model.CustomStatus{Text: "Lunch", ExpiresAt: model.GetMillis() - 1000})
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = Client.UpdateUserCustomStatus(th.BasicUser2.Id, &model.CustomStatus{Text: "Lunch"})
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = Client.RemoveUserCustomStatus(th.BasicUser2.Id)
+	CheckForbiddenStatus(t, resp)
+
+	ok, resp := Client.RemoveUserCustomStatus(th.BasicUser.Id)
+	CheckNoError(t, resp)
+	if !ok {
+		t.Fatal("Should have removed the custom status")
+	}
+
+	_, resp = Client.GetUserCustomStatus(th.BasicUser.Id)
+	CheckNotFoundStatus(t, resp)
+}
@@ -67,11 +67,12 @@ func getSystemPing(c *Context, w http.ResponseWriter, r *http.Request) {
 		dbStatusKey := "database_status"
 		s[dbStatusKey] = model.STATUS_OK
 
-		// Database Write/Read Check
+		// Database Write/Read Check, against the master since replicas may not have the write yet
+		c.App.ReadFromMaster()
 		currentTime := fmt.Sprintf("%d", time.Now().Unix())
 		healthCheckKey := "health_check"
 
-		writeErr := c.App.Srv.Store.System().SaveOrUpdate(&model.System{
+		writeErr := c.App.Store().System().SaveOrUpdate(&model.System{
 			Name:  healthCheckKey,
 			Value: currentTime,
 		})
@@ -80,7 +81,7 @@ func getSystemPing(c *Context, w http.ResponseWriter, r *http.Request) {
 			s[dbStatusKey] = model.STATUS_UNHEALTHY
 			s[model.STATUS] = model.STATUS_UNHEALTHY
 		} else {
-			healthCheck, readErr := c.App.Srv.Store.System().GetByName(healthCheckKey)
+			healthCheck, readErr := c.App.Store().System().GetByName(healthCheckKey)
 			if readErr != nil {
 				mlog.Debug("Unable to read from database.", mlog.Err(readErr))
 				s[dbStatusKey] = model.STATUS_UNHEALTHY
@@ -94,6 +95,20 @@ func getSystemPing(c *Context, w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
+		// Replicas lagging too far behind are already excluded from reads, so they are reported
+		// without affecting the overall status.
+		replicaStatusKey := "replica_status"
+		if replicaStatuses := c.App.Srv.Store.ReplicaStatuses(); len(replicaStatuses) > 0 {
+			s[replicaStatusKey] = model.STATUS_OK
+			for _, replicaStatus := range replicaStatuses {
+				if !replicaStatus.Healthy {
+					mlog.Debug("Read replica is unhealthy.", mlog.String("replica", replicaStatus.Name), mlog.Any("lag_seconds", replicaStatus.LagSeconds), mlog.String("error", replicaStatus.Error))
+					s[replicaStatusKey] = model.STATUS_UNHEALTHY
+				}
+			}
+			w.Header().Set(replicaStatusKey, s[replicaStatusKey])
+		}
+
 		filestoreStatusKey := "filestore_status"
 		s[filestoreStatusKey] = model.STATUS_OK
 		license := c.App.License()
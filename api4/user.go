@@ -97,7 +97,7 @@ func createUser(c *Context, w http.ResponseWriter, r *http.Request) {
 	var err *model.AppError
 	if len(tokenId) > 0 {
 		var token *model.Token
-		token, err = c.App.Srv.Store.Token().GetByToken(tokenId)
+		token, err = c.App.Store().Token().GetByToken(tokenId)
 		if err != nil {
 			c.Err = model.NewAppError("CreateUserWithToken", "api.user.create_user.signup_link_invalid.app_error", nil, err.Error(), http.StatusBadRequest)
 			return
@@ -15,6 +15,7 @@ func (api *API) InitWebhook() {
 	api.BaseRoutes.IncomingHook.Handle("", api.ApiSessionRequired(getIncomingHook)).Methods("GET")
 	api.BaseRoutes.IncomingHook.Handle("", api.ApiSessionRequired(updateIncomingHook)).Methods("PUT")
 	api.BaseRoutes.IncomingHook.Handle("", api.ApiSessionRequired(deleteIncomingHook)).Methods("DELETE")
+	api.BaseRoutes.IncomingHook.Handle("/preview", api.ApiSessionRequired(previewIncomingHook)).Methods("POST")
 
 	api.BaseRoutes.OutgoingHooks.Handle("", api.ApiSessionRequired(createOutgoingHook)).Methods("POST")
 	api.BaseRoutes.OutgoingHooks.Handle("", api.ApiSessionRequired(getOutgoingHooks)).Methods("GET")
@@ -216,6 +217,60 @@ func getIncomingHook(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(hook.ToJson()))
 }
 
+func previewIncomingHook(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireHookId()
+	if c.Err != nil {
+		return
+	}
+
+	preview := model.IncomingWebhookPreviewFromJson(r.Body)
+	if preview == nil {
+		c.SetInvalidParam("preview")
+		return
+	}
+
+	hook, err := c.App.GetIncomingWebhook(c.Params.HookId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	channel, err := c.App.GetChannel(hook.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(c.App.Session, hook.TeamId, model.PERMISSION_MANAGE_INCOMING_WEBHOOKS) ||
+		(channel.Type != model.CHANNEL_OPEN && !c.App.SessionHasPermissionToChannel(c.App.Session, hook.ChannelId, model.PERMISSION_READ_CHANNEL)) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_INCOMING_WEBHOOKS)
+		return
+	}
+
+	if c.App.Session.UserId != hook.UserId && !c.App.SessionHasPermissionToTeam(c.App.Session, hook.TeamId, model.PERMISSION_MANAGE_OTHERS_INCOMING_WEBHOOKS) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_OTHERS_INCOMING_WEBHOOKS)
+		return
+	}
+
+	// Copy the hook since it may be shared with the webhook cache
+	previewHook := *hook
+	if preview.PayloadTemplate != nil {
+		if len(*preview.PayloadTemplate) > model.INCOMING_WEBHOOK_PAYLOAD_TEMPLATE_MAX_LENGTH {
+			c.SetInvalidParam("payload_template")
+			return
+		}
+		previewHook.PayloadTemplate = *preview.PayloadTemplate
+	}
+
+	request, err := c.App.RenderIncomingWebhookPayload(&previewHook, preview.Payload)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(request.ToJson()))
+}
+
 func deleteIncomingHook(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireHookId()
 	if c.Err != nil {
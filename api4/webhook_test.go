@@ -308,6 +308,56 @@ func TestGetIncomingWebhook(t *testing.T) {
 	})
 }
 
+func TestPreviewIncomingWebhook(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.SystemAdminClient
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableIncomingWebhooks = true })
+
+	hook, resp := Client.CreateIncomingWebhook(&model.IncomingWebhook{
+		ChannelId:       th.BasicChannel.Id,
+		PayloadTemplate: `{"text": {{ json (printf "%s: %s" .level .message) }}, "channel": {{ json .target }}}`,
+	})
+	CheckNoError(t, resp)
+
+	payload := []byte(`{"level": "error", "message": "disk full", "target": "#alerts"}`)
+
+	t.Run("WithSavedTemplate", func(t *testing.T) {
+		request, resp := Client.PreviewIncomingWebhook(hook.Id, &model.IncomingWebhookPreview{Payload: payload})
+		CheckNoError(t, resp)
+		require.NotNil(t, request)
+		assert.Equal(t, "error: disk full", request.Text)
+		assert.Equal(t, "#alerts", request.ChannelName)
+	})
+
+	t.Run("WithTemplateOverride", func(t *testing.T) {
+		override := `{"text": {{ json (upper .message) }}}`
+		request, resp := Client.PreviewIncomingWebhook(hook.Id, &model.IncomingWebhookPreview{PayloadTemplate: &override, Payload: payload})
+		CheckNoError(t, resp)
+		require.NotNil(t, request)
+		assert.Equal(t, "DISK FULL", request.Text)
+
+		// The override must not be saved
+		savedHook, resp := Client.GetIncomingWebhook(hook.Id, "")
+		CheckNoError(t, resp)
+		assert.Equal(t, hook.PayloadTemplate, savedHook.PayloadTemplate)
+	})
+
+	t.Run("WithInvalidTemplate", func(t *testing.T) {
+		override := `{{ .message `
+		_, resp := Client.PreviewIncomingWebhook(hook.Id, &model.IncomingWebhookPreview{PayloadTemplate: &override, Payload: payload})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("WhenUserDoesNotHavePemissions", func(t *testing.T) {
+		th.LoginBasic()
+
+		_, resp := th.Client.PreviewIncomingWebhook(hook.Id, &model.IncomingWebhookPreview{Payload: payload})
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
 func TestDeleteIncomingWebhook(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -16,6 +16,8 @@ import (
 	"github.com/mattermost/mattermost-server/services/httpservice"
 	"github.com/mattermost/mattermost-server/services/imageproxy"
 	"github.com/mattermost/mattermost-server/services/timezones"
+	"github.com/mattermost/mattermost-server/services/tracing"
+	"github.com/mattermost/mattermost-server/store"
 	"github.com/mattermost/mattermost-server/utils"
 )
 
@@ -46,6 +48,23 @@ type App struct {
 	HTTPService httpservice.HTTPService
 	ImageProxy  *imageproxy.ImageProxy
 	Timezones   *timezones.Timezones
+
+	// Span is the span of the request served by this App, if traced. See SetSpan.
+	Span *tracing.Span
+
+	// store replaces the server's store for this App only, see ReadFromMaster and SetSpan.
+	store          store.Store
+	readFromMaster bool
+}
+
+// tracedStore is a store able to report its calls as spans of a trace.
+type tracedStore interface {
+	Traced(span *tracing.Span) store.Store
+}
+
+// auditedStore is a store recording the changes made through it to the audit log.
+type auditedStore interface {
+	Audited(actor func() store.AuditActor) store.Store
 }
 
 func New(options ...AppOption) *App {
@@ -58,6 +77,73 @@ func New(options ...AppOption) *App {
 	return app
 }
 
+// Store returns the store used by this App: the server's store, unless ReadFromMaster or SetSpan
+// was called.
+func (a *App) Store() store.Store {
+	if a.store != nil {
+		return a.store
+	}
+
+	return a.Srv.Store
+}
+
+// ReadFromMaster makes every following read of this App go to the master database instead of
+// the read replicas, which may not have caught up with recent writes yet.
+func (a *App) ReadFromMaster() {
+	a.readFromMaster = true
+	a.updateStore()
+}
+
+// SetSpan makes the following store calls of this App spans nested in the given one.
+func (a *App) SetSpan(span *tracing.Span) {
+	a.Span = span
+	a.updateStore()
+}
+
+// startSpan starts a span nested in the one of the request, if traced, for the named operation of
+// the App. The store calls made until the returned function finishes the span are nested in it.
+func (a *App) startSpan(name string) func() {
+	parent := a.Span
+	span := parent.StartChild("App."+name, tracing.SPAN_KIND_INTERNAL)
+	if span == nil {
+		return func() {}
+	}
+
+	a.SetSpan(span)
+	return func() {
+		span.Finish()
+		a.SetSpan(parent)
+	}
+}
+
+func (a *App) updateStore() {
+	s := a.Srv.Store
+	if a.readFromMaster {
+		s = s.MasterOnly()
+	}
+
+	if traced, ok := s.(tracedStore); ok && a.Span != nil {
+		s = traced.Traced(a.Span)
+	}
+
+	if audited, ok := s.(auditedStore); ok {
+		s = audited.Audited(a.auditActor)
+	}
+
+	a.store = s
+}
+
+// auditActor returns who the changes made by this App are made on behalf of, as of the session
+// it currently serves.
+func (a *App) auditActor() store.AuditActor {
+	return store.AuditActor{
+		UserId:    a.Session.UserId,
+		SessionId: a.Session.Id,
+		RequestId: a.RequestId,
+		IpAddress: a.IpAddress,
+	}
+}
+
 // DO NOT CALL THIS.
 // This is to avoid having to change all the code in cmd/mattermost/commands/* for now
 // shutdown should be called directly on the server
@@ -93,6 +179,12 @@ func (s *Server) initJobs() {
 	if jobsPluginsInterface != nil {
 		s.Jobs.Plugins = jobsPluginsInterface(s.FakeApp())
 	}
+	if jobsPostArchivalInterface != nil {
+		s.Jobs.PostArchival = jobsPostArchivalInterface(s.FakeApp())
+	}
+	if jobsPostShardRebalanceInterface != nil {
+		s.Jobs.PostShardRebalance = jobsPostShardRebalanceInterface(s.FakeApp())
+	}
 	s.Jobs.Workers = s.Jobs.InitWorkers()
 	s.Jobs.Schedulers = s.Jobs.InitSchedulers()
 }
@@ -109,7 +201,7 @@ func (a *App) EnsureDiagnosticId() {
 	if a.Srv.diagnosticId != "" {
 		return
 	}
-	props, err := a.Srv.Store.System().Get()
+	props, err := a.Store().System().Get()
 	if err != nil {
 		return
 	}
@@ -118,7 +210,7 @@ func (a *App) EnsureDiagnosticId() {
 	if len(id) == 0 {
 		id = model.NewId()
 		systemId := &model.System{Name: model.SYSTEM_DIAGNOSTIC_ID, Value: id}
-		a.Srv.Store.System().Save(systemId)
+		a.Store().System().Save(systemId)
 	}
 
 	a.Srv.diagnosticId = id
@@ -145,7 +237,7 @@ func (a *App) Handle404(w http.ResponseWriter, r *http.Request) {
 }
 
 func (a *App) getSystemInstallDate() (int64, *model.AppError) {
-	systemData, appErr := a.Srv.Store.System().GetByName(model.SYSTEM_INSTALLATION_DATE_KEY)
+	systemData, appErr := a.Store().System().GetByName(model.SYSTEM_INSTALLATION_DATE_KEY)
 	if appErr != nil {
 		return 0, appErr
 	}
@@ -8,9 +8,9 @@ import (
 )
 
 func (a *App) GetAudits(userId string, limit int) (model.Audits, *model.AppError) {
-	return a.Srv.Store.Audit().Get(userId, 0, limit)
+	return a.Store().Audit().Get(userId, 0, limit)
 }
 
 func (a *App) GetAuditsPage(userId string, page int, perPage int) (model.Audits, *model.AppError) {
-	return a.Srv.Store.Audit().Get(userId, page*perPage, perPage)
+	return a.Store().Audit().Get(userId, page*perPage, perPage)
 }
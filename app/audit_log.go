@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	AUDIT_LOG_EXPORT_BATCH_SIZE = 1000
+)
+
+// readAuditLogKey returns the key the hashes of the audit log are keyed with, read from a file for
+// the audit log not to be rewritten from the database alone, or nil if there is none.
+func readAuditLogKey(settings model.ComplianceSettings) ([]byte, error) {
+	if !*settings.EnableAuditLog || *settings.AuditLogKeyFile == "" {
+		return nil, nil
+	}
+
+	key, err := ioutil.ReadFile(*settings.AuditLogKeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the audit log key file")
+	}
+
+	key = bytes.TrimSpace(key)
+	if len(key) == 0 {
+		return nil, errors.New("the audit log key file is empty")
+	}
+
+	return key, nil
+}
+
+// ExportAuditLog writes the records of the audit log following the given sequence as JSON lines.
+// When verifying, it stops at the first record breaking the hash chain, which is checked with the
+// given key from the record at the given sequence on. It returns the last record written, if any.
+func (a *App) ExportAuditLog(writer io.Writer, afterSequence int64, verify bool, key []byte) (*model.AuditLogRecord, *model.AppError) {
+	verifier := &model.AuditLogVerifier{Key: key}
+	if afterSequence > 0 {
+		records, err := a.Srv.Store.AuditLog().GetAfter(afterSequence-1, 1)
+		if err != nil {
+			return nil, err
+		} else if len(records) == 0 || records[0].Sequence != afterSequence {
+			return nil, model.NewAppError("ExportAuditLog", "app.audit_log.export.missing.app_error", map[string]interface{}{"Sequence": afterSequence}, "", http.StatusNotFound)
+		}
+
+		verifier.LastSequence = records[0].Sequence
+		verifier.LastHash = records[0].Hash
+	}
+
+	var last *model.AuditLogRecord
+	for {
+		records, err := a.Srv.Store.AuditLog().GetAfter(afterSequence, AUDIT_LOG_EXPORT_BATCH_SIZE)
+		if err != nil {
+			return last, err
+		}
+
+		for _, record := range records {
+			if verify {
+				if err := verifier.Verify(record); err != nil {
+					return last, err
+				}
+			}
+
+			if _, err := io.WriteString(writer, record.ToJson()+"\n"); err != nil {
+				return last, model.NewAppError("ExportAuditLog", "app.audit_log.export.write.app_error", nil, "sequence="+strconv.FormatInt(record.Sequence, 10)+", "+err.Error(), http.StatusInternalServerError)
+			}
+			last = record
+		}
+
+		if len(records) < AUDIT_LOG_EXPORT_BATCH_SIZE {
+			return last, nil
+		}
+		afterSequence = records[len(records)-1].Sequence
+	}
+}
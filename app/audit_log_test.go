@@ -0,0 +1,146 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+func TestExportAuditLog(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	key := []byte("audit log key")
+	mainStore := th.App.Srv.Store
+	th.App.Srv.Store = store.NewAuditLayer(mainStore, key)
+	defer func() {
+		th.App.Srv.Store = mainStore
+	}()
+
+	th.App.Session = model.Session{Id: model.NewId(), UserId: th.BasicUser.Id}
+	th.App.RequestId = model.NewId()
+	th.App.updateStore()
+
+	var previous bytes.Buffer
+	last, appErr := th.App.ExportAuditLog(&previous, 0, false, key)
+	require.Nil(t, appErr)
+	after := int64(0)
+	if last != nil {
+		after = last.Sequence
+	}
+
+	channel := th.BasicChannel
+	channel.Purpose = "audited purpose"
+	_, appErr = th.App.UpdateChannel(channel)
+	require.Nil(t, appErr)
+
+	var exported bytes.Buffer
+	last, appErr = th.App.ExportAuditLog(&exported, after, true, key)
+	require.Nil(t, appErr)
+	require.NotNil(t, last)
+
+	records := make(map[int64]*model.AuditLogRecord)
+	var outcome *model.AuditLogRecord
+	scanner := bufio.NewScanner(&exported)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		record := model.AuditLogRecordFromJson(strings.NewReader(scanner.Text()))
+		require.NotNil(t, record)
+		records[record.Sequence] = record
+		if record.Method == "ChannelStore.Update" && record.Phase == model.AUDIT_LOG_PHASE_OUTCOME {
+			outcome = record
+		}
+	}
+	require.Nil(t, scanner.Err())
+	require.NotNil(t, outcome)
+
+	assert.Equal(t, th.BasicUser.Id, outcome.UserId)
+	assert.Equal(t, th.App.Session.Id, outcome.SessionId)
+	assert.Equal(t, th.App.RequestId, outcome.RequestId)
+	assert.Equal(t, model.AUDIT_LOG_STATUS_SUCCESS, outcome.Status)
+
+	var diff map[string][]interface{}
+	require.Nil(t, json.Unmarshal([]byte(outcome.Diff), &diff))
+	assert.Equal(t, []interface{}{"", "audited purpose"}, diff["purpose"])
+	assert.NotContains(t, diff, "display_name")
+
+	intent := records[outcome.IntentSequence]
+	require.NotNil(t, intent)
+	assert.Equal(t, model.AUDIT_LOG_PHASE_INTENT, intent.Phase)
+	assert.Contains(t, intent.EntityIds, channel.Id)
+	assert.Contains(t, intent.Params, "audited purpose")
+
+	t.Run("redacted", func(t *testing.T) {
+		appErr := th.App.UpdatePassword(th.BasicUser2, "new-password")
+		require.Nil(t, appErr)
+
+		var exported bytes.Buffer
+		_, appErr = th.App.ExportAuditLog(&exported, last.Sequence, true, key)
+		require.Nil(t, appErr)
+		assert.Contains(t, exported.String(), "UserStore.UpdatePassword")
+		assert.Contains(t, exported.String(), store.AUDIT_REDACTED)
+		user, appErr := mainStore.User().Get(th.BasicUser2.Id)
+		require.Nil(t, appErr)
+		require.NotEmpty(t, user.Password)
+		assert.NotContains(t, exported.String(), user.Password)
+	})
+
+	t.Run("another key", func(t *testing.T) {
+		var exported bytes.Buffer
+		_, appErr := th.App.ExportAuditLog(&exported, after, true, []byte("another key"))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.audit_log.verify.hash.app_error", appErr.Id)
+	})
+
+	t.Run("edited", func(t *testing.T) {
+		_, err := mainHelper.GetSqlSupplier().GetMaster().Exec("UPDATE AuditLog SET UserId = :UserId WHERE Sequence = :Sequence", map[string]interface{}{"UserId": model.NewId(), "Sequence": outcome.Sequence})
+		require.Nil(t, err)
+
+		var exported bytes.Buffer
+		_, appErr := th.App.ExportAuditLog(&exported, after, true, key)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.audit_log.verify.hash.app_error", appErr.Id)
+
+		_, appErr = th.App.ExportAuditLog(&exported, after, false, key)
+		require.Nil(t, appErr)
+	})
+}
+
+func TestReadAuditLogKey(t *testing.T) {
+	keyFile, err := ioutil.TempFile("", "audit_log_key")
+	require.Nil(t, err)
+	defer os.Remove(keyFile.Name())
+	_, err = keyFile.WriteString("secret key\n")
+	require.Nil(t, err)
+	require.Nil(t, keyFile.Close())
+
+	settings := model.ComplianceSettings{}
+	settings.SetDefaults()
+	*settings.AuditLogKeyFile = keyFile.Name()
+
+	key, err := readAuditLogKey(settings)
+	require.Nil(t, err)
+	assert.Nil(t, key, "the key should only be read when the audit log is enabled")
+
+	*settings.EnableAuditLog = true
+	key, err = readAuditLogKey(settings)
+	require.Nil(t, err)
+	assert.Equal(t, []byte("secret key"), key)
+
+	*settings.AuditLogKeyFile = keyFile.Name() + ".missing"
+	_, err = readAuditLogKey(settings)
+	assert.NotNil(t, err)
+}
@@ -47,11 +47,11 @@ func (a *App) CreateBasicUser(client *model.Client4) *model.AppError {
 		if resp.Error != nil {
 			return resp.Error
 		}
-		_, err := a.Srv.Store.User().VerifyEmail(ruser.Id, ruser.Email)
+		_, err := a.Store().User().VerifyEmail(ruser.Id, ruser.Email)
 		if err != nil {
 			return err
 		}
-		if _, err = a.Srv.Store.Team().SaveMember(&model.TeamMember{TeamId: basicteam.Id, UserId: ruser.Id}, *a.Config().TeamSettings.MaxUsersPerTeam); err != nil {
+		if _, err = a.Store().Team().SaveMember(&model.TeamMember{TeamId: basicteam.Id, UserId: ruser.Id}, *a.Config().TeamSettings.MaxUsersPerTeam); err != nil {
 			return err
 		}
 	}
@@ -87,7 +87,7 @@ func (a *App) ListAutocompleteCommands(teamId string, T goi18n.TranslateFunc) ([
 	}
 
 	if *a.Config().ServiceSettings.EnableCommands {
-		teamCmds, err := a.Srv.Store.Command().GetByTeam(teamId)
+		teamCmds, err := a.Store().Command().GetByTeam(teamId)
 		if err != nil {
 			return nil, err
 		}
@@ -109,7 +109,7 @@ func (a *App) ListTeamCommands(teamId string) ([]*model.Command, *model.AppError
 		return nil, model.NewAppError("ListTeamCommands", "api.command.disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	return a.Srv.Store.Command().GetByTeam(teamId)
+	return a.Store().Command().GetByTeam(teamId)
 }
 
 func (a *App) ListAllCommands(teamId string, T goi18n.TranslateFunc) ([]*model.Command, *model.AppError) {
@@ -134,7 +134,7 @@ func (a *App) ListAllCommands(teamId string, T goi18n.TranslateFunc) ([]*model.C
 	}
 
 	if *a.Config().ServiceSettings.EnableCommands {
-		teamCmds, err := a.Srv.Store.Command().GetByTeam(teamId)
+		teamCmds, err := a.Store().Command().GetByTeam(teamId)
 		if err != nil {
 			return nil, err
 		}
@@ -213,26 +213,26 @@ func (a *App) tryExecuteCustomCommand(args *model.CommandArgs, trigger string, m
 
 	chanChan := make(chan store.StoreResult, 1)
 	go func() {
-		channel, err := a.Srv.Store.Channel().Get(args.ChannelId, true)
+		channel, err := a.Store().Channel().Get(args.ChannelId, true)
 		chanChan <- store.StoreResult{Data: channel, Err: err}
 		close(chanChan)
 	}()
 
 	teamChan := make(chan store.StoreResult, 1)
 	go func() {
-		team, err := a.Srv.Store.Team().Get(args.TeamId)
+		team, err := a.Store().Team().Get(args.TeamId)
 		teamChan <- store.StoreResult{Data: team, Err: err}
 		close(teamChan)
 	}()
 
 	userChan := make(chan store.StoreResult, 1)
 	go func() {
-		user, err := a.Srv.Store.User().Get(args.UserId)
+		user, err := a.Store().User().Get(args.UserId)
 		userChan <- store.StoreResult{Data: user, Err: err}
 		close(userChan)
 	}()
 
-	teamCmds, err := a.Srv.Store.Command().GetByTeam(args.TeamId)
+	teamCmds, err := a.Store().Command().GetByTeam(args.TeamId)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -448,7 +448,7 @@ func (a *App) CreateCommand(cmd *model.Command) (*model.Command, *model.AppError
 
 	cmd.Trigger = strings.ToLower(cmd.Trigger)
 
-	teamCmds, err := a.Srv.Store.Command().GetByTeam(cmd.TeamId)
+	teamCmds, err := a.Store().Command().GetByTeam(cmd.TeamId)
 	if err != nil {
 		return nil, err
 	}
@@ -466,7 +466,7 @@ func (a *App) CreateCommand(cmd *model.Command) (*model.Command, *model.AppError
 		}
 	}
 
-	return a.Srv.Store.Command().Save(cmd)
+	return a.Store().Command().Save(cmd)
 }
 
 func (a *App) GetCommand(commandId string) (*model.Command, *model.AppError) {
@@ -474,7 +474,7 @@ func (a *App) GetCommand(commandId string) (*model.Command, *model.AppError) {
 		return nil, model.NewAppError("GetCommand", "api.command.disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	cmd, err := a.Srv.Store.Command().Get(commandId)
+	cmd, err := a.Store().Command().Get(commandId)
 	if err != nil {
 		err.StatusCode = http.StatusNotFound
 		return nil, err
@@ -497,13 +497,13 @@ func (a *App) UpdateCommand(oldCmd, updatedCmd *model.Command) (*model.Command,
 	updatedCmd.CreatorId = oldCmd.CreatorId
 	updatedCmd.TeamId = oldCmd.TeamId
 
-	return a.Srv.Store.Command().Update(updatedCmd)
+	return a.Store().Command().Update(updatedCmd)
 }
 
 func (a *App) MoveCommand(team *model.Team, command *model.Command) *model.AppError {
 	command.TeamId = team.Id
 
-	_, err := a.Srv.Store.Command().Update(command)
+	_, err := a.Store().Command().Update(command)
 	if err != nil {
 		return err
 	}
@@ -518,7 +518,7 @@ func (a *App) RegenCommandToken(cmd *model.Command) (*model.Command, *model.AppE
 
 	cmd.Token = model.NewId()
 
-	return a.Srv.Store.Command().Update(cmd)
+	return a.Store().Command().Update(cmd)
 }
 
 func (a *App) DeleteCommand(commandId string) *model.AppError {
@@ -526,5 +526,5 @@ func (a *App) DeleteCommand(commandId string) *model.AppError {
 		return model.NewAppError("DeleteCommand", "api.command.disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	return a.Srv.Store.Command().Delete(commandId, model.GetMillis())
+	return a.Store().Command().Delete(commandId, model.GetMillis())
 }
@@ -0,0 +1,89 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"strings"
+
+	goi18n "github.com/mattermost/go-i18n/i18n"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+type CustomStatusProvider struct {
+}
+
+const (
+	CMD_CUSTOM_STATUS       = "status"
+	CMD_CUSTOM_STATUS_CLEAR = "clear"
+)
+
+func init() {
+	RegisterCommandProvider(&CustomStatusProvider{})
+}
+
+func (me *CustomStatusProvider) GetTrigger() string {
+	return CMD_CUSTOM_STATUS
+}
+
+func (me *CustomStatusProvider) GetCommand(a *App, T goi18n.TranslateFunc) *model.Command {
+	return &model.Command{
+		Trigger:          CMD_CUSTOM_STATUS,
+		AutoComplete:     true,
+		AutoCompleteDesc: T("api.command_custom_status.desc"),
+		AutoCompleteHint: T("api.command_custom_status.hint"),
+		DisplayName:      T("api.command_custom_status.name"),
+	}
+}
+
+func (me *CustomStatusProvider) DoCommand(a *App, args *model.CommandArgs, message string) *model.CommandResponse {
+	message = strings.TrimSpace(message)
+
+	if message == "" {
+		customStatus, err := a.GetCustomStatus(args.UserId)
+		if err != nil {
+			return &model.CommandResponse{ResponseType: model.COMMAND_RESPONSE_TYPE_EPHEMERAL, Text: args.T("api.command_custom_status.app_error")}
+		}
+		if customStatus == nil {
+			return &model.CommandResponse{ResponseType: model.COMMAND_RESPONSE_TYPE_EPHEMERAL, Text: args.T("api.command_custom_status.empty")}
+		}
+		return &model.CommandResponse{ResponseType: model.COMMAND_RESPONSE_TYPE_EPHEMERAL, Text: args.T("api.command_custom_status.current", map[string]interface{}{"Status": formatCustomStatus(customStatus)})}
+	}
+
+	if message == CMD_CUSTOM_STATUS_CLEAR {
+		if err := a.RemoveCustomStatus(args.UserId); err != nil {
+			return &model.CommandResponse{ResponseType: model.COMMAND_RESPONSE_TYPE_EPHEMERAL, Text: args.T("api.command_custom_status.app_error")}
+		}
+		return &model.CommandResponse{ResponseType: model.COMMAND_RESPONSE_TYPE_EPHEMERAL, Text: args.T("api.command_custom_status.clear.success")}
+	}
+
+	customStatus, err := a.SetCustomStatus(args.UserId, parseCustomStatusCommand(message))
+	if err != nil {
+		return &model.CommandResponse{ResponseType: model.COMMAND_RESPONSE_TYPE_EPHEMERAL, Text: err.SystemMessage(args.T)}
+	}
+
+	return &model.CommandResponse{ResponseType: model.COMMAND_RESPONSE_TYPE_EPHEMERAL, Text: args.T("api.command_custom_status.success", map[string]interface{}{"Status": formatCustomStatus(customStatus)})}
+}
+
+// parseCustomStatusCommand splits a message such as ":palm_tree: On vacation" into the emoji and
+// text of a custom status. The emoji is optional.
+func parseCustomStatusCommand(message string) *model.CustomStatus {
+	customStatus := &model.CustomStatus{Text: message}
+
+	if strings.HasPrefix(message, ":") {
+		if end := strings.Index(message[1:], ":"); end > 0 && !strings.ContainsAny(message[1:end+1], " \t") {
+			customStatus.Emoji = message[1 : end+1]
+			customStatus.Text = message[end+2:]
+		}
+	}
+
+	return customStatus
+}
+
+func formatCustomStatus(customStatus *model.CustomStatus) string {
+	if customStatus.Emoji == "" {
+		return customStatus.Text
+	}
+
+	return strings.TrimSpace(":" + customStatus.Emoji + ": " + customStatus.Text)
+}
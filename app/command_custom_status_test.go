@@ -0,0 +1,30 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCustomStatusCommand(t *testing.T) {
+	for _, tc := range []struct {
+		Message       string
+		ExpectedEmoji string
+		ExpectedText  string
+	}{
+		{":palm_tree: On vacation until Monday", "palm_tree", " On vacation until Monday"},
+		{":calendar:", "calendar", ""},
+		{"Working from home", "", "Working from home"},
+		{": not an emoji: text", "", ": not an emoji: text"},
+		{"::", "", "::"},
+	} {
+		t.Run(tc.Message, func(t *testing.T) {
+			customStatus := parseCustomStatusCommand(tc.Message)
+			assert.Equal(t, tc.ExpectedEmoji, customStatus.Emoji)
+			assert.Equal(t, tc.ExpectedText, customStatus.Text)
+		})
+	}
+}
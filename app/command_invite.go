@@ -49,7 +49,7 @@ func (me *InviteProvider) DoCommand(a *App, args *model.CommandArgs, message str
 	targetUsername := splitMessage[0]
 	targetUsername = strings.TrimPrefix(targetUsername, "@")
 
-	userProfile, err := a.Srv.Store.User().GetByUsername(targetUsername)
+	userProfile, err := a.Store().User().GetByUsername(targetUsername)
 	if err != nil {
 		mlog.Error(err.Error())
 		return &model.CommandResponse{
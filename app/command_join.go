@@ -43,7 +43,7 @@ func (me *JoinProvider) DoCommand(a *App, args *model.CommandArgs, message strin
 		channelName = message[1:]
 	}
 
-	channel, err := a.Srv.Store.Channel().GetByName(args.TeamId, channelName, true)
+	channel, err := a.Store().Channel().GetByName(args.TeamId, channelName, true)
 	if err != nil {
 		return &model.CommandResponse{Text: args.T("api.command_join.list.app_error"), ResponseType: model.COMMAND_RESPONSE_TYPE_EPHEMERAL}
 	}
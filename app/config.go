@@ -113,7 +113,7 @@ func (a *App) ensurePostActionCookieSecret() error {
 
 	var secret *model.SystemPostActionCookieSecret
 
-	value, err := a.Srv.Store.System().GetByName(model.SYSTEM_POST_ACTION_COOKIE_SECRET)
+	value, err := a.Store().System().GetByName(model.SYSTEM_POST_ACTION_COOKIE_SECRET)
 	if err == nil {
 		if err := json.Unmarshal([]byte(value.Value), &secret); err != nil {
 			return err
@@ -138,7 +138,7 @@ func (a *App) ensurePostActionCookieSecret() error {
 			return err
 		}
 		system.Value = string(v)
-		if err = a.Srv.Store.System().Save(system); err == nil {
+		if err = a.Store().System().Save(system); err == nil {
 			// If we were able to save the key, use it, otherwise ignore the error.
 			secret = newSecret
 		}
@@ -147,7 +147,7 @@ func (a *App) ensurePostActionCookieSecret() error {
 	// If we weren't able to save a new key above, another server must have beat us to it. Get the
 	// key from the database, and if that fails, error out.
 	if secret == nil {
-		value, err := a.Srv.Store.System().GetByName(model.SYSTEM_POST_ACTION_COOKIE_SECRET)
+		value, err := a.Store().System().GetByName(model.SYSTEM_POST_ACTION_COOKIE_SECRET)
 		if err != nil {
 			return err
 		}
@@ -170,7 +170,7 @@ func (a *App) ensureAsymmetricSigningKey() error {
 
 	var key *model.SystemAsymmetricSigningKey
 
-	value, err := a.Srv.Store.System().GetByName(model.SYSTEM_ASYMMETRIC_SIGNING_KEY)
+	value, err := a.Store().System().GetByName(model.SYSTEM_ASYMMETRIC_SIGNING_KEY)
 	if err == nil {
 		if err := json.Unmarshal([]byte(value.Value), &key); err != nil {
 			return err
@@ -199,7 +199,7 @@ func (a *App) ensureAsymmetricSigningKey() error {
 			return err
 		}
 		system.Value = string(v)
-		if err = a.Srv.Store.System().Save(system); err == nil {
+		if err = a.Store().System().Save(system); err == nil {
 			// If we were able to save the key, use it, otherwise ignore the error.
 			key = newKey
 		}
@@ -208,7 +208,7 @@ func (a *App) ensureAsymmetricSigningKey() error {
 	// If we weren't able to save a new key above, another server must have beat us to it. Get the
 	// key from the database, and if that fails, error out.
 	if key == nil {
-		value, err := a.Srv.Store.System().GetByName(model.SYSTEM_ASYMMETRIC_SIGNING_KEY)
+		value, err := a.Store().System().GetByName(model.SYSTEM_ASYMMETRIC_SIGNING_KEY)
 		if err != nil {
 			return err
 		}
@@ -243,7 +243,7 @@ func (a *App) ensureInstallationDate() error {
 		return nil
 	}
 
-	installDate, err := a.Srv.Store.User().InferSystemInstallDate()
+	installDate, err := a.Store().User().InferSystemInstallDate()
 	var installationDate int64
 	if err == nil && installDate > 0 {
 		installationDate = installDate
@@ -251,7 +251,7 @@ func (a *App) ensureInstallationDate() error {
 		installationDate = utils.MillisFromTime(time.Now())
 	}
 
-	err = a.Srv.Store.System().SaveOrUpdate(&model.System{
+	err = a.Store().System().SaveOrUpdate(&model.System{
 		Name:  model.SYSTEM_INSTALLATION_DATE_KEY,
 		Value: strconv.FormatInt(installationDate, 10),
 	})
@@ -361,11 +361,19 @@ func (a *App) GetConfigFile(name string) ([]byte, error) {
 }
 
 // GetSanitizedConfig gets the configuration for a system admin without any secrets.
+//
+// Settings read from secrets are returned as the references to those secrets.
 func (a *App) GetSanitizedConfig() *model.Config {
 	cfg := a.Config().Clone()
 	cfg.Sanitize()
 
-	return cfg
+	cfgWithReferences, err := config.ApplySecretReferences(cfg, a.Srv.configStore.GetSecretReferences())
+	if err != nil {
+		mlog.Error("Failed to apply secret references to config", mlog.Err(err))
+		return cfg
+	}
+
+	return cfgWithReferences
 }
 
 // GetEnvironmentConfig returns a map of configuration keys whose values have been overridden by an environment variable.
@@ -375,13 +383,30 @@ func (a *App) GetEnvironmentConfig() map[string]interface{} {
 
 // SaveConfig replaces the active configuration, optionally notifying cluster peers.
 func (a *App) SaveConfig(newCfg *model.Config, sendConfigChangeClusterMessage bool) *model.AppError {
-	oldCfg, err := a.Srv.configStore.Set(newCfg)
+	if appErr := a.validatePluginSettings(a.Config(), newCfg); appErr != nil {
+		return appErr
+	}
+
+	var oldCfg *model.Config
+	var err error
+	if historyStore, ok := a.Srv.configStore.(config.HistoryStore); ok {
+		oldCfg, err = historyStore.SetByUser(newCfg, a.Session.UserId)
+	} else {
+		oldCfg, err = a.Srv.configStore.Set(newCfg)
+	}
 	if errors.Cause(err) == config.ErrReadOnlyConfiguration {
 		return model.NewAppError("saveConfig", "ent.cluster.save_config.error", nil, err.Error(), http.StatusForbidden)
+	} else if errors.Cause(err) == config.ErrSecretReferenceNotAllowed {
+		return model.NewAppError("saveConfig", "app.save_config.secret_reference.app_error", nil, err.Error(), http.StatusBadRequest)
 	} else if err != nil {
 		return model.NewAppError("saveConfig", "app.save_config.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
+	// Configuration received from another cluster node was already recorded by that node.
+	if sendConfigChangeClusterMessage {
+		a.savePluginConfigRevisions(oldCfg, newCfg)
+	}
+
 	if a.Metrics != nil {
 		if *a.Config().MetricsSettings.Enable {
 			a.Metrics.StartServer()
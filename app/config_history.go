@@ -0,0 +1,93 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/config"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (a *App) configHistoryStore(where string) (config.HistoryStore, *model.AppError) {
+	historyStore, ok := a.Srv.configStore.(config.HistoryStore)
+	if !ok {
+		return nil, model.NewAppError(where, "app.config.history.not_supported.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	return historyStore, nil
+}
+
+func (a *App) getConfigRevision(where string, historyStore config.HistoryStore, revisionId string) (*model.Config, *model.AppError) {
+	cfg, err := historyStore.GetRevision(revisionId)
+	if errors.Cause(err) == config.ErrRevisionNotFound {
+		return nil, model.NewAppError(where, "app.config.history.revision_not_found.app_error", nil, "revision_id="+revisionId, http.StatusNotFound)
+	} else if err != nil {
+		return nil, model.NewAppError(where, "app.config.history.get_revision.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return cfg, nil
+}
+
+// GetConfigHistory returns a page of the saved configuration revisions, newest first.
+func (a *App) GetConfigHistory(page, perPage int) ([]*model.ConfigRevision, *model.AppError) {
+	historyStore, appErr := a.configHistoryStore("GetConfigHistory")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	revisions, err := historyStore.History(page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetConfigHistory", "app.config.history.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return revisions, nil
+}
+
+// GetConfigDiff compares the configurations saved by two revisions. If toRevisionId is empty, the
+// first revision is compared against the current configuration.
+func (a *App) GetConfigDiff(fromRevisionId, toRevisionId string) (model.ConfigDiffs, *model.AppError) {
+	historyStore, appErr := a.configHistoryStore("GetConfigDiff")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	fromCfg, appErr := a.getConfigRevision("GetConfigDiff", historyStore, fromRevisionId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	toCfg := a.Config()
+	if toRevisionId != "" {
+		if toCfg, appErr = a.getConfigRevision("GetConfigDiff", historyStore, toRevisionId); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	diff, err := config.Diff(fromCfg, toCfg)
+	if err != nil {
+		return nil, model.NewAppError("GetConfigDiff", "app.config.history.diff.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return diff, nil
+}
+
+// RollbackConfig replaces the current configuration with the one saved by the given revision. The
+// change is saved like any other, so it is recorded as a new revision and config listeners and
+// the rest of the cluster are notified.
+func (a *App) RollbackConfig(revisionId string) *model.AppError {
+	historyStore, appErr := a.configHistoryStore("RollbackConfig")
+	if appErr != nil {
+		return appErr
+	}
+
+	cfg, appErr := a.getConfigRevision("RollbackConfig", historyStore, revisionId)
+	if appErr != nil {
+		return appErr
+	}
+
+	return a.SaveConfig(cfg, true)
+}
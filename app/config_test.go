@@ -4,12 +4,16 @@
 package app
 
 import (
+	"net/http"
+	"os"
 	"strconv"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/mattermost/mattermost-server/config"
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/store/sqlstore"
 	"github.com/mattermost/mattermost-server/utils"
@@ -63,6 +67,53 @@ func TestPostActionCookieSecret(t *testing.T) {
 	assert.Equal(t, 32, len(th.App.PostActionCookieSecret()))
 }
 
+func TestGetSanitizedConfigSecretReferences(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	os.Setenv("TEST_GITLAB_SECRET", "gitlab-secret")
+	defer os.Unsetenv("TEST_GITLAB_SECRET")
+	os.Setenv(config.SecretReferencesEnvironmentVariable, "true")
+	defer os.Unsetenv(config.SecretReferencesEnvironmentVariable)
+
+	// References can only be added to the backing store directly.
+	initialConfig := th.App.Config().Clone()
+	*initialConfig.GitLabSettings.Secret = "env:TEST_GITLAB_SECRET"
+	*initialConfig.TeamSettings.SiteName = "env:TEST_GITLAB_SECRET"
+	configStore, err := config.NewMemoryStoreWithOptions(&config.MemoryStoreOptions{
+		IgnoreEnvironmentOverrides: true,
+		InitialConfig:              initialConfig,
+	})
+	require.NoError(t, err)
+	th.App.Srv.configStore = configStore
+
+	// Only sensitive settings are resolved.
+	assert.Equal(t, "gitlab-secret", *th.App.Config().GitLabSettings.Secret)
+	assert.Equal(t, "env:TEST_GITLAB_SECRET", *th.App.Config().TeamSettings.SiteName)
+
+	cfg := th.App.GetSanitizedConfig()
+	assert.Equal(t, "env:TEST_GITLAB_SECRET", *cfg.GitLabSettings.Secret)
+
+	// Saving the sanitized config keeps referring to the secret.
+	*cfg.TeamSettings.SiteName = "site"
+	appErr := th.App.SaveConfig(cfg, false)
+	assert.Nil(t, appErr)
+	assert.Equal(t, "gitlab-secret", *th.App.Config().GitLabSettings.Secret)
+	assert.Equal(t, "site", *th.App.Config().TeamSettings.SiteName)
+	assert.Equal(t, map[string]interface{}{
+		"GitLabSettings": map[string]interface{}{"Secret": "env:TEST_GITLAB_SECRET"},
+	}, th.App.Srv.configStore.GetSecretReferences())
+
+	// Saving a new reference is refused.
+	cfg = th.App.GetSanitizedConfig()
+	*cfg.EmailSettings.SMTPPassword = "env:TEST_GITLAB_SECRET"
+	appErr = th.App.SaveConfig(cfg, false)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.save_config.secret_reference.app_error", appErr.Id)
+	assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	assert.NotEqual(t, "gitlab-secret", *th.App.Config().EmailSettings.SMTPPassword)
+}
+
 func TestClientConfigWithComputed(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
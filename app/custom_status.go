@@ -0,0 +1,133 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	CUSTOM_STATUS_EXPIRY_BATCH_SIZE = 100
+)
+
+func (a *App) GetCustomStatus(userId string) (*model.CustomStatus, *model.AppError) {
+	user, err := a.GetUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return user.GetCustomStatus(), nil
+}
+
+func (a *App) SetCustomStatus(userId string, cs *model.CustomStatus) (*model.CustomStatus, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableUserStatuses {
+		return nil, model.NewAppError("SetCustomStatus", "api.custom_status.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	cs.PreSave()
+	if err := cs.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if cs.IsExpired(model.GetMillis()) {
+		return nil, model.NewAppError("SetCustomStatus", "model.custom_status.is_valid.expires_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	user, err := a.GetUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	user.SetCustomStatus(cs)
+	if _, err := a.updateUserCustomStatus(user); err != nil {
+		return nil, err
+	}
+
+	if err := a.Store().User().SetCustomStatusExpiry(userId, cs.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	a.BroadcastCustomStatus(userId, cs)
+
+	return cs, nil
+}
+
+func (a *App) RemoveCustomStatus(userId string) *model.AppError {
+	user, err := a.GetUser(userId)
+	if err != nil {
+		return err
+	}
+
+	if user.GetCustomStatus() == nil {
+		return nil
+	}
+
+	user.ClearCustomStatus()
+	if _, err := a.updateUserCustomStatus(user); err != nil {
+		return err
+	}
+
+	if err := a.Store().User().SetCustomStatusExpiry(userId, 0); err != nil {
+		return err
+	}
+
+	a.BroadcastCustomStatus(userId, nil)
+
+	return nil
+}
+
+func (a *App) updateUserCustomStatus(user *model.User) (*model.User, *model.AppError) {
+	updatedUser, err := a.UpdateUser(user, false)
+	if err != nil {
+		return nil, err
+	}
+
+	a.sendUpdatedUserEvent(*updatedUser)
+
+	return updatedUser, nil
+}
+
+// BroadcastCustomStatus notifies clients that a user's custom status has changed. A nil custom
+// status means that it was cleared.
+func (a *App) BroadcastCustomStatus(userId string, cs *model.CustomStatus) {
+	event := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_CUSTOM_STATUS_CHANGE, "", "", userId, nil)
+	event.Add("user_id", userId)
+	if cs != nil {
+		event.Add("custom_status", cs.ToJson())
+	} else {
+		event.Add("custom_status", "")
+	}
+	a.Publish(event)
+}
+
+// RemoveExpiredCustomStatuses clears every custom status whose expiry time has passed.
+func (a *App) RemoveExpiredCustomStatuses() *model.AppError {
+	now := model.GetMillis()
+	for {
+		userIds, err := a.Store().User().ClearExpiredCustomStatuses(now, CUSTOM_STATUS_EXPIRY_BATCH_SIZE)
+		if err != nil {
+			return err
+		}
+
+		for _, userId := range userIds {
+			a.InvalidateCacheForUser(userId)
+
+			user, err := a.GetUser(userId)
+			if err != nil {
+				mlog.Error("Failed to get user with an expired custom status", mlog.String("user_id", userId), mlog.Err(err))
+				continue
+			}
+
+			a.sendUpdatedUserEvent(*user)
+			a.BroadcastCustomStatus(userId, nil)
+		}
+
+		if len(userIds) < CUSTOM_STATUS_EXPIRY_BATCH_SIZE {
+			return nil
+		}
+	}
+}
@@ -48,6 +48,7 @@ const (
 	TRACK_CONFIG_MESSAGE_EXPORT     = "config_message_export"
 	TRACK_CONFIG_DISPLAY            = "config_display"
 	TRACK_CONFIG_IMAGE_PROXY        = "config_image_proxy"
+	TRACK_CONFIG_TRACING            = "config_tracing"
 	TRACK_PERMISSIONS_GENERAL       = "permissions_general"
 	TRACK_PERMISSIONS_SYSTEM_SCHEME = "permissions_system_scheme"
 	TRACK_PERMISSIONS_TEAM_SCHEMES  = "permissions_team_schemes"
@@ -123,78 +124,78 @@ func (a *App) trackActivity() {
 
 	activeUsersDailyCountChan := make(chan store.StoreResult, 1)
 	go func() {
-		count, err := a.Srv.Store.User().AnalyticsActiveCount(DAY_MILLISECONDS, model.UserCountOptions{IncludeBotAccounts: false})
+		count, err := a.Store().User().AnalyticsActiveCount(DAY_MILLISECONDS, model.UserCountOptions{IncludeBotAccounts: false})
 		activeUsersDailyCountChan <- store.StoreResult{Data: count, Err: err}
 		close(activeUsersDailyCountChan)
 	}()
 
 	activeUsersMonthlyCountChan := make(chan store.StoreResult, 1)
 	go func() {
-		count, err := a.Srv.Store.User().AnalyticsActiveCount(MONTH_MILLISECONDS, model.UserCountOptions{IncludeBotAccounts: false})
+		count, err := a.Store().User().AnalyticsActiveCount(MONTH_MILLISECONDS, model.UserCountOptions{IncludeBotAccounts: false})
 		activeUsersMonthlyCountChan <- store.StoreResult{Data: count, Err: err}
 		close(activeUsersMonthlyCountChan)
 	}()
 
-	if count, err := a.Srv.Store.User().Count(model.UserCountOptions{IncludeDeleted: true}); err == nil {
+	if count, err := a.Store().User().Count(model.UserCountOptions{IncludeDeleted: true}); err == nil {
 		userCount = count
 	}
 
-	if count, err := a.Srv.Store.User().Count(model.UserCountOptions{IncludeBotAccounts: true, ExcludeRegularUsers: true}); err == nil {
+	if count, err := a.Store().User().Count(model.UserCountOptions{IncludeBotAccounts: true, ExcludeRegularUsers: true}); err == nil {
 		botAccountsCount = count
 	}
 
-	if iucr, err := a.Srv.Store.User().AnalyticsGetInactiveUsersCount(); err == nil {
+	if iucr, err := a.Store().User().AnalyticsGetInactiveUsersCount(); err == nil {
 		inactiveUserCount = iucr
 	}
 
-	teamCount, err := a.Srv.Store.Team().AnalyticsTeamCount()
+	teamCount, err := a.Store().Team().AnalyticsTeamCount()
 	if err != nil {
 		mlog.Error(err.Error())
 	}
 
-	if ucc, err := a.Srv.Store.Channel().AnalyticsTypeCount("", "O"); err == nil {
+	if ucc, err := a.Store().Channel().AnalyticsTypeCount("", "O"); err == nil {
 		publicChannelCount = ucc
 	}
 
-	if pcc, err := a.Srv.Store.Channel().AnalyticsTypeCount("", "P"); err == nil {
+	if pcc, err := a.Store().Channel().AnalyticsTypeCount("", "P"); err == nil {
 		privateChannelCount = pcc
 	}
 
-	if dcc, err := a.Srv.Store.Channel().AnalyticsTypeCount("", "D"); err == nil {
+	if dcc, err := a.Store().Channel().AnalyticsTypeCount("", "D"); err == nil {
 		directChannelCount = dcc
 	}
 
-	if duccr, err := a.Srv.Store.Channel().AnalyticsDeletedTypeCount("", "O"); err == nil {
+	if duccr, err := a.Store().Channel().AnalyticsDeletedTypeCount("", "O"); err == nil {
 		deletedPublicChannelCount = duccr
 	}
 
-	if dpccr, err := a.Srv.Store.Channel().AnalyticsDeletedTypeCount("", "P"); err == nil {
+	if dpccr, err := a.Store().Channel().AnalyticsDeletedTypeCount("", "P"); err == nil {
 		deletedPrivateChannelCount = dpccr
 	}
 
-	postsCount, _ = a.Srv.Store.Post().AnalyticsPostCount("", false, false)
+	postsCount, _ = a.Store().Post().AnalyticsPostCount("", false, false)
 
 	postCountsOptions := &model.AnalyticsPostCountsOptions{TeamId: "", BotsOnly: false, YesterdayOnly: true}
-	postCountsYesterday, _ := a.Srv.Store.Post().AnalyticsPostCountsByDay(postCountsOptions)
+	postCountsYesterday, _ := a.Store().Post().AnalyticsPostCountsByDay(postCountsOptions)
 	postsCountPreviousDay = 0
 	if len(postCountsYesterday) > 0 {
 		postsCountPreviousDay = int64(postCountsYesterday[0].Value)
 	}
 
 	postCountsOptions = &model.AnalyticsPostCountsOptions{TeamId: "", BotsOnly: true, YesterdayOnly: true}
-	botPostCountsYesterday, _ := a.Srv.Store.Post().AnalyticsPostCountsByDay(postCountsOptions)
+	botPostCountsYesterday, _ := a.Store().Post().AnalyticsPostCountsByDay(postCountsOptions)
 	botPostsCountPreviousDay = 0
 	if len(botPostCountsYesterday) > 0 {
 		botPostsCountPreviousDay = int64(botPostCountsYesterday[0].Value)
 	}
 
-	slashCommandsCount, _ = a.Srv.Store.Command().AnalyticsCommandCount("")
+	slashCommandsCount, _ = a.Store().Command().AnalyticsCommandCount("")
 
-	if c, err := a.Srv.Store.Webhook().AnalyticsIncomingCount(""); err == nil {
+	if c, err := a.Store().Webhook().AnalyticsIncomingCount(""); err == nil {
 		incomingWebhooksCount = c
 	}
 
-	outgoingWebhooksCount, _ = a.Srv.Store.Webhook().AnalyticsOutgoingCount("")
+	outgoingWebhooksCount, _ = a.Store().Webhook().AnalyticsOutgoingCount("")
 
 	var activeUsersDailyCount int64
 	if r := <-activeUsersDailyCountChan; r.Err == nil {
@@ -300,6 +301,10 @@ func (a *App) trackConfig() {
 		"disable_bots_when_owner_is_deactivated":                  *cfg.ServiceSettings.DisableBotsWhenOwnerIsDeactivated,
 		"enable_bot_account_creation":                             *cfg.ServiceSettings.EnableBotAccountCreation,
 		"enable_svgs":                                             *cfg.ServiceSettings.EnableSVGs,
+		"enable_urgent_post_priority":                             *cfg.ServiceSettings.EnableUrgentPostPriority,
+		"enable_read_receipts":                                    *cfg.ServiceSettings.EnableReadReceipts,
+		"persistent_notification_interval_minutes":                *cfg.ServiceSettings.PersistentNotificationIntervalMinutes,
+		"persistent_notification_max_count":                       *cfg.ServiceSettings.PersistentNotificationMaxCount,
 	})
 
 	a.SendDiagnostic(TRACK_CONFIG_TEAM, map[string]interface{}{
@@ -345,14 +350,23 @@ func (a *App) trackConfig() {
 	})
 
 	a.SendDiagnostic(TRACK_CONFIG_SQL, map[string]interface{}{
-		"driver_name":                    *cfg.SqlSettings.DriverName,
-		"trace":                          cfg.SqlSettings.Trace,
-		"max_idle_conns":                 *cfg.SqlSettings.MaxIdleConns,
-		"conn_max_lifetime_milliseconds": *cfg.SqlSettings.ConnMaxLifetimeMilliseconds,
-		"max_open_conns":                 *cfg.SqlSettings.MaxOpenConns,
-		"data_source_replicas":           len(cfg.SqlSettings.DataSourceReplicas),
-		"data_source_search_replicas":    len(cfg.SqlSettings.DataSourceSearchReplicas),
-		"query_timeout":                  *cfg.SqlSettings.QueryTimeout,
+		"driver_name":                        *cfg.SqlSettings.DriverName,
+		"trace":                              cfg.SqlSettings.Trace,
+		"max_idle_conns":                     *cfg.SqlSettings.MaxIdleConns,
+		"conn_max_lifetime_milliseconds":     *cfg.SqlSettings.ConnMaxLifetimeMilliseconds,
+		"max_open_conns":                     *cfg.SqlSettings.MaxOpenConns,
+		"data_source_replicas":               len(cfg.SqlSettings.DataSourceReplicas),
+		"data_source_search_replicas":        len(cfg.SqlSettings.DataSourceSearchReplicas),
+		"query_timeout":                      *cfg.SqlSettings.QueryTimeout,
+		"replica_lag_threshold_seconds":      *cfg.SqlSettings.ReplicaLagThresholdSeconds,
+		"replica_lag_check_interval_seconds": *cfg.SqlSettings.ReplicaLagCheckIntervalSeconds,
+		"sticky_master_after_write_seconds":  *cfg.SqlSettings.StickyMasterAfterWriteSeconds,
+		"online_migrations":                  *cfg.SqlSettings.OnlineMigrations,
+		"migration_batch_size":               *cfg.SqlSettings.MigrationBatchSize,
+		"slow_query_threshold_milliseconds":  *cfg.SqlSettings.SlowQueryThresholdMilliseconds,
+		"query_timeout_overrides":            len(cfg.SqlSettings.QueryTimeoutOverrides),
+		"post_shards":                        len(cfg.SqlSettings.PostShardDataSources),
+		"post_shard_key":                     *cfg.SqlSettings.PostShardKey,
 	})
 
 	a.SendDiagnostic(TRACK_CONFIG_LOG, map[string]interface{}{
@@ -490,8 +504,10 @@ func (a *App) trackConfig() {
 	})
 
 	a.SendDiagnostic(TRACK_CONFIG_COMPLIANCE, map[string]interface{}{
-		"enable":       *cfg.ComplianceSettings.Enable,
-		"enable_daily": *cfg.ComplianceSettings.EnableDaily,
+		"enable":                       *cfg.ComplianceSettings.Enable,
+		"enable_daily":                 *cfg.ComplianceSettings.EnableDaily,
+		"enable_audit_log":             *cfg.ComplianceSettings.EnableAuditLog,
+		"isdefault_audit_log_key_file": isDefault(*cfg.ComplianceSettings.AuditLogKeyFile, ""),
 	})
 
 	a.SendDiagnostic(TRACK_CONFIG_LOCALIZATION, map[string]interface{}{
@@ -602,6 +618,11 @@ func (a *App) trackConfig() {
 		"enable_uploads":                *cfg.PluginSettings.EnableUploads,
 		"allow_insecure_download_url":   *cfg.PluginSettings.AllowInsecureDownloadUrl,
 		"enable_health_check":           *cfg.PluginSettings.EnableHealthCheck,
+		"enable_marketplace":            *cfg.PluginSettings.EnableMarketplace,
+		"max_memory_mb":                 *cfg.PluginSettings.MaxMemoryMB,
+		"max_cpu_percent":               *cfg.PluginSettings.MaxCPUPercent,
+		"hook_timeout_seconds":          *cfg.PluginSettings.HookTimeoutSeconds,
+		"max_concurrent_hooks":          *cfg.PluginSettings.MaxConcurrentHooks,
 	})
 
 	a.SendDiagnostic(TRACK_CONFIG_DATA_RETENTION, map[string]interface{}{
@@ -610,6 +631,9 @@ func (a *App) trackConfig() {
 		"message_retention_days":  *cfg.DataRetentionSettings.MessageRetentionDays,
 		"file_retention_days":     *cfg.DataRetentionSettings.FileRetentionDays,
 		"deletion_job_start_time": *cfg.DataRetentionSettings.DeletionJobStartTime,
+		"enable_message_archival": *cfg.DataRetentionSettings.EnableMessageArchival,
+		"message_archival_months": *cfg.DataRetentionSettings.MessageArchivalMonths,
+		"archival_job_start_time": *cfg.DataRetentionSettings.ArchivalJobStartTime,
 	})
 
 	a.SendDiagnostic(TRACK_CONFIG_MESSAGE_EXPORT, map[string]interface{}{
@@ -635,6 +659,13 @@ func (a *App) trackConfig() {
 		"isdefault_remote_image_proxy_url":     isDefault(*cfg.ImageProxySettings.RemoteImageProxyURL, ""),
 		"isdefault_remote_image_proxy_options": isDefault(*cfg.ImageProxySettings.RemoteImageProxyOptions, ""),
 	})
+
+	a.SendDiagnostic(TRACK_CONFIG_TRACING, map[string]interface{}{
+		"enable":                    *cfg.TracingSettings.Enable,
+		"isdefault_export_endpoint": isDefault(*cfg.TracingSettings.ExportEndpoint, ""),
+		"isdefault_export_file":     isDefault(*cfg.TracingSettings.ExportFile, ""),
+		"sample_rate":               *cfg.TracingSettings.SampleRate,
+	})
 }
 
 func (a *App) trackLicense() {
@@ -728,7 +759,7 @@ func (a *App) trackServer() {
 		"operating_system": runtime.GOOS,
 	}
 
-	if scr, err := a.Srv.Store.User().AnalyticsGetSystemAdminCount(); err == nil {
+	if scr, err := a.Store().User().AnalyticsGetSystemAdminCount(); err == nil {
 		data["system_admins"] = scr
 	}
 
@@ -737,12 +768,12 @@ func (a *App) trackServer() {
 
 func (a *App) trackPermissions() {
 	phase1Complete := false
-	if _, err := a.Srv.Store.System().GetByName(ADVANCED_PERMISSIONS_MIGRATION_KEY); err == nil {
+	if _, err := a.Store().System().GetByName(ADVANCED_PERMISSIONS_MIGRATION_KEY); err == nil {
 		phase1Complete = true
 	}
 
 	phase2Complete := false
-	if _, err := a.Srv.Store.System().GetByName(model.MIGRATION_KEY_ADVANCED_PERMISSIONS_PHASE_2); err == nil {
+	if _, err := a.Store().System().GetByName(model.MIGRATION_KEY_ADVANCED_PERMISSIONS_PHASE_2); err == nil {
 		phase2Complete = true
 	}
 
@@ -834,7 +865,7 @@ func (a *App) trackPermissions() {
 				channelGuestPermissions = strings.Join(role.Permissions, " ")
 			}
 
-			count, _ := a.Srv.Store.Team().AnalyticsGetTeamCountForScheme(scheme.Id)
+			count, _ := a.Store().Team().AnalyticsGetTeamCountForScheme(scheme.Id)
 
 			a.SendDiagnostic(TRACK_PERMISSIONS_TEAM_SCHEMES, map[string]interface{}{
 				"scheme_id":                 scheme.Id,
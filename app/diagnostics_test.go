@@ -123,6 +123,7 @@ func TestDiagnostics(t *testing.T) {
 			TRACK_CONFIG_NATIVEAPP,
 			TRACK_CONFIG_ANALYTICS,
 			TRACK_CONFIG_PLUGIN,
+			TRACK_CONFIG_TRACING,
 			TRACK_ACTIVITY,
 			TRACK_SERVER,
 			TRACK_CONFIG_MESSAGE_EXPORT,
@@ -343,7 +343,7 @@ func (a *App) SendInviteEmails(team *model.Team, senderName string, senderUserId
 			props["name"] = team.Name
 			data := model.MapToJson(props)
 
-			if err := a.Srv.Store.Token().Save(token); err != nil {
+			if err := a.Store().Token().Save(token); err != nil {
 				mlog.Error(fmt.Sprintf("Failed to send invite email successfully err=%v", err))
 				continue
 			}
@@ -420,7 +420,7 @@ func (a *App) SendGuestInviteEmails(team *model.Team, channels []*model.Channel,
 			props["name"] = team.Name
 			data := model.MapToJson(props)
 
-			if err := a.Srv.Store.Token().Save(token); err != nil {
+			if err := a.Store().Token().Save(token); err != nil {
 				mlog.Error(fmt.Sprintf("Failed to send invite email successfully err=%v", err))
 				continue
 			}
@@ -83,6 +83,18 @@ func RegisterJobsPluginsJobInterface(f func(*App) tjobs.PluginsJobInterface) {
 	jobsPluginsInterface = f
 }
 
+var jobsPostArchivalInterface func(*App) tjobs.PostArchivalJobInterface
+
+func RegisterJobsPostArchivalJobInterface(f func(*App) tjobs.PostArchivalJobInterface) {
+	jobsPostArchivalInterface = f
+}
+
+var jobsPostShardRebalanceInterface func(*App) tjobs.PostShardRebalanceJobInterface
+
+func RegisterJobsPostShardRebalanceJobInterface(f func(*App) tjobs.PostShardRebalanceJobInterface) {
+	jobsPostShardRebalanceInterface = f
+}
+
 var ldapInterface func(*App) einterfaces.LdapInterface
 
 func RegisterLdapInterface(f func(*App) einterfaces.LdapInterface) {
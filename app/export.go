@@ -124,7 +124,7 @@ func (a *App) ExportVersion(writer io.Writer) *model.AppError {
 func (a *App) ExportAllTeams(writer io.Writer) *model.AppError {
 	afterId := strings.Repeat("0", 26)
 	for {
-		teams, err := a.Srv.Store.Team().GetAllForExportAfter(1000, afterId)
+		teams, err := a.Store().Team().GetAllForExportAfter(1000, afterId)
 
 		if err != nil {
 			return err
@@ -155,7 +155,7 @@ func (a *App) ExportAllTeams(writer io.Writer) *model.AppError {
 func (a *App) ExportAllChannels(writer io.Writer) *model.AppError {
 	afterId := strings.Repeat("0", 26)
 	for {
-		channels, err := a.Srv.Store.Channel().GetAllChannelsForExportAfter(1000, afterId)
+		channels, err := a.Store().Channel().GetAllChannelsForExportAfter(1000, afterId)
 
 		if err != nil {
 			return err
@@ -186,7 +186,7 @@ func (a *App) ExportAllChannels(writer io.Writer) *model.AppError {
 func (a *App) ExportAllUsers(writer io.Writer) *model.AppError {
 	afterId := strings.Repeat("0", 26)
 	for {
-		users, err := a.Srv.Store.User().GetAllAfter(1000, afterId)
+		users, err := a.Store().User().GetAllAfter(1000, afterId)
 
 		if err != nil {
 			return err
@@ -261,7 +261,7 @@ func (a *App) ExportAllUsers(writer io.Writer) *model.AppError {
 func (a *App) buildUserTeamAndChannelMemberships(userId string) (*[]UserTeamImportData, *model.AppError) {
 	var memberships []UserTeamImportData
 
-	members, err := a.Srv.Store.Team().GetTeamMembersForExport(userId)
+	members, err := a.Store().Team().GetTeamMembersForExport(userId)
 
 	if err != nil {
 		return nil, err
@@ -282,7 +282,7 @@ func (a *App) buildUserTeamAndChannelMemberships(userId string) (*[]UserTeamImpo
 		}
 
 		// Get the user theme
-		themePreference, err := a.Srv.Store.Preference().Get(member.UserId, model.PREFERENCE_CATEGORY_THEME, member.TeamId)
+		themePreference, err := a.Store().Preference().Get(member.UserId, model.PREFERENCE_CATEGORY_THEME, member.TeamId)
 		if err == nil {
 			memberData.Theme = &themePreference.Value
 		}
@@ -298,7 +298,7 @@ func (a *App) buildUserTeamAndChannelMemberships(userId string) (*[]UserTeamImpo
 func (a *App) buildUserChannelMemberships(userId string, teamId string) (*[]UserChannelImportData, *model.AppError) {
 	var memberships []UserChannelImportData
 
-	members, err := a.Srv.Store.Channel().GetChannelMembersForExport(userId, teamId)
+	members, err := a.Store().Channel().GetChannelMembersForExport(userId, teamId)
 	if err != nil {
 		return nil, err
 	}
@@ -340,7 +340,7 @@ func (a *App) ExportAllPosts(writer io.Writer) *model.AppError {
 	afterId := strings.Repeat("0", 26)
 
 	for {
-		posts, err := a.Srv.Store.Post().GetParentsForExportAfter(1000, afterId)
+		posts, err := a.Store().Post().GetParentsForExportAfter(1000, afterId)
 		if err != nil {
 			return err
 		}
@@ -382,7 +382,7 @@ func (a *App) ExportAllPosts(writer io.Writer) *model.AppError {
 func (a *App) buildPostReplies(postId string) (*[]ReplyImportData, *model.AppError) {
 	var replies []ReplyImportData
 
-	replyPosts, err := a.Srv.Store.Post().GetRepliesForExport(postId)
+	replyPosts, err := a.Store().Post().GetRepliesForExport(postId)
 	if err != nil {
 		return nil, err
 	}
@@ -404,14 +404,14 @@ func (a *App) buildPostReplies(postId string) (*[]ReplyImportData, *model.AppErr
 func (a *App) BuildPostReactions(postId string) (*[]ReactionImportData, *model.AppError) {
 	var reactionsOfPost []ReactionImportData
 
-	reactions, err := a.Srv.Store.Reaction().GetForPost(postId, true)
+	reactions, err := a.Store().Reaction().GetForPost(postId, true)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, reaction := range reactions {
 		var user *model.User
-		user, err = a.Srv.Store.User().Get(reaction.UserId)
+		user, err = a.Store().User().Get(reaction.UserId)
 		if err != nil {
 			if err.Id == store.MISSING_ACCOUNT_ERROR { // this is a valid case, the user that reacted might've been deleted by now
 				mlog.Info(fmt.Sprintf("Skipping reactions by user %v, since the entity doesn't exist anymore", reaction.UserId))
@@ -516,7 +516,7 @@ func (a *App) copyEmojiImages(emojiId string, emojiImagePath string, pathToDir s
 func (a *App) ExportAllDirectChannels(writer io.Writer) *model.AppError {
 	afterId := strings.Repeat("0", 26)
 	for {
-		channels, err := a.Srv.Store.Channel().GetAllDirectChannelsForExportAfter(1000, afterId)
+		channels, err := a.Store().Channel().GetAllDirectChannelsForExportAfter(1000, afterId)
 		if err != nil {
 			return err
 		}
@@ -552,7 +552,7 @@ func (a *App) ExportAllDirectChannels(writer io.Writer) *model.AppError {
 func (a *App) ExportAllDirectPosts(writer io.Writer) *model.AppError {
 	afterId := strings.Repeat("0", 26)
 	for {
-		posts, err := a.Srv.Store.Post().GetDirectPostParentsForExportAfter(1000, afterId)
+		posts, err := a.Store().Post().GetDirectPostParentsForExportAfter(1000, afterId)
 		if err != nil {
 			return err
 		}
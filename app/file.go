@@ -205,7 +205,7 @@ func (a *App) FindTeamIdForFilename(post *model.Post, filename string) string {
 	name, _ := url.QueryUnescape(split[4])
 
 	// This post is in a direct channel so we need to figure out what team the files are stored under.
-	teams, err := a.Srv.Store.Team().GetTeamsByUserId(post.UserId)
+	teams, err := a.Store().Team().GetTeamsByUserId(post.UserId)
 	if err != nil {
 		mlog.Error(fmt.Sprintf("Unable to get teams when migrating post to use FileInfo, err=%v", err), mlog.String("post_id", post.Id))
 		return ""
@@ -236,7 +236,7 @@ func (a *App) MigrateFilenamesToFileInfos(post *model.Post) []*model.FileInfo {
 		return []*model.FileInfo{}
 	}
 
-	channel, errCh := a.Srv.Store.Channel().Get(post.ChannelId, true)
+	channel, errCh := a.Store().Channel().Get(post.ChannelId, true)
 	// There's a weird bug that rarely happens where a post ends up with duplicate Filenames so remove those
 	filenames := utils.RemoveDuplicatesFromStringArray(post.Filenames)
 	if errCh != nil {
@@ -279,7 +279,7 @@ func (a *App) MigrateFilenamesToFileInfos(post *model.Post) []*model.FileInfo {
 	fileMigrationLock.Lock()
 	defer fileMigrationLock.Unlock()
 
-	result, err := a.Srv.Store.Post().Get(post.Id)
+	result, err := a.Store().Post().Get(post.Id)
 	if err != nil {
 		mlog.Error(fmt.Sprintf("Unable to get post when migrating post to use FileInfos, err=%v", err), mlog.String("post_id", post.Id))
 		return []*model.FileInfo{}
@@ -288,7 +288,7 @@ func (a *App) MigrateFilenamesToFileInfos(post *model.Post) []*model.FileInfo {
 	if newPost := result.Posts[post.Id]; len(newPost.Filenames) != len(post.Filenames) {
 		// Another thread has already created FileInfos for this post, so just return those
 		var fileInfos []*model.FileInfo
-		fileInfos, err = a.Srv.Store.FileInfo().GetForPost(post.Id, true, false, false)
+		fileInfos, err = a.Store().FileInfo().GetForPost(post.Id, true, false, false)
 		if err != nil {
 			mlog.Error(fmt.Sprintf("Unable to get FileInfos for migrated post, err=%v", err), mlog.String("post_id", post.Id))
 			return []*model.FileInfo{}
@@ -303,7 +303,7 @@ func (a *App) MigrateFilenamesToFileInfos(post *model.Post) []*model.FileInfo {
 	savedInfos := make([]*model.FileInfo, 0, len(infos))
 	fileIds := make([]string, 0, len(filenames))
 	for _, info := range infos {
-		if _, err = a.Srv.Store.FileInfo().Save(info); err != nil {
+		if _, err = a.Store().FileInfo().Save(info); err != nil {
 			mlog.Error(
 				fmt.Sprintf("Unable to save file info when migrating post to use FileInfos, err=%v", err),
 				mlog.String("post_id", post.Id),
@@ -325,7 +325,7 @@ func (a *App) MigrateFilenamesToFileInfos(post *model.Post) []*model.FileInfo {
 	newPost.FileIds = fileIds
 
 	// Update Posts to clear Filenames and set FileIds
-	if _, err = a.Srv.Store.Post().Update(newPost, post); err != nil {
+	if _, err = a.Store().Post().Update(newPost, post); err != nil {
 		mlog.Error(fmt.Sprintf("Unable to save migrated post when migrating to use FileInfos, new_file_ids=%v, old_filenames=%v, err=%v", newPost.FileIds, post.Filenames, err), mlog.String("post_id", post.Id))
 		return []*model.FileInfo{}
 	}
@@ -553,7 +553,7 @@ func (t *uploadFileTask) init(a *App) {
 
 	t.pluginsEnvironment = a.GetPluginsEnvironment()
 	t.writeFile = a.WriteFile
-	t.saveToDatabase = a.Srv.Store.FileInfo().Save
+	t.saveToDatabase = a.Store().FileInfo().Save
 }
 
 // UploadFileX uploads a single file as specified in t. It applies the upload
@@ -927,7 +927,7 @@ func (a *App) DoUploadFileExpectModification(now time.Time, rawTeamId string, ra
 		return nil, data, err
 	}
 
-	if _, err := a.Srv.Store.FileInfo().Save(info); err != nil {
+	if _, err := a.Store().FileInfo().Save(info); err != nil {
 		return nil, data, err
 	}
 
@@ -1071,7 +1071,7 @@ func (a *App) generatePreviewImage(img image.Image, previewPath string, width in
 }
 
 func (a *App) GetFileInfo(fileId string) (*model.FileInfo, *model.AppError) {
-	return a.Srv.Store.FileInfo().Get(fileId)
+	return a.Store().FileInfo().Get(fileId)
 }
 
 func (a *App) GetFile(fileId string) ([]byte, *model.AppError) {
@@ -1088,13 +1088,30 @@ func (a *App) GetFile(fileId string) ([]byte, *model.AppError) {
 	return data, nil
 }
 
+// FileHasBeenDownloaded notifies plugins that a file was sent to a client. userId is empty for
+// downloads through a public link.
+func (a *App) FileHasBeenDownloaded(info *model.FileInfo, userId string) {
+	pluginsEnvironment := a.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return
+	}
+
+	a.Srv.Go(func() {
+		pluginContext := a.PluginContext()
+		pluginsEnvironment.RunMultiPluginHook(func(hooks plugin.Hooks) bool {
+			hooks.FileHasBeenDownloaded(pluginContext, info, userId)
+			return true
+		}, plugin.FileHasBeenDownloadedId)
+	})
+}
+
 func (a *App) CopyFileInfos(userId string, fileIds []string) ([]string, *model.AppError) {
 	var newFileIds []string
 
 	now := model.GetMillis()
 
 	for _, fileId := range fileIds {
-		fileInfo, err := a.Srv.Store.FileInfo().Get(fileId)
+		fileInfo, err := a.Store().FileInfo().Get(fileId)
 		if err != nil {
 			return nil, err
 		}
@@ -1105,7 +1122,7 @@ func (a *App) CopyFileInfos(userId string, fileIds []string) ([]string, *model.A
 		fileInfo.UpdateAt = now
 		fileInfo.PostId = ""
 
-		if _, err := a.Srv.Store.FileInfo().Save(fileInfo); err != nil {
+		if _, err := a.Store().FileInfo().Save(fileInfo); err != nil {
 			return newFileIds, err
 		}
 
@@ -8,40 +8,40 @@ import (
 )
 
 func (a *App) GetGroup(id string) (*model.Group, *model.AppError) {
-	return a.Srv.Store.Group().Get(id)
+	return a.Store().Group().Get(id)
 }
 
 func (a *App) GetGroupByRemoteID(remoteID string, groupSource model.GroupSource) (*model.Group, *model.AppError) {
-	return a.Srv.Store.Group().GetByRemoteID(remoteID, groupSource)
+	return a.Store().Group().GetByRemoteID(remoteID, groupSource)
 }
 
 func (a *App) GetGroupsBySource(groupSource model.GroupSource) ([]*model.Group, *model.AppError) {
-	return a.Srv.Store.Group().GetAllBySource(groupSource)
+	return a.Store().Group().GetAllBySource(groupSource)
 }
 
 func (a *App) CreateGroup(group *model.Group) (*model.Group, *model.AppError) {
-	return a.Srv.Store.Group().Create(group)
+	return a.Store().Group().Create(group)
 }
 
 func (a *App) UpdateGroup(group *model.Group) (*model.Group, *model.AppError) {
-	return a.Srv.Store.Group().Update(group)
+	return a.Store().Group().Update(group)
 }
 
 func (a *App) DeleteGroup(groupID string) (*model.Group, *model.AppError) {
-	return a.Srv.Store.Group().Delete(groupID)
+	return a.Store().Group().Delete(groupID)
 }
 
 func (a *App) GetGroupMemberUsers(groupID string) ([]*model.User, *model.AppError) {
-	return a.Srv.Store.Group().GetMemberUsers(groupID)
+	return a.Store().Group().GetMemberUsers(groupID)
 }
 
 func (a *App) GetGroupMemberUsersPage(groupID string, page int, perPage int) ([]*model.User, int, *model.AppError) {
-	members, err := a.Srv.Store.Group().GetMemberUsersPage(groupID, page, perPage)
+	members, err := a.Store().Group().GetMemberUsersPage(groupID, page, perPage)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	count, err := a.Srv.Store.Group().GetMemberCount(groupID)
+	count, err := a.Store().Group().GetMemberCount(groupID)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -49,56 +49,56 @@ func (a *App) GetGroupMemberUsersPage(groupID string, page int, perPage int) ([]
 }
 
 func (a *App) UpsertGroupMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
-	return a.Srv.Store.Group().UpsertMember(groupID, userID)
+	return a.Store().Group().UpsertMember(groupID, userID)
 }
 
 func (a *App) DeleteGroupMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
-	return a.Srv.Store.Group().DeleteMember(groupID, userID)
+	return a.Store().Group().DeleteMember(groupID, userID)
 }
 
 func (a *App) CreateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
-	return a.Srv.Store.Group().CreateGroupSyncable(groupSyncable)
+	return a.Store().Group().CreateGroupSyncable(groupSyncable)
 }
 
 func (a *App) GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
-	return a.Srv.Store.Group().GetGroupSyncable(groupID, syncableID, syncableType)
+	return a.Store().Group().GetGroupSyncable(groupID, syncableID, syncableType)
 }
 
 func (a *App) GetGroupSyncables(groupID string, syncableType model.GroupSyncableType) ([]*model.GroupSyncable, *model.AppError) {
-	return a.Srv.Store.Group().GetAllGroupSyncablesByGroupId(groupID, syncableType)
+	return a.Store().Group().GetAllGroupSyncablesByGroupId(groupID, syncableType)
 }
 
 func (a *App) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
-	return a.Srv.Store.Group().UpdateGroupSyncable(groupSyncable)
+	return a.Store().Group().UpdateGroupSyncable(groupSyncable)
 }
 
 func (a *App) DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
-	return a.Srv.Store.Group().DeleteGroupSyncable(groupID, syncableID, syncableType)
+	return a.Store().Group().DeleteGroupSyncable(groupID, syncableID, syncableType)
 }
 
 func (a *App) TeamMembersToAdd(since int64) ([]*model.UserTeamIDPair, *model.AppError) {
-	return a.Srv.Store.Group().TeamMembersToAdd(since)
+	return a.Store().Group().TeamMembersToAdd(since)
 }
 
 func (a *App) ChannelMembersToAdd(since int64) ([]*model.UserChannelIDPair, *model.AppError) {
-	return a.Srv.Store.Group().ChannelMembersToAdd(since)
+	return a.Store().Group().ChannelMembersToAdd(since)
 }
 
 func (a *App) TeamMembersToRemove() ([]*model.TeamMember, *model.AppError) {
-	return a.Srv.Store.Group().TeamMembersToRemove()
+	return a.Store().Group().TeamMembersToRemove()
 }
 
 func (a *App) ChannelMembersToRemove() ([]*model.ChannelMember, *model.AppError) {
-	return a.Srv.Store.Group().ChannelMembersToRemove()
+	return a.Store().Group().ChannelMembersToRemove()
 }
 
 func (a *App) GetGroupsByChannel(channelId string, opts model.GroupSearchOpts) ([]*model.Group, int, *model.AppError) {
-	groups, err := a.Srv.Store.Group().GetGroupsByChannel(channelId, opts)
+	groups, err := a.Store().Group().GetGroupsByChannel(channelId, opts)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	count, err := a.Srv.Store.Group().CountGroupsByChannel(channelId, opts)
+	count, err := a.Store().Group().CountGroupsByChannel(channelId, opts)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -107,12 +107,12 @@ func (a *App) GetGroupsByChannel(channelId string, opts model.GroupSearchOpts) (
 }
 
 func (a *App) GetGroupsByTeam(teamId string, opts model.GroupSearchOpts) ([]*model.Group, int, *model.AppError) {
-	groups, err := a.Srv.Store.Group().GetGroupsByTeam(teamId, opts)
+	groups, err := a.Store().Group().GetGroupsByTeam(teamId, opts)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	count, err := a.Srv.Store.Group().CountGroupsByTeam(teamId, opts)
+	count, err := a.Store().Group().CountGroupsByTeam(teamId, opts)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -121,7 +121,7 @@ func (a *App) GetGroupsByTeam(teamId string, opts model.GroupSearchOpts) ([]*mod
 }
 
 func (a *App) GetGroups(page, perPage int, opts model.GroupSearchOpts) ([]*model.Group, *model.AppError) {
-	return a.Srv.Store.Group().GetGroups(page, perPage, opts)
+	return a.Store().Group().GetGroups(page, perPage, opts)
 }
 
 // TeamMembersMinusGroupMembers returns the set of users on the given team minus the set of users in the given
@@ -130,7 +130,7 @@ func (a *App) GetGroups(page, perPage int, opts model.GroupSearchOpts) ([]*model
 // The result can be used, for example, to determine the set of users who would be removed from a team if the team
 // were group-constrained with the given groups.
 func (a *App) TeamMembersMinusGroupMembers(teamID string, groupIDs []string, page, perPage int) ([]*model.UserWithGroups, int64, *model.AppError) {
-	users, err := a.Srv.Store.Group().TeamMembersMinusGroupMembers(teamID, groupIDs, page, perPage)
+	users, err := a.Store().Group().TeamMembersMinusGroupMembers(teamID, groupIDs, page, perPage)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -172,7 +172,7 @@ func (a *App) TeamMembersMinusGroupMembers(teamID string, groupIDs []string, pag
 		}
 	}
 
-	totalCount, err := a.Srv.Store.Group().CountTeamMembersMinusGroupMembers(teamID, groupIDs)
+	totalCount, err := a.Store().Group().CountTeamMembersMinusGroupMembers(teamID, groupIDs)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -180,7 +180,7 @@ func (a *App) TeamMembersMinusGroupMembers(teamID string, groupIDs []string, pag
 }
 
 func (a *App) GetGroupsByIDs(groupIDs []string) ([]*model.Group, *model.AppError) {
-	return a.Srv.Store.Group().GetByIDs(groupIDs)
+	return a.Store().Group().GetByIDs(groupIDs)
 }
 
 // ChannelMembersMinusGroupMembers returns the set of users in the given channel minus the set of users in the given
@@ -189,7 +189,7 @@ func (a *App) GetGroupsByIDs(groupIDs []string) ([]*model.Group, *model.AppError
 // The result can be used, for example, to determine the set of users who would be removed from a channel if the
 // channel were group-constrained with the given groups.
 func (a *App) ChannelMembersMinusGroupMembers(channelID string, groupIDs []string, page, perPage int) ([]*model.UserWithGroups, int64, *model.AppError) {
-	users, err := a.Srv.Store.Group().ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
+	users, err := a.Store().Group().ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -231,7 +231,7 @@ func (a *App) ChannelMembersMinusGroupMembers(channelID string, groupIDs []strin
 		}
 	}
 
-	totalCount, err := a.Srv.Store.Group().CountChannelMembersMinusGroupMembers(channelID, groupIDs)
+	totalCount, err := a.Store().Group().CountChannelMembersMinusGroupMembers(channelID, groupIDs)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -164,7 +164,7 @@ func (a *App) ImportTeam(data *TeamImportData, dryRun bool) *model.AppError {
 	}
 
 	var team *model.Team
-	team, err := a.Srv.Store.Team().GetByName(*data.Name)
+	team, err := a.Store().Team().GetByName(*data.Name)
 
 	if err != nil {
 		team = &model.Team{}
@@ -222,13 +222,13 @@ func (a *App) ImportChannel(data *ChannelImportData, dryRun bool) *model.AppErro
 		return nil
 	}
 
-	team, err := a.Srv.Store.Team().GetByName(*data.Team)
+	team, err := a.Store().Team().GetByName(*data.Team)
 	if err != nil {
 		return model.NewAppError("BulkImport", "app.import.import_channel.team_not_found.error", map[string]interface{}{"TeamName": *data.Team}, err.Error(), http.StatusBadRequest)
 	}
 
 	var channel *model.Channel
-	if result, err := a.Srv.Store.Channel().GetByNameIncludeDeleted(team.Id, *data.Name, true); err == nil {
+	if result, err := a.Store().Channel().GetByNameIncludeDeleted(team.Id, *data.Name, true); err == nil {
 		channel = result
 	} else {
 		channel = &model.Channel{}
@@ -296,7 +296,7 @@ func (a *App) ImportUser(data *UserImportData, dryRun bool) *model.AppError {
 
 	var user *model.User
 	var err *model.AppError
-	user, err = a.Srv.Store.User().GetByUsername(*data.Username)
+	user, err = a.Store().User().GetByUsername(*data.Username)
 	if err != nil {
 		user = &model.User{}
 		user.MakeNonNil()
@@ -499,7 +499,7 @@ func (a *App) ImportUser(data *UserImportData, dryRun bool) *model.AppError {
 			}
 		} else {
 			if hasUserAuthDataChanged {
-				if _, err = a.Srv.Store.User().UpdateAuthData(user.Id, authService, authData, user.Email, false); err != nil {
+				if _, err = a.Store().User().UpdateAuthData(user.Id, authService, authData, user.Email, false); err != nil {
 					return err
 				}
 			}
@@ -636,7 +636,7 @@ func (a *App) ImportUser(data *UserImportData, dryRun bool) *model.AppError {
 	}
 
 	if len(preferences) > 0 {
-		if err := a.Srv.Store.Preference().Save(&preferences); err != nil {
+		if err := a.Store().Preference().Save(&preferences); err != nil {
 			return model.NewAppError("BulkImport", "app.import.import_user.save_preferences.error", nil, err.Error(), http.StatusInternalServerError)
 		}
 	}
@@ -721,7 +721,7 @@ func (a *App) ImportUserTeams(user *model.User, data *[]UserTeamImportData) *mod
 	}
 
 	if len(teamThemePreferences) > 0 {
-		if err := a.Srv.Store.Preference().Save(&teamThemePreferences); err != nil {
+		if err := a.Store().Preference().Save(&teamThemePreferences); err != nil {
 			return model.NewAppError("BulkImport", "app.import.import_user_teams.save_preferences.error", nil, err.Error(), http.StatusInternalServerError)
 		}
 	}
@@ -818,7 +818,7 @@ func (a *App) ImportUserChannels(user *model.User, team *model.Team, teamMember
 	}
 
 	if len(preferences) > 0 {
-		if err := a.Srv.Store.Preference().Save(&preferences); err != nil {
+		if err := a.Store().Preference().Save(&preferences); err != nil {
 			return model.NewAppError("BulkImport", "app.import.import_user_channels.save_preferences.error", nil, err.Error(), http.StatusInternalServerError)
 		}
 	}
@@ -833,7 +833,7 @@ func (a *App) ImportReaction(data *ReactionImportData, post *model.Post, dryRun
 	}
 
 	var user *model.User
-	user, err = a.Srv.Store.User().GetByUsername(*data.User)
+	user, err = a.Store().User().GetByUsername(*data.User)
 	if err != nil {
 		return model.NewAppError("BulkImport", "app.import.import_post.user_not_found.error", map[string]interface{}{"Username": data.User}, err.Error(), http.StatusBadRequest)
 	}
@@ -844,7 +844,7 @@ func (a *App) ImportReaction(data *ReactionImportData, post *model.Post, dryRun
 		EmojiName: *data.EmojiName,
 		CreateAt:  *data.CreateAt,
 	}
-	if _, err = a.Srv.Store.Reaction().Save(reaction); err != nil {
+	if _, err = a.Store().Reaction().Save(reaction); err != nil {
 		return err
 	}
 
@@ -858,13 +858,13 @@ func (a *App) ImportReply(data *ReplyImportData, post *model.Post, teamId string
 	}
 
 	var user *model.User
-	user, err = a.Srv.Store.User().GetByUsername(*data.User)
+	user, err = a.Store().User().GetByUsername(*data.User)
 	if err != nil {
 		return model.NewAppError("BulkImport", "app.import.import_post.user_not_found.error", map[string]interface{}{"Username": data.User}, err.Error(), http.StatusBadRequest)
 	}
 
 	// Check if this post already exists.
-	replies, err := a.Srv.Store.Post().GetPostsCreatedAt(post.ChannelId, *data.CreateAt)
+	replies, err := a.Store().Post().GetPostsCreatedAt(post.ChannelId, *data.CreateAt)
 	if err != nil {
 		return err
 	}
@@ -896,11 +896,11 @@ func (a *App) ImportReply(data *ReplyImportData, post *model.Post, teamId string
 	}
 
 	if reply.Id == "" {
-		if _, err := a.Srv.Store.Post().Save(reply); err != nil {
+		if _, err := a.Store().Post().Save(reply); err != nil {
 			return err
 		}
 	} else {
-		if _, err := a.Srv.Store.Post().Overwrite(reply); err != nil {
+		if _, err := a.Store().Post().Overwrite(reply); err != nil {
 			return err
 		}
 	}
@@ -969,24 +969,24 @@ func (a *App) ImportPost(data *PostImportData, dryRun bool) *model.AppError {
 		return nil
 	}
 
-	team, err := a.Srv.Store.Team().GetByName(*data.Team)
+	team, err := a.Store().Team().GetByName(*data.Team)
 	if err != nil {
 		return model.NewAppError("BulkImport", "app.import.import_post.team_not_found.error", map[string]interface{}{"TeamName": *data.Team}, err.Error(), http.StatusBadRequest)
 	}
 
-	channel, err := a.Srv.Store.Channel().GetByName(team.Id, *data.Channel, false)
+	channel, err := a.Store().Channel().GetByName(team.Id, *data.Channel, false)
 	if err != nil {
 		return model.NewAppError("BulkImport", "app.import.import_post.channel_not_found.error", map[string]interface{}{"ChannelName": *data.Channel}, err.Error(), http.StatusBadRequest)
 	}
 
 	var user *model.User
-	user, err = a.Srv.Store.User().GetByUsername(*data.User)
+	user, err = a.Store().User().GetByUsername(*data.User)
 	if err != nil {
 		return model.NewAppError("BulkImport", "app.import.import_post.user_not_found.error", map[string]interface{}{"Username": *data.User}, err.Error(), http.StatusBadRequest)
 	}
 
 	// Check if this post already exists.
-	posts, err := a.Srv.Store.Post().GetPostsCreatedAt(channel.Id, *data.CreateAt)
+	posts, err := a.Store().Post().GetPostsCreatedAt(channel.Id, *data.CreateAt)
 	if err != nil {
 		return err
 	}
@@ -1020,11 +1020,11 @@ func (a *App) ImportPost(data *PostImportData, dryRun bool) *model.AppError {
 	}
 
 	if post.Id == "" {
-		if _, err = a.Srv.Store.Post().Save(post); err != nil {
+		if _, err = a.Store().Post().Save(post); err != nil {
 			return err
 		}
 	} else {
-		if _, err = a.Srv.Store.Post().Overwrite(post); err != nil {
+		if _, err = a.Store().Post().Overwrite(post); err != nil {
 			return err
 		}
 	}
@@ -1034,7 +1034,7 @@ func (a *App) ImportPost(data *PostImportData, dryRun bool) *model.AppError {
 
 		for _, username := range *data.FlaggedBy {
 			var user *model.User
-			user, err = a.Srv.Store.User().GetByUsername(username)
+			user, err = a.Store().User().GetByUsername(username)
 			if err != nil {
 				return model.NewAppError("BulkImport", "app.import.import_post.user_not_found.error", map[string]interface{}{"Username": username}, err.Error(), http.StatusBadRequest)
 			}
@@ -1048,7 +1048,7 @@ func (a *App) ImportPost(data *PostImportData, dryRun bool) *model.AppError {
 		}
 
 		if len(preferences) > 0 {
-			if err := a.Srv.Store.Preference().Save(&preferences); err != nil {
+			if err := a.Store().Preference().Save(&preferences); err != nil {
 				return model.NewAppError("BulkImport", "app.import.import_post.save_preferences.error", nil, err.Error(), http.StatusInternalServerError)
 			}
 		}
@@ -1090,7 +1090,7 @@ func (a *App) uploadAttachments(attachments *[]AttachmentImportData, post *model
 
 func (a *App) UpdateFileInfoWithPostId(post *model.Post) {
 	for _, fileId := range post.FileIds {
-		if err := a.Srv.Store.FileInfo().AttachToPost(fileId, post.Id, post.UserId); err != nil {
+		if err := a.Store().FileInfo().AttachToPost(fileId, post.Id, post.UserId); err != nil {
 			mlog.Error(fmt.Sprintf("Error attaching files to post. postId=%v, fileIds=%v, message=%v", post.Id, post.FileIds, err), mlog.String("post_id", post.Id))
 		}
 	}
@@ -1110,7 +1110,7 @@ func (a *App) ImportDirectChannel(data *DirectChannelImportData, dryRun bool) *m
 	userMap := make(map[string]string)
 	for _, username := range *data.Members {
 		var user *model.User
-		user, err = a.Srv.Store.User().GetByUsername(username)
+		user, err = a.Store().User().GetByUsername(username)
 		if err != nil {
 			return model.NewAppError("BulkImport", "app.import.import_direct_channel.member_not_found.error", nil, err.Error(), http.StatusBadRequest)
 		}
@@ -1156,14 +1156,14 @@ func (a *App) ImportDirectChannel(data *DirectChannelImportData, dryRun bool) *m
 		}
 	}
 
-	if err := a.Srv.Store.Preference().Save(&preferences); err != nil {
+	if err := a.Store().Preference().Save(&preferences); err != nil {
 		err.StatusCode = http.StatusBadRequest
 		return err
 	}
 
 	if data.Header != nil {
 		channel.Header = *data.Header
-		if _, appErr := a.Srv.Store.Channel().Update(channel); appErr != nil {
+		if _, appErr := a.Store().Channel().Update(channel); appErr != nil {
 			return model.NewAppError("BulkImport", "app.import.import_direct_channel.update_header_failed.error", nil, appErr.Error(), http.StatusBadRequest)
 		}
 	}
@@ -1185,7 +1185,7 @@ func (a *App) ImportDirectPost(data *DirectPostImportData, dryRun bool) *model.A
 	var userIds []string
 	for _, username := range *data.ChannelMembers {
 		var user *model.User
-		user, err = a.Srv.Store.User().GetByUsername(username)
+		user, err = a.Store().User().GetByUsername(username)
 		if err != nil {
 			return model.NewAppError("BulkImport", "app.import.import_direct_post.channel_member_not_found.error", nil, err.Error(), http.StatusBadRequest)
 		}
@@ -1209,13 +1209,13 @@ func (a *App) ImportDirectPost(data *DirectPostImportData, dryRun bool) *model.A
 	}
 
 	var user *model.User
-	user, err = a.Srv.Store.User().GetByUsername(*data.User)
+	user, err = a.Store().User().GetByUsername(*data.User)
 	if err != nil {
 		return model.NewAppError("BulkImport", "app.import.import_direct_post.user_not_found.error", map[string]interface{}{"Username": *data.User}, "", http.StatusBadRequest)
 	}
 
 	// Check if this post already exists.
-	posts, err := a.Srv.Store.Post().GetPostsCreatedAt(channel.Id, *data.CreateAt)
+	posts, err := a.Store().Post().GetPostsCreatedAt(channel.Id, *data.CreateAt)
 	if err != nil {
 		return err
 	}
@@ -1249,11 +1249,11 @@ func (a *App) ImportDirectPost(data *DirectPostImportData, dryRun bool) *model.A
 	}
 
 	if post.Id == "" {
-		if _, err = a.Srv.Store.Post().Save(post); err != nil {
+		if _, err = a.Store().Post().Save(post); err != nil {
 			return err
 		}
 	} else {
-		if _, err = a.Srv.Store.Post().Overwrite(post); err != nil {
+		if _, err = a.Store().Post().Overwrite(post); err != nil {
 			return err
 		}
 	}
@@ -1263,7 +1263,7 @@ func (a *App) ImportDirectPost(data *DirectPostImportData, dryRun bool) *model.A
 
 		for _, username := range *data.FlaggedBy {
 			var user *model.User
-			user, err = a.Srv.Store.User().GetByUsername(username)
+			user, err = a.Store().User().GetByUsername(username)
 			if err != nil {
 				return model.NewAppError("BulkImport", "app.import.import_direct_post.user_not_found.error", map[string]interface{}{"Username": username}, "", http.StatusBadRequest)
 			}
@@ -1277,7 +1277,7 @@ func (a *App) ImportDirectPost(data *DirectPostImportData, dryRun bool) *model.A
 		}
 
 		if len(preferences) > 0 {
-			if err := a.Srv.Store.Preference().Save(&preferences); err != nil {
+			if err := a.Store().Preference().Save(&preferences); err != nil {
 				return model.NewAppError("BulkImport", "app.import.import_direct_post.save_preferences.error", nil, err.Error(), http.StatusInternalServerError)
 			}
 		}
@@ -1315,7 +1315,7 @@ func (a *App) ImportEmoji(data *EmojiImportData, dryRun bool) *model.AppError {
 
 	var emoji *model.Emoji
 
-	emoji, appError := a.Srv.Store.Emoji().GetByName(*data.Name, true)
+	emoji, appError := a.Store().Emoji().GetByName(*data.Name, true)
 	if appError != nil && appError.StatusCode != http.StatusNotFound {
 		return appError
 	}
@@ -1339,7 +1339,7 @@ func (a *App) ImportEmoji(data *EmojiImportData, dryRun bool) *model.AppError {
 	}
 
 	if !alreadyExists {
-		if _, err := a.Srv.Store.Emoji().Save(emoji); err != nil {
+		if _, err := a.Store().Emoji().Save(emoji); err != nil {
 			return err
 		}
 	}
@@ -64,14 +64,14 @@ func (a *App) DoPostActionWithCookie(postId, actionId, userId, selectedOption st
 	// Start all queries here for parallel execution
 	pchan := make(chan store.StoreResult, 1)
 	go func() {
-		post, err := a.Srv.Store.Post().GetSingle(postId)
+		post, err := a.Store().Post().GetSingle(postId)
 		pchan <- store.StoreResult{Data: post, Err: err}
 		close(pchan)
 	}()
 
 	cchan := make(chan store.StoreResult, 1)
 	go func() {
-		channel, err := a.Srv.Store.Channel().GetForPost(postId)
+		channel, err := a.Store().Channel().GetForPost(postId)
 		cchan <- store.StoreResult{Data: channel, Err: err}
 		close(cchan)
 	}()
@@ -8,7 +8,7 @@ import (
 )
 
 func (a *App) GetJob(id string) (*model.Job, *model.AppError) {
-	return a.Srv.Store.Job().Get(id)
+	return a.Store().Job().Get(id)
 }
 
 func (a *App) GetJobsPage(page int, perPage int) ([]*model.Job, *model.AppError) {
@@ -16,7 +16,7 @@ func (a *App) GetJobsPage(page int, perPage int) ([]*model.Job, *model.AppError)
 }
 
 func (a *App) GetJobs(offset int, limit int) ([]*model.Job, *model.AppError) {
-	return a.Srv.Store.Job().GetAllPage(offset, limit)
+	return a.Store().Job().GetAllPage(offset, limit)
 }
 
 func (a *App) GetJobsByTypePage(jobType string, page int, perPage int) ([]*model.Job, *model.AppError) {
@@ -24,7 +24,7 @@ func (a *App) GetJobsByTypePage(jobType string, page int, perPage int) ([]*model
 }
 
 func (a *App) GetJobsByType(jobType string, offset int, limit int) ([]*model.Job, *model.AppError) {
-	return a.Srv.Store.Job().GetAllByTypePage(jobType, offset, limit)
+	return a.Store().Job().GetAllByTypePage(jobType, offset, limit)
 }
 
 func (a *App) CreateJob(job *model.Job) (*model.Job, *model.AppError) {
@@ -93,7 +93,7 @@ func (a *App) GetUserForLogin(id, loginId string) (*model.User, *model.AppError)
 	}
 
 	// Try to get the user by username/email
-	if user, err := a.Srv.Store.User().GetForLogin(loginId, enableUsername, enableEmail); err == nil {
+	if user, err := a.Store().User().GetForLogin(loginId, enableUsername, enableEmail); err == nil {
 		return user, nil
 	}
 
@@ -8,6 +8,7 @@ import (
 	"hash/fnv"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -185,7 +186,7 @@ func (a *App) ClearPushNotificationSync(currentSessionId, userId, channelId stri
 		ContentAvailable: 1,
 	}
 
-	if unreadCount, err := a.Srv.Store.User().GetUnreadCount(userId); err != nil {
+	if unreadCount, err := a.Store().User().GetUnreadCount(userId); err != nil {
 		msg.Badge = 0
 		mlog.Error(fmt.Sprint("We could not get the unread message count for the user", userId, err), mlog.String("user_id", userId))
 	} else {
@@ -357,12 +358,12 @@ func (a *App) SendAckToPushProxy(ack *model.PushNotificationAck) error {
 }
 
 func (a *App) getMobileAppSessions(userId string) ([]*model.Session, *model.AppError) {
-	return a.Srv.Store.Session().GetSessionsWithActiveDeviceIds(userId)
+	return a.Store().Session().GetSessionsWithActiveDeviceIds(userId)
 }
 
 func ShouldSendPushNotification(user *model.User, channelNotifyProps model.StringMap, wasMentioned bool, status *model.Status, post *model.Post) bool {
 	return DoesNotifyPropsAllowPushNotification(user, channelNotifyProps, post, wasMentioned) &&
-		DoesStatusAllowPushNotification(user.NotifyProps, status, post.ChannelId)
+		DoesStatusAllowPushNotification(user, status, post.ChannelId, post.IsUrgent())
 }
 
 func DoesNotifyPropsAllowPushNotification(user *model.User, channelNotifyProps model.StringMap, post *model.Post, wasMentioned bool) bool {
@@ -407,13 +408,18 @@ func DoesNotifyPropsAllowPushNotification(user *model.User, channelNotifyProps m
 	return true
 }
 
-func DoesStatusAllowPushNotification(userNotifyProps model.StringMap, status *model.Status, channelId string) bool {
-	// If User status is DND or OOO return false right away
-	if status.Status == model.STATUS_DND || status.Status == model.STATUS_OUT_OF_OFFICE {
+func DoesStatusAllowPushNotification(user *model.User, status *model.Status, channelId string, urgent bool) bool {
+	// If User status is DND or OOO return false right away, unless the post is urgent which bypasses DND
+	if (status.Status == model.STATUS_DND && !urgent) || status.Status == model.STATUS_OUT_OF_OFFICE {
 		return false
 	}
 
-	pushStatus, ok := userNotifyProps[model.PUSH_STATUS_NOTIFY_PROP]
+	// Outside of working hours only urgent posts are delivered
+	if !urgent && !user.IsWithinWorkingHours(time.Now()) {
+		return false
+	}
+
+	pushStatus, ok := user.NotifyProps[model.PUSH_STATUS_NOTIFY_PROP]
 	if (pushStatus == model.STATUS_ONLINE || !ok) && (status.ActiveChannel != channelId || model.GetMillis()-status.LastActivityAt > model.STATUS_CHANNEL_TIMEOUT) {
 		return true
 	}
@@ -444,14 +450,14 @@ func (a *App) BuildPushNotificationMessage(post *model.Post, user *model.User, c
 	}
 
 	if user.NotifyProps["push"] == "all" {
-		if unreadCount, err := a.Srv.Store.User().GetAnyUnreadPostCountForChannel(user.Id, channel.Id); err != nil {
+		if unreadCount, err := a.Store().User().GetAnyUnreadPostCountForChannel(user.Id, channel.Id); err != nil {
 			msg.Badge = 1
 			mlog.Error(fmt.Sprint("We could not get the unread message count for the user", user.Id, err), mlog.String("user_id", user.Id))
 		} else {
 			msg.Badge = int(unreadCount)
 		}
 	} else {
-		if unreadCount, err := a.Srv.Store.User().GetUnreadCount(user.Id); err != nil {
+		if unreadCount, err := a.Store().User().GetUnreadCount(user.Id); err != nil {
 			msg.Badge = 1
 			mlog.Error(fmt.Sprint("We could not get the unread message count for the user", user.Id, err), mlog.String("user_id", user.Id))
 		} else {
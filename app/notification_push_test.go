@@ -6,6 +6,7 @@ package app
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/utils"
@@ -530,11 +531,40 @@ func TestDoesStatusAllowPushNotification(t *testing.T) {
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			userNotifyProps := make(map[string]string)
-			userNotifyProps["push_status"] = tc.userNotifySetting
-			assert.Equal(t, tc.expected, DoesStatusAllowPushNotification(userNotifyProps, tc.status, tc.channelId))
+			user := &model.User{Id: userId, NotifyProps: make(map[string]string)}
+			user.NotifyProps["push_status"] = tc.userNotifySetting
+			assert.Equal(t, tc.expected, DoesStatusAllowPushNotification(user, tc.status, tc.channelId, false))
 		})
 	}
+
+	t.Run("urgent posts", func(t *testing.T) {
+		user := &model.User{Id: userId, NotifyProps: map[string]string{"push_status": model.STATUS_ONLINE}}
+		assert.True(t, DoesStatusAllowPushNotification(user, dnd, channelId, true), "urgent posts should bypass DND")
+
+		ooo := &model.Status{UserId: userId, Status: model.STATUS_OUT_OF_OFFICE, Manual: true, LastActivityAt: model.GetMillis(), ActiveChannel: ""}
+		assert.False(t, DoesStatusAllowPushNotification(user, ooo, channelId, true))
+	})
+
+	t.Run("outside of working hours", func(t *testing.T) {
+		now := time.Now().UTC()
+		workingHours := &model.WorkingHours{
+			Enabled: true,
+			Ranges: []*model.WorkingHoursRange{
+				{Day: now.AddDate(0, 0, 1).Weekday(), Start: "09:00", End: "17:00"},
+			},
+		}
+
+		user := &model.User{Id: userId, NotifyProps: map[string]string{"push_status": model.STATUS_ONLINE}}
+		user.Timezone = map[string]string{"useAutomaticTimezone": "false", "manualTimezone": "UTC"}
+		user.NotifyProps[model.WORKING_HOURS_NOTIFY_PROP] = workingHours.ToJson()
+
+		assert.False(t, DoesStatusAllowPushNotification(user, offline, channelId, false))
+		assert.True(t, DoesStatusAllowPushNotification(user, offline, channelId, true), "urgent posts should be delivered")
+
+		workingHours.Enabled = false
+		user.NotifyProps[model.WORKING_HOURS_NOTIFY_PROP] = workingHours.ToJson()
+		assert.True(t, DoesStatusAllowPushNotification(user, offline, channelId, false))
+	})
 }
 
 func TestGetPushNotificationMessage(t *testing.T) {
@@ -18,42 +18,42 @@ const systemSchemeName = "00000000-0000-0000-0000-000000000000" // Prevents coll
 
 func (a *App) ResetPermissionsSystem() *model.AppError {
 	// Reset all Teams to not have a scheme.
-	if err := a.Srv.Store.Team().ResetAllTeamSchemes(); err != nil {
+	if err := a.Store().Team().ResetAllTeamSchemes(); err != nil {
 		return err
 	}
 
 	// Reset all Channels to not have a scheme.
-	if err := a.Srv.Store.Channel().ResetAllChannelSchemes(); err != nil {
+	if err := a.Store().Channel().ResetAllChannelSchemes(); err != nil {
 		return err
 	}
 
 	// Reset all Custom Role assignments to Users.
-	if err := a.Srv.Store.User().ClearAllCustomRoleAssignments(); err != nil {
+	if err := a.Store().User().ClearAllCustomRoleAssignments(); err != nil {
 		return err
 	}
 
 	// Reset all Custom Role assignments to TeamMembers.
-	if err := a.Srv.Store.Team().ClearAllCustomRoleAssignments(); err != nil {
+	if err := a.Store().Team().ClearAllCustomRoleAssignments(); err != nil {
 		return err
 	}
 
 	// Reset all Custom Role assignments to ChannelMembers.
-	if err := a.Srv.Store.Channel().ClearAllCustomRoleAssignments(); err != nil {
+	if err := a.Store().Channel().ClearAllCustomRoleAssignments(); err != nil {
 		return err
 	}
 
 	// Purge all schemes from the database.
-	if err := a.Srv.Store.Scheme().PermanentDeleteAll(); err != nil {
+	if err := a.Store().Scheme().PermanentDeleteAll(); err != nil {
 		return err
 	}
 
 	// Purge all roles from the database.
-	if err := a.Srv.Store.Role().PermanentDeleteAll(); err != nil {
+	if err := a.Store().Role().PermanentDeleteAll(); err != nil {
 		return err
 	}
 
 	// Remove the "System" table entry that marks the advanced permissions migration as done.
-	if _, err := a.Srv.Store.System().PermanentDeleteByName(ADVANCED_PERMISSIONS_MIGRATION_KEY); err != nil {
+	if _, err := a.Store().System().PermanentDeleteByName(ADVANCED_PERMISSIONS_MIGRATION_KEY); err != nil {
 		return err
 	}
 
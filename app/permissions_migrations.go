@@ -24,6 +24,7 @@ const (
 	MIGRATION_KEY_REMOVE_CHANNEL_MANAGE_DELETE_FROM_TEAM_USER = "remove_channel_manage_delete_from_team_user"
 	MIGRATION_KEY_VIEW_MEMBERS_NEW_PERMISSION                 = "view_members_new_permission"
 	MIGRATION_KEY_ADD_MANAGE_GUESTS_PERMISSIONS               = "add_manage_guests_permissions"
+	MIGRATION_KEY_ADD_CREATE_URGENT_POST_PERMISSION           = "add_create_urgent_post_permission"
 
 	PERMISSION_MANAGE_SYSTEM                     = "manage_system"
 	PERMISSION_MANAGE_EMOJIS                     = "manage_emojis"
@@ -56,6 +57,8 @@ const (
 	PERMISSION_INVITE_GUEST                      = "invite_guest"
 	PERMISSION_PROMOTE_GUEST                     = "promote_guest"
 	PERMISSION_DEMOTE_TO_GUEST                   = "demote_to_guest"
+	PERMISSION_CREATE_POST                       = "create_post"
+	PERMISSION_CREATE_URGENT_POST                = "create_urgent_post"
 )
 
 func isRole(role string) func(string, map[string]map[string]bool) bool {
@@ -129,7 +132,7 @@ func applyPermissionsMap(roleName string, roleMap map[string]map[string]bool, mi
 }
 
 func (a *App) doPermissionsMigration(key string, migrationMap permissionsMap) *model.AppError {
-	if _, err := a.Srv.Store.System().GetByName(key); err == nil {
+	if _, err := a.Store().System().GetByName(key); err == nil {
 		return nil
 	}
 
@@ -148,12 +151,12 @@ func (a *App) doPermissionsMigration(key string, migrationMap permissionsMap) *m
 
 	for _, role := range roles {
 		role.Permissions = applyPermissionsMap(role.Name, roleMap, migrationMap)
-		if _, err := a.Srv.Store.Role().Save(role); err != nil {
+		if _, err := a.Store().Role().Save(role); err != nil {
 			return err
 		}
 	}
 
-	if err := a.Srv.Store.System().Save(&model.System{Name: key, Value: "true"}); err != nil {
+	if err := a.Store().System().Save(&model.System{Name: key, Value: "true"}); err != nil {
 		return err
 	}
 	return nil
@@ -291,6 +294,17 @@ func getAddManageGuestsPermissionsMigration() permissionsMap {
 	}
 }
 
+func getAddCreateUrgentPostPermissionMigration() permissionsMap {
+	return permissionsMap{
+		permissionTransformation{
+			On: permissionAnd(permissionExists(PERMISSION_CREATE_POST), func(role string, _ map[string]map[string]bool) bool {
+				return role != model.CHANNEL_GUEST_ROLE_ID
+			}),
+			Add: []string{PERMISSION_CREATE_URGENT_POST},
+		},
+	}
+}
+
 // DoPermissionsMigrations execute all the permissions migrations need by the current version.
 func (a *App) DoPermissionsMigrations() *model.AppError {
 	PermissionsMigrations := []struct {
@@ -306,6 +320,7 @@ func (a *App) DoPermissionsMigrations() *model.AppError {
 		{Key: MIGRATION_KEY_REMOVE_CHANNEL_MANAGE_DELETE_FROM_TEAM_USER, Migration: removeChannelManageDeleteFromTeamUser},
 		{Key: MIGRATION_KEY_VIEW_MEMBERS_NEW_PERMISSION, Migration: getViewMembersPermissionMigration},
 		{Key: MIGRATION_KEY_ADD_MANAGE_GUESTS_PERMISSIONS, Migration: getAddManageGuestsPermissionsMigration},
+		{Key: MIGRATION_KEY_ADD_CREATE_URGENT_POST_PERMISSION, Migration: getAddCreateUrgentPostPermissionMigration},
 	}
 
 	for _, migration := range PermissionsMigrations {
@@ -70,6 +70,7 @@ func (a *App) SyncPluginsActiveState() {
 			// If it's not enabled we need to deactivate it
 			if !pluginEnabled {
 				deactivated := pluginsEnvironment.Deactivate(pluginId)
+				a.UnregisterPluginScheduledJobs(pluginId)
 				if deactivated && plugin.Manifest.HasClient() {
 					message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_PLUGIN_DISABLED, "", "", "", nil)
 					message.Add("manifest", plugin.Manifest.ClientManifest())
@@ -147,6 +148,7 @@ func (a *App) InitPlugins(pluginDir, webappPluginDir string) {
 		mlog.Error("Failed to start up plugins", mlog.Err(err))
 		return
 	}
+	env.SetResourceLimits(plugin.ResourceLimitsFromConfig(&a.Config().PluginSettings))
 	a.SetPluginsEnvironment(env)
 
 	if err := a.SyncPlugins(); err != nil {
@@ -175,7 +177,10 @@ func (a *App) InitPlugins(pluginDir, webappPluginDir string) {
 	// Sync plugin active state when config changes. Also notify plugins.
 	a.Srv.PluginsLock.Lock()
 	a.RemoveConfigListener(a.Srv.PluginConfigListenerId)
-	a.Srv.PluginConfigListenerId = a.AddConfigListener(func(*model.Config, *model.Config) {
+	a.Srv.PluginConfigListenerId = a.AddConfigListener(func(_, newCfg *model.Config) {
+		if pluginsEnvironment := a.GetPluginsEnvironment(); pluginsEnvironment != nil {
+			pluginsEnvironment.SetResourceLimits(plugin.ResourceLimitsFromConfig(&newCfg.PluginSettings))
+		}
 		a.SyncPluginsActiveState()
 		if pluginsEnvironment := a.GetPluginsEnvironment(); pluginsEnvironment != nil {
 			pluginsEnvironment.RunMultiPluginHook(func(hooks plugin.Hooks) bool {
@@ -361,6 +366,7 @@ func (a *App) DisablePlugin(id string) *model.AppError {
 		cfg.PluginSettings.PluginStates[id] = &model.PluginState{Enable: false}
 	})
 	a.UnregisterPluginCommands(id)
+	a.UnregisterPluginScheduledJobs(id)
 
 	// This call will implicitly invoke SyncPluginsActiveState which will deactivate disabled plugins.
 	if err := a.SaveConfig(a.Config(), true); err != nil {
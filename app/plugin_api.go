@@ -13,6 +13,7 @@ import (
 
 	"github.com/mattermost/mattermost-server/mlog"
 	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/plugin"
 )
 
 type PluginAPI struct {
@@ -673,10 +674,22 @@ func (api *PluginAPI) KVSetWithExpiry(key string, value []byte, expireInSeconds
 	return api.app.SetPluginKeyWithExpiry(api.id, key, value, expireInSeconds)
 }
 
+func (api *PluginAPI) KVSetWithOptions(key string, value []byte, options model.PluginKVSetOptions) (bool, *model.AppError) {
+	return api.app.SetPluginKeyWithOptions(api.id, key, value, options)
+}
+
+func (api *PluginAPI) KVSetMulti(values map[string][]byte) *model.AppError {
+	return api.app.SetPluginKeys(api.id, values)
+}
+
 func (api *PluginAPI) KVGet(key string) ([]byte, *model.AppError) {
 	return api.app.GetPluginKey(api.id, key)
 }
 
+func (api *PluginAPI) KVGetMulti(keys []string) (map[string][]byte, *model.AppError) {
+	return api.app.GetPluginKeys(api.id, keys)
+}
+
 func (api *PluginAPI) KVDelete(key string) *model.AppError {
 	return api.app.DeletePluginKey(api.id, key)
 }
@@ -689,6 +702,26 @@ func (api *PluginAPI) KVList(page, perPage int) ([]string, *model.AppError) {
 	return api.app.ListPluginKeys(api.id, page, perPage)
 }
 
+func (api *PluginAPI) KVListWithPrefix(prefix string, page, perPage int) ([]string, *model.AppError) {
+	return api.app.ListPluginKeysWithPrefix(api.id, prefix, page, perPage)
+}
+
+func (api *PluginAPI) CallPlugin(c *plugin.Context, pluginId string, method string, request []byte) ([]byte, *model.AppError) {
+	return api.app.CallPlugin(c, api.id, pluginId, method, request)
+}
+
+func (api *PluginAPI) PublishPluginEvent(event string, payload []byte) *model.AppError {
+	return api.app.PublishPluginEvent(api.id, event, payload)
+}
+
+func (api *PluginAPI) SubscribePluginEvent(event string) *model.AppError {
+	return api.app.SubscribePluginEvent(api.id, event)
+}
+
+func (api *PluginAPI) UnsubscribePluginEvent(event string) *model.AppError {
+	return api.app.UnsubscribePluginEvent(api.id, event)
+}
+
 func (api *PluginAPI) PublishWebSocketEvent(event string, payload map[string]interface{}, broadcast *model.WebsocketBroadcast) {
 	api.app.Publish(&model.WebSocketEvent{
 		Event:     fmt.Sprintf("custom_%v_%v", api.id, event),
@@ -783,3 +816,11 @@ func (api *PluginAPI) DeleteBotIconImage(userId string) *model.AppError {
 
 	return api.app.DeleteBotIconImage(userId)
 }
+
+func (api *PluginAPI) RegisterScheduledJob(job *model.PluginScheduledJob) *model.AppError {
+	return api.app.RegisterPluginScheduledJob(api.id, job)
+}
+
+func (api *PluginAPI) UnregisterScheduledJob(name string) *model.AppError {
+	return api.app.UnregisterPluginScheduledJob(api.id, name)
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/plugin"
+)
+
+// changedPluginSettings returns the ids of the plugins whose settings differ between the two
+// configurations.
+func changedPluginSettings(oldCfg, newCfg *model.Config) []string {
+	var pluginIds []string
+	for pluginId, settings := range newCfg.PluginSettings.Plugins {
+		if !reflect.DeepEqual(oldCfg.PluginSettings.Plugins[pluginId], settings) {
+			pluginIds = append(pluginIds, pluginId)
+		}
+	}
+	for pluginId := range oldCfg.PluginSettings.Plugins {
+		if _, ok := newCfg.PluginSettings.Plugins[pluginId]; !ok {
+			pluginIds = append(pluginIds, pluginId)
+		}
+	}
+
+	return pluginIds
+}
+
+// findPluginManifest returns the manifest of an installed plugin, or nil if it can't be found.
+func findPluginManifest(pluginsEnvironment *plugin.Environment, pluginId string) *model.Manifest {
+	for _, info := range pluginsEnvironment.Active() {
+		if info.Manifest != nil && info.Manifest.Id == pluginId {
+			return info.Manifest
+		}
+	}
+
+	available, err := pluginsEnvironment.Available()
+	if err != nil {
+		mlog.Error("Failed to read available plugins", mlog.Err(err))
+		return nil
+	}
+	for _, info := range available {
+		if info.Manifest != nil && info.Manifest.Id == pluginId {
+			return info.Manifest
+		}
+	}
+
+	return nil
+}
+
+// validatePluginSettings checks the settings of each plugin changed by the new configuration
+// against the plugin's settings schema, then lets active plugins reject their new settings
+// through the ConfigurationWillBeSaved hook. Settings of plugins that aren't installed are not
+// validated.
+func (a *App) validatePluginSettings(oldCfg, newCfg *model.Config) *model.AppError {
+	pluginsEnvironment := a.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return nil
+	}
+
+	for _, pluginId := range changedPluginSettings(oldCfg, newCfg) {
+		manifest := findPluginManifest(pluginsEnvironment, pluginId)
+		if manifest == nil {
+			continue
+		}
+
+		settings := newCfg.PluginSettings.Plugins[pluginId]
+		if err := manifest.ValidateSettings(settings); err != nil {
+			return err
+		}
+
+		if !pluginsEnvironment.IsActive(pluginId) {
+			continue
+		}
+
+		hooks, err := pluginsEnvironment.HooksForPlugin(pluginId)
+		if err != nil {
+			continue
+		}
+
+		if settings == nil {
+			settings = map[string]interface{}{}
+		}
+		if message := hooks.ConfigurationWillBeSaved(a.PluginContext(), settings); message != "" {
+			return model.NewAppError("validatePluginSettings", "app.plugin.configuration_will_be_saved.rejected.app_error", map[string]interface{}{"PluginId": pluginId, "Message": message}, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// savePluginConfigRevisions records a revision for each plugin whose settings were changed,
+// attributed to the user of the current session.
+func (a *App) savePluginConfigRevisions(oldCfg, newCfg *model.Config) {
+	for _, pluginId := range changedPluginSettings(oldCfg, newCfg) {
+		settings := newCfg.PluginSettings.Plugins[pluginId]
+
+		revision := &model.PluginConfigRevision{
+			PluginId:    pluginId,
+			UserId:      a.Session.UserId,
+			Settings:    model.StringInterface(settings),
+			ChangedKeys: model.PluginSettingsChangedKeys(oldCfg.PluginSettings.Plugins[pluginId], settings),
+		}
+		if _, err := a.Store().PluginConfigRevision().Save(revision); err != nil {
+			mlog.Error("Failed to save plugin configuration revision", mlog.String("plugin_id", pluginId), mlog.Err(err))
+		}
+	}
+}
+
+// GetPluginConfigRevisions returns a page of the plugin's configuration revisions, newest first.
+func (a *App) GetPluginConfigRevisions(pluginId string, page, perPage int) ([]*model.PluginConfigRevision, *model.AppError) {
+	return a.Store().PluginConfigRevision().GetForPlugin(pluginId, page*perPage, perPage)
+}
+
+// RollbackPluginConfig restores the plugin's settings to those saved by the given revision. The
+// restored settings are validated and recorded as a new revision like any other change.
+func (a *App) RollbackPluginConfig(pluginId, revisionId string) *model.AppError {
+	revision, err := a.Store().PluginConfigRevision().Get(revisionId)
+	if err != nil {
+		return err
+	}
+
+	if revision.PluginId != pluginId {
+		return model.NewAppError("RollbackPluginConfig", "app.plugin.rollback_config.plugin_id.app_error", nil, "plugin_id="+pluginId+", revision_id="+revisionId, http.StatusNotFound)
+	}
+
+	cfg := a.Config().Clone()
+	cfg.PluginSettings.Plugins[pluginId] = map[string]interface{}(revision.Settings)
+
+	return a.SaveConfig(cfg, true)
+}
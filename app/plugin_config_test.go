@@ -0,0 +1,95 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestPluginConfigValidationAndRevisions(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	setupPluginApiTest(t, `
+		package main
+
+		import (
+			"github.com/mattermost/mattermost-server/plugin"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) ConfigurationWillBeSaved(c *plugin.Context, newSettings map[string]interface{}) string {
+			if newSettings["port"] == "0" {
+				return "port 0 is reserved"
+			}
+			return ""
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`, `{"id": "testpluginconfig", "backend": {"executable": "backend.exe"}, "settings_schema": {
+		"settings": [
+			{"key": "Port", "type": "text", "regex": "^[0-9]+$", "required": true}
+		]
+	}}`, "testpluginconfig", th.App)
+
+	th.App.Session = model.Session{UserId: th.SystemAdminUser.Id}
+	defer func() { th.App.Session = model.Session{} }()
+
+	saveSettings := func(settings map[string]interface{}) *model.AppError {
+		cfg := th.App.Config().Clone()
+		cfg.PluginSettings.Plugins["testpluginconfig"] = settings
+		return th.App.SaveConfig(cfg, true)
+	}
+
+	t.Run("schema violations are rejected", func(t *testing.T) {
+		appErr := saveSettings(map[string]interface{}{"port": "http"})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.manifest.validate_settings.regex.app_error", appErr.Id)
+
+		appErr = saveSettings(map[string]interface{}{})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.manifest.validate_settings.required.app_error", appErr.Id)
+	})
+
+	t.Run("plugins can reject their settings", func(t *testing.T) {
+		appErr := saveSettings(map[string]interface{}{"port": "0"})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.plugin.configuration_will_be_saved.rejected.app_error", appErr.Id)
+	})
+
+	t.Run("revisions are recorded and can be rolled back", func(t *testing.T) {
+		require.Nil(t, saveSettings(map[string]interface{}{"port": "8065"}))
+		time.Sleep(time.Millisecond)
+		require.Nil(t, saveSettings(map[string]interface{}{"port": "8066"}))
+
+		revisions, appErr := th.App.GetPluginConfigRevisions("testpluginconfig", 0, 10)
+		require.Nil(t, appErr)
+		require.Len(t, revisions, 2)
+		assert.Equal(t, "8066", revisions[0].Settings["port"])
+		assert.Equal(t, th.SystemAdminUser.Id, revisions[0].UserId)
+		assert.Equal(t, model.StringArray{"port"}, revisions[0].ChangedKeys)
+		assert.Equal(t, "8065", revisions[1].Settings["port"])
+
+		appErr = th.App.RollbackPluginConfig("otherplugin", revisions[1].Id)
+		require.NotNil(t, appErr)
+
+		require.Nil(t, th.App.RollbackPluginConfig("testpluginconfig", revisions[1].Id))
+		assert.Equal(t, "8065", th.App.Config().PluginSettings.Plugins["testpluginconfig"]["port"])
+
+		revisions, appErr = th.App.GetPluginConfigRevisions("testpluginconfig", 0, 10)
+		require.Nil(t, appErr)
+		assert.Len(t, revisions, 3)
+	})
+}
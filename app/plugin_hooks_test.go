@@ -831,6 +831,207 @@ func TestUserHasBeenCreated(t *testing.T) {
 	require.Equal(t, "plugin-callback-success", user.Nickname)
 }
 
+func TestReactionHasBeenAddedAndRemoved(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	tearDown, pluginIds, _ := SetAppEnvironmentWithPlugins(t,
+		[]string{
+			`
+		package main
+
+		import (
+			"github.com/mattermost/mattermost-server/plugin"
+			"github.com/mattermost/mattermost-server/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) ReactionHasBeenAdded(c *plugin.Context, reaction *model.Reaction) {
+			p.API.KVSet("added", []byte(reaction.EmojiName))
+		}
+
+		func (p *MyPlugin) ReactionHasBeenRemoved(c *plugin.Context, reaction *model.Reaction) {
+			p.API.KVSet("removed", []byte(reaction.EmojiName))
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`}, th.App, th.App.NewPluginAPI)
+	defer tearDown()
+
+	reaction, err := th.App.SaveReactionForPost(&model.Reaction{
+		UserId:    th.BasicUser.Id,
+		PostId:    th.BasicPost.Id,
+		EmojiName: "smile",
+	})
+	require.Nil(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	value, err := th.App.GetPluginKey(pluginIds[0], "added")
+	require.Nil(t, err)
+	require.Equal(t, "smile", string(value))
+
+	err = th.App.DeleteReactionForPost(reaction)
+	require.Nil(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	value, err = th.App.GetPluginKey(pluginIds[0], "removed")
+	require.Nil(t, err)
+	require.Equal(t, "smile", string(value))
+}
+
+func TestPostHasBeenDeleted(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	tearDown, pluginIds, _ := SetAppEnvironmentWithPlugins(t,
+		[]string{
+			`
+		package main
+
+		import (
+			"github.com/mattermost/mattermost-server/plugin"
+			"github.com/mattermost/mattermost-server/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) PostHasBeenDeleted(c *plugin.Context, post *model.Post) {
+			p.API.KVSet("deleted", []byte(post.Id))
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`}, th.App, th.App.NewPluginAPI)
+	defer tearDown()
+
+	post := th.CreatePost(th.BasicChannel)
+	_, err := th.App.DeletePost(post.Id, th.BasicUser.Id)
+	require.Nil(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	value, err := th.App.GetPluginKey(pluginIds[0], "deleted")
+	require.Nil(t, err)
+	require.Equal(t, post.Id, string(value))
+}
+
+func TestChannelHasBeenUpdatedAndArchived(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	tearDown, pluginIds, _ := SetAppEnvironmentWithPlugins(t,
+		[]string{
+			`
+		package main
+
+		import (
+			"github.com/mattermost/mattermost-server/plugin"
+			"github.com/mattermost/mattermost-server/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) ChannelHasBeenUpdated(c *plugin.Context, channel *model.Channel) {
+			p.API.KVSet("updated", []byte(channel.DisplayName))
+		}
+
+		func (p *MyPlugin) ChannelHasBeenArchived(c *plugin.Context, channel *model.Channel, actor *model.User) {
+			p.API.KVSet("archived", []byte(channel.Id+":"+actor.Id))
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`}, th.App, th.App.NewPluginAPI)
+	defer tearDown()
+
+	channel := th.CreateChannel(th.BasicTeam)
+	channel.DisplayName = "Updated by test"
+	_, err := th.App.UpdateChannel(channel)
+	require.Nil(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	value, err := th.App.GetPluginKey(pluginIds[0], "updated")
+	require.Nil(t, err)
+	require.Equal(t, "Updated by test", string(value))
+
+	err = th.App.DeleteChannel(channel, th.BasicUser.Id)
+	require.Nil(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	value, err = th.App.GetPluginKey(pluginIds[0], "archived")
+	require.Nil(t, err)
+	require.Equal(t, channel.Id+":"+th.BasicUser.Id, string(value))
+}
+
+func TestUserHasBeenUpdatedAndDeactivated(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	tearDown, pluginIds, _ := SetAppEnvironmentWithPlugins(t,
+		[]string{
+			`
+		package main
+
+		import (
+			"github.com/mattermost/mattermost-server/plugin"
+			"github.com/mattermost/mattermost-server/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) UserHasBeenUpdated(c *plugin.Context, newUser, oldUser *model.User) {
+			p.API.KVSet("updated", []byte(oldUser.Nickname+":"+newUser.Nickname))
+		}
+
+		func (p *MyPlugin) UserHasBeenDeactivated(c *plugin.Context, user *model.User) {
+			p.API.KVSet("deactivated", []byte(user.Id))
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`}, th.App, th.App.NewPluginAPI)
+	defer tearDown()
+
+	user := th.CreateUser()
+	oldNickname := user.Nickname
+	user.Nickname = "updated-nickname"
+	user, err := th.App.UpdateUser(user, false)
+	require.Nil(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	value, err := th.App.GetPluginKey(pluginIds[0], "updated")
+	require.Nil(t, err)
+	require.Equal(t, oldNickname+":updated-nickname", string(value))
+
+	_, err = th.App.UpdateActive(user, false)
+	require.Nil(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	value, err = th.App.GetPluginKey(pluginIds[0], "deactivated")
+	require.Nil(t, err)
+	require.Equal(t, user.Id, string(value))
+}
+
 func TestErrorString(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -0,0 +1,80 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/plugin"
+)
+
+// CallPlugin invokes a method declared by the target plugin on behalf of the source plugin, which
+// makes the call while handling a hook with the given context.
+func (a *App) CallPlugin(c *plugin.Context, sourcePluginId, targetPluginId, method string, request []byte) ([]byte, *model.AppError) {
+	pluginsEnvironment := a.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return nil, model.NewAppError("CallPlugin", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	hooks, chain, err := pluginsEnvironment.PreparePluginCall(c, sourcePluginId, targetPluginId, method)
+	switch err {
+	case nil:
+	case plugin.ErrPluginNotActive:
+		return nil, model.NewAppError("CallPlugin", "app.plugin.call_plugin.not_active.app_error", nil, "plugin_id="+targetPluginId, http.StatusServiceUnavailable)
+	case plugin.ErrPluginMethodNotDeclared:
+		return nil, model.NewAppError("CallPlugin", "app.plugin.call_plugin.method_not_declared.app_error", nil, "plugin_id="+targetPluginId+", method="+method, http.StatusNotFound)
+	case plugin.ErrCircularPluginCall:
+		return nil, model.NewAppError("CallPlugin", "app.plugin.call_plugin.circular.app_error", nil, "source_plugin_id="+sourcePluginId+", plugin_id="+targetPluginId, http.StatusConflict)
+	default:
+		return nil, model.NewAppError("CallPlugin", "app.plugin.call_plugin.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	context := a.PluginContext()
+	context.PluginCallChain = chain
+
+	return hooks.OnPluginRequest(context, sourcePluginId, method, request)
+}
+
+// PublishPluginEvent delivers an event from the source plugin to the plugins subscribed to it.
+func (a *App) PublishPluginEvent(sourcePluginId, event string, payload []byte) *model.AppError {
+	pluginsEnvironment := a.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return model.NewAppError("PublishPluginEvent", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if event == "" {
+		return model.NewAppError("PublishPluginEvent", "app.plugin.plugin_event.invalid_event.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	pluginsEnvironment.PublishPluginEvent(a.PluginContext(), sourcePluginId, event, payload)
+
+	return nil
+}
+
+func (a *App) SubscribePluginEvent(pluginId, event string) *model.AppError {
+	pluginsEnvironment := a.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return model.NewAppError("SubscribePluginEvent", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if event == "" {
+		return model.NewAppError("SubscribePluginEvent", "app.plugin.plugin_event.invalid_event.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	pluginsEnvironment.SubscribePluginEvent(pluginId, event)
+
+	return nil
+}
+
+func (a *App) UnsubscribePluginEvent(pluginId, event string) *model.AppError {
+	pluginsEnvironment := a.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return model.NewAppError("UnsubscribePluginEvent", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	pluginsEnvironment.UnsubscribePluginEvent(pluginId, event)
+
+	return nil
+}
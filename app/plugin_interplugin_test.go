@@ -0,0 +1,181 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/plugin"
+	"github.com/mattermost/mattermost-server/utils"
+)
+
+func TestInterPluginCommunication(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	pluginDir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(pluginDir)
+	webappPluginDir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(webappPluginDir)
+
+	env, err := plugin.NewEnvironment(th.App.NewPluginAPI, pluginDir, webappPluginDir, th.App.Log)
+	require.NoError(t, err)
+	th.App.SetPluginsEnvironment(env)
+
+	plugins := map[string]string{
+		"testcallee": `
+			package main
+
+			import (
+				"github.com/mattermost/mattermost-server/model"
+				"github.com/mattermost/mattermost-server/plugin"
+			)
+
+			type MyPlugin struct {
+				plugin.MattermostPlugin
+			}
+
+			func (p *MyPlugin) OnActivate() error {
+				if err := p.API.SubscribePluginEvent("ping"); err != nil {
+					return err
+				}
+				return nil
+			}
+
+			func (p *MyPlugin) OnPluginRequest(c *plugin.Context, sourcePluginId string, method string, request []byte) ([]byte, *model.AppError) {
+				switch method {
+				case "echo":
+					return append([]byte(sourcePluginId+":"), request...), nil
+				case "callback":
+					if _, err := p.API.CallPlugin(c, sourcePluginId, "echo", request); err != nil {
+						return []byte(err.Id), nil
+					}
+					return []byte("no error"), nil
+				}
+				return nil, nil
+			}
+
+			func (p *MyPlugin) OnPluginEvent(c *plugin.Context, sourcePluginId string, event string, payload []byte) {
+				p.API.KVSet(event, append([]byte(sourcePluginId+":"), payload...))
+			}
+
+			func main() {
+				plugin.ClientMain(&MyPlugin{})
+			}
+		`,
+		"testcaller": `
+			package main
+
+			import (
+				"github.com/mattermost/mattermost-server/model"
+				"github.com/mattermost/mattermost-server/plugin"
+			)
+
+			type MyPlugin struct {
+				plugin.MattermostPlugin
+			}
+
+			func (p *MyPlugin) OnPluginRequest(c *plugin.Context, sourcePluginId string, method string, request []byte) ([]byte, *model.AppError) {
+				return request, nil
+			}
+
+			func main() {
+				plugin.ClientMain(&MyPlugin{})
+			}
+		`,
+	}
+
+	for pluginId, code := range plugins {
+		backend := filepath.Join(pluginDir, pluginId, "backend.exe")
+		utils.CompileGo(t, code, backend)
+
+		err = ioutil.WriteFile(filepath.Join(pluginDir, pluginId, "plugin.json"), []byte(`{"id": "`+pluginId+`", "server": {"executable": "backend.exe", "methods": ["echo", "callback"]}}`), 0600)
+		require.NoError(t, err)
+
+		_, _, err = env.Activate(pluginId)
+		require.NoError(t, err)
+	}
+
+	t.Run("call a declared method", func(t *testing.T) {
+		response, appErr := th.App.CallPlugin(nil, "testcaller", "testcallee", "echo", []byte("hello"))
+		require.Nil(t, appErr)
+		assert.Equal(t, "testcaller:hello", string(response))
+	})
+
+	t.Run("call an undeclared method", func(t *testing.T) {
+		_, appErr := th.App.CallPlugin(nil, "testcaller", "testcallee", "unknown", nil)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+
+	t.Run("call an inactive plugin", func(t *testing.T) {
+		_, appErr := th.App.CallPlugin(nil, "testcaller", "missing", "echo", nil)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusServiceUnavailable, appErr.StatusCode)
+	})
+
+	t.Run("circular calls are rejected", func(t *testing.T) {
+		response, appErr := th.App.CallPlugin(nil, "testcaller", "testcallee", "callback", []byte("hello"))
+		require.Nil(t, appErr)
+		assert.Equal(t, "app.plugin.call_plugin.circular.app_error", string(response))
+
+		_, appErr = th.App.CallPlugin(nil, "testcallee", "testcallee", "echo", nil)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusConflict, appErr.StatusCode)
+
+		_, appErr = th.App.CallPlugin(&plugin.Context{PluginCallChain: []string{"testcallee"}}, "testcaller", "testcallee", "echo", nil)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusConflict, appErr.StatusCode)
+	})
+
+	t.Run("concurrent calls in both directions are allowed", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_, appErr := th.App.CallPlugin(nil, "testcaller", "testcallee", "echo", nil)
+				assert.Nil(t, appErr)
+			}()
+			go func() {
+				defer wg.Done()
+				_, appErr := th.App.CallPlugin(nil, "testcallee", "testcaller", "echo", nil)
+				assert.Nil(t, appErr)
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("publish an event", func(t *testing.T) {
+		require.Nil(t, th.App.PublishPluginEvent("testcaller", "ping", []byte("payload")))
+
+		var value []byte
+		for i := 0; i < 50 && value == nil; i++ {
+			time.Sleep(100 * time.Millisecond)
+			value, _ = th.App.GetPluginKey("testcallee", "ping")
+		}
+		assert.Equal(t, "testcaller:payload", string(value))
+
+		// Deactivation ends the subscription
+		env.Deactivate("testcallee")
+		require.Nil(t, th.App.DeletePluginKey("testcallee", "ping"))
+		require.Nil(t, th.App.PublishPluginEvent("testcaller", "ping", []byte("payload")))
+
+		time.Sleep(500 * time.Millisecond)
+		value, appErr := th.App.GetPluginKey("testcallee", "ping")
+		require.Nil(t, appErr)
+		assert.Nil(t, value)
+	})
+}
@@ -34,13 +34,13 @@ func (a *App) SetPluginKeyWithExpiry(pluginId string, key string, value []byte,
 		ExpireAt: expireInSeconds,
 	}
 
-	if _, err := a.Srv.Store.Plugin().SaveOrUpdate(kv); err != nil {
+	if _, err := a.Store().Plugin().SaveOrUpdate(kv); err != nil {
 		mlog.Error("Failed to set plugin key value", mlog.String("plugin_id", pluginId), mlog.String("key", key), mlog.Err(err))
 		return err
 	}
 
 	// Clean up a previous entry using the hashed key, if it exists.
-	if err := a.Srv.Store.Plugin().Delete(pluginId, getKeyHash(key)); err != nil {
+	if err := a.Store().Plugin().Delete(pluginId, getKeyHash(key)); err != nil {
 		mlog.Error("Failed to clean up previously hashed plugin key value", mlog.String("plugin_id", pluginId), mlog.String("key", key), mlog.Err(err))
 	}
 
@@ -54,14 +54,14 @@ func (a *App) CompareAndSetPluginKey(pluginId string, key string, oldValue, newV
 		Value:    newValue,
 	}
 
-	updated, err := a.Srv.Store.Plugin().CompareAndSet(kv, oldValue)
+	updated, err := a.Store().Plugin().CompareAndSet(kv, oldValue)
 	if err != nil {
 		mlog.Error("Failed to compare and set plugin key value", mlog.String("plugin_id", pluginId), mlog.String("key", key), mlog.Err(err))
 		return updated, err
 	}
 
 	// Clean up a previous entry using the hashed key, if it exists.
-	if err := a.Srv.Store.Plugin().Delete(pluginId, getKeyHash(key)); err != nil {
+	if err := a.Store().Plugin().Delete(pluginId, getKeyHash(key)); err != nil {
 		mlog.Error("Failed to clean up previously hashed plugin key value", mlog.String("plugin_id", pluginId), mlog.String("key", key), mlog.Err(err))
 	}
 
@@ -74,22 +74,64 @@ func (a *App) CompareAndDeletePluginKey(pluginId string, key string, oldValue []
 		Key:      key,
 	}
 
-	deleted, err := a.Srv.Store.Plugin().CompareAndDelete(kv, oldValue)
+	deleted, err := a.Store().Plugin().CompareAndDelete(kv, oldValue)
 	if err != nil {
 		mlog.Error("Failed to compare and delete plugin key value", mlog.String("plugin_id", pluginId), mlog.String("key", key), mlog.Err(err))
 		return deleted, err
 	}
 
 	// Clean up a previous entry using the hashed key, if it exists.
-	if err := a.Srv.Store.Plugin().Delete(pluginId, getKeyHash(key)); err != nil {
+	if err := a.Store().Plugin().Delete(pluginId, getKeyHash(key)); err != nil {
 		mlog.Error("Failed to clean up previously hashed plugin key value", mlog.String("plugin_id", pluginId), mlog.String("key", key), mlog.Err(err))
 	}
 
 	return deleted, nil
 }
 
+func (a *App) SetPluginKeyWithOptions(pluginId string, key string, value []byte, options model.PluginKVSetOptions) (bool, *model.AppError) {
+	updated, err := a.Store().Plugin().SetWithOptions(pluginId, key, value, options)
+	if err != nil {
+		mlog.Error("Failed to set plugin key value with options", mlog.String("plugin_id", pluginId), mlog.String("key", key), mlog.Err(err))
+		return updated, err
+	}
+
+	if !updated {
+		return false, nil
+	}
+
+	// Clean up a previous entry using the hashed key, if it exists.
+	if err := a.Store().Plugin().Delete(pluginId, getKeyHash(key)); err != nil {
+		mlog.Error("Failed to clean up previously hashed plugin key value", mlog.String("plugin_id", pluginId), mlog.String("key", key), mlog.Err(err))
+	}
+
+	return updated, nil
+}
+
+// SetPluginKeys saves all of the given values in a single transaction. A nil value removes the key.
+func (a *App) SetPluginKeys(pluginId string, values map[string][]byte) *model.AppError {
+	if len(values) > model.KEY_VALUE_MULTI_MAX_KEYS {
+		return model.NewAppError("SetPluginKeys", "app.plugin.kv_multi.too_many_keys.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	kvs := make([]*model.PluginKeyValue, 0, len(values))
+	for key, value := range values {
+		kvs = append(kvs, &model.PluginKeyValue{
+			PluginId: pluginId,
+			Key:      key,
+			Value:    value,
+		})
+	}
+
+	if err := a.Store().Plugin().SetMulti(kvs); err != nil {
+		mlog.Error("Failed to set plugin key values", mlog.String("plugin_id", pluginId), mlog.Err(err))
+		return err
+	}
+
+	return nil
+}
+
 func (a *App) GetPluginKey(pluginId string, key string) ([]byte, *model.AppError) {
-	if kv, err := a.Srv.Store.Plugin().Get(pluginId, key); err == nil {
+	if kv, err := a.Store().Plugin().Get(pluginId, key); err == nil {
 		return kv.Value, nil
 	} else if err.StatusCode != http.StatusNotFound {
 		mlog.Error("Failed to query plugin key value", mlog.String("plugin_id", pluginId), mlog.String("key", key), mlog.Err(err))
@@ -97,7 +139,7 @@ func (a *App) GetPluginKey(pluginId string, key string) ([]byte, *model.AppError
 	}
 
 	// Lookup using the hashed version of the key for keys written prior to v5.6.
-	if kv, err := a.Srv.Store.Plugin().Get(pluginId, getKeyHash(key)); err == nil {
+	if kv, err := a.Store().Plugin().Get(pluginId, getKeyHash(key)); err == nil {
 		return kv.Value, nil
 	} else if err.StatusCode != http.StatusNotFound {
 		mlog.Error("Failed to query plugin key value using hashed key", mlog.String("plugin_id", pluginId), mlog.String("key", key), mlog.Err(err))
@@ -107,14 +149,49 @@ func (a *App) GetPluginKey(pluginId string, key string) ([]byte, *model.AppError
 	return nil, nil
 }
 
+// GetPluginKeys returns the values for the given keys. Keys that don't exist are omitted.
+func (a *App) GetPluginKeys(pluginId string, keys []string) (map[string][]byte, *model.AppError) {
+	if len(keys) > model.KEY_VALUE_MULTI_MAX_KEYS {
+		return nil, model.NewAppError("GetPluginKeys", "app.plugin.kv_multi.too_many_keys.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	// Include the hashed version of each key for keys written prior to v5.6.
+	hashedKeys := make(map[string]string, len(keys))
+	lookup := make([]string, 0, len(keys)*2)
+	for _, key := range keys {
+		hashed := getKeyHash(key)
+		hashedKeys[hashed] = key
+		lookup = append(lookup, key, hashed)
+	}
+
+	kvs, err := a.Store().Plugin().GetMulti(pluginId, lookup)
+	if err != nil {
+		mlog.Error("Failed to query plugin key values", mlog.String("plugin_id", pluginId), mlog.Err(err))
+		return nil, err
+	}
+
+	values := make(map[string][]byte, len(kvs))
+	for _, kv := range kvs {
+		if key, ok := hashedKeys[kv.Key]; ok {
+			if _, exists := values[key]; !exists {
+				values[key] = kv.Value
+			}
+			continue
+		}
+		values[kv.Key] = kv.Value
+	}
+
+	return values, nil
+}
+
 func (a *App) DeletePluginKey(pluginId string, key string) *model.AppError {
-	if err := a.Srv.Store.Plugin().Delete(pluginId, getKeyHash(key)); err != nil {
+	if err := a.Store().Plugin().Delete(pluginId, getKeyHash(key)); err != nil {
 		mlog.Error("Failed to delete plugin key value", mlog.String("plugin_id", pluginId), mlog.String("key", key), mlog.Err(err))
 		return err
 	}
 
 	// Also delete the key without hashing
-	if err := a.Srv.Store.Plugin().Delete(pluginId, key); err != nil {
+	if err := a.Store().Plugin().Delete(pluginId, key); err != nil {
 		mlog.Error("Failed to delete plugin key value using hashed key", mlog.String("plugin_id", pluginId), mlog.String("key", key), mlog.Err(err))
 		return err
 	}
@@ -123,7 +200,7 @@ func (a *App) DeletePluginKey(pluginId string, key string) *model.AppError {
 }
 
 func (a *App) DeleteAllKeysForPlugin(pluginId string) *model.AppError {
-	if err := a.Srv.Store.Plugin().DeleteAllForPlugin(pluginId); err != nil {
+	if err := a.Store().Plugin().DeleteAllForPlugin(pluginId); err != nil {
 		mlog.Error("Failed to delete all plugin key values", mlog.String("plugin_id", pluginId), mlog.Err(err))
 		return err
 	}
@@ -136,7 +213,7 @@ func (a *App) DeleteAllExpiredPluginKeys() *model.AppError {
 		return nil
 	}
 
-	if err := a.Srv.Store.Plugin().DeleteAllExpired(); err != nil {
+	if err := a.Store().Plugin().DeleteAllExpired(); err != nil {
 		mlog.Error("Failed to delete all expired plugin key values", mlog.Err(err))
 		return err
 	}
@@ -145,7 +222,7 @@ func (a *App) DeleteAllExpiredPluginKeys() *model.AppError {
 }
 
 func (a *App) ListPluginKeys(pluginId string, page, perPage int) ([]string, *model.AppError) {
-	data, err := a.Srv.Store.Plugin().List(pluginId, page*perPage, perPage)
+	data, err := a.Store().Plugin().List(pluginId, page*perPage, perPage)
 
 	if err != nil {
 		mlog.Error("Failed to list plugin key values", mlog.Int("page", page), mlog.Int("perPage", perPage), mlog.Err(err))
@@ -154,3 +231,14 @@ func (a *App) ListPluginKeys(pluginId string, page, perPage int) ([]string, *mod
 
 	return data, nil
 }
+
+func (a *App) ListPluginKeysWithPrefix(pluginId, prefix string, page, perPage int) ([]string, *model.AppError) {
+	data, err := a.Store().Plugin().ListWithPrefix(pluginId, prefix, page*perPage, perPage)
+
+	if err != nil {
+		mlog.Error("Failed to list plugin key values with prefix", mlog.String("prefix", prefix), mlog.Int("page", page), mlog.Int("perPage", perPage), mlog.Err(err))
+		return nil, err
+	}
+
+	return data, nil
+}
@@ -0,0 +1,231 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/blang/semver"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	// marketplaceDownloadTimeout is deliberately generous to allow large plugins to be downloaded over slow connections.
+	marketplaceDownloadTimeout = 60 * time.Minute
+	marketplacePluginMaxSize   = 50 * 1024 * 1024
+	marketplaceCatalogMaxSize  = 10 * 1024 * 1024
+)
+
+func (a *App) checkMarketplaceEnabled(where string) *model.AppError {
+	if a.GetPluginsEnvironment() == nil {
+		return model.NewAppError(where, "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if !*a.Config().PluginSettings.EnableMarketplace {
+		return model.NewAppError(where, "app.plugin.marketplace.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	return nil
+}
+
+// getInstalledPluginVersions returns the version of every installed plugin keyed by plugin id.
+func (a *App) getInstalledPluginVersions() (map[string]string, *model.AppError) {
+	pluginsEnvironment := a.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return nil, model.NewAppError("getInstalledPluginVersions", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	bundles, err := pluginsEnvironment.Available()
+	if err != nil {
+		return nil, model.NewAppError("getInstalledPluginVersions", "app.plugin.sync.read_local_folder.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	versions := make(map[string]string, len(bundles))
+	for _, bundle := range bundles {
+		if bundle.Manifest != nil {
+			versions[bundle.Manifest.Id] = bundle.Manifest.Version
+		}
+	}
+
+	return versions, nil
+}
+
+func (a *App) fetchMarketplaceCatalog() ([]*model.MarketplacePlugin, *model.AppError) {
+	resp, err := a.HTTPService.MakeClient(true).Get(*a.Config().PluginSettings.MarketplaceUrl)
+	if err != nil {
+		return nil, model.NewAppError("fetchMarketplaceCatalog", "app.plugin.marketplace.request.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, model.NewAppError("fetchMarketplaceCatalog", "app.plugin.marketplace.request.app_error", nil, "status="+resp.Status, http.StatusInternalServerError)
+	}
+
+	plugins := model.MarketplacePluginsFromJson(io.LimitReader(resp.Body, marketplaceCatalogMaxSize))
+	if plugins == nil {
+		return nil, model.NewAppError("fetchMarketplaceCatalog", "app.plugin.marketplace.parse.app_error", nil, "", http.StatusInternalServerError)
+	}
+
+	return plugins, nil
+}
+
+// GetMarketplacePlugins returns the plugins listed in the configured marketplace catalog along with the
+// version of each that is currently installed, if any.
+func (a *App) GetMarketplacePlugins() ([]*model.MarketplacePlugin, *model.AppError) {
+	if err := a.checkMarketplaceEnabled("GetMarketplacePlugins"); err != nil {
+		return nil, err
+	}
+
+	plugins, err := a.fetchMarketplaceCatalog()
+	if err != nil {
+		return nil, err
+	}
+
+	installed, err := a.getInstalledPluginVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range plugins {
+		p.InstalledVersion = installed[p.Id]
+	}
+
+	return plugins, nil
+}
+
+// getMarketplacePluginVersion finds the requested version of a plugin in the catalog, defaulting to the
+// latest version compatible with this server.
+func (a *App) getMarketplacePluginVersion(request *model.MarketplacePluginRequest) (*model.MarketplacePluginVersion, *model.AppError) {
+	plugins, err := a.fetchMarketplaceCatalog()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range plugins {
+		if p.Id != request.Id {
+			continue
+		}
+
+		if request.Version == "" {
+			version := p.LatestCompatibleVersion(model.CurrentVersion)
+			if version == nil {
+				return nil, model.NewAppError("getMarketplacePluginVersion", "app.plugin.marketplace.incompatible.app_error", nil, "plugin_id="+request.Id, http.StatusBadRequest)
+			}
+			return version, nil
+		}
+
+		version := p.GetVersion(request.Version)
+		if version == nil {
+			break
+		}
+
+		if !version.IsCompatible(model.CurrentVersion) {
+			return nil, model.NewAppError("getMarketplacePluginVersion", "app.plugin.marketplace.incompatible.app_error", nil, "plugin_id="+request.Id+", version="+request.Version, http.StatusBadRequest)
+		}
+
+		return version, nil
+	}
+
+	return nil, model.NewAppError("getMarketplacePluginVersion", "app.plugin.marketplace.not_found.app_error", nil, "plugin_id="+request.Id+", version="+request.Version, http.StatusNotFound)
+}
+
+func (a *App) downloadMarketplacePlugin(version *model.MarketplacePluginVersion) ([]byte, *model.AppError) {
+	u, err := url.ParseRequestURI(version.DownloadURL)
+	if err != nil || !model.IsValidHttpUrl(version.DownloadURL) {
+		return nil, model.NewAppError("downloadMarketplacePlugin", "api.plugin.install.invalid_url.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !*a.Config().PluginSettings.AllowInsecureDownloadUrl && u.Scheme != "https" {
+		return nil, model.NewAppError("downloadMarketplacePlugin", "api.plugin.install.insecure_url.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	client := a.HTTPService.MakeClient(true)
+	client.Timeout = marketplaceDownloadTimeout
+
+	resp, err := client.Get(version.DownloadURL)
+	if err != nil {
+		return nil, model.NewAppError("downloadMarketplacePlugin", "api.plugin.install.download_failed.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, model.NewAppError("downloadMarketplacePlugin", "api.plugin.install.download_failed.app_error", nil, "status="+resp.Status, http.StatusBadRequest)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, marketplacePluginMaxSize+1))
+	if err != nil {
+		return nil, model.NewAppError("downloadMarketplacePlugin", "api.plugin.install.reading_stream_failed.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	if len(data) > marketplacePluginMaxSize {
+		return nil, model.NewAppError("downloadMarketplacePlugin", "app.plugin.marketplace.too_large.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return data, nil
+}
+
+func (a *App) installMarketplacePluginVersion(version *model.MarketplacePluginVersion, replace bool) (*model.Manifest, *model.AppError) {
+	data, err := a.downloadMarketplacePlugin(version)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.VerifyPluginSignature(data, version.Signature); err != nil {
+		return nil, err
+	}
+
+	return a.installPlugin(bytes.NewReader(data), replace)
+}
+
+// InstallMarketplacePlugin downloads a plugin listed in the marketplace catalog, verifies it was signed by a
+// trusted key and installs it. It fails if the plugin is already installed.
+func (a *App) InstallMarketplacePlugin(request *model.MarketplacePluginRequest) (*model.Manifest, *model.AppError) {
+	if err := a.checkMarketplaceEnabled("InstallMarketplacePlugin"); err != nil {
+		return nil, err
+	}
+
+	version, err := a.getMarketplacePluginVersion(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.installMarketplacePluginVersion(version, false)
+}
+
+// UpgradeMarketplacePlugin replaces an installed plugin with a newer version from the marketplace catalog.
+// Whether the plugin is enabled is left unchanged.
+func (a *App) UpgradeMarketplacePlugin(request *model.MarketplacePluginRequest) (*model.Manifest, *model.AppError) {
+	if err := a.checkMarketplaceEnabled("UpgradeMarketplacePlugin"); err != nil {
+		return nil, err
+	}
+
+	installed, err := a.getInstalledPluginVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	installedVersion, ok := installed[request.Id]
+	if !ok {
+		return nil, model.NewAppError("UpgradeMarketplacePlugin", "app.plugin.not_installed.app_error", nil, "plugin_id="+request.Id, http.StatusBadRequest)
+	}
+
+	version, err := a.getMarketplacePluginVersion(request)
+	if err != nil {
+		return nil, err
+	}
+
+	current, parseErr := semver.Parse(installedVersion)
+	target, targetErr := semver.Parse(version.Version)
+	if parseErr == nil && targetErr == nil && !target.GT(current) {
+		return nil, model.NewAppError("UpgradeMarketplacePlugin", "app.plugin.marketplace.not_newer.app_error", map[string]interface{}{"Version": installedVersion}, "plugin_id="+request.Id, http.StatusBadRequest)
+	}
+
+	return a.installMarketplacePluginVersion(version, true)
+}
@@ -0,0 +1,256 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/plugin"
+)
+
+// pluginScheduledJobLastRunKeyPrefix prefixes the name of a job in the key of its plugin's key value store
+// recording when the job was last scheduled.
+const pluginScheduledJobLastRunKeyPrefix = plugin.INTERNAL_KEY_PREFIX + "job_"
+
+// PluginJobScheduler schedules a job registered by a plugin through the jobs framework. All plugin jobs
+// share the JOB_TYPE_PLUGIN_SCHEDULED job type and are told apart by the plugin id and job name
+// recorded in the job data.
+type PluginJobScheduler struct {
+	app      *App
+	PluginId string
+	Job      *model.PluginScheduledJob
+}
+
+func (scheduler *PluginJobScheduler) Name() string {
+	return "PluginJobScheduler:" + scheduler.PluginId + "/" + scheduler.Job.Name
+}
+
+func (scheduler *PluginJobScheduler) JobType() string {
+	return model.JOB_TYPE_PLUGIN_SCHEDULED
+}
+
+func (scheduler *PluginJobScheduler) Enabled(cfg *model.Config) bool {
+	return *cfg.PluginSettings.Enable
+}
+
+func (scheduler *PluginJobScheduler) matches(job *model.Job) bool {
+	return job.Type == model.JOB_TYPE_PLUGIN_SCHEDULED &&
+		job.Data[model.PLUGIN_SCHEDULED_JOB_DATA_PLUGIN_ID] == scheduler.PluginId &&
+		job.Data[model.PLUGIN_SCHEDULED_JOB_DATA_NAME] == scheduler.Job.Name
+}
+
+func (scheduler *PluginJobScheduler) lastRunKey() string {
+	return pluginScheduledJobLastRunKeyPrefix + scheduler.Job.Name
+}
+
+// lastRun returns when this job was last scheduled, or the zero time if it never was. It is read from the
+// master, as the job would otherwise be scheduled again while the replicas catch up.
+func (scheduler *PluginJobScheduler) lastRun() (time.Time, *model.AppError) {
+	kv, err := scheduler.app.Srv.Store.MasterOnly().Plugin().Get(scheduler.PluginId, scheduler.lastRunKey())
+	if err != nil {
+		if err.StatusCode == http.StatusNotFound {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+
+	lastRun, parseErr := strconv.ParseInt(string(kv.Value), 10, 64)
+	if parseErr != nil {
+		return time.Time{}, model.NewAppError("PluginJobScheduler.lastRun", "app.plugin.scheduled_job.last_run.app_error", nil, "plugin_id="+scheduler.PluginId+", name="+scheduler.Job.Name+", "+parseErr.Error(), http.StatusInternalServerError)
+	}
+
+	return time.Unix(0, lastRun*int64(time.Millisecond)), nil
+}
+
+// recordRun records when this job was last scheduled, as its own jobs may be far behind those of the other
+// plugin jobs in the job history.
+func (scheduler *PluginJobScheduler) recordRun(job *model.Job) *model.AppError {
+	_, err := scheduler.app.Srv.Store.Plugin().SaveOrUpdate(&model.PluginKeyValue{
+		PluginId: scheduler.PluginId,
+		Key:      scheduler.lastRunKey(),
+		Value:    []byte(strconv.FormatInt(job.CreateAt, 10)),
+	})
+	return err
+}
+
+func (scheduler *PluginJobScheduler) hasPendingJob() (bool, *model.AppError) {
+	jobs, err := scheduler.app.Srv.Store.Job().GetAllByStatus(model.JOB_STATUS_PENDING)
+	if err != nil {
+		return false, err
+	}
+
+	for _, job := range jobs {
+		if scheduler.matches(job) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// NextScheduleTime ignores the pending and last successful jobs passed in since they're shared by all plugin
+// jobs, and looks up when this job itself last ran instead.
+func (scheduler *PluginJobScheduler) NextScheduleTime(cfg *model.Config, now time.Time, pendingJobs bool, lastSuccessfulJob *model.Job) *time.Time {
+	lastRun, err := scheduler.lastRun()
+	if err != nil {
+		mlog.Error("Failed to get the last run of plugin scheduled job", mlog.String("plugin_id", scheduler.PluginId), mlog.String("name", scheduler.Job.Name), mlog.Err(err))
+		return nil
+	}
+
+	nextTime := scheduler.Job.NextRunTime(now, lastRun)
+	if nextTime.IsZero() {
+		return nil
+	}
+
+	return &nextTime
+}
+
+// ScheduleJob creates a job unless the previous one is still waiting to be run, so that a slow job doesn't
+// pile up behind itself.
+func (scheduler *PluginJobScheduler) ScheduleJob(cfg *model.Config, pendingJobs bool, lastSuccessfulJob *model.Job) (*model.Job, *model.AppError) {
+	pending, err := scheduler.hasPendingJob()
+	if err != nil {
+		return nil, err
+	}
+
+	if pending {
+		return nil, nil
+	}
+
+	job, err := scheduler.app.Srv.Jobs.CreateJob(model.JOB_TYPE_PLUGIN_SCHEDULED, map[string]string{
+		model.PLUGIN_SCHEDULED_JOB_DATA_PLUGIN_ID: scheduler.PluginId,
+		model.PLUGIN_SCHEDULED_JOB_DATA_NAME:      scheduler.Job.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := scheduler.recordRun(job); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// RegisterPluginScheduledJob schedules a job for the plugin, replacing any job it previously registered with
+// the same name.
+func (a *App) RegisterPluginScheduledJob(pluginId string, job *model.PluginScheduledJob) *model.AppError {
+	if err := job.IsValid(); err != nil {
+		return err
+	}
+
+	scheduler := &PluginJobScheduler{
+		app:      a,
+		PluginId: pluginId,
+		Job: &model.PluginScheduledJob{
+			Name:     job.Name,
+			Schedule: job.Schedule,
+			Interval: job.Interval,
+		},
+	}
+
+	a.Srv.pluginScheduledJobsLock.Lock()
+	defer a.Srv.pluginScheduledJobsLock.Unlock()
+
+	remaining := []*PluginJobScheduler{}
+	for _, s := range a.Srv.pluginScheduledJobs {
+		if s.Name() != scheduler.Name() {
+			remaining = append(remaining, s)
+		}
+	}
+	a.Srv.pluginScheduledJobs = append(remaining, scheduler)
+
+	if a.Srv.Jobs != nil && a.Srv.Jobs.Schedulers != nil {
+		a.Srv.Jobs.Schedulers.AddScheduler(scheduler)
+	}
+
+	return nil
+}
+
+// UnregisterPluginScheduledJob stops scheduling the plugin's job with the given name.
+func (a *App) UnregisterPluginScheduledJob(pluginId, name string) *model.AppError {
+	a.Srv.pluginScheduledJobsLock.Lock()
+	defer a.Srv.pluginScheduledJobsLock.Unlock()
+
+	found := false
+	remaining := []*PluginJobScheduler{}
+	for _, s := range a.Srv.pluginScheduledJobs {
+		if s.PluginId == pluginId && s.Job.Name == name {
+			found = true
+			a.removePluginJobScheduler(s)
+			continue
+		}
+		remaining = append(remaining, s)
+	}
+	a.Srv.pluginScheduledJobs = remaining
+
+	if !found {
+		return model.NewAppError("UnregisterPluginScheduledJob", "app.plugin.scheduled_job.not_found.app_error", nil, "plugin_id="+pluginId+", name="+name, http.StatusNotFound)
+	}
+
+	return nil
+}
+
+// UnregisterPluginScheduledJobs stops scheduling all of the plugin's jobs.
+func (a *App) UnregisterPluginScheduledJobs(pluginId string) {
+	a.Srv.pluginScheduledJobsLock.Lock()
+	defer a.Srv.pluginScheduledJobsLock.Unlock()
+
+	remaining := []*PluginJobScheduler{}
+	for _, s := range a.Srv.pluginScheduledJobs {
+		if s.PluginId == pluginId {
+			a.removePluginJobScheduler(s)
+			continue
+		}
+		remaining = append(remaining, s)
+	}
+	a.Srv.pluginScheduledJobs = remaining
+}
+
+func (a *App) removePluginJobScheduler(scheduler *PluginJobScheduler) {
+	if a.Srv.Jobs != nil && a.Srv.Jobs.Schedulers != nil {
+		a.Srv.Jobs.Schedulers.RemoveScheduler(scheduler.Name())
+	}
+}
+
+// GetPluginScheduledJobs returns the jobs registered by the given plugin.
+func (a *App) GetPluginScheduledJobs(pluginId string) []*model.PluginScheduledJob {
+	a.Srv.pluginScheduledJobsLock.RLock()
+	defer a.Srv.pluginScheduledJobsLock.RUnlock()
+
+	jobs := []*model.PluginScheduledJob{}
+	for _, s := range a.Srv.pluginScheduledJobs {
+		if s.PluginId == pluginId {
+			jobs = append(jobs, s.Job)
+		}
+	}
+	return jobs
+}
+
+// RunPluginScheduledJob delivers a scheduled job to the plugin that registered it through the OnScheduledJob
+// hook. It is called by the jobs worker that claimed the job, so each job runs on a single server.
+func (a *App) RunPluginScheduledJob(job *model.Job) *model.AppError {
+	pluginId := job.Data[model.PLUGIN_SCHEDULED_JOB_DATA_PLUGIN_ID]
+	name := job.Data[model.PLUGIN_SCHEDULED_JOB_DATA_NAME]
+
+	pluginsEnvironment := a.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return model.NewAppError("RunPluginScheduledJob", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	hooks, err := pluginsEnvironment.HooksForPlugin(pluginId)
+	if err != nil {
+		return model.NewAppError("RunPluginScheduledJob", "app.plugin.scheduled_job.not_active.app_error", nil, "plugin_id="+pluginId+", "+err.Error(), http.StatusBadRequest)
+	}
+
+	if err := hooks.OnScheduledJob(a.PluginContext(), name); err != nil {
+		return model.NewAppError("RunPluginScheduledJob", "app.plugin.scheduled_job.failed.app_error", nil, "plugin_id="+pluginId+", name="+name+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestPluginScheduledJobs(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	pluginId := "testscheduledjobs"
+
+	pluginDir := setupPluginApiTest(t,
+		`
+		package main
+
+		import (
+			"fmt"
+			"time"
+
+			"github.com/mattermost/mattermost-server/model"
+			"github.com/mattermost/mattermost-server/plugin"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) OnActivate() error {
+			if err := p.API.RegisterScheduledJob(&model.PluginScheduledJob{Name: "every_hour", Interval: time.Hour}); err != nil {
+				return err
+			}
+			if err := p.API.RegisterScheduledJob(&model.PluginScheduledJob{Name: "nightly", Schedule: "@daily"}); err != nil {
+				return err
+			}
+			if err := p.API.RegisterScheduledJob(&model.PluginScheduledJob{Name: "invalid", Interval: time.Second}); err == nil {
+				return fmt.Errorf("expected an invalid job to be rejected")
+			}
+			return nil
+		}
+
+		func (p *MyPlugin) OnScheduledJob(c *plugin.Context, name string) error {
+			if name == "nightly" {
+				return fmt.Errorf("nightly job failed")
+			}
+			return p.API.KVSet("ran_"+name, []byte("true"))
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`,
+		`{"id": "testscheduledjobs", "backend": {"executable": "backend.exe"}}`, pluginId, th.App)
+	defer os.RemoveAll(pluginDir)
+
+	jobs := th.App.GetPluginScheduledJobs(pluginId)
+	require.Len(t, jobs, 2)
+
+	var scheduler *PluginJobScheduler
+	for _, s := range th.App.Srv.pluginScheduledJobs {
+		if s.PluginId == pluginId && s.Job.Name == "every_hour" {
+			scheduler = s
+		}
+	}
+	require.NotNil(t, scheduler)
+
+	t.Run("schedules an interval job that has never run straight away", func(t *testing.T) {
+		now := time.Now()
+		nextTime := scheduler.NextScheduleTime(th.App.Config(), now, false, nil)
+		require.NotNil(t, nextTime)
+		assert.Equal(t, now, *nextTime)
+	})
+
+	job, err := scheduler.ScheduleJob(th.App.Config(), false, nil)
+	require.Nil(t, err)
+	require.NotNil(t, job)
+	assert.Equal(t, model.JOB_TYPE_PLUGIN_SCHEDULED, job.Type)
+	assert.Equal(t, pluginId, job.Data[model.PLUGIN_SCHEDULED_JOB_DATA_PLUGIN_ID])
+	assert.Equal(t, "every_hour", job.Data[model.PLUGIN_SCHEDULED_JOB_DATA_NAME])
+	defer th.App.Srv.Store.Job().Delete(job.Id)
+
+	t.Run("doesn't schedule a job while the previous one is pending", func(t *testing.T) {
+		pendingJob, err := scheduler.ScheduleJob(th.App.Config(), false, nil)
+		require.Nil(t, err)
+		assert.Nil(t, pendingJob)
+	})
+
+	t.Run("schedules the next run an interval after the last one", func(t *testing.T) {
+		nextTime := scheduler.NextScheduleTime(th.App.Config(), time.Now(), true, nil)
+		require.NotNil(t, nextTime)
+		assert.Equal(t, job.CreateAt+time.Hour.Nanoseconds()/int64(time.Millisecond), (*nextTime).UnixNano()/int64(time.Millisecond))
+	})
+
+	t.Run("delivers the job to the plugin", func(t *testing.T) {
+		require.Nil(t, th.App.RunPluginScheduledJob(job))
+
+		value, err := th.App.GetPluginKey(pluginId, "ran_every_hour")
+		require.Nil(t, err)
+		assert.Equal(t, []byte("true"), value)
+	})
+
+	t.Run("errors returned by the plugin fail the job", func(t *testing.T) {
+		err := th.App.RunPluginScheduledJob(&model.Job{
+			Type: model.JOB_TYPE_PLUGIN_SCHEDULED,
+			Data: map[string]string{
+				model.PLUGIN_SCHEDULED_JOB_DATA_PLUGIN_ID: pluginId,
+				model.PLUGIN_SCHEDULED_JOB_DATA_NAME:      "nightly",
+			},
+		})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("jobs for inactive plugins fail", func(t *testing.T) {
+		err := th.App.RunPluginScheduledJob(&model.Job{
+			Type: model.JOB_TYPE_PLUGIN_SCHEDULED,
+			Data: map[string]string{
+				model.PLUGIN_SCHEDULED_JOB_DATA_PLUGIN_ID: "unknown",
+				model.PLUGIN_SCHEDULED_JOB_DATA_NAME:      "every_hour",
+			},
+		})
+		assert.NotNil(t, err)
+	})
+
+	require.Nil(t, th.App.UnregisterPluginScheduledJob(pluginId, "nightly"))
+	assert.NotNil(t, th.App.UnregisterPluginScheduledJob(pluginId, "nightly"))
+	assert.Len(t, th.App.GetPluginScheduledJobs(pluginId), 1)
+
+	th.App.UnregisterPluginScheduledJobs(pluginId)
+	assert.Empty(t, th.App.GetPluginScheduledJobs(pluginId))
+}
+
+func TestPluginJobSchedulerLastRun(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	pluginId := "testlastrun"
+	require.Nil(t, th.App.RegisterPluginScheduledJob(pluginId, &model.PluginScheduledJob{Name: "every_hour", Interval: time.Hour}))
+	defer th.App.UnregisterPluginScheduledJobs(pluginId)
+
+	scheduler := th.App.Srv.pluginScheduledJobs[len(th.App.Srv.pluginScheduledJobs)-1]
+	require.Equal(t, pluginId, scheduler.PluginId)
+
+	job, err := scheduler.ScheduleJob(th.App.Config(), false, nil)
+	require.Nil(t, err)
+	require.NotNil(t, job)
+	defer th.App.Srv.Store.Job().Delete(job.Id)
+
+	// More jobs of other plugins than were ever searched for the last run of the job.
+	for i := 0; i < 1001; i++ {
+		foreignJob, err := th.App.Srv.Store.Job().Save(&model.Job{
+			Id:       model.NewId(),
+			Type:     model.JOB_TYPE_PLUGIN_SCHEDULED,
+			CreateAt: job.CreateAt + int64(i) + 1,
+			Status:   model.JOB_STATUS_SUCCESS,
+			Data: map[string]string{
+				model.PLUGIN_SCHEDULED_JOB_DATA_PLUGIN_ID: "otherplugin",
+				model.PLUGIN_SCHEDULED_JOB_DATA_NAME:      "every_minute",
+			},
+		})
+		require.Nil(t, err)
+		defer th.App.Srv.Store.Job().Delete(foreignJob.Id)
+	}
+
+	nextTime := scheduler.NextScheduleTime(th.App.Config(), time.Now(), false, nil)
+	require.NotNil(t, nextTime)
+	assert.Equal(t, job.CreateAt+time.Hour.Nanoseconds()/int64(time.Millisecond), (*nextTime).UnixNano()/int64(time.Millisecond))
+
+	t.Run("a job of the same name of another plugin has its own last run", func(t *testing.T) {
+		require.Nil(t, th.App.RegisterPluginScheduledJob("otherplugin", &model.PluginScheduledJob{Name: "every_hour", Interval: time.Hour}))
+		defer th.App.UnregisterPluginScheduledJobs("otherplugin")
+
+		other := th.App.Srv.pluginScheduledJobs[len(th.App.Srv.pluginScheduledJobs)-1]
+		now := time.Now()
+		nextTime := other.NextScheduleTime(th.App.Config(), now, false, nil)
+		require.NotNil(t, nextTime)
+		assert.Equal(t, now, *nextTime)
+	})
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// fileStorePluginKeysFolder is the folder in the file store holding the public keys trusted to sign
+// plugin bundles. Each key is stored as a base64 encoded ed25519 public key.
+var fileStorePluginKeysFolder = filepath.Join(fileStorePluginFolder, "keys")
+
+const pluginKeyFileExtension = ".pub"
+
+func parsePluginPublicKey(data []byte) (ed25519.PublicKey, error) {
+	key, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(key) != ed25519.PublicKeySize {
+		return nil, errors.New("public key has the wrong size")
+	}
+
+	return ed25519.PublicKey(key), nil
+}
+
+func (a *App) getPluginKeyPath(name string) string {
+	return filepath.Join(fileStorePluginKeysFolder, name+pluginKeyFileExtension)
+}
+
+// AddTrustedPluginKey adds a public key to the set of keys trusted to sign plugin bundles, replacing any
+// existing key with the same name.
+func (a *App) AddTrustedPluginKey(name string, key []byte) *model.AppError {
+	if !model.IsValidAlphaNumHyphenUnderscore(name, false) {
+		return model.NewAppError("AddTrustedPluginKey", "app.plugin.key.invalid_name.app_error", nil, "name="+name, http.StatusBadRequest)
+	}
+
+	if _, err := parsePluginPublicKey(key); err != nil {
+		return model.NewAppError("AddTrustedPluginKey", "app.plugin.key.invalid.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	if _, err := a.WriteFile(bytes.NewReader(bytes.TrimSpace(key)), a.getPluginKeyPath(name)); err != nil {
+		return model.NewAppError("AddTrustedPluginKey", "app.plugin.key.store.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// DeleteTrustedPluginKey removes a public key from the set of keys trusted to sign plugin bundles.
+func (a *App) DeleteTrustedPluginKey(name string) *model.AppError {
+	path := a.getPluginKeyPath(name)
+
+	exists, err := a.FileExists(path)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return model.NewAppError("DeleteTrustedPluginKey", "app.plugin.key.not_found.app_error", nil, "name="+name, http.StatusNotFound)
+	}
+
+	return a.RemoveFile(path)
+}
+
+// GetTrustedPluginKeyNames returns the names of all public keys trusted to sign plugin bundles.
+func (a *App) GetTrustedPluginKeyNames() ([]string, *model.AppError) {
+	paths, err := a.ListDirectory(fileStorePluginKeysFolder)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, path := range paths {
+		if filepath.Ext(path) == pluginKeyFileExtension {
+			names = append(names, strings.TrimSuffix(filepath.Base(path), pluginKeyFileExtension))
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (a *App) getTrustedPluginKeys() ([]ed25519.PublicKey, *model.AppError) {
+	names, err := a.GetTrustedPluginKeyNames()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []ed25519.PublicKey{}
+	for _, name := range names {
+		data, err := a.ReadFile(a.getPluginKeyPath(name))
+		if err != nil {
+			return nil, err
+		}
+
+		key, parseErr := parsePluginPublicKey(data)
+		if parseErr != nil {
+			mlog.Warn("Ignoring invalid trusted plugin key", mlog.String("name", name), mlog.Err(parseErr))
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// VerifyPluginSignature checks that the plugin bundle was signed by one of the trusted public keys. The
+// signature is a base64 encoded ed25519 signature of the whole bundle.
+func (a *App) VerifyPluginSignature(bundle []byte, signature string) *model.AppError {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return model.NewAppError("VerifyPluginSignature", "app.plugin.signature.invalid.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	keys, appErr := a.getTrustedPluginKeys()
+	if appErr != nil {
+		return appErr
+	}
+
+	for _, key := range keys {
+		if ed25519.Verify(key, bundle, sig) {
+			return nil
+		}
+	}
+
+	return model.NewAppError("VerifyPluginSignature", "app.plugin.signature.untrusted.app_error", nil, "", http.StatusBadRequest)
+}
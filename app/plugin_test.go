@@ -186,6 +186,60 @@ func TestPluginKeyValueStoreCompareAndSet(t *testing.T) {
 	assert.Equal(t, []byte("test2"), ret)
 }
 
+func TestPluginKeyValueStoreMulti(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	pluginId := "testpluginid"
+
+	defer func() {
+		assert.Nil(t, th.App.DeleteAllKeysForPlugin(pluginId))
+	}()
+
+	assert.Nil(t, th.App.SetPluginKeys(pluginId, map[string][]byte{
+		"prefix_key1": []byte("value1"),
+		"prefix_key2": []byte("value2"),
+		"other":       []byte("value3"),
+	}))
+
+	// Simulate a key written prior to v5.6
+	_, err := th.App.Srv.Store.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginId, Key: getKeyHash("hashed"), Value: []byte("value4")})
+	assert.Nil(t, err)
+
+	values, err := th.App.GetPluginKeys(pluginId, []string{"prefix_key1", "other", "hashed", "missing"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]byte{
+		"prefix_key1": []byte("value1"),
+		"other":       []byte("value3"),
+		"hashed":      []byte("value4"),
+	}, values)
+
+	list, err := th.App.ListPluginKeysWithPrefix(pluginId, "prefix_", 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"prefix_key1", "prefix_key2"}, list)
+
+	tooMany := make([]string, model.KEY_VALUE_MULTI_MAX_KEYS+1)
+	_, err = th.App.GetPluginKeys(pluginId, tooMany)
+	assert.NotNil(t, err)
+
+	updated, err := th.App.SetPluginKeyWithOptions(pluginId, "other", []byte("value5"), model.PluginKVSetOptions{Atomic: true, OldValue: []byte("value3"), ExpireInSeconds: 60})
+	assert.Nil(t, err)
+	assert.True(t, updated)
+
+	ret, err := th.App.GetPluginKey(pluginId, "other")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value5"), ret)
+
+	// A failed atomic set leaves the key written prior to v5.6 in place
+	updated, err = th.App.SetPluginKeyWithOptions(pluginId, "hashed", []byte("value6"), model.PluginKVSetOptions{Atomic: true, OldValue: []byte("wrong")})
+	assert.Nil(t, err)
+	assert.False(t, updated)
+
+	ret, err = th.App.GetPluginKey(pluginId, "hashed")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value4"), ret)
+}
+
 func TestServePluginRequest(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
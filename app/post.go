@@ -25,8 +25,10 @@ const (
 )
 
 func (a *App) CreatePostAsUser(post *model.Post, currentSessionId string) (*model.Post, *model.AppError) {
+	defer a.startSpan("CreatePostAsUser")()
+
 	// Check that channel has not been deleted
-	channel, errCh := a.Srv.Store.Channel().Get(post.ChannelId, true)
+	channel, errCh := a.Store().Channel().Get(post.ChannelId, true)
 	if errCh != nil {
 		err := model.NewAppError("CreatePostAsUser", "api.context.invalid_param.app_error", map[string]interface{}{"Name": "post.channel_id"}, errCh.Error(), http.StatusBadRequest)
 		return nil, err
@@ -51,7 +53,7 @@ func (a *App) CreatePostAsUser(post *model.Post, currentSessionId string) (*mode
 		}
 
 		if err.Id == "api.post.create_post.town_square_read_only" {
-			user, userErr := a.Srv.Store.User().Get(post.UserId)
+			user, userErr := a.Store().User().Get(post.UserId)
 			if userErr != nil {
 				return nil, userErr
 			}
@@ -88,7 +90,7 @@ func (a *App) CreatePostAsUser(post *model.Post, currentSessionId string) (*mode
 }
 
 func (a *App) CreatePostMissingChannel(post *model.Post, triggerWebhooks bool) (*model.Post, *model.AppError) {
-	channel, err := a.Srv.Store.Channel().Get(post.ChannelId, true)
+	channel, err := a.Store().Channel().Get(post.ChannelId, true)
 	if err != nil {
 		return nil, err
 	}
@@ -138,6 +140,8 @@ func (a *App) deduplicateCreatePost(post *model.Post) (foundPost *model.Post, er
 }
 
 func (a *App) CreatePost(post *model.Post, channel *model.Channel, triggerWebhooks bool) (savedPost *model.Post, err *model.AppError) {
+	defer a.startSpan("CreatePost")()
+
 	foundPost, err := a.deduplicateCreatePost(post)
 	if err != nil {
 		return nil, err
@@ -167,13 +171,13 @@ func (a *App) CreatePost(post *model.Post, channel *model.Channel, triggerWebhoo
 	if len(post.RootId) > 0 {
 		pchan = make(chan store.StoreResult, 1)
 		go func() {
-			r, pErr := a.Srv.Store.Post().Get(post.RootId)
+			r, pErr := a.Store().Post().Get(post.RootId)
 			pchan <- store.StoreResult{Data: r, Err: pErr}
 			close(pchan)
 		}()
 	}
 
-	user, err := a.Srv.Store.User().Get(post.UserId)
+	user, err := a.Store().User().Get(post.UserId)
 	if err != nil {
 		return nil, err
 	}
@@ -262,11 +266,19 @@ func (a *App) CreatePost(post *model.Post, channel *model.Channel, triggerWebhoo
 		}
 	}
 
-	rpost, err := a.Srv.Store.Post().Save(post)
+	if err = a.validatePostPriority(post); err != nil {
+		return nil, err
+	}
+
+	rpost, err := a.Store().Post().Save(post)
 	if err != nil {
 		return nil, err
 	}
 
+	if err = a.savePostPriority(rpost); err != nil {
+		mlog.Error("Encountered error saving post priority", mlog.String("post_id", rpost.Id), mlog.Err(err))
+	}
+
 	// Update the mapping from pending post id to the actual post id, for any clients that
 	// might be duplicating requests.
 	a.Srv.seenPendingPostIdsCache.AddWithExpiresInSecs(post.PendingPostId, rpost.Id, int64(PENDING_POST_IDS_CACHE_TTL.Seconds()))
@@ -317,7 +329,7 @@ func (a *App) CreatePost(post *model.Post, channel *model.Channel, triggerWebhoo
 func (a *App) attachFilesToPost(post *model.Post) *model.AppError {
 	var attachedIds []string
 	for _, fileId := range post.FileIds {
-		err := a.Srv.Store.FileInfo().AttachToPost(fileId, post.Id, post.UserId)
+		err := a.Store().FileInfo().AttachToPost(fileId, post.Id, post.UserId)
 		if err != nil {
 			mlog.Warn("Failed to attach file to post", mlog.String("file_id", fileId), mlog.String("post_id", post.Id), mlog.Err(err))
 			continue
@@ -330,7 +342,7 @@ func (a *App) attachFilesToPost(post *model.Post) *model.AppError {
 		// We couldn't attach all files to the post, so ensure that post.FileIds reflects what was actually attached
 		post.FileIds = attachedIds
 
-		if _, err := a.Srv.Store.Post().Overwrite(post); err != nil {
+		if _, err := a.Store().Post().Overwrite(post); err != nil {
 			return err
 		}
 	}
@@ -348,7 +360,7 @@ func (a *App) FillInPostProps(post *model.Post, channel *model.Channel) *model.A
 
 	if len(channelMentions) > 0 {
 		if channel == nil {
-			postChannel, err := a.Srv.Store.Channel().GetForPost(post.Id)
+			postChannel, err := a.Store().Channel().GetForPost(post.Id)
 			if err != nil {
 				return model.NewAppError("FillInPostProps", "api.context.invalid_param.app_error", map[string]interface{}{"Name": "post.channel_id"}, err.Error(), http.StatusBadRequest)
 			}
@@ -381,7 +393,7 @@ func (a *App) FillInPostProps(post *model.Post, channel *model.Channel) *model.A
 func (a *App) handlePostEvents(post *model.Post, user *model.User, channel *model.Channel, triggerWebhooks bool, parentPostList *model.PostList) error {
 	var team *model.Team
 	if len(channel.TeamId) > 0 {
-		t, err := a.Srv.Store.Team().Get(channel.TeamId)
+		t, err := a.Store().Team().Get(channel.TeamId)
 		if err != nil {
 			return err
 		}
@@ -473,9 +485,11 @@ func (a *App) DeleteEphemeralPost(userId, postId string) {
 }
 
 func (a *App) UpdatePost(post *model.Post, safeUpdate bool) (*model.Post, *model.AppError) {
+	defer a.startSpan("UpdatePost")()
+
 	post.SanitizeProps()
 
-	postLists, err := a.Srv.Store.Post().Get(post.Id)
+	postLists, err := a.Store().Post().Get(post.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -549,7 +563,7 @@ func (a *App) UpdatePost(post *model.Post, safeUpdate bool) (*model.Post, *model
 		}
 	}
 
-	rpost, err := a.Srv.Store.Post().Update(newPost, oldPost)
+	rpost, err := a.Store().Post().Update(newPost, oldPost)
 	if err != nil {
 		return nil, err
 	}
@@ -566,7 +580,7 @@ func (a *App) UpdatePost(post *model.Post, safeUpdate bool) (*model.Post, *model
 
 	if a.IsESIndexingEnabled() {
 		a.Srv.Go(func() {
-			channel, chanErr := a.Srv.Store.Channel().GetForPost(rpost.Id)
+			channel, chanErr := a.Store().Channel().GetForPost(rpost.Id)
 			if chanErr != nil {
 				mlog.Error("Couldn't get channel for post for Elasticsearch indexing.", mlog.String("channel_id", rpost.ChannelId), mlog.String("post_id", rpost.Id))
 				return
@@ -589,6 +603,8 @@ func (a *App) UpdatePost(post *model.Post, safeUpdate bool) (*model.Post, *model
 }
 
 func (a *App) PatchPost(postId string, patch *model.PostPatch) (*model.Post, *model.AppError) {
+	defer a.startSpan("PatchPost")()
+
 	post, err := a.GetSinglePost(postId)
 	if err != nil {
 		return nil, err
@@ -615,43 +631,49 @@ func (a *App) PatchPost(postId string, patch *model.PostPatch) (*model.Post, *mo
 }
 
 func (a *App) GetPostsPage(channelId string, page int, perPage int) (*model.PostList, *model.AppError) {
-	return a.Srv.Store.Post().GetPosts(channelId, page*perPage, perPage, true)
+	defer a.startSpan("GetPostsPage")()
+
+	return a.Store().Post().GetPosts(channelId, page*perPage, perPage, true)
 }
 
 func (a *App) GetPosts(channelId string, offset int, limit int) (*model.PostList, *model.AppError) {
-	return a.Srv.Store.Post().GetPosts(channelId, offset, limit, true)
+	return a.Store().Post().GetPosts(channelId, offset, limit, true)
 }
 
 func (a *App) GetPostsEtag(channelId string) string {
-	return a.Srv.Store.Post().GetEtag(channelId, true)
+	return a.Store().Post().GetEtag(channelId, true)
 }
 
 func (a *App) GetPostsSince(channelId string, time int64) (*model.PostList, *model.AppError) {
-	return a.Srv.Store.Post().GetPostsSince(channelId, time, true)
+	defer a.startSpan("GetPostsSince")()
+
+	return a.Store().Post().GetPostsSince(channelId, time, true)
 }
 
 func (a *App) GetSinglePost(postId string) (*model.Post, *model.AppError) {
-	return a.Srv.Store.Post().GetSingle(postId)
+	return a.Store().Post().GetSingle(postId)
 }
 
 func (a *App) GetPostThread(postId string) (*model.PostList, *model.AppError) {
-	return a.Srv.Store.Post().Get(postId)
+	defer a.startSpan("GetPostThread")()
+
+	return a.Store().Post().Get(postId)
 }
 
 func (a *App) GetFlaggedPosts(userId string, offset int, limit int) (*model.PostList, *model.AppError) {
-	return a.Srv.Store.Post().GetFlaggedPosts(userId, offset, limit)
+	return a.Store().Post().GetFlaggedPosts(userId, offset, limit)
 }
 
 func (a *App) GetFlaggedPostsForTeam(userId, teamId string, offset int, limit int) (*model.PostList, *model.AppError) {
-	return a.Srv.Store.Post().GetFlaggedPostsForTeam(userId, teamId, offset, limit)
+	return a.Store().Post().GetFlaggedPostsForTeam(userId, teamId, offset, limit)
 }
 
 func (a *App) GetFlaggedPostsForChannel(userId, channelId string, offset int, limit int) (*model.PostList, *model.AppError) {
-	return a.Srv.Store.Post().GetFlaggedPostsForChannel(userId, channelId, offset, limit)
+	return a.Store().Post().GetFlaggedPostsForChannel(userId, channelId, offset, limit)
 }
 
 func (a *App) GetPermalinkPost(postId string, userId string) (*model.PostList, *model.AppError) {
-	list, err := a.Srv.Store.Post().Get(postId)
+	list, err := a.Store().Post().Get(postId)
 	if err != nil {
 		return nil, err
 	}
@@ -674,30 +696,30 @@ func (a *App) GetPermalinkPost(postId string, userId string) (*model.PostList, *
 }
 
 func (a *App) GetPostsBeforePost(channelId, postId string, page, perPage int) (*model.PostList, *model.AppError) {
-	return a.Srv.Store.Post().GetPostsBefore(channelId, postId, perPage, page*perPage)
+	return a.Store().Post().GetPostsBefore(channelId, postId, perPage, page*perPage)
 }
 
 func (a *App) GetPostsAfterPost(channelId, postId string, page, perPage int) (*model.PostList, *model.AppError) {
-	return a.Srv.Store.Post().GetPostsAfter(channelId, postId, perPage, page*perPage)
+	return a.Store().Post().GetPostsAfter(channelId, postId, perPage, page*perPage)
 }
 
 func (a *App) GetPostsAroundPost(postId, channelId string, offset, limit int, before bool) (*model.PostList, *model.AppError) {
 	if before {
-		return a.Srv.Store.Post().GetPostsBefore(channelId, postId, limit, offset)
+		return a.Store().Post().GetPostsBefore(channelId, postId, limit, offset)
 	}
-	return a.Srv.Store.Post().GetPostsAfter(channelId, postId, limit, offset)
+	return a.Store().Post().GetPostsAfter(channelId, postId, limit, offset)
 }
 
 func (a *App) GetPostAfterTime(channelId string, time int64) (*model.Post, *model.AppError) {
-	return a.Srv.Store.Post().GetPostAfterTime(channelId, time)
+	return a.Store().Post().GetPostAfterTime(channelId, time)
 }
 
 func (a *App) GetPostIdAfterTime(channelId string, time int64) (string, *model.AppError) {
-	return a.Srv.Store.Post().GetPostIdAfterTime(channelId, time)
+	return a.Store().Post().GetPostIdAfterTime(channelId, time)
 }
 
 func (a *App) GetPostIdBeforeTime(channelId string, time int64) (string, *model.AppError) {
-	return a.Srv.Store.Post().GetPostIdBeforeTime(channelId, time)
+	return a.Store().Post().GetPostIdBeforeTime(channelId, time)
 }
 
 func (a *App) GetNextPostIdFromPostList(postList *model.PostList) string {
@@ -815,7 +837,9 @@ func (a *App) GetPostsForChannelAroundLastUnread(channelId, userId string, limit
 }
 
 func (a *App) DeletePost(postId, deleteByID string) (*model.Post, *model.AppError) {
-	post, err := a.Srv.Store.Post().GetSingle(postId)
+	defer a.startSpan("DeletePost")()
+
+	post, err := a.Store().Post().GetSingle(postId)
 	if err != nil {
 		err.StatusCode = http.StatusBadRequest
 		return nil, err
@@ -831,7 +855,7 @@ func (a *App) DeletePost(postId, deleteByID string) (*model.Post, *model.AppErro
 		return nil, err
 	}
 
-	if err := a.Srv.Store.Post().Delete(postId, model.GetMillis(), deleteByID); err != nil {
+	if err := a.Store().Post().Delete(postId, model.GetMillis(), deleteByID); err != nil {
 		return nil, err
 	}
 
@@ -846,6 +870,16 @@ func (a *App) DeletePost(postId, deleteByID string) (*model.Post, *model.AppErro
 		a.DeleteFlaggedPosts(post.Id)
 	})
 
+	if pluginsEnvironment := a.GetPluginsEnvironment(); pluginsEnvironment != nil {
+		a.Srv.Go(func() {
+			pluginContext := a.PluginContext()
+			pluginsEnvironment.RunMultiPluginHook(func(hooks plugin.Hooks) bool {
+				hooks.PostHasBeenDeleted(pluginContext, post)
+				return true
+			}, plugin.PostHasBeenDeletedId)
+		})
+	}
+
 	if a.IsESIndexingEnabled() {
 		a.Srv.Go(func() {
 			if err := a.Elasticsearch.DeletePost(post); err != nil {
@@ -860,7 +894,7 @@ func (a *App) DeletePost(postId, deleteByID string) (*model.Post, *model.AppErro
 }
 
 func (a *App) DeleteFlaggedPosts(postId string) {
-	if err := a.Srv.Store.Preference().DeleteCategoryAndName(model.PREFERENCE_CATEGORY_FLAGGED_POST, postId); err != nil {
+	if err := a.Store().Preference().DeleteCategoryAndName(model.PREFERENCE_CATEGORY_FLAGGED_POST, postId); err != nil {
 		mlog.Warn("Unable to delete flagged post preference when deleting post.", mlog.Err(err))
 		return
 	}
@@ -871,7 +905,7 @@ func (a *App) DeletePostFiles(post *model.Post) {
 		return
 	}
 
-	if _, err := a.Srv.Store.FileInfo().DeleteForPost(post.Id); err != nil {
+	if _, err := a.Store().FileInfo().DeleteForPost(post.Id); err != nil {
 		mlog.Warn("Encountered error when deleting files for post", mlog.String("post_id", post.Id), mlog.Err(err))
 	}
 }
@@ -928,7 +962,7 @@ func (a *App) searchPostsInTeam(teamId string, userId string, paramsList []*mode
 
 		go func(params *model.SearchParams) {
 			defer wg.Done()
-			postList, err := a.Srv.Store.Post().Search(teamId, userId, params)
+			postList, err := a.Store().Post().Search(teamId, userId, params)
 			pchan <- store.StoreResult{Data: postList, Err: err}
 		}(params)
 	}
@@ -1022,7 +1056,7 @@ func (a *App) esSearchPostsInTeamForUser(paramsList []*model.SearchParams, userI
 	// Get the posts
 	postList := model.NewPostList()
 	if len(postIds) > 0 {
-		posts, err := a.Srv.Store.Post().GetPostsByIds(postIds)
+		posts, err := a.Store().Post().GetPostsByIds(postIds)
 		if err != nil {
 			return nil, err
 		}
@@ -1038,6 +1072,8 @@ func (a *App) esSearchPostsInTeamForUser(paramsList []*model.SearchParams, userI
 }
 
 func (a *App) SearchPostsInTeamForUser(terms string, userId string, teamId string, isOrSearch bool, includeDeletedChannels bool, timeZoneOffset int, page, perPage int) (*model.PostSearchResults, *model.AppError) {
+	defer a.startSpan("SearchPostsInTeamForUser")()
+
 	var postSearchResults *model.PostSearchResults
 	var err *model.AppError
 	paramsList := model.ParseSearchParams(strings.TrimSpace(terms), timeZoneOffset)
@@ -1098,7 +1134,7 @@ func (a *App) GetFileInfosForPostWithMigration(postId string) ([]*model.FileInfo
 
 	pchan := make(chan store.StoreResult, 1)
 	go func() {
-		post, err := a.Srv.Store.Post().GetSingle(postId)
+		post, err := a.Store().Post().GetSingle(postId)
 		pchan <- store.StoreResult{Data: post, Err: err}
 		close(pchan)
 	}()
@@ -1117,7 +1153,7 @@ func (a *App) GetFileInfosForPostWithMigration(postId string) ([]*model.FileInfo
 		post := result.Data.(*model.Post)
 
 		if len(post.Filenames) > 0 {
-			a.Srv.Store.FileInfo().InvalidateFileInfosForPostCache(postId)
+			a.Store().FileInfo().InvalidateFileInfosForPostCache(postId)
 			// The post has Filenames that need to be replaced with FileInfos
 			infos = a.MigrateFilenamesToFileInfos(post)
 		}
@@ -1127,7 +1163,7 @@ func (a *App) GetFileInfosForPostWithMigration(postId string) ([]*model.FileInfo
 }
 
 func (a *App) GetFileInfosForPost(postId string, fromMaster bool) ([]*model.FileInfo, *model.AppError) {
-	return a.Srv.Store.FileInfo().GetForPost(postId, fromMaster, false, true)
+	return a.Store().FileInfo().GetForPost(postId, fromMaster, false, true)
 }
 
 func (a *App) PostWithProxyAddedToImageURLs(post *model.Post) *model.Post {
@@ -1172,7 +1208,7 @@ func (a *App) ImageProxyRemover() (f func(string) string) {
 }
 
 func (a *App) MaxPostSize() int {
-	maxPostSize := a.Srv.Store.Post().GetMaxPostSize()
+	maxPostSize := a.Store().Post().GetMaxPostSize()
 	if maxPostSize == 0 {
 		return model.POST_MESSAGE_MAX_RUNES_V1
 	}
@@ -0,0 +1,222 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// POST_ARCHIVAL_BATCH_SIZE is the number of threads moved to the archive files at once.
+const POST_ARCHIVAL_BATCH_SIZE = 500
+
+// ArchiveOldPosts moves the threads older than the configured number of months, and untouched
+// since, out of the Posts table into archive files of the file store. Each batch of threads is
+// written to one archive file per channel. It returns the number of posts archived.
+func (a *App) ArchiveOldPosts() (int64, *model.AppError) {
+	months := *a.Config().DataRetentionSettings.MessageArchivalMonths
+	endTime := model.GetMillisForTime(time.Now().AddDate(0, -months, 0))
+
+	backend, err := a.FileBackend()
+	if err != nil {
+		return 0, err
+	}
+
+	var archived int64
+	var afterCreateAt int64
+	afterId := ""
+	for {
+		posts, err := a.Srv.Store.Post().GetThreadsForArchival(endTime, afterCreateAt, afterId, POST_ARCHIVAL_BATCH_SIZE)
+		if err != nil {
+			return archived, err
+		}
+
+		if len(posts) == 0 {
+			return archived, nil
+		}
+
+		// Replies are in the channel of their root, so each thread ends up in a single file.
+		var channelIds []string
+		postsByChannel := make(map[string][]*model.Post)
+		for _, post := range posts {
+			if post.RootId == "" && (post.CreateAt > afterCreateAt || (post.CreateAt == afterCreateAt && post.Id > afterId)) {
+				afterCreateAt, afterId = post.CreateAt, post.Id
+			}
+
+			if _, ok := postsByChannel[post.ChannelId]; !ok {
+				channelIds = append(channelIds, post.ChannelId)
+			}
+			postsByChannel[post.ChannelId] = append(postsByChannel[post.ChannelId], post)
+		}
+
+		for _, channelId := range channelIds {
+			channelPosts := postsByChannel[channelId]
+			path := model.NewPostArchivePath(channelId, model.GetMillis())
+
+			data, encodeErr := model.EncodePostArchive(channelPosts)
+			if encodeErr != nil {
+				return archived, model.NewAppError("ArchiveOldPosts", "app.post_archive.encode.app_error", nil, encodeErr.Error(), http.StatusInternalServerError)
+			}
+
+			if _, err := backend.WriteFile(bytes.NewReader(data), path); err != nil {
+				return archived, err
+			}
+
+			if err := a.Srv.Store.Post().Archive(path, channelPosts, endTime); err != nil {
+				// The posts were left in place, so the archive file is of no use.
+				if removeErr := backend.RemoveFile(path); removeErr != nil {
+					mlog.Warn("Failed to remove an unused post archive", mlog.String("path", path), mlog.Err(removeErr))
+				}
+
+				// Threads changed since they were read are left for a later run.
+				if err.StatusCode == http.StatusConflict {
+					mlog.Debug("Skipping threads changed while being archived", mlog.String("channel_id", channelId), mlog.Err(err))
+					continue
+				}
+				return archived, err
+			}
+
+			a.InvalidateCacheForChannelPosts(channelId)
+			archived += int64(len(channelPosts))
+		}
+	}
+}
+
+// DeleteExpiredArchivedPosts applies the message retention policy to the archive files, which the
+// data retention job doesn't see: the posts created before the retention period are removed from
+// them, and the files left empty are removed. It returns the number of posts deleted.
+func (a *App) DeleteExpiredArchivedPosts() (int64, *model.AppError) {
+	license := a.License()
+	if license == nil || !*license.Features.DataRetention || !*a.Config().DataRetentionSettings.EnableMessageDeletion {
+		return 0, nil
+	}
+
+	days := *a.Config().DataRetentionSettings.MessageRetentionDays
+	endTime := model.GetMillisForTime(time.Now().AddDate(0, 0, -days))
+
+	backend, err := a.FileBackend()
+	if err != nil {
+		return 0, err
+	}
+
+	var deleted int64
+	for {
+		paths, err := a.Srv.Store.Post().GetArchivePathsBefore(endTime, POST_ARCHIVAL_BATCH_SIZE)
+		if err != nil {
+			return deleted, err
+		}
+
+		if len(paths) == 0 {
+			return deleted, nil
+		}
+
+		for _, path := range paths {
+			data, err := backend.ReadFile(path)
+			if err != nil {
+				return deleted, err
+			}
+
+			posts, decodeErr := model.DecodePostArchive(data)
+			if decodeErr != nil {
+				return deleted, model.NewAppError("DeleteExpiredArchivedPosts", "app.post_archive.decode.app_error", nil, "path="+path+", "+decodeErr.Error(), http.StatusInternalServerError)
+			}
+
+			var kept []*model.Post
+			channelIds := make(map[string]bool)
+			for _, post := range posts {
+				channelIds[post.ChannelId] = true
+				if post.CreateAt >= endTime {
+					kept = append(kept, post)
+				}
+			}
+
+			// The remaining posts are written to a new file first, so that the index never points
+			// to a missing or partially written one.
+			newPath := ""
+			if len(kept) > 0 {
+				newPath = model.NewPostArchivePath(kept[0].ChannelId, model.GetMillis())
+
+				data, encodeErr := model.EncodePostArchive(kept)
+				if encodeErr != nil {
+					return deleted, model.NewAppError("DeleteExpiredArchivedPosts", "app.post_archive.encode.app_error", nil, encodeErr.Error(), http.StatusInternalServerError)
+				}
+
+				if _, err := backend.WriteFile(bytes.NewReader(data), newPath); err != nil {
+					return deleted, err
+				}
+			}
+
+			if err := a.Srv.Store.Post().ReplaceArchive(path, newPath, kept); err != nil {
+				if newPath != "" {
+					if removeErr := backend.RemoveFile(newPath); removeErr != nil {
+						mlog.Warn("Failed to remove an unused post archive", mlog.String("path", newPath), mlog.Err(removeErr))
+					}
+				}
+				return deleted, err
+			}
+
+			if err := backend.RemoveFile(path); err != nil {
+				mlog.Warn("Failed to remove an expired post archive", mlog.String("path", path), mlog.Err(err))
+			}
+
+			for id := range channelIds {
+				a.InvalidateCacheForChannelPosts(id)
+			}
+
+			deleted += int64(len(posts) - len(kept))
+		}
+	}
+}
+
+// RestoreArchivedPosts moves the archived posts of the channel, or of every channel if channelId
+// is empty, back into the Posts table and removes their archive files. It returns the number of
+// posts restored.
+func (a *App) RestoreArchivedPosts(channelId string) (int64, *model.AppError) {
+	paths, err := a.Srv.Store.Post().GetArchivePathsForChannel(channelId)
+	if err != nil {
+		return 0, err
+	}
+
+	backend, err := a.FileBackend()
+	if err != nil {
+		return 0, err
+	}
+
+	var restored int64
+	for _, path := range paths {
+		data, err := backend.ReadFile(path)
+		if err != nil {
+			return restored, err
+		}
+
+		posts, decodeErr := model.DecodePostArchive(data)
+		if decodeErr != nil {
+			return restored, model.NewAppError("RestoreArchivedPosts", "app.post_archive.decode.app_error", nil, "path="+path+", "+decodeErr.Error(), http.StatusInternalServerError)
+		}
+
+		if err := a.Srv.Store.Post().Unarchive(path, posts); err != nil {
+			return restored, err
+		}
+
+		if err := backend.RemoveFile(path); err != nil {
+			mlog.Warn("Failed to remove a restored post archive", mlog.String("path", path), mlog.Err(err))
+		}
+
+		channelIds := make(map[string]bool)
+		for _, post := range posts {
+			channelIds[post.ChannelId] = true
+		}
+		for id := range channelIds {
+			a.InvalidateCacheForChannelPosts(id)
+		}
+
+		restored += int64(len(posts))
+	}
+
+	return restored, nil
+}
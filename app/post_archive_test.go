@@ -0,0 +1,177 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store/archivelayer"
+)
+
+func TestArchiveOldPosts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	dir, err := ioutil.TempDir("", "archive")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.FileSettings.DriverName = model.IMAGE_DRIVER_LOCAL
+		*cfg.FileSettings.Directory = dir
+		*cfg.DataRetentionSettings.MessageArchivalMonths = 6
+	})
+
+	old := model.GetMillisForTime(time.Now().AddDate(-1, 0, 0))
+	save := func(post *model.Post) *model.Post {
+		post.ChannelId = th.BasicChannel.Id
+		post.UserId = th.BasicUser.Id
+		post.Message = "message " + model.NewId()
+		post, err := th.App.Srv.Store.Post().Save(post)
+		require.Nil(t, err)
+		return post
+	}
+
+	root := save(&model.Post{CreateAt: old})
+	reply := save(&model.Post{RootId: root.Id, CreateAt: old + 1})
+	activeRoot := save(&model.Post{CreateAt: old})
+	save(&model.Post{RootId: activeRoot.Id})
+
+	archived, appErr := th.App.ArchiveOldPosts()
+	require.Nil(t, appErr)
+	assert.Equal(t, int64(2), archived)
+
+	_, appErr = th.App.Srv.Store.Post().GetSingle(root.Id)
+	require.NotNil(t, appErr)
+	_, appErr = th.App.Srv.Store.Post().GetSingle(activeRoot.Id)
+	require.Nil(t, appErr)
+
+	paths, appErr := th.App.Srv.Store.Post().GetArchivePathsForChannel(th.BasicChannel.Id)
+	require.Nil(t, appErr)
+	require.Len(t, paths, 1)
+
+	exists, appErr := th.App.FileExists(paths[0])
+	require.Nil(t, appErr)
+	assert.True(t, exists)
+
+	t.Run("archived posts are found through the archive layer", func(t *testing.T) {
+		archiveStore := archivelayer.NewArchiveLayer(th.App.Srv.Store, th.App.ReadFile)
+
+		post, appErr := archiveStore.Post().GetSingle(reply.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, reply.Message, post.Message)
+
+		list, appErr := archiveStore.Post().Get(root.Id)
+		require.Nil(t, appErr)
+		assert.Len(t, list.Posts, 2)
+
+		list, appErr = archiveStore.Post().Search(th.BasicTeam.Id, th.BasicUser.Id, &model.SearchParams{Terms: strings.Fields(reply.Message)[1]})
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{reply.Id}, list.Order)
+	})
+
+	t.Run("nothing is left to archive", func(t *testing.T) {
+		archived, appErr := th.App.ArchiveOldPosts()
+		require.Nil(t, appErr)
+		assert.Equal(t, int64(0), archived)
+	})
+
+	restored, appErr := th.App.RestoreArchivedPosts(th.BasicChannel.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, int64(2), restored)
+
+	list, appErr := th.App.Srv.Store.Post().Get(reply.Id)
+	require.Nil(t, appErr)
+	assert.Len(t, list.Posts, 2)
+
+	exists, appErr = th.App.FileExists(paths[0])
+	require.Nil(t, appErr)
+	assert.False(t, exists)
+
+	paths, appErr = th.App.Srv.Store.Post().GetArchivePathsForChannel("")
+	require.Nil(t, appErr)
+	assert.Empty(t, paths)
+}
+
+func TestDeleteExpiredArchivedPosts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	dir, err := ioutil.TempDir("", "archive")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.FileSettings.DriverName = model.IMAGE_DRIVER_LOCAL
+		*cfg.FileSettings.Directory = dir
+		*cfg.DataRetentionSettings.MessageArchivalMonths = 6
+		*cfg.DataRetentionSettings.MessageRetentionDays = 365
+	})
+
+	save := func(post *model.Post) *model.Post {
+		post.ChannelId = th.BasicChannel.Id
+		post.UserId = th.BasicUser.Id
+		post.Message = "message " + model.NewId()
+		post, err := th.App.Srv.Store.Post().Save(post)
+		require.Nil(t, err)
+		return post
+	}
+
+	expired := model.GetMillisForTime(time.Now().AddDate(-2, 0, 0))
+	old := model.GetMillisForTime(time.Now().AddDate(0, -9, 0))
+	expiredRoot := save(&model.Post{CreateAt: expired})
+	save(&model.Post{RootId: expiredRoot.Id, CreateAt: expired + 1})
+	oldRoot := save(&model.Post{CreateAt: old})
+
+	archived, appErr := th.App.ArchiveOldPosts()
+	require.Nil(t, appErr)
+	require.Equal(t, int64(3), archived)
+
+	paths, appErr := th.App.Srv.Store.Post().GetArchivePathsForChannel(th.BasicChannel.Id)
+	require.Nil(t, appErr)
+	require.Len(t, paths, 1)
+
+	t.Run("nothing is deleted without a data retention license", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.DataRetentionSettings.EnableMessageDeletion = true
+		})
+
+		deleted, appErr := th.App.DeleteExpiredArchivedPosts()
+		require.Nil(t, appErr)
+		assert.Equal(t, int64(0), deleted)
+	})
+
+	th.App.SetLicense(model.NewTestLicense("data_retention"))
+
+	deleted, appErr := th.App.DeleteExpiredArchivedPosts()
+	require.Nil(t, appErr)
+	assert.Equal(t, int64(2), deleted)
+
+	archivePaths, appErr := th.App.Srv.Store.Post().GetArchivePaths([]string{expiredRoot.Id, oldRoot.Id})
+	require.Nil(t, appErr)
+	require.Len(t, archivePaths, 1)
+	newPath := archivePaths[oldRoot.Id]
+	assert.NotEqual(t, paths[0], newPath)
+
+	exists, appErr := th.App.FileExists(paths[0])
+	require.Nil(t, appErr)
+	assert.False(t, exists)
+
+	restored, appErr := th.App.RestoreArchivedPosts(th.BasicChannel.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, int64(1), restored)
+
+	_, appErr = th.App.Srv.Store.Post().GetSingle(oldRoot.Id)
+	require.Nil(t, appErr)
+	_, appErr = th.App.Srv.Store.Post().GetSingle(expiredRoot.Id)
+	require.NotNil(t, appErr)
+}
@@ -46,8 +46,18 @@ func (a *App) PreparePostListForClient(originalList *model.PostList) *model.Post
 		PrevPostId: originalList.PrevPostId,
 	}
 
+	postIds := make([]string, 0, len(originalList.Posts))
+	for id := range originalList.Posts {
+		postIds = append(postIds, id)
+	}
+
+	priorityMetadata, err := a.getPriorityMetadataForPosts(postIds)
+	if err != nil {
+		mlog.Warn("Failed to get priorities for a post list", mlog.Err(err))
+	}
+
 	for id, originalPost := range originalList.Posts {
-		post := a.PreparePostForClient(originalPost, false, false)
+		post := a.preparePostForClient(originalPost, false, false, priorityMetadata)
 
 		list.Posts[id] = post
 	}
@@ -78,6 +88,20 @@ func (a *App) OverrideIconURLIfEmoji(post *model.Post) {
 }
 
 func (a *App) PreparePostForClient(originalPost *model.Post, isNewPost bool, isEditPost bool) *model.Post {
+	var priorityMetadata *postPriorityMetadata
+	if !isNewPost {
+		var err *model.AppError
+		if priorityMetadata, err = a.getPriorityMetadataForPosts([]string{originalPost.Id}); err != nil {
+			mlog.Warn("Failed to get priority for a post", mlog.String("post_id", originalPost.Id), mlog.Err(err))
+		}
+	}
+
+	return a.preparePostForClient(originalPost, isNewPost, isEditPost, priorityMetadata)
+}
+
+// preparePostForClient adds metadata to a copy of the post, taking its priority and acknowledgements from
+// priorityMetadata unless it is a new post, which still carries its priority in its props.
+func (a *App) preparePostForClient(originalPost *model.Post, isNewPost bool, isEditPost bool, priorityMetadata *postPriorityMetadata) *model.Post {
 	post := originalPost.Clone()
 
 	// Proxy image links before constructing metadata so that requests go through the proxy
@@ -85,8 +109,20 @@ func (a *App) PreparePostForClient(originalPost *model.Post, isNewPost bool, isE
 
 	a.OverrideIconURLIfEmoji(post)
 
+	newPostPriority := post.GetPriority()
+
 	post.Metadata = &model.PostMetadata{}
 
+	// Priority and acknowledgements
+	if isNewPost {
+		post.Metadata.Priority = newPostPriority
+	} else if priorityMetadata != nil {
+		post.Metadata.Priority = priorityMetadata.priorities[post.Id]
+		if post.Metadata.Priority != nil && post.Metadata.Priority.RequestedAck {
+			post.Metadata.Acknowledgements = priorityMetadata.acknowledgements[post.Id]
+		}
+	}
+
 	// Emojis and reaction counts
 	if emojis, reactions, err := a.getEmojisAndReactionsForPost(post); err != nil {
 		mlog.Warn("Failed to get emojis and reactions for a post", mlog.String("post_id", post.Id), mlog.Err(err))
@@ -447,7 +483,7 @@ func getLinkMetadataFromCache(requestURL string, timestamp int64) (*opengraph.Op
 }
 
 func (a *App) getLinkMetadataFromDatabase(requestURL string, timestamp int64) (*opengraph.OpenGraph, *model.PostImage, bool) {
-	linkMetadata, err := a.Srv.Store.LinkMetadata().Get(requestURL, timestamp)
+	linkMetadata, err := a.Store().LinkMetadata().Get(requestURL, timestamp)
 	if err != nil {
 		return nil, nil, false
 	}
@@ -480,7 +516,7 @@ func (a *App) saveLinkMetadataToDatabase(requestURL string, timestamp int64, og
 		metadata.Type = model.LINK_METADATA_TYPE_NONE
 	}
 
-	_, err := a.Srv.Store.LinkMetadata().Save(metadata)
+	_, err := a.Store().LinkMetadata().Save(metadata)
 	if err != nil {
 		mlog.Warn("Failed to write link metadata", mlog.String("request_url", requestURL), mlog.Err(err))
 	}
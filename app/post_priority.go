@@ -0,0 +1,328 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// validatePostPriority checks the priority requested for a new post. Only root posts can be given a priority, and
+// only while urgent priority is enabled can they be urgent.
+func (a *App) validatePostPriority(post *model.Post) *model.AppError {
+	priority := post.GetPriority()
+	if priority == nil {
+		return nil
+	}
+
+	if post.RootId != "" {
+		return model.NewAppError("validatePostPriority", "app.post_priority.reply.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	switch priority.Priority {
+	case "", model.POST_PRIORITY_STANDARD, model.POST_PRIORITY_IMPORTANT:
+	case model.POST_PRIORITY_URGENT:
+		if !*a.Config().ServiceSettings.EnableUrgentPostPriority {
+			return model.NewAppError("validatePostPriority", "app.post_priority.urgent_disabled.app_error", nil, "", http.StatusNotImplemented)
+		}
+	default:
+		return model.NewAppError("validatePostPriority", "model.post_priority.is_valid.priority.app_error", nil, "priority="+priority.Priority, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// savePostPriority stores the priority of a newly created post. Nothing is stored for standard posts that don't
+// request acknowledgement.
+func (a *App) savePostPriority(post *model.Post) *model.AppError {
+	priority := post.GetPriority()
+	if priority == nil {
+		return nil
+	}
+
+	if (priority.Priority == "" || priority.Priority == model.POST_PRIORITY_STANDARD) && !priority.RequestedAck {
+		post.Metadata.Priority = nil
+		return nil
+	}
+
+	priority.PostId = post.Id
+	priority.ChannelId = post.ChannelId
+	priority.LastNotifiedAt = post.CreateAt
+	priority.NotificationCount = 0
+
+	_, err := a.Store().PostPriority().Save(priority)
+	return err
+}
+
+// getPriorityForPost returns the priority of the given post or nil if it doesn't have one. New posts still carry
+// the priority they were created with, so it isn't loaded from the database for them.
+func (a *App) getPriorityForPost(post *model.Post, isNewPost bool) (*model.PostPriority, *model.AppError) {
+	if isNewPost {
+		return post.GetPriority(), nil
+	}
+
+	priority, err := a.Store().PostPriority().Get(post.Id)
+	if err != nil {
+		if err.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return priority, nil
+}
+
+func (a *App) AcknowledgePost(userId, postId string) (*model.PostAcknowledgement, *model.AppError) {
+	post, err := a.GetSinglePost(postId)
+	if err != nil {
+		return nil, err
+	}
+
+	priority, err := a.getPriorityForPost(post, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if priority == nil || !priority.RequestedAck {
+		return nil, model.NewAppError("AcknowledgePost", "app.post_acknowledgement.not_requested.app_error", nil, "post_id="+postId, http.StatusBadRequest)
+	}
+
+	acknowledgement, err := a.Store().PostAcknowledgement().Save(&model.PostAcknowledgement{PostId: postId, UserId: userId})
+	if err != nil {
+		return nil, err
+	}
+
+	a.Srv.Go(func() {
+		a.sendAcknowledgementEvent(model.WEBSOCKET_EVENT_ACKNOWLEDGEMENT_ADDED, acknowledgement, post)
+	})
+
+	return acknowledgement, nil
+}
+
+func (a *App) UnacknowledgePost(userId, postId string) *model.AppError {
+	post, err := a.GetSinglePost(postId)
+	if err != nil {
+		return err
+	}
+
+	if err = a.Store().PostAcknowledgement().Delete(postId, userId); err != nil {
+		return err
+	}
+
+	a.Srv.Go(func() {
+		a.sendAcknowledgementEvent(model.WEBSOCKET_EVENT_ACKNOWLEDGEMENT_REMOVED, &model.PostAcknowledgement{PostId: postId, UserId: userId}, post)
+	})
+
+	return nil
+}
+
+func (a *App) sendAcknowledgementEvent(event string, acknowledgement *model.PostAcknowledgement, post *model.Post) {
+	message := model.NewWebSocketEvent(event, "", post.ChannelId, "", nil)
+	message.Add("acknowledgement", acknowledgement.ToJson())
+	a.Publish(message)
+}
+
+// postPriorityMetadata holds the priorities and acknowledgements of a set of posts so that they can be loaded
+// with one query each instead of once per post.
+type postPriorityMetadata struct {
+	priorities       map[string]*model.PostPriority
+	acknowledgements map[string][]*model.PostAcknowledgement
+}
+
+// getPriorityMetadataForPosts loads the priorities of the given posts and the acknowledgements of those that requested them.
+func (a *App) getPriorityMetadataForPosts(postIds []string) (*postPriorityMetadata, *model.AppError) {
+	metadata := &postPriorityMetadata{
+		priorities:       make(map[string]*model.PostPriority),
+		acknowledgements: make(map[string][]*model.PostAcknowledgement),
+	}
+	if len(postIds) == 0 {
+		return metadata, nil
+	}
+
+	priorities, err := a.Store().PostPriority().GetForPosts(postIds)
+	if err != nil {
+		return nil, err
+	}
+
+	ackPostIds := []string{}
+	for _, priority := range priorities {
+		metadata.priorities[priority.PostId] = priority
+		if priority.RequestedAck {
+			ackPostIds = append(ackPostIds, priority.PostId)
+		}
+	}
+	if len(ackPostIds) == 0 {
+		return metadata, nil
+	}
+
+	acknowledgements, err := a.Store().PostAcknowledgement().GetForPosts(ackPostIds)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, acknowledgement := range acknowledgements {
+		metadata.acknowledgements[acknowledgement.PostId] = append(metadata.acknowledgements[acknowledgement.PostId], acknowledgement)
+	}
+
+	return metadata, nil
+}
+
+func (a *App) GetPostAcknowledgements(postId string) ([]*model.PostAcknowledgement, *model.AppError) {
+	return a.Store().PostAcknowledgement().GetForPost(postId)
+}
+
+// GetPostAcknowledgementStatus returns who has acknowledged the post and which other members of the channel
+// haven't done so yet.
+func (a *App) GetPostAcknowledgementStatus(post *model.Post) (*model.PostAcknowledgementStatus, *model.AppError) {
+	acknowledgements, err := a.GetPostAcknowledgements(post.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles, err := a.Store().User().GetAllProfilesInChannel(post.ChannelId, true)
+	if err != nil {
+		return nil, err
+	}
+
+	acknowledged := make(map[string]bool, len(acknowledgements))
+	for _, acknowledgement := range acknowledgements {
+		acknowledged[acknowledgement.UserId] = true
+	}
+
+	pending := []string{}
+	for userId, profile := range profiles {
+		if userId != post.UserId && !acknowledged[userId] && !profile.IsBot && profile.DeleteAt == 0 {
+			pending = append(pending, userId)
+		}
+	}
+
+	return &model.PostAcknowledgementStatus{
+		PostId:           post.Id,
+		Acknowledgements: acknowledgements,
+		PendingUserIds:   pending,
+	}, nil
+}
+
+// getUrgentPostRecipients returns the ids of the users who are notified persistently about an urgent post. Those
+// are the other member of a direct channel or the users explicitly mentioned in any other channel. Channel wide
+// mentions are left out so that a single post can't repeatedly notify an entire channel.
+func (a *App) getUrgentPostRecipients(post *model.Post, channel *model.Channel, profileMap map[string]*model.User, channelMemberNotifyPropsMap map[string]model.StringMap) []string {
+	if channel.Type == model.CHANNEL_DIRECT {
+		otherUserId := channel.GetOtherUserIdForDM(post.UserId)
+		if _, ok := profileMap[otherUserId]; ok {
+			return []string{otherUserId}
+		}
+		return []string{}
+	}
+
+	keywords := a.getMentionKeywordsInChannel(profileMap, false, channelMemberNotifyPropsMap)
+	m := getExplicitMentions(post, keywords)
+
+	recipients := make([]string, 0, len(m.MentionedUserIds))
+	for userId := range m.MentionedUserIds {
+		if userId != post.UserId {
+			recipients = append(recipients, userId)
+		}
+	}
+
+	return recipients
+}
+
+// SendPersistentNotifications resends push notifications for urgent posts to the recipients who haven't
+// acknowledged them yet, at most once per configured interval and up to the configured number of times.
+func (a *App) SendPersistentNotifications() *model.AppError {
+	cfg := a.Config()
+	if !*cfg.EmailSettings.SendPushNotifications || *cfg.ServiceSettings.PersistentNotificationMaxCount == 0 {
+		return nil
+	}
+
+	now := model.GetMillis()
+	interval := int64(*cfg.ServiceSettings.PersistentNotificationIntervalMinutes) * 60 * 1000
+
+	priorities, err := a.Store().PostPriority().GetForPersistentNotifications(now-interval, *cfg.ServiceSettings.PersistentNotificationMaxCount)
+	if err != nil {
+		return err
+	}
+
+	for _, priority := range priorities {
+		if err := a.sendPersistentNotification(priority.PostId); err != nil {
+			mlog.Warn("Failed to send persistent notification", mlog.String("post_id", priority.PostId), mlog.Err(err))
+		}
+
+		if err := a.Store().PostPriority().MarkNotified(priority.PostId, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *App) sendPersistentNotification(postId string) *model.AppError {
+	post, err := a.GetSinglePost(postId)
+	if err != nil {
+		return err
+	}
+
+	channel, err := a.GetChannel(post.ChannelId)
+	if err != nil {
+		return err
+	}
+
+	if channel.DeleteAt > 0 {
+		return nil
+	}
+
+	sender, err := a.GetUser(post.UserId)
+	if err != nil {
+		return err
+	}
+
+	profileMap, err := a.Store().User().GetAllProfilesInChannel(channel.Id, true)
+	if err != nil {
+		return err
+	}
+
+	channelMemberNotifyPropsMap, err := a.Store().Channel().GetAllChannelMembersNotifyPropsForChannel(channel.Id, true)
+	if err != nil {
+		return err
+	}
+
+	acknowledgements, err := a.GetPostAcknowledgements(post.Id)
+	if err != nil {
+		return err
+	}
+
+	acknowledged := make(map[string]bool, len(acknowledgements))
+	for _, acknowledgement := range acknowledgements {
+		acknowledged[acknowledgement.UserId] = true
+	}
+
+	post = a.PreparePostForClient(post, false, false)
+
+	notification := &postNotification{
+		post:       post,
+		channel:    channel,
+		profileMap: profileMap,
+		sender:     sender,
+	}
+
+	for _, userId := range a.getUrgentPostRecipients(post, channel, profileMap, channelMemberNotifyPropsMap) {
+		if acknowledged[userId] {
+			continue
+		}
+
+		status, err := a.GetStatus(userId)
+		if err != nil {
+			status = &model.Status{UserId: userId, Status: model.STATUS_OFFLINE, Manual: false, LastActivityAt: 0, ActiveChannel: ""}
+		}
+
+		if ShouldSendPushNotification(profileMap[userId], channelMemberNotifyPropsMap[userId], true, status, post) {
+			a.sendPushNotification(notification, profileMap[userId], true, false, "")
+		}
+	}
+
+	return nil
+}
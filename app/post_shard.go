@@ -0,0 +1,123 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/mattermost/mattermost-server/store/shardlayer"
+	"github.com/mattermost/mattermost-server/store/sqlstore"
+)
+
+// POST_SHARD_REBALANCE_BATCH_SIZE is the number of channels checked for their shard at once.
+const POST_SHARD_REBALANCE_BATCH_SIZE = 1000
+
+// postShardMoveGracePeriod is how long moved channels are left on their previous shard, until no
+// server routes them there anymore.
+var postShardMoveGracePeriod = shardlayer.CHANNEL_SHARD_CACHE_SEC * time.Second
+
+// newPostShardLayer wraps the store in the shard layer when post shards are configured, keeping
+// the posts of each channel on one of them.
+func (s *Server) newPostShardLayer(baseStore store.Store) store.Store {
+	settings := s.FakeApp().Config().SqlSettings
+	if len(settings.PostShardDataSources) == 0 {
+		s.postShardStore = nil
+		return baseStore
+	}
+
+	shards := make(map[string]store.Store, len(settings.PostShardDataSources))
+	for name, dataSource := range settings.PostShardDataSources {
+		shardSettings := settings
+		shardSettings.DataSource = model.NewString(dataSource)
+		shardSettings.DataSourceReplicas = []string{}
+		shardSettings.DataSourceSearchReplicas = []string{}
+		shards[name] = store.NewLayeredStore(sqlstore.NewSqlSupplier(shardSettings, s.Metrics), s.Metrics, nil)
+	}
+
+	shardStore := shardlayer.NewShardLayer(baseStore, shards, *settings.PostShardKey)
+	s.postShardStore = &shardStore
+
+	return shardStore
+}
+
+// RebalancePostShards moves the posts of each channel to the shard it is placed on by the current
+// shards, such as after a shard is added. Moved channels are left on their previous shard for a
+// while, as other servers keep routing them there until their cache expires. It returns the
+// number of channels moved.
+func (a *App) RebalancePostShards() (int64, *model.AppError) {
+	shardStore := a.Srv.postShardStore
+	if shardStore == nil {
+		return 0, model.NewAppError("RebalancePostShards", "app.post_shard.rebalance.not_configured.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	// Finish the moves left over by an earlier run.
+	leftOver, err := a.Srv.Store.PostShard().GetMovedChannelShards()
+	if err != nil {
+		return 0, err
+	}
+	if len(leftOver) > 0 {
+		var lastMoveAt int64
+		for _, channelShard := range leftOver {
+			if channelShard.UpdateAt > lastMoveAt {
+				lastMoveAt = channelShard.UpdateAt
+			}
+		}
+		if wait := time.Duration(lastMoveAt-model.GetMillis())*time.Millisecond + postShardMoveGracePeriod; wait > 0 {
+			time.Sleep(wait)
+		}
+
+		for _, channelShard := range leftOver {
+			if err := shardStore.FinishMove(channelShard.ChannelId); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var moved []string
+	afterChannelId := ""
+	for {
+		channelShards, err := a.Srv.Store.PostShard().GetChannelShardsAfter(afterChannelId, POST_SHARD_REBALANCE_BATCH_SIZE)
+		if err != nil {
+			return int64(len(moved)), err
+		}
+
+		for _, channelShard := range channelShards {
+			afterChannelId = channelShard.ChannelId
+
+			shard := shardStore.PickChannelShard(channelShard.ChannelId, channelShard.TeamId)
+			if shard == channelShard.Shard {
+				continue
+			}
+
+			if err := shardStore.MoveChannel(channelShard.ChannelId, shard); err != nil {
+				return int64(len(moved)), err
+			}
+			moved = append(moved, channelShard.ChannelId)
+
+			mlog.Debug("Moved the posts of a channel to another shard", mlog.String("channel_id", channelShard.ChannelId), mlog.String("from", channelShard.Shard), mlog.String("to", shard))
+		}
+
+		if len(channelShards) < POST_SHARD_REBALANCE_BATCH_SIZE {
+			break
+		}
+	}
+
+	if len(moved) == 0 {
+		return 0, nil
+	}
+
+	time.Sleep(postShardMoveGracePeriod)
+
+	for _, channelId := range moved {
+		if err := shardStore.FinishMove(channelId); err != nil {
+			return int64(len(moved)), err
+		}
+	}
+
+	return int64(len(moved)), nil
+}
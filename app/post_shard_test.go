@@ -0,0 +1,80 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/mattermost/mattermost-server/store/shardlayer"
+	"github.com/mattermost/mattermost-server/store/sqlstore"
+	"github.com/mattermost/mattermost-server/store/storetest"
+)
+
+func TestRebalancePostShards(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("without shards", func(t *testing.T) {
+		_, appErr := th.App.RebalancePostShards()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.post_shard.rebalance.not_configured.app_error", appErr.Id)
+	})
+
+	if *th.App.Config().SqlSettings.DriverName != model.DATABASE_DRIVER_SQLITE {
+		t.Skip("the shards are tested as SQLite databases")
+	}
+
+	post := th.CreatePost(th.BasicChannel)
+
+	shards := make(map[string]store.Store)
+	for _, name := range []string{"shard1", "shard2"} {
+		settings := storetest.MakeSqlSettings(model.DATABASE_DRIVER_SQLITE)
+		defer os.Remove(strings.TrimPrefix(strings.SplitN(*settings.DataSource, "?", 2)[0], "file:"))
+		shards[name] = store.NewLayeredStore(sqlstore.NewSqlSupplier(*settings, nil), nil, nil)
+	}
+
+	mainStore := th.App.Srv.Store
+	shardStore := shardlayer.NewShardLayer(mainStore, shards, model.POST_SHARD_KEY_CHANNEL)
+	th.App.Srv.Store = shardStore
+	th.App.Srv.postShardStore = &shardStore
+	defer func() {
+		th.App.Srv.Store = mainStore
+		th.App.Srv.postShardStore = nil
+		for _, shard := range shards {
+			shard.Close()
+		}
+	}()
+
+	gracePeriod := postShardMoveGracePeriod
+	postShardMoveGracePeriod = 0
+	defer func() {
+		postShardMoveGracePeriod = gracePeriod
+	}()
+
+	// The channels created before the shards were configured are all in the main database.
+	moved, appErr := th.App.RebalancePostShards()
+	require.Nil(t, appErr)
+	assert.NotZero(t, moved)
+
+	shard := shardStore.PickChannelShard(th.BasicChannel.Id, th.BasicChannel.TeamId)
+	_, appErr = shards[shard].Post().GetSingle(post.Id)
+	require.Nil(t, appErr)
+	_, appErr = mainStore.Post().GetSingle(post.Id)
+	require.NotNil(t, appErr)
+
+	found, appErr := th.App.GetSinglePost(post.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, post.Message, found.Message)
+
+	moved, appErr = th.App.RebalancePostShards()
+	require.Nil(t, appErr)
+	assert.Zero(t, moved)
+}
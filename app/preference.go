@@ -10,7 +10,7 @@ import (
 )
 
 func (a *App) GetPreferencesForUser(userId string) (model.Preferences, *model.AppError) {
-	preferences, err := a.Srv.Store.Preference().GetAll(userId)
+	preferences, err := a.Store().Preference().GetAll(userId)
 	if err != nil {
 		err.StatusCode = http.StatusBadRequest
 		return nil, err
@@ -19,7 +19,7 @@ func (a *App) GetPreferencesForUser(userId string) (model.Preferences, *model.Ap
 }
 
 func (a *App) GetPreferenceByCategoryForUser(userId string, category string) (model.Preferences, *model.AppError) {
-	preferences, err := a.Srv.Store.Preference().GetCategory(userId, category)
+	preferences, err := a.Store().Preference().GetCategory(userId, category)
 	if err != nil {
 		err.StatusCode = http.StatusBadRequest
 		return nil, err
@@ -32,7 +32,7 @@ func (a *App) GetPreferenceByCategoryForUser(userId string, category string) (mo
 }
 
 func (a *App) GetPreferenceByCategoryAndNameForUser(userId string, category string, preferenceName string) (*model.Preference, *model.AppError) {
-	res, err := a.Srv.Store.Preference().Get(userId, category, preferenceName)
+	res, err := a.Store().Preference().Get(userId, category, preferenceName)
 	if err != nil {
 		err.StatusCode = http.StatusBadRequest
 		return nil, err
@@ -48,7 +48,7 @@ func (a *App) UpdatePreferences(userId string, preferences model.Preferences) *m
 		}
 	}
 
-	if err := a.Srv.Store.Preference().Save(&preferences); err != nil {
+	if err := a.Store().Preference().Save(&preferences); err != nil {
 		err.StatusCode = http.StatusBadRequest
 		return err
 	}
@@ -70,7 +70,7 @@ func (a *App) DeletePreferences(userId string, preferences model.Preferences) *m
 	}
 
 	for _, preference := range preferences {
-		if err := a.Srv.Store.Preference().Delete(userId, preference.Category, preference.Name); err != nil {
+		if err := a.Store().Preference().Delete(userId, preference.Category, preference.Name); err != nil {
 			err.StatusCode = http.StatusBadRequest
 			return err
 		}
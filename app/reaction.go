@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/plugin"
 )
 
 func (a *App) SaveReactionForPost(reaction *model.Reaction) (*model.Reaction, *model.AppError) {
@@ -36,7 +37,7 @@ func (a *App) SaveReactionForPost(reaction *model.Reaction) (*model.Reaction, *m
 		}
 	}
 
-	reaction, err = a.Srv.Store.Reaction().Save(reaction)
+	reaction, err = a.Store().Reaction().Save(reaction)
 	if err != nil {
 		return nil, err
 	}
@@ -48,17 +49,27 @@ func (a *App) SaveReactionForPost(reaction *model.Reaction) (*model.Reaction, *m
 		a.sendReactionEvent(model.WEBSOCKET_EVENT_REACTION_ADDED, reaction, post, true)
 	})
 
+	if pluginsEnvironment := a.GetPluginsEnvironment(); pluginsEnvironment != nil {
+		a.Srv.Go(func() {
+			pluginContext := a.PluginContext()
+			pluginsEnvironment.RunMultiPluginHook(func(hooks plugin.Hooks) bool {
+				hooks.ReactionHasBeenAdded(pluginContext, reaction)
+				return true
+			}, plugin.ReactionHasBeenAddedId)
+		})
+	}
+
 	return reaction, nil
 }
 
 func (a *App) GetReactionsForPost(postId string) ([]*model.Reaction, *model.AppError) {
-	return a.Srv.Store.Reaction().GetForPost(postId, true)
+	return a.Store().Reaction().GetForPost(postId, true)
 }
 
 func (a *App) GetBulkReactionsForPosts(postIds []string) (map[string][]*model.Reaction, *model.AppError) {
 	reactions := make(map[string][]*model.Reaction)
 
-	allReactions, err := a.Srv.Store.Reaction().BulkGetForPosts(postIds)
+	allReactions, err := a.Store().Reaction().BulkGetForPosts(postIds)
 	if err != nil {
 		return nil, err
 	}
@@ -114,7 +125,7 @@ func (a *App) DeleteReactionForPost(reaction *model.Reaction) *model.AppError {
 		hasReactions = false
 	}
 
-	if _, err := a.Srv.Store.Reaction().Delete(reaction); err != nil {
+	if _, err := a.Store().Reaction().Delete(reaction); err != nil {
 		return err
 	}
 
@@ -125,6 +136,16 @@ func (a *App) DeleteReactionForPost(reaction *model.Reaction) *model.AppError {
 		a.sendReactionEvent(model.WEBSOCKET_EVENT_REACTION_REMOVED, reaction, post, hasReactions)
 	})
 
+	if pluginsEnvironment := a.GetPluginsEnvironment(); pluginsEnvironment != nil {
+		a.Srv.Go(func() {
+			pluginContext := a.PluginContext()
+			pluginsEnvironment.RunMultiPluginHook(func(hooks plugin.Hooks) bool {
+				hooks.ReactionHasBeenRemoved(pluginContext, reaction)
+				return true
+			}, plugin.ReactionHasBeenRemovedId)
+		})
+	}
+
 	return nil
 }
 
@@ -0,0 +1,151 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// ReadReceiptsEnabledForUser returns false if the user has opted out of read receipts. Users who have
+// opted out neither share how far they've read nor see how far others have.
+func (a *App) ReadReceiptsEnabledForUser(userId string) bool {
+	pref, err := a.Store().Preference().Get(userId, model.PREFERENCE_CATEGORY_PRIVACY, model.PREFERENCE_NAME_READ_RECEIPTS)
+	if err != nil {
+		return true
+	}
+	return pref.Value != model.PREFERENCE_READ_RECEIPTS_HIDDEN
+}
+
+// getReadReceiptWatermarks returns the current LastViewedAt of the user for each of the given channels that
+// supports read receipts, along with the channel members it loaded to find them. Channels for which no receipts
+// should be sent are left out, and nothing is loaded beyond the cached channels unless one of them is a direct
+// or group message channel.
+func (a *App) getReadReceiptWatermarks(channelIds []string, userId string) (map[string]int64, map[string]*model.ChannelMember) {
+	watermarks := map[string]int64{}
+	members := map[string]*model.ChannelMember{}
+	if !*a.Config().ServiceSettings.EnableReadReceipts {
+		return watermarks, members
+	}
+
+	receiptChannelIds := []string{}
+	for _, channelId := range channelIds {
+		channel, err := a.Store().Channel().Get(channelId, true)
+		if err != nil {
+			mlog.Warn("Failed to get channel for read receipts", mlog.String("channel_id", channelId), mlog.Err(err))
+			continue
+		}
+
+		if channel.IsGroupOrDirect() {
+			receiptChannelIds = append(receiptChannelIds, channelId)
+		}
+	}
+
+	if len(receiptChannelIds) == 0 || !a.ReadReceiptsEnabledForUser(userId) {
+		return watermarks, members
+	}
+
+	for _, channelId := range receiptChannelIds {
+		member, err := a.Store().Channel().GetMember(channelId, userId)
+		if err != nil {
+			mlog.Warn("Failed to get channel member for read receipts", mlog.String("channel_id", channelId), mlog.Err(err))
+			continue
+		}
+
+		members[channelId] = member
+		watermarks[channelId] = member.LastViewedAt
+	}
+
+	return watermarks, members
+}
+
+// sendReadReceiptEvents notifies the other members of each channel whose read watermark has moved forward
+// since it was captured by getReadReceiptWatermarks.
+func (a *App) sendReadReceiptEvents(previous map[string]int64, current map[string]int64, userId string) {
+	for channelId, lastViewedAt := range previous {
+		newLastViewedAt, ok := current[channelId]
+		if !ok || newLastViewedAt <= lastViewedAt {
+			continue
+		}
+
+		omitUsers, err := a.getReadReceiptsOptedOutMembers(channelId)
+		if err != nil {
+			mlog.Warn("Failed to get channel members for read receipts", mlog.String("channel_id", channelId), mlog.Err(err))
+			continue
+		}
+		omitUsers[userId] = true
+
+		message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_READ_RECEIPT, "", channelId, "", omitUsers)
+		message.Add("user_id", userId)
+		message.Add("last_viewed_at", newLastViewedAt)
+		a.Publish(message)
+	}
+}
+
+func (a *App) getReadReceiptsOptedOutMembers(channelId string) (map[string]bool, *model.AppError) {
+	members, err := a.Store().Channel().GetMembers(channelId, 0, model.CHANNEL_GROUP_MAX_USERS)
+	if err != nil {
+		return nil, err
+	}
+
+	optedOut := map[string]bool{}
+	for _, member := range *members {
+		if !a.ReadReceiptsEnabledForUser(member.UserId) {
+			optedOut[member.UserId] = true
+		}
+	}
+
+	return optedOut, nil
+}
+
+// GetPostReadReceipts returns the members of the post's channel, other than its author, that have viewed the
+// channel since the post was made. Only direct and group message channels have read receipts.
+func (a *App) GetPostReadReceipts(post *model.Post, userId string) (*model.PostReadReceipts, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableReadReceipts {
+		return nil, model.NewAppError("GetPostReadReceipts", "app.read_receipt.feature_disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	channel, err := a.GetChannel(post.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !channel.IsGroupOrDirect() {
+		return nil, model.NewAppError("GetPostReadReceipts", "app.read_receipt.channel_type.app_error", nil, "channel_id="+channel.Id, http.StatusBadRequest)
+	}
+
+	if !a.ReadReceiptsEnabledForUser(userId) {
+		return nil, model.NewAppError("GetPostReadReceipts", "app.read_receipt.disabled.app_error", nil, "user_id="+userId, http.StatusForbidden)
+	}
+
+	members, err := a.Store().Channel().GetMembers(channel.Id, 0, model.CHANNEL_GROUP_MAX_USERS)
+	if err != nil {
+		return nil, err
+	}
+
+	receipts := &model.PostReadReceipts{
+		PostId:    post.Id,
+		ChannelId: post.ChannelId,
+		ReadBy:    []*model.ReadReceipt{},
+	}
+
+	for _, member := range *members {
+		if member.UserId == post.UserId || member.LastViewedAt < post.CreateAt {
+			continue
+		}
+
+		if member.UserId != userId && !a.ReadReceiptsEnabledForUser(member.UserId) {
+			continue
+		}
+
+		receipts.ReadBy = append(receipts.ReadBy, &model.ReadReceipt{
+			UserId:       member.UserId,
+			LastViewedAt: member.LastViewedAt,
+		})
+	}
+
+	return receipts, nil
+}
@@ -14,7 +14,7 @@ func (a *App) GetScheme(id string) (*model.Scheme, *model.AppError) {
 		return nil, err
 	}
 
-	return a.Srv.Store.Scheme().Get(id)
+	return a.Store().Scheme().Get(id)
 }
 
 func (a *App) GetSchemeByName(name string) (*model.Scheme, *model.AppError) {
@@ -22,7 +22,7 @@ func (a *App) GetSchemeByName(name string) (*model.Scheme, *model.AppError) {
 		return nil, err
 	}
 
-	return a.Srv.Store.Scheme().GetByName(name)
+	return a.Store().Scheme().GetByName(name)
 }
 
 func (a *App) GetSchemesPage(scope string, page int, perPage int) ([]*model.Scheme, *model.AppError) {
@@ -38,7 +38,7 @@ func (a *App) GetSchemes(scope string, offset int, limit int) ([]*model.Scheme,
 		return nil, err
 	}
 
-	return a.Srv.Store.Scheme().GetAllPage(scope, offset, limit)
+	return a.Store().Scheme().GetAllPage(scope, offset, limit)
 }
 
 func (a *App) CreateScheme(scheme *model.Scheme) (*model.Scheme, *model.AppError) {
@@ -57,7 +57,7 @@ func (a *App) CreateScheme(scheme *model.Scheme) (*model.Scheme, *model.AppError
 	scheme.UpdateAt = 0
 	scheme.DeleteAt = 0
 
-	return a.Srv.Store.Scheme().Save(scheme)
+	return a.Store().Scheme().Save(scheme)
 }
 
 func (a *App) PatchScheme(scheme *model.Scheme, patch *model.SchemePatch) (*model.Scheme, *model.AppError) {
@@ -79,7 +79,7 @@ func (a *App) UpdateScheme(scheme *model.Scheme) (*model.Scheme, *model.AppError
 		return nil, err
 	}
 
-	return a.Srv.Store.Scheme().Save(scheme)
+	return a.Store().Scheme().Save(scheme)
 }
 
 func (a *App) DeleteScheme(schemeId string) (*model.Scheme, *model.AppError) {
@@ -87,7 +87,7 @@ func (a *App) DeleteScheme(schemeId string) (*model.Scheme, *model.AppError) {
 		return nil, err
 	}
 
-	return a.Srv.Store.Scheme().Delete(schemeId)
+	return a.Store().Scheme().Delete(schemeId)
 }
 
 func (a *App) GetTeamsForSchemePage(scheme *model.Scheme, page int, perPage int) ([]*model.Team, *model.AppError) {
@@ -103,7 +103,7 @@ func (a *App) GetTeamsForScheme(scheme *model.Scheme, offset int, limit int) ([]
 		return nil, err
 	}
 
-	teams, err := a.Srv.Store.Team().GetTeamsByScheme(scheme.Id, offset, limit)
+	teams, err := a.Store().Team().GetTeamsByScheme(scheme.Id, offset, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -122,7 +122,7 @@ func (a *App) GetChannelsForScheme(scheme *model.Scheme, offset int, limit int)
 	if err := a.IsPhase2MigrationCompleted(); err != nil {
 		return nil, err
 	}
-	return a.Srv.Store.Channel().GetChannelsByScheme(scheme.Id, offset, limit)
+	return a.Store().Channel().GetChannelsByScheme(scheme.Id, offset, limit)
 }
 
 func (a *App) IsPhase2MigrationCompleted() *model.AppError {
@@ -130,7 +130,7 @@ func (a *App) IsPhase2MigrationCompleted() *model.AppError {
 		return nil
 	}
 
-	if _, err := a.Srv.Store.System().GetByName(model.MIGRATION_KEY_ADVANCED_PERMISSIONS_PHASE_2); err != nil {
+	if _, err := a.Store().System().GetByName(model.MIGRATION_KEY_ADVANCED_PERMISSIONS_PHASE_2); err != nil {
 		return model.NewAppError("App.IsPhase2MigrationCompleted", "app.schemes.is_phase_2_migration_completed.not_completed.app_error", nil, err.Error(), http.StatusNotImplemented)
 	}
 
@@ -142,7 +142,7 @@ func (a *App) IsPhase2MigrationCompleted() *model.AppError {
 func (a *App) SchemesIterator(batchSize int) func() []*model.Scheme {
 	offset := 0
 	return func() []*model.Scheme {
-		schemes, err := a.Srv.Store.Scheme().GetAllPage("", offset, batchSize)
+		schemes, err := a.Store().Scheme().GetAllPage("", offset, batchSize)
 		if err != nil {
 			return []*model.Scheme{}
 		}
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"reflect"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -33,7 +34,9 @@ import (
 	"github.com/mattermost/mattermost-server/services/httpservice"
 	"github.com/mattermost/mattermost-server/services/imageproxy"
 	"github.com/mattermost/mattermost-server/services/timezones"
+	"github.com/mattermost/mattermost-server/services/tracing"
 	"github.com/mattermost/mattermost-server/store"
+	"github.com/mattermost/mattermost-server/store/shardlayer"
 	"github.com/mattermost/mattermost-server/utils"
 )
 
@@ -84,8 +87,12 @@ type Server struct {
 
 	newStore func() store.Store
 
+	// postShardStore is the shard layer of the store, when post shards are configured.
+	postShardStore *shardlayer.ShardStore
+
 	htmlTemplateWatcher     *utils.HTMLTemplateWatcher
 	sessionCache            *utils.Cache
+	sessionWriteCache       *utils.Cache
 	seenPendingPostIdsCache *utils.Cache
 	configListenerId        string
 	licenseListenerId       string
@@ -98,6 +105,9 @@ type Server struct {
 	pluginCommands     []*PluginCommand
 	pluginCommandsLock sync.RWMutex
 
+	pluginScheduledJobs     []*PluginJobScheduler
+	pluginScheduledJobsLock sync.RWMutex
+
 	clientConfig        map[string]string
 	clientConfigHash    string
 	limitedClientConfig map[string]string
@@ -111,6 +121,9 @@ type Server struct {
 
 	ImageProxy *imageproxy.ImageProxy
 
+	// tracerValue holds the tracer of the requests served, see Tracer.
+	tracerValue atomic.Value
+
 	Log              *mlog.Logger
 	NotificationsLog *mlog.Logger
 
@@ -137,6 +150,7 @@ func NewServer(options ...Option) (*Server, error) {
 		RootRouter:              rootRouter,
 		licenseListeners:        map[string]func(){},
 		sessionCache:            utils.NewLru(model.SESSION_CACHE_SIZE),
+		sessionWriteCache:       utils.NewLru(model.SESSION_CACHE_SIZE),
 		seenPendingPostIdsCache: utils.NewLru(PENDING_POST_IDS_CACHE_SIZE),
 		clientConfig:            make(map[string]string),
 	}
@@ -182,6 +196,19 @@ func NewServer(options ...Option) (*Server, error) {
 
 	s.ImageProxy = imageproxy.MakeImageProxy(s, s.HTTPService, s.Log)
 
+	if err := s.initTracer(s.Config().TracingSettings); err != nil {
+		return nil, errors.Wrap(err, "unable to start tracing")
+	}
+	s.AddConfigListener(func(oldConfig, newConfig *model.Config) {
+		if reflect.DeepEqual(oldConfig.TracingSettings, newConfig.TracingSettings) {
+			return
+		}
+
+		if err := s.initTracer(newConfig.TracingSettings); err != nil {
+			mlog.Error("Failed to restart tracing", mlog.Err(err))
+		}
+	})
+
 	if err := utils.TranslationsPreInit(); err != nil {
 		return nil, errors.Wrapf(err, "unable to load Mattermost translation files")
 	}
@@ -278,6 +305,15 @@ func NewServer(options ...Option) (*Server, error) {
 		s.Go(func() {
 			runCommandWebhookCleanupJob(s)
 		})
+		s.Go(func() {
+			runCustomStatusExpiryJob(s)
+		})
+		s.Go(func() {
+			runScheduledStatusesJob(s)
+		})
+		s.Go(func() {
+			runPersistentNotificationsJob(s)
+		})
 
 		if complianceI := s.Compliance; complianceI != nil {
 			complianceI.StartComplianceDailyJob()
@@ -364,10 +400,33 @@ func (s *Server) Shutdown() error {
 		s.Store.Close()
 	}
 
+	s.Tracer().Close()
+
 	mlog.Info("Server stopped")
 	return nil
 }
 
+// Tracer returns the tracer of the requests served, or nil if tracing is disabled.
+func (s *Server) Tracer() *tracing.Tracer {
+	tracer, _ := s.tracerValue.Load().(*tracing.Tracer)
+	return tracer
+}
+
+// initTracer starts tracing as configured, replacing the previous tracer. The spans of the requests
+// still traced by the previous tracer once it is closed are dropped.
+func (s *Server) initTracer(settings model.TracingSettings) error {
+	tracer, err := tracing.NewTracerFromSettings(settings)
+	if err != nil {
+		return err
+	}
+
+	previous := s.Tracer()
+	s.tracerValue.Store(tracer)
+	previous.Close()
+
+	return nil
+}
+
 // Go creates a goroutine, but maintains a record of it to ensure that execution completes before
 // the server is shutdown.
 func (s *Server) Go(f func()) {
@@ -672,6 +731,19 @@ func runSessionCleanupJob(s *Server) {
 	}, time.Hour*24)
 }
 
+func runCustomStatusExpiryJob(s *Server) {
+	doCustomStatusExpiry(s)
+	model.CreateRecurringTask("Custom Status Expiry", func() {
+		doCustomStatusExpiry(s)
+	}, time.Minute*1)
+}
+
+func runPersistentNotificationsJob(s *Server) {
+	model.CreateRecurringTask("Persistent Notifications", func() {
+		doPersistentNotifications(s)
+	}, time.Minute*1)
+}
+
 func doSecurity(s *Server) {
 	s.DoSecurityUpdateCheck()
 }
@@ -690,6 +762,32 @@ func doCommandWebhookCleanup(s *Server) {
 	s.Store.CommandWebhook().Cleanup()
 }
 
+func doCustomStatusExpiry(s *Server) {
+	a := s.FakeApp()
+
+	// Only one node needs to clear expired statuses since the change is broadcast to the cluster
+	if !a.IsLeader() {
+		return
+	}
+
+	if err := a.RemoveExpiredCustomStatuses(); err != nil {
+		mlog.Error("Failed to remove expired custom statuses", mlog.Err(err))
+	}
+}
+
+func doPersistentNotifications(s *Server) {
+	a := s.FakeApp()
+
+	// Only one node needs to send persistent notifications or they would be sent once per node
+	if !a.IsLeader() {
+		return
+	}
+
+	if err := a.SendPersistentNotifications(); err != nil {
+		mlog.Error("Failed to send persistent notifications", mlog.Err(err))
+	}
+}
+
 const (
 	SESSIONS_CLEANUP_BATCH_SIZE = 1000
 )
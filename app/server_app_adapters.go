@@ -13,7 +13,9 @@ import (
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/services/mailservice"
 	"github.com/mattermost/mattermost-server/store"
+	"github.com/mattermost/mattermost-server/store/archivelayer"
 	"github.com/mattermost/mattermost-server/store/localcachelayer"
+	"github.com/mattermost/mattermost-server/store/rediscachelayer"
 	"github.com/mattermost/mattermost-server/store/sqlstore"
 	"github.com/mattermost/mattermost-server/utils"
 	"github.com/pkg/errors"
@@ -60,9 +62,29 @@ func (s *Server) RunOldAppInitalization() error {
 
 	s.initEnterprise()
 
+	auditLogKey, err := readAuditLogKey(s.FakeApp().Config().ComplianceSettings)
+	if err != nil {
+		return err
+	}
+
 	if s.FakeApp().Srv.newStore == nil {
 		s.FakeApp().Srv.newStore = func() store.Store {
-			return store.NewTimerLayer(localcachelayer.NewLocalCacheLayer(store.NewLayeredStore(sqlstore.NewSqlSupplier(s.FakeApp().Config().SqlSettings, s.Metrics), s.Metrics, s.Cluster), s.Metrics, s.Cluster), s.Metrics)
+			layeredStore := s.newPostShardLayer(store.NewLayeredStore(sqlstore.NewSqlSupplier(s.FakeApp().Config().SqlSettings, s.Metrics), s.Metrics, s.Cluster))
+			archiveStore := archivelayer.NewArchiveLayer(layeredStore, func(path string) ([]byte, *model.AppError) {
+				return s.FakeApp().ReadFile(path)
+			})
+			var cacheStore store.Store
+			if *s.FakeApp().Config().CacheSettings.CacheType == model.CACHE_TYPE_REDIS {
+				cacheStore = rediscachelayer.NewRedisCacheLayer(archiveStore, s.Metrics, s.FakeApp().Config().CacheSettings)
+			} else {
+				cacheStore = localcachelayer.NewLocalCacheLayer(archiveStore, s.Metrics, s.Cluster)
+			}
+			timerStore := store.NewTimerLayer(cacheStore, s.Metrics)
+			timerStore.SetQueryTimeouts(s.FakeApp().Config().SqlSettings.QueryTimeoutOverrides)
+			if *s.FakeApp().Config().ComplianceSettings.EnableAuditLog {
+				return store.NewAuditLayer(timerStore, auditLogKey)
+			}
+			return timerStore
 		}
 	}
 
@@ -284,3 +284,27 @@ func TestPanicLog(t *testing.T) {
 		t.Error("Panic was supposed to be logged")
 	}
 }
+
+func TestTracerConfigReload(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	require.Nil(t, th.App.Srv.Tracer())
+
+	dir, err := ioutil.TempDir("", "tracing")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.TracingSettings.Enable = true
+		*cfg.TracingSettings.ExportFile = path.Join(dir, "traces.json")
+	})
+	tracer := th.App.Srv.Tracer()
+	require.NotNil(t, tracer)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.LogSettings.EnableConsole = false })
+	require.True(t, tracer == th.App.Srv.Tracer(), "the tracer must be kept while its settings are unchanged")
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.TracingSettings.Enable = false })
+	require.Nil(t, th.App.Srv.Tracer())
+}
@@ -14,7 +14,7 @@ import (
 func (a *App) CreateSession(session *model.Session) (*model.Session, *model.AppError) {
 	session.Token = ""
 
-	session, err := a.Srv.Store.Session().Save(session)
+	session, err := a.Store().Session().Save(session)
 	if err != nil {
 		return nil, err
 	}
@@ -41,7 +41,7 @@ func (a *App) GetSession(token string) (*model.Session, *model.AppError) {
 	}
 
 	if session == nil {
-		if session, err = a.Srv.Store.Session().Get(token); err == nil {
+		if session, err = a.Store().Session().Get(token); err == nil {
 			if session != nil {
 				if session.Token != token {
 					return nil, model.NewAppError("GetSession", "api.context.invalid_token.error", map[string]interface{}{"Token": token, "Error": ""}, "", http.StatusUnauthorized)
@@ -90,11 +90,11 @@ func (a *App) GetSession(token string) (*model.Session, *model.AppError) {
 
 func (a *App) GetSessions(userId string) ([]*model.Session, *model.AppError) {
 
-	return a.Srv.Store.Session().GetSessions(userId)
+	return a.Store().Session().GetSessions(userId)
 }
 
 func (a *App) UpdateSessionsIsGuest(userId string, isGuest bool) {
-	sessions, err := a.Srv.Store.Session().GetSessions(userId)
+	sessions, err := a.Store().Session().GetSessions(userId)
 	if err != nil {
 		mlog.Error(fmt.Sprintf("Unable to get user sessions: userId=%s err=%s", userId, err.Error()))
 	}
@@ -105,7 +105,7 @@ func (a *App) UpdateSessionsIsGuest(userId string, isGuest bool) {
 		} else {
 			session.AddProp(model.SESSION_PROP_IS_GUEST, "false")
 		}
-		err := a.Srv.Store.Session().UpdateProps(session)
+		err := a.Store().Session().UpdateProps(session)
 		if err != nil {
 			mlog.Error(fmt.Sprintf("Unable to update isGuest session: %s", err.Error()))
 			continue
@@ -115,7 +115,7 @@ func (a *App) UpdateSessionsIsGuest(userId string, isGuest bool) {
 }
 
 func (a *App) RevokeAllSessions(userId string) *model.AppError {
-	sessions, err := a.Srv.Store.Session().GetSessions(userId)
+	sessions, err := a.Store().Session().GetSessions(userId)
 	if err != nil {
 		return err
 	}
@@ -123,7 +123,7 @@ func (a *App) RevokeAllSessions(userId string) *model.AppError {
 		if session.IsOAuth {
 			a.RevokeAccessToken(session.Token)
 		} else {
-			if err := a.Srv.Store.Session().Remove(session.Id); err != nil {
+			if err := a.Store().Session().Remove(session.Id); err != nil {
 				return err
 			}
 		}
@@ -138,11 +138,11 @@ func (a *App) RevokeAllSessions(userId string) *model.AppError {
 // in the server and revoke them
 func (a *App) RevokeSessionsFromAllUsers() *model.AppError {
 	// revoke tokens before sessions so they can't be used to relogin
-	tErr := a.Srv.Store.OAuth().RemoveAllAccessData()
+	tErr := a.Store().OAuth().RemoveAllAccessData()
 	if tErr != nil {
 		return tErr
 	}
-	err := a.Srv.Store.Session().RemoveAllSessions()
+	err := a.Store().Session().RemoveAllSessions()
 	if err != nil {
 		return err
 	}
@@ -207,8 +207,72 @@ func (a *App) SessionCacheLength() int {
 	return a.Srv.sessionCache.Len()
 }
 
+// RecordSessionWrite notes that the current session may just have written to the database, so
+// that its reads go to the master for a while, giving the read replicas time to catch up. The
+// other servers of the cluster are told as well, since the next request may reach any of them,
+// but only once half of the marker they were last sent has expired: a session writing
+// continuously sends at most one message every StickyMasterAfterWriteSeconds / 2.
+func (a *App) RecordSessionWrite() {
+	if a.Session.Id == "" || !a.stickyMasterAfterWrite() {
+		return
+	}
+
+	stickySeconds := int64(*a.Config().SqlSettings.StickyMasterAfterWriteSeconds)
+	now := model.GetMillis()
+
+	// The marker holds when it was last sent to the cluster, zero if it was received from it.
+	var sentAt int64
+	if value, ok := a.Srv.sessionWriteCache.Get(a.Session.Id); ok {
+		sentAt = value.(int64)
+	}
+
+	send := a.Cluster != nil && now-sentAt >= stickySeconds*1000/2
+	if send {
+		sentAt = now
+	}
+
+	a.Srv.sessionWriteCache.AddWithExpiresInSecs(a.Session.Id, sentAt, stickySeconds)
+
+	if send {
+		msg := &model.ClusterMessage{
+			Event:    model.CLUSTER_EVENT_RECORD_SESSION_WRITE,
+			SendType: model.CLUSTER_SEND_RELIABLE,
+			Data:     a.Session.Id,
+		}
+		a.Cluster.SendClusterMessage(msg)
+	}
+}
+
+func (a *App) RecordSessionWriteSkipClusterSend(sessionId string) {
+	if sessionId == "" || !a.stickyMasterAfterWrite() {
+		return
+	}
+
+	var sentAt int64
+	if value, ok := a.Srv.sessionWriteCache.Get(sessionId); ok {
+		sentAt = value.(int64)
+	}
+
+	a.Srv.sessionWriteCache.AddWithExpiresInSecs(sessionId, sentAt, int64(*a.Config().SqlSettings.StickyMasterAfterWriteSeconds))
+}
+
+func (a *App) stickyMasterAfterWrite() bool {
+	return *a.Config().SqlSettings.StickyMasterAfterWriteSeconds > 0 && len(a.Config().SqlSettings.DataSourceReplicas) > 0
+}
+
+// SessionWroteRecently reports whether the current session wrote to the database, through any
+// server of the cluster, within the configured SqlSettings.StickyMasterAfterWriteSeconds.
+func (a *App) SessionWroteRecently() bool {
+	if a.Session.Id == "" {
+		return false
+	}
+
+	_, ok := a.Srv.sessionWriteCache.Get(a.Session.Id)
+	return ok
+}
+
 func (a *App) RevokeSessionsForDeviceId(userId string, deviceId string, currentSessionId string) *model.AppError {
-	sessions, err := a.Srv.Store.Session().GetSessions(userId)
+	sessions, err := a.Store().Session().GetSessions(userId)
 	if err != nil {
 		return err
 	}
@@ -226,7 +290,7 @@ func (a *App) RevokeSessionsForDeviceId(userId string, deviceId string, currentS
 }
 
 func (a *App) GetSessionById(sessionId string) (*model.Session, *model.AppError) {
-	session, err := a.Srv.Store.Session().Get(sessionId)
+	session, err := a.Store().Session().Get(sessionId)
 	if err != nil {
 		err.StatusCode = http.StatusBadRequest
 		return nil, err
@@ -235,7 +299,7 @@ func (a *App) GetSessionById(sessionId string) (*model.Session, *model.AppError)
 }
 
 func (a *App) RevokeSessionById(sessionId string) *model.AppError {
-	session, err := a.Srv.Store.Session().Get(sessionId)
+	session, err := a.Store().Session().Get(sessionId)
 	if err != nil {
 		err.StatusCode = http.StatusBadRequest
 		return err
@@ -250,7 +314,7 @@ func (a *App) RevokeSession(session *model.Session) *model.AppError {
 			return err
 		}
 	} else {
-		if err := a.Srv.Store.Session().Remove(session.Id); err != nil {
+		if err := a.Store().Session().Remove(session.Id); err != nil {
 			return err
 		}
 	}
@@ -261,7 +325,7 @@ func (a *App) RevokeSession(session *model.Session) *model.AppError {
 }
 
 func (a *App) AttachDeviceId(sessionId string, deviceId string, expiresAt int64) *model.AppError {
-	_, err := a.Srv.Store.Session().UpdateDeviceId(sessionId, deviceId, expiresAt)
+	_, err := a.Store().Session().UpdateDeviceId(sessionId, deviceId, expiresAt)
 	if err != nil {
 		return err
 	}
@@ -278,7 +342,7 @@ func (a *App) UpdateLastActivityAtIfNeeded(session model.Session) {
 		return
 	}
 
-	if err := a.Srv.Store.Session().UpdateLastActivityAt(session.Id, now); err != nil {
+	if err := a.Store().Session().UpdateLastActivityAt(session.Id, now); err != nil {
 		mlog.Error(fmt.Sprintf("Failed to update LastActivityAt for user_id=%v and session_id=%v, err=%v", session.UserId, session.Id, err), mlog.String("user_id", session.UserId))
 	}
 
@@ -288,7 +352,7 @@ func (a *App) UpdateLastActivityAtIfNeeded(session model.Session) {
 
 func (a *App) CreateUserAccessToken(token *model.UserAccessToken) (*model.UserAccessToken, *model.AppError) {
 
-	user, err := a.Srv.Store.User().Get(token.UserId)
+	user, err := a.Store().User().Get(token.UserId)
 	if err != nil {
 		return nil, err
 	}
@@ -299,7 +363,7 @@ func (a *App) CreateUserAccessToken(token *model.UserAccessToken) (*model.UserAc
 
 	token.Token = model.NewId()
 
-	token, err = a.Srv.Store.UserAccessToken().Save(token)
+	token, err = a.Store().UserAccessToken().Save(token)
 	if err != nil {
 		return nil, err
 	}
@@ -316,7 +380,7 @@ func (a *App) CreateUserAccessToken(token *model.UserAccessToken) (*model.UserAc
 }
 
 func (a *App) createSessionForUserAccessToken(tokenString string) (*model.Session, *model.AppError) {
-	token, err := a.Srv.Store.UserAccessToken().GetByToken(tokenString)
+	token, err := a.Store().UserAccessToken().GetByToken(tokenString)
 	if err != nil {
 		return nil, model.NewAppError("createSessionForUserAccessToken", "app.user_access_token.invalid_or_missing", nil, err.Error(), http.StatusUnauthorized)
 	}
@@ -325,7 +389,7 @@ func (a *App) createSessionForUserAccessToken(tokenString string) (*model.Sessio
 		return nil, model.NewAppError("createSessionForUserAccessToken", "app.user_access_token.invalid_or_missing", nil, "inactive_token", http.StatusUnauthorized)
 	}
 
-	user, err := a.Srv.Store.User().Get(token.UserId)
+	user, err := a.Store().User().Get(token.UserId)
 	if err != nil {
 		return nil, err
 	}
@@ -357,7 +421,7 @@ func (a *App) createSessionForUserAccessToken(tokenString string) (*model.Sessio
 	}
 	session.SetExpireInDays(model.SESSION_USER_ACCESS_TOKEN_EXPIRY)
 
-	session, err = a.Srv.Store.Session().Save(session)
+	session, err = a.Store().Session().Save(session)
 	if err != nil {
 		return nil, err
 	}
@@ -370,9 +434,9 @@ func (a *App) createSessionForUserAccessToken(tokenString string) (*model.Sessio
 
 func (a *App) RevokeUserAccessToken(token *model.UserAccessToken) *model.AppError {
 	var session *model.Session
-	session, _ = a.Srv.Store.Session().Get(token.Token)
+	session, _ = a.Store().Session().Get(token.Token)
 
-	if err := a.Srv.Store.UserAccessToken().Delete(token.Id); err != nil {
+	if err := a.Store().UserAccessToken().Delete(token.Id); err != nil {
 		return err
 	}
 
@@ -385,9 +449,9 @@ func (a *App) RevokeUserAccessToken(token *model.UserAccessToken) *model.AppErro
 
 func (a *App) DisableUserAccessToken(token *model.UserAccessToken) *model.AppError {
 	var session *model.Session
-	session, _ = a.Srv.Store.Session().Get(token.Token)
+	session, _ = a.Store().Session().Get(token.Token)
 
-	if err := a.Srv.Store.UserAccessToken().UpdateTokenDisable(token.Id); err != nil {
+	if err := a.Store().UserAccessToken().UpdateTokenDisable(token.Id); err != nil {
 		return err
 	}
 
@@ -400,9 +464,9 @@ func (a *App) DisableUserAccessToken(token *model.UserAccessToken) *model.AppErr
 
 func (a *App) EnableUserAccessToken(token *model.UserAccessToken) *model.AppError {
 	var session *model.Session
-	session, _ = a.Srv.Store.Session().Get(token.Token)
+	session, _ = a.Store().Session().Get(token.Token)
 
-	err := a.Srv.Store.UserAccessToken().UpdateTokenEnable(token.Id)
+	err := a.Store().UserAccessToken().UpdateTokenEnable(token.Id)
 	if err != nil {
 		return err
 	}
@@ -415,7 +479,7 @@ func (a *App) EnableUserAccessToken(token *model.UserAccessToken) *model.AppErro
 }
 
 func (a *App) GetUserAccessTokens(page, perPage int) ([]*model.UserAccessToken, *model.AppError) {
-	tokens, err := a.Srv.Store.UserAccessToken().GetAll(page*perPage, perPage)
+	tokens, err := a.Store().UserAccessToken().GetAll(page*perPage, perPage)
 	if err != nil {
 		return nil, err
 	}
@@ -428,7 +492,7 @@ func (a *App) GetUserAccessTokens(page, perPage int) ([]*model.UserAccessToken,
 }
 
 func (a *App) GetUserAccessTokensForUser(userId string, page, perPage int) ([]*model.UserAccessToken, *model.AppError) {
-	tokens, err := a.Srv.Store.UserAccessToken().GetByUser(userId, page*perPage, perPage)
+	tokens, err := a.Store().UserAccessToken().GetByUser(userId, page*perPage, perPage)
 	if err != nil {
 		return nil, err
 	}
@@ -441,7 +505,7 @@ func (a *App) GetUserAccessTokensForUser(userId string, page, perPage int) ([]*m
 }
 
 func (a *App) GetUserAccessToken(tokenId string, sanitize bool) (*model.UserAccessToken, *model.AppError) {
-	token, err := a.Srv.Store.UserAccessToken().Get(tokenId)
+	token, err := a.Store().UserAccessToken().Get(tokenId)
 	if err != nil {
 		return nil, err
 	}
@@ -453,7 +517,7 @@ func (a *App) GetUserAccessToken(tokenId string, sanitize bool) (*model.UserAcce
 }
 
 func (a *App) SearchUserAccessTokens(term string) ([]*model.UserAccessToken, *model.AppError) {
-	tokens, err := a.Srv.Store.UserAccessToken().Search(term)
+	tokens, err := a.Store().UserAccessToken().Search(term)
 	if err != nil {
 		return nil, err
 	}
@@ -10,6 +10,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/services/tracing"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/mattermost/mattermost-server/store/storetest"
+	"github.com/mattermost/mattermost-server/testlib"
 )
 
 func TestCache(t *testing.T) {
@@ -177,3 +181,175 @@ func TestUpdateSessionOnPromoteDemote(t *testing.T) {
 		assert.Equal(t, "true", rsession.Props[model.SESSION_PROP_IS_GUEST])
 	})
 }
+
+func TestSessionWroteRecently(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	// The replicas are never changed by updating the config, so set them on the current one.
+	th.App.Config().SqlSettings.DataSourceReplicas = []string{"replica"}
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.SqlSettings.StickyMasterAfterWriteSeconds = 5 })
+
+	t.Run("writes are tracked per session", func(t *testing.T) {
+		th.App.Session = model.Session{Id: model.NewId()}
+		assert.False(t, th.App.SessionWroteRecently())
+
+		th.App.RecordSessionWrite()
+		assert.True(t, th.App.SessionWroteRecently())
+
+		th.App.Session = model.Session{Id: model.NewId()}
+		assert.False(t, th.App.SessionWroteRecently())
+	})
+
+	t.Run("writes are shared with the cluster", func(t *testing.T) {
+		testCluster := &testlib.FakeClusterInterface{}
+		th.App.Cluster = testCluster
+		defer func() { th.App.Cluster = nil }()
+
+		th.App.Session = model.Session{Id: model.NewId()}
+		th.App.RecordSessionWrite()
+
+		messages := testCluster.GetMessages()
+		require.Len(t, messages, 1)
+		assert.Equal(t, model.CLUSTER_EVENT_RECORD_SESSION_WRITE, messages[0].Event)
+		assert.Equal(t, th.App.Session.Id, messages[0].Data)
+	})
+
+	t.Run("writes are shared with the cluster once per half marker", func(t *testing.T) {
+		testCluster := &testlib.FakeClusterInterface{}
+		th.App.Cluster = testCluster
+		defer func() { th.App.Cluster = nil }()
+
+		th.App.Session = model.Session{Id: model.NewId()}
+		for i := 0; i < 10; i++ {
+			th.App.RecordSessionWrite()
+		}
+		assert.Len(t, testCluster.GetMessages(), 1)
+
+		// A marker received from another server is sent again on the next write.
+		session := model.Session{Id: model.NewId()}
+		th.App.RecordSessionWriteSkipClusterSend(session.Id)
+		th.App.Session = session
+		th.App.RecordSessionWrite()
+		th.App.RecordSessionWrite()
+		assert.Len(t, testCluster.GetMessages(), 2)
+	})
+
+	t.Run("writes through another server are tracked", func(t *testing.T) {
+		th.App.Session = model.Session{Id: model.NewId()}
+		th.App.ClusterRecordSessionWriteHandler(&model.ClusterMessage{
+			Event: model.CLUSTER_EVENT_RECORD_SESSION_WRITE,
+			Data:  th.App.Session.Id,
+		})
+		assert.True(t, th.App.SessionWroteRecently())
+	})
+
+	t.Run("writes without a session are not tracked", func(t *testing.T) {
+		th.App.Session = model.Session{}
+		th.App.RecordSessionWrite()
+		assert.False(t, th.App.SessionWroteRecently())
+	})
+
+	t.Run("writes are not tracked when disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.SqlSettings.StickyMasterAfterWriteSeconds = 0 })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.SqlSettings.StickyMasterAfterWriteSeconds = 5 })
+
+		th.App.Session = model.Session{Id: model.NewId()}
+		th.App.RecordSessionWrite()
+		assert.False(t, th.App.SessionWroteRecently())
+	})
+
+	t.Run("writes are not tracked without replicas", func(t *testing.T) {
+		th.App.Config().SqlSettings.DataSourceReplicas = []string{}
+
+		th.App.Session = model.Session{Id: model.NewId()}
+		th.App.RecordSessionWrite()
+		assert.False(t, th.App.SessionWroteRecently())
+	})
+}
+
+func TestReadFromMaster(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	assert.True(t, th.App.Store() == th.App.Srv.Store)
+
+	th.App.ReadFromMaster()
+	assert.True(t, th.App.Store() == th.App.Srv.Store.MasterOnly())
+}
+
+type recordingExporter struct {
+	spans []*tracing.Span
+}
+
+func (e *recordingExporter) Export(spans []*tracing.Span) error {
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *recordingExporter) Close() error {
+	return nil
+}
+
+func TestSetSpan(t *testing.T) {
+	mockStore := &storetest.Store{}
+	mockStore.UserStore.On("Get", "user_id").Return(&model.User{Id: "user_id"}, nil)
+	a := New(ServerConnector(&Server{Store: store.NewTimerLayer(mockStore, nil)}))
+
+	a.SetSpan(nil)
+	assert.True(t, a.Store() == a.Srv.Store)
+
+	exporter := &recordingExporter{}
+	tracer := tracing.NewTracer(exporter, 1)
+	span := tracer.StartTrace("GET /api/v4/users/{user_id}", tracing.SPAN_KIND_SERVER)
+
+	a.SetSpan(span)
+	assert.False(t, a.Store() == a.Srv.Store)
+
+	_, err := a.Store().User().Get("user_id")
+	require.Nil(t, err)
+
+	a.ReadFromMaster()
+	assert.True(t, a.Store().MasterOnly() == a.Store(), "the store must read from the master")
+	_, err = a.Store().User().Get("user_id")
+	require.Nil(t, err)
+
+	span.Finish()
+	tracer.Close()
+
+	require.Len(t, exporter.spans, 3)
+	for _, child := range exporter.spans[:2] {
+		assert.Equal(t, span.TraceId(), child.TraceId())
+	}
+}
+
+func TestStartSpan(t *testing.T) {
+	mockStore := &storetest.Store{}
+	mockStore.UserStore.On("Get", "user_id").Return(&model.User{Id: "user_id"}, nil)
+	a := New(ServerConnector(&Server{Store: store.NewTimerLayer(mockStore, nil)}))
+
+	t.Run("untraced requests", func(t *testing.T) {
+		finish := a.startSpan("GetUser")
+		assert.Nil(t, a.Span)
+		assert.True(t, a.Store() == a.Srv.Store)
+		finish()
+	})
+
+	exporter := &recordingExporter{}
+	tracer := tracing.NewTracer(exporter, 1)
+	span := tracer.StartTrace("GET /api/v4/users/{user_id}", tracing.SPAN_KIND_SERVER)
+	a.SetSpan(span)
+
+	finish := a.startSpan("GetUser")
+	assert.False(t, a.Span == span)
+	assert.Equal(t, span.TraceId(), a.Span.TraceId())
+	_, err := a.Store().User().Get("user_id")
+	require.Nil(t, err)
+	finish()
+	assert.True(t, a.Span == span, "the span of the request must be restored")
+
+	span.Finish()
+	tracer.Close()
+
+	require.Len(t, exporter.spans, 3, "the store call, the App operation and the request must be traced")
+}
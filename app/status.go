@@ -5,12 +5,17 @@ package app
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/mattermost/mattermost-server/mlog"
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/utils"
 )
 
+const (
+	WORKING_HOURS_BATCH_SIZE = 100
+)
+
 var statusCache *utils.Cache = utils.NewLru(model.STATUS_CACHE_SIZE)
 
 func ClearStatusCache() {
@@ -78,7 +83,7 @@ func (a *App) GetStatusesByIds(userIds []string) (map[string]interface{}, *model
 	}
 
 	if len(missingUserIds) > 0 {
-		statuses, err := a.Srv.Store.Status().GetByIds(missingUserIds)
+		statuses, err := a.Store().Status().GetByIds(missingUserIds)
 		if err != nil {
 			return nil, err
 		}
@@ -125,7 +130,7 @@ func (a *App) GetUserStatusesByIds(userIds []string) ([]*model.Status, *model.Ap
 	}
 
 	if len(missingUserIds) > 0 {
-		statuses, err := a.Srv.Store.Status().GetByIds(missingUserIds)
+		statuses, err := a.Store().Status().GetByIds(missingUserIds)
 		if err != nil {
 			return nil, err
 		}
@@ -214,11 +219,11 @@ func (a *App) SetStatusOnline(userId string, manual bool) {
 	// or enough time has passed since the previous action
 	if status.Status != oldStatus || status.Manual != oldManual || status.LastActivityAt-oldTime > model.STATUS_MIN_UPDATE_TIME {
 		if broadcast {
-			if err := a.Srv.Store.Status().SaveOrUpdate(status); err != nil {
+			if err := a.Store().Status().SaveOrUpdate(status); err != nil {
 				mlog.Error(fmt.Sprintf("Failed to save status for user_id=%v, err=%v", userId, err), mlog.String("user_id", userId))
 			}
 		} else {
-			if err := a.Srv.Store.Status().UpdateLastActivityAt(status.UserId, status.LastActivityAt); err != nil {
+			if err := a.Store().Status().UpdateLastActivityAt(status.UserId, status.LastActivityAt); err != nil {
 				mlog.Error(fmt.Sprintf("Failed to save status for user_id=%v, err=%v", userId, err), mlog.String("user_id", userId))
 			}
 		}
@@ -233,6 +238,9 @@ func (a *App) BroadcastStatus(status *model.Status) {
 	event := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_STATUS_CHANGE, "", "", status.UserId, nil)
 	event.Add("status", status.Status)
 	event.Add("user_id", status.UserId)
+	if status.Status == model.STATUS_DND && status.DNDEndTime > 0 {
+		event.Add("dnd_end_time", status.DNDEndTime)
+	}
 	a.Publish(event)
 }
 
@@ -296,6 +304,32 @@ func (a *App) SetStatusDoNotDisturb(userId string) {
 
 	status.Status = model.STATUS_DND
 	status.Manual = true
+	status.DNDEndTime = 0
+	status.PrevStatus = ""
+
+	a.SaveAndBroadcastStatus(status)
+}
+
+// SetStatusDoNotDisturbTimed sets the user's status to Do Not Disturb until endTime, after which
+// they are returned to the status they had beforehand.
+func (a *App) SetStatusDoNotDisturbTimed(userId string, endTime int64) {
+	if !*a.Config().ServiceSettings.EnableUserStatuses {
+		return
+	}
+
+	status, err := a.GetStatus(userId)
+
+	if err != nil {
+		status = &model.Status{UserId: userId, Status: model.STATUS_OFFLINE, Manual: false, LastActivityAt: 0, ActiveChannel: ""}
+	}
+
+	if status.Status != model.STATUS_DND {
+		status.PrevStatus = status.Status
+	}
+
+	status.Status = model.STATUS_DND
+	status.Manual = true
+	status.DNDEndTime = endTime
 
 	a.SaveAndBroadcastStatus(status)
 }
@@ -303,7 +337,7 @@ func (a *App) SetStatusDoNotDisturb(userId string) {
 func (a *App) SaveAndBroadcastStatus(status *model.Status) {
 	a.AddStatusCache(status)
 
-	if err := a.Srv.Store.Status().SaveOrUpdate(status); err != nil {
+	if err := a.Store().Status().SaveOrUpdate(status); err != nil {
 		mlog.Error(fmt.Sprintf("Failed to save status for user_id=%v, err=%v", status.UserId, err))
 	}
 
@@ -348,9 +382,104 @@ func (a *App) GetStatus(userId string) (*model.Status, *model.AppError) {
 		return status, nil
 	}
 
-	return a.Srv.Store.Status().Get(userId)
+	return a.Store().Status().Get(userId)
 }
 
 func (a *App) IsUserAway(lastActivityAt int64) bool {
 	return model.GetMillis()-lastActivityAt >= *a.Config().TeamSettings.UserStatusAwayTimeout*1000
 }
+
+func runScheduledStatusesJob(s *Server) {
+	model.CreateRecurringTask("Scheduled Statuses", func() {
+		doScheduledStatuses(s)
+	}, time.Minute*1)
+}
+
+func doScheduledStatuses(s *Server) {
+	a := s.FakeApp()
+
+	// Only one node needs to update scheduled statuses since the change is broadcast to the cluster
+	if !a.IsLeader() {
+		return
+	}
+
+	if err := a.UpdateScheduledStatuses(model.GetMillis()); err != nil {
+		mlog.Error("Failed to update scheduled statuses", mlog.Err(err))
+	}
+}
+
+// UpdateScheduledStatuses ends any timed Do Not Disturb statuses that have expired and puts users
+// whose working hours ended as of now into Do Not Disturb until their next working period begins.
+func (a *App) UpdateScheduledStatuses(now int64) *model.AppError {
+	if !*a.Config().ServiceSettings.EnableUserStatuses {
+		return nil
+	}
+
+	statuses, err := a.Store().Status().UpdateExpiredDNDStatuses(now)
+	if err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		a.AddStatusCache(status)
+		a.BroadcastStatus(status)
+	}
+
+	for {
+		userIds, err := a.Store().User().GetUserIdsWithWorkingHoursEnded(now, WORKING_HOURS_BATCH_SIZE)
+		if err != nil {
+			return err
+		}
+
+		for _, userId := range userIds {
+			user, err := a.GetUser(userId)
+			if err != nil {
+				mlog.Error("Failed to get user whose working hours ended", mlog.String("user_id", userId), mlog.Err(err))
+				if err := a.Store().User().SetWorkingHoursEnd(userId, 0); err != nil {
+					return err
+				}
+				continue
+			}
+
+			nowTime := time.Unix(0, now*int64(time.Millisecond)).In(user.GetLocation())
+			if workingHours := user.GetWorkingHours(); workingHours != nil && workingHours.Enabled && !workingHours.IsWithin(nowTime) {
+				a.setStatusOutsideWorkingHours(user.Id, workingHours.NextStart(nowTime))
+			}
+
+			if err := a.setWorkingHoursEnd(user, nowTime); err != nil {
+				return err
+			}
+		}
+
+		if len(userIds) < WORKING_HOURS_BATCH_SIZE {
+			return nil
+		}
+	}
+}
+
+// setStatusOutsideWorkingHours puts the user into Do Not Disturb until their next working period
+// begins at next, unless they have chosen a status that holds back notifications already.
+func (a *App) setStatusOutsideWorkingHours(userId string, next time.Time) {
+	if next.IsZero() {
+		return
+	}
+
+	if status, err := a.GetStatus(userId); err == nil && (status.Status == model.STATUS_DND || status.Status == model.STATUS_OUT_OF_OFFICE) {
+		return
+	}
+
+	a.SetStatusDoNotDisturbTimed(userId, model.GetMillisForTime(next))
+}
+
+// setWorkingHoursEnd records when the working period of the user that is current or next as of
+// now ends, for their status to be updated then.
+func (a *App) setWorkingHoursEnd(user *model.User, now time.Time) *model.AppError {
+	var endAt int64
+	if workingHours := user.GetWorkingHours(); workingHours != nil && workingHours.Enabled {
+		if end := workingHours.NextEnd(now.In(user.GetLocation())); !end.IsZero() {
+			endAt = model.GetMillisForTime(end)
+		}
+	}
+
+	return a.Store().User().SetWorkingHoursEnd(user.Id, endAt)
+}
@@ -5,6 +5,10 @@ package app
 
 import (
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost-server/model"
 )
@@ -38,3 +42,91 @@ func TestSaveStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoveExpiredCustomStatuses(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	_, err := th.App.SetCustomStatus(th.BasicUser.Id, &model.CustomStatus{Text: "In a meeting", ExpiresAt: model.GetMillis() + 60*1000})
+	require.Nil(t, err)
+	_, err = th.App.SetCustomStatus(th.BasicUser2.Id, &model.CustomStatus{Emoji: "palm_tree", Text: "On vacation"})
+	require.Nil(t, err)
+
+	// Simulate the first status expiring
+	user, err := th.App.GetUser(th.BasicUser.Id)
+	require.Nil(t, err)
+	user.SetCustomStatus(&model.CustomStatus{Text: "In a meeting", ExpiresAt: model.GetMillis() - 1000})
+	_, err = th.App.UpdateUser(user, false)
+	require.Nil(t, err)
+	require.Nil(t, th.App.Srv.Store.User().SetCustomStatusExpiry(user.Id, model.GetMillis()-1000))
+
+	require.Nil(t, th.App.RemoveExpiredCustomStatuses())
+
+	customStatus, err := th.App.GetCustomStatus(th.BasicUser.Id)
+	require.Nil(t, err)
+	assert.Nil(t, customStatus)
+
+	customStatus, err = th.App.GetCustomStatus(th.BasicUser2.Id)
+	require.Nil(t, err)
+	require.NotNil(t, customStatus)
+	assert.Equal(t, "On vacation", customStatus.Text)
+}
+
+func TestUpdateScheduledStatuses(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("timed do not disturb", func(t *testing.T) {
+		th.App.SetStatusAwayIfNeeded(th.BasicUser.Id, true)
+
+		now := model.GetMillis()
+		th.App.SetStatusDoNotDisturbTimed(th.BasicUser.Id, now+1000)
+
+		status, err := th.App.GetStatus(th.BasicUser.Id)
+		require.Nil(t, err)
+		require.Equal(t, model.STATUS_DND, status.Status)
+		require.Equal(t, model.STATUS_AWAY, status.PrevStatus)
+
+		require.Nil(t, th.App.UpdateScheduledStatuses(now+2000))
+
+		status, err = th.App.GetStatus(th.BasicUser.Id)
+		require.Nil(t, err)
+		assert.Equal(t, model.STATUS_AWAY, status.Status)
+		assert.Equal(t, int64(0), status.DNDEndTime)
+	})
+
+	t.Run("end of working hours", func(t *testing.T) {
+		th.App.SetStatusOnline(th.BasicUser2.Id, true)
+
+		today := time.Now().UTC()
+		workingHours := &model.WorkingHours{
+			Enabled: true,
+			Ranges: []*model.WorkingHoursRange{
+				{Day: today.Weekday(), Start: "09:00", End: "17:00"},
+			},
+		}
+
+		user, err := th.App.GetUser(th.BasicUser2.Id)
+		require.Nil(t, err)
+		user.Timezone = model.StringMap{"useAutomaticTimezone": "false", "manualTimezone": "UTC"}
+		user.NotifyProps[model.WORKING_HOURS_NOTIFY_PROP] = workingHours.ToJson()
+		_, err = th.App.UpdateUser(user, false)
+		require.Nil(t, err)
+
+		// Working hours end at the next 17:00, so move their end to today's.
+		year, month, day := today.Date()
+		now := time.Date(year, month, day, 17, 0, 0, 0, time.UTC)
+		require.Nil(t, th.App.Srv.Store.User().SetWorkingHoursEnd(user.Id, model.GetMillisForTime(now)))
+		require.Nil(t, th.App.UpdateScheduledStatuses(model.GetMillisForTime(now)))
+
+		status, err := th.App.GetStatus(th.BasicUser2.Id)
+		require.Nil(t, err)
+		assert.Equal(t, model.STATUS_DND, status.Status)
+		assert.Equal(t, model.STATUS_ONLINE, status.PrevStatus)
+		assert.Equal(t, model.GetMillisForTime(time.Date(year, month, day+7, 9, 0, 0, 0, time.UTC)), status.DNDEndTime)
+
+		userIds, err := th.App.Srv.Store.User().GetUserIdsWithWorkingHoursEnded(model.GetMillisForTime(now), 100)
+		require.Nil(t, err)
+		assert.NotContains(t, userIds, user.Id, "should record when the next working hours end")
+	})
+}
@@ -24,7 +24,7 @@ import (
 
 func (a *App) CreateTeam(team *model.Team) (*model.Team, *model.AppError) {
 	team.InviteId = ""
-	rteam, err := a.Srv.Store.Team().Save(team)
+	rteam, err := a.Store().Team().Save(team)
 	if err != nil {
 		return nil, err
 	}
@@ -138,7 +138,7 @@ func (a *App) UpdateTeam(team *model.Team) (*model.Team, *model.AppError) {
 }
 
 func (a *App) updateTeamUnsanitized(team *model.Team) (*model.Team, *model.AppError) {
-	return a.Srv.Store.Team().Update(team)
+	return a.Store().Team().Update(team)
 }
 
 // RenameTeam is used to rename the team Name and the DisplayName fields
@@ -177,7 +177,7 @@ func (a *App) UpdateTeamScheme(team *model.Team) (*model.Team, *model.AppError)
 
 	oldTeam.SchemeId = team.SchemeId
 
-	if oldTeam, err = a.Srv.Store.Team().Update(oldTeam); err != nil {
+	if oldTeam, err = a.Store().Team().Update(oldTeam); err != nil {
 		return nil, err
 	}
 
@@ -195,7 +195,7 @@ func (a *App) UpdateTeamPrivacy(teamId string, teamType string, allowOpenInvite
 	oldTeam.Type = teamType
 	oldTeam.AllowOpenInvite = allowOpenInvite
 
-	if oldTeam, err = a.Srv.Store.Team().Update(oldTeam); err != nil {
+	if oldTeam, err = a.Store().Team().Update(oldTeam); err != nil {
 		return err
 	}
 
@@ -230,7 +230,7 @@ func (a *App) RegenerateTeamInviteId(teamId string) (*model.Team, *model.AppErro
 
 	team.InviteId = model.NewId()
 
-	updatedTeam, err := a.Srv.Store.Team().Update(team)
+	updatedTeam, err := a.Store().Team().Update(team)
 	if err != nil {
 		return nil, err
 	}
@@ -268,7 +268,7 @@ func (a *App) GetSchemeRolesForTeam(teamId string) (string, string, string, *mod
 }
 
 func (a *App) UpdateTeamMemberRoles(teamId string, userId string, newRoles string) (*model.TeamMember, *model.AppError) {
-	member, err := a.Srv.Store.Team().GetMember(teamId, userId)
+	member, err := a.Store().Team().GetMember(teamId, userId)
 	if err != nil {
 		return nil, err
 	}
@@ -326,7 +326,7 @@ func (a *App) UpdateTeamMemberRoles(teamId string, userId string, newRoles strin
 
 	member.ExplicitRoles = strings.Join(newExplicitRoles, " ")
 
-	member, err = a.Srv.Store.Team().UpdateMember(member)
+	member, err = a.Store().Team().UpdateMember(member)
 	if err != nil {
 		return nil, err
 	}
@@ -357,7 +357,7 @@ func (a *App) UpdateTeamMemberSchemeRoles(teamId string, userId string, isScheme
 		member.ExplicitRoles = RemoveRoles([]string{model.TEAM_GUEST_ROLE_ID, model.TEAM_USER_ROLE_ID, model.TEAM_ADMIN_ROLE_ID}, member.ExplicitRoles)
 	}
 
-	member, err = a.Srv.Store.Team().UpdateMember(member)
+	member, err = a.Store().Team().UpdateMember(member)
 	if err != nil {
 		return nil, err
 	}
@@ -378,14 +378,14 @@ func (a *App) sendUpdatedMemberRoleEvent(userId string, member *model.TeamMember
 func (a *App) AddUserToTeam(teamId string, userId string, userRequestorId string) (*model.Team, *model.AppError) {
 	tchan := make(chan store.StoreResult, 1)
 	go func() {
-		team, err := a.Srv.Store.Team().Get(teamId)
+		team, err := a.Store().Team().Get(teamId)
 		tchan <- store.StoreResult{Data: team, Err: err}
 		close(tchan)
 	}()
 
 	uchan := make(chan store.StoreResult, 1)
 	go func() {
-		user, err := a.Srv.Store.User().Get(userId)
+		user, err := a.Store().User().Get(userId)
 		uchan <- store.StoreResult{Data: user, Err: err}
 		close(uchan)
 	}()
@@ -410,7 +410,7 @@ func (a *App) AddUserToTeam(teamId string, userId string, userRequestorId string
 }
 
 func (a *App) AddUserToTeamByTeamId(teamId string, user *model.User) *model.AppError {
-	team, err := a.Srv.Store.Team().Get(teamId)
+	team, err := a.Store().Team().Get(teamId)
 	if err != nil {
 		return err
 	}
@@ -419,7 +419,7 @@ func (a *App) AddUserToTeamByTeamId(teamId string, user *model.User) *model.AppE
 }
 
 func (a *App) AddUserToTeamByToken(userId string, tokenId string) (*model.Team, *model.AppError) {
-	token, err := a.Srv.Store.Token().GetByToken(tokenId)
+	token, err := a.Store().Token().GetByToken(tokenId)
 	if err != nil {
 		return nil, model.NewAppError("AddUserToTeamByToken", "api.user.create_user.signup_link_invalid.app_error", nil, err.Error(), http.StatusBadRequest)
 	}
@@ -437,14 +437,14 @@ func (a *App) AddUserToTeamByToken(userId string, tokenId string) (*model.Team,
 
 	tchan := make(chan store.StoreResult, 1)
 	go func() {
-		team, err := a.Srv.Store.Team().Get(tokenData["teamId"])
+		team, err := a.Store().Team().Get(tokenData["teamId"])
 		tchan <- store.StoreResult{Data: team, Err: err}
 		close(tchan)
 	}()
 
 	uchan := make(chan store.StoreResult, 1)
 	go func() {
-		user, err := a.Srv.Store.User().Get(userId)
+		user, err := a.Store().User().Get(userId)
 		uchan <- store.StoreResult{Data: user, Err: err}
 		close(uchan)
 	}()
@@ -477,7 +477,7 @@ func (a *App) AddUserToTeamByToken(userId string, tokenId string) (*model.Team,
 	}
 
 	if token.Type == TOKEN_TYPE_GUEST_INVITATION {
-		channels, err := a.Srv.Store.Channel().GetChannelsByIds(strings.Split(tokenData["channels"], " "))
+		channels, err := a.Store().Channel().GetChannelsByIds(strings.Split(tokenData["channels"], " "))
 		if err != nil {
 			return nil, err
 		}
@@ -500,14 +500,14 @@ func (a *App) AddUserToTeamByToken(userId string, tokenId string) (*model.Team,
 func (a *App) AddUserToTeamByInviteId(inviteId string, userId string) (*model.Team, *model.AppError) {
 	tchan := make(chan store.StoreResult, 1)
 	go func() {
-		team, err := a.Srv.Store.Team().GetByInviteId(inviteId)
+		team, err := a.Store().Team().GetByInviteId(inviteId)
 		tchan <- store.StoreResult{Data: team, Err: err}
 		close(tchan)
 	}()
 
 	uchan := make(chan store.StoreResult, 1)
 	go func() {
-		user, err := a.Srv.Store.User().Get(userId)
+		user, err := a.Store().User().Get(userId)
 		uchan <- store.StoreResult{Data: user, Err: err}
 		close(uchan)
 	}()
@@ -547,11 +547,11 @@ func (a *App) joinUserToTeam(team *model.Team, user *model.User) (*model.TeamMem
 		tm.SchemeAdmin = true
 	}
 
-	rtm, err := a.Srv.Store.Team().GetMember(team.Id, user.Id)
+	rtm, err := a.Store().Team().GetMember(team.Id, user.Id)
 	if err != nil {
 		// Membership appears to be missing. Lets try to add.
 		var tmr *model.TeamMember
-		tmr, err = a.Srv.Store.Team().SaveMember(tm, *a.Config().TeamSettings.MaxUsersPerTeam)
+		tmr, err = a.Store().Team().SaveMember(tm, *a.Config().TeamSettings.MaxUsersPerTeam)
 		if err != nil {
 			return nil, false, err
 		}
@@ -564,7 +564,7 @@ func (a *App) joinUserToTeam(team *model.Team, user *model.User) (*model.TeamMem
 		return rtm, true, nil
 	}
 
-	membersCount, err := a.Srv.Store.Team().GetActiveMemberCount(tm.TeamId, nil)
+	membersCount, err := a.Store().Team().GetActiveMemberCount(tm.TeamId, nil)
 	if err != nil {
 		return nil, false, err
 	}
@@ -573,7 +573,7 @@ func (a *App) joinUserToTeam(team *model.Team, user *model.User) (*model.TeamMem
 		return nil, false, model.NewAppError("joinUserToTeam", "app.team.join_user_to_team.max_accounts.app_error", nil, "teamId="+tm.TeamId, http.StatusBadRequest)
 	}
 
-	member, err := a.Srv.Store.Team().UpdateMember(tm)
+	member, err := a.Store().Team().UpdateMember(tm)
 	if err != nil {
 		return nil, false, err
 	}
@@ -608,7 +608,7 @@ func (a *App) JoinUserToTeam(team *model.Team, user *model.User, userRequestorId
 		})
 	}
 
-	if _, err := a.Srv.Store.User().UpdateUpdateAt(user.Id); err != nil {
+	if _, err := a.Store().User().UpdateUpdateAt(user.Id); err != nil {
 		return err
 	}
 
@@ -639,11 +639,11 @@ func (a *App) JoinUserToTeam(team *model.Team, user *model.User, userRequestorId
 }
 
 func (a *App) GetTeam(teamId string) (*model.Team, *model.AppError) {
-	return a.Srv.Store.Team().Get(teamId)
+	return a.Store().Team().Get(teamId)
 }
 
 func (a *App) GetTeamByName(name string) (*model.Team, *model.AppError) {
-	team, err := a.Srv.Store.Team().GetByName(name)
+	team, err := a.Store().Team().GetByName(name)
 	if err != nil {
 		err.StatusCode = http.StatusNotFound
 		return nil, err
@@ -652,23 +652,23 @@ func (a *App) GetTeamByName(name string) (*model.Team, *model.AppError) {
 }
 
 func (a *App) GetTeamByInviteId(inviteId string) (*model.Team, *model.AppError) {
-	return a.Srv.Store.Team().GetByInviteId(inviteId)
+	return a.Store().Team().GetByInviteId(inviteId)
 }
 
 func (a *App) GetAllTeams() ([]*model.Team, *model.AppError) {
-	return a.Srv.Store.Team().GetAll()
+	return a.Store().Team().GetAll()
 }
 
 func (a *App) GetAllTeamsPage(offset int, limit int) ([]*model.Team, *model.AppError) {
-	return a.Srv.Store.Team().GetAllPage(offset, limit)
+	return a.Store().Team().GetAllPage(offset, limit)
 }
 
 func (a *App) GetAllTeamsPageWithCount(offset int, limit int) (*model.TeamsWithCount, *model.AppError) {
-	totalCount, err := a.Srv.Store.Team().AnalyticsTeamCount()
+	totalCount, err := a.Store().Team().AnalyticsTeamCount()
 	if err != nil {
 		return nil, err
 	}
-	teams, err := a.Srv.Store.Team().GetAllPage(offset, limit)
+	teams, err := a.Store().Team().GetAllPage(offset, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -676,19 +676,19 @@ func (a *App) GetAllTeamsPageWithCount(offset int, limit int) (*model.TeamsWithC
 }
 
 func (a *App) GetAllPrivateTeams() ([]*model.Team, *model.AppError) {
-	return a.Srv.Store.Team().GetAllPrivateTeamListing()
+	return a.Store().Team().GetAllPrivateTeamListing()
 }
 
 func (a *App) GetAllPrivateTeamsPage(offset int, limit int) ([]*model.Team, *model.AppError) {
-	return a.Srv.Store.Team().GetAllPrivateTeamPageListing(offset, limit)
+	return a.Store().Team().GetAllPrivateTeamPageListing(offset, limit)
 }
 
 func (a *App) GetAllPrivateTeamsPageWithCount(offset int, limit int) (*model.TeamsWithCount, *model.AppError) {
-	totalCount, err := a.Srv.Store.Team().AnalyticsPrivateTeamCount()
+	totalCount, err := a.Store().Team().AnalyticsPrivateTeamCount()
 	if err != nil {
 		return nil, err
 	}
-	teams, err := a.Srv.Store.Team().GetAllPrivateTeamPageListing(offset, limit)
+	teams, err := a.Store().Team().GetAllPrivateTeamPageListing(offset, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -696,19 +696,19 @@ func (a *App) GetAllPrivateTeamsPageWithCount(offset int, limit int) (*model.Tea
 }
 
 func (a *App) GetAllPublicTeams() ([]*model.Team, *model.AppError) {
-	return a.Srv.Store.Team().GetAllTeamListing()
+	return a.Store().Team().GetAllTeamListing()
 }
 
 func (a *App) GetAllPublicTeamsPage(offset int, limit int) ([]*model.Team, *model.AppError) {
-	return a.Srv.Store.Team().GetAllTeamPageListing(offset, limit)
+	return a.Store().Team().GetAllTeamPageListing(offset, limit)
 }
 
 func (a *App) GetAllPublicTeamsPageWithCount(offset int, limit int) (*model.TeamsWithCount, *model.AppError) {
-	totalCount, err := a.Srv.Store.Team().AnalyticsPublicTeamCount()
+	totalCount, err := a.Store().Team().AnalyticsPublicTeamCount()
 	if err != nil {
 		return nil, err
 	}
-	teams, err := a.Srv.Store.Team().GetAllPublicTeamPageListing(offset, limit)
+	teams, err := a.Store().Team().GetAllPublicTeamPageListing(offset, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -716,39 +716,39 @@ func (a *App) GetAllPublicTeamsPageWithCount(offset int, limit int) (*model.Team
 }
 
 func (a *App) SearchAllTeams(term string) ([]*model.Team, *model.AppError) {
-	return a.Srv.Store.Team().SearchAll(term)
+	return a.Store().Team().SearchAll(term)
 }
 
 func (a *App) SearchPublicTeams(term string) ([]*model.Team, *model.AppError) {
-	return a.Srv.Store.Team().SearchOpen(term)
+	return a.Store().Team().SearchOpen(term)
 }
 
 func (a *App) SearchPrivateTeams(term string) ([]*model.Team, *model.AppError) {
-	return a.Srv.Store.Team().SearchPrivate(term)
+	return a.Store().Team().SearchPrivate(term)
 }
 
 func (a *App) GetTeamsForUser(userId string) ([]*model.Team, *model.AppError) {
-	return a.Srv.Store.Team().GetTeamsByUserId(userId)
+	return a.Store().Team().GetTeamsByUserId(userId)
 }
 
 func (a *App) GetTeamMember(teamId, userId string) (*model.TeamMember, *model.AppError) {
-	return a.Srv.Store.Team().GetMember(teamId, userId)
+	return a.Store().Team().GetMember(teamId, userId)
 }
 
 func (a *App) GetTeamMembersForUser(userId string) ([]*model.TeamMember, *model.AppError) {
-	return a.Srv.Store.Team().GetTeamsForUser(userId)
+	return a.Store().Team().GetTeamsForUser(userId)
 }
 
 func (a *App) GetTeamMembersForUserWithPagination(userId string, page, perPage int) ([]*model.TeamMember, *model.AppError) {
-	return a.Srv.Store.Team().GetTeamsForUserWithPagination(userId, page, perPage)
+	return a.Store().Team().GetTeamsForUserWithPagination(userId, page, perPage)
 }
 
 func (a *App) GetTeamMembers(teamId string, offset int, limit int, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, *model.AppError) {
-	return a.Srv.Store.Team().GetMembers(teamId, offset, limit, restrictions)
+	return a.Store().Team().GetMembers(teamId, offset, limit, restrictions)
 }
 
 func (a *App) GetTeamMembersByIds(teamId string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, *model.AppError) {
-	return a.Srv.Store.Team().GetMembersByIds(teamId, userIds, restrictions)
+	return a.Store().Team().GetMembersByIds(teamId, userIds, restrictions)
 }
 
 func (a *App) AddTeamMember(teamId, userId string) (*model.TeamMember, *model.AppError) {
@@ -824,7 +824,7 @@ func (a *App) AddTeamMemberByInviteId(inviteId, userId string) (*model.TeamMembe
 }
 
 func (a *App) GetTeamUnread(teamId, userId string) (*model.TeamUnread, *model.AppError) {
-	channelUnreads, err := a.Srv.Store.Team().GetChannelUnreadsForTeam(teamId, userId)
+	channelUnreads, err := a.Store().Team().GetChannelUnreadsForTeam(teamId, userId)
 	if err != nil {
 		return nil, err
 	}
@@ -849,14 +849,14 @@ func (a *App) GetTeamUnread(teamId, userId string) (*model.TeamUnread, *model.Ap
 func (a *App) RemoveUserFromTeam(teamId string, userId string, requestorId string) *model.AppError {
 	tchan := make(chan store.StoreResult, 1)
 	go func() {
-		team, err := a.Srv.Store.Team().Get(teamId)
+		team, err := a.Store().Team().Get(teamId)
 		tchan <- store.StoreResult{Data: team, Err: err}
 		close(tchan)
 	}()
 
 	uchan := make(chan store.StoreResult, 1)
 	go func() {
-		user, err := a.Srv.Store.User().Get(userId)
+		user, err := a.Store().User().Get(userId)
 		uchan <- store.StoreResult{Data: user, Err: err}
 		close(uchan)
 	}()
@@ -887,7 +887,7 @@ func (a *App) RemoveTeamMemberFromTeam(teamMember *model.TeamMember, requestorId
 	message.Add("team_id", teamMember.TeamId)
 	a.Publish(message)
 
-	user, err := a.Srv.Store.User().Get(teamMember.UserId)
+	user, err := a.Store().User().Get(teamMember.UserId)
 	if err != nil {
 		return err
 	}
@@ -895,7 +895,7 @@ func (a *App) RemoveTeamMemberFromTeam(teamMember *model.TeamMember, requestorId
 	teamMember.Roles = ""
 	teamMember.DeleteAt = model.GetMillis()
 
-	if _, err := a.Srv.Store.Team().UpdateMember(teamMember); err != nil {
+	if _, err := a.Store().Team().UpdateMember(teamMember); err != nil {
 		return err
 	}
 
@@ -923,12 +923,12 @@ func (a *App) RemoveTeamMemberFromTeam(teamMember *model.TeamMember, requestorId
 		})
 	}
 
-	if _, err := a.Srv.Store.User().UpdateUpdateAt(user.Id); err != nil {
+	if _, err := a.Store().User().UpdateUpdateAt(user.Id); err != nil {
 		return err
 	}
 
 	// delete the preferences that set the last channel used in the team and other team specific preferences
-	if err := a.Srv.Store.Preference().DeleteCategory(user.Id, teamMember.TeamId); err != nil {
+	if err := a.Store().Preference().DeleteCategory(user.Id, teamMember.TeamId); err != nil {
 		return err
 	}
 
@@ -947,7 +947,7 @@ func (a *App) LeaveTeam(team *model.Team, user *model.User, requestorId string)
 
 	var channelList *model.ChannelList
 
-	if channelList, err = a.Srv.Store.Channel().GetChannels(team.Id, user.Id, true); err != nil {
+	if channelList, err = a.Store().Channel().GetChannels(team.Id, user.Id, true); err != nil {
 		if err.Id == "store.sql_channel.get_channels.not_found.app_error" {
 			channelList = &model.ChannelList{}
 		} else {
@@ -958,13 +958,13 @@ func (a *App) LeaveTeam(team *model.Team, user *model.User, requestorId string)
 	for _, channel := range *channelList {
 		if !channel.IsGroupOrDirect() {
 			a.InvalidateCacheForChannelMembers(channel.Id)
-			if err = a.Srv.Store.Channel().RemoveMember(channel.Id, user.Id); err != nil {
+			if err = a.Store().Channel().RemoveMember(channel.Id, user.Id); err != nil {
 				return err
 			}
 		}
 	}
 
-	channel, err := a.Srv.Store.Channel().GetByName(team.Id, model.DEFAULT_CHANNEL, false)
+	channel, err := a.Store().Channel().GetByName(team.Id, model.DEFAULT_CHANNEL, false)
 	if err != nil {
 		return err
 	}
@@ -1037,14 +1037,14 @@ func (a *App) InviteNewUsersToTeam(emailList []string, teamId, senderId string)
 
 	tchan := make(chan store.StoreResult, 1)
 	go func() {
-		team, err := a.Srv.Store.Team().Get(teamId)
+		team, err := a.Store().Team().Get(teamId)
 		tchan <- store.StoreResult{Data: team, Err: err}
 		close(tchan)
 	}()
 
 	uchan := make(chan store.StoreResult, 1)
 	go func() {
-		user, err := a.Srv.Store.User().Get(senderId)
+		user, err := a.Store().User().Get(senderId)
 		uchan <- store.StoreResult{Data: user, Err: err}
 		close(uchan)
 	}()
@@ -1092,19 +1092,19 @@ func (a *App) InviteGuestsToChannels(teamId string, guestsInvite *model.GuestsIn
 
 	tchan := make(chan store.StoreResult, 1)
 	go func() {
-		team, err := a.Srv.Store.Team().Get(teamId)
+		team, err := a.Store().Team().Get(teamId)
 		tchan <- store.StoreResult{Data: team, Err: err}
 		close(tchan)
 	}()
 	cchan := make(chan store.StoreResult, 1)
 	go func() {
-		channels, err := a.Srv.Store.Channel().GetChannelsByIds(guestsInvite.Channels)
+		channels, err := a.Store().Channel().GetChannelsByIds(guestsInvite.Channels)
 		cchan <- store.StoreResult{Data: channels, Err: err}
 		close(cchan)
 	}()
 	uchan := make(chan store.StoreResult, 1)
 	go func() {
-		user, err := a.Srv.Store.User().Get(senderId)
+		user, err := a.Store().User().Get(senderId)
 		uchan <- store.StoreResult{Data: user, Err: err}
 		close(uchan)
 	}()
@@ -1153,14 +1153,14 @@ func (a *App) InviteGuestsToChannels(teamId string, guestsInvite *model.GuestsIn
 }
 
 func (a *App) FindTeamByName(name string) bool {
-	if _, err := a.Srv.Store.Team().GetByName(name); err != nil {
+	if _, err := a.Store().Team().GetByName(name); err != nil {
 		return false
 	}
 	return true
 }
 
 func (a *App) GetTeamsUnreadForUser(excludeTeamId string, userId string) ([]*model.TeamUnread, *model.AppError) {
-	data, err := a.Srv.Store.Team().GetChannelUnreadsForAllTeams(excludeTeamId, userId)
+	data, err := a.Store().Team().GetChannelUnreadsForAllTeams(excludeTeamId, userId)
 	if err != nil {
 		return nil, err
 	}
@@ -1208,11 +1208,11 @@ func (a *App) PermanentDeleteTeamId(teamId string) *model.AppError {
 
 func (a *App) PermanentDeleteTeam(team *model.Team) *model.AppError {
 	team.DeleteAt = model.GetMillis()
-	if _, err := a.Srv.Store.Team().Update(team); err != nil {
+	if _, err := a.Store().Team().Update(team); err != nil {
 		return err
 	}
 
-	if channels, err := a.Srv.Store.Channel().GetTeamChannels(team.Id); err != nil {
+	if channels, err := a.Store().Channel().GetTeamChannels(team.Id); err != nil {
 		if err.Id != "store.sql_channel.get_channels.not_found.app_error" {
 			return err
 		}
@@ -1222,15 +1222,15 @@ func (a *App) PermanentDeleteTeam(team *model.Team) *model.AppError {
 		}
 	}
 
-	if err := a.Srv.Store.Team().RemoveAllMembersByTeam(team.Id); err != nil {
+	if err := a.Store().Team().RemoveAllMembersByTeam(team.Id); err != nil {
 		return err
 	}
 
-	if err := a.Srv.Store.Command().PermanentDeleteByTeam(team.Id); err != nil {
+	if err := a.Store().Command().PermanentDeleteByTeam(team.Id); err != nil {
 		return err
 	}
 
-	if err := a.Srv.Store.Team().PermanentDelete(team.Id); err != nil {
+	if err := a.Store().Team().PermanentDelete(team.Id); err != nil {
 		return err
 	}
 
@@ -1246,7 +1246,7 @@ func (a *App) SoftDeleteTeam(teamId string) *model.AppError {
 	}
 
 	team.DeleteAt = model.GetMillis()
-	if team, err = a.Srv.Store.Team().Update(team); err != nil {
+	if team, err = a.Store().Team().Update(team); err != nil {
 		return err
 	}
 
@@ -1262,7 +1262,7 @@ func (a *App) RestoreTeam(teamId string) *model.AppError {
 	}
 
 	team.DeleteAt = 0
-	if team, err = a.Srv.Store.Team().Update(team); err != nil {
+	if team, err = a.Store().Team().Update(team); err != nil {
 		return err
 	}
 
@@ -1273,13 +1273,13 @@ func (a *App) RestoreTeam(teamId string) *model.AppError {
 func (a *App) GetTeamStats(teamId string, restrictions *model.ViewUsersRestrictions) (*model.TeamStats, *model.AppError) {
 	tchan := make(chan store.StoreResult, 1)
 	go func() {
-		totalMemberCount, err := a.Srv.Store.Team().GetTotalMemberCount(teamId, restrictions)
+		totalMemberCount, err := a.Store().Team().GetTotalMemberCount(teamId, restrictions)
 		tchan <- store.StoreResult{Data: totalMemberCount, Err: err}
 		close(tchan)
 	}()
 	achan := make(chan store.StoreResult, 1)
 	go func() {
-		memberCount, err := a.Srv.Store.Team().GetActiveMemberCount(teamId, restrictions)
+		memberCount, err := a.Store().Team().GetActiveMemberCount(teamId, restrictions)
 		achan <- store.StoreResult{Data: memberCount, Err: err}
 		close(achan)
 	}()
@@ -1307,7 +1307,7 @@ func (a *App) GetTeamIdFromQuery(query url.Values) (string, *model.AppError) {
 	inviteId := query.Get("id")
 
 	if len(tokenId) > 0 {
-		token, err := a.Srv.Store.Token().GetByToken(tokenId)
+		token, err := a.Store().Token().GetByToken(tokenId)
 		if err != nil {
 			return "", model.NewAppError("GetTeamIdFromQuery", "api.oauth.singup_with_oauth.invalid_link.app_error", nil, "", http.StatusBadRequest)
 		}
@@ -1326,7 +1326,7 @@ func (a *App) GetTeamIdFromQuery(query url.Values) (string, *model.AppError) {
 		return tokenData["teamId"], nil
 	}
 	if len(inviteId) > 0 {
-		team, err := a.Srv.Store.Team().GetByInviteId(inviteId)
+		team, err := a.Store().Team().GetByInviteId(inviteId)
 		if err == nil {
 			return team.Id, nil
 		}
@@ -1429,7 +1429,7 @@ func (a *App) SetTeamIconFromFile(team *model.Team, file io.Reader) *model.AppEr
 
 	curTime := model.GetMillis()
 
-	if err := a.Srv.Store.Team().UpdateLastTeamIconUpdate(team.Id, curTime); err != nil {
+	if err := a.Store().Team().UpdateLastTeamIconUpdate(team.Id, curTime); err != nil {
 		return model.NewAppError("SetTeamIcon", "api.team.team_icon.update.app_error", nil, err.Error(), http.StatusBadRequest)
 	}
 
@@ -1447,7 +1447,7 @@ func (a *App) RemoveTeamIcon(teamId string) *model.AppError {
 		return model.NewAppError("RemoveTeamIcon", "api.team.remove_team_icon.get_team.app_error", nil, err.Error(), http.StatusBadRequest)
 	}
 
-	if err := a.Srv.Store.Team().UpdateLastTeamIconUpdate(teamId, 0); err != nil {
+	if err := a.Store().Team().UpdateLastTeamIconUpdate(teamId, 0); err != nil {
 		return model.NewAppError("RemoveTeamIcon", "api.team.team_icon.update.app_error", nil, err.Error(), http.StatusBadRequest)
 	}
 
@@ -1459,10 +1459,10 @@ func (a *App) RemoveTeamIcon(teamId string) *model.AppError {
 }
 
 func (a *App) InvalidateAllEmailInvites() *model.AppError {
-	if err := a.Srv.Store.Token().RemoveAllTokensByType(TOKEN_TYPE_TEAM_INVITATION); err != nil {
+	if err := a.Store().Token().RemoveAllTokensByType(TOKEN_TYPE_TEAM_INVITATION); err != nil {
 		return model.NewAppError("InvalidateAllEmailInvites", "api.team.invalidate_all_email_invites.app_error", nil, err.Error(), http.StatusBadRequest)
 	}
-	if err := a.Srv.Store.Token().RemoveAllTokensByType(TOKEN_TYPE_GUEST_INVITATION); err != nil {
+	if err := a.Store().Token().RemoveAllTokensByType(TOKEN_TYPE_GUEST_INVITATION); err != nil {
 		return model.NewAppError("InvalidateAllEmailInvites", "api.team.invalidate_all_email_invites.app_error", nil, err.Error(), http.StatusBadRequest)
 	}
 	return nil
@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/disintegration/imaging"
 	"github.com/golang/freetype"
@@ -62,12 +63,12 @@ func (a *App) CreateUserWithToken(user *model.User, token *model.Token) (*model.
 
 	tokenData := model.MapFromJson(strings.NewReader(token.Extra))
 
-	team, err := a.Srv.Store.Team().Get(tokenData["teamId"])
+	team, err := a.Store().Team().Get(tokenData["teamId"])
 	if err != nil {
 		return nil, err
 	}
 
-	channels, err := a.Srv.Store.Channel().GetChannelsByIds(strings.Split(tokenData["channels"], " "))
+	channels, err := a.Store().Channel().GetChannelsByIds(strings.Split(tokenData["channels"], " "))
 	if err != nil {
 		return nil, err
 	}
@@ -112,7 +113,7 @@ func (a *App) CreateUserWithInviteId(user *model.User, inviteId string) (*model.
 		return nil, err
 	}
 
-	team, err := a.Srv.Store.Team().GetByInviteId(inviteId)
+	team, err := a.Store().Team().GetByInviteId(inviteId)
 	if err != nil {
 		return nil, err
 	}
@@ -188,7 +189,7 @@ func (a *App) IsUserSignUpAllowed() *model.AppError {
 
 func (a *App) IsFirstUserAccount() bool {
 	if a.SessionCacheLength() == 0 {
-		count, err := a.Srv.Store.User().Count(model.UserCountOptions{IncludeDeleted: true})
+		count, err := a.Store().User().Count(model.UserCountOptions{IncludeDeleted: true})
 		if err != nil {
 			mlog.Error(fmt.Sprint(err))
 			return false
@@ -204,7 +205,7 @@ func (a *App) IsFirstUserAccount() bool {
 // indexUser fetches the required information to index a user from the database and
 // calls the elasticsearch interface method
 func (a *App) indexUser(user *model.User) *model.AppError {
-	userTeams, err := a.Srv.Store.Team().GetTeamsByUserId(user.Id)
+	userTeams, err := a.Store().Team().GetTeamsByUserId(user.Id)
 	if err != nil {
 		return err
 	}
@@ -214,7 +215,7 @@ func (a *App) indexUser(user *model.User) *model.AppError {
 		userTeamsIds = append(userTeamsIds, team.Id)
 	}
 
-	userChannelMembers, err := a.Srv.Store.Channel().GetAllChannelMembersForUser(user.Id, false, true)
+	userChannelMembers, err := a.Store().Channel().GetAllChannelMembersForUser(user.Id, false, true)
 	if err != nil {
 		return err
 	}
@@ -263,7 +264,7 @@ func (a *App) createUserOrGuest(user *model.User, guest bool) (*model.User, *mod
 
 	// Below is a special case where the first user in the entire
 	// system is granted the system_admin role
-	count, err := a.Srv.Store.User().Count(model.UserCountOptions{IncludeDeleted: true})
+	count, err := a.Store().User().Count(model.UserCountOptions{IncludeDeleted: true})
 	if err != nil {
 		return nil, err
 	}
@@ -312,7 +313,7 @@ func (a *App) createUser(user *model.User) (*model.User, *model.AppError) {
 		return nil, err
 	}
 
-	ruser, err := a.Srv.Store.User().Save(user)
+	ruser, err := a.Store().User().Save(user)
 	if err != nil {
 		mlog.Error(fmt.Sprintf("Couldn't save the user err=%v", err))
 		return nil, err
@@ -325,7 +326,7 @@ func (a *App) createUser(user *model.User) (*model.User, *model.AppError) {
 	}
 
 	pref := model.Preference{UserId: ruser.Id, Category: model.PREFERENCE_CATEGORY_TUTORIAL_STEPS, Name: ruser.Id, Value: "0"}
-	if err := a.Srv.Store.Preference().Save(&model.Preferences{pref}); err != nil {
+	if err := a.Store().Preference().Save(&model.Preferences{pref}); err != nil {
 		mlog.Error(fmt.Sprintf("Encountered error saving tutorial preference, err=%v", err.Message))
 	}
 
@@ -351,12 +352,12 @@ func (a *App) CreateOAuthUser(service string, userData io.Reader, teamId string)
 	suchan := make(chan store.StoreResult, 1)
 	euchan := make(chan store.StoreResult, 1)
 	go func() {
-		userByAuth, err := a.Srv.Store.User().GetByAuth(user.AuthData, service)
+		userByAuth, err := a.Store().User().GetByAuth(user.AuthData, service)
 		suchan <- store.StoreResult{Data: userByAuth, Err: err}
 		close(suchan)
 	}()
 	go func() {
-		userByEmail, err := a.Srv.Store.User().GetByEmail(user.Email)
+		userByEmail, err := a.Store().User().GetByEmail(user.Email)
 		euchan <- store.StoreResult{Data: userByEmail, Err: err}
 		close(euchan)
 	}()
@@ -432,7 +433,7 @@ func (a *App) IsUsernameTaken(name string) bool {
 		return false
 	}
 
-	if _, err := a.Srv.Store.User().GetByUsername(name); err != nil {
+	if _, err := a.Store().User().GetByUsername(name); err != nil {
 		return false
 	}
 
@@ -440,11 +441,11 @@ func (a *App) IsUsernameTaken(name string) bool {
 }
 
 func (a *App) GetUser(userId string) (*model.User, *model.AppError) {
-	return a.Srv.Store.User().Get(userId)
+	return a.Store().User().Get(userId)
 }
 
 func (a *App) GetUserByUsername(username string) (*model.User, *model.AppError) {
-	result, err := a.Srv.Store.User().GetByUsername(username)
+	result, err := a.Store().User().GetByUsername(username)
 	if err != nil && err.Id == "store.sql_user.get_by_username.app_error" {
 		err.StatusCode = http.StatusNotFound
 		return nil, err
@@ -453,7 +454,7 @@ func (a *App) GetUserByUsername(username string) (*model.User, *model.AppError)
 }
 
 func (a *App) GetUserByEmail(email string) (*model.User, *model.AppError) {
-	user, err := a.Srv.Store.User().GetByEmail(email)
+	user, err := a.Store().User().GetByEmail(email)
 	if err != nil {
 		if err.Id == "store.sql_user.missing_account.const" {
 			err.StatusCode = http.StatusNotFound
@@ -466,11 +467,11 @@ func (a *App) GetUserByEmail(email string) (*model.User, *model.AppError) {
 }
 
 func (a *App) GetUserByAuth(authData *string, authService string) (*model.User, *model.AppError) {
-	return a.Srv.Store.User().GetByAuth(authData, authService)
+	return a.Store().User().GetByAuth(authData, authService)
 }
 
 func (a *App) GetUsers(options *model.UserGetOptions) ([]*model.User, *model.AppError) {
-	return a.Srv.Store.User().GetAllProfiles(options)
+	return a.Store().User().GetAllProfiles(options)
 }
 
 func (a *App) GetUsersPage(options *model.UserGetOptions, asAdmin bool) ([]*model.User, *model.AppError) {
@@ -483,15 +484,15 @@ func (a *App) GetUsersPage(options *model.UserGetOptions, asAdmin bool) ([]*mode
 }
 
 func (a *App) GetUsersEtag(restrictionsHash string) string {
-	return fmt.Sprintf("%v.%v.%v.%v", a.Srv.Store.User().GetEtagForAllProfiles(), a.Config().PrivacySettings.ShowFullName, a.Config().PrivacySettings.ShowEmailAddress, restrictionsHash)
+	return fmt.Sprintf("%v.%v.%v.%v", a.Store().User().GetEtagForAllProfiles(), a.Config().PrivacySettings.ShowFullName, a.Config().PrivacySettings.ShowEmailAddress, restrictionsHash)
 }
 
 func (a *App) GetUsersInTeam(options *model.UserGetOptions) ([]*model.User, *model.AppError) {
-	return a.Srv.Store.User().GetProfiles(options)
+	return a.Store().User().GetProfiles(options)
 }
 
 func (a *App) GetUsersNotInTeam(teamId string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
-	return a.Srv.Store.User().GetProfilesNotInTeam(teamId, groupConstrained, offset, limit, viewRestrictions)
+	return a.Store().User().GetProfilesNotInTeam(teamId, groupConstrained, offset, limit, viewRestrictions)
 }
 
 func (a *App) GetUsersInTeamPage(options *model.UserGetOptions, asAdmin bool) ([]*model.User, *model.AppError) {
@@ -513,19 +514,19 @@ func (a *App) GetUsersNotInTeamPage(teamId string, groupConstrained bool, page i
 }
 
 func (a *App) GetUsersInTeamEtag(teamId string, restrictionsHash string) string {
-	return fmt.Sprintf("%v.%v.%v.%v", a.Srv.Store.User().GetEtagForProfiles(teamId), a.Config().PrivacySettings.ShowFullName, a.Config().PrivacySettings.ShowEmailAddress, restrictionsHash)
+	return fmt.Sprintf("%v.%v.%v.%v", a.Store().User().GetEtagForProfiles(teamId), a.Config().PrivacySettings.ShowFullName, a.Config().PrivacySettings.ShowEmailAddress, restrictionsHash)
 }
 
 func (a *App) GetUsersNotInTeamEtag(teamId string, restrictionsHash string) string {
-	return fmt.Sprintf("%v.%v.%v.%v", a.Srv.Store.User().GetEtagForProfilesNotInTeam(teamId), a.Config().PrivacySettings.ShowFullName, a.Config().PrivacySettings.ShowEmailAddress, restrictionsHash)
+	return fmt.Sprintf("%v.%v.%v.%v", a.Store().User().GetEtagForProfilesNotInTeam(teamId), a.Config().PrivacySettings.ShowFullName, a.Config().PrivacySettings.ShowEmailAddress, restrictionsHash)
 }
 
 func (a *App) GetUsersInChannel(channelId string, offset int, limit int) ([]*model.User, *model.AppError) {
-	return a.Srv.Store.User().GetProfilesInChannel(channelId, offset, limit)
+	return a.Store().User().GetProfilesInChannel(channelId, offset, limit)
 }
 
 func (a *App) GetUsersInChannelByStatus(channelId string, offset int, limit int) ([]*model.User, *model.AppError) {
-	return a.Srv.Store.User().GetProfilesInChannelByStatus(channelId, offset, limit)
+	return a.Store().User().GetProfilesInChannelByStatus(channelId, offset, limit)
 }
 
 func (a *App) GetUsersInChannelMap(channelId string, offset int, limit int, asAdmin bool) (map[string]*model.User, *model.AppError) {
@@ -561,7 +562,7 @@ func (a *App) GetUsersInChannelPageByStatus(channelId string, page int, perPage
 }
 
 func (a *App) GetUsersNotInChannel(teamId string, channelId string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
-	return a.Srv.Store.User().GetProfilesNotInChannel(teamId, channelId, groupConstrained, offset, limit, viewRestrictions)
+	return a.Store().User().GetProfilesNotInChannel(teamId, channelId, groupConstrained, offset, limit, viewRestrictions)
 }
 
 func (a *App) GetUsersNotInChannelMap(teamId string, channelId string, groupConstrained bool, offset int, limit int, asAdmin bool, viewRestrictions *model.ViewUsersRestrictions) (map[string]*model.User, *model.AppError) {
@@ -599,23 +600,23 @@ func (a *App) GetUsersWithoutTeamPage(page int, perPage int, asAdmin bool, viewR
 }
 
 func (a *App) GetUsersWithoutTeam(offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
-	return a.Srv.Store.User().GetProfilesWithoutTeam(offset, limit, viewRestrictions)
+	return a.Store().User().GetProfilesWithoutTeam(offset, limit, viewRestrictions)
 }
 
 // GetTeamGroupUsers returns the users who are associated to the team via GroupTeams and GroupMembers.
 func (a *App) GetTeamGroupUsers(teamID string) ([]*model.User, *model.AppError) {
-	return a.Srv.Store.User().GetTeamGroupUsers(teamID)
+	return a.Store().User().GetTeamGroupUsers(teamID)
 }
 
 // GetChannelGroupUsers returns the users who are associated to the channel via GroupChannels and GroupMembers.
 func (a *App) GetChannelGroupUsers(channelID string) ([]*model.User, *model.AppError) {
-	return a.Srv.Store.User().GetChannelGroupUsers(channelID)
+	return a.Store().User().GetChannelGroupUsers(channelID)
 }
 
 func (a *App) GetUsersByIds(userIds []string, options *store.UserGetByIdsOpts) ([]*model.User, *model.AppError) {
 	allowFromCache := options.ViewRestrictions == nil
 
-	users, err := a.Srv.Store.User().GetProfileByIds(userIds, options, allowFromCache)
+	users, err := a.Store().User().GetProfileByIds(userIds, options, allowFromCache)
 	if err != nil {
 		return nil, err
 	}
@@ -624,7 +625,7 @@ func (a *App) GetUsersByIds(userIds []string, options *store.UserGetByIdsOpts) (
 }
 
 func (a *App) GetUsersByGroupChannelIds(channelIds []string, asAdmin bool) (map[string][]*model.User, *model.AppError) {
-	usersByChannelId, err := a.Srv.Store.User().GetProfileByGroupChannelIdsForUser(a.Session.UserId, channelIds)
+	usersByChannelId, err := a.Store().User().GetProfileByGroupChannelIdsForUser(a.Session.UserId, channelIds)
 	if err != nil {
 		return nil, err
 	}
@@ -636,7 +637,7 @@ func (a *App) GetUsersByGroupChannelIds(channelIds []string, asAdmin bool) (map[
 }
 
 func (a *App) GetUsersByUsernames(usernames []string, asAdmin bool, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
-	users, err := a.Srv.Store.User().GetProfilesByUsernames(usernames, viewRestrictions)
+	users, err := a.Store().User().GetProfilesByUsernames(usernames, viewRestrictions)
 	if err != nil {
 		return nil, err
 	}
@@ -657,7 +658,7 @@ func (a *App) GenerateMfaSecret(userId string) (*model.MfaSecret, *model.AppErro
 		return nil, err
 	}
 
-	mfaService := mfa.New(a, a.Srv.Store)
+	mfaService := mfa.New(a, a.Store())
 	secret, img, err := mfaService.GenerateSecret(user)
 	if err != nil {
 		return nil, err
@@ -668,7 +669,7 @@ func (a *App) GenerateMfaSecret(userId string) (*model.MfaSecret, *model.AppErro
 }
 
 func (a *App) ActivateMfa(userId, token string) *model.AppError {
-	user, err := a.Srv.Store.User().Get(userId)
+	user, err := a.Store().User().Get(userId)
 	if err != nil {
 		return err
 	}
@@ -677,7 +678,7 @@ func (a *App) ActivateMfa(userId, token string) *model.AppError {
 		return model.NewAppError("ActivateMfa", "api.user.activate_mfa.email_and_ldap_only.app_error", nil, "", http.StatusBadRequest)
 	}
 
-	mfaService := mfa.New(a, a.Srv.Store)
+	mfaService := mfa.New(a, a.Store())
 	if err := mfaService.Activate(user, token); err != nil {
 		return err
 	}
@@ -686,7 +687,7 @@ func (a *App) ActivateMfa(userId, token string) *model.AppError {
 }
 
 func (a *App) DeactivateMfa(userId string) *model.AppError {
-	mfaService := mfa.New(a, a.Srv.Store)
+	mfaService := mfa.New(a, a.Store())
 	if err := mfaService.Deactivate(userId); err != nil {
 		return err
 	}
@@ -834,7 +835,7 @@ func (a *App) SetDefaultProfileImage(user *model.User) *model.AppError {
 		return err
 	}
 
-	if err := a.Srv.Store.User().ResetLastPictureUpdate(user.Id); err != nil {
+	if err := a.Store().User().ResetLastPictureUpdate(user.Id); err != nil {
 		mlog.Error(err.Error())
 	}
 
@@ -907,7 +908,7 @@ func (a *App) SetProfileImageFromFile(userId string, file io.Reader) *model.AppE
 		return model.NewAppError("SetProfileImage", "api.user.upload_profile_user.upload_profile.app_error", nil, "", http.StatusInternalServerError)
 	}
 
-	if err := a.Srv.Store.User().UpdateLastPictureUpdate(userId); err != nil {
+	if err := a.Store().User().UpdateLastPictureUpdate(userId); err != nil {
 		mlog.Error(err.Error())
 	}
 	a.invalidateUserCacheAndPublish(userId)
@@ -985,7 +986,7 @@ func (a *App) UpdateActive(user *model.User, active bool) (*model.User, *model.A
 		user.DeleteAt = user.UpdateAt
 	}
 
-	userUpdate, err := a.Srv.Store.User().Update(user, true)
+	userUpdate, err := a.Store().User().Update(user, true)
 	if err != nil {
 		return nil, err
 	}
@@ -1002,6 +1003,18 @@ func (a *App) UpdateActive(user *model.User, active bool) (*model.User, *model.A
 
 	a.sendUpdatedUserEvent(*ruser)
 
+	if !active && userUpdate.Old.DeleteAt == 0 {
+		if pluginsEnvironment := a.GetPluginsEnvironment(); pluginsEnvironment != nil {
+			a.Srv.Go(func() {
+				pluginContext := a.PluginContext()
+				pluginsEnvironment.RunMultiPluginHook(func(hooks plugin.Hooks) bool {
+					hooks.UserHasBeenDeactivated(pluginContext, ruser)
+					return true
+				}, plugin.UserHasBeenDeactivatedId)
+			})
+		}
+	}
+
 	return ruser, nil
 }
 
@@ -1060,13 +1073,13 @@ func (a *App) UpdateUserAuth(userId string, userAuth *model.UserAuth) (*model.Us
 		}
 		password := model.HashPassword(userAuth.Password)
 
-		if err := a.Srv.Store.User().UpdatePassword(userId, password); err != nil {
+		if err := a.Store().User().UpdatePassword(userId, password); err != nil {
 			return nil, err
 		}
 	} else {
 		userAuth.Password = ""
 
-		if _, err := a.Srv.Store.User().UpdateAuthData(userId, userAuth.AuthService, userAuth.AuthData, "", false); err != nil {
+		if _, err := a.Store().User().UpdateAuthData(userId, userAuth.AuthService, userAuth.AuthData, "", false); err != nil {
 			return nil, err
 		}
 	}
@@ -1090,7 +1103,7 @@ func (a *App) sendUpdatedUserEvent(user model.User) {
 }
 
 func (a *App) UpdateUser(user *model.User, sendNotifications bool) (*model.User, *model.AppError) {
-	prev, err := a.Srv.Store.User().Get(user.Id)
+	prev, err := a.Store().User().Get(user.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -1127,11 +1140,17 @@ func (a *App) UpdateUser(user *model.User, sendNotifications bool) (*model.User,
 		}
 	}
 
-	userUpdate, err := a.Srv.Store.User().Update(user, false)
+	userUpdate, err := a.Store().User().Update(user, false)
 	if err != nil {
 		return nil, err
 	}
 
+	if userUpdate.New.NotifyProps[model.WORKING_HOURS_NOTIFY_PROP] != userUpdate.Old.NotifyProps[model.WORKING_HOURS_NOTIFY_PROP] || userUpdate.New.GetPreferredTimezone() != userUpdate.Old.GetPreferredTimezone() {
+		if err := a.setWorkingHoursEnd(userUpdate.New, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
 	if sendNotifications {
 		if userUpdate.New.Email != userUpdate.Old.Email || newEmail != "" {
 			if *a.Config().EmailSettings.RequireEmailVerification {
@@ -1160,6 +1179,16 @@ func (a *App) UpdateUser(user *model.User, sendNotifications bool) (*model.User,
 
 	a.InvalidateCacheForUser(user.Id)
 
+	if pluginsEnvironment := a.GetPluginsEnvironment(); pluginsEnvironment != nil {
+		a.Srv.Go(func() {
+			pluginContext := a.PluginContext()
+			pluginsEnvironment.RunMultiPluginHook(func(hooks plugin.Hooks) bool {
+				hooks.UserHasBeenUpdated(pluginContext, userUpdate.New, userUpdate.Old)
+				return true
+			}, plugin.UserHasBeenUpdatedId)
+		})
+	}
+
 	if a.IsESIndexingEnabled() {
 		a.Srv.Go(func() {
 			if err := a.indexUser(user); err != nil {
@@ -1242,7 +1271,7 @@ func (a *App) UpdatePassword(user *model.User, newPassword string) *model.AppErr
 
 	hashedPassword := model.HashPassword(newPassword)
 
-	if err := a.Srv.Store.User().UpdatePassword(user.Id, hashedPassword); err != nil {
+	if err := a.Store().User().UpdatePassword(user.Id, hashedPassword); err != nil {
 		return model.NewAppError("UpdatePassword", "api.user.update_password.failed.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
@@ -1343,7 +1372,7 @@ func (a *App) CreatePasswordRecoveryToken(userId, email string) (*model.Token, *
 
 	token := model.NewToken(TOKEN_TYPE_PASSWORD_RECOVERY, string(jsonData))
 
-	if err := a.Srv.Store.Token().Save(token); err != nil {
+	if err := a.Store().Token().Save(token); err != nil {
 		return nil, err
 	}
 
@@ -1351,7 +1380,7 @@ func (a *App) CreatePasswordRecoveryToken(userId, email string) (*model.Token, *
 }
 
 func (a *App) GetPasswordRecoveryToken(token string) (*model.Token, *model.AppError) {
-	rtoken, err := a.Srv.Store.Token().GetByToken(token)
+	rtoken, err := a.Store().Token().GetByToken(token)
 	if err != nil {
 		return nil, model.NewAppError("GetPasswordRecoveryToken", "api.user.reset_password.invalid_link.app_error", nil, err.Error(), http.StatusBadRequest)
 	}
@@ -1362,7 +1391,7 @@ func (a *App) GetPasswordRecoveryToken(token string) (*model.Token, *model.AppEr
 }
 
 func (a *App) DeleteToken(token *model.Token) *model.AppError {
-	return a.Srv.Store.Token().Delete(token.Token)
+	return a.Store().Token().Delete(token.Token)
 }
 
 func (a *App) UpdateUserRoles(userId string, newRoles string, sendWebSocketEvent bool) (*model.User, *model.AppError) {
@@ -1379,14 +1408,14 @@ func (a *App) UpdateUserRoles(userId string, newRoles string, sendWebSocketEvent
 	user.Roles = newRoles
 	uchan := make(chan store.StoreResult, 1)
 	go func() {
-		userUpdate, err := a.Srv.Store.User().Update(user, true)
+		userUpdate, err := a.Store().User().Update(user, true)
 		uchan <- store.StoreResult{Data: userUpdate, Err: err}
 		close(uchan)
 	}()
 
 	schan := make(chan store.StoreResult, 1)
 	go func() {
-		userId, err := a.Srv.Store.Session().UpdateRoles(user.Id, newRoles)
+		userId, err := a.Store().Session().UpdateRoles(user.Id, newRoles)
 		schan <- store.StoreResult{Data: userId, Err: err}
 		close(schan)
 	}()
@@ -1424,47 +1453,47 @@ func (a *App) PermanentDeleteUser(user *model.User) *model.AppError {
 		return err
 	}
 
-	if err := a.Srv.Store.Session().PermanentDeleteSessionsByUser(user.Id); err != nil {
+	if err := a.Store().Session().PermanentDeleteSessionsByUser(user.Id); err != nil {
 		return err
 	}
 
-	if err := a.Srv.Store.UserAccessToken().DeleteAllForUser(user.Id); err != nil {
+	if err := a.Store().UserAccessToken().DeleteAllForUser(user.Id); err != nil {
 		return err
 	}
 
-	if err := a.Srv.Store.OAuth().PermanentDeleteAuthDataByUser(user.Id); err != nil {
+	if err := a.Store().OAuth().PermanentDeleteAuthDataByUser(user.Id); err != nil {
 		return err
 	}
 
-	if err := a.Srv.Store.Webhook().PermanentDeleteIncomingByUser(user.Id); err != nil {
+	if err := a.Store().Webhook().PermanentDeleteIncomingByUser(user.Id); err != nil {
 		return err
 	}
 
-	if err := a.Srv.Store.Webhook().PermanentDeleteOutgoingByUser(user.Id); err != nil {
+	if err := a.Store().Webhook().PermanentDeleteOutgoingByUser(user.Id); err != nil {
 		return err
 	}
 
-	if err := a.Srv.Store.Command().PermanentDeleteByUser(user.Id); err != nil {
+	if err := a.Store().Command().PermanentDeleteByUser(user.Id); err != nil {
 		return err
 	}
 
-	if err := a.Srv.Store.Preference().PermanentDeleteByUser(user.Id); err != nil {
+	if err := a.Store().Preference().PermanentDeleteByUser(user.Id); err != nil {
 		return err
 	}
 
-	if err := a.Srv.Store.Channel().PermanentDeleteMembersByUser(user.Id); err != nil {
+	if err := a.Store().Channel().PermanentDeleteMembersByUser(user.Id); err != nil {
 		return err
 	}
 
-	if err := a.Srv.Store.Post().PermanentDeleteByUser(user.Id); err != nil {
+	if err := a.Store().Post().PermanentDeleteByUser(user.Id); err != nil {
 		return err
 	}
 
-	if err := a.Srv.Store.Bot().PermanentDelete(user.Id); err != nil {
+	if err := a.Store().Bot().PermanentDelete(user.Id); err != nil {
 		return err
 	}
 
-	infos, err := a.Srv.Store.FileInfo().GetForUser(user.Id)
+	infos, err := a.Store().FileInfo().GetForUser(user.Id)
 	if err != nil {
 		mlog.Warn("Error getting file list for user from FileInfoStore")
 	}
@@ -1496,19 +1525,19 @@ func (a *App) PermanentDeleteUser(user *model.User) *model.AppError {
 		}
 	}
 
-	if _, err := a.Srv.Store.FileInfo().PermanentDeleteByUser(user.Id); err != nil {
+	if _, err := a.Store().FileInfo().PermanentDeleteByUser(user.Id); err != nil {
 		return err
 	}
 
-	if err := a.Srv.Store.User().PermanentDelete(user.Id); err != nil {
+	if err := a.Store().User().PermanentDelete(user.Id); err != nil {
 		return err
 	}
 
-	if err := a.Srv.Store.Audit().PermanentDeleteByUser(user.Id); err != nil {
+	if err := a.Store().Audit().PermanentDeleteByUser(user.Id); err != nil {
 		return err
 	}
 
-	if err := a.Srv.Store.Team().RemoveAllMembersByUser(user.Id); err != nil {
+	if err := a.Store().Team().RemoveAllMembersByUser(user.Id); err != nil {
 		return err
 	}
 
@@ -1526,7 +1555,7 @@ func (a *App) PermanentDeleteUser(user *model.User) *model.AppError {
 }
 
 func (a *App) PermanentDeleteAllUsers() *model.AppError {
-	users, err := a.Srv.Store.User().GetAll()
+	users, err := a.Store().User().GetAll()
 	if err != nil {
 		return err
 	}
@@ -1608,7 +1637,7 @@ func (a *App) CreateVerifyEmailToken(userId string, newEmail string) (*model.Tok
 
 	token := model.NewToken(TOKEN_TYPE_VERIFY_EMAIL, string(jsonData))
 
-	if err := a.Srv.Store.Token().Save(token); err != nil {
+	if err := a.Store().Token().Save(token); err != nil {
 		return nil, err
 	}
 
@@ -1616,7 +1645,7 @@ func (a *App) CreateVerifyEmailToken(userId string, newEmail string) (*model.Tok
 }
 
 func (a *App) GetVerifyEmailToken(token string) (*model.Token, *model.AppError) {
-	rtoken, err := a.Srv.Store.Token().GetByToken(token)
+	rtoken, err := a.Store().Token().GetByToken(token)
 	if err != nil {
 		return nil, model.NewAppError("GetVerifyEmailToken", "api.user.verify_email.bad_link.app_error", nil, err.Error(), http.StatusBadRequest)
 	}
@@ -1628,7 +1657,7 @@ func (a *App) GetVerifyEmailToken(token string) (*model.Token, *model.AppError)
 
 // GetTotalUsersStats is used for the DM list total
 func (a *App) GetTotalUsersStats(viewRestrictions *model.ViewUsersRestrictions) (*model.UsersStats, *model.AppError) {
-	count, err := a.Srv.Store.User().Count(model.UserCountOptions{
+	count, err := a.Store().User().Count(model.UserCountOptions{
 		IncludeBotAccounts: true,
 		ViewRestrictions:   viewRestrictions,
 	})
@@ -1642,7 +1671,7 @@ func (a *App) GetTotalUsersStats(viewRestrictions *model.ViewUsersRestrictions)
 }
 
 func (a *App) VerifyUserEmail(userId, email string) *model.AppError {
-	_, err := a.Srv.Store.User().VerifyEmail(userId, email)
+	_, err := a.Store().User().VerifyEmail(userId, email)
 	if err != nil {
 		return err
 	}
@@ -1676,7 +1705,7 @@ func (a *App) SearchUsers(props *model.UserSearch, options *model.UserSearchOpti
 
 func (a *App) SearchUsersInChannel(channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	term = strings.TrimSpace(term)
-	users, err := a.Srv.Store.User().SearchInChannel(channelId, term, options)
+	users, err := a.Store().User().SearchInChannel(channelId, term, options)
 	if err != nil {
 		return nil, err
 	}
@@ -1689,7 +1718,7 @@ func (a *App) SearchUsersInChannel(channelId string, term string, options *model
 
 func (a *App) SearchUsersNotInChannel(teamId string, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	term = strings.TrimSpace(term)
-	users, err := a.Srv.Store.User().SearchNotInChannel(teamId, channelId, term, options)
+	users, err := a.Store().User().SearchNotInChannel(teamId, channelId, term, options)
 	if err != nil {
 		return nil, err
 	}
@@ -1715,7 +1744,7 @@ func (a *App) esSearchUsersInTeam(teamId, term string, options *model.UserSearch
 		return nil, err
 	}
 
-	users, err := a.Srv.Store.User().GetProfileByIds(usersIds, nil, false)
+	users, err := a.Store().User().GetProfileByIds(usersIds, nil, false)
 	if err != nil {
 		return nil, err
 	}
@@ -1740,7 +1769,7 @@ func (a *App) SearchUsersInTeam(teamId, term string, options *model.UserSearchOp
 	}
 
 	if !a.IsESAutocompletionEnabled() || err != nil {
-		users, err = a.Srv.Store.User().Search(teamId, term, options)
+		users, err = a.Store().User().Search(teamId, term, options)
 		if err != nil {
 			return nil, err
 		}
@@ -1755,7 +1784,7 @@ func (a *App) SearchUsersInTeam(teamId, term string, options *model.UserSearchOp
 
 func (a *App) SearchUsersNotInTeam(notInTeamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	term = strings.TrimSpace(term)
-	users, err := a.Srv.Store.User().SearchNotInTeam(notInTeamId, term, options)
+	users, err := a.Store().User().SearchNotInTeam(notInTeamId, term, options)
 	if err != nil {
 		return nil, err
 	}
@@ -1769,7 +1798,7 @@ func (a *App) SearchUsersNotInTeam(notInTeamId string, term string, options *mod
 
 func (a *App) SearchUsersWithoutTeam(term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	term = strings.TrimSpace(term)
-	users, err := a.Srv.Store.User().SearchWithoutTeam(term, options)
+	users, err := a.Store().User().SearchWithoutTeam(term, options)
 	if err != nil {
 		return nil, err
 	}
@@ -1802,14 +1831,14 @@ func (a *App) esAutocompleteUsersInChannel(teamId, channelId, term string, optio
 
 	uchan := make(chan store.StoreResult, 1)
 	go func() {
-		users, err := a.Srv.Store.User().GetProfileByIds(uchanIds, nil, false)
+		users, err := a.Store().User().GetProfileByIds(uchanIds, nil, false)
 		uchan <- store.StoreResult{Data: users, Err: err}
 		close(uchan)
 	}()
 
 	nuchan := make(chan store.StoreResult, 1)
 	go func() {
-		users, err := a.Srv.Store.User().GetProfileByIds(nuchanIds, nil, false)
+		users, err := a.Store().User().GetProfileByIds(nuchanIds, nil, false)
 		nuchan <- store.StoreResult{Data: users, Err: err}
 		close(nuchan)
 	}()
@@ -1860,14 +1889,14 @@ func (a *App) AutocompleteUsersInChannel(teamId string, channelId string, term s
 
 		uchan := make(chan store.StoreResult, 1)
 		go func() {
-			users, err := a.Srv.Store.User().SearchInChannel(channelId, term, options)
+			users, err := a.Store().User().SearchInChannel(channelId, term, options)
 			uchan <- store.StoreResult{Data: users, Err: err}
 			close(uchan)
 		}()
 
 		nuchan := make(chan store.StoreResult, 1)
 		go func() {
-			users, err := a.Srv.Store.User().SearchNotInChannel(teamId, channelId, term, options)
+			users, err := a.Store().User().SearchNotInChannel(teamId, channelId, term, options)
 			nuchan <- store.StoreResult{Data: users, Err: err}
 			close(nuchan)
 		}()
@@ -1915,7 +1944,7 @@ func (a *App) esAutocompleteUsersInTeam(teamId, term string, options *model.User
 		return nil, err
 	}
 
-	users, err := a.Srv.Store.User().GetProfileByIds(usersIds, nil, false)
+	users, err := a.Store().User().GetProfileByIds(usersIds, nil, false)
 	if err != nil {
 		return nil, err
 	}
@@ -1945,7 +1974,7 @@ func (a *App) AutocompleteUsersInTeam(teamId string, term string, options *model
 
 	if !a.IsESAutocompletionEnabled() || err != nil {
 		autocomplete = &model.UserAutocompleteInTeam{}
-		users, err := a.Srv.Store.User().Search(teamId, term, options)
+		users, err := a.Store().User().Search(teamId, term, options)
 		if err != nil {
 			return nil, err
 		}
@@ -1995,7 +2024,7 @@ func (a *App) UpdateOAuthUserAttrs(userData io.Reader, user *model.User, provide
 	}
 
 	if userAttrsChanged {
-		users, err := a.Srv.Store.User().Update(user, true)
+		users, err := a.Store().User().Update(user, true)
 		if err != nil {
 			return err
 		}
@@ -2116,7 +2145,7 @@ func (a *App) UserCanSeeOtherUser(userId string, otherUserId string) (bool, *mod
 	}
 
 	if len(restrictions.Teams) > 0 {
-		result, err := a.Srv.Store.Team().UserBelongsToTeams(otherUserId, restrictions.Teams)
+		result, err := a.Store().Team().UserBelongsToTeams(otherUserId, restrictions.Teams)
 		if err != nil {
 			return false, err
 		}
@@ -2139,7 +2168,7 @@ func (a *App) UserCanSeeOtherUser(userId string, otherUserId string) (bool, *mod
 }
 
 func (a *App) userBelongsToChannels(userId string, channelIds []string) (bool, *model.AppError) {
-	return a.Srv.Store.Channel().UserBelongsToChannels(userId, channelIds)
+	return a.Store().Channel().UserBelongsToChannels(userId, channelIds)
 }
 
 func (a *App) GetViewUsersRestrictions(userId string) (*model.ViewUsersRestrictions, *model.AppError) {
@@ -2147,7 +2176,7 @@ func (a *App) GetViewUsersRestrictions(userId string) (*model.ViewUsersRestricti
 		return nil, nil
 	}
 
-	teamIds, getTeamErr := a.Srv.Store.Team().GetUserTeamIds(userId, true)
+	teamIds, getTeamErr := a.Store().Team().GetUserTeamIds(userId, true)
 	if getTeamErr != nil {
 		return nil, getTeamErr
 	}
@@ -2159,7 +2188,7 @@ func (a *App) GetViewUsersRestrictions(userId string) (*model.ViewUsersRestricti
 		}
 	}
 
-	userChannelMembers, err := a.Srv.Store.Channel().GetAllChannelMembersForUser(userId, true, true)
+	userChannelMembers, err := a.Store().Channel().GetAllChannelMembersForUser(userId, true, true)
 	if err != nil {
 		return nil, err
 	}
@@ -2186,7 +2215,7 @@ func (a *App) GetViewUsersRestrictionsForTeam(userId string, teamId string) ([]s
 		return nil, nil
 	}
 
-	members, err := a.Srv.Store.Channel().GetMembersForUser(teamId, userId)
+	members, err := a.Store().Channel().GetMembersForUser(teamId, userId)
 	if err != nil {
 		return nil, err
 	}
@@ -2202,7 +2231,7 @@ func (a *App) GetViewUsersRestrictionsForTeam(userId string, teamId string) ([]s
 func (a *App) getListOfAllowedChannelsForTeam(teamId string, viewRestrictions *model.ViewUsersRestrictions) ([]string, *model.AppError) {
 	var listOfAllowedChannels []string
 	if viewRestrictions == nil || strings.Contains(strings.Join(viewRestrictions.Teams, "."), teamId) {
-		channels, err := a.Srv.Store.Channel().GetTeamChannels(teamId)
+		channels, err := a.Store().Channel().GetTeamChannels(teamId)
 		if err != nil {
 			return nil, err
 		}
@@ -2214,7 +2243,7 @@ func (a *App) getListOfAllowedChannelsForTeam(teamId string, viewRestrictions *m
 		return channelIds, nil
 	}
 
-	channels, err := a.Srv.Store.Channel().GetChannelsByIds(viewRestrictions.Channels)
+	channels, err := a.Store().Channel().GetChannelsByIds(viewRestrictions.Channels)
 	if err != nil {
 		return nil, err
 	}
@@ -2230,11 +2259,11 @@ func (a *App) getListOfAllowedChannelsForTeam(teamId string, viewRestrictions *m
 // PromoteGuestToUser Convert user's roles and all his mermbership's roles from
 // guest roles to regular user roles.
 func (a *App) PromoteGuestToUser(user *model.User, requestorId string) *model.AppError {
-	err := a.Srv.Store.User().PromoteGuestToUser(user.Id)
+	err := a.Store().User().PromoteGuestToUser(user.Id)
 	if err != nil {
 		return err
 	}
-	userTeams, err := a.Srv.Store.Team().GetTeamsByUserId(user.Id)
+	userTeams, err := a.Store().Team().GetTeamsByUserId(user.Id)
 	if err != nil {
 		return err
 	}
@@ -2280,7 +2309,7 @@ func (a *App) PromoteGuestToUser(user *model.User, requestorId string) *model.Ap
 // DemoteUserToGuest Convert user's roles and all his mermbership's roles from
 // regular user roles to guest roles.
 func (a *App) DemoteUserToGuest(user *model.User) *model.AppError {
-	err := a.Srv.Store.User().DemoteUserToGuest(user.Id)
+	err := a.Store().User().DemoteUserToGuest(user.Id)
 	if err != nil {
 		return err
 	}
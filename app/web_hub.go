@@ -197,40 +197,17 @@ func (a *App) PublishSkipClusterSend(message *model.WebSocketEvent) {
 }
 
 func (a *App) InvalidateCacheForChannel(channel *model.Channel) {
-	a.InvalidateCacheForChannelSkipClusterSend(channel.Id)
-	a.InvalidateCacheForChannelByNameSkipClusterSend(channel.TeamId, channel.Name)
-
-	if a.Cluster != nil {
-		msg := &model.ClusterMessage{
-			Event:    model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_CHANNEL,
-			SendType: model.CLUSTER_SEND_BEST_EFFORT,
-			Data:     channel.Id,
-		}
-
-		a.Cluster.SendClusterMessage(msg)
-
-		nameMsg := &model.ClusterMessage{
-			Event:    model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_CHANNEL_BY_NAME,
-			SendType: model.CLUSTER_SEND_BEST_EFFORT,
-			Props:    make(map[string]string),
-		}
-
-		nameMsg.Props["name"] = channel.Name
-		if channel.TeamId == "" {
-			nameMsg.Props["id"] = "dm"
-		} else {
-			nameMsg.Props["id"] = channel.TeamId
-		}
-
-		a.Cluster.SendClusterMessage(nameMsg)
+	teamId := channel.TeamId
+	if teamId == "" {
+		teamId = "dm"
 	}
-}
 
-func (a *App) InvalidateCacheForChannelSkipClusterSend(channelId string) {
-	a.Srv.Store.Channel().InvalidateChannel(channelId)
+	a.Store().Channel().InvalidateChannel(channel.Id)
+	a.Store().Channel().InvalidateChannelByName(teamId, channel.Name)
 }
 
 func (a *App) InvalidateCacheForChannelMembers(channelId string) {
+	a.Store().Channel().InvalidateMemberCount(channelId)
 	a.InvalidateCacheForChannelMembersSkipClusterSend(channelId)
 
 	if a.Cluster != nil {
@@ -244,9 +221,8 @@ func (a *App) InvalidateCacheForChannelMembers(channelId string) {
 }
 
 func (a *App) InvalidateCacheForChannelMembersSkipClusterSend(channelId string) {
-	a.Srv.Store.User().InvalidateProfilesInChannelCache(channelId)
-	a.Srv.Store.Channel().InvalidateMemberCount(channelId)
-	a.Srv.Store.Channel().InvalidateGuestCount(channelId)
+	a.Store().User().InvalidateProfilesInChannelCache(channelId)
+	a.Store().Channel().InvalidateGuestCount(channelId)
 }
 
 func (a *App) InvalidateCacheForChannelMembersNotifyProps(channelId string) {
@@ -263,18 +239,11 @@ func (a *App) InvalidateCacheForChannelMembersNotifyProps(channelId string) {
 }
 
 func (a *App) InvalidateCacheForChannelMembersNotifyPropsSkipClusterSend(channelId string) {
-	a.Srv.Store.Channel().InvalidateCacheForChannelMembersNotifyProps(channelId)
-}
-
-func (a *App) InvalidateCacheForChannelByNameSkipClusterSend(teamId, name string) {
-	if teamId == "" {
-		teamId = "dm"
-	}
-
-	a.Srv.Store.Channel().InvalidateChannelByName(teamId, name)
+	a.Store().Channel().InvalidateCacheForChannelMembersNotifyProps(channelId)
 }
 
 func (a *App) InvalidateCacheForChannelPosts(channelId string) {
+	a.Store().Post().InvalidateLastPostTimeCache(channelId)
 	a.InvalidateCacheForChannelPostsSkipClusterSend(channelId)
 
 	if a.Cluster != nil {
@@ -288,11 +257,11 @@ func (a *App) InvalidateCacheForChannelPosts(channelId string) {
 }
 
 func (a *App) InvalidateCacheForChannelPostsSkipClusterSend(channelId string) {
-	a.Srv.Store.Post().InvalidateLastPostTimeCache(channelId)
-	a.Srv.Store.Channel().InvalidatePinnedPostCount(channelId)
+	a.Store().Channel().InvalidatePinnedPostCount(channelId)
 }
 
 func (a *App) InvalidateCacheForUser(userId string) {
+	a.Store().User().InvalidatProfileCacheForUser(userId)
 	a.InvalidateCacheForUserSkipClusterSend(userId)
 
 	if a.Cluster != nil {
@@ -306,6 +275,7 @@ func (a *App) InvalidateCacheForUser(userId string) {
 }
 
 func (a *App) InvalidateCacheForUserTeams(userId string) {
+	a.Store().Team().InvalidateAllTeamIdsForUser(userId)
 	a.InvalidateCacheForUserTeamsSkipClusterSend(userId)
 
 	if a.Cluster != nil {
@@ -319,9 +289,8 @@ func (a *App) InvalidateCacheForUserTeams(userId string) {
 }
 
 func (a *App) InvalidateCacheForUserSkipClusterSend(userId string) {
-	a.Srv.Store.Channel().InvalidateAllChannelMembersForUser(userId)
-	a.Srv.Store.User().InvalidateProfilesInChannelCacheByUser(userId)
-	a.Srv.Store.User().InvalidatProfileCacheForUser(userId)
+	a.Store().Channel().InvalidateAllChannelMembersForUser(userId)
+	a.Store().User().InvalidateProfilesInChannelCacheByUser(userId)
 
 	hub := a.GetHubForUserId(userId)
 	if hub != nil {
@@ -330,8 +299,6 @@ func (a *App) InvalidateCacheForUserSkipClusterSend(userId string) {
 }
 
 func (a *App) InvalidateCacheForUserTeamsSkipClusterSend(userId string) {
-	a.Srv.Store.Team().InvalidateAllTeamIdsForUser(userId)
-
 	hub := a.GetHubForUserId(userId)
 	if hub != nil {
 		hub.InvalidateUser(userId)
@@ -352,7 +319,7 @@ func (a *App) InvalidateCacheForWebhook(webhookId string) {
 }
 
 func (a *App) InvalidateCacheForWebhookSkipClusterSend(webhookId string) {
-	a.Srv.Store.Webhook().InvalidateWebhookCache(webhookId)
+	a.Store().Webhook().InvalidateWebhookCache(webhookId)
 }
 
 func (a *App) InvalidateWebConnSessionCacheForUser(userId string) {
@@ -4,6 +4,7 @@
 package app
 
 import (
+	"bytes"
 	"io"
 	"net/http"
 	"regexp"
@@ -32,7 +33,7 @@ func (a *App) handleWebhookEvents(post *model.Post, team *model.Team, channel *m
 		return nil
 	}
 
-	hooks, err := a.Srv.Store.Webhook().GetOutgoingByTeam(team.Id, -1, -1)
+	hooks, err := a.Store().Webhook().GetOutgoingByTeam(team.Id, -1, -1)
 	if err != nil {
 		return err
 	}
@@ -323,7 +324,7 @@ func (a *App) CreateIncomingWebhookForChannel(creatorId string, channel *model.C
 		return nil, model.NewAppError("CreateIncomingWebhookForChannel", "api.incoming_webhook.invalid_username.app_error", nil, "", http.StatusBadRequest)
 	}
 
-	return a.Srv.Store.Webhook().SaveIncoming(hook)
+	return a.Store().Webhook().SaveIncoming(hook)
 }
 
 func (a *App) UpdateIncomingWebhook(oldHook, updatedHook *model.IncomingWebhook) (*model.IncomingWebhook, *model.AppError) {
@@ -349,7 +350,7 @@ func (a *App) UpdateIncomingWebhook(oldHook, updatedHook *model.IncomingWebhook)
 	updatedHook.TeamId = oldHook.TeamId
 	updatedHook.DeleteAt = oldHook.DeleteAt
 
-	newWebhook, err := a.Srv.Store.Webhook().UpdateIncoming(updatedHook)
+	newWebhook, err := a.Store().Webhook().UpdateIncoming(updatedHook)
 	if err != nil {
 		return nil, err
 	}
@@ -362,7 +363,7 @@ func (a *App) DeleteIncomingWebhook(hookId string) *model.AppError {
 		return model.NewAppError("DeleteIncomingWebhook", "api.incoming_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	if err := a.Srv.Store.Webhook().DeleteIncoming(hookId, model.GetMillis()); err != nil {
+	if err := a.Store().Webhook().DeleteIncoming(hookId, model.GetMillis()); err != nil {
 		return err
 	}
 
@@ -376,7 +377,7 @@ func (a *App) GetIncomingWebhook(hookId string) (*model.IncomingWebhook, *model.
 		return nil, model.NewAppError("GetIncomingWebhook", "api.incoming_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	return a.Srv.Store.Webhook().GetIncoming(hookId, true)
+	return a.Store().Webhook().GetIncoming(hookId, true)
 }
 
 func (a *App) GetIncomingWebhooksForTeamPage(teamId string, page, perPage int) ([]*model.IncomingWebhook, *model.AppError) {
@@ -388,7 +389,7 @@ func (a *App) GetIncomingWebhooksForTeamPageByUser(teamId string, userId string,
 		return nil, model.NewAppError("GetIncomingWebhooksForTeamPage", "api.incoming_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	return a.Srv.Store.Webhook().GetIncomingByTeamByUser(teamId, userId, page*perPage, perPage)
+	return a.Store().Webhook().GetIncomingByTeamByUser(teamId, userId, page*perPage, perPage)
 }
 
 func (a *App) GetIncomingWebhooksPageByUser(userId string, page, perPage int) ([]*model.IncomingWebhook, *model.AppError) {
@@ -396,7 +397,7 @@ func (a *App) GetIncomingWebhooksPageByUser(userId string, page, perPage int) ([
 		return nil, model.NewAppError("GetIncomingWebhooksPage", "api.incoming_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	return a.Srv.Store.Webhook().GetIncomingListByUser(userId, page*perPage, perPage)
+	return a.Store().Webhook().GetIncomingListByUser(userId, page*perPage, perPage)
 }
 
 func (a *App) GetIncomingWebhooksPage(page, perPage int) ([]*model.IncomingWebhook, *model.AppError) {
@@ -409,7 +410,7 @@ func (a *App) CreateOutgoingWebhook(hook *model.OutgoingWebhook) (*model.Outgoin
 	}
 
 	if len(hook.ChannelId) != 0 {
-		channel, errCh := a.Srv.Store.Channel().Get(hook.ChannelId, true)
+		channel, errCh := a.Store().Channel().Get(hook.ChannelId, true)
 		if errCh != nil {
 			return nil, errCh
 		}
@@ -425,7 +426,7 @@ func (a *App) CreateOutgoingWebhook(hook *model.OutgoingWebhook) (*model.Outgoin
 		return nil, model.NewAppError("CreateOutgoingWebhook", "api.webhook.create_outgoing.triggers.app_error", nil, "", http.StatusBadRequest)
 	}
 
-	if allHooks, err := a.Srv.Store.Webhook().GetOutgoingByTeam(hook.TeamId, -1, -1); err != nil {
+	if allHooks, err := a.Store().Webhook().GetOutgoingByTeam(hook.TeamId, -1, -1); err != nil {
 		return nil, err
 	} else {
 
@@ -439,7 +440,7 @@ func (a *App) CreateOutgoingWebhook(hook *model.OutgoingWebhook) (*model.Outgoin
 		}
 	}
 
-	webhook, err := a.Srv.Store.Webhook().SaveOutgoing(hook)
+	webhook, err := a.Store().Webhook().SaveOutgoing(hook)
 	if err != nil {
 		return nil, err
 	}
@@ -469,7 +470,7 @@ func (a *App) UpdateOutgoingWebhook(oldHook, updatedHook *model.OutgoingWebhook)
 		return nil, model.NewAppError("UpdateOutgoingWebhook", "api.webhook.create_outgoing.triggers.app_error", nil, "", http.StatusInternalServerError)
 	}
 
-	allHooks, err := a.Srv.Store.Webhook().GetOutgoingByTeam(oldHook.TeamId, -1, -1)
+	allHooks, err := a.Store().Webhook().GetOutgoingByTeam(oldHook.TeamId, -1, -1)
 	if err != nil {
 		return nil, err
 	}
@@ -489,7 +490,7 @@ func (a *App) UpdateOutgoingWebhook(oldHook, updatedHook *model.OutgoingWebhook)
 	updatedHook.TeamId = oldHook.TeamId
 	updatedHook.UpdateAt = model.GetMillis()
 
-	return a.Srv.Store.Webhook().UpdateOutgoing(updatedHook)
+	return a.Store().Webhook().UpdateOutgoing(updatedHook)
 }
 
 func (a *App) GetOutgoingWebhook(hookId string) (*model.OutgoingWebhook, *model.AppError) {
@@ -497,7 +498,7 @@ func (a *App) GetOutgoingWebhook(hookId string) (*model.OutgoingWebhook, *model.
 		return nil, model.NewAppError("GetOutgoingWebhook", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	return a.Srv.Store.Webhook().GetOutgoing(hookId)
+	return a.Store().Webhook().GetOutgoing(hookId)
 }
 
 func (a *App) GetOutgoingWebhooksPage(page, perPage int) ([]*model.OutgoingWebhook, *model.AppError) {
@@ -509,7 +510,7 @@ func (a *App) GetOutgoingWebhooksPageByUser(userId string, page, perPage int) ([
 		return nil, model.NewAppError("GetOutgoingWebhooksPage", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	return a.Srv.Store.Webhook().GetOutgoingListByUser(userId, page*perPage, perPage)
+	return a.Store().Webhook().GetOutgoingListByUser(userId, page*perPage, perPage)
 }
 
 func (a *App) GetOutgoingWebhooksForChannelPageByUser(channelId string, userId string, page, perPage int) ([]*model.OutgoingWebhook, *model.AppError) {
@@ -517,7 +518,7 @@ func (a *App) GetOutgoingWebhooksForChannelPageByUser(channelId string, userId s
 		return nil, model.NewAppError("GetOutgoingWebhooksForChannelPage", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	return a.Srv.Store.Webhook().GetOutgoingByChannelByUser(channelId, userId, page*perPage, perPage)
+	return a.Store().Webhook().GetOutgoingByChannelByUser(channelId, userId, page*perPage, perPage)
 }
 
 func (a *App) GetOutgoingWebhooksForTeamPage(teamId string, page, perPage int) ([]*model.OutgoingWebhook, *model.AppError) {
@@ -529,7 +530,7 @@ func (a *App) GetOutgoingWebhooksForTeamPageByUser(teamId string, userId string,
 		return nil, model.NewAppError("GetOutgoingWebhooksForTeamPage", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	return a.Srv.Store.Webhook().GetOutgoingByTeamByUser(teamId, userId, page*perPage, perPage)
+	return a.Store().Webhook().GetOutgoingByTeamByUser(teamId, userId, page*perPage, perPage)
 }
 
 func (a *App) DeleteOutgoingWebhook(hookId string) *model.AppError {
@@ -537,7 +538,7 @@ func (a *App) DeleteOutgoingWebhook(hookId string) *model.AppError {
 		return model.NewAppError("DeleteOutgoingWebhook", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	return a.Srv.Store.Webhook().DeleteOutgoing(hookId, model.GetMillis())
+	return a.Store().Webhook().DeleteOutgoing(hookId, model.GetMillis())
 }
 
 func (a *App) RegenOutgoingWebhookToken(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError) {
@@ -547,20 +548,38 @@ func (a *App) RegenOutgoingWebhookToken(hook *model.OutgoingWebhook) (*model.Out
 
 	hook.Token = model.NewId()
 
-	return a.Srv.Store.Webhook().UpdateOutgoing(hook)
+	return a.Store().Webhook().UpdateOutgoing(hook)
 }
 
-func (a *App) HandleIncomingWebhook(hookId string, req *model.IncomingWebhookRequest) *model.AppError {
+// GetIncomingWebhookForRequest returns the webhook a request was made to, for the request to be
+// decoded and then handled with it.
+func (a *App) GetIncomingWebhookForRequest(hookId string) (*model.IncomingWebhook, *model.AppError) {
 	if !*a.Config().ServiceSettings.EnableIncomingWebhooks {
-		return model.NewAppError("HandleIncomingWebhook", "web.incoming_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
+		return nil, model.NewAppError("GetIncomingWebhookForRequest", "web.incoming_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	hchan := make(chan store.StoreResult, 1)
-	go func() {
-		webhook, err := a.Srv.Store.Webhook().GetIncoming(hookId, true)
-		hchan <- store.StoreResult{Data: webhook, Err: err}
-		close(hchan)
-	}()
+	hook, err := a.Store().Webhook().GetIncoming(hookId, true)
+	if err != nil {
+		return nil, model.NewAppError("GetIncomingWebhookForRequest", "web.incoming_webhook.invalid.app_error", nil, "err="+err.Message, http.StatusBadRequest)
+	}
+
+	return hook, nil
+}
+
+// RenderIncomingWebhookPayload converts a raw payload into the request that would be posted by the
+// given webhook without posting it. It is used both to handle webhooks and to preview templates.
+func (a *App) RenderIncomingWebhookPayload(hook *model.IncomingWebhook, payload []byte) (*model.IncomingWebhookRequest, *model.AppError) {
+	if len(hook.PayloadTemplate) == 0 {
+		return model.IncomingWebhookRequestFromJson(bytes.NewReader(payload))
+	}
+
+	return model.RenderIncomingWebhookTemplate(hook.PayloadTemplate, payload)
+}
+
+func (a *App) HandleIncomingWebhook(hook *model.IncomingWebhook, req *model.IncomingWebhookRequest) *model.AppError {
+	if !*a.Config().ServiceSettings.EnableIncomingWebhooks {
+		return model.NewAppError("HandleIncomingWebhook", "web.incoming_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
 
 	if req == nil {
 		return model.NewAppError("HandleIncomingWebhook", "web.incoming_webhook.parse.app_error", nil, "", http.StatusBadRequest)
@@ -574,16 +593,9 @@ func (a *App) HandleIncomingWebhook(hookId string, req *model.IncomingWebhookReq
 	channelName := req.ChannelName
 	webhookType := req.Type
 
-	var hook *model.IncomingWebhook
-	if result := <-hchan; result.Err != nil {
-		return model.NewAppError("HandleIncomingWebhook", "web.incoming_webhook.invalid.app_error", nil, "err="+result.Err.Message, http.StatusBadRequest)
-	} else {
-		hook = result.Data.(*model.IncomingWebhook)
-	}
-
 	uchan := make(chan store.StoreResult, 1)
 	go func() {
-		user, err := a.Srv.Store.User().Get(hook.UserId)
+		user, err := a.Store().User().Get(hook.UserId)
 		uchan <- store.StoreResult{Data: user, Err: err}
 		close(uchan)
 	}()
@@ -607,7 +619,7 @@ func (a *App) HandleIncomingWebhook(hookId string, req *model.IncomingWebhookReq
 
 	if len(channelName) != 0 {
 		if channelName[0] == '@' {
-			if result, err := a.Srv.Store.User().GetByUsername(channelName[1:]); err != nil {
+			if result, err := a.Store().User().GetByUsername(channelName[1:]); err != nil {
 				return model.NewAppError("HandleIncomingWebhook", "web.incoming_webhook.user.app_error", nil, "err="+err.Message, http.StatusBadRequest)
 			} else {
 				if ch, err := a.GetOrCreateDirectChannel(hook.UserId, result.Id); err != nil {
@@ -619,21 +631,21 @@ func (a *App) HandleIncomingWebhook(hookId string, req *model.IncomingWebhookReq
 		} else if channelName[0] == '#' {
 			cchan = make(chan store.StoreResult, 1)
 			go func() {
-				chnn, chnnErr := a.Srv.Store.Channel().GetByName(hook.TeamId, channelName[1:], true)
+				chnn, chnnErr := a.Store().Channel().GetByName(hook.TeamId, channelName[1:], true)
 				cchan <- store.StoreResult{Data: chnn, Err: chnnErr}
 				close(cchan)
 			}()
 		} else {
 			cchan = make(chan store.StoreResult, 1)
 			go func() {
-				chnn, chnnErr := a.Srv.Store.Channel().GetByName(hook.TeamId, channelName, true)
+				chnn, chnnErr := a.Store().Channel().GetByName(hook.TeamId, channelName, true)
 				cchan <- store.StoreResult{Data: chnn, Err: chnnErr}
 				close(cchan)
 			}()
 		}
 	} else {
 		var err *model.AppError
-		channel, err = a.Srv.Store.Channel().Get(hook.ChannelId, true)
+		channel, err = a.Store().Channel().Get(hook.ChannelId, true)
 		if err != nil {
 			return model.NewAppError("HandleIncomingWebhook", "web.incoming_webhook.channel.app_error", nil, "err="+err.Message, err.StatusCode)
 		}
@@ -691,7 +703,7 @@ func (a *App) CreateCommandWebhook(commandId string, args *model.CommandArgs) (*
 		ParentId:  args.ParentId,
 	}
 
-	return a.Srv.Store.CommandWebhook().Save(hook)
+	return a.Store().CommandWebhook().Save(hook)
 }
 
 func (a *App) HandleCommandWebhook(hookId string, response *model.CommandResponse) *model.AppError {
@@ -699,12 +711,12 @@ func (a *App) HandleCommandWebhook(hookId string, response *model.CommandRespons
 		return model.NewAppError("HandleCommandWebhook", "web.command_webhook.parse.app_error", nil, "", http.StatusBadRequest)
 	}
 
-	hook, err := a.Srv.Store.CommandWebhook().Get(hookId)
+	hook, err := a.Store().CommandWebhook().Get(hookId)
 	if err != nil {
 		return model.NewAppError("HandleCommandWebhook", "web.command_webhook.invalid.app_error", nil, "err="+err.Message, err.StatusCode)
 	}
 
-	cmd, err := a.Srv.Store.Command().Get(hook.CommandId)
+	cmd, err := a.Store().Command().Get(hook.CommandId)
 	if err != nil {
 		return model.NewAppError("HandleCommandWebhook", "web.command_webhook.command.app_error", nil, "err="+err.Message, http.StatusBadRequest)
 	}
@@ -717,7 +729,7 @@ func (a *App) HandleCommandWebhook(hookId string, response *model.CommandRespons
 		ParentId:  hook.ParentId,
 	}
 
-	if err = a.Srv.Store.CommandWebhook().TryUse(hook.Id, 5); err != nil {
+	if err = a.Store().CommandWebhook().TryUse(hook.Id, 5); err != nil {
 		return model.NewAppError("HandleCommandWebhook", "web.command_webhook.invalid.app_error", nil, "err="+err.Message, err.StatusCode)
 	}
 
@@ -0,0 +1,92 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var AuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Management of the audit log",
+}
+
+var AuditExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export the audit log",
+	Long: `Export the records of the audit log of the changes made through the store to a file, as JSON lines.
+Unless --verify=false is given, the export stops at the first record breaking the hash chain linking the records, as left by records edited or deleted since they were written.
+The hashes are checked with the key of --key-file, or of ComplianceSettings.AuditLogKeyFile, which should be kept out of reach of whoever could edit the records.
+Records deleted from the end of the log can't be detected this way, so keep the last sequence and hash printed by each export to compare with the next one.`,
+	Example: "  audit export audit_log.jsonl\n  audit export --after 15000 audit_log.jsonl",
+	RunE:    auditExportCmdF,
+	Args:    cobra.ExactArgs(1),
+}
+
+func init() {
+	AuditExportCmd.Flags().Int64("after", 0, "Export the records following this sequence only. The hash chain is verified from the record at this sequence on.")
+	AuditExportCmd.Flags().Bool("verify", true, "Verify the hash chain linking the records while exporting them.")
+	AuditExportCmd.Flags().String("key-file", "", "The file holding the key the hashes are keyed with. Defaults to ComplianceSettings.AuditLogKeyFile.")
+
+	AuditCmd.AddCommand(
+		AuditExportCmd,
+	)
+	RootCmd.AddCommand(AuditCmd)
+}
+
+func auditExportCmdF(command *cobra.Command, args []string) error {
+	a, err := InitDBCommandContextCobra(command)
+	if err != nil {
+		return err
+	}
+	defer a.Shutdown()
+
+	after, err := command.Flags().GetInt64("after")
+	if err != nil {
+		return errors.Wrap(err, "after flag error")
+	}
+	verify, err := command.Flags().GetBool("verify")
+	if err != nil {
+		return errors.Wrap(err, "verify flag error")
+	}
+	keyFile, err := command.Flags().GetString("key-file")
+	if err != nil {
+		return errors.Wrap(err, "key-file flag error")
+	}
+	if keyFile == "" {
+		keyFile = *a.Config().ComplianceSettings.AuditLogKeyFile
+	}
+
+	var key []byte
+	if keyFile != "" {
+		if key, err = ioutil.ReadFile(keyFile); err != nil {
+			return errors.Wrap(err, "failed to read the audit log key file")
+		}
+		key = bytes.TrimSpace(key)
+	}
+
+	fileWriter, err := os.Create(args[0])
+	if err != nil {
+		return err
+	}
+	defer fileWriter.Close()
+
+	last, appErr := a.ExportAuditLog(fileWriter, after, verify, key)
+	if last != nil {
+		CommandPrettyPrintln(fmt.Sprintf("Exported the audit log up to sequence %v, with hash %v", last.Sequence, last.Hash))
+	} else if appErr == nil {
+		CommandPrettyPrintln("No records to export")
+	}
+	if appErr != nil {
+		return errors.Wrap(appErr, "failed to export the audit log")
+	}
+
+	return nil
+}
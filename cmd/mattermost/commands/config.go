@@ -7,9 +7,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -78,7 +80,36 @@ var MigrateConfigCmd = &cobra.Command{
 	RunE:    configMigrateCmdF,
 }
 
+var ConfigHistoryCmd = &cobra.Command{
+	Use:     "history",
+	Short:   "List saved configurations",
+	Long:    "Lists the configurations saved to a database-based configuration, newest first, with the settings each one changed.",
+	Example: "config history --page 0 --per-page 20",
+	Args:    cobra.NoArgs,
+	RunE:    configHistoryCmdF,
+}
+
+var ConfigDiffCmd = &cobra.Command{
+	Use:     "diff [from_revision] [to_revision]",
+	Short:   "Compare saved configurations",
+	Long:    "Compares the configurations saved by two revisions of a database-based configuration. If only one revision is given, it is compared against the active configuration.",
+	Example: "config diff 8gfbapbmxpyzpb3x4fzb9tsq3w",
+	Args:    cobra.RangeArgs(1, 2),
+	RunE:    configDiffCmdF,
+}
+
+var ConfigRollbackCmd = &cobra.Command{
+	Use:     "rollback [revision]",
+	Short:   "Restore a saved configuration",
+	Long:    "Replaces the active configuration of a database-based configuration with the one saved by the given revision.",
+	Example: "config rollback 8gfbapbmxpyzpb3x4fzb9tsq3w",
+	Args:    cobra.ExactArgs(1),
+	RunE:    configRollbackCmdF,
+}
+
 func init() {
+	ConfigHistoryCmd.Flags().Int("page", 0, "Page number to fetch for the list of configurations")
+	ConfigHistoryCmd.Flags().Int("per-page", 20, "Number of configurations to be fetched")
 	ConfigSubpathCmd.Flags().String("path", "", "Optional subpath; defaults to value in SiteURL")
 	ConfigShowCmd.Flags().Bool("json", false, "Output the configuration as JSON.")
 
@@ -89,6 +120,9 @@ func init() {
 		ConfigShowCmd,
 		ConfigSetCmd,
 		MigrateConfigCmd,
+		ConfigHistoryCmd,
+		ConfigDiffCmd,
+		ConfigRollbackCmd,
 	)
 	RootCmd.AddCommand(ConfigCmd)
 }
@@ -150,7 +184,12 @@ func configGetCmdF(command *cobra.Command, args []string) error {
 		return err
 	}
 
-	out, err := printConfigValues(configToMap(*configStore.Get()), strings.Split(args[0], "."), args[0])
+	cfg, err := config.ApplySecretReferences(configStore.Get(), configStore.GetSecretReferences())
+	if err != nil {
+		return errors.Wrap(err, "failed to apply secret references")
+	}
+
+	out, err := printConfigValues(configToMap(*cfg), strings.Split(args[0], "."), args[0])
 	if err != nil {
 		return err
 	}
@@ -176,7 +215,12 @@ func configShowCmdF(command *cobra.Command, args []string) error {
 		return err
 	}
 
-	config := *configStore.Get()
+	cfg, err := config.ApplySecretReferences(configStore.Get(), configStore.GetSecretReferences())
+	if err != nil {
+		return errors.Wrap(err, "failed to apply secret references")
+	}
+
+	config := *cfg
 
 	if useJSON {
 		configJSON, err := json.MarshalIndent(config, "", "    ")
@@ -239,6 +283,10 @@ func configSetCmdF(command *cobra.Command, args []string) error {
 		return errors.New("Invalid locale configuration")
 	}
 
+	if err := validatePluginSettings(configSetting, newConfig); err != nil {
+		return err
+	}
+
 	if _, err := configStore.Set(newConfig); err != nil {
 		return errors.Wrap(err, "failed to set config")
 	}
@@ -246,6 +294,129 @@ func configSetCmdF(command *cobra.Command, args []string) error {
 	return nil
 }
 
+func getConfigHistoryStore(command *cobra.Command) (config.HistoryStore, error) {
+	configStore, err := getConfigStore(command)
+	if err != nil {
+		return nil, err
+	}
+
+	historyStore, ok := configStore.(config.HistoryStore)
+	if !ok {
+		return nil, errors.New("configuration history is only kept for database-based configurations")
+	}
+
+	return historyStore, nil
+}
+
+func configHistoryCmdF(command *cobra.Command, args []string) error {
+	historyStore, err := getConfigHistoryStore(command)
+	if err != nil {
+		return err
+	}
+	defer historyStore.Close()
+
+	page, _ := command.Flags().GetInt("page")
+	perPage, _ := command.Flags().GetInt("per-page")
+
+	revisions, err := historyStore.History(page*perPage, perPage)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch configuration history")
+	}
+
+	for _, revision := range revisions {
+		active := ""
+		if revision.Active {
+			active = " (active)"
+		}
+		author := revision.UserId
+		if author == "" {
+			author = "system"
+		}
+
+		CommandPrettyPrintln(fmt.Sprintf("%s%s saved %s by %s", revision.Id, active, time.Unix(0, revision.CreateAt*int64(time.Millisecond)).Format(time.RFC3339), author))
+		printConfigDiff(revision.Diff)
+	}
+
+	return nil
+}
+
+func configDiffCmdF(command *cobra.Command, args []string) error {
+	historyStore, err := getConfigHistoryStore(command)
+	if err != nil {
+		return err
+	}
+	defer historyStore.Close()
+
+	fromCfg, err := historyStore.GetRevision(args[0])
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch configuration %s", args[0])
+	}
+
+	toCfg := historyStore.Get()
+	if len(args) > 1 {
+		if toCfg, err = historyStore.GetRevision(args[1]); err != nil {
+			return errors.Wrapf(err, "failed to fetch configuration %s", args[1])
+		}
+	}
+
+	diff, err := config.Diff(fromCfg, toCfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to compare configurations")
+	}
+
+	printConfigDiff(diff)
+
+	return nil
+}
+
+func configRollbackCmdF(command *cobra.Command, args []string) error {
+	historyStore, err := getConfigHistoryStore(command)
+	if err != nil {
+		return err
+	}
+	defer historyStore.Close()
+
+	if _, err := historyStore.Rollback(args[0], ""); err != nil {
+		return errors.Wrapf(err, "failed to roll back to configuration %s", args[0])
+	}
+
+	CommandPrettyPrintln("Restored configuration " + args[0])
+
+	return nil
+}
+
+func printConfigDiff(diff model.ConfigDiffs) {
+	for _, change := range diff {
+		oldValue, _ := json.Marshal(change.OldValue)
+		newValue, _ := json.Marshal(change.NewValue)
+		CommandPrettyPrintln(fmt.Sprintf("  %s: %s -> %s", strings.Join(change.Path, "."), oldValue, newValue))
+	}
+}
+
+// validatePluginSettings checks a change to a plugin's settings against the settings schema of the
+// installed plugin. Settings of plugins that aren't installed are not validated.
+func validatePluginSettings(configSetting string, cfg *model.Config) error {
+	parts := strings.SplitN(configSetting, ".", 4)
+	if len(parts) < 3 || parts[0] != "PluginSettings" || parts[1] != "Plugins" {
+		return nil
+	}
+
+	pluginId := parts[2]
+	info := model.BundleInfoForPath(filepath.Join(*cfg.PluginSettings.Directory, pluginId))
+	if info.Manifest == nil || info.Manifest.Id != pluginId {
+		return nil
+	}
+
+	utils.TranslationsPreInit()
+	model.AppErrorInit(utils.T)
+
+	if appErr := info.Manifest.ValidateSettings(cfg.PluginSettings.Plugins[pluginId]); appErr != nil {
+		return errors.Wrap(appErr, "invalid plugin setting")
+	}
+
+	return nil
+}
+
 func configMigrateCmdF(command *cobra.Command, args []string) error {
 	from := args[0]
 	to := args[1]
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"strings"
@@ -380,6 +381,35 @@ func TestSetConfig(t *testing.T) {
 	assert.Error(t, th.RunCommand(t, "config", "set", "Abc"))
 }
 
+func TestValidatePluginSettings(t *testing.T) {
+	pluginDir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(pluginDir)
+
+	require.NoError(t, os.Mkdir(filepath.Join(pluginDir, "testplugin"), 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(pluginDir, "testplugin", "plugin.json"), []byte(`{
+		"id": "testplugin",
+		"settings_schema": {
+			"settings": [
+				{"key": "Port", "type": "text", "regex": "^[0-9]+$"}
+			]
+		}
+	}`), 0600))
+
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	*cfg.PluginSettings.Directory = pluginDir
+	cfg.PluginSettings.Plugins["testplugin"] = map[string]interface{}{"port": "8065"}
+	cfg.PluginSettings.Plugins["otherplugin"] = map[string]interface{}{"port": "invalid"}
+
+	assert.NoError(t, validatePluginSettings("PluginSettings.Plugins.testplugin.port", cfg))
+	assert.NoError(t, validatePluginSettings("PluginSettings.Plugins.otherplugin.port", cfg))
+	assert.NoError(t, validatePluginSettings("ServiceSettings.SiteURL", cfg))
+
+	cfg.PluginSettings.Plugins["testplugin"]["port"] = "invalid"
+	assert.Error(t, validatePluginSettings("PluginSettings.Plugins.testplugin.port", cfg))
+}
+
 func TestUpdateMap(t *testing.T) {
 	// create a config to make changes
 	config := TestNewConfig{
@@ -0,0 +1,169 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost-server/config"
+	"github.com/mattermost/mattermost-server/store/sqlstore"
+)
+
+var DbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Management of the database schema",
+}
+
+var DbMigrateCmd = &cobra.Command{
+	Use:     "migrate",
+	Short:   "Apply the pending migrations",
+	Long:    "Brings the database schema up to date, as the server does when starting. With --dry-run, prints the SQL of the pending migrations instead of running it, which requires the legacy upgrades of an older schema version to have been applied.",
+	Example: "  db migrate --dry-run",
+	Args:    cobra.NoArgs,
+	RunE:    dbMigrateCmdF,
+}
+
+var DbDowngradeCmd = &cobra.Command{
+	Use:     "downgrade",
+	Short:   "Revert applied migrations",
+	Long:    "Reverts the applied migrations above the given version, latest first, or only the latest one without --to. Nothing is reverted if any of them is irreversible. Downgrade before starting an older version of Mattermost, since the server applies the pending migrations when starting.",
+	Example: "  db downgrade --to 2 --dry-run",
+	Args:    cobra.NoArgs,
+	RunE:    dbDowngradeCmdF,
+}
+
+func init() {
+	DbMigrateCmd.Flags().Bool("dry-run", false, "Print the SQL of the pending migrations without running it.")
+
+	DbDowngradeCmd.Flags().Int("to", -1, "Version of the last migration to keep applied.")
+	DbDowngradeCmd.Flags().Bool("dry-run", false, "Print the SQL reverting the migrations without running it.")
+	DbDowngradeCmd.Flags().Bool("confirm", false, "Confirm you really want to revert the migrations and a DB backup has been performed.")
+
+	DbCmd.AddCommand(
+		DbMigrateCmd,
+		DbDowngradeCmd,
+	)
+	RootCmd.AddCommand(DbCmd)
+}
+
+// openSqlSupplier connects to the configured database without migrating it.
+func openSqlSupplier(command *cobra.Command) (*sqlstore.SqlSupplier, error) {
+	configStore, err := getConfigStore(command)
+	if err != nil {
+		return nil, err
+	}
+	defer configStore.Close()
+
+	cfg, err := config.ApplySecretReferences(configStore.Get(), configStore.GetSecretReferences())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to apply secret references")
+	}
+
+	return sqlstore.OpenSqlSupplier(cfg.SqlSettings, nil), nil
+}
+
+func printMigrationPlans(plans []*sqlstore.MigrationPlan) {
+	for _, plan := range plans {
+		CommandPrintln(plan.String())
+	}
+}
+
+func dbMigrateCmdF(command *cobra.Command, args []string) error {
+	dryRun, _ := command.Flags().GetBool("dry-run")
+
+	supplier, err := openSqlSupplier(command)
+	if err != nil {
+		return err
+	}
+	defer supplier.Close()
+
+	if !dryRun {
+		applied, err := supplier.Migrate()
+		if err != nil {
+			return err
+		}
+
+		for _, plan := range applied {
+			CommandPrettyPrintln(fmt.Sprintf("Applied migration %d %s", plan.Version, plan.Name))
+		}
+		CommandPrettyPrintln("Database schema is up to date")
+
+		return nil
+	}
+
+	if supplier.GetCurrentSchemaVersion() == "" {
+		CommandPrintln("-- The database is not initialized: its tables would be created and the migrations recorded as applied.")
+		return nil
+	}
+
+	plans, err := supplier.PlanMigrations()
+	if err != nil {
+		return err
+	}
+
+	if len(plans) == 0 {
+		CommandPrintln("-- No pending migrations.")
+	}
+	printMigrationPlans(plans)
+
+	return nil
+}
+
+func dbDowngradeCmdF(command *cobra.Command, args []string) error {
+	toVersion, _ := command.Flags().GetInt("to")
+	dryRun, _ := command.Flags().GetBool("dry-run")
+	confirmFlag, _ := command.Flags().GetBool("confirm")
+
+	supplier, err := openSqlSupplier(command)
+	if err != nil {
+		return err
+	}
+	defer supplier.Close()
+
+	if toVersion < 0 {
+		applied, err := supplier.AppliedMigrations()
+		if err != nil {
+			return err
+		}
+
+		toVersion = 0
+		if len(applied) > 1 {
+			toVersion = applied[len(applied)-2].Version
+		}
+	}
+
+	plans, err := supplier.PlanDowngrade(toVersion)
+	if err != nil {
+		return err
+	}
+
+	if len(plans) == 0 {
+		CommandPrettyPrintln("No migrations to revert")
+		return nil
+	}
+
+	if dryRun {
+		printMigrationPlans(plans)
+		return nil
+	}
+
+	if !confirmFlag {
+		var confirm string
+		CommandPrettyPrintln(fmt.Sprintf("Reverting %d migration(s) may delete data. Have you performed a database backup? (YES/NO): ", len(plans)))
+		fmt.Scanln(&confirm)
+		if confirm != "YES" {
+			return errors.New("ABORTED: You did not answer YES exactly, in all capitals.")
+		}
+	}
+
+	reverted, err := supplier.Downgrade(toVersion)
+	for _, plan := range reverted {
+		CommandPrettyPrintln(fmt.Sprintf("Reverted migration %d %s", plan.Version, plan.Name))
+	}
+
+	return err
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDbMigrate(t *testing.T) {
+	th := Setup()
+	defer th.TearDown()
+
+	t.Run("dry run", func(t *testing.T) {
+		output := th.CheckCommand(t, "db", "migrate", "--dry-run")
+		assert.Contains(t, output, "No pending migrations")
+	})
+
+	t.Run("migrate", func(t *testing.T) {
+		output := th.CheckCommand(t, "db", "migrate")
+		assert.Contains(t, output, "Database schema is up to date")
+	})
+}
+
+func TestDbDowngrade(t *testing.T) {
+	th := Setup()
+	defer th.TearDown()
+
+	t.Run("dry run prints the reverting SQL", func(t *testing.T) {
+		output := th.CheckCommand(t, "db", "downgrade", "--to", "9", "--dry-run")
+		assert.Contains(t, output, "DROP TABLE AuditLog")
+	})
+
+	t.Run("irreversible migrations are refused", func(t *testing.T) {
+		output, err := th.RunCommandWithOutput(t, "db", "downgrade", "--to", "0", "--dry-run")
+		require.Error(t, err)
+		assert.Contains(t, output, "cannot be reverted")
+	})
+
+	t.Run("downgrade and migrate again", func(t *testing.T) {
+		output := th.CheckCommand(t, "db", "downgrade", "--to", "9", "--confirm")
+		assert.Contains(t, output, "Reverted migration")
+
+		output = th.CheckCommand(t, "db", "migrate", "--dry-run")
+		assert.Contains(t, output, "create table")
+		assert.Contains(t, output, "AuditLog")
+
+		th.CheckCommand(t, "db", "migrate")
+		output = th.CheckCommand(t, "db", "migrate", "--dry-run")
+		assert.Contains(t, output, "No pending migrations")
+	})
+}
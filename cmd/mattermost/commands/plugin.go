@@ -5,6 +5,7 @@ package commands
 
 import (
 	"errors"
+	"io/ioutil"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -55,13 +56,50 @@ var PluginListCmd = &cobra.Command{
 	RunE:    pluginListCmdF,
 }
 
+var PluginKeysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Management of trusted plugin signing keys",
+}
+
+var PluginKeysAddCmd = &cobra.Command{
+	Use:     "add [name] [file]",
+	Short:   "Add a trusted key",
+	Long:    "Add a base64 encoded ed25519 public key to the keys trusted to sign plugins installed from the marketplace.",
+	Example: `  plugin keys add mattermost mattermost.pub`,
+	Args:    cobra.ExactArgs(2),
+	RunE:    pluginKeysAddCmdF,
+}
+
+var PluginKeysDeleteCmd = &cobra.Command{
+	Use:     "delete [names]",
+	Short:   "Delete trusted keys",
+	Long:    "Delete keys from the keys trusted to sign plugins installed from the marketplace.",
+	Example: `  plugin keys delete mattermost`,
+	RunE:    pluginKeysDeleteCmdF,
+}
+
+var PluginKeysListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List trusted keys",
+	Long:    "List the names of the keys trusted to sign plugins installed from the marketplace.",
+	Example: `  plugin keys list`,
+	RunE:    pluginKeysListCmdF,
+}
+
 func init() {
+	PluginKeysCmd.AddCommand(
+		PluginKeysAddCmd,
+		PluginKeysDeleteCmd,
+		PluginKeysListCmd,
+	)
+
 	PluginCmd.AddCommand(
 		PluginAddCmd,
 		PluginDeleteCmd,
 		PluginEnableCmd,
 		PluginDisableCmd,
 		PluginListCmd,
+		PluginKeysCmd,
 	)
 	RootCmd.AddCommand(PluginCmd)
 }
@@ -184,3 +222,66 @@ func pluginListCmdF(command *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func pluginKeysAddCmdF(command *cobra.Command, args []string) error {
+	a, err := InitDBCommandContextCobra(command)
+	if err != nil {
+		return err
+	}
+	defer a.Shutdown()
+
+	key, err := ioutil.ReadFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	if appErr := a.AddTrustedPluginKey(args[0], key); appErr != nil {
+		return errors.New("Unable to add key: " + args[0] + ". Error: " + appErr.Error())
+	}
+
+	CommandPrettyPrintln("Added key: " + args[0])
+
+	return nil
+}
+
+func pluginKeysDeleteCmdF(command *cobra.Command, args []string) error {
+	a, err := InitDBCommandContextCobra(command)
+	if err != nil {
+		return err
+	}
+	defer a.Shutdown()
+
+	if len(args) < 1 {
+		return errors.New("Expected at least one argument. See help text for details.")
+	}
+
+	for _, name := range args {
+		if err := a.DeleteTrustedPluginKey(name); err != nil {
+			CommandPrintErrorln("Unable to delete key: " + name + ". Error: " + err.Error())
+		} else {
+			CommandPrettyPrintln("Deleted key: " + name)
+		}
+	}
+
+	return nil
+}
+
+func pluginKeysListCmdF(command *cobra.Command, args []string) error {
+	a, err := InitDBCommandContextCobra(command)
+	if err != nil {
+		return err
+	}
+	defer a.Shutdown()
+
+	names, appErr := a.GetTrustedPluginKeyNames()
+	if appErr != nil {
+		return errors.New("Unable to list keys. Error: " + appErr.Error())
+	}
+
+	CommandPrettyPrintln("Listing trusted plugin keys")
+	for _, name := range names {
+		CommandPrettyPrintln(name)
+	}
+
+	return nil
+}
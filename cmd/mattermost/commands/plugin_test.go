@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"encoding/base64"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
@@ -9,6 +11,7 @@ import (
 	"github.com/mattermost/mattermost-server/utils/fileutils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ed25519"
 )
 
 func TestPlugin(t *testing.T) {
@@ -44,3 +47,33 @@ func TestPlugin(t *testing.T) {
 
 	th.CheckCommand(t, "plugin", "delete", "testplugin")
 }
+
+func TestPluginKeys(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	publicKey, _, err := ed25519.GenerateKey(nil)
+	require.Nil(t, err)
+
+	dir, err := ioutil.TempDir("", "pluginkeys")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	keyPath := filepath.Join(dir, "test.pub")
+	require.Nil(t, ioutil.WriteFile(keyPath, []byte(base64.StdEncoding.EncodeToString(publicKey)), 0600))
+
+	invalidKeyPath := filepath.Join(dir, "invalid.pub")
+	require.Nil(t, ioutil.WriteFile(invalidKeyPath, []byte("not a key"), 0600))
+
+	th.CheckCommand(t, "plugin", "keys", "add", "testkey", keyPath)
+	require.Error(t, th.RunCommand(t, "plugin", "keys", "add", "invalidkey", invalidKeyPath))
+
+	output := th.CheckCommand(t, "plugin", "keys", "list")
+	assert.Contains(t, output, "testkey")
+	assert.NotContains(t, output, "invalidkey")
+
+	th.CheckCommand(t, "plugin", "keys", "delete", "testkey")
+
+	output = th.CheckCommand(t, "plugin", "keys", "list")
+	assert.NotContains(t, output, "testkey")
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var PostCmd = &cobra.Command{
+	Use:   "post",
+	Short: "Management of posts",
+}
+
+var PostArchiveCmd = &cobra.Command{
+	Use:     "archive",
+	Short:   "Archive old posts",
+	Long:    "Moves the threads older than DataRetentionSettings.MessageArchivalMonths out of the database into archive files of the file store, as the post archival job does. Archived posts can still be opened by id, such as from permalinks, and are still returned by searches.",
+	Example: "  post archive",
+	Args:    cobra.NoArgs,
+	RunE:    postArchiveCmdF,
+}
+
+var PostRestoreCmd = &cobra.Command{
+	Use:   "restore [channels]",
+	Short: "Restore archived posts",
+	Long: `Moves the archived posts of the channels back into the database and removes their archive files.
+Channels can be specified by [team]:[channel]. ie. myteam:mychannel or by channel ID.`,
+	Example: `  post restore myteam:mychannel
+  post restore --all`,
+	RunE: postRestoreCmdF,
+}
+
+var PostRebalanceCmd = &cobra.Command{
+	Use:     "rebalance",
+	Short:   "Rebalance the post shards",
+	Long:    "Moves the posts of each channel to the database shard it is placed on by SqlSettings.PostShardDataSources, as the post shard rebalance job does, such as after adding a shard.",
+	Example: "  post rebalance",
+	Args:    cobra.NoArgs,
+	RunE:    postRebalanceCmdF,
+}
+
+func init() {
+	PostRestoreCmd.Flags().Bool("all", false, "Restore the archived posts of every channel.")
+
+	PostCmd.AddCommand(
+		PostArchiveCmd,
+		PostRestoreCmd,
+		PostRebalanceCmd,
+	)
+	RootCmd.AddCommand(PostCmd)
+}
+
+func postArchiveCmdF(command *cobra.Command, args []string) error {
+	a, err := InitDBCommandContextCobra(command)
+	if err != nil {
+		return err
+	}
+	defer a.Shutdown()
+
+	archived, appErr := a.ArchiveOldPosts()
+	CommandPrintln(fmt.Sprintf("Archived %v posts", archived))
+	if appErr != nil {
+		return errors.Wrap(appErr, "failed to archive the posts")
+	}
+
+	return nil
+}
+
+func postRestoreCmdF(command *cobra.Command, args []string) error {
+	all, _ := command.Flags().GetBool("all")
+	if all == (len(args) > 0) {
+		return errors.New("Specify either channels or --all.")
+	}
+
+	a, err := InitDBCommandContextCobra(command)
+	if err != nil {
+		return err
+	}
+	defer a.Shutdown()
+
+	if all {
+		restored, appErr := a.RestoreArchivedPosts("")
+		CommandPrintln(fmt.Sprintf("Restored %v posts", restored))
+		if appErr != nil {
+			return errors.Wrap(appErr, "failed to restore the posts")
+		}
+		return nil
+	}
+
+	channels := getChannelsFromChannelArgs(a, args)
+	for i, channel := range channels {
+		if channel == nil {
+			CommandPrintErrorln("Unable to find channel '" + args[i] + "'")
+			continue
+		}
+
+		restored, appErr := a.RestoreArchivedPosts(channel.Id)
+		CommandPrintln(fmt.Sprintf("Restored %v posts of channel '%v'", restored, channel.Name))
+		if appErr != nil {
+			CommandPrintErrorln("Unable to restore the posts of channel '" + channel.Name + "' error: " + appErr.Error())
+		}
+	}
+
+	return nil
+}
+
+func postRebalanceCmdF(command *cobra.Command, args []string) error {
+	a, err := InitDBCommandContextCobra(command)
+	if err != nil {
+		return err
+	}
+	defer a.Shutdown()
+
+	moved, appErr := a.RebalancePostShards()
+	CommandPrintln(fmt.Sprintf("Moved %v channels", moved))
+	if appErr != nil {
+		return errors.Wrap(appErr, "failed to rebalance the post shards")
+	}
+
+	return nil
+}
@@ -6,7 +6,7 @@ package config
 import (
 	"bytes"
 	"io"
-	"strings"
+	"io/ioutil"
 	"sync"
 
 	"github.com/mattermost/mattermost-server/model"
@@ -21,6 +21,14 @@ type commonStore struct {
 	config                 *model.Config
 	configWithoutOverrides *model.Config
 	environmentOverrides   map[string]interface{}
+
+	// fragmentsDir, if set, names a directory of partial configurations merged over the
+	// backing store, and fragmentOverrides tracks the settings they last overrode.
+	fragmentsDir      string
+	fragmentOverrides map[string]interface{}
+
+	// secretReferences tracks the settings read from secrets, and the references to them.
+	secretReferences map[string]interface{}
 }
 
 // Get fetches the current, cached configuration.
@@ -31,12 +39,40 @@ func (cs *commonStore) Get() *model.Config {
 	return cs.config
 }
 
-// GetEnvironmentOverrides fetches the configuration fields overridden by environment variables.
+// GetEnvironmentOverrides fetches the configuration fields overridden by environment variables
+// or configuration fragments.
 func (cs *commonStore) GetEnvironmentOverrides() map[string]interface{} {
 	cs.configLock.RLock()
 	defer cs.configLock.RUnlock()
 
-	return cs.environmentOverrides
+	if len(cs.fragmentOverrides) == 0 {
+		return cs.environmentOverrides
+	}
+
+	overrides := mergeMaps(nil, cs.environmentOverrides)
+	for _, path := range getPaths(cs.fragmentOverrides) {
+		parent := overrides
+		for _, key := range path[:len(path)-1] {
+			child, ok := parent[key].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				parent[key] = child
+			}
+			parent = child
+		}
+		parent[path[len(path)-1]] = true
+	}
+
+	return overrides
+}
+
+// GetSecretReferences fetches the configuration fields read from secrets, and the references to
+// those secrets.
+func (cs *commonStore) GetSecretReferences() map[string]interface{} {
+	cs.configLock.RLock()
+	defer cs.configLock.RUnlock()
+
+	return cs.secretReferences
 }
 
 // set replaces the current configuration in its entirety, and updates the backing store
@@ -57,10 +93,14 @@ func (cs *commonStore) set(newCfg *model.Config, allowEnvironmentOverrides bool,
 	// 	return nil, errors.New("old configuration modified instead of cloning")
 	// }
 
-	// To both clone and re-apply the environment variable overrides we marshal and then
-	// unmarshal the config again.
-	var err error
-	newCfg, _, err = unmarshalConfig(strings.NewReader(newCfg.ToJson()), allowEnvironmentOverrides)
+	// To both clone and re-apply the fragment and environment variable overrides we marshal
+	// and then unmarshal the config again.
+	configData, err := applyFragmentOverrides([]byte(newCfg.ToJson()), cs.fragmentOverrides)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to apply config fragments")
+	}
+
+	newCfg, _, err = unmarshalConfig(bytes.NewReader(configData), allowEnvironmentOverrides)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to unmarshal config with env overrides")
 	}
@@ -71,17 +111,41 @@ func (cs *commonStore) set(newCfg *model.Config, allowEnvironmentOverrides bool,
 	// data from the existing config as necessary.
 	desanitize(oldCfg, newCfg)
 
+	// Secrets are only read from the backing store. Settings sent back as the references they
+	// were read from keep their secrets, and new references are refused.
+	newCfg, err = restoreSecrets(newCfg, oldCfg, cs.secretReferences)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to restore secrets")
+	}
+
+	secretReferences, err := retainSecretReferences(newCfg, oldCfg, cs.secretReferences)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retain secret references")
+	}
+
 	if validate != nil {
 		if err := validate(newCfg); err != nil {
 			return nil, errors.Wrap(err, "new configuration is invalid")
 		}
 	}
 
-	if err := persist(cs.removeEnvOverrides(newCfg)); err != nil {
+	// Never persist the secrets themselves.
+	cfgWithReferences, err := ApplySecretReferences(newCfg, secretReferences)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to apply secret references")
+	}
+
+	cfgWithoutOverrides, err := cs.removeOverrides(cfgWithReferences)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to remove overrides")
+	}
+
+	if err := persist(cfgWithoutOverrides); err != nil {
 		return nil, errors.Wrap(err, "failed to persist")
 	}
 
 	cs.config = newCfg
+	cs.secretReferences = secretReferences
 
 	unlockOnce.Do(cs.configLock.Unlock)
 
@@ -96,18 +160,39 @@ func (cs *commonStore) set(newCfg *model.Config, allowEnvironmentOverrides bool,
 //
 // This function assumes no lock has been acquired, as it acquires a write lock itself.
 func (cs *commonStore) load(f io.ReadCloser, needsSave bool, validate func(*model.Config) error, persist func(*model.Config) error) error {
-	// Duplicate f so that we can read a configuration without applying environment overrides
-	f2 := new(bytes.Buffer)
-	tee := io.TeeReader(f, f2)
+	// Keep the original data so that we can read a configuration without applying overrides
+	configData, err := ioutil.ReadAll(f)
+	if err != nil {
+		return errors.Wrap(err, "failed to read config")
+	}
+
+	var fragmentOverrides map[string]interface{}
+	if cs.fragmentsDir != "" {
+		fragmentOverrides, err = readFragments(cs.fragmentsDir)
+		if err != nil {
+			return errors.Wrap(err, "failed to read config fragments")
+		}
+	}
+
+	// Fragments are merged over the backing store, and environment variables over both.
+	configDataWithFragments, err := applyFragmentOverrides(configData, fragmentOverrides)
+	if err != nil {
+		return errors.Wrap(err, "failed to apply config fragments")
+	}
 
 	allowEnvironmentOverrides := true
-	loadedCfg, environmentOverrides, err := unmarshalConfig(tee, allowEnvironmentOverrides)
+	loadedCfg, environmentOverrides, err := unmarshalConfig(bytes.NewReader(configDataWithFragments), allowEnvironmentOverrides)
 	if err != nil {
 		return errors.Wrapf(err, "failed to unmarshal config with env overrides")
 	}
 
-	// Keep track of the original values that the Environment settings overrode
-	loadedCfgWithoutEnvOverrides, _, err := unmarshalConfig(f2, false)
+	loadedCfg, secretReferences, err := resolveSecrets(loadedCfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve secrets")
+	}
+
+	// Keep track of the original values that the Environment settings and fragments overrode
+	loadedCfgWithoutEnvOverrides, _, err := unmarshalConfig(bytes.NewReader(configData), false)
 	if err != nil {
 		return errors.Wrapf(err, "failed to unmarshal config without env overrides")
 	}
@@ -135,8 +220,16 @@ func (cs *commonStore) load(f io.ReadCloser, needsSave bool, validate func(*mode
 	defer unlockOnce.Do(cs.configLock.Unlock)
 
 	if needsSave && persist != nil {
-		cfgWithoutEnvOverrides := removeEnvOverrides(loadedCfg, loadedCfgWithoutEnvOverrides, environmentOverrides)
-		if err = persist(cfgWithoutEnvOverrides); err != nil {
+		cfgWithReferences, err := ApplySecretReferences(loadedCfg, secretReferences)
+		if err != nil {
+			return errors.Wrap(err, "failed to apply secret references")
+		}
+		cfgWithoutEnvOverrides := removeEnvOverrides(cfgWithReferences, loadedCfgWithoutEnvOverrides, environmentOverrides)
+		cfgWithoutOverrides, err := removeFragmentOverrides(cfgWithoutEnvOverrides, loadedCfgWithoutEnvOverrides, fragmentOverrides)
+		if err != nil {
+			return errors.Wrap(err, "failed to remove config fragments")
+		}
+		if err = persist(cfgWithoutOverrides); err != nil {
 			return errors.Wrap(err, "failed to persist required changes after load")
 		}
 	}
@@ -145,6 +238,8 @@ func (cs *commonStore) load(f io.ReadCloser, needsSave bool, validate func(*mode
 	cs.config = loadedCfg
 	cs.configWithoutOverrides = loadedCfgWithoutEnvOverrides
 	cs.environmentOverrides = environmentOverrides
+	cs.fragmentOverrides = fragmentOverrides
+	cs.secretReferences = secretReferences
 
 	unlockOnce.Do(cs.configLock.Unlock)
 
@@ -164,7 +259,9 @@ func (cs *commonStore) validate(cfg *model.Config) error {
 	return nil
 }
 
-// removeEnvOverrides returns a new config without the given environment overrides.
-func (cs *commonStore) removeEnvOverrides(cfg *model.Config) *model.Config {
-	return removeEnvOverrides(cfg, cs.configWithoutOverrides, cs.environmentOverrides)
+// removeOverrides returns a new config without the current environment and fragment overrides.
+func (cs *commonStore) removeOverrides(cfg *model.Config) (*model.Config, error) {
+	cfgWithoutEnvOverrides := removeEnvOverrides(cfg, cs.configWithoutOverrides, cs.environmentOverrides)
+
+	return removeFragmentOverrides(cfgWithoutEnvOverrides, cs.configWithoutOverrides, cs.fragmentOverrides)
 }
@@ -68,13 +68,23 @@ func initializeConfigurationsTable(db *sqlx.DB) error {
 		    Id VARCHAR(26) PRIMARY KEY,
 		    Value TEXT NOT NULL,
 		    CreateAt BIGINT NOT NULL,
-		    Active BOOLEAN NULL UNIQUE
+		    Active BOOLEAN NULL UNIQUE,
+		    UserId VARCHAR(26) NULL,
+		    Diff TEXT NULL
 		)
 	`)
 	if err != nil {
 		return errors.Wrap(err, "failed to create Configurations table")
 	}
 
+	// Configurations tables created before revisions were recorded lack the author and diff.
+	if err = addColumnIfNotExists(db, "Configurations", "UserId", "VARCHAR(26) NULL"); err != nil {
+		return err
+	}
+	if err = addColumnIfNotExists(db, "Configurations", "Diff", "TEXT NULL"); err != nil {
+		return err
+	}
+
 	_, err = db.Exec(`
 		CREATE TABLE IF NOT EXISTS ConfigurationFiles (
 		    Name VARCHAR(64) PRIMARY KEY,
@@ -90,6 +100,30 @@ func initializeConfigurationsTable(db *sqlx.DB) error {
 	return nil
 }
 
+// addColumnIfNotExists adds the column to the table unless it already exists.
+func addColumnIfNotExists(db *sqlx.DB, tableName, columnName, columnType string) error {
+	var count int
+	var err error
+	if db.DriverName() == "postgres" {
+		err = db.Get(&count, "SELECT COUNT(*) FROM information_schema.columns WHERE table_name = $1 AND column_name = $2", strings.ToLower(tableName), strings.ToLower(columnName))
+	} else {
+		err = db.Get(&count, "SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?", tableName, columnName)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to check for %s column in %s table", columnName, tableName)
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	if _, err = db.Exec("ALTER TABLE " + tableName + " ADD " + columnName + " " + columnType); err != nil {
+		return errors.Wrapf(err, "failed to add %s column to %s table", columnName, tableName)
+	}
+
+	return nil
+}
+
 // parseDSN splits up a connection string into a driver name and data source name.
 //
 // For example:
@@ -125,11 +159,25 @@ func parseDSN(dsn string) (string, string, error) {
 
 // Set replaces the current configuration in its entirety and updates the backing store.
 func (ds *DatabaseStore) Set(newCfg *model.Config) (*model.Config, error) {
-	return ds.commonStore.set(newCfg, true, ds.commonStore.validate, ds.persist)
+	return ds.SetByUser(newCfg, "")
+}
+
+// SetByUser replaces the current configuration like Set, recording the given user as the author
+// of the new revision.
+func (ds *DatabaseStore) SetByUser(newCfg *model.Config, userId string) (*model.Config, error) {
+	return ds.commonStore.set(newCfg, true, ds.commonStore.validate, func(cfg *model.Config) error {
+		return ds.persistByUser(cfg, userId)
+	})
 }
 
 // persist writes the configuration to the configured database.
 func (ds *DatabaseStore) persist(cfg *model.Config) error {
+	return ds.persistByUser(cfg, "")
+}
+
+// persistByUser writes the configuration to the configured database as a new revision, recording
+// its author and how it differs from the previously active configuration.
+func (ds *DatabaseStore) persistByUser(cfg *model.Config, userId string) error {
 	b, err := marshalConfig(cfg)
 	if err != nil {
 		return errors.Wrap(err, "failed to serialize")
@@ -155,6 +203,8 @@ func (ds *DatabaseStore) persist(cfg *model.Config) error {
 		"value":     value,
 		"create_at": createAt,
 		"key":       "ConfigurationId",
+		"user_id":   userId,
+		"diff":      nil,
 	}
 
 	// Skip the persist altogether if we're effectively writing the same configuration.
@@ -167,11 +217,25 @@ func (ds *DatabaseStore) persist(cfg *model.Config) error {
 		return nil
 	}
 
+	// The first configuration saved has nothing to be compared against.
+	if len(oldValue) > 0 {
+		oldCfg, _, err := unmarshalConfig(bytes.NewReader(oldValue), false)
+		if err != nil {
+			return errors.Wrap(err, "failed to deserialize active configuration")
+		}
+
+		diff, err := Diff(oldCfg, cfg)
+		if err != nil {
+			return errors.Wrap(err, "failed to compare with active configuration")
+		}
+		params["diff"] = diff.ToJson()
+	}
+
 	if _, err := tx.Exec("UPDATE Configurations SET Active = NULL WHERE Active"); err != nil {
 		return errors.Wrap(err, "failed to deactivate current configuration")
 	}
 
-	if _, err := tx.NamedExec("INSERT INTO Configurations (Id, Value, CreateAt, Active) VALUES (:id, :value, :create_at, TRUE)", params); err != nil {
+	if _, err := tx.NamedExec("INSERT INTO Configurations (Id, Value, CreateAt, Active, UserId, Diff) VALUES (:id, :value, :create_at, TRUE, :user_id, :diff)", params); err != nil {
 		return errors.Wrap(err, "failed to record new configuration")
 	}
 
@@ -214,6 +278,84 @@ func (ds *DatabaseStore) Load() (err error) {
 	return ds.commonStore.load(ioutil.NopCloser(bytes.NewReader(configurationData)), needsSave, ds.commonStore.validate, ds.persist)
 }
 
+// History fetches a page of the saved configuration revisions, newest first.
+func (ds *DatabaseStore) History(offset, limit int) ([]*model.ConfigRevision, error) {
+	query, args, err := sqlx.Named(`
+		SELECT Id, CreateAt, COALESCE(UserId, '') AS UserId, Active IS NOT NULL AS Active, COALESCE(Diff, '') AS Diff
+		FROM Configurations
+		ORDER BY CreateAt DESC, Id
+		LIMIT :limit OFFSET :offset`, map[string]interface{}{
+		"limit":  limit,
+		"offset": offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		Id       string `db:"Id"`
+		CreateAt int64  `db:"CreateAt"`
+		UserId   string `db:"UserId"`
+		Active   bool   `db:"Active"`
+		Diff     string `db:"Diff"`
+	}
+	if err = ds.db.Select(&rows, ds.db.Rebind(query), args...); err != nil {
+		return nil, errors.Wrap(err, "failed to query configuration revisions")
+	}
+
+	revisions := make([]*model.ConfigRevision, 0, len(rows))
+	for _, row := range rows {
+		revision := &model.ConfigRevision{
+			Id:       row.Id,
+			CreateAt: row.CreateAt,
+			UserId:   row.UserId,
+			Active:   row.Active,
+			Diff:     model.ConfigDiffs{},
+		}
+		if row.Diff != "" {
+			revision.Diff = model.ConfigDiffsFromJson(strings.NewReader(row.Diff))
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+// GetRevision fetches the configuration saved by the given revision.
+func (ds *DatabaseStore) GetRevision(id string) (*model.Config, error) {
+	query, args, err := sqlx.Named("SELECT Value FROM Configurations WHERE Id = :id", map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var value []byte
+	if err = ds.db.QueryRowx(ds.db.Rebind(query), args...).Scan(&value); err == sql.ErrNoRows {
+		return nil, ErrRevisionNotFound
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to query configuration revision %s", id)
+	}
+
+	cfg, _, err := unmarshalConfig(bytes.NewReader(value), false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to deserialize configuration revision %s", id)
+	}
+
+	return cfg, nil
+}
+
+// Rollback replaces the current configuration with the one saved by the given revision,
+// recording the result as a new revision by the given user. Listeners are notified as for Set.
+func (ds *DatabaseStore) Rollback(id string, userId string) (*model.Config, error) {
+	cfg, err := ds.GetRevision(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return ds.SetByUser(cfg, userId)
+}
+
 // GetFile fetches the contents of a previously persisted configuration file.
 func (ds *DatabaseStore) GetFile(name string) ([]byte, error) {
 	query, args, err := sqlx.Named("SELECT Data FROM ConfigurationFiles WHERE Name = :name", map[string]interface{}{
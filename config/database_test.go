@@ -941,3 +941,73 @@ func TestDatabaseStoreString(t *testing.T) {
 	assert.NotEmpty(t, actualUsername)
 	assert.Empty(t, actualPassword, "should mask password")
 }
+
+func TestDatabaseStoreHistory(t *testing.T) {
+	initialId, tearDown := setupConfigDatabase(t, minimalConfig, nil)
+	defer tearDown()
+
+	sqlSettings := mainHelper.GetSqlSettings()
+	ds, err := config.NewDatabaseStore(fmt.Sprintf("%s://%s", *sqlSettings.DriverName, *sqlSettings.DataSource))
+	require.NoError(t, err)
+	defer ds.Close()
+
+	userId := model.NewId()
+	newCfg := ds.Get().Clone()
+	*newCfg.ServiceSettings.SiteURL = "http://history"
+	*newCfg.EmailSettings.SMTPPassword = "secret"
+	time.Sleep(time.Millisecond)
+	_, err = ds.SetByUser(newCfg, userId)
+	require.NoError(t, err)
+
+	revisions, err := ds.History(0, 10)
+	require.NoError(t, err)
+	require.True(t, len(revisions) >= 2)
+
+	latest := revisions[0]
+	assert.True(t, latest.Active)
+	assert.Equal(t, userId, latest.UserId)
+	assert.Contains(t, latest.Diff, &model.ConfigDiff{
+		Path:     []string{"ServiceSettings", "SiteURL"},
+		OldValue: "http://minimal",
+		NewValue: "http://history",
+	})
+	assert.NotContains(t, latest.Diff.ToJson(), "secret")
+	for _, revision := range revisions[1:] {
+		assert.False(t, revision.Active)
+	}
+
+	_, err = ds.GetRevision(model.NewId())
+	assert.Equal(t, config.ErrRevisionNotFound, err)
+
+	revisionCfg, err := ds.GetRevision(latest.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "http://history", *revisionCfg.ServiceSettings.SiteURL)
+
+	t.Run("rollback notifies listeners", func(t *testing.T) {
+		called := make(chan bool, 1)
+		listenerId := ds.AddListener(func(oldCfg, newCfg *model.Config) {
+			called <- true
+		})
+		defer ds.RemoveListener(listenerId)
+
+		time.Sleep(time.Millisecond)
+		_, err = ds.Rollback(initialId, userId)
+		require.NoError(t, err)
+
+		select {
+		case <-called:
+		case <-time.After(5 * time.Second):
+			t.Fatal("callback should have been called on rollback")
+		}
+		assert.Equal(t, "http://minimal", *ds.Get().ServiceSettings.SiteURL)
+
+		revisions, err = ds.History(0, 1)
+		require.NoError(t, err)
+		require.Len(t, revisions, 1)
+		assert.NotEqual(t, initialId, revisions[0].Id)
+		assert.True(t, revisions[0].Active)
+
+		_, err = ds.Rollback(model.NewId(), userId)
+		assert.Equal(t, config.ErrRevisionNotFound, err)
+	})
+}
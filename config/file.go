@@ -24,18 +24,22 @@ var (
 
 // FileStore is a config store backed by a file such as config/config.json.
 //
-// It also uses the folder containing the configuration file for storing other configuration files.
+// It also uses the folder containing the configuration file for storing other configuration files,
+// and merges any *.json files in its conf.d folder over the configuration file.
 type FileStore struct {
 	commonStore
 
-	path    string
-	watch   bool
-	watcher *watcher
+	path             string
+	watch            bool
+	watcher          *watcher
+	fragmentsWatcher *watcher
+	secretWatchers   map[string]*watcher
 }
 
 // NewFileStore creates a new instance of a config store backed by the given file path.
 //
-// If watch is true, any external changes to the file will force a reload.
+// If watch is true, any external changes to the file, its config fragments or the files holding
+// secrets it refers to will force a reload.
 func NewFileStore(path string, watch bool) (fs *FileStore, err error) {
 	resolvedPath, err := resolveConfigFilePath(path)
 	if err != nil {
@@ -46,12 +50,19 @@ func NewFileStore(path string, watch bool) (fs *FileStore, err error) {
 		path:  resolvedPath,
 		watch: watch,
 	}
+	fs.fragmentsDir = filepath.Join(filepath.Dir(resolvedPath), FragmentsDirName)
 	if err = fs.Load(); err != nil {
 		return nil, errors.Wrap(err, "failed to load")
 	}
 
 	if fs.watch {
-		if err = fs.startWatcher(); err != nil {
+		// Persisting during the initial load may have already started a watcher that is now
+		// reloading concurrently.
+		fs.configLock.Lock()
+		err = fs.startWatcher()
+		fs.configLock.Unlock()
+
+		if err != nil {
 			mlog.Error("failed to start config watcher", mlog.String("path", path), mlog.Err(err))
 		}
 	}
@@ -94,13 +105,20 @@ func resolveConfigFilePath(path string) (string, error) {
 
 // Set replaces the current configuration in its entirety and updates the backing store.
 func (fs *FileStore) Set(newCfg *model.Config) (*model.Config, error) {
-	return fs.commonStore.set(newCfg, true, func(cfg *model.Config) error {
+	oldCfg, err := fs.commonStore.set(newCfg, true, func(cfg *model.Config) error {
 		if *fs.config.ClusterSettings.Enable && *fs.config.ClusterSettings.ReadOnlyConfig {
 			return ErrReadOnlyConfiguration
 		}
 
 		return fs.commonStore.validate(cfg)
 	}, fs.persist)
+	if err != nil {
+		return nil, err
+	}
+
+	fs.refreshSecretWatchers()
+
+	return oldCfg, nil
 }
 
 // persist writes the configuration to the configured file.
@@ -155,7 +173,13 @@ func (fs *FileStore) Load() (err error) {
 		}
 	}()
 
-	return fs.commonStore.load(f, needsSave, fs.commonStore.validate, fs.persist)
+	if err = fs.commonStore.load(f, needsSave, fs.commonStore.validate, fs.persist); err != nil {
+		return err
+	}
+
+	fs.refreshSecretWatchers()
+
+	return nil
 }
 
 // GetFile fetches the contents of a previously persisted configuration file.
@@ -216,25 +240,100 @@ func (fs *FileStore) RemoveFile(name string) error {
 }
 
 // startWatcher starts a watcher to monitor for external config file changes.
+//
+// Changes to the config fragments are also monitored, provided the conf.d folder exists, as are
+// changes to the files holding secrets referred to by the configuration.
+//
+// This function assumes the caller holds the write lock, or that the store is not yet shared.
 func (fs *FileStore) startWatcher() error {
 	if fs.watcher != nil {
 		return nil
 	}
 
-	watcher, err := newWatcher(fs.path, func() {
-		if err := fs.Load(); err != nil {
-			mlog.Error("failed to reload file on change", mlog.String("path", fs.path), mlog.Err(err))
-		}
-	})
+	watcher, err := newWatcher(fs.path, fs.reload)
 	if err != nil {
 		return err
 	}
 
 	fs.watcher = watcher
 
+	if info, statErr := os.Stat(fs.fragmentsDir); statErr == nil && info.IsDir() {
+		fragmentsWatcher, err := newFragmentsWatcher(fs.fragmentsDir, fs.reload)
+		if err != nil {
+			fs.stopWatcher()
+			return err
+		}
+
+		fs.fragmentsWatcher = fragmentsWatcher
+	}
+
+	fs.watchSecrets()
+
 	return nil
 }
 
+// reload updates the current configuration after an external change.
+func (fs *FileStore) reload() {
+	if err := fs.Load(); err != nil {
+		mlog.Error("failed to reload file on change", mlog.String("path", fs.path), mlog.Err(err))
+	}
+}
+
+// refreshSecretWatchers follows any change in the secrets referred to by the configuration.
+//
+// This function assumes no lock has been acquired, as it acquires a write lock itself.
+func (fs *FileStore) refreshSecretWatchers() {
+	fs.configLock.Lock()
+	defer fs.configLock.Unlock()
+
+	if fs.watcher == nil {
+		// Either watching is disabled, or the store was closed.
+		return
+	}
+
+	fs.watchSecrets()
+}
+
+// watchSecrets monitors the files holding secrets referred to by the configuration, and stops
+// monitoring those no longer referred to.
+//
+// This function assumes the caller holds the write lock, or that the store is not yet shared.
+func (fs *FileStore) watchSecrets() {
+	paths := make(map[string]bool)
+	for _, path := range secretFiles(fs.secretReferences) {
+		paths[path] = true
+	}
+
+	for path, secretWatcher := range fs.secretWatchers {
+		if paths[path] {
+			continue
+		}
+
+		if err := secretWatcher.Close(); err != nil {
+			mlog.Error("failed to close watcher", mlog.Err(err))
+		}
+		delete(fs.secretWatchers, path)
+	}
+
+	for path := range paths {
+		if _, ok := fs.secretWatchers[path]; ok {
+			continue
+		}
+
+		secretWatcher, err := newWatcher(path, fs.reload)
+		if err != nil {
+			// A missing secret would already have failed the load, so this isn't fatal.
+			mlog.Error("failed to start secret watcher", mlog.String("path", path), mlog.Err(err))
+			continue
+		}
+
+		if fs.secretWatchers == nil {
+			fs.secretWatchers = make(map[string]*watcher)
+		}
+		fs.secretWatchers[path] = secretWatcher
+	}
+}
+
 // stopWatcher stops any previously started watcher.
 func (fs *FileStore) stopWatcher() {
 	if fs.watcher == nil {
@@ -245,6 +344,20 @@ func (fs *FileStore) stopWatcher() {
 		mlog.Error("failed to close watcher", mlog.Err(err))
 	}
 	fs.watcher = nil
+
+	if fs.fragmentsWatcher != nil {
+		if err := fs.fragmentsWatcher.Close(); err != nil {
+			mlog.Error("failed to close watcher", mlog.Err(err))
+		}
+		fs.fragmentsWatcher = nil
+	}
+
+	for _, secretWatcher := range fs.secretWatchers {
+		if err := secretWatcher.Close(); err != nil {
+			mlog.Error("failed to close watcher", mlog.Err(err))
+		}
+	}
+	fs.secretWatchers = nil
 }
 
 // String returns the path to the file backing the config.
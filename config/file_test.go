@@ -1064,3 +1064,310 @@ func TestFileStoreString(t *testing.T) {
 
 	assert.Equal(t, "file://"+path, fs.String())
 }
+
+func TestFileStoreFragments(t *testing.T) {
+	writeFragment := func(t *testing.T, path, name, data string) {
+		t.Helper()
+
+		dir := filepath.Join(filepath.Dir(path), config.FragmentsDirName)
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644))
+	}
+
+	t.Run("merged over file", func(t *testing.T) {
+		path, tearDown := setupConfigFile(t, minimalConfig)
+		defer tearDown()
+
+		writeFragment(t, path, "10-site.json", `{"ServiceSettings": {"SiteURL": "http://fragment"}}`)
+		writeFragment(t, path, "20-teams.json", `{"TeamSettings": {"MaxUsersPerTeam": 10}}`)
+
+		fs, err := config.NewFileStore(path, false)
+		require.NoError(t, err)
+		defer fs.Close()
+
+		assert.Equal(t, "http://fragment", *fs.Get().ServiceSettings.SiteURL)
+		assert.Equal(t, 10, *fs.Get().TeamSettings.MaxUsersPerTeam)
+		assert.Equal(t, map[string]interface{}{
+			"ServiceSettings": map[string]interface{}{"SiteURL": true},
+			"TeamSettings":    map[string]interface{}{"MaxUsersPerTeam": true},
+		}, fs.GetEnvironmentOverrides())
+	})
+
+	t.Run("environment takes precedence", func(t *testing.T) {
+		path, tearDown := setupConfigFile(t, minimalConfig)
+		defer tearDown()
+
+		writeFragment(t, path, "site.json", `{"ServiceSettings": {"SiteURL": "http://fragment"}}`)
+
+		os.Setenv("MM_SERVICESETTINGS_SITEURL", "http://override")
+		defer os.Unsetenv("MM_SERVICESETTINGS_SITEURL")
+
+		fs, err := config.NewFileStore(path, false)
+		require.NoError(t, err)
+		defer fs.Close()
+
+		assert.Equal(t, "http://override", *fs.Get().ServiceSettings.SiteURL)
+	})
+
+	t.Run("not persisted", func(t *testing.T) {
+		path, tearDown := setupConfigFile(t, minimalConfig)
+		defer tearDown()
+
+		writeFragment(t, path, "site.json", `{"ServiceSettings": {"SiteURL": "http://fragment"}}`)
+
+		fs, err := config.NewFileStore(path, false)
+		require.NoError(t, err)
+		defer fs.Close()
+
+		newCfg := fs.Get().Clone()
+		*newCfg.ServiceSettings.SiteURL = "http://changed"
+		*newCfg.TeamSettings.SiteName = "changed"
+
+		_, err = fs.Set(newCfg)
+		require.NoError(t, err)
+
+		assert.Equal(t, "http://fragment", *fs.Get().ServiceSettings.SiteURL)
+		assert.Equal(t, "changed", *fs.Get().TeamSettings.SiteName)
+
+		actualConfig := getActualFileConfig(t, path)
+		assert.Equal(t, "http://minimal", *actualConfig.ServiceSettings.SiteURL)
+		assert.Equal(t, "changed", *actualConfig.TeamSettings.SiteName)
+	})
+
+	t.Run("invalid fragment", func(t *testing.T) {
+		path, tearDown := setupConfigFile(t, minimalConfig)
+		defer tearDown()
+
+		writeFragment(t, path, "broken.json", `{"ServiceSettings": {"NoSuchSetting": true}}`)
+
+		_, err := config.NewFileStore(path, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "broken.json")
+	})
+
+	t.Run("watched", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("skipping watcher test in short mode")
+		}
+
+		path, tearDown := setupConfigFile(t, minimalConfig)
+		defer tearDown()
+
+		writeFragment(t, path, "site.json", `{"ServiceSettings": {"SiteURL": "http://fragment"}}`)
+
+		fs, err := config.NewFileStore(path, true)
+		require.NoError(t, err)
+		defer fs.Close()
+
+		called := make(chan bool, 1)
+		fs.AddListener(func(oldCfg, newCfg *model.Config) {
+			if *newCfg.ServiceSettings.SiteURL == "http://minimal" {
+				called <- true
+			}
+		})
+
+		require.NoError(t, os.Remove(filepath.Join(filepath.Dir(path), config.FragmentsDirName, "site.json")))
+		select {
+		case <-called:
+		case <-time.After(5 * time.Second):
+			t.Fatal("callback should have been called when fragment removed")
+		}
+	})
+}
+
+func TestFileStoreSecrets(t *testing.T) {
+	setupSecret := func(t *testing.T, path, value string) string {
+		t.Helper()
+
+		secretPath := filepath.Join(filepath.Dir(path), "smtp-secret")
+		require.NoError(t, ioutil.WriteFile(secretPath, []byte(value+"\n"), 0600))
+
+		return secretPath
+	}
+
+	secretConfig := func(secretPath string) *model.Config {
+		cfg := minimalConfig.Clone()
+		cfg.EmailSettings.SMTPPassword = sToP("file://" + secretPath)
+		cfg.GitLabSettings.Secret = sToP("env:TEST_FILE_STORE_SECRET")
+
+		return cfg
+	}
+
+	// setupConfigFile clears the environment, so the secret and the opt-in are set after it.
+	setupEnv := func(t *testing.T) {
+		t.Helper()
+
+		os.Setenv("TEST_FILE_STORE_SECRET", "gitlab-secret")
+		os.Setenv(config.SecretReferencesEnvironmentVariable, "true")
+	}
+	defer os.Unsetenv("TEST_FILE_STORE_SECRET")
+	defer os.Unsetenv(config.SecretReferencesEnvironmentVariable)
+
+	t.Run("resolved on load", func(t *testing.T) {
+		path, tearDown := setupConfigFile(t, minimalConfig)
+		defer tearDown()
+		setupEnv(t)
+
+		secretPath := setupSecret(t, path, "smtp-secret")
+		cfgData, err := config.MarshalConfig(secretConfig(secretPath))
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(path, cfgData, 0644))
+
+		fs, err := config.NewFileStore(path, false)
+		require.NoError(t, err)
+		defer fs.Close()
+
+		assert.Equal(t, "smtp-secret", *fs.Get().EmailSettings.SMTPPassword)
+		assert.Equal(t, "gitlab-secret", *fs.Get().GitLabSettings.Secret)
+		assert.Equal(t, map[string]interface{}{
+			"EmailSettings":  map[string]interface{}{"SMTPPassword": "file://" + secretPath},
+			"GitLabSettings": map[string]interface{}{"Secret": "env:TEST_FILE_STORE_SECRET"},
+		}, fs.GetSecretReferences())
+	})
+
+	t.Run("references persisted", func(t *testing.T) {
+		path, tearDown := setupConfigFile(t, minimalConfig)
+		defer tearDown()
+		setupEnv(t)
+
+		secretPath := setupSecret(t, path, "smtp-secret")
+		cfgData, err := config.MarshalConfig(secretConfig(secretPath))
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(path, cfgData, 0644))
+
+		fs, err := config.NewFileStore(path, false)
+		require.NoError(t, err)
+		defer fs.Close()
+
+		newCfg := fs.Get().Clone()
+		*newCfg.GitLabSettings.Secret = "changed"
+		*newCfg.TeamSettings.SiteName = "changed"
+
+		_, err = fs.Set(newCfg)
+		require.NoError(t, err)
+
+		assert.Equal(t, "smtp-secret", *fs.Get().EmailSettings.SMTPPassword)
+		assert.Equal(t, "changed", *fs.Get().GitLabSettings.Secret)
+
+		actualConfig := getActualFileConfig(t, path)
+		assert.Equal(t, "file://"+secretPath, *actualConfig.EmailSettings.SMTPPassword)
+		assert.Equal(t, "changed", *actualConfig.GitLabSettings.Secret)
+		assert.Equal(t, "changed", *actualConfig.TeamSettings.SiteName)
+	})
+
+	t.Run("references sent back kept", func(t *testing.T) {
+		path, tearDown := setupConfigFile(t, minimalConfig)
+		defer tearDown()
+		setupEnv(t)
+
+		secretPath := setupSecret(t, path, "smtp-secret")
+		cfgData, err := config.MarshalConfig(secretConfig(secretPath))
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(path, cfgData, 0644))
+
+		fs, err := config.NewFileStore(path, false)
+		require.NoError(t, err)
+		defer fs.Close()
+
+		newCfg, err := config.ApplySecretReferences(fs.Get(), fs.GetSecretReferences())
+		require.NoError(t, err)
+		*newCfg.TeamSettings.SiteName = "changed"
+
+		_, err = fs.Set(newCfg)
+		require.NoError(t, err)
+
+		assert.Equal(t, "smtp-secret", *fs.Get().EmailSettings.SMTPPassword)
+		assert.Equal(t, "gitlab-secret", *fs.Get().GitLabSettings.Secret)
+
+		actualConfig := getActualFileConfig(t, path)
+		assert.Equal(t, "file://"+secretPath, *actualConfig.EmailSettings.SMTPPassword)
+		assert.Equal(t, "env:TEST_FILE_STORE_SECRET", *actualConfig.GitLabSettings.Secret)
+	})
+
+	t.Run("new references refused", func(t *testing.T) {
+		path, tearDown := setupConfigFile(t, minimalConfig)
+		defer tearDown()
+		setupEnv(t)
+
+		fs, err := config.NewFileStore(path, false)
+		require.NoError(t, err)
+		defer fs.Close()
+
+		newCfg := fs.Get().Clone()
+		*newCfg.EmailSettings.SMTPPassword = "env:TEST_FILE_STORE_SECRET"
+
+		_, err = fs.Set(newCfg)
+		require.Error(t, err)
+		assert.Equal(t, config.ErrSecretReferenceNotAllowed, errors.Cause(err))
+
+		actualConfig := getActualFileConfig(t, path)
+		assert.NotEqual(t, sToP("env:TEST_FILE_STORE_SECRET"), actualConfig.EmailSettings.SMTPPassword)
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		path, tearDown := setupConfigFile(t, minimalConfig)
+		defer tearDown()
+		os.Setenv("TEST_FILE_STORE_SECRET", "gitlab-secret")
+
+		secretPath := setupSecret(t, path, "smtp-secret")
+		cfgData, err := config.MarshalConfig(secretConfig(secretPath))
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(path, cfgData, 0644))
+
+		fs, err := config.NewFileStore(path, false)
+		require.NoError(t, err)
+		defer fs.Close()
+
+		assert.Equal(t, "file://"+secretPath, *fs.Get().EmailSettings.SMTPPassword)
+		assert.Nil(t, fs.GetSecretReferences())
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		path, tearDown := setupConfigFile(t, minimalConfig)
+		defer tearDown()
+		setupEnv(t)
+
+		cfgData, err := config.MarshalConfig(secretConfig(filepath.Join(filepath.Dir(path), "missing")))
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(path, cfgData, 0644))
+
+		_, err = config.NewFileStore(path, false)
+		require.Error(t, err)
+	})
+
+	t.Run("watched", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("skipping watcher test in short mode")
+		}
+
+		path, tearDown := setupConfigFile(t, minimalConfig)
+		defer tearDown()
+		setupEnv(t)
+
+		secretPath := setupSecret(t, path, "smtp-secret")
+		cfgData, err := config.MarshalConfig(secretConfig(secretPath))
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(path, cfgData, 0644))
+
+		fs, err := config.NewFileStore(path, true)
+		require.NoError(t, err)
+		defer fs.Close()
+
+		called := make(chan bool, 1)
+		fs.AddListener(func(oldCfg, newCfg *model.Config) {
+			if *newCfg.EmailSettings.SMTPPassword == "rotated" {
+				called <- true
+			}
+		})
+
+		setupSecret(t, path, "rotated")
+		select {
+		case <-called:
+		case <-time.After(5 * time.Second):
+			t.Fatal("callback should have been called when secret rotated")
+		}
+
+		actualConfig := getActualFileConfig(t, path)
+		assert.Equal(t, "file://"+secretPath, *actualConfig.EmailSettings.SMTPPassword)
+	})
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package config
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/utils/jsonutils"
+)
+
+// FragmentsDirName is the directory, alongside a configuration file, from which partial
+// configurations are merged over the file.
+const FragmentsDirName = "conf.d"
+
+// readFragments merges the *.json files in the given directory in lexical order, returning the
+// settings they override keyed by the names used in the serialized configuration. A missing
+// directory overrides nothing.
+func readFragments(dir string) (map[string]interface{}, error) {
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", dir)
+	}
+
+	// ReadDir returns the files sorted by name.
+	var overrides map[string]interface{}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		path := filepath.Join(dir, file.Name())
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", path)
+		}
+
+		var fragment map[string]interface{}
+		if err = json.Unmarshal(data, &fragment); err != nil {
+			return nil, errors.Wrapf(jsonutils.HumanizeJsonError(err, data), "failed to parse %s", path)
+		}
+
+		fragment, err = normalizeFragment(fragment, reflect.TypeOf(model.Config{}))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid settings in %s", path)
+		}
+
+		overrides = mergeMaps(overrides, fragment)
+	}
+
+	return overrides, nil
+}
+
+// normalizeFragment matches the keys of a fragment case-insensitively against the settings of the
+// given type, returning the fragment keyed as in the serialized configuration. Keys of plugin
+// settings are lowercased, as they are when the configuration is loaded.
+func normalizeFragment(in map[string]interface{}, t reflect.Type) (map[string]interface{}, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	out := make(map[string]interface{}, len(in))
+
+	switch t.Kind() {
+	case reflect.Struct:
+		fields := make(map[string]reflect.StructField, t.NumField())
+		names := make(map[string]string, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+
+			name := field.Name
+			if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag != "" {
+				name = tag
+			}
+
+			fields[strings.ToLower(name)] = field
+			names[strings.ToLower(name)] = name
+		}
+
+		for key, value := range in {
+			field, ok := fields[strings.ToLower(key)]
+			if !ok {
+				return nil, errors.Errorf("unknown setting %s", key)
+			}
+
+			normalized, err := normalizeFragmentValue(value, field.Type)
+			if err != nil {
+				return nil, errors.Wrap(err, key)
+			}
+			out[names[strings.ToLower(key)]] = normalized
+		}
+
+	case reflect.Map:
+		for key, value := range in {
+			normalized, err := normalizeFragmentValue(value, t.Elem())
+			if err != nil {
+				return nil, errors.Wrap(err, key)
+			}
+			out[strings.ToLower(key)] = normalized
+		}
+
+	default:
+		return nil, errors.Errorf("unexpected settings for %s", t)
+	}
+
+	return out, nil
+}
+
+func normalizeFragmentValue(value interface{}, t reflect.Type) (interface{}, error) {
+	valueMap, ok := value.(map[string]interface{})
+	if !ok {
+		return value, nil
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct && t.Kind() != reflect.Map {
+		// Free-form values, such as individual plugin settings, are kept as they are.
+		return value, nil
+	}
+
+	return normalizeFragment(valueMap, t)
+}
+
+// mergeMaps deeply merges src over dst, returning dst.
+func mergeMaps(dst, src map[string]interface{}) map[string]interface{} {
+	if dst == nil {
+		dst = make(map[string]interface{}, len(src))
+	}
+
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			dst[key] = mergeMaps(dstMap, srcMap)
+		} else if srcIsMap {
+			dst[key] = mergeMaps(nil, srcMap)
+		} else {
+			dst[key] = value
+		}
+	}
+
+	return dst
+}
+
+// applyFragmentOverrides returns the serialized configuration with the fragment overrides merged
+// over it.
+func applyFragmentOverrides(configData []byte, fragmentOverrides map[string]interface{}) ([]byte, error) {
+	if len(fragmentOverrides) == 0 {
+		return configData, nil
+	}
+
+	var configMap map[string]interface{}
+	if err := json.Unmarshal(configData, &configMap); err != nil {
+		return nil, jsonutils.HumanizeJsonError(err, configData)
+	}
+
+	return json.Marshal(mergeMaps(configMap, fragmentOverrides))
+}
+
+// removeFragmentOverrides returns a new config with each setting overridden by a fragment reset to
+// the value read from the store, or removed if the store had no such value.
+func removeFragmentOverrides(cfg, cfgWithoutOverrides *model.Config, fragmentOverrides map[string]interface{}) (*model.Config, error) {
+	if len(fragmentOverrides) == 0 {
+		return cfg, nil
+	}
+
+	var cfgMap, originalMap map[string]interface{}
+	if err := json.Unmarshal([]byte(cfg.ToJson()), &cfgMap); err != nil {
+		return nil, errors.Wrap(err, "failed to convert config")
+	}
+	if err := json.Unmarshal([]byte(cfgWithoutOverrides.ToJson()), &originalMap); err != nil {
+		return nil, errors.Wrap(err, "failed to convert original config")
+	}
+
+	for _, path := range getPaths(fragmentOverrides) {
+		removeFragmentOverride(cfgMap, originalMap, path)
+	}
+
+	return configFromMap(cfgMap)
+}
+
+// removeFragmentOverride resets the value at the given path to the original value, removing it
+// and any containing settings left empty if there was no original value.
+func removeFragmentOverride(cfgMap, originalMap map[string]interface{}, path []string) {
+	key := path[0]
+
+	if len(path) > 1 {
+		child, ok := cfgMap[key].(map[string]interface{})
+		if !ok {
+			return
+		}
+		originalChild, _ := originalMap[key].(map[string]interface{})
+
+		removeFragmentOverride(child, originalChild, path[1:])
+
+		if _, ok := originalMap[key]; len(child) == 0 && !ok {
+			delete(cfgMap, key)
+		}
+		return
+	}
+
+	if originalValue, ok := originalMap[key]; ok {
+		cfgMap[key] = originalValue
+	} else {
+		delete(cfgMap, key)
+	}
+}
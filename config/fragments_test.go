@@ -0,0 +1,134 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package config
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestReadFragments(t *testing.T) {
+	setupFragments := func(t *testing.T, files map[string]string) (string, func()) {
+		t.Helper()
+
+		dir, err := ioutil.TempDir("", "TestReadFragments")
+		require.NoError(t, err)
+
+		for name, data := range files {
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644))
+		}
+
+		return dir, func() {
+			os.RemoveAll(dir)
+		}
+	}
+
+	t.Run("missing directory", func(t *testing.T) {
+		overrides, err := readFragments(filepath.Join(os.TempDir(), model.NewId()))
+		require.NoError(t, err)
+		assert.Nil(t, overrides)
+	})
+
+	t.Run("merged in lexical order", func(t *testing.T) {
+		dir, tearDown := setupFragments(t, map[string]string{
+			"10-site.json":  `{"ServiceSettings": {"SiteURL": "http://first", "EnableDeveloper": true}}`,
+			"20-site.json":  `{"servicesettings": {"siteurl": "http://second"}}`,
+			"ignored.txt":   `not json`,
+			"30-teams.json": `{"TeamSettings": {"MaxUsersPerTeam": 10}}`,
+		})
+		defer tearDown()
+
+		overrides, err := readFragments(dir)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"ServiceSettings": map[string]interface{}{
+				"SiteURL":         "http://second",
+				"EnableDeveloper": true,
+			},
+			"TeamSettings": map[string]interface{}{
+				"MaxUsersPerTeam": float64(10),
+			},
+		}, overrides)
+	})
+
+	t.Run("plugin settings", func(t *testing.T) {
+		dir, tearDown := setupFragments(t, map[string]string{
+			"plugins.json": `{"PluginSettings": {"Plugins": {"Com.Example.Plugin": {"Setting": "value"}}}}`,
+		})
+		defer tearDown()
+
+		overrides, err := readFragments(dir)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"PluginSettings": map[string]interface{}{
+				"Plugins": map[string]interface{}{
+					"com.example.plugin": map[string]interface{}{"setting": "value"},
+				},
+			},
+		}, overrides)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		dir, tearDown := setupFragments(t, map[string]string{
+			"broken.json": `{"ServiceSettings": `,
+		})
+		defer tearDown()
+
+		_, err := readFragments(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "broken.json")
+	})
+
+	t.Run("unknown setting", func(t *testing.T) {
+		dir, tearDown := setupFragments(t, map[string]string{
+			"unknown.json": `{"ServiceSettings": {"NoSuchSetting": true}}`,
+		})
+		defer tearDown()
+
+		_, err := readFragments(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "NoSuchSetting")
+	})
+}
+
+func TestApplyAndRemoveFragmentOverrides(t *testing.T) {
+	baseCfg := &model.Config{}
+	baseCfg.SetDefaults()
+	*baseCfg.ServiceSettings.SiteURL = "http://base"
+
+	overrides := map[string]interface{}{
+		"ServiceSettings": map[string]interface{}{
+			"SiteURL": "http://fragment",
+		},
+		"PluginSettings": map[string]interface{}{
+			"Plugins": map[string]interface{}{
+				"com.example.plugin": map[string]interface{}{"setting": "value"},
+			},
+		},
+	}
+
+	data, err := applyFragmentOverrides([]byte(baseCfg.ToJson()), overrides)
+	require.NoError(t, err)
+
+	cfg := model.ConfigFromJson(bytes.NewReader(data))
+	require.NotNil(t, cfg)
+	assert.Equal(t, "http://fragment", *cfg.ServiceSettings.SiteURL)
+	assert.Equal(t, "value", cfg.PluginSettings.Plugins["com.example.plugin"]["setting"])
+
+	*cfg.TeamSettings.SiteName = "changed"
+
+	cfgWithoutOverrides, err := removeFragmentOverrides(cfg, baseCfg, overrides)
+	require.NoError(t, err)
+	assert.Equal(t, "http://base", *cfgWithoutOverrides.ServiceSettings.SiteURL)
+	assert.Equal(t, "changed", *cfgWithoutOverrides.TeamSettings.SiteName)
+	assert.NotContains(t, cfgWithoutOverrides.PluginSettings.Plugins, "com.example.plugin")
+}
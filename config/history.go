@@ -0,0 +1,160 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// ErrRevisionNotFound is returned when a configuration revision does not exist.
+var ErrRevisionNotFound = errors.New("configuration revision not found")
+
+// HistoryStore is a config store that keeps every configuration saved to it as a revision.
+type HistoryStore interface {
+	Store
+
+	// SetByUser replaces the current configuration like Set, recording the given user as the
+	// author of the new revision.
+	SetByUser(newCfg *model.Config, userId string) (*model.Config, error)
+
+	// History fetches a page of the saved configuration revisions, newest first.
+	History(offset, limit int) ([]*model.ConfigRevision, error)
+
+	// GetRevision fetches the configuration saved by the given revision.
+	GetRevision(id string) (*model.Config, error)
+
+	// Rollback replaces the current configuration with the one saved by the given revision,
+	// recording the result as a new revision by the given user.
+	Rollback(id string, userId string) (*model.Config, error)
+}
+
+// Diff returns the settings that differ between two configurations, ordered by path. Values
+// hidden by model.Config.Sanitize are masked.
+func Diff(oldCfg, newCfg *model.Config) (model.ConfigDiffs, error) {
+	oldMap, err := configToDiffMap(oldCfg, false)
+	if err != nil {
+		return nil, err
+	}
+	newMap, err := configToDiffMap(newCfg, false)
+	if err != nil {
+		return nil, err
+	}
+	oldSanitized, err := configToDiffMap(oldCfg, true)
+	if err != nil {
+		return nil, err
+	}
+	newSanitized, err := configToDiffMap(newCfg, true)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := model.ConfigDiffs{}
+	diffMaps(nil, oldMap, newMap, oldSanitized, newSanitized, &diffs)
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return strings.Join(diffs[i].Path, ".") < strings.Join(diffs[j].Path, ".")
+	})
+
+	return diffs, nil
+}
+
+// configToDiffMap converts the configuration to the generic form used to compare settings.
+func configToDiffMap(cfg *model.Config, sanitize bool) (map[string]interface{}, error) {
+	if cfg == nil {
+		return map[string]interface{}{}, nil
+	}
+
+	if sanitize {
+		cfg = cfg.Clone()
+		cfg.SetDefaults()
+		cfg.Sanitize()
+	}
+
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize")
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize")
+	}
+
+	return m, nil
+}
+
+func diffMaps(path []string, oldMap, newMap, oldSanitized, newSanitized map[string]interface{}, diffs *model.ConfigDiffs) {
+	keys := map[string]bool{}
+	for key := range oldMap {
+		keys[key] = true
+	}
+	for key := range newMap {
+		keys[key] = true
+	}
+
+	for key := range keys {
+		keyPath := append(append([]string{}, path...), key)
+		oldValue, newValue := oldMap[key], newMap[key]
+
+		oldChild, oldIsMap := oldValue.(map[string]interface{})
+		newChild, newIsMap := newValue.(map[string]interface{})
+		if oldIsMap && newIsMap {
+			oldSanitizedChild, _ := oldSanitized[key].(map[string]interface{})
+			newSanitizedChild, _ := newSanitized[key].(map[string]interface{})
+			diffMaps(keyPath, oldChild, newChild, oldSanitizedChild, newSanitizedChild, diffs)
+			continue
+		}
+
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+
+		*diffs = append(*diffs, &model.ConfigDiff{
+			Path:     keyPath,
+			OldValue: maskValue(oldValue, oldSanitized[key]),
+			NewValue: maskValue(newValue, newSanitized[key]),
+		})
+	}
+}
+
+// maskValue returns the value with anything hidden in its sanitized counterpart masked.
+func maskValue(value, sanitized interface{}) interface{} {
+	if m, ok := value.(map[string]interface{}); ok {
+		sanitizedMap, _ := sanitized.(map[string]interface{})
+
+		masked := make(map[string]interface{}, len(m))
+		for key, child := range m {
+			masked[key] = maskValue(child, sanitizedMap[key])
+		}
+		return masked
+	}
+
+	if isMasked(sanitized) {
+		return model.FAKE_SETTING
+	}
+
+	return value
+}
+
+func isMasked(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return v == model.FAKE_SETTING
+	case []interface{}:
+		for _, item := range v {
+			if item == model.FAKE_SETTING {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/config"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestDiff(t *testing.T) {
+	oldCfg := &model.Config{}
+	oldCfg.SetDefaults()
+	*oldCfg.ServiceSettings.SiteURL = "http://old"
+	*oldCfg.EmailSettings.SMTPPassword = "old password"
+	oldCfg.PluginSettings.Plugins["com.example.plugin"] = map[string]interface{}{"key": "old"}
+
+	t.Run("no changes", func(t *testing.T) {
+		diff, err := config.Diff(oldCfg, oldCfg.Clone())
+		require.NoError(t, err)
+		assert.Empty(t, diff)
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		newCfg := oldCfg.Clone()
+		*newCfg.ServiceSettings.SiteURL = "http://new"
+		*newCfg.EmailSettings.SMTPPassword = "new password"
+		newCfg.PluginSettings.Plugins["com.example.plugin"]["key"] = "new"
+		newCfg.SqlSettings.DataSourceReplicas = []string{"replica"}
+
+		diff, err := config.Diff(oldCfg, newCfg)
+		require.NoError(t, err)
+		assert.Equal(t, model.ConfigDiffs{
+			{Path: []string{"EmailSettings", "SMTPPassword"}, OldValue: model.FAKE_SETTING, NewValue: model.FAKE_SETTING},
+			{Path: []string{"PluginSettings", "Plugins", "com.example.plugin", "key"}, OldValue: "old", NewValue: "new"},
+			{Path: []string{"ServiceSettings", "SiteURL"}, OldValue: "http://old", NewValue: "http://new"},
+			{Path: []string{"SqlSettings", "DataSourceReplicas"}, OldValue: []interface{}{}, NewValue: model.FAKE_SETTING},
+		}, diff)
+	})
+
+	t.Run("from nothing", func(t *testing.T) {
+		diff, err := config.Diff(nil, oldCfg)
+		require.NoError(t, err)
+		assert.NotEmpty(t, diff)
+		assert.NotContains(t, diff.ToJson(), "old password")
+	})
+}
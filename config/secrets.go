@@ -0,0 +1,395 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package config
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	// SecretFilePrefix marks a setting whose value is read from the named file, e.g.
+	// file:///run/secrets/smtp.
+	SecretFilePrefix = "file://"
+
+	// SecretEnvPrefix marks a setting whose value is read from the named environment variable,
+	// e.g. env:SMTP_PASSWORD.
+	SecretEnvPrefix = "env:"
+)
+
+// IsSecretReference returns true if the given setting refers to a secret stored elsewhere.
+func IsSecretReference(value string) bool {
+	return (strings.HasPrefix(value, SecretFilePrefix) && len(value) > len(SecretFilePrefix)) ||
+		(strings.HasPrefix(value, SecretEnvPrefix) && len(value) > len(SecretEnvPrefix))
+}
+
+// resolveSecretReference fetches the secret the given reference refers to.
+func resolveSecretReference(reference string) (string, error) {
+	if strings.HasPrefix(reference, SecretEnvPrefix) {
+		name := strings.TrimPrefix(reference, SecretEnvPrefix)
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return "", errors.Errorf("environment variable %s is not set", name)
+		}
+
+		return value, nil
+	}
+
+	path := strings.TrimPrefix(reference, SecretFilePrefix)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read secret from %s", path)
+	}
+
+	// Secret files commonly end with a newline that isn't part of the secret.
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// secretFiles returns the paths of the files referenced by the given secret references.
+func secretFiles(references map[string]interface{}) []string {
+	var paths []string
+	seen := make(map[string]bool)
+
+	var add func(value interface{})
+	add = func(value interface{}) {
+		switch v := value.(type) {
+		case string:
+			if strings.HasPrefix(v, SecretFilePrefix) && !seen[v] {
+				seen[v] = true
+				paths = append(paths, strings.TrimPrefix(v, SecretFilePrefix))
+			}
+		case []interface{}:
+			for _, item := range v {
+				add(item)
+			}
+		case map[string]interface{}:
+			for _, item := range v {
+				add(item)
+			}
+		}
+	}
+	add(references)
+
+	return paths
+}
+
+// secretSettings are the only settings that may refer to secrets. They are the settings hidden by
+// model.Config.Sanitize, so that a secret is never returned through the API.
+var secretSettings = [][]string{
+	{"LdapSettings", "BindPassword"},
+	{"FileSettings", "PublicLinkSalt"},
+	{"FileSettings", "AmazonS3SecretAccessKey"},
+	{"EmailSettings", "SMTPPassword"},
+	{"GitLabSettings", "Secret"},
+	{"SqlSettings", "DataSource"},
+	{"SqlSettings", "AtRestEncryptKey"},
+	{"SqlSettings", "DataSourceReplicas"},
+	{"SqlSettings", "DataSourceSearchReplicas"},
+	{"SqlSettings", "PostShardDataSources"},
+	{"ElasticsearchSettings", "Password"},
+	{"CacheSettings", "RedisPassword"},
+}
+
+// SecretReferencesEnvironmentVariable must be set to true for the secret references in the backing
+// store to be resolved. Otherwise they are left as they are.
+const SecretReferencesEnvironmentVariable = "MM_ENABLE_SECRET_REFERENCES"
+
+// ErrSecretReferenceNotAllowed is returned when a configuration being saved adds a secret reference.
+// References may only be added to the backing store directly, so that saving a configuration can't
+// be used to read a file or environment variable of the server.
+var ErrSecretReferenceNotAllowed = errors.New("secret references can only be added to the backing store directly")
+
+func secretReferencesEnabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(SecretReferencesEnvironmentVariable))
+	return enabled
+}
+
+// resolveSecrets returns a new config with every secret reference in the secret settings replaced
+// by the secret it refers to, along with the references keyed by the path of the settings they were
+// read from. Nothing is resolved unless secret references have been enabled.
+func resolveSecrets(cfg *model.Config) (*model.Config, map[string]interface{}, error) {
+	if !secretReferencesEnabled() {
+		return cfg, nil, nil
+	}
+
+	cfgMap, err := configToMap(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var references map[string]interface{}
+	for _, path := range secretSettings {
+		// Lists such as database replicas keep the whole original list as their reference, while
+		// maps only keep the entries that are references.
+		original := getMapValue(cfgMap, path)
+		if list, ok := original.([]interface{}); ok {
+			original = append([]interface{}{}, list...)
+		}
+
+		found := make(map[string]interface{})
+		err := updateSecretSetting(cfgMap, path, func(key, value string) (string, error) {
+			if !IsSecretReference(value) {
+				return value, nil
+			}
+
+			resolved, err := resolveSecretReference(value)
+			if err != nil {
+				return "", errors.Wrapf(err, "failed to resolve %s", strings.Join(path, "."))
+			}
+			found[key] = value
+
+			return resolved, nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(found) == 0 {
+			continue
+		}
+
+		switch original.(type) {
+		case string:
+			references = setSecretReference(references, path, found[""])
+		case []interface{}:
+			references = setSecretReference(references, path, original)
+		default:
+			references = setSecretReference(references, path, found)
+		}
+	}
+
+	if len(references) == 0 {
+		return cfg, nil, nil
+	}
+
+	newCfg, err := configFromMap(cfgMap)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return newCfg, references, nil
+}
+
+// restoreSecrets returns a new config in which the secret settings sent back as the references
+// they were read from hold the secrets again. Any other new secret reference is refused with
+// ErrSecretReferenceNotAllowed.
+func restoreSecrets(cfg, oldCfg *model.Config, references map[string]interface{}) (*model.Config, error) {
+	cfgMap, err := configToMap(cfg)
+	if err != nil {
+		return nil, err
+	}
+	oldMap, err := configToMap(oldCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range secretSettings {
+		oldValues := secretSettingValues(getMapValue(oldMap, path))
+		referenceValues := secretSettingValues(getMapValue(references, path))
+
+		err := updateSecretSetting(cfgMap, path, func(key, value string) (string, error) {
+			if !IsSecretReference(value) {
+				return value, nil
+			}
+
+			if reference, ok := referenceValues[key]; ok && reference == value {
+				return oldValues[key], nil
+			}
+
+			// A reference that was never resolved, when secret references aren't enabled, may be
+			// saved again unchanged.
+			if oldValue, ok := oldValues[key]; ok && oldValue == value {
+				return value, nil
+			}
+
+			return "", errors.Wrapf(ErrSecretReferenceNotAllowed, "%s", strings.Join(path, "."))
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return configFromMap(cfgMap)
+}
+
+// retainSecretReferences returns the references of the settings whose secrets are unchanged
+// between the old, resolved config and the new one.
+func retainSecretReferences(cfg, oldCfg *model.Config, references map[string]interface{}) (map[string]interface{}, error) {
+	if len(references) == 0 {
+		return nil, nil
+	}
+
+	cfgMap, err := configToMap(cfg)
+	if err != nil {
+		return nil, err
+	}
+	oldMap, err := configToMap(oldCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var retained map[string]interface{}
+	for _, path := range getPaths(references) {
+		if reflect.DeepEqual(getMapValue(cfgMap, path), getMapValue(oldMap, path)) {
+			retained = setSecretReference(retained, path, getMapValue(references, path))
+		}
+	}
+
+	return retained, nil
+}
+
+// updateSecretSetting replaces each string held by the secret setting at the given path, whether
+// on its own or within a list or map, by the result of update. Strings within a list are keyed by
+// their index and those within a map by their key.
+func updateSecretSetting(cfgMap map[string]interface{}, path []string, update func(key, value string) (string, error)) error {
+	parent, _ := getMapValue(cfgMap, path[:len(path)-1]).(map[string]interface{})
+	if parent == nil {
+		return nil
+	}
+	name := path[len(path)-1]
+
+	switch v := parent[name].(type) {
+	case string:
+		updated, err := update("", v)
+		if err != nil {
+			return err
+		}
+		parent[name] = updated
+
+	case []interface{}:
+		for i, item := range v {
+			if s, ok := item.(string); ok {
+				updated, err := update(strconv.Itoa(i), s)
+				if err != nil {
+					return err
+				}
+				v[i] = updated
+			}
+		}
+
+	case map[string]interface{}:
+		for key, item := range v {
+			if s, ok := item.(string); ok {
+				updated, err := update(key, s)
+				if err != nil {
+					return err
+				}
+				v[key] = updated
+			}
+		}
+	}
+
+	return nil
+}
+
+// secretSettingValues returns the strings held by a secret setting, keyed as by updateSecretSetting.
+func secretSettingValues(value interface{}) map[string]string {
+	values := make(map[string]string)
+
+	switch v := value.(type) {
+	case string:
+		values[""] = v
+	case []interface{}:
+		for i, item := range v {
+			if s, ok := item.(string); ok {
+				values[strconv.Itoa(i)] = s
+			}
+		}
+	case map[string]interface{}:
+		for key, item := range v {
+			if s, ok := item.(string); ok {
+				values[key] = s
+			}
+		}
+	}
+
+	return values
+}
+
+func setSecretReference(references map[string]interface{}, path []string, value interface{}) map[string]interface{} {
+	if references == nil {
+		references = make(map[string]interface{})
+	}
+
+	parent := references
+	for _, key := range path[:len(path)-1] {
+		child, _ := parent[key].(map[string]interface{})
+		if child == nil {
+			child = make(map[string]interface{})
+			parent[key] = child
+		}
+		parent = child
+	}
+	parent[path[len(path)-1]] = value
+
+	return references
+}
+
+// getMapValue returns the value at the given path of a nested map, or nil if there isn't one.
+func getMapValue(m map[string]interface{}, path []string) interface{} {
+	var value interface{} = m
+	for _, key := range path {
+		parent, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = parent[key]
+	}
+
+	return value
+}
+
+// ApplySecretReferences returns a new config with the settings read from secrets replaced by the
+// references to those secrets, so that the secrets themselves are never exposed or persisted.
+func ApplySecretReferences(cfg *model.Config, references map[string]interface{}) (*model.Config, error) {
+	if len(references) == 0 {
+		return cfg, nil
+	}
+
+	cfgMap, err := configToMap(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range getPaths(references) {
+		parent, _ := getMapValue(cfgMap, path[:len(path)-1]).(map[string]interface{})
+		if parent == nil {
+			continue
+		}
+		parent[path[len(path)-1]] = getMapValue(references, path)
+	}
+
+	return configFromMap(cfgMap)
+}
+
+func configToMap(cfg *model.Config) (map[string]interface{}, error) {
+	var cfgMap map[string]interface{}
+	if err := json.Unmarshal([]byte(cfg.ToJson()), &cfgMap); err != nil {
+		return nil, errors.Wrap(err, "failed to convert config")
+	}
+
+	return cfgMap, nil
+}
+
+func configFromMap(cfgMap map[string]interface{}) (*model.Config, error) {
+	b, err := json.Marshal(cfgMap)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize config")
+	}
+
+	var cfg model.Config
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize config")
+	}
+
+	return &cfg, nil
+}
@@ -20,6 +20,10 @@ type Store interface {
 	// GetEnvironmentOverrides fetches the configuration fields overridden by environment variables.
 	GetEnvironmentOverrides() map[string]interface{}
 
+	// GetSecretReferences fetches the configuration fields read from secrets, and the references
+	// to those secrets.
+	GetSecretReferences() map[string]interface{}
+
 	// Set replaces the current configuration in its entirety and updates the backing store.
 	Set(*model.Config) (*model.Config, error)
 
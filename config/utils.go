@@ -43,6 +43,10 @@ func desanitize(actual, target *model.Config) {
 		*target.ElasticsearchSettings.Password = *actual.ElasticsearchSettings.Password
 	}
 
+	if *target.CacheSettings.RedisPassword == model.FAKE_SETTING {
+		*target.CacheSettings.RedisPassword = *actual.CacheSettings.RedisPassword
+	}
+
 	target.SqlSettings.DataSourceReplicas = make([]string, len(actual.SqlSettings.DataSourceReplicas))
 	for i := range target.SqlSettings.DataSourceReplicas {
 		target.SqlSettings.DataSourceReplicas[i] = actual.SqlSettings.DataSourceReplicas[i]
@@ -52,6 +56,11 @@ func desanitize(actual, target *model.Config) {
 	for i := range target.SqlSettings.DataSourceSearchReplicas {
 		target.SqlSettings.DataSourceSearchReplicas[i] = actual.SqlSettings.DataSourceSearchReplicas[i]
 	}
+
+	target.SqlSettings.PostShardDataSources = make(map[string]string, len(actual.SqlSettings.PostShardDataSources))
+	for name, dataSource := range actual.SqlSettings.PostShardDataSources {
+		target.SqlSettings.PostShardDataSources[name] = dataSource
+	}
 }
 
 // fixConfig patches invalid or missing data in the configuration, returning true if changed.
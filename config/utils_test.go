@@ -29,10 +29,12 @@ func TestDesanitize(t *testing.T) {
 	actual.SqlSettings.DataSource = sToP("data_source")
 	actual.SqlSettings.AtRestEncryptKey = sToP("at_rest_encrypt_key")
 	actual.ElasticsearchSettings.Password = sToP("password")
+	actual.CacheSettings.RedisPassword = sToP("redis_password")
 	actual.SqlSettings.DataSourceReplicas = append(actual.SqlSettings.DataSourceReplicas, "replica0")
 	actual.SqlSettings.DataSourceReplicas = append(actual.SqlSettings.DataSourceReplicas, "replica1")
 	actual.SqlSettings.DataSourceSearchReplicas = append(actual.SqlSettings.DataSourceSearchReplicas, "search_replica0")
 	actual.SqlSettings.DataSourceSearchReplicas = append(actual.SqlSettings.DataSourceSearchReplicas, "search_replica1")
+	actual.SqlSettings.PostShardDataSources = map[string]string{"shard1": "shard_data_source1"}
 
 	target := &model.Config{}
 	target.SetDefaults()
@@ -50,8 +52,10 @@ func TestDesanitize(t *testing.T) {
 	target.SqlSettings.DataSource = sToP(model.FAKE_SETTING)
 	target.SqlSettings.AtRestEncryptKey = sToP(model.FAKE_SETTING)
 	target.ElasticsearchSettings.Password = sToP(model.FAKE_SETTING)
+	target.CacheSettings.RedisPassword = sToP(model.FAKE_SETTING)
 	target.SqlSettings.DataSourceReplicas = append(target.SqlSettings.DataSourceReplicas, "old_replica0")
 	target.SqlSettings.DataSourceSearchReplicas = append(target.SqlSettings.DataSourceReplicas, "old_search_replica0")
+	target.SqlSettings.PostShardDataSources = map[string]string{"shard1": model.FAKE_SETTING}
 
 	actual_clone := actual.Clone()
 	desanitize(actual, target)
@@ -70,8 +74,10 @@ func TestDesanitize(t *testing.T) {
 	assert.Equal(t, *actual.SqlSettings.DataSource, *target.SqlSettings.DataSource)
 	assert.Equal(t, *actual.SqlSettings.AtRestEncryptKey, *target.SqlSettings.AtRestEncryptKey)
 	assert.Equal(t, *actual.ElasticsearchSettings.Password, *target.ElasticsearchSettings.Password)
+	assert.Equal(t, *actual.CacheSettings.RedisPassword, *target.CacheSettings.RedisPassword)
 	assert.Equal(t, actual.SqlSettings.DataSourceReplicas, target.SqlSettings.DataSourceReplicas)
 	assert.Equal(t, actual.SqlSettings.DataSourceSearchReplicas, target.SqlSettings.DataSourceSearchReplicas)
+	assert.Equal(t, actual.SqlSettings.PostShardDataSources, target.SqlSettings.PostShardDataSources)
 }
 
 func TestFixInvalidLocales(t *testing.T) {
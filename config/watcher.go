@@ -12,7 +12,7 @@ import (
 	"github.com/mattermost/mattermost-server/mlog"
 )
 
-// watcher monitors a file for changes
+// watcher monitors a file or directory for changes
 type watcher struct {
 	emitter
 
@@ -23,20 +23,46 @@ type watcher struct {
 
 // newWatcher creates a new instance of watcher to monitor for file changes.
 func newWatcher(path string, callback func()) (w *watcher, err error) {
-	fsWatcher, err := fsnotify.NewWatcher()
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to create fsnotify watcher for %s", path)
-	}
-
 	path = filepath.Clean(path)
 
 	// Watch the entire containing directory.
 	configDir, _ := filepath.Split(path)
-	if err := fsWatcher.Add(configDir); err != nil {
+
+	return watchDir(configDir, func(event fsnotify.Event) bool {
+		// We only care about the given file.
+		if filepath.Clean(event.Name) != path {
+			return false
+		}
+
+		return event.Op&fsnotify.Write == fsnotify.Write || event.Op&fsnotify.Create == fsnotify.Create
+	}, callback)
+}
+
+// newFragmentsWatcher creates a new instance of watcher to monitor for changes to the
+// configuration fragments in the given directory.
+func newFragmentsWatcher(dir string, callback func()) (w *watcher, err error) {
+	return watchDir(filepath.Clean(dir), func(event fsnotify.Event) bool {
+		if filepath.Ext(event.Name) != ".json" {
+			return false
+		}
+
+		// Unlike the configuration file, removing or renaming a fragment is also a change.
+		return event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) != 0
+	}, callback)
+}
+
+// watchDir monitors the given directory, invoking the callback for every event matching the filter.
+func watchDir(dir string, filter func(fsnotify.Event) bool, callback func()) (w *watcher, err error) {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create fsnotify watcher for %s", dir)
+	}
+
+	if err := fsWatcher.Add(dir); err != nil {
 		if closeErr := fsWatcher.Close(); closeErr != nil {
-			mlog.Error("failed to stop fsnotify watcher for %s", mlog.String("path", path), mlog.Err(closeErr))
+			mlog.Error("failed to stop fsnotify watcher for %s", mlog.String("path", dir), mlog.Err(closeErr))
 		}
-		return nil, errors.Wrapf(err, "failed to watch directory %s", configDir)
+		return nil, errors.Wrapf(err, "failed to watch directory %s", dir)
 	}
 
 	w = &watcher{
@@ -49,22 +75,19 @@ func newWatcher(path string, callback func()) (w *watcher, err error) {
 		defer close(w.closed)
 		defer func() {
 			if err := fsWatcher.Close(); err != nil {
-				mlog.Error("failed to stop fsnotify watcher for %s", mlog.String("path", path))
+				mlog.Error("failed to stop fsnotify watcher for %s", mlog.String("path", dir))
 			}
 		}()
 
 		for {
 			select {
 			case event := <-fsWatcher.Events:
-				// We only care about the given file.
-				if filepath.Clean(event.Name) == path {
-					if event.Op&fsnotify.Write == fsnotify.Write || event.Op&fsnotify.Create == fsnotify.Create {
-						mlog.Info("Config file watcher detected a change", mlog.String("path", path))
-						go callback()
-					}
+				if filter(event) {
+					mlog.Info("Config file watcher detected a change", mlog.String("path", event.Name))
+					go callback()
 				}
 			case err := <-fsWatcher.Errors:
-				mlog.Error("Failed while watching config file", mlog.String("path", path), mlog.Err(err))
+				mlog.Error("Failed while watching config file", mlog.String("path", dir), mlog.Err(err))
 			case <-w.close:
 				return
 			}
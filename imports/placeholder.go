@@ -8,4 +8,6 @@ package imports
 import (
 	_ "github.com/mattermost/mattermost-server/migrations"
 	_ "github.com/mattermost/mattermost-server/plugin/scheduler"
+	_ "github.com/mattermost/mattermost-server/postarchival"
+	_ "github.com/mattermost/mattermost-server/postsharding"
 )
@@ -114,13 +114,27 @@ func (watcher *Watcher) PollAndNotify() {
 				default:
 				}
 			}
-		} else if job.Type == model.JOB_TYPE_PLUGINS {
+		} else if job.Type == model.JOB_TYPE_PLUGINS || job.Type == model.JOB_TYPE_PLUGIN_SCHEDULED {
 			if watcher.workers.Plugins != nil {
 				select {
 				case watcher.workers.Plugins.JobChannel() <- *job:
 				default:
 				}
 			}
+		} else if job.Type == model.JOB_TYPE_POST_ARCHIVAL {
+			if watcher.workers.PostArchival != nil {
+				select {
+				case watcher.workers.PostArchival.JobChannel() <- *job:
+				default:
+				}
+			}
+		} else if job.Type == model.JOB_TYPE_POST_SHARD_REBALANCE {
+			if watcher.workers.PostShardRebalance != nil {
+				select {
+				case watcher.workers.PostShardRebalance.JobChannel() <- *job:
+				default:
+				}
+			}
 		}
 	}
 }
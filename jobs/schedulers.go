@@ -21,6 +21,11 @@ type Schedulers struct {
 	startOnce            sync.Once
 	jobs                 *JobServer
 
+	// mutex guards the schedulers and their next run times, which may change while running as
+	// schedulers are added and removed.
+	mutex        sync.Mutex
+	running      bool
+	isLeader     bool
 	schedulers   []model.Scheduler
 	nextRunTimes []*time.Time
 }
@@ -34,6 +39,7 @@ func (srv *JobServer) InitSchedulers() *Schedulers {
 		configChanged:        make(chan *model.Config),
 		clusterLeaderChanged: make(chan bool),
 		jobs:                 srv,
+		isLeader:             true,
 	}
 
 	if srv.DataRetentionJob != nil {
@@ -60,6 +66,10 @@ func (srv *JobServer) InitSchedulers() *Schedulers {
 		schedulers.schedulers = append(schedulers.schedulers, pluginsInterface.MakeScheduler())
 	}
 
+	if postArchivalInterface := srv.PostArchival; postArchivalInterface != nil {
+		schedulers.schedulers = append(schedulers.schedulers, postArchivalInterface.MakeScheduler())
+	}
+
 	schedulers.nextRunTimes = make([]*time.Time, len(schedulers.schedulers))
 	return schedulers
 }
@@ -77,6 +87,8 @@ func (schedulers *Schedulers) Start() *Schedulers {
 			}()
 
 			now := time.Now()
+			schedulers.mutex.Lock()
+			schedulers.running = true
 			for idx, scheduler := range schedulers.schedulers {
 				if !scheduler.Enabled(schedulers.jobs.Config()) {
 					schedulers.nextRunTimes[idx] = nil
@@ -84,6 +96,7 @@ func (schedulers *Schedulers) Start() *Schedulers {
 					schedulers.setNextRunTime(schedulers.jobs.Config(), idx, now, false)
 				}
 			}
+			schedulers.mutex.Unlock()
 
 			for {
 				select {
@@ -93,6 +106,7 @@ func (schedulers *Schedulers) Start() *Schedulers {
 				case now = <-time.After(1 * time.Minute):
 					cfg := schedulers.jobs.Config()
 
+					schedulers.mutex.Lock()
 					for idx, nextTime := range schedulers.nextRunTimes {
 						if nextTime == nil {
 							continue
@@ -111,7 +125,9 @@ func (schedulers *Schedulers) Start() *Schedulers {
 							}
 						}
 					}
+					schedulers.mutex.Unlock()
 				case newCfg := <-schedulers.configChanged:
+					schedulers.mutex.Lock()
 					for idx, scheduler := range schedulers.schedulers {
 						if !scheduler.Enabled(newCfg) {
 							schedulers.nextRunTimes[idx] = nil
@@ -119,7 +135,10 @@ func (schedulers *Schedulers) Start() *Schedulers {
 							schedulers.setNextRunTime(newCfg, idx, now, false)
 						}
 					}
+					schedulers.mutex.Unlock()
 				case isLeader := <-schedulers.clusterLeaderChanged:
+					schedulers.mutex.Lock()
+					schedulers.isLeader = isLeader
 					for idx := range schedulers.schedulers {
 						if !isLeader {
 							schedulers.nextRunTimes[idx] = nil
@@ -127,6 +146,7 @@ func (schedulers *Schedulers) Start() *Schedulers {
 							schedulers.setNextRunTime(schedulers.jobs.Config(), idx, now, false)
 						}
 					}
+					schedulers.mutex.Unlock()
 				}
 			}
 		})
@@ -135,6 +155,52 @@ func (schedulers *Schedulers) Start() *Schedulers {
 	return schedulers
 }
 
+// AddScheduler registers a scheduler after the schedulers have been initialised, replacing any existing
+// scheduler with the same name. This allows schedulers to come and go at runtime, e.g. as plugins are
+// activated and deactivated.
+func (schedulers *Schedulers) AddScheduler(scheduler model.Scheduler) {
+	schedulers.mutex.Lock()
+	defer schedulers.mutex.Unlock()
+
+	idx := schedulers.indexOf(scheduler.Name())
+	if idx == -1 {
+		schedulers.schedulers = append(schedulers.schedulers, scheduler)
+		schedulers.nextRunTimes = append(schedulers.nextRunTimes, nil)
+		idx = len(schedulers.schedulers) - 1
+	} else {
+		schedulers.schedulers[idx] = scheduler
+		schedulers.nextRunTimes[idx] = nil
+	}
+
+	cfg := schedulers.jobs.Config()
+	if schedulers.running && schedulers.isLeader && scheduler.Enabled(cfg) {
+		schedulers.setNextRunTime(cfg, idx, time.Now(), false)
+	}
+}
+
+// RemoveScheduler unregisters the scheduler with the given name. Jobs it already scheduled still run.
+func (schedulers *Schedulers) RemoveScheduler(name string) {
+	schedulers.mutex.Lock()
+	defer schedulers.mutex.Unlock()
+
+	idx := schedulers.indexOf(name)
+	if idx == -1 {
+		return
+	}
+
+	schedulers.schedulers = append(schedulers.schedulers[:idx], schedulers.schedulers[idx+1:]...)
+	schedulers.nextRunTimes = append(schedulers.nextRunTimes[:idx], schedulers.nextRunTimes[idx+1:]...)
+}
+
+func (schedulers *Schedulers) indexOf(name string) int {
+	for idx, scheduler := range schedulers.schedulers {
+		if scheduler.Name() == name {
+			return idx
+		}
+	}
+	return -1
+}
+
 func (schedulers *Schedulers) Stop() *Schedulers {
 	mlog.Info("Stopping schedulers.")
 	close(schedulers.stop)
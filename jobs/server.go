@@ -24,6 +24,8 @@ type JobServer struct {
 	LdapSync                ejobs.LdapSyncInterface
 	Migrations              tjobs.MigrationsJobInterface
 	Plugins                 tjobs.PluginsJobInterface
+	PostArchival            tjobs.PostArchivalJobInterface
+	PostShardRebalance      tjobs.PostShardRebalanceJobInterface
 }
 
 func NewJobServer(configService configservice.ConfigService, store store.Store) *JobServer {
@@ -23,6 +23,8 @@ type Workers struct {
 	LdapSync                 model.Worker
 	Migrations               model.Worker
 	Plugins                  model.Worker
+	PostArchival             model.Worker
+	PostShardRebalance       model.Worker
 
 	listenerId string
 }
@@ -61,6 +63,14 @@ func (srv *JobServer) InitWorkers() *Workers {
 		workers.Plugins = pluginsInterface.MakeWorker()
 	}
 
+	if postArchivalInterface := srv.PostArchival; postArchivalInterface != nil {
+		workers.PostArchival = postArchivalInterface.MakeWorker()
+	}
+
+	if postShardRebalanceInterface := srv.PostShardRebalance; postShardRebalanceInterface != nil {
+		workers.PostShardRebalance = postShardRebalanceInterface.MakeWorker()
+	}
+
 	return workers
 }
 
@@ -96,6 +106,14 @@ func (workers *Workers) Start() *Workers {
 			go workers.Plugins.Run()
 		}
 
+		if workers.PostArchival != nil && postArchivalEnabled(workers.ConfigService.Config()) {
+			go workers.PostArchival.Run()
+		}
+
+		if workers.PostShardRebalance != nil && len(workers.ConfigService.Config().SqlSettings.PostShardDataSources) > 0 {
+			go workers.PostShardRebalance.Run()
+		}
+
 		go workers.Watcher.Start()
 	})
 
@@ -146,6 +164,22 @@ func (workers *Workers) handleConfigChange(oldConfig *model.Config, newConfig *m
 			workers.LdapSync.Stop()
 		}
 	}
+
+	if workers.PostArchival != nil {
+		if !postArchivalEnabled(oldConfig) && postArchivalEnabled(newConfig) {
+			go workers.PostArchival.Run()
+		} else if postArchivalEnabled(oldConfig) && !postArchivalEnabled(newConfig) {
+			workers.PostArchival.Stop()
+		}
+	}
+
+	if workers.PostShardRebalance != nil {
+		if len(oldConfig.SqlSettings.PostShardDataSources) == 0 && len(newConfig.SqlSettings.PostShardDataSources) > 0 {
+			go workers.PostShardRebalance.Run()
+		} else if len(oldConfig.SqlSettings.PostShardDataSources) > 0 && len(newConfig.SqlSettings.PostShardDataSources) == 0 {
+			workers.PostShardRebalance.Stop()
+		}
+	}
 }
 
 func (workers *Workers) Stop() *Workers {
@@ -181,7 +215,21 @@ func (workers *Workers) Stop() *Workers {
 		workers.Plugins.Stop()
 	}
 
+	if workers.PostArchival != nil && postArchivalEnabled(workers.ConfigService.Config()) {
+		workers.PostArchival.Stop()
+	}
+
+	if workers.PostShardRebalance != nil && len(workers.ConfigService.Config().SqlSettings.PostShardDataSources) > 0 {
+		workers.PostShardRebalance.Stop()
+	}
+
 	mlog.Info("Stopped workers")
 
 	return workers
 }
+
+// postArchivalEnabled returns whether the post archival job runs, which also applies the message
+// retention to the archived posts.
+func postArchivalEnabled(cfg *model.Config) bool {
+	return *cfg.DataRetentionSettings.EnableMessageArchival || *cfg.DataRetentionSettings.EnableMessageDeletion
+}
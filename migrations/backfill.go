@@ -0,0 +1,95 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package migrations
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type BackfillProgress struct {
+	LastKey []string `json:"last_key"`
+	Updated int64    `json:"updated"`
+	Total   int64    `json:"total"`
+}
+
+func (p *BackfillProgress) ToJson() string {
+	b, _ := json.Marshal(p)
+	return string(b)
+}
+
+func BackfillProgressFromJson(data io.Reader) *BackfillProgress {
+	var o *BackfillProgress
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+// Percent estimates how far the backfill is, never reaching 100 before it is done since rows may
+// be added while it runs.
+func (p *BackfillProgress) Percent() int64 {
+	if p.Total <= 0 {
+		return 0
+	}
+
+	percent := p.Updated * 100 / p.Total
+	if percent > 99 {
+		return 99
+	}
+	return percent
+}
+
+// MakeBackfillMigrationsList returns the keys of the migrations running the backfills declared by
+// the database migrations applied.
+func MakeBackfillMigrationsList(store store.Store) ([]string, *model.AppError) {
+	names, err := store.Backfills()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, len(names))
+	for i, name := range names {
+		keys[i] = model.MIGRATION_KEY_BACKFILL_PREFIX + name
+	}
+
+	return keys, nil
+}
+
+func (worker *Worker) runBackfillMigration(key string, lastDone string) (bool, string, *model.AppError) {
+	name := strings.TrimPrefix(key, model.MIGRATION_KEY_BACKFILL_PREFIX)
+
+	var progress *BackfillProgress
+	if len(lastDone) == 0 {
+		// Haven't started the migration yet.
+		total, err := worker.app.Srv.Store.CountBackfillRemaining(name)
+		if err != nil {
+			return false, "", err
+		}
+		progress = &BackfillProgress{Total: total}
+	} else {
+		progress = BackfillProgressFromJson(strings.NewReader(lastDone))
+		if progress == nil {
+			return false, "", model.NewAppError("MigrationsWorker.runBackfillMigration", "migrations.worker.run_backfill_migration.invalid_progress", map[string]interface{}{"progress": lastDone}, "", http.StatusInternalServerError)
+		}
+	}
+
+	lastKey, updated, err := worker.app.Srv.Store.RunBackfillBatch(name, progress.LastKey, *worker.app.Config().SqlSettings.MigrationBatchSize)
+	if err != nil {
+		return false, progress.ToJson(), err
+	}
+
+	if lastKey == nil {
+		// No rows were left to update.
+		return true, progress.ToJson(), nil
+	}
+
+	progress.LastKey = lastKey
+	progress.Updated += updated
+
+	return false, progress.ToJson(), nil
+}
@@ -4,6 +4,7 @@
 package migrations
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -99,3 +100,21 @@ func TestGetMigrationState(t *testing.T) {
 	assert.Equal(t, j3.Id, job.Id)
 	assert.Equal(t, "unscheduled", state)
 }
+
+func TestBackfillProgress(t *testing.T) {
+	progress := &BackfillProgress{LastKey: []string{"channel", "user"}, Updated: 50, Total: 200}
+	assert.Equal(t, int64(25), progress.Percent())
+
+	decoded := BackfillProgressFromJson(strings.NewReader(progress.ToJson()))
+	require.NotNil(t, decoded)
+	assert.Equal(t, progress, decoded)
+
+	// Rows added while the backfill runs may take it past the total counted when it started.
+	progress.Updated = 250
+	assert.Equal(t, int64(99), progress.Percent())
+
+	progress.Total = 0
+	assert.Equal(t, int64(0), progress.Percent())
+
+	assert.Nil(t, BackfillProgressFromJson(strings.NewReader("not json")))
+}
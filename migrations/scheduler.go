@@ -49,8 +49,14 @@ func (scheduler *Scheduler) NextScheduleTime(cfg *model.Config, now time.Time, p
 func (scheduler *Scheduler) ScheduleJob(cfg *model.Config, pendingJobs bool, lastSuccessfulJob *model.Job) (*model.Job, *model.AppError) {
 	mlog.Debug("Scheduling Job", mlog.String("scheduler", scheduler.Name()))
 
+	backfills, err := MakeBackfillMigrationsList(scheduler.App.Srv.Store)
+	if err != nil {
+		mlog.Error("Failed to list the backfill migrations: ", mlog.String("scheduler", scheduler.Name()), mlog.String("error", err.Error()))
+		return nil, nil
+	}
+
 	// Work through the list of migrations in order. Schedule the first one that isn't done (assuming it isn't in progress already).
-	for _, key := range MakeMigrationsList() {
+	for _, key := range append(MakeMigrationsList(), backfills...) {
 		state, job, err := GetMigrationState(key, scheduler.App.Srv.Store)
 		if err != nil {
 			mlog.Error("Failed to determine status of migration: ", mlog.String("scheduler", scheduler.Name()), mlog.String("migration_key", key), mlog.String("error", err.Error()))
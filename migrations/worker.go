@@ -6,6 +6,7 @@ package migrations
 import (
 	"context"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/mattermost/mattermost-server/app"
@@ -111,6 +112,11 @@ func (worker *Worker) DoJob(job *model.Job) {
 				return
 			} else {
 				job.Data[JOB_DATA_KEY_MIGRATION_LAST_DONE] = progress
+				if strings.HasPrefix(job.Data[JOB_DATA_KEY_MIGRATION], model.MIGRATION_KEY_BACKFILL_PREFIX) {
+					if backfillProgress := BackfillProgressFromJson(strings.NewReader(progress)); backfillProgress != nil {
+						job.Progress = backfillProgress.Percent()
+					}
+				}
 				if err := worker.app.Srv.Jobs.UpdateInProgressJobData(job); err != nil {
 					mlog.Error("Worker: Failed to update migration status data for job", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
 					worker.setJobError(job, err)
@@ -153,6 +159,11 @@ func (worker *Worker) runMigration(key string, lastDone string) (bool, string, *
 	case model.MIGRATION_KEY_ADVANCED_PERMISSIONS_PHASE_2:
 		done, progress, err = worker.runAdvancedPermissionsPhase2Migration(lastDone)
 	default:
+		if strings.HasPrefix(key, model.MIGRATION_KEY_BACKFILL_PREFIX) {
+			done, progress, err = worker.runBackfillMigration(key, lastDone)
+			break
+		}
+
 		return false, "", model.NewAppError("MigrationsWorker.runMigration", "migrations.worker.run_migration.unknown_key", map[string]interface{}{"key": key}, "", http.StatusInternalServerError)
 	}
 
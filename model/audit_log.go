@@ -0,0 +1,136 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"unicode/utf8"
+)
+
+const (
+	AUDIT_LOG_PHASE_INTENT  = "intent"
+	AUDIT_LOG_PHASE_OUTCOME = "outcome"
+
+	AUDIT_LOG_STATUS_SUCCESS = "success"
+	AUDIT_LOG_STATUS_FAILURE = "failure"
+
+	// AUDIT_LOG_DATA_MAX_BYTES is the size past which the params and diff of a record are truncated.
+	AUDIT_LOG_DATA_MAX_BYTES = 65535
+)
+
+// AuditLogRecord is an entry of the append-only log of the changes made through the store. Each
+// change is recorded before it is made, with the entity ids and params of the call, then once made,
+// with its outcome and the fields it changed. Each record holds the hash of the one before it, so
+// that editing or deleting records breaks the chain from there on. The hashes are keyed with a key
+// kept out of the database, if any, for the chain not to be rebuilt after editing records.
+type AuditLogRecord struct {
+	Sequence       int64  `json:"sequence"`
+	Id             string `json:"id"`
+	CreateAt       int64  `json:"create_at"`
+	Phase          string `json:"phase"`
+	IntentSequence int64  `json:"intent_sequence"`
+	UserId         string `json:"user_id"`
+	SessionId      string `json:"session_id"`
+	RequestId      string `json:"request_id"`
+	IpAddress      string `json:"ip_address"`
+	Method         string `json:"method"`
+	EntityIds      string `json:"entity_ids"`
+	Params         string `json:"params"`
+	Diff           string `json:"diff"`
+	Status         string `json:"status"`
+	Error          string `json:"error"`
+	PrevHash       string `json:"prev_hash"`
+	Hash           string `json:"hash"`
+}
+
+func (o *AuditLogRecord) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func AuditLogRecordFromJson(data io.Reader) *AuditLogRecord {
+	var o *AuditLogRecord
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func (o *AuditLogRecord) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+
+	o.Params = truncateAuditLogData(o.Params)
+	o.Diff = truncateAuditLogData(o.Diff)
+}
+
+// truncateAuditLogData cuts the data down to AUDIT_LOG_DATA_MAX_BYTES without splitting a rune.
+func truncateAuditLogData(data string) string {
+	if len(data) <= AUDIT_LOG_DATA_MAX_BYTES {
+		return data
+	}
+
+	cut := AUDIT_LOG_DATA_MAX_BYTES
+	for cut > 0 && !utf8.RuneStart(data[cut]) {
+		cut--
+	}
+	return data[:cut]
+}
+
+// ComputeHash returns the hash of every field of the record but Hash itself, PrevHash included,
+// as an HMAC with the given key unless it is empty.
+func (o *AuditLogRecord) ComputeHash(key []byte) string {
+	record := *o
+	record.Hash = ""
+
+	b, _ := json.Marshal(&record)
+	if len(key) == 0 {
+		sum := sha256.Sum256(b)
+		return hex.EncodeToString(sum[:])
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(b)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// AuditLogVerifier checks that the records of the audit log, passed to it in order from the first
+// one, form an unbroken chain, hashed with the given key. Records deleted from the end of the log
+// can't be detected by the chain alone, so the last sequence and hash should be kept somewhere else
+// to compare with later.
+type AuditLogVerifier struct {
+	Key          []byte
+	LastSequence int64
+	LastHash     string
+}
+
+func (v *AuditLogVerifier) Verify(record *AuditLogRecord) *AppError {
+	params := map[string]interface{}{"Sequence": record.Sequence}
+	details := "sequence=" + strconv.FormatInt(record.Sequence, 10)
+
+	if record.Sequence != v.LastSequence+1 {
+		return NewAppError("AuditLogVerifier.Verify", "model.audit_log.verify.sequence.app_error", params, details+", expected="+strconv.FormatInt(v.LastSequence+1, 10), http.StatusConflict)
+	}
+
+	if record.PrevHash != v.LastHash {
+		return NewAppError("AuditLogVerifier.Verify", "model.audit_log.verify.prev_hash.app_error", params, details, http.StatusConflict)
+	}
+
+	if record.Hash != record.ComputeHash(v.Key) {
+		return NewAppError("AuditLogVerifier.Verify", "model.audit_log.verify.hash.app_error", params, details, http.StatusConflict)
+	}
+
+	v.LastSequence = record.Sequence
+	v.LastHash = record.Hash
+	return nil
+}
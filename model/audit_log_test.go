@@ -0,0 +1,101 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLogRecordJson(t *testing.T) {
+	record := AuditLogRecord{Sequence: 1, Id: NewId(), Method: "ChannelStore.Update"}
+	result := AuditLogRecordFromJson(strings.NewReader(record.ToJson()))
+
+	assert.Equal(t, record, *result)
+}
+
+func TestAuditLogRecordPreSave(t *testing.T) {
+	record := AuditLogRecord{Params: strings.Repeat("é", AUDIT_LOG_DATA_MAX_BYTES)}
+	record.PreSave()
+
+	assert.NotEmpty(t, record.Id)
+	assert.NotZero(t, record.CreateAt)
+	assert.True(t, len(record.Params) <= AUDIT_LOG_DATA_MAX_BYTES)
+	assert.True(t, utf8.ValidString(record.Params))
+}
+
+func TestAuditLogVerifier(t *testing.T) {
+	key := []byte("key")
+	chain := func(key []byte, records ...*AuditLogRecord) {
+		prevHash := ""
+		for _, record := range records {
+			record.PrevHash = prevHash
+			record.Hash = record.ComputeHash(key)
+			prevHash = record.Hash
+		}
+	}
+
+	var records []*AuditLogRecord
+	for i := int64(1); i <= 3; i++ {
+		records = append(records, &AuditLogRecord{Sequence: i, Id: NewId(), Method: "PostStore.Save"})
+	}
+	chain(key, records...)
+
+	verify := func(records ...*AuditLogRecord) *AppError {
+		verifier := &AuditLogVerifier{Key: key}
+		for _, record := range records {
+			if err := verifier.Verify(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	t.Run("unbroken", func(t *testing.T) {
+		require.Nil(t, verify(records...))
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		err := verify(records[0], records[2])
+		require.NotNil(t, err)
+		assert.Equal(t, "model.audit_log.verify.sequence.app_error", err.Id)
+	})
+
+	t.Run("edited", func(t *testing.T) {
+		edited := *records[1]
+		edited.Method = "PostStore.Delete"
+
+		err := verify(records[0], &edited, records[2])
+		require.NotNil(t, err)
+		assert.Equal(t, "model.audit_log.verify.hash.app_error", err.Id)
+	})
+
+	t.Run("rehashed", func(t *testing.T) {
+		edited := *records[1]
+		edited.Method = "PostStore.Delete"
+		edited.Hash = edited.ComputeHash(key)
+
+		err := verify(records[0], &edited, records[2])
+		require.NotNil(t, err)
+		assert.Equal(t, "model.audit_log.verify.prev_hash.app_error", err.Id)
+	})
+
+	t.Run("rebuilt without the key", func(t *testing.T) {
+		rebuilt := make([]*AuditLogRecord, len(records))
+		for i, record := range records {
+			copied := *record
+			rebuilt[i] = &copied
+		}
+		rebuilt[1].Method = "PostStore.Delete"
+		chain(nil, rebuilt...)
+
+		err := verify(rebuilt...)
+		require.NotNil(t, err)
+		assert.Equal(t, "model.audit_log.verify.hash.app_error", err.Id)
+	})
+}
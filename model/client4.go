@@ -2504,6 +2504,46 @@ func (c *Client4) UnpinPost(postId string) (bool, *Response) {
 	return CheckStatusOK(r), BuildResponse(r)
 }
 
+// AcknowledgePost acknowledges a post that requested acknowledgement on behalf of the user.
+func (c *Client4) AcknowledgePost(userId, postId string) (*PostAcknowledgement, *Response) {
+	r, err := c.DoApiPost(c.GetUserRoute(userId)+c.GetPostRoute(postId)+"/ack", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostAcknowledgementFromJson(r.Body), BuildResponse(r)
+}
+
+// UnacknowledgePost removes the user's acknowledgement of a post.
+func (c *Client4) UnacknowledgePost(userId, postId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetUserRoute(userId) + c.GetPostRoute(postId) + "/ack")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// GetPostAcknowledgementStatus returns who has and hasn't acknowledged a post.
+func (c *Client4) GetPostAcknowledgementStatus(postId string) (*PostAcknowledgementStatus, *Response) {
+	r, err := c.DoApiGet(c.GetPostRoute(postId)+"/ack", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostAcknowledgementStatusFromJson(r.Body), BuildResponse(r)
+}
+
+// GetPostReadReceipts returns the members of a direct or group message channel that have read up to a post.
+func (c *Client4) GetPostReadReceipts(postId string) (*PostReadReceipts, *Response) {
+	r, err := c.DoApiGet(c.GetPostRoute(postId)+"/read_receipts", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostReadReceiptsFromJson(r.Body), BuildResponse(r)
+}
+
 // GetPost gets a single post.
 func (c *Client4) GetPost(postId string, etag string) (*Post, *Response) {
 	r, err := c.DoApiGet(c.GetPostRoute(postId), etag)
@@ -3012,6 +3052,40 @@ func (c *Client4) InvalidateCaches() (bool, *Response) {
 	return CheckStatusOK(r), BuildResponse(r)
 }
 
+// GetConfigHistory will return a page of the saved configuration revisions, newest first. Only
+// supported when the configuration is stored in the database.
+func (c *Client4) GetConfigHistory(page, perPage int) ([]*ConfigRevision, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoApiGet(c.GetConfigRoute()+"/history"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ConfigRevisionListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetConfigDiff will compare the configurations saved by two revisions. If toRevisionId is empty,
+// the first revision is compared against the current configuration.
+func (c *Client4) GetConfigDiff(fromRevisionId, toRevisionId string) (ConfigDiffs, *Response) {
+	query := fmt.Sprintf("?from=%v&to=%v", fromRevisionId, toRevisionId)
+	r, err := c.DoApiGet(c.GetConfigRoute()+"/diff"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ConfigDiffsFromJson(r.Body), BuildResponse(r)
+}
+
+// RollbackConfig will replace the server configuration with the one saved by the given revision.
+func (c *Client4) RollbackConfig(revisionId string) (bool, *Response) {
+	r, err := c.DoApiPost(c.GetConfigRoute()+"/history/"+revisionId+"/rollback", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
 // UpdateConfig will update the server configuration.
 func (c *Client4) UpdateConfig(config *Config) (*Config, *Response) {
 	r, err := c.DoApiPut(c.GetConfigRoute(), config.ToJson())
@@ -3142,6 +3216,18 @@ func (c *Client4) GetIncomingWebhook(hookID string, etag string) (*IncomingWebho
 	return IncomingWebhookFromJson(r.Body), BuildResponse(r)
 }
 
+// PreviewIncomingWebhook renders a sample payload through an incoming webhook and returns the
+// request that would have been posted, without posting it.
+func (c *Client4) PreviewIncomingWebhook(hookID string, preview *IncomingWebhookPreview) (*IncomingWebhookRequest, *Response) {
+	r, err := c.DoApiPost(c.GetIncomingWebhookRoute(hookID)+"/preview", preview.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	request, _ := IncomingWebhookRequestFromJson(r.Body)
+	return request, BuildResponse(r)
+}
+
 // DeleteIncomingWebhook deletes and Incoming Webhook given the hook ID.
 func (c *Client4) DeleteIncomingWebhook(hookID string) (bool, *Response) {
 	r, err := c.DoApiDelete(c.GetIncomingWebhookRoute(hookID))
@@ -4011,6 +4097,36 @@ func (c *Client4) UpdateUserStatus(userId string, userStatus *Status) (*Status,
 	return StatusFromJson(r.Body), BuildResponse(r)
 }
 
+// GetUserCustomStatus returns the custom status of a user.
+func (c *Client4) GetUserCustomStatus(userId string) (*CustomStatus, *Response) {
+	r, err := c.DoApiGet(c.GetUserStatusRoute(userId)+"/custom", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CustomStatusFromJson(r.Body), BuildResponse(r)
+}
+
+// UpdateUserCustomStatus sets a user's custom status to the given emoji, text and expiry time.
+func (c *Client4) UpdateUserCustomStatus(userId string, customStatus *CustomStatus) (*CustomStatus, *Response) {
+	r, err := c.DoApiPut(c.GetUserStatusRoute(userId)+"/custom", customStatus.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CustomStatusFromJson(r.Body), BuildResponse(r)
+}
+
+// RemoveUserCustomStatus clears a user's custom status.
+func (c *Client4) RemoveUserCustomStatus(userId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetUserStatusRoute(userId) + "/custom")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
 // Emoji Section
 
 // CreateEmoji will save an emoji to the server if the current user has permission
@@ -4441,6 +4557,39 @@ func (c *Client4) InstallPluginFromUrl(downloadUrl string, force bool) (*Manifes
 	return ManifestFromJson(r.Body), BuildResponse(r)
 }
 
+// GetMarketplacePlugins will return the plugins listed in the configured marketplace catalog.
+// WARNING: PLUGINS ARE STILL EXPERIMENTAL. THIS FUNCTION IS SUBJECT TO CHANGE.
+func (c *Client4) GetMarketplacePlugins() ([]*MarketplacePlugin, *Response) {
+	r, err := c.DoApiGet(c.GetPluginsRoute()+"/marketplace", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return MarketplacePluginsFromJson(r.Body), BuildResponse(r)
+}
+
+// InstallMarketplacePlugin will download, verify and install a plugin from the marketplace catalog.
+// WARNING: PLUGINS ARE STILL EXPERIMENTAL. THIS FUNCTION IS SUBJECT TO CHANGE.
+func (c *Client4) InstallMarketplacePlugin(request *MarketplacePluginRequest) (*Manifest, *Response) {
+	r, err := c.DoApiPost(c.GetPluginsRoute()+"/marketplace", request.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ManifestFromJson(r.Body), BuildResponse(r)
+}
+
+// UpgradeMarketplacePlugin will replace an installed plugin with a newer version from the marketplace catalog.
+// WARNING: PLUGINS ARE STILL EXPERIMENTAL. THIS FUNCTION IS SUBJECT TO CHANGE.
+func (c *Client4) UpgradeMarketplacePlugin(request *MarketplacePluginRequest) (*Manifest, *Response) {
+	r, err := c.DoApiPost(c.GetPluginsRoute()+"/marketplace/upgrade", request.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ManifestFromJson(r.Body), BuildResponse(r)
+}
+
 // GetPlugins will return a list of plugin manifests for currently active plugins.
 // WARNING: PLUGINS ARE STILL EXPERIMENTAL. THIS FUNCTION IS SUBJECT TO CHANGE.
 func (c *Client4) GetPlugins() (*PluginsResponse, *Response) {
@@ -4508,6 +4657,29 @@ func (c *Client4) DisablePlugin(id string) (bool, *Response) {
 	return CheckStatusOK(r), BuildResponse(r)
 }
 
+// GetPluginConfigRevisions will return a page of the plugin's configuration revisions, newest first.
+// WARNING: PLUGINS ARE STILL EXPERIMENTAL. THIS FUNCTION IS SUBJECT TO CHANGE.
+func (c *Client4) GetPluginConfigRevisions(pluginId string, page, perPage int) ([]*PluginConfigRevision, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoApiGet(c.GetPluginRoute(pluginId)+"/config/revisions"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PluginConfigRevisionListFromJson(r.Body), BuildResponse(r)
+}
+
+// RollbackPluginConfig will restore the plugin's settings to those saved by the given revision.
+// WARNING: PLUGINS ARE STILL EXPERIMENTAL. THIS FUNCTION IS SUBJECT TO CHANGE.
+func (c *Client4) RollbackPluginConfig(pluginId, revisionId string) (bool, *Response) {
+	r, err := c.DoApiPost(c.GetPluginRoute(pluginId)+"/config/revisions/"+revisionId+"/rollback", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
 // UpdateChannelScheme will update a channel's scheme.
 func (c *Client4) UpdateChannelScheme(channelId, schemeId string) (bool, *Response) {
 	sip := &SchemeIDPatch{SchemeID: &schemeId}
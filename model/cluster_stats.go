@@ -9,10 +9,22 @@ import (
 )
 
 type ClusterStats struct {
-	Id                        string `json:"id"`
-	TotalWebsocketConnections int    `json:"total_websocket_connections"`
-	TotalReadDbConnections    int    `json:"total_read_db_connections"`
-	TotalMasterDbConnections  int    `json:"total_master_db_connections"`
+	Id                        string           `json:"id"`
+	TotalWebsocketConnections int              `json:"total_websocket_connections"`
+	TotalReadDbConnections    int              `json:"total_read_db_connections"`
+	TotalMasterDbConnections  int              `json:"total_master_db_connections"`
+	ReplicaStatuses           []*ReplicaStatus `json:"replica_statuses,omitempty"`
+}
+
+// ReplicaStatus describes the health of a read replica as last measured by the server. Replicas
+// lagging too far behind the master are not used for reads until they catch up. Error is set when
+// the lag could not be measured, in which case the replica is still used.
+type ReplicaStatus struct {
+	Name        string  `json:"name"`
+	Healthy     bool    `json:"healthy"`
+	LagSeconds  float64 `json:"lag_seconds"`
+	Error       string  `json:"error,omitempty"`
+	LastCheckAt int64   `json:"last_check_at"`
 }
 
 func (me *ClusterStats) ToJson() string {
@@ -17,3 +17,25 @@ func TestClusterStatsJson(t *testing.T) {
 		t.Fatal("Ids do not match")
 	}
 }
+
+func TestClusterStatsReplicaStatusesJson(t *testing.T) {
+	cluster := ClusterStats{
+		Id: NewId(),
+		ReplicaStatuses: []*ReplicaStatus{
+			{Name: "replica-0", Healthy: true, LagSeconds: 0.5},
+			{Name: "replica-1", Healthy: false, LagSeconds: 42, Error: "lagging"},
+		},
+	}
+	result := ClusterStatsFromJson(strings.NewReader(cluster.ToJson()))
+
+	if len(result.ReplicaStatuses) != 2 {
+		t.Fatal("replica statuses were not preserved")
+	}
+	if *result.ReplicaStatuses[1] != *cluster.ReplicaStatuses[1] {
+		t.Fatal("replica statuses do not match")
+	}
+
+	if strings.Contains((&ClusterStats{Id: NewId()}).ToJson(), "replica_statuses") {
+		t.Fatal("replica statuses should be omitted when there are no replicas")
+	}
+}
@@ -38,6 +38,10 @@ type CompliancePost struct {
 	PostHashtags   string
 	PostFileIds    string
 
+	// From PostsPriority and PostAcknowledgements
+	PostPriority         string
+	PostAcknowledgements string
+
 	IsBot bool
 }
 
@@ -67,6 +71,8 @@ func CompliancePostHeader() []string {
 		"PostHashtags",
 		"PostFileIds",
 		"UserType",
+		"PostPriority",
+		"PostAcknowledgements",
 	}
 }
 
@@ -122,5 +128,7 @@ func (me *CompliancePost) Row() []string {
 		me.PostProps,
 		me.PostHashtags,
 		me.PostFileIds,
+		me.PostPriority,
+		cleanComplianceStrings(me.PostAcknowledgements),
 	}
 }
@@ -14,11 +14,14 @@ func TestCompliancePostHeader(t *testing.T) {
 }
 
 func TestCompliancePost(t *testing.T) {
-	o := CompliancePost{TeamName: "test", PostFileIds: "files", PostCreateAt: GetMillis()}
+	o := CompliancePost{TeamName: "test", PostFileIds: "files", PostCreateAt: GetMillis(), PostPriority: POST_PRIORITY_URGENT, PostAcknowledgements: "user1"}
 	r := o.Row()
 
 	require.Equal(t, "test", r[0])
-	require.Equal(t, "files", r[len(r)-1])
+	require.Equal(t, "files", r[len(r)-3])
+	require.Equal(t, POST_PRIORITY_URGENT, r[len(r)-2])
+	require.Equal(t, "user1", r[len(r)-1])
+	require.Len(t, r, len(CompliancePostHeader()))
 }
 
 var cleanTests = []struct {
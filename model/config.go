@@ -104,7 +104,9 @@ const (
 	TEAM_SETTINGS_DEFAULT_CUSTOM_DESCRIPTION_TEXT  = ""
 	TEAM_SETTINGS_DEFAULT_USER_STATUS_AWAY_TIMEOUT = 300
 
-	SQL_SETTINGS_DEFAULT_DATA_SOURCE = "mmuser:mostest@tcp(localhost:3306)/mattermost_test?charset=utf8mb4,utf8&readTimeout=30s&writeTimeout=30s"
+	SQL_SETTINGS_DEFAULT_DATA_SOURCE                   = "mmuser:mostest@tcp(localhost:3306)/mattermost_test?charset=utf8mb4,utf8&readTimeout=30s&writeTimeout=30s"
+	SQL_SETTINGS_DEFAULT_REPLICA_LAG_THRESHOLD_SECONDS = 10
+	SQL_SETTINGS_DEFAULT_MIGRATION_BATCH_SIZE          = 1000
 
 	FILE_SETTINGS_DEFAULT_DIRECTORY = "./data/"
 
@@ -170,9 +172,12 @@ const (
 	DATA_RETENTION_SETTINGS_DEFAULT_MESSAGE_RETENTION_DAYS  = 365
 	DATA_RETENTION_SETTINGS_DEFAULT_FILE_RETENTION_DAYS     = 365
 	DATA_RETENTION_SETTINGS_DEFAULT_DELETION_JOB_START_TIME = "02:00"
+	DATA_RETENTION_SETTINGS_DEFAULT_MESSAGE_ARCHIVAL_MONTHS = 12
+	DATA_RETENTION_SETTINGS_DEFAULT_ARCHIVAL_JOB_START_TIME = "01:00"
 
 	PLUGIN_SETTINGS_DEFAULT_DIRECTORY        = "./plugins"
 	PLUGIN_SETTINGS_DEFAULT_CLIENT_DIRECTORY = "./client/plugins"
+	PLUGIN_SETTINGS_MAX_CPU_PERCENT          = 100
 
 	COMPLIANCE_EXPORT_TYPE_CSV             = "csv"
 	COMPLIANCE_EXPORT_TYPE_ACTIANCE        = "actiance"
@@ -187,6 +192,11 @@ const (
 	IMAGE_PROXY_TYPE_LOCAL      = "local"
 	IMAGE_PROXY_TYPE_ATMOS_CAMO = "atmos/camo"
 
+	CACHE_TYPE_LRU   = "lru"
+	CACHE_TYPE_REDIS = "redis"
+
+	CACHE_SETTINGS_DEFAULT_REDIS_ADDRESS = "localhost:6379"
+
 	GOOGLE_SETTINGS_DEFAULT_SCOPE             = "profile email"
 	GOOGLE_SETTINGS_DEFAULT_AUTH_ENDPOINT     = "https://accounts.google.com/o/oauth2/v2/auth"
 	GOOGLE_SETTINGS_DEFAULT_TOKEN_ENDPOINT    = "https://www.googleapis.com/oauth2/v4/token"
@@ -308,6 +318,10 @@ type ServiceSettings struct {
 	DisableBotsWhenOwnerIsDeactivated                 *bool `restricted:"true"`
 	EnableBotAccountCreation                          *bool
 	EnableSVGs                                        *bool
+	EnableUrgentPostPriority                          *bool
+	EnableReadReceipts                                *bool
+	PersistentNotificationIntervalMinutes             *int
+	PersistentNotificationMaxCount                    *int
 }
 
 func (s *ServiceSettings) SetDefaults(isUpdate bool) {
@@ -676,6 +690,22 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 			s.EnableSVGs = NewBool(false)
 		}
 	}
+
+	if s.EnableUrgentPostPriority == nil {
+		s.EnableUrgentPostPriority = NewBool(false)
+	}
+
+	if s.EnableReadReceipts == nil {
+		s.EnableReadReceipts = NewBool(true)
+	}
+
+	if s.PersistentNotificationIntervalMinutes == nil {
+		s.PersistentNotificationIntervalMinutes = NewInt(5)
+	}
+
+	if s.PersistentNotificationMaxCount == nil {
+		s.PersistentNotificationMaxCount = NewInt(6)
+	}
 }
 
 type ClusterSettings struct {
@@ -853,6 +883,12 @@ func (s *SSOSettings) setDefaults(scope, authEndpoint, tokenEndpoint, userApiEnd
 	}
 }
 
+// postShardNamePattern matches the names of post shards, which are recorded for each channel.
+var postShardNamePattern = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+// storeMethodNamePattern matches the names store methods are reported by, such as PostStore.Search.
+var storeMethodNamePattern = regexp.MustCompile(`^[A-Za-z]+Store\.[A-Za-z]+$`)
+
 type SqlSettings struct {
 	DriverName                  *string  `restricted:"true"`
 	DataSource                  *string  `restricted:"true"`
@@ -864,6 +900,19 @@ type SqlSettings struct {
 	Trace                       *bool    `restricted:"true"`
 	AtRestEncryptKey            *string  `restricted:"true"`
 	QueryTimeout                *int     `restricted:"true"`
+
+	ReplicaLagThresholdSeconds     *int `restricted:"true"`
+	ReplicaLagCheckIntervalSeconds *int `restricted:"true"`
+	StickyMasterAfterWriteSeconds  *int `restricted:"true"`
+
+	OnlineMigrations   *bool `restricted:"true"`
+	MigrationBatchSize *int  `restricted:"true"`
+
+	SlowQueryThresholdMilliseconds *int           `restricted:"true"`
+	QueryTimeoutOverrides          map[string]int `restricted:"true"`
+
+	PostShardDataSources map[string]string `restricted:"true"`
+	PostShardKey         *string           `restricted:"true"`
 }
 
 func (s *SqlSettings) SetDefaults(isUpdate bool) {
@@ -912,6 +961,42 @@ func (s *SqlSettings) SetDefaults(isUpdate bool) {
 	if s.QueryTimeout == nil {
 		s.QueryTimeout = NewInt(30)
 	}
+
+	if s.ReplicaLagThresholdSeconds == nil {
+		s.ReplicaLagThresholdSeconds = NewInt(SQL_SETTINGS_DEFAULT_REPLICA_LAG_THRESHOLD_SECONDS)
+	}
+
+	if s.ReplicaLagCheckIntervalSeconds == nil {
+		s.ReplicaLagCheckIntervalSeconds = NewInt(5)
+	}
+
+	if s.StickyMasterAfterWriteSeconds == nil {
+		s.StickyMasterAfterWriteSeconds = NewInt(5)
+	}
+
+	if s.OnlineMigrations == nil {
+		s.OnlineMigrations = NewBool(false)
+	}
+
+	if s.MigrationBatchSize == nil {
+		s.MigrationBatchSize = NewInt(SQL_SETTINGS_DEFAULT_MIGRATION_BATCH_SIZE)
+	}
+
+	if s.SlowQueryThresholdMilliseconds == nil {
+		s.SlowQueryThresholdMilliseconds = NewInt(0)
+	}
+
+	if s.QueryTimeoutOverrides == nil {
+		s.QueryTimeoutOverrides = map[string]int{}
+	}
+
+	if s.PostShardDataSources == nil {
+		s.PostShardDataSources = map[string]string{}
+	}
+
+	if s.PostShardKey == nil {
+		s.PostShardKey = NewString(POST_SHARD_KEY_CHANNEL)
+	}
 }
 
 type LogSettings struct {
@@ -1830,9 +1915,11 @@ func (s *LdapSettings) SetDefaults() {
 }
 
 type ComplianceSettings struct {
-	Enable      *bool
-	Directory   *string
-	EnableDaily *bool
+	Enable          *bool
+	Directory       *string
+	EnableDaily     *bool
+	EnableAuditLog  *bool   `restricted:"true"`
+	AuditLogKeyFile *string `restricted:"true"`
 }
 
 func (s *ComplianceSettings) SetDefaults() {
@@ -1847,6 +1934,14 @@ func (s *ComplianceSettings) SetDefaults() {
 	if s.EnableDaily == nil {
 		s.EnableDaily = NewBool(false)
 	}
+
+	if s.EnableAuditLog == nil {
+		s.EnableAuditLog = NewBool(false)
+	}
+
+	if s.AuditLogKeyFile == nil {
+		s.AuditLogKeyFile = NewString("")
+	}
 }
 
 type LocalizationSettings struct {
@@ -2149,6 +2244,9 @@ type DataRetentionSettings struct {
 	MessageRetentionDays  *int
 	FileRetentionDays     *int
 	DeletionJobStartTime  *string
+	EnableMessageArchival *bool
+	MessageArchivalMonths *int
+	ArchivalJobStartTime  *string
 }
 
 func (s *DataRetentionSettings) SetDefaults() {
@@ -2171,6 +2269,18 @@ func (s *DataRetentionSettings) SetDefaults() {
 	if s.DeletionJobStartTime == nil {
 		s.DeletionJobStartTime = NewString(DATA_RETENTION_SETTINGS_DEFAULT_DELETION_JOB_START_TIME)
 	}
+
+	if s.EnableMessageArchival == nil {
+		s.EnableMessageArchival = NewBool(false)
+	}
+
+	if s.MessageArchivalMonths == nil {
+		s.MessageArchivalMonths = NewInt(DATA_RETENTION_SETTINGS_DEFAULT_MESSAGE_ARCHIVAL_MONTHS)
+	}
+
+	if s.ArchivalJobStartTime == nil {
+		s.ArchivalJobStartTime = NewString(DATA_RETENTION_SETTINGS_DEFAULT_ARCHIVAL_JOB_START_TIME)
+	}
 }
 
 type JobSettings struct {
@@ -2192,6 +2302,15 @@ type PluginState struct {
 	Enable bool
 }
 
+// PluginLimitSettings overrides the resource limits of PluginSettings for a single plugin. Limits
+// left unset fall back to those of PluginSettings.
+type PluginLimitSettings struct {
+	MaxMemoryMB        *int
+	MaxCPUPercent      *int
+	HookTimeoutSeconds *int
+	MaxConcurrentHooks *int
+}
+
 type PluginSettings struct {
 	Enable                   *bool
 	EnableUploads            *bool   `restricted:"true"`
@@ -2201,6 +2320,13 @@ type PluginSettings struct {
 	ClientDirectory          *string `restricted:"true"`
 	Plugins                  map[string]map[string]interface{}
 	PluginStates             map[string]*PluginState
+	EnableMarketplace        *bool                           `restricted:"true"`
+	MarketplaceUrl           *string                         `restricted:"true"`
+	MaxMemoryMB              *int                            `restricted:"true"`
+	MaxCPUPercent            *int                            `restricted:"true"`
+	HookTimeoutSeconds       *int                            `restricted:"true"`
+	MaxConcurrentHooks       *int                            `restricted:"true"`
+	PluginLimits             map[string]*PluginLimitSettings `restricted:"true"`
 }
 
 func (s *PluginSettings) SetDefaults(ls LogSettings) {
@@ -2244,6 +2370,34 @@ func (s *PluginSettings) SetDefaults(ls LogSettings) {
 		s.PluginStates = make(map[string]*PluginState)
 	}
 
+	if s.PluginLimits == nil {
+		s.PluginLimits = make(map[string]*PluginLimitSettings)
+	}
+
+	if s.EnableMarketplace == nil {
+		s.EnableMarketplace = NewBool(false)
+	}
+
+	if s.MarketplaceUrl == nil {
+		s.MarketplaceUrl = NewString("")
+	}
+
+	if s.MaxMemoryMB == nil {
+		s.MaxMemoryMB = NewInt(0)
+	}
+
+	if s.MaxCPUPercent == nil {
+		s.MaxCPUPercent = NewInt(0)
+	}
+
+	if s.HookTimeoutSeconds == nil {
+		s.HookTimeoutSeconds = NewInt(0)
+	}
+
+	if s.MaxConcurrentHooks == nil {
+		s.MaxConcurrentHooks = NewInt(0)
+	}
+
 	if s.PluginStates["com.mattermost.nps"] == nil {
 		// Enable the NPS plugin by default if diagnostics are enabled
 		s.PluginStates["com.mattermost.nps"] = &PluginState{Enable: ls.EnableDiagnostics == nil || *ls.EnableDiagnostics}
@@ -2392,6 +2546,72 @@ func (ips *ImageProxySettings) SetDefaults(ss ServiceSettings) {
 	}
 }
 
+type CacheSettings struct {
+	CacheType     *string `restricted:"true"`
+	RedisAddress  *string `restricted:"true"`
+	RedisPassword *string `restricted:"true"`
+	RedisDB       *int    `restricted:"true"`
+}
+
+func (s *CacheSettings) SetDefaults() {
+	if s.CacheType == nil {
+		s.CacheType = NewString(CACHE_TYPE_LRU)
+	}
+
+	if s.RedisAddress == nil {
+		s.RedisAddress = NewString(CACHE_SETTINGS_DEFAULT_REDIS_ADDRESS)
+	}
+
+	if s.RedisPassword == nil {
+		s.RedisPassword = NewString("")
+	}
+
+	if s.RedisDB == nil {
+		s.RedisDB = NewInt(0)
+	}
+}
+
+type TracingSettings struct {
+	Enable         *bool    `restricted:"true"`
+	ExportEndpoint *string  `restricted:"true"`
+	ExportFile     *string  `restricted:"true"`
+	SampleRate     *float64 `restricted:"true"`
+}
+
+func (s *TracingSettings) SetDefaults() {
+	if s.Enable == nil {
+		s.Enable = NewBool(false)
+	}
+
+	if s.ExportEndpoint == nil {
+		s.ExportEndpoint = NewString("")
+	}
+
+	if s.ExportFile == nil {
+		s.ExportFile = NewString("")
+	}
+
+	if s.SampleRate == nil {
+		s.SampleRate = NewFloat64(1)
+	}
+}
+
+func (s *TracingSettings) isValid() *AppError {
+	if *s.SampleRate < 0 || *s.SampleRate > 1 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.tracing_sample_rate.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.ExportEndpoint != "" && !IsValidHttpUrl(*s.ExportEndpoint) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.tracing_export_endpoint.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.Enable && *s.ExportEndpoint == "" && *s.ExportFile == "" {
+		return NewAppError("Config.IsValid", "model.config.is_valid.tracing_export.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
 type ConfigFunc func() *Config
 
 type Config struct {
@@ -2429,6 +2649,8 @@ type Config struct {
 	DisplaySettings         DisplaySettings
 	GuestAccountsSettings   GuestAccountsSettings
 	ImageProxySettings      ImageProxySettings
+	CacheSettings           CacheSettings
+	TracingSettings         TracingSettings
 }
 
 func (o *Config) Clone() *Config {
@@ -2513,6 +2735,8 @@ func (o *Config) SetDefaults() {
 	o.DisplaySettings.SetDefaults()
 	o.GuestAccountsSettings.SetDefaults()
 	o.ImageProxySettings.SetDefaults(o.ServiceSettings)
+	o.CacheSettings.SetDefaults()
+	o.TracingSettings.SetDefaults()
 }
 
 func (o *Config) IsValid() *AppError {
@@ -2588,6 +2812,18 @@ func (o *Config) IsValid() *AppError {
 		return err
 	}
 
+	if err := o.CacheSettings.isValid(); err != nil {
+		return err
+	}
+
+	if err := o.TracingSettings.isValid(); err != nil {
+		return err
+	}
+
+	if err := o.PluginSettings.isValid(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -2628,7 +2864,7 @@ func (ss *SqlSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.encrypt_sql.app_error", nil, "", http.StatusBadRequest)
 	}
 
-	if !(*ss.DriverName == DATABASE_DRIVER_MYSQL || *ss.DriverName == DATABASE_DRIVER_POSTGRES) {
+	if !(*ss.DriverName == DATABASE_DRIVER_MYSQL || *ss.DriverName == DATABASE_DRIVER_POSTGRES || *ss.DriverName == DATABASE_DRIVER_SQLITE) {
 		return NewAppError("Config.IsValid", "model.config.is_valid.sql_driver.app_error", nil, "", http.StatusBadRequest)
 	}
 
@@ -2652,6 +2888,42 @@ func (ss *SqlSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.sql_max_conn.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *ss.ReplicaLagThresholdSeconds <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.sql_replica_lag_threshold.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *ss.ReplicaLagCheckIntervalSeconds < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.sql_replica_lag_check_interval.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *ss.StickyMasterAfterWriteSeconds < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.sql_sticky_master_after_write.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *ss.MigrationBatchSize <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.sql_migration_batch_size.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *ss.SlowQueryThresholdMilliseconds < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.sql_slow_query_threshold.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for method, timeout := range ss.QueryTimeoutOverrides {
+		if !storeMethodNamePattern.MatchString(method) || timeout <= 0 {
+			return NewAppError("Config.IsValid", "model.config.is_valid.sql_query_timeout_override.app_error", map[string]interface{}{"Method": method}, "", http.StatusBadRequest)
+		}
+	}
+
+	for name, dataSource := range ss.PostShardDataSources {
+		if !postShardNamePattern.MatchString(name) || name == POST_SHARD_MAIN || dataSource == "" {
+			return NewAppError("Config.IsValid", "model.config.is_valid.sql_post_shard.app_error", map[string]interface{}{"Name": name}, "", http.StatusBadRequest)
+		}
+	}
+
+	if *ss.PostShardKey != POST_SHARD_KEY_CHANNEL && *ss.PostShardKey != POST_SHARD_KEY_TEAM {
+		return NewAppError("Config.IsValid", "model.config.is_valid.sql_post_shard_key.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -2878,6 +3150,14 @@ func (ss *ServiceSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.group_unread_channels.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *ss.PersistentNotificationIntervalMinutes < 1 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.persistent_notification_interval.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *ss.PersistentNotificationMaxCount < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.persistent_notification_max_count.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -2932,6 +3212,14 @@ func (drs *DataRetentionSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.deletion_job_start_time.app_error", nil, err.Error(), http.StatusBadRequest)
 	}
 
+	if *drs.MessageArchivalMonths <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.message_archival_months_too_low.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if _, err := time.Parse("15:04", *drs.ArchivalJobStartTime); err != nil {
+		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.archival_job_start_time.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -3001,6 +3289,50 @@ func (ds *DisplaySettings) isValid() *AppError {
 	return nil
 }
 
+func (s *PluginSettings) isValid() *AppError {
+	if *s.EnableMarketplace && !IsValidHttpUrl(*s.MarketplaceUrl) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.marketplace_url.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if err := isValidPluginLimits(s.MaxMemoryMB, s.MaxCPUPercent, s.HookTimeoutSeconds, s.MaxConcurrentHooks); err != nil {
+		return err
+	}
+
+	for pluginId, limits := range s.PluginLimits {
+		if limits == nil {
+			continue
+		}
+
+		if err := isValidPluginLimits(limits.MaxMemoryMB, limits.MaxCPUPercent, limits.HookTimeoutSeconds, limits.MaxConcurrentHooks); err != nil {
+			err.DetailedError = "plugin_id=" + pluginId
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isValidPluginLimits checks the plugin resource limits that are set.
+func isValidPluginLimits(maxMemoryMB, maxCPUPercent, hookTimeoutSeconds, maxConcurrentHooks *int) *AppError {
+	if maxMemoryMB != nil && *maxMemoryMB < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.plugin_max_memory.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if maxCPUPercent != nil && (*maxCPUPercent < 0 || *maxCPUPercent > PLUGIN_SETTINGS_MAX_CPU_PERCENT) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.plugin_max_cpu.app_error", map[string]interface{}{"Max": PLUGIN_SETTINGS_MAX_CPU_PERCENT}, "", http.StatusBadRequest)
+	}
+
+	if hookTimeoutSeconds != nil && *hookTimeoutSeconds < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.plugin_hook_timeout.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if maxConcurrentHooks != nil && *maxConcurrentHooks < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.plugin_max_concurrent_hooks.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
 func (ips *ImageProxySettings) isValid() *AppError {
 	if *ips.Enable {
 		switch *ips.ImageProxyType {
@@ -3022,6 +3354,25 @@ func (ips *ImageProxySettings) isValid() *AppError {
 	return nil
 }
 
+func (s *CacheSettings) isValid() *AppError {
+	switch *s.CacheType {
+	case CACHE_TYPE_LRU:
+		// No other settings to validate
+	case CACHE_TYPE_REDIS:
+		if *s.RedisAddress == "" {
+			return NewAppError("Config.IsValid", "model.config.is_valid.cache_redis_address.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		if *s.RedisDB < 0 {
+			return NewAppError("Config.IsValid", "model.config.is_valid.cache_redis_db.app_error", nil, "", http.StatusBadRequest)
+		}
+	default:
+		return NewAppError("Config.IsValid", "model.config.is_valid.cache_type.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
 func (o *Config) GetSanitizeOptions() map[string]bool {
 	options := map[string]bool{}
 	options["fullname"] = *o.PrivacySettings.ShowFullName
@@ -3054,6 +3405,10 @@ func (o *Config) Sanitize() {
 
 	*o.ElasticsearchSettings.Password = FAKE_SETTING
 
+	if len(*o.CacheSettings.RedisPassword) > 0 {
+		*o.CacheSettings.RedisPassword = FAKE_SETTING
+	}
+
 	for i := range o.SqlSettings.DataSourceReplicas {
 		o.SqlSettings.DataSourceReplicas[i] = FAKE_SETTING
 	}
@@ -3061,4 +3416,8 @@ func (o *Config) Sanitize() {
 	for i := range o.SqlSettings.DataSourceSearchReplicas {
 		o.SqlSettings.DataSourceSearchReplicas[i] = FAKE_SETTING
 	}
+
+	for name := range o.SqlSettings.PostShardDataSources {
+		o.SqlSettings.PostShardDataSources[name] = FAKE_SETTING
+	}
 }
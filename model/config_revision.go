@@ -0,0 +1,53 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// ConfigRevision describes a configuration saved to a config store that keeps history. UserId is
+// empty for configurations not saved by a user, such as those saved at startup or from the
+// command line.
+type ConfigRevision struct {
+	Id       string      `json:"id"`
+	CreateAt int64       `json:"create_at"`
+	UserId   string      `json:"user_id"`
+	Active   bool        `json:"active"`
+	Diff     ConfigDiffs `json:"diff"`
+}
+
+// ConfigDiff describes a single setting that differs between two configurations. Path holds the
+// names of the nested settings leading to the value, such as ["ServiceSettings", "SiteURL"].
+// Sensitive values are masked.
+type ConfigDiff struct {
+	Path     []string    `json:"path"`
+	OldValue interface{} `json:"old_value"`
+	NewValue interface{} `json:"new_value"`
+}
+
+type ConfigDiffs []*ConfigDiff
+
+func (o ConfigDiffs) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ConfigDiffsFromJson(data io.Reader) ConfigDiffs {
+	var o ConfigDiffs
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func ConfigRevisionListToJson(l []*ConfigRevision) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func ConfigRevisionListFromJson(data io.Reader) []*ConfigRevision {
+	var l []*ConfigRevision
+	json.NewDecoder(data).Decode(&l)
+	return l
+}
@@ -0,0 +1,23 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigRevisionJson(t *testing.T) {
+	diff := ConfigDiffs{
+		{Path: []string{"ServiceSettings", "SiteURL"}, OldValue: "http://old", NewValue: "http://new"},
+	}
+	assert.Equal(t, diff, ConfigDiffsFromJson(strings.NewReader(diff.ToJson())))
+
+	revisions := []*ConfigRevision{
+		{Id: NewId(), CreateAt: GetMillis(), UserId: NewId(), Active: true, Diff: diff},
+	}
+	assert.Equal(t, revisions, ConfigRevisionListFromJson(strings.NewReader(ConfigRevisionListToJson(revisions))))
+}
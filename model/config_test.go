@@ -764,6 +764,263 @@ func TestImageProxySettingsIsValid(t *testing.T) {
 	}
 }
 
+func TestCacheSettingsIsValid(t *testing.T) {
+	for _, test := range []struct {
+		Name         string
+		CacheType    string
+		RedisAddress string
+		RedisDB      int
+		ExpectError  bool
+	}{
+		{
+			Name:        "lru",
+			CacheType:   CACHE_TYPE_LRU,
+			ExpectError: false,
+		},
+		{
+			Name:        "missing type",
+			CacheType:   "",
+			ExpectError: true,
+		},
+		{
+			Name:        "invalid type",
+			CacheType:   "garbage",
+			ExpectError: true,
+		},
+		{
+			Name:         "redis",
+			CacheType:    CACHE_TYPE_REDIS,
+			RedisAddress: "localhost:6379",
+			ExpectError:  false,
+		},
+		{
+			Name:         "redis, missing address",
+			CacheType:    CACHE_TYPE_REDIS,
+			RedisAddress: "",
+			ExpectError:  true,
+		},
+		{
+			Name:         "redis, negative database",
+			CacheType:    CACHE_TYPE_REDIS,
+			RedisAddress: "localhost:6379",
+			RedisDB:      -1,
+			ExpectError:  true,
+		},
+	} {
+		t.Run(test.Name, func(t *testing.T) {
+			cs := &CacheSettings{
+				CacheType:    &test.CacheType,
+				RedisAddress: &test.RedisAddress,
+				RedisDB:      &test.RedisDB,
+			}
+
+			err := cs.isValid()
+			if test.ExpectError {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestSqlSettingsReplicaLagIsValid(t *testing.T) {
+	for _, test := range []struct {
+		Name                           string
+		ReplicaLagThresholdSeconds     int
+		ReplicaLagCheckIntervalSeconds int
+		StickyMasterAfterWriteSeconds  int
+		ExpectError                    bool
+	}{
+		{
+			Name:                           "defaults",
+			ReplicaLagThresholdSeconds:     10,
+			ReplicaLagCheckIntervalSeconds: 5,
+			StickyMasterAfterWriteSeconds:  5,
+			ExpectError:                    false,
+		},
+		{
+			Name:                           "monitoring and sticky master disabled",
+			ReplicaLagThresholdSeconds:     10,
+			ReplicaLagCheckIntervalSeconds: 0,
+			StickyMasterAfterWriteSeconds:  0,
+			ExpectError:                    false,
+		},
+		{
+			Name:                           "zero threshold",
+			ReplicaLagThresholdSeconds:     0,
+			ReplicaLagCheckIntervalSeconds: 5,
+			StickyMasterAfterWriteSeconds:  5,
+			ExpectError:                    true,
+		},
+		{
+			Name:                           "negative check interval",
+			ReplicaLagThresholdSeconds:     10,
+			ReplicaLagCheckIntervalSeconds: -1,
+			StickyMasterAfterWriteSeconds:  5,
+			ExpectError:                    true,
+		},
+		{
+			Name:                           "negative sticky master duration",
+			ReplicaLagThresholdSeconds:     10,
+			ReplicaLagCheckIntervalSeconds: 5,
+			StickyMasterAfterWriteSeconds:  -1,
+			ExpectError:                    true,
+		},
+	} {
+		t.Run(test.Name, func(t *testing.T) {
+			ss := &SqlSettings{}
+			ss.SetDefaults(false)
+			*ss.ReplicaLagThresholdSeconds = test.ReplicaLagThresholdSeconds
+			*ss.ReplicaLagCheckIntervalSeconds = test.ReplicaLagCheckIntervalSeconds
+			*ss.StickyMasterAfterWriteSeconds = test.StickyMasterAfterWriteSeconds
+
+			err := ss.isValid()
+			if test.ExpectError {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestPluginSettingsLimitsIsValid(t *testing.T) {
+	ps := &PluginSettings{}
+	ps.SetDefaults(LogSettings{})
+	assert.Nil(t, ps.isValid())
+
+	*ps.MaxCPUPercent = PLUGIN_SETTINGS_MAX_CPU_PERCENT
+	assert.Nil(t, ps.isValid())
+
+	*ps.MaxCPUPercent = PLUGIN_SETTINGS_MAX_CPU_PERCENT + 1
+	assert.NotNil(t, ps.isValid())
+
+	*ps.MaxCPUPercent = 50
+	ps.PluginLimits["com.example.plugin"] = &PluginLimitSettings{MaxCPUPercent: NewInt(25)}
+	assert.Nil(t, ps.isValid())
+
+	ps.PluginLimits["com.example.plugin"].MaxCPUPercent = NewInt(PLUGIN_SETTINGS_MAX_CPU_PERCENT + 1)
+	assert.NotNil(t, ps.isValid())
+
+	ps.PluginLimits["com.example.plugin"] = &PluginLimitSettings{MaxConcurrentHooks: NewInt(-1)}
+	assert.NotNil(t, ps.isValid())
+}
+
+func TestSqlSettingsMigrationBatchSizeIsValid(t *testing.T) {
+	ss := &SqlSettings{}
+	ss.SetDefaults(false)
+	assert.False(t, *ss.OnlineMigrations)
+	assert.Equal(t, SQL_SETTINGS_DEFAULT_MIGRATION_BATCH_SIZE, *ss.MigrationBatchSize)
+	assert.Nil(t, ss.isValid())
+
+	*ss.MigrationBatchSize = 0
+	assert.NotNil(t, ss.isValid())
+}
+
+func TestSqlSettingsQueryLogIsValid(t *testing.T) {
+	ss := &SqlSettings{}
+	ss.SetDefaults(false)
+	assert.Equal(t, 0, *ss.SlowQueryThresholdMilliseconds)
+	assert.Empty(t, ss.QueryTimeoutOverrides)
+	assert.Nil(t, ss.isValid())
+
+	*ss.SlowQueryThresholdMilliseconds = -1
+	assert.NotNil(t, ss.isValid())
+	*ss.SlowQueryThresholdMilliseconds = 500
+
+	ss.QueryTimeoutOverrides = map[string]int{"PostStore.Search": 120}
+	assert.Nil(t, ss.isValid())
+
+	ss.QueryTimeoutOverrides = map[string]int{"PostStore.Search": 0}
+	assert.NotNil(t, ss.isValid())
+
+	ss.QueryTimeoutOverrides = map[string]int{"Search": 120}
+	err := ss.isValid()
+	require.NotNil(t, err)
+	assert.Equal(t, "model.config.is_valid.sql_query_timeout_override.app_error", err.Id)
+}
+
+func TestSqlSettingsPostShardsIsValid(t *testing.T) {
+	ss := &SqlSettings{}
+	ss.SetDefaults(false)
+	assert.Empty(t, ss.PostShardDataSources)
+	assert.Equal(t, POST_SHARD_KEY_CHANNEL, *ss.PostShardKey)
+	assert.Nil(t, ss.isValid())
+
+	ss.PostShardDataSources = map[string]string{"shard_1": "postgres://shard1"}
+	assert.Nil(t, ss.isValid())
+
+	ss.PostShardDataSources = map[string]string{"shard_1": ""}
+	assert.NotNil(t, ss.isValid())
+
+	ss.PostShardDataSources = map[string]string{"Shard 1": "postgres://shard1"}
+	err := ss.isValid()
+	require.NotNil(t, err)
+	assert.Equal(t, "model.config.is_valid.sql_post_shard.app_error", err.Id)
+
+	ss.PostShardDataSources = map[string]string{POST_SHARD_MAIN: "postgres://shard1"}
+	assert.NotNil(t, ss.isValid())
+
+	ss.PostShardDataSources = map[string]string{}
+	*ss.PostShardKey = POST_SHARD_KEY_TEAM
+	assert.Nil(t, ss.isValid())
+
+	*ss.PostShardKey = "user"
+	assert.NotNil(t, ss.isValid())
+}
+
+func TestDataRetentionSettingsArchivalIsValid(t *testing.T) {
+	drs := &DataRetentionSettings{}
+	drs.SetDefaults()
+	assert.False(t, *drs.EnableMessageArchival)
+	assert.Equal(t, 12, *drs.MessageArchivalMonths)
+	assert.Nil(t, drs.isValid())
+
+	*drs.MessageArchivalMonths = 0
+	err := drs.isValid()
+	require.NotNil(t, err)
+	assert.Equal(t, "model.config.is_valid.data_retention.message_archival_months_too_low.app_error", err.Id)
+	*drs.MessageArchivalMonths = 6
+
+	*drs.ArchivalJobStartTime = "25:00"
+	err = drs.isValid()
+	require.NotNil(t, err)
+	assert.Equal(t, "model.config.is_valid.data_retention.archival_job_start_time.app_error", err.Id)
+}
+
+func TestTracingSettingsIsValid(t *testing.T) {
+	for name, test := range map[string]struct {
+		Enable         bool
+		ExportEndpoint string
+		ExportFile     string
+		SampleRate     float64
+		ExpectError    bool
+	}{
+		"disabled":                 {SampleRate: 1},
+		"endpoint":                 {Enable: true, ExportEndpoint: "http://localhost:4318/v1/traces", SampleRate: 1},
+		"file":                     {Enable: true, ExportFile: "traces.json", SampleRate: 0.1},
+		"nowhere to export":        {Enable: true, SampleRate: 1, ExpectError: true},
+		"invalid endpoint":         {Enable: true, ExportEndpoint: "localhost:4318", SampleRate: 1, ExpectError: true},
+		"sample rate out of range": {Enable: true, ExportFile: "traces.json", SampleRate: 2, ExpectError: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			settings := TracingSettings{
+				Enable:         NewBool(test.Enable),
+				ExportEndpoint: NewString(test.ExportEndpoint),
+				ExportFile:     NewString(test.ExportFile),
+				SampleRate:     NewFloat64(test.SampleRate),
+			}
+
+			if test.ExpectError {
+				assert.NotNil(t, settings.isValid())
+			} else {
+				assert.Nil(t, settings.isValid())
+			}
+		})
+	}
+}
+
 func TestLdapSettingsIsValid(t *testing.T) {
 	for _, test := range []struct {
 		Name         string
@@ -925,8 +1182,10 @@ func TestConfigSanitize(t *testing.T) {
 	*c.FileSettings.AmazonS3SecretAccessKey = "bar"
 	*c.EmailSettings.SMTPPassword = "baz"
 	*c.GitLabSettings.Secret = "bingo"
+	*c.CacheSettings.RedisPassword = "bongo"
 	c.SqlSettings.DataSourceReplicas = []string{"stuff"}
 	c.SqlSettings.DataSourceSearchReplicas = []string{"stuff"}
+	c.SqlSettings.PostShardDataSources = map[string]string{"shard1": "stuff"}
 
 	c.Sanitize()
 
@@ -938,6 +1197,8 @@ func TestConfigSanitize(t *testing.T) {
 	assert.Equal(t, FAKE_SETTING, *c.SqlSettings.DataSource)
 	assert.Equal(t, FAKE_SETTING, *c.SqlSettings.AtRestEncryptKey)
 	assert.Equal(t, FAKE_SETTING, *c.ElasticsearchSettings.Password)
+	assert.Equal(t, FAKE_SETTING, *c.CacheSettings.RedisPassword)
 	assert.Equal(t, FAKE_SETTING, c.SqlSettings.DataSourceReplicas[0])
 	assert.Equal(t, FAKE_SETTING, c.SqlSettings.DataSourceSearchReplicas[0])
+	assert.Equal(t, FAKE_SETTING, c.SqlSettings.PostShardDataSources["shard1"])
 }
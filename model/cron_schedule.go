@@ -0,0 +1,171 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronScheduleMaxYears bounds the search for the next matching time so that schedules that can never
+// match, such as the 31st of February, don't loop forever.
+const cronScheduleMaxYears = 5
+
+var cronScheduleDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type cronField struct {
+	min, max int
+}
+
+var cronFields = []cronField{
+	{0, 59}, // minute
+	{0, 23}, // hour
+	{1, 31}, // day of month
+	{1, 12}, // month
+	{0, 7},  // day of week, where both 0 and 7 are Sunday
+}
+
+// CronSchedule is a parsed cron expression with the five standard fields: minute, hour, day of month,
+// month and day of week. Each field accepts *, single values, ranges, lists and steps, e.g. "*/15" or
+// "1-5". The common descriptors such as @hourly and @daily are also supported.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domRestricted, dowRestricted  bool
+}
+
+// ParseCronSchedule parses a cron expression.
+func ParseCronSchedule(spec string) (*CronSchedule, error) {
+	spec = strings.TrimSpace(spec)
+	if descriptor, ok := cronScheduleDescriptors[spec]; ok {
+		spec = descriptor
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("expected %d fields in cron expression, found %d", len(cronFields), len(fields))
+	}
+
+	bits := make([]uint64, len(fields))
+	for i, field := range fields {
+		b, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, err
+		}
+		bits[i] = b
+	}
+
+	// Sunday may be given as either 0 or 7.
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+
+	return &CronSchedule{
+		minute:        bits[0],
+		hour:          bits[1],
+		dom:           bits[2],
+		month:         bits[3],
+		dow:           bits[4],
+		domRestricted: fields[2] != "*",
+		dowRestricted: fields[4] != "*",
+	}, nil
+}
+
+func parseCronField(field string, bounds cronField) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		valueRange, step := part, 1
+		if i := strings.Index(part, "/"); i != -1 {
+			var err error
+			valueRange = part[:i]
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in cron field %q", field)
+			}
+		}
+
+		start, end := bounds.min, bounds.max
+		if valueRange != "*" {
+			values := strings.SplitN(valueRange, "-", 2)
+
+			var err error
+			if start, err = strconv.Atoi(values[0]); err != nil {
+				return 0, fmt.Errorf("invalid value in cron field %q", field)
+			}
+
+			if len(values) == 2 {
+				if end, err = strconv.Atoi(values[1]); err != nil {
+					return 0, fmt.Errorf("invalid range in cron field %q", field)
+				}
+			} else if step == 1 {
+				// A single value, whereas a value with a step such as 5/15 runs until the maximum.
+				end = start
+			}
+		}
+
+		if start < bounds.min || end > bounds.max || start > end {
+			return 0, fmt.Errorf("value out of range in cron field %q", field)
+		}
+
+		for value := start; value <= end; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+
+	return bits, nil
+}
+
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+
+	// As with cron, if both the day of month and day of week are restricted, matching either is enough.
+	if s.domRestricted && s.dowRestricted {
+		return domMatch || dowMatch
+	}
+
+	return domMatch && dowMatch
+}
+
+// Next returns the first time after t matched by the schedule, or the zero time if nothing matches
+// within the next few years.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + cronScheduleMaxYears
+
+	for t.Year() <= limit {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	for _, spec := range []string{
+		"* * * * *",
+		"*/15 * * * *",
+		"0 9-17 * * 1-5",
+		"0,30 0 1,15 * *",
+		"5/10 * * * 7",
+		"@daily",
+		"@hourly",
+	} {
+		_, err := ParseCronSchedule(spec)
+		assert.Nil(t, err, spec)
+	}
+
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@never",
+	} {
+		_, err := ParseCronSchedule(spec)
+		assert.NotNil(t, err, spec)
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// A Wednesday
+	now := time.Date(2019, time.July, 17, 10, 7, 30, 0, time.UTC)
+
+	for _, tc := range []struct {
+		Spec     string
+		Expected time.Time
+	}{
+		{"* * * * *", time.Date(2019, time.July, 17, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2019, time.July, 17, 10, 15, 0, 0, time.UTC)},
+		{"0 * * * *", time.Date(2019, time.July, 17, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2019, time.July, 18, 0, 0, 0, 0, time.UTC)},
+		{"30 9 * * 1-5", time.Date(2019, time.July, 18, 9, 30, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2019, time.July, 21, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2019, time.July, 21, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2019, time.August, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 1 *", time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 29 2 *", time.Date(2020, time.February, 29, 12, 0, 0, 0, time.UTC)},
+		// Either the day of month or the day of week may match when both are restricted
+		{"0 0 20 * 4", time.Date(2019, time.July, 18, 0, 0, 0, 0, time.UTC)},
+	} {
+		schedule, err := ParseCronSchedule(tc.Spec)
+		require.Nil(t, err, tc.Spec)
+		assert.Equal(t, tc.Expected, schedule.Next(now), tc.Spec)
+	}
+
+	schedule, err := ParseCronSchedule("0 0 31 2 *")
+	require.Nil(t, err)
+	assert.True(t, schedule.Next(now).IsZero(), "a schedule that never matches shouldn't have a next time")
+}
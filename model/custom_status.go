@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	USER_PROPS_KEY_CUSTOM_STATUS = "customStatus"
+
+	CUSTOM_STATUS_TEXT_MAX_RUNES = 100
+)
+
+// CustomStatus is a free-form status that a user can display alongside their presence. It is
+// stored as JSON in the user's props so that it is returned with their profile.
+type CustomStatus struct {
+	Emoji string `json:"emoji"`
+	Text  string `json:"text"`
+
+	// ExpiresAt is the time in milliseconds at which the custom status is cleared, or 0 if it
+	// never expires.
+	ExpiresAt int64 `json:"expires_at"`
+}
+
+func (cs *CustomStatus) PreSave() {
+	cs.Emoji = strings.Trim(strings.TrimSpace(cs.Emoji), ":")
+	cs.Text = strings.TrimSpace(cs.Text)
+}
+
+func (cs *CustomStatus) IsValid() *AppError {
+	if len(cs.Emoji) == 0 && len(cs.Text) == 0 {
+		return NewAppError("CustomStatus.IsValid", "model.custom_status.is_valid.empty.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(cs.Emoji) > EMOJI_NAME_MAX_LENGTH || (len(cs.Emoji) > 0 && !IsValidAlphaNumHyphenUnderscore(strings.Replace(cs.Emoji, "+", "", -1), false)) {
+		return NewAppError("CustomStatus.IsValid", "model.custom_status.is_valid.emoji.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(cs.Text) > CUSTOM_STATUS_TEXT_MAX_RUNES {
+		return NewAppError("CustomStatus.IsValid", "model.custom_status.is_valid.text.app_error", map[string]interface{}{"MaxLength": CUSTOM_STATUS_TEXT_MAX_RUNES}, "", http.StatusBadRequest)
+	}
+
+	if cs.ExpiresAt < 0 {
+		return NewAppError("CustomStatus.IsValid", "model.custom_status.is_valid.expires_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// IsExpired returns true if the custom status has an expiry time that is at or before now,
+// which is given in milliseconds.
+func (cs *CustomStatus) IsExpired(now int64) bool {
+	return cs.ExpiresAt > 0 && cs.ExpiresAt <= now
+}
+
+func (cs *CustomStatus) ToJson() string {
+	b, _ := json.Marshal(cs)
+	return string(b)
+}
+
+func CustomStatusFromJson(data io.Reader) *CustomStatus {
+	var cs *CustomStatus
+	json.NewDecoder(data).Decode(&cs)
+	return cs
+}
+
+// GetCustomStatus returns the user's custom status or nil if they don't have one.
+func (u *User) GetCustomStatus() *CustomStatus {
+	if u.Props == nil || u.Props[USER_PROPS_KEY_CUSTOM_STATUS] == "" {
+		return nil
+	}
+
+	return CustomStatusFromJson(strings.NewReader(u.Props[USER_PROPS_KEY_CUSTOM_STATUS]))
+}
+
+func (u *User) SetCustomStatus(cs *CustomStatus) {
+	u.MakeNonNil()
+	u.Props[USER_PROPS_KEY_CUSTOM_STATUS] = cs.ToJson()
+}
+
+func (u *User) ClearCustomStatus() {
+	if u.Props != nil {
+		delete(u.Props, USER_PROPS_KEY_CUSTOM_STATUS)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomStatusIsValid(t *testing.T) {
+	cs := &CustomStatus{}
+	assert.NotNil(t, cs.IsValid(), "should require an emoji or text")
+
+	cs = &CustomStatus{Emoji: ":palm_tree:", Text: "  On vacation  "}
+	cs.PreSave()
+	assert.Equal(t, "palm_tree", cs.Emoji)
+	assert.Equal(t, "On vacation", cs.Text)
+	assert.Nil(t, cs.IsValid())
+
+	cs.Emoji = "+1"
+	assert.Nil(t, cs.IsValid())
+
+	cs.Emoji = "not an emoji"
+	assert.NotNil(t, cs.IsValid())
+
+	cs.Emoji = ""
+	cs.Text = strings.Repeat("🏖", CUSTOM_STATUS_TEXT_MAX_RUNES)
+	assert.Nil(t, cs.IsValid())
+
+	cs.Text += "a"
+	assert.NotNil(t, cs.IsValid())
+
+	cs.Text = "Lunch"
+	cs.ExpiresAt = -1
+	assert.NotNil(t, cs.IsValid())
+}
+
+func TestCustomStatusIsExpired(t *testing.T) {
+	now := GetMillis()
+
+	assert.False(t, (&CustomStatus{Text: "forever"}).IsExpired(now))
+	assert.False(t, (&CustomStatus{Text: "later", ExpiresAt: now + 1}).IsExpired(now))
+	assert.True(t, (&CustomStatus{Text: "now", ExpiresAt: now}).IsExpired(now))
+}
+
+func TestUserCustomStatus(t *testing.T) {
+	user := &User{}
+	assert.Nil(t, user.GetCustomStatus())
+
+	user.SetCustomStatus(&CustomStatus{Emoji: "calendar", Text: "In a meeting", ExpiresAt: 1234})
+	cs := user.GetCustomStatus()
+	require.NotNil(t, cs)
+	assert.Equal(t, "calendar", cs.Emoji)
+	assert.Equal(t, "In a meeting", cs.Text)
+	assert.Equal(t, int64(1234), cs.ExpiresAt)
+
+	user.ClearCustomStatus()
+	assert.Nil(t, user.GetCustomStatus())
+}
@@ -13,6 +13,10 @@ import (
 
 const (
 	DEFAULT_WEBHOOK_USERNAME = "webhook"
+
+	// INCOMING_WEBHOOK_REQUEST_MAX_SIZE is the size past which the body of a request made to an
+	// incoming webhook is refused.
+	INCOMING_WEBHOOK_REQUEST_MAX_SIZE = 10 * 1024 * 1024
 )
 
 type IncomingWebhook struct {
@@ -28,6 +32,10 @@ type IncomingWebhook struct {
 	Username      string `json:"username"`
 	IconURL       string `json:"icon_url"`
 	ChannelLocked bool   `json:"channel_locked"`
+
+	// PayloadTemplate, if set, is a text/template that transforms an arbitrary JSON payload into
+	// an IncomingWebhookRequest. See RenderIncomingWebhookTemplate.
+	PayloadTemplate string `json:"payload_template"`
 }
 
 type IncomingWebhookRequest struct {
@@ -106,6 +114,16 @@ func (o *IncomingWebhook) IsValid() *AppError {
 		return NewAppError("IncomingWebhook.IsValid", "model.incoming_hook.icon_url.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if len(o.PayloadTemplate) > INCOMING_WEBHOOK_PAYLOAD_TEMPLATE_MAX_LENGTH {
+		return NewAppError("IncomingWebhook.IsValid", "model.incoming_hook.payload_template.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.PayloadTemplate) > 0 {
+		if _, err := ParseIncomingWebhookTemplate(o.PayloadTemplate); err != nil {
+			return NewAppError("IncomingWebhook.IsValid", "model.incoming_hook.payload_template.app_error", nil, err.Error(), http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,292 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	INCOMING_WEBHOOK_PAYLOAD_TEMPLATE_MAX_LENGTH = 8000
+	INCOMING_WEBHOOK_RENDERED_PAYLOAD_MAX_SIZE   = 256 * 1024
+
+	// INCOMING_WEBHOOK_TEMPLATE_MAX_STEPS bounds the loop iterations and template calls made while
+	// rendering a payload, and INCOMING_WEBHOOK_TEMPLATE_TIMEOUT the time spent rendering it, since a
+	// template can loop over numbers or over the payload as many times as it wants.
+	INCOMING_WEBHOOK_TEMPLATE_MAX_STEPS = 100000
+	INCOMING_WEBHOOK_TEMPLATE_TIMEOUT   = 2 * time.Second
+
+	// incomingWebhookTemplateStepFunc is the function called at each step of a template, added to
+	// the body of every loop and template.
+	incomingWebhookTemplateStepFunc = "_step"
+)
+
+var (
+	errIncomingWebhookRenderTooLarge   = errors.New("rendered payload exceeds the maximum size")
+	errIncomingWebhookRenderTooComplex = errors.New("rendering the payload exceeds the maximum number of steps or time")
+	errIncomingWebhookReplaceEmpty     = errors.New("the string to replace must not be empty")
+	errIncomingWebhookFormatTooWide    = errors.New("the format must not take a width or precision from its arguments or set one larger than the maximum size")
+)
+
+// incomingWebhookTemplateFuncs is the complete set of functions available to payload templates
+// in addition to the text/template builtins, of which print, printf and println are replaced.
+// None of them have side effects or access anything other than their arguments, and those
+// building strings refuse to build one larger than INCOMING_WEBHOOK_RENDERED_PAYLOAD_MAX_SIZE,
+// since the output buffer only sees what they build once it is written.
+var incomingWebhookTemplateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return limitIncomingWebhookString(string(b))
+	},
+	"lower":     strings.ToLower,
+	"upper":     strings.ToUpper,
+	"title":     strings.Title,
+	"trim":      strings.TrimSpace,
+	"contains":  func(substr, s string) bool { return strings.Contains(s, substr) },
+	"hasPrefix": func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+	"hasSuffix": func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+	"replace": func(old, new, s string) (string, error) {
+		if old == "" {
+			return "", errIncomingWebhookReplaceEmpty
+		}
+		if len(new) > len(old) && len(s)+strings.Count(s, old)*(len(new)-len(old)) > INCOMING_WEBHOOK_RENDERED_PAYLOAD_MAX_SIZE {
+			return "", errIncomingWebhookRenderTooLarge
+		}
+		return strings.Replace(s, old, new, -1), nil
+	},
+	"split": func(sep, s string) ([]string, error) {
+		if _, err := limitIncomingWebhookString(s); err != nil {
+			return nil, err
+		}
+		return strings.Split(s, sep), nil
+	},
+	"join": func(sep string, v interface{}) (string, error) {
+		var parts []string
+		switch l := v.(type) {
+		case []string:
+			parts = l
+		case []interface{}:
+			parts = make([]string, len(l))
+			for i, item := range l {
+				parts[i] = fmt.Sprint(item)
+			}
+		default:
+			return limitIncomingWebhookString(fmt.Sprint(v))
+		}
+
+		size := len(sep) * (len(parts) - 1)
+		for _, part := range parts {
+			size += len(part)
+		}
+		if size > INCOMING_WEBHOOK_RENDERED_PAYLOAD_MAX_SIZE {
+			return "", errIncomingWebhookRenderTooLarge
+		}
+		return strings.Join(parts, sep), nil
+	},
+	"print": func(args ...interface{}) (string, error) {
+		return limitIncomingWebhookString(fmt.Sprint(args...))
+	},
+	"println": func(args ...interface{}) (string, error) {
+		return limitIncomingWebhookString(fmt.Sprintln(args...))
+	},
+	"printf": func(format string, args ...interface{}) (string, error) {
+		if err := checkIncomingWebhookFormat(format); err != nil {
+			return "", err
+		}
+		return limitIncomingWebhookString(fmt.Sprintf(format, args...))
+	},
+	"truncate": func(length int, s string) string {
+		if length < 0 || utf8.RuneCountInString(s) <= length {
+			return s
+		}
+		return string([]rune(s)[:length])
+	},
+	"default": func(def interface{}, v interface{}) interface{} {
+		if v == nil {
+			return def
+		}
+		if s, ok := v.(string); ok && s == "" {
+			return def
+		}
+		return v
+	},
+	"formatTime": func(layout string, v interface{}) string {
+		var t time.Time
+		switch value := v.(type) {
+		case float64:
+			// JSON numbers are assumed to be seconds since the epoch
+			t = time.Unix(int64(value), 0).UTC()
+		case string:
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return value
+			}
+			t = parsed
+		default:
+			return fmt.Sprint(v)
+		}
+		return t.Format(layout)
+	},
+}
+
+func limitIncomingWebhookString(s string) (string, error) {
+	if len(s) > INCOMING_WEBHOOK_RENDERED_PAYLOAD_MAX_SIZE {
+		return "", errIncomingWebhookRenderTooLarge
+	}
+	return s, nil
+}
+
+// checkIncomingWebhookFormat refuses printf formats whose widths or precisions, given inline or
+// as arguments, would make fmt pad a value beyond the maximum size before it can be checked.
+func checkIncomingWebhookFormat(format string) error {
+	number := 0
+	for _, r := range format {
+		switch {
+		case r == '*':
+			return errIncomingWebhookFormatTooWide
+		case r >= '0' && r <= '9':
+			number = number*10 + int(r-'0')
+			if number > INCOMING_WEBHOOK_RENDERED_PAYLOAD_MAX_SIZE {
+				return errIncomingWebhookFormatTooWide
+			}
+		default:
+			number = 0
+		}
+	}
+	return nil
+}
+
+// limitedBuffer is a bytes.Buffer that refuses to grow beyond max bytes so that a template can't
+// be used to amplify a small payload into an arbitrarily large one.
+type limitedBuffer struct {
+	bytes.Buffer
+	max int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.max {
+		return 0, errIncomingWebhookRenderTooLarge
+	}
+	return b.Buffer.Write(p)
+}
+
+// ParseIncomingWebhookTemplate parses a payload template using the restricted set of functions
+// that are available to incoming webhook templates.
+func ParseIncomingWebhookTemplate(text string) (*template.Template, error) {
+	return parseIncomingWebhookTemplate(text, func() (string, error) { return "", nil })
+}
+
+// parseIncomingWebhookTemplate parses a payload template calling the given function at the start
+// of every loop iteration and template call, so that it can stop runaway templates.
+func parseIncomingWebhookTemplate(text string, step func() (string, error)) (*template.Template, error) {
+	funcs := template.FuncMap{incomingWebhookTemplateStepFunc: step}
+
+	tmpl, err := template.New("payload").Funcs(incomingWebhookTemplateFuncs).Funcs(funcs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	stepTmpl, err := template.New("step").Funcs(funcs).Parse("{{" + incomingWebhookTemplateStepFunc + "}}")
+	if err != nil {
+		return nil, err
+	}
+	stepNode := stepTmpl.Tree.Root.Nodes[0]
+
+	for _, t := range tmpl.Templates() {
+		if t.Tree == nil || t.Tree.Root == nil {
+			continue
+		}
+		addIncomingWebhookTemplateSteps(t.Tree.Root, stepNode)
+		t.Tree.Root.Nodes = append([]parse.Node{stepNode}, t.Tree.Root.Nodes...)
+	}
+
+	return tmpl, nil
+}
+
+func addIncomingWebhookTemplateSteps(node parse.Node, stepNode parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			addIncomingWebhookTemplateSteps(child, stepNode)
+		}
+	case *parse.IfNode:
+		addIncomingWebhookTemplateSteps(n.List, stepNode)
+		addIncomingWebhookTemplateSteps(n.ElseList, stepNode)
+	case *parse.WithNode:
+		addIncomingWebhookTemplateSteps(n.List, stepNode)
+		addIncomingWebhookTemplateSteps(n.ElseList, stepNode)
+	case *parse.RangeNode:
+		addIncomingWebhookTemplateSteps(n.List, stepNode)
+		addIncomingWebhookTemplateSteps(n.ElseList, stepNode)
+		n.List.Nodes = append([]parse.Node{stepNode}, n.List.Nodes...)
+	}
+}
+
+// RenderIncomingWebhookTemplate executes the given payload template against an arbitrary JSON
+// document. The template must produce an IncomingWebhookRequest encoded as JSON, which allows it
+// to set the text, attachments, props and channel of the resulting post.
+func RenderIncomingWebhookTemplate(text string, payload []byte) (*IncomingWebhookRequest, *AppError) {
+	steps := 0
+	deadline := time.Now().Add(INCOMING_WEBHOOK_TEMPLATE_TIMEOUT)
+	tmpl, err := parseIncomingWebhookTemplate(text, func() (string, error) {
+		steps++
+		if steps > INCOMING_WEBHOOK_TEMPLATE_MAX_STEPS || time.Now().After(deadline) {
+			return "", errIncomingWebhookRenderTooComplex
+		}
+		return "", nil
+	})
+	if err != nil {
+		return nil, NewAppError("RenderIncomingWebhookTemplate", "model.incoming_hook.payload_template.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	var data interface{}
+	if len(bytes.TrimSpace(payload)) > 0 {
+		if err = json.Unmarshal(payload, &data); err != nil {
+			if err = json.Unmarshal(escapeControlCharsFromPayload(payload), &data); err != nil {
+				return nil, NewAppError("RenderIncomingWebhookTemplate", "model.incoming_hook.parse_data.app_error", nil, err.Error(), http.StatusBadRequest)
+			}
+		}
+	}
+
+	out := &limitedBuffer{max: INCOMING_WEBHOOK_RENDERED_PAYLOAD_MAX_SIZE}
+	if err = tmpl.Execute(out, data); err != nil {
+		return nil, NewAppError("RenderIncomingWebhookTemplate", "model.incoming_hook.render_template.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	return IncomingWebhookRequestFromJson(&out.Buffer)
+}
+
+// IncomingWebhookPreview is a sample payload to render through an incoming webhook without
+// posting it. PayloadTemplate, if set, is used in place of the webhook's saved template.
+type IncomingWebhookPreview struct {
+	PayloadTemplate *string         `json:"payload_template"`
+	Payload         json.RawMessage `json:"payload"`
+}
+
+func (o *IncomingWebhookPreview) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func IncomingWebhookPreviewFromJson(data io.Reader) *IncomingWebhookPreview {
+	var o *IncomingWebhookPreview
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
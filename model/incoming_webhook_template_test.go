@@ -0,0 +1,150 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderIncomingWebhookTemplate(t *testing.T) {
+	payload := []byte(`{
+		"event": {"title": "CPU high", "level": "warning", "tags": ["prod", "db"], "timestamp": 0},
+		"url": "https://example.com/alerts/1"
+	}`)
+
+	t.Run("text and channel", func(t *testing.T) {
+		tmpl := `{"text": {{ json (printf "%s (%s)" .event.title (upper .event.level)) }}, "channel": "#alerts"}`
+
+		request, err := RenderIncomingWebhookTemplate(tmpl, payload)
+		require.Nil(t, err)
+		assert.Equal(t, "CPU high (WARNING)", request.Text)
+		assert.Equal(t, "#alerts", request.ChannelName)
+	})
+
+	t.Run("attachments and props", func(t *testing.T) {
+		tmpl := `{
+			"attachments": [{"title": {{ json .event.title }}, "title_link": {{ json .url }}, "text": {{ json (join ", " .event.tags) }}}],
+			"props": {"alert_time": {{ json (formatTime "2006-01-02" .event.timestamp) }}}
+		}`
+
+		request, err := RenderIncomingWebhookTemplate(tmpl, payload)
+		require.Nil(t, err)
+		require.Len(t, request.Attachments, 1)
+		assert.Equal(t, "CPU high", request.Attachments[0].Title)
+		assert.Equal(t, "https://example.com/alerts/1", request.Attachments[0].TitleLink)
+		assert.Equal(t, "prod, db", request.Attachments[0].Text)
+		assert.Equal(t, "1970-01-01", request.Props["alert_time"])
+	})
+
+	t.Run("missing keys", func(t *testing.T) {
+		tmpl := `{"text": {{ json (default "no summary" .summary) }}}`
+
+		request, err := RenderIncomingWebhookTemplate(tmpl, payload)
+		require.Nil(t, err)
+		assert.Equal(t, "no summary", request.Text)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := RenderIncomingWebhookTemplate(`{{ .event.title `, payload)
+		require.NotNil(t, err)
+		assert.Equal(t, "model.incoming_hook.payload_template.app_error", err.Id)
+	})
+
+	t.Run("unknown function", func(t *testing.T) {
+		_, err := RenderIncomingWebhookTemplate(`{{ exec "ls" }}`, payload)
+		require.NotNil(t, err)
+		assert.Equal(t, "model.incoming_hook.payload_template.app_error", err.Id)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		_, err := RenderIncomingWebhookTemplate(`{"text": "static"}`, []byte(`{not json`))
+		require.NotNil(t, err)
+		assert.Equal(t, "model.incoming_hook.parse_data.app_error", err.Id)
+	})
+
+	t.Run("rendered output is not json", func(t *testing.T) {
+		_, err := RenderIncomingWebhookTemplate(`{{ .event.title }}`, payload)
+		require.NotNil(t, err)
+		assert.Equal(t, "model.incoming_hook.parse_data.app_error", err.Id)
+	})
+
+	t.Run("rendered output too large", func(t *testing.T) {
+		tmpl := `{{ range .event.tags }}` + strings.Repeat("x", INCOMING_WEBHOOK_RENDERED_PAYLOAD_MAX_SIZE) + `{{ end }}`
+
+		_, err := RenderIncomingWebhookTemplate(tmpl, payload)
+		require.NotNil(t, err)
+		assert.Equal(t, "model.incoming_hook.render_template.app_error", err.Id)
+	})
+
+	t.Run("strings amplified by functions", func(t *testing.T) {
+		for name, tmpl := range map[string]string{
+			"replace": `{{ $a := "xxxxxxxxxxxxxxxx" }}` + strings.Repeat(`{{ $a = replace "x" $a $a }}`, 8) + `{"text": "static"}`,
+			"printf":  `{{ $a := "xxxxxxxxxxxxxxxx" }}` + strings.Repeat(`{{ $a = printf "%s%s" $a $a }}`, 20) + `{"text": "static"}`,
+			"print":   `{{ $a := "xxxxxxxxxxxxxxxx" }}` + strings.Repeat(`{{ $a = print $a $a }}`, 20) + `{"text": "static"}`,
+			"join":    `{{ $a := "xxxxxxxxxxxxxxxx" }}` + strings.Repeat(`{{ $a = join $a (split "" $a) }}`, 4) + `{"text": "static"}`,
+			"width":   `{"text": "{{ printf "%999999999d" 1 }}"}`,
+			"star":    `{"text": "{{ printf "%*d" 999999999 1 }}"}`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := RenderIncomingWebhookTemplate(tmpl, payload)
+				require.NotNil(t, err)
+				assert.Equal(t, "model.incoming_hook.render_template.app_error", err.Id)
+			})
+		}
+	})
+
+	t.Run("replacing an empty string", func(t *testing.T) {
+		_, err := RenderIncomingWebhookTemplate(`{"text": {{ json (replace "" "x" .event.title) }}}`, payload)
+		require.NotNil(t, err)
+		assert.Equal(t, "model.incoming_hook.render_template.app_error", err.Id)
+	})
+
+	t.Run("loops within the step limit", func(t *testing.T) {
+		tmpl := `{"text": "{{ range .event.tags }}{{ range $.event.tags }}{{ . }}{{ end }}{{ end }}"}`
+
+		request, err := RenderIncomingWebhookTemplate(tmpl, payload)
+		require.Nil(t, err)
+		assert.Equal(t, "proddbproddb", request.Text)
+	})
+
+	t.Run("too many loop iterations", func(t *testing.T) {
+		tmpl := `{{ range 1000000 }}{{ range 1000000 }}{{ end }}{{ end }}`
+
+		_, err := RenderIncomingWebhookTemplate(tmpl, payload)
+		require.NotNil(t, err)
+		assert.Equal(t, "model.incoming_hook.render_template.app_error", err.Id)
+	})
+
+	t.Run("too many template calls", func(t *testing.T) {
+		tmpl := `{{ define "x" }}{{ template "x" }}{{ template "x" }}{{ end }}{{ template "x" }}`
+
+		_, err := RenderIncomingWebhookTemplate(tmpl, payload)
+		require.NotNil(t, err)
+		assert.Equal(t, "model.incoming_hook.render_template.app_error", err.Id)
+	})
+}
+
+func TestIncomingWebhookPayloadTemplateIsValid(t *testing.T) {
+	o := IncomingWebhook{
+		Id:        NewId(),
+		CreateAt:  GetMillis(),
+		UpdateAt:  GetMillis(),
+		UserId:    NewId(),
+		ChannelId: NewId(),
+		TeamId:    NewId(),
+	}
+
+	o.PayloadTemplate = `{"text": {{ json .text }}}`
+	assert.Nil(t, o.IsValid())
+
+	o.PayloadTemplate = `{{ if .text }}`
+	assert.NotNil(t, o.IsValid())
+
+	o.PayloadTemplate = strings.Repeat("x", INCOMING_WEBHOOK_PAYLOAD_TEMPLATE_MAX_LENGTH+1)
+	assert.NotNil(t, o.IsValid())
+}
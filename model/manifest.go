@@ -9,8 +9,10 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/blang/semver"
@@ -67,6 +69,17 @@ type PluginSetting struct {
 	// For "radio" or "dropdown" settings, this is the list of pre-defined options that the user can choose
 	// from.
 	Options []*PluginOption `json:"options,omitempty" yaml:"options,omitempty"`
+
+	// Whether the setting must have a value. A required setting with a default is satisfied by the
+	// default when no value has been saved. Does not apply to "bool" settings.
+	Required bool `json:"required,omitempty" yaml:"required,omitempty"`
+
+	// For "text", "longtext" and "username" settings, a regular expression that any non-empty value
+	// must match.
+	Regex string `json:"regex,omitempty" yaml:"regex,omitempty"`
+
+	// The error to display when the value does not match Regex.
+	RegexErrorText string `json:"regex_error_text,omitempty" yaml:"regex_error_text,omitempty"`
 }
 
 type PluginSettingsSchema struct {
@@ -168,6 +181,10 @@ type ManifestServer struct {
 	// If your plugin is compiled for multiple platforms, consider bundling them together
 	// and using the Executables field instead.
 	Executable string `json:"executable" yaml:"executable"`
+
+	// Methods are the names of the methods other plugins may invoke through API.CallPlugin.
+	// Requests for any other method are rejected before reaching the plugin.
+	Methods []string `json:"methods,omitempty" yaml:"methods,omitempty"`
 }
 
 type ManifestExecutables struct {
@@ -268,6 +285,107 @@ func (m *Manifest) HasServer() bool {
 	return m.Server != nil || m.Backend != nil
 }
 
+// DeclaresMethod returns true if the plugin's server component allows other plugins to call the
+// given method.
+func (m *Manifest) DeclaresMethod(method string) bool {
+	server := m.Server
+	if server == nil {
+		server = m.Backend
+	}
+	if server == nil {
+		return false
+	}
+
+	for _, declared := range server.Methods {
+		if declared == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateSettings checks the given plugin settings against the manifest's settings schema.
+// Setting keys are matched case-insensitively and values for keys not in the schema are ignored.
+func (m *Manifest) ValidateSettings(settings map[string]interface{}) *AppError {
+	if m.SettingsSchema == nil {
+		return nil
+	}
+
+	values := make(map[string]interface{}, len(settings))
+	for key, value := range settings {
+		values[strings.ToLower(key)] = value
+	}
+
+	for _, setting := range m.SettingsSchema.Settings {
+		if err := setting.validateValue(m.Id, values[strings.ToLower(setting.Key)]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *PluginSetting) validateValue(pluginId string, value interface{}) *AppError {
+	params := map[string]interface{}{"PluginId": pluginId, "Key": s.Key}
+
+	if value == nil {
+		value = s.Default
+	}
+
+	switch s.Type {
+	case "bool":
+		if _, ok := value.(bool); value != nil && !ok {
+			return NewAppError("Manifest.ValidateSettings", "model.manifest.validate_settings.type.app_error", params, "expected a boolean", http.StatusBadRequest)
+		}
+		return nil
+	case "dropdown", "radio", "generated", "text", "longtext", "username":
+	default:
+		// Settings rendered by the plugin itself can't be validated by the server.
+		return nil
+	}
+
+	str, ok := value.(string)
+	if value != nil && !ok {
+		return NewAppError("Manifest.ValidateSettings", "model.manifest.validate_settings.type.app_error", params, "expected a string", http.StatusBadRequest)
+	}
+
+	if str == "" {
+		if s.Required {
+			return NewAppError("Manifest.ValidateSettings", "model.manifest.validate_settings.required.app_error", params, "", http.StatusBadRequest)
+		}
+		return nil
+	}
+
+	switch s.Type {
+	case "dropdown", "radio":
+		for _, option := range s.Options {
+			if option.Value == str {
+				return nil
+			}
+		}
+		return NewAppError("Manifest.ValidateSettings", "model.manifest.validate_settings.option.app_error", params, "value="+str, http.StatusBadRequest)
+	case "text", "longtext", "username":
+		if s.Regex == "" {
+			return nil
+		}
+
+		re, err := regexp.Compile(s.Regex)
+		if err != nil {
+			return NewAppError("Manifest.ValidateSettings", "model.manifest.validate_settings.invalid_regex.app_error", params, err.Error(), http.StatusBadRequest)
+		}
+		if !re.MatchString(str) {
+			if s.RegexErrorText != "" {
+				params["Message"] = s.RegexErrorText
+				return NewAppError("Manifest.ValidateSettings", "model.manifest.validate_settings.regex_message.app_error", params, "", http.StatusBadRequest)
+			}
+			return NewAppError("Manifest.ValidateSettings", "model.manifest.validate_settings.regex.app_error", params, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
 func (m *Manifest) HasWebapp() bool {
 	return m.Webapp != nil
 }
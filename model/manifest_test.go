@@ -590,6 +590,66 @@ func TestManifestHasServer(t *testing.T) {
 	}
 }
 
+func TestManifestDeclaresMethod(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Manifest    *Manifest
+		Method      string
+		Expected    bool
+	}{
+		{
+			"no server",
+			&Manifest{},
+			"sync",
+			false,
+		},
+		{
+			"no methods",
+			&Manifest{
+				Server: &ManifestServer{},
+			},
+			"sync",
+			false,
+		},
+		{
+			"declared method",
+			&Manifest{
+				Server: &ManifestServer{
+					Methods: []string{"sync", "status"},
+				},
+			},
+			"status",
+			true,
+		},
+		{
+			"undeclared method",
+			&Manifest{
+				Server: &ManifestServer{
+					Methods: []string{"sync", "status"},
+				},
+			},
+			"delete",
+			false,
+		},
+		{
+			"declared via deprecated backend",
+			&Manifest{
+				Backend: &ManifestServer{
+					Methods: []string{"sync"},
+				},
+			},
+			"sync",
+			true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Description, func(t *testing.T) {
+			assert.Equal(t, testCase.Expected, testCase.Manifest.DeclaresMethod(testCase.Method))
+		})
+	}
+}
+
 func TestManifestHasWebapp(t *testing.T) {
 	testCases := []struct {
 		Description string
@@ -675,3 +735,86 @@ func TestManifestMeetMinServerVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestManifestValidateSettings(t *testing.T) {
+	manifest := &Manifest{
+		Id: "com.example.plugin",
+		SettingsSchema: &PluginSettingsSchema{
+			Settings: []*PluginSetting{
+				{Key: "Enabled", Type: "bool"},
+				{Key: "Color", Type: "dropdown", Options: []*PluginOption{{Value: "red"}, {Value: "blue"}}},
+				{Key: "Token", Type: "text", Required: true},
+				{Key: "Channel", Type: "text", Default: "town-square", Required: true},
+				{Key: "Port", Type: "text", Regex: "^[0-9]+$"},
+				{Key: "Host", Type: "text", Regex: "^[a-z.]+$", RegexErrorText: "Must be a lowercase hostname."},
+				{Key: "Custom", Type: "custom"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		Description string
+		Settings    map[string]interface{}
+		ExpectedId  string
+	}{
+		{
+			"valid",
+			map[string]interface{}{"enabled": true, "color": "red", "token": "abc", "port": "8065", "host": "example.com", "custom": 5},
+			"",
+		},
+		{
+			"keys are case-insensitive",
+			map[string]interface{}{"Token": "abc"},
+			"",
+		},
+		{
+			"missing required setting",
+			map[string]interface{}{},
+			"model.manifest.validate_settings.required.app_error",
+		},
+		{
+			"empty required setting",
+			map[string]interface{}{"token": "abc", "channel": ""},
+			"model.manifest.validate_settings.required.app_error",
+		},
+		{
+			"wrong type for bool",
+			map[string]interface{}{"token": "abc", "enabled": "true"},
+			"model.manifest.validate_settings.type.app_error",
+		},
+		{
+			"wrong type for text",
+			map[string]interface{}{"token": 5.0},
+			"model.manifest.validate_settings.type.app_error",
+		},
+		{
+			"unknown option",
+			map[string]interface{}{"token": "abc", "color": "green"},
+			"model.manifest.validate_settings.option.app_error",
+		},
+		{
+			"regex mismatch",
+			map[string]interface{}{"token": "abc", "port": "http"},
+			"model.manifest.validate_settings.regex.app_error",
+		},
+		{
+			"regex mismatch with message",
+			map[string]interface{}{"token": "abc", "host": "Example.com"},
+			"model.manifest.validate_settings.regex_message.app_error",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Description, func(t *testing.T) {
+			err := manifest.ValidateSettings(testCase.Settings)
+			if testCase.ExpectedId == "" {
+				assert.Nil(t, err)
+			} else {
+				require.NotNil(t, err)
+				assert.Equal(t, testCase.ExpectedId, err.Id)
+			}
+		})
+	}
+
+	assert.Nil(t, (&Manifest{}).ValidateSettings(map[string]interface{}{"any": "value"}))
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/blang/semver"
+)
+
+// MarketplacePluginVersion is a single release of a plugin listed in the marketplace catalog. The
+// signature is a base64 encoded ed25519 signature of the bundle served from DownloadURL.
+type MarketplacePluginVersion struct {
+	Version          string `json:"version"`
+	MinServerVersion string `json:"min_server_version,omitempty"`
+	DownloadURL      string `json:"download_url"`
+	Signature        string `json:"signature"`
+}
+
+// MarketplacePlugin is a plugin listed in the marketplace catalog. InstalledVersion is filled in by
+// the server and is empty if the plugin isn't installed.
+type MarketplacePlugin struct {
+	Id               string                      `json:"id"`
+	Name             string                      `json:"name"`
+	Description      string                      `json:"description,omitempty"`
+	HomepageURL      string                      `json:"homepage_url,omitempty"`
+	Versions         []*MarketplacePluginVersion `json:"versions"`
+	InstalledVersion string                      `json:"installed_version,omitempty"`
+}
+
+// MarketplacePluginRequest identifies a plugin to install or upgrade from the marketplace. If Version
+// is empty, the latest version compatible with the server is used.
+type MarketplacePluginRequest struct {
+	Id      string `json:"id"`
+	Version string `json:"version"`
+}
+
+// IsCompatible returns true if the version can run on the given server version.
+func (v *MarketplacePluginVersion) IsCompatible(serverVersion string) bool {
+	if v.MinServerVersion == "" {
+		return true
+	}
+
+	minServerVersion, err := semver.Parse(v.MinServerVersion)
+	if err != nil {
+		return false
+	}
+
+	sv, err := semver.Parse(serverVersion)
+	if err != nil {
+		return false
+	}
+
+	return sv.GTE(minServerVersion)
+}
+
+// GetVersion returns the listed version of the plugin with the given version number, or nil if there
+// isn't one.
+func (p *MarketplacePlugin) GetVersion(version string) *MarketplacePluginVersion {
+	for _, v := range p.Versions {
+		if v.Version == version {
+			return v
+		}
+	}
+	return nil
+}
+
+// LatestCompatibleVersion returns the highest version of the plugin that can run on the given server
+// version, or nil if there isn't one. Versions that aren't valid semantic versions are ignored.
+func (p *MarketplacePlugin) LatestCompatibleVersion(serverVersion string) *MarketplacePluginVersion {
+	var latest *MarketplacePluginVersion
+	var latestVersion semver.Version
+
+	for _, v := range p.Versions {
+		version, err := semver.Parse(v.Version)
+		if err != nil || !v.IsCompatible(serverVersion) {
+			continue
+		}
+
+		if latest == nil || version.GT(latestVersion) {
+			latest = v
+			latestVersion = version
+		}
+	}
+
+	return latest
+}
+
+func MarketplacePluginsToJson(plugins []*MarketplacePlugin) string {
+	b, _ := json.Marshal(plugins)
+	return string(b)
+}
+
+func MarketplacePluginsFromJson(data io.Reader) []*MarketplacePlugin {
+	var plugins []*MarketplacePlugin
+	json.NewDecoder(data).Decode(&plugins)
+	return plugins
+}
+
+func (r *MarketplacePluginRequest) ToJson() string {
+	b, _ := json.Marshal(r)
+	return string(b)
+}
+
+func MarketplacePluginRequestFromJson(data io.Reader) *MarketplacePluginRequest {
+	var r *MarketplacePluginRequest
+	json.NewDecoder(data).Decode(&r)
+	return r
+}
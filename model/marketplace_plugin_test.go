@@ -0,0 +1,61 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarketplacePluginsJson(t *testing.T) {
+	plugins := []*MarketplacePlugin{
+		{
+			Id:   "com.example.plugin",
+			Name: "Example",
+			Versions: []*MarketplacePluginVersion{
+				{Version: "1.0.0", DownloadURL: "https://example.com/1.0.0.tar.gz", Signature: "c2lnbmF0dXJl"},
+			},
+		},
+	}
+
+	result := MarketplacePluginsFromJson(strings.NewReader(MarketplacePluginsToJson(plugins)))
+	assert.Equal(t, plugins, result)
+}
+
+func TestMarketplacePluginVersionIsCompatible(t *testing.T) {
+	assert.True(t, (&MarketplacePluginVersion{}).IsCompatible("5.12.0"))
+	assert.True(t, (&MarketplacePluginVersion{MinServerVersion: "5.12.0"}).IsCompatible("5.12.0"))
+	assert.True(t, (&MarketplacePluginVersion{MinServerVersion: "5.10.0"}).IsCompatible("5.12.0"))
+	assert.False(t, (&MarketplacePluginVersion{MinServerVersion: "5.14.0"}).IsCompatible("5.12.0"))
+	assert.False(t, (&MarketplacePluginVersion{MinServerVersion: "invalid"}).IsCompatible("5.12.0"))
+}
+
+func TestMarketplacePluginLatestCompatibleVersion(t *testing.T) {
+	p := &MarketplacePlugin{
+		Id: "com.example.plugin",
+		Versions: []*MarketplacePluginVersion{
+			{Version: "0.9.0"},
+			{Version: "1.1.0", MinServerVersion: "5.10.0"},
+			{Version: "2.0.0", MinServerVersion: "6.0.0"},
+			{Version: "not-a-version"},
+			{Version: "1.0.0"},
+		},
+	}
+
+	latest := p.LatestCompatibleVersion("5.12.0")
+	require.NotNil(t, latest)
+	assert.Equal(t, "1.1.0", latest.Version)
+
+	latest = p.LatestCompatibleVersion("6.1.0")
+	require.NotNil(t, latest)
+	assert.Equal(t, "2.0.0", latest.Version)
+
+	assert.Nil(t, (&MarketplacePlugin{}).LatestCompatibleVersion("5.12.0"))
+
+	assert.Equal(t, "0.9.0", p.GetVersion("0.9.0").Version)
+	assert.Nil(t, p.GetVersion("3.0.0"))
+}
@@ -5,4 +5,8 @@ package model
 
 const (
 	MIGRATION_KEY_ADVANCED_PERMISSIONS_PHASE_2 = "migration_advanced_permissions_phase_2"
+
+	// MIGRATION_KEY_BACKFILL_PREFIX prefixes the name of a backfill declared by a database
+	// migration to form the key of the migration job running it.
+	MIGRATION_KEY_BACKFILL_PREFIX = "migration_backfill_"
 )
@@ -66,6 +66,7 @@ var PERMISSION_DELETE_OTHERS_EMOJIS *Permission
 var PERMISSION_CREATE_POST *Permission
 var PERMISSION_CREATE_POST_PUBLIC *Permission
 var PERMISSION_CREATE_POST_EPHEMERAL *Permission
+var PERMISSION_CREATE_URGENT_POST *Permission
 var PERMISSION_EDIT_POST *Permission
 var PERMISSION_EDIT_OTHERS_POSTS *Permission
 var PERMISSION_DELETE_POST *Permission
@@ -410,6 +411,12 @@ func initializePermissions() {
 		"authentication.permissions.create_post_ephemeral.description",
 		PERMISSION_SCOPE_CHANNEL,
 	}
+	PERMISSION_CREATE_URGENT_POST = &Permission{
+		"create_urgent_post",
+		"authentication.permissions.create_urgent_post.name",
+		"authentication.permissions.create_urgent_post.description",
+		PERMISSION_SCOPE_CHANNEL,
+	}
 	PERMISSION_EDIT_POST = &Permission{
 		"edit_post",
 		"authentication.permissions.edit_post.name",
@@ -607,6 +614,7 @@ func initializePermissions() {
 		PERMISSION_CREATE_POST,
 		PERMISSION_CREATE_POST_PUBLIC,
 		PERMISSION_CREATE_POST_EPHEMERAL,
+		PERMISSION_CREATE_URGENT_POST,
 		PERMISSION_EDIT_POST,
 		PERMISSION_EDIT_OTHERS_POSTS,
 		PERMISSION_DELETE_POST,
@@ -0,0 +1,109 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// PluginConfigRevision records the settings of a plugin as saved by a single configuration change.
+// UserId is empty for changes not made by a user, such as those made by the plugin itself.
+type PluginConfigRevision struct {
+	Id          string          `json:"id"`
+	PluginId    string          `json:"plugin_id"`
+	UserId      string          `json:"user_id"`
+	CreateAt    int64           `json:"create_at"`
+	Settings    StringInterface `json:"settings"`
+	ChangedKeys StringArray     `json:"changed_keys"`
+}
+
+func (o *PluginConfigRevision) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+
+	if o.Settings == nil {
+		o.Settings = StringInterface{}
+	}
+}
+
+func (o *PluginConfigRevision) IsValid() *AppError {
+	if len(o.Id) != 26 {
+		return NewAppError("PluginConfigRevision.IsValid", "model.plugin_config_revision.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.PluginId == "" || len(o.PluginId) > KEY_VALUE_PLUGIN_ID_MAX_RUNES {
+		return NewAppError("PluginConfigRevision.IsValid", "model.plugin_config_revision.is_valid.plugin_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UserId != "" && len(o.UserId) != 26 {
+		return NewAppError("PluginConfigRevision.IsValid", "model.plugin_config_revision.is_valid.user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("PluginConfigRevision.IsValid", "model.plugin_config_revision.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *PluginConfigRevision) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PluginConfigRevisionFromJson(data io.Reader) *PluginConfigRevision {
+	var o *PluginConfigRevision
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func PluginConfigRevisionListToJson(l []*PluginConfigRevision) string {
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+func PluginConfigRevisionListFromJson(data io.Reader) []*PluginConfigRevision {
+	var l []*PluginConfigRevision
+	json.NewDecoder(data).Decode(&l)
+	return l
+}
+
+// PluginSettingsChangedKeys returns the sorted, lowercased keys whose values differ between two
+// versions of a plugin's settings.
+func PluginSettingsChangedKeys(oldSettings, newSettings map[string]interface{}) []string {
+	oldValues := make(map[string]interface{}, len(oldSettings))
+	for key, value := range oldSettings {
+		oldValues[strings.ToLower(key)] = value
+	}
+
+	newValues := make(map[string]interface{}, len(newSettings))
+	for key, value := range newSettings {
+		newValues[strings.ToLower(key)] = value
+	}
+
+	changed := []string{}
+	for key, value := range newValues {
+		if oldValue, ok := oldValues[key]; !ok || !reflect.DeepEqual(oldValue, value) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range oldValues {
+		if _, ok := newValues[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+
+	sort.Strings(changed)
+	return changed
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginConfigRevisionIsValid(t *testing.T) {
+	revision := &PluginConfigRevision{PluginId: "com.example.plugin"}
+	revision.PreSave()
+	require.Nil(t, revision.IsValid())
+	assert.NotNil(t, revision.Settings)
+
+	revision.UserId = NewId()
+	assert.Nil(t, revision.IsValid())
+
+	revision.UserId = "invalid"
+	assert.NotNil(t, revision.IsValid())
+
+	revision.UserId = ""
+	revision.PluginId = ""
+	assert.NotNil(t, revision.IsValid())
+
+	revision.PluginId = "com.example.plugin"
+	revision.CreateAt = 0
+	assert.NotNil(t, revision.IsValid())
+}
+
+func TestPluginConfigRevisionJson(t *testing.T) {
+	revision := &PluginConfigRevision{
+		PluginId:    "com.example.plugin",
+		Settings:    StringInterface{"token": "abc"},
+		ChangedKeys: StringArray{"token"},
+	}
+	revision.PreSave()
+
+	decoded := PluginConfigRevisionFromJson(strings.NewReader(revision.ToJson()))
+	assert.Equal(t, revision, decoded)
+
+	list := PluginConfigRevisionListFromJson(strings.NewReader(PluginConfigRevisionListToJson([]*PluginConfigRevision{revision})))
+	assert.Equal(t, []*PluginConfigRevision{revision}, list)
+}
+
+func TestPluginSettingsChangedKeys(t *testing.T) {
+	assert.Equal(t, []string{}, PluginSettingsChangedKeys(nil, nil))
+
+	assert.Equal(t,
+		[]string{"added", "changed", "removed"},
+		PluginSettingsChangedKeys(
+			map[string]interface{}{"Changed": "a", "Removed": true, "same": 1.0},
+			map[string]interface{}{"changed": "b", "Added": false, "Same": 1.0},
+		),
+	)
+}
@@ -11,6 +11,7 @@ import (
 const (
 	KEY_VALUE_PLUGIN_ID_MAX_RUNES = 190
 	KEY_VALUE_KEY_MAX_RUNES       = 50
+	KEY_VALUE_MULTI_MAX_KEYS      = 200
 )
 
 type PluginKeyValue struct {
@@ -31,3 +32,31 @@ func (kv *PluginKeyValue) IsValid() *AppError {
 
 	return nil
 }
+
+// PluginKVSetOptions controls how a key-value pair is written by SetWithOptions.
+type PluginKVSetOptions struct {
+	Atomic          bool   // Only store the value if the current value matches OldValue.
+	OldValue        []byte // The value to compare against when Atomic is true. Nil means the key must not exist.
+	ExpireInSeconds int64  // Remove the key after the given number of seconds. Zero means the key never expires.
+}
+
+func (opt *PluginKVSetOptions) IsValid() *AppError {
+	if !opt.Atomic && opt.OldValue != nil {
+		return NewAppError("PluginKVSetOptions.IsValid", "model.plugin_kvset_options.is_valid.old_value.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if opt.ExpireInSeconds < 0 {
+		return NewAppError("PluginKVSetOptions.IsValid", "model.plugin_kvset_options.is_valid.expire_in_seconds.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// ExpireAt returns the absolute expiry time in milliseconds for a key written at the given time,
+// or zero if the key does not expire.
+func (opt *PluginKVSetOptions) ExpireAt(now int64) int64 {
+	if opt.ExpireInSeconds > 0 {
+		return now + opt.ExpireInSeconds*1000
+	}
+	return 0
+}
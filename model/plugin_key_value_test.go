@@ -23,3 +23,25 @@ func TestPluginKeyIsValid(t *testing.T) {
 	kv.Key = "this is an extremely long key and should be invalid and this is being verified in this test"
 	assert.NotNil(t, kv.IsValid())
 }
+
+func TestPluginKVSetOptionsIsValid(t *testing.T) {
+	opt := PluginKVSetOptions{}
+	assert.Nil(t, opt.IsValid())
+
+	opt.OldValue = []byte("old")
+	assert.NotNil(t, opt.IsValid())
+
+	opt.Atomic = true
+	assert.Nil(t, opt.IsValid())
+
+	opt.ExpireInSeconds = -1
+	assert.NotNil(t, opt.IsValid())
+}
+
+func TestPluginKVSetOptionsExpireAt(t *testing.T) {
+	opt := PluginKVSetOptions{}
+	assert.Equal(t, int64(0), opt.ExpireAt(1000))
+
+	opt.ExpireInSeconds = 60
+	assert.Equal(t, int64(61000), opt.ExpireAt(1000))
+}
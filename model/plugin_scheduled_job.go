@@ -0,0 +1,66 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	// PLUGIN_SCHEDULED_JOB_NAME_MAX_LENGTH leaves room in a key of the plugin's key value store
+	// for the prefix of the key recording when the job last ran.
+	PLUGIN_SCHEDULED_JOB_NAME_MAX_LENGTH = 40
+	PLUGIN_SCHEDULED_JOB_MIN_INTERVAL    = time.Minute
+
+	PLUGIN_SCHEDULED_JOB_DATA_PLUGIN_ID = "plugin_id"
+	PLUGIN_SCHEDULED_JOB_DATA_NAME      = "name"
+)
+
+// PluginScheduledJob describes periodic work registered by a plugin. Exactly one of Schedule, a cron
+// expression, or Interval must be set. Each run is delivered to the plugin through the OnScheduledJob
+// hook on a single server in the cluster.
+type PluginScheduledJob struct {
+	Name     string
+	Schedule string
+	Interval time.Duration
+}
+
+func (j *PluginScheduledJob) IsValid() *AppError {
+	if j.Name == "" || len(j.Name) > PLUGIN_SCHEDULED_JOB_NAME_MAX_LENGTH || !IsValidAlphaNumHyphenUnderscore(j.Name, false) {
+		return NewAppError("PluginScheduledJob.IsValid", "model.plugin_scheduled_job.is_valid.name.app_error", nil, "name="+j.Name, http.StatusBadRequest)
+	}
+
+	if (j.Schedule == "") == (j.Interval == 0) {
+		return NewAppError("PluginScheduledJob.IsValid", "model.plugin_scheduled_job.is_valid.schedule.app_error", nil, "name="+j.Name, http.StatusBadRequest)
+	}
+
+	if j.Schedule != "" {
+		if _, err := ParseCronSchedule(j.Schedule); err != nil {
+			return NewAppError("PluginScheduledJob.IsValid", "model.plugin_scheduled_job.is_valid.schedule.app_error", nil, "name="+j.Name+", "+err.Error(), http.StatusBadRequest)
+		}
+	} else if j.Interval < PLUGIN_SCHEDULED_JOB_MIN_INTERVAL {
+		return NewAppError("PluginScheduledJob.IsValid", "model.plugin_scheduled_job.is_valid.interval.app_error", map[string]interface{}{"Min": PLUGIN_SCHEDULED_JOB_MIN_INTERVAL.String()}, "name="+j.Name, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// NextRunTime returns when the job should next run. lastRun is when the job was last scheduled, or the
+// zero time if it never has been, in which case an interval job runs straight away.
+func (j *PluginScheduledJob) NextRunTime(now time.Time, lastRun time.Time) time.Time {
+	if j.Schedule != "" {
+		schedule, err := ParseCronSchedule(j.Schedule)
+		if err != nil {
+			return time.Time{}
+		}
+		return schedule.Next(now)
+	}
+
+	if lastRun.IsZero() {
+		return now
+	}
+
+	return lastRun.Add(j.Interval)
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluginScheduledJobIsValid(t *testing.T) {
+	for _, tc := range []struct {
+		Description string
+		Job         PluginScheduledJob
+		Valid       bool
+	}{
+		{"cron schedule", PluginScheduledJob{Name: "cleanup", Schedule: "*/5 * * * *"}, true},
+		{"interval", PluginScheduledJob{Name: "sync_users", Interval: time.Hour}, true},
+		{"missing name", PluginScheduledJob{Schedule: "@daily"}, false},
+		{"invalid name", PluginScheduledJob{Name: "clean up", Schedule: "@daily"}, false},
+		{"name too long", PluginScheduledJob{Name: strings.Repeat("a", PLUGIN_SCHEDULED_JOB_NAME_MAX_LENGTH+1), Schedule: "@daily"}, false},
+		{"no schedule", PluginScheduledJob{Name: "cleanup"}, false},
+		{"schedule and interval", PluginScheduledJob{Name: "cleanup", Schedule: "@daily", Interval: time.Hour}, false},
+		{"invalid schedule", PluginScheduledJob{Name: "cleanup", Schedule: "every day"}, false},
+		{"interval too short", PluginScheduledJob{Name: "cleanup", Interval: time.Second}, false},
+	} {
+		t.Run(tc.Description, func(t *testing.T) {
+			if tc.Valid {
+				assert.Nil(t, tc.Job.IsValid())
+			} else {
+				assert.NotNil(t, tc.Job.IsValid())
+			}
+		})
+	}
+}
+
+func TestPluginScheduledJobNextRunTime(t *testing.T) {
+	now := time.Date(2019, time.July, 17, 10, 7, 30, 0, time.UTC)
+
+	job := PluginScheduledJob{Name: "sync", Interval: time.Hour}
+	assert.Equal(t, now, job.NextRunTime(now, time.Time{}), "an interval job that has never run should run straight away")
+	assert.Equal(t, now.Add(30*time.Minute), job.NextRunTime(now, now.Add(-30*time.Minute)))
+
+	job = PluginScheduledJob{Name: "sync", Schedule: "@hourly"}
+	assert.Equal(t, time.Date(2019, time.July, 17, 11, 0, 0, 0, time.UTC), job.NextRunTime(now, now.Add(-30*time.Minute)))
+}
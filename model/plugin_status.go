@@ -17,6 +17,20 @@ const (
 	PluginStateStopping            = 5 // unused by server
 )
 
+const (
+	PluginLimitViolationMemory          = "memory"
+	PluginLimitViolationHookTimeout     = "hook_timeout"
+	PluginLimitViolationHookConcurrency = "hook_concurrency"
+)
+
+// PluginLimitViolation summarizes how often a plugin has exceeded one of its resource limits.
+type PluginLimitViolation struct {
+	Type   string `json:"type"`
+	Count  int64  `json:"count"`
+	LastAt int64  `json:"last_at"`
+	Detail string `json:"detail"`
+}
+
 // PluginStatus provides a cluster-aware view of installed plugins.
 type PluginStatus struct {
 	PluginId    string `json:"plugin_id"`
@@ -26,6 +40,8 @@ type PluginStatus struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Version     string `json:"version"`
+
+	LimitViolations []*PluginLimitViolation `json:"limit_violations,omitempty"`
 }
 
 type PluginStatuses []*PluginStatus
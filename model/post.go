@@ -336,6 +336,27 @@ func (o *Post) IsSystemMessage() bool {
 	return len(o.Type) >= len(POST_SYSTEM_MESSAGE_PREFIX) && o.Type[:len(POST_SYSTEM_MESSAGE_PREFIX)] == POST_SYSTEM_MESSAGE_PREFIX
 }
 
+// IsJoinLeaveMessage returns whether the post is a system message about users joining or leaving
+// a channel or team, which doesn't count towards the messages of the channel.
+func (o *Post) IsJoinLeaveMessage() bool {
+	switch o.Type {
+	case
+		POST_JOIN_LEAVE,
+		POST_ADD_REMOVE,
+		POST_JOIN_CHANNEL,
+		POST_LEAVE_CHANNEL,
+		POST_JOIN_TEAM,
+		POST_LEAVE_TEAM,
+		POST_ADD_TO_CHANNEL,
+		POST_REMOVE_FROM_CHANNEL,
+		POST_ADD_TO_TEAM,
+		POST_REMOVE_FROM_TEAM:
+		return true
+	}
+
+	return false
+}
+
 func (p *Post) Patch(patch *PostPatch) {
 	if patch.IsPinned != nil {
 		p.IsPinned = *patch.IsPinned
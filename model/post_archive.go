@@ -0,0 +1,103 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// POST_ARCHIVE_DIRECTORY is the directory of the file store holding the archived posts.
+const POST_ARCHIVE_DIRECTORY = "archive/posts/"
+
+// PostArchiveIndex records which archive file of the file store holds a post moved out of the
+// Posts table, so that it can still be found by id. The text of the post is kept along with it, so
+// that it can still be found by searches.
+type PostArchiveIndex struct {
+	PostId    string `json:"post_id"`
+	ChannelId string `json:"channel_id"`
+	RootId    string `json:"root_id"`
+	UserId    string `json:"user_id"`
+	Type      string `json:"type"`
+	CreateAt  int64  `json:"create_at"`
+	DeleteAt  int64  `json:"delete_at"`
+	Message   string `json:"message"`
+	Hashtags  string `json:"hashtags"`
+	Path      string `json:"path"`
+}
+
+// NewPostArchiveIndex returns the index of a post written to the archive file at path.
+func NewPostArchiveIndex(post *Post, path string) *PostArchiveIndex {
+	return &PostArchiveIndex{
+		PostId:    post.Id,
+		ChannelId: post.ChannelId,
+		RootId:    post.RootId,
+		UserId:    post.UserId,
+		Type:      post.Type,
+		CreateAt:  post.CreateAt,
+		DeleteAt:  post.DeleteAt,
+		Message:   post.Message,
+		Hashtags:  post.Hashtags,
+		Path:      path,
+	}
+}
+
+// NewPostArchivePath returns the path of a new archive file for posts of the given channel.
+func NewPostArchivePath(channelId string, archivedAt int64) string {
+	return fmt.Sprintf("%s%s/%s-%s.jsonl.gz", POST_ARCHIVE_DIRECTORY, channelId, time.Unix(0, archivedAt*int64(time.Millisecond)).UTC().Format("20060102"), NewId())
+}
+
+// EncodePostArchive encodes the posts as gzipped JSON, one post per line.
+func EncodePostArchive(posts []*Post) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+
+	encoder := json.NewEncoder(writer)
+	for _, post := range posts {
+		if err := encoder.Encode(post); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// DecodePostArchive decodes the posts of an archive file written by EncodePostArchive.
+func DecodePostArchive(data []byte) ([]*Post, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var posts []*Post
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var post Post
+		if err := json.Unmarshal(scanner.Bytes(), &post); err != nil {
+			return nil, err
+		}
+		posts = append(posts, &post)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
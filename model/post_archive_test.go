@@ -0,0 +1,39 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostArchive(t *testing.T) {
+	root := &Post{Id: NewId(), ChannelId: NewId(), UserId: NewId(), Message: "root\nwith a new line", CreateAt: 1}
+	reply := &Post{Id: NewId(), ChannelId: root.ChannelId, UserId: NewId(), RootId: root.Id, Message: "reply", CreateAt: 2}
+	reply.AddProp("key", "value")
+
+	data, err := EncodePostArchive([]*Post{root, reply})
+	require.Nil(t, err)
+
+	posts, err := DecodePostArchive(data)
+	require.Nil(t, err)
+	require.Len(t, posts, 2)
+	assert.Equal(t, root, posts[0])
+	assert.Equal(t, reply.Id, posts[1].Id)
+	assert.Equal(t, "value", posts[1].Props["key"])
+
+	_, err = DecodePostArchive([]byte("not gzipped"))
+	assert.NotNil(t, err)
+}
+
+func TestNewPostArchivePath(t *testing.T) {
+	channelId := NewId()
+	path := NewPostArchivePath(channelId, 1546300800000)
+	assert.True(t, strings.HasPrefix(path, POST_ARCHIVE_DIRECTORY+channelId+"/20190101-"), path)
+	assert.True(t, strings.HasSuffix(path, ".jsonl.gz"), path)
+	assert.NotEqual(t, path, NewPostArchivePath(channelId, 1546300800000))
+}
@@ -26,6 +26,13 @@ type PostMetadata struct {
 
 	// Reactions holds reactions made to the post.
 	Reactions []*Reaction `json:"reactions,omitempty"`
+
+	// Priority holds the priority of the post and whether it requests acknowledgement. Unlike the other fields,
+	// this is set by the client when creating the post.
+	Priority *PostPriority `json:"priority,omitempty"`
+
+	// Acknowledgements holds the acknowledgements made to the post if it requested them.
+	Acknowledgements []*PostAcknowledgement `json:"acknowledgements,omitempty"`
 }
 
 type PostImage struct {
@@ -0,0 +1,137 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+const (
+	POST_PRIORITY_STANDARD  = "standard"
+	POST_PRIORITY_IMPORTANT = "important"
+	POST_PRIORITY_URGENT    = "urgent"
+)
+
+// PostPriority marks a post as more important than a regular message. Urgent posts are delivered
+// even to users in Do Not Disturb and are re-sent to the users mentioned in them until they
+// acknowledge the post, so they always request acknowledgement.
+type PostPriority struct {
+	PostId       string `json:"post_id"`
+	ChannelId    string `json:"channel_id"`
+	Priority     string `json:"priority"`
+	RequestedAck bool   `json:"requested_ack"`
+
+	// LastNotifiedAt and NotificationCount track the persistent notifications sent for urgent posts.
+	LastNotifiedAt    int64 `json:"-"`
+	NotificationCount int   `json:"-"`
+}
+
+// PostAcknowledgement records that a user has acknowledged a post that requested it.
+type PostAcknowledgement struct {
+	PostId         string `json:"post_id"`
+	UserId         string `json:"user_id"`
+	AcknowledgedAt int64  `json:"acknowledged_at"`
+}
+
+// PostAcknowledgementStatus describes which of the users expected to acknowledge a post have done
+// so and which haven't yet.
+type PostAcknowledgementStatus struct {
+	PostId           string                 `json:"post_id"`
+	Acknowledgements []*PostAcknowledgement `json:"acknowledgements"`
+	PendingUserIds   []string               `json:"pending_user_ids"`
+}
+
+func (o *PostPriority) PreSave() {
+	if o.Priority == "" {
+		o.Priority = POST_PRIORITY_STANDARD
+	}
+
+	if o.Priority == POST_PRIORITY_URGENT {
+		o.RequestedAck = true
+	}
+}
+
+func (o *PostPriority) IsValid() *AppError {
+	if len(o.PostId) != 26 {
+		return NewAppError("PostPriority.IsValid", "model.post_priority.is_valid.post_id.app_error", nil, "post_id="+o.PostId, http.StatusBadRequest)
+	}
+
+	if len(o.ChannelId) != 26 {
+		return NewAppError("PostPriority.IsValid", "model.post_priority.is_valid.channel_id.app_error", nil, "channel_id="+o.ChannelId, http.StatusBadRequest)
+	}
+
+	switch o.Priority {
+	case POST_PRIORITY_STANDARD, POST_PRIORITY_IMPORTANT, POST_PRIORITY_URGENT:
+	default:
+		return NewAppError("PostPriority.IsValid", "model.post_priority.is_valid.priority.app_error", nil, "priority="+o.Priority, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// IsUrgent returns true if the post has urgent priority.
+func (o *PostPriority) IsUrgent() bool {
+	return o != nil && o.Priority == POST_PRIORITY_URGENT
+}
+
+func (o *PostAcknowledgement) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PostAcknowledgementFromJson(data io.Reader) *PostAcknowledgement {
+	var o *PostAcknowledgement
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func (o *PostAcknowledgement) PreSave() {
+	if o.AcknowledgedAt == 0 {
+		o.AcknowledgedAt = GetMillis()
+	}
+}
+
+func (o *PostAcknowledgement) IsValid() *AppError {
+	if len(o.PostId) != 26 {
+		return NewAppError("PostAcknowledgement.IsValid", "model.post_acknowledgement.is_valid.post_id.app_error", nil, "post_id="+o.PostId, http.StatusBadRequest)
+	}
+
+	if len(o.UserId) != 26 {
+		return NewAppError("PostAcknowledgement.IsValid", "model.post_acknowledgement.is_valid.user_id.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	if o.AcknowledgedAt == 0 {
+		return NewAppError("PostAcknowledgement.IsValid", "model.post_acknowledgement.is_valid.acknowledged_at.app_error", nil, "post_id="+o.PostId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *PostAcknowledgementStatus) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PostAcknowledgementStatusFromJson(data io.Reader) *PostAcknowledgementStatus {
+	var o *PostAcknowledgementStatus
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+// GetPriority returns the priority of the post from its metadata, or nil if it has none.
+func (o *Post) GetPriority() *PostPriority {
+	if o.Metadata == nil {
+		return nil
+	}
+
+	return o.Metadata.Priority
+}
+
+// IsUrgent returns true if the post has urgent priority, which allows it to be delivered to users
+// who are in Do Not Disturb or outside of their working hours.
+func (o *Post) IsUrgent() bool {
+	return o.GetPriority().IsUrgent()
+}
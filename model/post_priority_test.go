@@ -0,0 +1,72 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostPriorityIsValid(t *testing.T) {
+	priority := &PostPriority{PostId: NewId(), ChannelId: NewId()}
+	priority.PreSave()
+	require.Nil(t, priority.IsValid())
+	assert.Equal(t, POST_PRIORITY_STANDARD, priority.Priority)
+	assert.False(t, priority.RequestedAck)
+
+	priority.Priority = POST_PRIORITY_URGENT
+	priority.PreSave()
+	require.Nil(t, priority.IsValid())
+	assert.True(t, priority.RequestedAck, "urgent posts should always request acknowledgement")
+
+	priority.Priority = "critical"
+	require.NotNil(t, priority.IsValid())
+
+	priority.Priority = POST_PRIORITY_IMPORTANT
+	priority.PostId = "invalid"
+	require.NotNil(t, priority.IsValid())
+}
+
+func TestPostIsUrgent(t *testing.T) {
+	post := &Post{}
+	assert.False(t, post.IsUrgent())
+
+	post.Metadata = &PostMetadata{}
+	assert.False(t, post.IsUrgent())
+
+	post.Metadata.Priority = &PostPriority{Priority: POST_PRIORITY_IMPORTANT}
+	assert.False(t, post.IsUrgent())
+
+	post.Metadata.Priority.Priority = POST_PRIORITY_URGENT
+	assert.True(t, post.IsUrgent())
+}
+
+func TestPostPriorityJson(t *testing.T) {
+	post := PostFromJson(strings.NewReader(`{"message": "test", "metadata": {"priority": {"priority": "urgent", "requested_ack": true}}}`))
+	require.NotNil(t, post.GetPriority())
+	assert.Equal(t, POST_PRIORITY_URGENT, post.GetPriority().Priority)
+	assert.True(t, post.GetPriority().RequestedAck)
+}
+
+func TestPostAcknowledgementIsValid(t *testing.T) {
+	ack := &PostAcknowledgement{PostId: NewId(), UserId: NewId()}
+	require.NotNil(t, ack.IsValid())
+
+	ack.PreSave()
+	require.Nil(t, ack.IsValid())
+
+	ack.UserId = ""
+	require.NotNil(t, ack.IsValid())
+}
+
+func TestPostAcknowledgementJson(t *testing.T) {
+	ack := &PostAcknowledgement{PostId: NewId(), UserId: NewId(), AcknowledgedAt: GetMillis()}
+	assert.Equal(t, ack, PostAcknowledgementFromJson(strings.NewReader(ack.ToJson())))
+
+	status := &PostAcknowledgementStatus{PostId: ack.PostId, Acknowledgements: []*PostAcknowledgement{ack}, PendingUserIds: []string{NewId()}}
+	assert.Equal(t, status, PostAcknowledgementStatusFromJson(strings.NewReader(status.ToJson())))
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"hash/fnv"
+)
+
+const (
+	POST_SHARD_KEY_CHANNEL = "channel"
+	POST_SHARD_KEY_TEAM    = "team"
+
+	// POST_SHARD_MAIN is the previous shard of channels moved out of the main database.
+	POST_SHARD_MAIN = "main"
+)
+
+// ChannelShard records the database shard holding the posts of a channel. The posts of channels
+// without a shard are in the main database. PreviousShard is the shard the channel was last moved
+// from, or POST_SHARD_MAIN for the main database, until the posts left behind there are removed.
+type ChannelShard struct {
+	ChannelId     string `json:"channel_id"`
+	TeamId        string `json:"team_id" db:"-"`
+	Shard         string `json:"shard"`
+	PreviousShard string `json:"previous_shard"`
+	UpdateAt      int64  `json:"update_at"`
+}
+
+// PostShardData is a batch of the posts of a channel, along with their reactions, file infos and
+// archive index entries, as copied from one database shard to another.
+type PostShardData struct {
+	Posts          []*Post             `json:"posts"`
+	Reactions      []*Reaction         `json:"reactions"`
+	FileInfos      []*FileInfo         `json:"file_infos"`
+	ArchiveIndexes []*PostArchiveIndex `json:"archive_indexes"`
+}
+
+// IsEmpty returns whether the batch holds nothing to copy.
+func (o *PostShardData) IsEmpty() bool {
+	return len(o.Posts) == 0 && len(o.Reactions) == 0 && len(o.FileInfos) == 0 && len(o.ArchiveIndexes) == 0
+}
+
+// PostShardKey returns the key placing the posts of the channel on a shard: the team of the
+// channel when sharding by team, or else the channel itself. Direct and group channels belong to
+// no team and are always placed by channel.
+func PostShardKey(channelId string, teamId string, shardKey string) string {
+	if shardKey == POST_SHARD_KEY_TEAM && teamId != "" {
+		return teamId
+	}
+
+	return channelId
+}
+
+// PickPostShard returns which of the shards the key is placed on. The shards are ranked by a hash
+// of the key and their name, so adding or removing a shard only moves the keys placed on it.
+func PickPostShard(key string, shards []string) string {
+	var picked string
+	var pickedScore uint64
+	for _, shard := range shards {
+		hash := fnv.New64a()
+		hash.Write([]byte(shard))
+		hash.Write([]byte{0})
+		hash.Write([]byte(key))
+		score := mixPostShardHash(hash.Sum64())
+
+		if picked == "" || score > pickedScore || (score == pickedScore && shard < picked) {
+			picked = shard
+			pickedScore = score
+		}
+	}
+
+	return picked
+}
+
+// mixPostShardHash spreads the bits of an FNV hash, which differ little between similar inputs.
+func mixPostShardHash(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
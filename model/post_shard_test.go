@@ -0,0 +1,58 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostShardKey(t *testing.T) {
+	assert.Equal(t, "channel", PostShardKey("channel", "team", POST_SHARD_KEY_CHANNEL))
+	assert.Equal(t, "team", PostShardKey("channel", "team", POST_SHARD_KEY_TEAM))
+	assert.Equal(t, "channel", PostShardKey("channel", "", POST_SHARD_KEY_TEAM))
+}
+
+func TestPickPostShard(t *testing.T) {
+	assert.Equal(t, "", PickPostShard(NewId(), nil))
+
+	shards := []string{"shard1", "shard2", "shard3"}
+
+	keys := make([]string, 3000)
+	for i := range keys {
+		keys[i] = NewId()
+	}
+
+	t.Run("placement is stable and independent of the order of the shards", func(t *testing.T) {
+		for _, key := range keys[:100] {
+			assert.Equal(t, PickPostShard(key, shards), PickPostShard(key, []string{"shard3", "shard1", "shard2"}))
+		}
+	})
+
+	t.Run("keys are spread over the shards", func(t *testing.T) {
+		counts := make(map[string]int)
+		for _, key := range keys {
+			counts[PickPostShard(key, shards)]++
+		}
+
+		for _, shard := range shards {
+			assert.InDelta(t, 1000, counts[shard], 200, shard)
+		}
+	})
+
+	t.Run("adding a shard only moves keys to it", func(t *testing.T) {
+		moved := 0
+		for _, key := range keys {
+			before := PickPostShard(key, shards)
+			after := PickPostShard(key, append([]string{"shard4"}, shards...))
+			if after != before {
+				assert.Equal(t, "shard4", after)
+				moved++
+			}
+		}
+
+		assert.InDelta(t, 750, moved, 200)
+	})
+}
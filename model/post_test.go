@@ -132,6 +132,13 @@ func TestPostIsSystemMessage(t *testing.T) {
 	}
 }
 
+func TestPostIsJoinLeaveMessage(t *testing.T) {
+	assert.True(t, (&Post{Type: POST_JOIN_CHANNEL}).IsJoinLeaveMessage())
+	assert.True(t, (&Post{Type: POST_REMOVE_FROM_TEAM}).IsJoinLeaveMessage())
+	assert.False(t, (&Post{Type: POST_HEADER_CHANGE}).IsJoinLeaveMessage())
+	assert.False(t, (&Post{}).IsJoinLeaveMessage())
+}
+
 func TestPostChannelMentions(t *testing.T) {
 	post := Post{Message: "~a ~b ~b ~c/~d."}
 	assert.Equal(t, []string{"a", "b", "c", "d"}, post.ChannelMentions())
@@ -47,6 +47,11 @@ const (
 	PREFERENCE_EMAIL_INTERVAL_FIFTEEN_AS_SECONDS  = "900"
 	PREFERENCE_EMAIL_INTERVAL_HOUR                = "hour"
 	PREFERENCE_EMAIL_INTERVAL_HOUR_AS_SECONDS     = "3600"
+
+	PREFERENCE_CATEGORY_PRIVACY      = "privacy"
+	PREFERENCE_NAME_READ_RECEIPTS    = "read_receipts"
+	PREFERENCE_READ_RECEIPTS_ENABLED = "true"
+	PREFERENCE_READ_RECEIPTS_HIDDEN  = "false"
 )
 
 type Preference struct {
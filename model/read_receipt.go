@@ -0,0 +1,44 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// ReadReceipt records how far a channel member has read, as of their last view of the channel.
+type ReadReceipt struct {
+	UserId       string `json:"user_id"`
+	LastViewedAt int64  `json:"last_viewed_at"`
+}
+
+// PostReadReceipts lists the members of a direct or group message channel that have read up to
+// and including a given post.
+type PostReadReceipts struct {
+	PostId    string         `json:"post_id"`
+	ChannelId string         `json:"channel_id"`
+	ReadBy    []*ReadReceipt `json:"read_by"`
+}
+
+func (o *PostReadReceipts) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PostReadReceiptsFromJson(data io.Reader) *PostReadReceipts {
+	var o *PostReadReceipts
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+// HasRead returns true if the given user has read up to the post.
+func (o *PostReadReceipts) HasRead(userId string) bool {
+	for _, receipt := range o.ReadBy {
+		if receipt.UserId == userId {
+			return true
+		}
+	}
+	return false
+}
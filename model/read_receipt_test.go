@@ -0,0 +1,37 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostReadReceiptsJson(t *testing.T) {
+	o := PostReadReceipts{
+		PostId:    NewId(),
+		ChannelId: NewId(),
+		ReadBy: []*ReadReceipt{
+			{UserId: NewId(), LastViewedAt: GetMillis()},
+		},
+	}
+
+	ro := PostReadReceiptsFromJson(strings.NewReader(o.ToJson()))
+	assert.Equal(t, o, *ro)
+}
+
+func TestPostReadReceiptsHasRead(t *testing.T) {
+	userId := NewId()
+	o := PostReadReceipts{
+		PostId: NewId(),
+		ReadBy: []*ReadReceipt{
+			{UserId: userId, LastViewedAt: GetMillis()},
+		},
+	}
+
+	assert.True(t, o.HasRead(userId))
+	assert.False(t, o.HasRead(NewId()))
+}
@@ -31,6 +31,11 @@ type SearchParams struct {
 	TimeZoneOffset         int
 	// True if this search doesn't originate from a "current user".
 	SearchWithoutUserId bool
+	// ChannelIds and UserIds, when set, are the channels searched and the authors searched for
+	// in place of those looked up from the channel memberships and the user filters. They are
+	// resolved by the store, such as when the posts are kept apart from the channels and users.
+	ChannelIds []string `json:"-"`
+	UserIds    []string `json:"-"`
 }
 
 // Returns the epoch timestamp of the start of the day specified by SearchParams.AfterDate
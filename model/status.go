@@ -25,6 +25,11 @@ type Status struct {
 	Manual         bool   `json:"manual"`
 	LastActivityAt int64  `json:"last_activity_at"`
 	ActiveChannel  string `json:"active_channel,omitempty" db:"-"`
+
+	// DNDEndTime is the time in milliseconds at which a Do Not Disturb status ends and the user
+	// returns to PrevStatus, or 0 if it lasts until the user changes it.
+	DNDEndTime int64  `json:"dnd_end_time"`
+	PrevStatus string `json:"prev_status,omitempty"`
 }
 
 func (o *Status) ToJson() string {
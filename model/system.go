@@ -18,6 +18,7 @@ const (
 	SYSTEM_ASYMMETRIC_SIGNING_KEY    = "AsymmetricSigningKey"
 	SYSTEM_POST_ACTION_COOKIE_SECRET = "PostActionCookieSecret"
 	SYSTEM_INSTALLATION_DATE_KEY     = "InstallationDate"
+	SYSTEM_AUDIT_LOG_HEAD            = "AuditLogHead"
 )
 
 type System struct {
@@ -290,6 +290,12 @@ func (u *User) IsValid() *AppError {
 		return InvalidUserError("locale", u.Id)
 	}
 
+	if workingHours, ok := u.NotifyProps[WORKING_HOURS_NOTIFY_PROP]; ok && workingHours != "" {
+		if wh := WorkingHoursFromJson(strings.NewReader(workingHours)); wh == nil || !wh.IsValid() {
+			return InvalidUserError("working_hours", u.Id)
+		}
+	}
+
 	return nil
 }
 
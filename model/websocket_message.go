@@ -37,6 +37,7 @@ const (
 	WEBSOCKET_EVENT_PREFERENCES_DELETED     = "preferences_deleted"
 	WEBSOCKET_EVENT_EPHEMERAL_MESSAGE       = "ephemeral_message"
 	WEBSOCKET_EVENT_STATUS_CHANGE           = "status_change"
+	WEBSOCKET_EVENT_CUSTOM_STATUS_CHANGE    = "custom_status_change"
 	WEBSOCKET_EVENT_HELLO                   = "hello"
 	WEBSOCKET_AUTHENTICATION_CHALLENGE      = "authentication_challenge"
 	WEBSOCKET_EVENT_REACTION_ADDED          = "reaction_added"
@@ -51,6 +52,9 @@ const (
 	WEBSOCKET_EVENT_LICENSE_CHANGED         = "license_changed"
 	WEBSOCKET_EVENT_CONFIG_CHANGED          = "config_changed"
 	WEBSOCKET_EVENT_OPEN_DIALOG             = "open_dialog"
+	WEBSOCKET_EVENT_ACKNOWLEDGEMENT_ADDED   = "post_acknowledgement_added"
+	WEBSOCKET_EVENT_ACKNOWLEDGEMENT_REMOVED = "post_acknowledgement_removed"
+	WEBSOCKET_EVENT_READ_RECEIPT            = "read_receipt"
 )
 
 type WebSocketMessage interface {
@@ -0,0 +1,172 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	WORKING_HOURS_NOTIFY_PROP = "working_hours"
+
+	workingHoursTimeLayout = "15:04"
+)
+
+// WorkingHours is a weekly schedule, in the user's timezone, outside of which the user's
+// notifications are held back and their status is set to Do Not Disturb.
+type WorkingHours struct {
+	Enabled bool                 `json:"enabled"`
+	Ranges  []*WorkingHoursRange `json:"ranges"`
+}
+
+// WorkingHoursRange is a period of working time on a single day of the week. Start and End are
+// formatted as "15:04". Periods spanning midnight must be split into two ranges.
+type WorkingHoursRange struct {
+	Day   time.Weekday `json:"day"`
+	Start string       `json:"start"`
+	End   string       `json:"end"`
+}
+
+func (wh *WorkingHours) ToJson() string {
+	b, _ := json.Marshal(wh)
+	return string(b)
+}
+
+func WorkingHoursFromJson(data io.Reader) *WorkingHours {
+	var wh *WorkingHours
+	json.NewDecoder(data).Decode(&wh)
+	return wh
+}
+
+func (wh *WorkingHours) IsValid() bool {
+	for _, r := range wh.Ranges {
+		if r == nil || r.Day < time.Sunday || r.Day > time.Saturday {
+			return false
+		}
+
+		start, startErr := time.Parse(workingHoursTimeLayout, r.Start)
+		end, endErr := time.Parse(workingHoursTimeLayout, r.End)
+		if startErr != nil || endErr != nil || !start.Before(end) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// bounds returns the start and end of the range on the same day as t.
+func (r *WorkingHoursRange) bounds(t time.Time) (time.Time, time.Time) {
+	start, _ := time.Parse(workingHoursTimeLayout, r.Start)
+	end, _ := time.Parse(workingHoursTimeLayout, r.End)
+
+	year, month, day := t.Date()
+	return time.Date(year, month, day, start.Hour(), start.Minute(), 0, 0, t.Location()),
+		time.Date(year, month, day, end.Hour(), end.Minute(), 0, 0, t.Location())
+}
+
+// IsWithin returns true if t falls inside one of the working hours ranges. A disabled schedule
+// covers all times. t is expected to be in the user's location.
+func (wh *WorkingHours) IsWithin(t time.Time) bool {
+	if !wh.Enabled {
+		return true
+	}
+
+	for _, r := range wh.Ranges {
+		if r.Day != t.Weekday() {
+			continue
+		}
+
+		start, end := r.bounds(t)
+		if !t.Before(start) && t.Before(end) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NextStart returns the first time after t at which a working hours range begins, or the zero
+// time if there are none.
+func (wh *WorkingHours) NextStart(t time.Time) time.Time {
+	var next time.Time
+
+	for i := 0; i <= 7; i++ {
+		day := t.AddDate(0, 0, i)
+		for _, r := range wh.Ranges {
+			if r.Day != day.Weekday() {
+				continue
+			}
+
+			start, _ := r.bounds(day)
+			if start.After(t) && (next.IsZero() || start.Before(next)) {
+				next = start
+			}
+		}
+
+		if !next.IsZero() {
+			return next
+		}
+	}
+
+	return next
+}
+
+// NextEnd returns the first time after t at which a working hours range ends, or the zero time if
+// there are none.
+func (wh *WorkingHours) NextEnd(t time.Time) time.Time {
+	var next time.Time
+
+	for i := 0; i <= 7; i++ {
+		day := t.AddDate(0, 0, i)
+		for _, r := range wh.Ranges {
+			if r.Day != day.Weekday() {
+				continue
+			}
+
+			_, end := r.bounds(day)
+			if end.After(t) && (next.IsZero() || end.Before(next)) {
+				next = end
+			}
+		}
+
+		if !next.IsZero() {
+			return next
+		}
+	}
+
+	return next
+}
+
+// GetWorkingHours returns the user's working hours schedule or nil if they haven't set one.
+func (u *User) GetWorkingHours() *WorkingHours {
+	if u.NotifyProps == nil || u.NotifyProps[WORKING_HOURS_NOTIFY_PROP] == "" {
+		return nil
+	}
+
+	return WorkingHoursFromJson(strings.NewReader(u.NotifyProps[WORKING_HOURS_NOTIFY_PROP]))
+}
+
+// GetLocation returns the user's preferred timezone, falling back to UTC if it is unknown.
+func (u *User) GetLocation() *time.Location {
+	location, err := time.LoadLocation(u.GetPreferredTimezone())
+	if err != nil {
+		return time.UTC
+	}
+
+	return location
+}
+
+// IsWithinWorkingHours returns true if the given time is inside the user's working hours, or if
+// they don't have a working hours schedule.
+func (u *User) IsWithinWorkingHours(t time.Time) bool {
+	workingHours := u.GetWorkingHours()
+	if workingHours == nil {
+		return true
+	}
+
+	return workingHours.IsWithin(t.In(u.GetLocation()))
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkingHoursJson(t *testing.T) {
+	wh := &WorkingHours{Enabled: true, Ranges: []*WorkingHoursRange{{Day: time.Monday, Start: "09:00", End: "17:00"}}}
+	result := WorkingHoursFromJson(strings.NewReader(wh.ToJson()))
+	require.NotNil(t, result)
+	assert.Equal(t, wh, result)
+}
+
+func TestWorkingHoursIsValid(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		ranges   []*WorkingHoursRange
+		expected bool
+	}{
+		{"empty", nil, true},
+		{"valid", []*WorkingHoursRange{{Day: time.Monday, Start: "09:00", End: "17:00"}}, true},
+		{"invalid day", []*WorkingHoursRange{{Day: 7, Start: "09:00", End: "17:00"}}, false},
+		{"invalid start", []*WorkingHoursRange{{Day: time.Monday, Start: "9am", End: "17:00"}}, false},
+		{"end before start", []*WorkingHoursRange{{Day: time.Monday, Start: "17:00", End: "09:00"}}, false},
+		{"nil range", []*WorkingHoursRange{nil}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			wh := &WorkingHours{Enabled: true, Ranges: tc.ranges}
+			assert.Equal(t, tc.expected, wh.IsValid())
+		})
+	}
+}
+
+func TestWorkingHoursIsWithin(t *testing.T) {
+	wh := &WorkingHours{
+		Enabled: true,
+		Ranges: []*WorkingHoursRange{
+			{Day: time.Monday, Start: "09:00", End: "12:00"},
+			{Day: time.Monday, Start: "13:00", End: "17:00"},
+		},
+	}
+
+	// 2019-09-02 was a Monday
+	assert.False(t, wh.IsWithin(time.Date(2019, 9, 2, 8, 59, 0, 0, time.UTC)))
+	assert.True(t, wh.IsWithin(time.Date(2019, 9, 2, 9, 0, 0, 0, time.UTC)))
+	assert.False(t, wh.IsWithin(time.Date(2019, 9, 2, 12, 30, 0, 0, time.UTC)))
+	assert.True(t, wh.IsWithin(time.Date(2019, 9, 2, 16, 59, 0, 0, time.UTC)))
+	assert.False(t, wh.IsWithin(time.Date(2019, 9, 2, 17, 0, 0, 0, time.UTC)))
+	assert.False(t, wh.IsWithin(time.Date(2019, 9, 3, 10, 0, 0, 0, time.UTC)))
+
+	wh.Enabled = false
+	assert.True(t, wh.IsWithin(time.Date(2019, 9, 3, 10, 0, 0, 0, time.UTC)))
+}
+
+func TestWorkingHoursNextStart(t *testing.T) {
+	wh := &WorkingHours{
+		Enabled: true,
+		Ranges: []*WorkingHoursRange{
+			{Day: time.Monday, Start: "09:00", End: "17:00"},
+			{Day: time.Friday, Start: "09:00", End: "12:00"},
+		},
+	}
+
+	assert.Equal(t, time.Date(2019, 9, 6, 9, 0, 0, 0, time.UTC), wh.NextStart(time.Date(2019, 9, 2, 17, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2019, 9, 9, 9, 0, 0, 0, time.UTC), wh.NextStart(time.Date(2019, 9, 6, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2019, 9, 2, 9, 0, 0, 0, time.UTC), wh.NextStart(time.Date(2019, 9, 2, 8, 0, 0, 0, time.UTC)))
+
+	assert.True(t, (&WorkingHours{Enabled: true}).NextStart(time.Now()).IsZero())
+}
+
+func TestWorkingHoursNextEnd(t *testing.T) {
+	wh := &WorkingHours{
+		Enabled: true,
+		Ranges: []*WorkingHoursRange{
+			{Day: time.Monday, Start: "09:00", End: "17:00"},
+			{Day: time.Friday, Start: "09:00", End: "12:00"},
+		},
+	}
+
+	assert.Equal(t, time.Date(2019, 9, 2, 17, 0, 0, 0, time.UTC), wh.NextEnd(time.Date(2019, 9, 2, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2019, 9, 6, 12, 0, 0, 0, time.UTC), wh.NextEnd(time.Date(2019, 9, 2, 17, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2019, 9, 9, 17, 0, 0, 0, time.UTC), wh.NextEnd(time.Date(2019, 9, 6, 12, 0, 0, 0, time.UTC)))
+
+	assert.True(t, (&WorkingHours{Enabled: true}).NextEnd(time.Now()).IsZero())
+}
+
+func TestUserIsWithinWorkingHours(t *testing.T) {
+	user := &User{NotifyProps: StringMap{}, Timezone: StringMap{"useAutomaticTimezone": "false", "manualTimezone": "America/New_York"}}
+	monday := time.Date(2019, 9, 2, 14, 0, 0, 0, time.UTC)
+
+	assert.True(t, user.IsWithinWorkingHours(monday), "users without working hours are always available")
+
+	wh := &WorkingHours{Enabled: true, Ranges: []*WorkingHoursRange{{Day: time.Monday, Start: "09:00", End: "10:00"}}}
+	user.NotifyProps[WORKING_HOURS_NOTIFY_PROP] = wh.ToJson()
+
+	// 14:00 UTC is 10:00 in New York during daylight saving time
+	assert.False(t, user.IsWithinWorkingHours(monday))
+	assert.True(t, user.IsWithinWorkingHours(monday.Add(-time.Minute)))
+}
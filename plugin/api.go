@@ -487,9 +487,31 @@ type API interface {
 	// Minimum server version: 5.6
 	KVSetWithExpiry(key string, value []byte, expireInSeconds int64) *model.AppError
 
+	// KVSetWithOptions stores a key-value pair, unique per plugin, according to the given options.
+	// If options.Atomic is set, the value is only stored if the current value matches options.OldValue,
+	// or, when options.OldValue is nil, if the key does not exist. Setting a nil value removes the key.
+	// Returns (false, err) if DB error occurred
+	// Returns (false, nil) if the value was not set
+	// Returns (true, nil) if the value was set
+	//
+	// Minimum server version: 5.15
+	KVSetWithOptions(key string, value []byte, options model.PluginKVSetOptions) (bool, *model.AppError)
+
+	// KVSetMulti stores the given key-value pairs, unique per plugin, in a single transaction.
+	// Either all of the values are stored or none of them are. A nil value removes the key.
+	//
+	// Minimum server version: 5.15
+	KVSetMulti(values map[string][]byte) *model.AppError
+
 	// KVGet retrieves a value based on the key, unique per plugin. Returns nil for non-existent keys.
 	KVGet(key string) ([]byte, *model.AppError)
 
+	// KVGetMulti retrieves the values for the given keys, unique per plugin. Non-existent keys are
+	// omitted from the result.
+	//
+	// Minimum server version: 5.15
+	KVGetMulti(keys []string) (map[string][]byte, *model.AppError)
+
 	// KVDelete removes a key-value pair, unique per plugin. Returns nil for non-existent keys.
 	KVDelete(key string) *model.AppError
 
@@ -503,6 +525,39 @@ type API interface {
 	// Minimum server version: 5.6
 	KVList(page, perPage int) ([]string, *model.AppError)
 
+	// KVListWithPrefix lists the keys for a plugin that start with the given prefix, leaving out expired keys.
+	//
+	// Minimum server version: 5.15
+	KVListWithPrefix(prefix string, page, perPage int) ([]string, *model.AppError)
+
+	// CallPlugin invokes a method declared in another plugin's manifest, passing request to the
+	// other plugin's OnPluginRequest hook and returning its response. The call fails if the other
+	// plugin is not active, does not declare the method, or if the call would wait on itself
+	// through a chain of plugin calls.
+	//
+	// c is the context of the hook the call is made from, so that the chain of plugin calls is
+	// passed on; it may be nil for calls made outside of a hook.
+	//
+	// Minimum server version: 5.15
+	CallPlugin(c *Context, pluginId string, method string, request []byte) ([]byte, *model.AppError)
+
+	// PublishPluginEvent sends an event to the OnPluginEvent hook of every other active plugin
+	// subscribed to it on this server. Delivery is asynchronous.
+	//
+	// Minimum server version: 5.15
+	PublishPluginEvent(event string, payload []byte) *model.AppError
+
+	// SubscribePluginEvent subscribes the plugin to events with the given name published by other
+	// plugins. Subscriptions end when the plugin is deactivated, so they should be made in OnActivate.
+	//
+	// Minimum server version: 5.15
+	SubscribePluginEvent(event string) *model.AppError
+
+	// UnsubscribePluginEvent stops delivery of events with the given name to the plugin.
+	//
+	// Minimum server version: 5.15
+	UnsubscribePluginEvent(event string) *model.AppError
+
 	// PublishWebSocketEvent sends an event to WebSocket connections.
 	// event is the type and will be prepended with "custom_<pluginid>_".
 	// payload is the data sent with the event. Interface values must be primitive Go types or mattermost-server/model types.
@@ -598,6 +653,20 @@ type API interface {
 	//
 	// Minimum server version: 5.14
 	DeleteBotIconImage(botUserId string) *model.AppError
+
+	// RegisterScheduledJob registers a job to be run periodically, either on a cron schedule such as
+	// "*/15 * * * *" or at a fixed interval. Runs are scheduled through the server's jobs framework and
+	// delivered to the plugin through the OnScheduledJob hook on exactly one server in the cluster.
+	// Registering a job with the same name as an existing one replaces it. Jobs are unregistered when
+	// the plugin is deactivated, so they should be registered from OnActivate.
+	//
+	// Minimum server version: 5.15
+	RegisterScheduledJob(job *model.PluginScheduledJob) *model.AppError
+
+	// UnregisterScheduledJob stops scheduling the job with the given name.
+	//
+	// Minimum server version: 5.15
+	UnregisterScheduledJob(name string) *model.AppError
 }
 
 var handshake = plugin.HandshakeConfig{
@@ -0,0 +1,108 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	cgroupRoot      = "/sys/fs/cgroup"
+	cgroupParent    = "mattermost-plugins"
+	cgroupCPUPeriod = 100000
+)
+
+// pluginCgroup is a cgroups v2 group containing a single plugin process.
+type pluginCgroup struct {
+	path string
+}
+
+// newPluginCgroup creates a cgroup enforcing the memory and CPU limits for the given plugin. It
+// returns nil if neither limit is set, or errCgroupsUnavailable if the host does not use the
+// unified cgroups v2 hierarchy.
+func newPluginCgroup(pluginId string, limits ResourceLimits) (*pluginCgroup, error) {
+	if limits.MaxMemoryBytes <= 0 && limits.MaxCPUPercent <= 0 {
+		return nil, nil
+	}
+
+	if _, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); err != nil {
+		return nil, errCgroupsUnavailable
+	}
+
+	parent := filepath.Join(cgroupRoot, cgroupParent)
+	if err := os.Mkdir(parent, 0755); err != nil && !os.IsExist(err) {
+		return nil, err
+	}
+
+	// The controllers must be enabled at every level above the plugin's group.
+	for _, dir := range []string{cgroupRoot, parent} {
+		if err := writeCgroupFile(dir, "cgroup.subtree_control", "+memory +cpu"); err != nil {
+			return nil, err
+		}
+	}
+
+	cg := &pluginCgroup{path: filepath.Join(parent, pluginId)}
+	if err := os.Mkdir(cg.path, 0755); err != nil && !os.IsExist(err) {
+		return nil, err
+	}
+
+	memoryMax := "max"
+	if limits.MaxMemoryBytes > 0 {
+		memoryMax = strconv.FormatInt(limits.MaxMemoryBytes, 10)
+	}
+	if err := writeCgroupFile(cg.path, "memory.max", memoryMax); err != nil {
+		cg.Remove()
+		return nil, err
+	}
+
+	cpuMax := fmt.Sprintf("max %d", cgroupCPUPeriod)
+	if limits.MaxCPUPercent > 0 {
+		cpuMax = fmt.Sprintf("%d %d", cgroupCPUPeriod*limits.MaxCPUPercent/100, cgroupCPUPeriod)
+	}
+	if err := writeCgroupFile(cg.path, "cpu.max", cpuMax); err != nil {
+		cg.Remove()
+		return nil, err
+	}
+
+	return cg, nil
+}
+
+// AddProcess moves the process with the given pid into the cgroup.
+func (cg *pluginCgroup) AddProcess(pid int) error {
+	return writeCgroupFile(cg.path, "cgroup.procs", strconv.Itoa(pid))
+}
+
+// OOMKills returns the number of processes in the cgroup killed for exceeding the memory limit.
+func (cg *pluginCgroup) OOMKills() (int64, error) {
+	file, err := os.Open(filepath.Join(cg.path, "memory.events"))
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == "oom_kill" {
+			return strconv.ParseInt(fields[1], 10, 64)
+		}
+	}
+
+	return 0, scanner.Err()
+}
+
+// Remove deletes the cgroup. It fails if the plugin process is still running.
+func (cg *pluginCgroup) Remove() error {
+	return os.Remove(cg.path)
+}
+
+func writeCgroupFile(dir, name, value string) error {
+	return ioutil.WriteFile(filepath.Join(dir, name), []byte(value), 0644)
+}
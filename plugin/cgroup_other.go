@@ -0,0 +1,30 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+//go:build !linux
+// +build !linux
+
+package plugin
+
+// pluginCgroup is unsupported outside of Linux, so memory and CPU limits are not enforced.
+type pluginCgroup struct{}
+
+func newPluginCgroup(pluginId string, limits ResourceLimits) (*pluginCgroup, error) {
+	if limits.MaxMemoryBytes <= 0 && limits.MaxCPUPercent <= 0 {
+		return nil, nil
+	}
+
+	return nil, errCgroupsUnavailable
+}
+
+func (cg *pluginCgroup) AddProcess(pid int) error {
+	return nil
+}
+
+func (cg *pluginCgroup) OOMKills() (int64, error) {
+	return 0, nil
+}
+
+func (cg *pluginCgroup) Remove() error {
+	return nil
+}
@@ -32,6 +32,7 @@ type hooksRPCClient struct {
 	muxBroker   *plugin.MuxBroker
 	apiImpl     API
 	implemented [TotalHooksId]bool
+	limiter     *hookLimiter
 }
 
 type hooksRPCServer struct {
@@ -45,6 +46,7 @@ type hooksPlugin struct {
 	hooks   interface{}
 	apiImpl API
 	log     *mlog.Logger
+	limiter *hookLimiter
 }
 
 func (p *hooksPlugin) Server(b *plugin.MuxBroker) (interface{}, error) {
@@ -52,7 +54,16 @@ func (p *hooksPlugin) Server(b *plugin.MuxBroker) (interface{}, error) {
 }
 
 func (p *hooksPlugin) Client(b *plugin.MuxBroker, client *rpc.Client) (interface{}, error) {
-	return &hooksRPCClient{client: client, log: p.log, muxBroker: b, apiImpl: p.apiImpl}, nil
+	return &hooksRPCClient{client: client, log: p.log, muxBroker: b, apiImpl: p.apiImpl, limiter: p.limiter}, nil
+}
+
+// call invokes a hook in the plugin, subject to the plugin's hook timeout and concurrency limits.
+func (g *hooksRPCClient) call(serviceMethod string, args interface{}, reply interface{}) error {
+	if g.limiter == nil {
+		return g.client.Call(serviceMethod, args, reply)
+	}
+
+	return g.limiter.call(g.client, serviceMethod, args, reply)
 }
 
 type apiRPCClient struct {
@@ -446,6 +457,10 @@ func (s *hooksRPCServer) FileWillBeUploaded(args *Z_FileWillBeUploadedArgs, retu
 	return nil
 }
 
+// hookLimitRejectionReason is given for a post or update that a plugin could not filter within its resource
+// limits, so that it isn't let through unfiltered.
+const hookLimitRejectionReason = "The plugin did not respond in time."
+
 // MessageWillBePosted is in this file because of the difficulty of identifiying which fields need special behaviour.
 // The special behaviour needed is decoding the returned post into the original one to avoid the unintentional removal
 // of fields by older plugins.
@@ -467,7 +482,10 @@ func (g *hooksRPCClient) MessageWillBePosted(c *Context, post *model.Post) (*mod
 	_args := &Z_MessageWillBePostedArgs{c, post}
 	_returns := &Z_MessageWillBePostedReturns{A: _args.B}
 	if g.implemented[MessageWillBePostedId] {
-		if err := g.client.Call("Plugin.MessageWillBePosted", _args, _returns); err != nil {
+		if err := g.call("Plugin.MessageWillBePosted", _args, _returns); isHookLimitError(err) {
+			g.log.Error("RPC call MessageWillBePosted to plugin exceeded its resource limits, rejecting the post.", mlog.Err(err))
+			return nil, hookLimitRejectionReason
+		} else if err != nil {
 			g.log.Error("RPC call MessageWillBePosted to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -508,7 +526,10 @@ func (g *hooksRPCClient) MessageWillBeUpdated(c *Context, newPost, oldPost *mode
 	_args := &Z_MessageWillBeUpdatedArgs{c, newPost, oldPost}
 	_returns := &Z_MessageWillBeUpdatedReturns{A: _args.B}
 	if g.implemented[MessageWillBeUpdatedId] {
-		if err := g.client.Call("Plugin.MessageWillBeUpdated", _args, _returns); err != nil {
+		if err := g.call("Plugin.MessageWillBeUpdated", _args, _returns); isHookLimitError(err) {
+			g.log.Error("RPC call MessageWillBeUpdated to plugin exceeded its resource limits, rejecting the update.", mlog.Err(err))
+			return nil, hookLimitRejectionReason
+		} else if err != nil {
 			g.log.Error("RPC call MessageWillBeUpdated to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -29,7 +29,7 @@ func (g *hooksRPCClient) OnDeactivate() error {
 	_args := &Z_OnDeactivateArgs{}
 	_returns := &Z_OnDeactivateReturns{}
 	if g.implemented[OnDeactivateId] {
-		if err := g.client.Call("Plugin.OnDeactivate", _args, _returns); err != nil {
+		if err := g.call("Plugin.OnDeactivate", _args, _returns); err != nil {
 			g.log.Error("RPC call OnDeactivate to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -63,7 +63,7 @@ func (g *hooksRPCClient) OnConfigurationChange() error {
 	_args := &Z_OnConfigurationChangeArgs{}
 	_returns := &Z_OnConfigurationChangeReturns{}
 	if g.implemented[OnConfigurationChangeId] {
-		if err := g.client.Call("Plugin.OnConfigurationChange", _args, _returns); err != nil {
+		if err := g.call("Plugin.OnConfigurationChange", _args, _returns); err != nil {
 			g.log.Error("RPC call OnConfigurationChange to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -100,7 +100,7 @@ func (g *hooksRPCClient) ExecuteCommand(c *Context, args *model.CommandArgs) (*m
 	_args := &Z_ExecuteCommandArgs{c, args}
 	_returns := &Z_ExecuteCommandReturns{}
 	if g.implemented[ExecuteCommandId] {
-		if err := g.client.Call("Plugin.ExecuteCommand", _args, _returns); err != nil {
+		if err := g.call("Plugin.ExecuteCommand", _args, _returns); err != nil {
 			g.log.Error("RPC call ExecuteCommand to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -135,7 +135,7 @@ func (g *hooksRPCClient) UserHasBeenCreated(c *Context, user *model.User) {
 	_args := &Z_UserHasBeenCreatedArgs{c, user}
 	_returns := &Z_UserHasBeenCreatedReturns{}
 	if g.implemented[UserHasBeenCreatedId] {
-		if err := g.client.Call("Plugin.UserHasBeenCreated", _args, _returns); err != nil {
+		if err := g.call("Plugin.UserHasBeenCreated", _args, _returns); err != nil {
 			g.log.Error("RPC call UserHasBeenCreated to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -154,6 +154,77 @@ func (s *hooksRPCServer) UserHasBeenCreated(args *Z_UserHasBeenCreatedArgs, retu
 	return nil
 }
 
+func init() {
+	hookNameToId["UserHasBeenUpdated"] = UserHasBeenUpdatedId
+}
+
+type Z_UserHasBeenUpdatedArgs struct {
+	A *Context
+	B *model.User
+	C *model.User
+}
+
+type Z_UserHasBeenUpdatedReturns struct {
+}
+
+func (g *hooksRPCClient) UserHasBeenUpdated(c *Context, newUser, oldUser *model.User) {
+	_args := &Z_UserHasBeenUpdatedArgs{c, newUser, oldUser}
+	_returns := &Z_UserHasBeenUpdatedReturns{}
+	if g.implemented[UserHasBeenUpdatedId] {
+		if err := g.call("Plugin.UserHasBeenUpdated", _args, _returns); err != nil {
+			g.log.Error("RPC call UserHasBeenUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) UserHasBeenUpdated(args *Z_UserHasBeenUpdatedArgs, returns *Z_UserHasBeenUpdatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		UserHasBeenUpdated(c *Context, newUser, oldUser *model.User)
+	}); ok {
+		hook.UserHasBeenUpdated(args.A, args.B, args.C)
+
+	} else {
+		return encodableError(fmt.Errorf("Hook UserHasBeenUpdated called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["UserHasBeenDeactivated"] = UserHasBeenDeactivatedId
+}
+
+type Z_UserHasBeenDeactivatedArgs struct {
+	A *Context
+	B *model.User
+}
+
+type Z_UserHasBeenDeactivatedReturns struct {
+}
+
+func (g *hooksRPCClient) UserHasBeenDeactivated(c *Context, user *model.User) {
+	_args := &Z_UserHasBeenDeactivatedArgs{c, user}
+	_returns := &Z_UserHasBeenDeactivatedReturns{}
+	if g.implemented[UserHasBeenDeactivatedId] {
+		if err := g.call("Plugin.UserHasBeenDeactivated", _args, _returns); err != nil {
+			g.log.Error("RPC call UserHasBeenDeactivated to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) UserHasBeenDeactivated(args *Z_UserHasBeenDeactivatedArgs, returns *Z_UserHasBeenDeactivatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		UserHasBeenDeactivated(c *Context, user *model.User)
+	}); ok {
+		hook.UserHasBeenDeactivated(args.A, args.B)
+
+	} else {
+		return encodableError(fmt.Errorf("Hook UserHasBeenDeactivated called but not implemented."))
+	}
+	return nil
+}
+
 func init() {
 	hookNameToId["UserWillLogIn"] = UserWillLogInId
 }
@@ -171,7 +242,7 @@ func (g *hooksRPCClient) UserWillLogIn(c *Context, user *model.User) string {
 	_args := &Z_UserWillLogInArgs{c, user}
 	_returns := &Z_UserWillLogInReturns{}
 	if g.implemented[UserWillLogInId] {
-		if err := g.client.Call("Plugin.UserWillLogIn", _args, _returns); err != nil {
+		if err := g.call("Plugin.UserWillLogIn", _args, _returns); err != nil {
 			g.log.Error("RPC call UserWillLogIn to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -206,7 +277,7 @@ func (g *hooksRPCClient) UserHasLoggedIn(c *Context, user *model.User) {
 	_args := &Z_UserHasLoggedInArgs{c, user}
 	_returns := &Z_UserHasLoggedInReturns{}
 	if g.implemented[UserHasLoggedInId] {
-		if err := g.client.Call("Plugin.UserHasLoggedIn", _args, _returns); err != nil {
+		if err := g.call("Plugin.UserHasLoggedIn", _args, _returns); err != nil {
 			g.log.Error("RPC call UserHasLoggedIn to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -241,7 +312,7 @@ func (g *hooksRPCClient) MessageHasBeenPosted(c *Context, post *model.Post) {
 	_args := &Z_MessageHasBeenPostedArgs{c, post}
 	_returns := &Z_MessageHasBeenPostedReturns{}
 	if g.implemented[MessageHasBeenPostedId] {
-		if err := g.client.Call("Plugin.MessageHasBeenPosted", _args, _returns); err != nil {
+		if err := g.call("Plugin.MessageHasBeenPosted", _args, _returns); err != nil {
 			g.log.Error("RPC call MessageHasBeenPosted to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -277,7 +348,7 @@ func (g *hooksRPCClient) MessageHasBeenUpdated(c *Context, newPost, oldPost *mod
 	_args := &Z_MessageHasBeenUpdatedArgs{c, newPost, oldPost}
 	_returns := &Z_MessageHasBeenUpdatedReturns{}
 	if g.implemented[MessageHasBeenUpdatedId] {
-		if err := g.client.Call("Plugin.MessageHasBeenUpdated", _args, _returns); err != nil {
+		if err := g.call("Plugin.MessageHasBeenUpdated", _args, _returns); err != nil {
 			g.log.Error("RPC call MessageHasBeenUpdated to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -296,6 +367,111 @@ func (s *hooksRPCServer) MessageHasBeenUpdated(args *Z_MessageHasBeenUpdatedArgs
 	return nil
 }
 
+func init() {
+	hookNameToId["PostHasBeenDeleted"] = PostHasBeenDeletedId
+}
+
+type Z_PostHasBeenDeletedArgs struct {
+	A *Context
+	B *model.Post
+}
+
+type Z_PostHasBeenDeletedReturns struct {
+}
+
+func (g *hooksRPCClient) PostHasBeenDeleted(c *Context, post *model.Post) {
+	_args := &Z_PostHasBeenDeletedArgs{c, post}
+	_returns := &Z_PostHasBeenDeletedReturns{}
+	if g.implemented[PostHasBeenDeletedId] {
+		if err := g.call("Plugin.PostHasBeenDeleted", _args, _returns); err != nil {
+			g.log.Error("RPC call PostHasBeenDeleted to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) PostHasBeenDeleted(args *Z_PostHasBeenDeletedArgs, returns *Z_PostHasBeenDeletedReturns) error {
+	if hook, ok := s.impl.(interface {
+		PostHasBeenDeleted(c *Context, post *model.Post)
+	}); ok {
+		hook.PostHasBeenDeleted(args.A, args.B)
+
+	} else {
+		return encodableError(fmt.Errorf("Hook PostHasBeenDeleted called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ReactionHasBeenAdded"] = ReactionHasBeenAddedId
+}
+
+type Z_ReactionHasBeenAddedArgs struct {
+	A *Context
+	B *model.Reaction
+}
+
+type Z_ReactionHasBeenAddedReturns struct {
+}
+
+func (g *hooksRPCClient) ReactionHasBeenAdded(c *Context, reaction *model.Reaction) {
+	_args := &Z_ReactionHasBeenAddedArgs{c, reaction}
+	_returns := &Z_ReactionHasBeenAddedReturns{}
+	if g.implemented[ReactionHasBeenAddedId] {
+		if err := g.call("Plugin.ReactionHasBeenAdded", _args, _returns); err != nil {
+			g.log.Error("RPC call ReactionHasBeenAdded to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) ReactionHasBeenAdded(args *Z_ReactionHasBeenAddedArgs, returns *Z_ReactionHasBeenAddedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ReactionHasBeenAdded(c *Context, reaction *model.Reaction)
+	}); ok {
+		hook.ReactionHasBeenAdded(args.A, args.B)
+
+	} else {
+		return encodableError(fmt.Errorf("Hook ReactionHasBeenAdded called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ReactionHasBeenRemoved"] = ReactionHasBeenRemovedId
+}
+
+type Z_ReactionHasBeenRemovedArgs struct {
+	A *Context
+	B *model.Reaction
+}
+
+type Z_ReactionHasBeenRemovedReturns struct {
+}
+
+func (g *hooksRPCClient) ReactionHasBeenRemoved(c *Context, reaction *model.Reaction) {
+	_args := &Z_ReactionHasBeenRemovedArgs{c, reaction}
+	_returns := &Z_ReactionHasBeenRemovedReturns{}
+	if g.implemented[ReactionHasBeenRemovedId] {
+		if err := g.call("Plugin.ReactionHasBeenRemoved", _args, _returns); err != nil {
+			g.log.Error("RPC call ReactionHasBeenRemoved to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) ReactionHasBeenRemoved(args *Z_ReactionHasBeenRemovedArgs, returns *Z_ReactionHasBeenRemovedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ReactionHasBeenRemoved(c *Context, reaction *model.Reaction)
+	}); ok {
+		hook.ReactionHasBeenRemoved(args.A, args.B)
+
+	} else {
+		return encodableError(fmt.Errorf("Hook ReactionHasBeenRemoved called but not implemented."))
+	}
+	return nil
+}
+
 func init() {
 	hookNameToId["ChannelHasBeenCreated"] = ChannelHasBeenCreatedId
 }
@@ -312,7 +488,7 @@ func (g *hooksRPCClient) ChannelHasBeenCreated(c *Context, channel *model.Channe
 	_args := &Z_ChannelHasBeenCreatedArgs{c, channel}
 	_returns := &Z_ChannelHasBeenCreatedReturns{}
 	if g.implemented[ChannelHasBeenCreatedId] {
-		if err := g.client.Call("Plugin.ChannelHasBeenCreated", _args, _returns); err != nil {
+		if err := g.call("Plugin.ChannelHasBeenCreated", _args, _returns); err != nil {
 			g.log.Error("RPC call ChannelHasBeenCreated to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -331,6 +507,77 @@ func (s *hooksRPCServer) ChannelHasBeenCreated(args *Z_ChannelHasBeenCreatedArgs
 	return nil
 }
 
+func init() {
+	hookNameToId["ChannelHasBeenUpdated"] = ChannelHasBeenUpdatedId
+}
+
+type Z_ChannelHasBeenUpdatedArgs struct {
+	A *Context
+	B *model.Channel
+}
+
+type Z_ChannelHasBeenUpdatedReturns struct {
+}
+
+func (g *hooksRPCClient) ChannelHasBeenUpdated(c *Context, channel *model.Channel) {
+	_args := &Z_ChannelHasBeenUpdatedArgs{c, channel}
+	_returns := &Z_ChannelHasBeenUpdatedReturns{}
+	if g.implemented[ChannelHasBeenUpdatedId] {
+		if err := g.call("Plugin.ChannelHasBeenUpdated", _args, _returns); err != nil {
+			g.log.Error("RPC call ChannelHasBeenUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) ChannelHasBeenUpdated(args *Z_ChannelHasBeenUpdatedArgs, returns *Z_ChannelHasBeenUpdatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ChannelHasBeenUpdated(c *Context, channel *model.Channel)
+	}); ok {
+		hook.ChannelHasBeenUpdated(args.A, args.B)
+
+	} else {
+		return encodableError(fmt.Errorf("Hook ChannelHasBeenUpdated called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ChannelHasBeenArchived"] = ChannelHasBeenArchivedId
+}
+
+type Z_ChannelHasBeenArchivedArgs struct {
+	A *Context
+	B *model.Channel
+	C *model.User
+}
+
+type Z_ChannelHasBeenArchivedReturns struct {
+}
+
+func (g *hooksRPCClient) ChannelHasBeenArchived(c *Context, channel *model.Channel, actor *model.User) {
+	_args := &Z_ChannelHasBeenArchivedArgs{c, channel, actor}
+	_returns := &Z_ChannelHasBeenArchivedReturns{}
+	if g.implemented[ChannelHasBeenArchivedId] {
+		if err := g.call("Plugin.ChannelHasBeenArchived", _args, _returns); err != nil {
+			g.log.Error("RPC call ChannelHasBeenArchived to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) ChannelHasBeenArchived(args *Z_ChannelHasBeenArchivedArgs, returns *Z_ChannelHasBeenArchivedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ChannelHasBeenArchived(c *Context, channel *model.Channel, actor *model.User)
+	}); ok {
+		hook.ChannelHasBeenArchived(args.A, args.B, args.C)
+
+	} else {
+		return encodableError(fmt.Errorf("Hook ChannelHasBeenArchived called but not implemented."))
+	}
+	return nil
+}
+
 func init() {
 	hookNameToId["UserHasJoinedChannel"] = UserHasJoinedChannelId
 }
@@ -348,7 +595,7 @@ func (g *hooksRPCClient) UserHasJoinedChannel(c *Context, channelMember *model.C
 	_args := &Z_UserHasJoinedChannelArgs{c, channelMember, actor}
 	_returns := &Z_UserHasJoinedChannelReturns{}
 	if g.implemented[UserHasJoinedChannelId] {
-		if err := g.client.Call("Plugin.UserHasJoinedChannel", _args, _returns); err != nil {
+		if err := g.call("Plugin.UserHasJoinedChannel", _args, _returns); err != nil {
 			g.log.Error("RPC call UserHasJoinedChannel to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -384,7 +631,7 @@ func (g *hooksRPCClient) UserHasLeftChannel(c *Context, channelMember *model.Cha
 	_args := &Z_UserHasLeftChannelArgs{c, channelMember, actor}
 	_returns := &Z_UserHasLeftChannelReturns{}
 	if g.implemented[UserHasLeftChannelId] {
-		if err := g.client.Call("Plugin.UserHasLeftChannel", _args, _returns); err != nil {
+		if err := g.call("Plugin.UserHasLeftChannel", _args, _returns); err != nil {
 			g.log.Error("RPC call UserHasLeftChannel to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -420,7 +667,7 @@ func (g *hooksRPCClient) UserHasJoinedTeam(c *Context, teamMember *model.TeamMem
 	_args := &Z_UserHasJoinedTeamArgs{c, teamMember, actor}
 	_returns := &Z_UserHasJoinedTeamReturns{}
 	if g.implemented[UserHasJoinedTeamId] {
-		if err := g.client.Call("Plugin.UserHasJoinedTeam", _args, _returns); err != nil {
+		if err := g.call("Plugin.UserHasJoinedTeam", _args, _returns); err != nil {
 			g.log.Error("RPC call UserHasJoinedTeam to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -456,7 +703,7 @@ func (g *hooksRPCClient) UserHasLeftTeam(c *Context, teamMember *model.TeamMembe
 	_args := &Z_UserHasLeftTeamArgs{c, teamMember, actor}
 	_returns := &Z_UserHasLeftTeamReturns{}
 	if g.implemented[UserHasLeftTeamId] {
-		if err := g.client.Call("Plugin.UserHasLeftTeam", _args, _returns); err != nil {
+		if err := g.call("Plugin.UserHasLeftTeam", _args, _returns); err != nil {
 			g.log.Error("RPC call UserHasLeftTeam to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -475,6 +722,190 @@ func (s *hooksRPCServer) UserHasLeftTeam(args *Z_UserHasLeftTeamArgs, returns *Z
 	return nil
 }
 
+func init() {
+	hookNameToId["FileHasBeenDownloaded"] = FileHasBeenDownloadedId
+}
+
+type Z_FileHasBeenDownloadedArgs struct {
+	A *Context
+	B *model.FileInfo
+	C string
+}
+
+type Z_FileHasBeenDownloadedReturns struct {
+}
+
+func (g *hooksRPCClient) FileHasBeenDownloaded(c *Context, info *model.FileInfo, userId string) {
+	_args := &Z_FileHasBeenDownloadedArgs{c, info, userId}
+	_returns := &Z_FileHasBeenDownloadedReturns{}
+	if g.implemented[FileHasBeenDownloadedId] {
+		if err := g.call("Plugin.FileHasBeenDownloaded", _args, _returns); err != nil {
+			g.log.Error("RPC call FileHasBeenDownloaded to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) FileHasBeenDownloaded(args *Z_FileHasBeenDownloadedArgs, returns *Z_FileHasBeenDownloadedReturns) error {
+	if hook, ok := s.impl.(interface {
+		FileHasBeenDownloaded(c *Context, info *model.FileInfo, userId string)
+	}); ok {
+		hook.FileHasBeenDownloaded(args.A, args.B, args.C)
+
+	} else {
+		return encodableError(fmt.Errorf("Hook FileHasBeenDownloaded called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["OnScheduledJob"] = OnScheduledJobId
+}
+
+type Z_OnScheduledJobArgs struct {
+	A *Context
+	B string
+}
+
+type Z_OnScheduledJobReturns struct {
+	A error
+}
+
+func (g *hooksRPCClient) OnScheduledJob(c *Context, name string) error {
+	_args := &Z_OnScheduledJobArgs{c, name}
+	_returns := &Z_OnScheduledJobReturns{}
+	if g.implemented[OnScheduledJobId] {
+		if err := g.call("Plugin.OnScheduledJob", _args, _returns); err != nil {
+			g.log.Error("RPC call OnScheduledJob to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) OnScheduledJob(args *Z_OnScheduledJobArgs, returns *Z_OnScheduledJobReturns) error {
+	if hook, ok := s.impl.(interface {
+		OnScheduledJob(c *Context, name string) error
+	}); ok {
+		returns.A = hook.OnScheduledJob(args.A, args.B)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("Hook OnScheduledJob called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["OnPluginRequest"] = OnPluginRequestId
+}
+
+type Z_OnPluginRequestArgs struct {
+	A *Context
+	B string
+	C string
+	D []byte
+}
+
+type Z_OnPluginRequestReturns struct {
+	A []byte
+	B *model.AppError
+}
+
+func (g *hooksRPCClient) OnPluginRequest(c *Context, sourcePluginId string, method string, request []byte) ([]byte, *model.AppError) {
+	_args := &Z_OnPluginRequestArgs{c, sourcePluginId, method, request}
+	_returns := &Z_OnPluginRequestReturns{}
+	if g.implemented[OnPluginRequestId] {
+		if err := g.call("Plugin.OnPluginRequest", _args, _returns); err != nil {
+			g.log.Error("RPC call OnPluginRequest to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) OnPluginRequest(args *Z_OnPluginRequestArgs, returns *Z_OnPluginRequestReturns) error {
+	if hook, ok := s.impl.(interface {
+		OnPluginRequest(c *Context, sourcePluginId string, method string, request []byte) ([]byte, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.OnPluginRequest(args.A, args.B, args.C, args.D)
+
+	} else {
+		return encodableError(fmt.Errorf("Hook OnPluginRequest called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["OnPluginEvent"] = OnPluginEventId
+}
+
+type Z_OnPluginEventArgs struct {
+	A *Context
+	B string
+	C string
+	D []byte
+}
+
+type Z_OnPluginEventReturns struct {
+}
+
+func (g *hooksRPCClient) OnPluginEvent(c *Context, sourcePluginId string, event string, payload []byte) {
+	_args := &Z_OnPluginEventArgs{c, sourcePluginId, event, payload}
+	_returns := &Z_OnPluginEventReturns{}
+	if g.implemented[OnPluginEventId] {
+		if err := g.call("Plugin.OnPluginEvent", _args, _returns); err != nil {
+			g.log.Error("RPC call OnPluginEvent to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) OnPluginEvent(args *Z_OnPluginEventArgs, returns *Z_OnPluginEventReturns) error {
+	if hook, ok := s.impl.(interface {
+		OnPluginEvent(c *Context, sourcePluginId string, event string, payload []byte)
+	}); ok {
+		hook.OnPluginEvent(args.A, args.B, args.C, args.D)
+
+	} else {
+		return encodableError(fmt.Errorf("Hook OnPluginEvent called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ConfigurationWillBeSaved"] = ConfigurationWillBeSavedId
+}
+
+type Z_ConfigurationWillBeSavedArgs struct {
+	A *Context
+	B map[string]interface{}
+}
+
+type Z_ConfigurationWillBeSavedReturns struct {
+	A string
+}
+
+func (g *hooksRPCClient) ConfigurationWillBeSaved(c *Context, newSettings map[string]interface{}) string {
+	_args := &Z_ConfigurationWillBeSavedArgs{c, newSettings}
+	_returns := &Z_ConfigurationWillBeSavedReturns{}
+	if g.implemented[ConfigurationWillBeSavedId] {
+		if err := g.call("Plugin.ConfigurationWillBeSaved", _args, _returns); err != nil {
+			g.log.Error("RPC call ConfigurationWillBeSaved to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) ConfigurationWillBeSaved(args *Z_ConfigurationWillBeSavedArgs, returns *Z_ConfigurationWillBeSavedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ConfigurationWillBeSaved(c *Context, newSettings map[string]interface{}) string
+	}); ok {
+		returns.A = hook.ConfigurationWillBeSaved(args.A, args.B)
+
+	} else {
+		return encodableError(fmt.Errorf("Hook ConfigurationWillBeSaved called but not implemented."))
+	}
+	return nil
+}
+
 type Z_RegisterCommandArgs struct {
 	A *model.Command
 }
@@ -3608,6 +4039,65 @@ func (s *apiRPCServer) KVSetWithExpiry(args *Z_KVSetWithExpiryArgs, returns *Z_K
 	return nil
 }
 
+type Z_KVSetWithOptionsArgs struct {
+	A string
+	B []byte
+	C model.PluginKVSetOptions
+}
+
+type Z_KVSetWithOptionsReturns struct {
+	A bool
+	B *model.AppError
+}
+
+func (g *apiRPCClient) KVSetWithOptions(key string, value []byte, options model.PluginKVSetOptions) (bool, *model.AppError) {
+	_args := &Z_KVSetWithOptionsArgs{key, value, options}
+	_returns := &Z_KVSetWithOptionsReturns{}
+	if err := g.client.Call("Plugin.KVSetWithOptions", _args, _returns); err != nil {
+		log.Printf("RPC call to KVSetWithOptions API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) KVSetWithOptions(args *Z_KVSetWithOptionsArgs, returns *Z_KVSetWithOptionsReturns) error {
+	if hook, ok := s.impl.(interface {
+		KVSetWithOptions(key string, value []byte, options model.PluginKVSetOptions) (bool, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.KVSetWithOptions(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("API KVSetWithOptions called but not implemented."))
+	}
+	return nil
+}
+
+type Z_KVSetMultiArgs struct {
+	A map[string][]byte
+}
+
+type Z_KVSetMultiReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) KVSetMulti(values map[string][]byte) *model.AppError {
+	_args := &Z_KVSetMultiArgs{values}
+	_returns := &Z_KVSetMultiReturns{}
+	if err := g.client.Call("Plugin.KVSetMulti", _args, _returns); err != nil {
+		log.Printf("RPC call to KVSetMulti API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) KVSetMulti(args *Z_KVSetMultiArgs, returns *Z_KVSetMultiReturns) error {
+	if hook, ok := s.impl.(interface {
+		KVSetMulti(values map[string][]byte) *model.AppError
+	}); ok {
+		returns.A = hook.KVSetMulti(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API KVSetMulti called but not implemented."))
+	}
+	return nil
+}
+
 type Z_KVGetArgs struct {
 	A string
 }
@@ -3637,6 +4127,35 @@ func (s *apiRPCServer) KVGet(args *Z_KVGetArgs, returns *Z_KVGetReturns) error {
 	return nil
 }
 
+type Z_KVGetMultiArgs struct {
+	A []string
+}
+
+type Z_KVGetMultiReturns struct {
+	A map[string][]byte
+	B *model.AppError
+}
+
+func (g *apiRPCClient) KVGetMulti(keys []string) (map[string][]byte, *model.AppError) {
+	_args := &Z_KVGetMultiArgs{keys}
+	_returns := &Z_KVGetMultiReturns{}
+	if err := g.client.Call("Plugin.KVGetMulti", _args, _returns); err != nil {
+		log.Printf("RPC call to KVGetMulti API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) KVGetMulti(args *Z_KVGetMultiArgs, returns *Z_KVGetMultiReturns) error {
+	if hook, ok := s.impl.(interface {
+		KVGetMulti(keys []string) (map[string][]byte, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.KVGetMulti(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API KVGetMulti called but not implemented."))
+	}
+	return nil
+}
+
 type Z_KVDeleteArgs struct {
 	A string
 }
@@ -3722,6 +4241,154 @@ func (s *apiRPCServer) KVList(args *Z_KVListArgs, returns *Z_KVListReturns) erro
 	return nil
 }
 
+type Z_KVListWithPrefixArgs struct {
+	A string
+	B int
+	C int
+}
+
+type Z_KVListWithPrefixReturns struct {
+	A []string
+	B *model.AppError
+}
+
+func (g *apiRPCClient) KVListWithPrefix(prefix string, page, perPage int) ([]string, *model.AppError) {
+	_args := &Z_KVListWithPrefixArgs{prefix, page, perPage}
+	_returns := &Z_KVListWithPrefixReturns{}
+	if err := g.client.Call("Plugin.KVListWithPrefix", _args, _returns); err != nil {
+		log.Printf("RPC call to KVListWithPrefix API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) KVListWithPrefix(args *Z_KVListWithPrefixArgs, returns *Z_KVListWithPrefixReturns) error {
+	if hook, ok := s.impl.(interface {
+		KVListWithPrefix(prefix string, page, perPage int) ([]string, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.KVListWithPrefix(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("API KVListWithPrefix called but not implemented."))
+	}
+	return nil
+}
+
+type Z_CallPluginArgs struct {
+	A *Context
+	B string
+	C string
+	D []byte
+}
+
+type Z_CallPluginReturns struct {
+	A []byte
+	B *model.AppError
+}
+
+func (g *apiRPCClient) CallPlugin(c *Context, pluginId string, method string, request []byte) ([]byte, *model.AppError) {
+	_args := &Z_CallPluginArgs{c, pluginId, method, request}
+	_returns := &Z_CallPluginReturns{}
+	if err := g.client.Call("Plugin.CallPlugin", _args, _returns); err != nil {
+		log.Printf("RPC call to CallPlugin API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) CallPlugin(args *Z_CallPluginArgs, returns *Z_CallPluginReturns) error {
+	if hook, ok := s.impl.(interface {
+		CallPlugin(c *Context, pluginId string, method string, request []byte) ([]byte, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.CallPlugin(args.A, args.B, args.C, args.D)
+	} else {
+		return encodableError(fmt.Errorf("API CallPlugin called but not implemented."))
+	}
+	return nil
+}
+
+type Z_PublishPluginEventArgs struct {
+	A string
+	B []byte
+}
+
+type Z_PublishPluginEventReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) PublishPluginEvent(event string, payload []byte) *model.AppError {
+	_args := &Z_PublishPluginEventArgs{event, payload}
+	_returns := &Z_PublishPluginEventReturns{}
+	if err := g.client.Call("Plugin.PublishPluginEvent", _args, _returns); err != nil {
+		log.Printf("RPC call to PublishPluginEvent API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) PublishPluginEvent(args *Z_PublishPluginEventArgs, returns *Z_PublishPluginEventReturns) error {
+	if hook, ok := s.impl.(interface {
+		PublishPluginEvent(event string, payload []byte) *model.AppError
+	}); ok {
+		returns.A = hook.PublishPluginEvent(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("API PublishPluginEvent called but not implemented."))
+	}
+	return nil
+}
+
+type Z_SubscribePluginEventArgs struct {
+	A string
+}
+
+type Z_SubscribePluginEventReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) SubscribePluginEvent(event string) *model.AppError {
+	_args := &Z_SubscribePluginEventArgs{event}
+	_returns := &Z_SubscribePluginEventReturns{}
+	if err := g.client.Call("Plugin.SubscribePluginEvent", _args, _returns); err != nil {
+		log.Printf("RPC call to SubscribePluginEvent API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) SubscribePluginEvent(args *Z_SubscribePluginEventArgs, returns *Z_SubscribePluginEventReturns) error {
+	if hook, ok := s.impl.(interface {
+		SubscribePluginEvent(event string) *model.AppError
+	}); ok {
+		returns.A = hook.SubscribePluginEvent(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API SubscribePluginEvent called but not implemented."))
+	}
+	return nil
+}
+
+type Z_UnsubscribePluginEventArgs struct {
+	A string
+}
+
+type Z_UnsubscribePluginEventReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) UnsubscribePluginEvent(event string) *model.AppError {
+	_args := &Z_UnsubscribePluginEventArgs{event}
+	_returns := &Z_UnsubscribePluginEventReturns{}
+	if err := g.client.Call("Plugin.UnsubscribePluginEvent", _args, _returns); err != nil {
+		log.Printf("RPC call to UnsubscribePluginEvent API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) UnsubscribePluginEvent(args *Z_UnsubscribePluginEventArgs, returns *Z_UnsubscribePluginEventReturns) error {
+	if hook, ok := s.impl.(interface {
+		UnsubscribePluginEvent(event string) *model.AppError
+	}); ok {
+		returns.A = hook.UnsubscribePluginEvent(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API UnsubscribePluginEvent called but not implemented."))
+	}
+	return nil
+}
+
 type Z_PublishWebSocketEventArgs struct {
 	A string
 	B map[string]interface{}
@@ -4243,3 +4910,59 @@ func (s *apiRPCServer) DeleteBotIconImage(args *Z_DeleteBotIconImageArgs, return
 	}
 	return nil
 }
+
+type Z_RegisterScheduledJobArgs struct {
+	A *model.PluginScheduledJob
+}
+
+type Z_RegisterScheduledJobReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) RegisterScheduledJob(job *model.PluginScheduledJob) *model.AppError {
+	_args := &Z_RegisterScheduledJobArgs{job}
+	_returns := &Z_RegisterScheduledJobReturns{}
+	if err := g.client.Call("Plugin.RegisterScheduledJob", _args, _returns); err != nil {
+		log.Printf("RPC call to RegisterScheduledJob API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) RegisterScheduledJob(args *Z_RegisterScheduledJobArgs, returns *Z_RegisterScheduledJobReturns) error {
+	if hook, ok := s.impl.(interface {
+		RegisterScheduledJob(job *model.PluginScheduledJob) *model.AppError
+	}); ok {
+		returns.A = hook.RegisterScheduledJob(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API RegisterScheduledJob called but not implemented."))
+	}
+	return nil
+}
+
+type Z_UnregisterScheduledJobArgs struct {
+	A string
+}
+
+type Z_UnregisterScheduledJobReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) UnregisterScheduledJob(name string) *model.AppError {
+	_args := &Z_UnregisterScheduledJobArgs{name}
+	_returns := &Z_UnregisterScheduledJobReturns{}
+	if err := g.client.Call("Plugin.UnregisterScheduledJob", _args, _returns); err != nil {
+		log.Printf("RPC call to UnregisterScheduledJob API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) UnregisterScheduledJob(args *Z_UnregisterScheduledJobArgs, returns *Z_UnregisterScheduledJobReturns) error {
+	if hook, ok := s.impl.(interface {
+		UnregisterScheduledJob(name string) *model.AppError
+	}); ok {
+		returns.A = hook.UnregisterScheduledJob(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API UnregisterScheduledJob called but not implemented."))
+	}
+	return nil
+}
@@ -12,4 +12,7 @@ type Context struct {
 	IpAddress      string
 	AcceptLanguage string
 	UserAgent      string
+	// PluginCallChain lists, in call order, the plugins waiting on this hook through
+	// API.CallPlugin. It is only set for OnPluginRequest.
+	PluginCallChain []string
 }
@@ -32,6 +32,10 @@ type registeredPlugin struct {
 	failTimeStamps []time.Time
 	lastError      error
 	supervisor     *supervisor
+	violations     limitViolations
+
+	restartLock  sync.Mutex
+	restartTimer *time.Timer
 }
 
 // Environment represents the execution environment of active plugins.
@@ -45,6 +49,12 @@ type Environment struct {
 	newAPIImpl           apiImplCreatorFunc
 	pluginDir            string
 	webappPluginDir      string
+
+	resourceLimitsLock   sync.RWMutex
+	resourceLimits       ResourceLimits
+	pluginResourceLimits map[string]ResourceLimits
+
+	pluginEvents pluginEventBus
 }
 
 func NewEnvironment(newAPIImpl apiImplCreatorFunc, pluginDir string, webappPluginDir string, logger *mlog.Logger) (*Environment, error) {
@@ -56,6 +66,25 @@ func NewEnvironment(newAPIImpl apiImplCreatorFunc, pluginDir string, webappPlugi
 	}, nil
 }
 
+// SetResourceLimits sets the limits applied to server-side plugin processes, along with the limits
+// of plugins that override them. The limits take effect the next time each plugin is activated.
+func (env *Environment) SetResourceLimits(limits ResourceLimits, pluginLimits map[string]ResourceLimits) {
+	env.resourceLimitsLock.Lock()
+	defer env.resourceLimitsLock.Unlock()
+	env.resourceLimits = limits
+	env.pluginResourceLimits = pluginLimits
+}
+
+func (env *Environment) getResourceLimits(pluginId string) ResourceLimits {
+	env.resourceLimitsLock.RLock()
+	defer env.resourceLimitsLock.RUnlock()
+
+	if limits, ok := env.pluginResourceLimits[pluginId]; ok {
+		return limits
+	}
+	return env.resourceLimits
+}
+
 // Performs a full scan of the given path.
 //
 // This function will return info for all subdirectories that appear to be plugins (i.e. all
@@ -156,6 +185,10 @@ func (env *Environment) Statuses() (model.PluginStatuses, error) {
 			Version:     plugin.Manifest.Version,
 		}
 
+		if rp, ok := env.registeredPlugins.Load(plugin.Manifest.Id); ok {
+			status.LimitViolations = rp.(*registeredPlugin).violations.list()
+		}
+
 		pluginStatuses = append(pluginStatuses, status)
 	}
 
@@ -227,7 +260,10 @@ func (env *Environment) Activate(id string) (manifest *model.Manifest, activated
 	}
 
 	if pluginInfo.Manifest.HasServer() {
-		sup, err := newSupervisor(pluginInfo, env.logger, env.newAPIImpl(pluginInfo.Manifest))
+		sup, err := newSupervisorWithLimits(pluginInfo, env.logger, env.newAPIImpl(pluginInfo.Manifest), env.getResourceLimits(id), func(violationType, detail string) {
+			env.logger.Warn("Plugin exceeded a resource limit", mlog.String("plugin_id", id), mlog.String("limit", violationType), mlog.String("detail", detail))
+			rp.violations.record(violationType, detail)
+		})
 		if err != nil {
 			return nil, false, errors.Wrapf(err, "unable to start plugin: %v", id)
 		}
@@ -244,7 +280,9 @@ func (env *Environment) Activate(id string) (manifest *model.Manifest, activated
 }
 
 func (env *Environment) RemovePlugin(id string) {
-	if _, ok := env.registeredPlugins.Load(id); ok {
+	if rp, ok := env.registeredPlugins.Load(id); ok {
+		rp.(*registeredPlugin).cancelRestart()
+		env.pluginEvents.removePlugin(id)
 		env.registeredPlugins.Delete(id)
 	}
 }
@@ -256,6 +294,14 @@ func (env *Environment) Deactivate(id string) bool {
 		return false
 	}
 
+	rp := p.(*registeredPlugin)
+
+	// A deliberate deactivation supersedes any restart scheduled after a crash.
+	rp.cancelRestart()
+
+	// Plugins subscribe to events again as part of their activation.
+	env.pluginEvents.removePlugin(id)
+
 	isActive := env.IsActive(id)
 
 	env.SetPluginState(id, model.PluginStateNotRunning)
@@ -264,7 +310,6 @@ func (env *Environment) Deactivate(id string) bool {
 		return false
 	}
 
-	rp := p.(*registeredPlugin)
 	if rp.supervisor != nil {
 		if err := rp.supervisor.Hooks().OnDeactivate(); err != nil {
 			env.logger.Error("Plugin OnDeactivate() error", mlog.String("plugin_id", rp.BundleInfo.Manifest.Id), mlog.Err(err))
@@ -291,6 +336,8 @@ func (env *Environment) Shutdown() {
 	var wg sync.WaitGroup
 	env.registeredPlugins.Range(func(key, value interface{}) bool {
 		rp := value.(*registeredPlugin)
+		rp.cancelRestart()
+		env.pluginEvents.removePlugin(rp.BundleInfo.Manifest.Id)
 
 		if rp.supervisor == nil {
 			return true
@@ -420,6 +467,41 @@ func (env *Environment) RunMultiPluginHook(hookRunnerFunc func(hooks Hooks) bool
 	})
 }
 
+// scheduleRestart runs restart after the given delay unless cancelled first.
+func (rp *registeredPlugin) scheduleRestart(delay time.Duration, restart func()) {
+	rp.restartLock.Lock()
+	defer rp.restartLock.Unlock()
+
+	if rp.restartTimer != nil {
+		rp.restartTimer.Stop()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(delay, func() {
+		rp.restartLock.Lock()
+		if rp.restartTimer != timer {
+			// Cancelled or superseded by another restart
+			rp.restartLock.Unlock()
+			return
+		}
+		rp.restartTimer = nil
+		rp.restartLock.Unlock()
+
+		restart()
+	})
+	rp.restartTimer = timer
+}
+
+func (rp *registeredPlugin) cancelRestart() {
+	rp.restartLock.Lock()
+	defer rp.restartLock.Unlock()
+
+	if rp.restartTimer != nil {
+		rp.restartTimer.Stop()
+		rp.restartTimer = nil
+	}
+}
+
 func newRegisteredPlugin(bundle *model.BundleInfo) *registeredPlugin {
 	state := model.PluginStateNotRunning
 	return &registeredPlugin{failTimeStamps: []time.Time{}, State: &state, BundleInfo: bundle}
@@ -17,6 +17,8 @@ const (
 	HEALTH_CHECK_DISABLE_DURATION = 60 * time.Minute // How long we wait for num fails to incur before disabling the plugin
 	HEALTH_CHECK_PING_FAIL_LIMIT  = 3                // How many times we call RPC ping in a row before it is considered a failure
 	HEALTH_CHECK_RESTART_LIMIT    = 3                // How many times we restart a plugin before we disable it
+	HEALTH_CHECK_BACKOFF_INITIAL  = 30 * time.Second // How long we wait before restarting a plugin that failed again after a restart
+	HEALTH_CHECK_BACKOFF_MAX      = 10 * time.Minute // The longest we wait before restarting a crashing plugin
 )
 
 type PluginHealthCheckJob struct {
@@ -85,6 +87,8 @@ func (job *PluginHealthCheckJob) checkPlugin(id string) {
 		return
 	}
 
+	sup.CheckMemoryLimit()
+
 	pluginErr := sup.PerformHealthCheck()
 
 	if pluginErr != nil {
@@ -110,6 +114,15 @@ func (job *PluginHealthCheckJob) handleHealthCheckFail(id string, err error) {
 		mlog.Debug(fmt.Sprintf("Deactivating plugin due to multiple crashes `%s`", id))
 		job.env.Deactivate(id)
 		job.env.SetPluginState(id, model.PluginStateFailedToStayRunning)
+	} else if delay := restartBackoff(p); delay > 0 {
+		// The plugin is crash looping, so leave it stopped for a while before trying again.
+		mlog.Debug(fmt.Sprintf("Restarting plugin due to failed health check `%s` in %v", id, delay))
+		job.env.Deactivate(id)
+		p.scheduleRestart(delay, func() {
+			if _, _, err := job.env.Activate(id); err != nil {
+				mlog.Error(fmt.Sprintf("Failed to restart plugin `%s`: %s", id, err.Error()))
+			}
+		})
 	} else {
 		mlog.Debug(fmt.Sprintf("Restarting plugin due to failed health check `%s`", id))
 		if err := job.env.RestartPlugin(id); err != nil {
@@ -133,6 +146,33 @@ func (job *PluginHealthCheckJob) Cancel() {
 	<-job.cancelled
 }
 
+// restartBackoff returns how long to wait before restarting a plugin that has failed its health
+// check. The first failure within HEALTH_CHECK_DISABLE_DURATION restarts the plugin immediately,
+// with the delay doubling for each subsequent failure up to HEALTH_CHECK_BACKOFF_MAX.
+func restartBackoff(rp *registeredPlugin) time.Duration {
+	recentFailures := 0
+	now := time.Now()
+	for _, t := range rp.failTimeStamps {
+		if now.Sub(t) <= HEALTH_CHECK_DISABLE_DURATION {
+			recentFailures++
+		}
+	}
+
+	if recentFailures <= 1 {
+		return 0
+	}
+
+	delay := HEALTH_CHECK_BACKOFF_INITIAL
+	for i := 2; i < recentFailures && delay < HEALTH_CHECK_BACKOFF_MAX; i++ {
+		delay *= 2
+	}
+	if delay > HEALTH_CHECK_BACKOFF_MAX {
+		delay = HEALTH_CHECK_BACKOFF_MAX
+	}
+
+	return delay
+}
+
 // shouldDeactivatePlugin determines if a plugin needs to be deactivated after certain criteria is met.
 //
 // The criteria is based on if the plugin has consistently failed during the configured number of restarts, within the configured time window.
@@ -154,3 +154,55 @@ func TestShouldDeactivatePlugin(t *testing.T) {
 	result = shouldDeactivatePlugin(rp)
 	require.Equal(t, false, result)
 }
+
+func TestRestartBackoff(t *testing.T) {
+	bundle := &model.BundleInfo{}
+	now := time.Now()
+
+	// A single recent failure restarts immediately
+	rp := newRegisteredPlugin(bundle)
+	rp.failTimeStamps = append(rp.failTimeStamps, now)
+	require.Equal(t, time.Duration(0), restartBackoff(rp))
+
+	// Old failures don't count towards the backoff
+	rp = newRegisteredPlugin(bundle)
+	rp.failTimeStamps = append(rp.failTimeStamps, now.Add(-HEALTH_CHECK_DISABLE_DURATION*2))
+	rp.failTimeStamps = append(rp.failTimeStamps, now)
+	require.Equal(t, time.Duration(0), restartBackoff(rp))
+
+	// Repeated failures back off exponentially
+	rp = newRegisteredPlugin(bundle)
+	rp.failTimeStamps = append(rp.failTimeStamps, now.Add(-time.Minute), now)
+	require.Equal(t, HEALTH_CHECK_BACKOFF_INITIAL, restartBackoff(rp))
+
+	rp.failTimeStamps = append(rp.failTimeStamps, now)
+	require.Equal(t, HEALTH_CHECK_BACKOFF_INITIAL*2, restartBackoff(rp))
+
+	// The backoff is capped
+	for i := 0; i < 10; i++ {
+		rp.failTimeStamps = append(rp.failTimeStamps, now)
+	}
+	require.Equal(t, HEALTH_CHECK_BACKOFF_MAX, restartBackoff(rp))
+}
+
+func TestScheduleRestart(t *testing.T) {
+	rp := newRegisteredPlugin(&model.BundleInfo{})
+
+	restarted := make(chan bool, 1)
+	rp.scheduleRestart(10*time.Millisecond, func() { restarted <- true })
+
+	select {
+	case <-restarted:
+	case <-time.After(time.Second):
+		require.Fail(t, "plugin was not restarted")
+	}
+
+	rp.scheduleRestart(10*time.Millisecond, func() { restarted <- true })
+	rp.cancelRestart()
+
+	select {
+	case <-restarted:
+		require.Fail(t, "cancelled restart should not run")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
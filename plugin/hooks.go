@@ -15,25 +15,37 @@ import (
 // Feel free to add more, but do not change existing assignments. Follow the naming convention of
 // <HookName>Id as the autogenerated glue code depends on that.
 const (
-	OnActivateId            = 0
-	OnDeactivateId          = 1
-	ServeHTTPId             = 2
-	OnConfigurationChangeId = 3
-	ExecuteCommandId        = 4
-	MessageWillBePostedId   = 5
-	MessageWillBeUpdatedId  = 6
-	MessageHasBeenPostedId  = 7
-	MessageHasBeenUpdatedId = 8
-	UserHasJoinedChannelId  = 9
-	UserHasLeftChannelId    = 10
-	UserHasJoinedTeamId     = 11
-	UserHasLeftTeamId       = 12
-	ChannelHasBeenCreatedId = 13
-	FileWillBeUploadedId    = 14
-	UserWillLogInId         = 15
-	UserHasLoggedInId       = 16
-	UserHasBeenCreatedId    = 17
-	TotalHooksId            = iota
+	OnActivateId               = 0
+	OnDeactivateId             = 1
+	ServeHTTPId                = 2
+	OnConfigurationChangeId    = 3
+	ExecuteCommandId           = 4
+	MessageWillBePostedId      = 5
+	MessageWillBeUpdatedId     = 6
+	MessageHasBeenPostedId     = 7
+	MessageHasBeenUpdatedId    = 8
+	UserHasJoinedChannelId     = 9
+	UserHasLeftChannelId       = 10
+	UserHasJoinedTeamId        = 11
+	UserHasLeftTeamId          = 12
+	ChannelHasBeenCreatedId    = 13
+	FileWillBeUploadedId       = 14
+	UserWillLogInId            = 15
+	UserHasLoggedInId          = 16
+	UserHasBeenCreatedId       = 17
+	OnScheduledJobId           = 18
+	ReactionHasBeenAddedId     = 19
+	ReactionHasBeenRemovedId   = 20
+	ChannelHasBeenUpdatedId    = 21
+	ChannelHasBeenArchivedId   = 22
+	UserHasBeenUpdatedId       = 23
+	UserHasBeenDeactivatedId   = 24
+	PostHasBeenDeletedId       = 25
+	FileHasBeenDownloadedId    = 26
+	OnPluginRequestId          = 27
+	OnPluginEventId            = 28
+	ConfigurationWillBeSavedId = 29
+	TotalHooksId               = iota
 )
 
 const (
@@ -82,6 +94,16 @@ type Hooks interface {
 	// Minimum server version: 5.10
 	UserHasBeenCreated(c *Context, user *model.User)
 
+	// UserHasBeenUpdated is invoked after a user has been updated in the database.
+	//
+	// Minimum server version: 5.15
+	UserHasBeenUpdated(c *Context, newUser, oldUser *model.User)
+
+	// UserHasBeenDeactivated is invoked after a user has been deactivated.
+	//
+	// Minimum server version: 5.15
+	UserHasBeenDeactivated(c *Context, user *model.User)
+
 	// UserWillLogIn before the login of the user is returned. Returning a non empty string will reject the login event.
 	// If you don't need to reject the login event, see UserHasLoggedIn
 	UserWillLogIn(c *Context, user *model.User) string
@@ -126,9 +148,35 @@ type Hooks interface {
 	// created the post.
 	MessageHasBeenUpdated(c *Context, newPost, oldPost *model.Post)
 
+	// PostHasBeenDeleted is invoked after a post has been deleted from the database.
+	//
+	// Minimum server version: 5.15
+	PostHasBeenDeleted(c *Context, post *model.Post)
+
+	// ReactionHasBeenAdded is invoked after a reaction has been committed to the database.
+	//
+	// Minimum server version: 5.15
+	ReactionHasBeenAdded(c *Context, reaction *model.Reaction)
+
+	// ReactionHasBeenRemoved is invoked after a reaction has been removed from the database.
+	//
+	// Minimum server version: 5.15
+	ReactionHasBeenRemoved(c *Context, reaction *model.Reaction)
+
 	// ChannelHasBeenCreated is invoked after the channel has been committed to the database.
 	ChannelHasBeenCreated(c *Context, channel *model.Channel)
 
+	// ChannelHasBeenUpdated is invoked after the channel has been updated in the database.
+	//
+	// Minimum server version: 5.15
+	ChannelHasBeenUpdated(c *Context, channel *model.Channel)
+
+	// ChannelHasBeenArchived is invoked after the channel has been archived in the database.
+	// If actor is not nil, the channel was archived by the actor.
+	//
+	// Minimum server version: 5.15
+	ChannelHasBeenArchived(c *Context, channel *model.Channel, actor *model.User)
+
 	// UserHasJoinedChannel is invoked after the membership has been committed to the database.
 	// If actor is not nil, the user was invited to the channel by the actor.
 	UserHasJoinedChannel(c *Context, channelMember *model.ChannelMember, actor *model.User)
@@ -155,4 +203,38 @@ type Hooks interface {
 	// Note that this method will be called for files uploaded by plugins, including the plugin that uploaded the post.
 	// FileInfo.Size will be automatically set properly if you modify the file.
 	FileWillBeUploaded(c *Context, info *model.FileInfo, file io.Reader, output io.Writer) (*model.FileInfo, string)
+
+	// FileHasBeenDownloaded is invoked after a file has been sent to a client. userId is empty
+	// when the file was downloaded through a public link.
+	//
+	// Minimum server version: 5.15
+	FileHasBeenDownloaded(c *Context, info *model.FileInfo, userId string)
+
+	// OnScheduledJob is invoked when a job registered with API.RegisterScheduledJob is due. Each run
+	// is delivered to only one server in a cluster. Returning an error marks the job as failed.
+	OnScheduledJob(c *Context, name string) error
+
+	// OnPluginRequest is invoked when another plugin calls one of the methods declared in this
+	// plugin's manifest through API.CallPlugin. The returned bytes or error are passed back to
+	// the calling plugin. Calls made to other plugins while handling the request should pass c
+	// on to API.CallPlugin.
+	//
+	// Minimum server version: 5.15
+	OnPluginRequest(c *Context, sourcePluginId string, method string, request []byte) ([]byte, *model.AppError)
+
+	// OnPluginEvent is invoked when another plugin publishes an event this plugin has subscribed
+	// to through API.SubscribePluginEvent. Events are delivered in the order they were published.
+	//
+	// Minimum server version: 5.15
+	OnPluginEvent(c *Context, sourcePluginId string, event string, payload []byte)
+
+	// ConfigurationWillBeSaved is invoked before a change to this plugin's settings is saved,
+	// after the settings have been validated against the manifest's settings schema. newSettings
+	// holds all of the plugin's settings as they will be saved.
+	//
+	// To reject the change, return a non-empty string describing why the settings are invalid.
+	// To allow the change, return an empty string.
+	//
+	// Minimum server version: 5.15
+	ConfigurationWillBeSaved(c *Context, newSettings map[string]interface{}) string
 }
@@ -262,7 +262,7 @@ func (g *hooksRPCClient) {{.Name}}{{funcStyle .Params}} {{funcStyle .Return}} {
 	_args := &{{.Name | obscure}}Args{ {{valuesOnly .Params}} }
 	_returns := &{{.Name | obscure}}Returns{}
 	if g.implemented[{{.Name}}Id] {
-		if err := g.client.Call("Plugin.{{.Name}}", _args, _returns); err != nil {
+		if err := g.call("Plugin.{{.Name}}", _args, _returns); err != nil {
 			g.log.Error("RPC call {{.Name}} to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -0,0 +1,193 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/mattermost/mattermost-server/mlog"
+)
+
+const (
+	// PLUGIN_EVENT_QUEUE_SIZE is how many events may be waiting for delivery to a single plugin
+	// before further events for that plugin are dropped.
+	PLUGIN_EVENT_QUEUE_SIZE = 256
+)
+
+var (
+	ErrPluginNotActive         = errors.New("plugin is not active")
+	ErrPluginMethodNotDeclared = errors.New("plugin does not declare the requested method")
+	ErrCircularPluginCall      = errors.New("plugin call would create a cycle")
+)
+
+type pluginEvent struct {
+	context        *Context
+	sourcePluginId string
+	event          string
+	payload        []byte
+}
+
+// pluginEventBus delivers published events to subscribed plugins. Each subscriber has its own
+// queue so that events are delivered in the order they were published without the publisher
+// waiting on any subscriber.
+type pluginEventBus struct {
+	mutex         sync.Mutex
+	subscriptions map[string]map[string]bool
+	queues        map[string]chan *pluginEvent
+}
+
+func (bus *pluginEventBus) subscribe(pluginId, event string, deliver func(pluginId string, ev *pluginEvent)) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	if bus.subscriptions == nil {
+		bus.subscriptions = make(map[string]map[string]bool)
+		bus.queues = make(map[string]chan *pluginEvent)
+	}
+	if bus.subscriptions[event] == nil {
+		bus.subscriptions[event] = make(map[string]bool)
+	}
+	bus.subscriptions[event][pluginId] = true
+
+	if _, ok := bus.queues[pluginId]; !ok {
+		queue := make(chan *pluginEvent, PLUGIN_EVENT_QUEUE_SIZE)
+		bus.queues[pluginId] = queue
+		go func() {
+			for ev := range queue {
+				deliver(pluginId, ev)
+			}
+		}()
+	}
+}
+
+func (bus *pluginEventBus) unsubscribe(pluginId, event string) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	delete(bus.subscriptions[event], pluginId)
+	if len(bus.subscriptions[event]) == 0 {
+		delete(bus.subscriptions, event)
+	}
+}
+
+// removePlugin drops all of the plugin's subscriptions and any events still waiting for it.
+func (bus *pluginEventBus) removePlugin(pluginId string) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	for event, subscribers := range bus.subscriptions {
+		delete(subscribers, pluginId)
+		if len(subscribers) == 0 {
+			delete(bus.subscriptions, event)
+		}
+	}
+
+	if queue, ok := bus.queues[pluginId]; ok {
+		close(queue)
+		delete(bus.queues, pluginId)
+	}
+}
+
+func (bus *pluginEventBus) publish(ev *pluginEvent) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	for pluginId := range bus.subscriptions[ev.event] {
+		if pluginId == ev.sourcePluginId {
+			continue
+		}
+
+		select {
+		case bus.queues[pluginId] <- ev:
+		default:
+			mlog.Warn("Dropping plugin event for slow subscriber", mlog.String("plugin_id", pluginId), mlog.String("event", ev.event), mlog.String("source_plugin_id", ev.sourcePluginId))
+		}
+	}
+}
+
+// PreparePluginCall prepares a call from the source plugin to a method declared by the target
+// plugin, made while the source handles a hook with the given context. It returns the target's
+// hooks and the chain of plugin calls to pass to its OnPluginRequest hook.
+//
+// The target must be active, declare the method in its manifest and implement OnPluginRequest.
+// Calls back into a plugin already waiting on the chain fail with ErrCircularPluginCall. Each
+// chain only covers the calls made on behalf of a single hook, so concurrent calls between the
+// same plugins do not conflict.
+func (env *Environment) PreparePluginCall(c *Context, sourcePluginId, targetPluginId, method string) (Hooks, []string, error) {
+	p, ok := env.registeredPlugins.Load(targetPluginId)
+	if !ok || !env.IsActive(targetPluginId) {
+		return nil, nil, ErrPluginNotActive
+	}
+
+	rp := p.(*registeredPlugin)
+	if rp.supervisor == nil || !rp.supervisor.Implements(OnPluginRequestId) || !rp.BundleInfo.Manifest.DeclaresMethod(method) {
+		return nil, nil, ErrPluginMethodNotDeclared
+	}
+
+	chain, err := nextPluginCallChain(c, sourcePluginId, targetPluginId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rp.supervisor.Hooks(), chain, nil
+}
+
+// nextPluginCallChain extends the chain of plugin calls in the context with a call from the
+// source plugin to the target.
+func nextPluginCallChain(c *Context, sourcePluginId, targetPluginId string) ([]string, error) {
+	var chain []string
+	if c != nil {
+		chain = c.PluginCallChain
+	}
+
+	if targetPluginId == sourcePluginId {
+		return nil, ErrCircularPluginCall
+	}
+	for _, pluginId := range chain {
+		if pluginId == targetPluginId {
+			return nil, ErrCircularPluginCall
+		}
+	}
+
+	next := make([]string, 0, len(chain)+1)
+	next = append(next, chain...)
+	return append(next, sourcePluginId), nil
+}
+
+// SubscribePluginEvent delivers events with the given name to the plugin's OnPluginEvent hook
+// until the plugin unsubscribes or is deactivated.
+func (env *Environment) SubscribePluginEvent(pluginId, event string) {
+	env.pluginEvents.subscribe(pluginId, event, env.deliverPluginEvent)
+}
+
+// UnsubscribePluginEvent stops delivering events with the given name to the plugin.
+func (env *Environment) UnsubscribePluginEvent(pluginId, event string) {
+	env.pluginEvents.unsubscribe(pluginId, event)
+}
+
+// PublishPluginEvent queues an event for every other plugin subscribed to it. Events are only
+// delivered to plugins that are active at the time of delivery.
+func (env *Environment) PublishPluginEvent(c *Context, sourcePluginId, event string, payload []byte) {
+	env.pluginEvents.publish(&pluginEvent{
+		context:        c,
+		sourcePluginId: sourcePluginId,
+		event:          event,
+		payload:        payload,
+	})
+}
+
+func (env *Environment) deliverPluginEvent(pluginId string, ev *pluginEvent) {
+	p, ok := env.registeredPlugins.Load(pluginId)
+	if !ok || !env.IsActive(pluginId) {
+		return
+	}
+
+	rp := p.(*registeredPlugin)
+	if rp.supervisor == nil || !rp.supervisor.Implements(OnPluginEventId) {
+		return
+	}
+
+	rp.supervisor.Hooks().OnPluginEvent(ev.context, ev.sourcePluginId, ev.event, ev.payload)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextPluginCallChain(t *testing.T) {
+	_, err := nextPluginCallChain(nil, "a", "a")
+	assert.Equal(t, ErrCircularPluginCall, err)
+
+	chain, err := nextPluginCallChain(nil, "a", "b")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, chain)
+
+	chain, err = nextPluginCallChain(&Context{PluginCallChain: chain}, "b", "c")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, chain)
+
+	// c -> a would complete the cycle a -> b -> c -> a
+	_, err = nextPluginCallChain(&Context{PluginCallChain: chain}, "c", "a")
+	assert.Equal(t, ErrCircularPluginCall, err)
+
+	// Calls on behalf of other hooks only see their own chain
+	chain, err = nextPluginCallChain(&Context{}, "c", "a")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c"}, chain)
+}
+
+func TestPluginEventBus(t *testing.T) {
+	var bus pluginEventBus
+
+	var mutex sync.Mutex
+	delivered := map[string][]string{}
+	deliver := func(pluginId string, ev *pluginEvent) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		delivered[pluginId] = append(delivered[pluginId], ev.event+":"+string(ev.payload))
+	}
+	waitFor := func(pluginId string, count int) []string {
+		for i := 0; i < 100; i++ {
+			mutex.Lock()
+			events := delivered[pluginId]
+			mutex.Unlock()
+			if len(events) >= count {
+				return events
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		mutex.Lock()
+		defer mutex.Unlock()
+		return delivered[pluginId]
+	}
+
+	bus.subscribe("a", "created", deliver)
+	bus.subscribe("b", "created", deliver)
+	bus.subscribe("b", "deleted", deliver)
+
+	for _, payload := range []string{"1", "2", "3"} {
+		bus.publish(&pluginEvent{sourcePluginId: "a", event: "created", payload: []byte(payload)})
+	}
+	bus.publish(&pluginEvent{sourcePluginId: "c", event: "deleted", payload: []byte("4")})
+
+	// Events are delivered in order and never back to the publisher
+	assert.Equal(t, []string{"created:1", "created:2", "created:3", "deleted:4"}, waitFor("b", 4))
+	assert.Empty(t, waitFor("a", 0))
+
+	bus.unsubscribe("b", "created")
+	bus.removePlugin("a")
+	bus.publish(&pluginEvent{sourcePluginId: "c", event: "created", payload: []byte("5")})
+	bus.publish(&pluginEvent{sourcePluginId: "c", event: "deleted", payload: []byte("6")})
+
+	assert.Equal(t, []string{"created:1", "created:2", "created:3", "deleted:4", "deleted:6"}, waitFor("b", 5))
+	assert.Empty(t, waitFor("a", 0))
+	assert.NotContains(t, bus.queues, "a")
+}
@@ -7,6 +7,8 @@ package plugintest
 import (
 	model "github.com/mattermost/mattermost-server/model"
 	mock "github.com/stretchr/testify/mock"
+
+	plugin "github.com/mattermost/mattermost-server/plugin"
 )
 
 // API is an autogenerated mock type for the API type
@@ -64,6 +66,31 @@ func (_m *API) AddReaction(reaction *model.Reaction) (*model.Reaction, *model.Ap
 	return r0, r1
 }
 
+// CallPlugin provides a mock function with given fields: c, pluginId, method, request
+func (_m *API) CallPlugin(c *plugin.Context, pluginId string, method string, request []byte) ([]byte, *model.AppError) {
+	ret := _m.Called(c, pluginId, method, request)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(*plugin.Context, string, string, []byte) []byte); ok {
+		r0 = rf(c, pluginId, method, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(*plugin.Context, string, string, []byte) *model.AppError); ok {
+		r1 = rf(c, pluginId, method, request)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // CopyFileInfos provides a mock function with given fields: userId, fileIds
 func (_m *API) CopyFileInfos(userId string, fileIds []string) ([]string, *model.AppError) {
 	ret := _m.Called(userId, fileIds)
@@ -2024,6 +2051,31 @@ func (_m *API) KVGet(key string) ([]byte, *model.AppError) {
 	return r0, r1
 }
 
+// KVGetMulti provides a mock function with given fields: keys
+func (_m *API) KVGetMulti(keys []string) (map[string][]byte, *model.AppError) {
+	ret := _m.Called(keys)
+
+	var r0 map[string][]byte
+	if rf, ok := ret.Get(0).(func([]string) map[string][]byte); ok {
+		r0 = rf(keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]byte)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func([]string) *model.AppError); ok {
+		r1 = rf(keys)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // KVList provides a mock function with given fields: page, perPage
 func (_m *API) KVList(page int, perPage int) ([]string, *model.AppError) {
 	ret := _m.Called(page, perPage)
@@ -2049,6 +2101,31 @@ func (_m *API) KVList(page int, perPage int) ([]string, *model.AppError) {
 	return r0, r1
 }
 
+// KVListWithPrefix provides a mock function with given fields: prefix, page, perPage
+func (_m *API) KVListWithPrefix(prefix string, page int, perPage int) ([]string, *model.AppError) {
+	ret := _m.Called(prefix, page, perPage)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, int, int) []string); ok {
+		r0 = rf(prefix, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, int, int) *model.AppError); ok {
+		r1 = rf(prefix, page, perPage)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // KVSet provides a mock function with given fields: key, value
 func (_m *API) KVSet(key string, value []byte) *model.AppError {
 	ret := _m.Called(key, value)
@@ -2065,6 +2142,22 @@ func (_m *API) KVSet(key string, value []byte) *model.AppError {
 	return r0
 }
 
+// KVSetMulti provides a mock function with given fields: values
+func (_m *API) KVSetMulti(values map[string][]byte) *model.AppError {
+	ret := _m.Called(values)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(map[string][]byte) *model.AppError); ok {
+		r0 = rf(values)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // KVSetWithExpiry provides a mock function with given fields: key, value, expireInSeconds
 func (_m *API) KVSetWithExpiry(key string, value []byte, expireInSeconds int64) *model.AppError {
 	ret := _m.Called(key, value, expireInSeconds)
@@ -2081,6 +2174,29 @@ func (_m *API) KVSetWithExpiry(key string, value []byte, expireInSeconds int64)
 	return r0
 }
 
+// KVSetWithOptions provides a mock function with given fields: key, value, options
+func (_m *API) KVSetWithOptions(key string, value []byte, options model.PluginKVSetOptions) (bool, *model.AppError) {
+	ret := _m.Called(key, value, options)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, []byte, model.PluginKVSetOptions) bool); ok {
+		r0 = rf(key, value, options)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, []byte, model.PluginKVSetOptions) *model.AppError); ok {
+		r1 = rf(key, value, options)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // LoadPluginConfiguration provides a mock function with given fields: dest
 func (_m *API) LoadPluginConfiguration(dest interface{}) error {
 	ret := _m.Called(dest)
@@ -2184,6 +2300,22 @@ func (_m *API) PermanentDeleteBot(botUserId string) *model.AppError {
 	return r0
 }
 
+// PublishPluginEvent provides a mock function with given fields: event, payload
+func (_m *API) PublishPluginEvent(event string, payload []byte) *model.AppError {
+	ret := _m.Called(event, payload)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, []byte) *model.AppError); ok {
+		r0 = rf(event, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // PublishWebSocketEvent provides a mock function with given fields: event, payload, broadcast
 func (_m *API) PublishWebSocketEvent(event string, payload map[string]interface{}, broadcast *model.WebsocketBroadcast) {
 	_m.Called(event, payload, broadcast)
@@ -2228,6 +2360,22 @@ func (_m *API) RegisterCommand(command *model.Command) error {
 	return r0
 }
 
+// RegisterScheduledJob provides a mock function with given fields: job
+func (_m *API) RegisterScheduledJob(job *model.PluginScheduledJob) *model.AppError {
+	ret := _m.Called(job)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(*model.PluginScheduledJob) *model.AppError); ok {
+		r0 = rf(job)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // RemovePlugin provides a mock function with given fields: id
 func (_m *API) RemovePlugin(id string) *model.AppError {
 	ret := _m.Called(id)
@@ -2488,6 +2636,22 @@ func (_m *API) SetTeamIcon(teamId string, data []byte) *model.AppError {
 	return r0
 }
 
+// SubscribePluginEvent provides a mock function with given fields: event
+func (_m *API) SubscribePluginEvent(event string) *model.AppError {
+	ret := _m.Called(event)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string) *model.AppError); ok {
+		r0 = rf(event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // UnregisterCommand provides a mock function with given fields: teamId, trigger
 func (_m *API) UnregisterCommand(teamId string, trigger string) error {
 	ret := _m.Called(teamId, trigger)
@@ -2502,6 +2666,38 @@ func (_m *API) UnregisterCommand(teamId string, trigger string) error {
 	return r0
 }
 
+// UnregisterScheduledJob provides a mock function with given fields: name
+func (_m *API) UnregisterScheduledJob(name string) *model.AppError {
+	ret := _m.Called(name)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string) *model.AppError); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
+// UnsubscribePluginEvent provides a mock function with given fields: event
+func (_m *API) UnsubscribePluginEvent(event string) *model.AppError {
+	ret := _m.Called(event)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string) *model.AppError); ok {
+		r0 = rf(event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // UpdateBotActive provides a mock function with given fields: botUserId, active
 func (_m *API) UpdateBotActive(botUserId string, active bool) (*model.Bot, *model.AppError) {
 	ret := _m.Called(botUserId, active)
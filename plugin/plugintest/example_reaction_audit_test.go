@@ -0,0 +1,68 @@
+package plugintest_test
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/plugin"
+	"github.com/mattermost/mattermost-server/plugin/plugintest"
+)
+
+type ReactionAuditPlugin struct {
+	plugin.MattermostPlugin
+}
+
+func (p *ReactionAuditPlugin) ReactionHasBeenAdded(c *plugin.Context, reaction *model.Reaction) {
+	p.API.LogInfo("Reaction added", "user_id", reaction.UserId, "post_id", reaction.PostId, "emoji_name", reaction.EmojiName)
+}
+
+func (p *ReactionAuditPlugin) ReactionHasBeenRemoved(c *plugin.Context, reaction *model.Reaction) {
+	p.API.LogInfo("Reaction removed", "user_id", reaction.UserId, "post_id", reaction.PostId, "emoji_name", reaction.EmojiName)
+}
+
+func (p *ReactionAuditPlugin) PostHasBeenDeleted(c *plugin.Context, post *model.Post) {
+	p.API.LogInfo("Post deleted", "post_id", post.Id)
+}
+
+func TestReactionAuditPlugin(t *testing.T) {
+	reaction := &model.Reaction{
+		UserId:    model.NewId(),
+		PostId:    model.NewId(),
+		EmojiName: "smile",
+	}
+	post := &model.Post{Id: reaction.PostId}
+
+	api := &plugintest.API{}
+	api.On("LogInfo", "Reaction added", "user_id", reaction.UserId, "post_id", reaction.PostId, "emoji_name", "smile").Return().Once()
+	api.On("LogInfo", "Reaction removed", "user_id", reaction.UserId, "post_id", reaction.PostId, "emoji_name", "smile").Return().Once()
+	api.On("LogInfo", "Post deleted", "post_id", post.Id).Return().Once()
+	defer api.AssertExpectations(t)
+
+	p := &ReactionAuditPlugin{}
+	p.SetAPI(api)
+
+	p.ReactionHasBeenAdded(&plugin.Context{}, reaction)
+	p.ReactionHasBeenRemoved(&plugin.Context{}, reaction)
+	p.PostHasBeenDeleted(&plugin.Context{}, post)
+}
+
+func TestHooksMock(t *testing.T) {
+	channel := &model.Channel{Id: model.NewId()}
+	user := &model.User{Id: model.NewId()}
+	info := &model.FileInfo{Id: model.NewId()}
+
+	hooks := &plugintest.Hooks{}
+	hooks.On("ChannelHasBeenUpdated", &plugin.Context{}, channel).Return().Once()
+	hooks.On("ChannelHasBeenArchived", &plugin.Context{}, channel, user).Return().Once()
+	hooks.On("UserHasBeenUpdated", &plugin.Context{}, user, user).Return().Once()
+	hooks.On("UserHasBeenDeactivated", &plugin.Context{}, user).Return().Once()
+	hooks.On("FileHasBeenDownloaded", &plugin.Context{}, info, user.Id).Return().Once()
+	defer hooks.AssertExpectations(t)
+
+	var h plugin.Hooks = hooks
+	h.ChannelHasBeenUpdated(&plugin.Context{}, channel)
+	h.ChannelHasBeenArchived(&plugin.Context{}, channel, user)
+	h.UserHasBeenUpdated(&plugin.Context{}, user, user)
+	h.UserHasBeenDeactivated(&plugin.Context{}, user)
+	h.FileHasBeenDownloaded(&plugin.Context{}, info, user.Id)
+}
@@ -20,11 +20,35 @@ type Hooks struct {
 	mock.Mock
 }
 
+// ChannelHasBeenArchived provides a mock function with given fields: c, channel, actor
+func (_m *Hooks) ChannelHasBeenArchived(c *plugin.Context, channel *model.Channel, actor *model.User) {
+	_m.Called(c, channel, actor)
+}
+
 // ChannelHasBeenCreated provides a mock function with given fields: c, channel
 func (_m *Hooks) ChannelHasBeenCreated(c *plugin.Context, channel *model.Channel) {
 	_m.Called(c, channel)
 }
 
+// ChannelHasBeenUpdated provides a mock function with given fields: c, channel
+func (_m *Hooks) ChannelHasBeenUpdated(c *plugin.Context, channel *model.Channel) {
+	_m.Called(c, channel)
+}
+
+// ConfigurationWillBeSaved provides a mock function with given fields: c, newSettings
+func (_m *Hooks) ConfigurationWillBeSaved(c *plugin.Context, newSettings map[string]interface{}) string {
+	ret := _m.Called(c, newSettings)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, map[string]interface{}) string); ok {
+		r0 = rf(c, newSettings)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // ExecuteCommand provides a mock function with given fields: c, args
 func (_m *Hooks) ExecuteCommand(c *plugin.Context, args *model.CommandArgs) (*model.CommandResponse, *model.AppError) {
 	ret := _m.Called(c, args)
@@ -50,6 +74,11 @@ func (_m *Hooks) ExecuteCommand(c *plugin.Context, args *model.CommandArgs) (*mo
 	return r0, r1
 }
 
+// FileHasBeenDownloaded provides a mock function with given fields: c, info, userId
+func (_m *Hooks) FileHasBeenDownloaded(c *plugin.Context, info *model.FileInfo, userId string) {
+	_m.Called(c, info, userId)
+}
+
 // FileWillBeUploaded provides a mock function with given fields: c, info, file, output
 func (_m *Hooks) FileWillBeUploaded(c *plugin.Context, info *model.FileInfo, file io.Reader, output io.Writer) (*model.FileInfo, string) {
 	ret := _m.Called(c, info, file, output)
@@ -194,6 +223,65 @@ func (_m *Hooks) OnDeactivate() error {
 	return r0
 }
 
+// OnPluginEvent provides a mock function with given fields: c, sourcePluginId, event, payload
+func (_m *Hooks) OnPluginEvent(c *plugin.Context, sourcePluginId string, event string, payload []byte) {
+	_m.Called(c, sourcePluginId, event, payload)
+}
+
+// OnPluginRequest provides a mock function with given fields: c, sourcePluginId, method, request
+func (_m *Hooks) OnPluginRequest(c *plugin.Context, sourcePluginId string, method string, request []byte) ([]byte, *model.AppError) {
+	ret := _m.Called(c, sourcePluginId, method, request)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(*plugin.Context, string, string, []byte) []byte); ok {
+		r0 = rf(c, sourcePluginId, method, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(*plugin.Context, string, string, []byte) *model.AppError); ok {
+		r1 = rf(c, sourcePluginId, method, request)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// OnScheduledJob provides a mock function with given fields: c, name
+func (_m *Hooks) OnScheduledJob(c *plugin.Context, name string) error {
+	ret := _m.Called(c, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, string) error); ok {
+		r0 = rf(c, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PostHasBeenDeleted provides a mock function with given fields: c, post
+func (_m *Hooks) PostHasBeenDeleted(c *plugin.Context, post *model.Post) {
+	_m.Called(c, post)
+}
+
+// ReactionHasBeenAdded provides a mock function with given fields: c, reaction
+func (_m *Hooks) ReactionHasBeenAdded(c *plugin.Context, reaction *model.Reaction) {
+	_m.Called(c, reaction)
+}
+
+// ReactionHasBeenRemoved provides a mock function with given fields: c, reaction
+func (_m *Hooks) ReactionHasBeenRemoved(c *plugin.Context, reaction *model.Reaction) {
+	_m.Called(c, reaction)
+}
+
 // ServeHTTP provides a mock function with given fields: c, w, r
 func (_m *Hooks) ServeHTTP(c *plugin.Context, w http.ResponseWriter, r *http.Request) {
 	_m.Called(c, w, r)
@@ -204,6 +292,16 @@ func (_m *Hooks) UserHasBeenCreated(c *plugin.Context, user *model.User) {
 	_m.Called(c, user)
 }
 
+// UserHasBeenDeactivated provides a mock function with given fields: c, user
+func (_m *Hooks) UserHasBeenDeactivated(c *plugin.Context, user *model.User) {
+	_m.Called(c, user)
+}
+
+// UserHasBeenUpdated provides a mock function with given fields: c, newUser, oldUser
+func (_m *Hooks) UserHasBeenUpdated(c *plugin.Context, newUser *model.User, oldUser *model.User) {
+	_m.Called(c, newUser, oldUser)
+}
+
 // UserHasJoinedChannel provides a mock function with given fields: c, channelMember, actor
 func (_m *Hooks) UserHasJoinedChannel(c *plugin.Context, channelMember *model.ChannelMember, actor *model.User) {
 	_m.Called(c, channelMember, actor)
@@ -0,0 +1,227 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"net/rpc"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// ResourceLimits are applied to each server-side plugin process as it is activated. A zero value
+// for any field means the corresponding resource is not limited.
+type ResourceLimits struct {
+	// MaxMemoryBytes caps the memory of the plugin process using cgroups v2 where available.
+	MaxMemoryBytes int64
+
+	// MaxCPUPercent caps the CPU time of the plugin process, as a percentage of a single core of
+	// up to model.PLUGIN_SETTINGS_MAX_CPU_PERCENT, using cgroups v2 where available.
+	MaxCPUPercent int
+
+	// HookTimeout is the longest a single hook invocation may take before it is abandoned.
+	HookTimeout time.Duration
+
+	// MaxConcurrentHooks is the maximum number of hook invocations in flight for a plugin. Further
+	// invocations wait for one of them to finish.
+	MaxConcurrentHooks int
+}
+
+// hookQueueTimeout is how long a hook invocation waits for a free slot when the plugin has no
+// hook timeout. Otherwise it waits for up to the hook timeout.
+const hookQueueTimeout = 5 * time.Second
+
+// ResourceLimitsFromConfig builds the default plugin resource limits from the plugin settings, along
+// with the limits of each plugin that overrides any of them.
+func ResourceLimitsFromConfig(settings *model.PluginSettings) (ResourceLimits, map[string]ResourceLimits) {
+	defaults := ResourceLimits{
+		MaxMemoryBytes:     int64(*settings.MaxMemoryMB) * 1024 * 1024,
+		MaxCPUPercent:      *settings.MaxCPUPercent,
+		HookTimeout:        time.Duration(*settings.HookTimeoutSeconds) * time.Second,
+		MaxConcurrentHooks: *settings.MaxConcurrentHooks,
+	}
+
+	overrides := make(map[string]ResourceLimits, len(settings.PluginLimits))
+	for pluginId, pluginLimits := range settings.PluginLimits {
+		if pluginLimits == nil {
+			continue
+		}
+
+		limits := defaults
+		if pluginLimits.MaxMemoryMB != nil {
+			limits.MaxMemoryBytes = int64(*pluginLimits.MaxMemoryMB) * 1024 * 1024
+		}
+		if pluginLimits.MaxCPUPercent != nil {
+			limits.MaxCPUPercent = *pluginLimits.MaxCPUPercent
+		}
+		if pluginLimits.HookTimeoutSeconds != nil {
+			limits.HookTimeout = time.Duration(*pluginLimits.HookTimeoutSeconds) * time.Second
+		}
+		if pluginLimits.MaxConcurrentHooks != nil {
+			limits.MaxConcurrentHooks = *pluginLimits.MaxConcurrentHooks
+		}
+		overrides[pluginId] = limits
+	}
+
+	return defaults, overrides
+}
+
+var errCgroupsUnavailable = errors.New("cgroups v2 is not available")
+
+// hookLimitError is returned for a hook invocation that was refused or abandoned because of the
+// plugin's resource limits, as opposed to one that failed in the plugin.
+type hookLimitError struct {
+	message string
+}
+
+func (e *hookLimitError) Error() string {
+	return e.message
+}
+
+// isHookLimitError returns true if the hook invocation failed because of the plugin's resource limits.
+func isHookLimitError(err error) bool {
+	_, ok := err.(*hookLimitError)
+	return ok
+}
+
+// limitViolations tracks the resource limit violations of a single plugin across restarts.
+type limitViolations struct {
+	mutex      sync.Mutex
+	violations map[string]*model.PluginLimitViolation
+}
+
+func (lv *limitViolations) record(violationType, detail string) {
+	lv.mutex.Lock()
+	defer lv.mutex.Unlock()
+
+	if lv.violations == nil {
+		lv.violations = make(map[string]*model.PluginLimitViolation)
+	}
+
+	violation, ok := lv.violations[violationType]
+	if !ok {
+		violation = &model.PluginLimitViolation{Type: violationType}
+		lv.violations[violationType] = violation
+	}
+
+	violation.Count++
+	violation.LastAt = model.GetMillis()
+	violation.Detail = detail
+}
+
+// list returns a copy of the recorded violations, ordered by type.
+func (lv *limitViolations) list() []*model.PluginLimitViolation {
+	lv.mutex.Lock()
+	defer lv.mutex.Unlock()
+
+	var list []*model.PluginLimitViolation
+	for _, violationType := range []string{
+		model.PluginLimitViolationMemory,
+		model.PluginLimitViolationHookTimeout,
+		model.PluginLimitViolationHookConcurrency,
+	} {
+		if violation, ok := lv.violations[violationType]; ok {
+			copied := *violation
+			list = append(list, &copied)
+		}
+	}
+
+	return list
+}
+
+// hookLimiter enforces the hook timeout and concurrency limits for calls made to a plugin.
+type hookLimiter struct {
+	timeout      time.Duration
+	queueTimeout time.Duration
+	slots        chan struct{}
+	onViolation  func(violationType, detail string)
+}
+
+// newHookLimiter returns nil if neither the hook timeout nor the concurrency limit is set.
+func newHookLimiter(limits ResourceLimits, onViolation func(violationType, detail string)) *hookLimiter {
+	if limits.HookTimeout <= 0 && limits.MaxConcurrentHooks <= 0 {
+		return nil
+	}
+
+	limiter := &hookLimiter{
+		timeout:      limits.HookTimeout,
+		queueTimeout: hookQueueTimeout,
+		onViolation:  onViolation,
+	}
+	if limits.HookTimeout > 0 {
+		limiter.queueTimeout = limits.HookTimeout
+	}
+	if limits.MaxConcurrentHooks > 0 {
+		limiter.slots = make(chan struct{}, limits.MaxConcurrentHooks)
+	}
+
+	return limiter
+}
+
+func (l *hookLimiter) call(client *rpc.Client, serviceMethod string, args interface{}, reply interface{}) error {
+	if l.slots != nil {
+		queueTimer := time.NewTimer(l.queueTimeout)
+		select {
+		case l.slots <- struct{}{}:
+			queueTimer.Stop()
+		case <-queueTimer.C:
+			l.onViolation(model.PluginLimitViolationHookConcurrency, serviceMethod)
+			return &hookLimitError{fmt.Sprintf("too many concurrent calls to %s, none finished within %v", serviceMethod, l.queueTimeout)}
+		}
+	}
+
+	release := func() {
+		if l.slots != nil {
+			<-l.slots
+		}
+	}
+
+	if l.timeout <= 0 {
+		defer release()
+		return client.Call(serviceMethod, args, reply)
+	}
+
+	// Decode into a deep copy of the reply so that a reply arriving after the timeout can't race
+	// with the caller, while values the caller preset in the reply are still decoded into.
+	pending := reflect.New(reflect.TypeOf(reply).Elem())
+	if err := copyReply(reply, pending.Interface()); err != nil {
+		release()
+		return err
+	}
+	call := client.Go(serviceMethod, args, pending.Interface(), make(chan *rpc.Call, 1))
+
+	timer := time.NewTimer(l.timeout)
+	defer timer.Stop()
+
+	select {
+	case <-call.Done:
+		release()
+		if call.Error != nil {
+			return call.Error
+		}
+		reflect.ValueOf(reply).Elem().Set(pending.Elem())
+		return nil
+	case <-timer.C:
+		// The abandoned call gives up its slot so that a plugin that never responds can't block
+		// every later hook invocation.
+		release()
+		l.onViolation(model.PluginLimitViolationHookTimeout, serviceMethod)
+		return &hookLimitError{fmt.Sprintf("%s did not complete within %v", serviceMethod, l.timeout)}
+	}
+}
+
+func copyReply(src interface{}, dst interface{}) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(src); err != nil {
+		return err
+	}
+
+	return gob.NewDecoder(&buf).Decode(dst)
+}
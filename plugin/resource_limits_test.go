@@ -0,0 +1,212 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"net"
+	"net/rpc"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+type limiterTestService struct {
+	release chan struct{}
+}
+
+func (s *limiterTestService) Echo(args string, reply *string) error {
+	*reply = args
+	return nil
+}
+
+func (s *limiterTestService) Block(args string, reply *string) error {
+	<-s.release
+	*reply = args
+	return nil
+}
+
+func newLimiterTestClient(t *testing.T) (*rpc.Client, *limiterTestService) {
+	service := &limiterTestService{release: make(chan struct{})}
+
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("Test", service))
+
+	serverConn, clientConn := net.Pipe()
+	go server.ServeConn(serverConn)
+
+	return rpc.NewClient(clientConn), service
+}
+
+func TestNewHookLimiter(t *testing.T) {
+	assert.Nil(t, newHookLimiter(ResourceLimits{}, nil))
+	assert.Nil(t, newHookLimiter(ResourceLimits{MaxMemoryBytes: 1024, MaxCPUPercent: 50}, nil))
+	assert.NotNil(t, newHookLimiter(ResourceLimits{HookTimeout: time.Second}, nil))
+	assert.NotNil(t, newHookLimiter(ResourceLimits{MaxConcurrentHooks: 1}, nil))
+}
+
+func TestHookLimiterTimeout(t *testing.T) {
+	client, service := newLimiterTestClient(t)
+	defer client.Close()
+
+	var violations limitViolations
+	limiter := newHookLimiter(ResourceLimits{HookTimeout: 50 * time.Millisecond}, violations.record)
+
+	var reply string
+	require.NoError(t, limiter.call(client, "Test.Echo", "hello", &reply))
+	assert.Equal(t, "hello", reply)
+
+	reply = ""
+	require.Error(t, limiter.call(client, "Test.Block", "late", &reply))
+	assert.Equal(t, "", reply)
+
+	// The late reply must not be written once the call has been abandoned
+	close(service.release)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, "", reply)
+
+	list := violations.list()
+	require.Len(t, list, 1)
+	assert.Equal(t, model.PluginLimitViolationHookTimeout, list[0].Type)
+	assert.Equal(t, int64(1), list[0].Count)
+	assert.Equal(t, "Test.Block", list[0].Detail)
+}
+
+func TestHookLimiterTimeoutReleasesSlot(t *testing.T) {
+	client, service := newLimiterTestClient(t)
+	defer client.Close()
+	defer close(service.release)
+
+	var violations limitViolations
+	limiter := newHookLimiter(ResourceLimits{HookTimeout: 50 * time.Millisecond, MaxConcurrentHooks: 1}, violations.record)
+
+	var reply string
+	err := limiter.call(client, "Test.Block", "stuck", &reply)
+	require.Error(t, err)
+	assert.True(t, isHookLimitError(err))
+
+	// The plugin still hasn't responded, but the abandoned call no longer holds the only slot
+	assert.Len(t, limiter.slots, 0)
+	require.NoError(t, limiter.call(client, "Test.Echo", "next", &reply))
+	assert.Equal(t, "next", reply)
+}
+
+func TestHookLimiterConcurrency(t *testing.T) {
+	client, service := newLimiterTestClient(t)
+	defer client.Close()
+
+	var violations limitViolations
+	limiter := newHookLimiter(ResourceLimits{MaxConcurrentHooks: 1}, violations.record)
+	limiter.queueTimeout = 200 * time.Millisecond
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		var reply string
+		assert.NoError(t, limiter.call(client, "Test.Block", "first", &reply))
+	}()
+
+	// Wait for the first call to occupy the only slot
+	for i := 0; i < 100 && len(limiter.slots) == 0; i++ {
+		time.Sleep(5 * time.Millisecond)
+	}
+	require.Len(t, limiter.slots, 1)
+
+	// A call that can't get a slot within the queue timeout is refused
+	var reply string
+	err := limiter.call(client, "Test.Echo", "second", &reply)
+	require.Error(t, err)
+	assert.True(t, isHookLimitError(err))
+
+	// A call that gets a slot while waiting goes through
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(service.release)
+	}()
+	require.NoError(t, limiter.call(client, "Test.Echo", "third", &reply))
+	assert.Equal(t, "third", reply)
+	wg.Wait()
+
+	list := violations.list()
+	require.Len(t, list, 1)
+	assert.Equal(t, model.PluginLimitViolationHookConcurrency, list[0].Type)
+}
+
+type blockingHooksService struct {
+	release chan struct{}
+}
+
+func (s *blockingHooksService) MessageWillBePosted(args *Z_MessageWillBePostedArgs, returns *Z_MessageWillBePostedReturns) error {
+	<-s.release
+	returns.A = args.B
+	return nil
+}
+
+func TestMessageWillBePostedRejectedByLimits(t *testing.T) {
+	service := &blockingHooksService{release: make(chan struct{})}
+	defer close(service.release)
+
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("Plugin", service))
+
+	serverConn, clientConn := net.Pipe()
+	go server.ServeConn(serverConn)
+	client := rpc.NewClient(clientConn)
+	defer client.Close()
+
+	var violations limitViolations
+	hooks := &hooksRPCClient{
+		client:  client,
+		log:     mlog.NewTestingLogger(t),
+		limiter: newHookLimiter(ResourceLimits{HookTimeout: 50 * time.Millisecond}, violations.record),
+	}
+	hooks.implemented[MessageWillBePostedId] = true
+
+	post, rejectionReason := hooks.MessageWillBePosted(&Context{}, &model.Post{Message: "hello"})
+	assert.Nil(t, post)
+	assert.Equal(t, hookLimitRejectionReason, rejectionReason)
+
+	list := violations.list()
+	require.Len(t, list, 1)
+	assert.Equal(t, model.PluginLimitViolationHookTimeout, list[0].Type)
+}
+
+func TestResourceLimitsFromConfig(t *testing.T) {
+	settings := model.PluginSettings{}
+	settings.SetDefaults(model.LogSettings{})
+	defaults, overrides := ResourceLimitsFromConfig(&settings)
+	assert.Equal(t, ResourceLimits{}, defaults)
+	assert.Empty(t, overrides)
+
+	*settings.MaxMemoryMB = 256
+	*settings.MaxCPUPercent = 50
+	*settings.HookTimeoutSeconds = 10
+	*settings.MaxConcurrentHooks = 4
+	settings.PluginLimits["com.example.plugin"] = &model.PluginLimitSettings{
+		MaxMemoryMB:        model.NewInt(1024),
+		MaxConcurrentHooks: model.NewInt(0),
+	}
+
+	defaults, overrides = ResourceLimitsFromConfig(&settings)
+	assert.Equal(t, ResourceLimits{
+		MaxMemoryBytes:     256 * 1024 * 1024,
+		MaxCPUPercent:      50,
+		HookTimeout:        10 * time.Second,
+		MaxConcurrentHooks: 4,
+	}, defaults)
+	assert.Equal(t, map[string]ResourceLimits{
+		"com.example.plugin": {
+			MaxMemoryBytes:     1024 * 1024 * 1024,
+			MaxCPUPercent:      50,
+			HookTimeout:        10 * time.Second,
+			MaxConcurrentHooks: 0,
+		},
+	}, overrides)
+}
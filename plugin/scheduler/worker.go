@@ -73,6 +73,11 @@ func (worker *Worker) DoJob(job *model.Job) {
 		return
 	}
 
+	if job.Type == model.JOB_TYPE_PLUGIN_SCHEDULED {
+		worker.doScheduledJob(job)
+		return
+	}
+
 	err := worker.app.DeleteAllExpiredPluginKeys()
 	if err == nil {
 		mlog.Info("Worker: Job is complete", mlog.String("worker", worker.name), mlog.String("job_id", job.Id))
@@ -85,6 +90,17 @@ func (worker *Worker) DoJob(job *model.Job) {
 	}
 }
 
+func (worker *Worker) doScheduledJob(job *model.Job) {
+	if err := worker.app.RunPluginScheduledJob(job); err != nil {
+		mlog.Error("Worker: Plugin scheduled job failed", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+		worker.setJobError(job, err)
+		return
+	}
+
+	mlog.Info("Worker: Job is complete", mlog.String("worker", worker.name), mlog.String("job_id", job.Id))
+	worker.setJobSuccess(job)
+}
+
 func (worker *Worker) setJobSuccess(job *model.Job) {
 	if err := worker.app.Srv.Jobs.SetJobSuccess(job); err != nil {
 		mlog.Error("Worker: Failed to set success for job", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
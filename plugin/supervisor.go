@@ -21,10 +21,19 @@ type supervisor struct {
 	hooks       Hooks
 	implemented [TotalHooksId]bool
 	pid         int
+	cgroup      *pluginCgroup
+	oomKills    int64
+	onViolation func(violationType, detail string)
 }
 
-func newSupervisor(pluginInfo *model.BundleInfo, parentLogger *mlog.Logger, apiImpl API) (retSupervisor *supervisor, retErr error) {
-	sup := supervisor{}
+func newSupervisor(pluginInfo *model.BundleInfo, parentLogger *mlog.Logger, apiImpl API) (*supervisor, error) {
+	return newSupervisorWithLimits(pluginInfo, parentLogger, apiImpl, ResourceLimits{}, func(string, string) {})
+}
+
+// newSupervisorWithLimits starts the plugin process, confining it to the given resource limits.
+// onViolation is called whenever the plugin exceeds one of its limits.
+func newSupervisorWithLimits(pluginInfo *model.BundleInfo, parentLogger *mlog.Logger, apiImpl API, limits ResourceLimits, onViolation func(violationType, detail string)) (retSupervisor *supervisor, retErr error) {
+	sup := supervisor{onViolation: onViolation}
 	defer func() {
 		if retErr != nil {
 			sup.Shutdown()
@@ -42,6 +51,7 @@ func newSupervisor(pluginInfo *model.BundleInfo, parentLogger *mlog.Logger, apiI
 		"hooks": &hooksPlugin{
 			log:     wrappedLogger,
 			apiImpl: apiImpl,
+			limiter: newHookLimiter(limits, onViolation),
 		},
 	}
 
@@ -73,6 +83,17 @@ func newSupervisor(pluginInfo *model.BundleInfo, parentLogger *mlog.Logger, apiI
 
 	sup.pid = cmd.Process.Pid
 
+	if cg, err := newPluginCgroup(pluginInfo.Manifest.Id, limits); err != nil {
+		wrappedLogger.Warn("Unable to apply memory and CPU limits to plugin", mlog.Err(err))
+	} else if cg != nil {
+		if err := cg.AddProcess(sup.pid); err != nil {
+			wrappedLogger.Warn("Unable to apply memory and CPU limits to plugin", mlog.Err(err))
+			cg.Remove()
+		} else {
+			sup.cgroup = cg
+		}
+	}
+
 	raw, err := rpcClient.Dispense("hooks")
 	if err != nil {
 		return nil, err
@@ -102,6 +123,31 @@ func (sup *supervisor) Shutdown() {
 	if sup.client != nil {
 		sup.client.Kill()
 	}
+
+	if sup.cgroup != nil {
+		if err := sup.cgroup.Remove(); err != nil {
+			mlog.Debug("Unable to remove plugin cgroup", mlog.Err(err))
+		}
+	}
+}
+
+// CheckMemoryLimit reports a memory limit violation if the plugin process has been killed for
+// exceeding its memory limit since the last check.
+func (sup *supervisor) CheckMemoryLimit() {
+	if sup.cgroup == nil {
+		return
+	}
+
+	oomKills, err := sup.cgroup.OOMKills()
+	if err != nil {
+		mlog.Debug("Unable to read plugin memory events", mlog.Err(err))
+		return
+	}
+
+	if oomKills > sup.oomKills {
+		sup.onViolation(model.PluginLimitViolationMemory, fmt.Sprintf("plugin process killed %d time(s) for exceeding its memory limit", oomKills-sup.oomKills))
+		sup.oomKills = oomKills
+	}
 }
 
 func (sup *supervisor) Hooks() Hooks {
@@ -0,0 +1,19 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package postarchival
+
+import (
+	"github.com/mattermost/mattermost-server/app"
+	tjobs "github.com/mattermost/mattermost-server/jobs/interfaces"
+)
+
+type PostArchivalJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsPostArchivalJobInterface(func(a *app.App) tjobs.PostArchivalJobInterface {
+		return &PostArchivalJobInterfaceImpl{a}
+	})
+}
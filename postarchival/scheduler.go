@@ -0,0 +1,51 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package postarchival
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-server/app"
+	"github.com/mattermost/mattermost-server/jobs"
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+type Scheduler struct {
+	App *app.App
+}
+
+func (m *PostArchivalJobInterfaceImpl) MakeScheduler() model.Scheduler {
+	return &Scheduler{m.App}
+}
+
+func (scheduler *Scheduler) Name() string {
+	return "PostArchivalScheduler"
+}
+
+func (scheduler *Scheduler) JobType() string {
+	return model.JOB_TYPE_POST_ARCHIVAL
+}
+
+// Enabled also schedules the job when only the message retention is enabled, so that it still
+// applies to the posts archived before the archival was disabled.
+func (scheduler *Scheduler) Enabled(cfg *model.Config) bool {
+	return *cfg.DataRetentionSettings.EnableMessageArchival || *cfg.DataRetentionSettings.EnableMessageDeletion
+}
+
+func (scheduler *Scheduler) NextScheduleTime(cfg *model.Config, now time.Time, pendingJobs bool, lastSuccessfulJob *model.Job) *time.Time {
+	parsedTime, err := time.Parse("15:04", *cfg.DataRetentionSettings.ArchivalJobStartTime)
+	if err != nil {
+		mlog.Error("Cannot determine the next schedule time for the post archival job. ArchivalJobStartTime config value is invalid.", mlog.Err(err))
+		return nil
+	}
+
+	return jobs.GenerateNextStartDateTime(now, parsedTime)
+}
+
+func (scheduler *Scheduler) ScheduleJob(cfg *model.Config, pendingJobs bool, lastSuccessfulJob *model.Job) (*model.Job, *model.AppError) {
+	mlog.Debug("Scheduling Job", mlog.String("scheduler", scheduler.Name()))
+
+	return scheduler.App.Srv.Jobs.CreateJob(model.JOB_TYPE_POST_ARCHIVAL, nil)
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package postarchival
+
+import (
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/app"
+	"github.com/mattermost/mattermost-server/jobs"
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+type Worker struct {
+	name      string
+	stop      chan bool
+	stopped   chan bool
+	jobs      chan model.Job
+	jobServer *jobs.JobServer
+	app       *app.App
+}
+
+func (m *PostArchivalJobInterfaceImpl) MakeWorker() model.Worker {
+	worker := Worker{
+		name:      "PostArchival",
+		stop:      make(chan bool, 1),
+		stopped:   make(chan bool, 1),
+		jobs:      make(chan model.Job),
+		jobServer: m.App.Srv.Jobs,
+		app:       m.App,
+	}
+
+	return &worker
+}
+
+func (worker *Worker) Run() {
+	mlog.Debug("Worker started", mlog.String("worker", worker.name))
+
+	defer func() {
+		mlog.Debug("Worker finished", mlog.String("worker", worker.name))
+		worker.stopped <- true
+	}()
+
+	for {
+		select {
+		case <-worker.stop:
+			mlog.Debug("Worker received stop signal", mlog.String("worker", worker.name))
+			return
+		case job := <-worker.jobs:
+			mlog.Debug("Worker received a new candidate job.", mlog.String("worker", worker.name))
+			worker.DoJob(&job)
+		}
+	}
+}
+
+func (worker *Worker) Stop() {
+	mlog.Debug("Worker stopping", mlog.String("worker", worker.name))
+	worker.stop <- true
+	<-worker.stopped
+}
+
+func (worker *Worker) JobChannel() chan<- model.Job {
+	return worker.jobs
+}
+
+func (worker *Worker) DoJob(job *model.Job) {
+	if claimed, err := worker.jobServer.ClaimJob(job); err != nil {
+		mlog.Info("Worker experienced an error while trying to claim job",
+			mlog.String("worker", worker.name),
+			mlog.String("job_id", job.Id),
+			mlog.String("error", err.Error()))
+		return
+	} else if !claimed {
+		return
+	}
+
+	if job.Data == nil {
+		job.Data = make(map[string]string)
+	}
+
+	var archived int64
+	if *worker.app.Config().DataRetentionSettings.EnableMessageArchival {
+		var err *model.AppError
+		archived, err = worker.app.ArchiveOldPosts()
+		job.Data["archived_posts"] = strconv.FormatInt(archived, 10)
+
+		if err != nil {
+			mlog.Error("Worker: Failed to archive old posts", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.Int64("archived_posts", archived), mlog.String("error", err.Error()))
+			worker.setJobError(job, err)
+			return
+		}
+	}
+
+	deleted, err := worker.app.DeleteExpiredArchivedPosts()
+	job.Data["deleted_archived_posts"] = strconv.FormatInt(deleted, 10)
+
+	if err != nil {
+		mlog.Error("Worker: Failed to delete expired archived posts", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.Int64("deleted_archived_posts", deleted), mlog.String("error", err.Error()))
+		worker.setJobError(job, err)
+		return
+	}
+
+	if err := worker.jobServer.UpdateInProgressJobData(job); err != nil {
+		mlog.Warn("Worker: Failed to record the number of archived posts", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+	}
+
+	mlog.Info("Worker: Job is complete", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.Int64("archived_posts", archived), mlog.Int64("deleted_archived_posts", deleted))
+	worker.setJobSuccess(job)
+}
+
+func (worker *Worker) setJobSuccess(job *model.Job) {
+	if err := worker.app.Srv.Jobs.SetJobSuccess(job); err != nil {
+		mlog.Error("Worker: Failed to set success for job", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+		worker.setJobError(job, err)
+	}
+}
+
+func (worker *Worker) setJobError(job *model.Job, appError *model.AppError) {
+	if err := worker.app.Srv.Jobs.SetJobError(job, appError); err != nil {
+		mlog.Error("Worker: Failed to set job error", mlog.String("worker", worker.name), mlog.String("job_id", job.Id), mlog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package postsharding
+
+import (
+	"github.com/mattermost/mattermost-server/app"
+	tjobs "github.com/mattermost/mattermost-server/jobs/interfaces"
+)
+
+type PostShardRebalanceJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsPostShardRebalanceJobInterface(func(a *app.App) tjobs.PostShardRebalanceJobInterface {
+		return &PostShardRebalanceJobInterfaceImpl{a}
+	})
+}
@@ -0,0 +1,256 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	SERVICE_NAME = "mattermost-server"
+
+	EXPORT_REQUEST_TIMEOUT = 10 * time.Second
+
+	otlpStatusCodeError = 2
+)
+
+// Exporter sends finished spans to where the traces are collected.
+type Exporter interface {
+	Export(spans []*Span) error
+	Close() error
+}
+
+// The types below follow the JSON encoding of the OTLP ExportTraceServiceRequest, in which ids
+// are hexadecimal and 64 bit integers are strings.
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type otlpSpan struct {
+	TraceId           string          `json:"traceId"`
+	SpanId            string          `json:"spanId"`
+	ParentSpanId      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              SpanKind        `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            *otlpStatus     `json:"status,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+}
+
+func newOtlpValue(value interface{}) otlpValue {
+	switch v := value.(type) {
+	case string:
+		return otlpValue{StringValue: model.NewString(v)}
+	case int:
+		return otlpValue{IntValue: model.NewString(strconv.Itoa(v))}
+	case int64:
+		return otlpValue{IntValue: model.NewString(strconv.FormatInt(v, 10))}
+	case float64:
+		return otlpValue{DoubleValue: model.NewFloat64(v)}
+	case bool:
+		return otlpValue{BoolValue: model.NewBool(v)}
+	default:
+		return otlpValue{StringValue: model.NewString(fmt.Sprint(v))}
+	}
+}
+
+func newOtlpSpan(span *Span) otlpSpan {
+	span.mutex.Lock()
+	defer span.mutex.Unlock()
+
+	result := otlpSpan{
+		TraceId:           span.traceId,
+		SpanId:            span.spanId,
+		ParentSpanId:      span.parentSpanId,
+		Name:              span.name,
+		Kind:              span.kind,
+		StartTimeUnixNano: strconv.FormatInt(span.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.end.UnixNano(), 10),
+	}
+
+	for _, attribute := range span.attributes {
+		result.Attributes = append(result.Attributes, otlpAttribute{Key: attribute.key, Value: newOtlpValue(attribute.value)})
+	}
+
+	if span.err != "" {
+		result.Status = &otlpStatus{Code: otlpStatusCodeError, Message: span.err}
+	}
+
+	return result
+}
+
+// encodeSpans encodes the spans as an OTLP export request.
+func encodeSpans(spans []*Span) ([]byte, error) {
+	scopeSpans := otlpScopeSpans{
+		Scope: otlpScope{Name: SERVICE_NAME, Version: model.CurrentVersion},
+		Spans: make([]otlpSpan, 0, len(spans)),
+	}
+	for _, span := range spans {
+		scopeSpans.Spans = append(scopeSpans.Spans, newOtlpSpan(span))
+	}
+
+	request := otlpRequest{
+		ResourceSpans: []otlpResourceSpans{
+			{
+				Resource: otlpResource{
+					Attributes: []otlpAttribute{
+						{Key: "service.name", Value: newOtlpValue(SERVICE_NAME)},
+						{Key: "service.version", Value: newOtlpValue(model.CurrentVersion)},
+					},
+				},
+				ScopeSpans: []otlpScopeSpans{scopeSpans},
+			},
+		},
+	}
+
+	return json.Marshal(request)
+}
+
+// HTTPExporter posts the spans to an OTLP/HTTP collector, such as
+// http://localhost:4318/v1/traces.
+type HTTPExporter struct {
+	endpoint string
+	client   *http.Client
+}
+
+func NewHTTPExporter(endpoint string) *HTTPExporter {
+	return &HTTPExporter{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: EXPORT_REQUEST_TIMEOUT},
+	}
+}
+
+func (e *HTTPExporter) Export(spans []*Span) error {
+	body, err := encodeSpans(spans)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode the spans")
+	}
+
+	resp, err := e.client.Post(e.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to post the spans")
+	}
+	defer resp.Body.Close()
+	ioutil.ReadAll(resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("the collector responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (e *HTTPExporter) Close() error {
+	return nil
+}
+
+// FileExporter appends the spans to a local file, one OTLP export request per line, as read by
+// the file receiver of the OpenTelemetry collector.
+type FileExporter struct {
+	mutex sync.Mutex
+	file  *os.File
+}
+
+func NewFileExporter(path string) (*FileExporter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %s", path)
+	}
+
+	return &FileExporter{file: file}, nil
+}
+
+func (e *FileExporter) Export(spans []*Span) error {
+	line, err := encodeSpans(spans)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode the spans")
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if _, err := e.file.Write(append(line, '\n')); err != nil {
+		return errors.Wrap(err, "failed to write the spans")
+	}
+
+	return nil
+}
+
+func (e *FileExporter) Close() error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return e.file.Close()
+}
+
+// NewTracerFromSettings returns the tracer configured by the settings, or nil if tracing is
+// disabled. The spans are posted to the endpoint if set, and written to the file otherwise.
+func NewTracerFromSettings(settings model.TracingSettings) (*Tracer, error) {
+	if settings.Enable == nil || !*settings.Enable {
+		return nil, nil
+	}
+
+	var exporter Exporter
+	if *settings.ExportEndpoint != "" {
+		exporter = NewHTTPExporter(*settings.ExportEndpoint)
+	} else {
+		fileExporter, err := NewFileExporter(*settings.ExportFile)
+		if err != nil {
+			return nil, err
+		}
+		exporter = fileExporter
+	}
+
+	return NewTracer(exporter, *settings.SampleRate), nil
+}
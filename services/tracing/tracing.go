@@ -0,0 +1,238 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+// Package tracing records the work done for a request as a trace of nested spans, exported in
+// the JSON encoding of the OpenTelemetry protocol (OTLP).
+//
+// A nil *Tracer and a nil *Span are valid and record nothing, so that callers need not check
+// whether tracing is enabled or the request was sampled.
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	mathrand "math/rand"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/mlog"
+)
+
+const (
+	// EXPORT_INTERVAL is how often the finished spans are exported.
+	EXPORT_INTERVAL = 5 * time.Second
+
+	// EXPORT_BATCH_SIZE is the number of finished spans exported early, before the interval.
+	EXPORT_BATCH_SIZE = 512
+
+	// QUEUE_SIZE is the number of finished spans kept waiting for export, beyond which spans are
+	// dropped rather than slowing down the requests.
+	QUEUE_SIZE = 4096
+)
+
+// SpanKind is the role of a span in the trace, as defined by OpenTelemetry.
+type SpanKind int
+
+const (
+	SPAN_KIND_INTERNAL SpanKind = 1
+	SPAN_KIND_SERVER   SpanKind = 2
+	SPAN_KIND_CLIENT   SpanKind = 3
+)
+
+type attribute struct {
+	key   string
+	value interface{}
+}
+
+// Span is a timed operation of a trace, such as the handling of a request or a store call.
+type Span struct {
+	tracer       *Tracer
+	traceId      string
+	spanId       string
+	parentSpanId string
+	name         string
+	kind         SpanKind
+	start        time.Time
+
+	mutex      sync.Mutex
+	end        time.Time
+	attributes []attribute
+	err        string
+}
+
+// StartChild starts a span nested in this one.
+func (s *Span) StartChild(name string, kind SpanKind) *Span {
+	if s == nil {
+		return nil
+	}
+
+	return &Span{
+		tracer:       s.tracer,
+		traceId:      s.traceId,
+		spanId:       newId(8),
+		parentSpanId: s.spanId,
+		name:         name,
+		kind:         kind,
+		start:        time.Now(),
+	}
+}
+
+// SetAttribute records a string, integer, float or boolean describing the span.
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.attributes = append(s.attributes, attribute{key: key, value: value})
+}
+
+// SetError marks the operation of the span as failed.
+func (s *Span) SetError(message string) {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.err = message
+}
+
+// TraceId returns the id of the trace of the span, as 32 hexadecimal characters.
+func (s *Span) TraceId() string {
+	if s == nil {
+		return ""
+	}
+
+	return s.traceId
+}
+
+// Finish ends the span and queues it for export. A span must be finished only once.
+func (s *Span) Finish() {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	s.end = time.Now()
+	s.mutex.Unlock()
+
+	s.tracer.queue(s)
+}
+
+// Tracer starts traces and exports their spans in batches.
+type Tracer struct {
+	exporter   Exporter
+	sampleRate float64
+
+	spans   chan *Span
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// NewTracer starts exporting the finished spans, keeping the given fraction of the traces.
+func NewTracer(exporter Exporter, sampleRate float64) *Tracer {
+	tracer := &Tracer{
+		exporter:   exporter,
+		sampleRate: sampleRate,
+		spans:      make(chan *Span, QUEUE_SIZE),
+		stop:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+
+	go tracer.run(EXPORT_INTERVAL)
+
+	return tracer
+}
+
+// StartTrace starts the root span of a new trace, or returns nil if the trace is not sampled.
+func (t *Tracer) StartTrace(name string, kind SpanKind) *Span {
+	if t == nil || t.sampleRate <= 0 || (t.sampleRate < 1 && mathrand.Float64() >= t.sampleRate) {
+		return nil
+	}
+
+	return &Span{
+		tracer:  t,
+		traceId: newId(16),
+		spanId:  newId(8),
+		name:    name,
+		kind:    kind,
+		start:   time.Now(),
+	}
+}
+
+// Close exports the spans still queued and stops the tracer.
+func (t *Tracer) Close() {
+	if t == nil {
+		return
+	}
+
+	close(t.stop)
+	<-t.stopped
+
+	if err := t.exporter.Close(); err != nil {
+		mlog.Warn("Failed to close the trace exporter", mlog.Err(err))
+	}
+}
+
+func (t *Tracer) queue(span *Span) {
+	select {
+	case t.spans <- span:
+	default:
+		// Dropping the span is better than holding up the request.
+	}
+}
+
+func (t *Tracer) run(interval time.Duration) {
+	defer close(t.stopped)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	batch := make([]*Span, 0, EXPORT_BATCH_SIZE)
+	export := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		if err := t.exporter.Export(batch); err != nil {
+			mlog.Warn("Failed to export the trace spans", mlog.Int("spans", len(batch)), mlog.Err(err))
+		}
+		batch = make([]*Span, 0, EXPORT_BATCH_SIZE)
+	}
+
+	for {
+		select {
+		case span := <-t.spans:
+			batch = append(batch, span)
+			if len(batch) >= EXPORT_BATCH_SIZE {
+				export()
+			}
+		case <-ticker.C:
+			export()
+		case <-t.stop:
+			for {
+				select {
+				case span := <-t.spans:
+					batch = append(batch, span)
+				default:
+					export()
+					return
+				}
+			}
+		}
+	}
+}
+
+func newId(size int) string {
+	id := make([]byte, size)
+	if _, err := rand.Read(id); err != nil {
+		// Fall back to a weaker but still unique enough id.
+		mathrand.Read(id)
+	}
+
+	return hex.EncodeToString(id)
+}
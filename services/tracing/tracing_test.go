@@ -0,0 +1,185 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package tracing
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+type testExporter struct {
+	spans []*Span
+}
+
+func (e *testExporter) Export(spans []*Span) error {
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *testExporter) Close() error {
+	return nil
+}
+
+func TestNilTracer(t *testing.T) {
+	var tracer *Tracer
+
+	span := tracer.StartTrace("request", SPAN_KIND_SERVER)
+	require.Nil(t, span)
+
+	child := span.StartChild("store", SPAN_KIND_INTERNAL)
+	require.Nil(t, child)
+	child.SetAttribute("key", "value")
+	child.SetError("failed")
+	child.Finish()
+	assert.Equal(t, "", child.TraceId())
+
+	tracer.Close()
+}
+
+func TestTracer(t *testing.T) {
+	t.Run("spans are exported when closing", func(t *testing.T) {
+		exporter := &testExporter{}
+		tracer := NewTracer(exporter, 1)
+
+		root := tracer.StartTrace("GET /api/v4/users/me", SPAN_KIND_SERVER)
+		require.NotNil(t, root)
+		child := root.StartChild("UserStore.Get", SPAN_KIND_INTERNAL)
+		child.Finish()
+		root.Finish()
+
+		tracer.Close()
+
+		require.Len(t, exporter.spans, 2)
+		assert.Equal(t, child, exporter.spans[0])
+		assert.Equal(t, root, exporter.spans[1])
+		assert.Len(t, root.TraceId(), 32)
+		assert.Equal(t, root.traceId, child.traceId)
+		assert.Equal(t, root.spanId, child.parentSpanId)
+		assert.NotEqual(t, root.spanId, child.spanId)
+	})
+
+	t.Run("no traces are sampled at a rate of 0", func(t *testing.T) {
+		tracer := NewTracer(&testExporter{}, 0)
+		defer tracer.Close()
+
+		assert.Nil(t, tracer.StartTrace("request", SPAN_KIND_SERVER))
+	})
+}
+
+func TestEncodeSpans(t *testing.T) {
+	tracer := &Tracer{sampleRate: 1}
+	root := tracer.StartTrace("GET /api/v4/users/me", SPAN_KIND_SERVER)
+	root.SetAttribute("http.status_code", 500)
+	root.SetAttribute("http.method", "GET")
+	root.SetError("failed")
+	child := root.StartChild("UserStore.Get", SPAN_KIND_INTERNAL)
+	child.SetAttribute("success", true)
+
+	data, err := encodeSpans([]*Span{child, root})
+	require.Nil(t, err)
+
+	var request map[string]interface{}
+	require.Nil(t, json.Unmarshal(data, &request))
+
+	resourceSpans := request["resourceSpans"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"key": "service.name", "value": map[string]interface{}{"stringValue": SERVICE_NAME}}, resourceSpans["resource"].(map[string]interface{})["attributes"].([]interface{})[0])
+
+	spans := resourceSpans["scopeSpans"].([]interface{})[0].(map[string]interface{})["spans"].([]interface{})
+	require.Len(t, spans, 2)
+
+	encodedChild := spans[0].(map[string]interface{})
+	assert.Equal(t, child.traceId, encodedChild["traceId"])
+	assert.Equal(t, root.spanId, encodedChild["parentSpanId"])
+	assert.Equal(t, "UserStore.Get", encodedChild["name"])
+	assert.Equal(t, float64(SPAN_KIND_INTERNAL), encodedChild["kind"])
+	assert.Nil(t, encodedChild["status"])
+
+	encodedRoot := spans[1].(map[string]interface{})
+	assert.Nil(t, encodedRoot["parentSpanId"])
+	assert.Equal(t, float64(SPAN_KIND_SERVER), encodedRoot["kind"])
+	assert.IsType(t, "", encodedRoot["startTimeUnixNano"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"key": "http.status_code", "value": map[string]interface{}{"intValue": "500"}},
+		map[string]interface{}{"key": "http.method", "value": map[string]interface{}{"stringValue": "GET"}},
+	}, encodedRoot["attributes"])
+	assert.Equal(t, map[string]interface{}{"code": float64(2), "message": "failed"}, encodedRoot["status"])
+}
+
+func TestExporters(t *testing.T) {
+	tracer := &Tracer{sampleRate: 1}
+	spans := []*Span{tracer.StartTrace("request", SPAN_KIND_SERVER)}
+
+	t.Run("http", func(t *testing.T) {
+		var body []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/traces", r.URL.Path)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			body, _ = ioutil.ReadAll(r.Body)
+		}))
+		defer server.Close()
+
+		exporter := NewHTTPExporter(server.URL + "/v1/traces")
+		require.Nil(t, exporter.Export(spans))
+		assert.Contains(t, string(body), `"name":"request"`)
+
+		failingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer failingServer.Close()
+
+		failing := NewHTTPExporter(failingServer.URL + "/v1/traces")
+		require.EqualError(t, failing.Export(spans), "the collector responded with status 400")
+	})
+
+	t.Run("file", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "tracing")
+		require.Nil(t, err)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "traces.json")
+		exporter, err := NewFileExporter(path)
+		require.Nil(t, err)
+		require.Nil(t, exporter.Export(spans))
+		require.Nil(t, exporter.Export(spans))
+		require.Nil(t, exporter.Close())
+
+		data, err := ioutil.ReadFile(path)
+		require.Nil(t, err)
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		require.Len(t, lines, 2)
+		assert.Contains(t, lines[1], `"name":"request"`)
+	})
+}
+
+func TestNewTracerFromSettings(t *testing.T) {
+	settings := model.TracingSettings{}
+	settings.SetDefaults()
+
+	tracer, err := NewTracerFromSettings(settings)
+	require.Nil(t, err)
+	assert.Nil(t, tracer)
+
+	*settings.Enable = true
+	*settings.ExportFile = filepath.Join("missing", "directory", "traces.json")
+	_, err = NewTracerFromSettings(settings)
+	require.NotNil(t, err)
+
+	*settings.ExportEndpoint = "http://localhost:4318/v1/traces"
+	tracer, err = NewTracerFromSettings(settings)
+	require.Nil(t, err)
+	require.NotNil(t, tracer)
+	assert.IsType(t, &HTTPExporter{}, tracer.exporter)
+	tracer.Close()
+}
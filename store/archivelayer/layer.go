@@ -0,0 +1,96 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package archivelayer
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/mattermost/mattermost-server/utils"
+)
+
+const (
+	ARCHIVE_CACHE_SIZE = 100
+	ARCHIVE_CACHE_SEC  = 15 * 60
+)
+
+// ArchiveReader reads an archive file from the file store.
+type ArchiveReader func(path string) ([]byte, *model.AppError)
+
+// ArchiveStore falls back to the archive files for the posts moved out of the Posts table, so
+// that looking them up by id, or searching for them, keeps working after they are archived.
+type ArchiveStore struct {
+	store.Store
+	readArchive  ArchiveReader
+	archiveCache *utils.Cache
+
+	post       ArchivePostStore
+	masterOnly *ArchiveStore
+}
+
+func NewArchiveLayer(baseStore store.Store, readArchive ArchiveReader) ArchiveStore {
+	archiveStore := ArchiveStore{
+		readArchive: readArchive,
+		// Archive files are never changed once written, so they need not be invalidated.
+		archiveCache: utils.NewLruWithParams(ARCHIVE_CACHE_SIZE, "Post Archive", ARCHIVE_CACHE_SEC, ""),
+	}
+	archiveStore.initStores(baseStore)
+
+	masterOnly := archiveStore
+	masterOnly.initStores(baseStore.MasterOnly())
+	masterOnly.masterOnly = &masterOnly
+	archiveStore.masterOnly = &masterOnly
+
+	return archiveStore
+}
+
+func (s *ArchiveStore) initStores(baseStore store.Store) {
+	s.Store = baseStore
+	s.post = ArchivePostStore{PostStore: baseStore.Post(), rootStore: s}
+}
+
+func (s ArchiveStore) Post() store.PostStore {
+	return s.post
+}
+
+func (s ArchiveStore) MasterOnly() store.Store {
+	return *s.masterOnly
+}
+
+// WithQueryTimeout returns a view of this store whose base store runs its queries with the given
+// timeout.
+func (s ArchiveStore) WithQueryTimeout(timeout time.Duration) store.Store {
+	view := s
+	view.initStores(store.WithQueryTimeout(s.Store, timeout))
+
+	masterOnly := *s.masterOnly
+	masterOnly.initStores(store.WithQueryTimeout(s.masterOnly.Store, timeout))
+	masterOnly.masterOnly = &masterOnly
+	view.masterOnly = &masterOnly
+
+	return view
+}
+
+// readPosts returns the posts of the archive file at path.
+func (s *ArchiveStore) readPosts(path string) ([]*model.Post, *model.AppError) {
+	if posts, ok := s.archiveCache.Get(path); ok {
+		return posts.([]*model.Post), nil
+	}
+
+	data, appErr := s.readArchive(path)
+	if appErr != nil {
+		return nil, model.NewAppError("ArchiveStore.readPosts", "store.archive_layer.read.app_error", nil, "path="+path+", "+appErr.Error(), http.StatusInternalServerError)
+	}
+
+	posts, err := model.DecodePostArchive(data)
+	if err != nil {
+		return nil, model.NewAppError("ArchiveStore.readPosts", "store.archive_layer.read.app_error", nil, "path="+path+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	s.archiveCache.AddWithDefaultExpires(path, posts)
+
+	return posts, nil
+}
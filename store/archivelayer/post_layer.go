@@ -0,0 +1,229 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package archivelayer
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+// POST_SEARCH_LIMIT is the number of posts returned by a search, as by the search of the Posts
+// table.
+const POST_SEARCH_LIMIT = 100
+
+type ArchivePostStore struct {
+	store.PostStore
+	rootStore *ArchiveStore
+}
+
+// getArchivedPosts returns those of the given posts that were archived, by id, reading each of
+// their archive files once.
+func (s ArchivePostStore) getArchivedPosts(postIds []string) (map[string]*model.Post, *model.AppError) {
+	paths, err := s.PostStore.GetArchivePaths(postIds)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.readArchivedPosts(paths)
+}
+
+// readArchivedPosts returns the posts held by the given archive files, by post id, reading each of
+// the files once.
+func (s ArchivePostStore) readArchivedPosts(paths map[string]string) (map[string]*model.Post, *model.AppError) {
+	postIdsByPath := make(map[string][]string)
+	for postId, path := range paths {
+		postIdsByPath[path] = append(postIdsByPath[path], postId)
+	}
+
+	posts := make(map[string]*model.Post, len(paths))
+	for path, archivedIds := range postIdsByPath {
+		archivedPosts, err := s.rootStore.readPosts(path)
+		if err != nil {
+			return nil, err
+		}
+
+		wanted := make(map[string]bool, len(archivedIds))
+		for _, postId := range archivedIds {
+			wanted[postId] = true
+		}
+
+		for _, post := range archivedPosts {
+			if wanted[post.Id] {
+				posts[post.Id] = post.Clone()
+			}
+		}
+	}
+
+	return posts, nil
+}
+
+func (s ArchivePostStore) Get(id string) (*model.PostList, *model.AppError) {
+	list, err := s.PostStore.Get(id)
+	if err == nil || err.StatusCode != http.StatusNotFound {
+		return list, err
+	}
+
+	paths, archiveErr := s.PostStore.GetArchivePaths([]string{id})
+	if archiveErr != nil {
+		return nil, archiveErr
+	} else if _, ok := paths[id]; !ok {
+		return nil, err
+	}
+
+	// Whole threads are archived together, so the thread of the post is in the same file.
+	archivedPosts, archiveErr := s.rootStore.readPosts(paths[id])
+	if archiveErr != nil {
+		return nil, archiveErr
+	}
+
+	var post *model.Post
+	for _, archivedPost := range archivedPosts {
+		if archivedPost.Id == id && archivedPost.DeleteAt == 0 {
+			post = archivedPost
+		}
+	}
+	if post == nil {
+		return nil, err
+	}
+
+	list = model.NewPostList()
+	list.AddPost(post.Clone())
+	list.AddOrder(post.Id)
+
+	rootId := post.RootId
+	if rootId == "" {
+		rootId = post.Id
+	}
+
+	for _, archivedPost := range archivedPosts {
+		if (archivedPost.Id == rootId || archivedPost.RootId == rootId) && archivedPost.DeleteAt == 0 {
+			list.AddPost(archivedPost.Clone())
+			list.AddOrder(archivedPost.Id)
+		}
+	}
+
+	return list, nil
+}
+
+func (s ArchivePostStore) GetSingle(id string) (*model.Post, *model.AppError) {
+	post, err := s.PostStore.GetSingle(id)
+	if err == nil || err.StatusCode != http.StatusNotFound {
+		return post, err
+	}
+
+	archivedPosts, archiveErr := s.getArchivedPosts([]string{id})
+	if archiveErr != nil {
+		return nil, archiveErr
+	}
+
+	if archivedPost, ok := archivedPosts[id]; ok && archivedPost.DeleteAt == 0 {
+		return archivedPost, nil
+	}
+
+	return nil, err
+}
+
+func (s ArchivePostStore) GetPostsByIds(postIds []string) ([]*model.Post, *model.AppError) {
+	posts, err := s.PostStore.GetPostsByIds(postIds)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]bool, len(posts))
+	for _, post := range posts {
+		found[post.Id] = true
+	}
+
+	var missingIds []string
+	for _, postId := range postIds {
+		if !found[postId] {
+			missingIds = append(missingIds, postId)
+		}
+	}
+
+	if len(missingIds) == 0 {
+		return posts, nil
+	}
+
+	archivedPosts, err := s.getArchivedPosts(missingIds)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(archivedPosts) == 0 {
+		return posts, nil
+	}
+
+	for _, post := range archivedPosts {
+		posts = append(posts, post)
+	}
+
+	sort.Slice(posts, func(i, j int) bool {
+		return posts[i].CreateAt > posts[j].CreateAt
+	})
+
+	return posts, nil
+}
+
+// Search also searches the archived posts by their indexes, merging those matching the search with
+// the posts found, latest first.
+func (s ArchivePostStore) Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, *model.AppError) {
+	list, err := s.PostStore.Search(teamId, userId, params)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, err := s.PostStore.SearchArchive(teamId, userId, params)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make(map[string]string, len(indexes))
+	for _, index := range indexes {
+		if _, ok := list.Posts[index.PostId]; !ok {
+			paths[index.PostId] = index.Path
+		}
+	}
+
+	if len(paths) == 0 {
+		return list, nil
+	}
+
+	archivedPosts, err := s.readArchivedPosts(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	posts := make([]*model.Post, 0, len(list.Order)+len(archivedPosts))
+	for _, postId := range list.Order {
+		posts = append(posts, list.Posts[postId])
+	}
+	for _, post := range archivedPosts {
+		if post.DeleteAt == 0 {
+			posts = append(posts, post)
+		}
+	}
+
+	sort.Slice(posts, func(i, j int) bool {
+		if posts[i].CreateAt != posts[j].CreateAt {
+			return posts[i].CreateAt > posts[j].CreateAt
+		}
+		return posts[i].Id > posts[j].Id
+	})
+	if len(posts) > POST_SEARCH_LIMIT {
+		posts = posts[:POST_SEARCH_LIMIT]
+	}
+
+	merged := model.NewPostList()
+	for _, post := range posts {
+		merged.AddPost(post)
+		merged.AddOrder(post.Id)
+	}
+	merged.MakeNonNil()
+
+	return merged, nil
+}
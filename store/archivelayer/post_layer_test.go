@@ -0,0 +1,128 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package archivelayer
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store/storetest/mocks"
+)
+
+func TestArchivePostStore(t *testing.T) {
+	root := &model.Post{Id: model.NewId(), ChannelId: model.NewId(), Message: "root", CreateAt: 1000}
+	reply := &model.Post{Id: model.NewId(), ChannelId: root.ChannelId, RootId: root.Id, Message: "reply", CreateAt: 1500}
+	deletedReply := &model.Post{Id: model.NewId(), ChannelId: root.ChannelId, RootId: root.Id, Message: "deleted", CreateAt: 1600, DeleteAt: 1700}
+	otherRoot := &model.Post{Id: model.NewId(), ChannelId: root.ChannelId, Message: "other", CreateAt: 1100}
+	hotPost := &model.Post{Id: model.NewId(), ChannelId: root.ChannelId, Message: "hot", CreateAt: 5000}
+
+	path := model.NewPostArchivePath(root.ChannelId, 2000)
+	archive, err := model.EncodePostArchive([]*model.Post{root, reply, deletedReply, otherRoot})
+	require.Nil(t, err)
+
+	notFound := model.NewAppError("SqlPostStore.GetSingle", "store.sql_post.get.app_error", nil, "", http.StatusNotFound)
+
+	mockPostStore := mocks.PostStore{}
+	mockPostStore.On("GetSingle", hotPost.Id).Return(hotPost, nil)
+	mockPostStore.On("GetSingle", mock.Anything).Return(nil, notFound)
+	mockPostStore.On("Get", mock.Anything).Return(nil, notFound)
+	mockPostStore.On("GetPostsByIds", []string{hotPost.Id, reply.Id, root.Id}).Return([]*model.Post{hotPost}, nil)
+	mockPostStore.On("GetArchivePaths", mock.Anything).Return(func(postIds []string) map[string]string {
+		paths := make(map[string]string)
+		for _, postId := range postIds {
+			if postId == root.Id || postId == reply.Id || postId == deletedReply.Id || postId == otherRoot.Id {
+				paths[postId] = path
+			}
+		}
+		return paths
+	}, nil)
+
+	mockPostStore.On("Search", "team", "user", mock.Anything).Return(&model.PostList{Order: []string{hotPost.Id}, Posts: map[string]*model.Post{hotPost.Id: hotPost}}, nil)
+	mockPostStore.On("SearchArchive", "team", "user", mock.Anything).Return([]*model.PostArchiveIndex{
+		model.NewPostArchiveIndex(reply, path),
+		model.NewPostArchiveIndex(deletedReply, path),
+		model.NewPostArchiveIndex(root, path),
+	}, nil)
+
+	mockStore := mocks.Store{}
+	mockStore.On("Post").Return(&mockPostStore)
+	mockStore.On("MasterOnly").Return(&mockStore)
+
+	reads := 0
+	archiveStore := NewArchiveLayer(&mockStore, func(archivePath string) ([]byte, *model.AppError) {
+		require.Equal(t, path, archivePath)
+		reads++
+		return archive, nil
+	})
+
+	t.Run("GetSingle", func(t *testing.T) {
+		post, err := archiveStore.Post().GetSingle(hotPost.Id)
+		require.Nil(t, err)
+		assert.Equal(t, hotPost, post)
+
+		post, err = archiveStore.Post().GetSingle(reply.Id)
+		require.Nil(t, err)
+		assert.Equal(t, "reply", post.Message)
+
+		_, err = archiveStore.Post().GetSingle(deletedReply.Id)
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+
+		_, err = archiveStore.Post().GetSingle(model.NewId())
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	})
+
+	t.Run("Get returns the archived thread", func(t *testing.T) {
+		list, err := archiveStore.Post().Get(reply.Id)
+		require.Nil(t, err)
+		assert.Len(t, list.Posts, 2)
+		assert.Contains(t, list.Posts, root.Id)
+		assert.Contains(t, list.Posts, reply.Id)
+		assert.Equal(t, reply.Id, list.Order[0])
+	})
+
+	t.Run("GetPostsByIds", func(t *testing.T) {
+		posts, err := archiveStore.Post().GetPostsByIds([]string{hotPost.Id, reply.Id, root.Id})
+		require.Nil(t, err)
+		require.Len(t, posts, 3)
+		assert.Equal(t, []string{hotPost.Id, reply.Id, root.Id}, []string{posts[0].Id, posts[1].Id, posts[2].Id})
+	})
+
+	t.Run("Search merges the archived posts found", func(t *testing.T) {
+		list, err := archiveStore.Post().Search("team", "user", &model.SearchParams{Terms: "search"})
+		require.Nil(t, err)
+		assert.Equal(t, []string{hotPost.Id, reply.Id, root.Id}, list.Order)
+		assert.Len(t, list.Posts, 3)
+	})
+
+	t.Run("archives are read once", func(t *testing.T) {
+		assert.Equal(t, 1, reads)
+	})
+
+	t.Run("archived posts are copies", func(t *testing.T) {
+		post, err := archiveStore.Post().GetSingle(root.Id)
+		require.Nil(t, err)
+		post.Message = "changed"
+
+		post, err = archiveStore.Post().GetSingle(root.Id)
+		require.Nil(t, err)
+		assert.Equal(t, "root", post.Message)
+	})
+
+	t.Run("reading the archive fails", func(t *testing.T) {
+		failing := NewArchiveLayer(&mockStore, func(archivePath string) ([]byte, *model.AppError) {
+			return nil, model.NewAppError("ReadFile", "api.file.read_file.reading_local.app_error", nil, "", http.StatusInternalServerError)
+		})
+
+		_, err := failing.Post().GetSingle(root.Id)
+		require.NotNil(t, err)
+		assert.Equal(t, "store.archive_layer.read.app_error", err.Id)
+	})
+}
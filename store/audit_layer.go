@@ -0,0 +1,2914 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make store-layers"
+// DO NOT EDIT
+
+package store
+
+import (
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/services/tracing"
+)
+
+type AuditLayer struct {
+	Store
+	AuditStore                AuditStore
+	AuditLogStore             AuditLogStore
+	BotStore                  BotStore
+	ChannelStore              ChannelStore
+	ChannelMemberHistoryStore ChannelMemberHistoryStore
+	ClusterDiscoveryStore     ClusterDiscoveryStore
+	CommandStore              CommandStore
+	CommandWebhookStore       CommandWebhookStore
+	ComplianceStore           ComplianceStore
+	EmojiStore                EmojiStore
+	FileInfoStore             FileInfoStore
+	GroupStore                GroupStore
+	JobStore                  JobStore
+	LicenseStore              LicenseStore
+	LinkMetadataStore         LinkMetadataStore
+	OAuthStore                OAuthStore
+	PluginStore               PluginStore
+	PluginConfigRevisionStore PluginConfigRevisionStore
+	PostStore                 PostStore
+	PostAcknowledgementStore  PostAcknowledgementStore
+	PostPriorityStore         PostPriorityStore
+	PostShardStore            PostShardStore
+	PreferenceStore           PreferenceStore
+	ReactionStore             ReactionStore
+	RoleStore                 RoleStore
+	SchemeStore               SchemeStore
+	SessionStore              SessionStore
+	StatusStore               StatusStore
+	SystemStore               SystemStore
+	TeamStore                 TeamStore
+	TermsOfServiceStore       TermsOfServiceStore
+	TokenStore                TokenStore
+	UserStore                 UserStore
+	UserAccessTokenStore      UserAccessTokenStore
+	UserTermsOfServiceStore   UserTermsOfServiceStore
+	WebhookStore              WebhookStore
+
+	actor      func() AuditActor
+	key        []byte
+	masterOnly *AuditLayer
+}
+
+func (s *AuditLayer) Audit() AuditStore {
+	return s.AuditStore
+}
+
+func (s *AuditLayer) AuditLog() AuditLogStore {
+	return s.AuditLogStore
+}
+
+func (s *AuditLayer) Bot() BotStore {
+	return s.BotStore
+}
+
+func (s *AuditLayer) Channel() ChannelStore {
+	return s.ChannelStore
+}
+
+func (s *AuditLayer) ChannelMemberHistory() ChannelMemberHistoryStore {
+	return s.ChannelMemberHistoryStore
+}
+
+func (s *AuditLayer) ClusterDiscovery() ClusterDiscoveryStore {
+	return s.ClusterDiscoveryStore
+}
+
+func (s *AuditLayer) Command() CommandStore {
+	return s.CommandStore
+}
+
+func (s *AuditLayer) CommandWebhook() CommandWebhookStore {
+	return s.CommandWebhookStore
+}
+
+func (s *AuditLayer) Compliance() ComplianceStore {
+	return s.ComplianceStore
+}
+
+func (s *AuditLayer) Emoji() EmojiStore {
+	return s.EmojiStore
+}
+
+func (s *AuditLayer) FileInfo() FileInfoStore {
+	return s.FileInfoStore
+}
+
+func (s *AuditLayer) Group() GroupStore {
+	return s.GroupStore
+}
+
+func (s *AuditLayer) Job() JobStore {
+	return s.JobStore
+}
+
+func (s *AuditLayer) License() LicenseStore {
+	return s.LicenseStore
+}
+
+func (s *AuditLayer) LinkMetadata() LinkMetadataStore {
+	return s.LinkMetadataStore
+}
+
+func (s *AuditLayer) OAuth() OAuthStore {
+	return s.OAuthStore
+}
+
+func (s *AuditLayer) Plugin() PluginStore {
+	return s.PluginStore
+}
+
+func (s *AuditLayer) PluginConfigRevision() PluginConfigRevisionStore {
+	return s.PluginConfigRevisionStore
+}
+
+func (s *AuditLayer) Post() PostStore {
+	return s.PostStore
+}
+
+func (s *AuditLayer) PostAcknowledgement() PostAcknowledgementStore {
+	return s.PostAcknowledgementStore
+}
+
+func (s *AuditLayer) PostPriority() PostPriorityStore {
+	return s.PostPriorityStore
+}
+
+func (s *AuditLayer) PostShard() PostShardStore {
+	return s.PostShardStore
+}
+
+func (s *AuditLayer) Preference() PreferenceStore {
+	return s.PreferenceStore
+}
+
+func (s *AuditLayer) Reaction() ReactionStore {
+	return s.ReactionStore
+}
+
+func (s *AuditLayer) Role() RoleStore {
+	return s.RoleStore
+}
+
+func (s *AuditLayer) Scheme() SchemeStore {
+	return s.SchemeStore
+}
+
+func (s *AuditLayer) Session() SessionStore {
+	return s.SessionStore
+}
+
+func (s *AuditLayer) Status() StatusStore {
+	return s.StatusStore
+}
+
+func (s *AuditLayer) System() SystemStore {
+	return s.SystemStore
+}
+
+func (s *AuditLayer) Team() TeamStore {
+	return s.TeamStore
+}
+
+func (s *AuditLayer) TermsOfService() TermsOfServiceStore {
+	return s.TermsOfServiceStore
+}
+
+func (s *AuditLayer) Token() TokenStore {
+	return s.TokenStore
+}
+
+func (s *AuditLayer) User() UserStore {
+	return s.UserStore
+}
+
+func (s *AuditLayer) UserAccessToken() UserAccessTokenStore {
+	return s.UserAccessTokenStore
+}
+
+func (s *AuditLayer) UserTermsOfService() UserTermsOfServiceStore {
+	return s.UserTermsOfServiceStore
+}
+
+func (s *AuditLayer) Webhook() WebhookStore {
+	return s.WebhookStore
+}
+
+type AuditLayerAuditStore struct {
+	AuditStore
+	Root *AuditLayer
+}
+
+type AuditLayerAuditLogStore struct {
+	AuditLogStore
+	Root *AuditLayer
+}
+
+type AuditLayerBotStore struct {
+	BotStore
+	Root *AuditLayer
+}
+
+type AuditLayerChannelStore struct {
+	ChannelStore
+	Root *AuditLayer
+}
+
+type AuditLayerChannelMemberHistoryStore struct {
+	ChannelMemberHistoryStore
+	Root *AuditLayer
+}
+
+type AuditLayerClusterDiscoveryStore struct {
+	ClusterDiscoveryStore
+	Root *AuditLayer
+}
+
+type AuditLayerCommandStore struct {
+	CommandStore
+	Root *AuditLayer
+}
+
+type AuditLayerCommandWebhookStore struct {
+	CommandWebhookStore
+	Root *AuditLayer
+}
+
+type AuditLayerComplianceStore struct {
+	ComplianceStore
+	Root *AuditLayer
+}
+
+type AuditLayerEmojiStore struct {
+	EmojiStore
+	Root *AuditLayer
+}
+
+type AuditLayerFileInfoStore struct {
+	FileInfoStore
+	Root *AuditLayer
+}
+
+type AuditLayerGroupStore struct {
+	GroupStore
+	Root *AuditLayer
+}
+
+type AuditLayerJobStore struct {
+	JobStore
+	Root *AuditLayer
+}
+
+type AuditLayerLicenseStore struct {
+	LicenseStore
+	Root *AuditLayer
+}
+
+type AuditLayerLinkMetadataStore struct {
+	LinkMetadataStore
+	Root *AuditLayer
+}
+
+type AuditLayerOAuthStore struct {
+	OAuthStore
+	Root *AuditLayer
+}
+
+type AuditLayerPluginStore struct {
+	PluginStore
+	Root *AuditLayer
+}
+
+type AuditLayerPluginConfigRevisionStore struct {
+	PluginConfigRevisionStore
+	Root *AuditLayer
+}
+
+type AuditLayerPostStore struct {
+	PostStore
+	Root *AuditLayer
+}
+
+type AuditLayerPostAcknowledgementStore struct {
+	PostAcknowledgementStore
+	Root *AuditLayer
+}
+
+type AuditLayerPostPriorityStore struct {
+	PostPriorityStore
+	Root *AuditLayer
+}
+
+type AuditLayerPostShardStore struct {
+	PostShardStore
+	Root *AuditLayer
+}
+
+type AuditLayerPreferenceStore struct {
+	PreferenceStore
+	Root *AuditLayer
+}
+
+type AuditLayerReactionStore struct {
+	ReactionStore
+	Root *AuditLayer
+}
+
+type AuditLayerRoleStore struct {
+	RoleStore
+	Root *AuditLayer
+}
+
+type AuditLayerSchemeStore struct {
+	SchemeStore
+	Root *AuditLayer
+}
+
+type AuditLayerSessionStore struct {
+	SessionStore
+	Root *AuditLayer
+}
+
+type AuditLayerStatusStore struct {
+	StatusStore
+	Root *AuditLayer
+}
+
+type AuditLayerSystemStore struct {
+	SystemStore
+	Root *AuditLayer
+}
+
+type AuditLayerTeamStore struct {
+	TeamStore
+	Root *AuditLayer
+}
+
+type AuditLayerTermsOfServiceStore struct {
+	TermsOfServiceStore
+	Root *AuditLayer
+}
+
+type AuditLayerTokenStore struct {
+	TokenStore
+	Root *AuditLayer
+}
+
+type AuditLayerUserStore struct {
+	UserStore
+	Root *AuditLayer
+}
+
+type AuditLayerUserAccessTokenStore struct {
+	UserAccessTokenStore
+	Root *AuditLayer
+}
+
+type AuditLayerUserTermsOfServiceStore struct {
+	UserTermsOfServiceStore
+	Root *AuditLayer
+}
+
+type AuditLayerWebhookStore struct {
+	WebhookStore
+	Root *AuditLayer
+}
+
+func (s *AuditLayerAuditStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	var resultVar0 int64
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Audit", "PermanentDeleteBatch", []string{"endTime", "limit"}, endTime, limit)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.AuditStore.PermanentDeleteBatch(endTime, limit)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerAuditStore) PermanentDeleteByUser(userId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Audit", "PermanentDeleteByUser", []string{"userId"}, userId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.AuditStore.PermanentDeleteByUser(userId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerAuditStore) Save(audit *model.Audit) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Audit", "Save", []string{"audit"}, audit)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.AuditStore.Save(audit)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerBotStore) PermanentDelete(userId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Bot", "PermanentDelete", []string{"userId"}, userId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.BotStore.PermanentDelete(userId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerBotStore) Save(bot *model.Bot) (*model.Bot, *model.AppError) {
+	var resultVar0 *model.Bot
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Bot", "Save", []string{"bot"}, bot)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.BotStore.Save(bot)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerBotStore) Update(bot *model.Bot) (*model.Bot, *model.AppError) {
+	var resultVar0 *model.Bot
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Bot", "Update", []string{"bot"}, bot)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.BotStore.Update(bot)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerChannelStore) ClearAllCustomRoleAssignments() *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Channel", "ClearAllCustomRoleAssignments", []string{})
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.ChannelStore.ClearAllCustomRoleAssignments()
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerChannelStore) CreateDirectChannel(userId *model.User, otherUserId *model.User) (*model.Channel, *model.AppError) {
+	var resultVar0 *model.Channel
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Channel", "CreateDirectChannel", []string{"userId", "otherUserId"}, userId, otherUserId)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.ChannelStore.CreateDirectChannel(userId, otherUserId)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerChannelStore) Delete(channelId string, time int64) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Channel", "Delete", []string{"channelId", "time"}, channelId, time)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.ChannelStore.Delete(channelId, time)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerChannelStore) MigrateChannelMembers(fromChannelId string, fromUserId string) (map[string]string, *model.AppError) {
+	var resultVar0 map[string]string
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Channel", "MigrateChannelMembers", []string{"fromChannelId", "fromUserId"}, fromChannelId, fromUserId)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.ChannelStore.MigrateChannelMembers(fromChannelId, fromUserId)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerChannelStore) MigratePublicChannels() error {
+	var resultVar0 error
+
+	// Without an error to return, the change is made even if it can't be audited.
+	entry, _ := s.Root.begin("Channel", "MigratePublicChannels", []string{})
+
+	resultVar0 = s.ChannelStore.MigratePublicChannels()
+
+	s.Root.end(entry, nil, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerChannelStore) PermanentDelete(channelId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Channel", "PermanentDelete", []string{"channelId"}, channelId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.ChannelStore.PermanentDelete(channelId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerChannelStore) PermanentDeleteByTeam(teamId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Channel", "PermanentDeleteByTeam", []string{"teamId"}, teamId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.ChannelStore.PermanentDeleteByTeam(teamId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerChannelStore) PermanentDeleteMembersByChannel(channelId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Channel", "PermanentDeleteMembersByChannel", []string{"channelId"}, channelId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.ChannelStore.PermanentDeleteMembersByChannel(channelId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerChannelStore) PermanentDeleteMembersByUser(userId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Channel", "PermanentDeleteMembersByUser", []string{"userId"}, userId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.ChannelStore.PermanentDeleteMembersByUser(userId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerChannelStore) RemoveAllDeactivatedMembers(channelId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Channel", "RemoveAllDeactivatedMembers", []string{"channelId"}, channelId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.ChannelStore.RemoveAllDeactivatedMembers(channelId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerChannelStore) RemoveMember(channelId string, userId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Channel", "RemoveMember", []string{"channelId", "userId"}, channelId, userId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.ChannelStore.RemoveMember(channelId, userId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerChannelStore) ResetAllChannelSchemes() *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Channel", "ResetAllChannelSchemes", []string{})
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.ChannelStore.ResetAllChannelSchemes()
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerChannelStore) Restore(channelId string, time int64) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Channel", "Restore", []string{"channelId", "time"}, channelId, time)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.ChannelStore.Restore(channelId, time)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerChannelStore) Save(channel *model.Channel, maxChannelsPerTeam int64) (*model.Channel, *model.AppError) {
+	var resultVar0 *model.Channel
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Channel", "Save", []string{"channel", "maxChannelsPerTeam"}, channel, maxChannelsPerTeam)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.ChannelStore.Save(channel, maxChannelsPerTeam)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerChannelStore) SaveDirectChannel(channel *model.Channel, member1 *model.ChannelMember, member2 *model.ChannelMember) (*model.Channel, *model.AppError) {
+	var resultVar0 *model.Channel
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Channel", "SaveDirectChannel", []string{"channel", "member1", "member2"}, channel, member1, member2)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.ChannelStore.SaveDirectChannel(channel, member1, member2)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerChannelStore) SaveMember(member *model.ChannelMember) (*model.ChannelMember, *model.AppError) {
+	var resultVar0 *model.ChannelMember
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Channel", "SaveMember", []string{"member"}, member)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.ChannelStore.SaveMember(member)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerChannelStore) SetDeleteAt(channelId string, deleteAt int64, updateAt int64) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Channel", "SetDeleteAt", []string{"channelId", "deleteAt", "updateAt"}, channelId, deleteAt, updateAt)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.ChannelStore.SetDeleteAt(channelId, deleteAt, updateAt)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerChannelStore) Update(channel *model.Channel) (*model.Channel, *model.AppError) {
+	var resultVar0 *model.Channel
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Channel", "Update", []string{"channel"}, channel)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.ChannelStore.Update(channel)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerChannelStore) UpdateMember(member *model.ChannelMember) (*model.ChannelMember, *model.AppError) {
+	var resultVar0 *model.ChannelMember
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Channel", "UpdateMember", []string{"member"}, member)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.ChannelStore.UpdateMember(member)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	var resultVar0 int64
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("ChannelMemberHistory", "PermanentDeleteBatch", []string{"endTime", "limit"}, endTime, limit)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.ChannelMemberHistoryStore.PermanentDeleteBatch(endTime, limit)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerCommandStore) Delete(commandId string, time int64) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Command", "Delete", []string{"commandId", "time"}, commandId, time)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.CommandStore.Delete(commandId, time)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerCommandStore) PermanentDeleteByTeam(teamId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Command", "PermanentDeleteByTeam", []string{"teamId"}, teamId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.CommandStore.PermanentDeleteByTeam(teamId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerCommandStore) PermanentDeleteByUser(userId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Command", "PermanentDeleteByUser", []string{"userId"}, userId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.CommandStore.PermanentDeleteByUser(userId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerCommandStore) Save(webhook *model.Command) (*model.Command, *model.AppError) {
+	var resultVar0 *model.Command
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Command", "Save", []string{"webhook"}, webhook)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.CommandStore.Save(webhook)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerCommandStore) Update(hook *model.Command) (*model.Command, *model.AppError) {
+	var resultVar0 *model.Command
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Command", "Update", []string{"hook"}, hook)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.CommandStore.Update(hook)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerCommandWebhookStore) Cleanup() {
+
+	// Without an error to return, the change is made even if it can't be audited.
+	entry, _ := s.Root.begin("CommandWebhook", "Cleanup", []string{})
+
+	s.CommandWebhookStore.Cleanup()
+
+	s.Root.end(entry, nil)
+
+}
+
+func (s *AuditLayerCommandWebhookStore) Save(webhook *model.CommandWebhook) (*model.CommandWebhook, *model.AppError) {
+	var resultVar0 *model.CommandWebhook
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("CommandWebhook", "Save", []string{"webhook"}, webhook)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.CommandWebhookStore.Save(webhook)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerComplianceStore) Save(compliance *model.Compliance) (*model.Compliance, *model.AppError) {
+	var resultVar0 *model.Compliance
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Compliance", "Save", []string{"compliance"}, compliance)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.ComplianceStore.Save(compliance)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerComplianceStore) Update(compliance *model.Compliance) (*model.Compliance, *model.AppError) {
+	var resultVar0 *model.Compliance
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Compliance", "Update", []string{"compliance"}, compliance)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.ComplianceStore.Update(compliance)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerEmojiStore) Delete(emoji *model.Emoji, time int64) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Emoji", "Delete", []string{"emoji", "time"}, emoji, time)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.EmojiStore.Delete(emoji, time)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, *model.AppError) {
+	var resultVar0 *model.Emoji
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Emoji", "Save", []string{"emoji"}, emoji)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.EmojiStore.Save(emoji)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerFileInfoStore) AttachToPost(fileId string, postId string, creatorId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("FileInfo", "AttachToPost", []string{"fileId", "postId", "creatorId"}, fileId, postId, creatorId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.FileInfoStore.AttachToPost(fileId, postId, creatorId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerFileInfoStore) DeleteForPost(postId string) (string, *model.AppError) {
+	var resultVar0 string
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("FileInfo", "DeleteForPost", []string{"postId"}, postId)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.FileInfoStore.DeleteForPost(postId)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerFileInfoStore) PermanentDelete(fileId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("FileInfo", "PermanentDelete", []string{"fileId"}, fileId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.FileInfoStore.PermanentDelete(fileId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	var resultVar0 int64
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("FileInfo", "PermanentDeleteBatch", []string{"endTime", "limit"}, endTime, limit)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.FileInfoStore.PermanentDeleteBatch(endTime, limit)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerFileInfoStore) PermanentDeleteByUser(userId string) (int64, *model.AppError) {
+	var resultVar0 int64
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("FileInfo", "PermanentDeleteByUser", []string{"userId"}, userId)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.FileInfoStore.PermanentDeleteByUser(userId)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, *model.AppError) {
+	var resultVar0 *model.FileInfo
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("FileInfo", "Save", []string{"info"}, info)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.FileInfoStore.Save(info)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerGroupStore) Create(group *model.Group) (*model.Group, *model.AppError) {
+	var resultVar0 *model.Group
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Group", "Create", []string{"group"}, group)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.GroupStore.Create(group)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerGroupStore) CreateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+	var resultVar0 *model.GroupSyncable
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Group", "CreateGroupSyncable", []string{"groupSyncable"}, groupSyncable)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.GroupStore.CreateGroupSyncable(groupSyncable)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerGroupStore) Delete(groupID string) (*model.Group, *model.AppError) {
+	var resultVar0 *model.Group
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Group", "Delete", []string{"groupID"}, groupID)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.GroupStore.Delete(groupID)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerGroupStore) DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
+	var resultVar0 *model.GroupSyncable
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Group", "DeleteGroupSyncable", []string{"groupID", "syncableID", "syncableType"}, groupID, syncableID, syncableType)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.GroupStore.DeleteGroupSyncable(groupID, syncableID, syncableType)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerGroupStore) DeleteMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+	var resultVar0 *model.GroupMember
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Group", "DeleteMember", []string{"groupID", "userID"}, groupID, userID)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.GroupStore.DeleteMember(groupID, userID)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerGroupStore) Update(group *model.Group) (*model.Group, *model.AppError) {
+	var resultVar0 *model.Group
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Group", "Update", []string{"group"}, group)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.GroupStore.Update(group)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+	var resultVar0 *model.GroupSyncable
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Group", "UpdateGroupSyncable", []string{"groupSyncable"}, groupSyncable)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.GroupStore.UpdateGroupSyncable(groupSyncable)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerGroupStore) UpsertMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+	var resultVar0 *model.GroupMember
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Group", "UpsertMember", []string{"groupID", "userID"}, groupID, userID)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.GroupStore.UpsertMember(groupID, userID)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerLicenseStore) Save(license *model.LicenseRecord) (*model.LicenseRecord, *model.AppError) {
+	var resultVar0 *model.LicenseRecord
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("License", "Save", []string{"license"}, license)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.LicenseStore.Save(license)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerOAuthStore) DeleteApp(id string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("OAuth", "DeleteApp", []string{"id"}, id)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.OAuthStore.DeleteApp(id)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerOAuthStore) PermanentDeleteAuthDataByUser(userId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("OAuth", "PermanentDeleteAuthDataByUser", []string{"userId"}, userId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.OAuthStore.PermanentDeleteAuthDataByUser(userId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerOAuthStore) RemoveAccessData(token string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("OAuth", "RemoveAccessData", []string{"token"}, token)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.OAuthStore.RemoveAccessData(token)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerOAuthStore) RemoveAllAccessData() *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("OAuth", "RemoveAllAccessData", []string{})
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.OAuthStore.RemoveAllAccessData()
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerOAuthStore) RemoveAuthData(code string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("OAuth", "RemoveAuthData", []string{"code"}, code)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.OAuthStore.RemoveAuthData(code)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, *model.AppError) {
+	var resultVar0 *model.AccessData
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("OAuth", "SaveAccessData", []string{"accessData"}, accessData)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.OAuthStore.SaveAccessData(accessData)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError) {
+	var resultVar0 *model.OAuthApp
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("OAuth", "SaveApp", []string{"app"}, app)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.OAuthStore.SaveApp(app)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, *model.AppError) {
+	var resultVar0 *model.AuthData
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("OAuth", "SaveAuthData", []string{"authData"}, authData)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.OAuthStore.SaveAuthData(authData)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, *model.AppError) {
+	var resultVar0 *model.AccessData
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("OAuth", "UpdateAccessData", []string{"accessData"}, accessData)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.OAuthStore.UpdateAccessData(accessData)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError) {
+	var resultVar0 *model.OAuthApp
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("OAuth", "UpdateApp", []string{"app"}, app)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.OAuthStore.UpdateApp(app)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, *model.AppError) {
+	var resultVar0 bool
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Plugin", "CompareAndDelete", []string{"keyVal", "oldValue"}, keyVal, oldValue)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.PluginStore.CompareAndDelete(keyVal, oldValue)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, *model.AppError) {
+	var resultVar0 bool
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Plugin", "CompareAndSet", []string{"keyVal", "oldValue"}, keyVal, oldValue)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.PluginStore.CompareAndSet(keyVal, oldValue)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerPluginStore) Delete(pluginId string, key string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Plugin", "Delete", []string{"pluginId", "key"}, pluginId, key)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.PluginStore.Delete(pluginId, key)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerPluginStore) DeleteAllExpired() *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Plugin", "DeleteAllExpired", []string{})
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.PluginStore.DeleteAllExpired()
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerPluginStore) DeleteAllForPlugin(PluginId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Plugin", "DeleteAllForPlugin", []string{"PluginId"}, PluginId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.PluginStore.DeleteAllForPlugin(PluginId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, *model.AppError) {
+	var resultVar0 *model.PluginKeyValue
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Plugin", "SaveOrUpdate", []string{"keyVal"}, keyVal)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.PluginStore.SaveOrUpdate(keyVal)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerPluginStore) SetMulti(keyVals []*model.PluginKeyValue) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Plugin", "SetMulti", []string{"keyVals"}, keyVals)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.PluginStore.SetMulti(keyVals)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerPluginStore) SetWithOptions(pluginId string, key string, value []byte, options model.PluginKVSetOptions) (bool, *model.AppError) {
+	var resultVar0 bool
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Plugin", "SetWithOptions", []string{"pluginId", "key", "value", "options"}, pluginId, key, value, options)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.PluginStore.SetWithOptions(pluginId, key, value, options)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerPluginConfigRevisionStore) Save(revision *model.PluginConfigRevision) (*model.PluginConfigRevision, *model.AppError) {
+	var resultVar0 *model.PluginConfigRevision
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("PluginConfigRevision", "Save", []string{"revision"}, revision)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.PluginConfigRevisionStore.Save(revision)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerPostStore) Archive(path string, posts []*model.Post, endTime int64) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Post", "Archive", []string{"path", "posts", "endTime"}, path, posts, endTime)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.PostStore.Archive(path, posts, endTime)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerPostStore) Delete(postId string, time int64, deleteByID string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Post", "Delete", []string{"postId", "time", "deleteByID"}, postId, time, deleteByID)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.PostStore.Delete(postId, time, deleteByID)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerPostStore) Overwrite(post *model.Post) (*model.Post, *model.AppError) {
+	var resultVar0 *model.Post
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Post", "Overwrite", []string{"post"}, post)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.PostStore.Overwrite(post)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	var resultVar0 int64
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Post", "PermanentDeleteBatch", []string{"endTime", "limit"}, endTime, limit)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.PostStore.PermanentDeleteBatch(endTime, limit)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerPostStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Post", "PermanentDeleteByChannel", []string{"channelId"}, channelId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.PostStore.PermanentDeleteByChannel(channelId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerPostStore) PermanentDeleteByUser(userId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Post", "PermanentDeleteByUser", []string{"userId"}, userId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.PostStore.PermanentDeleteByUser(userId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerPostStore) Save(post *model.Post) (*model.Post, *model.AppError) {
+	var resultVar0 *model.Post
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Post", "Save", []string{"post"}, post)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.PostStore.Save(post)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerPostStore) Unarchive(path string, posts []*model.Post) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Post", "Unarchive", []string{"path", "posts"}, path, posts)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.PostStore.Unarchive(path, posts)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, *model.AppError) {
+	var resultVar0 *model.Post
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Post", "Update", []string{"newPost", "oldPost"}, newPost, oldPost)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.PostStore.Update(newPost, oldPost)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerPostAcknowledgementStore) Delete(postId string, userId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("PostAcknowledgement", "Delete", []string{"postId", "userId"}, postId, userId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.PostAcknowledgementStore.Delete(postId, userId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerPostAcknowledgementStore) Save(acknowledgement *model.PostAcknowledgement) (*model.PostAcknowledgement, *model.AppError) {
+	var resultVar0 *model.PostAcknowledgement
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("PostAcknowledgement", "Save", []string{"acknowledgement"}, acknowledgement)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.PostAcknowledgementStore.Save(acknowledgement)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerPostPriorityStore) Save(priority *model.PostPriority) (*model.PostPriority, *model.AppError) {
+	var resultVar0 *model.PostPriority
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("PostPriority", "Save", []string{"priority"}, priority)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.PostPriorityStore.Save(priority)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, *model.AppError) {
+	var resultVar0 int64
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Preference", "CleanupFlagsBatch", []string{"limit"}, limit)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.PreferenceStore.CleanupFlagsBatch(limit)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerPreferenceStore) Delete(userId string, category string, name string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Preference", "Delete", []string{"userId", "category", "name"}, userId, category, name)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.PreferenceStore.Delete(userId, category, name)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerPreferenceStore) DeleteCategory(userId string, category string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Preference", "DeleteCategory", []string{"userId", "category"}, userId, category)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.PreferenceStore.DeleteCategory(userId, category)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerPreferenceStore) DeleteCategoryAndName(category string, name string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Preference", "DeleteCategoryAndName", []string{"category", "name"}, category, name)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.PreferenceStore.DeleteCategoryAndName(category, name)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerPreferenceStore) PermanentDeleteByUser(userId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Preference", "PermanentDeleteByUser", []string{"userId"}, userId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.PreferenceStore.PermanentDeleteByUser(userId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerPreferenceStore) Save(preferences *model.Preferences) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Preference", "Save", []string{"preferences"}, preferences)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.PreferenceStore.Save(preferences)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, *model.AppError) {
+	var resultVar0 *model.Reaction
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Reaction", "Delete", []string{"reaction"}, reaction)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.ReactionStore.Delete(reaction)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerReactionStore) DeleteAllWithEmojiName(emojiName string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Reaction", "DeleteAllWithEmojiName", []string{"emojiName"}, emojiName)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.ReactionStore.DeleteAllWithEmojiName(emojiName)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	var resultVar0 int64
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Reaction", "PermanentDeleteBatch", []string{"endTime", "limit"}, endTime, limit)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.ReactionStore.PermanentDeleteBatch(endTime, limit)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, *model.AppError) {
+	var resultVar0 *model.Reaction
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Reaction", "Save", []string{"reaction"}, reaction)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.ReactionStore.Save(reaction)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerRoleStore) Delete(roldId string) (*model.Role, *model.AppError) {
+	var resultVar0 *model.Role
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Role", "Delete", []string{"roldId"}, roldId)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.RoleStore.Delete(roldId)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerRoleStore) PermanentDeleteAll() *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Role", "PermanentDeleteAll", []string{})
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.RoleStore.PermanentDeleteAll()
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerRoleStore) Save(role *model.Role) (*model.Role, *model.AppError) {
+	var resultVar0 *model.Role
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Role", "Save", []string{"role"}, role)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.RoleStore.Save(role)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerSchemeStore) Delete(schemeId string) (*model.Scheme, *model.AppError) {
+	var resultVar0 *model.Scheme
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Scheme", "Delete", []string{"schemeId"}, schemeId)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.SchemeStore.Delete(schemeId)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerSchemeStore) PermanentDeleteAll() *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Scheme", "PermanentDeleteAll", []string{})
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.SchemeStore.PermanentDeleteAll()
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, *model.AppError) {
+	var resultVar0 *model.Scheme
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Scheme", "Save", []string{"scheme"}, scheme)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.SchemeStore.Save(scheme)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerSessionStore) Cleanup(expiryTime int64, batchSize int64) {
+
+	// Without an error to return, the change is made even if it can't be audited.
+	entry, _ := s.Root.begin("Session", "Cleanup", []string{"expiryTime", "batchSize"}, expiryTime, batchSize)
+
+	s.SessionStore.Cleanup(expiryTime, batchSize)
+
+	s.Root.end(entry, nil)
+
+}
+
+func (s *AuditLayerSessionStore) PermanentDeleteSessionsByUser(teamId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Session", "PermanentDeleteSessionsByUser", []string{"teamId"}, teamId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.SessionStore.PermanentDeleteSessionsByUser(teamId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerSessionStore) Remove(sessionIdOrToken string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Session", "Remove", []string{"sessionIdOrToken"}, sessionIdOrToken)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.SessionStore.Remove(sessionIdOrToken)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerSessionStore) RemoveAllSessions() *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Session", "RemoveAllSessions", []string{})
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.SessionStore.RemoveAllSessions()
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerSessionStore) Save(session *model.Session) (*model.Session, *model.AppError) {
+	var resultVar0 *model.Session
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Session", "Save", []string{"session"}, session)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.SessionStore.Save(session)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerSessionStore) UpdateDeviceId(id string, deviceId string, expiresAt int64) (string, *model.AppError) {
+	var resultVar0 string
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Session", "UpdateDeviceId", []string{"id", "deviceId", "expiresAt"}, id, deviceId, expiresAt)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.SessionStore.UpdateDeviceId(id, deviceId, expiresAt)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerSessionStore) UpdateProps(session *model.Session) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Session", "UpdateProps", []string{"session"}, session)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.SessionStore.UpdateProps(session)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerSessionStore) UpdateRoles(userId string, roles string) (string, *model.AppError) {
+	var resultVar0 string
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Session", "UpdateRoles", []string{"userId", "roles"}, userId, roles)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.SessionStore.UpdateRoles(userId, roles)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerSystemStore) PermanentDeleteByName(name string) (*model.System, *model.AppError) {
+	var resultVar0 *model.System
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("System", "PermanentDeleteByName", []string{"name"}, name)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.SystemStore.PermanentDeleteByName(name)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerSystemStore) Save(system *model.System) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("System", "Save", []string{"system"}, system)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.SystemStore.Save(system)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerSystemStore) SaveOrUpdate(system *model.System) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("System", "SaveOrUpdate", []string{"system"}, system)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.SystemStore.SaveOrUpdate(system)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerSystemStore) Update(system *model.System) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("System", "Update", []string{"system"}, system)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.SystemStore.Update(system)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerTeamStore) ClearAllCustomRoleAssignments() *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Team", "ClearAllCustomRoleAssignments", []string{})
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.TeamStore.ClearAllCustomRoleAssignments()
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerTeamStore) MigrateTeamMembers(fromTeamId string, fromUserId string) (map[string]string, *model.AppError) {
+	var resultVar0 map[string]string
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Team", "MigrateTeamMembers", []string{"fromTeamId", "fromUserId"}, fromTeamId, fromUserId)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.TeamStore.MigrateTeamMembers(fromTeamId, fromUserId)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerTeamStore) PermanentDelete(teamId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Team", "PermanentDelete", []string{"teamId"}, teamId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.TeamStore.PermanentDelete(teamId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerTeamStore) RemoveAllMembersByTeam(teamId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Team", "RemoveAllMembersByTeam", []string{"teamId"}, teamId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.TeamStore.RemoveAllMembersByTeam(teamId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerTeamStore) RemoveAllMembersByUser(userId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Team", "RemoveAllMembersByUser", []string{"userId"}, userId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.TeamStore.RemoveAllMembersByUser(userId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerTeamStore) RemoveMember(teamId string, userId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Team", "RemoveMember", []string{"teamId", "userId"}, teamId, userId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.TeamStore.RemoveMember(teamId, userId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerTeamStore) ResetAllTeamSchemes() *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Team", "ResetAllTeamSchemes", []string{})
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.TeamStore.ResetAllTeamSchemes()
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerTeamStore) Save(team *model.Team) (*model.Team, *model.AppError) {
+	var resultVar0 *model.Team
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Team", "Save", []string{"team"}, team)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.TeamStore.Save(team)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerTeamStore) SaveMember(member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, *model.AppError) {
+	var resultVar0 *model.TeamMember
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Team", "SaveMember", []string{"member", "maxUsersPerTeam"}, member, maxUsersPerTeam)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.TeamStore.SaveMember(member, maxUsersPerTeam)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerTeamStore) Update(team *model.Team) (*model.Team, *model.AppError) {
+	var resultVar0 *model.Team
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Team", "Update", []string{"team"}, team)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.TeamStore.Update(team)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerTeamStore) UpdateLastTeamIconUpdate(teamId string, curTime int64) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Team", "UpdateLastTeamIconUpdate", []string{"teamId", "curTime"}, teamId, curTime)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.TeamStore.UpdateLastTeamIconUpdate(teamId, curTime)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerTeamStore) UpdateMember(member *model.TeamMember) (*model.TeamMember, *model.AppError) {
+	var resultVar0 *model.TeamMember
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Team", "UpdateMember", []string{"member"}, member)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.TeamStore.UpdateMember(member)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerTermsOfServiceStore) Save(termsOfService *model.TermsOfService) (*model.TermsOfService, *model.AppError) {
+	var resultVar0 *model.TermsOfService
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("TermsOfService", "Save", []string{"termsOfService"}, termsOfService)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.TermsOfServiceStore.Save(termsOfService)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerTokenStore) Cleanup() {
+
+	// Without an error to return, the change is made even if it can't be audited.
+	entry, _ := s.Root.begin("Token", "Cleanup", []string{})
+
+	s.TokenStore.Cleanup()
+
+	s.Root.end(entry, nil)
+
+}
+
+func (s *AuditLayerTokenStore) Delete(token string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Token", "Delete", []string{"token"}, token)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.TokenStore.Delete(token)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerTokenStore) RemoveAllTokensByType(tokenType string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Token", "RemoveAllTokensByType", []string{"tokenType"}, tokenType)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.TokenStore.RemoveAllTokensByType(tokenType)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerTokenStore) Save(recovery *model.Token) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Token", "Save", []string{"recovery"}, recovery)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.TokenStore.Save(recovery)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerUserStore) ClearAllCustomRoleAssignments() *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("User", "ClearAllCustomRoleAssignments", []string{})
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.UserStore.ClearAllCustomRoleAssignments()
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerUserStore) PermanentDelete(userId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("User", "PermanentDelete", []string{"userId"}, userId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.UserStore.PermanentDelete(userId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerUserStore) ResetLastPictureUpdate(userId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("User", "ResetLastPictureUpdate", []string{"userId"}, userId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.UserStore.ResetLastPictureUpdate(userId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerUserStore) Save(user *model.User) (*model.User, *model.AppError) {
+	var resultVar0 *model.User
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("User", "Save", []string{"user"}, user)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.UserStore.Save(user)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerUserStore) SetCustomStatusExpiry(userId string, expiresAt int64) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("User", "SetCustomStatusExpiry", []string{"userId", "expiresAt"}, userId, expiresAt)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.UserStore.SetCustomStatusExpiry(userId, expiresAt)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerUserStore) Update(user *model.User, allowRoleUpdate bool) (*model.UserUpdate, *model.AppError) {
+	var resultVar0 *model.UserUpdate
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("User", "Update", []string{"user", "allowRoleUpdate"}, user, allowRoleUpdate)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.UserStore.Update(user, allowRoleUpdate)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerUserStore) UpdateAuthData(userId string, service string, authData *string, email string, resetMfa bool) (string, *model.AppError) {
+	var resultVar0 string
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("User", "UpdateAuthData", []string{"userId", "service", "authData", "email", "resetMfa"}, userId, service, authData, email, resetMfa)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.UserStore.UpdateAuthData(userId, service, authData, email, resetMfa)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerUserStore) UpdateFailedPasswordAttempts(userId string, attempts int) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("User", "UpdateFailedPasswordAttempts", []string{"userId", "attempts"}, userId, attempts)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.UserStore.UpdateFailedPasswordAttempts(userId, attempts)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerUserStore) UpdateLastPictureUpdate(userId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("User", "UpdateLastPictureUpdate", []string{"userId"}, userId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.UserStore.UpdateLastPictureUpdate(userId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerUserStore) UpdateMfaActive(userId string, active bool) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("User", "UpdateMfaActive", []string{"userId", "active"}, userId, active)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.UserStore.UpdateMfaActive(userId, active)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerUserStore) UpdateMfaSecret(userId string, secret string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("User", "UpdateMfaSecret", []string{"userId", "secret"}, userId, secret)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.UserStore.UpdateMfaSecret(userId, secret)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerUserStore) UpdatePassword(userId string, newPassword string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("User", "UpdatePassword", []string{"userId", "newPassword"}, userId, newPassword)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.UserStore.UpdatePassword(userId, newPassword)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerUserStore) UpdateUpdateAt(userId string) (int64, *model.AppError) {
+	var resultVar0 int64
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("User", "UpdateUpdateAt", []string{"userId"}, userId)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.UserStore.UpdateUpdateAt(userId)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerUserStore) VerifyEmail(userId string, email string) (string, *model.AppError) {
+	var resultVar0 string
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("User", "VerifyEmail", []string{"userId", "email"}, userId, email)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.UserStore.VerifyEmail(userId, email)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerUserAccessTokenStore) Delete(tokenId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("UserAccessToken", "Delete", []string{"tokenId"}, tokenId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.UserAccessTokenStore.Delete(tokenId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerUserAccessTokenStore) DeleteAllForUser(userId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("UserAccessToken", "DeleteAllForUser", []string{"userId"}, userId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.UserAccessTokenStore.DeleteAllForUser(userId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerUserAccessTokenStore) Save(token *model.UserAccessToken) (*model.UserAccessToken, *model.AppError) {
+	var resultVar0 *model.UserAccessToken
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("UserAccessToken", "Save", []string{"token"}, token)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.UserAccessTokenStore.Save(token)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerUserAccessTokenStore) UpdateTokenDisable(tokenId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("UserAccessToken", "UpdateTokenDisable", []string{"tokenId"}, tokenId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.UserAccessTokenStore.UpdateTokenDisable(tokenId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerUserAccessTokenStore) UpdateTokenEnable(tokenId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("UserAccessToken", "UpdateTokenEnable", []string{"tokenId"}, tokenId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.UserAccessTokenStore.UpdateTokenEnable(tokenId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerUserTermsOfServiceStore) Delete(userId string, termsOfServiceId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("UserTermsOfService", "Delete", []string{"userId", "termsOfServiceId"}, userId, termsOfServiceId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.UserTermsOfServiceStore.Delete(userId, termsOfServiceId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerUserTermsOfServiceStore) Save(userTermsOfService *model.UserTermsOfService) (*model.UserTermsOfService, *model.AppError) {
+	var resultVar0 *model.UserTermsOfService
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("UserTermsOfService", "Save", []string{"userTermsOfService"}, userTermsOfService)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.UserTermsOfServiceStore.Save(userTermsOfService)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerWebhookStore) DeleteIncoming(webhookId string, time int64) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Webhook", "DeleteIncoming", []string{"webhookId", "time"}, webhookId, time)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.WebhookStore.DeleteIncoming(webhookId, time)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerWebhookStore) DeleteOutgoing(webhookId string, time int64) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Webhook", "DeleteOutgoing", []string{"webhookId", "time"}, webhookId, time)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.WebhookStore.DeleteOutgoing(webhookId, time)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerWebhookStore) PermanentDeleteIncomingByChannel(channelId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Webhook", "PermanentDeleteIncomingByChannel", []string{"channelId"}, channelId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.WebhookStore.PermanentDeleteIncomingByChannel(channelId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerWebhookStore) PermanentDeleteIncomingByUser(userId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Webhook", "PermanentDeleteIncomingByUser", []string{"userId"}, userId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.WebhookStore.PermanentDeleteIncomingByUser(userId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerWebhookStore) PermanentDeleteOutgoingByChannel(channelId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Webhook", "PermanentDeleteOutgoingByChannel", []string{"channelId"}, channelId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.WebhookStore.PermanentDeleteOutgoingByChannel(channelId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerWebhookStore) PermanentDeleteOutgoingByUser(userId string) *model.AppError {
+	var resultVar0 *model.AppError
+
+	entry, auditErr := s.Root.begin("Webhook", "PermanentDeleteOutgoingByUser", []string{"userId"}, userId)
+	if auditErr != nil {
+		resultVar0 = auditErr
+		return resultVar0
+	}
+
+	resultVar0 = s.WebhookStore.PermanentDeleteOutgoingByUser(userId)
+
+	s.Root.end(entry, resultVar0, resultVar0)
+	return resultVar0
+}
+
+func (s *AuditLayerWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, *model.AppError) {
+	var resultVar0 *model.IncomingWebhook
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Webhook", "SaveIncoming", []string{"webhook"}, webhook)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.WebhookStore.SaveIncoming(webhook)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError) {
+	var resultVar0 *model.OutgoingWebhook
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Webhook", "SaveOutgoing", []string{"webhook"}, webhook)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.WebhookStore.SaveOutgoing(webhook)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerWebhookStore) UpdateIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, *model.AppError) {
+	var resultVar0 *model.IncomingWebhook
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Webhook", "UpdateIncoming", []string{"webhook"}, webhook)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.WebhookStore.UpdateIncoming(webhook)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError) {
+	var resultVar0 *model.OutgoingWebhook
+	var resultVar1 *model.AppError
+
+	entry, auditErr := s.Root.begin("Webhook", "UpdateOutgoing", []string{"hook"}, hook)
+	if auditErr != nil {
+		resultVar1 = auditErr
+		return resultVar0, resultVar1
+	}
+
+	resultVar0, resultVar1 = s.WebhookStore.UpdateOutgoing(hook)
+
+	s.Root.end(entry, resultVar1, resultVar0, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *AuditLayer) MasterOnly() Store {
+	return s.masterOnly
+}
+
+// Traced returns a view of this store reporting each of its calls as a span nested in the given
+// one, if the store it wraps can, or this store itself otherwise.
+func (s *AuditLayer) Traced(span *tracing.Span) Store {
+	traced, ok := s.Store.(interface {
+		Traced(span *tracing.Span) Store
+	})
+	if !ok || span == nil {
+		return s
+	}
+
+	return s.view(traced.Traced(span), s.actor)
+}
+
+// Audited returns a view of this store recording the changes made through it on behalf of the
+// actor returned by the given function, which is called for each change.
+func (s *AuditLayer) Audited(actor func() AuditActor) Store {
+	return s.view(s.Store, actor)
+}
+
+func (s *AuditLayer) view(childStore Store, actor func() AuditActor) *AuditLayer {
+	newStore := newAuditLayer(childStore, actor, s.key)
+	if s.masterOnly == s {
+		newStore.masterOnly = newStore
+	} else {
+		newStore.masterOnly = newAuditLayer(childStore.MasterOnly(), actor, s.key)
+		newStore.masterOnly.masterOnly = newStore.masterOnly
+	}
+	return newStore
+}
+
+// NewAuditLayer returns a store recording the changes made through it to the audit log, with the
+// hashes chaining the records keyed with the given key unless it is empty.
+func NewAuditLayer(childStore Store, key []byte) *AuditLayer {
+	newStore := newAuditLayer(childStore, nil, key)
+	newStore.masterOnly = newAuditLayer(childStore.MasterOnly(), nil, key)
+	newStore.masterOnly.masterOnly = newStore.masterOnly
+	return newStore
+}
+
+func newAuditLayer(childStore Store, actor func() AuditActor, key []byte) *AuditLayer {
+	newStore := AuditLayer{
+		Store: childStore,
+		actor: actor,
+		key:   key,
+	}
+
+	newStore.AuditStore = &AuditLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.AuditLogStore = &AuditLayerAuditLogStore{AuditLogStore: childStore.AuditLog(), Root: &newStore}
+	newStore.BotStore = &AuditLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
+	newStore.ChannelStore = &AuditLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
+	newStore.ChannelMemberHistoryStore = &AuditLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
+	newStore.ClusterDiscoveryStore = &AuditLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
+	newStore.CommandStore = &AuditLayerCommandStore{CommandStore: childStore.Command(), Root: &newStore}
+	newStore.CommandWebhookStore = &AuditLayerCommandWebhookStore{CommandWebhookStore: childStore.CommandWebhook(), Root: &newStore}
+	newStore.ComplianceStore = &AuditLayerComplianceStore{ComplianceStore: childStore.Compliance(), Root: &newStore}
+	newStore.EmojiStore = &AuditLayerEmojiStore{EmojiStore: childStore.Emoji(), Root: &newStore}
+	newStore.FileInfoStore = &AuditLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
+	newStore.GroupStore = &AuditLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
+	newStore.JobStore = &AuditLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
+	newStore.LicenseStore = &AuditLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
+	newStore.LinkMetadataStore = &AuditLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
+	newStore.OAuthStore = &AuditLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
+	newStore.PluginStore = &AuditLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
+	newStore.PluginConfigRevisionStore = &AuditLayerPluginConfigRevisionStore{PluginConfigRevisionStore: childStore.PluginConfigRevision(), Root: &newStore}
+	newStore.PostStore = &AuditLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
+	newStore.PostAcknowledgementStore = &AuditLayerPostAcknowledgementStore{PostAcknowledgementStore: childStore.PostAcknowledgement(), Root: &newStore}
+	newStore.PostPriorityStore = &AuditLayerPostPriorityStore{PostPriorityStore: childStore.PostPriority(), Root: &newStore}
+	newStore.PostShardStore = &AuditLayerPostShardStore{PostShardStore: childStore.PostShard(), Root: &newStore}
+	newStore.PreferenceStore = &AuditLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
+	newStore.ReactionStore = &AuditLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
+	newStore.RoleStore = &AuditLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
+	newStore.SchemeStore = &AuditLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
+	newStore.SessionStore = &AuditLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
+	newStore.StatusStore = &AuditLayerStatusStore{StatusStore: childStore.Status(), Root: &newStore}
+	newStore.SystemStore = &AuditLayerSystemStore{SystemStore: childStore.System(), Root: &newStore}
+	newStore.TeamStore = &AuditLayerTeamStore{TeamStore: childStore.Team(), Root: &newStore}
+	newStore.TermsOfServiceStore = &AuditLayerTermsOfServiceStore{TermsOfServiceStore: childStore.TermsOfService(), Root: &newStore}
+	newStore.TokenStore = &AuditLayerTokenStore{TokenStore: childStore.Token(), Root: &newStore}
+	newStore.UserStore = &AuditLayerUserStore{UserStore: childStore.User(), Root: &newStore}
+	newStore.UserAccessTokenStore = &AuditLayerUserAccessTokenStore{UserAccessTokenStore: childStore.UserAccessToken(), Root: &newStore}
+	newStore.UserTermsOfServiceStore = &AuditLayerUserTermsOfServiceStore{UserTermsOfServiceStore: childStore.UserTermsOfService(), Root: &newStore}
+	newStore.WebhookStore = &AuditLayerWebhookStore{WebhookStore: childStore.Webhook(), Root: &newStore}
+	return &newStore
+}
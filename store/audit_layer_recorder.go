@@ -0,0 +1,342 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package store
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const AUDIT_REDACTED = "[redacted]"
+
+// AuditActor is who the changes made through an AuditLayer are made on behalf of. Changes made
+// without an actor are made by the server itself.
+type AuditActor struct {
+	UserId    string
+	SessionId string
+	RequestId string
+	IpAddress string
+}
+
+// auditedEntity reads the entities of a store, for the audit log to record the fields changed by
+// each call making changes to one of them.
+type auditedEntity struct {
+	// modelType is the type of the params holding the entity itself.
+	modelType reflect.Type
+	// idParam is the name of the params holding the id of the entity, besides "id".
+	idParam string
+	// idField is the field holding the id of the entity in modelType.
+	idField string
+	get     func(s Store, id string) (interface{}, *model.AppError)
+}
+
+var auditedEntities = map[string]auditedEntity{
+	"Bot": {reflect.TypeOf(&model.Bot{}), "botUserId", "UserId", func(s Store, id string) (interface{}, *model.AppError) {
+		return s.Bot().Get(id, true)
+	}},
+	"Channel": {reflect.TypeOf(&model.Channel{}), "channelId", "Id", func(s Store, id string) (interface{}, *model.AppError) {
+		return s.Channel().Get(id, false)
+	}},
+	"Command": {reflect.TypeOf(&model.Command{}), "commandId", "Id", func(s Store, id string) (interface{}, *model.AppError) {
+		return s.Command().Get(id)
+	}},
+	"Compliance": {reflect.TypeOf(&model.Compliance{}), "complianceId", "Id", func(s Store, id string) (interface{}, *model.AppError) {
+		return s.Compliance().Get(id)
+	}},
+	"Emoji": {reflect.TypeOf(&model.Emoji{}), "emojiId", "Id", func(s Store, id string) (interface{}, *model.AppError) {
+		return s.Emoji().Get(id, false)
+	}},
+	"FileInfo": {reflect.TypeOf(&model.FileInfo{}), "fileId", "Id", func(s Store, id string) (interface{}, *model.AppError) {
+		return s.FileInfo().Get(id)
+	}},
+	"Group": {reflect.TypeOf(&model.Group{}), "groupID", "Id", func(s Store, id string) (interface{}, *model.AppError) {
+		return s.Group().Get(id)
+	}},
+	"OAuth": {reflect.TypeOf(&model.OAuthApp{}), "appId", "Id", func(s Store, id string) (interface{}, *model.AppError) {
+		return s.OAuth().GetApp(id)
+	}},
+	"Post": {reflect.TypeOf(&model.Post{}), "postId", "Id", func(s Store, id string) (interface{}, *model.AppError) {
+		return s.Post().GetSingle(id)
+	}},
+	"Role": {reflect.TypeOf(&model.Role{}), "roleId", "Id", func(s Store, id string) (interface{}, *model.AppError) {
+		return s.Role().Get(id)
+	}},
+	"Scheme": {reflect.TypeOf(&model.Scheme{}), "schemeId", "Id", func(s Store, id string) (interface{}, *model.AppError) {
+		return s.Scheme().Get(id)
+	}},
+	"Team": {reflect.TypeOf(&model.Team{}), "teamId", "Id", func(s Store, id string) (interface{}, *model.AppError) {
+		return s.Team().Get(id)
+	}},
+	"User": {reflect.TypeOf(&model.User{}), "userId", "Id", func(s Store, id string) (interface{}, *model.AppError) {
+		return s.User().Get(id)
+	}},
+	"UserAccessToken": {reflect.TypeOf(&model.UserAccessToken{}), "tokenId", "Id", func(s Store, id string) (interface{}, *model.AppError) {
+		return s.UserAccessToken().Get(id)
+	}},
+}
+
+// auditEntry is a change being made through an AuditLayer, recorded ahead in the audit log.
+type auditEntry struct {
+	method         string
+	actor          AuditActor
+	entity         *auditedEntity
+	entityId       string
+	entityIds      map[string]interface{}
+	before         interface{}
+	intentSequence int64
+}
+
+// auditEntityId returns the id of the entity changed by the call with the given params, if any.
+// Calls changing the members of an entity, or what it is linked to, leave the entity unchanged.
+func auditEntityId(entity *auditedEntity, methodName string, paramNames []string, params []interface{}) string {
+	if strings.Contains(methodName, "Member") || strings.Contains(methodName, "Syncable") {
+		return ""
+	}
+
+	for i, param := range params {
+		if id, ok := param.(string); ok && (paramNames[i] == "id" || paramNames[i] == entity.idParam) {
+			return id
+		}
+
+		value := reflect.ValueOf(param)
+		if value.Type() == entity.modelType && !value.IsNil() {
+			return value.Elem().FieldByName(entity.idField).String()
+		}
+	}
+
+	return ""
+}
+
+// auditEntityIds returns the ids held by the given values, keyed by the name of each value: the
+// string values named after ids, and the id of the model values.
+func auditEntityIds(names []string, values []interface{}) map[string]interface{} {
+	ids := make(map[string]interface{})
+	for i, value := range values {
+		name := names[i]
+		switch typed := value.(type) {
+		case string:
+			if typed != "" && (name == "id" || strings.HasSuffix(name, "Id") || strings.HasSuffix(name, "ID")) {
+				ids[name] = typed
+			}
+		case []string:
+			if len(typed) > 0 && (strings.HasSuffix(name, "Ids") || strings.HasSuffix(name, "IDs")) {
+				ids[name] = typed
+			}
+		default:
+			v := reflect.ValueOf(value)
+			if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+				continue
+			}
+			if id := v.Elem().FieldByName("Id"); id.IsValid() && id.Kind() == reflect.String && id.String() != "" {
+				ids[name] = id.String()
+			}
+		}
+	}
+
+	return ids
+}
+
+// isAuditRedacted returns whether the values of the given field or param are kept out of the log.
+func isAuditRedacted(name string) bool {
+	name = strings.ToLower(name)
+	return strings.Contains(name, "password") || strings.Contains(name, "secret") || strings.HasSuffix(name, "token")
+}
+
+// auditRedact replaces the values of the redacted fields found in the given JSON value.
+func auditRedact(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, field := range typed {
+			if isAuditRedacted(key) {
+				typed[key] = AUDIT_REDACTED
+			} else {
+				typed[key] = auditRedact(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = auditRedact(item)
+		}
+	}
+
+	return value
+}
+
+// auditJsonValue returns the given value as decoded from its JSON, or nil for nil values.
+func auditJsonValue(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	if v := reflect.ValueOf(value); (v.Kind() == reflect.Ptr || v.Kind() == reflect.Map || v.Kind() == reflect.Slice) && v.IsNil() {
+		return nil
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return nil
+	}
+	return decoded
+}
+
+func auditJson(value interface{}) string {
+	b, _ := json.Marshal(value)
+	return string(b)
+}
+
+// auditParams returns the JSON of the given params, keyed by name, with redacted values replaced.
+func auditParams(names []string, params []interface{}) string {
+	values := make(map[string]interface{}, len(params))
+	for i, param := range params {
+		if isAuditRedacted(names[i]) {
+			values[names[i]] = AUDIT_REDACTED
+		} else {
+			values[names[i]] = auditRedact(auditJsonValue(param))
+		}
+	}
+
+	return auditJson(values)
+}
+
+// auditDiff returns the JSON of the fields differing between the two states of an entity, each
+// keyed by name to its values before and after, or "" if there is neither state. Changes to
+// redacted fields are recorded, but without their values.
+func auditDiff(before interface{}, after interface{}) string {
+	beforeFields, beforeIsObject := auditJsonValue(before).(map[string]interface{})
+	afterFields, afterIsObject := auditJsonValue(after).(map[string]interface{})
+	if !beforeIsObject && !afterIsObject {
+		return ""
+	}
+
+	diff := make(map[string][]interface{})
+	for name, value := range afterFields {
+		if previous, ok := beforeFields[name]; !ok || !reflect.DeepEqual(previous, value) {
+			diff[name] = []interface{}{previous, value}
+		}
+	}
+	for name, value := range beforeFields {
+		if _, ok := afterFields[name]; !ok {
+			diff[name] = []interface{}{value, nil}
+		}
+	}
+
+	for name, values := range diff {
+		for i, value := range values {
+			if value != nil && isAuditRedacted(name) {
+				values[i] = AUDIT_REDACTED
+			} else {
+				values[i] = auditRedact(value)
+			}
+		}
+	}
+
+	return auditJson(diff)
+}
+
+// getEntity returns the entity with the given id as currently stored, or nil if missing.
+func (s *AuditLayer) getEntity(entity *auditedEntity, id string) interface{} {
+	value, err := entity.get(s.Store.MasterOnly(), id)
+	if err != nil {
+		return nil
+	}
+	return value
+}
+
+// begin records the given call ahead of making it. The call must be refused if it can't be
+// recorded.
+func (s *AuditLayer) begin(subStoreName string, methodName string, paramNames []string, params ...interface{}) (*auditEntry, *model.AppError) {
+	entry := &auditEntry{
+		method:    subStoreName + "Store." + methodName,
+		entityIds: auditEntityIds(paramNames, params),
+	}
+	if s.actor != nil {
+		entry.actor = s.actor()
+	}
+
+	if entity, ok := auditedEntities[subStoreName]; ok {
+		entry.entity = &entity
+		entry.entityId = auditEntityId(entry.entity, methodName, paramNames, params)
+		if entry.entityId != "" {
+			entry.before = s.getEntity(entry.entity, entry.entityId)
+		}
+	}
+
+	record, err := s.Store.AuditLog().Append(&model.AuditLogRecord{
+		Phase:     model.AUDIT_LOG_PHASE_INTENT,
+		UserId:    entry.actor.UserId,
+		SessionId: entry.actor.SessionId,
+		RequestId: entry.actor.RequestId,
+		IpAddress: entry.actor.IpAddress,
+		Method:    entry.method,
+		EntityIds: auditJson(entry.entityIds),
+		Params:    auditParams(paramNames, params),
+	}, s.key)
+	if err != nil {
+		mlog.Error("Unable to record a change to the audit log", mlog.String("method", entry.method), mlog.Err(err))
+		return nil, model.NewAppError("AuditLayer.begin", "store.audit_layer.append.app_error", nil, "method="+entry.method+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	entry.intentSequence = record.Sequence
+	return entry, nil
+}
+
+// end records the outcome of the call begun with the given entry. The call was made already, so a
+// failure to record its outcome is only logged.
+func (s *AuditLayer) end(entry *auditEntry, appErr *model.AppError, results ...interface{}) {
+	if entry == nil {
+		return
+	}
+
+	record := &model.AuditLogRecord{
+		Phase:          model.AUDIT_LOG_PHASE_OUTCOME,
+		IntentSequence: entry.intentSequence,
+		UserId:         entry.actor.UserId,
+		SessionId:      entry.actor.SessionId,
+		RequestId:      entry.actor.RequestId,
+		IpAddress:      entry.actor.IpAddress,
+		Method:         entry.method,
+		Status:         model.AUDIT_LOG_STATUS_SUCCESS,
+	}
+
+	// The entity created by the call is its first result holding an id.
+	var created interface{}
+	for _, result := range results {
+		if _, isError := result.(*model.AppError); isError {
+			continue
+		}
+		if ids := auditEntityIds([]string{"result"}, []interface{}{result}); len(ids) > 0 {
+			entry.entityIds["result"] = ids["result"]
+			created = result
+			break
+		}
+	}
+	record.EntityIds = auditJson(entry.entityIds)
+
+	if appErr != nil {
+		record.Status = model.AUDIT_LOG_STATUS_FAILURE
+		record.Error = appErr.Id
+	} else if entry.entityId != "" {
+		// Calls returning the entity they changed save reading it again.
+		after := created
+		if created == nil || auditEntityId(entry.entity, "", []string{"result"}, []interface{}{created}) != entry.entityId {
+			after = s.getEntity(entry.entity, entry.entityId)
+		}
+		record.Diff = auditDiff(entry.before, after)
+	} else {
+		record.Diff = auditDiff(nil, created)
+	}
+
+	if _, err := s.Store.AuditLog().Append(record, s.key); err != nil {
+		mlog.Error("Unable to record the outcome of a change to the audit log", mlog.String("method", entry.method), mlog.Int64("intent_sequence", entry.intentSequence), mlog.Err(err))
+	}
+}
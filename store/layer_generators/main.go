@@ -18,14 +18,17 @@ import (
 )
 
 func main() {
-	code := GenerateTimerLayer()
+	writeLayer("timer_layer.go", GenerateTimerLayer())
+	writeLayer("audit_layer.go", GenerateAuditLayer())
+}
 
+func writeLayer(fileName string, code string) {
 	formatedCode, err := format.Source([]byte(code))
 	if err != nil {
 		panic(err)
 	}
 
-	err = ioutil.WriteFile(path.Join("timer_layer.go"), formatedCode, 0644)
+	err = ioutil.WriteFile(path.Join(fileName), formatedCode, 0644)
 	if err != nil {
 		panic(err)
 	}
@@ -79,6 +82,7 @@ func ExtractStoreMetadata() StoreMetadata {
 		"TotalReadDbConnections":   true,
 		"TotalSearchDbConnections": true,
 		"GetCurrentSchemaVersion":  true,
+		"ReplicaStatuses":          true,
 	}
 
 	metadata := StoreMetadata{Methods: map[string]Method{}, SubStores: map[string]SubStore{}}
@@ -152,12 +156,8 @@ func ExtractStoreMetadata() StoreMetadata {
 	return metadata
 }
 
-func GenerateTimerLayer() string {
-	out := bytes.NewBufferString("")
-	metadata := ExtractStoreMetadata()
-	metadata.Name = "TimerLayer"
-
-	myFuncs := template.FuncMap{
+func layerFuncs() template.FuncMap {
+	return template.FuncMap{
 		"joinResults": func(results []string) string {
 			return strings.Join(results, ", ")
 		},
@@ -177,6 +177,14 @@ func GenerateTimerLayer() string {
 			}
 			return strings.Join(vars, ", ")
 		},
+		"errorResult": func(results []string) string {
+			for idx, typeName := range results {
+				if typeName == "*model.AppError" {
+					return fmt.Sprintf("resultVar%d", idx)
+				}
+			}
+			return "nil"
+		},
 		"errorToBoolean": func(results []string) string {
 			for idx, typeName := range results {
 				if typeName == "*model.AppError" {
@@ -200,6 +208,14 @@ func GenerateTimerLayer() string {
 			return strings.Join(paramsWithType, ", ")
 		},
 	}
+}
+
+func GenerateTimerLayer() string {
+	out := bytes.NewBufferString("")
+	metadata := ExtractStoreMetadata()
+	metadata.Name = "TimerLayer"
+
+	myFuncs := layerFuncs()
 
 	t, err := template.New("timer-layer").Funcs(myFuncs).Parse(`
 // Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
@@ -215,6 +231,7 @@ import (
 
     "github.com/mattermost/mattermost-server/einterfaces"
 	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/services/tracing"
 )
 
 type {{.Name}} struct {
@@ -222,6 +239,12 @@ type {{.Name}} struct {
 	Metrics einterfaces.MetricsInterface
 {{range $index, $element := .SubStores}}	{{$index}}Store {{$index}}Store
 {{end}}
+	masterOnly *{{.Name}}
+	span       *tracing.Span
+
+	// queryTimeouts holds, for the store methods whose query timeout is overridden, the view of
+	// the wrapped store applying it. See SetQueryTimeouts.
+	queryTimeouts map[string]Store
 }
 
 {{range $index, $element := .SubStores}}func (s *{{$.Name}}) {{$index}}() {{$index}}Store {
@@ -240,11 +263,16 @@ type {{.Name}} struct {
 {{range $substoreName, $substore := .SubStores}}
 {{range $index, $element := $substore.Methods}}
 func (s *{{$.Name}}{{$substoreName}}Store) {{$index}}({{$element.Params | joinParamsWithType}}) {{$element.Results | joinResultsForSignature}} {
+	childStore := s.{{$substoreName}}Store
+	if view, ok := s.Root.queryTimeouts["{{$substoreName}}Store.{{$index}}"]; ok {
+		childStore = view.{{$substoreName}}()
+	}
+	span := s.Root.span.StartChild("{{$substoreName}}Store.{{$index}}", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 	{{if $element.Results | len | eq 0}}
-	s.{{$substoreName}}Store.{{$index}}({{$element.Params | joinParams}})
+	childStore.{{$index}}({{$element.Params | joinParams}})
 	{{ else }}
-	{{$element.Results | genResultsVars}} := s.{{$substoreName}}Store.{{$index}}({{$element.Params | joinParams}})
+	{{$element.Results | genResultsVars}} := childStore.{{$index}}({{$element.Params | joinParams}})
 	{{ end }}
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -255,6 +283,7 @@ func (s *{{$.Name}}{{$substoreName}}Store) {{$index}}({{$element.Params | joinPa
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("{{$substoreName}}Store.{{$index}}", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, {{$element.Results | errorResult}})
 	return {{$element.Results | genResultsVars}}
 }
 {{end}}
@@ -267,7 +296,57 @@ func (s *{{$.Name}}) {{$index}}({{$element.Params | joinParamsWithType}}) {{$ele
 	{{ end}}}
 {{end}}
 
+func (s *{{.Name}}) MasterOnly() Store {
+	return s.masterOnly
+}
+
+// Traced returns a view of this store reporting each of its calls as a span nested in the given
+// one, or this store itself if the span is nil.
+func (s *{{.Name}}) Traced(span *tracing.Span) Store {
+	if span == nil {
+		return s
+	}
+
+	newStore := new{{.Name}}(s.Store, s.Metrics)
+	newStore.span = span
+	newStore.queryTimeouts = s.queryTimeouts
+	if s.masterOnly == s {
+		newStore.masterOnly = newStore
+	} else {
+		newStore.masterOnly = s.masterOnly.Traced(span).(*{{.Name}})
+	}
+	return newStore
+}
+
+// SetQueryTimeouts makes the given store methods, such as PostStore.Search, run their queries with
+// the timeouts given for them, in seconds, if the wrapped store is able to. It must be called
+// before the store is used.
+func (s *{{.Name}}) SetQueryTimeouts(timeouts map[string]int) {
+	s.queryTimeouts = queryTimeoutViews(s.Store, timeouts)
+	if s.masterOnly != s {
+		s.masterOnly.queryTimeouts = queryTimeoutViews(s.masterOnly.Store, timeouts)
+	}
+}
+
+func (s *{{.Name}}) finishSpan(span *tracing.Span, err *model.AppError) {
+	if span == nil {
+		return
+	}
+
+	if err != nil {
+		span.SetError(err.Id)
+	}
+	span.Finish()
+}
+
 func New{{.Name}}(childStore Store, metrics einterfaces.MetricsInterface) *{{.Name}} {
+	newStore := new{{.Name}}(childStore, metrics)
+	newStore.masterOnly = new{{.Name}}(childStore.MasterOnly(), metrics)
+	newStore.masterOnly.masterOnly = newStore.masterOnly
+	return newStore
+}
+
+func new{{.Name}}(childStore Store, metrics einterfaces.MetricsInterface) *{{.Name}} {
 	newStore := {{.Name}}{
 		Store: childStore,
 		Metrics: metrics,
@@ -286,3 +365,187 @@ func New{{.Name}}(childStore Store, metrics einterfaces.MetricsInterface) *{{.Na
 	}
 	return out.String()
 }
+
+// auditedMethodPrefixes are the prefixes of the names of the store methods making changes.
+var auditedMethodPrefixes = []string{
+	"Save", "Update", "Delete", "PermanentDelete", "Remove", "Overwrite", "Create", "Set",
+	"Increment", "Upsert", "Attach", "Archive", "Unarchive", "Restore", "Migrate", "Reset",
+	"ClearAll", "Cleanup", "CompareAnd", "Verify",
+}
+
+// unauditedStores and unauditedMethods make changes too frequent, or too internal to the server,
+// to be worth auditing. The audit log itself is never audited.
+var unauditedStores = map[string]bool{
+	"AuditLog":         true,
+	"ClusterDiscovery": true,
+	"Job":              true,
+	"LinkMetadata":     true,
+	"PostShard":        true,
+	"Status":           true,
+}
+
+var unauditedMethods = map[string]bool{
+	"Channel.IncrementMentionCount": true,
+	"Channel.UpdateLastViewedAt":    true,
+	"PostPriority.MarkNotified":     true,
+	"Session.UpdateLastActivityAt":  true,
+	"User.SetWorkingHoursEnd":       true,
+}
+
+func isAuditedMethod(subStoreName string, methodName string) bool {
+	if unauditedStores[subStoreName] || unauditedMethods[subStoreName+"."+methodName] {
+		return false
+	}
+
+	for _, prefix := range auditedMethodPrefixes {
+		if strings.HasPrefix(methodName, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func GenerateAuditLayer() string {
+	out := bytes.NewBufferString("")
+	metadata := ExtractStoreMetadata()
+	metadata.Name = "AuditLayer"
+
+	for subStoreName, subStore := range metadata.SubStores {
+		for methodName := range subStore.Methods {
+			if !isAuditedMethod(subStoreName, methodName) {
+				delete(subStore.Methods, methodName)
+			}
+		}
+	}
+
+	myFuncs := layerFuncs()
+	myFuncs["hasErrorResult"] = func(results []string) bool {
+		for _, typeName := range results {
+			if typeName == "*model.AppError" {
+				return true
+			}
+		}
+		return false
+	}
+
+	t, err := template.New("audit-layer").Funcs(myFuncs).Parse(`
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make store-layers"
+// DO NOT EDIT
+
+package store
+
+import (
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/services/tracing"
+)
+
+type {{.Name}} struct {
+	Store
+{{range $index, $element := .SubStores}}	{{$index}}Store {{$index}}Store
+{{end}}
+	actor      func() AuditActor
+	key        []byte
+	masterOnly *{{.Name}}
+}
+
+{{range $index, $element := .SubStores}}func (s *{{$.Name}}) {{$index}}() {{$index}}Store {
+	return s.{{$index}}Store
+}
+
+{{end}}
+
+{{range $index, $element := .SubStores}}type {{$.Name}}{{$index}}Store struct {
+	{{$index}}Store
+	Root *{{$.Name}}
+}
+
+{{end}}
+
+{{range $substoreName, $substore := .SubStores}}
+{{range $index, $element := $substore.Methods}}
+func (s *{{$.Name}}{{$substoreName}}Store) {{$index}}({{$element.Params | joinParamsWithType}}) {{$element.Results | joinResultsForSignature}} {
+	{{range $resultIndex, $result := $element.Results}}var resultVar{{$resultIndex}} {{$result}}
+	{{end}}
+	{{if $element.Results | hasErrorResult}}entry, auditErr := s.Root.begin("{{$substoreName}}", "{{$index}}", []string{ {{range $element.Params}}"{{.Name}}", {{end}} }{{range $element.Params}}, {{.Name}}{{end}})
+	if auditErr != nil {
+		{{$element.Results | errorResult}} = auditErr
+		return {{$element.Results | genResultsVars}}
+	}{{else}}// Without an error to return, the change is made even if it can't be audited.
+	entry, _ := s.Root.begin("{{$substoreName}}", "{{$index}}", []string{ {{range $element.Params}}"{{.Name}}", {{end}} }{{range $element.Params}}, {{.Name}}{{end}}){{end}}
+	{{if $element.Results | len | eq 0}}
+	s.{{$substoreName}}Store.{{$index}}({{$element.Params | joinParams}})
+	{{ else }}
+	{{$element.Results | genResultsVars}} = s.{{$substoreName}}Store.{{$index}}({{$element.Params | joinParams}})
+	{{ end }}
+	s.Root.end(entry, {{$element.Results | errorResult}}{{range $resultIndex, $result := $element.Results}}, resultVar{{$resultIndex}}{{end}})
+	{{if $element.Results | len | eq 0}}{{else}}return {{$element.Results | genResultsVars}}{{end}}
+}
+{{end}}
+{{end}}
+
+func (s *{{.Name}}) MasterOnly() Store {
+	return s.masterOnly
+}
+
+// Traced returns a view of this store reporting each of its calls as a span nested in the given
+// one, if the store it wraps can, or this store itself otherwise.
+func (s *{{.Name}}) Traced(span *tracing.Span) Store {
+	traced, ok := s.Store.(interface {
+		Traced(span *tracing.Span) Store
+	})
+	if !ok || span == nil {
+		return s
+	}
+
+	return s.view(traced.Traced(span), s.actor)
+}
+
+// Audited returns a view of this store recording the changes made through it on behalf of the
+// actor returned by the given function, which is called for each change.
+func (s *{{.Name}}) Audited(actor func() AuditActor) Store {
+	return s.view(s.Store, actor)
+}
+
+func (s *{{.Name}}) view(childStore Store, actor func() AuditActor) *{{.Name}} {
+	newStore := new{{.Name}}(childStore, actor, s.key)
+	if s.masterOnly == s {
+		newStore.masterOnly = newStore
+	} else {
+		newStore.masterOnly = new{{.Name}}(childStore.MasterOnly(), actor, s.key)
+		newStore.masterOnly.masterOnly = newStore.masterOnly
+	}
+	return newStore
+}
+
+// New{{.Name}} returns a store recording the changes made through it to the audit log, with the
+// hashes chaining the records keyed with the given key unless it is empty.
+func New{{.Name}}(childStore Store, key []byte) *{{.Name}} {
+	newStore := new{{.Name}}(childStore, nil, key)
+	newStore.masterOnly = new{{.Name}}(childStore.MasterOnly(), nil, key)
+	newStore.masterOnly.masterOnly = newStore.masterOnly
+	return newStore
+}
+
+func new{{.Name}}(childStore Store, actor func() AuditActor, key []byte) *{{.Name}} {
+	newStore := {{.Name}}{
+		Store: childStore,
+		actor: actor,
+		key:   key,
+	}
+	{{range $substoreName, $substore := .SubStores}}
+	newStore.{{$substoreName}}Store = &{{$.Name}}{{$substoreName}}Store{{"{"}}{{$substoreName}}Store: childStore.{{$substoreName}}(), Root: &newStore}{{end}}
+	return &newStore
+}
+`)
+	if err != nil {
+		panic(err)
+	}
+	err = t.Execute(out, metadata)
+	if err != nil {
+		panic(err)
+	}
+	return out.String()
+}
@@ -5,13 +5,10 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/mattermost/mattermost-server/einterfaces"
-	"github.com/mattermost/mattermost-server/mlog"
-)
-
-const (
-	ENABLE_EXPERIMENTAL_REDIS = false
+	"github.com/mattermost/mattermost-server/model"
 )
 
 type LayeredStoreDatabaseLayer interface {
@@ -23,8 +20,8 @@ type LayeredStore struct {
 	TmpContext      context.Context
 	DatabaseLayer   LayeredStoreDatabaseLayer
 	LocalCacheLayer *LocalCacheSupplier
-	RedisLayer      *RedisSupplier
 	LayerChainHead  LayeredStoreSupplier
+	masterOnly      *LayeredStore
 }
 
 func NewLayeredStore(db LayeredStoreDatabaseLayer, metrics einterfaces.MetricsInterface, cluster einterfaces.ClusterInterface) Store {
@@ -35,14 +32,19 @@ func NewLayeredStore(db LayeredStoreDatabaseLayer, metrics einterfaces.MetricsIn
 	}
 
 	// Setup the chain
-	if ENABLE_EXPERIMENTAL_REDIS {
-		mlog.Debug("Experimental redis enabled.")
-		store.RedisLayer = NewRedisSupplier()
-		store.RedisLayer.SetChainNext(store.DatabaseLayer)
-		store.LayerChainHead = store.RedisLayer
-	} else {
-		store.LocalCacheLayer.SetChainNext(store.DatabaseLayer)
-		store.LayerChainHead = store.LocalCacheLayer
+	store.LocalCacheLayer.SetChainNext(store.DatabaseLayer)
+	store.LayerChainHead = store.LocalCacheLayer
+
+	// The master only view shares the cache layer, only the database layer differs.
+	store.masterOnly = store
+	if masterOnly, ok := db.MasterOnly().(LayeredStoreDatabaseLayer); ok {
+		store.masterOnly = &LayeredStore{
+			TmpContext:      store.TmpContext,
+			DatabaseLayer:   masterOnly,
+			LocalCacheLayer: store.LocalCacheLayer,
+			LayerChainHead:  store.LayerChainHead,
+		}
+		store.masterOnly.masterOnly = store.masterOnly
 	}
 
 	return store
@@ -178,6 +180,26 @@ func (s *LayeredStore) LinkMetadata() LinkMetadataStore {
 	return s.DatabaseLayer.LinkMetadata()
 }
 
+func (s *LayeredStore) PostPriority() PostPriorityStore {
+	return s.DatabaseLayer.PostPriority()
+}
+
+func (s *LayeredStore) PostAcknowledgement() PostAcknowledgementStore {
+	return s.DatabaseLayer.PostAcknowledgement()
+}
+
+func (s *LayeredStore) PluginConfigRevision() PluginConfigRevisionStore {
+	return s.DatabaseLayer.PluginConfigRevision()
+}
+
+func (s *LayeredStore) PostShard() PostShardStore {
+	return s.DatabaseLayer.PostShard()
+}
+
+func (s *LayeredStore) AuditLog() AuditLogStore {
+	return s.DatabaseLayer.AuditLog()
+}
+
 func (s *LayeredStore) MarkSystemRanUnitTests() {
 	s.DatabaseLayer.MarkSystemRanUnitTests()
 }
@@ -211,6 +233,49 @@ func (s *LayeredStore) TotalSearchDbConnections() int {
 	return s.DatabaseLayer.TotalSearchDbConnections()
 }
 
+func (s *LayeredStore) ReplicaStatuses() []*model.ReplicaStatus {
+	return s.DatabaseLayer.ReplicaStatuses()
+}
+
+func (s *LayeredStore) MasterOnly() Store {
+	return s.masterOnly
+}
+
+// WithQueryTimeout returns a view of this store whose database layer runs its queries with the
+// given timeout. Like the master only view, it shares the cache layer.
+func (s *LayeredStore) WithQueryTimeout(timeout time.Duration) Store {
+	db, ok := WithQueryTimeout(s.DatabaseLayer, timeout).(LayeredStoreDatabaseLayer)
+	if !ok {
+		return s
+	}
+
+	view := &LayeredStore{
+		TmpContext:      s.TmpContext,
+		DatabaseLayer:   db,
+		LocalCacheLayer: s.LocalCacheLayer,
+		LayerChainHead:  s.LayerChainHead,
+	}
+	if s.masterOnly == s {
+		view.masterOnly = view
+	} else {
+		view.masterOnly = s.masterOnly.WithQueryTimeout(timeout).(*LayeredStore)
+		view.masterOnly.masterOnly = view.masterOnly
+	}
+	return view
+}
+
 func (s *LayeredStore) CheckIntegrity() <-chan IntegrityCheckResult {
 	return s.DatabaseLayer.CheckIntegrity()
 }
+
+func (s *LayeredStore) Backfills() ([]string, *model.AppError) {
+	return s.DatabaseLayer.Backfills()
+}
+
+func (s *LayeredStore) CountBackfillRemaining(name string) (int64, *model.AppError) {
+	return s.DatabaseLayer.CountBackfillRemaining(name)
+}
+
+func (s *LayeredStore) RunBackfillBatch(name string, afterKey []string, batchSize int) ([]string, int64, *model.AppError) {
+	return s.DatabaseLayer.RunBackfillBatch(name, afterKey, batchSize)
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package localcachelayer
+
+import (
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type LocalCacheChannelStore struct {
+	store.ChannelStore
+	rootStore *LocalCacheStore
+}
+
+func (s *LocalCacheChannelStore) handleClusterInvalidateChannelMemberCounts(msg *model.ClusterMessage) {
+	if msg.Data == CLEAR_CACHE_MESSAGE_DATA {
+		s.rootStore.channelMemberCountsCache.Purge()
+	} else {
+		s.rootStore.channelMemberCountsCache.Remove(msg.Data)
+	}
+}
+
+func (s *LocalCacheChannelStore) handleClusterInvalidateChannelById(msg *model.ClusterMessage) {
+	if msg.Data == CLEAR_CACHE_MESSAGE_DATA {
+		s.rootStore.channelByIdCache.Purge()
+	} else {
+		s.rootStore.channelByIdCache.Remove(msg.Data)
+	}
+}
+
+func (s *LocalCacheChannelStore) handleClusterInvalidateChannelByName(msg *model.ClusterMessage) {
+	if msg.Data == CLEAR_CACHE_MESSAGE_DATA {
+		s.rootStore.channelByNameCache.Purge()
+	} else {
+		s.rootStore.channelByNameCache.Remove(msg.Data)
+	}
+}
+
+func (s LocalCacheChannelStore) ClearCaches() {
+	// The caller is responsible for propagating a full purge to the rest of
+	// the cluster, so only the local caches are cleared here.
+	s.rootStore.channelMemberCountsCache.Purge()
+	s.rootStore.channelByIdCache.Purge()
+	s.rootStore.channelByNameCache.Purge()
+	s.ChannelStore.ClearCaches()
+
+	s.rootStore.doIncrementInvalidationCounter("Channel Member Counts - Purge")
+	s.rootStore.doIncrementInvalidationCounter("Channel - Purge")
+	s.rootStore.doIncrementInvalidationCounter("Channel By Name - Purge")
+}
+
+func (s LocalCacheChannelStore) InvalidateMemberCount(channelId string) {
+	s.rootStore.doInvalidateCacheCluster(s.rootStore.channelMemberCountsCache, channelId)
+	s.rootStore.doIncrementInvalidationCounter("Channel Member Counts - Remove by ChannelId")
+}
+
+func (s LocalCacheChannelStore) GetMemberCountFromCache(channelId string) int64 {
+	if count := s.rootStore.doStandardReadCache(s.rootStore.channelMemberCountsCache, channelId); count != nil {
+		return count.(int64)
+	}
+
+	count, err := s.GetMemberCount(channelId, true)
+	if err != nil {
+		return 0
+	}
+
+	return count
+}
+
+func (s LocalCacheChannelStore) GetMemberCount(channelId string, allowFromCache bool) (int64, *model.AppError) {
+	if allowFromCache {
+		if count := s.rootStore.doStandardReadCache(s.rootStore.channelMemberCountsCache, channelId); count != nil {
+			return count.(int64), nil
+		}
+	}
+
+	count, err := s.ChannelStore.GetMemberCount(channelId, allowFromCache)
+	if err != nil {
+		return 0, err
+	}
+
+	if allowFromCache {
+		s.rootStore.doStandardAddToCache(s.rootStore.channelMemberCountsCache, channelId, count)
+	}
+
+	return count, nil
+}
+
+func (s LocalCacheChannelStore) InvalidateChannel(channelId string) {
+	s.rootStore.doInvalidateCacheCluster(s.rootStore.channelByIdCache, channelId)
+	s.rootStore.doIncrementInvalidationCounter("Channel - Remove by ChannelId")
+}
+
+func (s LocalCacheChannelStore) InvalidateChannelByName(teamId, name string) {
+	s.rootStore.doInvalidateCacheCluster(s.rootStore.channelByNameCache, teamId+name)
+	s.rootStore.doIncrementInvalidationCounter("Channel by Name - Remove by TeamId and Name")
+}
+
+func (s LocalCacheChannelStore) Get(id string, allowFromCache bool) (*model.Channel, *model.AppError) {
+	if allowFromCache {
+		if channel := s.rootStore.doStandardReadCache(s.rootStore.channelByIdCache, id); channel != nil {
+			return channel.(*model.Channel).DeepCopy(), nil
+		}
+	}
+
+	channel, err := s.ChannelStore.Get(id, allowFromCache)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rootStore.doStandardAddToCache(s.rootStore.channelByIdCache, id, channel)
+
+	return channel, nil
+}
+
+func (s LocalCacheChannelStore) GetFromMaster(id string) (*model.Channel, *model.AppError) {
+	channel, err := s.ChannelStore.GetFromMaster(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rootStore.doStandardAddToCache(s.rootStore.channelByIdCache, id, channel)
+
+	return channel, nil
+}
+
+func (s LocalCacheChannelStore) GetByName(teamId string, name string, allowFromCache bool) (*model.Channel, *model.AppError) {
+	return s.getByName(teamId, name, false, allowFromCache)
+}
+
+func (s LocalCacheChannelStore) GetByNameIncludeDeleted(teamId string, name string, allowFromCache bool) (*model.Channel, *model.AppError) {
+	return s.getByName(teamId, name, true, allowFromCache)
+}
+
+func (s LocalCacheChannelStore) getByName(teamId string, name string, includeDeleted bool, allowFromCache bool) (*model.Channel, *model.AppError) {
+	if allowFromCache {
+		if channel := s.rootStore.doStandardReadCache(s.rootStore.channelByNameCache, teamId+name); channel != nil {
+			return channel.(*model.Channel), nil
+		}
+	}
+
+	var channel *model.Channel
+	var err *model.AppError
+	if includeDeleted {
+		channel, err = s.ChannelStore.GetByNameIncludeDeleted(teamId, name, allowFromCache)
+	} else {
+		channel, err = s.ChannelStore.GetByName(teamId, name, allowFromCache)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.rootStore.doStandardAddToCache(s.rootStore.channelByNameCache, teamId+name, channel)
+
+	return channel, nil
+}
+
+func (s LocalCacheChannelStore) GetByNames(teamId string, names []string, allowFromCache bool) ([]*model.Channel, *model.AppError) {
+	var channels []*model.Channel
+
+	if allowFromCache {
+		var misses []string
+		visited := make(map[string]struct{})
+		for _, name := range names {
+			if _, ok := visited[name]; ok {
+				continue
+			}
+			visited[name] = struct{}{}
+			if channel := s.rootStore.doStandardReadCache(s.rootStore.channelByNameCache, teamId+name); channel != nil {
+				channels = append(channels, channel.(*model.Channel))
+			} else {
+				misses = append(misses, name)
+			}
+		}
+		names = misses
+	}
+
+	if len(names) > 0 {
+		dbChannels, err := s.ChannelStore.GetByNames(teamId, names, allowFromCache)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, channel := range dbChannels {
+			s.rootStore.doStandardAddToCache(s.rootStore.channelByNameCache, teamId+channel.Name, channel)
+			channels = append(channels, channel)
+		}
+	}
+
+	return channels, nil
+}
+
+func (s LocalCacheChannelStore) Delete(channelId string, time int64) *model.AppError {
+	defer s.InvalidateChannel(channelId)
+	return s.ChannelStore.Delete(channelId, time)
+}
+
+func (s LocalCacheChannelStore) Restore(channelId string, time int64) *model.AppError {
+	defer s.InvalidateChannel(channelId)
+	return s.ChannelStore.Restore(channelId, time)
+}
+
+func (s LocalCacheChannelStore) SetDeleteAt(channelId string, deleteAt, updateAt int64) *model.AppError {
+	defer s.InvalidateChannel(channelId)
+	return s.ChannelStore.SetDeleteAt(channelId, deleteAt, updateAt)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package localcachelayer
+
+import (
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type LocalCacheEmojiStore struct {
+	store.EmojiStore
+	rootStore *LocalCacheStore
+}
+
+func (s *LocalCacheEmojiStore) handleClusterInvalidateEmojiById(msg *model.ClusterMessage) {
+	if msg.Data == CLEAR_CACHE_MESSAGE_DATA {
+		s.rootStore.emojiCacheById.Purge()
+	} else {
+		s.rootStore.emojiCacheById.Remove(msg.Data)
+	}
+}
+
+func (s *LocalCacheEmojiStore) handleClusterInvalidateEmojiIdByName(msg *model.ClusterMessage) {
+	if msg.Data == CLEAR_CACHE_MESSAGE_DATA {
+		s.rootStore.emojiIdCacheByName.Purge()
+	} else {
+		s.rootStore.emojiIdCacheByName.Remove(msg.Data)
+	}
+}
+
+func (s LocalCacheEmojiStore) Get(id string, allowFromCache bool) (*model.Emoji, *model.AppError) {
+	if allowFromCache {
+		if emoji := s.rootStore.doStandardReadCache(s.rootStore.emojiCacheById, id); emoji != nil {
+			return emoji.(*model.Emoji), nil
+		}
+	}
+
+	emoji, err := s.EmojiStore.Get(id, allowFromCache)
+	if err != nil {
+		return nil, err
+	}
+
+	if allowFromCache {
+		s.addToCache(emoji)
+	}
+
+	return emoji, nil
+}
+
+func (s LocalCacheEmojiStore) GetByName(name string, allowFromCache bool) (*model.Emoji, *model.AppError) {
+	if id, ok := model.GetSystemEmojiId(name); ok {
+		return s.Get(id, allowFromCache)
+	}
+
+	if allowFromCache {
+		if id := s.rootStore.doStandardReadCache(s.rootStore.emojiIdCacheByName, name); id != nil {
+			if emoji := s.rootStore.doStandardReadCache(s.rootStore.emojiCacheById, id.(string)); emoji != nil {
+				return emoji.(*model.Emoji), nil
+			}
+		}
+	}
+
+	emoji, err := s.EmojiStore.GetByName(name, allowFromCache)
+	if err != nil {
+		return nil, err
+	}
+
+	if allowFromCache {
+		s.addToCache(emoji)
+	}
+
+	return emoji, nil
+}
+
+func (s LocalCacheEmojiStore) Delete(emoji *model.Emoji, time int64) *model.AppError {
+	defer s.rootStore.doInvalidateCacheCluster(s.rootStore.emojiCacheById, emoji.Id)
+	defer s.rootStore.doInvalidateCacheCluster(s.rootStore.emojiIdCacheByName, emoji.Name)
+	return s.EmojiStore.Delete(emoji, time)
+}
+
+func (s LocalCacheEmojiStore) addToCache(emoji *model.Emoji) {
+	s.rootStore.doStandardAddToCache(s.rootStore.emojiCacheById, emoji.Id, emoji)
+	s.rootStore.doStandardAddToCache(s.rootStore.emojiIdCacheByName, emoji.Name, emoji.Id)
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package localcachelayer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/mattermost/mattermost-server/store/storetest"
+	"github.com/mattermost/mattermost-server/store/storetest/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmojiStore(t *testing.T) {
+	StoreTest(t, storetest.TestEmojiStore)
+}
+
+func TestEmojiStoreCaching(t *testing.T) {
+	StoreTest(t, testEmojiCaching)
+}
+
+func TestEmojiStoreCache(t *testing.T) {
+	fakeEmoji := model.Emoji{Id: "123", Name: "emoji-name"}
+
+	t.Run("first call by id not cached, second cached and returning same data", func(t *testing.T) {
+		mockStore := getMockStore()
+		cachedStore := NewLocalCacheLayer(mockStore, nil, nil)
+
+		emoji, err := cachedStore.Emoji().Get("123", true)
+		require.Nil(t, err)
+		assert.Equal(t, emoji, &fakeEmoji)
+		mockStore.Emoji().(*mocks.EmojiStore).AssertNumberOfCalls(t, "Get", 1)
+		emoji, err = cachedStore.Emoji().Get("123", true)
+		require.Nil(t, err)
+		assert.Equal(t, emoji, &fakeEmoji)
+		mockStore.Emoji().(*mocks.EmojiStore).AssertNumberOfCalls(t, "Get", 1)
+	})
+
+	t.Run("first call by name not cached, second cached and returning same data", func(t *testing.T) {
+		mockStore := getMockStore()
+		cachedStore := NewLocalCacheLayer(mockStore, nil, nil)
+
+		emoji, err := cachedStore.Emoji().GetByName("emoji-name", true)
+		require.Nil(t, err)
+		assert.Equal(t, emoji, &fakeEmoji)
+		mockStore.Emoji().(*mocks.EmojiStore).AssertNumberOfCalls(t, "GetByName", 1)
+		emoji, err = cachedStore.Emoji().GetByName("emoji-name", true)
+		require.Nil(t, err)
+		assert.Equal(t, emoji, &fakeEmoji)
+		mockStore.Emoji().(*mocks.EmojiStore).AssertNumberOfCalls(t, "GetByName", 1)
+		cachedStore.Emoji().Get("123", true)
+		mockStore.Emoji().(*mocks.EmojiStore).AssertNumberOfCalls(t, "Get", 0)
+	})
+
+	t.Run("first call not cached, second force no cached", func(t *testing.T) {
+		mockStore := getMockStore()
+		cachedStore := NewLocalCacheLayer(mockStore, nil, nil)
+
+		cachedStore.Emoji().Get("123", true)
+		mockStore.Emoji().(*mocks.EmojiStore).AssertNumberOfCalls(t, "Get", 1)
+		cachedStore.Emoji().Get("123", false)
+		mockStore.Emoji().(*mocks.EmojiStore).AssertNumberOfCalls(t, "Get", 2)
+	})
+
+	t.Run("first call not cached, delete, and then not cached again", func(t *testing.T) {
+		mockStore := getMockStore()
+		cachedStore := NewLocalCacheLayer(mockStore, nil, nil)
+
+		cachedStore.Emoji().GetByName("emoji-name", true)
+		mockStore.Emoji().(*mocks.EmojiStore).AssertNumberOfCalls(t, "GetByName", 1)
+		cachedStore.Emoji().Delete(&fakeEmoji, 0)
+		cachedStore.Emoji().GetByName("emoji-name", true)
+		mockStore.Emoji().(*mocks.EmojiStore).AssertNumberOfCalls(t, "GetByName", 2)
+		cachedStore.Emoji().Get("123", true)
+		mockStore.Emoji().(*mocks.EmojiStore).AssertNumberOfCalls(t, "Get", 0)
+	})
+}
+
+func testEmojiCaching(t *testing.T, ss store.Store) {
+	emojis := make([]*model.Emoji, 3)
+	for i := range emojis {
+		emojis[i] = &model.Emoji{
+			CreatorId: model.NewId(),
+			Name:      model.NewId(),
+		}
+	}
+
+	for _, emoji := range emojis {
+		_, err := ss.Emoji().Save(emoji)
+		require.Nil(t, err)
+	}
+	defer func() {
+		for _, emoji := range emojis {
+			err := ss.Emoji().Delete(emoji, time.Now().Unix())
+			require.Nil(t, err)
+		}
+	}()
+
+	var retrievedEmoji *model.Emoji
+	var cachedEmoji *model.Emoji
+	var err *model.AppError
+
+	for _, emoji := range emojis {
+		cachedEmoji, err = ss.Emoji().Get(emoji.Id, true)
+		assert.Nilf(t, err, "should be able to retrieve emoji with id %v", emoji.Id)
+
+		retrievedEmoji, err = ss.Emoji().Get(emoji.Id, false)
+		if assert.Nilf(t, err, "should be able to retrieve emoji with id %v", emoji.Id) {
+			assert.Falsef(t, retrievedEmoji == cachedEmoji, "should not be the same as cached with id %v", emoji.Id)
+		}
+
+		retrievedEmoji, err = ss.Emoji().Get(emoji.Id, true)
+		if assert.Nilf(t, err, "should be able to retrieve emoji with id %v", emoji.Id) {
+			assert.Truef(t, retrievedEmoji == cachedEmoji, "should be the cached emoji with id %v", emoji.Id)
+		}
+
+		retrievedEmoji, err = ss.Emoji().GetByName(emoji.Name, false)
+		if assert.Nilf(t, err, "should be able to retrieve emoji with name %v", emoji.Name) {
+			assert.Falsef(t, retrievedEmoji == cachedEmoji, "should not be the same as cached with name %v", emoji.Name)
+		}
+
+		retrievedEmoji, _ = ss.Emoji().GetByName(emoji.Name, true)
+		if assert.Nilf(t, err, "should be able to retrieve emoji with name %v", emoji.Name) {
+			assert.Truef(t, retrievedEmoji == cachedEmoji, "should be the cached emoji with name %v", emoji.Name)
+		}
+	}
+
+	_, err = ss.Emoji().Get(model.NewId(), false)
+	assert.NotNilf(t, err, "should not retrieve emoji with unsaved ID")
+	_, err = ss.Emoji().GetByName(model.NewId(), false)
+	assert.NotNilf(t, err, "should not retrieve emoji with unsaved name")
+}
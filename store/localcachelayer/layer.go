@@ -4,6 +4,8 @@
 package localcachelayer
 
 import (
+	"time"
+
 	"github.com/mattermost/mattermost-server/einterfaces"
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/store"
@@ -20,6 +22,27 @@ const (
 	SCHEME_CACHE_SIZE = 20000
 	SCHEME_CACHE_SEC  = 30 * 60
 
+	CHANNEL_MEMBERS_COUNTS_CACHE_SIZE = model.CHANNEL_CACHE_SIZE
+	CHANNEL_MEMBERS_COUNTS_CACHE_SEC  = 30 * 60
+
+	CHANNEL_CACHE_SIZE = model.CHANNEL_CACHE_SIZE
+	CHANNEL_CACHE_SEC  = 15 * 60
+
+	USER_PROFILE_BY_ID_CACHE_SIZE = model.SESSION_CACHE_SIZE
+	USER_PROFILE_BY_ID_CACHE_SEC  = 15 * 60
+
+	TEAM_CACHE_SIZE = model.SESSION_CACHE_SIZE
+	TEAM_CACHE_SEC  = 30 * 60
+
+	EMOJI_CACHE_SIZE = 5000
+	EMOJI_CACHE_SEC  = 30 * 60
+
+	LAST_POST_TIME_CACHE_SIZE = 25000
+	LAST_POST_TIME_CACHE_SEC  = 15 * 60
+
+	LAST_POSTS_CACHE_SIZE = 1000
+	LAST_POSTS_CACHE_SEC  = 15 * 60
+
 	CLEAR_CACHE_MESSAGE_DATA = ""
 )
 
@@ -33,29 +56,86 @@ type LocalCacheStore struct {
 	roleCache     *utils.Cache
 	scheme        LocalCacheSchemeStore
 	schemeCache   *utils.Cache
+
+	channel                  LocalCacheChannelStore
+	channelMemberCountsCache *utils.Cache
+	channelByIdCache         *utils.Cache
+	channelByNameCache       *utils.Cache
+
+	user                  LocalCacheUserStore
+	userProfileByIdsCache *utils.Cache
+
+	team                       LocalCacheTeamStore
+	teamAllTeamIdsForUserCache *utils.Cache
+
+	emoji              LocalCacheEmojiStore
+	emojiCacheById     *utils.Cache
+	emojiIdCacheByName *utils.Cache
+
+	post              LocalCachePostStore
+	lastPostTimeCache *utils.Cache
+	lastPostsCache    *utils.Cache
+
+	masterOnly *LocalCacheStore
 }
 
 func NewLocalCacheLayer(baseStore store.Store, metrics einterfaces.MetricsInterface, cluster einterfaces.ClusterInterface) LocalCacheStore {
 	localCacheStore := LocalCacheStore{
-		Store:   baseStore,
 		cluster: cluster,
 		metrics: metrics,
 	}
 	localCacheStore.reactionCache = utils.NewLruWithParams(REACTION_CACHE_SIZE, "Reaction", REACTION_CACHE_SEC, model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_REACTIONS)
-	localCacheStore.reaction = LocalCacheReactionStore{ReactionStore: baseStore.Reaction(), rootStore: &localCacheStore}
 	localCacheStore.roleCache = utils.NewLruWithParams(ROLE_CACHE_SIZE, "Role", ROLE_CACHE_SEC, model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_ROLES)
-	localCacheStore.role = LocalCacheRoleStore{RoleStore: baseStore.Role(), rootStore: &localCacheStore}
 	localCacheStore.schemeCache = utils.NewLruWithParams(SCHEME_CACHE_SIZE, "Scheme", SCHEME_CACHE_SEC, model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_SCHEMES)
-	localCacheStore.scheme = LocalCacheSchemeStore{SchemeStore: baseStore.Scheme(), rootStore: &localCacheStore}
+	localCacheStore.channelMemberCountsCache = utils.NewLruWithParams(CHANNEL_MEMBERS_COUNTS_CACHE_SIZE, "Channel Member Counts", CHANNEL_MEMBERS_COUNTS_CACHE_SEC, model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_CHANNEL_MEMBER_COUNTS)
+	localCacheStore.channelByIdCache = utils.NewLruWithParams(CHANNEL_CACHE_SIZE, "Channel", CHANNEL_CACHE_SEC, model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_CHANNEL)
+	localCacheStore.channelByNameCache = utils.NewLruWithParams(CHANNEL_CACHE_SIZE, "Channel By Name", CHANNEL_CACHE_SEC, model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_CHANNEL_BY_NAME)
+	localCacheStore.userProfileByIdsCache = utils.NewLruWithParams(USER_PROFILE_BY_ID_CACHE_SIZE, "Profile By Ids", USER_PROFILE_BY_ID_CACHE_SEC, model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_PROFILE_BY_IDS)
+	localCacheStore.teamAllTeamIdsForUserCache = utils.NewLruWithParams(TEAM_CACHE_SIZE, "All Team Ids for User", TEAM_CACHE_SEC, model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_TEAMS)
+	localCacheStore.emojiCacheById = utils.NewLruWithParams(EMOJI_CACHE_SIZE, "Emoji", EMOJI_CACHE_SEC, model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_EMOJIS_BY_ID)
+	localCacheStore.emojiIdCacheByName = utils.NewLruWithParams(EMOJI_CACHE_SIZE, "Emoji Id By Name", EMOJI_CACHE_SEC, model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_EMOJIS_ID_BY_NAME)
+	localCacheStore.lastPostTimeCache = utils.NewLruWithParams(LAST_POST_TIME_CACHE_SIZE, "Last Post Time", LAST_POST_TIME_CACHE_SEC, model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_LAST_POST_TIME)
+	localCacheStore.lastPostsCache = utils.NewLruWithParams(LAST_POSTS_CACHE_SIZE, "Last Posts Cache", LAST_POSTS_CACHE_SEC, model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_LAST_POSTS)
+	localCacheStore.initStores(baseStore)
 
 	if cluster != nil {
 		cluster.RegisterClusterMessageHandler(model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_REACTIONS, localCacheStore.reaction.handleClusterInvalidateReaction)
 		cluster.RegisterClusterMessageHandler(model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_ROLES, localCacheStore.role.handleClusterInvalidateRole)
 		cluster.RegisterClusterMessageHandler(model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_SCHEMES, localCacheStore.scheme.handleClusterInvalidateScheme)
+		cluster.RegisterClusterMessageHandler(model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_CHANNEL_MEMBER_COUNTS, localCacheStore.channel.handleClusterInvalidateChannelMemberCounts)
+		cluster.RegisterClusterMessageHandler(model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_CHANNEL, localCacheStore.channel.handleClusterInvalidateChannelById)
+		cluster.RegisterClusterMessageHandler(model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_CHANNEL_BY_NAME, localCacheStore.channel.handleClusterInvalidateChannelByName)
+		cluster.RegisterClusterMessageHandler(model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_PROFILE_BY_IDS, localCacheStore.user.handleClusterInvalidateProfileByIds)
+		cluster.RegisterClusterMessageHandler(model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_TEAMS, localCacheStore.team.handleClusterInvalidateTeam)
+		cluster.RegisterClusterMessageHandler(model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_EMOJIS_BY_ID, localCacheStore.emoji.handleClusterInvalidateEmojiById)
+		cluster.RegisterClusterMessageHandler(model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_EMOJIS_ID_BY_NAME, localCacheStore.emoji.handleClusterInvalidateEmojiIdByName)
+		cluster.RegisterClusterMessageHandler(model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_LAST_POST_TIME, localCacheStore.post.handleClusterInvalidateLastPostTime)
+		cluster.RegisterClusterMessageHandler(model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_LAST_POSTS, localCacheStore.post.handleClusterInvalidateLastPosts)
 	}
+
+	// The master only view shares the caches, so that writes made through either invalidate both.
+	masterOnly := localCacheStore
+	masterOnly.initStores(baseStore.MasterOnly())
+	masterOnly.masterOnly = &masterOnly
+	localCacheStore.masterOnly = &masterOnly
+
 	return localCacheStore
 }
 
+// initStores wraps the stores of the given base store, caching their results in the caches of
+// this store.
+func (s *LocalCacheStore) initStores(baseStore store.Store) {
+	s.Store = baseStore
+	s.reaction = LocalCacheReactionStore{ReactionStore: baseStore.Reaction(), rootStore: s}
+	s.role = LocalCacheRoleStore{RoleStore: baseStore.Role(), rootStore: s}
+	s.scheme = LocalCacheSchemeStore{SchemeStore: baseStore.Scheme(), rootStore: s}
+	s.channel = LocalCacheChannelStore{ChannelStore: baseStore.Channel(), rootStore: s}
+	s.user = LocalCacheUserStore{UserStore: baseStore.User(), rootStore: s}
+	s.team = LocalCacheTeamStore{TeamStore: baseStore.Team(), rootStore: s}
+	s.emoji = LocalCacheEmojiStore{EmojiStore: baseStore.Emoji(), rootStore: s}
+	s.post = LocalCachePostStore{PostStore: baseStore.Post(), rootStore: s}
+}
+
 func (s LocalCacheStore) Reaction() store.ReactionStore {
 	return s.reaction
 }
@@ -68,6 +148,44 @@ func (s LocalCacheStore) Scheme() store.SchemeStore {
 	return s.scheme
 }
 
+func (s LocalCacheStore) Channel() store.ChannelStore {
+	return s.channel
+}
+
+func (s LocalCacheStore) User() store.UserStore {
+	return s.user
+}
+
+func (s LocalCacheStore) Team() store.TeamStore {
+	return s.team
+}
+
+func (s LocalCacheStore) Emoji() store.EmojiStore {
+	return s.emoji
+}
+
+func (s LocalCacheStore) Post() store.PostStore {
+	return s.post
+}
+
+func (s LocalCacheStore) MasterOnly() store.Store {
+	return *s.masterOnly
+}
+
+// WithQueryTimeout returns a view of this store sharing its caches whose base store runs its
+// queries with the given timeout.
+func (s LocalCacheStore) WithQueryTimeout(timeout time.Duration) store.Store {
+	view := s
+	view.initStores(store.WithQueryTimeout(s.Store, timeout))
+
+	masterOnly := *s.masterOnly
+	masterOnly.initStores(store.WithQueryTimeout(s.masterOnly.Store, timeout))
+	masterOnly.masterOnly = &masterOnly
+	view.masterOnly = &masterOnly
+
+	return view
+}
+
 func (s LocalCacheStore) DropAllTables() {
 	s.Invalidate()
 	s.Store.DropAllTables()
@@ -116,6 +234,21 @@ func (s *LocalCacheStore) doClearCacheCluster(cache *utils.Cache) {
 	}
 }
 
+func (s *LocalCacheStore) doIncrementInvalidationCounter(name string) {
+	if s.metrics != nil {
+		s.metrics.IncrementMemCacheInvalidationCounter(name)
+	}
+}
+
 func (s *LocalCacheStore) Invalidate() {
 	s.doClearCacheCluster(s.reactionCache)
+	s.doClearCacheCluster(s.channelMemberCountsCache)
+	s.doClearCacheCluster(s.channelByIdCache)
+	s.doClearCacheCluster(s.channelByNameCache)
+	s.doClearCacheCluster(s.userProfileByIdsCache)
+	s.doClearCacheCluster(s.teamAllTeamIdsForUserCache)
+	s.doClearCacheCluster(s.emojiCacheById)
+	s.doClearCacheCluster(s.emojiIdCacheByName)
+	s.doClearCacheCluster(s.lastPostTimeCache)
+	s.doClearCacheCluster(s.lastPostsCache)
 }
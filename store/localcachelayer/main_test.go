@@ -4,9 +4,11 @@
 package localcachelayer
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
 	"github.com/mattermost/mattermost-server/store/storetest/mocks"
 	"github.com/mattermost/mattermost-server/testlib"
 )
@@ -41,6 +43,55 @@ func getMockStore() *mocks.Store {
 	mockSchemesStore.On("PermanentDeleteAll").Return(nil)
 	mockStore.On("Scheme").Return(&mockSchemesStore)
 
+	fakeChannel := model.Channel{Id: "123", TeamId: "team-id", Name: "channel-name"}
+	mockChannelsStore := mocks.ChannelStore{}
+	mockChannelsStore.On("Get", "123", true).Return(&fakeChannel, nil)
+	mockChannelsStore.On("Get", "123", false).Return(&fakeChannel, nil)
+	mockChannelsStore.On("GetByName", "team-id", "channel-name", true).Return(&fakeChannel, nil)
+	mockChannelsStore.On("GetByName", "team-id", "channel-name", false).Return(&fakeChannel, nil)
+	mockChannelsStore.On("GetMemberCount", "123", true).Return(int64(10), nil)
+	mockChannelsStore.On("GetMemberCount", "123", false).Return(int64(10), nil)
+	mockChannelsStore.On("Delete", "123", int64(0)).Return(nil)
+	mockChannelsStore.On("ClearCaches").Return()
+	mockStore.On("Channel").Return(&mockChannelsStore)
+
+	fakeUser := model.User{Id: "123", Username: "user-name"}
+	mockUsersStore := mocks.UserStore{}
+	mockUsersStore.On("GetProfileByIds", []string{"123"}, &store.UserGetByIdsOpts{}, false).Return([]*model.User{&fakeUser}, nil)
+	mockUsersStore.On("ClearCaches").Return()
+	mockStore.On("User").Return(&mockUsersStore)
+
+	fakeMember := model.TeamMember{TeamId: "team-id", UserId: "123"}
+	mockTeamsStore := mocks.TeamStore{}
+	mockTeamsStore.On("GetUserTeamIds", "123", true).Return([]string{"team-id"}, nil)
+	mockTeamsStore.On("GetUserTeamIds", "123", false).Return([]string{"team-id"}, nil)
+	mockTeamsStore.On("SaveMember", &fakeMember, -1).Return(&fakeMember, nil)
+	mockTeamsStore.On("ClearCaches").Return()
+	mockStore.On("Team").Return(&mockTeamsStore)
+
+	fakeEmoji := model.Emoji{Id: "123", Name: "emoji-name"}
+	mockEmojisStore := mocks.EmojiStore{}
+	mockEmojisStore.On("Get", "123", true).Return(&fakeEmoji, nil)
+	mockEmojisStore.On("Get", "123", false).Return(&fakeEmoji, nil)
+	mockEmojisStore.On("GetByName", "emoji-name", true).Return(&fakeEmoji, nil)
+	mockEmojisStore.On("GetByName", "emoji-name", false).Return(&fakeEmoji, nil)
+	mockEmojisStore.On("Delete", &fakeEmoji, int64(0)).Return(nil)
+	mockStore.On("Emoji").Return(&mockEmojisStore)
+
+	fakePosts := model.NewPostList()
+	fakePosts.AddPost(&model.Post{Id: "post-id", ChannelId: "123", UpdateAt: 100})
+	fakePosts.AddOrder("post-id")
+	mockPostsStore := mocks.PostStore{}
+	mockPostsStore.On("GetEtag", "123", true).Return(fmt.Sprintf("%v.%v", model.CurrentVersion, 100))
+	mockPostsStore.On("GetEtag", "123", false).Return(fmt.Sprintf("%v.%v", model.CurrentVersion, 100))
+	mockPostsStore.On("GetPostsSince", "123", int64(50), true).Return(fakePosts, nil)
+	mockPostsStore.On("GetPostsSince", "123", int64(100), true).Return(model.NewPostList(), nil)
+	mockPostsStore.On("GetPosts", "123", 0, 30, true).Return(fakePosts, nil)
+	mockPostsStore.On("GetPosts", "123", 0, 30, false).Return(fakePosts, nil)
+	mockPostsStore.On("ClearCaches").Return()
+	mockStore.On("Post").Return(&mockPostsStore)
+
+	mockStore.On("MasterOnly").Return(&mockStore)
 	return &mockStore
 }
 
@@ -0,0 +1,122 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package localcachelayer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type LocalCachePostStore struct {
+	store.PostStore
+	rootStore *LocalCacheStore
+}
+
+func (s *LocalCachePostStore) handleClusterInvalidateLastPostTime(msg *model.ClusterMessage) {
+	if msg.Data == CLEAR_CACHE_MESSAGE_DATA {
+		s.rootStore.lastPostTimeCache.Purge()
+	} else {
+		s.rootStore.lastPostTimeCache.Remove(msg.Data)
+	}
+}
+
+func (s *LocalCachePostStore) handleClusterInvalidateLastPosts(msg *model.ClusterMessage) {
+	if msg.Data == CLEAR_CACHE_MESSAGE_DATA {
+		s.rootStore.lastPostsCache.Purge()
+	} else {
+		s.rootStore.lastPostsCache.Remove(msg.Data)
+	}
+}
+
+func (s LocalCachePostStore) ClearCaches() {
+	// The caller is responsible for propagating a full purge to the rest of
+	// the cluster, so only the local caches are cleared here.
+	s.rootStore.lastPostTimeCache.Purge()
+	s.rootStore.lastPostsCache.Purge()
+	s.PostStore.ClearCaches()
+
+	s.rootStore.doIncrementInvalidationCounter("Last Post Time - Purge")
+	s.rootStore.doIncrementInvalidationCounter("Last Posts Cache - Purge")
+}
+
+func (s LocalCachePostStore) InvalidateLastPostTimeCache(channelId string) {
+	s.rootStore.doInvalidateCacheCluster(s.rootStore.lastPostTimeCache, channelId)
+
+	// Keys are "{channelid}{limit}" and caching only occurs on limits of 30 and 60
+	s.rootStore.doInvalidateCacheCluster(s.rootStore.lastPostsCache, channelId+"30")
+	s.rootStore.doInvalidateCacheCluster(s.rootStore.lastPostsCache, channelId+"60")
+
+	s.rootStore.doIncrementInvalidationCounter("Last Post Time - Remove by Channel Id")
+	s.rootStore.doIncrementInvalidationCounter("Last Posts Cache - Remove by Channel Id")
+}
+
+func (s LocalCachePostStore) GetEtag(channelId string, allowFromCache bool) string {
+	if allowFromCache {
+		if lastTime := s.rootStore.doStandardReadCache(s.rootStore.lastPostTimeCache, channelId); lastTime != nil {
+			return fmt.Sprintf("%v.%v", model.CurrentVersion, lastTime.(int64))
+		}
+	}
+
+	result := s.PostStore.GetEtag(channelId, allowFromCache)
+
+	// The etag is "{version}.{last post time}", and the version itself contains dots.
+	splittedResult := strings.Split(result, ".")
+	if lastTime, err := strconv.ParseInt(splittedResult[len(splittedResult)-1], 10, 64); err == nil {
+		s.rootStore.doStandardAddToCache(s.rootStore.lastPostTimeCache, channelId, lastTime)
+	}
+
+	return result
+}
+
+func (s LocalCachePostStore) GetPostsSince(channelId string, time int64, allowFromCache bool) (*model.PostList, *model.AppError) {
+	if allowFromCache {
+		// If the last post in the channel's time is less than or equal to the time we are getting posts since,
+		// we can safely return no posts.
+		if lastTime := s.rootStore.doStandardReadCache(s.rootStore.lastPostTimeCache, channelId); lastTime != nil && lastTime.(int64) <= time {
+			return model.NewPostList(), nil
+		}
+	}
+
+	list, err := s.PostStore.GetPostsSince(channelId, time, allowFromCache)
+	if err != nil {
+		return nil, err
+	}
+
+	latestUpdate := time
+	for _, p := range list.Posts {
+		if latestUpdate < p.UpdateAt {
+			latestUpdate = p.UpdateAt
+		}
+	}
+	s.rootStore.doStandardAddToCache(s.rootStore.lastPostTimeCache, channelId, latestUpdate)
+
+	return list, nil
+}
+
+func (s LocalCachePostStore) GetPosts(channelId string, offset int, limit int, allowFromCache bool) (*model.PostList, *model.AppError) {
+	// Caching only occurs on limits of 30 and 60, the common limits requested by MM clients
+	cacheable := offset == 0 && (limit == 60 || limit == 30)
+	key := fmt.Sprintf("%s%v", channelId, limit)
+
+	if allowFromCache && cacheable {
+		if list := s.rootStore.doStandardReadCache(s.rootStore.lastPostsCache, key); list != nil {
+			return list.(*model.PostList), nil
+		}
+	}
+
+	list, err := s.PostStore.GetPosts(channelId, offset, limit, allowFromCache)
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheable {
+		s.rootStore.doStandardAddToCache(s.rootStore.lastPostsCache, key, list)
+	}
+
+	return list, nil
+}
@@ -67,3 +67,37 @@ func TestRoleStoreCache(t *testing.T) {
 		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 2)
 	})
 }
+
+func TestRoleStoreMasterOnlyCache(t *testing.T) {
+	fakeRole := model.Role{Id: "123", Name: "role-name"}
+
+	t.Run("master only view reads from the same cache", func(t *testing.T) {
+		mockStore := getMockStore()
+		cachedStore := NewLocalCacheLayer(mockStore, nil, nil)
+
+		cachedStore.Role().GetByName("role-name")
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 1)
+		role, err := cachedStore.MasterOnly().Role().GetByName("role-name")
+		require.Nil(t, err)
+		assert.Equal(t, &fakeRole, role)
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 1)
+	})
+
+	t.Run("writes through the master only view invalidate the cache", func(t *testing.T) {
+		mockStore := getMockStore()
+		cachedStore := NewLocalCacheLayer(mockStore, nil, nil)
+
+		cachedStore.Role().GetByName("role-name")
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 1)
+		cachedStore.MasterOnly().Role().Delete("123")
+		cachedStore.Role().GetByName("role-name")
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 2)
+	})
+
+	t.Run("master only view of the view is itself", func(t *testing.T) {
+		cachedStore := NewLocalCacheLayer(getMockStore(), nil, nil)
+
+		masterOnly := cachedStore.MasterOnly()
+		assert.Equal(t, masterOnly, masterOnly.MasterOnly())
+	})
+}
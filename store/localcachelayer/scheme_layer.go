@@ -47,7 +47,18 @@ func (s LocalCacheSchemeStore) Delete(schemeId string) (*model.Scheme, *model.Ap
 	defer s.rootStore.doInvalidateCacheCluster(s.rootStore.schemeCache, schemeId)
 	defer s.rootStore.doClearCacheCluster(s.rootStore.roleCache)
 
-	return s.SchemeStore.Delete(schemeId)
+	scheme, err := s.SchemeStore.Delete(schemeId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Channels using a deleted channel scheme are reset to the default one.
+	if scheme.Scope == model.SCHEME_SCOPE_CHANNEL {
+		s.rootStore.doClearCacheCluster(s.rootStore.channelByIdCache)
+		s.rootStore.doClearCacheCluster(s.rootStore.channelByNameCache)
+	}
+
+	return scheme, nil
 }
 
 func (s LocalCacheSchemeStore) PermanentDeleteAll() *model.AppError {
@@ -0,0 +1,75 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package localcachelayer
+
+import (
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type LocalCacheTeamStore struct {
+	store.TeamStore
+	rootStore *LocalCacheStore
+}
+
+func (s *LocalCacheTeamStore) handleClusterInvalidateTeam(msg *model.ClusterMessage) {
+	if msg.Data == CLEAR_CACHE_MESSAGE_DATA {
+		s.rootStore.teamAllTeamIdsForUserCache.Purge()
+	} else {
+		s.rootStore.teamAllTeamIdsForUserCache.Remove(msg.Data)
+	}
+}
+
+func (s LocalCacheTeamStore) ClearCaches() {
+	// The caller is responsible for propagating a full purge to the rest of
+	// the cluster, so only the local cache is cleared here.
+	s.rootStore.teamAllTeamIdsForUserCache.Purge()
+	s.TeamStore.ClearCaches()
+
+	s.rootStore.doIncrementInvalidationCounter("All Team Ids for User - Purge")
+}
+
+func (s LocalCacheTeamStore) InvalidateAllTeamIdsForUser(userId string) {
+	s.rootStore.doInvalidateCacheCluster(s.rootStore.teamAllTeamIdsForUserCache, userId)
+	s.rootStore.doIncrementInvalidationCounter("All Team Ids for User - Remove by UserId")
+}
+
+func (s LocalCacheTeamStore) GetUserTeamIds(userId string, allowFromCache bool) ([]string, *model.AppError) {
+	if allowFromCache {
+		if teamIds := s.rootStore.doStandardReadCache(s.rootStore.teamAllTeamIdsForUserCache, userId); teamIds != nil {
+			return teamIds.([]string), nil
+		}
+	}
+
+	teamIds, err := s.TeamStore.GetUserTeamIds(userId, allowFromCache)
+	if err != nil {
+		return teamIds, err
+	}
+
+	if allowFromCache {
+		s.rootStore.doStandardAddToCache(s.rootStore.teamAllTeamIdsForUserCache, userId, teamIds)
+	}
+
+	return teamIds, nil
+}
+
+func (s LocalCacheTeamStore) Update(team *model.Team) (*model.Team, *model.AppError) {
+	updatedTeam, err := s.TeamStore.Update(team)
+	if err != nil {
+		return nil, err
+	}
+
+	// A deleted team drops out of every member's team ids, and we have no
+	// cheap way of finding those members, so clear the whole cache.
+	if updatedTeam.DeleteAt != 0 {
+		s.rootStore.doClearCacheCluster(s.rootStore.teamAllTeamIdsForUserCache)
+	}
+
+	return updatedTeam, nil
+}
+
+func (s LocalCacheTeamStore) SaveMember(member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, *model.AppError) {
+	defer s.InvalidateAllTeamIdsForUser(member.UserId)
+	return s.TeamStore.SaveMember(member, maxUsersPerTeam)
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package localcachelayer
+
+import (
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type LocalCacheUserStore struct {
+	store.UserStore
+	rootStore *LocalCacheStore
+}
+
+func (s *LocalCacheUserStore) handleClusterInvalidateProfileByIds(msg *model.ClusterMessage) {
+	if msg.Data == CLEAR_CACHE_MESSAGE_DATA {
+		s.rootStore.userProfileByIdsCache.Purge()
+	} else {
+		s.rootStore.userProfileByIdsCache.Remove(msg.Data)
+	}
+}
+
+func (s LocalCacheUserStore) ClearCaches() {
+	// The caller is responsible for propagating a full purge to the rest of
+	// the cluster, so only the local cache is cleared here.
+	s.rootStore.userProfileByIdsCache.Purge()
+	s.UserStore.ClearCaches()
+
+	s.rootStore.doIncrementInvalidationCounter("Profile By Ids - Purge")
+}
+
+func (s LocalCacheUserStore) InvalidatProfileCacheForUser(userId string) {
+	s.rootStore.doInvalidateCacheCluster(s.rootStore.userProfileByIdsCache, userId)
+	s.rootStore.doIncrementInvalidationCounter("Profile By Ids - Remove")
+}
+
+func (s LocalCacheUserStore) GetProfileByIds(userIds []string, options *store.UserGetByIdsOpts, allowFromCache bool) ([]*model.User, *model.AppError) {
+	if options == nil {
+		options = &store.UserGetByIdsOpts{}
+	}
+
+	users := []*model.User{}
+	remainingUserIds := make([]string, 0)
+
+	if allowFromCache {
+		for _, userId := range userIds {
+			if cacheItem := s.rootStore.doStandardReadCache(s.rootStore.userProfileByIdsCache, userId); cacheItem != nil {
+				u := &model.User{}
+				*u = *cacheItem.(*model.User)
+
+				if options.Since == 0 || u.UpdateAt > options.Since {
+					users = append(users, u)
+				}
+			} else {
+				remainingUserIds = append(remainingUserIds, userId)
+			}
+		}
+	} else {
+		remainingUserIds = userIds
+	}
+
+	// If everything came from the cache then just return
+	if len(remainingUserIds) == 0 {
+		return users, nil
+	}
+
+	remainingUsers, err := s.UserStore.GetProfileByIds(remainingUserIds, options, false)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, u := range remainingUsers {
+		cpy := &model.User{}
+		*cpy = *u
+		s.rootStore.doStandardAddToCache(s.rootStore.userProfileByIdsCache, cpy.Id, cpy)
+		users = append(users, u)
+	}
+
+	return users, nil
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package store
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/mlog"
+)
+
+// QueryTimeoutViewer is implemented by the stores able to run the queries of a view of themselves
+// with another timeout than the configured one.
+type QueryTimeoutViewer interface {
+	WithQueryTimeout(timeout time.Duration) Store
+}
+
+// WithQueryTimeout returns a view of the store running its queries with the given timeout, or the
+// store itself if it is unable to.
+func WithQueryTimeout(s Store, timeout time.Duration) Store {
+	if viewer, ok := s.(QueryTimeoutViewer); ok {
+		return viewer.WithQueryTimeout(timeout)
+	}
+
+	return s
+}
+
+// queryTimeoutViews returns, for each store method given, such as PostStore.Search, the view of
+// the store running its queries with the timeout given for it, in seconds.
+func queryTimeoutViews(s Store, timeouts map[string]int) map[string]Store {
+	if len(timeouts) == 0 {
+		return nil
+	}
+
+	methods := storeMethodNames()
+	views := make(map[time.Duration]Store)
+	result := make(map[string]Store, len(timeouts))
+	for method, seconds := range timeouts {
+		if !methods[method] {
+			mlog.Warn("Ignoring the query timeout of an unknown store method", mlog.String("method", method))
+			continue
+		}
+
+		timeout := time.Duration(seconds) * time.Second
+		if _, ok := views[timeout]; !ok {
+			views[timeout] = WithQueryTimeout(s, timeout)
+		}
+		result[method] = views[timeout]
+	}
+
+	return result
+}
+
+// storeMethodNames returns the names of all the methods of the stores, such as PostStore.Search.
+func storeMethodNames() map[string]bool {
+	names := make(map[string]bool)
+
+	storeType := reflect.TypeOf((*Store)(nil)).Elem()
+	for i := 0; i < storeType.NumMethod(); i++ {
+		method := storeType.Method(i).Type
+		if method.NumIn() != 0 || method.NumOut() != 1 || method.Out(0).Kind() != reflect.Interface {
+			continue
+		}
+
+		subStore := method.Out(0)
+		if subStore == storeType || subStore.PkgPath() != storeType.PkgPath() || !strings.HasSuffix(subStore.Name(), "Store") {
+			continue
+		}
+		for j := 0; j < subStore.NumMethod(); j++ {
+			names[subStore.Name()+"."+subStore.Method(j).Name] = true
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package rediscachelayer
+
+import (
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type RedisCacheChannelStore struct {
+	store.ChannelStore
+	rootStore *RedisCacheStore
+}
+
+func (s RedisCacheChannelStore) ClearCaches() {
+	s.rootStore.doClearCache(s.rootStore.channelMemberCountsCache)
+	s.rootStore.doClearCache(s.rootStore.channelByIdCache)
+	s.rootStore.doClearCache(s.rootStore.channelByNameCache)
+	s.ChannelStore.ClearCaches()
+}
+
+func (s RedisCacheChannelStore) InvalidateMemberCount(channelId string) {
+	s.rootStore.doInvalidateCache(s.rootStore.channelMemberCountsCache, channelId)
+}
+
+func (s RedisCacheChannelStore) GetMemberCountFromCache(channelId string) int64 {
+	count, err := s.GetMemberCount(channelId, true)
+	if err != nil {
+		return 0
+	}
+
+	return count
+}
+
+func (s RedisCacheChannelStore) GetMemberCount(channelId string, allowFromCache bool) (int64, *model.AppError) {
+	if allowFromCache {
+		var count int64
+		if s.rootStore.doStandardReadCache(s.rootStore.channelMemberCountsCache, channelId, &count) {
+			return count, nil
+		}
+	}
+
+	count, err := s.ChannelStore.GetMemberCount(channelId, allowFromCache)
+	if err != nil {
+		return 0, err
+	}
+
+	if allowFromCache {
+		s.rootStore.doStandardAddToCache(s.rootStore.channelMemberCountsCache, channelId, count)
+	}
+
+	return count, nil
+}
+
+func (s RedisCacheChannelStore) InvalidateChannel(channelId string) {
+	s.rootStore.doInvalidateCache(s.rootStore.channelByIdCache, channelId)
+}
+
+func (s RedisCacheChannelStore) InvalidateChannelByName(teamId, name string) {
+	s.rootStore.doInvalidateCache(s.rootStore.channelByNameCache, teamId+name)
+}
+
+func (s RedisCacheChannelStore) Get(id string, allowFromCache bool) (*model.Channel, *model.AppError) {
+	if allowFromCache {
+		var channel *model.Channel
+		if s.rootStore.doStandardReadCache(s.rootStore.channelByIdCache, id, &channel) {
+			return channel, nil
+		}
+	}
+
+	channel, err := s.ChannelStore.Get(id, allowFromCache)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rootStore.doStandardAddToCache(s.rootStore.channelByIdCache, id, channel)
+
+	return channel, nil
+}
+
+func (s RedisCacheChannelStore) GetFromMaster(id string) (*model.Channel, *model.AppError) {
+	channel, err := s.ChannelStore.GetFromMaster(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rootStore.doStandardAddToCache(s.rootStore.channelByIdCache, id, channel)
+
+	return channel, nil
+}
+
+func (s RedisCacheChannelStore) GetByName(teamId string, name string, allowFromCache bool) (*model.Channel, *model.AppError) {
+	return s.getByName(teamId, name, false, allowFromCache)
+}
+
+func (s RedisCacheChannelStore) GetByNameIncludeDeleted(teamId string, name string, allowFromCache bool) (*model.Channel, *model.AppError) {
+	return s.getByName(teamId, name, true, allowFromCache)
+}
+
+func (s RedisCacheChannelStore) getByName(teamId string, name string, includeDeleted bool, allowFromCache bool) (*model.Channel, *model.AppError) {
+	if allowFromCache {
+		var channel *model.Channel
+		if s.rootStore.doStandardReadCache(s.rootStore.channelByNameCache, teamId+name, &channel) {
+			return channel, nil
+		}
+	}
+
+	var channel *model.Channel
+	var err *model.AppError
+	if includeDeleted {
+		channel, err = s.ChannelStore.GetByNameIncludeDeleted(teamId, name, allowFromCache)
+	} else {
+		channel, err = s.ChannelStore.GetByName(teamId, name, allowFromCache)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.rootStore.doStandardAddToCache(s.rootStore.channelByNameCache, teamId+name, channel)
+
+	return channel, nil
+}
+
+func (s RedisCacheChannelStore) GetByNames(teamId string, names []string, allowFromCache bool) ([]*model.Channel, *model.AppError) {
+	var channels []*model.Channel
+
+	if allowFromCache {
+		var misses []string
+		visited := make(map[string]struct{})
+		for _, name := range names {
+			if _, ok := visited[name]; ok {
+				continue
+			}
+			visited[name] = struct{}{}
+			var channel *model.Channel
+			if s.rootStore.doStandardReadCache(s.rootStore.channelByNameCache, teamId+name, &channel) {
+				channels = append(channels, channel)
+			} else {
+				misses = append(misses, name)
+			}
+		}
+		names = misses
+	}
+
+	if len(names) > 0 {
+		dbChannels, err := s.ChannelStore.GetByNames(teamId, names, allowFromCache)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, channel := range dbChannels {
+			s.rootStore.doStandardAddToCache(s.rootStore.channelByNameCache, teamId+channel.Name, channel)
+			channels = append(channels, channel)
+		}
+	}
+
+	return channels, nil
+}
+
+func (s RedisCacheChannelStore) Delete(channelId string, time int64) *model.AppError {
+	defer s.InvalidateChannel(channelId)
+	return s.ChannelStore.Delete(channelId, time)
+}
+
+func (s RedisCacheChannelStore) Restore(channelId string, time int64) *model.AppError {
+	defer s.InvalidateChannel(channelId)
+	return s.ChannelStore.Restore(channelId, time)
+}
+
+func (s RedisCacheChannelStore) SetDeleteAt(channelId string, deleteAt, updateAt int64) *model.AppError {
+	defer s.InvalidateChannel(channelId)
+	return s.ChannelStore.SetDeleteAt(channelId, deleteAt, updateAt)
+}
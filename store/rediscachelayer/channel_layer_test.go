@@ -0,0 +1,149 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package rediscachelayer
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store/storetest"
+	"github.com/mattermost/mattermost-server/store/storetest/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelStore(t *testing.T) {
+	StoreTestWithSqlSupplier(t, storetest.TestChannelStore)
+}
+
+func TestChannelStoreChannelCache(t *testing.T) {
+	fakeChannel := model.Channel{Id: "123", TeamId: "team-id", Name: "channel-name", GroupConstrained: model.NewBool(false)}
+
+	t.Run("first call not cached, second cached and returning same data", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		channel, err := cachedStore.Channel().Get("123", true)
+		require.Nil(t, err)
+		assert.Equal(t, &fakeChannel, channel)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "Get", 1)
+		channel, err = cachedStore.Channel().Get("123", true)
+		require.Nil(t, err)
+		assert.Equal(t, &fakeChannel, channel)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "Get", 1)
+	})
+
+	t.Run("first call not cached, second force no cached", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		cachedStore.Channel().Get("123", true)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "Get", 1)
+		cachedStore.Channel().Get("123", false)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "Get", 2)
+	})
+
+	t.Run("first call not cached, delete, and then not cached again", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		cachedStore.Channel().Get("123", true)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "Get", 1)
+		cachedStore.Channel().Delete("123", 0)
+		cachedStore.Channel().Get("123", true)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "Get", 2)
+	})
+
+	t.Run("first call by name not cached, invalidate, and then not cached again", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		channel, err := cachedStore.Channel().GetByName("team-id", "channel-name", true)
+		require.Nil(t, err)
+		assert.Equal(t, &fakeChannel, channel)
+		cachedStore.Channel().GetByName("team-id", "channel-name", true)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetByName", 1)
+		cachedStore.Channel().InvalidateChannelByName("team-id", "channel-name")
+		cachedStore.Channel().GetByName("team-id", "channel-name", true)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetByName", 2)
+	})
+
+	t.Run("first call not cached, clear caches, and then not cached again", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		cachedStore.Channel().Get("123", true)
+		cachedStore.Channel().GetByName("team-id", "channel-name", true)
+		cachedStore.Channel().ClearCaches()
+		assert.Empty(t, server.Keys())
+	})
+}
+
+func TestChannelStoreMemberCountCache(t *testing.T) {
+	t.Run("first call not cached, second cached and returning same data", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		count, err := cachedStore.Channel().GetMemberCount("123", true)
+		require.Nil(t, err)
+		assert.Equal(t, int64(10), count)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetMemberCount", 1)
+		count, err = cachedStore.Channel().GetMemberCount("123", true)
+		require.Nil(t, err)
+		assert.Equal(t, int64(10), count)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetMemberCount", 1)
+		assert.Equal(t, int64(10), cachedStore.Channel().GetMemberCountFromCache("123"))
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetMemberCount", 1)
+	})
+
+	t.Run("first call not cached, second force no cached", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		cachedStore.Channel().GetMemberCount("123", true)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetMemberCount", 1)
+		cachedStore.Channel().GetMemberCount("123", false)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetMemberCount", 2)
+	})
+
+	t.Run("first call not cached, invalidate, and then not cached again", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		cachedStore.Channel().GetMemberCount("123", true)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetMemberCount", 1)
+		cachedStore.Channel().InvalidateMemberCount("123")
+		cachedStore.Channel().GetMemberCount("123", true)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetMemberCount", 2)
+	})
+
+	t.Run("first call not cached, clear caches, and then not cached again", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		cachedStore.Channel().GetMemberCount("123", true)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetMemberCount", 1)
+		cachedStore.Channel().ClearCaches()
+		cachedStore.Channel().GetMemberCount("123", true)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetMemberCount", 2)
+	})
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package rediscachelayer
+
+import (
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type RedisCacheEmojiStore struct {
+	store.EmojiStore
+	rootStore *RedisCacheStore
+}
+
+func (s RedisCacheEmojiStore) Get(id string, allowFromCache bool) (*model.Emoji, *model.AppError) {
+	if allowFromCache {
+		var emoji *model.Emoji
+		if s.rootStore.doStandardReadCache(s.rootStore.emojiCacheById, id, &emoji) {
+			return emoji, nil
+		}
+	}
+
+	emoji, err := s.EmojiStore.Get(id, allowFromCache)
+	if err != nil {
+		return nil, err
+	}
+
+	if allowFromCache {
+		s.addToCache(emoji)
+	}
+
+	return emoji, nil
+}
+
+func (s RedisCacheEmojiStore) GetByName(name string, allowFromCache bool) (*model.Emoji, *model.AppError) {
+	if id, ok := model.GetSystemEmojiId(name); ok {
+		return s.Get(id, allowFromCache)
+	}
+
+	if allowFromCache {
+		var id string
+		if s.rootStore.doStandardReadCache(s.rootStore.emojiIdCacheByName, name, &id) {
+			var emoji *model.Emoji
+			if s.rootStore.doStandardReadCache(s.rootStore.emojiCacheById, id, &emoji) {
+				return emoji, nil
+			}
+		}
+	}
+
+	emoji, err := s.EmojiStore.GetByName(name, allowFromCache)
+	if err != nil {
+		return nil, err
+	}
+
+	if allowFromCache {
+		s.addToCache(emoji)
+	}
+
+	return emoji, nil
+}
+
+func (s RedisCacheEmojiStore) Delete(emoji *model.Emoji, time int64) *model.AppError {
+	defer s.rootStore.doInvalidateCache(s.rootStore.emojiCacheById, emoji.Id)
+	defer s.rootStore.doInvalidateCache(s.rootStore.emojiIdCacheByName, emoji.Name)
+	return s.EmojiStore.Delete(emoji, time)
+}
+
+func (s RedisCacheEmojiStore) addToCache(emoji *model.Emoji) {
+	s.rootStore.doStandardAddToCache(s.rootStore.emojiCacheById, emoji.Id, emoji)
+	s.rootStore.doStandardAddToCache(s.rootStore.emojiIdCacheByName, emoji.Name, emoji.Id)
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package rediscachelayer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fakeRedis is a minimal in-process Redis stand-in speaking just enough of the
+// RESP protocol for the commands used by the cache layer.
+type fakeRedis struct {
+	addr     string
+	listener net.Listener
+	mutex    sync.Mutex
+	data     map[string]fakeRedisEntry
+	conns    map[net.Conn]struct{}
+}
+
+type fakeRedisEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+func newFakeRedis() *fakeRedis {
+	r := &fakeRedis{
+		data: make(map[string]fakeRedisEntry),
+	}
+	r.listen("127.0.0.1:0")
+	return r
+}
+
+func (r *fakeRedis) listen(addr string) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		panic(err)
+	}
+
+	r.mutex.Lock()
+	r.addr = listener.Addr().String()
+	r.listener = listener
+	r.conns = make(map[net.Conn]struct{})
+	r.mutex.Unlock()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			r.mutex.Lock()
+			r.conns[conn] = struct{}{}
+			r.mutex.Unlock()
+
+			go r.serve(conn)
+		}
+	}()
+}
+
+// Close stops the server and drops every open connection, keeping the data.
+func (r *fakeRedis) Close() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.listener.Close()
+	for conn := range r.conns {
+		conn.Close()
+	}
+}
+
+// Restart listens again on the address used before Close.
+func (r *fakeRedis) Restart() {
+	r.listen(r.addr)
+}
+
+func (r *fakeRedis) Addr() string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.addr
+}
+
+func (r *fakeRedis) Keys() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	keys := []string{}
+	for key := range r.data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (r *fakeRedis) Set(key, value string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.data[key] = fakeRedisEntry{value: value}
+}
+
+func (r *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+
+		if _, err := io.WriteString(conn, r.execute(args)); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return nil, fmt.Errorf("unexpected line %q", line)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, count)
+	for i := range args {
+		line, err = reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		size, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+		if err != nil {
+			return nil, err
+		}
+
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+
+	return args, nil
+}
+
+func bulkString(value string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+}
+
+func (r *fakeRedis) execute(args []string) string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return "+PONG\r\n"
+	case "GET":
+		entry, ok := r.data[args[1]]
+		if !ok || (!entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt)) {
+			return "$-1\r\n"
+		}
+		return bulkString(entry.value)
+	case "SET":
+		entry := fakeRedisEntry{value: args[2]}
+		if len(args) == 5 {
+			amount, _ := strconv.Atoi(args[4])
+			switch strings.ToUpper(args[3]) {
+			case "EX":
+				entry.expiresAt = time.Now().Add(time.Duration(amount) * time.Second)
+			case "PX":
+				entry.expiresAt = time.Now().Add(time.Duration(amount) * time.Millisecond)
+			}
+		}
+		r.data[args[1]] = entry
+		return "+OK\r\n"
+	case "DEL":
+		deleted := 0
+		for _, key := range args[1:] {
+			if _, ok := r.data[key]; ok {
+				delete(r.data, key)
+				deleted++
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", deleted)
+	case "SCAN":
+		// Every matching key is returned at once with a zero cursor.
+		pattern := "*"
+		for i := 2; i+1 < len(args); i += 2 {
+			if strings.ToUpper(args[i]) == "MATCH" {
+				pattern = args[i+1]
+			}
+		}
+
+		var matches []string
+		for key := range r.data {
+			if ok, _ := path.Match(pattern, key); ok {
+				matches = append(matches, key)
+			}
+		}
+
+		reply := "*2\r\n" + bulkString("0") + fmt.Sprintf("*%d\r\n", len(matches))
+		for _, key := range matches {
+			reply += bulkString(key)
+		}
+		return reply
+	default:
+		return fmt.Sprintf("-ERR unknown command '%s'\r\n", args[0])
+	}
+}
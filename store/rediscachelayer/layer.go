@@ -0,0 +1,383 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package rediscachelayer
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/mattermost/mattermost-server/einterfaces"
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+const (
+	REDIS_EXPIRY_TIME    = 30 * time.Minute
+	REDIS_TIMEOUT        = 500 * time.Millisecond
+	REDIS_RETRY_INTERVAL = 30 * time.Second
+	REDIS_SCAN_COUNT     = 1000
+
+	REACTION_CACHE_PREFIX           = "reactions:"
+	ROLE_CACHE_PREFIX               = "roles:"
+	SCHEME_CACHE_PREFIX             = "schemes:"
+	USER_PROFILE_BY_ID_CACHE_PREFIX = "users:"
+	CHANNEL_MEMBER_COUNTS_PREFIX    = "channels:membercount:"
+	CHANNEL_CACHE_PREFIX            = "channels:id:"
+	CHANNEL_BY_NAME_CACHE_PREFIX    = "channels:name:"
+	TEAM_IDS_FOR_USER_CACHE_PREFIX  = "teams:user:"
+	EMOJI_CACHE_PREFIX              = "emojis:id:"
+	EMOJI_ID_BY_NAME_CACHE_PREFIX   = "emojis:name:"
+	LAST_POST_TIME_CACHE_PREFIX     = "posts:lastposttime:"
+	LAST_POSTS_CACHE_PREFIX         = "posts:last:"
+)
+
+// redisCache describes a group of keys sharing a prefix in Redis, the
+// equivalent of a single utils.Cache in the local cache layer.
+type redisCache struct {
+	name   string
+	prefix string
+}
+
+type RedisCacheStore struct {
+	store.Store
+	metrics einterfaces.MetricsInterface
+	client  *redis.Client
+	status  *redisStatus
+
+	reaction      RedisCacheReactionStore
+	reactionCache redisCache
+	role          RedisCacheRoleStore
+	roleCache     redisCache
+	scheme        RedisCacheSchemeStore
+	schemeCache   redisCache
+
+	user                  RedisCacheUserStore
+	userProfileByIdsCache redisCache
+
+	channel                  RedisCacheChannelStore
+	channelMemberCountsCache redisCache
+	channelByIdCache         redisCache
+	channelByNameCache       redisCache
+
+	team                       RedisCacheTeamStore
+	teamAllTeamIdsForUserCache redisCache
+
+	emoji              RedisCacheEmojiStore
+	emojiCacheById     redisCache
+	emojiIdCacheByName redisCache
+
+	post              RedisCachePostStore
+	lastPostTimeCache redisCache
+	lastPostsCache    redisCache
+
+	masterOnly *RedisCacheStore
+}
+
+// redisStatus tracks whether the Redis server can currently be used. After a
+// failure every cache operation is skipped until the retry interval elapses,
+// so an unreachable server only costs one timeout per interval.
+type redisStatus struct {
+	mutex         sync.Mutex
+	retryInterval time.Duration
+	down          bool
+	downUntil     time.Time
+}
+
+func NewRedisCacheLayer(baseStore store.Store, metrics einterfaces.MetricsInterface, settings model.CacheSettings) *RedisCacheStore {
+	client := redis.NewClient(&redis.Options{
+		Addr:         *settings.RedisAddress,
+		Password:     *settings.RedisPassword,
+		DB:           *settings.RedisDB,
+		DialTimeout:  REDIS_TIMEOUT,
+		ReadTimeout:  REDIS_TIMEOUT,
+		WriteTimeout: REDIS_TIMEOUT,
+	})
+
+	return newRedisCacheLayer(baseStore, metrics, client)
+}
+
+func newRedisCacheLayer(baseStore store.Store, metrics einterfaces.MetricsInterface, client *redis.Client) *RedisCacheStore {
+	redisStore := &RedisCacheStore{
+		metrics: metrics,
+		client:  client,
+		status:  &redisStatus{retryInterval: REDIS_RETRY_INTERVAL},
+	}
+	redisStore.reactionCache = redisCache{name: "Reaction", prefix: REACTION_CACHE_PREFIX}
+	redisStore.roleCache = redisCache{name: "Role", prefix: ROLE_CACHE_PREFIX}
+	redisStore.schemeCache = redisCache{name: "Scheme", prefix: SCHEME_CACHE_PREFIX}
+	redisStore.userProfileByIdsCache = redisCache{name: "Profile By Ids", prefix: USER_PROFILE_BY_ID_CACHE_PREFIX}
+	redisStore.channelMemberCountsCache = redisCache{name: "Channel Member Counts", prefix: CHANNEL_MEMBER_COUNTS_PREFIX}
+	redisStore.channelByIdCache = redisCache{name: "Channel", prefix: CHANNEL_CACHE_PREFIX}
+	redisStore.channelByNameCache = redisCache{name: "Channel By Name", prefix: CHANNEL_BY_NAME_CACHE_PREFIX}
+	redisStore.teamAllTeamIdsForUserCache = redisCache{name: "All Team Ids for User", prefix: TEAM_IDS_FOR_USER_CACHE_PREFIX}
+	redisStore.emojiCacheById = redisCache{name: "Emoji", prefix: EMOJI_CACHE_PREFIX}
+	redisStore.emojiIdCacheByName = redisCache{name: "Emoji Id By Name", prefix: EMOJI_ID_BY_NAME_CACHE_PREFIX}
+	redisStore.lastPostTimeCache = redisCache{name: "Last Post Time", prefix: LAST_POST_TIME_CACHE_PREFIX}
+	redisStore.lastPostsCache = redisCache{name: "Last Posts Cache", prefix: LAST_POSTS_CACHE_PREFIX}
+	redisStore.initStores(baseStore)
+
+	// The master only view shares the caches, so that writes made through either invalidate both.
+	masterOnly := *redisStore
+	masterOnly.initStores(baseStore.MasterOnly())
+	masterOnly.masterOnly = &masterOnly
+	redisStore.masterOnly = &masterOnly
+
+	if err := client.Ping().Err(); err != nil {
+		redisStore.markUnavailable(err)
+	}
+
+	return redisStore
+}
+
+// initStores wraps the stores of the given base store, caching their results in the caches of
+// this store.
+func (s *RedisCacheStore) initStores(baseStore store.Store) {
+	s.Store = baseStore
+	s.reaction = RedisCacheReactionStore{ReactionStore: baseStore.Reaction(), rootStore: s}
+	s.role = RedisCacheRoleStore{RoleStore: baseStore.Role(), rootStore: s}
+	s.scheme = RedisCacheSchemeStore{SchemeStore: baseStore.Scheme(), rootStore: s}
+	s.user = RedisCacheUserStore{UserStore: baseStore.User(), rootStore: s}
+	s.channel = RedisCacheChannelStore{ChannelStore: baseStore.Channel(), rootStore: s}
+	s.team = RedisCacheTeamStore{TeamStore: baseStore.Team(), rootStore: s}
+	s.emoji = RedisCacheEmojiStore{EmojiStore: baseStore.Emoji(), rootStore: s}
+	s.post = RedisCachePostStore{PostStore: baseStore.Post(), rootStore: s}
+}
+
+func (s *RedisCacheStore) Reaction() store.ReactionStore {
+	return s.reaction
+}
+
+func (s *RedisCacheStore) Role() store.RoleStore {
+	return s.role
+}
+
+func (s *RedisCacheStore) Scheme() store.SchemeStore {
+	return s.scheme
+}
+
+func (s *RedisCacheStore) User() store.UserStore {
+	return s.user
+}
+
+func (s *RedisCacheStore) Channel() store.ChannelStore {
+	return s.channel
+}
+
+func (s *RedisCacheStore) Team() store.TeamStore {
+	return s.team
+}
+
+func (s *RedisCacheStore) Emoji() store.EmojiStore {
+	return s.emoji
+}
+
+func (s *RedisCacheStore) Post() store.PostStore {
+	return s.post
+}
+
+func (s *RedisCacheStore) MasterOnly() store.Store {
+	return s.masterOnly
+}
+
+// WithQueryTimeout returns a view of this store sharing its caches whose base store runs its
+// queries with the given timeout.
+func (s *RedisCacheStore) WithQueryTimeout(timeout time.Duration) store.Store {
+	view := *s
+	view.initStores(store.WithQueryTimeout(s.Store, timeout))
+
+	masterOnly := *s.masterOnly
+	masterOnly.initStores(store.WithQueryTimeout(s.masterOnly.Store, timeout))
+	masterOnly.masterOnly = &masterOnly
+	view.masterOnly = &masterOnly
+
+	return &view
+}
+
+func (s *RedisCacheStore) DropAllTables() {
+	s.Invalidate()
+	s.Store.DropAllTables()
+}
+
+func (s *RedisCacheStore) Close() {
+	s.Store.Close()
+	s.client.Close()
+}
+
+func (s *RedisCacheStore) caches() []redisCache {
+	return []redisCache{
+		s.reactionCache,
+		s.roleCache,
+		s.schemeCache,
+		s.userProfileByIdsCache,
+		s.channelMemberCountsCache,
+		s.channelByIdCache,
+		s.channelByNameCache,
+		s.teamAllTeamIdsForUserCache,
+		s.emojiCacheById,
+		s.emojiIdCacheByName,
+		s.lastPostTimeCache,
+		s.lastPostsCache,
+	}
+}
+
+// available reports whether Redis should be used. Once the retry interval
+// after a failure has elapsed the server is pinged again and, if it answers,
+// every cache is purged before use: invalidations issued while it was
+// unreachable were lost, so any entry left in it may be stale.
+//
+// The caller that retries claims the next interval before releasing the lock,
+// so the ping and purge run without blocking the other callers, which keep
+// falling back to the database until the purge is done.
+func (s *RedisCacheStore) available() bool {
+	s.status.mutex.Lock()
+	if !s.status.down {
+		s.status.mutex.Unlock()
+		return true
+	}
+
+	if time.Now().Before(s.status.downUntil) {
+		s.status.mutex.Unlock()
+		return false
+	}
+	s.status.downUntil = time.Now().Add(s.status.retryInterval)
+	s.status.mutex.Unlock()
+
+	err := s.client.Ping().Err()
+	if err == nil {
+		for _, cache := range s.caches() {
+			if err = s.purge(cache); err != nil {
+				break
+			}
+		}
+	}
+
+	if err != nil {
+		return false
+	}
+
+	s.status.mutex.Lock()
+	defer s.status.mutex.Unlock()
+
+	mlog.Info("Redis cache is available again.")
+	s.status.down = false
+	return true
+}
+
+func (s *RedisCacheStore) markUnavailable(err error) {
+	s.status.mutex.Lock()
+	defer s.status.mutex.Unlock()
+
+	if !s.status.down {
+		mlog.Warn("Redis cache is unavailable, falling back to the database.", mlog.Err(err))
+	}
+	s.status.down = true
+	s.status.downUntil = time.Now().Add(s.status.retryInterval)
+}
+
+func (s *RedisCacheStore) purge(cache redisCache) error {
+	var cursor uint64
+	for {
+		keys, next, err := s.client.Scan(cursor, cache.prefix+"*", REDIS_SCAN_COUNT).Result()
+		if err != nil {
+			return err
+		}
+
+		if len(keys) > 0 {
+			if err := s.client.Del(keys...).Err(); err != nil {
+				return err
+			}
+		}
+
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+func (s *RedisCacheStore) doStandardReadCache(cache redisCache, key string, value interface{}) bool {
+	if !s.available() {
+		return false
+	}
+
+	data, err := s.client.Get(cache.prefix + key).Bytes()
+	if err == nil {
+		if err = json.Unmarshal(data, value); err == nil {
+			if s.metrics != nil {
+				s.metrics.IncrementMemCacheHitCounter(cache.name)
+			}
+			return true
+		}
+		mlog.Warn("Unable to decode redis cache entry.", mlog.String("key", cache.prefix+key), mlog.Err(err))
+	} else if err != redis.Nil {
+		s.markUnavailable(err)
+		return false
+	}
+
+	if s.metrics != nil {
+		s.metrics.IncrementMemCacheMissCounter(cache.name)
+	}
+
+	return false
+}
+
+func (s *RedisCacheStore) doStandardAddToCache(cache redisCache, key string, value interface{}) {
+	if !s.available() {
+		return
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		mlog.Warn("Unable to encode redis cache entry.", mlog.String("key", cache.prefix+key), mlog.Err(err))
+		return
+	}
+
+	if err := s.client.Set(cache.prefix+key, data, REDIS_EXPIRY_TIME).Err(); err != nil {
+		s.markUnavailable(err)
+	}
+}
+
+// doInvalidateCache removes the given keys. Every node reads the same
+// entries, so unlike the local cache layer no cluster message is needed.
+func (s *RedisCacheStore) doInvalidateCache(cache redisCache, keys ...string) {
+	if s.metrics != nil {
+		s.metrics.IncrementMemCacheInvalidationCounter(cache.name + " - Remove")
+	}
+
+	if !s.available() {
+		return
+	}
+
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = cache.prefix + key
+	}
+
+	if err := s.client.Del(prefixed...).Err(); err != nil {
+		s.markUnavailable(err)
+	}
+}
+
+func (s *RedisCacheStore) doClearCache(cache redisCache) {
+	if s.metrics != nil {
+		s.metrics.IncrementMemCacheInvalidationCounter(cache.name + " - Purge")
+	}
+
+	if !s.available() {
+		return
+	}
+
+	if err := s.purge(cache); err != nil {
+		s.markUnavailable(err)
+	}
+}
+
+func (s *RedisCacheStore) Invalidate() {
+	for _, cache := range s.caches() {
+		s.doClearCache(cache)
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package rediscachelayer
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/mattermost/mattermost-server/store/sqlstore"
+	"github.com/mattermost/mattermost-server/store/storetest"
+	"github.com/mattermost/mattermost-server/store/storetest/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type storeType struct {
+	Name        string
+	SqlSettings *model.SqlSettings
+	SqlSupplier *sqlstore.SqlSupplier
+	Redis       *fakeRedis
+	Store       store.Store
+}
+
+var storeTypes []*storeType
+
+func StoreTest(t *testing.T, f func(*testing.T, store.Store)) {
+	defer func() {
+		if err := recover(); err != nil {
+			tearDownStores()
+			panic(err)
+		}
+	}()
+	for _, st := range storeTypes {
+		st := st
+		t.Run(st.Name, func(t *testing.T) { f(t, st.Store) })
+	}
+}
+
+func StoreTestWithSqlSupplier(t *testing.T, f func(*testing.T, store.Store, storetest.SqlSupplier)) {
+	defer func() {
+		if err := recover(); err != nil {
+			tearDownStores()
+			panic(err)
+		}
+	}()
+	for _, st := range storeTypes {
+		st := st
+		t.Run(st.Name, func(t *testing.T) { f(t, st.Store, st.SqlSupplier) })
+	}
+}
+
+func initStores() {
+	if storetest.TestDriverEnabled(model.DATABASE_DRIVER_MYSQL) {
+		storeTypes = append(storeTypes, &storeType{
+			Name:        "RedisCache+MySQL",
+			SqlSettings: storetest.MakeSqlSettings(model.DATABASE_DRIVER_MYSQL),
+		})
+	}
+	if storetest.TestDriverEnabled(model.DATABASE_DRIVER_POSTGRES) {
+		storeTypes = append(storeTypes, &storeType{
+			Name:        "RedisCache+PostgreSQL",
+			SqlSettings: storetest.MakeSqlSettings(model.DATABASE_DRIVER_POSTGRES),
+		})
+	}
+	if storetest.TestDriverEnabled(model.DATABASE_DRIVER_SQLITE) {
+		storeTypes = append(storeTypes, &storeType{
+			Name:        "RedisCache+SQLite",
+			SqlSettings: storetest.MakeSqlSettings(model.DATABASE_DRIVER_SQLITE),
+		})
+	}
+
+	defer func() {
+		if err := recover(); err != nil {
+			tearDownStores()
+			panic(err)
+		}
+	}()
+	var wg sync.WaitGroup
+	for _, st := range storeTypes {
+		st := st
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			st.SqlSupplier = sqlstore.NewSqlSupplier(*st.SqlSettings, nil)
+			st.Redis = newFakeRedis()
+			st.Store = getTestRedisCacheLayer(store.NewLayeredStore(st.SqlSupplier, nil, nil), st.Redis)
+			st.Store.DropAllTables()
+			st.Store.MarkSystemRanUnitTests()
+		}()
+	}
+	wg.Wait()
+}
+
+var tearDownStoresOnce sync.Once
+
+func tearDownStores() {
+	tearDownStoresOnce.Do(func() {
+		var wg sync.WaitGroup
+		wg.Add(len(storeTypes))
+		for _, st := range storeTypes {
+			st := st
+			go func() {
+				if st.Store != nil {
+					st.Store.Close()
+				}
+				if st.Redis != nil {
+					st.Redis.Close()
+				}
+				wg.Done()
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestRedisUnavailable(t *testing.T) {
+	t.Run("reads and writes fall through to the store when redis is down", func(t *testing.T) {
+		server := newFakeRedis()
+		server.Close()
+
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+		defer cachedStore.client.Close()
+
+		role, err := cachedStore.Role().GetByName("role-name")
+		require.Nil(t, err)
+		assert.Equal(t, "role-name", role.Name)
+		role, err = cachedStore.Role().GetByName("role-name")
+		require.Nil(t, err)
+		assert.Equal(t, "role-name", role.Name)
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 2)
+
+		cachedStore.Role().Delete("123")
+		cachedStore.Invalidate()
+	})
+
+	t.Run("redis going away falls back to the store", func(t *testing.T) {
+		server := newFakeRedis()
+
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+		defer cachedStore.client.Close()
+
+		cachedStore.Role().GetByName("role-name")
+		cachedStore.Role().GetByName("role-name")
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 1)
+
+		server.Close()
+
+		role, err := cachedStore.Role().GetByName("role-name")
+		require.Nil(t, err)
+		assert.Equal(t, "role-name", role.Name)
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 2)
+	})
+
+	t.Run("caches are purged when redis comes back", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+		defer cachedStore.client.Close()
+		cachedStore.status.retryInterval = 0
+
+		cachedStore.Role().GetByName("role-name")
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 1)
+		require.Equal(t, []string{"roles:role-name"}, server.Keys())
+
+		// The role changes while the cache cannot be reached, so the
+		// invalidation is lost and the entry left in redis is stale.
+		server.Close()
+		cachedStore.Role().Save(&model.Role{Id: "123", Name: "role-name", Permissions: []string{"permission"}})
+		server.Restart()
+
+		// Connections pooled before the restart are stale and may fail once.
+		available := false
+		for i := 0; i < 5 && !available; i++ {
+			available = cachedStore.available()
+		}
+		require.True(t, available)
+		assert.Empty(t, server.Keys())
+
+		cachedStore.Role().GetByName("role-name")
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 2)
+	})
+
+	t.Run("a failed retry waits for the next interval", func(t *testing.T) {
+		server := newFakeRedis()
+		server.Close()
+
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+		defer cachedStore.client.Close()
+
+		cachedStore.status.mutex.Lock()
+		cachedStore.status.downUntil = time.Now()
+		cachedStore.status.mutex.Unlock()
+
+		require.False(t, cachedStore.available())
+
+		cachedStore.status.mutex.Lock()
+		defer cachedStore.status.mutex.Unlock()
+		assert.True(t, cachedStore.status.down)
+		assert.True(t, cachedStore.status.downUntil.After(time.Now()))
+	})
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package rediscachelayer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-redis/redis"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/mattermost/mattermost-server/store/storetest/mocks"
+	"github.com/mattermost/mattermost-server/testlib"
+)
+
+var mainHelper *testlib.MainHelper
+
+func getMockStore() *mocks.Store {
+	mockStore := mocks.Store{}
+
+	fakeReaction := model.Reaction{PostId: "123", UserId: "user-id", EmojiName: "smile"}
+	mockReactionsStore := mocks.ReactionStore{}
+	mockReactionsStore.On("Save", &fakeReaction).Return(&model.Reaction{}, nil)
+	mockReactionsStore.On("Delete", &fakeReaction).Return(&model.Reaction{}, nil)
+	mockReactionsStore.On("GetForPost", "123", false).Return([]*model.Reaction{&fakeReaction}, nil)
+	mockReactionsStore.On("DeleteAllWithEmojiName", "smile").Return(nil)
+	mockStore.On("Reaction").Return(&mockReactionsStore)
+
+	fakeRole := model.Role{Id: "123", Name: "role-name", Permissions: []string{"permission"}}
+	mockRolesStore := mocks.RoleStore{}
+	mockRolesStore.On("Save", &fakeRole).Return(&model.Role{}, nil)
+	mockRolesStore.On("Delete", "123").Return(&fakeRole, nil)
+	mockRolesStore.On("GetByName", "role-name").Return(&fakeRole, nil)
+	mockRolesStore.On("GetByNames", []string{"role-name"}).Return([]*model.Role{&fakeRole}, nil)
+	mockRolesStore.On("PermanentDeleteAll").Return(nil)
+	mockStore.On("Role").Return(&mockRolesStore)
+
+	fakeScheme := model.Scheme{Id: "123", Name: "scheme-name", Scope: model.SCHEME_SCOPE_CHANNEL}
+	mockSchemesStore := mocks.SchemeStore{}
+	mockSchemesStore.On("Save", &fakeScheme).Return(&model.Scheme{}, nil)
+	mockSchemesStore.On("Delete", "123").Return(&fakeScheme, nil)
+	mockSchemesStore.On("Get", "123").Return(&fakeScheme, nil)
+	mockSchemesStore.On("PermanentDeleteAll").Return(nil)
+	mockStore.On("Scheme").Return(&mockSchemesStore)
+
+	fakeChannel := model.Channel{Id: "123", TeamId: "team-id", Name: "channel-name", GroupConstrained: model.NewBool(false)}
+	mockChannelsStore := mocks.ChannelStore{}
+	mockChannelsStore.On("Get", "123", true).Return(&fakeChannel, nil)
+	mockChannelsStore.On("Get", "123", false).Return(&fakeChannel, nil)
+	mockChannelsStore.On("GetByName", "team-id", "channel-name", true).Return(&fakeChannel, nil)
+	mockChannelsStore.On("GetByName", "team-id", "channel-name", false).Return(&fakeChannel, nil)
+	mockChannelsStore.On("Delete", "123", int64(0)).Return(nil)
+	mockChannelsStore.On("GetMemberCount", "123", true).Return(int64(10), nil)
+	mockChannelsStore.On("GetMemberCount", "123", false).Return(int64(10), nil)
+	mockChannelsStore.On("ClearCaches").Return()
+	mockStore.On("Channel").Return(&mockChannelsStore)
+
+	fakeUser := model.User{Id: "123", Username: "user-name", UpdateAt: 100, Props: model.StringMap{}}
+	mockUsersStore := mocks.UserStore{}
+	mockUsersStore.On("GetProfileByIds", []string{"123"}, &store.UserGetByIdsOpts{}, false).Return([]*model.User{&fakeUser}, nil)
+	mockUsersStore.On("ClearCaches").Return()
+	mockStore.On("User").Return(&mockUsersStore)
+
+	fakeMember := model.TeamMember{TeamId: "team-id", UserId: "123"}
+	mockTeamsStore := mocks.TeamStore{}
+	mockTeamsStore.On("GetUserTeamIds", "123", true).Return([]string{"team-id"}, nil)
+	mockTeamsStore.On("GetUserTeamIds", "123", false).Return([]string{"team-id"}, nil)
+	mockTeamsStore.On("SaveMember", &fakeMember, -1).Return(&fakeMember, nil)
+	mockTeamsStore.On("ClearCaches").Return()
+	mockStore.On("Team").Return(&mockTeamsStore)
+
+	fakeEmoji := model.Emoji{Id: "123", Name: "emoji-name"}
+	mockEmojisStore := mocks.EmojiStore{}
+	mockEmojisStore.On("Get", "123", true).Return(&fakeEmoji, nil)
+	mockEmojisStore.On("Get", "123", false).Return(&fakeEmoji, nil)
+	mockEmojisStore.On("GetByName", "emoji-name", true).Return(&fakeEmoji, nil)
+	mockEmojisStore.On("GetByName", "emoji-name", false).Return(&fakeEmoji, nil)
+	mockEmojisStore.On("Delete", &fakeEmoji, int64(0)).Return(nil)
+	mockStore.On("Emoji").Return(&mockEmojisStore)
+
+	fakePosts := model.NewPostList()
+	fakePosts.AddPost(&model.Post{Id: "post-id", ChannelId: "123", UpdateAt: 100})
+	fakePosts.AddOrder("post-id")
+	mockPostsStore := mocks.PostStore{}
+	mockPostsStore.On("GetEtag", "123", true).Return(fmt.Sprintf("%v.%v", model.CurrentVersion, 100))
+	mockPostsStore.On("GetEtag", "123", false).Return(fmt.Sprintf("%v.%v", model.CurrentVersion, 100))
+	mockPostsStore.On("GetPostsSince", "123", int64(50), true).Return(fakePosts, nil)
+	mockPostsStore.On("GetPostsSince", "123", int64(100), true).Return(model.NewPostList(), nil)
+	mockPostsStore.On("GetPosts", "123", 0, 30, true).Return(fakePosts, nil)
+	mockPostsStore.On("GetPosts", "123", 0, 30, false).Return(fakePosts, nil)
+	mockPostsStore.On("ClearCaches").Return()
+	mockStore.On("Post").Return(&mockPostsStore)
+
+	mockStore.On("MasterOnly").Return(&mockStore)
+	return &mockStore
+}
+
+// getTestRedisCacheLayer wraps baseStore with a redis cache layer backed by
+// the given fake server.
+func getTestRedisCacheLayer(baseStore store.Store, server *fakeRedis) *RedisCacheStore {
+	client := redis.NewClient(&redis.Options{
+		Addr:         server.Addr(),
+		DialTimeout:  REDIS_TIMEOUT,
+		ReadTimeout:  REDIS_TIMEOUT,
+		WriteTimeout: REDIS_TIMEOUT,
+	})
+	return newRedisCacheLayer(baseStore, nil, client)
+}
+
+func TestMain(m *testing.M) {
+	mainHelper = testlib.NewMainHelperWithOptions(nil)
+	defer mainHelper.Close()
+
+	initStores()
+	mainHelper.Main(m)
+	tearDownStores()
+}
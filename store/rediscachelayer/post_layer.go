@@ -0,0 +1,100 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package rediscachelayer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type RedisCachePostStore struct {
+	store.PostStore
+	rootStore *RedisCacheStore
+}
+
+func (s RedisCachePostStore) ClearCaches() {
+	s.rootStore.doClearCache(s.rootStore.lastPostTimeCache)
+	s.rootStore.doClearCache(s.rootStore.lastPostsCache)
+	s.PostStore.ClearCaches()
+}
+
+func (s RedisCachePostStore) InvalidateLastPostTimeCache(channelId string) {
+	s.rootStore.doInvalidateCache(s.rootStore.lastPostTimeCache, channelId)
+
+	// Keys are "{channelid}{limit}" and caching only occurs on limits of 30 and 60
+	s.rootStore.doInvalidateCache(s.rootStore.lastPostsCache, channelId+"30", channelId+"60")
+}
+
+func (s RedisCachePostStore) GetEtag(channelId string, allowFromCache bool) string {
+	if allowFromCache {
+		var lastTime int64
+		if s.rootStore.doStandardReadCache(s.rootStore.lastPostTimeCache, channelId, &lastTime) {
+			return fmt.Sprintf("%v.%v", model.CurrentVersion, lastTime)
+		}
+	}
+
+	result := s.PostStore.GetEtag(channelId, allowFromCache)
+
+	// The etag is "{version}.{last post time}", and the version itself contains dots.
+	splittedResult := strings.Split(result, ".")
+	if lastTime, err := strconv.ParseInt(splittedResult[len(splittedResult)-1], 10, 64); err == nil {
+		s.rootStore.doStandardAddToCache(s.rootStore.lastPostTimeCache, channelId, lastTime)
+	}
+
+	return result
+}
+
+func (s RedisCachePostStore) GetPostsSince(channelId string, time int64, allowFromCache bool) (*model.PostList, *model.AppError) {
+	if allowFromCache {
+		// If the last post in the channel's time is less than or equal to the time we are getting posts since,
+		// we can safely return no posts.
+		var lastTime int64
+		if s.rootStore.doStandardReadCache(s.rootStore.lastPostTimeCache, channelId, &lastTime) && lastTime <= time {
+			return model.NewPostList(), nil
+		}
+	}
+
+	list, err := s.PostStore.GetPostsSince(channelId, time, allowFromCache)
+	if err != nil {
+		return nil, err
+	}
+
+	latestUpdate := time
+	for _, p := range list.Posts {
+		if latestUpdate < p.UpdateAt {
+			latestUpdate = p.UpdateAt
+		}
+	}
+	s.rootStore.doStandardAddToCache(s.rootStore.lastPostTimeCache, channelId, latestUpdate)
+
+	return list, nil
+}
+
+func (s RedisCachePostStore) GetPosts(channelId string, offset int, limit int, allowFromCache bool) (*model.PostList, *model.AppError) {
+	// Caching only occurs on limits of 30 and 60, the common limits requested by MM clients
+	cacheable := offset == 0 && (limit == 60 || limit == 30)
+	key := fmt.Sprintf("%s%v", channelId, limit)
+
+	if allowFromCache && cacheable {
+		var list *model.PostList
+		if s.rootStore.doStandardReadCache(s.rootStore.lastPostsCache, key, &list) {
+			return list, nil
+		}
+	}
+
+	list, err := s.PostStore.GetPosts(channelId, offset, limit, allowFromCache)
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheable {
+		s.rootStore.doStandardAddToCache(s.rootStore.lastPostsCache, key, list)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package rediscachelayer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store/storetest"
+	"github.com/mattermost/mattermost-server/store/storetest/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostStore(t *testing.T) {
+	StoreTestWithSqlSupplier(t, storetest.TestPostStore)
+}
+
+func TestPostStoreLastPostTimeCache(t *testing.T) {
+	fakeEtag := fmt.Sprintf("%v.%v", model.CurrentVersion, 100)
+
+	t.Run("first call not cached, second cached and returning same data", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		assert.Equal(t, fakeEtag, cachedStore.Post().GetEtag("123", true))
+		mockStore.Post().(*mocks.PostStore).AssertNumberOfCalls(t, "GetEtag", 1)
+		assert.Equal(t, fakeEtag, cachedStore.Post().GetEtag("123", true))
+		mockStore.Post().(*mocks.PostStore).AssertNumberOfCalls(t, "GetEtag", 1)
+	})
+
+	t.Run("first call not cached, second force no cached", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		cachedStore.Post().GetEtag("123", true)
+		mockStore.Post().(*mocks.PostStore).AssertNumberOfCalls(t, "GetEtag", 1)
+		cachedStore.Post().GetEtag("123", false)
+		mockStore.Post().(*mocks.PostStore).AssertNumberOfCalls(t, "GetEtag", 2)
+	})
+
+	t.Run("first call not cached, invalidate, and then not cached again", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		cachedStore.Post().GetEtag("123", true)
+		mockStore.Post().(*mocks.PostStore).AssertNumberOfCalls(t, "GetEtag", 1)
+		cachedStore.Post().InvalidateLastPostTimeCache("123")
+		cachedStore.Post().GetEtag("123", true)
+		mockStore.Post().(*mocks.PostStore).AssertNumberOfCalls(t, "GetEtag", 2)
+	})
+
+	t.Run("posts since the last post time are served from the cache", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		list, err := cachedStore.Post().GetPostsSince("123", 50, true)
+		require.Nil(t, err)
+		assert.Len(t, list.Order, 1)
+		mockStore.Post().(*mocks.PostStore).AssertNumberOfCalls(t, "GetPostsSince", 1)
+
+		list, err = cachedStore.Post().GetPostsSince("123", 100, true)
+		require.Nil(t, err)
+		assert.Empty(t, list.Order)
+		mockStore.Post().(*mocks.PostStore).AssertNumberOfCalls(t, "GetPostsSince", 1)
+
+		cachedStore.Post().GetEtag("123", true)
+		mockStore.Post().(*mocks.PostStore).AssertNumberOfCalls(t, "GetEtag", 0)
+	})
+}
+
+func TestPostStoreLastPostsCache(t *testing.T) {
+	t.Run("first call not cached, second cached and returning same data", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		list, err := cachedStore.Post().GetPosts("123", 0, 30, true)
+		require.Nil(t, err)
+		assert.Equal(t, []string{"post-id"}, list.Order)
+		mockStore.Post().(*mocks.PostStore).AssertNumberOfCalls(t, "GetPosts", 1)
+		list, err = cachedStore.Post().GetPosts("123", 0, 30, true)
+		require.Nil(t, err)
+		assert.Equal(t, []string{"post-id"}, list.Order)
+		mockStore.Post().(*mocks.PostStore).AssertNumberOfCalls(t, "GetPosts", 1)
+	})
+
+	t.Run("first call not cached, second force no cached", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		cachedStore.Post().GetPosts("123", 0, 30, true)
+		mockStore.Post().(*mocks.PostStore).AssertNumberOfCalls(t, "GetPosts", 1)
+		cachedStore.Post().GetPosts("123", 0, 30, false)
+		mockStore.Post().(*mocks.PostStore).AssertNumberOfCalls(t, "GetPosts", 2)
+	})
+
+	t.Run("first call not cached, invalidate, and then not cached again", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		cachedStore.Post().GetPosts("123", 0, 30, true)
+		mockStore.Post().(*mocks.PostStore).AssertNumberOfCalls(t, "GetPosts", 1)
+		cachedStore.Post().InvalidateLastPostTimeCache("123")
+		cachedStore.Post().GetPosts("123", 0, 30, true)
+		mockStore.Post().(*mocks.PostStore).AssertNumberOfCalls(t, "GetPosts", 2)
+	})
+
+	t.Run("first call not cached, clear caches, and then not cached again", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		cachedStore.Post().GetPosts("123", 0, 30, true)
+		mockStore.Post().(*mocks.PostStore).AssertNumberOfCalls(t, "GetPosts", 1)
+		cachedStore.Post().ClearCaches()
+		cachedStore.Post().GetPosts("123", 0, 30, true)
+		mockStore.Post().(*mocks.PostStore).AssertNumberOfCalls(t, "GetPosts", 2)
+	})
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package rediscachelayer
+
+import (
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type RedisCacheReactionStore struct {
+	store.ReactionStore
+	rootStore *RedisCacheStore
+}
+
+func (s RedisCacheReactionStore) Save(reaction *model.Reaction) (*model.Reaction, *model.AppError) {
+	defer s.rootStore.doInvalidateCache(s.rootStore.reactionCache, reaction.PostId)
+	return s.ReactionStore.Save(reaction)
+}
+
+func (s RedisCacheReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, *model.AppError) {
+	defer s.rootStore.doInvalidateCache(s.rootStore.reactionCache, reaction.PostId)
+	return s.ReactionStore.Delete(reaction)
+}
+
+func (s RedisCacheReactionStore) GetForPost(postId string, allowFromCache bool) ([]*model.Reaction, *model.AppError) {
+	if !allowFromCache {
+		return s.ReactionStore.GetForPost(postId, false)
+	}
+
+	var reactions []*model.Reaction
+	if s.rootStore.doStandardReadCache(s.rootStore.reactionCache, postId, &reactions) {
+		return reactions, nil
+	}
+
+	reactions, err := s.ReactionStore.GetForPost(postId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rootStore.doStandardAddToCache(s.rootStore.reactionCache, postId, reactions)
+
+	return reactions, nil
+}
+
+func (s RedisCacheReactionStore) DeleteAllWithEmojiName(emojiName string) *model.AppError {
+	// As in the local cache layer, there is no way to find which post ids use
+	// this emoji name, so the whole cache is cleared.
+	defer s.rootStore.doClearCache(s.rootStore.reactionCache)
+	return s.ReactionStore.DeleteAllWithEmojiName(emojiName)
+}
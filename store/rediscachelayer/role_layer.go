@@ -0,0 +1,78 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package rediscachelayer
+
+import (
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type RedisCacheRoleStore struct {
+	store.RoleStore
+	rootStore *RedisCacheStore
+}
+
+func (s RedisCacheRoleStore) Save(role *model.Role) (*model.Role, *model.AppError) {
+	if len(role.Name) != 0 {
+		defer s.rootStore.doInvalidateCache(s.rootStore.roleCache, role.Name)
+	}
+	return s.RoleStore.Save(role)
+}
+
+func (s RedisCacheRoleStore) GetByName(name string) (*model.Role, *model.AppError) {
+	var role *model.Role
+	if s.rootStore.doStandardReadCache(s.rootStore.roleCache, name, &role) {
+		return role, nil
+	}
+
+	role, err := s.RoleStore.GetByName(name)
+	if err != nil {
+		return nil, err
+	}
+	s.rootStore.doStandardAddToCache(s.rootStore.roleCache, name, role)
+	return role, nil
+}
+
+func (s RedisCacheRoleStore) GetByNames(names []string) ([]*model.Role, *model.AppError) {
+	var foundRoles []*model.Role
+	var rolesToQuery []string
+
+	for _, roleName := range names {
+		var role *model.Role
+		if s.rootStore.doStandardReadCache(s.rootStore.roleCache, roleName, &role) {
+			foundRoles = append(foundRoles, role)
+		} else {
+			rolesToQuery = append(rolesToQuery, roleName)
+		}
+	}
+
+	if len(rolesToQuery) == 0 {
+		return foundRoles, nil
+	}
+
+	roles, err := s.RoleStore.GetByNames(rolesToQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, role := range roles {
+		s.rootStore.doStandardAddToCache(s.rootStore.roleCache, role.Name, role)
+	}
+	return append(foundRoles, roles...), nil
+}
+
+func (s RedisCacheRoleStore) Delete(roleId string) (*model.Role, *model.AppError) {
+	role, err := s.RoleStore.Delete(roleId)
+
+	if err == nil {
+		s.rootStore.doInvalidateCache(s.rootStore.roleCache, role.Name)
+	}
+	return role, err
+}
+
+func (s RedisCacheRoleStore) PermanentDeleteAll() *model.AppError {
+	defer s.rootStore.doClearCache(s.rootStore.roleCache)
+
+	return s.RoleStore.PermanentDeleteAll()
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package rediscachelayer
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store/storetest"
+	"github.com/mattermost/mattermost-server/store/storetest/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleStore(t *testing.T) {
+	StoreTest(t, storetest.TestRoleStore)
+}
+
+func TestRoleStoreCache(t *testing.T) {
+	fakeRole := model.Role{Id: "123", Name: "role-name", Permissions: []string{"permission"}}
+
+	t.Run("first call not cached, second cached and returning same data", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		role, err := cachedStore.Role().GetByName("role-name")
+		require.Nil(t, err)
+		assert.Equal(t, &fakeRole, role)
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 1)
+		role, err = cachedStore.Role().GetByName("role-name")
+		require.Nil(t, err)
+		assert.Equal(t, &fakeRole, role)
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 1)
+	})
+
+	t.Run("cache is shared between stores using the same redis", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+		otherMockStore := getMockStore()
+		otherCachedStore := getTestRedisCacheLayer(otherMockStore, server)
+
+		cachedStore.Role().GetByName("role-name")
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 1)
+		otherCachedStore.Role().GetByName("role-name")
+		otherMockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 0)
+
+		otherCachedStore.Role().Save(&fakeRole)
+		cachedStore.Role().GetByName("role-name")
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 2)
+	})
+
+	t.Run("first call by names not cached, second cached", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		roles, err := cachedStore.Role().GetByNames([]string{"role-name"})
+		require.Nil(t, err)
+		assert.Equal(t, []*model.Role{&fakeRole}, roles)
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByNames", 1)
+		roles, err = cachedStore.Role().GetByNames([]string{"role-name"})
+		require.Nil(t, err)
+		assert.Equal(t, []*model.Role{&fakeRole}, roles)
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByNames", 1)
+	})
+
+	t.Run("first call not cached, delete, and then not cached again", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		cachedStore.Role().GetByName("role-name")
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 1)
+		cachedStore.Role().Delete("123")
+		cachedStore.Role().GetByName("role-name")
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 2)
+	})
+
+	t.Run("first call not cached, permanent delete all, and then not cached again", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		cachedStore.Role().GetByName("role-name")
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 1)
+		cachedStore.Role().PermanentDeleteAll()
+		assert.Empty(t, server.Keys())
+		cachedStore.Role().GetByName("role-name")
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 2)
+	})
+}
+
+func TestRoleStoreMasterOnlyCache(t *testing.T) {
+	fakeRole := model.Role{Id: "123", Name: "role-name", Permissions: []string{"permission"}}
+
+	t.Run("master only view reads from the same cache", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		cachedStore.Role().GetByName("role-name")
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 1)
+		role, err := cachedStore.MasterOnly().Role().GetByName("role-name")
+		require.Nil(t, err)
+		assert.Equal(t, &fakeRole, role)
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 1)
+	})
+
+	t.Run("writes through the master only view invalidate the cache", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		cachedStore.Role().GetByName("role-name")
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 1)
+		cachedStore.MasterOnly().Role().Delete("123")
+		cachedStore.Role().GetByName("role-name")
+		mockStore.Role().(*mocks.RoleStore).AssertNumberOfCalls(t, "GetByName", 2)
+	})
+}
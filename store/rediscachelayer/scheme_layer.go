@@ -0,0 +1,62 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package rediscachelayer
+
+import (
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type RedisCacheSchemeStore struct {
+	store.SchemeStore
+	rootStore *RedisCacheStore
+}
+
+func (s RedisCacheSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, *model.AppError) {
+	if len(scheme.Id) != 0 {
+		defer s.rootStore.doInvalidateCache(s.rootStore.schemeCache, scheme.Id)
+	}
+	return s.SchemeStore.Save(scheme)
+}
+
+func (s RedisCacheSchemeStore) Get(schemeId string) (*model.Scheme, *model.AppError) {
+	var scheme *model.Scheme
+	if s.rootStore.doStandardReadCache(s.rootStore.schemeCache, schemeId, &scheme) {
+		return scheme, nil
+	}
+
+	scheme, err := s.SchemeStore.Get(schemeId)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rootStore.doStandardAddToCache(s.rootStore.schemeCache, schemeId, scheme)
+
+	return scheme, nil
+}
+
+func (s RedisCacheSchemeStore) Delete(schemeId string) (*model.Scheme, *model.AppError) {
+	defer s.rootStore.doInvalidateCache(s.rootStore.schemeCache, schemeId)
+	defer s.rootStore.doClearCache(s.rootStore.roleCache)
+
+	scheme, err := s.SchemeStore.Delete(schemeId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Channels using a deleted channel scheme are reset to the default one.
+	if scheme.Scope == model.SCHEME_SCOPE_CHANNEL {
+		s.rootStore.doClearCache(s.rootStore.channelByIdCache)
+		s.rootStore.doClearCache(s.rootStore.channelByNameCache)
+	}
+
+	return scheme, nil
+}
+
+func (s RedisCacheSchemeStore) PermanentDeleteAll() *model.AppError {
+	defer s.rootStore.doClearCache(s.rootStore.schemeCache)
+	defer s.rootStore.doClearCache(s.rootStore.roleCache)
+
+	return s.SchemeStore.PermanentDeleteAll()
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package rediscachelayer
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store/storetest"
+	"github.com/mattermost/mattermost-server/store/storetest/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemeStore(t *testing.T) {
+	StoreTest(t, storetest.TestSchemeStore)
+}
+
+func TestSchemeStoreCache(t *testing.T) {
+	fakeScheme := model.Scheme{Id: "123", Name: "scheme-name", Scope: model.SCHEME_SCOPE_CHANNEL}
+
+	t.Run("first call not cached, second cached and returning same data", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		scheme, err := cachedStore.Scheme().Get("123")
+		require.Nil(t, err)
+		assert.Equal(t, &fakeScheme, scheme)
+		mockStore.Scheme().(*mocks.SchemeStore).AssertNumberOfCalls(t, "Get", 1)
+		scheme, err = cachedStore.Scheme().Get("123")
+		require.Nil(t, err)
+		assert.Equal(t, &fakeScheme, scheme)
+		mockStore.Scheme().(*mocks.SchemeStore).AssertNumberOfCalls(t, "Get", 1)
+	})
+
+	t.Run("first call not cached, save, and then not cached again", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		cachedStore.Scheme().Get("123")
+		mockStore.Scheme().(*mocks.SchemeStore).AssertNumberOfCalls(t, "Get", 1)
+		cachedStore.Scheme().Save(&fakeScheme)
+		cachedStore.Scheme().Get("123")
+		mockStore.Scheme().(*mocks.SchemeStore).AssertNumberOfCalls(t, "Get", 2)
+	})
+
+	t.Run("deleting a channel scheme clears the channel caches", func(t *testing.T) {
+		server := newFakeRedis()
+		defer server.Close()
+		mockStore := getMockStore()
+		cachedStore := getTestRedisCacheLayer(mockStore, server)
+
+		cachedStore.Scheme().Get("123")
+		cachedStore.Channel().Get("123", true)
+		cachedStore.Channel().GetByName("team-id", "channel-name", true)
+		cachedStore.Scheme().Delete("123")
+		assert.Empty(t, server.Keys())
+		cachedStore.Scheme().Get("123")
+		mockStore.Scheme().(*mocks.SchemeStore).AssertNumberOfCalls(t, "Get", 2)
+		cachedStore.Channel().Get("123", true)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "Get", 2)
+	})
+}
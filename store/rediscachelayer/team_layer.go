@@ -0,0 +1,63 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package rediscachelayer
+
+import (
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type RedisCacheTeamStore struct {
+	store.TeamStore
+	rootStore *RedisCacheStore
+}
+
+func (s RedisCacheTeamStore) ClearCaches() {
+	s.rootStore.doClearCache(s.rootStore.teamAllTeamIdsForUserCache)
+	s.TeamStore.ClearCaches()
+}
+
+func (s RedisCacheTeamStore) InvalidateAllTeamIdsForUser(userId string) {
+	s.rootStore.doInvalidateCache(s.rootStore.teamAllTeamIdsForUserCache, userId)
+}
+
+func (s RedisCacheTeamStore) GetUserTeamIds(userId string, allowFromCache bool) ([]string, *model.AppError) {
+	if allowFromCache {
+		var teamIds []string
+		if s.rootStore.doStandardReadCache(s.rootStore.teamAllTeamIdsForUserCache, userId, &teamIds) {
+			return teamIds, nil
+		}
+	}
+
+	teamIds, err := s.TeamStore.GetUserTeamIds(userId, allowFromCache)
+	if err != nil {
+		return teamIds, err
+	}
+
+	if allowFromCache {
+		s.rootStore.doStandardAddToCache(s.rootStore.teamAllTeamIdsForUserCache, userId, teamIds)
+	}
+
+	return teamIds, nil
+}
+
+func (s RedisCacheTeamStore) Update(team *model.Team) (*model.Team, *model.AppError) {
+	updatedTeam, err := s.TeamStore.Update(team)
+	if err != nil {
+		return nil, err
+	}
+
+	// As in the local cache layer, there is no cheap way of finding the members
+	// of a deleted team, so the whole cache is cleared.
+	if updatedTeam.DeleteAt != 0 {
+		s.rootStore.doClearCache(s.rootStore.teamAllTeamIdsForUserCache)
+	}
+
+	return updatedTeam, nil
+}
+
+func (s RedisCacheTeamStore) SaveMember(member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, *model.AppError) {
+	defer s.InvalidateAllTeamIdsForUser(member.UserId)
+	return s.TeamStore.SaveMember(member, maxUsersPerTeam)
+}
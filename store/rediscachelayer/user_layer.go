@@ -0,0 +1,69 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package rediscachelayer
+
+import (
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type RedisCacheUserStore struct {
+	store.UserStore
+	rootStore *RedisCacheStore
+}
+
+func (s RedisCacheUserStore) ClearCaches() {
+	s.rootStore.doClearCache(s.rootStore.userProfileByIdsCache)
+	s.UserStore.ClearCaches()
+}
+
+func (s RedisCacheUserStore) InvalidatProfileCacheForUser(userId string) {
+	s.rootStore.doInvalidateCache(s.rootStore.userProfileByIdsCache, userId)
+}
+
+func (s RedisCacheUserStore) GetProfileByIds(userIds []string, options *store.UserGetByIdsOpts, allowFromCache bool) ([]*model.User, *model.AppError) {
+	if options == nil {
+		options = &store.UserGetByIdsOpts{}
+	}
+
+	users := []*model.User{}
+	remainingUserIds := make([]string, 0)
+
+	if allowFromCache {
+		for _, userId := range userIds {
+			var u *model.User
+			if s.rootStore.doStandardReadCache(s.rootStore.userProfileByIdsCache, userId, &u) {
+				// Empty props are omitted from the encoded user.
+				if u.Props == nil {
+					u.Props = model.StringMap{}
+				}
+
+				if options.Since == 0 || u.UpdateAt > options.Since {
+					users = append(users, u)
+				}
+			} else {
+				remainingUserIds = append(remainingUserIds, userId)
+			}
+		}
+	} else {
+		remainingUserIds = userIds
+	}
+
+	// If everything came from the cache then just return
+	if len(remainingUserIds) == 0 {
+		return users, nil
+	}
+
+	remainingUsers, err := s.UserStore.GetProfileByIds(remainingUserIds, options, false)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, u := range remainingUsers {
+		s.rootStore.doStandardAddToCache(s.rootStore.userProfileByIdsCache, u.Id, u)
+		users = append(users, u)
+	}
+
+	return users, nil
+}
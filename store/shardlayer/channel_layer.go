@@ -0,0 +1,207 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package shardlayer
+
+import (
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+// ShardChannelStore places new channels on a shard, and keeps the copy of each channel on its
+// shard up to date.
+type ShardChannelStore struct {
+	store.ChannelStore
+	rootStore *ShardStore
+}
+
+// placeChannel places the posts of a new channel on its shard. Channels that could not be placed
+// keep their posts in the main database until the shards are rebalanced.
+func (s ShardChannelStore) placeChannel(channel *model.Channel) {
+	shard := s.rootStore.PickChannelShard(channel.Id, channel.TeamId)
+
+	shardStore, err := s.rootStore.shardStore(shard)
+	if err == nil {
+		err = shardStore.PostShard().SaveChannelMirror(channel)
+	}
+	if err == nil {
+		_, err = s.rootStore.Store.PostShard().SaveChannelShard(&model.ChannelShard{ChannelId: channel.Id, Shard: shard})
+	}
+	if err != nil {
+		mlog.Error("Unable to place the channel on a shard", mlog.String("channel_id", channel.Id), mlog.String("shard", shard), mlog.Err(err))
+		return
+	}
+
+	s.rootStore.channelShardCache.AddWithDefaultExpires(channel.Id, shard)
+}
+
+// refreshMirror copies the channel to the shard holding its posts.
+func (s ShardChannelStore) refreshMirror(channelId string) *model.AppError {
+	shard, err := s.rootStore.channelShard(channelId)
+	if err != nil || shard == "" {
+		return err
+	}
+
+	shardStore, err := s.rootStore.shardStore(shard)
+	if err != nil {
+		return err
+	}
+
+	channel, err := s.ChannelStore.GetFromMaster(channelId)
+	if err != nil {
+		return err
+	}
+
+	return shardStore.PostShard().SaveChannelMirror(channel)
+}
+
+func (s ShardChannelStore) Save(channel *model.Channel, maxChannelsPerTeam int64) (*model.Channel, *model.AppError) {
+	saved, err := s.ChannelStore.Save(channel, maxChannelsPerTeam)
+	if err != nil {
+		return nil, err
+	}
+
+	s.placeChannel(saved)
+
+	return saved, nil
+}
+
+func (s ShardChannelStore) CreateDirectChannel(userId *model.User, otherUserId *model.User) (*model.Channel, *model.AppError) {
+	channel, err := s.ChannelStore.CreateDirectChannel(userId, otherUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	s.placeChannel(channel)
+
+	return channel, nil
+}
+
+func (s ShardChannelStore) SaveDirectChannel(channel *model.Channel, member1 *model.ChannelMember, member2 *model.ChannelMember) (*model.Channel, *model.AppError) {
+	saved, err := s.ChannelStore.SaveDirectChannel(channel, member1, member2)
+	if err != nil {
+		return nil, err
+	}
+
+	s.placeChannel(saved)
+
+	return saved, nil
+}
+
+func (s ShardChannelStore) Update(channel *model.Channel) (*model.Channel, *model.AppError) {
+	updated, err := s.ChannelStore.Update(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.refreshMirror(updated.Id); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s ShardChannelStore) Delete(channelId string, time int64) *model.AppError {
+	if err := s.ChannelStore.Delete(channelId, time); err != nil {
+		return err
+	}
+
+	return s.refreshMirror(channelId)
+}
+
+func (s ShardChannelStore) Restore(channelId string, time int64) *model.AppError {
+	if err := s.ChannelStore.Restore(channelId, time); err != nil {
+		return err
+	}
+
+	return s.refreshMirror(channelId)
+}
+
+func (s ShardChannelStore) SetDeleteAt(channelId string, deleteAt int64, updateAt int64) *model.AppError {
+	if err := s.ChannelStore.SetDeleteAt(channelId, deleteAt, updateAt); err != nil {
+		return err
+	}
+
+	return s.refreshMirror(channelId)
+}
+
+// PermanentDelete deletes the channel along with its copies on the shards. Its posts are deleted
+// beforehand through the post store.
+func (s ShardChannelStore) PermanentDelete(channelId string) *model.AppError {
+	channelShard, err := s.rootStore.Store.PostShard().GetChannelShard(channelId)
+	if err != nil {
+		return err
+	}
+
+	if err := s.ChannelStore.PermanentDelete(channelId); err != nil {
+		return err
+	}
+
+	for _, shard := range []string{channelShard.Shard, channelShard.PreviousShard} {
+		if shard == "" || shard == model.POST_SHARD_MAIN {
+			continue
+		}
+
+		shardStore, err := s.rootStore.shardStore(shard)
+		if err != nil {
+			return err
+		}
+		if shard == channelShard.PreviousShard {
+			if err := shardStore.PostShard().DeleteChannelData(channelId); err != nil {
+				return err
+			}
+		}
+		if err := shardStore.PostShard().DeleteChannelMirror(channelId); err != nil {
+			return err
+		}
+	}
+
+	if err := s.rootStore.Store.PostShard().DeleteChannelShard(channelId); err != nil {
+		return err
+	}
+
+	s.rootStore.channelShardCache.Remove(channelId)
+
+	return nil
+}
+
+func (s ShardChannelStore) GetPinnedPosts(channelId string) (*model.PostList, *model.AppError) {
+	shardStore, err := s.rootStore.channelStore(channelId)
+	if err != nil {
+		return nil, err
+	}
+
+	return shardStore.Channel().GetPinnedPosts(channelId)
+}
+
+func (s ShardChannelStore) GetPinnedPostCount(channelId string, allowFromCache bool) (int64, *model.AppError) {
+	shardStore, err := s.rootStore.channelStore(channelId)
+	if err != nil {
+		return 0, err
+	}
+
+	return shardStore.Channel().GetPinnedPostCount(channelId, allowFromCache)
+}
+
+func (s ShardChannelStore) GetForPost(postId string) (*model.Channel, *model.AppError) {
+	channelId, err := s.rootStore.postChannel(postId)
+	if err != nil {
+		return nil, err
+	} else if channelId == "" {
+		return s.ChannelStore.GetForPost(postId)
+	}
+
+	return s.ChannelStore.Get(channelId, true)
+}
+
+func (s ShardChannelStore) GetMemberForPost(postId string, userId string) (*model.ChannelMember, *model.AppError) {
+	channelId, err := s.rootStore.postChannel(postId)
+	if err != nil {
+		return nil, err
+	} else if channelId == "" {
+		return s.ChannelStore.GetMemberForPost(postId, userId)
+	}
+
+	return s.ChannelStore.GetMember(channelId, userId)
+}
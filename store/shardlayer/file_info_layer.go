@@ -0,0 +1,191 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package shardlayer
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+// ShardFileInfoStore keeps the file infos of posts on the shard of their channel. Files are
+// uploaded before the post they are attached to is created, so they are saved to the main
+// database until then.
+type ShardFileInfoStore struct {
+	store.FileInfoStore
+	rootStore *ShardStore
+}
+
+// getFromAny returns the file info read by get from the first store holding it.
+func (s ShardFileInfoStore) getFromAny(get func(shardStore store.Store) (*model.FileInfo, *model.AppError)) (*model.FileInfo, *model.AppError) {
+	var notFound *model.AppError
+	for _, shard := range s.rootStore.locations() {
+		shardStore, err := s.rootStore.shardStore(shard)
+		if err != nil {
+			return nil, err
+		}
+
+		info, err := get(shardStore)
+		if err == nil {
+			return info, nil
+		} else if err.StatusCode != http.StatusNotFound {
+			return nil, err
+		}
+
+		if notFound == nil {
+			notFound = err
+		}
+	}
+
+	return nil, notFound
+}
+
+func (s ShardFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, *model.AppError) {
+	if info.PostId == "" {
+		return s.FileInfoStore.Save(info)
+	}
+
+	shardStore, err := s.rootStore.postStore(info.PostId)
+	if err != nil {
+		return nil, err
+	}
+
+	return shardStore.FileInfo().Save(info)
+}
+
+func (s ShardFileInfoStore) Get(id string) (*model.FileInfo, *model.AppError) {
+	return s.getFromAny(func(shardStore store.Store) (*model.FileInfo, *model.AppError) {
+		return shardStore.FileInfo().Get(id)
+	})
+}
+
+func (s ShardFileInfoStore) GetByPath(path string) (*model.FileInfo, *model.AppError) {
+	return s.getFromAny(func(shardStore store.Store) (*model.FileInfo, *model.AppError) {
+		return shardStore.FileInfo().GetByPath(path)
+	})
+}
+
+func (s ShardFileInfoStore) GetForPost(postId string, readFromMaster, includeDeleted, allowFromCache bool) ([]*model.FileInfo, *model.AppError) {
+	shardStore, err := s.rootStore.postStore(postId)
+	if err != nil {
+		return nil, err
+	}
+
+	return shardStore.FileInfo().GetForPost(postId, readFromMaster, includeDeleted, allowFromCache)
+}
+
+func (s ShardFileInfoStore) GetForUser(userId string) ([]*model.FileInfo, *model.AppError) {
+	infosByShard := make([][]*model.FileInfo, len(s.rootStore.locations()))
+	err := s.rootStore.fanOut(func(i int, shard string, shardStore store.Store) *model.AppError {
+		infos, err := shardStore.FileInfo().GetForUser(userId)
+		infosByShard[i] = infos
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var infos []*model.FileInfo
+	seen := make(map[string]bool)
+	for _, shardInfos := range infosByShard {
+		for _, info := range shardInfos {
+			if !seen[info.Id] {
+				seen[info.Id] = true
+				infos = append(infos, info)
+			}
+		}
+	}
+
+	return infos, nil
+}
+
+func (s ShardFileInfoStore) InvalidateFileInfosForPostCache(postId string) {
+	s.FileInfoStore.InvalidateFileInfosForPostCache(postId)
+	for _, name := range s.rootStore.shardNames {
+		s.rootStore.shards[name].FileInfo().InvalidateFileInfosForPostCache(postId)
+	}
+}
+
+// AttachToPost moves the file info from the main database, where it was saved when the file was
+// uploaded, to the shard of the post.
+func (s ShardFileInfoStore) AttachToPost(fileId string, postId string, creatorId string) *model.AppError {
+	shard, err := s.rootStore.postShard(postId)
+	if err != nil {
+		return err
+	} else if shard == "" {
+		return s.FileInfoStore.AttachToPost(fileId, postId, creatorId)
+	}
+
+	shardStore, err := s.rootStore.shardStore(shard)
+	if err != nil {
+		return err
+	}
+
+	info, err := s.FileInfoStore.Get(fileId)
+	if err != nil && err.StatusCode != http.StatusNotFound {
+		return err
+	} else if err == nil {
+		if err := shardStore.PostShard().SaveChannelData(&model.PostShardData{FileInfos: []*model.FileInfo{info}}, 0); err != nil {
+			return err
+		}
+	}
+
+	if err := shardStore.FileInfo().AttachToPost(fileId, postId, creatorId); err != nil {
+		return err
+	}
+
+	if info != nil {
+		return s.FileInfoStore.PermanentDelete(fileId)
+	}
+
+	return nil
+}
+
+func (s ShardFileInfoStore) DeleteForPost(postId string) (string, *model.AppError) {
+	shardStore, err := s.rootStore.postStore(postId)
+	if err != nil {
+		return "", err
+	}
+
+	return shardStore.FileInfo().DeleteForPost(postId)
+}
+
+func (s ShardFileInfoStore) PermanentDelete(fileId string) *model.AppError {
+	return s.rootStore.fanOut(func(i int, shard string, shardStore store.Store) *model.AppError {
+		return shardStore.FileInfo().PermanentDelete(fileId)
+	})
+}
+
+func (s ShardFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	return s.rootStore.deleteBatch(limit, func(shardStore store.Store, limit int64) (int64, *model.AppError) {
+		return shardStore.FileInfo().PermanentDeleteBatch(endTime, limit)
+	})
+}
+
+func (s ShardFileInfoStore) PermanentDeleteByUser(userId string) (int64, *model.AppError) {
+	counts := make([]int64, len(s.rootStore.locations()))
+	err := s.rootStore.fanOut(func(i int, shard string, shardStore store.Store) *model.AppError {
+		count, err := shardStore.FileInfo().PermanentDeleteByUser(userId)
+		counts[i] = count
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, count := range counts {
+		total += count
+	}
+
+	return total, nil
+}
+
+func (s ShardFileInfoStore) ClearCaches() {
+	s.FileInfoStore.ClearCaches()
+	for _, name := range s.rootStore.shardNames {
+		s.rootStore.shards[name].FileInfo().ClearCaches()
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package shardlayer
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/mattermost/mattermost-server/utils"
+)
+
+const (
+	CHANNEL_SHARD_CACHE_SIZE = 50000
+	CHANNEL_SHARD_CACHE_SEC  = 60
+
+	POST_CHANNEL_CACHE_SIZE = 50000
+	POST_CHANNEL_CACHE_SEC  = 30 * 60
+
+	CHANNEL_DATA_BATCH_SIZE = 1000
+)
+
+// ShardStore keeps the posts, reactions and file infos of each channel on one of several database
+// shards, as recorded in the main database, and fans out the operations spanning channels. The
+// channels themselves are kept in the main database, and copied to the shard holding their posts
+// so that queries joining them keep working there.
+type ShardStore struct {
+	store.Store
+	shards     map[string]store.Store
+	shardNames []string
+	shardKey   string
+
+	// channelShardCache holds the shard of each channel. Other servers keep routing a moved channel
+	// to its previous shard until their entry expires.
+	channelShardCache *utils.Cache
+	// postChannelCache holds the channel of each post, which never changes.
+	postChannelCache *utils.Cache
+
+	post       ShardPostStore
+	reaction   ShardReactionStore
+	fileInfo   ShardFileInfoStore
+	channel    ShardChannelStore
+	preference ShardPreferenceStore
+	masterOnly *ShardStore
+}
+
+func NewShardLayer(baseStore store.Store, shards map[string]store.Store, shardKey string) ShardStore {
+	shardStore := ShardStore{
+		shardKey:          shardKey,
+		channelShardCache: utils.NewLruWithParams(CHANNEL_SHARD_CACHE_SIZE, "Channel Shard", CHANNEL_SHARD_CACHE_SEC, ""),
+		postChannelCache:  utils.NewLruWithParams(POST_CHANNEL_CACHE_SIZE, "Post Channel", POST_CHANNEL_CACHE_SEC, ""),
+	}
+	for name := range shards {
+		shardStore.shardNames = append(shardStore.shardNames, name)
+	}
+	sort.Strings(shardStore.shardNames)
+	shardStore.initStores(baseStore, shards)
+
+	masterOnlyShards := make(map[string]store.Store, len(shards))
+	for name, shard := range shards {
+		masterOnlyShards[name] = shard.MasterOnly()
+	}
+
+	masterOnly := shardStore
+	masterOnly.initStores(baseStore.MasterOnly(), masterOnlyShards)
+	masterOnly.masterOnly = &masterOnly
+	shardStore.masterOnly = &masterOnly
+
+	return shardStore
+}
+
+func (s *ShardStore) initStores(baseStore store.Store, shards map[string]store.Store) {
+	s.Store = baseStore
+	s.shards = shards
+	s.post = ShardPostStore{PostStore: baseStore.Post(), rootStore: s}
+	s.reaction = ShardReactionStore{ReactionStore: baseStore.Reaction(), rootStore: s}
+	s.fileInfo = ShardFileInfoStore{FileInfoStore: baseStore.FileInfo(), rootStore: s}
+	s.channel = ShardChannelStore{ChannelStore: baseStore.Channel(), rootStore: s}
+	s.preference = ShardPreferenceStore{PreferenceStore: baseStore.Preference(), rootStore: s}
+}
+
+func (s ShardStore) Post() store.PostStore {
+	return s.post
+}
+
+func (s ShardStore) Reaction() store.ReactionStore {
+	return s.reaction
+}
+
+func (s ShardStore) FileInfo() store.FileInfoStore {
+	return s.fileInfo
+}
+
+func (s ShardStore) Channel() store.ChannelStore {
+	return s.channel
+}
+
+func (s ShardStore) Preference() store.PreferenceStore {
+	return s.preference
+}
+
+func (s ShardStore) MasterOnly() store.Store {
+	return *s.masterOnly
+}
+
+// WithQueryTimeout returns a view of this store whose base store and shards run their queries
+// with the given timeout.
+func (s ShardStore) WithQueryTimeout(timeout time.Duration) store.Store {
+	withQueryTimeout := func(shards map[string]store.Store) map[string]store.Store {
+		views := make(map[string]store.Store, len(shards))
+		for name, shard := range shards {
+			views[name] = store.WithQueryTimeout(shard, timeout)
+		}
+		return views
+	}
+
+	view := s
+	view.initStores(store.WithQueryTimeout(s.Store, timeout), withQueryTimeout(s.shards))
+
+	masterOnly := *s.masterOnly
+	masterOnly.initStores(store.WithQueryTimeout(s.masterOnly.Store, timeout), withQueryTimeout(s.masterOnly.shards))
+	masterOnly.masterOnly = &masterOnly
+	view.masterOnly = &masterOnly
+
+	return view
+}
+
+func (s ShardStore) Close() {
+	s.Store.Close()
+	for _, name := range s.shardNames {
+		s.shards[name].Close()
+	}
+}
+
+func (s ShardStore) DropAllTables() {
+	s.Store.DropAllTables()
+	for _, name := range s.shardNames {
+		s.shards[name].DropAllTables()
+	}
+}
+
+func (s ShardStore) LockToMaster() {
+	s.Store.LockToMaster()
+	for _, name := range s.shardNames {
+		s.shards[name].LockToMaster()
+	}
+}
+
+func (s ShardStore) UnlockFromMaster() {
+	s.Store.UnlockFromMaster()
+	for _, name := range s.shardNames {
+		s.shards[name].UnlockFromMaster()
+	}
+}
+
+// ShardNames returns the names of the configured shards, in order.
+func (s *ShardStore) ShardNames() []string {
+	return s.shardNames
+}
+
+// PickChannelShard returns the shard the posts of the channel are placed on.
+func (s *ShardStore) PickChannelShard(channelId string, teamId string) string {
+	return model.PickPostShard(model.PostShardKey(channelId, teamId, s.shardKey), s.shardNames)
+}
+
+// shardStore returns the store of the named shard, or the main store for the main database.
+func (s *ShardStore) shardStore(shard string) (store.Store, *model.AppError) {
+	if shard == "" || shard == model.POST_SHARD_MAIN {
+		return s.Store, nil
+	}
+
+	shardStore, ok := s.shards[shard]
+	if !ok {
+		return nil, model.NewAppError("ShardStore.shardStore", "store.shard_layer.unknown_shard.app_error", map[string]interface{}{"Name": shard}, "", http.StatusInternalServerError)
+	}
+
+	return shardStore, nil
+}
+
+// locations returns the names of the main database, as "", and of each shard.
+func (s *ShardStore) locations() []string {
+	return append([]string{""}, s.shardNames...)
+}
+
+// channelShard returns the shard holding the posts of the channel, or "" for the main database.
+func (s *ShardStore) channelShard(channelId string) (string, *model.AppError) {
+	if shard, ok := s.channelShardCache.Get(channelId); ok {
+		return shard.(string), nil
+	}
+
+	channelShard, err := s.Store.PostShard().GetChannelShard(channelId)
+	if err != nil {
+		return "", err
+	}
+
+	s.channelShardCache.AddWithDefaultExpires(channelId, channelShard.Shard)
+
+	return channelShard.Shard, nil
+}
+
+// channelStore returns the store holding the posts of the channel.
+func (s *ShardStore) channelStore(channelId string) (store.Store, *model.AppError) {
+	shard, err := s.channelShard(channelId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.shardStore(shard)
+}
+
+// postChannel returns the channel of the post, or "" when the post is nowhere to be found.
+func (s *ShardStore) postChannel(postId string) (string, *model.AppError) {
+	if channelId, ok := s.postChannelCache.Get(postId); ok {
+		return channelId.(string), nil
+	}
+
+	posts, err := s.post.GetPostsByIds([]string{postId})
+	if err != nil {
+		return "", err
+	}
+
+	for _, post := range posts {
+		if post.Id == postId {
+			s.postChannelCache.AddWithDefaultExpires(postId, post.ChannelId)
+			return post.ChannelId, nil
+		}
+	}
+
+	return "", nil
+}
+
+// postShard returns the shard holding the post, or "" for the main database, where posts that are
+// nowhere to be found are reported as missing.
+func (s *ShardStore) postShard(postId string) (string, *model.AppError) {
+	channelId, err := s.postChannel(postId)
+	if err != nil || channelId == "" {
+		return "", err
+	}
+
+	return s.channelShard(channelId)
+}
+
+// postStore returns the store holding the post.
+func (s *ShardStore) postStore(postId string) (store.Store, *model.AppError) {
+	shard, err := s.postShard(postId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.shardStore(shard)
+}
+
+// previousPostShard returns the shard the channel of the post is being moved from, or "" for the
+// main database, and whether the channel is being moved at all.
+func (s *ShardStore) previousPostShard(postId string) (string, bool, *model.AppError) {
+	channelId, err := s.postChannel(postId)
+	if err != nil || channelId == "" {
+		return "", false, err
+	}
+
+	channelShard, err := s.Store.PostShard().GetChannelShard(channelId)
+	if err != nil || channelShard.PreviousShard == "" {
+		return "", false, err
+	}
+
+	if channelShard.PreviousShard == model.POST_SHARD_MAIN {
+		return "", true, nil
+	}
+
+	return channelShard.PreviousShard, true, nil
+}
+
+// holdsChannel returns whether the posts of the channel are on the given shard. Channels moved away
+// from a shard are left there until the move is finished, and are skipped when fanning out.
+func (s *ShardStore) holdsChannel(shard string, channelId string) (bool, *model.AppError) {
+	channelShard, err := s.channelShard(channelId)
+	if err != nil {
+		return false, err
+	}
+
+	return channelShard == shard, nil
+}
+
+// fanOut calls f with the main database and each shard at the same time, and returns the first
+// error, if any.
+func (s *ShardStore) fanOut(f func(i int, shard string, shardStore store.Store) *model.AppError) *model.AppError {
+	locations := s.locations()
+	errs := make([]*model.AppError, len(locations))
+
+	var wg sync.WaitGroup
+	for i, shard := range locations {
+		wg.Add(1)
+		go func(i int, shard string) {
+			defer wg.Done()
+
+			shardStore, err := s.shardStore(shard)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			errs[i] = f(i, shard, shardStore)
+		}(i, shard)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteBatch deletes up to limit rows through each store in turn, as the batches of the data
+// retention job are limited across all of them.
+func (s *ShardStore) deleteBatch(limit int64, deleteFrom func(shardStore store.Store, limit int64) (int64, *model.AppError)) (int64, *model.AppError) {
+	var deleted int64
+	for _, shard := range s.locations() {
+		if deleted >= limit {
+			break
+		}
+
+		shardStore, err := s.shardStore(shard)
+		if err != nil {
+			return deleted, err
+		}
+
+		count, err := deleteFrom(shardStore, limit-deleted)
+		if err != nil {
+			return deleted, err
+		}
+		deleted += count
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,439 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package shardlayer
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/mattermost/mattermost-server/store/sqlstore"
+	"github.com/mattermost/mattermost-server/store/storetest"
+)
+
+// testShardStore is a shard store over temporary SQLite databases, with the given shards.
+type testShardStore struct {
+	ShardStore
+	main   store.Store
+	shards map[string]store.Store
+	files  []string
+}
+
+func newTestShardStore(t *testing.T, shardNames ...string) *testShardStore {
+	if !storetest.TestDriverEnabled(model.DATABASE_DRIVER_SQLITE) {
+		t.Skip("the shard layer is tested against SQLite databases")
+	}
+
+	ts := &testShardStore{shards: make(map[string]store.Store)}
+	open := func() store.Store {
+		settings := storetest.MakeSqlSettings(model.DATABASE_DRIVER_SQLITE)
+		ts.files = append(ts.files, *settings.DataSource)
+		return store.NewLayeredStore(sqlstore.NewSqlSupplier(*settings, nil), nil, nil)
+	}
+
+	ts.main = open()
+	for _, name := range shardNames {
+		ts.shards[name] = open()
+	}
+	ts.ShardStore = NewShardLayer(ts.main, ts.shards, model.POST_SHARD_KEY_CHANNEL)
+
+	return ts
+}
+
+func (ts *testShardStore) Close() {
+	ts.ShardStore.Close()
+	for _, dataSource := range ts.files {
+		os.Remove(sqliteFile(dataSource))
+	}
+}
+
+func sqliteFile(dataSource string) string {
+	return strings.TrimPrefix(strings.SplitN(dataSource, "?", 2)[0], "file:")
+}
+
+func (ts *testShardStore) saveChannel(t *testing.T, teamId string) *model.Channel {
+	channel, err := ts.Channel().Save(&model.Channel{TeamId: teamId, DisplayName: "Channel", Name: "zz" + model.NewId() + "b", Type: model.CHANNEL_OPEN}, -1)
+	require.Nil(t, err)
+	return channel
+}
+
+func (ts *testShardStore) savePost(t *testing.T, channelId string, userId string, message string) *model.Post {
+	post, err := ts.Post().Save(&model.Post{ChannelId: channelId, UserId: userId, Message: message})
+	require.Nil(t, err)
+	return post
+}
+
+func TestShardStorePlacement(t *testing.T) {
+	ts := newTestShardStore(t, "shard1", "shard2")
+	defer ts.Close()
+
+	channel := ts.saveChannel(t, model.NewId())
+	shard := ts.PickChannelShard(channel.Id, channel.TeamId)
+	require.NotEmpty(t, shard)
+
+	channelShard, err := ts.main.PostShard().GetChannelShard(channel.Id)
+	require.Nil(t, err)
+	assert.Equal(t, shard, channelShard.Shard)
+
+	t.Run("the channel is copied to its shard", func(t *testing.T) {
+		mirror, err := ts.shards[shard].Channel().GetFromMaster(channel.Id)
+		require.Nil(t, err)
+		assert.Equal(t, channel.Name, mirror.Name)
+
+		channel.DisplayName = "Renamed"
+		_, err = ts.Channel().Update(channel)
+		require.Nil(t, err)
+
+		mirror, err = ts.shards[shard].Channel().GetFromMaster(channel.Id)
+		require.Nil(t, err)
+		assert.Equal(t, "Renamed", mirror.DisplayName)
+	})
+
+	userId := model.NewId()
+	post := ts.savePost(t, channel.Id, userId, "hello shards")
+
+	t.Run("posts are saved to the shard of their channel", func(t *testing.T) {
+		_, err := ts.main.Post().GetSingle(post.Id)
+		require.NotNil(t, err)
+
+		saved, err := ts.shards[shard].Post().GetSingle(post.Id)
+		require.Nil(t, err)
+		assert.Equal(t, post.Message, saved.Message)
+
+		found, err := ts.Post().GetSingle(post.Id)
+		require.Nil(t, err)
+		assert.Equal(t, post.Message, found.Message)
+
+		list, err := ts.Post().GetPosts(channel.Id, 0, 10, false)
+		require.Nil(t, err)
+		assert.Equal(t, []string{post.Id}, list.Order)
+	})
+
+	t.Run("the channel in the main database tracks its last post", func(t *testing.T) {
+		mainChannel, err := ts.main.Channel().GetFromMaster(channel.Id)
+		require.Nil(t, err)
+		assert.Equal(t, post.CreateAt, mainChannel.LastPostAt)
+		assert.Equal(t, int64(1), mainChannel.TotalMsgCount)
+	})
+
+	t.Run("reactions and file infos follow their post", func(t *testing.T) {
+		_, err := ts.Reaction().Save(&model.Reaction{UserId: userId, PostId: post.Id, EmojiName: "smile"})
+		require.Nil(t, err)
+
+		reactions, err := ts.shards[shard].Reaction().GetForPost(post.Id, false)
+		require.Nil(t, err)
+		assert.Len(t, reactions, 1)
+
+		reactions, err = ts.Reaction().BulkGetForPosts([]string{post.Id})
+		require.Nil(t, err)
+		assert.Len(t, reactions, 1)
+
+		// Files are uploaded before the post they are attached to is created.
+		info, err := ts.FileInfo().Save(&model.FileInfo{CreatorId: userId, Path: "file.txt"})
+		require.Nil(t, err)
+		_, err = ts.main.FileInfo().Get(info.Id)
+		require.Nil(t, err)
+
+		require.Nil(t, ts.FileInfo().AttachToPost(info.Id, post.Id, userId))
+
+		_, err = ts.main.FileInfo().Get(info.Id)
+		require.NotNil(t, err)
+
+		infos, err := ts.FileInfo().GetForPost(post.Id, true, false, false)
+		require.Nil(t, err)
+		require.Len(t, infos, 1)
+		assert.Equal(t, info.Id, infos[0].Id)
+
+		found, err := ts.FileInfo().Get(info.Id)
+		require.Nil(t, err)
+		assert.Equal(t, post.Id, found.PostId)
+	})
+
+	t.Run("the channel of a post is found on its shard", func(t *testing.T) {
+		found, err := ts.Channel().GetForPost(post.Id)
+		require.Nil(t, err)
+		assert.Equal(t, channel.Id, found.Id)
+	})
+}
+
+func TestShardStoreFanOut(t *testing.T) {
+	ts := newTestShardStore(t, "shard1", "shard2", "shard3")
+	defer ts.Close()
+
+	teamId := model.NewId()
+	userId := model.NewId()
+
+	var channels []*model.Channel
+	var posts []*model.Post
+	for i := 0; i < 6; i++ {
+		channel := ts.saveChannel(t, teamId)
+		_, err := ts.Channel().SaveMember(&model.ChannelMember{ChannelId: channel.Id, UserId: userId, NotifyProps: model.GetDefaultChannelNotifyProps()})
+		require.Nil(t, err)
+
+		channels = append(channels, channel)
+		posts = append(posts, ts.savePost(t, channel.Id, userId, "fanout message "+model.NewId()))
+	}
+
+	shardsUsed := make(map[string]bool)
+	for _, channel := range channels {
+		shardsUsed[ts.PickChannelShard(channel.Id, teamId)] = true
+	}
+	require.True(t, len(shardsUsed) > 1, "the channels should be spread over several shards")
+
+	t.Run("GetPostsByIds", func(t *testing.T) {
+		var postIds []string
+		for _, post := range posts {
+			postIds = append(postIds, post.Id)
+		}
+
+		found, err := ts.Post().GetPostsByIds(postIds)
+		require.Nil(t, err)
+		assert.Len(t, found, len(posts))
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		list, err := ts.Post().Search(teamId, userId, &model.SearchParams{Terms: "fanout"})
+		require.Nil(t, err)
+		assert.Len(t, list.Order, len(posts))
+
+		list, err = ts.Post().Search(teamId, userId, &model.SearchParams{Terms: "fanout", InChannels: []string{channels[0].Name}})
+		require.Nil(t, err)
+		assert.Equal(t, []string{posts[0].Id}, list.Order)
+	})
+
+	t.Run("SearchArchive", func(t *testing.T) {
+		var archivedIds []string
+		for _, channel := range channels[:3] {
+			post := ts.savePost(t, channel.Id, userId, "shelved message "+model.NewId())
+			require.Nil(t, ts.Post().Archive(model.NewPostArchivePath(channel.Id, model.GetMillis()), []*model.Post{post}, model.GetMillis()+1))
+			archivedIds = append(archivedIds, post.Id)
+		}
+
+		indexes, err := ts.Post().SearchArchive(teamId, userId, &model.SearchParams{Terms: "shelved"})
+		require.Nil(t, err)
+
+		var foundIds []string
+		for _, index := range indexes {
+			foundIds = append(foundIds, index.PostId)
+		}
+		assert.ElementsMatch(t, archivedIds, foundIds)
+
+		indexes, err = ts.Post().SearchArchive(teamId, userId, &model.SearchParams{Terms: "shelved", InChannels: []string{channels[1].Name}})
+		require.Nil(t, err)
+		require.Len(t, indexes, 1)
+		assert.Equal(t, archivedIds[1], indexes[0].PostId)
+	})
+
+	t.Run("AnalyticsPostCount", func(t *testing.T) {
+		count, err := ts.Post().AnalyticsPostCount(teamId, false, false)
+		require.Nil(t, err)
+		assert.Equal(t, int64(len(posts)), count)
+	})
+
+	t.Run("GetFlaggedPosts", func(t *testing.T) {
+		preferences := model.Preferences{
+			{UserId: userId, Category: model.PREFERENCE_CATEGORY_FLAGGED_POST, Name: posts[1].Id, Value: "true"},
+			{UserId: userId, Category: model.PREFERENCE_CATEGORY_FLAGGED_POST, Name: posts[4].Id, Value: "true"},
+		}
+		require.Nil(t, ts.Preference().Save(&preferences))
+
+		list, err := ts.Post().GetFlaggedPosts(userId, 0, 10)
+		require.Nil(t, err)
+		assert.Equal(t, []string{posts[4].Id, posts[1].Id}, list.Order)
+
+		list, err = ts.Post().GetFlaggedPosts(userId, 1, 10)
+		require.Nil(t, err)
+		assert.Equal(t, []string{posts[1].Id}, list.Order)
+
+		list, err = ts.Post().GetFlaggedPostsForTeam(userId, model.NewId(), 0, 10)
+		require.Nil(t, err)
+		assert.Empty(t, list.Order)
+
+		list, err = ts.Post().GetFlaggedPostsForChannel(userId, channels[1].Id, 0, 10)
+		require.Nil(t, err)
+		assert.Equal(t, []string{posts[1].Id}, list.Order)
+
+		// Flags of posts on other shards are not cleaned up as if the posts were deleted.
+		deleted, err := ts.Preference().CleanupFlagsBatch(100)
+		require.Nil(t, err)
+		assert.Equal(t, int64(0), deleted)
+	})
+
+	t.Run("GetThreadsForArchival", func(t *testing.T) {
+		endTime := model.GetMillis() + 1
+
+		var archivable []string
+		var afterCreateAt int64
+		afterId := ""
+		for {
+			threads, err := ts.Post().GetThreadsForArchival(endTime, afterCreateAt, afterId, 2)
+			require.Nil(t, err)
+			require.True(t, len(threads) <= 2)
+			if len(threads) == 0 {
+				break
+			}
+
+			for _, post := range threads {
+				archivable = append(archivable, post.Id)
+			}
+			last := threads[len(threads)-1]
+			afterCreateAt, afterId = last.CreateAt, last.Id
+		}
+
+		var postIds []string
+		for _, post := range posts {
+			postIds = append(postIds, post.Id)
+		}
+		assert.ElementsMatch(t, postIds, archivable, "every thread of every shard should be paged through once")
+	})
+
+	t.Run("PermanentDeleteBatch", func(t *testing.T) {
+		deleted, err := ts.Post().PermanentDeleteBatch(model.GetMillis()+1, 4)
+		require.Nil(t, err)
+		assert.Equal(t, int64(4), deleted)
+
+		deleted, err = ts.Post().PermanentDeleteBatch(model.GetMillis()+1, 10)
+		require.Nil(t, err)
+		assert.Equal(t, int64(len(posts)-4), deleted)
+	})
+}
+
+func TestShardStoreMoveChannel(t *testing.T) {
+	ts := newTestShardStore(t, "shard1", "shard2")
+	defer ts.Close()
+
+	channel := ts.saveChannel(t, model.NewId())
+	from := ts.PickChannelShard(channel.Id, channel.TeamId)
+	to := "shard1"
+	if from == to {
+		to = "shard2"
+	}
+
+	userId := model.NewId()
+	post := ts.savePost(t, channel.Id, userId, "moving")
+	_, err := ts.Reaction().Save(&model.Reaction{UserId: userId, PostId: post.Id, EmojiName: "smile"})
+	require.Nil(t, err)
+
+	require.Nil(t, ts.MoveChannel(channel.Id, to))
+
+	channelShard, err := ts.main.PostShard().GetChannelShard(channel.Id)
+	require.Nil(t, err)
+	assert.Equal(t, to, channelShard.Shard)
+	assert.Equal(t, from, channelShard.PreviousShard)
+
+	// Posts written to the previous shard until other servers route the channel to its new
+	// shard are copied when the move is finished.
+	late, err := ts.shards[from].Post().Save(&model.Post{ChannelId: channel.Id, UserId: userId, Message: "late"})
+	require.Nil(t, err)
+
+	t.Run("posts left on the previous shard are not found twice", func(t *testing.T) {
+		found, err := ts.Post().GetPostsByIds([]string{post.Id})
+		require.Nil(t, err)
+		assert.Len(t, found, 1)
+	})
+
+	require.Nil(t, ts.FinishMove(channel.Id))
+
+	channelShard, err = ts.main.PostShard().GetChannelShard(channel.Id)
+	require.Nil(t, err)
+	assert.Equal(t, to, channelShard.Shard)
+	assert.Equal(t, "", channelShard.PreviousShard)
+
+	list, err := ts.Post().GetPosts(channel.Id, 0, 10, false)
+	require.Nil(t, err)
+	assert.ElementsMatch(t, []string{post.Id, late.Id}, list.Order)
+
+	reactions, err := ts.Reaction().GetForPost(post.Id, false)
+	require.Nil(t, err)
+	assert.Len(t, reactions, 1)
+
+	_, err = ts.shards[from].Post().GetSingle(post.Id)
+	require.NotNil(t, err)
+	_, err = ts.shards[from].Channel().GetFromMaster(channel.Id)
+	require.NotNil(t, err)
+
+	t.Run("moving back to the main database", func(t *testing.T) {
+		require.Nil(t, ts.MoveChannel(channel.Id, ""))
+		require.Nil(t, ts.FinishMove(channel.Id))
+
+		channelShard, err := ts.main.PostShard().GetChannelShard(channel.Id)
+		require.Nil(t, err)
+		assert.Equal(t, "", channelShard.Shard)
+
+		_, err = ts.main.Post().GetSingle(post.Id)
+		require.Nil(t, err)
+		_, err = ts.main.Channel().GetFromMaster(channel.Id)
+		require.Nil(t, err)
+		_, err = ts.shards[to].Channel().GetFromMaster(channel.Id)
+		require.NotNil(t, err)
+	})
+}
+
+func TestShardStoreMoveChannelWrites(t *testing.T) {
+	ts := newTestShardStore(t, "shard1", "shard2")
+	defer ts.Close()
+
+	channel := ts.saveChannel(t, model.NewId())
+	from := ts.PickChannelShard(channel.Id, channel.TeamId)
+	to := "shard1"
+	if from == to {
+		to = "shard2"
+	}
+
+	userId := model.NewId()
+	post := ts.savePost(t, channel.Id, userId, "moving")
+	deleted := ts.savePost(t, channel.Id, userId, "deleted")
+	smile := &model.Reaction{UserId: userId, PostId: post.Id, EmojiName: "smile"}
+	heart := &model.Reaction{UserId: userId, PostId: post.Id, EmojiName: "heart"}
+	for _, reaction := range []*model.Reaction{smile, heart} {
+		_, err := ts.Reaction().Save(reaction)
+		require.Nil(t, err)
+	}
+
+	// Separates the writes below from the move, as their order is told by their time.
+	time.Sleep(2 * time.Millisecond)
+	require.Nil(t, ts.MoveChannel(channel.Id, to))
+	time.Sleep(2 * time.Millisecond)
+
+	// Servers routing the channel to its new shard react to the post and remove a reaction.
+	_, err := ts.Reaction().Save(&model.Reaction{UserId: userId, PostId: post.Id, EmojiName: "wave"})
+	require.Nil(t, err)
+	_, err = ts.Reaction().Delete(heart)
+	require.Nil(t, err)
+	time.Sleep(2 * time.Millisecond)
+
+	// Servers still routing the channel to its previous shard edit and delete posts, and remove a
+	// reaction.
+	previous := ts.shards[from]
+	_, err = previous.Reaction().Delete(smile)
+	require.Nil(t, err)
+	oldPost, err := previous.Post().GetSingle(post.Id)
+	require.Nil(t, err)
+	edited := oldPost.Clone()
+	edited.Message = "edited"
+	_, err = previous.Post().Update(edited, oldPost)
+	require.Nil(t, err)
+	require.Nil(t, previous.Post().Delete(deleted.Id, model.GetMillis(), userId))
+
+	require.Nil(t, ts.FinishMove(channel.Id))
+
+	saved, err := ts.shards[to].Post().GetSingle(post.Id)
+	require.Nil(t, err)
+	assert.Equal(t, "edited", saved.Message)
+	assert.True(t, saved.HasReactions)
+
+	_, err = ts.shards[to].Post().GetSingle(deleted.Id)
+	assert.NotNil(t, err, "the post deleted on the previous shard should stay deleted")
+
+	reactions, err := ts.Reaction().GetForPost(post.Id, false)
+	require.Nil(t, err)
+	require.Len(t, reactions, 1)
+	assert.Equal(t, "wave", reactions[0].EmojiName)
+}
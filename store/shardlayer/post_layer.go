@@ -0,0 +1,847 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package shardlayer
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+const (
+	POST_SEARCH_LIMIT    = 100
+	ANALYTICS_ROWS_LIMIT = 30
+)
+
+type ShardPostStore struct {
+	store.PostStore
+	rootStore *ShardStore
+}
+
+// heldPosts returns those of the posts read from the shard whose channel is held there.
+func (s ShardPostStore) heldPosts(shard string, posts []*model.Post) ([]*model.Post, *model.AppError) {
+	held := posts[:0:0]
+	for _, post := range posts {
+		ok, err := s.rootStore.holdsChannel(shard, post.ChannelId)
+		if err != nil {
+			return nil, err
+		} else if ok {
+			held = append(held, post)
+		}
+	}
+
+	return held, nil
+}
+
+func sortPostsByCreateAtDesc(posts []*model.Post) {
+	sort.Slice(posts, func(i, j int) bool {
+		if posts[i].CreateAt != posts[j].CreateAt {
+			return posts[i].CreateAt > posts[j].CreateAt
+		}
+		return posts[i].Id > posts[j].Id
+	})
+}
+
+func newPostList(posts []*model.Post) *model.PostList {
+	list := model.NewPostList()
+	for _, post := range posts {
+		list.AddPost(post)
+		list.AddOrder(post.Id)
+	}
+
+	return list
+}
+
+func (s ShardPostStore) Save(post *model.Post) (*model.Post, *model.AppError) {
+	shard, err := s.rootStore.channelShard(post.ChannelId)
+	if err != nil {
+		return nil, err
+	} else if shard == "" {
+		return s.PostStore.Save(post)
+	}
+
+	shardStore, err := s.rootStore.shardStore(shard)
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := shardStore.Post().Save(post)
+	if err != nil {
+		return nil, err
+	}
+
+	// The channel is kept in the main database, so its last post time is updated there.
+	if err := s.rootStore.Store.PostShard().UpdateChannelLastPostAt(saved.ChannelId, saved.CreateAt, !saved.IsJoinLeaveMessage()); err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+func (s ShardPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, *model.AppError) {
+	shard, err := s.rootStore.channelShard(newPost.ChannelId)
+	if err != nil {
+		return nil, err
+	} else if shard == "" {
+		return s.PostStore.Update(newPost, oldPost)
+	}
+
+	shardStore, err := s.rootStore.shardStore(shard)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := shardStore.Post().Update(newPost, oldPost)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.rootStore.Store.PostShard().UpdateChannelLastPostAt(updated.ChannelId, updated.UpdateAt, false); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s ShardPostStore) Get(id string) (*model.PostList, *model.AppError) {
+	shardStore, err := s.rootStore.postStore(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return shardStore.Post().Get(id)
+}
+
+func (s ShardPostStore) GetSingle(id string) (*model.Post, *model.AppError) {
+	shardStore, err := s.rootStore.postStore(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return shardStore.Post().GetSingle(id)
+}
+
+func (s ShardPostStore) Delete(postId string, time int64, deleteByID string) *model.AppError {
+	shardStore, err := s.rootStore.postStore(postId)
+	if err != nil {
+		return err
+	}
+
+	return shardStore.Post().Delete(postId, time, deleteByID)
+}
+
+func (s ShardPostStore) PermanentDeleteByUser(userId string) *model.AppError {
+	return s.rootStore.fanOut(func(i int, shard string, shardStore store.Store) *model.AppError {
+		return shardStore.Post().PermanentDeleteByUser(userId)
+	})
+}
+
+func (s ShardPostStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	shardStore, err := s.rootStore.channelStore(channelId)
+	if err != nil {
+		return err
+	}
+
+	return shardStore.Post().PermanentDeleteByChannel(channelId)
+}
+
+func (s ShardPostStore) GetPosts(channelId string, offset int, limit int, allowFromCache bool) (*model.PostList, *model.AppError) {
+	shardStore, err := s.rootStore.channelStore(channelId)
+	if err != nil {
+		return nil, err
+	}
+
+	return shardStore.Post().GetPosts(channelId, offset, limit, allowFromCache)
+}
+
+// getFlaggedPosts returns the posts flagged by the user that are kept by keep, newest first. The
+// flags are kept in the main database, and the posts are looked up on every shard.
+func (s ShardPostStore) getFlaggedPosts(userId string, offset int, limit int, keep func(posts []*model.Post) ([]*model.Post, *model.AppError)) (*model.PostList, *model.AppError) {
+	preferences, err := s.rootStore.Store.Preference().GetCategory(userId, model.PREFERENCE_CATEGORY_FLAGGED_POST)
+	if err != nil {
+		return nil, err
+	}
+
+	postIds := make([]string, 0, len(preferences))
+	for _, preference := range preferences {
+		postIds = append(postIds, preference.Name)
+	}
+	if len(postIds) == 0 {
+		return model.NewPostList(), nil
+	}
+
+	found, err := s.GetPostsByIds(postIds)
+	if err != nil {
+		return nil, err
+	}
+
+	posts := found[:0]
+	for _, post := range found {
+		if post.DeleteAt == 0 {
+			posts = append(posts, post)
+		}
+	}
+
+	if keep != nil {
+		if posts, err = keep(posts); err != nil {
+			return nil, err
+		}
+	}
+
+	sortPostsByCreateAtDesc(posts)
+
+	if offset >= len(posts) {
+		return model.NewPostList(), nil
+	}
+	posts = posts[offset:]
+	if limit < len(posts) {
+		posts = posts[:limit]
+	}
+
+	return newPostList(posts), nil
+}
+
+func (s ShardPostStore) GetFlaggedPosts(userId string, offset int, limit int) (*model.PostList, *model.AppError) {
+	return s.getFlaggedPosts(userId, offset, limit, nil)
+}
+
+func (s ShardPostStore) GetFlaggedPostsForTeam(userId, teamId string, offset int, limit int) (*model.PostList, *model.AppError) {
+	return s.getFlaggedPosts(userId, offset, limit, func(posts []*model.Post) ([]*model.Post, *model.AppError) {
+		var channelIds []string
+		seen := make(map[string]bool)
+		for _, post := range posts {
+			if !seen[post.ChannelId] {
+				seen[post.ChannelId] = true
+				channelIds = append(channelIds, post.ChannelId)
+			}
+		}
+		if len(channelIds) == 0 {
+			return posts, nil
+		}
+
+		channels, err := s.rootStore.Store.Channel().GetChannelsByIds(channelIds)
+		if err != nil {
+			return nil, err
+		}
+
+		// Direct and group channels belong to no team, and are shown on every team.
+		inTeam := make(map[string]bool, len(channels))
+		for _, channel := range channels {
+			inTeam[channel.Id] = channel.TeamId == teamId || channel.TeamId == ""
+		}
+
+		kept := posts[:0]
+		for _, post := range posts {
+			if inTeam[post.ChannelId] {
+				kept = append(kept, post)
+			}
+		}
+
+		return kept, nil
+	})
+}
+
+func (s ShardPostStore) GetFlaggedPostsForChannel(userId, channelId string, offset int, limit int) (*model.PostList, *model.AppError) {
+	return s.getFlaggedPosts(userId, offset, limit, func(posts []*model.Post) ([]*model.Post, *model.AppError) {
+		kept := posts[:0]
+		for _, post := range posts {
+			if post.ChannelId == channelId {
+				kept = append(kept, post)
+			}
+		}
+
+		return kept, nil
+	})
+}
+
+func (s ShardPostStore) GetPostsBefore(channelId string, postId string, numPosts int, offset int) (*model.PostList, *model.AppError) {
+	shardStore, err := s.rootStore.channelStore(channelId)
+	if err != nil {
+		return nil, err
+	}
+
+	return shardStore.Post().GetPostsBefore(channelId, postId, numPosts, offset)
+}
+
+func (s ShardPostStore) GetPostsAfter(channelId string, postId string, numPosts int, offset int) (*model.PostList, *model.AppError) {
+	shardStore, err := s.rootStore.channelStore(channelId)
+	if err != nil {
+		return nil, err
+	}
+
+	return shardStore.Post().GetPostsAfter(channelId, postId, numPosts, offset)
+}
+
+func (s ShardPostStore) GetPostsSince(channelId string, time int64, allowFromCache bool) (*model.PostList, *model.AppError) {
+	shardStore, err := s.rootStore.channelStore(channelId)
+	if err != nil {
+		return nil, err
+	}
+
+	return shardStore.Post().GetPostsSince(channelId, time, allowFromCache)
+}
+
+func (s ShardPostStore) GetPostAfterTime(channelId string, time int64) (*model.Post, *model.AppError) {
+	shardStore, err := s.rootStore.channelStore(channelId)
+	if err != nil {
+		return nil, err
+	}
+
+	return shardStore.Post().GetPostAfterTime(channelId, time)
+}
+
+func (s ShardPostStore) GetPostIdAfterTime(channelId string, time int64) (string, *model.AppError) {
+	shardStore, err := s.rootStore.channelStore(channelId)
+	if err != nil {
+		return "", err
+	}
+
+	return shardStore.Post().GetPostIdAfterTime(channelId, time)
+}
+
+func (s ShardPostStore) GetPostIdBeforeTime(channelId string, time int64) (string, *model.AppError) {
+	shardStore, err := s.rootStore.channelStore(channelId)
+	if err != nil {
+		return "", err
+	}
+
+	return shardStore.Post().GetPostIdBeforeTime(channelId, time)
+}
+
+func (s ShardPostStore) GetEtag(channelId string, allowFromCache bool) string {
+	shardStore, err := s.rootStore.channelStore(channelId)
+	if err != nil {
+		// The main database returns an etag that always differs, so the posts are read again.
+		mlog.Error("Unable to find the shard of the channel", mlog.String("channel_id", channelId), mlog.Err(err))
+		return s.PostStore.GetEtag(channelId, allowFromCache)
+	}
+
+	return shardStore.Post().GetEtag(channelId, allowFromCache)
+}
+
+// Search resolves the channels and users searched in the main database, where they are kept, and
+// searches each shard for the posts of the channels it holds.
+func (s ShardPostStore) Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, *model.AppError) {
+	scopesByShard, err := s.searchScopesByShard(teamId, userId, params)
+	if err != nil {
+		return nil, err
+	}
+
+	lists := make([]*model.PostList, len(s.rootStore.locations()))
+	if err := s.rootStore.fanOut(func(i int, shard string, shardStore store.Store) *model.AppError {
+		shardScope, ok := scopesByShard[shard]
+		if !ok {
+			return nil
+		}
+
+		list, err := shardStore.Post().Search(teamId, userId, shardScope)
+		lists[i] = list
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	var posts []*model.Post
+	for _, list := range lists {
+		if list == nil {
+			continue
+		}
+		for _, postId := range list.Order {
+			posts = append(posts, list.Posts[postId])
+		}
+	}
+
+	sortPostsByCreateAtDesc(posts)
+	if len(posts) > POST_SEARCH_LIMIT {
+		posts = posts[:POST_SEARCH_LIMIT]
+	}
+
+	return newPostList(posts), nil
+}
+
+// SearchArchive searches the archive indexes of each shard as Search does its posts.
+func (s ShardPostStore) SearchArchive(teamId string, userId string, params *model.SearchParams) ([]*model.PostArchiveIndex, *model.AppError) {
+	scopesByShard, err := s.searchScopesByShard(teamId, userId, params)
+	if err != nil {
+		return nil, err
+	}
+
+	indexesByShard := make([][]*model.PostArchiveIndex, len(s.rootStore.locations()))
+	if err := s.rootStore.fanOut(func(i int, shard string, shardStore store.Store) *model.AppError {
+		shardScope, ok := scopesByShard[shard]
+		if !ok {
+			return nil
+		}
+
+		indexes, err := shardStore.Post().SearchArchive(teamId, userId, shardScope)
+		indexesByShard[i] = indexes
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	indexes := []*model.PostArchiveIndex{}
+	for _, shardIndexes := range indexesByShard {
+		indexes = append(indexes, shardIndexes...)
+	}
+
+	sort.Slice(indexes, func(i, j int) bool {
+		if indexes[i].CreateAt != indexes[j].CreateAt {
+			return indexes[i].CreateAt > indexes[j].CreateAt
+		}
+		return indexes[i].PostId > indexes[j].PostId
+	})
+	if len(indexes) > POST_SEARCH_LIMIT {
+		indexes = indexes[:POST_SEARCH_LIMIT]
+	}
+
+	return indexes, nil
+}
+
+// searchScopesByShard resolves the channels and users searched in the main database, and returns
+// the scope of the search on each of the shards holding any of the channels.
+func (s ShardPostStore) searchScopesByShard(teamId string, userId string, params *model.SearchParams) (map[string]*model.SearchParams, *model.AppError) {
+	scope, err := s.PostStore.ResolveSearchScope(teamId, userId, params)
+	if err != nil {
+		return nil, err
+	}
+
+	scopesByShard := make(map[string]*model.SearchParams)
+	for _, channelId := range scope.ChannelIds {
+		shard, err := s.rootStore.channelShard(channelId)
+		if err != nil {
+			return nil, err
+		}
+
+		shardScope, ok := scopesByShard[shard]
+		if !ok {
+			shardScope = &model.SearchParams{}
+			*shardScope = *scope
+			shardScope.ChannelIds = nil
+			scopesByShard[shard] = shardScope
+		}
+		shardScope.ChannelIds = append(shardScope.ChannelIds, channelId)
+	}
+
+	return scopesByShard, nil
+}
+
+// mergeAnalyticsRows adds up the rows of each shard by name, keeping the latest days as the
+// queries of each shard do.
+func mergeAnalyticsRows(rowsByShard []model.AnalyticsRows) model.AnalyticsRows {
+	var merged model.AnalyticsRows
+	byName := make(map[string]*model.AnalyticsRow)
+	for _, rows := range rowsByShard {
+		for _, row := range rows {
+			if mergedRow, ok := byName[row.Name]; ok {
+				mergedRow.Value += row.Value
+				continue
+			}
+
+			mergedRow := &model.AnalyticsRow{Name: row.Name, Value: row.Value}
+			byName[row.Name] = mergedRow
+			merged = append(merged, mergedRow)
+		}
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Name > merged[j].Name
+	})
+	if len(merged) > ANALYTICS_ROWS_LIMIT {
+		merged = merged[:ANALYTICS_ROWS_LIMIT]
+	}
+
+	return merged
+}
+
+// AnalyticsUserCountsWithPostsByDay adds up the users posting on each shard, so users posting in
+// channels on several shards on the same day are counted once for each of them.
+func (s ShardPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (model.AnalyticsRows, *model.AppError) {
+	rowsByShard := make([]model.AnalyticsRows, len(s.rootStore.locations()))
+	err := s.rootStore.fanOut(func(i int, shard string, shardStore store.Store) *model.AppError {
+		rows, err := shardStore.Post().AnalyticsUserCountsWithPostsByDay(teamId)
+		rowsByShard[i] = rows
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeAnalyticsRows(rowsByShard), nil
+}
+
+// AnalyticsPostCountsByDay adds up the posts of each shard. Bots are only kept in the main
+// database, so the posts of bots are only counted there.
+func (s ShardPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, *model.AppError) {
+	rowsByShard := make([]model.AnalyticsRows, len(s.rootStore.locations()))
+	err := s.rootStore.fanOut(func(i int, shard string, shardStore store.Store) *model.AppError {
+		rows, err := shardStore.Post().AnalyticsPostCountsByDay(options)
+		rowsByShard[i] = rows
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeAnalyticsRows(rowsByShard), nil
+}
+
+func (s ShardPostStore) AnalyticsPostCount(teamId string, mustHaveFile bool, mustHaveHashtag bool) (int64, *model.AppError) {
+	counts := make([]int64, len(s.rootStore.locations()))
+	err := s.rootStore.fanOut(func(i int, shard string, shardStore store.Store) *model.AppError {
+		count, err := shardStore.Post().AnalyticsPostCount(teamId, mustHaveFile, mustHaveHashtag)
+		counts[i] = count
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, count := range counts {
+		total += count
+	}
+
+	return total, nil
+}
+
+func (s ShardPostStore) ClearCaches() {
+	s.PostStore.ClearCaches()
+	for _, name := range s.rootStore.shardNames {
+		s.rootStore.shards[name].Post().ClearCaches()
+	}
+}
+
+func (s ShardPostStore) InvalidateLastPostTimeCache(channelId string) {
+	shardStore, err := s.rootStore.channelStore(channelId)
+	if err != nil {
+		mlog.Error("Unable to find the shard of the channel", mlog.String("channel_id", channelId), mlog.Err(err))
+		return
+	}
+
+	shardStore.Post().InvalidateLastPostTimeCache(channelId)
+}
+
+func (s ShardPostStore) GetPostsCreatedAt(channelId string, time int64) ([]*model.Post, *model.AppError) {
+	shardStore, err := s.rootStore.channelStore(channelId)
+	if err != nil {
+		return nil, err
+	}
+
+	return shardStore.Post().GetPostsCreatedAt(channelId, time)
+}
+
+func (s ShardPostStore) Overwrite(post *model.Post) (*model.Post, *model.AppError) {
+	shardStore, err := s.rootStore.channelStore(post.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	return shardStore.Post().Overwrite(post)
+}
+
+func (s ShardPostStore) GetPostsByIds(postIds []string) ([]*model.Post, *model.AppError) {
+	postsByShard := make([][]*model.Post, len(s.rootStore.locations()))
+	err := s.rootStore.fanOut(func(i int, shard string, shardStore store.Store) *model.AppError {
+		posts, err := shardStore.Post().GetPostsByIds(postIds)
+		if err != nil {
+			return err
+		}
+
+		postsByShard[i], err = s.heldPosts(shard, posts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var posts []*model.Post
+	for _, shardPosts := range postsByShard {
+		posts = append(posts, shardPosts...)
+	}
+	sortPostsByCreateAtDesc(posts)
+
+	return posts, nil
+}
+
+func (s ShardPostStore) GetPostsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.PostForIndexing, *model.AppError) {
+	postsByShard := make([][]*model.PostForIndexing, len(s.rootStore.locations()))
+	err := s.rootStore.fanOut(func(i int, shard string, shardStore store.Store) *model.AppError {
+		posts, err := shardStore.Post().GetPostsBatchForIndexing(startTime, endTime, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, post := range posts {
+			ok, err := s.rootStore.holdsChannel(shard, post.ChannelId)
+			if err != nil {
+				return err
+			} else if ok {
+				postsByShard[i] = append(postsByShard[i], post)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var posts []*model.PostForIndexing
+	for _, shardPosts := range postsByShard {
+		posts = append(posts, shardPosts...)
+	}
+
+	// Each shard returns its oldest posts, so the oldest of all of them make the batch.
+	sort.Slice(posts, func(i, j int) bool {
+		if posts[i].CreateAt != posts[j].CreateAt {
+			return posts[i].CreateAt < posts[j].CreateAt
+		}
+		return posts[i].Id < posts[j].Id
+	})
+	if len(posts) > limit {
+		posts = posts[:limit]
+	}
+
+	return posts, nil
+}
+
+func (s ShardPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	return s.rootStore.deleteBatch(limit, func(shardStore store.Store, limit int64) (int64, *model.AppError) {
+		return shardStore.Post().PermanentDeleteBatch(endTime, limit)
+	})
+}
+
+func (s ShardPostStore) GetOldest() (*model.Post, *model.AppError) {
+	oldestByShard := make([]*model.Post, len(s.rootStore.locations()))
+	errs := make([]*model.AppError, len(oldestByShard))
+	if err := s.rootStore.fanOut(func(i int, shard string, shardStore store.Store) *model.AppError {
+		// A shard without posts reports an error, which is only returned when all of them do.
+		oldestByShard[i], errs[i] = shardStore.Post().GetOldest()
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var oldest *model.Post
+	for _, post := range oldestByShard {
+		if post != nil && (oldest == nil || post.CreateAt < oldest.CreateAt) {
+			oldest = post
+		}
+	}
+	if oldest == nil {
+		return nil, errs[0]
+	}
+
+	return oldest, nil
+}
+
+// GetMaxPostSize returns the largest post size supported by every shard.
+func (s ShardPostStore) GetMaxPostSize() int {
+	maxPostSize := s.PostStore.GetMaxPostSize()
+	for _, name := range s.rootStore.shardNames {
+		if size := s.rootStore.shards[name].Post().GetMaxPostSize(); size < maxPostSize {
+			maxPostSize = size
+		}
+	}
+
+	return maxPostSize
+}
+
+func exportUnsupportedError(where string) *model.AppError {
+	return model.NewAppError(where, "store.shard_layer.export.app_error", nil, "", http.StatusNotImplemented)
+}
+
+// GetParentsForExportAfter is not supported across shards, as the posts of all channels are
+// exported in the order of their ids.
+func (s ShardPostStore) GetParentsForExportAfter(limit int, afterId string) ([]*model.PostForExport, *model.AppError) {
+	return nil, exportUnsupportedError("ShardPostStore.GetParentsForExportAfter")
+}
+
+func (s ShardPostStore) GetRepliesForExport(parentId string) ([]*model.ReplyForExport, *model.AppError) {
+	return nil, exportUnsupportedError("ShardPostStore.GetRepliesForExport")
+}
+
+func (s ShardPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string) ([]*model.DirectPostForExport, *model.AppError) {
+	return nil, exportUnsupportedError("ShardPostStore.GetDirectPostParentsForExportAfter")
+}
+
+func (s ShardPostStore) GetThreadsForArchival(endTime int64, afterCreateAt int64, afterId string, limit int) ([]*model.Post, *model.AppError) {
+	postsByShard := make([][]*model.Post, len(s.rootStore.locations()))
+	err := s.rootStore.fanOut(func(i int, shard string, shardStore store.Store) *model.AppError {
+		posts, err := shardStore.Post().GetThreadsForArchival(endTime, afterCreateAt, afterId, limit)
+		if err != nil {
+			return err
+		}
+
+		postsByShard[i], err = s.heldPosts(shard, posts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var roots []*model.Post
+	replies := make(map[string][]*model.Post)
+	for _, shardPosts := range postsByShard {
+		for _, post := range shardPosts {
+			if post.RootId == "" {
+				roots = append(roots, post)
+			} else {
+				replies[post.RootId] = append(replies[post.RootId], post)
+			}
+		}
+	}
+
+	// Only the first threads of all the shards are kept, so that the next page starts after the
+	// last of them without skipping the threads of another shard.
+	sort.Slice(roots, func(i, j int) bool {
+		if roots[i].CreateAt != roots[j].CreateAt {
+			return roots[i].CreateAt < roots[j].CreateAt
+		}
+		return roots[i].Id < roots[j].Id
+	})
+	if len(roots) > limit {
+		roots = roots[:limit]
+	}
+
+	posts := roots
+	for _, root := range roots {
+		posts = append(posts, replies[root.Id]...)
+	}
+
+	return posts, nil
+}
+
+func (s ShardPostStore) Archive(path string, posts []*model.Post, endTime int64) *model.AppError {
+	if len(posts) == 0 {
+		return s.PostStore.Archive(path, posts, endTime)
+	}
+
+	// Archive files hold the posts of a single channel.
+	shardStore, err := s.rootStore.channelStore(posts[0].ChannelId)
+	if err != nil {
+		return err
+	}
+
+	return shardStore.Post().Archive(path, posts, endTime)
+}
+
+func (s ShardPostStore) GetArchivePaths(postIds []string) (map[string]string, *model.AppError) {
+	pathsByShard := make([]map[string]string, len(s.rootStore.locations()))
+	err := s.rootStore.fanOut(func(i int, shard string, shardStore store.Store) *model.AppError {
+		paths, err := shardStore.Post().GetArchivePaths(postIds)
+		pathsByShard[i] = paths
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make(map[string]string)
+	for _, shardPaths := range pathsByShard {
+		for postId, path := range shardPaths {
+			paths[postId] = path
+		}
+	}
+
+	return paths, nil
+}
+
+func (s ShardPostStore) GetArchivePathsForChannel(channelId string) ([]string, *model.AppError) {
+	if channelId != "" {
+		shardStore, err := s.rootStore.channelStore(channelId)
+		if err != nil {
+			return nil, err
+		}
+
+		return shardStore.Post().GetArchivePathsForChannel(channelId)
+	}
+
+	pathsByShard := make([][]string, len(s.rootStore.locations()))
+	err := s.rootStore.fanOut(func(i int, shard string, shardStore store.Store) *model.AppError {
+		paths, err := shardStore.Post().GetArchivePathsForChannel(channelId)
+		pathsByShard[i] = paths
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	seen := make(map[string]bool)
+	for _, shardPaths := range pathsByShard {
+		for _, path := range shardPaths {
+			if !seen[path] {
+				seen[path] = true
+				paths = append(paths, path)
+			}
+		}
+	}
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+func (s ShardPostStore) GetArchivePathsBefore(endTime int64, limit int) ([]string, *model.AppError) {
+	pathsByShard := make([][]string, len(s.rootStore.locations()))
+	err := s.rootStore.fanOut(func(i int, shard string, shardStore store.Store) *model.AppError {
+		paths, err := shardStore.Post().GetArchivePathsBefore(endTime, limit)
+		pathsByShard[i] = paths
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	seen := make(map[string]bool)
+	for _, shardPaths := range pathsByShard {
+		for _, path := range shardPaths {
+			if !seen[path] {
+				seen[path] = true
+				paths = append(paths, path)
+			}
+		}
+	}
+	sort.Strings(paths)
+	if len(paths) > limit {
+		paths = paths[:limit]
+	}
+
+	return paths, nil
+}
+
+func (s ShardPostStore) ReplaceArchive(path string, newPath string, posts []*model.Post) *model.AppError {
+	if len(posts) == 0 {
+		return s.rootStore.fanOut(func(i int, shard string, shardStore store.Store) *model.AppError {
+			return shardStore.Post().ReplaceArchive(path, newPath, posts)
+		})
+	}
+
+	shardStore, err := s.rootStore.channelStore(posts[0].ChannelId)
+	if err != nil {
+		return err
+	}
+
+	return shardStore.Post().ReplaceArchive(path, newPath, posts)
+}
+
+func (s ShardPostStore) Unarchive(path string, posts []*model.Post) *model.AppError {
+	if len(posts) == 0 {
+		return s.rootStore.fanOut(func(i int, shard string, shardStore store.Store) *model.AppError {
+			return shardStore.Post().Unarchive(path, posts)
+		})
+	}
+
+	shardStore, err := s.rootStore.channelStore(posts[0].ChannelId)
+	if err != nil {
+		return err
+	}
+
+	return shardStore.Post().Unarchive(path, posts)
+}
@@ -0,0 +1,20 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package shardlayer
+
+import (
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type ShardPreferenceStore struct {
+	store.PreferenceStore
+	rootStore *ShardStore
+}
+
+// CleanupFlagsBatch deletes nothing, as it deletes the flags of posts missing from the main
+// database, where the posts of channels on other shards are missing too.
+func (s ShardPreferenceStore) CleanupFlagsBatch(limit int64) (int64, *model.AppError) {
+	return 0, nil
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package shardlayer
+
+import (
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type ShardReactionStore struct {
+	store.ReactionStore
+	rootStore *ShardStore
+}
+
+func (s ShardReactionStore) Save(reaction *model.Reaction) (*model.Reaction, *model.AppError) {
+	shardStore, err := s.rootStore.postStore(reaction.PostId)
+	if err != nil {
+		return nil, err
+	}
+
+	return shardStore.Reaction().Save(reaction)
+}
+
+func (s ShardReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, *model.AppError) {
+	shard, err := s.rootStore.postShard(reaction.PostId)
+	if err != nil {
+		return nil, err
+	}
+
+	shardStore, err := s.rootStore.shardStore(shard)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := shardStore.Reaction().Delete(reaction)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reactions are removed rather than marked as deleted, so the reaction is also removed from
+	// the shard its channel is being moved from for finishing the move not to copy it back.
+	previousShard, moving, err := s.rootStore.previousPostShard(reaction.PostId)
+	if err != nil {
+		return nil, err
+	} else if moving && previousShard != shard {
+		previousStore, err := s.rootStore.shardStore(previousShard)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := previousStore.Reaction().Delete(reaction); err != nil {
+			return nil, err
+		}
+	}
+
+	return deleted, nil
+}
+
+func (s ShardReactionStore) GetForPost(postId string, allowFromCache bool) ([]*model.Reaction, *model.AppError) {
+	shardStore, err := s.rootStore.postStore(postId)
+	if err != nil {
+		return nil, err
+	}
+
+	return shardStore.Reaction().GetForPost(postId, allowFromCache)
+}
+
+func (s ShardReactionStore) DeleteAllWithEmojiName(emojiName string) *model.AppError {
+	return s.rootStore.fanOut(func(i int, shard string, shardStore store.Store) *model.AppError {
+		return shardStore.Reaction().DeleteAllWithEmojiName(emojiName)
+	})
+}
+
+func (s ShardReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	return s.rootStore.deleteBatch(limit, func(shardStore store.Store, limit int64) (int64, *model.AppError) {
+		return shardStore.Reaction().PermanentDeleteBatch(endTime, limit)
+	})
+}
+
+func (s ShardReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, *model.AppError) {
+	reactionsByShard := make([][]*model.Reaction, len(s.rootStore.locations()))
+	err := s.rootStore.fanOut(func(i int, shard string, shardStore store.Store) *model.AppError {
+		reactions, err := shardStore.Reaction().BulkGetForPosts(postIds)
+		reactionsByShard[i] = reactions
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The reactions of a channel being moved are on both of its shards until the move is finished.
+	var reactions []*model.Reaction
+	seen := make(map[model.Reaction]bool)
+	for _, shardReactions := range reactionsByShard {
+		for _, reaction := range shardReactions {
+			key := model.Reaction{UserId: reaction.UserId, PostId: reaction.PostId, EmojiName: reaction.EmojiName}
+			if !seen[key] {
+				seen[key] = true
+				reactions = append(reactions, reaction)
+			}
+		}
+	}
+
+	return reactions, nil
+}
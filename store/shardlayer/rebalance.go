@@ -0,0 +1,144 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package shardlayer
+
+import (
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+// copyChannelData copies the posts of the channel, along with their reactions, file infos and
+// archive index entries, skipping what was already copied. Posts and file infos edited or deleted
+// since they were copied are copied again, and the reactions created before movedAt that were
+// removed since are deleted.
+func copyChannelData(from store.Store, to store.Store, channelId string, movedAt int64) *model.AppError {
+	afterPostId := ""
+	for {
+		data, err := from.PostShard().GetChannelData(channelId, afterPostId, CHANNEL_DATA_BATCH_SIZE)
+		if err != nil {
+			return err
+		}
+
+		if !data.IsEmpty() {
+			if err := to.PostShard().SaveChannelData(data, movedAt); err != nil {
+				return err
+			}
+		}
+
+		if len(data.Posts) < CHANNEL_DATA_BATCH_SIZE {
+			return nil
+		}
+		afterPostId = data.Posts[len(data.Posts)-1].Id
+	}
+}
+
+// MoveChannel copies the posts of the channel to the given shard, or to the main database for "",
+// and routes the channel there. The posts are left on the previous shard, where other servers keep
+// writing until their cached shard of the channel expires, until the move is finished with
+// FinishMove.
+func (s *ShardStore) MoveChannel(channelId string, shard string) *model.AppError {
+	channelShard, err := s.Store.PostShard().GetChannelShard(channelId)
+	if err != nil {
+		return err
+	}
+
+	if channelShard.PreviousShard != "" {
+		if err := s.FinishMove(channelId); err != nil {
+			return err
+		}
+	}
+
+	if channelShard.Shard == shard {
+		return nil
+	}
+
+	from, err := s.shardStore(channelShard.Shard)
+	if err != nil {
+		return err
+	}
+	to, err := s.shardStore(shard)
+	if err != nil {
+		return err
+	}
+
+	if shard != "" {
+		channel, err := s.Store.Channel().GetFromMaster(channelId)
+		if err != nil {
+			return err
+		}
+
+		if err := to.PostShard().SaveChannelMirror(channel); err != nil {
+			return err
+		}
+	}
+
+	if err := copyChannelData(from, to, channelId, 0); err != nil {
+		return err
+	}
+
+	previousShard := channelShard.Shard
+	if previousShard == "" {
+		previousShard = model.POST_SHARD_MAIN
+	}
+
+	if _, err := s.Store.PostShard().SaveChannelShard(&model.ChannelShard{ChannelId: channelId, Shard: shard, PreviousShard: previousShard}); err != nil {
+		return err
+	}
+
+	s.channelShardCache.Remove(channelId)
+
+	return nil
+}
+
+// FinishMove copies the posts written, edited or deleted on the previous shard of the channel since
+// it was moved, and deletes them there. Of the posts edited on both shards, the last edit is kept.
+// It must only be called once no server routes the channel to its previous shard anymore.
+func (s *ShardStore) FinishMove(channelId string) *model.AppError {
+	channelShard, err := s.Store.PostShard().GetChannelShard(channelId)
+	if err != nil {
+		return err
+	} else if channelShard.PreviousShard == "" {
+		return nil
+	}
+
+	from, err := s.shardStore(channelShard.PreviousShard)
+	if err != nil {
+		return err
+	}
+	to, err := s.shardStore(channelShard.Shard)
+	if err != nil {
+		return err
+	}
+
+	// The reactions to copied posts made on the new shard were created after the channel was
+	// moved there, so those created before and missing from the previous shard were removed there.
+	if err := copyChannelData(from, to, channelId, channelShard.UpdateAt); err != nil {
+		return err
+	}
+
+	if err := from.PostShard().DeleteChannelData(channelId); err != nil {
+		return err
+	}
+
+	// The main database holds the channel itself rather than a copy of it.
+	if channelShard.PreviousShard != model.POST_SHARD_MAIN {
+		if err := from.PostShard().DeleteChannelMirror(channelId); err != nil {
+			return err
+		}
+	}
+
+	if channelShard.Shard == "" {
+		err = s.Store.PostShard().DeleteChannelShard(channelId)
+	} else {
+		channelShard.PreviousShard = ""
+		_, err = s.Store.PostShard().SaveChannelShard(channelShard)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.channelShardCache.Remove(channelId)
+
+	return nil
+}
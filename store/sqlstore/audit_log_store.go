@@ -0,0 +1,145 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/gorp"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlAuditLogStore struct {
+	SqlStore
+}
+
+func NewSqlAuditLogStore(sqlStore SqlStore) store.AuditLogStore {
+	s := &SqlAuditLogStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.AuditLogRecord{}, "AuditLog").SetKeys(false, "Sequence")
+		table.ColMap("Id").SetMaxSize(26).SetUnique(true)
+		table.ColMap("Phase").SetMaxSize(16)
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("SessionId").SetMaxSize(26)
+		table.ColMap("RequestId").SetMaxSize(26)
+		table.ColMap("IpAddress").SetMaxSize(64)
+		table.ColMap("Method").SetMaxSize(128)
+		table.ColMap("EntityIds").SetMaxSize(4000)
+		table.ColMap("Params").SetMaxSize(model.AUDIT_LOG_DATA_MAX_BYTES)
+		table.ColMap("Diff").SetMaxSize(model.AUDIT_LOG_DATA_MAX_BYTES)
+		table.ColMap("Status").SetMaxSize(16)
+		table.ColMap("Error").SetMaxSize(128)
+		table.ColMap("PrevHash").SetMaxSize(64)
+		table.ColMap("Hash").SetMaxSize(64)
+	}
+
+	return s
+}
+
+func (s SqlAuditLogStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_auditlog_create_at", "AuditLog", "CreateAt")
+	s.CreateIndexIfNotExists("idx_auditlog_user_id", "AuditLog", "UserId")
+}
+
+// Append adds the record at the end of the log, chained to the last record and hashed with the
+// given key. The sequence and hash of the last record are kept in the Systems table, whose row is
+// locked until the record is added, so that concurrent appends are chained one after the other.
+func (s SqlAuditLogStore) Append(record *model.AuditLogRecord, key []byte) (*model.AuditLogRecord, *model.AppError) {
+	record.PreSave()
+
+	// Servers adding the row of the last record at the same time fail but for one, and so try
+	// again with the row in place.
+	err := s.append(record, key)
+	if err != nil {
+		err = s.append(record, key)
+	}
+	if err != nil {
+		return nil, model.NewAppError("SqlAuditLogStore.Append", "store.sql_audit_log.append.app_error", nil, "method="+record.Method+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return record, nil
+}
+
+func (s SqlAuditLogStore) append(record *model.AuditLogRecord, key []byte) error {
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return err
+	}
+	defer finalizeTransaction(transaction)
+
+	sequence, hash, err := s.lockHead(transaction)
+	if err != nil {
+		return err
+	}
+
+	record.Sequence = sequence + 1
+	record.PrevHash = hash
+	record.Hash = record.ComputeHash(key)
+
+	if err := transaction.Insert(record); err != nil {
+		return err
+	}
+
+	head := strconv.FormatInt(record.Sequence, 10) + ":" + record.Hash
+	if _, err := transaction.Exec("UPDATE Systems SET Value = :Value WHERE Name = :Name", map[string]interface{}{"Name": model.SYSTEM_AUDIT_LOG_HEAD, "Value": head}); err != nil {
+		return err
+	}
+
+	return transaction.Commit()
+}
+
+// lockHead locks the row of the Systems table holding the sequence and hash of the last record,
+// and returns them. The row is added from the last record the first time.
+func (s SqlAuditLogStore) lockHead(transaction *gorp.Transaction) (int64, string, error) {
+	// SQLite transactions lock the whole database from the start already.
+	query := "SELECT Value FROM Systems WHERE Name = :Name"
+	if s.DriverName() != model.DATABASE_DRIVER_SQLITE {
+		query += " FOR UPDATE"
+	}
+
+	head, err := transaction.SelectNullStr(query, map[string]interface{}{"Name": model.SYSTEM_AUDIT_LOG_HEAD})
+	if err != nil {
+		return 0, "", err
+	}
+
+	if !head.Valid {
+		var last model.AuditLogRecord
+		if err := transaction.SelectOne(&last, "SELECT Sequence, Hash FROM AuditLog ORDER BY Sequence DESC LIMIT 1"); err != nil && err != sql.ErrNoRows {
+			return 0, "", err
+		}
+
+		value := strconv.FormatInt(last.Sequence, 10) + ":" + last.Hash
+		if err := transaction.Insert(&model.System{Name: model.SYSTEM_AUDIT_LOG_HEAD, Value: value}); err != nil {
+			return 0, "", err
+		}
+
+		return last.Sequence, last.Hash, nil
+	}
+
+	parts := strings.SplitN(head.String, ":", 2)
+	sequence, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || len(parts) != 2 {
+		return 0, "", errors.Errorf("invalid audit log head %q", head.String)
+	}
+
+	return sequence, parts[1], nil
+}
+
+// GetAfter returns the records following the given sequence, in order, starting from the first
+// record for 0.
+func (s SqlAuditLogStore) GetAfter(sequence int64, limit int) ([]*model.AuditLogRecord, *model.AppError) {
+	var records []*model.AuditLogRecord
+	if _, err := s.GetReplica().Select(&records, "SELECT * FROM AuditLog WHERE Sequence > :Sequence ORDER BY Sequence LIMIT :Limit", map[string]interface{}{"Sequence": sequence, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlAuditLogStore.GetAfter", "store.sql_audit_log.get_after.app_error", nil, "sequence="+strconv.FormatInt(sequence, 10)+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return records, nil
+}
@@ -76,6 +76,8 @@ func (s SqlAuditStore) PermanentDeleteBatch(endTime int64, limit int64) (int64,
 	var query string
 	if s.DriverName() == "postgres" {
 		query = "DELETE from Audits WHERE Id = any (array (SELECT Id FROM Audits WHERE CreateAt < :EndTime LIMIT :Limit))"
+	} else if s.DriverName() == model.DATABASE_DRIVER_SQLITE {
+		query = "DELETE FROM Audits WHERE rowid IN (SELECT rowid FROM Audits WHERE CreateAt < :EndTime LIMIT :Limit)"
 	} else {
 		query = "DELETE from Audits WHERE CreateAt < :EndTime LIMIT :Limit"
 	}
@@ -0,0 +1,225 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// Backfill updates the existing rows of a table in batches, walking the table in order of its
+// primary key so that each batch only locks the rows it updates.
+type Backfill struct {
+	Name  string
+	Table string
+
+	// Keys are the columns of the primary key of the table, Id unless set.
+	Keys []string
+
+	// Set is the assignment updating a row, such as "Column = 0".
+	Set string
+
+	// Where selects the rows still to update, such as "Column IS NULL", so that the rows already
+	// updated are skipped should the backfill be restarted.
+	Where string
+}
+
+func (b *Backfill) keys() []string {
+	if len(b.Keys) == 0 {
+		return []string{"Id"}
+	}
+
+	return b.Keys
+}
+
+// rowValue returns the row value of the given expressions, compared as a whole in the order
+// given, as supported by MySQL, Postgres and SQLite alike.
+func rowValue(expressions []string) string {
+	return "(" + strings.Join(expressions, ", ") + ")"
+}
+
+// placeholders returns count placeholders for the driver, numbered after the given offset where
+// the driver requires it.
+func placeholders(driverName string, offset, count int) []string {
+	result := make([]string, count)
+	for i := range result {
+		if driverName == model.DATABASE_DRIVER_POSTGRES {
+			result[i] = "$" + strconv.Itoa(offset+i+1)
+		} else {
+			result[i] = "?"
+		}
+	}
+
+	return result
+}
+
+// findBackfill returns the backfill with the given name among those of the applied migrations.
+func findBackfill(sqlStore SqlStore, all []*Migration, name string) (*Backfill, error) {
+	applied, err := appliedMigrations(sqlStore)
+	if err != nil {
+		return nil, err
+	}
+
+	appliedVersions := make(map[int]bool, len(applied))
+	for _, migration := range applied {
+		appliedVersions[migration.Version] = true
+	}
+
+	for _, migration := range all {
+		for _, backfill := range migration.Backfills {
+			if backfill.Name == name && appliedVersions[migration.Version] {
+				return backfill, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// appliedBackfills returns the names of the backfills of the applied migrations, in order of
+// version.
+func appliedBackfills(sqlStore SqlStore, all []*Migration) ([]string, error) {
+	pending, err := pendingMigrations(sqlStore, all)
+	if err != nil {
+		return nil, err
+	}
+
+	pendingVersions := make(map[int]bool, len(pending))
+	for _, migration := range pending {
+		pendingVersions[migration.Version] = true
+	}
+
+	names := []string{}
+	for _, migration := range sortedMigrations(all) {
+		if pendingVersions[migration.Version] {
+			continue
+		}
+		for _, backfill := range migration.Backfills {
+			names = append(names, backfill.Name)
+		}
+	}
+
+	return names, nil
+}
+
+// countBackfillRemaining counts the rows the backfill has yet to update.
+func countBackfillRemaining(sqlStore SqlStore, backfill *Backfill) (int64, error) {
+	return sqlStore.GetMaster().SelectInt("SELECT COUNT(*) FROM " + backfill.Table + " WHERE (" + backfill.Where + ")")
+}
+
+// runBackfillBatch updates the next batch of rows after the given key, returning the key of the
+// last row of the batch and the number of rows updated. A nil key means no rows were left.
+func runBackfillBatch(sqlStore SqlStore, backfill *Backfill, afterKey []string, batchSize int) ([]string, int64, error) {
+	keys := backfill.keys()
+	driverName := sqlStore.DriverName()
+
+	where := "(" + backfill.Where + ")"
+	args := []interface{}{}
+	if len(afterKey) > 0 {
+		if len(afterKey) != len(keys) {
+			return nil, 0, fmt.Errorf("expected a key of %d columns, got %d", len(keys), len(afterKey))
+		}
+
+		where += " AND " + rowValue(keys) + " > " + rowValue(placeholders(driverName, 0, len(keys)))
+		for _, value := range afterKey {
+			args = append(args, value)
+		}
+	}
+
+	rows, err := sqlStore.GetMaster().Query("SELECT "+strings.Join(keys, ", ")+" FROM "+backfill.Table+" WHERE "+where+" ORDER BY "+strings.Join(keys, ", ")+" LIMIT "+strconv.Itoa(batchSize), args...)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to select the batch")
+	}
+
+	var lastKey []string
+	for rows.Next() {
+		values := make([]string, len(keys))
+		dest := make([]interface{}, len(keys))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err = rows.Scan(dest...); err != nil {
+			rows.Close()
+			return nil, 0, errors.Wrap(err, "failed to scan the batch")
+		}
+		lastKey = values
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, 0, errors.Wrap(err, "failed to select the batch")
+	}
+
+	if lastKey == nil {
+		return nil, 0, nil
+	}
+
+	where += " AND " + rowValue(keys) + " <= " + rowValue(placeholders(driverName, len(args), len(keys)))
+	for _, value := range lastKey {
+		args = append(args, value)
+	}
+
+	result, err := sqlStore.GetMaster().Exec("UPDATE "+backfill.Table+" SET "+backfill.Set+" WHERE "+where, args...)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to update the batch")
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to count the rows updated")
+	}
+
+	return lastKey, updated, nil
+}
+
+// Backfills returns the names of the backfills declared by the applied migrations, in order.
+func (ss *SqlSupplier) Backfills() ([]string, *model.AppError) {
+	names, err := appliedBackfills(ss, migrations)
+	if err != nil {
+		return nil, model.NewAppError("SqlSupplier.Backfills", "store.sql.backfills.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return names, nil
+}
+
+// CountBackfillRemaining counts the rows the backfill has yet to update.
+func (ss *SqlSupplier) CountBackfillRemaining(name string) (int64, *model.AppError) {
+	backfill, err := findBackfill(ss, migrations, name)
+	if err != nil {
+		return 0, model.NewAppError("SqlSupplier.CountBackfillRemaining", "store.sql.backfills.app_error", nil, err.Error(), http.StatusInternalServerError)
+	} else if backfill == nil {
+		return 0, model.NewAppError("SqlSupplier.CountBackfillRemaining", "store.sql.backfills.unknown.app_error", map[string]interface{}{"Name": name}, "", http.StatusNotFound)
+	}
+
+	count, err := countBackfillRemaining(ss, backfill)
+	if err != nil {
+		return 0, model.NewAppError("SqlSupplier.CountBackfillRemaining", "store.sql.backfills.count.app_error", map[string]interface{}{"Name": name}, err.Error(), http.StatusInternalServerError)
+	}
+
+	return count, nil
+}
+
+// RunBackfillBatch updates the next batch of at most batchSize rows after the given key, starting
+// from the first row without one. It returns the key to resume after and the number of rows
+// updated, the key being nil once no rows are left.
+func (ss *SqlSupplier) RunBackfillBatch(name string, afterKey []string, batchSize int) ([]string, int64, *model.AppError) {
+	backfill, err := findBackfill(ss, migrations, name)
+	if err != nil {
+		return nil, 0, model.NewAppError("SqlSupplier.RunBackfillBatch", "store.sql.backfills.app_error", nil, err.Error(), http.StatusInternalServerError)
+	} else if backfill == nil {
+		return nil, 0, model.NewAppError("SqlSupplier.RunBackfillBatch", "store.sql.backfills.unknown.app_error", map[string]interface{}{"Name": name}, "", http.StatusNotFound)
+	}
+
+	lastKey, updated, err := runBackfillBatch(ss, backfill, afterKey, batchSize)
+	if err != nil {
+		return nil, 0, model.NewAppError("SqlSupplier.RunBackfillBatch", "store.sql.backfills.run_batch.app_error", map[string]interface{}{"Name": name}, err.Error(), http.StatusInternalServerError)
+	}
+
+	return lastKey, updated, nil
+}
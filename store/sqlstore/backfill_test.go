@@ -0,0 +1,263 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestBackfill(t *testing.T) {
+	supplier := newReplicatedSqliteSupplier(t, 0, 0)
+	defer supplier.Close()
+
+	backfill := &Backfill{
+		Name:  "backfill_test_msg_count",
+		Table: "BackfillTest",
+		Keys:  []string{"ChannelId", "UserId"},
+		Set:   "MsgCount = 0",
+		Where: "MsgCount IS NULL",
+	}
+	testMigrations := []*Migration{
+		{
+			Version: 1000,
+			Name:    "backfill_test",
+			Up: func(sqlStore SqlStore) ([]string, error) {
+				return []string{"CREATE TABLE BackfillTest (ChannelId varchar(26) NOT NULL, UserId varchar(26) NOT NULL, MsgCount bigint, PRIMARY KEY (ChannelId, UserId))"}, nil
+			},
+			Backfills: []*Backfill{backfill},
+		},
+	}
+
+	t.Run("backfills of pending migrations are not listed", func(t *testing.T) {
+		names, err := appliedBackfills(supplier, testMigrations)
+		require.Nil(t, err)
+		assert.Empty(t, names)
+
+		found, err := findBackfill(supplier, testMigrations, backfill.Name)
+		require.Nil(t, err)
+		assert.Nil(t, found)
+	})
+
+	_, err := applyMigrations(supplier, testMigrations, false)
+	require.Nil(t, err)
+
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			_, err = supplier.GetMaster().Exec("INSERT INTO BackfillTest (ChannelId, UserId) VALUES (?, ?)", fmt.Sprintf("channel%v", i), fmt.Sprintf("user%v", j))
+			require.Nil(t, err)
+		}
+	}
+	// Rows already up to date are skipped.
+	_, err = supplier.GetMaster().Exec("UPDATE BackfillTest SET MsgCount = 10 WHERE UserId = 'user0'")
+	require.Nil(t, err)
+
+	t.Run("backfills of applied migrations are listed", func(t *testing.T) {
+		names, err := appliedBackfills(supplier, testMigrations)
+		require.Nil(t, err)
+		assert.Equal(t, []string{backfill.Name}, names)
+
+		found, err := findBackfill(supplier, testMigrations, backfill.Name)
+		require.Nil(t, err)
+		assert.Equal(t, backfill, found)
+	})
+
+	t.Run("batches walk the table in key order", func(t *testing.T) {
+		remaining, err := countBackfillRemaining(supplier, backfill)
+		require.Nil(t, err)
+		assert.Equal(t, int64(20), remaining)
+
+		lastKey, updated, err := runBackfillBatch(supplier, backfill, nil, 6)
+		require.Nil(t, err)
+		assert.Equal(t, []string{"channel1", "user2"}, lastKey)
+		assert.Equal(t, int64(6), updated)
+
+		var total int64
+		batches := 1
+		for lastKey != nil {
+			total += updated
+			lastKey, updated, err = runBackfillBatch(supplier, backfill, lastKey, 6)
+			require.Nil(t, err)
+			batches++
+		}
+		assert.Equal(t, int64(20), total)
+		assert.Equal(t, 5, batches)
+
+		remaining, err = countBackfillRemaining(supplier, backfill)
+		require.Nil(t, err)
+		assert.Zero(t, remaining)
+
+		count, err := supplier.GetMaster().SelectInt("SELECT COUNT(*) FROM BackfillTest WHERE MsgCount = 10")
+		require.Nil(t, err)
+		assert.Equal(t, int64(5), count, "rows already up to date must not be updated")
+	})
+
+	t.Run("resuming after the last row does nothing", func(t *testing.T) {
+		lastKey, updated, err := runBackfillBatch(supplier, backfill, []string{"channel4", "user4"}, 6)
+		require.Nil(t, err)
+		assert.Nil(t, lastKey)
+		assert.Zero(t, updated)
+	})
+
+	t.Run("keys must match the primary key", func(t *testing.T) {
+		_, _, err := runBackfillBatch(supplier, backfill, []string{"channel1"}, 6)
+		require.EqualError(t, err, "expected a key of 2 columns, got 1")
+	})
+
+	t.Run("unknown backfills are reported", func(t *testing.T) {
+		_, appErr := supplier.CountBackfillRemaining("unknown")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "store.sql.backfills.unknown.app_error", appErr.Id)
+
+		_, _, appErr = supplier.RunBackfillBatch("unknown", nil, 10)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "store.sql.backfills.unknown.app_error", appErr.Id)
+	})
+}
+
+func TestMigrationBackfills(t *testing.T) {
+	supplier := newReplicatedSqliteSupplier(t, 0, 0)
+	defer supplier.Close()
+
+	names, err := appliedBackfills(supplier, migrations)
+	require.Nil(t, err)
+	assert.Contains(t, names, "incoming_webhooks_payload_template")
+	assert.Contains(t, names, "users_saml_auth_data_lower")
+
+	t.Run("webhooks are read before the payload template is backfilled", func(t *testing.T) {
+		webhook, appErr := supplier.Webhook().SaveIncoming(&model.IncomingWebhook{
+			ChannelId: model.NewId(),
+			TeamId:    model.NewId(),
+			UserId:    model.NewId(),
+		})
+		require.Nil(t, appErr)
+		supplier.Webhook().ClearCaches()
+
+		_, err = supplier.GetMaster().Exec("UPDATE IncomingWebhooks SET PayloadTemplate = NULL WHERE Id = ?", webhook.Id)
+		require.Nil(t, err)
+
+		received, appErr := supplier.Webhook().GetIncoming(webhook.Id, false)
+		require.Nil(t, appErr)
+		assert.Equal(t, "", received.PayloadTemplate)
+
+		backfill, err := findBackfill(supplier, migrations, "incoming_webhooks_payload_template")
+		require.Nil(t, err)
+		_, updated, err := runBackfillBatch(supplier, backfill, nil, 100)
+		require.Nil(t, err)
+		assert.Equal(t, int64(1), updated)
+	})
+
+	t.Run("saml auth data is lowercased", func(t *testing.T) {
+		user, appErr := supplier.User().Save(&model.User{
+			Email:       "success+" + model.NewId() + "@simulator.amazonses.com",
+			Username:    model.NewId(),
+			AuthService: model.USER_AUTH_SERVICE_SAML,
+			AuthData:    model.NewString("Mixed" + model.NewId()),
+		})
+		require.Nil(t, appErr)
+
+		backfill, err := findBackfill(supplier, migrations, "users_saml_auth_data_lower")
+		require.Nil(t, err)
+		remaining, err := countBackfillRemaining(supplier, backfill)
+		require.Nil(t, err)
+		assert.Equal(t, int64(1), remaining)
+
+		_, _, err = runBackfillBatch(supplier, backfill, nil, 100)
+		require.Nil(t, err)
+
+		authData, err := supplier.GetMaster().SelectStr("SELECT AuthData FROM Users WHERE Id = ?", user.Id)
+		require.Nil(t, err)
+		assert.Equal(t, strings.ToLower(*user.AuthData), authData)
+	})
+}
+
+// dialectSqlStore presents a SQLite store as using another driver, to check the statements
+// planned for it.
+type dialectSqlStore struct {
+	*SqlSupplier
+	driverName string
+	online     bool
+}
+
+func (s *dialectSqlStore) DriverName() string     { return s.driverName }
+func (s *dialectSqlStore) OnlineMigrations() bool { return s.online }
+
+func TestOnlineMigrationStatements(t *testing.T) {
+	supplier := newReplicatedSqliteSupplier(t, 0, 0)
+	defer supplier.Close()
+
+	newSqlStore := func(driverName string, online bool) SqlStore {
+		return &dialectSqlStore{SqlSupplier: supplier, driverName: driverName, online: online}
+	}
+
+	t.Run("create index", func(t *testing.T) {
+		columns := []string{"ChannelId", "CreateAt"}
+
+		assert.Equal(t, "CREATE INDEX idx_test ON Posts (ChannelId, CreateAt)", createIndexQuery(model.DATABASE_DRIVER_POSTGRES, "idx_test", "Posts", columns, false, false))
+		assert.Equal(t, "CREATE UNIQUE INDEX CONCURRENTLY idx_test ON Posts (ChannelId, CreateAt)", createIndexQuery(model.DATABASE_DRIVER_POSTGRES, "idx_test", "Posts", columns, true, true))
+		assert.Equal(t, "CREATE INDEX idx_test ON Posts (ChannelId, CreateAt)", createIndexQuery(model.DATABASE_DRIVER_MYSQL, "idx_test", "Posts", columns, false, false))
+		assert.Equal(t, "CREATE INDEX idx_test ON Posts (ChannelId, CreateAt) ALGORITHM=INPLACE LOCK=NONE", createIndexQuery(model.DATABASE_DRIVER_MYSQL, "idx_test", "Posts", columns, false, true))
+		assert.Equal(t, "CREATE INDEX idx_test ON Posts (ChannelId, CreateAt)", createIndexQuery(model.DATABASE_DRIVER_SQLITE, "idx_test", "Posts", columns, false, true))
+	})
+
+	t.Run("add column", func(t *testing.T) {
+		step := addColumn("Posts", "Test", "bigint", "bigint", model.NewString("0"))
+
+		statements, err := step(newSqlStore(model.DATABASE_DRIVER_MYSQL, false))
+		require.Nil(t, err)
+		assert.Equal(t, []string{"ALTER TABLE Posts ADD Test bigint DEFAULT '0'"}, statements)
+
+		statements, err = step(newSqlStore(model.DATABASE_DRIVER_MYSQL, true))
+		require.Nil(t, err)
+		assert.Equal(t, []string{"ALTER TABLE Posts ADD Test bigint DEFAULT '0', ALGORITHM=INPLACE, LOCK=NONE"}, statements)
+
+		statements, err = step(newSqlStore(model.DATABASE_DRIVER_POSTGRES, true))
+		require.Nil(t, err)
+		assert.Equal(t, []string{"ALTER TABLE Posts ADD Test bigint DEFAULT '0'"}, statements)
+	})
+}
+
+func TestOnlineIndexMigration(t *testing.T) {
+	supplier := newReplicatedSqliteSupplier(t, 0, 0)
+	defer supplier.Close()
+	*supplier.settings.OnlineMigrations = true
+
+	testMigrations := []*Migration{
+		{
+			Version:       1000,
+			Name:          "online_index_test",
+			Up:            createIndex("idx_status_test", "Status", []string{"Status", "LastActivityAt"}, false),
+			Down:          dropIndex("idx_status_test", "Status"),
+			NoTransaction: true,
+		},
+	}
+
+	applied, err := applyMigrations(supplier, testMigrations, false)
+	require.Nil(t, err)
+	require.Len(t, applied, 1)
+	assert.Equal(t, []string{"CREATE INDEX idx_status_test ON Status (Status, LastActivityAt)"}, applied[0].Statements)
+
+	exists, valid, err := indexExists(supplier, "idx_status_test", "Status")
+	require.Nil(t, err)
+	assert.True(t, exists)
+	assert.True(t, valid)
+	statements, err := createIndex("idx_status_test", "Status", []string{"Status"}, false)(supplier)
+	require.Nil(t, err)
+	assert.Empty(t, statements, "existing indexes are not created again")
+
+	reverted, err := revertMigrations(supplier, testMigrations, 999)
+	require.Nil(t, err)
+	require.Len(t, reverted, 1)
+	assert.Equal(t, []string{"DROP INDEX idx_status_test"}, reverted[0].Statements)
+
+	exists, _, err = indexExists(supplier, "idx_status_test", "Status")
+	require.Nil(t, err)
+	assert.False(t, exists)
+}
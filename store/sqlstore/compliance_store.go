@@ -7,11 +7,14 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/store"
 )
 
+const COMPLIANCE_ACKNOWLEDGEMENTS_BATCH_SIZE = 1000
+
 type SqlComplianceStore struct {
 	SqlStore
 }
@@ -122,8 +125,8 @@ func (s SqlComplianceStore) ComplianceExport(job *model.Compliance) ([]*model.Co
 		emailQuery += ")"
 	}
 
-	query :=
-		`(SELECT
+	channelsQuery :=
+		`SELECT
 			Teams.Name AS TeamName,
 			Teams.DisplayName AS TeamDisplayName,
 			Channels.Name AS ChannelName,
@@ -144,6 +147,7 @@ func (s SqlComplianceStore) ComplianceExport(job *model.Compliance) ([]*model.Co
 			Posts.Props AS PostProps,
 			Posts.Hashtags AS PostHashtags,
 			Posts.FileIds AS PostFileIds,
+			COALESCE(PostsPriority.Priority, '') AS PostPriority,
 			Bots.UserId IS NOT NULL AS IsBot
 		FROM
 			Teams,
@@ -151,6 +155,7 @@ func (s SqlComplianceStore) ComplianceExport(job *model.Compliance) ([]*model.Co
 			Users,
 			Posts
         LEFT JOIN Bots ON Bots.UserId = Posts.UserId
+		LEFT JOIN PostsPriority ON PostsPriority.PostId = Posts.Id
 		WHERE
 			Teams.Id = Channels.TeamId
 				AND Posts.ChannelId = Channels.Id
@@ -158,9 +163,10 @@ func (s SqlComplianceStore) ComplianceExport(job *model.Compliance) ([]*model.Co
 				AND Posts.CreateAt > :StartTime
 				AND Posts.CreateAt <= :EndTime
 				` + emailQuery + `
-				` + keywordQuery + `)
-		UNION ALL
-		(SELECT
+				` + keywordQuery
+
+	directMessagesQuery :=
+		`SELECT
 			'direct-messages' AS TeamName,
 			'Direct Messages' AS TeamDisplayName,
 			Channels.Name AS ChannelName,
@@ -181,12 +187,14 @@ func (s SqlComplianceStore) ComplianceExport(job *model.Compliance) ([]*model.Co
 			Posts.Props AS PostProps,
 			Posts.Hashtags AS PostHashtags,
 			Posts.FileIds AS PostFileIds,
+			COALESCE(PostsPriority.Priority, '') AS PostPriority,
 			Bots.UserId IS NOT NULL AS IsBot
 		FROM
 			Channels,
 			Users,
 			Posts
 		LEFT JOIN Bots ON Bots.UserId = Posts.UserId
+		LEFT JOIN PostsPriority ON PostsPriority.PostId = Posts.Id
 		WHERE
 			Channels.TeamId = ''
 				AND Posts.ChannelId = Channels.Id
@@ -194,7 +202,9 @@ func (s SqlComplianceStore) ComplianceExport(job *model.Compliance) ([]*model.Co
 				AND Posts.CreateAt > :StartTime
 				AND Posts.CreateAt <= :EndTime
 				` + emailQuery + `
-				` + keywordQuery + `)
+				` + keywordQuery
+
+	query := unionQuery(s.DriverName(), "UNION ALL", channelsQuery, directMessagesQuery) + `
 		ORDER BY PostCreateAt
 		LIMIT 30000`
 
@@ -203,9 +213,74 @@ func (s SqlComplianceStore) ComplianceExport(job *model.Compliance) ([]*model.Co
 	if _, err := s.GetReplica().Select(&cposts, query, props); err != nil {
 		return nil, model.NewAppError("SqlPostStore.ComplianceExport", "store.sql_post.compliance_export.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
+
+	if err := s.fillComplianceAcknowledgements(cposts); err != nil {
+		return nil, err
+	}
+
 	return cposts, nil
 }
 
+// fillComplianceAcknowledgements sets the acknowledgements of every exported post that has a priority. They're
+// listed as "username (time)" in the order they were made.
+func (s SqlComplianceStore) fillComplianceAcknowledgements(cposts []*model.CompliancePost) *model.AppError {
+	postsById := make(map[string]*model.CompliancePost)
+	for _, cpost := range cposts {
+		if cpost.PostPriority != "" {
+			postsById[cpost.PostId] = cpost
+		}
+	}
+
+	postIds := make([]string, 0, len(postsById))
+	for postId := range postsById {
+		postIds = append(postIds, postId)
+	}
+
+	for i := 0; i < len(postIds); i += COMPLIANCE_ACKNOWLEDGEMENTS_BATCH_SIZE {
+		end := i + COMPLIANCE_ACKNOWLEDGEMENTS_BATCH_SIZE
+		if end > len(postIds) {
+			end = len(postIds)
+		}
+
+		params := map[string]interface{}{}
+		keys := make([]string, 0, end-i)
+		for j, postId := range postIds[i:end] {
+			key := "PostId" + strconv.Itoa(j)
+			params[key] = postId
+			keys = append(keys, ":"+key)
+		}
+
+		var acks []struct {
+			PostId         string
+			Username       string
+			AcknowledgedAt int64
+		}
+		if _, err := s.GetReplica().Select(&acks,
+			`SELECT
+				PostAcknowledgements.PostId,
+				Users.Username,
+				PostAcknowledgements.AcknowledgedAt
+			FROM
+				PostAcknowledgements
+			INNER JOIN Users ON Users.Id = PostAcknowledgements.UserId
+			WHERE
+				PostAcknowledgements.PostId IN (`+strings.Join(keys, ", ")+`)
+			ORDER BY PostAcknowledgements.AcknowledgedAt`, params); err != nil {
+			return model.NewAppError("SqlPostStore.ComplianceExport", "store.sql_post.compliance_export.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		for _, ack := range acks {
+			cpost := postsById[ack.PostId]
+			if cpost.PostAcknowledgements != "" {
+				cpost.PostAcknowledgements += ", "
+			}
+			cpost.PostAcknowledgements += ack.Username + " (" + time.Unix(0, ack.AcknowledgedAt*int64(time.Millisecond)).UTC().Format(time.RFC3339) + ")"
+		}
+	}
+
+	return nil
+}
+
 func (s SqlComplianceStore) MessageExport(after int64, limit int) ([]*model.MessageExport, *model.AppError) {
 	props := map[string]interface{}{"StartTime": after, "Limit": limit}
 	query :=
@@ -11,17 +11,8 @@ import (
 	"github.com/mattermost/mattermost-server/einterfaces"
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/store"
-	"github.com/mattermost/mattermost-server/utils"
 )
 
-const (
-	EMOJI_CACHE_SIZE = 5000
-	EMOJI_CACHE_SEC  = 1800 // 30 mins
-)
-
-var emojiCacheById = utils.NewLru(EMOJI_CACHE_SIZE)
-var emojiIdCacheByName = utils.NewLru(EMOJI_CACHE_SIZE)
-
 type SqlEmojiStore struct {
 	SqlStore
 	metrics einterfaces.MetricsInterface
@@ -66,13 +57,7 @@ func (es SqlEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, *model.AppError)
 }
 
 func (es SqlEmojiStore) Get(id string, allowFromCache bool) (*model.Emoji, *model.AppError) {
-	if allowFromCache {
-		if emoji, ok := es.getFromCacheById(id); ok {
-			return emoji, nil
-		}
-	}
-
-	return es.getBy("Id", id, allowFromCache)
+	return es.getBy("Id", id)
 }
 
 func (es SqlEmojiStore) GetByName(name string, allowFromCache bool) (*model.Emoji, *model.AppError) {
@@ -80,13 +65,7 @@ func (es SqlEmojiStore) GetByName(name string, allowFromCache bool) (*model.Emoj
 		return es.Get(id, allowFromCache)
 	}
 
-	if allowFromCache {
-		if emoji, ok := es.getFromCacheByName(name); ok {
-			return emoji, nil
-		}
-	}
-
-	return es.getBy("Name", name, allowFromCache)
+	return es.getBy("Name", name)
 }
 
 func (es SqlEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji, *model.AppError) {
@@ -139,8 +118,6 @@ func (es SqlEmojiStore) Delete(emoji *model.Emoji, time int64) *model.AppError {
 		return model.NewAppError("SqlEmojiStore.Delete", "store.sql_emoji.delete.no_results", nil, "id="+emoji.Id+", err="+err.Error(), http.StatusBadRequest)
 	}
 
-	es.removeFromCache(emoji)
-
 	return nil
 }
 
@@ -172,7 +149,7 @@ func (es SqlEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*mode
 }
 
 // getBy returns one active (not deleted) emoji, found by any one column (what/key).
-func (es SqlEmojiStore) getBy(what string, key interface{}, addToCache bool) (*model.Emoji, *model.AppError) {
+func (es SqlEmojiStore) getBy(what string, key interface{}) (*model.Emoji, *model.AppError) {
 	var emoji *model.Emoji
 
 	err := es.GetReplica().SelectOne(&emoji,
@@ -193,51 +170,5 @@ func (es SqlEmojiStore) getBy(what string, key interface{}, addToCache bool) (*m
 		return nil, model.NewAppError("SqlEmojiStore.GetByName", "store.sql_emoji.get.app_error", nil, "key="+fmt.Sprintf("%v", key)+", "+err.Error(), status)
 	}
 
-	if addToCache {
-		es.addToCache(emoji)
-	}
-
 	return emoji, nil
 }
-
-func (es SqlEmojiStore) addToCache(emoji *model.Emoji) {
-	emojiCacheById.AddWithExpiresInSecs(emoji.Id, emoji, EMOJI_CACHE_SEC)
-	emojiIdCacheByName.AddWithExpiresInSecs(emoji.Name, emoji.Id, EMOJI_CACHE_SEC)
-}
-
-func (es SqlEmojiStore) getFromCacheById(id string) (*model.Emoji, bool) {
-	if cacheItem, ok := emojiCacheById.Get(id); ok {
-		es.incrementMemCacheHitCounter("Emoji")
-		return cacheItem.(*model.Emoji), true
-	}
-	es.incrementMemCacheMissCounter("Emoji")
-	return nil, false
-}
-
-func (es SqlEmojiStore) getFromCacheByName(name string) (*model.Emoji, bool) {
-	if id, ok := emojiIdCacheByName.Get(name); ok {
-		return es.getFromCacheById(id.(string))
-	}
-
-	es.incrementMemCacheMissCounter("Emoji")
-	return nil, false
-}
-
-func (es SqlEmojiStore) incrementMemCacheHitCounter(cache string) {
-	if es.metrics == nil {
-		return
-	}
-	es.metrics.IncrementMemCacheHitCounter(cache)
-}
-
-func (es SqlEmojiStore) incrementMemCacheMissCounter(cache string) {
-	if es.metrics == nil {
-		return
-	}
-	es.metrics.IncrementMemCacheMissCounter(cache)
-}
-
-func (es SqlEmojiStore) removeFromCache(emoji *model.Emoji) {
-	emojiCacheById.Remove(emoji.Id)
-	emojiIdCacheByName.Remove(emoji.Name)
-}
@@ -258,6 +258,8 @@ func (s SqlFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int6
 	var query string
 	if s.DriverName() == "postgres" {
 		query = "DELETE from FileInfo WHERE Id = any (array (SELECT Id FROM FileInfo WHERE CreateAt < :EndTime LIMIT :Limit))"
+	} else if s.DriverName() == model.DATABASE_DRIVER_SQLITE {
+		query = "DELETE FROM FileInfo WHERE rowid IN (SELECT rowid FROM FileInfo WHERE CreateAt < :EndTime LIMIT :Limit)"
 	} else {
 		query = "DELETE from FileInfo WHERE CreateAt < :EndTime LIMIT :Limit"
 	}
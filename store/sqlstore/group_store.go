@@ -855,10 +855,15 @@ func (s *SqlGroupStore) groupsBySyncableBaseQuery(st model.GroupSyncableType, t
 	if len(opts.Q) > 0 {
 		pattern := fmt.Sprintf("%%%s%%", sanitizeSearchTerm(opts.Q, "\\"))
 		operatorKeyword := "ILIKE"
+		escapeClause := ""
 		if s.DriverName() == model.DATABASE_DRIVER_MYSQL {
 			operatorKeyword = "LIKE"
+		} else if s.DriverName() == model.DATABASE_DRIVER_SQLITE {
+			// SQLite's LIKE is case-insensitive, but has no default escape character.
+			operatorKeyword = "LIKE"
+			escapeClause = " ESCAPE '\\'"
 		}
-		query = query.Where(fmt.Sprintf("(ug.Name %[1]s ? OR ug.DisplayName %[1]s ?)", operatorKeyword), pattern, pattern)
+		query = query.Where(fmt.Sprintf("(ug.Name %[1]s ?%[2]s OR ug.DisplayName %[1]s ?%[2]s)", operatorKeyword, escapeClause), pattern, pattern)
 	}
 
 	return query
@@ -921,10 +926,15 @@ func (s *SqlGroupStore) GetGroups(page, perPage int, opts model.GroupSearchOpts)
 	if len(opts.Q) > 0 {
 		pattern := fmt.Sprintf("%%%s%%", sanitizeSearchTerm(opts.Q, "\\"))
 		operatorKeyword := "ILIKE"
+		escapeClause := ""
 		if s.DriverName() == model.DATABASE_DRIVER_MYSQL {
 			operatorKeyword = "LIKE"
+		} else if s.DriverName() == model.DATABASE_DRIVER_SQLITE {
+			// SQLite's LIKE is case-insensitive, but has no default escape character.
+			operatorKeyword = "LIKE"
+			escapeClause = " ESCAPE '\\'"
 		}
-		groupsQuery = groupsQuery.Where(fmt.Sprintf("(g.Name %[1]s ? OR g.DisplayName %[1]s ?)", operatorKeyword), pattern, pattern)
+		groupsQuery = groupsQuery.Where(fmt.Sprintf("(g.Name %[1]s ?%[2]s OR g.DisplayName %[1]s ?%[2]s)", operatorKeyword, escapeClause), pattern, pattern)
 	}
 
 	if len(opts.NotAssociatedToTeam) == 26 {
@@ -978,7 +988,7 @@ func (s *SqlGroupStore) teamMembersMinusGroupMembersQuery(teamID string, groupID
 		selectStr = "count(DISTINCT Users.Id)"
 	} else {
 		tmpl := "Users.*, TeamMembers.SchemeGuest, TeamMembers.SchemeAdmin, TeamMembers.SchemeUser, %s AS GroupIDs"
-		if s.DriverName() == model.DATABASE_DRIVER_MYSQL {
+		if s.DriverName() == model.DATABASE_DRIVER_MYSQL || s.DriverName() == model.DATABASE_DRIVER_SQLITE {
 			selectStr = fmt.Sprintf(tmpl, "group_concat(UserGroups.Id)")
 		} else {
 			selectStr = fmt.Sprintf(tmpl, "string_agg(UserGroups.Id, ',')")
@@ -1056,7 +1066,7 @@ func (s *SqlGroupStore) channelMembersMinusGroupMembersQuery(channelID string, g
 		selectStr = "count(DISTINCT Users.Id)"
 	} else {
 		tmpl := "Users.*, ChannelMembers.SchemeGuest, ChannelMembers.SchemeAdmin, ChannelMembers.SchemeUser, %s AS GroupIDs"
-		if s.DriverName() == model.DATABASE_DRIVER_MYSQL {
+		if s.DriverName() == model.DATABASE_DRIVER_MYSQL || s.DriverName() == model.DATABASE_DRIVER_SQLITE {
 			selectStr = fmt.Sprintf(tmpl, "group_concat(UserGroups.Id)")
 		} else {
 			selectStr = fmt.Sprintf(tmpl, "string_agg(UserGroups.Id, ',')")
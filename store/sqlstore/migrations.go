@@ -0,0 +1,766 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"context"
+	"fmt"
+	"hash/crc32"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/gorp"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	MIGRATIONS_TABLE        = "Migrations"
+	MIGRATIONS_LOCK_NAME    = "mattermost_migrations"
+	MIGRATIONS_LOCK_TIMEOUT = 10 * time.Minute
+)
+
+// migrationStatements returns the statements making up one direction of a migration for the given
+// store. They may depend on the current schema, so that a migration overlapping changes already
+// made by the legacy upgrades runs nothing.
+type migrationStatements func(sqlStore SqlStore) ([]string, error)
+
+// Migration is a named change to the database schema, applied in order of version. Migrations
+// without a Down step cannot be reverted.
+//
+// Updating the existing rows of a large table inline would hold the upgrade, and locks, for as
+// long as it takes, so such updates are declared as Backfills instead, run in batches by the
+// migrations job once the migration is applied.
+type Migration struct {
+	Version   int
+	Name      string
+	Up        migrationStatements
+	Down      migrationStatements
+	Backfills []*Backfill
+
+	// NoTransaction is set for migrations whose statements cannot run within a transaction, such
+	// as those creating an index online on Postgres. Their steps must be safe to run again should
+	// the migration be interrupted.
+	NoTransaction bool
+}
+
+// MigrationPlan holds the statements a migration runs, or would run, against a given database.
+type MigrationPlan struct {
+	Version    int
+	Name       string
+	Statements []string
+}
+
+// AppliedMigration is a migration recorded as applied to the database.
+type AppliedMigration struct {
+	Version   int
+	Name      string
+	AppliedAt int64
+}
+
+// migrations lists every migration in the order they are applied. Changes to the schema must be
+// added here, with a new version, rather than to the legacy upgrades in upgrade.go. This includes
+// the tables of new models: Migrate creates them before applying the migrations, but only the
+// migrations show them to an administrator planning the upgrade.
+var migrations = []*Migration{
+	{
+		Version: 1,
+		Name:    "tokens_extra_2048",
+		Up: forDialects(map[string][]string{
+			model.DATABASE_DRIVER_POSTGRES: {"ALTER TABLE Tokens ALTER COLUMN Extra TYPE varchar(2048)"},
+			model.DATABASE_DRIVER_MYSQL:    {"ALTER TABLE Tokens MODIFY Extra text"},
+		}),
+		// Reverting would truncate the tokens already using the extra length.
+		Down: nil,
+	},
+	{
+		Version: 2,
+		Name:    "incoming_webhooks_payload_template",
+		Up:      addColumn("IncomingWebhooks", "PayloadTemplate", "text", "varchar(8000)", nil),
+		Down:    dropColumn("IncomingWebhooks", "PayloadTemplate"),
+		Backfills: []*Backfill{
+			{
+				Name:  "incoming_webhooks_payload_template",
+				Table: "IncomingWebhooks",
+				Set:   "PayloadTemplate = ''",
+				Where: "PayloadTemplate IS NULL",
+			},
+		},
+	},
+	{
+		Version: 3,
+		Name:    "status_dnd_end_time",
+		Up: sequence(
+			addColumn("Status", "DNDEndTime", "BIGINT", "BIGINT", model.NewString("0")),
+			addColumn("Status", "PrevStatus", "VARCHAR(32)", "VARCHAR(32)", model.NewString("")),
+		),
+		Down: sequence(
+			// SQLite refuses to drop an indexed column.
+			forDialects(map[string][]string{
+				model.DATABASE_DRIVER_SQLITE: {"DROP INDEX IF EXISTS idx_status_dndendtime"},
+			}),
+			dropColumn("Status", "DNDEndTime"),
+			dropColumn("Status", "PrevStatus"),
+		),
+	},
+	{
+		Version: 4,
+		Name:    "custom_status_expiries",
+		Up:      createTable(customStatusExpiry{}, "CustomStatusExpiries"),
+		Down:    dropTable("CustomStatusExpiries"),
+	},
+	{
+		Version: 5,
+		Name:    "working_hours_ends",
+		Up:      createTable(workingHoursEnd{}, "WorkingHoursEnds"),
+		Down:    dropTable("WorkingHoursEnds"),
+	},
+	{
+		Version: 6,
+		Name:    "posts_priority",
+		Up: sequence(
+			createTable(model.PostPriority{}, "PostsPriority"),
+			createTable(model.PostAcknowledgement{}, "PostAcknowledgements"),
+		),
+		Down: sequence(
+			dropTable("PostAcknowledgements"),
+			dropTable("PostsPriority"),
+		),
+	},
+	{
+		Version: 7,
+		Name:    "plugin_config_revisions",
+		Up:      createTable(model.PluginConfigRevision{}, "PluginConfigRevisions"),
+		Down:    dropTable("PluginConfigRevisions"),
+	},
+	{
+		Version: 8,
+		Name:    "post_archive_index",
+		Up:      createTable(model.PostArchiveIndex{}, "PostArchiveIndex"),
+		Down:    dropTable("PostArchiveIndex"),
+	},
+	{
+		Version: 9,
+		Name:    "channel_shards",
+		Up:      createTable(model.ChannelShard{}, "ChannelShards"),
+		Down:    dropTable("ChannelShards"),
+	},
+	{
+		Version: 10,
+		Name:    "audit_log",
+		Up:      createTable(model.AuditLogRecord{}, "AuditLog"),
+		Down:    dropTable("AuditLog"),
+	},
+	{
+		// The row updates of the legacy upgrades of the largest tables, which only match rows on
+		// databases upgraded from the versions noted.
+		Version: 11,
+		Name:    "legacy_upgrade_backfills",
+		Up:      noStatements,
+		Down:    noStatements,
+		Backfills: []*Backfill{
+			// 3.5
+			{Name: "users_system_user_role", Table: "Users", Set: "Roles = 'system_user'", Where: "Roles = ''"},
+			{Name: "users_system_admin_role", Table: "Users", Set: "Roles = 'system_user system_admin'", Where: "Roles = 'system_admin'"},
+			{Name: "team_members_team_user_role", Table: "TeamMembers", Keys: []string{"TeamId", "UserId"}, Set: "Roles = 'team_user'", Where: "Roles = ''"},
+			{Name: "team_members_team_admin_role", Table: "TeamMembers", Keys: []string{"TeamId", "UserId"}, Set: "Roles = 'team_user team_admin'", Where: "Roles = 'admin'"},
+			{Name: "channel_members_channel_user_role", Table: "ChannelMembers", Keys: []string{"ChannelId", "UserId"}, Set: "Roles = 'channel_user'", Where: "Roles = ''"},
+			{Name: "channel_members_channel_admin_role", Table: "ChannelMembers", Keys: []string{"ChannelId", "UserId"}, Set: "Roles = 'channel_user channel_admin'", Where: "Roles = 'admin'"},
+			// 4.10
+			{Name: "users_saml_auth_data_lower", Table: "Users", Set: "AuthData = LOWER(AuthData)", Where: "AuthService = 'saml' AND AuthData <> LOWER(AuthData)"},
+		},
+	},
+	{
+		Version: 12,
+		Name:    "post_archive_index_search",
+		Up: sequence(
+			addColumn("PostArchiveIndex", "UserId", "VARCHAR(26)", "VARCHAR(26)", model.NewString("")),
+			addColumn("PostArchiveIndex", "Type", "VARCHAR(26)", "VARCHAR(26)", model.NewString("")),
+			addColumn("PostArchiveIndex", "DeleteAt", "BIGINT", "BIGINT", model.NewString("0")),
+			addColumn("PostArchiveIndex", "Message", "text", "varchar(65535)", nil),
+			addColumn("PostArchiveIndex", "Hashtags", "VARCHAR(1000)", "VARCHAR(1000)", model.NewString("")),
+		),
+		Down: sequence(
+			dropFullTextIndex("idx_postarchiveindex_hashtags_txt", "PostArchiveIndex"),
+			dropFullTextIndex("idx_postarchiveindex_message_txt", "PostArchiveIndex"),
+			dropColumn("PostArchiveIndex", "Hashtags"),
+			dropColumn("PostArchiveIndex", "Message"),
+			dropColumn("PostArchiveIndex", "DeleteAt"),
+			dropColumn("PostArchiveIndex", "Type"),
+			dropColumn("PostArchiveIndex", "UserId"),
+		),
+	},
+}
+
+// noStatements is the step of a migration that only declares backfills.
+func noStatements(sqlStore SqlStore) ([]string, error) {
+	return nil, nil
+}
+
+// forDialects runs the statements given for the configured driver, and nothing for the others.
+func forDialects(statements map[string][]string) migrationStatements {
+	return func(sqlStore SqlStore) ([]string, error) {
+		return statements[sqlStore.DriverName()], nil
+	}
+}
+
+// sequence runs the statements of each step in turn.
+func sequence(steps ...migrationStatements) migrationStatements {
+	return func(sqlStore SqlStore) ([]string, error) {
+		var statements []string
+		for _, step := range steps {
+			stepStatements, err := step(sqlStore)
+			if err != nil {
+				return nil, err
+			}
+			statements = append(statements, stepStatements...)
+		}
+		return statements, nil
+	}
+}
+
+// addColumn adds the column unless it already exists. SQLite accepts the MySQL column types,
+// mapping them to its own type affinities. Online, MySQL is required to add it without blocking
+// writes, failing otherwise, while Postgres 11 and later never rewrite the table to add a column.
+func addColumn(tableName, columnName, mySqlColType, postgresColType string, defaultValue *string) migrationStatements {
+	return func(sqlStore SqlStore) ([]string, error) {
+		if sqlStore.DoesColumnExist(tableName, columnName) {
+			return nil, nil
+		}
+
+		colType := mySqlColType
+		if sqlStore.DriverName() == model.DATABASE_DRIVER_POSTGRES {
+			colType = postgresColType
+		}
+
+		statement := "ALTER TABLE " + tableName + " ADD " + columnName + " " + colType
+		if defaultValue != nil {
+			statement += " DEFAULT '" + *defaultValue + "'"
+		}
+		if sqlStore.OnlineMigrations() && sqlStore.DriverName() == model.DATABASE_DRIVER_MYSQL {
+			statement += ", ALGORITHM=INPLACE, LOCK=NONE"
+		}
+
+		return []string{statement}, nil
+	}
+}
+
+// createIndex creates the index unless it already exists. Online, the index is built without
+// blocking writes, which on Postgres requires the migration to run outside of a transaction.
+func createIndex(indexName, tableName string, columnNames []string, unique bool) migrationStatements {
+	return func(sqlStore SqlStore) ([]string, error) {
+		exists, valid, err := indexExists(sqlStore, indexName, tableName)
+		if err != nil {
+			return nil, err
+		}
+		if exists && valid {
+			return nil, nil
+		}
+
+		var statements []string
+		if exists {
+			// An interrupted concurrent build leaves an invalid index behind, which is never used.
+			statements = append(statements, "DROP INDEX CONCURRENTLY "+indexName)
+		}
+
+		return append(statements, createIndexQuery(sqlStore.DriverName(), indexName, tableName, columnNames, unique, sqlStore.OnlineMigrations())), nil
+	}
+}
+
+// dropIndex drops the index if it exists.
+func dropIndex(indexName, tableName string) migrationStatements {
+	return func(sqlStore SqlStore) ([]string, error) {
+		exists, _, err := indexExists(sqlStore, indexName, tableName)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, nil
+		}
+
+		switch sqlStore.DriverName() {
+		case model.DATABASE_DRIVER_MYSQL:
+			statement := "DROP INDEX " + indexName + " ON " + tableName
+			if sqlStore.OnlineMigrations() {
+				statement += " ALGORITHM=INPLACE LOCK=NONE"
+			}
+			return []string{statement}, nil
+		case model.DATABASE_DRIVER_POSTGRES:
+			if sqlStore.OnlineMigrations() {
+				return []string{"DROP INDEX CONCURRENTLY " + indexName}, nil
+			}
+		}
+
+		return []string{"DROP INDEX " + indexName}, nil
+	}
+}
+
+// dropFullTextIndex drops the full text index if it exists. On SQLite, it is a virtual table kept
+// up to date by triggers, which fail once the columns they copy are dropped.
+func dropFullTextIndex(indexName, tableName string) migrationStatements {
+	return func(sqlStore SqlStore) ([]string, error) {
+		if sqlStore.DriverName() != model.DATABASE_DRIVER_SQLITE {
+			return dropIndex(indexName, tableName)(sqlStore)
+		}
+
+		if !sqlStore.DoesTableExist(indexName) {
+			return nil, nil
+		}
+
+		var statements []string
+		for _, suffix := range sqliteFullTextTriggerSuffixes {
+			statements = append(statements, "DROP TRIGGER IF EXISTS "+indexName+suffix)
+		}
+		return append(statements, "DROP TABLE "+indexName), nil
+	}
+}
+
+// indexExists reports whether the index exists and, on Postgres, whether it is valid.
+func indexExists(sqlStore SqlStore, indexName, tableName string) (bool, bool, error) {
+	var count int64
+	var err error
+	switch sqlStore.DriverName() {
+	case model.DATABASE_DRIVER_POSTGRES:
+		count, err = sqlStore.GetMaster().SelectInt("SELECT COUNT(0) FROM pg_class WHERE relname = $1 AND relkind = 'i'", strings.ToLower(indexName))
+		if err == nil && count > 0 {
+			var invalid int64
+			invalid, err = sqlStore.GetMaster().SelectInt("SELECT COUNT(0) FROM pg_index WHERE indexrelid = $1::regclass AND NOT indisvalid", strings.ToLower(indexName))
+			if err == nil {
+				return true, invalid == 0, nil
+			}
+		}
+	case model.DATABASE_DRIVER_MYSQL:
+		count, err = sqlStore.GetMaster().SelectInt("SELECT COUNT(0) FROM information_schema.statistics WHERE TABLE_SCHEMA = DATABASE() AND table_name = ? AND index_name = ?", tableName, indexName)
+	default:
+		count, err = sqlStore.GetMaster().SelectInt("SELECT COUNT(0) FROM sqlite_master WHERE type = 'index' AND name = ?", indexName)
+	}
+
+	if err != nil {
+		return false, false, errors.Wrapf(err, "failed to check index %s", indexName)
+	}
+
+	return count > 0, count > 0, nil
+}
+
+// dropColumn drops the column if it exists.
+func dropColumn(tableName, columnName string) migrationStatements {
+	return func(sqlStore SqlStore) ([]string, error) {
+		if !sqlStore.DoesColumnExist(tableName, columnName) {
+			return nil, nil
+		}
+
+		return []string{"ALTER TABLE " + tableName + " DROP COLUMN " + columnName}, nil
+	}
+}
+
+// createTable creates the table of the given model, as mapped by its store, unless it already
+// exists.
+func createTable(value interface{}, tableName string) migrationStatements {
+	return func(sqlStore SqlStore) ([]string, error) {
+		if sqlStore.DoesTableExist(tableName) {
+			return nil, nil
+		}
+
+		table, err := sqlStore.GetMaster().TableFor(reflect.TypeOf(value), false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to find the mapping of table %s", tableName)
+		}
+
+		// The plans print their own statement terminators.
+		return []string{strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(table.SqlForCreate(false)), ";"))}, nil
+	}
+}
+
+// dropTable drops the table if it exists.
+func dropTable(tableName string) migrationStatements {
+	return func(sqlStore SqlStore) ([]string, error) {
+		if !sqlStore.DoesTableExist(tableName) {
+			return nil, nil
+		}
+
+		return []string{"DROP TABLE " + tableName}, nil
+	}
+}
+
+func createMigrationsTableIfNotExists(sqlStore SqlStore) error {
+	if _, err := sqlStore.GetMaster().ExecNoTimeout("CREATE TABLE IF NOT EXISTS " + MIGRATIONS_TABLE + " (Version integer NOT NULL PRIMARY KEY, Name varchar(64) NOT NULL, AppliedAt bigint NOT NULL)"); err != nil {
+		return errors.Wrap(err, "failed to create the migrations table")
+	}
+
+	return nil
+}
+
+// appliedMigrations returns the migrations recorded as applied, in order of version. A database
+// without the migrations table has none.
+func appliedMigrations(sqlStore SqlStore) ([]*AppliedMigration, error) {
+	if !sqlStore.DoesTableExist(MIGRATIONS_TABLE) {
+		return []*AppliedMigration{}, nil
+	}
+
+	var applied []*AppliedMigration
+	if _, err := sqlStore.GetMaster().Select(&applied, "SELECT Version, Name, AppliedAt FROM "+MIGRATIONS_TABLE+" ORDER BY Version"); err != nil {
+		return nil, errors.Wrap(err, "failed to get the applied migrations")
+	}
+
+	return applied, nil
+}
+
+// pendingMigrations returns the migrations not yet applied, in the order they would be.
+func pendingMigrations(sqlStore SqlStore, all []*Migration) ([]*Migration, error) {
+	applied, err := appliedMigrations(sqlStore)
+	if err != nil {
+		return nil, err
+	}
+
+	appliedVersions := make(map[int]bool, len(applied))
+	for _, migration := range applied {
+		appliedVersions[migration.Version] = true
+	}
+
+	pending := []*Migration{}
+	for _, migration := range sortedMigrations(all) {
+		if !appliedVersions[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+
+	return pending, nil
+}
+
+// revertibleMigrations returns the applied migrations above the given version, latest first,
+// failing if any of them cannot be reverted.
+func revertibleMigrations(sqlStore SqlStore, all []*Migration, toVersion int) ([]*Migration, error) {
+	applied, err := appliedMigrations(sqlStore)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[int]*Migration, len(all))
+	for _, migration := range all {
+		known[migration.Version] = migration
+	}
+
+	reverted := []*Migration{}
+	for i := len(applied) - 1; i >= 0 && applied[i].Version > toVersion; i-- {
+		migration, ok := known[applied[i].Version]
+		if !ok {
+			return nil, errors.Errorf("migration %d %s was applied by a newer version of Mattermost and cannot be reverted by this one", applied[i].Version, applied[i].Name)
+		}
+		if migration.Down == nil {
+			return nil, errors.Errorf("migration %d %s cannot be reverted", migration.Version, migration.Name)
+		}
+		reverted = append(reverted, migration)
+	}
+
+	return reverted, nil
+}
+
+func sortedMigrations(all []*Migration) []*Migration {
+	sorted := make([]*Migration, len(all))
+	copy(sorted, all)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+
+	return sorted
+}
+
+func planUp(sqlStore SqlStore, migration *Migration) (*MigrationPlan, error) {
+	statements, err := migration.Up(sqlStore)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to plan migration %d %s", migration.Version, migration.Name)
+	}
+
+	return &MigrationPlan{Version: migration.Version, Name: migration.Name, Statements: statements}, nil
+}
+
+func planDown(sqlStore SqlStore, migration *Migration) (*MigrationPlan, error) {
+	statements, err := migration.Down(sqlStore)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to plan reverting migration %d %s", migration.Version, migration.Name)
+	}
+
+	return &MigrationPlan{Version: migration.Version, Name: migration.Name, Statements: statements}, nil
+}
+
+// runMigration executes the statements of the plan and records the outcome in a single
+// transaction. MySQL commits implicitly after each schema change, so a failure part way through a
+// migration leaves the statements before it applied there.
+func runMigration(sqlStore SqlStore, plan *MigrationPlan, up bool, noTransaction bool) error {
+	if noTransaction {
+		for _, statement := range plan.Statements {
+			if _, err := sqlStore.GetMaster().ExecNoTimeout(statement); err != nil {
+				return errors.Wrapf(err, "failed to run migration %d %s", plan.Version, plan.Name)
+			}
+		}
+
+		return recordMigration(sqlStore.GetMaster(), plan, up)
+	}
+
+	transaction, err := sqlStore.GetMaster().Begin()
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	for _, statement := range plan.Statements {
+		if _, err = transaction.Exec(statement); err != nil {
+			return errors.Wrapf(err, "failed to run migration %d %s", plan.Version, plan.Name)
+		}
+	}
+
+	if err = recordMigration(transaction, plan, up); err != nil {
+		return err
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+// recordMigration records the migration as applied, or no longer applied.
+func recordMigration(executor gorp.SqlExecutor, plan *MigrationPlan, up bool) error {
+	var err error
+	if up {
+		_, err = executor.Exec("INSERT INTO "+MIGRATIONS_TABLE+" (Version, Name, AppliedAt) VALUES (:Version, :Name, :AppliedAt)", map[string]interface{}{"Version": plan.Version, "Name": plan.Name, "AppliedAt": model.GetMillis()})
+	} else {
+		_, err = executor.Exec("DELETE FROM "+MIGRATIONS_TABLE+" WHERE Version = :Version", map[string]interface{}{"Version": plan.Version})
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to record migration %d %s", plan.Version, plan.Name)
+	}
+
+	return nil
+}
+
+// applyMigrations applies the pending migrations in order, stopping at the first failure. On a
+// fresh database, whose tables were just created from the current models, the migrations are only
+// recorded as applied.
+func applyMigrations(sqlStore SqlStore, all []*Migration, fresh bool) ([]*MigrationPlan, error) {
+	if err := createMigrationsTableIfNotExists(sqlStore); err != nil {
+		return nil, err
+	}
+
+	pending, err := pendingMigrations(sqlStore, all)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := []*MigrationPlan{}
+	for _, migration := range pending {
+		plan := &MigrationPlan{Version: migration.Version, Name: migration.Name}
+		if !fresh {
+			if plan, err = planUp(sqlStore, migration); err != nil {
+				return applied, err
+			}
+		}
+
+		if err := runMigration(sqlStore, plan, true, migration.NoTransaction); err != nil {
+			return applied, err
+		}
+
+		mlog.Info("Applied database migration.", mlog.Int("version", plan.Version), mlog.String("name", plan.Name), mlog.Int("statements", len(plan.Statements)))
+		applied = append(applied, plan)
+	}
+
+	return applied, nil
+}
+
+// revertMigrations reverts the applied migrations above the given version, latest first.
+func revertMigrations(sqlStore SqlStore, all []*Migration, toVersion int) ([]*MigrationPlan, error) {
+	revertible, err := revertibleMigrations(sqlStore, all, toVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	reverted := []*MigrationPlan{}
+	for _, migration := range revertible {
+		plan, err := planDown(sqlStore, migration)
+		if err != nil {
+			return reverted, err
+		}
+
+		if err := runMigration(sqlStore, plan, false, migration.NoTransaction); err != nil {
+			return reverted, err
+		}
+
+		mlog.Info("Reverted database migration.", mlog.Int("version", plan.Version), mlog.String("name", plan.Name), mlog.Int("statements", len(plan.Statements)))
+		reverted = append(reverted, plan)
+	}
+
+	return reverted, nil
+}
+
+// sqliteMigrationsMutex serializes migrations within the process for SQLite, which only supports
+// a single server.
+var sqliteMigrationsMutex sync.Mutex
+
+// lockMigrations takes a lock held by at most one server of the cluster at a time, waiting for it
+// if another is migrating the database. The returned function releases it.
+func lockMigrations(sqlStore SqlStore) (func(), error) {
+	if sqlStore.DriverName() == model.DATABASE_DRIVER_SQLITE {
+		sqliteMigrationsMutex.Lock()
+		return sqliteMigrationsMutex.Unlock, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), MIGRATIONS_LOCK_TIMEOUT)
+	defer cancel()
+
+	// Both MySQL and Postgres tie the lock to the session taking it, so it is held on a dedicated
+	// connection and released along with it should the server die.
+	conn, err := sqlStore.GetMaster().Db.Conn(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get a connection for the migrations lock")
+	}
+
+	var lock, unlock string
+	var args []interface{}
+	switch sqlStore.DriverName() {
+	case model.DATABASE_DRIVER_MYSQL:
+		// MySQL locks are server wide, so the name includes the database.
+		lock = "SELECT GET_LOCK(CONCAT(?, '_', CRC32(DATABASE())), ?)"
+		unlock = "SELECT RELEASE_LOCK(CONCAT(?, '_', CRC32(DATABASE())))"
+		args = []interface{}{MIGRATIONS_LOCK_NAME, int(MIGRATIONS_LOCK_TIMEOUT.Seconds())}
+	case model.DATABASE_DRIVER_POSTGRES:
+		lock = "SELECT 1 FROM pg_advisory_lock($1)"
+		unlock = "SELECT pg_advisory_unlock($1)"
+		args = []interface{}{int64(crc32.ChecksumIEEE([]byte(MIGRATIONS_LOCK_NAME)))}
+	default:
+		conn.Close()
+		return nil, errors.Errorf("unsupported driver %s", sqlStore.DriverName())
+	}
+
+	mlog.Debug("Acquiring the migrations lock.")
+
+	var acquired *int
+	if err = conn.QueryRowContext(ctx, lock, args...).Scan(&acquired); err != nil || acquired == nil || *acquired != 1 {
+		conn.Close()
+		if err == nil {
+			err = errors.New("timed out")
+		}
+		return nil, errors.Wrap(err, "failed to acquire the migrations lock")
+	}
+
+	return func() {
+		if _, err := conn.ExecContext(context.Background(), unlock, args[0]); err != nil {
+			mlog.Warn("Failed to release the migrations lock.", mlog.Err(err))
+		}
+		conn.Close()
+	}, nil
+}
+
+// Migrate brings the schema up to date: it creates the missing tables, runs the legacy upgrades,
+// applies the pending migrations and creates the missing indexes, all while holding the migrations
+// lock. It returns the migrations applied.
+func (ss *SqlSupplier) Migrate() ([]*MigrationPlan, error) {
+	unlock, err := lockMigrations(ss)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if err = ss.GetMaster().CreateTablesIfNotExists(); err != nil {
+		return nil, errors.Wrap(err, "failed to create tables")
+	}
+
+	// The tables of a fresh database were created from the current models, leaving nothing for
+	// the migrations to do.
+	fresh := ss.GetCurrentSchemaVersion() == ""
+
+	if err = UpgradeDatabase(ss, model.CurrentVersion); err != nil {
+		return nil, errors.Wrap(err, "failed to upgrade database")
+	}
+
+	applied, err := applyMigrations(ss, migrations, fresh)
+	if err != nil {
+		return applied, err
+	}
+
+	ss.createIndexesIfNotExists()
+	ss.oldStores.preference.(*SqlPreferenceStore).DeleteUnusedFeatures()
+
+	return applied, nil
+}
+
+// PlanMigrations returns the pending migrations and the statements they would run against the
+// database as it is, without changing it. The legacy upgrades run their statements directly, so
+// they cannot be planned: a database whose schema version is behind must be migrated first.
+func (ss *SqlSupplier) PlanMigrations() ([]*MigrationPlan, error) {
+	if pending, err := legacyUpgradesPending(ss, model.CurrentVersion); err != nil {
+		return nil, err
+	} else if pending {
+		return nil, errors.Errorf("the legacy upgrades from schema version %s to %s cannot be planned, back up the database and migrate it first", ss.GetCurrentSchemaVersion(), model.CurrentVersion)
+	}
+
+	pending, err := pendingMigrations(ss, migrations)
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make([]*MigrationPlan, len(pending))
+	for i, migration := range pending {
+		if plans[i], err = planUp(ss, migration); err != nil {
+			return nil, err
+		}
+	}
+
+	return plans, nil
+}
+
+// Downgrade reverts the applied migrations above the given version, latest first, while holding
+// the migrations lock. Nothing is reverted if any of them cannot be. It returns the migrations
+// reverted.
+func (ss *SqlSupplier) Downgrade(toVersion int) ([]*MigrationPlan, error) {
+	unlock, err := lockMigrations(ss)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return revertMigrations(ss, migrations, toVersion)
+}
+
+// PlanDowngrade returns the migrations Downgrade would revert and the statements they would run,
+// without changing the database.
+func (ss *SqlSupplier) PlanDowngrade(toVersion int) ([]*MigrationPlan, error) {
+	revertible, err := revertibleMigrations(ss, migrations, toVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make([]*MigrationPlan, len(revertible))
+	for i, migration := range revertible {
+		if plans[i], err = planDown(ss, migration); err != nil {
+			return nil, err
+		}
+	}
+
+	return plans, nil
+}
+
+// AppliedMigrations returns the migrations recorded as applied to the database, in order.
+func (ss *SqlSupplier) AppliedMigrations() ([]*AppliedMigration, error) {
+	return appliedMigrations(ss)
+}
+
+// String formats the plan as a SQL script.
+func (p *MigrationPlan) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("-- %d %s\n", p.Version, p.Name))
+	if len(p.Statements) == 0 {
+		sb.WriteString("-- nothing to run, the schema already matches\n")
+	}
+	for _, statement := range p.Statements {
+		sb.WriteString(statement + ";\n")
+	}
+
+	return sb.String()
+}
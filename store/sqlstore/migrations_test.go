@@ -0,0 +1,250 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func appliedVersions(t *testing.T, supplier *SqlSupplier) []int {
+	applied, err := supplier.AppliedMigrations()
+	require.Nil(t, err)
+
+	versions := []int{}
+	for _, migration := range applied {
+		versions = append(versions, migration.Version)
+	}
+	return versions
+}
+
+func TestMigrations(t *testing.T) {
+	t.Run("versions are unique and names are set", func(t *testing.T) {
+		versions := make(map[int]bool)
+		for _, migration := range migrations {
+			assert.False(t, versions[migration.Version], "duplicate version %d", migration.Version)
+			assert.NotEmpty(t, migration.Name)
+			assert.NotNil(t, migration.Up)
+			versions[migration.Version] = true
+		}
+	})
+
+	t.Run("fresh database records every migration as applied", func(t *testing.T) {
+		supplier := newReplicatedSqliteSupplier(t, 0, 0)
+		defer supplier.Close()
+
+		assert.Len(t, appliedVersions(t, supplier), len(migrations))
+
+		plans, err := supplier.PlanMigrations()
+		require.Nil(t, err)
+		assert.Empty(t, plans)
+	})
+
+	t.Run("downgrade and migrate again", func(t *testing.T) {
+		supplier := newReplicatedSqliteSupplier(t, 0, 0)
+		defer supplier.Close()
+
+		plans, err := supplier.PlanDowngrade(2)
+		require.Nil(t, err)
+		require.Len(t, plans, len(migrations)-2)
+		assert.Equal(t, "post_archive_index_search", plans[0].Name)
+		assert.Contains(t, plans[0].Statements, "DROP TABLE idx_postarchiveindex_message_txt")
+		assert.Contains(t, plans[0].Statements, "ALTER TABLE PostArchiveIndex DROP COLUMN Message")
+		assert.Equal(t, "legacy_upgrade_backfills", plans[1].Name)
+		assert.Empty(t, plans[1].Statements)
+		assert.Equal(t, "audit_log", plans[2].Name)
+		assert.Equal(t, []string{"DROP TABLE AuditLog"}, plans[2].Statements)
+		assert.Equal(t, "status_dnd_end_time", plans[len(plans)-1].Name)
+		assert.Contains(t, plans[len(plans)-1].Statements, "ALTER TABLE Status DROP COLUMN DNDEndTime")
+		assert.True(t, supplier.DoesColumnExist("Status", "DNDEndTime"), "planning must not change the schema")
+
+		reverted, err := supplier.Downgrade(2)
+		require.Nil(t, err)
+		assert.Equal(t, plans, reverted)
+		assert.False(t, supplier.DoesColumnExist("Status", "DNDEndTime"))
+		assert.False(t, supplier.DoesColumnExist("Status", "PrevStatus"))
+		assert.False(t, supplier.DoesTableExist("AuditLog"))
+		assert.Equal(t, []int{1, 2}, appliedVersions(t, supplier))
+
+		plans, err = supplier.PlanMigrations()
+		require.Nil(t, err)
+		require.Len(t, plans, len(migrations)-2)
+		assert.Equal(t, []string{
+			"ALTER TABLE Status ADD DNDEndTime BIGINT DEFAULT '0'",
+			"ALTER TABLE Status ADD PrevStatus VARCHAR(32) DEFAULT ''",
+		}, plans[0].Statements)
+		auditLogPlan := plans[len(plans)-3]
+		require.Len(t, auditLogPlan.Statements, 1)
+		assert.True(t, strings.HasPrefix(auditLogPlan.Statements[0], `create table "AuditLog" (`), auditLogPlan.Statements[0])
+		assert.False(t, strings.HasSuffix(auditLogPlan.Statements[0], ";"))
+		assert.False(t, supplier.DoesColumnExist("Status", "DNDEndTime"), "planning must not change the schema")
+		assert.False(t, supplier.DoesTableExist("AuditLog"), "planning must not change the schema")
+
+		applied, err := supplier.Migrate()
+		require.Nil(t, err)
+		require.Len(t, applied, len(plans))
+		assert.Equal(t, plans[0], applied[0])
+		assert.True(t, supplier.DoesColumnExist("Status", "DNDEndTime"))
+		assert.True(t, supplier.DoesColumnExist("Status", "PrevStatus"))
+		assert.True(t, supplier.DoesTableExist("AuditLog"))
+		assert.Len(t, appliedVersions(t, supplier), len(migrations))
+	})
+
+	t.Run("new tables are created by their migrations", func(t *testing.T) {
+		supplier := newReplicatedSqliteSupplier(t, 0, 0)
+		defer supplier.Close()
+
+		_, err := supplier.Downgrade(9)
+		require.Nil(t, err)
+		require.False(t, supplier.DoesTableExist("AuditLog"))
+
+		_, err = applyMigrations(supplier, migrations, false)
+		require.Nil(t, err)
+		assert.True(t, supplier.DoesTableExist("AuditLog"))
+	})
+
+	t.Run("the legacy upgrades cannot be planned", func(t *testing.T) {
+		supplier := newReplicatedSqliteSupplier(t, 0, 0)
+		defer supplier.Close()
+
+		_, err := supplier.GetMaster().Exec("UPDATE Systems SET Value = '5.0.0' WHERE Name = 'Version'")
+		require.Nil(t, err)
+
+		_, err = supplier.PlanMigrations()
+		require.EqualError(t, err, "the legacy upgrades from schema version 5.0.0 to "+model.CurrentVersion+" cannot be planned, back up the database and migrate it first")
+	})
+
+	t.Run("irreversible migrations prevent the downgrade", func(t *testing.T) {
+		supplier := newReplicatedSqliteSupplier(t, 0, 0)
+		defer supplier.Close()
+
+		_, err := supplier.PlanDowngrade(0)
+		require.EqualError(t, err, "migration 1 tokens_extra_2048 cannot be reverted")
+
+		_, err = supplier.Downgrade(0)
+		require.EqualError(t, err, "migration 1 tokens_extra_2048 cannot be reverted")
+		assert.Len(t, appliedVersions(t, supplier), len(migrations))
+		assert.True(t, supplier.DoesColumnExist("Status", "DNDEndTime"))
+	})
+}
+
+func TestApplyMigrations(t *testing.T) {
+	supplier := newReplicatedSqliteSupplier(t, 0, 0)
+	defer supplier.Close()
+
+	testMigrations := []*Migration{
+		{
+			Version: 1001,
+			Name:    "second",
+			Up:      addColumn("MigrationTest", "Extra", "VARCHAR(32)", "VARCHAR(32)", nil),
+			Down:    dropColumn("MigrationTest", "Extra"),
+		},
+		{
+			Version: 1000,
+			Name:    "first",
+			Up: func(sqlStore SqlStore) ([]string, error) {
+				return []string{"CREATE TABLE MigrationTest (Id varchar(26) NOT NULL PRIMARY KEY)"}, nil
+			},
+		},
+	}
+
+	t.Run("pending migrations are applied in order of version", func(t *testing.T) {
+		applied, err := applyMigrations(supplier, testMigrations, false)
+		require.Nil(t, err)
+		require.Len(t, applied, 2)
+		assert.Equal(t, "first", applied[0].Name)
+		assert.Equal(t, "second", applied[1].Name)
+		assert.True(t, supplier.DoesColumnExist("MigrationTest", "Extra"))
+	})
+
+	t.Run("applied migrations are not run again", func(t *testing.T) {
+		applied, err := applyMigrations(supplier, testMigrations, false)
+		require.Nil(t, err)
+		assert.Empty(t, applied)
+	})
+
+	t.Run("a failed migration is not recorded", func(t *testing.T) {
+		failing := append(testMigrations, &Migration{
+			Version: 1002,
+			Name:    "failing",
+			Up: func(sqlStore SqlStore) ([]string, error) {
+				return []string{"ALTER TABLE MigrationTest ADD Other VARCHAR(32)", "NOT SQL"}, nil
+			},
+		})
+
+		applied, err := applyMigrations(supplier, failing, false)
+		require.NotNil(t, err)
+		assert.Empty(t, applied)
+		assert.False(t, supplier.DoesColumnExist("MigrationTest", "Other"), "the failed migration must be rolled back")
+
+		pending, err := pendingMigrations(supplier, failing)
+		require.Nil(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, "failing", pending[0].Name)
+	})
+
+	t.Run("reverting stops at the first irreversible migration", func(t *testing.T) {
+		_, err := revertMigrations(supplier, testMigrations, 0)
+		require.EqualError(t, err, "migration 1000 first cannot be reverted")
+		assert.True(t, supplier.DoesColumnExist("MigrationTest", "Extra"))
+
+		reverted, err := revertMigrations(supplier, testMigrations, 1000)
+		require.Nil(t, err)
+		require.Len(t, reverted, 1)
+		assert.Equal(t, "second", reverted[0].Name)
+		assert.False(t, supplier.DoesColumnExist("MigrationTest", "Extra"))
+	})
+
+	t.Run("migrations unknown to this version cannot be reverted", func(t *testing.T) {
+		_, err := applyMigrations(supplier, testMigrations, false)
+		require.Nil(t, err)
+
+		_, err = revertMigrations(supplier, testMigrations[1:], 1000)
+		require.EqualError(t, err, "migration 1001 second was applied by a newer version of Mattermost and cannot be reverted by this one")
+	})
+}
+
+func TestMigrationPlanString(t *testing.T) {
+	plan := &MigrationPlan{Version: 3, Name: "status_dnd_end_time", Statements: []string{"ALTER TABLE Status ADD DNDEndTime BIGINT"}}
+	assert.Equal(t, "-- 3 status_dnd_end_time\nALTER TABLE Status ADD DNDEndTime BIGINT;\n", plan.String())
+
+	plan.Statements = nil
+	assert.Equal(t, "-- 3 status_dnd_end_time\n-- nothing to run, the schema already matches\n", plan.String())
+}
+
+func TestLockMigrations(t *testing.T) {
+	supplier := newReplicatedSqliteSupplier(t, 0, 0)
+	defer supplier.Close()
+
+	unlock, err := lockMigrations(supplier)
+	require.Nil(t, err)
+
+	acquired := make(chan struct{})
+	go func() {
+		secondUnlock, err := lockMigrations(supplier)
+		require.Nil(t, err)
+		close(acquired)
+		secondUnlock()
+	}()
+
+	select {
+	case <-acquired:
+		require.Fail(t, "the lock must not be acquired while held")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock()
+
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the lock must be acquired once released")
+	}
+}
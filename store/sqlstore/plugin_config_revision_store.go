@@ -0,0 +1,73 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlPluginConfigRevisionStore struct {
+	SqlStore
+}
+
+func NewSqlPluginConfigRevisionStore(sqlStore SqlStore) store.PluginConfigRevisionStore {
+	s := &SqlPluginConfigRevisionStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.PluginConfigRevision{}, "PluginConfigRevisions").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("PluginId").SetMaxSize(190)
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("Settings").SetMaxSize(model.POST_MESSAGE_MAX_BYTES_V2)
+		table.ColMap("ChangedKeys").SetMaxSize(4000)
+	}
+
+	return s
+}
+
+func (s SqlPluginConfigRevisionStore) CreateIndexesIfNotExists() {
+	s.CreateCompositeIndexIfNotExists("idx_pluginconfigrevisions_plugin_id_create_at", "PluginConfigRevisions", []string{"PluginId", "CreateAt"})
+}
+
+func (s SqlPluginConfigRevisionStore) Save(revision *model.PluginConfigRevision) (*model.PluginConfigRevision, *model.AppError) {
+	revision.PreSave()
+	if err := revision.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(revision); err != nil {
+		return nil, model.NewAppError("SqlPluginConfigRevisionStore.Save", "store.sql_plugin_config_revision.save.app_error", nil, "plugin_id="+revision.PluginId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return revision, nil
+}
+
+func (s SqlPluginConfigRevisionStore) Get(id string) (*model.PluginConfigRevision, *model.AppError) {
+	var revision *model.PluginConfigRevision
+
+	if err := s.GetReplica().SelectOne(&revision, "SELECT * FROM PluginConfigRevisions WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlPluginConfigRevisionStore.Get", "store.sql_plugin_config_revision.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlPluginConfigRevisionStore.Get", "store.sql_plugin_config_revision.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return revision, nil
+}
+
+// GetForPlugin returns the plugin's configuration revisions, newest first.
+func (s SqlPluginConfigRevisionStore) GetForPlugin(pluginId string, offset, limit int) ([]*model.PluginConfigRevision, *model.AppError) {
+	var revisions []*model.PluginConfigRevision
+
+	if _, err := s.GetReplica().Select(&revisions, "SELECT * FROM PluginConfigRevisions WHERE PluginId = :PluginId ORDER BY CreateAt DESC, Id LIMIT :Limit OFFSET :Offset",
+		map[string]interface{}{"PluginId": pluginId, "Limit": limit, "Offset": offset}); err != nil {
+		return nil, model.NewAppError("SqlPluginConfigRevisionStore.GetForPlugin", "store.sql_plugin_config_revision.get_for_plugin.app_error", nil, "plugin_id="+pluginId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return revisions, nil
+}
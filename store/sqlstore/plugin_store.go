@@ -61,6 +61,10 @@ func (ps SqlPluginStore) SaveOrUpdate(kv *model.PluginKeyValue) (*model.PluginKe
 		if _, err := ps.GetMaster().Exec("INSERT INTO PluginKeyValueStore (PluginId, PKey, PValue, ExpireAt) VALUES(:PluginId, :Key, :Value, :ExpireAt) ON DUPLICATE KEY UPDATE PValue = :Value, ExpireAt = :ExpireAt", map[string]interface{}{"PluginId": kv.PluginId, "Key": kv.Key, "Value": kv.Value, "ExpireAt": kv.ExpireAt}); err != nil {
 			return nil, model.NewAppError("SqlPluginStore.SaveOrUpdate", "store.sql_plugin_store.save.app_error", nil, err.Error(), http.StatusInternalServerError)
 		}
+	} else if ps.DriverName() == model.DATABASE_DRIVER_SQLITE {
+		if _, err := ps.GetMaster().Exec("INSERT INTO PluginKeyValueStore (PluginId, PKey, PValue, ExpireAt) VALUES(:PluginId, :Key, :Value, :ExpireAt) ON CONFLICT (PluginId, PKey) DO UPDATE SET PValue = :Value, ExpireAt = :ExpireAt", map[string]interface{}{"PluginId": kv.PluginId, "Key": kv.Key, "Value": kv.Value, "ExpireAt": kv.ExpireAt}); err != nil {
+			return nil, model.NewAppError("SqlPluginStore.SaveOrUpdate", "store.sql_plugin_store.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
 	}
 
 	return kv, nil
@@ -147,6 +151,149 @@ func (ps SqlPluginStore) CompareAndDelete(kv *model.PluginKeyValue, oldValue []b
 	return true, nil
 }
 
+func (ps SqlPluginStore) SetWithOptions(pluginId string, key string, value []byte, options model.PluginKVSetOptions) (bool, *model.AppError) {
+	if err := options.IsValid(); err != nil {
+		return false, err
+	}
+
+	currentTime := model.GetMillis()
+	kv := &model.PluginKeyValue{
+		PluginId: pluginId,
+		Key:      key,
+		Value:    value,
+		ExpireAt: options.ExpireAt(currentTime),
+	}
+
+	if err := kv.IsValid(); err != nil {
+		return false, err
+	}
+
+	if !options.Atomic {
+		if value == nil {
+			if err := ps.Delete(pluginId, key); err != nil {
+				return false, err
+			}
+			return true, nil
+		}
+
+		if _, err := ps.SaveOrUpdate(kv); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	if options.OldValue == nil {
+		if value == nil {
+			// Nothing to remove and nil can't be stored
+			return false, nil
+		}
+
+		// An expired key is treated as missing, so clear it out before attempting the insert
+		if _, err := ps.GetMaster().Exec(
+			`DELETE FROM PluginKeyValueStore WHERE PluginId = :PluginId AND PKey = :Key AND ExpireAt != 0 AND ExpireAt <= :CurrentTime`,
+			map[string]interface{}{"PluginId": pluginId, "Key": key, "CurrentTime": currentTime},
+		); err != nil {
+			return false, model.NewAppError("SqlPluginStore.SetWithOptions", "store.sql_plugin_store.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		if err := ps.GetMaster().Insert(kv); err != nil {
+			if IsUniqueConstraintError(err, []string{"PRIMARY", "PluginId", "Key", "PKey"}) {
+				return false, nil
+			}
+			return false, model.NewAppError("SqlPluginStore.SetWithOptions", "store.sql_plugin_store.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		return true, nil
+	}
+
+	var result sql.Result
+	var err error
+	if value == nil {
+		result, err = ps.GetMaster().Exec(
+			`DELETE FROM PluginKeyValueStore WHERE PluginId = :PluginId AND PKey = :Key AND PValue = :Old AND (ExpireAt = 0 OR ExpireAt > :CurrentTime)`,
+			map[string]interface{}{
+				"PluginId":    pluginId,
+				"Key":         key,
+				"Old":         options.OldValue,
+				"CurrentTime": currentTime,
+			},
+		)
+	} else {
+		result, err = ps.GetMaster().Exec(
+			`UPDATE PluginKeyValueStore SET PValue = :New, ExpireAt = :ExpireAt WHERE PluginId = :PluginId AND PKey = :Key AND PValue = :Old AND (ExpireAt = 0 OR ExpireAt > :CurrentTime)`,
+			map[string]interface{}{
+				"PluginId":    pluginId,
+				"Key":         key,
+				"Old":         options.OldValue,
+				"New":         value,
+				"ExpireAt":    kv.ExpireAt,
+				"CurrentTime": currentTime,
+			},
+		)
+	}
+	if err != nil {
+		return false, model.NewAppError("SqlPluginStore.SetWithOptions", "store.sql_plugin_store.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		return false, model.NewAppError("SqlPluginStore.SetWithOptions", "store.sql_plugin_store.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	} else if rowsAffected == 0 {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// SetMulti saves or removes all of the given key-value pairs in a single transaction. A nil value
+// removes the key.
+func (ps SqlPluginStore) SetMulti(keyVals []*model.PluginKeyValue) *model.AppError {
+	for _, kv := range keyVals {
+		if err := kv.IsValid(); err != nil {
+			return err
+		}
+	}
+
+	transaction, err := ps.GetMaster().Begin()
+	if err != nil {
+		return model.NewAppError("SqlPluginStore.SetMulti", "store.sql_plugin_store.save_multi.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	for _, kv := range keyVals {
+		if kv.Value == nil {
+			if _, err := transaction.Exec("DELETE FROM PluginKeyValueStore WHERE PluginId = :PluginId AND PKey = :Key", map[string]interface{}{"PluginId": kv.PluginId, "Key": kv.Key}); err != nil {
+				return model.NewAppError("SqlPluginStore.SetMulti", "store.sql_plugin_store.save_multi.app_error", nil, fmt.Sprintf("plugin_id=%v, key=%v, err=%v", kv.PluginId, kv.Key, err.Error()), http.StatusInternalServerError)
+			}
+			continue
+		}
+
+		if ps.DriverName() == model.DATABASE_DRIVER_POSTGRES {
+			// A unique constraint violation aborts a PostgreSQL transaction, so a concurrent
+			// insert of the same key fails the whole batch rather than being ignored
+			rowsAffected, err := transaction.Update(kv)
+			if err == nil && rowsAffected == 0 {
+				err = transaction.Insert(kv)
+			}
+			if err != nil {
+				return model.NewAppError("SqlPluginStore.SetMulti", "store.sql_plugin_store.save_multi.app_error", nil, fmt.Sprintf("plugin_id=%v, key=%v, err=%v", kv.PluginId, kv.Key, err.Error()), http.StatusInternalServerError)
+			}
+		} else if ps.DriverName() == model.DATABASE_DRIVER_MYSQL {
+			if _, err := transaction.Exec("INSERT INTO PluginKeyValueStore (PluginId, PKey, PValue, ExpireAt) VALUES(:PluginId, :Key, :Value, :ExpireAt) ON DUPLICATE KEY UPDATE PValue = :Value, ExpireAt = :ExpireAt", map[string]interface{}{"PluginId": kv.PluginId, "Key": kv.Key, "Value": kv.Value, "ExpireAt": kv.ExpireAt}); err != nil {
+				return model.NewAppError("SqlPluginStore.SetMulti", "store.sql_plugin_store.save_multi.app_error", nil, fmt.Sprintf("plugin_id=%v, key=%v, err=%v", kv.PluginId, kv.Key, err.Error()), http.StatusInternalServerError)
+			}
+		} else if ps.DriverName() == model.DATABASE_DRIVER_SQLITE {
+			if _, err := transaction.Exec("INSERT INTO PluginKeyValueStore (PluginId, PKey, PValue, ExpireAt) VALUES(:PluginId, :Key, :Value, :ExpireAt) ON CONFLICT (PluginId, PKey) DO UPDATE SET PValue = :Value, ExpireAt = :ExpireAt", map[string]interface{}{"PluginId": kv.PluginId, "Key": kv.Key, "Value": kv.Value, "ExpireAt": kv.ExpireAt}); err != nil {
+				return model.NewAppError("SqlPluginStore.SetMulti", "store.sql_plugin_store.save_multi.app_error", nil, fmt.Sprintf("plugin_id=%v, key=%v, err=%v", kv.PluginId, kv.Key, err.Error()), http.StatusInternalServerError)
+			}
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return model.NewAppError("SqlPluginStore.SetMulti", "store.sql_plugin_store.save_multi.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
 func (ps SqlPluginStore) Get(pluginId, key string) (*model.PluginKeyValue, *model.AppError) {
 	var kv *model.PluginKeyValue
 	currentTime := model.GetMillis()
@@ -160,6 +307,25 @@ func (ps SqlPluginStore) Get(pluginId, key string) (*model.PluginKeyValue, *mode
 	return kv, nil
 }
 
+// GetMulti returns the unexpired key-value pairs for the given keys. Keys that don't exist are
+// omitted from the result.
+func (ps SqlPluginStore) GetMulti(pluginId string, keys []string) ([]*model.PluginKeyValue, *model.AppError) {
+	var kvs []*model.PluginKeyValue
+	if len(keys) == 0 {
+		return kvs, nil
+	}
+
+	keysQuery, params := MapStringsToQueryParams(keys, "Key")
+	params["PluginId"] = pluginId
+	params["CurrentTime"] = model.GetMillis()
+
+	if _, err := ps.GetReplica().Select(&kvs, "SELECT * FROM PluginKeyValueStore WHERE PluginId = :PluginId AND PKey IN "+keysQuery+" AND (ExpireAt = 0 OR ExpireAt > :CurrentTime)", params); err != nil {
+		return nil, model.NewAppError("SqlPluginStore.GetMulti", "store.sql_plugin_store.get_multi.app_error", nil, fmt.Sprintf("plugin_id=%v, err=%v", pluginId, err.Error()), http.StatusInternalServerError)
+	}
+
+	return kvs, nil
+}
+
 func (ps SqlPluginStore) Delete(pluginId, key string) *model.AppError {
 	if _, err := ps.GetMaster().Exec("DELETE FROM PluginKeyValueStore WHERE PluginId = :PluginId AND PKey = :Key", map[string]interface{}{"PluginId": pluginId, "Key": key}); err != nil {
 		return model.NewAppError("SqlPluginStore.Delete", "store.sql_plugin_store.delete.app_error", nil, fmt.Sprintf("plugin_id=%v, key=%v, err=%v", pluginId, key, err.Error()), http.StatusInternalServerError)
@@ -199,3 +365,21 @@ func (ps SqlPluginStore) List(pluginId string, offset int, limit int) ([]string,
 
 	return keys, nil
 }
+
+func (ps SqlPluginStore) ListWithPrefix(pluginId, prefix string, offset int, limit int) ([]string, *model.AppError) {
+	if limit <= 0 {
+		limit = DEFAULT_PLUGIN_KEY_FETCH_LIMIT
+	}
+
+	if offset <= 0 {
+		offset = 0
+	}
+
+	var keys []string
+	_, err := ps.GetReplica().Select(&keys, "SELECT PKey FROM PluginKeyValueStore WHERE PluginId = :PluginId AND PKey LIKE :Prefix ESCAPE '*' AND (ExpireAt = 0 OR ExpireAt > :CurrentTime) order by PKey limit :Limit offset :Offset", map[string]interface{}{"PluginId": pluginId, "Prefix": escapeLikePrefix(prefix, "*") + "%", "CurrentTime": model.GetMillis(), "Limit": limit, "Offset": offset})
+	if err != nil {
+		return nil, model.NewAppError("SqlPluginStore.ListWithPrefix", "store.sql_plugin_store.list.app_error", nil, fmt.Sprintf("plugin_id=%v, prefix=%v, err=%v", pluginId, prefix, err.Error()), http.StatusInternalServerError)
+	}
+
+	return keys, nil
+}
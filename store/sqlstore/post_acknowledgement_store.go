@@ -0,0 +1,88 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlPostAcknowledgementStore struct {
+	SqlStore
+}
+
+func NewSqlPostAcknowledgementStore(sqlStore SqlStore) store.PostAcknowledgementStore {
+	s := &SqlPostAcknowledgementStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.PostAcknowledgement{}, "PostAcknowledgements").SetKeys(false, "PostId", "UserId")
+		table.ColMap("PostId").SetMaxSize(26)
+		table.ColMap("UserId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlPostAcknowledgementStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_postacknowledgements_user_id", "PostAcknowledgements", "UserId")
+}
+
+// Save records the acknowledgement. Acknowledging a post more than once keeps the time of the
+// first acknowledgement.
+func (s SqlPostAcknowledgementStore) Save(acknowledgement *model.PostAcknowledgement) (*model.PostAcknowledgement, *model.AppError) {
+	acknowledgement.PreSave()
+	if err := acknowledgement.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(acknowledgement); err != nil {
+		if !IsUniqueConstraintError(err, []string{"PRIMARY", "postacknowledgements_pkey"}) {
+			return nil, model.NewAppError("SqlPostAcknowledgementStore.Save", "store.sql_post_acknowledgement.save.app_error", nil, "post_id="+acknowledgement.PostId+", user_id="+acknowledgement.UserId+", "+err.Error(), http.StatusInternalServerError)
+		}
+
+		if err := s.GetMaster().SelectOne(acknowledgement, "SELECT * FROM PostAcknowledgements WHERE PostId = :PostId AND UserId = :UserId",
+			map[string]interface{}{"PostId": acknowledgement.PostId, "UserId": acknowledgement.UserId}); err != nil {
+			return nil, model.NewAppError("SqlPostAcknowledgementStore.Save", "store.sql_post_acknowledgement.save.app_error", nil, "post_id="+acknowledgement.PostId+", user_id="+acknowledgement.UserId+", "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return acknowledgement, nil
+}
+
+func (s SqlPostAcknowledgementStore) Delete(postId string, userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM PostAcknowledgements WHERE PostId = :PostId AND UserId = :UserId",
+		map[string]interface{}{"PostId": postId, "UserId": userId}); err != nil {
+		return model.NewAppError("SqlPostAcknowledgementStore.Delete", "store.sql_post_acknowledgement.delete.app_error", nil, "post_id="+postId+", user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlPostAcknowledgementStore) GetForPost(postId string) ([]*model.PostAcknowledgement, *model.AppError) {
+	var acknowledgements []*model.PostAcknowledgement
+
+	if _, err := s.GetMaster().Select(&acknowledgements, "SELECT * FROM PostAcknowledgements WHERE PostId = :PostId ORDER BY AcknowledgedAt",
+		map[string]interface{}{"PostId": postId}); err != nil {
+		return nil, model.NewAppError("SqlPostAcknowledgementStore.GetForPost", "store.sql_post_acknowledgement.get_for_post.app_error", nil, "post_id="+postId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return acknowledgements, nil
+}
+
+// GetForPosts returns the acknowledgements of the given posts, in the order they were made.
+func (s SqlPostAcknowledgementStore) GetForPosts(postIds []string) ([]*model.PostAcknowledgement, *model.AppError) {
+	acknowledgements := []*model.PostAcknowledgement{}
+	if len(postIds) == 0 {
+		return acknowledgements, nil
+	}
+
+	keys, params := MapStringsToQueryParams(postIds, "PostId")
+	if _, err := s.GetReplica().Select(&acknowledgements, "SELECT * FROM PostAcknowledgements WHERE PostId IN "+keys+" ORDER BY AcknowledgedAt", params); err != nil {
+		return nil, model.NewAppError("SqlPostAcknowledgementStore.GetForPosts", "store.sql_post_acknowledgement.get_for_posts.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return acknowledgements, nil
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlPostPriorityStore struct {
+	SqlStore
+}
+
+func NewSqlPostPriorityStore(sqlStore SqlStore) store.PostPriorityStore {
+	s := &SqlPostPriorityStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.PostPriority{}, "PostsPriority").SetKeys(false, "PostId")
+		table.ColMap("PostId").SetMaxSize(26)
+		table.ColMap("ChannelId").SetMaxSize(26)
+		table.ColMap("Priority").SetMaxSize(32)
+	}
+
+	return s
+}
+
+func (s SqlPostPriorityStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_postspriority_channel_id", "PostsPriority", "ChannelId")
+	s.CreateIndexIfNotExists("idx_postspriority_last_notified_at", "PostsPriority", "LastNotifiedAt")
+}
+
+func (s SqlPostPriorityStore) Save(priority *model.PostPriority) (*model.PostPriority, *model.AppError) {
+	priority.PreSave()
+	if err := priority.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(priority); err != nil {
+		return nil, model.NewAppError("SqlPostPriorityStore.Save", "store.sql_post_priority.save.app_error", nil, "post_id="+priority.PostId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return priority, nil
+}
+
+func (s SqlPostPriorityStore) Get(postId string) (*model.PostPriority, *model.AppError) {
+	var priority *model.PostPriority
+
+	if err := s.GetReplica().SelectOne(&priority, "SELECT * FROM PostsPriority WHERE PostId = :PostId", map[string]interface{}{"PostId": postId}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlPostPriorityStore.Get", "store.sql_post_priority.get.app_error", nil, "post_id="+postId+", "+err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlPostPriorityStore.Get", "store.sql_post_priority.get.app_error", nil, "post_id="+postId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return priority, nil
+}
+
+// GetForPosts returns the priorities of those of the given posts that have one.
+func (s SqlPostPriorityStore) GetForPosts(postIds []string) ([]*model.PostPriority, *model.AppError) {
+	priorities := []*model.PostPriority{}
+	if len(postIds) == 0 {
+		return priorities, nil
+	}
+
+	keys, params := MapStringsToQueryParams(postIds, "PostId")
+	if _, err := s.GetReplica().Select(&priorities, "SELECT * FROM PostsPriority WHERE PostId IN "+keys, params); err != nil {
+		return nil, model.NewAppError("SqlPostPriorityStore.GetForPosts", "store.sql_post_priority.get_for_posts.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return priorities, nil
+}
+
+// GetForPersistentNotifications returns the urgent posts that were last notified before the given
+// time and have been notified fewer than maxCount times.
+func (s SqlPostPriorityStore) GetForPersistentNotifications(notifiedBefore int64, maxCount int) ([]*model.PostPriority, *model.AppError) {
+	var priorities []*model.PostPriority
+
+	if _, err := s.GetMaster().Select(&priorities,
+		`SELECT
+			*
+		FROM
+			PostsPriority
+		WHERE
+			Priority = :Priority
+			AND LastNotifiedAt <= :NotifiedBefore
+			AND NotificationCount < :MaxCount
+		ORDER BY LastNotifiedAt`,
+		map[string]interface{}{"Priority": model.POST_PRIORITY_URGENT, "NotifiedBefore": notifiedBefore, "MaxCount": maxCount}); err != nil {
+		return nil, model.NewAppError("SqlPostPriorityStore.GetForPersistentNotifications", "store.sql_post_priority.get_for_persistent_notifications.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return priorities, nil
+}
+
+func (s SqlPostPriorityStore) MarkNotified(postId string, notifiedAt int64) *model.AppError {
+	if _, err := s.GetMaster().Exec("UPDATE PostsPriority SET LastNotifiedAt = :NotifiedAt, NotificationCount = NotificationCount + 1 WHERE PostId = :PostId",
+		map[string]interface{}{"PostId": postId, "NotifiedAt": notifiedAt}); err != nil {
+		return model.NewAppError("SqlPostPriorityStore.MarkNotified", "store.sql_post_priority.mark_notified.app_error", nil, "post_id="+postId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
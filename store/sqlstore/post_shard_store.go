@@ -0,0 +1,394 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/gorp"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+// SqlPostShardStore keeps the records of which shard holds the posts of each channel in the main
+// database, and copies the posts of channels between shards.
+type SqlPostShardStore struct {
+	SqlStore
+}
+
+func NewSqlPostShardStore(sqlStore SqlStore) store.PostShardStore {
+	s := &SqlPostShardStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.ChannelShard{}, "ChannelShards").SetKeys(false, "ChannelId")
+		table.ColMap("ChannelId").SetMaxSize(26)
+		table.ColMap("Shard").SetMaxSize(32)
+		table.ColMap("PreviousShard").SetMaxSize(32)
+	}
+
+	return s
+}
+
+func (s SqlPostShardStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_channelshards_previous_shard", "ChannelShards", "PreviousShard")
+}
+
+// SaveChannelShard records the shard holding the posts of the channel.
+func (s SqlPostShardStore) SaveChannelShard(channelShard *model.ChannelShard) (*model.ChannelShard, *model.AppError) {
+	channelShard.UpdateAt = model.GetMillis()
+
+	count, err := s.GetMaster().Update(channelShard)
+	if err == nil && count == 0 {
+		err = s.GetMaster().Insert(channelShard)
+		if err != nil && IsUniqueConstraintError(err, []string{"PRIMARY", "channelshards_pkey"}) {
+			// Recorded concurrently, so the record exists now.
+			_, err = s.GetMaster().Update(channelShard)
+		}
+	}
+
+	if err != nil {
+		return nil, model.NewAppError("SqlPostShardStore.SaveChannelShard", "store.sql_post_shard.save_channel_shard.app_error", nil, "channel_id="+channelShard.ChannelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return channelShard, nil
+}
+
+// GetChannelShard returns the shard holding the posts of the channel. Channels without a record
+// have their posts in the main database.
+func (s SqlPostShardStore) GetChannelShard(channelId string) (*model.ChannelShard, *model.AppError) {
+	var channelShard model.ChannelShard
+	if err := s.GetMaster().SelectOne(&channelShard, "SELECT * FROM ChannelShards WHERE ChannelId = :ChannelId", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		if err == sql.ErrNoRows {
+			return &model.ChannelShard{ChannelId: channelId}, nil
+		}
+		return nil, model.NewAppError("SqlPostShardStore.GetChannelShard", "store.sql_post_shard.get_channel_shard.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return &channelShard, nil
+}
+
+// GetChannelShardsAfter returns the shards of the channels with ids after the given one, in order
+// of id, along with the teams of the channels. Every channel is returned, recorded or not.
+func (s SqlPostShardStore) GetChannelShardsAfter(afterChannelId string, limit int) ([]*model.ChannelShard, *model.AppError) {
+	query := `
+		SELECT
+			Channels.Id AS ChannelId,
+			Channels.TeamId AS TeamId,
+			COALESCE(ChannelShards.Shard, '') AS Shard,
+			COALESCE(ChannelShards.PreviousShard, '') AS PreviousShard,
+			COALESCE(ChannelShards.UpdateAt, 0) AS UpdateAt
+		FROM
+			Channels
+		LEFT JOIN
+			ChannelShards ON ChannelShards.ChannelId = Channels.Id
+		WHERE
+			Channels.Id > :AfterChannelId
+		ORDER BY
+			Channels.Id
+		LIMIT :Limit`
+
+	var channelShards []*model.ChannelShard
+	if _, err := s.GetReplica().Select(&channelShards, query, map[string]interface{}{"AfterChannelId": afterChannelId, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlPostShardStore.GetChannelShardsAfter", "store.sql_post_shard.get_channel_shards.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return channelShards, nil
+}
+
+// GetMovedChannelShards returns the channels moved to another shard whose posts may still be
+// left behind on the shard they were moved from.
+func (s SqlPostShardStore) GetMovedChannelShards() ([]*model.ChannelShard, *model.AppError) {
+	var channelShards []*model.ChannelShard
+	if _, err := s.GetMaster().Select(&channelShards, "SELECT * FROM ChannelShards WHERE PreviousShard != '' ORDER BY ChannelId"); err != nil {
+		return nil, model.NewAppError("SqlPostShardStore.GetMovedChannelShards", "store.sql_post_shard.get_channel_shards.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return channelShards, nil
+}
+
+func (s SqlPostShardStore) DeleteChannelShard(channelId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM ChannelShards WHERE ChannelId = :ChannelId", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return model.NewAppError("SqlPostShardStore.DeleteChannelShard", "store.sql_post_shard.delete_channel_shard.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// UpdateChannelLastPostAt updates the channel for a post saved or edited on another shard, as
+// saving or editing the post in this database does.
+func (s SqlPostShardStore) UpdateChannelLastPostAt(channelId string, lastPostAt int64, countMessage bool) *model.AppError {
+	if err := updateChannelLastPostAt(s, channelId, lastPostAt, countMessage); err != nil {
+		return model.NewAppError("SqlPostShardStore.UpdateChannelLastPostAt", "store.sql_post_shard.update_channel_last_post_at.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// SaveChannelMirror saves a copy of the channel, as it is in the main database, for the queries
+// of the posts of the channel that join them with their channel.
+func (s SqlPostShardStore) SaveChannelMirror(channel *model.Channel) *model.AppError {
+	count, err := s.GetMaster().Update(channel)
+	if err == nil && count == 0 {
+		err = s.GetMaster().Insert(channel)
+	}
+
+	if err != nil {
+		return model.NewAppError("SqlPostShardStore.SaveChannelMirror", "store.sql_post_shard.save_channel_mirror.app_error", nil, "channel_id="+channel.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlPostShardStore) DeleteChannelMirror(channelId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM Channels WHERE Id = :ChannelId", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return model.NewAppError("SqlPostShardStore.DeleteChannelMirror", "store.sql_post_shard.delete_channel_mirror.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// GetChannelData returns up to limit posts of the channel with ids after the given one, in order
+// of id, with their reactions and file infos. It also returns the archive index entries of the
+// archived posts of the channel in the same range of ids, along with their reactions and file
+// infos. A batch with fewer posts than the limit is the last one.
+func (s SqlPostShardStore) GetChannelData(channelId string, afterPostId string, limit int) (*model.PostShardData, *model.AppError) {
+	data := &model.PostShardData{}
+	params := map[string]interface{}{"ChannelId": channelId, "AfterPostId": afterPostId, "Limit": limit}
+
+	if _, err := s.GetMaster().Select(&data.Posts, "SELECT * FROM Posts WHERE ChannelId = :ChannelId AND Id > :AfterPostId ORDER BY Id LIMIT :Limit", params); err != nil {
+		return nil, model.NewAppError("SqlPostShardStore.GetChannelData", "store.sql_post_shard.get_channel_data.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	archiveQuery := "SELECT " + postArchiveIndexColumns + " FROM PostArchiveIndex WHERE ChannelId = :ChannelId AND PostId > :AfterPostId"
+	if len(data.Posts) == limit {
+		archiveQuery += " AND PostId <= :LastPostId"
+		params["LastPostId"] = data.Posts[len(data.Posts)-1].Id
+	}
+	archiveQuery += " ORDER BY PostId"
+
+	if _, err := s.GetMaster().Select(&data.ArchiveIndexes, archiveQuery, params); err != nil {
+		return nil, model.NewAppError("SqlPostShardStore.GetChannelData", "store.sql_post_shard.get_channel_data.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	postIds := make([]string, 0, len(data.Posts)+len(data.ArchiveIndexes))
+	for _, post := range data.Posts {
+		postIds = append(postIds, post.Id)
+	}
+	for _, index := range data.ArchiveIndexes {
+		postIds = append(postIds, index.PostId)
+	}
+
+	if len(postIds) == 0 {
+		return data, nil
+	}
+
+	keys, postParams := MapStringsToQueryParams(postIds, "PostId")
+
+	if _, err := s.GetMaster().Select(&data.Reactions, "SELECT * FROM Reactions WHERE PostId IN "+keys, postParams); err != nil {
+		return nil, model.NewAppError("SqlPostShardStore.GetChannelData", "store.sql_post_shard.get_channel_data.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if _, err := s.GetMaster().Select(&data.FileInfos, "SELECT * FROM FileInfo WHERE PostId IN "+keys, postParams); err != nil {
+		return nil, model.NewAppError("SqlPostShardStore.GetChannelData", "store.sql_post_shard.get_channel_data.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return data, nil
+}
+
+// SaveChannelData saves a batch of posts copied from another shard, skipping what is already there
+// so that an interrupted copy can be resumed. Posts and file infos already there are replaced by
+// those of the batch edited or deleted since. The reactions to the posts of the batch created
+// before movedAt, and missing from it, are deleted as they were removed from the other shard.
+func (s SqlPostShardStore) SaveChannelData(data *model.PostShardData, movedAt int64) *model.AppError {
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return model.NewAppError("SqlPostShardStore.SaveChannelData", "store.sql_post_shard.save_channel_data.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	var postIds, fileIds, archivedPostIds []string
+	for _, post := range data.Posts {
+		postIds = append(postIds, post.Id)
+	}
+	for _, info := range data.FileInfos {
+		fileIds = append(fileIds, info.Id)
+	}
+	for _, index := range data.ArchiveIndexes {
+		archivedPostIds = append(archivedPostIds, index.PostId)
+	}
+
+	existingPosts, err := selectUpdateAts(transaction, "SELECT Id, UpdateAt FROM Posts WHERE Id IN ", postIds)
+	if err != nil {
+		return model.NewAppError("SqlPostShardStore.SaveChannelData", "store.sql_post_shard.save_channel_data.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	for _, post := range data.Posts {
+		if updateAt, ok := existingPosts[post.Id]; !ok {
+			err = transaction.Insert(post)
+		} else if post.UpdateAt > updateAt {
+			_, err = transaction.Update(post)
+		}
+		if err != nil {
+			return model.NewAppError("SqlPostShardStore.SaveChannelData", "store.sql_post_shard.save_channel_data.app_error", nil, "post_id="+post.Id+", "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	existingFileInfos, err := selectUpdateAts(transaction, "SELECT Id, UpdateAt FROM FileInfo WHERE Id IN ", fileIds)
+	if err != nil {
+		return model.NewAppError("SqlPostShardStore.SaveChannelData", "store.sql_post_shard.save_channel_data.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	for _, info := range data.FileInfos {
+		if updateAt, ok := existingFileInfos[info.Id]; !ok {
+			err = transaction.Insert(info)
+		} else if info.UpdateAt > updateAt {
+			_, err = transaction.Update(info)
+		}
+		if err != nil {
+			return model.NewAppError("SqlPostShardStore.SaveChannelData", "store.sql_post_shard.save_channel_data.app_error", nil, "file_id="+info.Id+", "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	existingIndexes, err := selectExistingIds(transaction, "SELECT PostId FROM PostArchiveIndex WHERE PostId IN ", archivedPostIds)
+	if err != nil {
+		return model.NewAppError("SqlPostShardStore.SaveChannelData", "store.sql_post_shard.save_channel_data.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	for _, index := range data.ArchiveIndexes {
+		if !existingIndexes[index.PostId] {
+			if err := transaction.Insert(index); err != nil {
+				return model.NewAppError("SqlPostShardStore.SaveChannelData", "store.sql_post_shard.save_channel_data.app_error", nil, "post_id="+index.PostId+", "+err.Error(), http.StatusInternalServerError)
+			}
+		}
+	}
+
+	// The reactions of every post of the batch are compared, as those of posts whose reactions
+	// were all removed are not in the batch.
+	reactionPostIds := append(postIds, archivedPostIds...)
+	for _, reaction := range data.Reactions {
+		reactionPostIds = append(reactionPostIds, reaction.PostId)
+	}
+
+	if len(reactionPostIds) > 0 {
+		keys, params := MapStringsToQueryParams(reactionPostIds, "PostId")
+
+		var existingReactions []*model.Reaction
+		if _, err := transaction.Select(&existingReactions, "SELECT * FROM Reactions WHERE PostId IN "+keys, params); err != nil {
+			return model.NewAppError("SqlPostShardStore.SaveChannelData", "store.sql_post_shard.save_channel_data.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		copied := make(map[model.Reaction]bool, len(data.Reactions))
+		for _, reaction := range data.Reactions {
+			copied[model.Reaction{UserId: reaction.UserId, PostId: reaction.PostId, EmojiName: reaction.EmojiName}] = true
+		}
+
+		existing := make(map[model.Reaction]bool, len(existingReactions))
+		for _, reaction := range existingReactions {
+			key := model.Reaction{UserId: reaction.UserId, PostId: reaction.PostId, EmojiName: reaction.EmojiName}
+			if !copied[key] && reaction.CreateAt < movedAt {
+				if _, err := transaction.Exec("DELETE FROM Reactions WHERE UserId = :UserId AND PostId = :PostId AND EmojiName = :EmojiName", map[string]interface{}{"UserId": reaction.UserId, "PostId": reaction.PostId, "EmojiName": reaction.EmojiName}); err != nil {
+					return model.NewAppError("SqlPostShardStore.SaveChannelData", "store.sql_post_shard.save_channel_data.app_error", nil, "post_id="+reaction.PostId+", "+err.Error(), http.StatusInternalServerError)
+				}
+				continue
+			}
+			existing[key] = true
+		}
+
+		for _, reaction := range data.Reactions {
+			if !existing[model.Reaction{UserId: reaction.UserId, PostId: reaction.PostId, EmojiName: reaction.EmojiName}] {
+				if err := transaction.Insert(reaction); err != nil {
+					return model.NewAppError("SqlPostShardStore.SaveChannelData", "store.sql_post_shard.save_channel_data.app_error", nil, "post_id="+reaction.PostId+", "+err.Error(), http.StatusInternalServerError)
+				}
+			}
+		}
+
+		// The posts copied over those already there may have been reacted to on either shard.
+		if _, err := transaction.Exec("UPDATE Posts SET HasReactions = (SELECT count(0) > 0 FROM Reactions WHERE PostId = Posts.Id) WHERE Id IN "+keys, params); err != nil {
+			return model.NewAppError("SqlPostShardStore.SaveChannelData", "store.sql_post_shard.save_channel_data.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return model.NewAppError("SqlPostShardStore.SaveChannelData", "store.sql_post_shard.save_channel_data.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// selectUpdateAts returns the update times of those of the ids the query, selecting the id and
+// update time and ending with the IN operator, finds.
+func selectUpdateAts(transaction *gorp.Transaction, query string, ids []string) (map[string]int64, error) {
+	updateAts := make(map[string]int64)
+	if len(ids) == 0 {
+		return updateAts, nil
+	}
+
+	keys, params := MapStringsToQueryParams(ids, "Id")
+
+	var rows []struct {
+		Id       string
+		UpdateAt int64
+	}
+	if _, err := transaction.Select(&rows, query+keys, params); err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		updateAts[row.Id] = row.UpdateAt
+	}
+
+	return updateAts, nil
+}
+
+// selectExistingIds returns which of the ids the query, ending with the IN operator, finds.
+func selectExistingIds(transaction *gorp.Transaction, query string, ids []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	if len(ids) == 0 {
+		return existing, nil
+	}
+
+	keys, params := MapStringsToQueryParams(ids, "Id")
+
+	var existingIds []string
+	if _, err := transaction.Select(&existingIds, query+keys, params); err != nil {
+		return nil, err
+	}
+
+	for _, id := range existingIds {
+		existing[id] = true
+	}
+
+	return existing, nil
+}
+
+// DeleteChannelData deletes the posts of the channel, archived or not, with their reactions and
+// file infos.
+func (s SqlPostShardStore) DeleteChannelData(channelId string) *model.AppError {
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return model.NewAppError("SqlPostShardStore.DeleteChannelData", "store.sql_post_shard.delete_channel_data.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	postsOfChannel := `
+		PostId IN (SELECT Id FROM Posts WHERE ChannelId = :ChannelId)
+		OR PostId IN (SELECT PostId FROM PostArchiveIndex WHERE ChannelId = :ChannelId)`
+
+	queries := []string{
+		"DELETE FROM Reactions WHERE " + postsOfChannel,
+		"DELETE FROM FileInfo WHERE " + postsOfChannel,
+		"DELETE FROM PostArchiveIndex WHERE ChannelId = :ChannelId",
+		"DELETE FROM Posts WHERE ChannelId = :ChannelId",
+	}
+
+	for _, query := range queries {
+		if _, err := transaction.Exec(query, map[string]interface{}{"ChannelId": channelId}); err != nil {
+			return model.NewAppError("SqlPostShardStore.DeleteChannelData", "store.sql_post_shard.delete_channel_data.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return model.NewAppError("SqlPostShardStore.DeleteChannelData", "store.sql_post_shard.delete_channel_data.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -20,39 +20,24 @@ import (
 	"github.com/mattermost/mattermost-server/utils"
 )
 
+// postArchiveIndexColumns selects the columns of an archive index. Message is NULL for the posts
+// archived before it was added.
+const postArchiveIndexColumns = "PostId, ChannelId, RootId, UserId, Type, CreateAt, DeleteAt, COALESCE(Message, '') AS Message, Hashtags, Path"
+
 type SqlPostStore struct {
 	SqlStore
 	metrics           einterfaces.MetricsInterface
-	lastPostTimeCache *utils.Cache
-	lastPostsCache    *utils.Cache
 	maxPostSizeOnce   sync.Once
 	maxPostSizeCached int
 }
 
-const (
-	LAST_POST_TIME_CACHE_SIZE = 25000
-	LAST_POST_TIME_CACHE_SEC  = 900 // 15 minutes
-
-	LAST_POSTS_CACHE_SIZE = 1000
-	LAST_POSTS_CACHE_SEC  = 900 // 15 minutes
-)
-
 func (s *SqlPostStore) ClearCaches() {
-	s.lastPostTimeCache.Purge()
-	s.lastPostsCache.Purge()
-
-	if s.metrics != nil {
-		s.metrics.IncrementMemCacheInvalidationCounter("Last Post Time - Purge")
-		s.metrics.IncrementMemCacheInvalidationCounter("Last Posts Cache - Purge")
-	}
 }
 
 func NewSqlPostStore(sqlStore SqlStore, metrics einterfaces.MetricsInterface) store.PostStore {
 	s := &SqlPostStore{
 		SqlStore:          sqlStore,
 		metrics:           metrics,
-		lastPostTimeCache: utils.NewLru(LAST_POST_TIME_CACHE_SIZE),
-		lastPostsCache:    utils.NewLru(LAST_POSTS_CACHE_SIZE),
 		maxPostSizeCached: model.POST_MESSAGE_MAX_RUNES_V1,
 	}
 
@@ -70,6 +55,16 @@ func NewSqlPostStore(sqlStore SqlStore, metrics einterfaces.MetricsInterface) st
 		table.ColMap("Props").SetMaxSize(8000)
 		table.ColMap("Filenames").SetMaxSize(model.POST_FILENAMES_MAX_RUNES)
 		table.ColMap("FileIds").SetMaxSize(150)
+
+		archiveTable := db.AddTableWithName(model.PostArchiveIndex{}, "PostArchiveIndex").SetKeys(false, "PostId")
+		archiveTable.ColMap("PostId").SetMaxSize(26)
+		archiveTable.ColMap("ChannelId").SetMaxSize(26)
+		archiveTable.ColMap("RootId").SetMaxSize(26)
+		archiveTable.ColMap("UserId").SetMaxSize(26)
+		archiveTable.ColMap("Type").SetMaxSize(26)
+		archiveTable.ColMap("Message").SetMaxSize(model.POST_MESSAGE_MAX_BYTES_V2)
+		archiveTable.ColMap("Hashtags").SetMaxSize(1000)
+		archiveTable.ColMap("Path").SetMaxSize(512)
 	}
 
 	return s
@@ -89,6 +84,12 @@ func (s *SqlPostStore) CreateIndexesIfNotExists() {
 
 	s.CreateFullTextIndexIfNotExists("idx_posts_message_txt", "Posts", "Message")
 	s.CreateFullTextIndexIfNotExists("idx_posts_hashtags_txt", "Posts", "Hashtags")
+
+	s.CreateIndexIfNotExists("idx_postarchiveindex_channel_id", "PostArchiveIndex", "ChannelId")
+	s.CreateIndexIfNotExists("idx_postarchiveindex_path", "PostArchiveIndex", "Path")
+
+	s.CreateFullTextIndexIfNotExists("idx_postarchiveindex_message_txt", "PostArchiveIndex", "Message")
+	s.CreateFullTextIndexIfNotExists("idx_postarchiveindex_hashtags_txt", "PostArchiveIndex", "Hashtags")
 }
 
 func (s *SqlPostStore) Save(post *model.Post) (*model.Post, *model.AppError) {
@@ -109,19 +110,8 @@ func (s *SqlPostStore) Save(post *model.Post) (*model.Post, *model.AppError) {
 
 	time := post.UpdateAt
 
-	if post.Type != model.POST_JOIN_LEAVE && post.Type != model.POST_ADD_REMOVE &&
-		post.Type != model.POST_JOIN_CHANNEL && post.Type != model.POST_LEAVE_CHANNEL &&
-		post.Type != model.POST_JOIN_TEAM && post.Type != model.POST_LEAVE_TEAM &&
-		post.Type != model.POST_ADD_TO_CHANNEL && post.Type != model.POST_REMOVE_FROM_CHANNEL &&
-		post.Type != model.POST_ADD_TO_TEAM && post.Type != model.POST_REMOVE_FROM_TEAM {
-		if _, err := s.GetMaster().Exec("UPDATE Channels SET LastPostAt = GREATEST(:LastPostAt, LastPostAt), TotalMsgCount = TotalMsgCount + 1 WHERE Id = :ChannelId", map[string]interface{}{"LastPostAt": time, "ChannelId": post.ChannelId}); err != nil {
-			mlog.Error("Error updating Channel LastPostAt.", mlog.Err(err))
-		}
-	} else {
-		// don't update TotalMsgCount for unimportant messages so that the channel isn't marked as unread
-		if _, err := s.GetMaster().Exec("UPDATE Channels SET LastPostAt = :LastPostAt WHERE Id = :ChannelId AND LastPostAt < :LastPostAt", map[string]interface{}{"LastPostAt": time, "ChannelId": post.ChannelId}); err != nil {
-			mlog.Error("Error updating Channel LastPostAt.", mlog.Err(err))
-		}
+	if err := updateChannelLastPostAt(s, post.ChannelId, time, !post.IsJoinLeaveMessage()); err != nil {
+		mlog.Error("Error updating Channel LastPostAt.", mlog.Err(err))
 	}
 
 	if len(post.RootId) > 0 {
@@ -133,6 +123,19 @@ func (s *SqlPostStore) Save(post *model.Post) (*model.Post, *model.AppError) {
 	return post, nil
 }
 
+// updateChannelLastPostAt moves the LastPostAt of the channel forward to the given time and, if
+// countMessage is set, counts one more message in the channel.
+func updateChannelLastPostAt(s SqlStore, channelId string, lastPostAt int64, countMessage bool) error {
+	if countMessage {
+		_, err := s.GetMaster().Exec("UPDATE Channels SET LastPostAt = "+greatestFunction(s.DriverName())+"(:LastPostAt, LastPostAt), TotalMsgCount = TotalMsgCount + 1 WHERE Id = :ChannelId", map[string]interface{}{"LastPostAt": lastPostAt, "ChannelId": channelId})
+		return err
+	}
+
+	// don't update TotalMsgCount for unimportant messages so that the channel isn't marked as unread
+	_, err := s.GetMaster().Exec("UPDATE Channels SET LastPostAt = :LastPostAt WHERE Id = :ChannelId AND LastPostAt < :LastPostAt", map[string]interface{}{"LastPostAt": lastPostAt, "ChannelId": channelId})
+	return err
+}
+
 func (s *SqlPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, *model.AppError) {
 	newPost.UpdateAt = model.GetMillis()
 	newPost.PreCommit()
@@ -154,7 +157,7 @@ func (s *SqlPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.
 	}
 
 	time := model.GetMillis()
-	s.GetMaster().Exec("UPDATE Channels SET LastPostAt = :LastPostAt  WHERE Id = :ChannelId AND LastPostAt < :LastPostAt", map[string]interface{}{"LastPostAt": time, "ChannelId": newPost.ChannelId})
+	updateChannelLastPostAt(s, newPost.ChannelId, time, false)
 
 	if len(newPost.RootId) > 0 {
 		s.GetMaster().Exec("UPDATE Posts SET UpdateAt = :UpdateAt WHERE Id = :RootId AND UpdateAt < :UpdateAt", map[string]interface{}{"UpdateAt": time, "RootId": newPost.RootId})
@@ -321,36 +324,9 @@ type etagPosts struct {
 }
 
 func (s *SqlPostStore) InvalidateLastPostTimeCache(channelId string) {
-	s.lastPostTimeCache.Remove(channelId)
-
-	// Keys are "{channelid}{limit}" and caching only occurs on limits of 30 and 60
-	s.lastPostsCache.Remove(channelId + "30")
-	s.lastPostsCache.Remove(channelId + "60")
-
-	if s.metrics != nil {
-		s.metrics.IncrementMemCacheInvalidationCounter("Last Post Time - Remove by Channel Id")
-		s.metrics.IncrementMemCacheInvalidationCounter("Last Posts Cache - Remove by Channel Id")
-	}
 }
 
 func (s *SqlPostStore) GetEtag(channelId string, allowFromCache bool) string {
-	if allowFromCache {
-		if cacheItem, ok := s.lastPostTimeCache.Get(channelId); ok {
-			if s.metrics != nil {
-				s.metrics.IncrementMemCacheHitCounter("Last Post Time")
-			}
-			return fmt.Sprintf("%v.%v", model.CurrentVersion, cacheItem.(int64))
-		} else {
-			if s.metrics != nil {
-				s.metrics.IncrementMemCacheMissCounter("Last Post Time")
-			}
-		}
-	} else {
-		if s.metrics != nil {
-			s.metrics.IncrementMemCacheMissCounter("Last Post Time")
-		}
-	}
-
 	var et etagPosts
 	err := s.GetReplica().SelectOne(&et, "SELECT Id, UpdateAt FROM Posts WHERE ChannelId = :ChannelId ORDER BY UpdateAt DESC LIMIT 1", map[string]interface{}{"ChannelId": channelId})
 	var result string
@@ -360,7 +336,6 @@ func (s *SqlPostStore) GetEtag(channelId string, allowFromCache bool) string {
 		result = fmt.Sprintf("%v.%v", model.CurrentVersion, et.UpdateAt)
 	}
 
-	s.lastPostTimeCache.AddWithExpiresInSecs(channelId, et.UpdateAt, LAST_POST_TIME_CACHE_SEC)
 	return result
 }
 
@@ -450,20 +425,6 @@ func (s *SqlPostStore) GetPosts(channelId string, offset int, limit int, allowFr
 		return nil, model.NewAppError("SqlPostStore.GetLinearPosts", "store.sql_post.get_posts.app_error", nil, "channelId="+channelId, http.StatusBadRequest)
 	}
 
-	// Caching only occurs on limits of 30 and 60, the common limits requested by MM clients
-	if allowFromCache && offset == 0 && (limit == 60 || limit == 30) {
-		if cacheItem, ok := s.lastPostsCache.Get(fmt.Sprintf("%s%v", channelId, limit)); ok {
-			if s.metrics != nil {
-				s.metrics.IncrementMemCacheHitCounter("Last Posts Cache")
-			}
-			return cacheItem.(*model.PostList), nil
-		}
-	}
-
-	if s.metrics != nil {
-		s.metrics.IncrementMemCacheMissCounter("Last Posts Cache")
-	}
-
 	rpc := make(chan store.StoreResult, 1)
 	go func() {
 		posts, err := s.getRootPosts(channelId, offset, limit)
@@ -504,43 +465,22 @@ func (s *SqlPostStore) GetPosts(channelId string, offset int, limit int, allowFr
 
 	list.MakeNonNil()
 
-	// Caching only occurs on limits of 30 and 60, the common limits requested by MM clients
-	if offset == 0 && (limit == 60 || limit == 30) {
-		s.lastPostsCache.AddWithExpiresInSecs(fmt.Sprintf("%s%v", channelId, limit), list, LAST_POSTS_CACHE_SEC)
-	}
-
 	return list, err
 }
 
 func (s *SqlPostStore) GetPostsSince(channelId string, time int64, allowFromCache bool) (*model.PostList, *model.AppError) {
-	if allowFromCache {
-		// If the last post in the channel's time is less than or equal to the time we are getting posts since,
-		// we can safely return no posts.
-		if cacheItem, ok := s.lastPostTimeCache.Get(channelId); ok && cacheItem.(int64) <= time {
-			if s.metrics != nil {
-				s.metrics.IncrementMemCacheHitCounter("Last Post Time")
-			}
-			list := model.NewPostList()
-			return list, nil
-		}
-	}
-
-	if s.metrics != nil {
-		s.metrics.IncrementMemCacheMissCounter("Last Post Time")
-	}
-
 	var posts []*model.Post
 	_, err := s.GetReplica().Select(&posts,
-		`(SELECT
-			*
-		FROM
-			Posts
-		WHERE
-			(UpdateAt > :Time
-				AND ChannelId = :ChannelId)
-			LIMIT 1000)
-		UNION
-			(SELECT
+		unionQuery(s.DriverName(), "UNION",
+			`SELECT
+				*
+			FROM
+				Posts
+			WHERE
+				(UpdateAt > :Time
+					AND ChannelId = :ChannelId)
+				LIMIT 1000`,
+			`SELECT
 			    *
 			FROM
 			    Posts
@@ -554,7 +494,7 @@ func (s *SqlPostStore) GetPostsSince(channelId string, time int64, allowFromCach
 			    WHERE
 			        UpdateAt > :Time
 						AND ChannelId = :ChannelId
-				LIMIT 1000) temp_tab))
+				LIMIT 1000) temp_tab)`)+`
 		ORDER BY CreateAt DESC`,
 		map[string]interface{}{"ChannelId": channelId, "Time": time})
 
@@ -564,20 +504,13 @@ func (s *SqlPostStore) GetPostsSince(channelId string, time int64, allowFromCach
 
 	list := model.NewPostList()
 
-	latestUpdate := time
-
 	for _, p := range posts {
 		list.AddPost(p)
 		if p.UpdateAt > time {
 			list.AddOrder(p.Id)
 		}
-		if latestUpdate < p.UpdateAt {
-			latestUpdate = p.UpdateAt
-		}
 	}
 
-	s.lastPostTimeCache.AddWithExpiresInSecs(channelId, latestUpdate, LAST_POST_TIME_CACHE_SEC)
-
 	return list, nil
 }
 
@@ -893,8 +826,15 @@ func (s *SqlPostStore) buildSearchPostFilterClause(fromUsers []string, excludedU
 		return "", queryParams
 	}
 
-	filterQuery := `
-		AND UserId IN (
+	usersQuery, queryParams := s.buildSearchUsersQuery(fromUsers, excludedUsers, queryParams)
+
+	return "AND UserId IN (" + usersQuery + ")", queryParams
+}
+
+// buildSearchUsersQuery returns the query for the ids of the members of the team matching the
+// user filters of a search.
+func (s *SqlPostStore) buildSearchUsersQuery(fromUsers []string, excludedUsers []string, queryParams map[string]interface{}) (string, map[string]interface{}) {
+	usersQuery := `
 			SELECT
 				Id
 			FROM
@@ -904,33 +844,20 @@ func (s *SqlPostStore) buildSearchPostFilterClause(fromUsers []string, excludedU
 				TeamMembers.TeamId = :TeamId
 				AND Users.Id = TeamMembers.UserId
 				FROM_USER_FILTER
-				EXCLUDED_USER_FILTER)`
+				EXCLUDED_USER_FILTER`
 
 	fromUserClause, queryParams := s.buildSearchUserFilterClause(fromUsers, "FromUser", false, queryParams)
-	filterQuery = strings.Replace(filterQuery, "FROM_USER_FILTER", fromUserClause, 1)
+	usersQuery = strings.Replace(usersQuery, "FROM_USER_FILTER", fromUserClause, 1)
 
 	excludedUserClause, queryParams := s.buildSearchUserFilterClause(excludedUsers, "ExcludedUser", true, queryParams)
-	filterQuery = strings.Replace(filterQuery, "EXCLUDED_USER_FILTER", excludedUserClause, 1)
+	usersQuery = strings.Replace(usersQuery, "EXCLUDED_USER_FILTER", excludedUserClause, 1)
 
-	return filterQuery, queryParams
+	return usersQuery, queryParams
 }
 
-func (s *SqlPostStore) Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, *model.AppError) {
-	queryParams := map[string]interface{}{
-		"TeamId": teamId,
-		"UserId": userId,
-	}
-
-	list := model.NewPostList()
-	if params.Terms == "" && params.ExcludedTerms == "" &&
-		len(params.InChannels) == 0 && len(params.ExcludedChannels) == 0 &&
-		len(params.FromUsers) == 0 && len(params.ExcludedUsers) == 0 &&
-		len(params.OnDate) == 0 && len(params.AfterDate) == 0 && len(params.BeforeDate) == 0 {
-		return list, nil
-	}
-
-	var posts []*model.Post
-
+// buildSearchChannelsQuery returns the query for the ids of the channels of the team, and of the
+// direct and group channels, that the user searches in.
+func (s *SqlPostStore) buildSearchChannelsQuery(params *model.SearchParams, queryParams map[string]interface{}) (string, map[string]interface{}) {
 	deletedQueryPart := "AND DeleteAt = 0"
 	if params.IncludeDeletedChannels {
 		deletedQueryPart = ""
@@ -941,16 +868,7 @@ func (s *SqlPostStore) Search(teamId string, userId string, params *model.Search
 		userIdPart = ""
 	}
 
-	searchQuery := `
-			SELECT
-				*
-			FROM
-				Posts
-			WHERE
-				DeleteAt = 0
-				AND Type NOT LIKE '` + model.POST_SYSTEM_MESSAGE_PREFIX + `%'
-				POST_FILTER
-				AND ChannelId IN (
+	channelsQuery := `
 					SELECT
 						Id
 					FROM
@@ -962,34 +880,218 @@ func (s *SqlPostStore) Search(teamId string, userId string, params *model.Search
 							` + userIdPart + `
 							` + deletedQueryPart + `
 							IN_CHANNEL_FILTER
-							EXCLUDED_CHANNEL_FILTER)
+							EXCLUDED_CHANNEL_FILTER`
+
+	inChannelClause, queryParams := s.buildSearchChannelFilterClause(params.InChannels, "InChannel", false, queryParams)
+	channelsQuery = strings.Replace(channelsQuery, "IN_CHANNEL_FILTER", inChannelClause, 1)
+
+	excludedChannelClause, queryParams := s.buildSearchChannelFilterClause(params.ExcludedChannels, "ExcludedChannel", true, queryParams)
+	channelsQuery = strings.Replace(channelsQuery, "EXCLUDED_CHANNEL_FILTER", excludedChannelClause, 1)
+
+	return channelsQuery, queryParams
+}
+
+// buildSearchIdsClause returns the clause restricting the column to the given ids.
+func (s *SqlPostStore) buildSearchIdsClause(column string, ids []string, paramPrefix string, queryParams map[string]interface{}) (string, map[string]interface{}) {
+	keys, idParams := MapStringsToQueryParams(ids, paramPrefix)
+	for key, value := range idParams {
+		queryParams[key] = value
+	}
+
+	return "AND " + column + " IN " + keys, queryParams
+}
+
+// ResolveSearchScope returns a copy of the search parameters with the channels searched and, when
+// searching by author, the authors searched for resolved to their ids. Searching with them instead
+// doesn't need the channel memberships or users of the database the posts are kept in.
+func (s *SqlPostStore) ResolveSearchScope(teamId string, userId string, params *model.SearchParams) (*model.SearchParams, *model.AppError) {
+	scope := *params
+
+	channelsQuery, queryParams := s.buildSearchChannelsQuery(params, map[string]interface{}{"TeamId": teamId, "UserId": userId})
+
+	var channelIds []string
+	if _, err := s.GetSearchReplica().Select(&channelIds, channelsQuery, queryParams); err != nil {
+		return nil, model.NewAppError("SqlPostStore.ResolveSearchScope", "store.sql_post.resolve_search_scope.app_error", nil, "team_id="+teamId+", user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	// Channels are listed once per member when searching without a user.
+	scope.ChannelIds = []string{}
+	seen := make(map[string]bool, len(channelIds))
+	for _, channelId := range channelIds {
+		if !seen[channelId] {
+			seen[channelId] = true
+			scope.ChannelIds = append(scope.ChannelIds, channelId)
+		}
+	}
+
+	if len(params.FromUsers) > 0 || len(params.ExcludedUsers) > 0 {
+		usersQuery, queryParams := s.buildSearchUsersQuery(params.FromUsers, params.ExcludedUsers, map[string]interface{}{"TeamId": teamId})
+
+		scope.UserIds = []string{}
+		if _, err := s.GetSearchReplica().Select(&scope.UserIds, usersQuery, queryParams); err != nil {
+			return nil, model.NewAppError("SqlPostStore.ResolveSearchScope", "store.sql_post.resolve_search_scope.app_error", nil, "team_id="+teamId+", user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return &scope, nil
+}
+
+var sqliteSearchTerm = regexp.MustCompile(`"[^"]*"|\S+`)
+
+// sqliteFullTextQuery builds an FTS5 query joining the given search terms with the given operator.
+// Quoted terms are matched as phrases, and terms ending with * as prefixes.
+func sqliteFullTextQuery(terms string, operator string) string {
+	var query []string
+	for _, term := range sqliteSearchTerm.FindAllString(terms, -1) {
+		if strings.HasPrefix(term, `"`) && strings.HasSuffix(term, `"`) && len(term) > 1 {
+			if phrase := strings.Join(strings.Fields(strings.Trim(term, `"`)), " "); phrase != "" {
+				query = append(query, quoteSqliteFullTextTerm(phrase))
+			}
+		} else if strings.HasSuffix(term, "*") {
+			if prefix := strings.TrimRight(term, "*"); prefix != "" {
+				query = append(query, quoteSqliteFullTextTerm(prefix)+"*")
+			}
+		} else {
+			query = append(query, quoteSqliteFullTextTerm(term))
+		}
+	}
+
+	return strings.Join(query, operator)
+}
+
+func (s *SqlPostStore) Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, *model.AppError) {
+	list := model.NewPostList()
+
+	searchQuery, queryParams := s.buildSearchQuery("Posts", "*", teamId, userId, params)
+	if searchQuery == "" {
+		return list, nil
+	}
+
+	var posts []*model.Post
+	_, err := s.GetSearchReplica().Select(&posts, searchQuery, queryParams)
+	if err != nil {
+		mlog.Warn("Query error searching posts.", mlog.Err(err))
+		// Don't return the error to the caller as it is of no use to the user. Instead return an empty set of search results.
+	} else {
+		for _, p := range posts {
+			if params.IsHashtag && !hasSearchedHashtag(p.Hashtags, params.Terms) {
+				continue
+			}
+			list.AddPost(p)
+			list.AddOrder(p.Id)
+		}
+	}
+	list.MakeNonNil()
+	return list, nil
+}
+
+// SearchArchive returns the indexes of up to 100 archived posts matching a search, latest first.
+// The posts themselves are read from their archive files by the caller.
+func (s *SqlPostStore) SearchArchive(teamId string, userId string, params *model.SearchParams) ([]*model.PostArchiveIndex, *model.AppError) {
+	indexes := []*model.PostArchiveIndex{}
+
+	searchQuery, queryParams := s.buildSearchQuery("PostArchiveIndex", postArchiveIndexColumns, teamId, userId, params)
+	if searchQuery == "" {
+		return indexes, nil
+	}
+
+	var found []*model.PostArchiveIndex
+	if _, err := s.GetSearchReplica().Select(&found, searchQuery, queryParams); err != nil {
+		mlog.Warn("Query error searching archived posts.", mlog.Err(err))
+		// As for the posts of the Posts table, an empty set of search results is returned instead.
+		return indexes, nil
+	}
+
+	for _, index := range found {
+		if params.IsHashtag && !hasSearchedHashtag(index.Hashtags, params.Terms) {
+			continue
+		}
+		indexes = append(indexes, index)
+	}
+
+	return indexes, nil
+}
+
+// hasSearchedHashtag reports whether any of the hashtags of a post is exactly one of those searched
+// for, as the full text search also matches hashtags merely starting with them.
+func hasSearchedHashtag(hashtags string, terms string) bool {
+	termMap := map[string]bool{}
+	for _, term := range strings.Split(terms, " ") {
+		termMap[strings.ToUpper(term)] = true
+	}
+
+	for _, tag := range strings.Split(hashtags, " ") {
+		if termMap[strings.ToUpper(tag)] {
+			return true
+		}
+	}
+	return false
+}
+
+// buildSearchQuery returns the query selecting the given columns of up to 100 rows of the table
+// matching a search, latest first, or an empty query if the search cannot match anything. The
+// table is either Posts or PostArchiveIndex, which have the columns searched in common and are
+// both indexed for full text search.
+func (s *SqlPostStore) buildSearchQuery(tableName string, columns string, teamId string, userId string, params *model.SearchParams) (string, map[string]interface{}) {
+	queryParams := map[string]interface{}{
+		"TeamId": teamId,
+		"UserId": userId,
+	}
+
+	if params.Terms == "" && params.ExcludedTerms == "" &&
+		len(params.InChannels) == 0 && len(params.ExcludedChannels) == 0 &&
+		len(params.FromUsers) == 0 && len(params.ExcludedUsers) == 0 &&
+		len(params.OnDate) == 0 && len(params.AfterDate) == 0 && len(params.BeforeDate) == 0 {
+		return "", nil
+	}
+
+	// Resolved scopes without any channel or author match nothing.
+	if (params.ChannelIds != nil && len(params.ChannelIds) == 0) || (params.UserIds != nil && len(params.UserIds) == 0) {
+		return "", nil
+	}
+
+	searchQuery := `
+			SELECT
+				` + columns + `
+			FROM
+				` + tableName + `
+			WHERE
+				DeleteAt = 0
+				AND Type NOT LIKE '` + model.POST_SYSTEM_MESSAGE_PREFIX + `%'
+				POST_FILTER
+				CHANNEL_FILTER
 				CREATEDATE_CLAUSE
 				SEARCH_CLAUSE
 				ORDER BY CreateAt DESC
 			LIMIT 100`
 
-	inChannelClause, queryParams := s.buildSearchChannelFilterClause(params.InChannels, "InChannel", false, queryParams)
-	searchQuery = strings.Replace(searchQuery, "IN_CHANNEL_FILTER", inChannelClause, 1)
-
-	excludedChannelClause, queryParams := s.buildSearchChannelFilterClause(params.ExcludedChannels, "ExcludedChannel", true, queryParams)
-	searchQuery = strings.Replace(searchQuery, "EXCLUDED_CHANNEL_FILTER", excludedChannelClause, 1)
+	var channelFilterClause string
+	if params.ChannelIds != nil {
+		channelFilterClause, queryParams = s.buildSearchIdsClause("ChannelId", params.ChannelIds, "SearchChannelId", queryParams)
+	} else {
+		var channelsQuery string
+		channelsQuery, queryParams = s.buildSearchChannelsQuery(params, queryParams)
+		channelFilterClause = "AND ChannelId IN (" + channelsQuery + ")"
+	}
+	searchQuery = strings.Replace(searchQuery, "CHANNEL_FILTER", channelFilterClause, 1)
 
-	postFilterClause, queryParams := s.buildSearchPostFilterClause(params.FromUsers, params.ExcludedUsers, queryParams)
+	var postFilterClause string
+	if params.UserIds != nil {
+		postFilterClause, queryParams = s.buildSearchIdsClause("UserId", params.UserIds, "SearchUserId", queryParams)
+	} else {
+		postFilterClause, queryParams = s.buildSearchPostFilterClause(params.FromUsers, params.ExcludedUsers, queryParams)
+	}
 	searchQuery = strings.Replace(searchQuery, "POST_FILTER", postFilterClause, 1)
 
 	createDateFilterClause, queryParams := s.buildCreateDateFilterClause(params, queryParams)
 	searchQuery = strings.Replace(searchQuery, "CREATEDATE_CLAUSE", createDateFilterClause, 1)
 
-	termMap := map[string]bool{}
 	terms := params.Terms
 	excludedTerms := params.ExcludedTerms
 
 	searchType := "Message"
 	if params.IsHashtag {
 		searchType = "Hashtags"
-		for _, term := range strings.Split(terms, " ") {
-			termMap[strings.ToUpper(term)] = true
-		}
 	}
 
 	// these chars have special meaning and can be treated as spaces
@@ -1039,38 +1141,40 @@ func (s *SqlPostStore) Search(teamId string, userId string, params *model.Search
 			}
 			queryParams["Terms"] = strings.Join(splitTerms, " ") + excludeClause
 		}
-	}
+	} else if s.DriverName() == model.DATABASE_DRIVER_SQLITE {
+		operator := " AND "
+		if params.OrTerms {
+			operator = " OR "
+		}
 
-	_, err := s.GetSearchReplica().Select(&posts, searchQuery, queryParams)
-	if err != nil {
-		mlog.Warn("Query error searching posts.", mlog.Err(err))
-		// Don't return the error to the caller as it is of no use to the user. Instead return an empty set of search results.
-	} else {
-		for _, p := range posts {
-			if searchType == "Hashtags" {
-				exactMatch := false
-				for _, tag := range strings.Split(p.Hashtags, " ") {
-					if termMap[strings.ToUpper(tag)] {
-						exactMatch = true
-						break
-					}
-				}
-				if !exactMatch {
-					continue
-				}
-			}
-			list.AddPost(p)
-			list.AddOrder(p.Id)
+		// FTS5 can only exclude terms from those matched, so a search for excluded terms alone
+		// matches nothing, as it does for the other databases.
+		fulltextTerms := sqliteFullTextQuery(terms, operator)
+		if fulltextTerms == "" {
+			fulltextTerms = `""`
+		} else if excludedFulltextTerms := sqliteFullTextQuery(excludedTerms, " OR "); excludedFulltextTerms != "" {
+			fulltextTerms = "(" + fulltextTerms + ") NOT (" + excludedFulltextTerms + ")"
 		}
+		queryParams["Terms"] = fulltextTerms
+
+		indexName := "idx_" + strings.ToLower(tableName) + "_" + strings.ToLower(searchType) + "_txt"
+
+		searchClause := fmt.Sprintf("AND rowid IN (SELECT rowid FROM %[1]s WHERE %[1]s MATCH :Terms)", indexName)
+		searchQuery = strings.Replace(searchQuery, "SEARCH_CLAUSE", searchClause, 1)
 	}
-	list.MakeNonNil()
-	return list, nil
+
+	return searchQuery, queryParams
 }
 
 func (s *SqlPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (model.AnalyticsRows, *model.AppError) {
+	postDate := "DATE(FROM_UNIXTIME(Posts.CreateAt / 1000))"
+	if s.DriverName() == model.DATABASE_DRIVER_SQLITE {
+		postDate = "DATE(Posts.CreateAt / 1000, 'unixepoch', 'localtime')"
+	}
+
 	query :=
 		`SELECT DISTINCT
-		        DATE(FROM_UNIXTIME(Posts.CreateAt / 1000)) AS Name,
+		        ` + postDate + ` AS Name,
 		        COUNT(DISTINCT Posts.UserId) AS Value
 		FROM Posts`
 
@@ -1081,7 +1185,7 @@ func (s *SqlPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (model.A
 	}
 
 	query += ` Posts.CreateAt >= :StartTime AND Posts.CreateAt <= :EndTime
-		GROUP BY DATE(FROM_UNIXTIME(Posts.CreateAt / 1000))
+		GROUP BY ` + postDate + `
 		ORDER BY Name DESC
 		LIMIT 30`
 
@@ -1119,9 +1223,14 @@ func (s *SqlPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (model.A
 
 func (s *SqlPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, *model.AppError) {
 
+	postDate := "DATE(FROM_UNIXTIME(Posts.CreateAt / 1000))"
+	if s.DriverName() == model.DATABASE_DRIVER_SQLITE {
+		postDate = "DATE(Posts.CreateAt / 1000, 'unixepoch', 'localtime')"
+	}
+
 	query :=
 		`SELECT
-		        DATE(FROM_UNIXTIME(Posts.CreateAt / 1000)) AS Name,
+		        ` + postDate + ` AS Name,
 		        COUNT(Posts.Id) AS Value
 		    FROM Posts`
 
@@ -1137,7 +1246,7 @@ func (s *SqlPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCoun
 
 	query += ` Posts.CreateAt <= :EndTime
 		            AND Posts.CreateAt >= :StartTime
-		GROUP BY DATE(FROM_UNIXTIME(Posts.CreateAt / 1000))
+		GROUP BY ` + postDate + `
 		ORDER BY Name DESC
 		LIMIT 30`
 
@@ -1279,6 +1388,8 @@ func (s *SqlPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64,
 	var query string
 	if s.DriverName() == "postgres" {
 		query = "DELETE from Posts WHERE Id = any (array (SELECT Id FROM Posts WHERE CreateAt < :EndTime LIMIT :Limit))"
+	} else if s.DriverName() == model.DATABASE_DRIVER_SQLITE {
+		query = "DELETE FROM Posts WHERE rowid IN (SELECT rowid FROM Posts WHERE CreateAt < :EndTime LIMIT :Limit)"
 	} else {
 		query = "DELETE from Posts WHERE CreateAt < :EndTime LIMIT :Limit"
 	}
@@ -1339,6 +1450,9 @@ func (s *SqlPostStore) determineMaxPostSize() int {
 		`); err != nil {
 			mlog.Error("Unable to determine the maximum supported post size", mlog.Err(err))
 		}
+	} else if s.DriverName() == model.DATABASE_DRIVER_SQLITE {
+		// SQLite doesn't restrict the length of TEXT columns, so allow as much as MySQL does.
+		maxPostSizeBytes = model.POST_MESSAGE_MAX_BYTES_V2
 	} else {
 		mlog.Warn("No implementation found to determine the maximum supported post size")
 	}
@@ -1521,3 +1635,221 @@ func (s *SqlPostStore) GetDirectPostParentsForExportAfter(limit int, afterId str
 	}
 	return posts, nil
 }
+
+// GetThreadsForArchival returns up to limit threads, roots followed by their replies, that were
+// created, last replied to and last edited before endTime. Threads with pinned posts are skipped.
+// Threads are ordered by the creation time and id of their roots, starting after the given ones.
+func (s *SqlPostStore) GetThreadsForArchival(endTime int64, afterCreateAt int64, afterId string, limit int) ([]*model.Post, *model.AppError) {
+	var roots []*model.Post
+	_, err := s.GetReplica().Select(&roots, `
+		SELECT
+			*
+		FROM
+			Posts
+		WHERE
+			RootId = ''
+			AND CreateAt < :EndTime
+			AND UpdateAt < :EndTime
+			AND IsPinned = :IsPinned
+			AND (CreateAt > :AfterCreateAt OR (CreateAt = :AfterCreateAt AND Id > :AfterId))
+			AND NOT EXISTS (
+				SELECT
+					1
+				FROM
+					Posts Replies
+				WHERE
+					Replies.RootId = Posts.Id
+					AND (Replies.UpdateAt >= :EndTime OR Replies.IsPinned = :ReplyIsPinned)
+			)
+		ORDER BY
+			CreateAt, Id
+		LIMIT
+			:Limit`, map[string]interface{}{"EndTime": endTime, "IsPinned": false, "ReplyIsPinned": true, "AfterCreateAt": afterCreateAt, "AfterId": afterId, "Limit": limit})
+	if err != nil {
+		return nil, model.NewAppError("SqlPostStore.GetThreadsForArchival", "store.sql_post.get_threads_for_archival.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if len(roots) == 0 {
+		return roots, nil
+	}
+
+	rootIds := make([]string, 0, len(roots))
+	for _, root := range roots {
+		rootIds = append(rootIds, root.Id)
+	}
+
+	keys, params := MapStringsToQueryParams(rootIds, "RootId")
+
+	var replies []*model.Post
+	if _, err := s.GetReplica().Select(&replies, "SELECT * FROM Posts WHERE RootId IN "+keys+" ORDER BY CreateAt, Id", params); err != nil {
+		return nil, model.NewAppError("SqlPostStore.GetThreadsForArchival", "store.sql_post.get_threads_for_archival.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return append(roots, replies...), nil
+}
+
+// Archive records that the posts were written to the archive file at path and removes them from
+// the Posts table. Nothing is archived if any of the posts was changed or removed since endTime.
+func (s *SqlPostStore) Archive(path string, posts []*model.Post, endTime int64) *model.AppError {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return model.NewAppError("SqlPostStore.Archive", "store.sql_post.archive.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	postIds := make([]string, 0, len(posts))
+	for _, post := range posts {
+		if err := transaction.Insert(model.NewPostArchiveIndex(post, path)); err != nil {
+			return model.NewAppError("SqlPostStore.Archive", "store.sql_post.archive.app_error", nil, "post_id="+post.Id+", "+err.Error(), http.StatusInternalServerError)
+		}
+		postIds = append(postIds, post.Id)
+	}
+
+	keys, params := MapStringsToQueryParams(postIds, "PostId")
+	params["EndTime"] = endTime
+
+	result, err := transaction.Exec("DELETE FROM Posts WHERE Id IN "+keys+" AND UpdateAt < :EndTime", params)
+	if err != nil {
+		return model.NewAppError("SqlPostStore.Archive", "store.sql_post.archive.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if deleted, err := result.RowsAffected(); err != nil {
+		return model.NewAppError("SqlPostStore.Archive", "store.sql_post.archive.app_error", nil, err.Error(), http.StatusInternalServerError)
+	} else if deleted != int64(len(posts)) {
+		return model.NewAppError("SqlPostStore.Archive", "store.sql_post.archive.changed.app_error", nil, fmt.Sprintf("path=%v, expected=%v, deleted=%v", path, len(posts), deleted), http.StatusConflict)
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return model.NewAppError("SqlPostStore.Archive", "store.sql_post.archive.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// GetArchivePaths returns the paths of the archive files holding those of the given posts that
+// were archived, by post id.
+func (s *SqlPostStore) GetArchivePaths(postIds []string) (map[string]string, *model.AppError) {
+	paths := make(map[string]string)
+	if len(postIds) == 0 {
+		return paths, nil
+	}
+
+	keys, params := MapStringsToQueryParams(postIds, "PostId")
+
+	var indexes []*model.PostArchiveIndex
+	if _, err := s.GetReplica().Select(&indexes, "SELECT "+postArchiveIndexColumns+" FROM PostArchiveIndex WHERE PostId IN "+keys, params); err != nil {
+		return nil, model.NewAppError("SqlPostStore.GetArchivePaths", "store.sql_post.get_archive_paths.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	for _, index := range indexes {
+		paths[index.PostId] = index.Path
+	}
+
+	return paths, nil
+}
+
+// GetArchivePathsForChannel returns the paths of the archive files holding posts of the channel,
+// or of every channel if channelId is empty.
+func (s *SqlPostStore) GetArchivePathsForChannel(channelId string) ([]string, *model.AppError) {
+	query := "SELECT DISTINCT Path FROM PostArchiveIndex"
+	if channelId != "" {
+		query += " WHERE ChannelId = :ChannelId"
+	}
+	query += " ORDER BY Path"
+
+	var paths []string
+	if _, err := s.GetReplica().Select(&paths, query, map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return nil, model.NewAppError("SqlPostStore.GetArchivePathsForChannel", "store.sql_post.get_archive_paths.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return paths, nil
+}
+
+// GetArchivePathsBefore returns the paths of up to limit archive files holding posts created
+// before endTime.
+func (s *SqlPostStore) GetArchivePathsBefore(endTime int64, limit int) ([]string, *model.AppError) {
+	var paths []string
+	if _, err := s.GetReplica().Select(&paths, "SELECT DISTINCT Path FROM PostArchiveIndex WHERE CreateAt < :EndTime ORDER BY Path LIMIT :Limit", map[string]interface{}{"EndTime": endTime, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlPostStore.GetArchivePathsBefore", "store.sql_post.get_archive_paths.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return paths, nil
+}
+
+// ReplaceArchive forgets the archive file at path and records that the given posts were written
+// to the archive file at newPath instead.
+func (s *SqlPostStore) ReplaceArchive(path string, newPath string, posts []*model.Post) *model.AppError {
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return model.NewAppError("SqlPostStore.ReplaceArchive", "store.sql_post.replace_archive.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	if _, err := transaction.Exec("DELETE FROM PostArchiveIndex WHERE Path = :Path", map[string]interface{}{"Path": path}); err != nil {
+		return model.NewAppError("SqlPostStore.ReplaceArchive", "store.sql_post.replace_archive.app_error", nil, "path="+path+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	for _, post := range posts {
+		if err := transaction.Insert(model.NewPostArchiveIndex(post, newPath)); err != nil {
+			return model.NewAppError("SqlPostStore.ReplaceArchive", "store.sql_post.replace_archive.app_error", nil, "post_id="+post.Id+", "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return model.NewAppError("SqlPostStore.ReplaceArchive", "store.sql_post.replace_archive.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// Unarchive moves the posts read from the archive file at path back into the Posts table, skipping
+// those already there, and forgets the archive file.
+func (s *SqlPostStore) Unarchive(path string, posts []*model.Post) *model.AppError {
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return model.NewAppError("SqlPostStore.Unarchive", "store.sql_post.unarchive.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	existing := make(map[string]bool)
+	if len(posts) > 0 {
+		postIds := make([]string, 0, len(posts))
+		for _, post := range posts {
+			postIds = append(postIds, post.Id)
+		}
+
+		keys, params := MapStringsToQueryParams(postIds, "PostId")
+
+		var existingIds []string
+		if _, err := transaction.Select(&existingIds, "SELECT Id FROM Posts WHERE Id IN "+keys, params); err != nil {
+			return model.NewAppError("SqlPostStore.Unarchive", "store.sql_post.unarchive.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		for _, id := range existingIds {
+			existing[id] = true
+		}
+	}
+
+	for _, post := range posts {
+		if existing[post.Id] {
+			continue
+		}
+
+		if err := transaction.Insert(post); err != nil {
+			return model.NewAppError("SqlPostStore.Unarchive", "store.sql_post.unarchive.app_error", nil, "post_id="+post.Id+", "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if _, err := transaction.Exec("DELETE FROM PostArchiveIndex WHERE Path = :Path", map[string]interface{}{"Path": path}); err != nil {
+		return model.NewAppError("SqlPostStore.Unarchive", "store.sql_post.unarchive.app_error", nil, "path="+path+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return model.NewAppError("SqlPostStore.Unarchive", "store.sql_post.unarchive.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -6,9 +6,20 @@ package sqlstore
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/mattermost/mattermost-server/store/storetest"
 )
 
 func TestPostStore(t *testing.T) {
 	StoreTestWithSqlSupplier(t, storetest.TestPostStore)
 }
+
+func TestSqliteFullTextQuery(t *testing.T) {
+	assert.Equal(t, "", sqliteFullTextQuery("", " AND "))
+	assert.Equal(t, `"apple" AND "banana"`, sqliteFullTextQuery("apple banana", " AND "))
+	assert.Equal(t, `"apple" OR "banana"`, sqliteFullTextQuery("apple banana", " OR "))
+	assert.Equal(t, `"app"* AND "banana split"`, sqliteFullTextQuery(`app* "banana  split"`, " AND "))
+	assert.Equal(t, `"a""b"`, sqliteFullTextQuery(`a"b`, " AND "))
+	assert.Equal(t, "", sqliteFullTextQuery(`* ""`, " AND "))
+}
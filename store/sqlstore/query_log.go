@@ -0,0 +1,154 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/mattermost/gorp"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// STORE_METHOD_MAX_DEPTH is how many frames of the stack are searched for the calling store method.
+const STORE_METHOD_MAX_DEPTH = 32
+
+var sqlstorePackage = reflect.TypeOf(SqlSupplier{}).PkgPath() + "."
+
+// storeMethodName returns the name of the store method running the current query, such as
+// PostStore.Search, or an empty string if the query is run outside of any store method.
+func storeMethodName() string {
+	pcs := make([]uintptr, STORE_METHOD_MAX_DEPTH)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	for {
+		frame, more := frames.Next()
+		if name := parseStoreMethodName(frame.Function); name != "" {
+			return name
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// parseStoreMethodName returns the store method name of a function of the stack, such as
+// PostStore.Search for github.com/mattermost/mattermost-server/store/sqlstore.(*SqlPostStore).Search,
+// or an empty string for anything but the exported methods of the stores and their closures.
+func parseStoreMethodName(function string) string {
+	if !strings.HasPrefix(function, sqlstorePackage) {
+		return ""
+	}
+
+	name := strings.NewReplacer("(*", "", ")", "").Replace(strings.TrimPrefix(function, sqlstorePackage))
+	parts := strings.Split(name, ".")
+	if len(parts) < 2 || !strings.HasPrefix(parts[0], "Sql") || !strings.HasSuffix(parts[0], "Store") {
+		return ""
+	}
+
+	if method := parts[1]; method == "" || !unicode.IsUpper(rune(method[0])) {
+		return ""
+	}
+
+	return strings.TrimPrefix(parts[0], "Sql") + "." + parts[1]
+}
+
+// redactQuery replaces the string and number literals of the query with ?, so that it can be
+// logged without the data it was run for, and collapses its whitespace.
+func redactQuery(query string) string {
+	var result strings.Builder
+	result.Grow(len(query))
+
+	isIdentifier := func(c byte) bool {
+		return c == '_' || c == '$' || c == '@' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+	}
+
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '\'':
+			i++
+			for i < len(query) {
+				if query[i] == '\\' {
+					i += 2
+				} else if query[i] == '\'' && i+1 < len(query) && query[i+1] == '\'' {
+					i += 2
+				} else if query[i] == '\'' {
+					break
+				} else {
+					i++
+				}
+			}
+			i++
+			result.WriteByte('?')
+		case c >= '0' && c <= '9' && (i == 0 || !isIdentifier(query[i-1])):
+			for i < len(query) && (query[i] == '.' || (query[i] >= '0' && query[i] <= '9')) {
+				i++
+			}
+			result.WriteByte('?')
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			for i < len(query) && (query[i] == ' ' || query[i] == '\t' || query[i] == '\n' || query[i] == '\r') {
+				i++
+			}
+			if result.Len() > 0 && i < len(query) {
+				result.WriteByte(' ')
+			}
+		default:
+			result.WriteByte(c)
+			i++
+		}
+	}
+
+	return result.String()
+}
+
+// queryLogger receives every query run by gorp, logging the slow ones and passing them all on
+// to the SQL trace if enabled.
+type queryLogger struct {
+	trace     gorp.GorpLogger
+	threshold time.Duration
+}
+
+func newQueryLogger(settings *model.SqlSettings, trace gorp.GorpLogger) *queryLogger {
+	logger := &queryLogger{trace: trace}
+	if settings.SlowQueryThresholdMilliseconds != nil {
+		logger.threshold = time.Duration(*settings.SlowQueryThresholdMilliseconds) * time.Millisecond
+	}
+
+	if logger.trace == nil && logger.threshold <= 0 {
+		return nil
+	}
+
+	return logger
+}
+
+// Printf is called by gorp after each query with the prefix of the connection, the query, its
+// arguments and its duration.
+func (l *queryLogger) Printf(format string, v ...interface{}) {
+	if l.trace != nil {
+		l.trace.Printf(format, v...)
+	}
+
+	if l.threshold <= 0 || len(v) != 4 {
+		return
+	}
+
+	query, _ := v[1].(string)
+	duration, ok := v[3].(time.Duration)
+	if !ok || duration < l.threshold {
+		return
+	}
+
+	// The arguments are left out as they are the data the query was run for.
+	mlog.Warn("Slow query",
+		mlog.String("method", storeMethodName()),
+		mlog.Duration("duration", duration),
+		mlog.String("query", redactQuery(query)),
+	)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestParseStoreMethodName(t *testing.T) {
+	for function, expected := range map[string]string{
+		sqlstorePackage + "(*SqlPostStore).Search":                   "PostStore.Search",
+		sqlstorePackage + "SqlChannelStore.GetMember":                "ChannelStore.GetMember",
+		sqlstorePackage + "(*SqlPostStore).Save.func1":               "PostStore.Save",
+		sqlstorePackage + "(*SqlRoleStore).createRole":               "",
+		sqlstorePackage + "(*SqlSupplier).GetMaster":                 "",
+		sqlstorePackage + "executePossiblyEmptyQuery":                "",
+		"github.com/mattermost/gorp.(*DbMap).Select":                 "",
+		"github.com/mattermost/mattermost-server/app.(*App).GetPost": "",
+	} {
+		assert.Equal(t, expected, parseStoreMethodName(function), function)
+	}
+}
+
+func TestRedactQuery(t *testing.T) {
+	for query, expected := range map[string]string{
+		"SELECT * FROM Posts WHERE Id = :Id":                                "SELECT * FROM Posts WHERE Id = :Id",
+		"SELECT * FROM Posts WHERE Id = 'abc' AND DeleteAt = 0 LIMIT 100":   "SELECT * FROM Posts WHERE Id = ? AND DeleteAt = ? LIMIT ?",
+		"SELECT * FROM Users WHERE Username = 'o''brien\\'s' AND Id = $1":   "SELECT * FROM Users WHERE Username = ? AND Id = $1",
+		"SELECT Props2, t1.Id FROM Channels t1 WHERE Total > 1.5":           "SELECT Props2, t1.Id FROM Channels t1 WHERE Total > ?",
+		"\n\t\tSELECT *\n\t\tFROM Posts\n\t\tWHERE  Message = 'secret'\n\t": "SELECT * FROM Posts WHERE Message = ?",
+		"SELECT * FROM Posts WHERE Message = 'unterminated":                 "SELECT * FROM Posts WHERE Message = ?",
+	} {
+		assert.Equal(t, expected, redactQuery(query))
+	}
+}
+
+type recordingLogger struct {
+	lines []string
+}
+
+func (l *recordingLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestQueryLogger(t *testing.T) {
+	settings := model.SqlSettings{}
+	settings.SetDefaults(false)
+
+	t.Run("nothing is logged by default", func(t *testing.T) {
+		assert.Nil(t, newQueryLogger(&settings, nil))
+	})
+
+	t.Run("queries are passed on to the trace", func(t *testing.T) {
+		*settings.SlowQueryThresholdMilliseconds = 1
+		trace := &recordingLogger{}
+		logger := newQueryLogger(&settings, trace)
+		require.NotNil(t, logger)
+		assert.Equal(t, time.Millisecond, logger.threshold)
+
+		logger.Printf("%s%s [%s] (%v)", "", "SELECT 1", "", 2*time.Millisecond)
+		assert.Equal(t, []string{"SELECT 1 [] (2ms)"}, trace.lines)
+	})
+}
+
+func TestQueryTimeoutViews(t *testing.T) {
+	supplier := newReplicatedSqliteSupplier(t, 1, 0)
+	defer supplier.Close()
+
+	t.Run("no overrides", func(t *testing.T) {
+		assert.True(t, supplier.WithQueryTimeout(120*time.Second) == supplier)
+		assert.True(t, supplier.GetMaster() == supplier.master)
+	})
+
+	supplier.settings.QueryTimeoutOverrides = map[string]int{"PostStore.Search": 120, "PostStore.GetPostsSince": 120}
+	supplier.initQueryTimeoutViews(nil)
+	require.Len(t, supplier.queryTimeoutViews, 1)
+
+	view := supplier.WithQueryTimeout(120 * time.Second).(*SqlSupplier)
+
+	t.Run("the view uses its timeout", func(t *testing.T) {
+		dbmap := view.GetReplica()
+		assert.True(t, dbmap != supplier.replicas[0])
+		assert.Equal(t, 120*time.Second, dbmap.QueryTimeout)
+		assert.True(t, dbmap.Db == supplier.replicas[0].Db)
+		assert.Equal(t, 120*time.Second, view.GetMaster().QueryTimeout)
+
+		one, err := dbmap.SelectInt("SELECT 1")
+		require.Nil(t, err)
+		assert.Equal(t, int64(1), one)
+	})
+
+	t.Run("the supplier keeps the default timeout", func(t *testing.T) {
+		assert.True(t, supplier.GetMaster() == supplier.master)
+		assert.Equal(t, 30*time.Second, supplier.GetMaster().QueryTimeout)
+		assert.True(t, supplier.WithQueryTimeout(60*time.Second) == supplier)
+	})
+
+	t.Run("reads from the master use the timeout too", func(t *testing.T) {
+		masterOnly := view.MasterOnly().(*SqlSupplier)
+		assert.True(t, masterOnly == supplier.masterOnly.WithQueryTimeout(120*time.Second))
+		assert.Equal(t, 120*time.Second, masterOnly.GetReplica().QueryTimeout)
+		assert.True(t, masterOnly.GetReplica().Db == supplier.master.Db)
+
+		_, appErr := masterOnly.Post().GetPostsSince(model.NewId(), 0, false)
+		require.Nil(t, appErr)
+	})
+}
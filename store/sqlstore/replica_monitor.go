@@ -0,0 +1,242 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	dbsql "database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mattermost/gorp"
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// replicaLagFunc measures how many seconds the given replica is behind the master.
+type replicaLagFunc func(replica *gorp.DbMap) (float64, error)
+
+// replicaMonitor periodically measures the replication lag of the read replicas and keeps track
+// of the ones close enough to the master to serve reads. Until the first check completes, every
+// replica is assumed to be healthy, and so is any replica whose lag cannot be measured.
+type replicaMonitor struct {
+	names     []string
+	replicas  []*gorp.DbMap
+	lagFunc   replicaLagFunc
+	threshold float64
+
+	mutex    sync.RWMutex
+	healthy  []*gorp.DbMap
+	statuses []*model.ReplicaStatus
+
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+func newReplicaMonitor(replicas []*gorp.DbMap, lagFunc replicaLagFunc, thresholdSeconds int) *replicaMonitor {
+	monitor := &replicaMonitor{
+		names:     make([]string, len(replicas)),
+		replicas:  replicas,
+		lagFunc:   lagFunc,
+		threshold: float64(thresholdSeconds),
+		healthy:   replicas,
+		statuses:  make([]*model.ReplicaStatus, len(replicas)),
+	}
+
+	for i := range replicas {
+		monitor.names[i] = fmt.Sprintf("replica-%v", i)
+		monitor.statuses[i] = &model.ReplicaStatus{Name: monitor.names[i], Healthy: true}
+	}
+
+	return monitor
+}
+
+// start checks the replicas once and then again at every interval until stopped.
+func (m *replicaMonitor) start(interval time.Duration) {
+	m.check()
+
+	m.stop = make(chan struct{})
+	m.stopped = make(chan struct{})
+
+	go func() {
+		defer close(m.stopped)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				m.check()
+			case <-m.stop:
+				return
+			}
+		}
+	}()
+}
+
+func (m *replicaMonitor) shutdown() {
+	if m.stop == nil {
+		return
+	}
+
+	close(m.stop)
+	<-m.stopped
+	m.stop = nil
+}
+
+// check measures the lag of every replica, excluding those above the threshold. Replicas whose lag
+// cannot be measured, e.g. because the database user may not read the replication status or the
+// database does not report it, are kept for reads with the error in their status.
+func (m *replicaMonitor) check() {
+	healthy := []*gorp.DbMap{}
+	statuses := make([]*model.ReplicaStatus, len(m.replicas))
+
+	for i, replica := range m.replicas {
+		status := &model.ReplicaStatus{Name: m.names[i], LastCheckAt: model.GetMillis()}
+
+		lag, err := m.lagFunc(replica)
+		if err != nil {
+			status.Error = err.Error()
+			status.Healthy = true
+		} else {
+			status.LagSeconds = lag
+			status.Healthy = lag <= m.threshold
+		}
+
+		if status.Healthy {
+			healthy = append(healthy, replica)
+		}
+		statuses[i] = status
+	}
+
+	m.mutex.Lock()
+	previous := m.statuses
+	m.healthy = healthy
+	m.statuses = statuses
+	m.mutex.Unlock()
+
+	for i, status := range statuses {
+		if status.Error != "" && previous[i].Error == "" {
+			mlog.Warn("Unable to measure the lag of a read replica, still using it for reads.", mlog.String("replica", status.Name), mlog.String("error", status.Error))
+		}
+
+		if status.Healthy == previous[i].Healthy {
+			continue
+		}
+
+		if status.Healthy {
+			mlog.Info("Read replica caught up with the master, using it for reads again.", mlog.String("replica", status.Name), mlog.Any("lag_seconds", status.LagSeconds))
+		} else {
+			mlog.Warn("Read replica is lagging behind the master, excluding it from reads.", mlog.String("replica", status.Name), mlog.Any("lag_seconds", status.LagSeconds))
+		}
+	}
+}
+
+// healthyReplicas returns the replicas currently eligible for reads.
+func (m *replicaMonitor) healthyReplicas() []*gorp.DbMap {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.healthy
+}
+
+// replicaStatuses returns a copy of the last measured status of every replica.
+func (m *replicaMonitor) replicaStatuses() []*model.ReplicaStatus {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	statuses := make([]*model.ReplicaStatus, len(m.statuses))
+	for i, status := range m.statuses {
+		statusCopy := *status
+		statuses[i] = &statusCopy
+	}
+
+	return statuses
+}
+
+// replicaLag measures the replication lag of the given replica using the mechanism native to the
+// configured database.
+func (ss *SqlSupplier) replicaLag(replica *gorp.DbMap) (float64, error) {
+	switch ss.DriverName() {
+	case model.DATABASE_DRIVER_MYSQL:
+		return mySQLReplicaLag(replica)
+	case model.DATABASE_DRIVER_POSTGRES:
+		return postgresReplicaLag(replica)
+	default:
+		// SQLite replicas are independent databases, there is no replication to lag behind.
+		return 0, nil
+	}
+}
+
+func mySQLReplicaLag(replica *gorp.DbMap) (float64, error) {
+	rows, err := replica.Db.Query("SHOW SLAVE STATUS")
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+
+	if !rows.Next() {
+		if err = rows.Err(); err != nil {
+			return 0, err
+		}
+		return 0, fmt.Errorf("replication is not configured")
+	}
+
+	values := make([]dbsql.RawBytes, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err = rows.Scan(dest...); err != nil {
+		return 0, err
+	}
+
+	for i, column := range columns {
+		if column != "Seconds_Behind_Master" {
+			continue
+		}
+
+		// NULL means the replication threads are not running.
+		if values[i] == nil {
+			return 0, fmt.Errorf("replication is not running")
+		}
+
+		var lag float64
+		if _, err = fmt.Sscan(string(values[i]), &lag); err != nil {
+			return 0, err
+		}
+		return lag, nil
+	}
+
+	return 0, fmt.Errorf("replication lag is not reported")
+}
+
+func postgresReplicaLag(replica *gorp.DbMap) (float64, error) {
+	version, err := replica.SelectInt("SELECT current_setting('server_version_num')::integer")
+	if err != nil {
+		return 0, err
+	}
+
+	// The replay timestamp is that of the last replayed transaction, so it only reflects the lag
+	// while the replica has yet to replay everything it received.
+	query := `SELECT CASE WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0) END`
+	if version < 100000 {
+		query = `SELECT CASE WHEN pg_last_xlog_receive_location() = pg_last_xlog_replay_location() THEN 0
+			ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0) END`
+	}
+
+	lag, err := replica.SelectFloat(query)
+	if err != nil {
+		return 0, err
+	}
+
+	return lag, nil
+}
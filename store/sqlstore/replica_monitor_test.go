@@ -0,0 +1,170 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mattermost/gorp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func newReplicatedSqliteSupplier(t *testing.T, replicas, searchReplicas int) *SqlSupplier {
+	if !sqliteSupported {
+		t.Skip("SQLite is not built in")
+	}
+
+	settings := model.SqlSettings{}
+	settings.SetDefaults(false)
+	*settings.DriverName = model.DATABASE_DRIVER_SQLITE
+	*settings.DataSource = ":memory:"
+	*settings.MaxIdleConns = 1
+	*settings.MaxOpenConns = 1
+	*settings.ReplicaLagCheckIntervalSeconds = 0
+	for i := 0; i < replicas; i++ {
+		settings.DataSourceReplicas = append(settings.DataSourceReplicas, ":memory:")
+	}
+	for i := 0; i < searchReplicas; i++ {
+		settings.DataSourceSearchReplicas = append(settings.DataSourceSearchReplicas, ":memory:")
+	}
+
+	return NewSqlSupplier(settings, nil)
+}
+
+func TestReplicaMonitor(t *testing.T) {
+	supplier := newReplicatedSqliteSupplier(t, 3, 0)
+	defer supplier.Close()
+	monitor := supplier.replicaMonitor
+	require.NotNil(t, monitor)
+
+	lags := map[*gorp.DbMap]float64{
+		supplier.replicas[0]: 1,
+		supplier.replicas[1]: 60,
+		supplier.replicas[2]: 0,
+	}
+	failing := supplier.replicas[2]
+	monitor.lagFunc = func(replica *gorp.DbMap) (float64, error) {
+		if replica == failing {
+			return 0, errors.New("connection refused")
+		}
+		return lags[replica], nil
+	}
+
+	t.Run("every replica is healthy before the first check", func(t *testing.T) {
+		assert.Equal(t, supplier.replicas, monitor.healthyReplicas())
+		for _, status := range supplier.ReplicaStatuses() {
+			assert.True(t, status.Healthy)
+		}
+	})
+
+	t.Run("lagging replicas are excluded and unmeasurable ones kept", func(t *testing.T) {
+		monitor.check()
+
+		assert.Equal(t, []*gorp.DbMap{supplier.replicas[0], supplier.replicas[2]}, monitor.healthyReplicas())
+
+		statuses := supplier.ReplicaStatuses()
+		require.Len(t, statuses, 3)
+		assert.Equal(t, "replica-0", statuses[0].Name)
+		assert.True(t, statuses[0].Healthy)
+		assert.Equal(t, float64(1), statuses[0].LagSeconds)
+		assert.False(t, statuses[1].Healthy)
+		assert.Equal(t, float64(60), statuses[1].LagSeconds)
+		assert.True(t, statuses[2].Healthy)
+		assert.Equal(t, "connection refused", statuses[2].Error)
+		assert.NotZero(t, statuses[2].LastCheckAt)
+
+		for i := 0; i < 5; i++ {
+			assert.NotEqual(t, supplier.replicas[1], supplier.GetReplica())
+		}
+	})
+
+	t.Run("reads fall back to the master without healthy replicas", func(t *testing.T) {
+		lags[supplier.replicas[0]] = 30
+		lags[supplier.replicas[2]] = 30
+		failing = nil
+		monitor.check()
+
+		assert.Empty(t, monitor.healthyReplicas())
+		assert.Equal(t, supplier.GetMaster(), supplier.GetReplica())
+	})
+
+	t.Run("replicas are used again once they catch up", func(t *testing.T) {
+		lags[supplier.replicas[0]] = 0
+		lags[supplier.replicas[1]] = 0
+		lags[supplier.replicas[2]] = 0
+		monitor.check()
+
+		replicas := make(map[*gorp.DbMap]bool)
+		for i := 0; i < 6; i++ {
+			replicas[supplier.GetReplica()] = true
+		}
+		assert.Len(t, replicas, 3)
+		assert.NotContains(t, replicas, supplier.GetMaster())
+	})
+
+	t.Run("statuses are copies", func(t *testing.T) {
+		supplier.ReplicaStatuses()[0].Healthy = false
+		assert.True(t, supplier.ReplicaStatuses()[0].Healthy)
+	})
+}
+
+func TestReplicaMonitorStart(t *testing.T) {
+	supplier := newReplicatedSqliteSupplier(t, 1, 0)
+	defer supplier.Close()
+
+	monitor := newReplicaMonitor(supplier.replicas, func(replica *gorp.DbMap) (float64, error) {
+		return 100, nil
+	}, 10)
+	monitor.start(time.Hour)
+	defer monitor.shutdown()
+
+	// The first check runs before start returns.
+	assert.Empty(t, monitor.healthyReplicas())
+}
+
+func TestSqliteReplicaLag(t *testing.T) {
+	supplier := newReplicatedSqliteSupplier(t, 1, 0)
+	defer supplier.Close()
+
+	lag, err := supplier.replicaLag(supplier.replicas[0])
+	require.Nil(t, err)
+	assert.Zero(t, lag)
+}
+
+func TestMasterOnly(t *testing.T) {
+	t.Run("without replicas", func(t *testing.T) {
+		supplier := newReplicatedSqliteSupplier(t, 0, 0)
+		defer supplier.Close()
+
+		assert.True(t, supplier.MasterOnly() == supplier)
+		assert.Nil(t, supplier.ReplicaStatuses())
+	})
+
+	t.Run("with replicas", func(t *testing.T) {
+		supplier := newReplicatedSqliteSupplier(t, 2, 2)
+		defer supplier.Close()
+
+		masterOnly, ok := supplier.MasterOnly().(*SqlSupplier)
+		require.True(t, ok)
+		assert.True(t, masterOnly != supplier)
+		assert.True(t, masterOnly.MasterOnly() == masterOnly)
+
+		assert.NotEqual(t, supplier.GetMaster(), supplier.GetReplica())
+		assert.NotEqual(t, supplier.GetMaster(), supplier.GetSearchReplica())
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, supplier.GetMaster(), masterOnly.GetReplica())
+			assert.Equal(t, supplier.GetMaster(), masterOnly.GetSearchReplica())
+		}
+
+		// The view has its own stores, reading through itself, and reports the same replicas.
+		assert.True(t, masterOnly.Post() != supplier.Post())
+		assert.True(t, masterOnly.Post().(*SqlPostStore).SqlStore == masterOnly)
+		assert.Equal(t, supplier.ReplicaStatuses(), masterOnly.ReplicaStatuses())
+	})
+}
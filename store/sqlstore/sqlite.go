@@ -0,0 +1,27 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+//go:build cgo && sqlite
+// +build cgo,sqlite
+
+package sqlstore
+
+import (
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteSupported is true when the SQLite driver is built in, which requires cgo and the sqlite
+// build tag.
+const sqliteSupported = true
+
+// sqliteConstraintError reports whether err is a SQLite unique or primary key constraint
+// violation.
+func sqliteConstraintError(err error) (unique bool, primaryKey bool) {
+	sqliteErr, ok := err.(sqlite3.Error)
+	if !ok {
+		return false, false
+	}
+
+	primaryKey = sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+	return primaryKey || sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique, primaryKey
+}
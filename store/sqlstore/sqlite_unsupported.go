@@ -0,0 +1,14 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+//go:build !cgo || !sqlite
+// +build !cgo !sqlite
+
+package sqlstore
+
+// sqliteSupported is false since the SQLite driver requires cgo and the sqlite build tag.
+const sqliteSupported = false
+
+func sqliteConstraintError(err error) (unique bool, primaryKey bool) {
+	return false, false
+}
@@ -29,6 +29,7 @@ func NewSqlStatusStore(sqlStore SqlStore) store.StatusStore {
 		table.ColMap("UserId").SetMaxSize(26)
 		table.ColMap("Status").SetMaxSize(32)
 		table.ColMap("ActiveChannel").SetMaxSize(26)
+		table.ColMap("PrevStatus").SetMaxSize(32)
 	}
 
 	return s
@@ -37,6 +38,7 @@ func NewSqlStatusStore(sqlStore SqlStore) store.StatusStore {
 func (s SqlStatusStore) CreateIndexesIfNotExists() {
 	s.CreateIndexIfNotExists("idx_status_user_id", "Status", "UserId")
 	s.CreateIndexIfNotExists("idx_status_status", "Status", "Status")
+	s.CreateIndexIfNotExists("idx_status_dndendtime", "Status", "DNDEndTime")
 }
 
 func (s SqlStatusStore) SaveOrUpdate(status *model.Status) *model.AppError {
@@ -115,3 +117,44 @@ func (s SqlStatusStore) UpdateLastActivityAt(userId string, lastActivityAt int64
 
 	return nil
 }
+
+// UpdateExpiredDNDStatuses returns every user whose timed Do Not Disturb status has ended as of
+// now to the status they had before, and returns the updated statuses. Each status is only
+// updated if it is still the one that ended, so that statuses changed meanwhile are left as is.
+func (s SqlStatusStore) UpdateExpiredDNDStatuses(now int64) ([]*model.Status, *model.AppError) {
+	var statuses []*model.Status
+	if _, err := s.GetMaster().Select(&statuses, "SELECT * FROM Status WHERE Status = :Status AND DNDEndTime > 0 AND DNDEndTime <= :Now", map[string]interface{}{"Status": model.STATUS_DND, "Now": now}); err != nil {
+		return nil, model.NewAppError("SqlStatusStore.UpdateExpiredDNDStatuses", "store.sql_status.update_expired_dnd_statuses.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	updated := []*model.Status{}
+	for _, status := range statuses {
+		prevStatus := status.PrevStatus
+		if prevStatus == "" {
+			prevStatus = model.STATUS_OFFLINE
+		}
+
+		result, err := s.GetMaster().Exec(`UPDATE
+				Status
+			SET
+				Status = :PrevStatus,
+				PrevStatus = '',
+				DNDEndTime = 0
+			WHERE
+				UserId = :UserId AND Status = :Status AND DNDEndTime = :DNDEndTime`,
+			map[string]interface{}{"UserId": status.UserId, "Status": model.STATUS_DND, "DNDEndTime": status.DNDEndTime, "PrevStatus": prevStatus})
+		if err != nil {
+			return nil, model.NewAppError("SqlStatusStore.UpdateExpiredDNDStatuses", "store.sql_status.update_expired_dnd_statuses.app_error", nil, "user_id="+status.UserId+", "+err.Error(), http.StatusInternalServerError)
+		}
+		if rows, _ := result.RowsAffected(); rows == 0 {
+			continue
+		}
+
+		status.Status = prevStatus
+		status.PrevStatus = ""
+		status.DNDEndTime = 0
+		updated = append(updated, status)
+	}
+
+	return updated, nil
+}
@@ -41,6 +41,7 @@ import (
 
 type SqlStore interface {
 	DriverName() string
+	OnlineMigrations() bool
 	GetCurrentSchemaVersion() string
 	GetMaster() *gorp.DbMap
 	GetSearchReplica() *gorp.DbMap
@@ -98,5 +99,10 @@ type SqlStore interface {
 	TermsOfService() store.TermsOfServiceStore
 	UserTermsOfService() store.UserTermsOfServiceStore
 	LinkMetadata() store.LinkMetadataStore
+	PostPriority() store.PostPriorityStore
+	PostAcknowledgement() store.PostAcknowledgementStore
+	PluginConfigRevision() store.PluginConfigRevisionStore
+	PostShard() store.PostShardStore
+	AuditLog() store.AuditLogStore
 	getQueryBuilder() sq.StatementBuilderType
 }
@@ -48,14 +48,24 @@ func StoreTestWithSqlSupplier(t *testing.T, f func(*testing.T, store.Store, stor
 }
 
 func initStores() {
-	storeTypes = append(storeTypes, &storeType{
-		Name:        "MySQL",
-		SqlSettings: storetest.MakeSqlSettings(model.DATABASE_DRIVER_MYSQL),
-	})
-	storeTypes = append(storeTypes, &storeType{
-		Name:        "PostgreSQL",
-		SqlSettings: storetest.MakeSqlSettings(model.DATABASE_DRIVER_POSTGRES),
-	})
+	if storetest.TestDriverEnabled(model.DATABASE_DRIVER_MYSQL) {
+		storeTypes = append(storeTypes, &storeType{
+			Name:        "MySQL",
+			SqlSettings: storetest.MakeSqlSettings(model.DATABASE_DRIVER_MYSQL),
+		})
+	}
+	if storetest.TestDriverEnabled(model.DATABASE_DRIVER_POSTGRES) {
+		storeTypes = append(storeTypes, &storeType{
+			Name:        "PostgreSQL",
+			SqlSettings: storetest.MakeSqlSettings(model.DATABASE_DRIVER_POSTGRES),
+		})
+	}
+	if storetest.TestDriverEnabled(model.DATABASE_DRIVER_SQLITE) {
+		storeTypes = append(storeTypes, &storeType{
+			Name:        "SQLite",
+			SqlSettings: storetest.MakeSqlSettings(model.DATABASE_DRIVER_SQLITE),
+		})
+	}
 
 	defer func() {
 		if err := recover(); err != nil {
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	sqltrace "log"
+	"net/url"
 	"os"
 	"strings"
 	"sync/atomic"
@@ -65,8 +66,19 @@ const (
 	EXIT_REMOVE_INDEX_SQLITE         = 136
 	EXIT_TABLE_EXISTS_SQLITE         = 137
 	EXIT_DOES_COLUMN_EXISTS_SQLITE   = 138
+	EXIT_CREATE_COLUMN_SQLITE        = 139
+	EXIT_INDEX_EXISTS                = 140
 )
 
+// sqliteConnectionParams are the connection parameters SQLite needs to be shared by concurrent
+// connections: writers wait on each other instead of failing, readers don't block writers, and
+// transactions take the write lock upfront instead of failing to upgrade a read lock.
+var sqliteConnectionParams = map[string]string{
+	"_busy_timeout": "10000",
+	"_journal_mode": "WAL",
+	"_txlock":       "immediate",
+}
+
 type SqlSupplierOldStores struct {
 	team                 store.TeamStore
 	channel              store.ChannelStore
@@ -99,6 +111,11 @@ type SqlSupplierOldStores struct {
 	group                store.GroupStore
 	UserTermsOfService   store.UserTermsOfServiceStore
 	linkMetadata         store.LinkMetadataStore
+	postPriority         store.PostPriorityStore
+	postAcknowledgement  store.PostAcknowledgementStore
+	pluginConfigRevision store.PluginConfigRevisionStore
+	postShard            store.PostShardStore
+	auditLog             store.AuditLogStore
 }
 
 type SqlSupplier struct {
@@ -113,9 +130,33 @@ type SqlSupplier struct {
 	oldStores      SqlSupplierOldStores
 	settings       *model.SqlSettings
 	lockedToMaster bool
+	replicaMonitor *replicaMonitor
+	masterOnly     *SqlSupplier
+	readFromMaster bool
+
+	// conns maps the connections of the supplier to the copies of them used instead by a view of
+	// it, such as one returned by WithQueryTimeout.
+	conns map[*gorp.DbMap]*gorp.DbMap
+	// queryTimeoutViews holds the views returned by WithQueryTimeout, one for each of the query
+	// timeouts overridden in the settings.
+	queryTimeoutViews map[time.Duration]*SqlSupplier
 }
 
 func NewSqlSupplier(settings model.SqlSettings, metrics einterfaces.MetricsInterface) *SqlSupplier {
+	supplier := OpenSqlSupplier(settings, metrics)
+
+	if _, err := supplier.Migrate(); err != nil {
+		mlog.Critical("Failed to upgrade database.", mlog.Err(err))
+		time.Sleep(time.Second)
+		os.Exit(EXIT_GENERIC_FAILURE)
+	}
+
+	return supplier
+}
+
+// OpenSqlSupplier connects to the database without creating or upgrading its schema, for the
+// tools managing migrations themselves.
+func OpenSqlSupplier(settings model.SqlSettings, metrics einterfaces.MetricsInterface) *SqlSupplier {
 	supplier := &SqlSupplier{
 		rrCounter: 0,
 		srCounter: 0,
@@ -123,83 +164,186 @@ func NewSqlSupplier(settings model.SqlSettings, metrics einterfaces.MetricsInter
 	}
 
 	supplier.initConnection()
+	supplier.initReplicaMonitor()
+	supplier.initStores(metrics)
+	supplier.initMasterOnly(metrics)
+	supplier.initQueryTimeoutViews(metrics)
 
-	supplier.oldStores.team = NewSqlTeamStore(supplier, metrics)
-	supplier.oldStores.channel = NewSqlChannelStore(supplier, metrics)
-	supplier.oldStores.post = NewSqlPostStore(supplier, metrics)
-	supplier.oldStores.user = NewSqlUserStore(supplier, metrics)
-	supplier.oldStores.bot = NewSqlBotStore(supplier, metrics)
-	supplier.oldStores.audit = NewSqlAuditStore(supplier)
-	supplier.oldStores.cluster = NewSqlClusterDiscoveryStore(supplier)
-	supplier.oldStores.compliance = NewSqlComplianceStore(supplier)
-	supplier.oldStores.session = NewSqlSessionStore(supplier)
-	supplier.oldStores.oauth = NewSqlOAuthStore(supplier)
-	supplier.oldStores.system = NewSqlSystemStore(supplier)
-	supplier.oldStores.webhook = NewSqlWebhookStore(supplier, metrics)
-	supplier.oldStores.command = NewSqlCommandStore(supplier)
-	supplier.oldStores.commandWebhook = NewSqlCommandWebhookStore(supplier)
-	supplier.oldStores.preference = NewSqlPreferenceStore(supplier)
-	supplier.oldStores.license = NewSqlLicenseStore(supplier)
-	supplier.oldStores.token = NewSqlTokenStore(supplier)
-	supplier.oldStores.emoji = NewSqlEmojiStore(supplier, metrics)
-	supplier.oldStores.status = NewSqlStatusStore(supplier)
-	supplier.oldStores.fileInfo = NewSqlFileInfoStore(supplier, metrics)
-	supplier.oldStores.job = NewSqlJobStore(supplier)
-	supplier.oldStores.userAccessToken = NewSqlUserAccessTokenStore(supplier)
-	supplier.oldStores.channelMemberHistory = NewSqlChannelMemberHistoryStore(supplier)
-	supplier.oldStores.plugin = NewSqlPluginStore(supplier)
-	supplier.oldStores.TermsOfService = NewSqlTermsOfServiceStore(supplier, metrics)
-	supplier.oldStores.UserTermsOfService = NewSqlUserTermsOfServiceStore(supplier)
-	supplier.oldStores.linkMetadata = NewSqlLinkMetadataStore(supplier)
-	supplier.oldStores.reaction = NewSqlReactionStore(supplier)
-	supplier.oldStores.role = NewSqlRoleStore(supplier)
-	supplier.oldStores.scheme = NewSqlSchemeStore(supplier)
-	supplier.oldStores.group = NewSqlGroupStore(supplier)
-
-	err := supplier.GetMaster().CreateTablesIfNotExists()
-	if err != nil {
-		mlog.Critical("Error creating database tables.", mlog.Err(err))
-		time.Sleep(time.Second)
-		os.Exit(EXIT_CREATE_TABLE)
+	return supplier
+}
+
+func (ss *SqlSupplier) createIndexesIfNotExists() {
+	ss.oldStores.team.(*SqlTeamStore).CreateIndexesIfNotExists()
+	ss.oldStores.channel.(*SqlChannelStore).CreateIndexesIfNotExists()
+	ss.oldStores.post.(*SqlPostStore).CreateIndexesIfNotExists()
+	ss.oldStores.user.(*SqlUserStore).CreateIndexesIfNotExists()
+	ss.oldStores.bot.(*SqlBotStore).CreateIndexesIfNotExists()
+	ss.oldStores.audit.(*SqlAuditStore).CreateIndexesIfNotExists()
+	ss.oldStores.compliance.(*SqlComplianceStore).CreateIndexesIfNotExists()
+	ss.oldStores.session.(*SqlSessionStore).CreateIndexesIfNotExists()
+	ss.oldStores.oauth.(*SqlOAuthStore).CreateIndexesIfNotExists()
+	ss.oldStores.system.(*SqlSystemStore).CreateIndexesIfNotExists()
+	ss.oldStores.webhook.(*SqlWebhookStore).CreateIndexesIfNotExists()
+	ss.oldStores.command.(*SqlCommandStore).CreateIndexesIfNotExists()
+	ss.oldStores.commandWebhook.(*SqlCommandWebhookStore).CreateIndexesIfNotExists()
+	ss.oldStores.preference.(*SqlPreferenceStore).CreateIndexesIfNotExists()
+	ss.oldStores.license.(*SqlLicenseStore).CreateIndexesIfNotExists()
+	ss.oldStores.token.(*SqlTokenStore).CreateIndexesIfNotExists()
+	ss.oldStores.emoji.(*SqlEmojiStore).CreateIndexesIfNotExists()
+	ss.oldStores.status.(*SqlStatusStore).CreateIndexesIfNotExists()
+	ss.oldStores.fileInfo.(*SqlFileInfoStore).CreateIndexesIfNotExists()
+	ss.oldStores.job.(*SqlJobStore).CreateIndexesIfNotExists()
+	ss.oldStores.userAccessToken.(*SqlUserAccessTokenStore).CreateIndexesIfNotExists()
+	ss.oldStores.plugin.(*SqlPluginStore).CreateIndexesIfNotExists()
+	ss.oldStores.TermsOfService.(SqlTermsOfServiceStore).CreateIndexesIfNotExists()
+	ss.oldStores.UserTermsOfService.(SqlUserTermsOfServiceStore).CreateIndexesIfNotExists()
+	ss.oldStores.linkMetadata.(*SqlLinkMetadataStore).CreateIndexesIfNotExists()
+	ss.oldStores.postPriority.(*SqlPostPriorityStore).CreateIndexesIfNotExists()
+	ss.oldStores.postAcknowledgement.(*SqlPostAcknowledgementStore).CreateIndexesIfNotExists()
+	ss.oldStores.pluginConfigRevision.(*SqlPluginConfigRevisionStore).CreateIndexesIfNotExists()
+	ss.oldStores.postShard.(*SqlPostShardStore).CreateIndexesIfNotExists()
+	ss.oldStores.auditLog.(*SqlAuditLogStore).CreateIndexesIfNotExists()
+	ss.oldStores.group.(*SqlGroupStore).CreateIndexesIfNotExists()
+}
+
+func (s *SqlSupplier) initStores(metrics einterfaces.MetricsInterface) {
+	s.oldStores.team = NewSqlTeamStore(s, metrics)
+	s.oldStores.channel = NewSqlChannelStore(s, metrics)
+	s.oldStores.post = NewSqlPostStore(s, metrics)
+	s.oldStores.user = NewSqlUserStore(s, metrics)
+	s.oldStores.bot = NewSqlBotStore(s, metrics)
+	s.oldStores.audit = NewSqlAuditStore(s)
+	s.oldStores.cluster = NewSqlClusterDiscoveryStore(s)
+	s.oldStores.compliance = NewSqlComplianceStore(s)
+	s.oldStores.session = NewSqlSessionStore(s)
+	s.oldStores.oauth = NewSqlOAuthStore(s)
+	s.oldStores.system = NewSqlSystemStore(s)
+	s.oldStores.webhook = NewSqlWebhookStore(s, metrics)
+	s.oldStores.command = NewSqlCommandStore(s)
+	s.oldStores.commandWebhook = NewSqlCommandWebhookStore(s)
+	s.oldStores.preference = NewSqlPreferenceStore(s)
+	s.oldStores.license = NewSqlLicenseStore(s)
+	s.oldStores.token = NewSqlTokenStore(s)
+	s.oldStores.emoji = NewSqlEmojiStore(s, metrics)
+	s.oldStores.status = NewSqlStatusStore(s)
+	s.oldStores.fileInfo = NewSqlFileInfoStore(s, metrics)
+	s.oldStores.job = NewSqlJobStore(s)
+	s.oldStores.userAccessToken = NewSqlUserAccessTokenStore(s)
+	s.oldStores.channelMemberHistory = NewSqlChannelMemberHistoryStore(s)
+	s.oldStores.plugin = NewSqlPluginStore(s)
+	s.oldStores.TermsOfService = NewSqlTermsOfServiceStore(s, metrics)
+	s.oldStores.UserTermsOfService = NewSqlUserTermsOfServiceStore(s)
+	s.oldStores.linkMetadata = NewSqlLinkMetadataStore(s)
+	s.oldStores.postPriority = NewSqlPostPriorityStore(s)
+	s.oldStores.postAcknowledgement = NewSqlPostAcknowledgementStore(s)
+	s.oldStores.pluginConfigRevision = NewSqlPluginConfigRevisionStore(s)
+	s.oldStores.postShard = NewSqlPostShardStore(s)
+	s.oldStores.auditLog = NewSqlAuditLogStore(s)
+	s.oldStores.reaction = NewSqlReactionStore(s)
+	s.oldStores.role = NewSqlRoleStore(s)
+	s.oldStores.scheme = NewSqlSchemeStore(s)
+	s.oldStores.group = NewSqlGroupStore(s)
+}
+
+func (s *SqlSupplier) initReplicaMonitor() {
+	if len(s.replicas) == 0 {
+		return
 	}
 
-	err = UpgradeDatabase(supplier, model.CurrentVersion)
-	if err != nil {
-		mlog.Critical("Failed to upgrade database.", mlog.Err(err))
-		time.Sleep(time.Second)
-		os.Exit(EXIT_GENERIC_FAILURE)
+	threshold := model.SQL_SETTINGS_DEFAULT_REPLICA_LAG_THRESHOLD_SECONDS
+	if s.settings.ReplicaLagThresholdSeconds != nil {
+		threshold = *s.settings.ReplicaLagThresholdSeconds
 	}
 
-	supplier.oldStores.team.(*SqlTeamStore).CreateIndexesIfNotExists()
-	supplier.oldStores.channel.(*SqlChannelStore).CreateIndexesIfNotExists()
-	supplier.oldStores.post.(*SqlPostStore).CreateIndexesIfNotExists()
-	supplier.oldStores.user.(*SqlUserStore).CreateIndexesIfNotExists()
-	supplier.oldStores.bot.(*SqlBotStore).CreateIndexesIfNotExists()
-	supplier.oldStores.audit.(*SqlAuditStore).CreateIndexesIfNotExists()
-	supplier.oldStores.compliance.(*SqlComplianceStore).CreateIndexesIfNotExists()
-	supplier.oldStores.session.(*SqlSessionStore).CreateIndexesIfNotExists()
-	supplier.oldStores.oauth.(*SqlOAuthStore).CreateIndexesIfNotExists()
-	supplier.oldStores.system.(*SqlSystemStore).CreateIndexesIfNotExists()
-	supplier.oldStores.webhook.(*SqlWebhookStore).CreateIndexesIfNotExists()
-	supplier.oldStores.command.(*SqlCommandStore).CreateIndexesIfNotExists()
-	supplier.oldStores.commandWebhook.(*SqlCommandWebhookStore).CreateIndexesIfNotExists()
-	supplier.oldStores.preference.(*SqlPreferenceStore).CreateIndexesIfNotExists()
-	supplier.oldStores.license.(*SqlLicenseStore).CreateIndexesIfNotExists()
-	supplier.oldStores.token.(*SqlTokenStore).CreateIndexesIfNotExists()
-	supplier.oldStores.emoji.(*SqlEmojiStore).CreateIndexesIfNotExists()
-	supplier.oldStores.status.(*SqlStatusStore).CreateIndexesIfNotExists()
-	supplier.oldStores.fileInfo.(*SqlFileInfoStore).CreateIndexesIfNotExists()
-	supplier.oldStores.job.(*SqlJobStore).CreateIndexesIfNotExists()
-	supplier.oldStores.userAccessToken.(*SqlUserAccessTokenStore).CreateIndexesIfNotExists()
-	supplier.oldStores.plugin.(*SqlPluginStore).CreateIndexesIfNotExists()
-	supplier.oldStores.TermsOfService.(SqlTermsOfServiceStore).CreateIndexesIfNotExists()
-	supplier.oldStores.UserTermsOfService.(SqlUserTermsOfServiceStore).CreateIndexesIfNotExists()
-	supplier.oldStores.linkMetadata.(*SqlLinkMetadataStore).CreateIndexesIfNotExists()
-	supplier.oldStores.group.(*SqlGroupStore).CreateIndexesIfNotExists()
-
-	supplier.oldStores.preference.(*SqlPreferenceStore).DeleteUnusedFeatures()
+	s.replicaMonitor = newReplicaMonitor(s.replicas, s.replicaLag, threshold)
 
-	return supplier
+	if s.settings.ReplicaLagCheckIntervalSeconds != nil && *s.settings.ReplicaLagCheckIntervalSeconds > 0 {
+		s.replicaMonitor.start(time.Duration(*s.settings.ReplicaLagCheckIntervalSeconds) * time.Second)
+	}
+}
+
+// initMasterOnly prepares the view returned by MasterOnly. It shares the connections of this
+// supplier but has its own stores, whose reads all go to the master.
+func (s *SqlSupplier) initMasterOnly(metrics einterfaces.MetricsInterface) {
+	if len(s.replicas) == 0 && len(s.searchReplicas) == 0 {
+		s.masterOnly = s
+		return
+	}
+
+	s.masterOnly = &SqlSupplier{
+		master:         s.master,
+		replicas:       s.replicas,
+		searchReplicas: s.searchReplicas,
+		settings:       s.settings,
+		replicaMonitor: s.replicaMonitor,
+		readFromMaster: true,
+	}
+	s.masterOnly.masterOnly = s.masterOnly
+	s.masterOnly.initStores(metrics)
+}
+
+// initQueryTimeoutViews prepares the views returned by WithQueryTimeout. Like the master only
+// view, they share the connections of this supplier, copied to apply their timeout.
+func (s *SqlSupplier) initQueryTimeoutViews(metrics einterfaces.MetricsInterface) {
+	for _, seconds := range s.settings.QueryTimeoutOverrides {
+		timeout := time.Duration(seconds) * time.Second
+		if _, ok := s.queryTimeoutViews[timeout]; ok {
+			continue
+		}
+
+		view := s.newQueryTimeoutView(timeout, metrics)
+		if s.masterOnly == s {
+			view.masterOnly = view
+		} else {
+			view.masterOnly = s.masterOnly.newQueryTimeoutView(timeout, metrics)
+			view.masterOnly.masterOnly = view.masterOnly
+			s.masterOnly.queryTimeoutViews[timeout] = view.masterOnly
+		}
+		s.queryTimeoutViews[timeout] = view
+	}
+}
+
+func (s *SqlSupplier) newQueryTimeoutView(timeout time.Duration, metrics einterfaces.MetricsInterface) *SqlSupplier {
+	if s.queryTimeoutViews == nil {
+		s.queryTimeoutViews = make(map[time.Duration]*SqlSupplier)
+	}
+
+	view := &SqlSupplier{
+		master:         s.master,
+		replicas:       s.replicas,
+		searchReplicas: s.searchReplicas,
+		settings:       s.settings,
+		replicaMonitor: s.replicaMonitor,
+		readFromMaster: s.readFromMaster,
+		conns:          make(map[*gorp.DbMap]*gorp.DbMap),
+	}
+	for _, dbmap := range append(s.GetAllConns(), s.searchReplicas...) {
+		conn := *dbmap
+		conn.QueryTimeout = timeout
+		view.conns[dbmap] = &conn
+	}
+	view.initStores(metrics)
+
+	return view
+}
+
+// WithQueryTimeout returns a view of this supplier whose queries time out after the given duration,
+// if it is one of the query timeouts overridden in the settings, or this supplier otherwise.
+func (ss *SqlSupplier) WithQueryTimeout(timeout time.Duration) store.Store {
+	if view, ok := ss.queryTimeoutViews[timeout]; ok {
+		return view
+	}
+
+	return ss
+}
+
+// conn returns the connection to use in place of the given one.
+func (ss *SqlSupplier) conn(dbmap *gorp.DbMap) *gorp.DbMap {
+	if conn, ok := ss.conns[dbmap]; ok {
+		return conn
+	}
+
+	return dbmap
 }
 
 func (s *SqlSupplier) SetChainNext(next store.LayeredStoreSupplier) {
@@ -210,7 +354,39 @@ func (s *SqlSupplier) Next() store.LayeredStoreSupplier {
 	return s.next
 }
 
+// sqliteDataSource applies the default SQLite connection parameters to the given data source,
+// unless configured otherwise.
+func sqliteDataSource(dataSource string) string {
+	parts := strings.SplitN(dataSource, "?", 2)
+
+	params := url.Values{}
+	if len(parts) == 2 {
+		var err error
+		if params, err = url.ParseQuery(parts[1]); err != nil {
+			// Leave the data source for the driver to reject.
+			return dataSource
+		}
+	}
+
+	for key, value := range sqliteConnectionParams {
+		if params.Get(key) == "" {
+			params.Set(key, value)
+		}
+	}
+
+	return parts[0] + "?" + params.Encode()
+}
+
 func setupConnection(con_type string, dataSource string, settings *model.SqlSettings) *gorp.DbMap {
+	if *settings.DriverName == model.DATABASE_DRIVER_SQLITE {
+		if !sqliteSupported {
+			mlog.Critical("SQLite is not supported by this build, it must be built with cgo and the sqlite build tag.")
+			time.Sleep(time.Second)
+			os.Exit(EXIT_NO_DRIVER)
+		}
+		dataSource = sqliteDataSource(dataSource)
+	}
+
 	db, err := dbsql.Open(*settings.DriverName, dataSource)
 	if err != nil {
 		mlog.Critical("Failed to open SQL connection to err.", mlog.Err(err))
@@ -257,8 +433,13 @@ func setupConnection(con_type string, dataSource string, settings *model.SqlSett
 		os.Exit(EXIT_NO_DRIVER)
 	}
 
+	var trace gorp.GorpLogger
 	if settings.Trace != nil && *settings.Trace {
-		dbmap.TraceOn("", sqltrace.New(os.Stdout, "sql-trace:", sqltrace.Lmicroseconds))
+		trace = sqltrace.New(os.Stdout, "sql-trace:", sqltrace.Lmicroseconds)
+	}
+
+	if logger := newQueryLogger(settings, trace); logger != nil {
+		dbmap.TraceOn("", logger)
 	}
 
 	return dbmap
@@ -282,6 +463,11 @@ func (s *SqlSupplier) initConnection() {
 	}
 }
 
+// OnlineMigrations reports whether schema changes to existing tables should avoid blocking writes.
+func (ss *SqlSupplier) OnlineMigrations() bool {
+	return ss.settings.OnlineMigrations != nil && *ss.settings.OnlineMigrations
+}
+
 func (ss *SqlSupplier) DriverName() string {
 	return *ss.settings.DriverName
 }
@@ -292,25 +478,45 @@ func (ss *SqlSupplier) GetCurrentSchemaVersion() string {
 }
 
 func (ss *SqlSupplier) GetMaster() *gorp.DbMap {
-	return ss.master
+	return ss.conn(ss.master)
 }
 
 func (ss *SqlSupplier) GetSearchReplica() *gorp.DbMap {
-	if len(ss.settings.DataSourceSearchReplicas) == 0 {
+	if len(ss.settings.DataSourceSearchReplicas) == 0 || ss.readFromMaster {
 		return ss.GetReplica()
 	}
 
 	rrNum := atomic.AddInt64(&ss.srCounter, 1) % int64(len(ss.searchReplicas))
-	return ss.searchReplicas[rrNum]
+	return ss.conn(ss.searchReplicas[rrNum])
 }
 
+// GetReplica returns the next replica eligible for reads, falling back to the master when none
+// is or when reads are locked to the master.
 func (ss *SqlSupplier) GetReplica() *gorp.DbMap {
-	if len(ss.settings.DataSourceReplicas) == 0 || ss.lockedToMaster {
+	if len(ss.settings.DataSourceReplicas) == 0 || ss.lockedToMaster || ss.readFromMaster {
 		return ss.GetMaster()
 	}
 
-	rrNum := atomic.AddInt64(&ss.rrCounter, 1) % int64(len(ss.replicas))
-	return ss.replicas[rrNum]
+	replicas := ss.replicaMonitor.healthyReplicas()
+	if len(replicas) == 0 {
+		return ss.GetMaster()
+	}
+
+	rrNum := atomic.AddInt64(&ss.rrCounter, 1) % int64(len(replicas))
+	return ss.conn(replicas[rrNum])
+}
+
+// MasterOnly returns a view of this supplier whose reads, including searches, all go to the master.
+func (ss *SqlSupplier) MasterOnly() store.Store {
+	return ss.masterOnly
+}
+
+func (ss *SqlSupplier) ReplicaStatuses() []*model.ReplicaStatus {
+	if ss.replicaMonitor == nil {
+		return nil
+	}
+
+	return ss.replicaMonitor.replicaStatuses()
 }
 
 func (ss *SqlSupplier) TotalMasterDbConnections() int {
@@ -395,7 +601,7 @@ func (ss *SqlSupplier) DoesTableExist(tableName string) bool {
 
 	} else if ss.DriverName() == model.DATABASE_DRIVER_SQLITE {
 		count, err := ss.GetMaster().SelectInt(
-			`SELECT name FROM sqlite_master WHERE type='table' AND name=?`,
+			`SELECT COUNT(0) FROM sqlite_master WHERE type='table' AND name=?`,
 			tableName,
 		)
 
@@ -523,6 +729,20 @@ func (ss *SqlSupplier) DoesTriggerExist(triggerName string) bool {
 
 		return count > 0
 
+	} else if ss.DriverName() == model.DATABASE_DRIVER_SQLITE {
+		count, err := ss.GetMaster().SelectInt(
+			`SELECT COUNT(0) FROM sqlite_master WHERE type='trigger' AND name=?`,
+			triggerName,
+		)
+
+		if err != nil {
+			mlog.Critical("Failed to check if trigger exists", mlog.Err(err))
+			time.Sleep(time.Second)
+			os.Exit(EXIT_GENERIC_FAILURE)
+		}
+
+		return count > 0
+
 	} else {
 		mlog.Critical("Failed to check if column exists because of missing driver")
 		time.Sleep(time.Second)
@@ -557,6 +777,17 @@ func (ss *SqlSupplier) CreateColumnIfNotExists(tableName string, columnName stri
 
 		return true
 
+	} else if ss.DriverName() == model.DATABASE_DRIVER_SQLITE {
+		// SQLite accepts the MySQL column types, mapping them to its own type affinities.
+		_, err := ss.GetMaster().ExecNoTimeout("ALTER TABLE " + tableName + " ADD " + columnName + " " + mySqlColType + " DEFAULT '" + defaultValue + "'")
+		if err != nil {
+			mlog.Critical("Failed to create column", mlog.Err(err))
+			time.Sleep(time.Second)
+			os.Exit(EXIT_CREATE_COLUMN_SQLITE)
+		}
+
+		return true
+
 	} else {
 		mlog.Critical("Failed to create column because of missing driver")
 		time.Sleep(time.Second)
@@ -591,6 +822,16 @@ func (ss *SqlSupplier) CreateColumnIfNotExistsNoDefault(tableName string, column
 
 		return true
 
+	} else if ss.DriverName() == model.DATABASE_DRIVER_SQLITE {
+		_, err := ss.GetMaster().ExecNoTimeout("ALTER TABLE " + tableName + " ADD " + columnName + " " + mySqlColType)
+		if err != nil {
+			mlog.Critical("Failed to create column", mlog.Err(err))
+			time.Sleep(time.Second)
+			os.Exit(EXIT_CREATE_COLUMN_SQLITE)
+		}
+
+		return true
+
 	} else {
 		mlog.Critical("Failed to create column because of missing driver")
 		time.Sleep(time.Second)
@@ -638,7 +879,7 @@ func (ss *SqlSupplier) RenameColumnIfExists(tableName string, oldColumnName stri
 	var err error
 	if ss.DriverName() == model.DATABASE_DRIVER_MYSQL {
 		_, err = ss.GetMaster().ExecNoTimeout("ALTER TABLE " + tableName + " CHANGE " + oldColumnName + " " + newColumnName + " " + colType)
-	} else if ss.DriverName() == model.DATABASE_DRIVER_POSTGRES {
+	} else if ss.DriverName() == model.DATABASE_DRIVER_POSTGRES || ss.DriverName() == model.DATABASE_DRIVER_SQLITE {
 		_, err = ss.GetMaster().ExecNoTimeout("ALTER TABLE " + tableName + " RENAME COLUMN " + oldColumnName + " TO " + newColumnName)
 	}
 
@@ -662,6 +903,13 @@ func (ss *SqlSupplier) GetMaxLengthOfColumnIfExists(tableName string, columnName
 		result, err = ss.GetMaster().SelectStr("SELECT CHARACTER_MAXIMUM_LENGTH FROM information_schema.columns WHERE table_name = '" + tableName + "' AND COLUMN_NAME = '" + columnName + "'")
 	} else if ss.DriverName() == model.DATABASE_DRIVER_POSTGRES {
 		result, err = ss.GetMaster().SelectStr("SELECT character_maximum_length FROM information_schema.columns WHERE table_name = '" + strings.ToLower(tableName) + "' AND column_name = '" + strings.ToLower(columnName) + "'")
+	} else if ss.DriverName() == model.DATABASE_DRIVER_SQLITE {
+		// SQLite doesn't enforce the length of a column, but reports the declared type, e.g. varchar(64).
+		var colType string
+		colType, err = ss.GetMaster().SelectStr("SELECT type FROM pragma_table_info(?) WHERE name = ?", tableName, columnName)
+		if start, end := strings.Index(colType, "("), strings.Index(colType, ")"); start >= 0 && end > start {
+			result = colType[start+1 : end]
+		}
 	}
 
 	if err != nil {
@@ -683,6 +931,10 @@ func (ss *SqlSupplier) AlterColumnTypeIfExists(tableName string, columnName stri
 		_, err = ss.GetMaster().ExecNoTimeout("ALTER TABLE " + tableName + " MODIFY " + columnName + " " + mySqlColType)
 	} else if ss.DriverName() == model.DATABASE_DRIVER_POSTGRES {
 		_, err = ss.GetMaster().ExecNoTimeout("ALTER TABLE " + strings.ToLower(tableName) + " ALTER COLUMN " + strings.ToLower(columnName) + " TYPE " + postgresColType)
+	} else if ss.DriverName() == model.DATABASE_DRIVER_SQLITE {
+		// SQLite can't alter the type of a column, but the declared type is only used to
+		// determine the affinity of the values stored, which never needs to change.
+		return true
 	}
 
 	if err != nil {
@@ -771,7 +1023,17 @@ func (ss *SqlSupplier) createIndexIfNotExists(indexName string, tableName string
 		_, errExists := ss.GetMaster().SelectStr("SELECT $1::regclass", indexName)
 		// It should fail if the index does not exist
 		if errExists == nil {
-			return false
+			if !ss.OnlineMigrations() || !ss.isInvalidPostgresIndex(indexName) {
+				return false
+			}
+
+			// An interrupted concurrent build leaves an invalid index behind, which is never used.
+			mlog.Warn("Rebuilding an index left invalid by an interrupted build.", mlog.String("index", indexName))
+			if _, err := ss.GetMaster().ExecNoTimeout("DROP INDEX CONCURRENTLY " + indexName); err != nil {
+				mlog.Critical("Failed to remove invalid index", mlog.Err(err))
+				time.Sleep(time.Second)
+				os.Exit(EXIT_CREATE_INDEX_POSTGRES)
+			}
 		}
 
 		query := ""
@@ -782,9 +1044,13 @@ func (ss *SqlSupplier) createIndexIfNotExists(indexName string, tableName string
 			}
 			columnName := columnNames[0]
 			postgresColumnNames := convertMySQLFullTextColumnsToPostgres(columnName)
-			query = "CREATE INDEX " + indexName + " ON " + tableName + " USING gin(to_tsvector('english', " + postgresColumnNames + "))"
+			concurrently := ""
+			if ss.OnlineMigrations() {
+				concurrently = "CONCURRENTLY "
+			}
+			query = "CREATE INDEX " + concurrently + indexName + " ON " + tableName + " USING gin(to_tsvector('english', " + postgresColumnNames + "))"
 		} else {
-			query = "CREATE " + uniqueStr + "INDEX " + indexName + " ON " + tableName + " (" + strings.Join(columnNames, ", ") + ")"
+			query = createIndexQuery(ss.DriverName(), indexName, tableName, columnNames, unique, ss.OnlineMigrations())
 		}
 
 		_, err := ss.GetMaster().ExecNoTimeout(query)
@@ -806,19 +1072,35 @@ func (ss *SqlSupplier) createIndexIfNotExists(indexName string, tableName string
 			return false
 		}
 
-		fullTextIndex := ""
+		// InnoDB can't build full text indexes without blocking writes, so those are never online.
+		query := createIndexQuery(ss.DriverName(), indexName, tableName, columnNames, unique, ss.OnlineMigrations())
 		if indexType == INDEX_TYPE_FULL_TEXT {
-			fullTextIndex = " FULLTEXT "
+			query = "CREATE  " + uniqueStr + " FULLTEXT  INDEX " + indexName + " ON " + tableName + " (" + strings.Join(columnNames, ", ") + ")"
 		}
 
-		_, err = ss.GetMaster().ExecNoTimeout("CREATE  " + uniqueStr + fullTextIndex + " INDEX " + indexName + " ON " + tableName + " (" + strings.Join(columnNames, ", ") + ")")
+		_, err = ss.GetMaster().ExecNoTimeout(query)
 		if err != nil {
 			mlog.Critical("Failed to create index", mlog.Err(err))
 			time.Sleep(time.Second)
 			os.Exit(EXIT_CREATE_INDEX_FULL_MYSQL)
 		}
 	} else if ss.DriverName() == model.DATABASE_DRIVER_SQLITE {
-		_, err := ss.GetMaster().ExecNoTimeout("CREATE INDEX IF NOT EXISTS " + indexName + " ON " + tableName + " (" + strings.Join(columnNames, ", ") + ")")
+		count, err := ss.GetMaster().SelectInt("SELECT COUNT(0) FROM sqlite_master WHERE name = ?", indexName)
+		if err != nil {
+			mlog.Critical("Failed to check index", mlog.Err(err))
+			time.Sleep(time.Second)
+			os.Exit(EXIT_CREATE_INDEX_SQLITE)
+		}
+
+		if count > 0 {
+			return false
+		}
+
+		if indexType == INDEX_TYPE_FULL_TEXT {
+			err = ss.createSqliteFullTextIndex(indexName, tableName, columnNames)
+		} else {
+			_, err = ss.GetMaster().ExecNoTimeout(createIndexQuery(ss.DriverName(), indexName, tableName, columnNames, unique, false))
+		}
 		if err != nil {
 			mlog.Critical("Failed to create index", mlog.Err(err))
 			time.Sleep(time.Second)
@@ -833,6 +1115,39 @@ func (ss *SqlSupplier) createIndexIfNotExists(indexName string, tableName string
 	return true
 }
 
+// createIndexQuery returns the statement creating a regular index. Online, the index is built
+// without blocking writes to the table, at the cost of a slower build.
+func createIndexQuery(driverName, indexName, tableName string, columnNames []string, unique bool, online bool) string {
+	uniqueStr := ""
+	if unique {
+		uniqueStr = "UNIQUE "
+	}
+
+	query := "CREATE " + uniqueStr + "INDEX "
+	if online && driverName == model.DATABASE_DRIVER_POSTGRES {
+		query += "CONCURRENTLY "
+	}
+	query += indexName + " ON " + tableName + " (" + strings.Join(columnNames, ", ") + ")"
+	if online && driverName == model.DATABASE_DRIVER_MYSQL {
+		query += " ALGORITHM=INPLACE LOCK=NONE"
+	}
+
+	return query
+}
+
+// isInvalidPostgresIndex reports whether the index exists but was left invalid by a failed
+// concurrent build.
+func (ss *SqlSupplier) isInvalidPostgresIndex(indexName string) bool {
+	count, err := ss.GetMaster().SelectInt("SELECT COUNT(0) FROM pg_index WHERE indexrelid = $1::regclass AND NOT indisvalid", indexName)
+	if err != nil {
+		mlog.Critical("Failed to check index", mlog.Err(err))
+		time.Sleep(time.Second)
+		os.Exit(EXIT_CREATE_INDEX_POSTGRES)
+	}
+
+	return count > 0
+}
+
 func (ss *SqlSupplier) RemoveIndexIfExists(indexName string, tableName string) bool {
 
 	if ss.DriverName() == model.DATABASE_DRIVER_POSTGRES {
@@ -870,7 +1185,30 @@ func (ss *SqlSupplier) RemoveIndexIfExists(indexName string, tableName string) b
 			os.Exit(EXIT_REMOVE_INDEX_MYSQL)
 		}
 	} else if ss.DriverName() == model.DATABASE_DRIVER_SQLITE {
-		_, err := ss.GetMaster().ExecNoTimeout("DROP INDEX IF EXISTS " + indexName)
+		indexType, err := ss.GetMaster().SelectNullStr("SELECT type FROM sqlite_master WHERE name = ?", indexName)
+		if err != nil {
+			mlog.Critical("Failed to check index", mlog.Err(err))
+			time.Sleep(time.Second)
+			os.Exit(EXIT_REMOVE_INDEX_SQLITE)
+		}
+
+		if !indexType.Valid {
+			return false
+		}
+
+		if indexType.String == "table" {
+			// Full text indexes are virtual tables kept up to date by triggers.
+			for _, suffix := range sqliteFullTextTriggerSuffixes {
+				if _, err = ss.GetMaster().ExecNoTimeout("DROP TRIGGER IF EXISTS " + indexName + suffix); err != nil {
+					break
+				}
+			}
+			if err == nil {
+				_, err = ss.GetMaster().ExecNoTimeout("DROP TABLE " + indexName)
+			}
+		} else {
+			_, err = ss.GetMaster().ExecNoTimeout("DROP INDEX " + indexName)
+		}
 		if err != nil {
 			mlog.Critical("Failed to remove index", mlog.Err(err))
 			time.Sleep(time.Second)
@@ -885,6 +1223,49 @@ func (ss *SqlSupplier) RemoveIndexIfExists(indexName string, tableName string) b
 	return true
 }
 
+var sqliteFullTextTriggerSuffixes = []string{"_insert", "_update", "_delete"}
+
+// createSqliteFullTextIndex indexes the given columns of a table in an FTS5 virtual table of the
+// same name as the index, kept up to date with the table by triggers. The virtual table is
+// searched by matching it against the rowid of the table, e.g.
+//
+//	rowid IN (SELECT rowid FROM idx_posts_message_txt WHERE idx_posts_message_txt MATCH ?)
+func (ss *SqlSupplier) createSqliteFullTextIndex(indexName string, tableName string, columnNames []string) error {
+	// Full text indexes are created with the column names joined as one, as expected by MySQL.
+	var columns []string
+	for _, columnName := range columnNames {
+		columns = append(columns, strings.Split(columnName, ", ")...)
+	}
+
+	newColumns := make([]string, len(columns))
+	oldColumns := make([]string, len(columns))
+	for i, column := range columns {
+		newColumns[i] = "new." + column
+		oldColumns[i] = "old." + column
+	}
+
+	columnList := strings.Join(columns, ", ")
+	insert := "INSERT INTO " + indexName + " (rowid, " + columnList + ") VALUES (new.rowid, " + strings.Join(newColumns, ", ") + ");"
+	delete := "INSERT INTO " + indexName + " (" + indexName + ", rowid, " + columnList + ") VALUES ('delete', old.rowid, " + strings.Join(oldColumns, ", ") + ");"
+
+	queries := []string{
+		"CREATE VIRTUAL TABLE " + indexName + " USING fts5(" + columnList + ", content='" + tableName + "', content_rowid='rowid', tokenize='porter unicode61')",
+		"CREATE TRIGGER " + indexName + "_insert AFTER INSERT ON " + tableName + " BEGIN " + insert + " END",
+		"CREATE TRIGGER " + indexName + "_update AFTER UPDATE ON " + tableName + " BEGIN " + delete + " " + insert + " END",
+		"CREATE TRIGGER " + indexName + "_delete AFTER DELETE ON " + tableName + " BEGIN " + delete + " END",
+		// Index any existing rows.
+		"INSERT INTO " + indexName + " (" + indexName + ") VALUES ('rebuild')",
+	}
+
+	for _, query := range queries {
+		if _, err := ss.GetMaster().ExecNoTimeout(query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func IsUniqueConstraintError(err error, indexName []string) bool {
 	unique := false
 	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
@@ -895,12 +1276,24 @@ func IsUniqueConstraintError(err error, indexName []string) bool {
 		unique = true
 	}
 
+	sqliteUnique, primaryKey := sqliteConstraintError(err)
+	if sqliteUnique {
+		unique = true
+	}
+
 	field := false
 	for _, contain := range indexName {
 		if strings.Contains(err.Error(), contain) {
 			field = true
 			break
 		}
+
+		// SQLite names the columns rather than the index, so match primary keys by the names
+		// MySQL and PostgreSQL give them.
+		if primaryKey && (contain == "PRIMARY" || strings.HasSuffix(contain, "_pkey")) {
+			field = true
+			break
+		}
 	}
 
 	return unique && field
@@ -915,6 +1308,9 @@ func (ss *SqlSupplier) GetAllConns() []*gorp.DbMap {
 
 func (ss *SqlSupplier) Close() {
 	mlog.Info("Closing SqlStore")
+	if ss.replicaMonitor != nil {
+		ss.replicaMonitor.shutdown()
+	}
 	ss.master.Db.Close()
 	for _, replica := range ss.replicas {
 		replica.Db.Close()
@@ -1053,6 +1449,26 @@ func (ss *SqlSupplier) LinkMetadata() store.LinkMetadataStore {
 	return ss.oldStores.linkMetadata
 }
 
+func (ss *SqlSupplier) PostPriority() store.PostPriorityStore {
+	return ss.oldStores.postPriority
+}
+
+func (ss *SqlSupplier) PostAcknowledgement() store.PostAcknowledgementStore {
+	return ss.oldStores.postAcknowledgement
+}
+
+func (ss *SqlSupplier) PluginConfigRevision() store.PluginConfigRevisionStore {
+	return ss.oldStores.pluginConfigRevision
+}
+
+func (ss *SqlSupplier) PostShard() store.PostShardStore {
+	return ss.oldStores.postShard
+}
+
+func (ss *SqlSupplier) AuditLog() store.AuditLogStore {
+	return ss.oldStores.auditLog
+}
+
 func (ss *SqlSupplier) DropAllTables() {
 	ss.master.TruncateTables()
 }
@@ -1156,6 +1572,31 @@ type JSONSerializable interface {
 	ToJson() string
 }
 
+// unionQuery combines two SELECT queries with the given UNION operator. The queries are
+// parenthesized so each may have its own LIMIT, which SQLite only allows within subqueries.
+func unionQuery(driverName string, operator string, left string, right string) string {
+	if driverName == model.DATABASE_DRIVER_SQLITE {
+		return fmt.Sprintf("SELECT * FROM (%s) %s SELECT * FROM (%s)", left, operator, right)
+	}
+
+	return fmt.Sprintf("(%s) %s (%s)", left, operator, right)
+}
+
+// greatestFunction returns the name of the function returning the greatest of its arguments.
+func greatestFunction(driverName string) string {
+	if driverName == model.DATABASE_DRIVER_SQLITE {
+		return "MAX"
+	}
+
+	return "GREATEST"
+}
+
+// quoteSqliteFullTextTerm quotes a search term as an FTS5 string, so that any characters in it
+// are matched as text rather than parsed as query syntax.
+func quoteSqliteFullTextTerm(term string) string {
+	return `"` + strings.Replace(term, `"`, `""`, -1) + `"`
+}
+
 func convertMySQLFullTextColumnsToPostgres(columnNames string) string {
 	columns := strings.Split(columnNames, ", ")
 	concatenatedColumnNames := ""
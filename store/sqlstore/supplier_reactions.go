@@ -151,6 +151,8 @@ func (s *SqlReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int
 	var query string
 	if s.DriverName() == "postgres" {
 		query = "DELETE from Reactions WHERE CreateAt = any (array (SELECT CreateAt FROM Reactions WHERE CreateAt < :EndTime LIMIT :Limit))"
+	} else if s.DriverName() == model.DATABASE_DRIVER_SQLITE {
+		query = "DELETE FROM Reactions WHERE rowid IN (SELECT rowid FROM Reactions WHERE CreateAt < :EndTime LIMIT :Limit)"
 	} else {
 		query = "DELETE from Reactions WHERE CreateAt < :EndTime LIMIT :Limit"
 	}
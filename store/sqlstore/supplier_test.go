@@ -4,15 +4,19 @@ import (
 	"testing"
 
 	"github.com/mattermost/gorp"
-	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/store/sqlstore"
+	"github.com/mattermost/mattermost-server/store/storetest"
 )
 
 func TestGetReplica(t *testing.T) {
 	t.Parallel()
+	if !storetest.TestDriverEnabled(model.DATABASE_DRIVER_SQLITE) {
+		t.Skip("the replicas are tested as SQLite databases")
+	}
+
 	testCases := []struct {
 		Description              string
 		DataSourceReplicas       []string
@@ -141,6 +145,10 @@ func TestGetReplica(t *testing.T) {
 
 func TestGetAllConns(t *testing.T) {
 	t.Parallel()
+	if !storetest.TestDriverEnabled(model.DATABASE_DRIVER_SQLITE) {
+		t.Skip("the replicas are tested as SQLite databases")
+	}
+
 	testCases := []struct {
 		Description              string
 		DataSourceReplicas       []string
@@ -71,6 +71,28 @@ const (
 	EXIT_TEAM_INVITEID_MIGRATION_FAILED = 1006
 )
 
+// legacyUpgradesPending reports whether UpgradeDatabase would run any of the legacy upgrades, that
+// is whether the recorded schema version is behind the given model version. A fresh database has
+// none to run.
+func legacyUpgradesPending(sqlStore SqlStore, currentModelVersionString string) (bool, error) {
+	currentSchemaVersionString := sqlStore.GetCurrentSchemaVersion()
+	if currentSchemaVersionString == "" {
+		return false, nil
+	}
+
+	currentModelVersion, err := semver.Parse(currentModelVersionString)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse current model version %s", currentModelVersionString)
+	}
+
+	currentSchemaVersion, err := semver.Parse(currentSchemaVersionString)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse database schema version %s", currentSchemaVersionString)
+	}
+
+	return currentSchemaVersion.LT(currentModelVersion), nil
+}
+
 // UpgradeDatabase attempts to migrate the schema to the latest supported version.
 // The value of model.CurrentVersion is accepted as a parameter for unit testing, but it is not
 // used to stop migrations at that version.
@@ -303,12 +325,8 @@ func UpgradeDatabaseToVersion34(sqlStore SqlStore) {
 
 func UpgradeDatabaseToVersion35(sqlStore SqlStore) {
 	if shouldPerformUpgrade(sqlStore, VERSION_3_4_0, VERSION_3_5_0) {
-		sqlStore.GetMaster().Exec("UPDATE Users SET Roles = 'system_user' WHERE Roles = ''")
-		sqlStore.GetMaster().Exec("UPDATE Users SET Roles = 'system_user system_admin' WHERE Roles = 'system_admin'")
-		sqlStore.GetMaster().Exec("UPDATE TeamMembers SET Roles = 'team_user' WHERE Roles = ''")
-		sqlStore.GetMaster().Exec("UPDATE TeamMembers SET Roles = 'team_user team_admin' WHERE Roles = 'admin'")
-		sqlStore.GetMaster().Exec("UPDATE ChannelMembers SET Roles = 'channel_user' WHERE Roles = ''")
-		sqlStore.GetMaster().Exec("UPDATE ChannelMembers SET Roles = 'channel_user channel_admin' WHERE Roles = 'admin'")
+		// The roles of the existing users and members are updated by the backfills of the
+		// legacy_upgrade_backfills migration.
 
 		// The rest of the migration from Filenames -> FileIds is done lazily in api.GetFileInfosForPost
 		sqlStore.CreateColumnIfNotExists("Posts", "FileIds", "varchar(150)", "varchar(150)", "[]")
@@ -493,8 +511,9 @@ func UpgradeDatabaseToVersion410(sqlStore SqlStore) {
 		sqlStore.RemoveIndexIfExists("Name_2", "Emoji")
 		sqlStore.RemoveIndexIfExists("ClientId_2", "OAuthAccessData")
 
+		// The SAML auth data of the existing users is lowercased by the backfills of the
+		// legacy_upgrade_backfills migration.
 		saveSchemaVersion(sqlStore, VERSION_4_10_0)
-		sqlStore.GetMaster().Exec("UPDATE Users SET AuthData=LOWER(AuthData) WHERE AuthService = 'saml'")
 	}
 }
 
@@ -527,6 +546,8 @@ func UpgradeDatabaseToVersion50(sqlStore SqlStore) {
 		sqlStore.CreateColumnIfNotExistsNoDefault("ChannelMembers", "SchemeAdmin", "boolean", "boolean")
 
 		sqlStore.CreateColumnIfNotExists("Roles", "BuiltIn", "boolean", "boolean", "0")
+		// Roles holds a handful of rows, and custom roles created afterwards are not built in, so
+		// unlike the members these are updated inline.
 		sqlStore.GetMaster().Exec("UPDATE Roles SET BuiltIn=true")
 		sqlStore.GetMaster().Exec("UPDATE Roles SET SchemeManaged=false WHERE Name NOT IN ('system_user', 'system_admin', 'team_user', 'team_admin', 'channel_user', 'channel_admin')")
 		sqlStore.CreateColumnIfNotExists("IncomingWebhooks", "ChannelLocked", "boolean", "boolean", "0")
@@ -693,6 +714,8 @@ func UpgradeDatabaseToVersion512(sqlStore SqlStore) {
 		sqlStore.CreateColumnIfNotExistsNoDefault("Schemes", "DefaultTeamGuestRole", "text", "VARCHAR(64)")
 		sqlStore.CreateColumnIfNotExistsNoDefault("Schemes", "DefaultChannelGuestRole", "text", "VARCHAR(64)")
 
+		// Schemes holds a handful of rows, which cannot be read while NULL, so they are updated
+		// inline.
 		sqlStore.GetMaster().Exec("UPDATE Schemes SET DefaultTeamGuestRole = '', DefaultChannelGuestRole = ''")
 
 		// Saturday, January 24, 2065 5:20:00 AM GMT. To remove all personal access token sessions.
@@ -738,11 +761,7 @@ func UpgradeDatabaseToVersion516(sqlStore SqlStore) {
 	// TODO: Uncomment following condition when version 5.16.0 is released
 	// if shouldPerformUpgrade(sqlStore, VERSION_5_15_0, VERSION_5_16_0) {
 
-	if sqlStore.DriverName() == model.DATABASE_DRIVER_POSTGRES {
-		sqlStore.GetMaster().Exec("ALTER TABLE Tokens ALTER COLUMN Extra TYPE varchar(2048)")
-	} else if sqlStore.DriverName() == model.DATABASE_DRIVER_MYSQL {
-		sqlStore.GetMaster().Exec("ALTER TABLE Tokens MODIFY Extra text")
-	}
+	// The schema changes of this release are made by the migrations in migrations.go.
 
 	// 	saveSchemaVersion(sqlStore, VERSION_5_16_0)
 	// }
@@ -74,6 +74,8 @@ func (s SqlUserAccessTokenStore) deleteSessionsAndTokensById(transaction *gorp.T
 		query = "DELETE FROM Sessions s USING UserAccessTokens o WHERE o.Token = s.Token AND o.Id = :Id"
 	} else if s.DriverName() == model.DATABASE_DRIVER_MYSQL {
 		query = "DELETE s.* FROM Sessions s INNER JOIN UserAccessTokens o ON o.Token = s.Token WHERE o.Id = :Id"
+	} else if s.DriverName() == model.DATABASE_DRIVER_SQLITE {
+		query = "DELETE FROM Sessions WHERE Token IN (SELECT Token FROM UserAccessTokens WHERE Id = :Id)"
 	}
 
 	if _, err := transaction.Exec(query, map[string]interface{}{"Id": tokenId}); err != nil {
@@ -115,6 +117,8 @@ func (s SqlUserAccessTokenStore) deleteSessionsandTokensByUser(transaction *gorp
 		query = "DELETE FROM Sessions s USING UserAccessTokens o WHERE o.Token = s.Token AND o.UserId = :UserId"
 	} else if s.DriverName() == model.DATABASE_DRIVER_MYSQL {
 		query = "DELETE s.* FROM Sessions s INNER JOIN UserAccessTokens o ON o.Token = s.Token WHERE o.UserId = :UserId"
+	} else if s.DriverName() == model.DATABASE_DRIVER_SQLITE {
+		query = "DELETE FROM Sessions WHERE Token IN (SELECT Token FROM UserAccessTokens WHERE UserId = :UserId)"
 	}
 
 	if _, err := transaction.Exec(query, map[string]interface{}{"UserId": userId}); err != nil {
@@ -227,6 +231,8 @@ func (s SqlUserAccessTokenStore) deleteSessionsAndDisableToken(transaction *gorp
 		query = "DELETE FROM Sessions s USING UserAccessTokens o WHERE o.Token = s.Token AND o.Id = :Id"
 	} else if s.DriverName() == model.DATABASE_DRIVER_MYSQL {
 		query = "DELETE s.* FROM Sessions s INNER JOIN UserAccessTokens o ON o.Token = s.Token WHERE o.Id = :Id"
+	} else if s.DriverName() == model.DATABASE_DRIVER_SQLITE {
+		query = "DELETE FROM Sessions WHERE Token IN (SELECT Token FROM UserAccessTokens WHERE Id = :Id)"
 	}
 
 	if _, err := transaction.Exec(query, map[string]interface{}{"Id": tokenId}); err != nil {
@@ -23,8 +23,6 @@ import (
 const (
 	PROFILES_IN_CHANNEL_CACHE_SIZE  = model.CHANNEL_CACHE_SIZE
 	PROFILES_IN_CHANNEL_CACHE_SEC   = 900 // 15 mins
-	PROFILE_BY_IDS_CACHE_SIZE       = model.SESSION_CACHE_SIZE
-	PROFILE_BY_IDS_CACHE_SEC        = 900 // 15 mins
 	MAX_GROUP_CHANNELS_FOR_PROFILES = 50
 )
 
@@ -35,6 +33,21 @@ var (
 	USER_SEARCH_TYPE_ALL                = []string{"Username", "FirstName", "LastName", "Nickname", "Email"}
 )
 
+// customStatusExpiry is when the custom status of a user expires, kept apart from the status in
+// the user's props so that expired statuses can be found by index.
+type customStatusExpiry struct {
+	UserId    string
+	ExpiresAt int64
+}
+
+// workingHoursEnd is when the current or next working period of a user ends, kept apart from the
+// working hours in the user's notify props so that the users whose working period ended can be
+// found by index.
+type workingHoursEnd struct {
+	UserId string
+	EndAt  int64
+}
+
 type SqlUserStore struct {
 	SqlStore
 	metrics einterfaces.MetricsInterface
@@ -44,24 +57,16 @@ type SqlUserStore struct {
 }
 
 var profilesInChannelCache *utils.Cache = utils.NewLru(PROFILES_IN_CHANNEL_CACHE_SIZE)
-var profileByIdsCache *utils.Cache = utils.NewLru(PROFILE_BY_IDS_CACHE_SIZE)
 
 func (us SqlUserStore) ClearCaches() {
 	profilesInChannelCache.Purge()
-	profileByIdsCache.Purge()
 
 	if us.metrics != nil {
 		us.metrics.IncrementMemCacheInvalidationCounter("Profiles in Channel - Purge")
-		us.metrics.IncrementMemCacheInvalidationCounter("Profile By Ids - Purge")
 	}
 }
 
 func (us SqlUserStore) InvalidatProfileCacheForUser(userId string) {
-	profileByIdsCache.Remove(userId)
-
-	if us.metrics != nil {
-		us.metrics.IncrementMemCacheInvalidationCounter("Profile By Ids - Remove")
-	}
 }
 
 func NewSqlUserStore(sqlStore SqlStore, metrics einterfaces.MetricsInterface) store.UserStore {
@@ -93,6 +98,12 @@ func NewSqlUserStore(sqlStore SqlStore, metrics einterfaces.MetricsInterface) st
 		table.ColMap("MfaSecret").SetMaxSize(128)
 		table.ColMap("Position").SetMaxSize(128)
 		table.ColMap("Timezone").SetMaxSize(256)
+
+		tablecs := db.AddTableWithName(customStatusExpiry{}, "CustomStatusExpiries").SetKeys(false, "UserId")
+		tablecs.ColMap("UserId").SetMaxSize(26)
+
+		tablewh := db.AddTableWithName(workingHoursEnd{}, "WorkingHoursEnds").SetKeys(false, "UserId")
+		tablewh.ColMap("UserId").SetMaxSize(26)
 	}
 
 	return us
@@ -103,6 +114,8 @@ func (us SqlUserStore) CreateIndexesIfNotExists() {
 	us.CreateIndexIfNotExists("idx_users_update_at", "Users", "UpdateAt")
 	us.CreateIndexIfNotExists("idx_users_create_at", "Users", "CreateAt")
 	us.CreateIndexIfNotExists("idx_users_delete_at", "Users", "DeleteAt")
+	us.CreateIndexIfNotExists("idx_customstatusexpiries_expires_at", "CustomStatusExpiries", "ExpiresAt")
+	us.CreateIndexIfNotExists("idx_workinghoursends_end_at", "WorkingHoursEnds", "EndAt")
 
 	if us.DriverName() == model.DATABASE_DRIVER_POSTGRES {
 		us.CreateIndexIfNotExists("idx_users_email_lower_textpattern", "Users", "lower(Email) text_pattern_ops")
@@ -794,40 +807,13 @@ func (us SqlUserStore) GetProfileByIds(userIds []string, options *store.UserGetB
 	}
 
 	users := []*model.User{}
-	remainingUserIds := make([]string, 0)
-
-	if allowFromCache {
-		for _, userId := range userIds {
-			if cacheItem, ok := profileByIdsCache.Get(userId); ok {
-				u := &model.User{}
-				*u = *cacheItem.(*model.User)
-
-				if options.Since == 0 || u.UpdateAt > options.Since {
-					users = append(users, u)
-				}
-			} else {
-				remainingUserIds = append(remainingUserIds, userId)
-			}
-		}
-		if us.metrics != nil {
-			us.metrics.AddMemCacheHitCounter("Profile By Ids", float64(len(users)))
-			us.metrics.AddMemCacheMissCounter("Profile By Ids", float64(len(remainingUserIds)))
-		}
-	} else {
-		remainingUserIds = userIds
-		if us.metrics != nil {
-			us.metrics.AddMemCacheMissCounter("Profile By Ids", float64(len(remainingUserIds)))
-		}
-	}
-
-	// If everything came from the cache then just return
-	if len(remainingUserIds) == 0 {
+	if len(userIds) == 0 {
 		return users, nil
 	}
 
 	query := us.usersQuery.
 		Where(map[string]interface{}{
-			"u.Id": remainingUserIds,
+			"u.Id": userIds,
 		}).
 		OrderBy("u.Username ASC")
 
@@ -850,10 +836,6 @@ func (us SqlUserStore) GetProfileByIds(userIds []string, options *store.UserGetB
 
 	for _, u := range users {
 		u.Sanitize(map[string]bool{})
-
-		cpy := &model.User{}
-		*cpy = *u
-		profileByIdsCache.AddWithExpiresInSecs(cpy.Id, cpy, PROFILE_BY_IDS_CACHE_SEC)
 	}
 
 	return users, nil
@@ -997,6 +979,113 @@ func (us SqlUserStore) GetAllUsingAuthService(authService string) ([]*model.User
 	return users, nil
 }
 
+// SetCustomStatusExpiry records when the custom status of the user expires, or that it doesn't
+// for 0.
+func (us SqlUserStore) SetCustomStatusExpiry(userId string, expiresAt int64) *model.AppError {
+	transaction, err := us.GetMaster().Begin()
+	if err != nil {
+		return model.NewAppError("SqlUserStore.SetCustomStatusExpiry", "store.sql_user.set_custom_status_expiry.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	if _, err := transaction.Exec("DELETE FROM CustomStatusExpiries WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlUserStore.SetCustomStatusExpiry", "store.sql_user.set_custom_status_expiry.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if expiresAt > 0 {
+		if err := transaction.Insert(&customStatusExpiry{UserId: userId, ExpiresAt: expiresAt}); err != nil {
+			return model.NewAppError("SqlUserStore.SetCustomStatusExpiry", "store.sql_user.set_custom_status_expiry.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return model.NewAppError("SqlUserStore.SetCustomStatusExpiry", "store.sql_user.set_custom_status_expiry.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// ClearExpiredCustomStatuses removes from the props of up to limit users the custom statuses that
+// expired as of now, and returns the ids of those users. The props of each user are only written
+// if they are unchanged since read, so that concurrent edits to the user aren't lost, and are
+// otherwise left for the next call.
+func (us SqlUserStore) ClearExpiredCustomStatuses(now int64, limit int) ([]string, *model.AppError) {
+	var expiries []*customStatusExpiry
+	if _, err := us.GetMaster().Select(&expiries, "SELECT * FROM CustomStatusExpiries WHERE ExpiresAt <= :Now ORDER BY ExpiresAt LIMIT :Limit", map[string]interface{}{"Now": now, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlUserStore.ClearExpiredCustomStatuses", "store.sql_user.clear_expired_custom_statuses.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	userIds := []string{}
+	for _, expiry := range expiries {
+		params := map[string]interface{}{"UserId": expiry.UserId, "Now": now}
+
+		props, err := us.GetMaster().SelectNullStr("SELECT Props FROM Users WHERE Id = :UserId", params)
+		if err != nil {
+			return nil, model.NewAppError("SqlUserStore.ClearExpiredCustomStatuses", "store.sql_user.clear_expired_custom_statuses.app_error", nil, "user_id="+expiry.UserId+", "+err.Error(), http.StatusInternalServerError)
+		}
+
+		user := &model.User{Props: model.MapFromJson(strings.NewReader(props.String))}
+		if cs := user.GetCustomStatus(); cs != nil && cs.IsExpired(now) {
+			user.ClearCustomStatus()
+			params["Props"] = model.MapToJson(user.Props)
+			params["OldProps"] = props.String
+			params["UpdateAt"] = model.GetMillis()
+
+			result, err := us.GetMaster().Exec("UPDATE Users SET Props = :Props, UpdateAt = :UpdateAt WHERE Id = :UserId AND Props = :OldProps", params)
+			if err != nil {
+				return nil, model.NewAppError("SqlUserStore.ClearExpiredCustomStatuses", "store.sql_user.clear_expired_custom_statuses.app_error", nil, "user_id="+expiry.UserId+", "+err.Error(), http.StatusInternalServerError)
+			}
+			if rows, _ := result.RowsAffected(); rows == 0 {
+				continue
+			}
+			userIds = append(userIds, expiry.UserId)
+		}
+
+		if _, err := us.GetMaster().Exec("DELETE FROM CustomStatusExpiries WHERE UserId = :UserId AND ExpiresAt <= :Now", params); err != nil {
+			return nil, model.NewAppError("SqlUserStore.ClearExpiredCustomStatuses", "store.sql_user.clear_expired_custom_statuses.app_error", nil, "user_id="+expiry.UserId+", "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return userIds, nil
+}
+
+// SetWorkingHoursEnd records when the current or next working period of the user ends, or that
+// they have no working hours for 0.
+func (us SqlUserStore) SetWorkingHoursEnd(userId string, endAt int64) *model.AppError {
+	transaction, err := us.GetMaster().Begin()
+	if err != nil {
+		return model.NewAppError("SqlUserStore.SetWorkingHoursEnd", "store.sql_user.set_working_hours_end.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	if _, err := transaction.Exec("DELETE FROM WorkingHoursEnds WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlUserStore.SetWorkingHoursEnd", "store.sql_user.set_working_hours_end.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if endAt > 0 {
+		if err := transaction.Insert(&workingHoursEnd{UserId: userId, EndAt: endAt}); err != nil {
+			return model.NewAppError("SqlUserStore.SetWorkingHoursEnd", "store.sql_user.set_working_hours_end.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return model.NewAppError("SqlUserStore.SetWorkingHoursEnd", "store.sql_user.set_working_hours_end.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// GetUserIdsWithWorkingHoursEnded returns the ids of up to limit users whose working period ended
+// as of now, earliest first.
+func (us SqlUserStore) GetUserIdsWithWorkingHoursEnded(now int64, limit int) ([]string, *model.AppError) {
+	var userIds []string
+	if _, err := us.GetMaster().Select(&userIds, "SELECT UserId FROM WorkingHoursEnds WHERE EndAt <= :Now ORDER BY EndAt LIMIT :Limit", map[string]interface{}{"Now": now, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlUserStore.GetUserIdsWithWorkingHoursEnded", "store.sql_user.get_user_ids_with_working_hours_ended.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return userIds, nil
+}
+
 func (us SqlUserStore) GetByUsername(username string) (*model.User, *model.AppError) {
 	query := us.usersQuery.Where("u.Username = ?", username)
 
@@ -1352,10 +1441,15 @@ func (us SqlUserStore) GetProfilesNotInTeam(teamId string, groupConstrained bool
 }
 
 func (us SqlUserStore) GetEtagForProfilesNotInTeam(teamId string) string {
+	etagColumn := "CONCAT(MAX(UpdateAt), '.', COUNT(Id))"
+	if us.DriverName() == model.DATABASE_DRIVER_SQLITE {
+		etagColumn = "MAX(UpdateAt) || '.' || COUNT(Id)"
+	}
+
 	var querystr string
 	querystr = `
 		SELECT
-			CONCAT(MAX(UpdateAt), '.', COUNT(Id)) as etag
+			` + etagColumn + ` as etag
 		FROM
 			Users as u
 		LEFT JOIN TeamMembers tm
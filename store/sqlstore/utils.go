@@ -29,6 +29,18 @@ func sanitizeSearchTerm(term string, escapeChar string) string {
 	return term
 }
 
+// escapeLikePrefix escapes the LIKE wildcards and the escape character itself so that prefix is
+// matched literally, unlike sanitizeSearchTerm which drops the escape character.
+func escapeLikePrefix(prefix string, escapeChar string) string {
+	prefix = strings.Replace(prefix, escapeChar, escapeChar+escapeChar, -1)
+
+	for _, c := range escapeLikeSearchChar {
+		prefix = strings.Replace(prefix, c, escapeChar+c, -1)
+	}
+
+	return prefix
+}
+
 // Converts a list of strings into a list of query parameters and a named parameter map that can
 // be used as part of a SQL query.
 func MapStringsToQueryParams(list []string, paramPrefix string) (string, map[string]interface{}) {
@@ -58,3 +58,19 @@ func TestSanitizeSearchTerm(t *testing.T) {
 	result = sanitizeSearchTerm(term, "*")
 	require.Equal(t, result, expected)
 }
+
+func TestEscapeLikePrefix(t *testing.T) {
+	prefix := "test"
+	result := escapeLikePrefix(prefix, "*")
+	require.Equal(t, prefix, result)
+
+	prefix = "test_%"
+	expected := "test*_*%"
+	result = escapeLikePrefix(prefix, "*")
+	require.Equal(t, expected, result)
+
+	prefix = "**test_"
+	expected = "****test*_"
+	result = escapeLikePrefix(prefix, "*")
+	require.Equal(t, expected, result)
+}
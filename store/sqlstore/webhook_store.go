@@ -24,6 +24,10 @@ const (
 	WEBHOOK_CACHE_SEC  = 900 // 15 minutes
 )
 
+// incomingWebhookColumns selects the columns of an incoming webhook. PayloadTemplate is NULL for
+// the webhooks created before it was added until its backfill has run.
+const incomingWebhookColumns = "Id, CreateAt, UpdateAt, DeleteAt, UserId, ChannelId, TeamId, DisplayName, Description, Username, IconURL, ChannelLocked, COALESCE(PayloadTemplate, '') AS PayloadTemplate"
+
 var webhookCache = utils.NewLru(WEBHOOK_CACHE_SIZE)
 
 func (s SqlWebhookStore) ClearCaches() {
@@ -48,6 +52,7 @@ func NewSqlWebhookStore(sqlStore SqlStore, metrics einterfaces.MetricsInterface)
 		table.ColMap("TeamId").SetMaxSize(26)
 		table.ColMap("DisplayName").SetMaxSize(64)
 		table.ColMap("Description").SetMaxSize(500)
+		table.ColMap("PayloadTemplate").SetMaxSize(model.INCOMING_WEBHOOK_PAYLOAD_TEMPLATE_MAX_LENGTH)
 
 		tableo := db.AddTableWithName(model.OutgoingWebhook{}, "OutgoingWebhooks").SetKeys(false, "Id")
 		tableo.ColMap("Id").SetMaxSize(26)
@@ -131,7 +136,7 @@ func (s SqlWebhookStore) GetIncoming(id string, allowFromCache bool) (*model.Inc
 	}
 
 	var webhook model.IncomingWebhook
-	if err := s.GetReplica().SelectOne(&webhook, "SELECT * FROM IncomingWebhooks WHERE Id = :Id AND DeleteAt = 0", map[string]interface{}{"Id": id}); err != nil {
+	if err := s.GetReplica().SelectOne(&webhook, "SELECT "+incomingWebhookColumns+" FROM IncomingWebhooks WHERE Id = :Id AND DeleteAt = 0", map[string]interface{}{"Id": id}); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, model.NewAppError("SqlWebhookStore.GetIncoming", "store.sql_webhooks.get_incoming.app_error", nil, "id="+id+", err="+err.Error(), http.StatusNotFound)
 		}
@@ -182,7 +187,7 @@ func (s SqlWebhookStore) GetIncomingListByUser(userId string, offset, limit int)
 	var webhooks []*model.IncomingWebhook
 
 	query := s.getQueryBuilder().
-		Select("*").
+		Select(incomingWebhookColumns).
 		From("IncomingWebhooks").
 		Where(sq.Eq{"DeleteAt": int(0)}).Limit(uint64(limit)).Offset(uint64(offset))
 
@@ -207,7 +212,7 @@ func (s SqlWebhookStore) GetIncomingByTeamByUser(teamId string, userId string, o
 	var webhooks []*model.IncomingWebhook
 
 	query := s.getQueryBuilder().
-		Select("*").
+		Select(incomingWebhookColumns).
 		From("IncomingWebhooks").
 		Where(sq.And{
 			sq.Eq{"TeamId": teamId},
@@ -237,7 +242,7 @@ func (s SqlWebhookStore) GetIncomingByTeam(teamId string, offset, limit int) ([]
 func (s SqlWebhookStore) GetIncomingByChannel(channelId string) ([]*model.IncomingWebhook, *model.AppError) {
 	var webhooks []*model.IncomingWebhook
 
-	if _, err := s.GetReplica().Select(&webhooks, "SELECT * FROM IncomingWebhooks WHERE ChannelId = :ChannelId AND DeleteAt = 0", map[string]interface{}{"ChannelId": channelId}); err != nil {
+	if _, err := s.GetReplica().Select(&webhooks, "SELECT "+incomingWebhookColumns+" FROM IncomingWebhooks WHERE ChannelId = :ChannelId AND DeleteAt = 0", map[string]interface{}{"ChannelId": channelId}); err != nil {
 		return nil, model.NewAppError("SqlWebhookStore.GetIncomingByChannel", "store.sql_webhooks.get_incoming_by_channel.app_error", nil, "channelId="+channelId+", err="+err.Error(), http.StatusInternalServerError)
 	}
 
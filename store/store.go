@@ -46,6 +46,11 @@ type Store interface {
 	Group() GroupStore
 	UserTermsOfService() UserTermsOfServiceStore
 	LinkMetadata() LinkMetadataStore
+	PostPriority() PostPriorityStore
+	PostAcknowledgement() PostAcknowledgementStore
+	PluginConfigRevision() PluginConfigRevisionStore
+	PostShard() PostShardStore
+	AuditLog() AuditLogStore
 	MarkSystemRanUnitTests()
 	Close()
 	LockToMaster()
@@ -55,7 +60,12 @@ type Store interface {
 	TotalMasterDbConnections() int
 	TotalReadDbConnections() int
 	TotalSearchDbConnections() int
+	ReplicaStatuses() []*model.ReplicaStatus
+	MasterOnly() Store
 	CheckIntegrity() <-chan IntegrityCheckResult
+	Backfills() ([]string, *model.AppError)
+	CountBackfillRemaining(name string) (int64, *model.AppError)
+	RunBackfillBatch(name string, afterKey []string, batchSize int) ([]string, int64, *model.AppError)
 }
 
 type TeamStore interface {
@@ -217,6 +227,7 @@ type PostStore interface {
 	GetPostIdBeforeTime(channelId string, time int64) (string, *model.AppError)
 	GetEtag(channelId string, allowFromCache bool) string
 	Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, *model.AppError)
+	ResolveSearchScope(teamId string, userId string, params *model.SearchParams) (*model.SearchParams, *model.AppError)
 	AnalyticsUserCountsWithPostsByDay(teamId string) (model.AnalyticsRows, *model.AppError)
 	AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, *model.AppError)
 	AnalyticsPostCount(teamId string, mustHaveFile bool, mustHaveHashtag bool) (int64, *model.AppError)
@@ -232,6 +243,14 @@ type PostStore interface {
 	GetParentsForExportAfter(limit int, afterId string) ([]*model.PostForExport, *model.AppError)
 	GetRepliesForExport(parentId string) ([]*model.ReplyForExport, *model.AppError)
 	GetDirectPostParentsForExportAfter(limit int, afterId string) ([]*model.DirectPostForExport, *model.AppError)
+	GetThreadsForArchival(endTime int64, afterCreateAt int64, afterId string, limit int) ([]*model.Post, *model.AppError)
+	Archive(path string, posts []*model.Post, endTime int64) *model.AppError
+	GetArchivePaths(postIds []string) (map[string]string, *model.AppError)
+	SearchArchive(teamId string, userId string, params *model.SearchParams) ([]*model.PostArchiveIndex, *model.AppError)
+	GetArchivePathsForChannel(channelId string) ([]string, *model.AppError)
+	GetArchivePathsBefore(endTime int64, limit int) ([]string, *model.AppError)
+	ReplaceArchive(path string, newPath string, posts []*model.Post) *model.AppError
+	Unarchive(path string, posts []*model.Post) *model.AppError
 }
 
 type UserStore interface {
@@ -263,6 +282,10 @@ type UserStore interface {
 	GetByEmail(email string) (*model.User, *model.AppError)
 	GetByAuth(authData *string, authService string) (*model.User, *model.AppError)
 	GetAllUsingAuthService(authService string) ([]*model.User, *model.AppError)
+	SetCustomStatusExpiry(userId string, expiresAt int64) *model.AppError
+	ClearExpiredCustomStatuses(now int64, limit int) ([]string, *model.AppError)
+	SetWorkingHoursEnd(userId string, endAt int64) *model.AppError
+	GetUserIdsWithWorkingHoursEnded(now int64, limit int) ([]string, *model.AppError)
 	GetByUsername(username string) (*model.User, *model.AppError)
 	GetForLogin(loginId string, allowSignInWithUsername, allowSignInWithEmail bool) (*model.User, *model.AppError)
 	VerifyEmail(userId, email string) (string, *model.AppError)
@@ -470,6 +493,7 @@ type StatusStore interface {
 	ResetAll() *model.AppError
 	GetTotalActiveUsersCount() (int64, *model.AppError)
 	UpdateLastActivityAt(userId string, lastActivityAt int64) *model.AppError
+	UpdateExpiredDNDStatuses(now int64) ([]*model.Status, *model.AppError)
 }
 
 type FileInfoStore interface {
@@ -528,11 +552,15 @@ type PluginStore interface {
 	SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, *model.AppError)
 	CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, *model.AppError)
 	CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, *model.AppError)
+	SetWithOptions(pluginId string, key string, value []byte, options model.PluginKVSetOptions) (bool, *model.AppError)
+	SetMulti(keyVals []*model.PluginKeyValue) *model.AppError
 	Get(pluginId, key string) (*model.PluginKeyValue, *model.AppError)
+	GetMulti(pluginId string, keys []string) ([]*model.PluginKeyValue, *model.AppError)
 	Delete(pluginId, key string) *model.AppError
 	DeleteAllForPlugin(PluginId string) *model.AppError
 	DeleteAllExpired() *model.AppError
 	List(pluginId string, page, perPage int) ([]string, *model.AppError)
+	ListWithPrefix(pluginId, prefix string, offset, limit int) ([]string, *model.AppError)
 }
 
 type RoleStore interface {
@@ -612,6 +640,48 @@ type LinkMetadataStore interface {
 	Get(url string, timestamp int64) (*model.LinkMetadata, *model.AppError)
 }
 
+type PostPriorityStore interface {
+	Save(priority *model.PostPriority) (*model.PostPriority, *model.AppError)
+	Get(postId string) (*model.PostPriority, *model.AppError)
+	GetForPosts(postIds []string) ([]*model.PostPriority, *model.AppError)
+	GetForPersistentNotifications(notifiedBefore int64, maxCount int) ([]*model.PostPriority, *model.AppError)
+	MarkNotified(postId string, notifiedAt int64) *model.AppError
+}
+
+type PostAcknowledgementStore interface {
+	Save(acknowledgement *model.PostAcknowledgement) (*model.PostAcknowledgement, *model.AppError)
+	Delete(postId string, userId string) *model.AppError
+	GetForPost(postId string) ([]*model.PostAcknowledgement, *model.AppError)
+	GetForPosts(postIds []string) ([]*model.PostAcknowledgement, *model.AppError)
+}
+
+type PluginConfigRevisionStore interface {
+	Save(revision *model.PluginConfigRevision) (*model.PluginConfigRevision, *model.AppError)
+	Get(id string) (*model.PluginConfigRevision, *model.AppError)
+	GetForPlugin(pluginId string, offset, limit int) ([]*model.PluginConfigRevision, *model.AppError)
+}
+
+type PostShardStore interface {
+	SaveChannelShard(channelShard *model.ChannelShard) (*model.ChannelShard, *model.AppError)
+	GetChannelShard(channelId string) (*model.ChannelShard, *model.AppError)
+	GetChannelShardsAfter(afterChannelId string, limit int) ([]*model.ChannelShard, *model.AppError)
+	GetMovedChannelShards() ([]*model.ChannelShard, *model.AppError)
+	DeleteChannelShard(channelId string) *model.AppError
+	UpdateChannelLastPostAt(channelId string, lastPostAt int64, countMessage bool) *model.AppError
+	SaveChannelMirror(channel *model.Channel) *model.AppError
+	DeleteChannelMirror(channelId string) *model.AppError
+	GetChannelData(channelId string, afterPostId string, limit int) (*model.PostShardData, *model.AppError)
+	SaveChannelData(data *model.PostShardData, movedAt int64) *model.AppError
+	DeleteChannelData(channelId string) *model.AppError
+}
+
+// AuditLogStore appends records to the audit log of the changes made through the store, see
+// model.AuditLogRecord. Records can't be updated or deleted through it.
+type AuditLogStore interface {
+	Append(record *model.AuditLogRecord, key []byte) (*model.AuditLogRecord, *model.AppError)
+	GetAfter(sequence int64, limit int) ([]*model.AuditLogRecord, *model.AppError)
+}
+
 // ChannelSearchOpts contains options for searching channels.
 //
 // NotAssociatedToGroup will exclude channels that have associated, active GroupChannels records.
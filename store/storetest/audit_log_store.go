@@ -0,0 +1,86 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// auditLogTestKey is the key the records appended by the tests are hashed with.
+var auditLogTestKey = []byte("audit log test key")
+
+func TestAuditLogStore(t *testing.T, ss store.Store) {
+	t.Run("Append", func(t *testing.T) { testAuditLogStoreAppend(t, ss) })
+	t.Run("AppendConcurrently", func(t *testing.T) { testAuditLogStoreAppendConcurrently(t, ss) })
+}
+
+// getAuditLogAfter returns every record of the log following the given sequence.
+func getAuditLogAfter(t *testing.T, ss store.Store, sequence int64) []*model.AuditLogRecord {
+	var records []*model.AuditLogRecord
+	for {
+		page, err := ss.AuditLog().GetAfter(sequence, 100)
+		require.Nil(t, err)
+
+		records = append(records, page...)
+		if len(page) < 100 {
+			return records
+		}
+		sequence = page[len(page)-1].Sequence
+	}
+}
+
+func testAuditLogStoreAppend(t *testing.T, ss store.Store) {
+	existing := getAuditLogAfter(t, ss, 0)
+
+	first, err := ss.AuditLog().Append(&model.AuditLogRecord{Phase: model.AUDIT_LOG_PHASE_INTENT, UserId: model.NewId(), Method: "ChannelStore.Update"}, auditLogTestKey)
+	require.Nil(t, err)
+	assert.NotEmpty(t, first.Id)
+	assert.NotZero(t, first.CreateAt)
+	assert.Equal(t, first.ComputeHash(auditLogTestKey), first.Hash)
+	assert.NotEqual(t, first.ComputeHash(nil), first.Hash)
+
+	second, err := ss.AuditLog().Append(&model.AuditLogRecord{Phase: model.AUDIT_LOG_PHASE_OUTCOME, IntentSequence: first.Sequence, Method: "ChannelStore.Update", Status: model.AUDIT_LOG_STATUS_SUCCESS}, auditLogTestKey)
+	require.Nil(t, err)
+	assert.Equal(t, first.Sequence+1, second.Sequence)
+	assert.Equal(t, first.Hash, second.PrevHash)
+
+	if len(existing) > 0 {
+		assert.Equal(t, existing[len(existing)-1].Hash, first.PrevHash)
+	} else {
+		assert.Equal(t, int64(1), first.Sequence)
+		assert.Equal(t, "", first.PrevHash)
+	}
+
+	records := getAuditLogAfter(t, ss, first.Sequence-1)
+	require.Len(t, records, 2)
+	assert.Equal(t, *first, *records[0])
+	assert.Equal(t, *second, *records[1])
+}
+
+func testAuditLogStoreAppendConcurrently(t *testing.T, ss store.Store) {
+	existing := getAuditLogAfter(t, ss, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := ss.AuditLog().Append(&model.AuditLogRecord{Phase: model.AUDIT_LOG_PHASE_INTENT, Method: "PostStore.Save"}, auditLogTestKey)
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+
+	verifier := &model.AuditLogVerifier{Key: auditLogTestKey}
+	for _, record := range append(existing, getAuditLogAfter(t, ss, int64(len(existing)))...) {
+		require.Nil(t, verifier.Verify(record))
+	}
+	assert.Equal(t, int64(len(existing)+5), verifier.LastSequence)
+}
@@ -17,6 +17,7 @@ func TestComplianceStore(t *testing.T, ss store.Store) {
 	t.Run("", func(t *testing.T) { testComplianceStore(t, ss) })
 	t.Run("ComplianceExport", func(t *testing.T) { testComplianceExport(t, ss) })
 	t.Run("ComplianceExportDirectMessages", func(t *testing.T) { testComplianceExportDirectMessages(t, ss) })
+	t.Run("ComplianceExportPostPriority", func(t *testing.T) { testComplianceExportPostPriority(t, ss) })
 	t.Run("MessageExportPublicChannel", func(t *testing.T) { testMessageExportPublicChannel(t, ss) })
 	t.Run("MessageExportPrivateChannel", func(t *testing.T) { testMessageExportPrivateChannel(t, ss) })
 	t.Run("MessageExportDirectMessageChannel", func(t *testing.T) { testMessageExportDirectMessageChannel(t, ss) })
@@ -258,6 +259,44 @@ func testComplianceExportDirectMessages(t *testing.T, ss store.Store) {
 	assert.Equal(t, cposts[len(cposts)-1].PostId, o3.Id)
 }
 
+func testComplianceExportPostPriority(t *testing.T, ss store.Store) {
+	time.Sleep(100 * time.Millisecond)
+
+	t1, err := ss.Team().Save(&model.Team{DisplayName: "DisplayName", Name: "zz" + model.NewId() + "b", Email: MakeEmail(), Type: model.TEAM_OPEN})
+	require.Nil(t, err)
+
+	u1, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId()})
+	require.Nil(t, err)
+
+	u2, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId()})
+	require.Nil(t, err)
+
+	c1, err := ss.Channel().Save(&model.Channel{TeamId: t1.Id, DisplayName: "Channel", Name: "zz" + model.NewId() + "b", Type: model.CHANNEL_OPEN}, -1)
+	require.Nil(t, err)
+
+	o1, err := ss.Post().Save(&model.Post{ChannelId: c1.Id, UserId: u1.Id, CreateAt: model.GetMillis(), Message: "zz" + model.NewId() + "b"})
+	require.Nil(t, err)
+
+	o2, err := ss.Post().Save(&model.Post{ChannelId: c1.Id, UserId: u1.Id, CreateAt: o1.CreateAt + 10, Message: "zz" + model.NewId() + "b"})
+	require.Nil(t, err)
+
+	_, err = ss.PostPriority().Save(&model.PostPriority{PostId: o2.Id, ChannelId: c1.Id, Priority: model.POST_PRIORITY_URGENT})
+	require.Nil(t, err)
+	_, err = ss.PostAcknowledgement().Save(&model.PostAcknowledgement{PostId: o2.Id, UserId: u2.Id})
+	require.Nil(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+
+	cr1 := &model.Compliance{Desc: "test" + model.NewId(), StartAt: o1.CreateAt - 1, EndAt: o2.CreateAt + 1, Emails: u1.Email}
+	cposts, err := ss.Compliance().ComplianceExport(cr1)
+	require.Nil(t, err)
+	require.Len(t, cposts, 2)
+	assert.Equal(t, "", cposts[0].PostPriority)
+	assert.Equal(t, "", cposts[0].PostAcknowledgements)
+	assert.Equal(t, model.POST_PRIORITY_URGENT, cposts[1].PostPriority)
+	assert.Contains(t, cposts[1].PostAcknowledgements, u2.Username)
+}
+
 func testMessageExportPublicChannel(t *testing.T, ss store.Store) {
 	// get the starting number of message export entries
 	startTime := model.GetMillis()
@@ -503,6 +503,9 @@ func testGroupGetMemberUsersPage(t *testing.T, ss store.Store) {
 	_, err = ss.Group().UpsertMember(group.Id, user1.Id)
 	require.Nil(t, err)
 
+	// Ensure the users are ordered by creation
+	time.Sleep(time.Millisecond)
+
 	u2 := &model.User{
 		Email:    MakeEmail(),
 		Username: model.NewId(),
@@ -513,6 +516,9 @@ func testGroupGetMemberUsersPage(t *testing.T, ss store.Store) {
 	_, err = ss.Group().UpsertMember(group.Id, user2.Id)
 	require.Nil(t, err)
 
+	// Ensure the users are ordered by creation
+	time.Sleep(time.Millisecond)
+
 	u3 := &model.User{
 		Email:    MakeEmail(),
 		Username: model.NewId(),
@@ -901,6 +907,8 @@ func testDeleteGroupSyncable(t *testing.T, ss store.Store) {
 	require.Equal(t, err.Id, "store.sql_group.no_rows")
 
 	// Happy path...
+	// Ensure new UpdateAt > previous UpdateAt
+	time.Sleep(1 * time.Millisecond)
 	d1, err := ss.Group().DeleteGroupSyncable(groupTeam.GroupId, groupTeam.SyncableId, model.GroupSyncableTypeTeam)
 	require.Nil(t, err)
 	require.NotZero(t, d1.DeleteAt)
@@ -969,6 +977,8 @@ func testPendingAutoAddTeamMembers(t *testing.T, ss store.Store) {
 	require.Len(t, teamMembers, 0)
 
 	// Delete and restore GroupMember should return result
+	// Ensure the restored CreateAt > the time after the syncable was created
+	time.Sleep(2 * time.Millisecond)
 	_, err = ss.Group().DeleteMember(group.Id, user.Id)
 	require.Nil(t, err)
 	_, err = ss.Group().UpsertMember(group.Id, user.Id)
@@ -1128,6 +1138,8 @@ func testPendingAutoAddChannelMembers(t *testing.T, ss store.Store) {
 	require.Len(t, channelMembers, 0)
 
 	// Delete and restore GroupMember should return result
+	// Ensure the restored CreateAt > the time after the syncable was created
+	time.Sleep(2 * time.Millisecond)
 	_, err = ss.Group().DeleteMember(group.Id, user.Id)
 	require.Nil(t, err)
 	_, err = ss.Group().UpsertMember(group.Id, user.Id)
@@ -34,6 +34,47 @@ func (_m *LayeredStoreDatabaseLayer) Audit() store.AuditStore {
 	return r0
 }
 
+// AuditLog provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) AuditLog() store.AuditLogStore {
+	ret := _m.Called()
+
+	var r0 store.AuditLogStore
+	if rf, ok := ret.Get(0).(func() store.AuditLogStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.AuditLogStore)
+		}
+	}
+
+	return r0
+}
+
+// Backfills provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) Backfills() ([]string, *model.AppError) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func() *model.AppError); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Bot provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) Bot() store.BotStore {
 	ret := _m.Called()
@@ -167,6 +208,29 @@ func (_m *LayeredStoreDatabaseLayer) Compliance() store.ComplianceStore {
 	return r0
 }
 
+// CountBackfillRemaining provides a mock function with given fields: name
+func (_m *LayeredStoreDatabaseLayer) CountBackfillRemaining(name string) (int64, *model.AppError) {
+	ret := _m.Called(name)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string) *model.AppError); ok {
+		r1 = rf(name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // DropAllTables provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) DropAllTables() {
 	_m.Called()
@@ -292,6 +356,22 @@ func (_m *LayeredStoreDatabaseLayer) MarkSystemRanUnitTests() {
 	_m.Called()
 }
 
+// MasterOnly provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) MasterOnly() store.Store {
+	ret := _m.Called()
+
+	var r0 store.Store
+	if rf, ok := ret.Get(0).(func() store.Store); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.Store)
+		}
+	}
+
+	return r0
+}
+
 // Next provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) Next() store.LayeredStoreSupplier {
 	ret := _m.Called()
@@ -340,6 +420,22 @@ func (_m *LayeredStoreDatabaseLayer) Plugin() store.PluginStore {
 	return r0
 }
 
+// PluginConfigRevision provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) PluginConfigRevision() store.PluginConfigRevisionStore {
+	ret := _m.Called()
+
+	var r0 store.PluginConfigRevisionStore
+	if rf, ok := ret.Get(0).(func() store.PluginConfigRevisionStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PluginConfigRevisionStore)
+		}
+	}
+
+	return r0
+}
+
 // Post provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) Post() store.PostStore {
 	ret := _m.Called()
@@ -356,6 +452,54 @@ func (_m *LayeredStoreDatabaseLayer) Post() store.PostStore {
 	return r0
 }
 
+// PostAcknowledgement provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) PostAcknowledgement() store.PostAcknowledgementStore {
+	ret := _m.Called()
+
+	var r0 store.PostAcknowledgementStore
+	if rf, ok := ret.Get(0).(func() store.PostAcknowledgementStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostAcknowledgementStore)
+		}
+	}
+
+	return r0
+}
+
+// PostPriority provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) PostPriority() store.PostPriorityStore {
+	ret := _m.Called()
+
+	var r0 store.PostPriorityStore
+	if rf, ok := ret.Get(0).(func() store.PostPriorityStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostPriorityStore)
+		}
+	}
+
+	return r0
+}
+
+// PostShard provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) PostShard() store.PostShardStore {
+	ret := _m.Called()
+
+	var r0 store.PostShardStore
+	if rf, ok := ret.Get(0).(func() store.PostShardStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostShardStore)
+		}
+	}
+
+	return r0
+}
+
 // Preference provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) Preference() store.PreferenceStore {
 	ret := _m.Called()
@@ -388,6 +532,22 @@ func (_m *LayeredStoreDatabaseLayer) Reaction() store.ReactionStore {
 	return r0
 }
 
+// ReplicaStatuses provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) ReplicaStatuses() []*model.ReplicaStatus {
+	ret := _m.Called()
+
+	var r0 []*model.ReplicaStatus
+	if rf, ok := ret.Get(0).(func() []*model.ReplicaStatus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ReplicaStatus)
+		}
+	}
+
+	return r0
+}
+
 // Role provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) Role() store.RoleStore {
 	ret := _m.Called()
@@ -619,6 +779,38 @@ func (_m *LayeredStoreDatabaseLayer) RoleSave(ctx context.Context, role *model.R
 	return r0, r1
 }
 
+// RunBackfillBatch provides a mock function with given fields: name, afterKey, batchSize
+func (_m *LayeredStoreDatabaseLayer) RunBackfillBatch(name string, afterKey []string, batchSize int) ([]string, int64, *model.AppError) {
+	ret := _m.Called(name, afterKey, batchSize)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, []string, int) []string); ok {
+		r0 = rf(name, afterKey, batchSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(string, []string, int) int64); ok {
+		r1 = rf(name, afterKey, batchSize)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 *model.AppError
+	if rf, ok := ret.Get(2).(func(string, []string, int) *model.AppError); ok {
+		r2 = rf(name, afterKey, batchSize)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(*model.AppError)
+		}
+	}
+
+	return r0, r1, r2
+}
+
 // Scheme provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) Scheme() store.SchemeStore {
 	ret := _m.Called()
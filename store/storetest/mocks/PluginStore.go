@@ -133,6 +133,31 @@ func (_m *PluginStore) Get(pluginId string, key string) (*model.PluginKeyValue,
 	return r0, r1
 }
 
+// GetMulti provides a mock function with given fields: pluginId, keys
+func (_m *PluginStore) GetMulti(pluginId string, keys []string) ([]*model.PluginKeyValue, *model.AppError) {
+	ret := _m.Called(pluginId, keys)
+
+	var r0 []*model.PluginKeyValue
+	if rf, ok := ret.Get(0).(func(string, []string) []*model.PluginKeyValue); ok {
+		r0 = rf(pluginId, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PluginKeyValue)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, []string) *model.AppError); ok {
+		r1 = rf(pluginId, keys)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: pluginId, page, perPage
 func (_m *PluginStore) List(pluginId string, page int, perPage int) ([]string, *model.AppError) {
 	ret := _m.Called(pluginId, page, perPage)
@@ -158,6 +183,31 @@ func (_m *PluginStore) List(pluginId string, page int, perPage int) ([]string, *
 	return r0, r1
 }
 
+// ListWithPrefix provides a mock function with given fields: pluginId, prefix, offset, limit
+func (_m *PluginStore) ListWithPrefix(pluginId string, prefix string, offset int, limit int) ([]string, *model.AppError) {
+	ret := _m.Called(pluginId, prefix, offset, limit)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, string, int, int) []string); ok {
+		r0 = rf(pluginId, prefix, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, string, int, int) *model.AppError); ok {
+		r1 = rf(pluginId, prefix, offset, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // SaveOrUpdate provides a mock function with given fields: keyVal
 func (_m *PluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, *model.AppError) {
 	ret := _m.Called(keyVal)
@@ -182,3 +232,42 @@ func (_m *PluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.Plugin
 
 	return r0, r1
 }
+
+// SetMulti provides a mock function with given fields: keyVals
+func (_m *PluginStore) SetMulti(keyVals []*model.PluginKeyValue) *model.AppError {
+	ret := _m.Called(keyVals)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func([]*model.PluginKeyValue) *model.AppError); ok {
+		r0 = rf(keyVals)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
+// SetWithOptions provides a mock function with given fields: pluginId, key, value, options
+func (_m *PluginStore) SetWithOptions(pluginId string, key string, value []byte, options model.PluginKVSetOptions) (bool, *model.AppError) {
+	ret := _m.Called(pluginId, key, value, options)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string, []byte, model.PluginKVSetOptions) bool); ok {
+		r0 = rf(pluginId, key, value, options)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, string, []byte, model.PluginKVSetOptions) *model.AppError); ok {
+		r1 = rf(pluginId, key, value, options)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
@@ -87,6 +87,22 @@ func (_m *PostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (model.Ana
 	return r0, r1
 }
 
+// Archive provides a mock function with given fields: path, posts, endTime
+func (_m *PostStore) Archive(path string, posts []*model.Post, endTime int64) *model.AppError {
+	ret := _m.Called(path, posts, endTime)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, []*model.Post, int64) *model.AppError); ok {
+		r0 = rf(path, posts, endTime)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // ClearCaches provides a mock function with given fields:
 func (_m *PostStore) ClearCaches() {
 	_m.Called()
@@ -133,6 +149,81 @@ func (_m *PostStore) Get(id string) (*model.PostList, *model.AppError) {
 	return r0, r1
 }
 
+// GetArchivePaths provides a mock function with given fields: postIds
+func (_m *PostStore) GetArchivePaths(postIds []string) (map[string]string, *model.AppError) {
+	ret := _m.Called(postIds)
+
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func([]string) map[string]string); ok {
+		r0 = rf(postIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func([]string) *model.AppError); ok {
+		r1 = rf(postIds)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetArchivePathsBefore provides a mock function with given fields: endTime, limit
+func (_m *PostStore) GetArchivePathsBefore(endTime int64, limit int) ([]string, *model.AppError) {
+	ret := _m.Called(endTime, limit)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(int64, int) []string); ok {
+		r0 = rf(endTime, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(int64, int) *model.AppError); ok {
+		r1 = rf(endTime, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetArchivePathsForChannel provides a mock function with given fields: channelId
+func (_m *PostStore) GetArchivePathsForChannel(channelId string) ([]string, *model.AppError) {
+	ret := _m.Called(channelId)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(channelId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string) *model.AppError); ok {
+		r1 = rf(channelId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetDirectPostParentsForExportAfter provides a mock function with given fields: limit, afterId
 func (_m *PostStore) GetDirectPostParentsForExportAfter(limit int, afterId string) ([]*model.DirectPostForExport, *model.AppError) {
 	ret := _m.Called(limit, afterId)
@@ -607,6 +698,31 @@ func (_m *PostStore) GetSingle(id string) (*model.Post, *model.AppError) {
 	return r0, r1
 }
 
+// GetThreadsForArchival provides a mock function with given fields: endTime, afterCreateAt, afterId, limit
+func (_m *PostStore) GetThreadsForArchival(endTime int64, afterCreateAt int64, afterId string, limit int) ([]*model.Post, *model.AppError) {
+	ret := _m.Called(endTime, afterCreateAt, afterId, limit)
+
+	var r0 []*model.Post
+	if rf, ok := ret.Get(0).(func(int64, int64, string, int) []*model.Post); ok {
+		r0 = rf(endTime, afterCreateAt, afterId, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Post)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(int64, int64, string, int) *model.AppError); ok {
+		r1 = rf(endTime, afterCreateAt, afterId, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // InvalidateLastPostTimeCache provides a mock function with given fields: channelId
 func (_m *PostStore) InvalidateLastPostTimeCache(channelId string) {
 	_m.Called(channelId)
@@ -692,6 +808,47 @@ func (_m *PostStore) PermanentDeleteByUser(userId string) *model.AppError {
 	return r0
 }
 
+// ReplaceArchive provides a mock function with given fields: path, newPath, posts
+func (_m *PostStore) ReplaceArchive(path string, newPath string, posts []*model.Post) *model.AppError {
+	ret := _m.Called(path, newPath, posts)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, string, []*model.Post) *model.AppError); ok {
+		r0 = rf(path, newPath, posts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
+// ResolveSearchScope provides a mock function with given fields: teamId, userId, params
+func (_m *PostStore) ResolveSearchScope(teamId string, userId string, params *model.SearchParams) (*model.SearchParams, *model.AppError) {
+	ret := _m.Called(teamId, userId, params)
+
+	var r0 *model.SearchParams
+	if rf, ok := ret.Get(0).(func(string, string, *model.SearchParams) *model.SearchParams); ok {
+		r0 = rf(teamId, userId, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SearchParams)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, string, *model.SearchParams) *model.AppError); ok {
+		r1 = rf(teamId, userId, params)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: post
 func (_m *PostStore) Save(post *model.Post) (*model.Post, *model.AppError) {
 	ret := _m.Called(post)
@@ -742,6 +899,47 @@ func (_m *PostStore) Search(teamId string, userId string, params *model.SearchPa
 	return r0, r1
 }
 
+// SearchArchive provides a mock function with given fields: teamId, userId, params
+func (_m *PostStore) SearchArchive(teamId string, userId string, params *model.SearchParams) ([]*model.PostArchiveIndex, *model.AppError) {
+	ret := _m.Called(teamId, userId, params)
+
+	var r0 []*model.PostArchiveIndex
+	if rf, ok := ret.Get(0).(func(string, string, *model.SearchParams) []*model.PostArchiveIndex); ok {
+		r0 = rf(teamId, userId, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PostArchiveIndex)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, string, *model.SearchParams) *model.AppError); ok {
+		r1 = rf(teamId, userId, params)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// Unarchive provides a mock function with given fields: path, posts
+func (_m *PostStore) Unarchive(path string, posts []*model.Post) *model.AppError {
+	ret := _m.Called(path, posts)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, []*model.Post) *model.AppError); ok {
+		r0 = rf(path, posts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: newPost, oldPost
 func (_m *PostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, *model.AppError) {
 	ret := _m.Called(newPost, oldPost)
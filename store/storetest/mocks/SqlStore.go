@@ -62,6 +62,22 @@ func (_m *SqlStore) Audit() store.AuditStore {
 	return r0
 }
 
+// AuditLog provides a mock function with given fields:
+func (_m *SqlStore) AuditLog() store.AuditLogStore {
+	ret := _m.Called()
+
+	var r0 store.AuditLogStore
+	if rf, ok := ret.Get(0).(func() store.AuditLogStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.AuditLogStore)
+		}
+	}
+
+	return r0
+}
+
 // Bot provides a mock function with given fields:
 func (_m *SqlStore) Bot() store.BotStore {
 	ret := _m.Called()
@@ -501,6 +517,20 @@ func (_m *SqlStore) OAuth() store.OAuthStore {
 	return r0
 }
 
+// OnlineMigrations provides a mock function with given fields:
+func (_m *SqlStore) OnlineMigrations() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // Plugin provides a mock function with given fields:
 func (_m *SqlStore) Plugin() store.PluginStore {
 	ret := _m.Called()
@@ -517,6 +547,22 @@ func (_m *SqlStore) Plugin() store.PluginStore {
 	return r0
 }
 
+// PluginConfigRevision provides a mock function with given fields:
+func (_m *SqlStore) PluginConfigRevision() store.PluginConfigRevisionStore {
+	ret := _m.Called()
+
+	var r0 store.PluginConfigRevisionStore
+	if rf, ok := ret.Get(0).(func() store.PluginConfigRevisionStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PluginConfigRevisionStore)
+		}
+	}
+
+	return r0
+}
+
 // Post provides a mock function with given fields:
 func (_m *SqlStore) Post() store.PostStore {
 	ret := _m.Called()
@@ -533,6 +579,54 @@ func (_m *SqlStore) Post() store.PostStore {
 	return r0
 }
 
+// PostAcknowledgement provides a mock function with given fields:
+func (_m *SqlStore) PostAcknowledgement() store.PostAcknowledgementStore {
+	ret := _m.Called()
+
+	var r0 store.PostAcknowledgementStore
+	if rf, ok := ret.Get(0).(func() store.PostAcknowledgementStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostAcknowledgementStore)
+		}
+	}
+
+	return r0
+}
+
+// PostPriority provides a mock function with given fields:
+func (_m *SqlStore) PostPriority() store.PostPriorityStore {
+	ret := _m.Called()
+
+	var r0 store.PostPriorityStore
+	if rf, ok := ret.Get(0).(func() store.PostPriorityStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostPriorityStore)
+		}
+	}
+
+	return r0
+}
+
+// PostShard provides a mock function with given fields:
+func (_m *SqlStore) PostShard() store.PostShardStore {
+	ret := _m.Called()
+
+	var r0 store.PostShardStore
+	if rf, ok := ret.Get(0).(func() store.PostShardStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostShardStore)
+		}
+	}
+
+	return r0
+}
+
 // Preference provides a mock function with given fields:
 func (_m *SqlStore) Preference() store.PreferenceStore {
 	ret := _m.Called()
@@ -119,6 +119,31 @@ func (_m *StatusStore) SaveOrUpdate(status *model.Status) *model.AppError {
 	return r0
 }
 
+// UpdateExpiredDNDStatuses provides a mock function with given fields: now
+func (_m *StatusStore) UpdateExpiredDNDStatuses(now int64) ([]*model.Status, *model.AppError) {
+	ret := _m.Called(now)
+
+	var r0 []*model.Status
+	if rf, ok := ret.Get(0).(func(int64) []*model.Status); ok {
+		r0 = rf(now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Status)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(int64) *model.AppError); ok {
+		r1 = rf(now)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // UpdateLastActivityAt provides a mock function with given fields: userId, lastActivityAt
 func (_m *StatusStore) UpdateLastActivityAt(userId string, lastActivityAt int64) *model.AppError {
 	ret := _m.Called(userId, lastActivityAt)
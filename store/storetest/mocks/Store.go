@@ -5,8 +5,10 @@
 package mocks
 
 import (
-	store "github.com/mattermost/mattermost-server/store"
+	model "github.com/mattermost/mattermost-server/model"
 	mock "github.com/stretchr/testify/mock"
+
+	store "github.com/mattermost/mattermost-server/store"
 )
 
 // Store is an autogenerated mock type for the Store type
@@ -30,6 +32,47 @@ func (_m *Store) Audit() store.AuditStore {
 	return r0
 }
 
+// AuditLog provides a mock function with given fields:
+func (_m *Store) AuditLog() store.AuditLogStore {
+	ret := _m.Called()
+
+	var r0 store.AuditLogStore
+	if rf, ok := ret.Get(0).(func() store.AuditLogStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.AuditLogStore)
+		}
+	}
+
+	return r0
+}
+
+// Backfills provides a mock function with given fields:
+func (_m *Store) Backfills() ([]string, *model.AppError) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func() *model.AppError); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Bot provides a mock function with given fields:
 func (_m *Store) Bot() store.BotStore {
 	ret := _m.Called()
@@ -163,6 +206,29 @@ func (_m *Store) Compliance() store.ComplianceStore {
 	return r0
 }
 
+// CountBackfillRemaining provides a mock function with given fields: name
+func (_m *Store) CountBackfillRemaining(name string) (int64, *model.AppError) {
+	ret := _m.Called(name)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string) *model.AppError); ok {
+		r1 = rf(name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // DropAllTables provides a mock function with given fields:
 func (_m *Store) DropAllTables() {
 	_m.Called()
@@ -288,6 +354,22 @@ func (_m *Store) MarkSystemRanUnitTests() {
 	_m.Called()
 }
 
+// MasterOnly provides a mock function with given fields:
+func (_m *Store) MasterOnly() store.Store {
+	ret := _m.Called()
+
+	var r0 store.Store
+	if rf, ok := ret.Get(0).(func() store.Store); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.Store)
+		}
+	}
+
+	return r0
+}
+
 // OAuth provides a mock function with given fields:
 func (_m *Store) OAuth() store.OAuthStore {
 	ret := _m.Called()
@@ -320,6 +402,22 @@ func (_m *Store) Plugin() store.PluginStore {
 	return r0
 }
 
+// PluginConfigRevision provides a mock function with given fields:
+func (_m *Store) PluginConfigRevision() store.PluginConfigRevisionStore {
+	ret := _m.Called()
+
+	var r0 store.PluginConfigRevisionStore
+	if rf, ok := ret.Get(0).(func() store.PluginConfigRevisionStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PluginConfigRevisionStore)
+		}
+	}
+
+	return r0
+}
+
 // Post provides a mock function with given fields:
 func (_m *Store) Post() store.PostStore {
 	ret := _m.Called()
@@ -336,6 +434,54 @@ func (_m *Store) Post() store.PostStore {
 	return r0
 }
 
+// PostAcknowledgement provides a mock function with given fields:
+func (_m *Store) PostAcknowledgement() store.PostAcknowledgementStore {
+	ret := _m.Called()
+
+	var r0 store.PostAcknowledgementStore
+	if rf, ok := ret.Get(0).(func() store.PostAcknowledgementStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostAcknowledgementStore)
+		}
+	}
+
+	return r0
+}
+
+// PostPriority provides a mock function with given fields:
+func (_m *Store) PostPriority() store.PostPriorityStore {
+	ret := _m.Called()
+
+	var r0 store.PostPriorityStore
+	if rf, ok := ret.Get(0).(func() store.PostPriorityStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostPriorityStore)
+		}
+	}
+
+	return r0
+}
+
+// PostShard provides a mock function with given fields:
+func (_m *Store) PostShard() store.PostShardStore {
+	ret := _m.Called()
+
+	var r0 store.PostShardStore
+	if rf, ok := ret.Get(0).(func() store.PostShardStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostShardStore)
+		}
+	}
+
+	return r0
+}
+
 // Preference provides a mock function with given fields:
 func (_m *Store) Preference() store.PreferenceStore {
 	ret := _m.Called()
@@ -368,6 +514,22 @@ func (_m *Store) Reaction() store.ReactionStore {
 	return r0
 }
 
+// ReplicaStatuses provides a mock function with given fields:
+func (_m *Store) ReplicaStatuses() []*model.ReplicaStatus {
+	ret := _m.Called()
+
+	var r0 []*model.ReplicaStatus
+	if rf, ok := ret.Get(0).(func() []*model.ReplicaStatus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ReplicaStatus)
+		}
+	}
+
+	return r0
+}
+
 // Role provides a mock function with given fields:
 func (_m *Store) Role() store.RoleStore {
 	ret := _m.Called()
@@ -384,6 +546,38 @@ func (_m *Store) Role() store.RoleStore {
 	return r0
 }
 
+// RunBackfillBatch provides a mock function with given fields: name, afterKey, batchSize
+func (_m *Store) RunBackfillBatch(name string, afterKey []string, batchSize int) ([]string, int64, *model.AppError) {
+	ret := _m.Called(name, afterKey, batchSize)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, []string, int) []string); ok {
+		r0 = rf(name, afterKey, batchSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(string, []string, int) int64); ok {
+		r1 = rf(name, afterKey, batchSize)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 *model.AppError
+	if rf, ok := ret.Get(2).(func(string, []string, int) *model.AppError); ok {
+		r2 = rf(name, afterKey, batchSize)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(*model.AppError)
+		}
+	}
+
+	return r0, r1, r2
+}
+
 // Scheme provides a mock function with given fields:
 func (_m *Store) Scheme() store.SchemeStore {
 	ret := _m.Called()
@@ -105,6 +105,31 @@ func (_m *UserStore) ClearCaches() {
 	_m.Called()
 }
 
+// ClearExpiredCustomStatuses provides a mock function with given fields: now, limit
+func (_m *UserStore) ClearExpiredCustomStatuses(now int64, limit int) ([]string, *model.AppError) {
+	ret := _m.Called(now, limit)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(int64, int) []string); ok {
+		r0 = rf(now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(int64, int) *model.AppError); ok {
+		r1 = rf(now, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Count provides a mock function with given fields: options
 func (_m *UserStore) Count(options model.UserCountOptions) (int64, *model.AppError) {
 	ret := _m.Called(options)
@@ -853,6 +878,31 @@ func (_m *UserStore) GetUnreadCountForChannel(userId string, channelId string) (
 	return r0, r1
 }
 
+// GetUserIdsWithWorkingHoursEnded provides a mock function with given fields: now, limit
+func (_m *UserStore) GetUserIdsWithWorkingHoursEnded(now int64, limit int) ([]string, *model.AppError) {
+	ret := _m.Called(now, limit)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(int64, int) []string); ok {
+		r0 = rf(now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(int64, int) *model.AppError); ok {
+		r1 = rf(now, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetUsersBatchForIndexing provides a mock function with given fields: startTime, endTime, limit
 func (_m *UserStore) GetUsersBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.UserForIndexing, *model.AppError) {
 	ret := _m.Called(startTime, endTime, limit)
@@ -1114,6 +1164,38 @@ func (_m *UserStore) SearchWithoutTeam(term string, options *model.UserSearchOpt
 	return r0, r1
 }
 
+// SetCustomStatusExpiry provides a mock function with given fields: userId, expiresAt
+func (_m *UserStore) SetCustomStatusExpiry(userId string, expiresAt int64) *model.AppError {
+	ret := _m.Called(userId, expiresAt)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, int64) *model.AppError); ok {
+		r0 = rf(userId, expiresAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
+// SetWorkingHoursEnd provides a mock function with given fields: userId, endAt
+func (_m *UserStore) SetWorkingHoursEnd(userId string, endAt int64) *model.AppError {
+	ret := _m.Called(userId, endAt)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, int64) *model.AppError); ok {
+		r0 = rf(userId, endAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: user, allowRoleUpdate
 func (_m *UserStore) Update(user *model.User, allowRoleUpdate bool) (*model.UserUpdate, *model.AppError) {
 	ret := _m.Called(user, allowRoleUpdate)
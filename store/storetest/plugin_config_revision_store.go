@@ -0,0 +1,67 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginConfigRevisionStore(t *testing.T, ss store.Store) {
+	t.Run("SaveAndGet", func(t *testing.T) { testPluginConfigRevisionStoreSaveAndGet(t, ss) })
+	t.Run("GetForPlugin", func(t *testing.T) { testPluginConfigRevisionStoreGetForPlugin(t, ss) })
+}
+
+func testPluginConfigRevisionStoreSaveAndGet(t *testing.T, ss store.Store) {
+	revision, err := ss.PluginConfigRevision().Save(&model.PluginConfigRevision{
+		PluginId:    "com.example." + model.NewId(),
+		UserId:      model.NewId(),
+		Settings:    model.StringInterface{"token": "abc", "enabled": true},
+		ChangedKeys: model.StringArray{"token"},
+	})
+	require.Nil(t, err)
+	require.Len(t, revision.Id, 26)
+
+	received, err := ss.PluginConfigRevision().Get(revision.Id)
+	require.Nil(t, err)
+	assert.Equal(t, revision, received)
+
+	_, err = ss.PluginConfigRevision().Get(model.NewId())
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+
+	_, err = ss.PluginConfigRevision().Save(&model.PluginConfigRevision{})
+	assert.NotNil(t, err)
+}
+
+func testPluginConfigRevisionStoreGetForPlugin(t *testing.T, ss store.Store) {
+	pluginId := "com.example." + model.NewId()
+
+	var saved []*model.PluginConfigRevision
+	for i := 0; i < 3; i++ {
+		revision, err := ss.PluginConfigRevision().Save(&model.PluginConfigRevision{
+			PluginId: pluginId,
+			CreateAt: int64(1000 + i),
+			Settings: model.StringInterface{"count": float64(i)},
+		})
+		require.Nil(t, err)
+		saved = append(saved, revision)
+	}
+
+	_, err := ss.PluginConfigRevision().Save(&model.PluginConfigRevision{PluginId: "com.example." + model.NewId()})
+	require.Nil(t, err)
+
+	revisions, err := ss.PluginConfigRevision().GetForPlugin(pluginId, 0, 10)
+	require.Nil(t, err)
+	assert.Equal(t, []*model.PluginConfigRevision{saved[2], saved[1], saved[0]}, revisions)
+
+	revisions, err = ss.PluginConfigRevision().GetForPlugin(pluginId, 1, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []*model.PluginConfigRevision{saved[1]}, revisions)
+}
@@ -19,6 +19,11 @@ func TestPluginStore(t *testing.T, ss store.Store) {
 	t.Run("PluginDelete", func(t *testing.T) { testPluginDelete(t, ss) })
 	t.Run("PluginDeleteAll", func(t *testing.T) { testPluginDeleteAll(t, ss) })
 	t.Run("PluginDeleteExpired", func(t *testing.T) { testPluginDeleteExpired(t, ss) })
+	t.Run("PluginSetWithOptions", func(t *testing.T) { testPluginSetWithOptions(t, ss) })
+	t.Run("PluginSetWithOptionsAtomic", func(t *testing.T) { testPluginSetWithOptionsAtomic(t, ss) })
+	t.Run("PluginGetMultiSetMulti", func(t *testing.T) { testPluginGetMultiSetMulti(t, ss) })
+	t.Run("PluginListWithPrefix", func(t *testing.T) { testPluginListWithPrefix(t, ss) })
+	t.Run("PluginListExpired", func(t *testing.T) { testPluginListExpired(t, ss) })
 }
 
 func testPluginSaveGet(t *testing.T, ss store.Store) {
@@ -185,3 +190,217 @@ func testPluginDeleteExpired(t *testing.T, ss store.Store) {
 		assert.Equal(t, kv2.ExpireAt, received.ExpireAt)
 	}
 }
+
+func testPluginSetWithOptions(t *testing.T, ss store.Store) {
+	pluginId := model.NewId()
+	key := model.NewId()
+
+	defer func() {
+		_ = ss.Plugin().DeleteAllForPlugin(pluginId)
+	}()
+
+	ok, err := ss.Plugin().SetWithOptions(pluginId, key, []byte("value"), model.PluginKVSetOptions{ExpireInSeconds: 60})
+	require.Nil(t, err)
+	assert.True(t, ok)
+
+	received, err := ss.Plugin().Get(pluginId, key)
+	require.Nil(t, err)
+	assert.Equal(t, []byte("value"), received.Value)
+	assert.True(t, received.ExpireAt > model.GetMillis())
+
+	// Overwriting without an expiry clears it
+	ok, err = ss.Plugin().SetWithOptions(pluginId, key, []byte("value2"), model.PluginKVSetOptions{})
+	require.Nil(t, err)
+	assert.True(t, ok)
+
+	received, err = ss.Plugin().Get(pluginId, key)
+	require.Nil(t, err)
+	assert.Equal(t, []byte("value2"), received.Value)
+	assert.Equal(t, int64(0), received.ExpireAt)
+
+	// Setting nil removes the key
+	ok, err = ss.Plugin().SetWithOptions(pluginId, key, nil, model.PluginKVSetOptions{})
+	require.Nil(t, err)
+	assert.True(t, ok)
+
+	_, err = ss.Plugin().Get(pluginId, key)
+	require.NotNil(t, err)
+
+	// Invalid options are rejected
+	_, err = ss.Plugin().SetWithOptions(pluginId, key, []byte("value"), model.PluginKVSetOptions{OldValue: []byte("old")})
+	require.NotNil(t, err)
+}
+
+func testPluginSetWithOptionsAtomic(t *testing.T, ss store.Store) {
+	pluginId := model.NewId()
+	key := model.NewId()
+
+	defer func() {
+		_ = ss.Plugin().DeleteAllForPlugin(pluginId)
+	}()
+
+	t.Run("insert when missing", func(t *testing.T) {
+		ok, err := ss.Plugin().SetWithOptions(pluginId, key, []byte("value"), model.PluginKVSetOptions{Atomic: true})
+		require.Nil(t, err)
+		assert.True(t, ok)
+
+		ok, err = ss.Plugin().SetWithOptions(pluginId, key, []byte("other"), model.PluginKVSetOptions{Atomic: true})
+		require.Nil(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("update when matching", func(t *testing.T) {
+		ok, err := ss.Plugin().SetWithOptions(pluginId, key, []byte("value2"), model.PluginKVSetOptions{Atomic: true, OldValue: []byte("wrong")})
+		require.Nil(t, err)
+		assert.False(t, ok)
+
+		ok, err = ss.Plugin().SetWithOptions(pluginId, key, []byte("value2"), model.PluginKVSetOptions{Atomic: true, OldValue: []byte("value"), ExpireInSeconds: 60})
+		require.Nil(t, err)
+		assert.True(t, ok)
+
+		received, err := ss.Plugin().Get(pluginId, key)
+		require.Nil(t, err)
+		assert.Equal(t, []byte("value2"), received.Value)
+		assert.True(t, received.ExpireAt > 0)
+	})
+
+	t.Run("delete when matching", func(t *testing.T) {
+		ok, err := ss.Plugin().SetWithOptions(pluginId, key, nil, model.PluginKVSetOptions{Atomic: true, OldValue: []byte("value")})
+		require.Nil(t, err)
+		assert.False(t, ok)
+
+		ok, err = ss.Plugin().SetWithOptions(pluginId, key, nil, model.PluginKVSetOptions{Atomic: true, OldValue: []byte("value2")})
+		require.Nil(t, err)
+		assert.True(t, ok)
+
+		_, err = ss.Plugin().Get(pluginId, key)
+		require.NotNil(t, err)
+	})
+
+	t.Run("expired key is treated as missing", func(t *testing.T) {
+		expiredKey := model.NewId()
+		_, err := ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{
+			PluginId: pluginId,
+			Key:      expiredKey,
+			Value:    []byte("stale"),
+			ExpireAt: model.GetMillis() - 5000,
+		})
+		require.Nil(t, err)
+
+		ok, err := ss.Plugin().SetWithOptions(pluginId, expiredKey, []byte("fresh"), model.PluginKVSetOptions{Atomic: true, OldValue: []byte("stale")})
+		require.Nil(t, err)
+		assert.False(t, ok)
+
+		ok, err = ss.Plugin().SetWithOptions(pluginId, expiredKey, []byte("fresh"), model.PluginKVSetOptions{Atomic: true})
+		require.Nil(t, err)
+		assert.True(t, ok)
+
+		received, err := ss.Plugin().Get(pluginId, expiredKey)
+		require.Nil(t, err)
+		assert.Equal(t, []byte("fresh"), received.Value)
+	})
+}
+
+func testPluginGetMultiSetMulti(t *testing.T, ss store.Store) {
+	pluginId := model.NewId()
+
+	defer func() {
+		_ = ss.Plugin().DeleteAllForPlugin(pluginId)
+	}()
+
+	kvs := []*model.PluginKeyValue{
+		{PluginId: pluginId, Key: "a", Value: []byte("1")},
+		{PluginId: pluginId, Key: "b", Value: []byte("2")},
+		{PluginId: pluginId, Key: "c", Value: []byte("3"), ExpireAt: model.GetMillis() - 5000},
+	}
+	require.Nil(t, ss.Plugin().SetMulti(kvs))
+
+	received, err := ss.Plugin().GetMulti(pluginId, []string{"a", "b", "c", "d"})
+	require.Nil(t, err)
+	require.Len(t, received, 2)
+
+	values := map[string]string{}
+	for _, kv := range received {
+		values[kv.Key] = string(kv.Value)
+	}
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, values)
+
+	// Update one key and remove another in the same batch
+	require.Nil(t, ss.Plugin().SetMulti([]*model.PluginKeyValue{
+		{PluginId: pluginId, Key: "a", Value: []byte("4")},
+		{PluginId: pluginId, Key: "b", Value: nil},
+	}))
+
+	received, err = ss.Plugin().GetMulti(pluginId, []string{"a", "b"})
+	require.Nil(t, err)
+	require.Len(t, received, 1)
+	assert.Equal(t, "a", received[0].Key)
+	assert.Equal(t, []byte("4"), received[0].Value)
+
+	// An invalid entry fails the whole batch before anything is written
+	err = ss.Plugin().SetMulti([]*model.PluginKeyValue{
+		{PluginId: pluginId, Key: "e", Value: []byte("5")},
+		{PluginId: pluginId, Key: "", Value: []byte("6")},
+	})
+	require.NotNil(t, err)
+
+	_, err = ss.Plugin().Get(pluginId, "e")
+	require.NotNil(t, err)
+
+	received, err = ss.Plugin().GetMulti(pluginId, []string{})
+	require.Nil(t, err)
+	assert.Len(t, received, 0)
+}
+
+func testPluginListWithPrefix(t *testing.T, ss store.Store) {
+	pluginId := model.NewId()
+
+	defer func() {
+		_ = ss.Plugin().DeleteAllForPlugin(pluginId)
+	}()
+
+	for _, key := range []string{"user_1", "user_2", "user_3", "userx", "team_1", "user%1"} {
+		_, err := ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginId, Key: key, Value: []byte(key)})
+		require.Nil(t, err)
+	}
+	_, err := ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginId, Key: "user_4", Value: []byte("expired"), ExpireAt: model.GetMillis() - 5000})
+	require.Nil(t, err)
+
+	keys, err := ss.Plugin().ListWithPrefix(pluginId, "user_", 0, 100)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"user_1", "user_2", "user_3"}, keys)
+
+	keys, err = ss.Plugin().ListWithPrefix(pluginId, "user_", 1, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"user_2"}, keys)
+
+	keys, err = ss.Plugin().ListWithPrefix(pluginId, "user%", 0, 100)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"user%1"}, keys)
+
+	keys, err = ss.Plugin().ListWithPrefix(pluginId, "missing", 0, 100)
+	require.Nil(t, err)
+	assert.Len(t, keys, 0)
+}
+
+func testPluginListExpired(t *testing.T, ss store.Store) {
+	pluginId := model.NewId()
+
+	defer func() {
+		_ = ss.Plugin().DeleteAllForPlugin(pluginId)
+	}()
+
+	_, err := ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginId, Key: "live", Value: []byte("1")})
+	require.Nil(t, err)
+	_, err = ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginId, Key: "expired", Value: []byte("2"), ExpireAt: model.GetMillis() - 5000})
+	require.Nil(t, err)
+
+	// List keeps returning expired keys until they're purged
+	keys, err := ss.Plugin().List(pluginId, 0, 100)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"expired", "live"}, keys)
+
+	keys, err = ss.Plugin().ListWithPrefix(pluginId, "", 0, 100)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"live"}, keys)
+}
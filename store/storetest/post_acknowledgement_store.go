@@ -0,0 +1,81 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostAcknowledgementStore(t *testing.T, ss store.Store) {
+	t.Run("SaveAndDelete", func(t *testing.T) { testPostAcknowledgementStoreSaveAndDelete(t, ss) })
+	t.Run("GetForPosts", func(t *testing.T) { testPostAcknowledgementStoreGetForPosts(t, ss) })
+}
+
+func testPostAcknowledgementStoreSaveAndDelete(t *testing.T, ss store.Store) {
+	postId := model.NewId()
+	userId1 := model.NewId()
+	userId2 := model.NewId()
+
+	ack1, err := ss.PostAcknowledgement().Save(&model.PostAcknowledgement{PostId: postId, UserId: userId1, AcknowledgedAt: 1000})
+	require.Nil(t, err)
+	assert.Equal(t, int64(1000), ack1.AcknowledgedAt)
+
+	_, err = ss.PostAcknowledgement().Save(&model.PostAcknowledgement{PostId: postId, UserId: userId2, AcknowledgedAt: 2000})
+	require.Nil(t, err)
+
+	// Acknowledging again keeps the original time
+	ack1, err = ss.PostAcknowledgement().Save(&model.PostAcknowledgement{PostId: postId, UserId: userId1, AcknowledgedAt: 3000})
+	require.Nil(t, err)
+	assert.Equal(t, int64(1000), ack1.AcknowledgedAt)
+
+	acks, err := ss.PostAcknowledgement().GetForPost(postId)
+	require.Nil(t, err)
+	require.Len(t, acks, 2)
+	assert.Equal(t, userId1, acks[0].UserId)
+	assert.Equal(t, userId2, acks[1].UserId)
+
+	require.Nil(t, ss.PostAcknowledgement().Delete(postId, userId1))
+
+	acks, err = ss.PostAcknowledgement().GetForPost(postId)
+	require.Nil(t, err)
+	require.Len(t, acks, 1)
+	assert.Equal(t, userId2, acks[0].UserId)
+
+	_, err = ss.PostAcknowledgement().Save(&model.PostAcknowledgement{PostId: "invalid", UserId: userId1})
+	require.NotNil(t, err)
+}
+
+func testPostAcknowledgementStoreGetForPosts(t *testing.T, ss store.Store) {
+	postId1 := model.NewId()
+	postId2 := model.NewId()
+	userId1 := model.NewId()
+	userId2 := model.NewId()
+
+	_, err := ss.PostAcknowledgement().Save(&model.PostAcknowledgement{PostId: postId1, UserId: userId2, AcknowledgedAt: 2000})
+	require.Nil(t, err)
+	_, err = ss.PostAcknowledgement().Save(&model.PostAcknowledgement{PostId: postId1, UserId: userId1, AcknowledgedAt: 1000})
+	require.Nil(t, err)
+	_, err = ss.PostAcknowledgement().Save(&model.PostAcknowledgement{PostId: postId2, UserId: userId1, AcknowledgedAt: 3000})
+	require.Nil(t, err)
+	_, err = ss.PostAcknowledgement().Save(&model.PostAcknowledgement{PostId: model.NewId(), UserId: userId1, AcknowledgedAt: 4000})
+	require.Nil(t, err)
+
+	acks, err := ss.PostAcknowledgement().GetForPosts([]string{postId1, postId2})
+	require.Nil(t, err)
+	require.Len(t, acks, 3)
+	assert.Equal(t, postId1, acks[0].PostId)
+	assert.Equal(t, userId1, acks[0].UserId)
+	assert.Equal(t, postId1, acks[1].PostId)
+	assert.Equal(t, userId2, acks[1].UserId)
+	assert.Equal(t, postId2, acks[2].PostId)
+
+	acks, err = ss.PostAcknowledgement().GetForPosts([]string{})
+	require.Nil(t, err)
+	assert.Empty(t, acks)
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostPriorityStore(t *testing.T, ss store.Store) {
+	t.Run("SaveAndGet", func(t *testing.T) { testPostPriorityStoreSaveAndGet(t, ss) })
+	t.Run("GetForPosts", func(t *testing.T) { testPostPriorityStoreGetForPosts(t, ss) })
+	t.Run("PersistentNotifications", func(t *testing.T) { testPostPriorityStorePersistentNotifications(t, ss) })
+}
+
+func testPostPriorityStoreSaveAndGet(t *testing.T, ss store.Store) {
+	priority := &model.PostPriority{PostId: model.NewId(), ChannelId: model.NewId(), Priority: model.POST_PRIORITY_IMPORTANT, RequestedAck: true}
+
+	saved, err := ss.PostPriority().Save(priority)
+	require.Nil(t, err)
+	assert.Equal(t, model.POST_PRIORITY_IMPORTANT, saved.Priority)
+
+	received, err := ss.PostPriority().Get(priority.PostId)
+	require.Nil(t, err)
+	assert.Equal(t, priority.ChannelId, received.ChannelId)
+	assert.Equal(t, model.POST_PRIORITY_IMPORTANT, received.Priority)
+	assert.True(t, received.RequestedAck)
+
+	_, err = ss.PostPriority().Save(&model.PostPriority{PostId: model.NewId(), ChannelId: model.NewId(), Priority: "invalid"})
+	require.NotNil(t, err)
+
+	_, err = ss.PostPriority().Get(model.NewId())
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testPostPriorityStoreGetForPosts(t *testing.T, ss store.Store) {
+	important := &model.PostPriority{PostId: model.NewId(), ChannelId: model.NewId(), Priority: model.POST_PRIORITY_IMPORTANT}
+	_, err := ss.PostPriority().Save(important)
+	require.Nil(t, err)
+
+	urgent := &model.PostPriority{PostId: model.NewId(), ChannelId: model.NewId(), Priority: model.POST_PRIORITY_URGENT}
+	_, err = ss.PostPriority().Save(urgent)
+	require.Nil(t, err)
+
+	priorities, err := ss.PostPriority().GetForPosts([]string{important.PostId, urgent.PostId, model.NewId()})
+	require.Nil(t, err)
+	require.Len(t, priorities, 2)
+
+	byPostId := map[string]*model.PostPriority{}
+	for _, priority := range priorities {
+		byPostId[priority.PostId] = priority
+	}
+	assert.Equal(t, model.POST_PRIORITY_IMPORTANT, byPostId[important.PostId].Priority)
+	assert.Equal(t, model.POST_PRIORITY_URGENT, byPostId[urgent.PostId].Priority)
+
+	priorities, err = ss.PostPriority().GetForPosts([]string{})
+	require.Nil(t, err)
+	assert.Empty(t, priorities)
+}
+
+func testPostPriorityStorePersistentNotifications(t *testing.T, ss store.Store) {
+	urgent := &model.PostPriority{PostId: model.NewId(), ChannelId: model.NewId(), Priority: model.POST_PRIORITY_URGENT, LastNotifiedAt: 1000}
+	_, err := ss.PostPriority().Save(urgent)
+	require.Nil(t, err)
+	assert.True(t, urgent.RequestedAck, "urgent posts should always request acknowledgement")
+
+	important := &model.PostPriority{PostId: model.NewId(), ChannelId: model.NewId(), Priority: model.POST_PRIORITY_IMPORTANT, LastNotifiedAt: 1000}
+	_, err = ss.PostPriority().Save(important)
+	require.Nil(t, err)
+
+	containsPost := func(priorities []*model.PostPriority, postId string) bool {
+		for _, priority := range priorities {
+			if priority.PostId == postId {
+				return true
+			}
+		}
+		return false
+	}
+
+	priorities, err := ss.PostPriority().GetForPersistentNotifications(2000, 2)
+	require.Nil(t, err)
+	assert.True(t, containsPost(priorities, urgent.PostId))
+	assert.False(t, containsPost(priorities, important.PostId))
+
+	require.Nil(t, ss.PostPriority().MarkNotified(urgent.PostId, 3000))
+
+	priorities, err = ss.PostPriority().GetForPersistentNotifications(2000, 2)
+	require.Nil(t, err)
+	assert.False(t, containsPost(priorities, urgent.PostId), "should not return posts notified after the cutoff")
+
+	priorities, err = ss.PostPriority().GetForPersistentNotifications(4000, 2)
+	require.Nil(t, err)
+	assert.True(t, containsPost(priorities, urgent.PostId))
+
+	require.Nil(t, ss.PostPriority().MarkNotified(urgent.PostId, 3000))
+
+	priorities, err = ss.PostPriority().GetForPersistentNotifications(4000, 2)
+	require.Nil(t, err)
+	assert.False(t, containsPost(priorities, urgent.PostId), "should not return posts that reached the maximum count")
+}
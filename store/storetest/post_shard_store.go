@@ -0,0 +1,277 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostShardStore(t *testing.T, ss store.Store) {
+	t.Run("SaveAndGetChannelShard", func(t *testing.T) { testPostShardStoreSaveAndGetChannelShard(t, ss) })
+	t.Run("GetChannelShardsAfter", func(t *testing.T) { testPostShardStoreGetChannelShardsAfter(t, ss) })
+	t.Run("UpdateChannelLastPostAt", func(t *testing.T) { testPostShardStoreUpdateChannelLastPostAt(t, ss) })
+	t.Run("ChannelMirror", func(t *testing.T) { testPostShardStoreChannelMirror(t, ss) })
+	t.Run("ChannelData", func(t *testing.T) { testPostShardStoreChannelData(t, ss) })
+}
+
+func testPostShardStoreSaveAndGetChannelShard(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+
+	channelShard, err := ss.PostShard().GetChannelShard(channelId)
+	require.Nil(t, err)
+	assert.Equal(t, channelId, channelShard.ChannelId)
+	assert.Equal(t, "", channelShard.Shard)
+
+	_, err = ss.PostShard().SaveChannelShard(&model.ChannelShard{ChannelId: channelId, Shard: "shard1"})
+	require.Nil(t, err)
+
+	channelShard, err = ss.PostShard().GetChannelShard(channelId)
+	require.Nil(t, err)
+	assert.Equal(t, "shard1", channelShard.Shard)
+	assert.NotZero(t, channelShard.UpdateAt)
+
+	_, err = ss.PostShard().SaveChannelShard(&model.ChannelShard{ChannelId: channelId, Shard: "shard2", PreviousShard: "shard1"})
+	require.Nil(t, err)
+
+	channelShard, err = ss.PostShard().GetChannelShard(channelId)
+	require.Nil(t, err)
+	assert.Equal(t, "shard2", channelShard.Shard)
+	assert.Equal(t, "shard1", channelShard.PreviousShard)
+
+	moved, err := ss.PostShard().GetMovedChannelShards()
+	require.Nil(t, err)
+	found := false
+	for _, movedShard := range moved {
+		found = found || movedShard.ChannelId == channelId
+	}
+	assert.True(t, found)
+
+	require.Nil(t, ss.PostShard().DeleteChannelShard(channelId))
+
+	channelShard, err = ss.PostShard().GetChannelShard(channelId)
+	require.Nil(t, err)
+	assert.Equal(t, "", channelShard.Shard)
+}
+
+func testPostShardStoreGetChannelShardsAfter(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+
+	c1, err := ss.Channel().Save(&model.Channel{TeamId: teamId, DisplayName: "Channel1", Name: "zz" + model.NewId() + "b", Type: model.CHANNEL_OPEN}, -1)
+	require.Nil(t, err)
+	c2, err := ss.Channel().Save(&model.Channel{TeamId: teamId, DisplayName: "Channel2", Name: "zz" + model.NewId() + "b", Type: model.CHANNEL_OPEN}, -1)
+	require.Nil(t, err)
+
+	_, err = ss.PostShard().SaveChannelShard(&model.ChannelShard{ChannelId: c2.Id, Shard: "shard1"})
+	require.Nil(t, err)
+
+	channelShards := make(map[string]*model.ChannelShard)
+	afterChannelId := ""
+	for {
+		batch, err := ss.PostShard().GetChannelShardsAfter(afterChannelId, 10)
+		require.Nil(t, err)
+
+		for _, channelShard := range batch {
+			assert.True(t, channelShard.ChannelId > afterChannelId)
+			afterChannelId = channelShard.ChannelId
+			channelShards[channelShard.ChannelId] = channelShard
+		}
+
+		if len(batch) < 10 {
+			break
+		}
+	}
+
+	require.Contains(t, channelShards, c1.Id)
+	assert.Equal(t, "", channelShards[c1.Id].Shard)
+	assert.Equal(t, teamId, channelShards[c1.Id].TeamId)
+
+	require.Contains(t, channelShards, c2.Id)
+	assert.Equal(t, "shard1", channelShards[c2.Id].Shard)
+	assert.Equal(t, teamId, channelShards[c2.Id].TeamId)
+}
+
+func testPostShardStoreUpdateChannelLastPostAt(t *testing.T, ss store.Store) {
+	channel, err := ss.Channel().Save(&model.Channel{TeamId: model.NewId(), DisplayName: "Channel", Name: "zz" + model.NewId() + "b", Type: model.CHANNEL_OPEN}, -1)
+	require.Nil(t, err)
+
+	require.Nil(t, ss.PostShard().UpdateChannelLastPostAt(channel.Id, 2000, true))
+
+	channel, err = ss.Channel().GetFromMaster(channel.Id)
+	require.Nil(t, err)
+	assert.Equal(t, int64(2000), channel.LastPostAt)
+	assert.Equal(t, int64(1), channel.TotalMsgCount)
+
+	require.Nil(t, ss.PostShard().UpdateChannelLastPostAt(channel.Id, 1000, false))
+	require.Nil(t, ss.PostShard().UpdateChannelLastPostAt(channel.Id, 3000, false))
+
+	channel, err = ss.Channel().GetFromMaster(channel.Id)
+	require.Nil(t, err)
+	assert.Equal(t, int64(3000), channel.LastPostAt)
+	assert.Equal(t, int64(1), channel.TotalMsgCount)
+}
+
+func testPostShardStoreChannelMirror(t *testing.T, ss store.Store) {
+	channel := &model.Channel{TeamId: model.NewId(), DisplayName: "Mirrored", Name: "zz" + model.NewId() + "b", Type: model.CHANNEL_OPEN}
+	channel.PreSave()
+
+	require.Nil(t, ss.PostShard().SaveChannelMirror(channel))
+
+	mirror, err := ss.Channel().GetFromMaster(channel.Id)
+	require.Nil(t, err)
+	assert.Equal(t, "Mirrored", mirror.DisplayName)
+
+	channel.DisplayName = "Renamed"
+	require.Nil(t, ss.PostShard().SaveChannelMirror(channel))
+
+	mirror, err = ss.Channel().GetFromMaster(channel.Id)
+	require.Nil(t, err)
+	assert.Equal(t, "Renamed", mirror.DisplayName)
+
+	require.Nil(t, ss.PostShard().DeleteChannelMirror(channel.Id))
+
+	_, err = ss.Channel().GetFromMaster(channel.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testPostShardStoreChannelData(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	userId := model.NewId()
+
+	var posts []*model.Post
+	for i := 0; i < 3; i++ {
+		post, err := ss.Post().Save(&model.Post{ChannelId: channelId, UserId: userId, Message: "message " + model.NewId(), CreateAt: 1000 + int64(i)})
+		require.Nil(t, err)
+		posts = append(posts, post)
+	}
+
+	_, err := ss.Reaction().Save(&model.Reaction{UserId: userId, PostId: posts[0].Id, EmojiName: "smile"})
+	require.Nil(t, err)
+
+	info, err := ss.FileInfo().Save(&model.FileInfo{CreatorId: userId, PostId: posts[1].Id, Path: "file.txt"})
+	require.Nil(t, err)
+
+	// Archive one of the posts, along with a reaction to it.
+	archived, err := ss.Post().Save(&model.Post{ChannelId: channelId, UserId: userId, Message: "archived", CreateAt: 500})
+	require.Nil(t, err)
+	_, err = ss.Reaction().Save(&model.Reaction{UserId: userId, PostId: archived.Id, EmojiName: "wave"})
+	require.Nil(t, err)
+	archived, err = ss.Post().GetSingle(archived.Id)
+	require.Nil(t, err)
+	require.Nil(t, ss.Post().Archive(model.NewPostArchivePath(channelId, 2000), []*model.Post{archived}, model.GetMillis()+1))
+
+	var batches []*model.PostShardData
+	afterPostId := ""
+	for {
+		data, err := ss.PostShard().GetChannelData(channelId, afterPostId, 2)
+		require.Nil(t, err)
+		batches = append(batches, data)
+
+		if len(data.Posts) < 2 {
+			break
+		}
+		afterPostId = data.Posts[len(data.Posts)-1].Id
+	}
+
+	var postCount, reactionCount, fileInfoCount, indexCount int
+	for _, data := range batches {
+		postCount += len(data.Posts)
+		reactionCount += len(data.Reactions)
+		fileInfoCount += len(data.FileInfos)
+		indexCount += len(data.ArchiveIndexes)
+	}
+	assert.Equal(t, 3, postCount)
+	assert.Equal(t, 2, reactionCount)
+	assert.Equal(t, 1, fileInfoCount)
+	assert.Equal(t, 1, indexCount)
+
+	require.Nil(t, ss.PostShard().DeleteChannelData(channelId))
+
+	_, err = ss.Post().GetSingle(posts[0].Id)
+	require.NotNil(t, err)
+	_, err = ss.FileInfo().Get(info.Id)
+	require.NotNil(t, err)
+	paths, err := ss.Post().GetArchivePaths([]string{archived.Id})
+	require.Nil(t, err)
+	assert.Empty(t, paths)
+
+	// Saving the batches again, as when resuming a copy, skips what is already there.
+	for _, data := range batches {
+		require.Nil(t, ss.PostShard().SaveChannelData(data, 0))
+	}
+	for _, data := range batches {
+		require.Nil(t, ss.PostShard().SaveChannelData(data, 0))
+	}
+
+	for _, post := range posts {
+		saved, err := ss.Post().GetSingle(post.Id)
+		require.Nil(t, err)
+		assert.Equal(t, post.Message, saved.Message)
+	}
+
+	reactions, err := ss.Reaction().GetForPost(posts[0].Id, false)
+	require.Nil(t, err)
+	assert.Len(t, reactions, 1)
+
+	_, err = ss.FileInfo().Get(info.Id)
+	require.Nil(t, err)
+
+	paths, err = ss.Post().GetArchivePaths([]string{archived.Id})
+	require.Nil(t, err)
+	assert.Len(t, paths, 1)
+
+	t.Run("reactions removed before the move are deleted", func(t *testing.T) {
+		reacted, err := ss.Post().GetSingle(posts[0].Id)
+		require.Nil(t, err)
+		data := &model.PostShardData{Posts: []*model.Post{reacted}}
+
+		reactions, err := ss.Reaction().GetForPost(posts[0].Id, false)
+		require.Nil(t, err)
+		require.Len(t, reactions, 1)
+
+		// Reactions created after the move were made on this shard rather than removed from the other.
+		require.Nil(t, ss.PostShard().SaveChannelData(data, reactions[0].CreateAt))
+		reactions, err = ss.Reaction().GetForPost(posts[0].Id, false)
+		require.Nil(t, err)
+		require.Len(t, reactions, 1)
+
+		require.Nil(t, ss.PostShard().SaveChannelData(data, reactions[0].CreateAt+1))
+		reactions, err = ss.Reaction().GetForPost(posts[0].Id, false)
+		require.Nil(t, err)
+		assert.Empty(t, reactions)
+
+		reacted, err = ss.Post().GetSingle(posts[0].Id)
+		require.Nil(t, err)
+		assert.False(t, reacted.HasReactions)
+	})
+
+	t.Run("the last edit of a post is kept", func(t *testing.T) {
+		saved, err := ss.Post().GetSingle(posts[1].Id)
+		require.Nil(t, err)
+
+		stale := saved.Clone()
+		stale.Message = "stale"
+		stale.UpdateAt = saved.UpdateAt - 1
+		require.Nil(t, ss.PostShard().SaveChannelData(&model.PostShardData{Posts: []*model.Post{stale}}, 0))
+
+		current, err := ss.Post().GetSingle(posts[1].Id)
+		require.Nil(t, err)
+		assert.Equal(t, saved.Message, current.Message)
+
+		edited := saved.Clone()
+		edited.Message = "edited"
+		edited.UpdateAt = saved.UpdateAt + 1
+		require.Nil(t, ss.PostShard().SaveChannelData(&model.PostShardData{Posts: []*model.Post{edited}}, 0))
+
+		current, err = ss.Post().GetSingle(posts[1].Id)
+		require.Nil(t, err)
+		assert.Equal(t, "edited", current.Message)
+	})
+}
@@ -5,6 +5,7 @@ package storetest
 
 import (
 	"fmt"
+	"net/http"
 	"sort"
 	"strings"
 	"testing"
@@ -45,6 +46,10 @@ func TestPostStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("GetPostsByIds", func(t *testing.T) { testPostStoreGetPostsByIds(t, ss) })
 	t.Run("GetPostsBatchForIndexing", func(t *testing.T) { testPostStoreGetPostsBatchForIndexing(t, ss) })
 	t.Run("PermanentDeleteBatch", func(t *testing.T) { testPostStorePermanentDeleteBatch(t, ss) })
+	t.Run("GetThreadsForArchival", func(t *testing.T) { testPostStoreGetThreadsForArchival(t, ss) })
+	t.Run("ArchiveAndUnarchive", func(t *testing.T) { testPostStoreArchiveAndUnarchive(t, ss) })
+	t.Run("ReplaceArchive", func(t *testing.T) { testPostStoreReplaceArchive(t, ss) })
+	t.Run("SearchArchive", func(t *testing.T) { testPostStoreSearchArchive(t, ss) })
 	t.Run("GetOldest", func(t *testing.T) { testPostStoreGetOldest(t, ss) })
 	t.Run("TestGetMaxPostSize", func(t *testing.T) { testGetMaxPostSize(t, ss) })
 	t.Run("GetParentsForExportAfter", func(t *testing.T) { testPostStoreGetParentsForExportAfter(t, ss) })
@@ -723,29 +728,6 @@ func testPostStoreGetPostsWithDetails(t *testing.T, ss store.Store) {
 	if r2.Posts[o1.Id].Message != o1.Message {
 		t.Fatal("Missing parent")
 	}
-
-	// Run once to fill cache
-	_, err = ss.Post().GetPosts(o1.ChannelId, 0, 30, true)
-	require.Nil(t, err)
-
-	o6 := &model.Post{}
-	o6.ChannelId = o1.ChannelId
-	o6.UserId = model.NewId()
-	o6.Message = "zz" + model.NewId() + "b"
-	_, err = ss.Post().Save(o6)
-	require.Nil(t, err)
-
-	// Should only be 6 since we hit the cache
-	r3, err := ss.Post().GetPosts(o1.ChannelId, 0, 30, true)
-	require.Nil(t, err)
-	assert.Equal(t, 6, len(r3.Order))
-
-	ss.Post().InvalidateLastPostTimeCache(o1.ChannelId)
-
-	// Cache was invalidated, we should get all the posts
-	r4, err := ss.Post().GetPosts(o1.ChannelId, 0, 30, true)
-	require.Nil(t, err)
-	assert.Equal(t, 7, len(r4.Order))
 }
 
 func testPostStoreGetPostsBeforeAfter(t *testing.T, ss store.Store) {
@@ -1503,8 +1485,38 @@ func testPostStoreSearch(t *testing.T, ss store.Store) {
 			for _, expectedMessageResultId := range tc.expectedMessageResultIds {
 				assert.Contains(t, result.Order, expectedMessageResultId)
 			}
+
+			// Searching the resolved channels and users finds the same posts.
+			scope, err := ss.Post().ResolveSearchScope(teamId, userId, tc.searchParams)
+			require.Nil(t, err)
+			require.NotNil(t, scope.ChannelIds)
+			assert.Nil(t, tc.searchParams.ChannelIds)
+
+			result, err = ss.Post().Search(teamId, userId, scope)
+			require.Nil(t, err)
+			require.Len(t, result.Order, tc.expectedResultsCount)
+			for _, expectedMessageResultId := range tc.expectedMessageResultIds {
+				assert.Contains(t, result.Order, expectedMessageResultId)
+			}
 		})
 	}
+
+	t.Run("resolved scope without channels", func(t *testing.T) {
+		result, err := ss.Post().Search(teamId, userId, &model.SearchParams{Terms: "corey", ChannelIds: []string{}})
+		require.Nil(t, err)
+		assert.Empty(t, result.Order)
+	})
+
+	t.Run("resolved scope without users", func(t *testing.T) {
+		scope, err := ss.Post().ResolveSearchScope(teamId, userId, &model.SearchParams{Terms: "corey", FromUsers: []string{"nobody"}})
+		require.Nil(t, err)
+		require.NotNil(t, scope.UserIds)
+		assert.Empty(t, scope.UserIds)
+
+		result, err := ss.Post().Search(teamId, userId, scope)
+		require.Nil(t, err)
+		assert.Empty(t, result.Order)
+	})
 }
 
 func testUserCountsWithPostsByDay(t *testing.T, ss store.Store) {
@@ -1889,7 +1901,7 @@ func testPostStoreGetFlaggedPostsForTeam(t *testing.T, ss store.Store, s SqlSupp
 	}
 
 	// Manually truncate Channels table until testlib can handle cleanups
-	s.GetMaster().Exec("TRUNCATE Channels")
+	s.GetMaster().Exec("DELETE FROM Channels")
 }
 
 func testPostStoreGetFlaggedPosts(t *testing.T, ss store.Store) {
@@ -2457,6 +2469,209 @@ func testPostStorePermanentDeleteBatch(t *testing.T, ss store.Store) {
 	}
 }
 
+func testPostStoreGetThreadsForArchival(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	save := func(post *model.Post) *model.Post {
+		post.ChannelId = channelId
+		post.UserId = model.NewId()
+		post.Message = "zz" + model.NewId() + "b"
+		post, err := ss.Post().Save(post)
+		require.Nil(t, err)
+		return post
+	}
+
+	oldRoot := save(&model.Post{CreateAt: 1000})
+	oldReply := save(&model.Post{RootId: oldRoot.Id, CreateAt: 1500})
+	nextRoot := save(&model.Post{CreateAt: 1001})
+
+	activeRoot := save(&model.Post{CreateAt: 1000})
+	save(&model.Post{RootId: activeRoot.Id, CreateAt: 5000})
+
+	save(&model.Post{CreateAt: 1000, IsPinned: true})
+
+	pinnedReplyRoot := save(&model.Post{CreateAt: 1000})
+	save(&model.Post{RootId: pinnedReplyRoot.Id, CreateAt: 1200, IsPinned: true})
+
+	save(&model.Post{CreateAt: 5000})
+
+	getPostIds := func(afterCreateAt int64, afterId string) []string {
+		posts, err := ss.Post().GetThreadsForArchival(3000, afterCreateAt, afterId, 10000)
+		require.Nil(t, err)
+
+		var postIds []string
+		for _, post := range posts {
+			if post.ChannelId == channelId {
+				postIds = append(postIds, post.Id)
+			}
+		}
+		return postIds
+	}
+
+	assert.Equal(t, []string{oldRoot.Id, nextRoot.Id, oldReply.Id}, getPostIds(0, ""))
+	assert.Equal(t, []string{nextRoot.Id}, getPostIds(oldRoot.CreateAt, oldRoot.Id))
+	assert.Empty(t, getPostIds(nextRoot.CreateAt, nextRoot.Id))
+}
+
+func testPostStoreArchiveAndUnarchive(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	save := func(post *model.Post) *model.Post {
+		post.ChannelId = channelId
+		post.UserId = model.NewId()
+		post.Message = "zz" + model.NewId() + "b"
+		post, err := ss.Post().Save(post)
+		require.Nil(t, err)
+		return post
+	}
+
+	root := save(&model.Post{CreateAt: 1000})
+	reply := save(&model.Post{RootId: root.Id, CreateAt: 1500})
+	root, err := ss.Post().GetSingle(root.Id)
+	require.Nil(t, err)
+
+	path := model.NewPostArchivePath(channelId, model.GetMillis())
+	require.Nil(t, ss.Post().Archive(path, []*model.Post{root, reply}, 3000))
+
+	_, err = ss.Post().GetSingle(root.Id)
+	assert.NotNil(t, err)
+	_, err = ss.Post().GetSingle(reply.Id)
+	assert.NotNil(t, err)
+
+	paths, err := ss.Post().GetArchivePaths([]string{root.Id, reply.Id, model.NewId()})
+	require.Nil(t, err)
+	assert.Equal(t, map[string]string{root.Id: path, reply.Id: path}, paths)
+
+	channelPaths, err := ss.Post().GetArchivePathsForChannel(channelId)
+	require.Nil(t, err)
+	assert.Equal(t, []string{path}, channelPaths)
+
+	allPaths, err := ss.Post().GetArchivePathsForChannel("")
+	require.Nil(t, err)
+	assert.Contains(t, allPaths, path)
+
+	t.Run("posts changed since the end time are not archived", func(t *testing.T) {
+		activeRoot := save(&model.Post{CreateAt: 1000})
+		activeReply := save(&model.Post{RootId: activeRoot.Id, CreateAt: 5000})
+
+		err := ss.Post().Archive(model.NewPostArchivePath(channelId, model.GetMillis()), []*model.Post{activeRoot, activeReply}, 3000)
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusConflict, err.StatusCode)
+
+		_, err = ss.Post().GetSingle(activeRoot.Id)
+		assert.Nil(t, err)
+
+		paths, err := ss.Post().GetArchivePaths([]string{activeRoot.Id, activeReply.Id})
+		require.Nil(t, err)
+		assert.Empty(t, paths)
+	})
+
+	require.Nil(t, ss.Post().Unarchive(path, []*model.Post{root, reply}))
+
+	list, err := ss.Post().Get(reply.Id)
+	require.Nil(t, err)
+	assert.Len(t, list.Posts, 2)
+	assert.Equal(t, root.Message, list.Posts[root.Id].Message)
+
+	paths, err = ss.Post().GetArchivePaths([]string{root.Id, reply.Id})
+	require.Nil(t, err)
+	assert.Empty(t, paths)
+
+	// Restoring again is harmless, as the posts already there are skipped.
+	require.Nil(t, ss.Post().Unarchive(path, []*model.Post{root, reply}))
+}
+
+func testPostStoreReplaceArchive(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	save := func(post *model.Post) *model.Post {
+		post.ChannelId = channelId
+		post.UserId = model.NewId()
+		post.Message = "zz" + model.NewId() + "b"
+		post, err := ss.Post().Save(post)
+		require.Nil(t, err)
+		return post
+	}
+
+	root := save(&model.Post{CreateAt: 1000})
+	reply := save(&model.Post{RootId: root.Id, CreateAt: 1500})
+	root, err := ss.Post().GetSingle(root.Id)
+	require.Nil(t, err)
+
+	path := model.NewPostArchivePath(channelId, model.GetMillis())
+	require.Nil(t, ss.Post().Archive(path, []*model.Post{root, reply}, 3000))
+
+	paths, err := ss.Post().GetArchivePathsBefore(1200, 10000)
+	require.Nil(t, err)
+	assert.Contains(t, paths, path)
+
+	newPath := model.NewPostArchivePath(channelId, model.GetMillis()+1)
+	require.Nil(t, ss.Post().ReplaceArchive(path, newPath, []*model.Post{reply}))
+
+	archivePaths, err := ss.Post().GetArchivePaths([]string{root.Id, reply.Id})
+	require.Nil(t, err)
+	assert.Equal(t, map[string]string{reply.Id: newPath}, archivePaths)
+
+	paths, err = ss.Post().GetArchivePathsBefore(1200, 10000)
+	require.Nil(t, err)
+	assert.NotContains(t, paths, path)
+	assert.NotContains(t, paths, newPath)
+
+	require.Nil(t, ss.Post().ReplaceArchive(newPath, "", nil))
+
+	channelPaths, err := ss.Post().GetArchivePathsForChannel(channelId)
+	require.Nil(t, err)
+	assert.Empty(t, channelPaths)
+}
+
+func testPostStoreSearchArchive(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	userId := model.NewId()
+	save := func(post *model.Post) *model.Post {
+		post.ChannelId = channelId
+		post.UserId = userId
+		post, err := ss.Post().Save(post)
+		require.Nil(t, err)
+		return post
+	}
+
+	root := save(&model.Post{Message: "archived bluebird sighting #birds", Hashtags: "#birds", CreateAt: 1000})
+	reply := save(&model.Post{RootId: root.Id, Message: "another bluebird", CreateAt: 1500})
+	deleted := save(&model.Post{Message: "deleted bluebird", CreateAt: 1600, DeleteAt: 1700})
+	root, err := ss.Post().GetSingle(root.Id)
+	require.Nil(t, err)
+
+	path := model.NewPostArchivePath(channelId, model.GetMillis())
+	require.Nil(t, ss.Post().Archive(path, []*model.Post{root, reply, deleted}, model.GetMillis()+1))
+
+	search := func(params *model.SearchParams) []string {
+		if params.ChannelIds == nil {
+			params.ChannelIds = []string{channelId}
+		}
+		indexes, err := ss.Post().SearchArchive(model.NewId(), userId, params)
+		require.Nil(t, err)
+
+		var postIds []string
+		for _, index := range indexes {
+			assert.Equal(t, path, index.Path)
+			postIds = append(postIds, index.PostId)
+		}
+		return postIds
+	}
+
+	assert.Equal(t, []string{reply.Id, root.Id}, search(&model.SearchParams{Terms: "bluebird"}))
+	assert.Equal(t, []string{root.Id}, search(&model.SearchParams{Terms: "sighting"}))
+	assert.Equal(t, []string{root.Id}, search(&model.SearchParams{Terms: "#birds", IsHashtag: true}))
+	assert.Empty(t, search(&model.SearchParams{Terms: "#bird", IsHashtag: true}))
+	assert.Equal(t, []string{reply.Id}, search(&model.SearchParams{Terms: "bluebird", ExcludedTerms: "sighting"}))
+	assert.Empty(t, search(&model.SearchParams{Terms: "bluebird", ChannelIds: []string{}}))
+
+	list, err := ss.Post().Search(model.NewId(), userId, &model.SearchParams{Terms: "bluebird", ChannelIds: []string{channelId}})
+	require.Nil(t, err)
+	assert.Empty(t, list.Order, "archived posts are no longer in the Posts table")
+
+	// The posts moved back to the Posts table are no longer found in the archive.
+	require.Nil(t, ss.Post().Unarchive(path, []*model.Post{root, reply, deleted}))
+	assert.Empty(t, search(&model.SearchParams{Terms: "bluebird"}))
+}
+
 func testPostStoreGetOldest(t *testing.T, ss store.Store) {
 	o0 := &model.Post{}
 	o0.ChannelId = model.NewId()
@@ -2661,7 +2876,7 @@ func testPostStoreGetDirectPostParentsForExportAfter(t *testing.T, ss store.Stor
 	assert.Equal(t, p1.Message, r1[0].Message)
 
 	// Manually truncate Channels table until testlib can handle cleanups
-	s.GetMaster().Exec("TRUNCATE Channels")
+	s.GetMaster().Exec("DELETE FROM Channels")
 }
 
 func testPostStoreGetDirectPostParentsForExportAfterDeleted(t *testing.T, ss store.Store, s SqlSupplier) {
@@ -2729,7 +2944,7 @@ func testPostStoreGetDirectPostParentsForExportAfterDeleted(t *testing.T, ss sto
 	assert.Equal(t, 0, len(r1))
 
 	// Manually truncate Channels table until testlib can handle cleanups
-	s.GetMaster().Exec("TRUNCATE Channels")
+	s.GetMaster().Exec("DELETE FROM Channels")
 }
 
 func testPostStoreGetDirectPostParentsForExportAfterBatched(t *testing.T, ss store.Store, s SqlSupplier) {
@@ -2805,5 +3020,5 @@ func testPostStoreGetDirectPostParentsForExportAfterBatched(t *testing.T, ss sto
 	assert.ElementsMatch(t, postIds[:100], exportedPostIds)
 
 	// Manually truncate Channels table until testlib can handle cleanups
-	s.GetMaster().Exec("TRUNCATE Channels")
+	s.GetMaster().Exec("DELETE FROM Channels")
 }
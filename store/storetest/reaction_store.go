@@ -5,6 +5,7 @@ package storetest
 
 import (
 	"testing"
+	"time"
 
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/store"
@@ -33,6 +34,8 @@ func testReactionSave(t *testing.T, ss store.Store) {
 		PostId:    post.Id,
 		EmojiName: model.NewId(),
 	}
+	// Ensure update at timestamp changes
+	time.Sleep(time.Millisecond)
 	reaction, err := ss.Reaction().Save(reaction1)
 	if err != nil {
 		t.Fatal(err)
@@ -129,6 +132,9 @@ func testReactionDelete(t *testing.T, ss store.Store) {
 	}
 	firstUpdateAt := result.Posts[post.Id].UpdateAt
 
+	// Ensure update at timestamp changes
+	time.Sleep(time.Millisecond)
+
 	if _, err = ss.Reaction().Delete(reaction); err != nil {
 		t.Fatal(err)
 	}
@@ -9,6 +9,8 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"path/filepath"
+	"strings"
 
 	"database/sql"
 
@@ -48,6 +50,39 @@ func log(message string) {
 	}
 }
 
+// TestDriverEnabled returns true if the store tests should run against the given driver. All
+// drivers built in are tested unless TEST_DATABASE_DRIVERS lists the ones to test, e.g.
+// "postgres,sqlite3". SQLite is only built in with cgo and the sqlite build tag.
+func TestDriverEnabled(driver string) bool {
+	if !driverRegistered(driver) {
+		log(fmt.Sprintf("Skipping %s, its driver is not built in", driver))
+		return false
+	}
+
+	drivers := os.Getenv("TEST_DATABASE_DRIVERS")
+	if drivers == "" {
+		return true
+	}
+
+	for _, enabled := range strings.Split(drivers, ",") {
+		if strings.TrimSpace(enabled) == driver {
+			return true
+		}
+	}
+
+	return false
+}
+
+func driverRegistered(driver string) bool {
+	for _, registered := range sql.Drivers() {
+		if registered == driver {
+			return true
+		}
+	}
+
+	return false
+}
+
 // MySQLSettings returns the database settings to connect to the MySQL unittesting database.
 // The database name is generated randomly and must be created before use.
 func MySQLSettings() *model.SqlSettings {
@@ -77,6 +112,18 @@ func PostgreSQLSettings() *model.SqlSettings {
 	return databaseSettings("postgres", dsnUrl.String())
 }
 
+// SQLiteSettings returns the database settings to connect to a SQLite unittesting database.
+// The database is stored in a randomly named file created on first use.
+func SQLiteSettings() *model.SqlSettings {
+	dir := getEnv("TEST_DATABASE_SQLITE_DIR", os.TempDir())
+
+	return databaseSettings(model.DATABASE_DRIVER_SQLITE, filepath.Join(dir, "db"+model.NewId()+".db"))
+}
+
+func sqliteDSNPath(dsn string) string {
+	return strings.TrimPrefix(strings.SplitN(dsn, "?", 2)[0], "file:")
+}
+
 func mySQLRootDSN(dsn string) string {
 	rootPwd := getEnv("TEST_DATABASE_MYSQL_ROOT_PASSWD", defaultMysqlRootPWD)
 	cfg, err := mysql.ParseDSN(dsn)
@@ -186,6 +233,11 @@ func MakeSqlSettings(driver string) *model.SqlSettings {
 	case model.DATABASE_DRIVER_POSTGRES:
 		settings = PostgreSQLSettings()
 		dbName = postgreSQLDSNDatabase(*settings.DataSource)
+	case model.DATABASE_DRIVER_SQLITE:
+		// The database file is created when first connected to.
+		settings = SQLiteSettings()
+		log("Using temporary " + driver + " database " + sqliteDSNPath(*settings.DataSource))
+		return settings
 	default:
 		panic("unsupported driver " + driver)
 	}
@@ -221,6 +273,16 @@ func CleanupSqlSettings(settings *model.SqlSettings) {
 		dbName = mySQLDSNDatabase(*settings.DataSource)
 	case model.DATABASE_DRIVER_POSTGRES:
 		dbName = postgreSQLDSNDatabase(*settings.DataSource)
+	case model.DATABASE_DRIVER_SQLITE:
+		dbPath := sqliteDSNPath(*settings.DataSource)
+		for _, suffix := range []string{"", "-wal", "-shm"} {
+			if err := os.Remove(dbPath + suffix); err != nil && !os.IsNotExist(err) {
+				panic("failed to remove temporary database " + dbPath + ": " + err.Error())
+			}
+		}
+
+		log("Removed temporary database " + dbPath)
+		return
 	default:
 		panic("unsupported driver " + driver)
 	}
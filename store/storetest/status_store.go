@@ -15,6 +15,7 @@ import (
 func TestStatusStore(t *testing.T, ss store.Store) {
 	t.Run("", func(t *testing.T) { testStatusStore(t, ss) })
 	t.Run("ActiveUserCount", func(t *testing.T) { testActiveUserCount(t, ss) })
+	t.Run("UpdateExpiredDNDStatuses", func(t *testing.T) { testUpdateExpiredDNDStatuses(t, ss) })
 }
 
 func testStatusStore(t *testing.T, ss store.Store) {
@@ -69,6 +70,53 @@ func testActiveUserCount(t *testing.T, ss store.Store) {
 	}
 }
 
+func testUpdateExpiredDNDStatuses(t *testing.T, ss store.Store) {
+	now := model.GetMillis()
+
+	expired := &model.Status{UserId: model.NewId(), Status: model.STATUS_DND, Manual: true, DNDEndTime: now - 1000, PrevStatus: model.STATUS_AWAY}
+	require.Nil(t, ss.Status().SaveOrUpdate(expired))
+
+	expiredWithoutPrevious := &model.Status{UserId: model.NewId(), Status: model.STATUS_DND, Manual: true, DNDEndTime: now - 1000}
+	require.Nil(t, ss.Status().SaveOrUpdate(expiredWithoutPrevious))
+
+	notExpired := &model.Status{UserId: model.NewId(), Status: model.STATUS_DND, Manual: true, DNDEndTime: now + 60*1000, PrevStatus: model.STATUS_ONLINE}
+	require.Nil(t, ss.Status().SaveOrUpdate(notExpired))
+
+	untimed := &model.Status{UserId: model.NewId(), Status: model.STATUS_DND, Manual: true}
+	require.Nil(t, ss.Status().SaveOrUpdate(untimed))
+
+	statuses, err := ss.Status().UpdateExpiredDNDStatuses(now)
+	require.Nil(t, err)
+
+	updated := map[string]*model.Status{}
+	for _, status := range statuses {
+		updated[status.UserId] = status
+	}
+	require.Contains(t, updated, expired.UserId)
+	require.Contains(t, updated, expiredWithoutPrevious.UserId)
+	require.NotContains(t, updated, notExpired.UserId)
+	require.NotContains(t, updated, untimed.UserId)
+	require.Equal(t, model.STATUS_AWAY, updated[expired.UserId].Status)
+
+	status, err := ss.Status().Get(expired.UserId)
+	require.Nil(t, err)
+	require.Equal(t, model.STATUS_AWAY, status.Status)
+	require.Equal(t, int64(0), status.DNDEndTime)
+	require.Equal(t, "", status.PrevStatus)
+
+	status, err = ss.Status().Get(expiredWithoutPrevious.UserId)
+	require.Nil(t, err)
+	require.Equal(t, model.STATUS_OFFLINE, status.Status)
+
+	status, err = ss.Status().Get(notExpired.UserId)
+	require.Nil(t, err)
+	require.Equal(t, model.STATUS_DND, status.Status)
+
+	status, err = ss.Status().Get(untimed.UserId)
+	require.Nil(t, err)
+	require.Equal(t, model.STATUS_DND, status.Status)
+}
+
 type ByUserId []*model.Status
 
 func (s ByUserId) Len() int           { return len(s) }
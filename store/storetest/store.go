@@ -6,6 +6,7 @@ package storetest
 import (
 	"github.com/stretchr/testify/mock"
 
+	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/store"
 	"github.com/mattermost/mattermost-server/store/storetest/mocks"
 )
@@ -43,6 +44,11 @@ type Store struct {
 	GroupStore                mocks.GroupStore
 	UserTermsOfServiceStore   mocks.UserTermsOfServiceStore
 	LinkMetadataStore         mocks.LinkMetadataStore
+	PostPriorityStore         mocks.PostPriorityStore
+	PostAcknowledgementStore  mocks.PostAcknowledgementStore
+	PluginConfigRevisionStore mocks.PluginConfigRevisionStore
+	PostShardStore            mocks.PostShardStore
+	AuditLogStore             mocks.AuditLogStore
 }
 
 func (s *Store) Team() store.TeamStore                             { return &s.TeamStore }
@@ -76,20 +82,42 @@ func (s *Store) UserTermsOfService() store.UserTermsOfServiceStore { return &s.U
 func (s *Store) ChannelMemberHistory() store.ChannelMemberHistoryStore {
 	return &s.ChannelMemberHistoryStore
 }
-func (s *Store) Group() store.GroupStore               { return &s.GroupStore }
-func (s *Store) LinkMetadata() store.LinkMetadataStore { return &s.LinkMetadataStore }
-func (s *Store) MarkSystemRanUnitTests()               { /* do nothing */ }
-func (s *Store) Close()                                { /* do nothing */ }
-func (s *Store) LockToMaster()                         { /* do nothing */ }
-func (s *Store) UnlockFromMaster()                     { /* do nothing */ }
-func (s *Store) DropAllTables()                        { /* do nothing */ }
-func (s *Store) TotalMasterDbConnections() int         { return 1 }
-func (s *Store) TotalReadDbConnections() int           { return 1 }
-func (s *Store) TotalSearchDbConnections() int         { return 1 }
-func (s *Store) GetCurrentSchemaVersion() string       { return "" }
+func (s *Store) PostAcknowledgement() store.PostAcknowledgementStore {
+	return &s.PostAcknowledgementStore
+}
+func (s *Store) PluginConfigRevision() store.PluginConfigRevisionStore {
+	return &s.PluginConfigRevisionStore
+}
+func (s *Store) PostShard() store.PostShardStore {
+	return &s.PostShardStore
+}
+func (s *Store) AuditLog() store.AuditLogStore {
+	return &s.AuditLogStore
+}
+func (s *Store) Group() store.GroupStore                 { return &s.GroupStore }
+func (s *Store) LinkMetadata() store.LinkMetadataStore   { return &s.LinkMetadataStore }
+func (s *Store) PostPriority() store.PostPriorityStore   { return &s.PostPriorityStore }
+func (s *Store) MarkSystemRanUnitTests()                 { /* do nothing */ }
+func (s *Store) Close()                                  { /* do nothing */ }
+func (s *Store) LockToMaster()                           { /* do nothing */ }
+func (s *Store) UnlockFromMaster()                       { /* do nothing */ }
+func (s *Store) DropAllTables()                          { /* do nothing */ }
+func (s *Store) TotalMasterDbConnections() int           { return 1 }
+func (s *Store) TotalReadDbConnections() int             { return 1 }
+func (s *Store) TotalSearchDbConnections() int           { return 1 }
+func (s *Store) GetCurrentSchemaVersion() string         { return "" }
+func (s *Store) ReplicaStatuses() []*model.ReplicaStatus { return nil }
+func (s *Store) MasterOnly() store.Store                 { return s }
 func (s *Store) CheckIntegrity() <-chan store.IntegrityCheckResult {
 	return make(chan store.IntegrityCheckResult)
 }
+func (s *Store) Backfills() ([]string, *model.AppError) { return []string{}, nil }
+func (s *Store) CountBackfillRemaining(name string) (int64, *model.AppError) {
+	return 0, nil
+}
+func (s *Store) RunBackfillBatch(name string, afterKey []string, batchSize int) ([]string, int64, *model.AppError) {
+	return nil, 0, nil
+}
 
 func (s *Store) AssertExpectations(t mock.TestingT) bool {
 	return mock.AssertExpectationsForObjects(t,
@@ -44,6 +44,8 @@ func TestUserStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("UpdateFailedPasswordAttempts", func(t *testing.T) { testUserStoreUpdateFailedPasswordAttempts(t, ss) })
 	t.Run("Get", func(t *testing.T) { testUserStoreGet(t, ss) })
 	t.Run("GetAllUsingAuthService", func(t *testing.T) { testGetAllUsingAuthService(t, ss) })
+	t.Run("ClearExpiredCustomStatuses", func(t *testing.T) { testUserStoreClearExpiredCustomStatuses(t, ss) })
+	t.Run("GetUserIdsWithWorkingHoursEnded", func(t *testing.T) { testUserStoreGetUserIdsWithWorkingHoursEnded(t, ss) })
 	t.Run("GetAllProfiles", func(t *testing.T) { testUserStoreGetAllProfiles(t, ss) })
 	t.Run("GetProfiles", func(t *testing.T) { testUserStoreGetProfiles(t, ss) })
 	t.Run("GetProfilesInChannel", func(t *testing.T) { testUserStoreGetProfilesInChannel(t, ss) })
@@ -236,6 +238,9 @@ func testUserStoreUpdateUpdateAt(t *testing.T, ss store.Store) {
 	_, err = ss.Team().SaveMember(&model.TeamMember{TeamId: model.NewId(), UserId: u1.Id}, -1)
 	require.Nil(t, err)
 
+	// Ensure update at timestamp changes
+	time.Sleep(time.Millisecond)
+
 	if _, err = ss.User().UpdateUpdateAt(u1.Id); err != nil {
 		t.Fatal(err)
 	}
@@ -377,6 +382,86 @@ func testGetAllUsingAuthService(t *testing.T, ss store.Store) {
 	})
 }
 
+func testUserStoreClearExpiredCustomStatuses(t *testing.T, ss store.Store) {
+	now := model.GetMillis()
+
+	u1 := &model.User{Email: MakeEmail(), Username: "u1" + model.NewId()}
+	u1.SetCustomStatus(&model.CustomStatus{Emoji: "palm_tree", Text: "On vacation", ExpiresAt: now - 1000})
+	u1, err := ss.User().Save(u1)
+	require.Nil(t, err)
+	defer func() { require.Nil(t, ss.User().PermanentDelete(u1.Id)) }()
+	require.Nil(t, ss.User().SetCustomStatusExpiry(u1.Id, now-1000))
+
+	u2 := &model.User{Email: MakeEmail(), Username: "u2" + model.NewId()}
+	u2.SetCustomStatus(&model.CustomStatus{Text: "In a meeting", ExpiresAt: now + 60000})
+	u2, err = ss.User().Save(u2)
+	require.Nil(t, err)
+	defer func() { require.Nil(t, ss.User().PermanentDelete(u2.Id)) }()
+	require.Nil(t, ss.User().SetCustomStatusExpiry(u2.Id, now+60000))
+
+	// The status of u3 was replaced by one that doesn't expire since its expiry was recorded.
+	u3 := &model.User{Email: MakeEmail(), Username: "u3" + model.NewId()}
+	u3.SetCustomStatus(&model.CustomStatus{Text: "Working"})
+	u3, err = ss.User().Save(u3)
+	require.Nil(t, err)
+	defer func() { require.Nil(t, ss.User().PermanentDelete(u3.Id)) }()
+	require.Nil(t, ss.User().SetCustomStatusExpiry(u3.Id, now-1000))
+
+	userIds, err := ss.User().ClearExpiredCustomStatuses(now, 100)
+	require.Nil(t, err)
+	assert.Contains(t, userIds, u1.Id)
+	assert.NotContains(t, userIds, u2.Id, "should not clear statuses that haven't expired")
+	assert.NotContains(t, userIds, u3.Id, "should not clear statuses that don't expire")
+
+	user, err := ss.User().Get(u1.Id)
+	require.Nil(t, err)
+	assert.Nil(t, user.GetCustomStatus())
+
+	user, err = ss.User().Get(u2.Id)
+	require.Nil(t, err)
+	assert.NotNil(t, user.GetCustomStatus())
+
+	user, err = ss.User().Get(u3.Id)
+	require.Nil(t, err)
+	assert.NotNil(t, user.GetCustomStatus())
+
+	userIds, err = ss.User().ClearExpiredCustomStatuses(now, 100)
+	require.Nil(t, err)
+	assert.NotContains(t, userIds, u1.Id, "should only clear expired statuses once")
+
+	require.Nil(t, ss.User().SetCustomStatusExpiry(u2.Id, 0))
+	userIds, err = ss.User().ClearExpiredCustomStatuses(now+120000, 100)
+	require.Nil(t, err)
+	assert.NotContains(t, userIds, u2.Id, "should not clear statuses whose expiry was removed")
+}
+
+func testUserStoreGetUserIdsWithWorkingHoursEnded(t *testing.T, ss store.Store) {
+	now := model.GetMillis()
+
+	ended := model.NewId()
+	require.Nil(t, ss.User().SetWorkingHoursEnd(ended, now-1000))
+	defer func() { require.Nil(t, ss.User().SetWorkingHoursEnd(ended, 0)) }()
+
+	notEnded := model.NewId()
+	require.Nil(t, ss.User().SetWorkingHoursEnd(notEnded, now+60*1000))
+	defer func() { require.Nil(t, ss.User().SetWorkingHoursEnd(notEnded, 0)) }()
+
+	removed := model.NewId()
+	require.Nil(t, ss.User().SetWorkingHoursEnd(removed, now-1000))
+	require.Nil(t, ss.User().SetWorkingHoursEnd(removed, 0))
+
+	userIds, err := ss.User().GetUserIdsWithWorkingHoursEnded(now, 100)
+	require.Nil(t, err)
+	assert.Contains(t, userIds, ended)
+	assert.NotContains(t, userIds, notEnded, "should not return users whose working hours haven't ended")
+	assert.NotContains(t, userIds, removed, "should not return users without working hours")
+
+	require.Nil(t, ss.User().SetWorkingHoursEnd(ended, now+60*1000))
+	userIds, err = ss.User().GetUserIdsWithWorkingHoursEnded(now, 100)
+	require.Nil(t, err)
+	assert.NotContains(t, userIds, ended, "should return users by their latest working hours end")
+}
+
 func sanitized(user *model.User) *model.User {
 	clonedUser := model.UserFromJson(strings.NewReader(user.ToJson()))
 	clonedUser.AuthData = new(string)
@@ -1262,6 +1347,9 @@ func testUserStoreGetProfilesByIds(t *testing.T, ss store.Store) {
 	_, err = ss.Team().SaveMember(&model.TeamMember{TeamId: teamId, UserId: u2.Id}, -1)
 	require.Nil(t, err)
 
+	// Ensure the users are ordered by creation
+	time.Sleep(time.Millisecond)
+
 	u3, err := ss.User().Save(&model.User{
 		Email:    MakeEmail(),
 		Username: "u3" + model.NewId(),
@@ -2184,6 +2272,9 @@ func testUserStoreGetNewUsersForTeam(t *testing.T, ss store.Store) {
 	_, err = ss.Team().SaveMember(&model.TeamMember{TeamId: teamId, UserId: u1.Id}, -1)
 	require.Nil(t, err)
 
+	// Ensure the users are ordered by creation
+	time.Sleep(time.Millisecond)
+
 	u2, err := ss.User().Save(&model.User{
 		Email:    MakeEmail(),
 		Username: "u2" + model.NewId(),
@@ -2193,6 +2284,9 @@ func testUserStoreGetNewUsersForTeam(t *testing.T, ss store.Store) {
 	_, err = ss.Team().SaveMember(&model.TeamMember{TeamId: teamId, UserId: u2.Id}, -1)
 	require.Nil(t, err)
 
+	// Ensure the users are ordered by creation
+	time.Sleep(time.Millisecond)
+
 	u3, err := ss.User().Save(&model.User{
 		Email:    MakeEmail(),
 		Username: "u3" + model.NewId(),
@@ -4877,6 +4971,9 @@ func testUserStoreResetLastPictureUpdate(t *testing.T, ss store.Store) {
 	assert.NotZero(t, user.LastPictureUpdate)
 	assert.NotZero(t, user.UpdateAt)
 
+	// Ensure update at timestamp changes
+	time.Sleep(time.Millisecond)
+
 	err = ss.User().ResetLastPictureUpdate(u1.Id)
 	require.Nil(t, err)
 
@@ -11,12 +11,14 @@ import (
 
 	"github.com/mattermost/mattermost-server/einterfaces"
 	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/services/tracing"
 )
 
 type TimerLayer struct {
 	Store
 	Metrics                   einterfaces.MetricsInterface
 	AuditStore                AuditStore
+	AuditLogStore             AuditLogStore
 	BotStore                  BotStore
 	ChannelStore              ChannelStore
 	ChannelMemberHistoryStore ChannelMemberHistoryStore
@@ -32,7 +34,11 @@ type TimerLayer struct {
 	LinkMetadataStore         LinkMetadataStore
 	OAuthStore                OAuthStore
 	PluginStore               PluginStore
+	PluginConfigRevisionStore PluginConfigRevisionStore
 	PostStore                 PostStore
+	PostAcknowledgementStore  PostAcknowledgementStore
+	PostPriorityStore         PostPriorityStore
+	PostShardStore            PostShardStore
 	PreferenceStore           PreferenceStore
 	ReactionStore             ReactionStore
 	RoleStore                 RoleStore
@@ -47,12 +53,23 @@ type TimerLayer struct {
 	UserAccessTokenStore      UserAccessTokenStore
 	UserTermsOfServiceStore   UserTermsOfServiceStore
 	WebhookStore              WebhookStore
+
+	masterOnly *TimerLayer
+	span       *tracing.Span
+
+	// queryTimeouts holds, for the store methods whose query timeout is overridden, the view of
+	// the wrapped store applying it. See SetQueryTimeouts.
+	queryTimeouts map[string]Store
 }
 
 func (s *TimerLayer) Audit() AuditStore {
 	return s.AuditStore
 }
 
+func (s *TimerLayer) AuditLog() AuditLogStore {
+	return s.AuditLogStore
+}
+
 func (s *TimerLayer) Bot() BotStore {
 	return s.BotStore
 }
@@ -113,10 +130,26 @@ func (s *TimerLayer) Plugin() PluginStore {
 	return s.PluginStore
 }
 
+func (s *TimerLayer) PluginConfigRevision() PluginConfigRevisionStore {
+	return s.PluginConfigRevisionStore
+}
+
 func (s *TimerLayer) Post() PostStore {
 	return s.PostStore
 }
 
+func (s *TimerLayer) PostAcknowledgement() PostAcknowledgementStore {
+	return s.PostAcknowledgementStore
+}
+
+func (s *TimerLayer) PostPriority() PostPriorityStore {
+	return s.PostPriorityStore
+}
+
+func (s *TimerLayer) PostShard() PostShardStore {
+	return s.PostShardStore
+}
+
 func (s *TimerLayer) Preference() PreferenceStore {
 	return s.PreferenceStore
 }
@@ -178,6 +211,11 @@ type TimerLayerAuditStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerAuditLogStore struct {
+	AuditLogStore
+	Root *TimerLayer
+}
+
 type TimerLayerBotStore struct {
 	BotStore
 	Root *TimerLayer
@@ -253,11 +291,31 @@ type TimerLayerPluginStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerPluginConfigRevisionStore struct {
+	PluginConfigRevisionStore
+	Root *TimerLayer
+}
+
 type TimerLayerPostStore struct {
 	PostStore
 	Root *TimerLayer
 }
 
+type TimerLayerPostAcknowledgementStore struct {
+	PostAcknowledgementStore
+	Root *TimerLayer
+}
+
+type TimerLayerPostPriorityStore struct {
+	PostPriorityStore
+	Root *TimerLayer
+}
+
+type TimerLayerPostShardStore struct {
+	PostShardStore
+	Root *TimerLayer
+}
+
 type TimerLayerPreferenceStore struct {
 	PreferenceStore
 	Root *TimerLayer
@@ -329,9 +387,14 @@ type TimerLayerWebhookStore struct {
 }
 
 func (s *TimerLayerAuditStore) Get(user_id string, offset int, limit int) (model.Audits, *model.AppError) {
+	childStore := s.AuditStore
+	if view, ok := s.Root.queryTimeouts["AuditStore.Get"]; ok {
+		childStore = view.Audit()
+	}
+	span := s.Root.span.StartChild("AuditStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.AuditStore.Get(user_id, offset, limit)
+	resultVar0, resultVar1 := childStore.Get(user_id, offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -342,13 +405,19 @@ func (s *TimerLayerAuditStore) Get(user_id string, offset int, limit int) (model
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("AuditStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerAuditStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	childStore := s.AuditStore
+	if view, ok := s.Root.queryTimeouts["AuditStore.PermanentDeleteBatch"]; ok {
+		childStore = view.Audit()
+	}
+	span := s.Root.span.StartChild("AuditStore.PermanentDeleteBatch", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.AuditStore.PermanentDeleteBatch(endTime, limit)
+	resultVar0, resultVar1 := childStore.PermanentDeleteBatch(endTime, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -359,13 +428,19 @@ func (s *TimerLayerAuditStore) PermanentDeleteBatch(endTime int64, limit int64)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("AuditStore.PermanentDeleteBatch", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerAuditStore) PermanentDeleteByUser(userId string) *model.AppError {
+	childStore := s.AuditStore
+	if view, ok := s.Root.queryTimeouts["AuditStore.PermanentDeleteByUser"]; ok {
+		childStore = view.Audit()
+	}
+	span := s.Root.span.StartChild("AuditStore.PermanentDeleteByUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.AuditStore.PermanentDeleteByUser(userId)
+	resultVar0 := childStore.PermanentDeleteByUser(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -376,13 +451,19 @@ func (s *TimerLayerAuditStore) PermanentDeleteByUser(userId string) *model.AppEr
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("AuditStore.PermanentDeleteByUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerAuditStore) Save(audit *model.Audit) *model.AppError {
+	childStore := s.AuditStore
+	if view, ok := s.Root.queryTimeouts["AuditStore.Save"]; ok {
+		childStore = view.Audit()
+	}
+	span := s.Root.span.StartChild("AuditStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.AuditStore.Save(audit)
+	resultVar0 := childStore.Save(audit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -393,13 +474,65 @@ func (s *TimerLayerAuditStore) Save(audit *model.Audit) *model.AppError {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("AuditStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
+func (s *TimerLayerAuditLogStore) Append(record *model.AuditLogRecord, key []byte) (*model.AuditLogRecord, *model.AppError) {
+	childStore := s.AuditLogStore
+	if view, ok := s.Root.queryTimeouts["AuditLogStore.Append"]; ok {
+		childStore = view.AuditLog()
+	}
+	span := s.Root.span.StartChild("AuditLogStore.Append", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.Append(record, key)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditLogStore.Append", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerAuditLogStore) GetAfter(sequence int64, limit int) ([]*model.AuditLogRecord, *model.AppError) {
+	childStore := s.AuditLogStore
+	if view, ok := s.Root.queryTimeouts["AuditLogStore.GetAfter"]; ok {
+		childStore = view.AuditLog()
+	}
+	span := s.Root.span.StartChild("AuditLogStore.GetAfter", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetAfter(sequence, limit)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditLogStore.GetAfter", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerBotStore) Get(userId string, includeDeleted bool) (*model.Bot, *model.AppError) {
+	childStore := s.BotStore
+	if view, ok := s.Root.queryTimeouts["BotStore.Get"]; ok {
+		childStore = view.Bot()
+	}
+	span := s.Root.span.StartChild("BotStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.BotStore.Get(userId, includeDeleted)
+	resultVar0, resultVar1 := childStore.Get(userId, includeDeleted)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -410,13 +543,19 @@ func (s *TimerLayerBotStore) Get(userId string, includeDeleted bool) (*model.Bot
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("BotStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerBotStore) GetAll(options *model.BotGetOptions) ([]*model.Bot, *model.AppError) {
+	childStore := s.BotStore
+	if view, ok := s.Root.queryTimeouts["BotStore.GetAll"]; ok {
+		childStore = view.Bot()
+	}
+	span := s.Root.span.StartChild("BotStore.GetAll", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.BotStore.GetAll(options)
+	resultVar0, resultVar1 := childStore.GetAll(options)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -427,13 +566,19 @@ func (s *TimerLayerBotStore) GetAll(options *model.BotGetOptions) ([]*model.Bot,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("BotStore.GetAll", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerBotStore) PermanentDelete(userId string) *model.AppError {
+	childStore := s.BotStore
+	if view, ok := s.Root.queryTimeouts["BotStore.PermanentDelete"]; ok {
+		childStore = view.Bot()
+	}
+	span := s.Root.span.StartChild("BotStore.PermanentDelete", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.BotStore.PermanentDelete(userId)
+	resultVar0 := childStore.PermanentDelete(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -444,13 +589,19 @@ func (s *TimerLayerBotStore) PermanentDelete(userId string) *model.AppError {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("BotStore.PermanentDelete", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerBotStore) Save(bot *model.Bot) (*model.Bot, *model.AppError) {
+	childStore := s.BotStore
+	if view, ok := s.Root.queryTimeouts["BotStore.Save"]; ok {
+		childStore = view.Bot()
+	}
+	span := s.Root.span.StartChild("BotStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.BotStore.Save(bot)
+	resultVar0, resultVar1 := childStore.Save(bot)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -461,13 +612,19 @@ func (s *TimerLayerBotStore) Save(bot *model.Bot) (*model.Bot, *model.AppError)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("BotStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerBotStore) Update(bot *model.Bot) (*model.Bot, *model.AppError) {
+	childStore := s.BotStore
+	if view, ok := s.Root.queryTimeouts["BotStore.Update"]; ok {
+		childStore = view.Bot()
+	}
+	span := s.Root.span.StartChild("BotStore.Update", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.BotStore.Update(bot)
+	resultVar0, resultVar1 := childStore.Update(bot)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -478,13 +635,19 @@ func (s *TimerLayerBotStore) Update(bot *model.Bot) (*model.Bot, *model.AppError
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("BotStore.Update", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) AnalyticsDeletedTypeCount(teamId string, channelType string) (int64, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.AnalyticsDeletedTypeCount"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.AnalyticsDeletedTypeCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.AnalyticsDeletedTypeCount(teamId, channelType)
+	resultVar0, resultVar1 := childStore.AnalyticsDeletedTypeCount(teamId, channelType)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -495,13 +658,19 @@ func (s *TimerLayerChannelStore) AnalyticsDeletedTypeCount(teamId string, channe
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.AnalyticsDeletedTypeCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) AnalyticsTypeCount(teamId string, channelType string) (int64, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.AnalyticsTypeCount"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.AnalyticsTypeCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.AnalyticsTypeCount(teamId, channelType)
+	resultVar0, resultVar1 := childStore.AnalyticsTypeCount(teamId, channelType)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -512,13 +681,19 @@ func (s *TimerLayerChannelStore) AnalyticsTypeCount(teamId string, channelType s
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.AnalyticsTypeCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) AutocompleteInTeam(teamId string, term string, includeDeleted bool) (*model.ChannelList, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.AutocompleteInTeam"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.AutocompleteInTeam", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.AutocompleteInTeam(teamId, term, includeDeleted)
+	resultVar0, resultVar1 := childStore.AutocompleteInTeam(teamId, term, includeDeleted)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -529,13 +704,19 @@ func (s *TimerLayerChannelStore) AutocompleteInTeam(teamId string, term string,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.AutocompleteInTeam", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) AutocompleteInTeamForSearch(teamId string, userId string, term string, includeDeleted bool) (*model.ChannelList, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.AutocompleteInTeamForSearch"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.AutocompleteInTeamForSearch", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.AutocompleteInTeamForSearch(teamId, userId, term, includeDeleted)
+	resultVar0, resultVar1 := childStore.AutocompleteInTeamForSearch(teamId, userId, term, includeDeleted)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -546,13 +727,19 @@ func (s *TimerLayerChannelStore) AutocompleteInTeamForSearch(teamId string, user
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.AutocompleteInTeamForSearch", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) ClearAllCustomRoleAssignments() *model.AppError {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.ClearAllCustomRoleAssignments"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.ClearAllCustomRoleAssignments", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.ClearAllCustomRoleAssignments()
+	resultVar0 := childStore.ClearAllCustomRoleAssignments()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -563,13 +750,19 @@ func (s *TimerLayerChannelStore) ClearAllCustomRoleAssignments() *model.AppError
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.ClearAllCustomRoleAssignments", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerChannelStore) ClearCaches() {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.ClearCaches"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.ClearCaches", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.ChannelStore.ClearCaches()
+	childStore.ClearCaches()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -580,13 +773,19 @@ func (s *TimerLayerChannelStore) ClearCaches() {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.ClearCaches", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return
 }
 
 func (s *TimerLayerChannelStore) CreateDirectChannel(userId *model.User, otherUserId *model.User) (*model.Channel, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.CreateDirectChannel"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.CreateDirectChannel", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.CreateDirectChannel(userId, otherUserId)
+	resultVar0, resultVar1 := childStore.CreateDirectChannel(userId, otherUserId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -597,13 +796,19 @@ func (s *TimerLayerChannelStore) CreateDirectChannel(userId *model.User, otherUs
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.CreateDirectChannel", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) Delete(channelId string, time int64) *model.AppError {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.Delete"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.Delete", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.Delete(channelId, time)
+	resultVar0 := childStore.Delete(channelId, time)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -614,13 +819,19 @@ func (s *TimerLayerChannelStore) Delete(channelId string, time int64) *model.App
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.Delete", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerChannelStore) Get(id string, allowFromCache bool) (*model.Channel, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.Get"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.Get(id, allowFromCache)
+	resultVar0, resultVar1 := childStore.Get(id, allowFromCache)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -631,13 +842,19 @@ func (s *TimerLayerChannelStore) Get(id string, allowFromCache bool) (*model.Cha
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetAll(teamId string) ([]*model.Channel, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetAll"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetAll", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetAll(teamId)
+	resultVar0, resultVar1 := childStore.GetAll(teamId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -648,13 +865,19 @@ func (s *TimerLayerChannelStore) GetAll(teamId string) ([]*model.Channel, *model
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAll", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetAllChannelMembersForUser(userId string, allowFromCache bool, includeDeleted bool) (map[string]string, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetAllChannelMembersForUser"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetAllChannelMembersForUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetAllChannelMembersForUser(userId, allowFromCache, includeDeleted)
+	resultVar0, resultVar1 := childStore.GetAllChannelMembersForUser(userId, allowFromCache, includeDeleted)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -665,13 +888,19 @@ func (s *TimerLayerChannelStore) GetAllChannelMembersForUser(userId string, allo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllChannelMembersForUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetAllChannelMembersNotifyPropsForChannel(channelId string, allowFromCache bool) (map[string]model.StringMap, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetAllChannelMembersNotifyPropsForChannel"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetAllChannelMembersNotifyPropsForChannel", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetAllChannelMembersNotifyPropsForChannel(channelId, allowFromCache)
+	resultVar0, resultVar1 := childStore.GetAllChannelMembersNotifyPropsForChannel(channelId, allowFromCache)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -682,13 +911,19 @@ func (s *TimerLayerChannelStore) GetAllChannelMembersNotifyPropsForChannel(chann
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllChannelMembersNotifyPropsForChannel", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetAllChannels(page int, perPage int, opts ChannelSearchOpts) (*model.ChannelListWithTeamData, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetAllChannels"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetAllChannels", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetAllChannels(page, perPage, opts)
+	resultVar0, resultVar1 := childStore.GetAllChannels(page, perPage, opts)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -699,13 +934,19 @@ func (s *TimerLayerChannelStore) GetAllChannels(page int, perPage int, opts Chan
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllChannels", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetAllChannelsCount(opts ChannelSearchOpts) (int64, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetAllChannelsCount"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetAllChannelsCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetAllChannelsCount(opts)
+	resultVar0, resultVar1 := childStore.GetAllChannelsCount(opts)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -716,13 +957,19 @@ func (s *TimerLayerChannelStore) GetAllChannelsCount(opts ChannelSearchOpts) (in
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllChannelsCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetAllChannelsForExportAfter(limit int, afterId string) ([]*model.ChannelForExport, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetAllChannelsForExportAfter"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetAllChannelsForExportAfter", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetAllChannelsForExportAfter(limit, afterId)
+	resultVar0, resultVar1 := childStore.GetAllChannelsForExportAfter(limit, afterId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -733,13 +980,19 @@ func (s *TimerLayerChannelStore) GetAllChannelsForExportAfter(limit int, afterId
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllChannelsForExportAfter", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetAllDirectChannelsForExportAfter(limit int, afterId string) ([]*model.DirectChannelForExport, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetAllDirectChannelsForExportAfter"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetAllDirectChannelsForExportAfter", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetAllDirectChannelsForExportAfter(limit, afterId)
+	resultVar0, resultVar1 := childStore.GetAllDirectChannelsForExportAfter(limit, afterId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -750,13 +1003,19 @@ func (s *TimerLayerChannelStore) GetAllDirectChannelsForExportAfter(limit int, a
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllDirectChannelsForExportAfter", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetByName(team_id string, name string, allowFromCache bool) (*model.Channel, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetByName"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetByName", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetByName(team_id, name, allowFromCache)
+	resultVar0, resultVar1 := childStore.GetByName(team_id, name, allowFromCache)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -767,13 +1026,19 @@ func (s *TimerLayerChannelStore) GetByName(team_id string, name string, allowFro
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetByName", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetByNameIncludeDeleted(team_id string, name string, allowFromCache bool) (*model.Channel, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetByNameIncludeDeleted"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetByNameIncludeDeleted", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetByNameIncludeDeleted(team_id, name, allowFromCache)
+	resultVar0, resultVar1 := childStore.GetByNameIncludeDeleted(team_id, name, allowFromCache)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -784,13 +1049,19 @@ func (s *TimerLayerChannelStore) GetByNameIncludeDeleted(team_id string, name st
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetByNameIncludeDeleted", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetByNames(team_id string, names []string, allowFromCache bool) ([]*model.Channel, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetByNames"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetByNames", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetByNames(team_id, names, allowFromCache)
+	resultVar0, resultVar1 := childStore.GetByNames(team_id, names, allowFromCache)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -801,13 +1072,19 @@ func (s *TimerLayerChannelStore) GetByNames(team_id string, names []string, allo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetByNames", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetChannelCounts(teamId string, userId string) (*model.ChannelCounts, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetChannelCounts"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetChannelCounts", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetChannelCounts(teamId, userId)
+	resultVar0, resultVar1 := childStore.GetChannelCounts(teamId, userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -818,13 +1095,19 @@ func (s *TimerLayerChannelStore) GetChannelCounts(teamId string, userId string)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelCounts", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetChannelMembersForExport(userId string, teamId string) ([]*model.ChannelMemberForExport, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetChannelMembersForExport"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetChannelMembersForExport", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetChannelMembersForExport(userId, teamId)
+	resultVar0, resultVar1 := childStore.GetChannelMembersForExport(userId, teamId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -835,13 +1118,19 @@ func (s *TimerLayerChannelStore) GetChannelMembersForExport(userId string, teamI
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelMembersForExport", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetChannelMembersTimezones(channelId string) ([]model.StringMap, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetChannelMembersTimezones"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetChannelMembersTimezones", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetChannelMembersTimezones(channelId)
+	resultVar0, resultVar1 := childStore.GetChannelMembersTimezones(channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -852,13 +1141,19 @@ func (s *TimerLayerChannelStore) GetChannelMembersTimezones(channelId string) ([
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelMembersTimezones", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetChannelUnread(channelId string, userId string) (*model.ChannelUnread, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetChannelUnread"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetChannelUnread", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetChannelUnread(channelId, userId)
+	resultVar0, resultVar1 := childStore.GetChannelUnread(channelId, userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -869,13 +1164,19 @@ func (s *TimerLayerChannelStore) GetChannelUnread(channelId string, userId strin
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelUnread", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetChannels(teamId string, userId string, includeDeleted bool) (*model.ChannelList, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetChannels"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetChannels", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetChannels(teamId, userId, includeDeleted)
+	resultVar0, resultVar1 := childStore.GetChannels(teamId, userId, includeDeleted)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -886,13 +1187,19 @@ func (s *TimerLayerChannelStore) GetChannels(teamId string, userId string, inclu
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannels", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetChannelsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.Channel, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetChannelsBatchForIndexing"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetChannelsBatchForIndexing", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetChannelsBatchForIndexing(startTime, endTime, limit)
+	resultVar0, resultVar1 := childStore.GetChannelsBatchForIndexing(startTime, endTime, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -903,13 +1210,19 @@ func (s *TimerLayerChannelStore) GetChannelsBatchForIndexing(startTime int64, en
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelsBatchForIndexing", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetChannelsByIds(channelIds []string) ([]*model.Channel, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetChannelsByIds"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetChannelsByIds", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetChannelsByIds(channelIds)
+	resultVar0, resultVar1 := childStore.GetChannelsByIds(channelIds)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -920,13 +1233,19 @@ func (s *TimerLayerChannelStore) GetChannelsByIds(channelIds []string) ([]*model
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelsByIds", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetChannelsByScheme(schemeId string, offset int, limit int) (model.ChannelList, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetChannelsByScheme"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetChannelsByScheme", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetChannelsByScheme(schemeId, offset, limit)
+	resultVar0, resultVar1 := childStore.GetChannelsByScheme(schemeId, offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -937,13 +1256,19 @@ func (s *TimerLayerChannelStore) GetChannelsByScheme(schemeId string, offset int
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelsByScheme", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetDeleted(team_id string, offset int, limit int) (*model.ChannelList, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetDeleted"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetDeleted", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetDeleted(team_id, offset, limit)
+	resultVar0, resultVar1 := childStore.GetDeleted(team_id, offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -954,13 +1279,19 @@ func (s *TimerLayerChannelStore) GetDeleted(team_id string, offset int, limit in
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetDeleted", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetDeletedByName(team_id string, name string) (*model.Channel, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetDeletedByName"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetDeletedByName", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetDeletedByName(team_id, name)
+	resultVar0, resultVar1 := childStore.GetDeletedByName(team_id, name)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -971,13 +1302,19 @@ func (s *TimerLayerChannelStore) GetDeletedByName(team_id string, name string) (
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetDeletedByName", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetForPost(postId string) (*model.Channel, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetForPost"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetForPost", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetForPost(postId)
+	resultVar0, resultVar1 := childStore.GetForPost(postId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -988,13 +1325,19 @@ func (s *TimerLayerChannelStore) GetForPost(postId string) (*model.Channel, *mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetForPost", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetFromMaster(id string) (*model.Channel, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetFromMaster"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetFromMaster", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetFromMaster(id)
+	resultVar0, resultVar1 := childStore.GetFromMaster(id)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1005,13 +1348,19 @@ func (s *TimerLayerChannelStore) GetFromMaster(id string) (*model.Channel, *mode
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetFromMaster", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetGuestCount(channelId string, allowFromCache bool) (int64, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetGuestCount"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetGuestCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetGuestCount(channelId, allowFromCache)
+	resultVar0, resultVar1 := childStore.GetGuestCount(channelId, allowFromCache)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1022,13 +1371,19 @@ func (s *TimerLayerChannelStore) GetGuestCount(channelId string, allowFromCache
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetGuestCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetGuestCountFromCache(channelId string) int64 {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetGuestCountFromCache"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetGuestCountFromCache", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.GetGuestCountFromCache(channelId)
+	resultVar0 := childStore.GetGuestCountFromCache(channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1039,13 +1394,19 @@ func (s *TimerLayerChannelStore) GetGuestCountFromCache(channelId string) int64
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetGuestCountFromCache", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return resultVar0
 }
 
 func (s *TimerLayerChannelStore) GetMember(channelId string, userId string) (*model.ChannelMember, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetMember"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetMember", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetMember(channelId, userId)
+	resultVar0, resultVar1 := childStore.GetMember(channelId, userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1056,13 +1417,19 @@ func (s *TimerLayerChannelStore) GetMember(channelId string, userId string) (*mo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMember", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetMemberCount(channelId string, allowFromCache bool) (int64, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetMemberCount"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetMemberCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetMemberCount(channelId, allowFromCache)
+	resultVar0, resultVar1 := childStore.GetMemberCount(channelId, allowFromCache)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1073,13 +1440,19 @@ func (s *TimerLayerChannelStore) GetMemberCount(channelId string, allowFromCache
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMemberCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetMemberCountFromCache(channelId string) int64 {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetMemberCountFromCache"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetMemberCountFromCache", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.GetMemberCountFromCache(channelId)
+	resultVar0 := childStore.GetMemberCountFromCache(channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1090,13 +1463,19 @@ func (s *TimerLayerChannelStore) GetMemberCountFromCache(channelId string) int64
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMemberCountFromCache", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return resultVar0
 }
 
 func (s *TimerLayerChannelStore) GetMemberForPost(postId string, userId string) (*model.ChannelMember, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetMemberForPost"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetMemberForPost", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetMemberForPost(postId, userId)
+	resultVar0, resultVar1 := childStore.GetMemberForPost(postId, userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1107,13 +1486,19 @@ func (s *TimerLayerChannelStore) GetMemberForPost(postId string, userId string)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMemberForPost", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetMembers(channelId string, offset int, limit int) (*model.ChannelMembers, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetMembers"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetMembers", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetMembers(channelId, offset, limit)
+	resultVar0, resultVar1 := childStore.GetMembers(channelId, offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1124,13 +1509,19 @@ func (s *TimerLayerChannelStore) GetMembers(channelId string, offset int, limit
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMembers", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetMembersByIds(channelId string, userIds []string) (*model.ChannelMembers, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetMembersByIds"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetMembersByIds", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetMembersByIds(channelId, userIds)
+	resultVar0, resultVar1 := childStore.GetMembersByIds(channelId, userIds)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1141,13 +1532,19 @@ func (s *TimerLayerChannelStore) GetMembersByIds(channelId string, userIds []str
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMembersByIds", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetMembersForUser(teamId string, userId string) (*model.ChannelMembers, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetMembersForUser"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetMembersForUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetMembersForUser(teamId, userId)
+	resultVar0, resultVar1 := childStore.GetMembersForUser(teamId, userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1158,13 +1555,19 @@ func (s *TimerLayerChannelStore) GetMembersForUser(teamId string, userId string)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMembersForUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetMembersForUserWithPagination(teamId string, userId string, page int, perPage int) (*model.ChannelMembers, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetMembersForUserWithPagination"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetMembersForUserWithPagination", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetMembersForUserWithPagination(teamId, userId, page, perPage)
+	resultVar0, resultVar1 := childStore.GetMembersForUserWithPagination(teamId, userId, page, perPage)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1175,13 +1578,19 @@ func (s *TimerLayerChannelStore) GetMembersForUserWithPagination(teamId string,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMembersForUserWithPagination", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetMoreChannels(teamId string, userId string, offset int, limit int) (*model.ChannelList, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetMoreChannels"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetMoreChannels", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetMoreChannels(teamId, userId, offset, limit)
+	resultVar0, resultVar1 := childStore.GetMoreChannels(teamId, userId, offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1192,13 +1601,65 @@ func (s *TimerLayerChannelStore) GetMoreChannels(teamId string, userId string, o
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMoreChannels", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) GetPinnedPostCount(channelId string, allowFromCache bool) (int64, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetPinnedPostCount"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetPinnedPostCount", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetPinnedPostCount(channelId, allowFromCache)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetPinnedPostCount", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerChannelStore) GetPinnedPostCountFromCache(channelId string) int64 {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetPinnedPostCountFromCache"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetPinnedPostCountFromCache", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0 := childStore.GetPinnedPostCountFromCache(channelId)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetPinnedPostCountFromCache", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, nil)
+	return resultVar0
+}
+
 func (s *TimerLayerChannelStore) GetPinnedPosts(channelId string) (*model.PostList, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetPinnedPosts"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetPinnedPosts", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetPinnedPosts(channelId)
+	resultVar0, resultVar1 := childStore.GetPinnedPosts(channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1209,13 +1670,19 @@ func (s *TimerLayerChannelStore) GetPinnedPosts(channelId string) (*model.PostLi
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetPinnedPosts", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetPublicChannelsByIdsForTeam(teamId string, channelIds []string) (*model.ChannelList, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetPublicChannelsByIdsForTeam"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetPublicChannelsByIdsForTeam", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetPublicChannelsByIdsForTeam(teamId, channelIds)
+	resultVar0, resultVar1 := childStore.GetPublicChannelsByIdsForTeam(teamId, channelIds)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1226,13 +1693,19 @@ func (s *TimerLayerChannelStore) GetPublicChannelsByIdsForTeam(teamId string, ch
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetPublicChannelsByIdsForTeam", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetPublicChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetPublicChannelsForTeam"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetPublicChannelsForTeam", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetPublicChannelsForTeam(teamId, offset, limit)
+	resultVar0, resultVar1 := childStore.GetPublicChannelsForTeam(teamId, offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1243,13 +1716,19 @@ func (s *TimerLayerChannelStore) GetPublicChannelsForTeam(teamId string, offset
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetPublicChannelsForTeam", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) GetTeamChannels(teamId string) (*model.ChannelList, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.GetTeamChannels"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.GetTeamChannels", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.GetTeamChannels(teamId)
+	resultVar0, resultVar1 := childStore.GetTeamChannels(teamId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1260,13 +1739,19 @@ func (s *TimerLayerChannelStore) GetTeamChannels(teamId string) (*model.ChannelL
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetTeamChannels", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) IncrementMentionCount(channelId string, userId string) *model.AppError {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.IncrementMentionCount"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.IncrementMentionCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.IncrementMentionCount(channelId, userId)
+	resultVar0 := childStore.IncrementMentionCount(channelId, userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1277,13 +1762,19 @@ func (s *TimerLayerChannelStore) IncrementMentionCount(channelId string, userId
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.IncrementMentionCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerChannelStore) InvalidateAllChannelMembersForUser(userId string) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.InvalidateAllChannelMembersForUser"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.InvalidateAllChannelMembersForUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.ChannelStore.InvalidateAllChannelMembersForUser(userId)
+	childStore.InvalidateAllChannelMembersForUser(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1294,13 +1785,19 @@ func (s *TimerLayerChannelStore) InvalidateAllChannelMembersForUser(userId strin
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateAllChannelMembersForUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return
 }
 
 func (s *TimerLayerChannelStore) InvalidateCacheForChannelMembersNotifyProps(channelId string) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.InvalidateCacheForChannelMembersNotifyProps"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.InvalidateCacheForChannelMembersNotifyProps", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.ChannelStore.InvalidateCacheForChannelMembersNotifyProps(channelId)
+	childStore.InvalidateCacheForChannelMembersNotifyProps(channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1311,13 +1808,19 @@ func (s *TimerLayerChannelStore) InvalidateCacheForChannelMembersNotifyProps(cha
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateCacheForChannelMembersNotifyProps", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return
 }
 
 func (s *TimerLayerChannelStore) InvalidateChannel(id string) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.InvalidateChannel"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.InvalidateChannel", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.ChannelStore.InvalidateChannel(id)
+	childStore.InvalidateChannel(id)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1328,13 +1831,19 @@ func (s *TimerLayerChannelStore) InvalidateChannel(id string) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateChannel", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return
 }
 
 func (s *TimerLayerChannelStore) InvalidateChannelByName(teamId string, name string) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.InvalidateChannelByName"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.InvalidateChannelByName", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.ChannelStore.InvalidateChannelByName(teamId, name)
+	childStore.InvalidateChannelByName(teamId, name)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1345,13 +1854,19 @@ func (s *TimerLayerChannelStore) InvalidateChannelByName(teamId string, name str
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateChannelByName", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return
 }
 
 func (s *TimerLayerChannelStore) InvalidateGuestCount(channelId string) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.InvalidateGuestCount"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.InvalidateGuestCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.ChannelStore.InvalidateGuestCount(channelId)
+	childStore.InvalidateGuestCount(channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1362,13 +1877,19 @@ func (s *TimerLayerChannelStore) InvalidateGuestCount(channelId string) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateGuestCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return
 }
 
 func (s *TimerLayerChannelStore) InvalidateMemberCount(channelId string) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.InvalidateMemberCount"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.InvalidateMemberCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.ChannelStore.InvalidateMemberCount(channelId)
+	childStore.InvalidateMemberCount(channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1379,13 +1900,19 @@ func (s *TimerLayerChannelStore) InvalidateMemberCount(channelId string) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateMemberCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return
 }
 
 func (s *TimerLayerChannelStore) InvalidatePinnedPostCount(channelId string) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.InvalidatePinnedPostCount"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.InvalidatePinnedPostCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.ChannelStore.InvalidatePinnedPostCount(channelId)
+	childStore.InvalidatePinnedPostCount(channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1396,13 +1923,19 @@ func (s *TimerLayerChannelStore) InvalidatePinnedPostCount(channelId string) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidatePinnedPostCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return
 }
 
 func (s *TimerLayerChannelStore) IsUserInChannelUseCache(userId string, channelId string) bool {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.IsUserInChannelUseCache"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.IsUserInChannelUseCache", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.IsUserInChannelUseCache(userId, channelId)
+	resultVar0 := childStore.IsUserInChannelUseCache(userId, channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1413,13 +1946,19 @@ func (s *TimerLayerChannelStore) IsUserInChannelUseCache(userId string, channelI
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.IsUserInChannelUseCache", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return resultVar0
 }
 
 func (s *TimerLayerChannelStore) MigrateChannelMembers(fromChannelId string, fromUserId string) (map[string]string, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.MigrateChannelMembers"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.MigrateChannelMembers", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.MigrateChannelMembers(fromChannelId, fromUserId)
+	resultVar0, resultVar1 := childStore.MigrateChannelMembers(fromChannelId, fromUserId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1430,13 +1969,19 @@ func (s *TimerLayerChannelStore) MigrateChannelMembers(fromChannelId string, fro
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.MigrateChannelMembers", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) MigratePublicChannels() error {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.MigratePublicChannels"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.MigratePublicChannels", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.MigratePublicChannels()
+	resultVar0 := childStore.MigratePublicChannels()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1447,13 +1992,19 @@ func (s *TimerLayerChannelStore) MigratePublicChannels() error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.MigratePublicChannels", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return resultVar0
 }
 
 func (s *TimerLayerChannelStore) PermanentDelete(channelId string) *model.AppError {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.PermanentDelete"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.PermanentDelete", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.PermanentDelete(channelId)
+	resultVar0 := childStore.PermanentDelete(channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1464,13 +2015,19 @@ func (s *TimerLayerChannelStore) PermanentDelete(channelId string) *model.AppErr
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.PermanentDelete", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerChannelStore) PermanentDeleteByTeam(teamId string) *model.AppError {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.PermanentDeleteByTeam"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.PermanentDeleteByTeam", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.PermanentDeleteByTeam(teamId)
+	resultVar0 := childStore.PermanentDeleteByTeam(teamId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1481,13 +2038,19 @@ func (s *TimerLayerChannelStore) PermanentDeleteByTeam(teamId string) *model.App
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.PermanentDeleteByTeam", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerChannelStore) PermanentDeleteMembersByChannel(channelId string) *model.AppError {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.PermanentDeleteMembersByChannel"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.PermanentDeleteMembersByChannel", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.PermanentDeleteMembersByChannel(channelId)
+	resultVar0 := childStore.PermanentDeleteMembersByChannel(channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1498,13 +2061,19 @@ func (s *TimerLayerChannelStore) PermanentDeleteMembersByChannel(channelId strin
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.PermanentDeleteMembersByChannel", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerChannelStore) PermanentDeleteMembersByUser(userId string) *model.AppError {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.PermanentDeleteMembersByUser"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.PermanentDeleteMembersByUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.PermanentDeleteMembersByUser(userId)
+	resultVar0 := childStore.PermanentDeleteMembersByUser(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1515,13 +2084,19 @@ func (s *TimerLayerChannelStore) PermanentDeleteMembersByUser(userId string) *mo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.PermanentDeleteMembersByUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerChannelStore) RemoveAllDeactivatedMembers(channelId string) *model.AppError {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.RemoveAllDeactivatedMembers"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.RemoveAllDeactivatedMembers", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.RemoveAllDeactivatedMembers(channelId)
+	resultVar0 := childStore.RemoveAllDeactivatedMembers(channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1532,13 +2107,19 @@ func (s *TimerLayerChannelStore) RemoveAllDeactivatedMembers(channelId string) *
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.RemoveAllDeactivatedMembers", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerChannelStore) RemoveMember(channelId string, userId string) *model.AppError {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.RemoveMember"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.RemoveMember", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.RemoveMember(channelId, userId)
+	resultVar0 := childStore.RemoveMember(channelId, userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1549,13 +2130,19 @@ func (s *TimerLayerChannelStore) RemoveMember(channelId string, userId string) *
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.RemoveMember", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerChannelStore) ResetAllChannelSchemes() *model.AppError {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.ResetAllChannelSchemes"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.ResetAllChannelSchemes", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.ResetAllChannelSchemes()
+	resultVar0 := childStore.ResetAllChannelSchemes()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1566,13 +2153,19 @@ func (s *TimerLayerChannelStore) ResetAllChannelSchemes() *model.AppError {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.ResetAllChannelSchemes", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerChannelStore) Restore(channelId string, time int64) *model.AppError {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.Restore"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.Restore", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.Restore(channelId, time)
+	resultVar0 := childStore.Restore(channelId, time)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1583,13 +2176,19 @@ func (s *TimerLayerChannelStore) Restore(channelId string, time int64) *model.Ap
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.Restore", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerChannelStore) Save(channel *model.Channel, maxChannelsPerTeam int64) (*model.Channel, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.Save"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.Save(channel, maxChannelsPerTeam)
+	resultVar0, resultVar1 := childStore.Save(channel, maxChannelsPerTeam)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1600,13 +2199,19 @@ func (s *TimerLayerChannelStore) Save(channel *model.Channel, maxChannelsPerTeam
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) SaveDirectChannel(channel *model.Channel, member1 *model.ChannelMember, member2 *model.ChannelMember) (*model.Channel, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.SaveDirectChannel"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.SaveDirectChannel", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.SaveDirectChannel(channel, member1, member2)
+	resultVar0, resultVar1 := childStore.SaveDirectChannel(channel, member1, member2)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1617,13 +2222,19 @@ func (s *TimerLayerChannelStore) SaveDirectChannel(channel *model.Channel, membe
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SaveDirectChannel", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) SaveMember(member *model.ChannelMember) (*model.ChannelMember, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.SaveMember"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.SaveMember", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.SaveMember(member)
+	resultVar0, resultVar1 := childStore.SaveMember(member)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1634,13 +2245,19 @@ func (s *TimerLayerChannelStore) SaveMember(member *model.ChannelMember) (*model
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SaveMember", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) SearchAllChannels(term string, opts ChannelSearchOpts) (*model.ChannelListWithTeamData, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.SearchAllChannels"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.SearchAllChannels", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.SearchAllChannels(term, opts)
+	resultVar0, resultVar1 := childStore.SearchAllChannels(term, opts)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1651,13 +2268,19 @@ func (s *TimerLayerChannelStore) SearchAllChannels(term string, opts ChannelSear
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchAllChannels", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) SearchForUserInTeam(userId string, teamId string, term string, includeDeleted bool) (*model.ChannelList, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.SearchForUserInTeam"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.SearchForUserInTeam", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.SearchForUserInTeam(userId, teamId, term, includeDeleted)
+	resultVar0, resultVar1 := childStore.SearchForUserInTeam(userId, teamId, term, includeDeleted)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1668,13 +2291,19 @@ func (s *TimerLayerChannelStore) SearchForUserInTeam(userId string, teamId strin
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchForUserInTeam", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) SearchGroupChannels(userId string, term string) (*model.ChannelList, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.SearchGroupChannels"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.SearchGroupChannels", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.SearchGroupChannels(userId, term)
+	resultVar0, resultVar1 := childStore.SearchGroupChannels(userId, term)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1685,13 +2314,19 @@ func (s *TimerLayerChannelStore) SearchGroupChannels(userId string, term string)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchGroupChannels", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) SearchInTeam(teamId string, term string, includeDeleted bool) (*model.ChannelList, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.SearchInTeam"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.SearchInTeam", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.SearchInTeam(teamId, term, includeDeleted)
+	resultVar0, resultVar1 := childStore.SearchInTeam(teamId, term, includeDeleted)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1702,13 +2337,19 @@ func (s *TimerLayerChannelStore) SearchInTeam(teamId string, term string, includ
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchInTeam", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) SearchMore(userId string, teamId string, term string) (*model.ChannelList, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.SearchMore"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.SearchMore", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.SearchMore(userId, teamId, term)
+	resultVar0, resultVar1 := childStore.SearchMore(userId, teamId, term)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1719,13 +2360,19 @@ func (s *TimerLayerChannelStore) SearchMore(userId string, teamId string, term s
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchMore", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) SetDeleteAt(channelId string, deleteAt int64, updateAt int64) *model.AppError {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.SetDeleteAt"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.SetDeleteAt", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelStore.SetDeleteAt(channelId, deleteAt, updateAt)
+	resultVar0 := childStore.SetDeleteAt(channelId, deleteAt, updateAt)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1736,13 +2383,19 @@ func (s *TimerLayerChannelStore) SetDeleteAt(channelId string, deleteAt int64, u
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SetDeleteAt", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerChannelStore) Update(channel *model.Channel) (*model.Channel, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.Update"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.Update", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.Update(channel)
+	resultVar0, resultVar1 := childStore.Update(channel)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1753,13 +2406,19 @@ func (s *TimerLayerChannelStore) Update(channel *model.Channel) (*model.Channel,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.Update", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) UpdateLastViewedAt(channelIds []string, userId string) (map[string]int64, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.UpdateLastViewedAt"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.UpdateLastViewedAt", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.UpdateLastViewedAt(channelIds, userId)
+	resultVar0, resultVar1 := childStore.UpdateLastViewedAt(channelIds, userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1770,13 +2429,19 @@ func (s *TimerLayerChannelStore) UpdateLastViewedAt(channelIds []string, userId
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.UpdateLastViewedAt", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) UpdateMember(member *model.ChannelMember) (*model.ChannelMember, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.UpdateMember"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.UpdateMember", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.UpdateMember(member)
+	resultVar0, resultVar1 := childStore.UpdateMember(member)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1787,13 +2452,19 @@ func (s *TimerLayerChannelStore) UpdateMember(member *model.ChannelMember) (*mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.UpdateMember", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelStore) UserBelongsToChannels(userId string, channelIds []string) (bool, *model.AppError) {
+	childStore := s.ChannelStore
+	if view, ok := s.Root.queryTimeouts["ChannelStore.UserBelongsToChannels"]; ok {
+		childStore = view.Channel()
+	}
+	span := s.Root.span.StartChild("ChannelStore.UserBelongsToChannels", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelStore.UserBelongsToChannels(userId, channelIds)
+	resultVar0, resultVar1 := childStore.UserBelongsToChannels(userId, channelIds)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1804,13 +2475,19 @@ func (s *TimerLayerChannelStore) UserBelongsToChannels(userId string, channelIds
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.UserBelongsToChannels", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelId string) ([]*model.ChannelMemberHistoryResult, *model.AppError) {
+	childStore := s.ChannelMemberHistoryStore
+	if view, ok := s.Root.queryTimeouts["ChannelMemberHistoryStore.GetUsersInChannelDuring"]; ok {
+		childStore = view.ChannelMemberHistory()
+	}
+	span := s.Root.span.StartChild("ChannelMemberHistoryStore.GetUsersInChannelDuring", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelMemberHistoryStore.GetUsersInChannelDuring(startTime, endTime, channelId)
+	resultVar0, resultVar1 := childStore.GetUsersInChannelDuring(startTime, endTime, channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1821,13 +2498,19 @@ func (s *TimerLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.GetUsersInChannelDuring", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerChannelMemberHistoryStore) LogJoinEvent(userId string, channelId string, joinTime int64) *model.AppError {
+	childStore := s.ChannelMemberHistoryStore
+	if view, ok := s.Root.queryTimeouts["ChannelMemberHistoryStore.LogJoinEvent"]; ok {
+		childStore = view.ChannelMemberHistory()
+	}
+	span := s.Root.span.StartChild("ChannelMemberHistoryStore.LogJoinEvent", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelMemberHistoryStore.LogJoinEvent(userId, channelId, joinTime)
+	resultVar0 := childStore.LogJoinEvent(userId, channelId, joinTime)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1838,13 +2521,19 @@ func (s *TimerLayerChannelMemberHistoryStore) LogJoinEvent(userId string, channe
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.LogJoinEvent", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerChannelMemberHistoryStore) LogLeaveEvent(userId string, channelId string, leaveTime int64) *model.AppError {
+	childStore := s.ChannelMemberHistoryStore
+	if view, ok := s.Root.queryTimeouts["ChannelMemberHistoryStore.LogLeaveEvent"]; ok {
+		childStore = view.ChannelMemberHistory()
+	}
+	span := s.Root.span.StartChild("ChannelMemberHistoryStore.LogLeaveEvent", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.ChannelMemberHistoryStore.LogLeaveEvent(userId, channelId, leaveTime)
+	resultVar0 := childStore.LogLeaveEvent(userId, channelId, leaveTime)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1855,13 +2544,19 @@ func (s *TimerLayerChannelMemberHistoryStore) LogLeaveEvent(userId string, chann
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.LogLeaveEvent", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	childStore := s.ChannelMemberHistoryStore
+	if view, ok := s.Root.queryTimeouts["ChannelMemberHistoryStore.PermanentDeleteBatch"]; ok {
+		childStore = view.ChannelMemberHistory()
+	}
+	span := s.Root.span.StartChild("ChannelMemberHistoryStore.PermanentDeleteBatch", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ChannelMemberHistoryStore.PermanentDeleteBatch(endTime, limit)
+	resultVar0, resultVar1 := childStore.PermanentDeleteBatch(endTime, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1872,13 +2567,19 @@ func (s *TimerLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.PermanentDeleteBatch", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerClusterDiscoveryStore) Cleanup() *model.AppError {
+	childStore := s.ClusterDiscoveryStore
+	if view, ok := s.Root.queryTimeouts["ClusterDiscoveryStore.Cleanup"]; ok {
+		childStore = view.ClusterDiscovery()
+	}
+	span := s.Root.span.StartChild("ClusterDiscoveryStore.Cleanup", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.ClusterDiscoveryStore.Cleanup()
+	resultVar0 := childStore.Cleanup()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1889,13 +2590,19 @@ func (s *TimerLayerClusterDiscoveryStore) Cleanup() *model.AppError {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Cleanup", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscovery) (bool, *model.AppError) {
+	childStore := s.ClusterDiscoveryStore
+	if view, ok := s.Root.queryTimeouts["ClusterDiscoveryStore.Delete"]; ok {
+		childStore = view.ClusterDiscovery()
+	}
+	span := s.Root.span.StartChild("ClusterDiscoveryStore.Delete", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ClusterDiscoveryStore.Delete(discovery)
+	resultVar0, resultVar1 := childStore.Delete(discovery)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1906,13 +2613,19 @@ func (s *TimerLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscove
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Delete", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerClusterDiscoveryStore) Exists(discovery *model.ClusterDiscovery) (bool, *model.AppError) {
+	childStore := s.ClusterDiscoveryStore
+	if view, ok := s.Root.queryTimeouts["ClusterDiscoveryStore.Exists"]; ok {
+		childStore = view.ClusterDiscovery()
+	}
+	span := s.Root.span.StartChild("ClusterDiscoveryStore.Exists", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ClusterDiscoveryStore.Exists(discovery)
+	resultVar0, resultVar1 := childStore.Exists(discovery)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1923,13 +2636,19 @@ func (s *TimerLayerClusterDiscoveryStore) Exists(discovery *model.ClusterDiscove
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Exists", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerClusterDiscoveryStore) GetAll(discoveryType string, clusterName string) ([]*model.ClusterDiscovery, *model.AppError) {
+	childStore := s.ClusterDiscoveryStore
+	if view, ok := s.Root.queryTimeouts["ClusterDiscoveryStore.GetAll"]; ok {
+		childStore = view.ClusterDiscovery()
+	}
+	span := s.Root.span.StartChild("ClusterDiscoveryStore.GetAll", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ClusterDiscoveryStore.GetAll(discoveryType, clusterName)
+	resultVar0, resultVar1 := childStore.GetAll(discoveryType, clusterName)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1940,13 +2659,19 @@ func (s *TimerLayerClusterDiscoveryStore) GetAll(discoveryType string, clusterNa
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.GetAll", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery) *model.AppError {
+	childStore := s.ClusterDiscoveryStore
+	if view, ok := s.Root.queryTimeouts["ClusterDiscoveryStore.Save"]; ok {
+		childStore = view.ClusterDiscovery()
+	}
+	span := s.Root.span.StartChild("ClusterDiscoveryStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.ClusterDiscoveryStore.Save(discovery)
+	resultVar0 := childStore.Save(discovery)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1957,13 +2682,19 @@ func (s *TimerLayerClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerClusterDiscoveryStore) SetLastPingAt(discovery *model.ClusterDiscovery) *model.AppError {
+	childStore := s.ClusterDiscoveryStore
+	if view, ok := s.Root.queryTimeouts["ClusterDiscoveryStore.SetLastPingAt"]; ok {
+		childStore = view.ClusterDiscovery()
+	}
+	span := s.Root.span.StartChild("ClusterDiscoveryStore.SetLastPingAt", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.ClusterDiscoveryStore.SetLastPingAt(discovery)
+	resultVar0 := childStore.SetLastPingAt(discovery)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1974,13 +2705,19 @@ func (s *TimerLayerClusterDiscoveryStore) SetLastPingAt(discovery *model.Cluster
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.SetLastPingAt", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerCommandStore) AnalyticsCommandCount(teamId string) (int64, *model.AppError) {
+	childStore := s.CommandStore
+	if view, ok := s.Root.queryTimeouts["CommandStore.AnalyticsCommandCount"]; ok {
+		childStore = view.Command()
+	}
+	span := s.Root.span.StartChild("CommandStore.AnalyticsCommandCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.CommandStore.AnalyticsCommandCount(teamId)
+	resultVar0, resultVar1 := childStore.AnalyticsCommandCount(teamId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -1991,13 +2728,19 @@ func (s *TimerLayerCommandStore) AnalyticsCommandCount(teamId string) (int64, *m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.AnalyticsCommandCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerCommandStore) Delete(commandId string, time int64) *model.AppError {
+	childStore := s.CommandStore
+	if view, ok := s.Root.queryTimeouts["CommandStore.Delete"]; ok {
+		childStore = view.Command()
+	}
+	span := s.Root.span.StartChild("CommandStore.Delete", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.CommandStore.Delete(commandId, time)
+	resultVar0 := childStore.Delete(commandId, time)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2008,13 +2751,19 @@ func (s *TimerLayerCommandStore) Delete(commandId string, time int64) *model.App
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Delete", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerCommandStore) Get(id string) (*model.Command, *model.AppError) {
+	childStore := s.CommandStore
+	if view, ok := s.Root.queryTimeouts["CommandStore.Get"]; ok {
+		childStore = view.Command()
+	}
+	span := s.Root.span.StartChild("CommandStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.CommandStore.Get(id)
+	resultVar0, resultVar1 := childStore.Get(id)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2025,13 +2774,19 @@ func (s *TimerLayerCommandStore) Get(id string) (*model.Command, *model.AppError
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerCommandStore) GetByTeam(teamId string) ([]*model.Command, *model.AppError) {
+	childStore := s.CommandStore
+	if view, ok := s.Root.queryTimeouts["CommandStore.GetByTeam"]; ok {
+		childStore = view.Command()
+	}
+	span := s.Root.span.StartChild("CommandStore.GetByTeam", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.CommandStore.GetByTeam(teamId)
+	resultVar0, resultVar1 := childStore.GetByTeam(teamId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2042,13 +2797,19 @@ func (s *TimerLayerCommandStore) GetByTeam(teamId string) ([]*model.Command, *mo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.GetByTeam", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerCommandStore) GetByTrigger(teamId string, trigger string) (*model.Command, *model.AppError) {
+	childStore := s.CommandStore
+	if view, ok := s.Root.queryTimeouts["CommandStore.GetByTrigger"]; ok {
+		childStore = view.Command()
+	}
+	span := s.Root.span.StartChild("CommandStore.GetByTrigger", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.CommandStore.GetByTrigger(teamId, trigger)
+	resultVar0, resultVar1 := childStore.GetByTrigger(teamId, trigger)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2059,13 +2820,19 @@ func (s *TimerLayerCommandStore) GetByTrigger(teamId string, trigger string) (*m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.GetByTrigger", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerCommandStore) PermanentDeleteByTeam(teamId string) *model.AppError {
+	childStore := s.CommandStore
+	if view, ok := s.Root.queryTimeouts["CommandStore.PermanentDeleteByTeam"]; ok {
+		childStore = view.Command()
+	}
+	span := s.Root.span.StartChild("CommandStore.PermanentDeleteByTeam", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.CommandStore.PermanentDeleteByTeam(teamId)
+	resultVar0 := childStore.PermanentDeleteByTeam(teamId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2076,13 +2843,19 @@ func (s *TimerLayerCommandStore) PermanentDeleteByTeam(teamId string) *model.App
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.PermanentDeleteByTeam", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerCommandStore) PermanentDeleteByUser(userId string) *model.AppError {
+	childStore := s.CommandStore
+	if view, ok := s.Root.queryTimeouts["CommandStore.PermanentDeleteByUser"]; ok {
+		childStore = view.Command()
+	}
+	span := s.Root.span.StartChild("CommandStore.PermanentDeleteByUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.CommandStore.PermanentDeleteByUser(userId)
+	resultVar0 := childStore.PermanentDeleteByUser(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2093,13 +2866,19 @@ func (s *TimerLayerCommandStore) PermanentDeleteByUser(userId string) *model.App
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.PermanentDeleteByUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerCommandStore) Save(webhook *model.Command) (*model.Command, *model.AppError) {
+	childStore := s.CommandStore
+	if view, ok := s.Root.queryTimeouts["CommandStore.Save"]; ok {
+		childStore = view.Command()
+	}
+	span := s.Root.span.StartChild("CommandStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.CommandStore.Save(webhook)
+	resultVar0, resultVar1 := childStore.Save(webhook)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2110,13 +2889,19 @@ func (s *TimerLayerCommandStore) Save(webhook *model.Command) (*model.Command, *
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerCommandStore) Update(hook *model.Command) (*model.Command, *model.AppError) {
+	childStore := s.CommandStore
+	if view, ok := s.Root.queryTimeouts["CommandStore.Update"]; ok {
+		childStore = view.Command()
+	}
+	span := s.Root.span.StartChild("CommandStore.Update", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.CommandStore.Update(hook)
+	resultVar0, resultVar1 := childStore.Update(hook)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2127,13 +2912,19 @@ func (s *TimerLayerCommandStore) Update(hook *model.Command) (*model.Command, *m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Update", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerCommandWebhookStore) Cleanup() {
+	childStore := s.CommandWebhookStore
+	if view, ok := s.Root.queryTimeouts["CommandWebhookStore.Cleanup"]; ok {
+		childStore = view.CommandWebhook()
+	}
+	span := s.Root.span.StartChild("CommandWebhookStore.Cleanup", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.CommandWebhookStore.Cleanup()
+	childStore.Cleanup()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2144,13 +2935,19 @@ func (s *TimerLayerCommandWebhookStore) Cleanup() {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.Cleanup", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return
 }
 
 func (s *TimerLayerCommandWebhookStore) Get(id string) (*model.CommandWebhook, *model.AppError) {
+	childStore := s.CommandWebhookStore
+	if view, ok := s.Root.queryTimeouts["CommandWebhookStore.Get"]; ok {
+		childStore = view.CommandWebhook()
+	}
+	span := s.Root.span.StartChild("CommandWebhookStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.CommandWebhookStore.Get(id)
+	resultVar0, resultVar1 := childStore.Get(id)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2161,13 +2958,19 @@ func (s *TimerLayerCommandWebhookStore) Get(id string) (*model.CommandWebhook, *
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerCommandWebhookStore) Save(webhook *model.CommandWebhook) (*model.CommandWebhook, *model.AppError) {
+	childStore := s.CommandWebhookStore
+	if view, ok := s.Root.queryTimeouts["CommandWebhookStore.Save"]; ok {
+		childStore = view.CommandWebhook()
+	}
+	span := s.Root.span.StartChild("CommandWebhookStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.CommandWebhookStore.Save(webhook)
+	resultVar0, resultVar1 := childStore.Save(webhook)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2178,13 +2981,19 @@ func (s *TimerLayerCommandWebhookStore) Save(webhook *model.CommandWebhook) (*mo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerCommandWebhookStore) TryUse(id string, limit int) *model.AppError {
+	childStore := s.CommandWebhookStore
+	if view, ok := s.Root.queryTimeouts["CommandWebhookStore.TryUse"]; ok {
+		childStore = view.CommandWebhook()
+	}
+	span := s.Root.span.StartChild("CommandWebhookStore.TryUse", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.CommandWebhookStore.TryUse(id, limit)
+	resultVar0 := childStore.TryUse(id, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2195,13 +3004,19 @@ func (s *TimerLayerCommandWebhookStore) TryUse(id string, limit int) *model.AppE
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.TryUse", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerComplianceStore) ComplianceExport(compliance *model.Compliance) ([]*model.CompliancePost, *model.AppError) {
+	childStore := s.ComplianceStore
+	if view, ok := s.Root.queryTimeouts["ComplianceStore.ComplianceExport"]; ok {
+		childStore = view.Compliance()
+	}
+	span := s.Root.span.StartChild("ComplianceStore.ComplianceExport", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ComplianceStore.ComplianceExport(compliance)
+	resultVar0, resultVar1 := childStore.ComplianceExport(compliance)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2212,13 +3027,19 @@ func (s *TimerLayerComplianceStore) ComplianceExport(compliance *model.Complianc
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.ComplianceExport", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerComplianceStore) Get(id string) (*model.Compliance, *model.AppError) {
+	childStore := s.ComplianceStore
+	if view, ok := s.Root.queryTimeouts["ComplianceStore.Get"]; ok {
+		childStore = view.Compliance()
+	}
+	span := s.Root.span.StartChild("ComplianceStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ComplianceStore.Get(id)
+	resultVar0, resultVar1 := childStore.Get(id)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2229,13 +3050,19 @@ func (s *TimerLayerComplianceStore) Get(id string) (*model.Compliance, *model.Ap
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerComplianceStore) GetAll(offset int, limit int) (model.Compliances, *model.AppError) {
+	childStore := s.ComplianceStore
+	if view, ok := s.Root.queryTimeouts["ComplianceStore.GetAll"]; ok {
+		childStore = view.Compliance()
+	}
+	span := s.Root.span.StartChild("ComplianceStore.GetAll", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ComplianceStore.GetAll(offset, limit)
+	resultVar0, resultVar1 := childStore.GetAll(offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2246,13 +3073,19 @@ func (s *TimerLayerComplianceStore) GetAll(offset int, limit int) (model.Complia
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.GetAll", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerComplianceStore) MessageExport(after int64, limit int) ([]*model.MessageExport, *model.AppError) {
+	childStore := s.ComplianceStore
+	if view, ok := s.Root.queryTimeouts["ComplianceStore.MessageExport"]; ok {
+		childStore = view.Compliance()
+	}
+	span := s.Root.span.StartChild("ComplianceStore.MessageExport", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ComplianceStore.MessageExport(after, limit)
+	resultVar0, resultVar1 := childStore.MessageExport(after, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2263,13 +3096,19 @@ func (s *TimerLayerComplianceStore) MessageExport(after int64, limit int) ([]*mo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.MessageExport", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerComplianceStore) Save(compliance *model.Compliance) (*model.Compliance, *model.AppError) {
+	childStore := s.ComplianceStore
+	if view, ok := s.Root.queryTimeouts["ComplianceStore.Save"]; ok {
+		childStore = view.Compliance()
+	}
+	span := s.Root.span.StartChild("ComplianceStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ComplianceStore.Save(compliance)
+	resultVar0, resultVar1 := childStore.Save(compliance)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2280,13 +3119,19 @@ func (s *TimerLayerComplianceStore) Save(compliance *model.Compliance) (*model.C
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerComplianceStore) Update(compliance *model.Compliance) (*model.Compliance, *model.AppError) {
+	childStore := s.ComplianceStore
+	if view, ok := s.Root.queryTimeouts["ComplianceStore.Update"]; ok {
+		childStore = view.Compliance()
+	}
+	span := s.Root.span.StartChild("ComplianceStore.Update", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ComplianceStore.Update(compliance)
+	resultVar0, resultVar1 := childStore.Update(compliance)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2297,13 +3142,19 @@ func (s *TimerLayerComplianceStore) Update(compliance *model.Compliance) (*model
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.Update", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerEmojiStore) Delete(emoji *model.Emoji, time int64) *model.AppError {
+	childStore := s.EmojiStore
+	if view, ok := s.Root.queryTimeouts["EmojiStore.Delete"]; ok {
+		childStore = view.Emoji()
+	}
+	span := s.Root.span.StartChild("EmojiStore.Delete", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.EmojiStore.Delete(emoji, time)
+	resultVar0 := childStore.Delete(emoji, time)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2314,13 +3165,19 @@ func (s *TimerLayerEmojiStore) Delete(emoji *model.Emoji, time int64) *model.App
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Delete", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerEmojiStore) Get(id string, allowFromCache bool) (*model.Emoji, *model.AppError) {
+	childStore := s.EmojiStore
+	if view, ok := s.Root.queryTimeouts["EmojiStore.Get"]; ok {
+		childStore = view.Emoji()
+	}
+	span := s.Root.span.StartChild("EmojiStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.EmojiStore.Get(id, allowFromCache)
+	resultVar0, resultVar1 := childStore.Get(id, allowFromCache)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2331,13 +3188,19 @@ func (s *TimerLayerEmojiStore) Get(id string, allowFromCache bool) (*model.Emoji
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerEmojiStore) GetByName(name string, allowFromCache bool) (*model.Emoji, *model.AppError) {
+	childStore := s.EmojiStore
+	if view, ok := s.Root.queryTimeouts["EmojiStore.GetByName"]; ok {
+		childStore = view.Emoji()
+	}
+	span := s.Root.span.StartChild("EmojiStore.GetByName", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.EmojiStore.GetByName(name, allowFromCache)
+	resultVar0, resultVar1 := childStore.GetByName(name, allowFromCache)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2348,13 +3211,19 @@ func (s *TimerLayerEmojiStore) GetByName(name string, allowFromCache bool) (*mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetByName", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, *model.AppError) {
+	childStore := s.EmojiStore
+	if view, ok := s.Root.queryTimeouts["EmojiStore.GetList"]; ok {
+		childStore = view.Emoji()
+	}
+	span := s.Root.span.StartChild("EmojiStore.GetList", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.EmojiStore.GetList(offset, limit, sort)
+	resultVar0, resultVar1 := childStore.GetList(offset, limit, sort)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2365,13 +3234,19 @@ func (s *TimerLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetList", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji, *model.AppError) {
+	childStore := s.EmojiStore
+	if view, ok := s.Root.queryTimeouts["EmojiStore.GetMultipleByName"]; ok {
+		childStore = view.Emoji()
+	}
+	span := s.Root.span.StartChild("EmojiStore.GetMultipleByName", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.EmojiStore.GetMultipleByName(names)
+	resultVar0, resultVar1 := childStore.GetMultipleByName(names)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2382,13 +3257,19 @@ func (s *TimerLayerEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetMultipleByName", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, *model.AppError) {
+	childStore := s.EmojiStore
+	if view, ok := s.Root.queryTimeouts["EmojiStore.Save"]; ok {
+		childStore = view.Emoji()
+	}
+	span := s.Root.span.StartChild("EmojiStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.EmojiStore.Save(emoji)
+	resultVar0, resultVar1 := childStore.Save(emoji)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2399,13 +3280,19 @@ func (s *TimerLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, *model.Ap
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, *model.AppError) {
+	childStore := s.EmojiStore
+	if view, ok := s.Root.queryTimeouts["EmojiStore.Search"]; ok {
+		childStore = view.Emoji()
+	}
+	span := s.Root.span.StartChild("EmojiStore.Search", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.EmojiStore.Search(name, prefixOnly, limit)
+	resultVar0, resultVar1 := childStore.Search(name, prefixOnly, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2416,13 +3303,19 @@ func (s *TimerLayerEmojiStore) Search(name string, prefixOnly bool, limit int) (
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Search", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerFileInfoStore) AttachToPost(fileId string, postId string, creatorId string) *model.AppError {
+	childStore := s.FileInfoStore
+	if view, ok := s.Root.queryTimeouts["FileInfoStore.AttachToPost"]; ok {
+		childStore = view.FileInfo()
+	}
+	span := s.Root.span.StartChild("FileInfoStore.AttachToPost", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.FileInfoStore.AttachToPost(fileId, postId, creatorId)
+	resultVar0 := childStore.AttachToPost(fileId, postId, creatorId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2433,13 +3326,19 @@ func (s *TimerLayerFileInfoStore) AttachToPost(fileId string, postId string, cre
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.AttachToPost", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerFileInfoStore) ClearCaches() {
+	childStore := s.FileInfoStore
+	if view, ok := s.Root.queryTimeouts["FileInfoStore.ClearCaches"]; ok {
+		childStore = view.FileInfo()
+	}
+	span := s.Root.span.StartChild("FileInfoStore.ClearCaches", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.FileInfoStore.ClearCaches()
+	childStore.ClearCaches()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2450,13 +3349,19 @@ func (s *TimerLayerFileInfoStore) ClearCaches() {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.ClearCaches", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return
 }
 
 func (s *TimerLayerFileInfoStore) DeleteForPost(postId string) (string, *model.AppError) {
+	childStore := s.FileInfoStore
+	if view, ok := s.Root.queryTimeouts["FileInfoStore.DeleteForPost"]; ok {
+		childStore = view.FileInfo()
+	}
+	span := s.Root.span.StartChild("FileInfoStore.DeleteForPost", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.FileInfoStore.DeleteForPost(postId)
+	resultVar0, resultVar1 := childStore.DeleteForPost(postId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2467,13 +3372,19 @@ func (s *TimerLayerFileInfoStore) DeleteForPost(postId string) (string, *model.A
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.DeleteForPost", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerFileInfoStore) Get(id string) (*model.FileInfo, *model.AppError) {
+	childStore := s.FileInfoStore
+	if view, ok := s.Root.queryTimeouts["FileInfoStore.Get"]; ok {
+		childStore = view.FileInfo()
+	}
+	span := s.Root.span.StartChild("FileInfoStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.FileInfoStore.Get(id)
+	resultVar0, resultVar1 := childStore.Get(id)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2484,13 +3395,19 @@ func (s *TimerLayerFileInfoStore) Get(id string) (*model.FileInfo, *model.AppErr
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, *model.AppError) {
+	childStore := s.FileInfoStore
+	if view, ok := s.Root.queryTimeouts["FileInfoStore.GetByPath"]; ok {
+		childStore = view.FileInfo()
+	}
+	span := s.Root.span.StartChild("FileInfoStore.GetByPath", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.FileInfoStore.GetByPath(path)
+	resultVar0, resultVar1 := childStore.GetByPath(path)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2501,13 +3418,19 @@ func (s *TimerLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, *mode
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetByPath", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerFileInfoStore) GetForPost(postId string, readFromMaster bool, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, *model.AppError) {
+	childStore := s.FileInfoStore
+	if view, ok := s.Root.queryTimeouts["FileInfoStore.GetForPost"]; ok {
+		childStore = view.FileInfo()
+	}
+	span := s.Root.span.StartChild("FileInfoStore.GetForPost", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.FileInfoStore.GetForPost(postId, readFromMaster, includeDeleted, allowFromCache)
+	resultVar0, resultVar1 := childStore.GetForPost(postId, readFromMaster, includeDeleted, allowFromCache)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2518,13 +3441,19 @@ func (s *TimerLayerFileInfoStore) GetForPost(postId string, readFromMaster bool,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetForPost", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerFileInfoStore) GetForUser(userId string) ([]*model.FileInfo, *model.AppError) {
+	childStore := s.FileInfoStore
+	if view, ok := s.Root.queryTimeouts["FileInfoStore.GetForUser"]; ok {
+		childStore = view.FileInfo()
+	}
+	span := s.Root.span.StartChild("FileInfoStore.GetForUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.FileInfoStore.GetForUser(userId)
+	resultVar0, resultVar1 := childStore.GetForUser(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2535,13 +3464,19 @@ func (s *TimerLayerFileInfoStore) GetForUser(userId string) ([]*model.FileInfo,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetForUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerFileInfoStore) InvalidateFileInfosForPostCache(postId string) {
+	childStore := s.FileInfoStore
+	if view, ok := s.Root.queryTimeouts["FileInfoStore.InvalidateFileInfosForPostCache"]; ok {
+		childStore = view.FileInfo()
+	}
+	span := s.Root.span.StartChild("FileInfoStore.InvalidateFileInfosForPostCache", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.FileInfoStore.InvalidateFileInfosForPostCache(postId)
+	childStore.InvalidateFileInfosForPostCache(postId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2552,13 +3487,19 @@ func (s *TimerLayerFileInfoStore) InvalidateFileInfosForPostCache(postId string)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.InvalidateFileInfosForPostCache", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return
 }
 
 func (s *TimerLayerFileInfoStore) PermanentDelete(fileId string) *model.AppError {
+	childStore := s.FileInfoStore
+	if view, ok := s.Root.queryTimeouts["FileInfoStore.PermanentDelete"]; ok {
+		childStore = view.FileInfo()
+	}
+	span := s.Root.span.StartChild("FileInfoStore.PermanentDelete", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.FileInfoStore.PermanentDelete(fileId)
+	resultVar0 := childStore.PermanentDelete(fileId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2569,13 +3510,19 @@ func (s *TimerLayerFileInfoStore) PermanentDelete(fileId string) *model.AppError
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDelete", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	childStore := s.FileInfoStore
+	if view, ok := s.Root.queryTimeouts["FileInfoStore.PermanentDeleteBatch"]; ok {
+		childStore = view.FileInfo()
+	}
+	span := s.Root.span.StartChild("FileInfoStore.PermanentDeleteBatch", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.FileInfoStore.PermanentDeleteBatch(endTime, limit)
+	resultVar0, resultVar1 := childStore.PermanentDeleteBatch(endTime, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2586,13 +3533,19 @@ func (s *TimerLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limit int6
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDeleteBatch", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerFileInfoStore) PermanentDeleteByUser(userId string) (int64, *model.AppError) {
+	childStore := s.FileInfoStore
+	if view, ok := s.Root.queryTimeouts["FileInfoStore.PermanentDeleteByUser"]; ok {
+		childStore = view.FileInfo()
+	}
+	span := s.Root.span.StartChild("FileInfoStore.PermanentDeleteByUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.FileInfoStore.PermanentDeleteByUser(userId)
+	resultVar0, resultVar1 := childStore.PermanentDeleteByUser(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2603,13 +3556,19 @@ func (s *TimerLayerFileInfoStore) PermanentDeleteByUser(userId string) (int64, *
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDeleteByUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, *model.AppError) {
+	childStore := s.FileInfoStore
+	if view, ok := s.Root.queryTimeouts["FileInfoStore.Save"]; ok {
+		childStore = view.FileInfo()
+	}
+	span := s.Root.span.StartChild("FileInfoStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.FileInfoStore.Save(info)
+	resultVar0, resultVar1 := childStore.Save(info)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2620,13 +3579,19 @@ func (s *TimerLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, *
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.ChannelMembersMinusGroupMembers"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.ChannelMembersMinusGroupMembers", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
+	resultVar0, resultVar1 := childStore.ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2637,13 +3602,19 @@ func (s *TimerLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.ChannelMembersMinusGroupMembers", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) ChannelMembersToAdd(since int64) ([]*model.UserChannelIDPair, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.ChannelMembersToAdd"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.ChannelMembersToAdd", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.ChannelMembersToAdd(since)
+	resultVar0, resultVar1 := childStore.ChannelMembersToAdd(since)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2654,13 +3625,19 @@ func (s *TimerLayerGroupStore) ChannelMembersToAdd(since int64) ([]*model.UserCh
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.ChannelMembersToAdd", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) ChannelMembersToRemove() ([]*model.ChannelMember, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.ChannelMembersToRemove"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.ChannelMembersToRemove", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.ChannelMembersToRemove()
+	resultVar0, resultVar1 := childStore.ChannelMembersToRemove()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2671,13 +3648,19 @@ func (s *TimerLayerGroupStore) ChannelMembersToRemove() ([]*model.ChannelMember,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.ChannelMembersToRemove", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) CountChannelMembersMinusGroupMembers(channelID string, groupIDs []string) (int64, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.CountChannelMembersMinusGroupMembers"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.CountChannelMembersMinusGroupMembers", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.CountChannelMembersMinusGroupMembers(channelID, groupIDs)
+	resultVar0, resultVar1 := childStore.CountChannelMembersMinusGroupMembers(channelID, groupIDs)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2688,13 +3671,19 @@ func (s *TimerLayerGroupStore) CountChannelMembersMinusGroupMembers(channelID st
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountChannelMembersMinusGroupMembers", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) CountGroupsByChannel(channelId string, opts model.GroupSearchOpts) (int64, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.CountGroupsByChannel"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.CountGroupsByChannel", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.CountGroupsByChannel(channelId, opts)
+	resultVar0, resultVar1 := childStore.CountGroupsByChannel(channelId, opts)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2705,13 +3694,19 @@ func (s *TimerLayerGroupStore) CountGroupsByChannel(channelId string, opts model
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountGroupsByChannel", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) CountGroupsByTeam(teamId string, opts model.GroupSearchOpts) (int64, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.CountGroupsByTeam"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.CountGroupsByTeam", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.CountGroupsByTeam(teamId, opts)
+	resultVar0, resultVar1 := childStore.CountGroupsByTeam(teamId, opts)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2722,13 +3717,19 @@ func (s *TimerLayerGroupStore) CountGroupsByTeam(teamId string, opts model.Group
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountGroupsByTeam", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) CountTeamMembersMinusGroupMembers(teamID string, groupIDs []string) (int64, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.CountTeamMembersMinusGroupMembers"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.CountTeamMembersMinusGroupMembers", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.CountTeamMembersMinusGroupMembers(teamID, groupIDs)
+	resultVar0, resultVar1 := childStore.CountTeamMembersMinusGroupMembers(teamID, groupIDs)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2739,13 +3740,19 @@ func (s *TimerLayerGroupStore) CountTeamMembersMinusGroupMembers(teamID string,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountTeamMembersMinusGroupMembers", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) Create(group *model.Group) (*model.Group, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.Create"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.Create", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.Create(group)
+	resultVar0, resultVar1 := childStore.Create(group)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2756,13 +3763,19 @@ func (s *TimerLayerGroupStore) Create(group *model.Group) (*model.Group, *model.
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Create", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) CreateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.CreateGroupSyncable"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.CreateGroupSyncable", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.CreateGroupSyncable(groupSyncable)
+	resultVar0, resultVar1 := childStore.CreateGroupSyncable(groupSyncable)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2773,13 +3786,19 @@ func (s *TimerLayerGroupStore) CreateGroupSyncable(groupSyncable *model.GroupSyn
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CreateGroupSyncable", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) Delete(groupID string) (*model.Group, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.Delete"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.Delete", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.Delete(groupID)
+	resultVar0, resultVar1 := childStore.Delete(groupID)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2790,13 +3809,19 @@ func (s *TimerLayerGroupStore) Delete(groupID string) (*model.Group, *model.AppE
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Delete", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.DeleteGroupSyncable"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.DeleteGroupSyncable", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.DeleteGroupSyncable(groupID, syncableID, syncableType)
+	resultVar0, resultVar1 := childStore.DeleteGroupSyncable(groupID, syncableID, syncableType)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2807,13 +3832,19 @@ func (s *TimerLayerGroupStore) DeleteGroupSyncable(groupID string, syncableID st
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.DeleteGroupSyncable", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) DeleteMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.DeleteMember"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.DeleteMember", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.DeleteMember(groupID, userID)
+	resultVar0, resultVar1 := childStore.DeleteMember(groupID, userID)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2824,13 +3855,19 @@ func (s *TimerLayerGroupStore) DeleteMember(groupID string, userID string) (*mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.DeleteMember", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) Get(groupID string) (*model.Group, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.Get"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.Get(groupID)
+	resultVar0, resultVar1 := childStore.Get(groupID)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2841,13 +3878,19 @@ func (s *TimerLayerGroupStore) Get(groupID string) (*model.Group, *model.AppErro
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) GetAllBySource(groupSource model.GroupSource) ([]*model.Group, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.GetAllBySource"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.GetAllBySource", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.GetAllBySource(groupSource)
+	resultVar0, resultVar1 := childStore.GetAllBySource(groupSource)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2858,13 +3901,19 @@ func (s *TimerLayerGroupStore) GetAllBySource(groupSource model.GroupSource) ([]
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetAllBySource", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) GetAllGroupSyncablesByGroupId(groupID string, syncableType model.GroupSyncableType) ([]*model.GroupSyncable, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.GetAllGroupSyncablesByGroupId"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.GetAllGroupSyncablesByGroupId", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.GetAllGroupSyncablesByGroupId(groupID, syncableType)
+	resultVar0, resultVar1 := childStore.GetAllGroupSyncablesByGroupId(groupID, syncableType)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2875,13 +3924,19 @@ func (s *TimerLayerGroupStore) GetAllGroupSyncablesByGroupId(groupID string, syn
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetAllGroupSyncablesByGroupId", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) GetByIDs(groupIDs []string) ([]*model.Group, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.GetByIDs"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.GetByIDs", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.GetByIDs(groupIDs)
+	resultVar0, resultVar1 := childStore.GetByIDs(groupIDs)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2892,13 +3947,19 @@ func (s *TimerLayerGroupStore) GetByIDs(groupIDs []string) ([]*model.Group, *mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetByIDs", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) GetByRemoteID(remoteID string, groupSource model.GroupSource) (*model.Group, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.GetByRemoteID"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.GetByRemoteID", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.GetByRemoteID(remoteID, groupSource)
+	resultVar0, resultVar1 := childStore.GetByRemoteID(remoteID, groupSource)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2909,13 +3970,19 @@ func (s *TimerLayerGroupStore) GetByRemoteID(remoteID string, groupSource model.
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetByRemoteID", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.GetGroupSyncable"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.GetGroupSyncable", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.GetGroupSyncable(groupID, syncableID, syncableType)
+	resultVar0, resultVar1 := childStore.GetGroupSyncable(groupID, syncableID, syncableType)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2926,13 +3993,19 @@ func (s *TimerLayerGroupStore) GetGroupSyncable(groupID string, syncableID strin
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroupSyncable", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) GetGroups(page int, perPage int, opts model.GroupSearchOpts) ([]*model.Group, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.GetGroups"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.GetGroups", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.GetGroups(page, perPage, opts)
+	resultVar0, resultVar1 := childStore.GetGroups(page, perPage, opts)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2943,13 +4016,19 @@ func (s *TimerLayerGroupStore) GetGroups(page int, perPage int, opts model.Group
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroups", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) GetGroupsByChannel(channelId string, opts model.GroupSearchOpts) ([]*model.Group, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.GetGroupsByChannel"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.GetGroupsByChannel", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.GetGroupsByChannel(channelId, opts)
+	resultVar0, resultVar1 := childStore.GetGroupsByChannel(channelId, opts)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2960,13 +4039,19 @@ func (s *TimerLayerGroupStore) GetGroupsByChannel(channelId string, opts model.G
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroupsByChannel", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) GetGroupsByTeam(teamId string, opts model.GroupSearchOpts) ([]*model.Group, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.GetGroupsByTeam"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.GetGroupsByTeam", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.GetGroupsByTeam(teamId, opts)
+	resultVar0, resultVar1 := childStore.GetGroupsByTeam(teamId, opts)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2977,13 +4062,19 @@ func (s *TimerLayerGroupStore) GetGroupsByTeam(teamId string, opts model.GroupSe
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroupsByTeam", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) GetMemberCount(groupID string) (int64, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.GetMemberCount"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.GetMemberCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.GetMemberCount(groupID)
+	resultVar0, resultVar1 := childStore.GetMemberCount(groupID)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -2994,13 +4085,19 @@ func (s *TimerLayerGroupStore) GetMemberCount(groupID string) (int64, *model.App
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) GetMemberUsers(groupID string) ([]*model.User, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.GetMemberUsers"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.GetMemberUsers", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.GetMemberUsers(groupID)
+	resultVar0, resultVar1 := childStore.GetMemberUsers(groupID)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3011,13 +4108,19 @@ func (s *TimerLayerGroupStore) GetMemberUsers(groupID string) ([]*model.User, *m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberUsers", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) GetMemberUsersPage(groupID string, page int, perPage int) ([]*model.User, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.GetMemberUsersPage"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.GetMemberUsersPage", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.GetMemberUsersPage(groupID, page, perPage)
+	resultVar0, resultVar1 := childStore.GetMemberUsersPage(groupID, page, perPage)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3028,13 +4131,19 @@ func (s *TimerLayerGroupStore) GetMemberUsersPage(groupID string, page int, perP
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberUsersPage", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) TeamMembersMinusGroupMembers(teamID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.TeamMembersMinusGroupMembers"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.TeamMembersMinusGroupMembers", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.TeamMembersMinusGroupMembers(teamID, groupIDs, page, perPage)
+	resultVar0, resultVar1 := childStore.TeamMembersMinusGroupMembers(teamID, groupIDs, page, perPage)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3045,13 +4154,19 @@ func (s *TimerLayerGroupStore) TeamMembersMinusGroupMembers(teamID string, group
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.TeamMembersMinusGroupMembers", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) TeamMembersToAdd(since int64) ([]*model.UserTeamIDPair, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.TeamMembersToAdd"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.TeamMembersToAdd", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.TeamMembersToAdd(since)
+	resultVar0, resultVar1 := childStore.TeamMembersToAdd(since)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3062,13 +4177,19 @@ func (s *TimerLayerGroupStore) TeamMembersToAdd(since int64) ([]*model.UserTeamI
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.TeamMembersToAdd", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) TeamMembersToRemove() ([]*model.TeamMember, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.TeamMembersToRemove"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.TeamMembersToRemove", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.TeamMembersToRemove()
+	resultVar0, resultVar1 := childStore.TeamMembersToRemove()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3079,13 +4200,19 @@ func (s *TimerLayerGroupStore) TeamMembersToRemove() ([]*model.TeamMember, *mode
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.TeamMembersToRemove", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) Update(group *model.Group) (*model.Group, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.Update"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.Update", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.Update(group)
+	resultVar0, resultVar1 := childStore.Update(group)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3096,13 +4223,19 @@ func (s *TimerLayerGroupStore) Update(group *model.Group) (*model.Group, *model.
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Update", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.UpdateGroupSyncable"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.UpdateGroupSyncable", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.UpdateGroupSyncable(groupSyncable)
+	resultVar0, resultVar1 := childStore.UpdateGroupSyncable(groupSyncable)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3113,13 +4246,19 @@ func (s *TimerLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyn
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.UpdateGroupSyncable", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerGroupStore) UpsertMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+	childStore := s.GroupStore
+	if view, ok := s.Root.queryTimeouts["GroupStore.UpsertMember"]; ok {
+		childStore = view.Group()
+	}
+	span := s.Root.span.StartChild("GroupStore.UpsertMember", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.GroupStore.UpsertMember(groupID, userID)
+	resultVar0, resultVar1 := childStore.UpsertMember(groupID, userID)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3130,13 +4269,19 @@ func (s *TimerLayerGroupStore) UpsertMember(groupID string, userID string) (*mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.UpsertMember", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerJobStore) Delete(id string) (string, *model.AppError) {
+	childStore := s.JobStore
+	if view, ok := s.Root.queryTimeouts["JobStore.Delete"]; ok {
+		childStore = view.Job()
+	}
+	span := s.Root.span.StartChild("JobStore.Delete", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.Delete(id)
+	resultVar0, resultVar1 := childStore.Delete(id)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3147,13 +4292,19 @@ func (s *TimerLayerJobStore) Delete(id string) (string, *model.AppError) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.Delete", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerJobStore) Get(id string) (*model.Job, *model.AppError) {
+	childStore := s.JobStore
+	if view, ok := s.Root.queryTimeouts["JobStore.Get"]; ok {
+		childStore = view.Job()
+	}
+	span := s.Root.span.StartChild("JobStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.Get(id)
+	resultVar0, resultVar1 := childStore.Get(id)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3164,13 +4315,19 @@ func (s *TimerLayerJobStore) Get(id string) (*model.Job, *model.AppError) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerJobStore) GetAllByStatus(status string) ([]*model.Job, *model.AppError) {
+	childStore := s.JobStore
+	if view, ok := s.Root.queryTimeouts["JobStore.GetAllByStatus"]; ok {
+		childStore = view.Job()
+	}
+	span := s.Root.span.StartChild("JobStore.GetAllByStatus", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.GetAllByStatus(status)
+	resultVar0, resultVar1 := childStore.GetAllByStatus(status)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3181,13 +4338,19 @@ func (s *TimerLayerJobStore) GetAllByStatus(status string) ([]*model.Job, *model
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByStatus", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerJobStore) GetAllByType(jobType string) ([]*model.Job, *model.AppError) {
+	childStore := s.JobStore
+	if view, ok := s.Root.queryTimeouts["JobStore.GetAllByType"]; ok {
+		childStore = view.Job()
+	}
+	span := s.Root.span.StartChild("JobStore.GetAllByType", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.GetAllByType(jobType)
+	resultVar0, resultVar1 := childStore.GetAllByType(jobType)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3198,13 +4361,19 @@ func (s *TimerLayerJobStore) GetAllByType(jobType string) ([]*model.Job, *model.
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByType", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerJobStore) GetAllByTypePage(jobType string, offset int, limit int) ([]*model.Job, *model.AppError) {
+	childStore := s.JobStore
+	if view, ok := s.Root.queryTimeouts["JobStore.GetAllByTypePage"]; ok {
+		childStore = view.Job()
+	}
+	span := s.Root.span.StartChild("JobStore.GetAllByTypePage", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.GetAllByTypePage(jobType, offset, limit)
+	resultVar0, resultVar1 := childStore.GetAllByTypePage(jobType, offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3215,13 +4384,19 @@ func (s *TimerLayerJobStore) GetAllByTypePage(jobType string, offset int, limit
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByTypePage", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerJobStore) GetAllPage(offset int, limit int) ([]*model.Job, *model.AppError) {
+	childStore := s.JobStore
+	if view, ok := s.Root.queryTimeouts["JobStore.GetAllPage"]; ok {
+		childStore = view.Job()
+	}
+	span := s.Root.span.StartChild("JobStore.GetAllPage", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.GetAllPage(offset, limit)
+	resultVar0, resultVar1 := childStore.GetAllPage(offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3232,13 +4407,19 @@ func (s *TimerLayerJobStore) GetAllPage(offset int, limit int) ([]*model.Job, *m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllPage", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerJobStore) GetCountByStatusAndType(status string, jobType string) (int64, *model.AppError) {
+	childStore := s.JobStore
+	if view, ok := s.Root.queryTimeouts["JobStore.GetCountByStatusAndType"]; ok {
+		childStore = view.Job()
+	}
+	span := s.Root.span.StartChild("JobStore.GetCountByStatusAndType", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.GetCountByStatusAndType(status, jobType)
+	resultVar0, resultVar1 := childStore.GetCountByStatusAndType(status, jobType)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3249,13 +4430,19 @@ func (s *TimerLayerJobStore) GetCountByStatusAndType(status string, jobType stri
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetCountByStatusAndType", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerJobStore) GetNewestJobByStatusAndType(status string, jobType string) (*model.Job, *model.AppError) {
+	childStore := s.JobStore
+	if view, ok := s.Root.queryTimeouts["JobStore.GetNewestJobByStatusAndType"]; ok {
+		childStore = view.Job()
+	}
+	span := s.Root.span.StartChild("JobStore.GetNewestJobByStatusAndType", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.GetNewestJobByStatusAndType(status, jobType)
+	resultVar0, resultVar1 := childStore.GetNewestJobByStatusAndType(status, jobType)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3266,13 +4453,19 @@ func (s *TimerLayerJobStore) GetNewestJobByStatusAndType(status string, jobType
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetNewestJobByStatusAndType", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerJobStore) Save(job *model.Job) (*model.Job, *model.AppError) {
+	childStore := s.JobStore
+	if view, ok := s.Root.queryTimeouts["JobStore.Save"]; ok {
+		childStore = view.Job()
+	}
+	span := s.Root.span.StartChild("JobStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.Save(job)
+	resultVar0, resultVar1 := childStore.Save(job)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3283,13 +4476,19 @@ func (s *TimerLayerJobStore) Save(job *model.Job) (*model.Job, *model.AppError)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus string) (bool, *model.AppError) {
+	childStore := s.JobStore
+	if view, ok := s.Root.queryTimeouts["JobStore.UpdateOptimistically"]; ok {
+		childStore = view.Job()
+	}
+	span := s.Root.span.StartChild("JobStore.UpdateOptimistically", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.UpdateOptimistically(job, currentStatus)
+	resultVar0, resultVar1 := childStore.UpdateOptimistically(job, currentStatus)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3300,13 +4499,19 @@ func (s *TimerLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateOptimistically", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerJobStore) UpdateStatus(id string, status string) (*model.Job, *model.AppError) {
+	childStore := s.JobStore
+	if view, ok := s.Root.queryTimeouts["JobStore.UpdateStatus"]; ok {
+		childStore = view.Job()
+	}
+	span := s.Root.span.StartChild("JobStore.UpdateStatus", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.UpdateStatus(id, status)
+	resultVar0, resultVar1 := childStore.UpdateStatus(id, status)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3317,13 +4522,19 @@ func (s *TimerLayerJobStore) UpdateStatus(id string, status string) (*model.Job,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateStatus", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerJobStore) UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (bool, *model.AppError) {
+	childStore := s.JobStore
+	if view, ok := s.Root.queryTimeouts["JobStore.UpdateStatusOptimistically"]; ok {
+		childStore = view.Job()
+	}
+	span := s.Root.span.StartChild("JobStore.UpdateStatusOptimistically", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.JobStore.UpdateStatusOptimistically(id, currentStatus, newStatus)
+	resultVar0, resultVar1 := childStore.UpdateStatusOptimistically(id, currentStatus, newStatus)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3334,13 +4545,19 @@ func (s *TimerLayerJobStore) UpdateStatusOptimistically(id string, currentStatus
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateStatusOptimistically", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerLicenseStore) Get(id string) (*model.LicenseRecord, *model.AppError) {
+	childStore := s.LicenseStore
+	if view, ok := s.Root.queryTimeouts["LicenseStore.Get"]; ok {
+		childStore = view.License()
+	}
+	span := s.Root.span.StartChild("LicenseStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.LicenseStore.Get(id)
+	resultVar0, resultVar1 := childStore.Get(id)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3351,13 +4568,19 @@ func (s *TimerLayerLicenseStore) Get(id string) (*model.LicenseRecord, *model.Ap
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("LicenseStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerLicenseStore) Save(license *model.LicenseRecord) (*model.LicenseRecord, *model.AppError) {
+	childStore := s.LicenseStore
+	if view, ok := s.Root.queryTimeouts["LicenseStore.Save"]; ok {
+		childStore = view.License()
+	}
+	span := s.Root.span.StartChild("LicenseStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.LicenseStore.Save(license)
+	resultVar0, resultVar1 := childStore.Save(license)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3368,13 +4591,19 @@ func (s *TimerLayerLicenseStore) Save(license *model.LicenseRecord) (*model.Lice
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("LicenseStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerLinkMetadataStore) Get(url string, timestamp int64) (*model.LinkMetadata, *model.AppError) {
+	childStore := s.LinkMetadataStore
+	if view, ok := s.Root.queryTimeouts["LinkMetadataStore.Get"]; ok {
+		childStore = view.LinkMetadata()
+	}
+	span := s.Root.span.StartChild("LinkMetadataStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.LinkMetadataStore.Get(url, timestamp)
+	resultVar0, resultVar1 := childStore.Get(url, timestamp)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3385,13 +4614,19 @@ func (s *TimerLayerLinkMetadataStore) Get(url string, timestamp int64) (*model.L
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("LinkMetadataStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*model.LinkMetadata, *model.AppError) {
+	childStore := s.LinkMetadataStore
+	if view, ok := s.Root.queryTimeouts["LinkMetadataStore.Save"]; ok {
+		childStore = view.LinkMetadata()
+	}
+	span := s.Root.span.StartChild("LinkMetadataStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.LinkMetadataStore.Save(linkMetadata)
+	resultVar0, resultVar1 := childStore.Save(linkMetadata)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3402,13 +4637,19 @@ func (s *TimerLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("LinkMetadataStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerOAuthStore) DeleteApp(id string) *model.AppError {
+	childStore := s.OAuthStore
+	if view, ok := s.Root.queryTimeouts["OAuthStore.DeleteApp"]; ok {
+		childStore = view.OAuth()
+	}
+	span := s.Root.span.StartChild("OAuthStore.DeleteApp", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.OAuthStore.DeleteApp(id)
+	resultVar0 := childStore.DeleteApp(id)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3419,13 +4660,19 @@ func (s *TimerLayerOAuthStore) DeleteApp(id string) *model.AppError {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.DeleteApp", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerOAuthStore) GetAccessData(token string) (*model.AccessData, *model.AppError) {
+	childStore := s.OAuthStore
+	if view, ok := s.Root.queryTimeouts["OAuthStore.GetAccessData"]; ok {
+		childStore = view.OAuth()
+	}
+	span := s.Root.span.StartChild("OAuthStore.GetAccessData", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.GetAccessData(token)
+	resultVar0, resultVar1 := childStore.GetAccessData(token)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3436,13 +4683,19 @@ func (s *TimerLayerOAuthStore) GetAccessData(token string) (*model.AccessData, *
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAccessData", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerOAuthStore) GetAccessDataByRefreshToken(token string) (*model.AccessData, *model.AppError) {
+	childStore := s.OAuthStore
+	if view, ok := s.Root.queryTimeouts["OAuthStore.GetAccessDataByRefreshToken"]; ok {
+		childStore = view.OAuth()
+	}
+	span := s.Root.span.StartChild("OAuthStore.GetAccessDataByRefreshToken", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.GetAccessDataByRefreshToken(token)
+	resultVar0, resultVar1 := childStore.GetAccessDataByRefreshToken(token)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3453,13 +4706,19 @@ func (s *TimerLayerOAuthStore) GetAccessDataByRefreshToken(token string) (*model
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAccessDataByRefreshToken", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerOAuthStore) GetAccessDataByUserForApp(userId string, clientId string) ([]*model.AccessData, *model.AppError) {
+	childStore := s.OAuthStore
+	if view, ok := s.Root.queryTimeouts["OAuthStore.GetAccessDataByUserForApp"]; ok {
+		childStore = view.OAuth()
+	}
+	span := s.Root.span.StartChild("OAuthStore.GetAccessDataByUserForApp", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.GetAccessDataByUserForApp(userId, clientId)
+	resultVar0, resultVar1 := childStore.GetAccessDataByUserForApp(userId, clientId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3470,13 +4729,19 @@ func (s *TimerLayerOAuthStore) GetAccessDataByUserForApp(userId string, clientId
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAccessDataByUserForApp", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerOAuthStore) GetApp(id string) (*model.OAuthApp, *model.AppError) {
+	childStore := s.OAuthStore
+	if view, ok := s.Root.queryTimeouts["OAuthStore.GetApp"]; ok {
+		childStore = view.OAuth()
+	}
+	span := s.Root.span.StartChild("OAuthStore.GetApp", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.GetApp(id)
+	resultVar0, resultVar1 := childStore.GetApp(id)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3487,13 +4752,19 @@ func (s *TimerLayerOAuthStore) GetApp(id string) (*model.OAuthApp, *model.AppErr
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetApp", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerOAuthStore) GetAppByUser(userId string, offset int, limit int) ([]*model.OAuthApp, *model.AppError) {
+	childStore := s.OAuthStore
+	if view, ok := s.Root.queryTimeouts["OAuthStore.GetAppByUser"]; ok {
+		childStore = view.OAuth()
+	}
+	span := s.Root.span.StartChild("OAuthStore.GetAppByUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.GetAppByUser(userId, offset, limit)
+	resultVar0, resultVar1 := childStore.GetAppByUser(userId, offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3504,13 +4775,19 @@ func (s *TimerLayerOAuthStore) GetAppByUser(userId string, offset int, limit int
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAppByUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp, *model.AppError) {
+	childStore := s.OAuthStore
+	if view, ok := s.Root.queryTimeouts["OAuthStore.GetApps"]; ok {
+		childStore = view.OAuth()
+	}
+	span := s.Root.span.StartChild("OAuthStore.GetApps", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.GetApps(offset, limit)
+	resultVar0, resultVar1 := childStore.GetApps(offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3521,13 +4798,19 @@ func (s *TimerLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetApps", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerOAuthStore) GetAuthData(code string) (*model.AuthData, *model.AppError) {
+	childStore := s.OAuthStore
+	if view, ok := s.Root.queryTimeouts["OAuthStore.GetAuthData"]; ok {
+		childStore = view.OAuth()
+	}
+	span := s.Root.span.StartChild("OAuthStore.GetAuthData", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.GetAuthData(code)
+	resultVar0, resultVar1 := childStore.GetAuthData(code)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3538,13 +4821,19 @@ func (s *TimerLayerOAuthStore) GetAuthData(code string) (*model.AuthData, *model
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAuthData", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerOAuthStore) GetAuthorizedApps(userId string, offset int, limit int) ([]*model.OAuthApp, *model.AppError) {
+	childStore := s.OAuthStore
+	if view, ok := s.Root.queryTimeouts["OAuthStore.GetAuthorizedApps"]; ok {
+		childStore = view.OAuth()
+	}
+	span := s.Root.span.StartChild("OAuthStore.GetAuthorizedApps", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.GetAuthorizedApps(userId, offset, limit)
+	resultVar0, resultVar1 := childStore.GetAuthorizedApps(userId, offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3555,13 +4844,19 @@ func (s *TimerLayerOAuthStore) GetAuthorizedApps(userId string, offset int, limi
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAuthorizedApps", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerOAuthStore) GetPreviousAccessData(userId string, clientId string) (*model.AccessData, *model.AppError) {
+	childStore := s.OAuthStore
+	if view, ok := s.Root.queryTimeouts["OAuthStore.GetPreviousAccessData"]; ok {
+		childStore = view.OAuth()
+	}
+	span := s.Root.span.StartChild("OAuthStore.GetPreviousAccessData", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.GetPreviousAccessData(userId, clientId)
+	resultVar0, resultVar1 := childStore.GetPreviousAccessData(userId, clientId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3572,13 +4867,19 @@ func (s *TimerLayerOAuthStore) GetPreviousAccessData(userId string, clientId str
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetPreviousAccessData", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerOAuthStore) PermanentDeleteAuthDataByUser(userId string) *model.AppError {
+	childStore := s.OAuthStore
+	if view, ok := s.Root.queryTimeouts["OAuthStore.PermanentDeleteAuthDataByUser"]; ok {
+		childStore = view.OAuth()
+	}
+	span := s.Root.span.StartChild("OAuthStore.PermanentDeleteAuthDataByUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.OAuthStore.PermanentDeleteAuthDataByUser(userId)
+	resultVar0 := childStore.PermanentDeleteAuthDataByUser(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3589,13 +4890,19 @@ func (s *TimerLayerOAuthStore) PermanentDeleteAuthDataByUser(userId string) *mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.PermanentDeleteAuthDataByUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerOAuthStore) RemoveAccessData(token string) *model.AppError {
+	childStore := s.OAuthStore
+	if view, ok := s.Root.queryTimeouts["OAuthStore.RemoveAccessData"]; ok {
+		childStore = view.OAuth()
+	}
+	span := s.Root.span.StartChild("OAuthStore.RemoveAccessData", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.OAuthStore.RemoveAccessData(token)
+	resultVar0 := childStore.RemoveAccessData(token)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3606,13 +4913,19 @@ func (s *TimerLayerOAuthStore) RemoveAccessData(token string) *model.AppError {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.RemoveAccessData", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerOAuthStore) RemoveAllAccessData() *model.AppError {
+	childStore := s.OAuthStore
+	if view, ok := s.Root.queryTimeouts["OAuthStore.RemoveAllAccessData"]; ok {
+		childStore = view.OAuth()
+	}
+	span := s.Root.span.StartChild("OAuthStore.RemoveAllAccessData", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.OAuthStore.RemoveAllAccessData()
+	resultVar0 := childStore.RemoveAllAccessData()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3623,13 +4936,19 @@ func (s *TimerLayerOAuthStore) RemoveAllAccessData() *model.AppError {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.RemoveAllAccessData", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerOAuthStore) RemoveAuthData(code string) *model.AppError {
+	childStore := s.OAuthStore
+	if view, ok := s.Root.queryTimeouts["OAuthStore.RemoveAuthData"]; ok {
+		childStore = view.OAuth()
+	}
+	span := s.Root.span.StartChild("OAuthStore.RemoveAuthData", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.OAuthStore.RemoveAuthData(code)
+	resultVar0 := childStore.RemoveAuthData(code)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3640,13 +4959,19 @@ func (s *TimerLayerOAuthStore) RemoveAuthData(code string) *model.AppError {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.RemoveAuthData", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, *model.AppError) {
+	childStore := s.OAuthStore
+	if view, ok := s.Root.queryTimeouts["OAuthStore.SaveAccessData"]; ok {
+		childStore = view.OAuth()
+	}
+	span := s.Root.span.StartChild("OAuthStore.SaveAccessData", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.SaveAccessData(accessData)
+	resultVar0, resultVar1 := childStore.SaveAccessData(accessData)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3657,13 +4982,19 @@ func (s *TimerLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*mo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveAccessData", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError) {
+	childStore := s.OAuthStore
+	if view, ok := s.Root.queryTimeouts["OAuthStore.SaveApp"]; ok {
+		childStore = view.OAuth()
+	}
+	span := s.Root.span.StartChild("OAuthStore.SaveApp", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.SaveApp(app)
+	resultVar0, resultVar1 := childStore.SaveApp(app)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3674,13 +5005,19 @@ func (s *TimerLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, *m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveApp", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, *model.AppError) {
+	childStore := s.OAuthStore
+	if view, ok := s.Root.queryTimeouts["OAuthStore.SaveAuthData"]; ok {
+		childStore = view.OAuth()
+	}
+	span := s.Root.span.StartChild("OAuthStore.SaveAuthData", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.SaveAuthData(authData)
+	resultVar0, resultVar1 := childStore.SaveAuthData(authData)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3691,13 +5028,19 @@ func (s *TimerLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.Au
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveAuthData", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, *model.AppError) {
+	childStore := s.OAuthStore
+	if view, ok := s.Root.queryTimeouts["OAuthStore.UpdateAccessData"]; ok {
+		childStore = view.OAuth()
+	}
+	span := s.Root.span.StartChild("OAuthStore.UpdateAccessData", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.UpdateAccessData(accessData)
+	resultVar0, resultVar1 := childStore.UpdateAccessData(accessData)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3708,13 +5051,19 @@ func (s *TimerLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.UpdateAccessData", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError) {
+	childStore := s.OAuthStore
+	if view, ok := s.Root.queryTimeouts["OAuthStore.UpdateApp"]; ok {
+		childStore = view.OAuth()
+	}
+	span := s.Root.span.StartChild("OAuthStore.UpdateApp", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.OAuthStore.UpdateApp(app)
+	resultVar0, resultVar1 := childStore.UpdateApp(app)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3725,13 +5074,42 @@ func (s *TimerLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.UpdateApp", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, *model.AppError) {
+	childStore := s.PluginStore
+	if view, ok := s.Root.queryTimeouts["PluginStore.CompareAndDelete"]; ok {
+		childStore = view.Plugin()
+	}
+	span := s.Root.span.StartChild("PluginStore.CompareAndDelete", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.CompareAndDelete(keyVal, oldValue)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndDelete", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, *model.AppError) {
+	childStore := s.PluginStore
+	if view, ok := s.Root.queryTimeouts["PluginStore.CompareAndSet"]; ok {
+		childStore = view.Plugin()
+	}
+	span := s.Root.span.StartChild("PluginStore.CompareAndSet", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PluginStore.CompareAndSet(keyVal, oldValue)
+	resultVar0, resultVar1 := childStore.CompareAndSet(keyVal, oldValue)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3742,13 +5120,19 @@ func (s *TimerLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldV
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndSet", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerPluginStore) Delete(pluginId string, key string) *model.AppError {
+	childStore := s.PluginStore
+	if view, ok := s.Root.queryTimeouts["PluginStore.Delete"]; ok {
+		childStore = view.Plugin()
+	}
+	span := s.Root.span.StartChild("PluginStore.Delete", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.PluginStore.Delete(pluginId, key)
+	resultVar0 := childStore.Delete(pluginId, key)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3759,13 +5143,19 @@ func (s *TimerLayerPluginStore) Delete(pluginId string, key string) *model.AppEr
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Delete", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerPluginStore) DeleteAllExpired() *model.AppError {
+	childStore := s.PluginStore
+	if view, ok := s.Root.queryTimeouts["PluginStore.DeleteAllExpired"]; ok {
+		childStore = view.Plugin()
+	}
+	span := s.Root.span.StartChild("PluginStore.DeleteAllExpired", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.PluginStore.DeleteAllExpired()
+	resultVar0 := childStore.DeleteAllExpired()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3776,13 +5166,19 @@ func (s *TimerLayerPluginStore) DeleteAllExpired() *model.AppError {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.DeleteAllExpired", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerPluginStore) DeleteAllForPlugin(PluginId string) *model.AppError {
+	childStore := s.PluginStore
+	if view, ok := s.Root.queryTimeouts["PluginStore.DeleteAllForPlugin"]; ok {
+		childStore = view.Plugin()
+	}
+	span := s.Root.span.StartChild("PluginStore.DeleteAllForPlugin", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.PluginStore.DeleteAllForPlugin(PluginId)
+	resultVar0 := childStore.DeleteAllForPlugin(PluginId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3793,13 +5189,19 @@ func (s *TimerLayerPluginStore) DeleteAllForPlugin(PluginId string) *model.AppEr
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.DeleteAllForPlugin", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerPluginStore) Get(pluginId string, key string) (*model.PluginKeyValue, *model.AppError) {
+	childStore := s.PluginStore
+	if view, ok := s.Root.queryTimeouts["PluginStore.Get"]; ok {
+		childStore = view.Plugin()
+	}
+	span := s.Root.span.StartChild("PluginStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PluginStore.Get(pluginId, key)
+	resultVar0, resultVar1 := childStore.Get(pluginId, key)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3810,13 +5212,42 @@ func (s *TimerLayerPluginStore) Get(pluginId string, key string) (*model.PluginK
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPluginStore) GetMulti(pluginId string, keys []string) ([]*model.PluginKeyValue, *model.AppError) {
+	childStore := s.PluginStore
+	if view, ok := s.Root.queryTimeouts["PluginStore.GetMulti"]; ok {
+		childStore = view.Plugin()
+	}
+	span := s.Root.span.StartChild("PluginStore.GetMulti", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetMulti(pluginId, keys)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.GetMulti", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerPluginStore) List(pluginId string, page int, perPage int) ([]string, *model.AppError) {
+	childStore := s.PluginStore
+	if view, ok := s.Root.queryTimeouts["PluginStore.List"]; ok {
+		childStore = view.Plugin()
+	}
+	span := s.Root.span.StartChild("PluginStore.List", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PluginStore.List(pluginId, page, perPage)
+	resultVar0, resultVar1 := childStore.List(pluginId, page, perPage)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3827,13 +5258,42 @@ func (s *TimerLayerPluginStore) List(pluginId string, page int, perPage int) ([]
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.List", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPluginStore) ListWithPrefix(pluginId string, prefix string, offset int, limit int) ([]string, *model.AppError) {
+	childStore := s.PluginStore
+	if view, ok := s.Root.queryTimeouts["PluginStore.ListWithPrefix"]; ok {
+		childStore = view.Plugin()
+	}
+	span := s.Root.span.StartChild("PluginStore.ListWithPrefix", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.ListWithPrefix(pluginId, prefix, offset, limit)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.ListWithPrefix", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, *model.AppError) {
+	childStore := s.PluginStore
+	if view, ok := s.Root.queryTimeouts["PluginStore.SaveOrUpdate"]; ok {
+		childStore = view.Plugin()
+	}
+	span := s.Root.span.StartChild("PluginStore.SaveOrUpdate", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PluginStore.SaveOrUpdate(keyVal)
+	resultVar0, resultVar1 := childStore.SaveOrUpdate(keyVal)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3844,13 +5304,134 @@ func (s *TimerLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.SaveOrUpdate", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPluginStore) SetMulti(keyVals []*model.PluginKeyValue) *model.AppError {
+	childStore := s.PluginStore
+	if view, ok := s.Root.queryTimeouts["PluginStore.SetMulti"]; ok {
+		childStore = view.Plugin()
+	}
+	span := s.Root.span.StartChild("PluginStore.SetMulti", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0 := childStore.SetMulti(keyVals)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.SetMulti", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar0)
+	return resultVar0
+}
+
+func (s *TimerLayerPluginStore) SetWithOptions(pluginId string, key string, value []byte, options model.PluginKVSetOptions) (bool, *model.AppError) {
+	childStore := s.PluginStore
+	if view, ok := s.Root.queryTimeouts["PluginStore.SetWithOptions"]; ok {
+		childStore = view.Plugin()
+	}
+	span := s.Root.span.StartChild("PluginStore.SetWithOptions", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.SetWithOptions(pluginId, key, value, options)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.SetWithOptions", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPluginConfigRevisionStore) Get(id string) (*model.PluginConfigRevision, *model.AppError) {
+	childStore := s.PluginConfigRevisionStore
+	if view, ok := s.Root.queryTimeouts["PluginConfigRevisionStore.Get"]; ok {
+		childStore = view.PluginConfigRevision()
+	}
+	span := s.Root.span.StartChild("PluginConfigRevisionStore.Get", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.Get(id)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginConfigRevisionStore.Get", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPluginConfigRevisionStore) GetForPlugin(pluginId string, offset int, limit int) ([]*model.PluginConfigRevision, *model.AppError) {
+	childStore := s.PluginConfigRevisionStore
+	if view, ok := s.Root.queryTimeouts["PluginConfigRevisionStore.GetForPlugin"]; ok {
+		childStore = view.PluginConfigRevision()
+	}
+	span := s.Root.span.StartChild("PluginConfigRevisionStore.GetForPlugin", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetForPlugin(pluginId, offset, limit)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginConfigRevisionStore.GetForPlugin", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPluginConfigRevisionStore) Save(revision *model.PluginConfigRevision) (*model.PluginConfigRevision, *model.AppError) {
+	childStore := s.PluginConfigRevisionStore
+	if view, ok := s.Root.queryTimeouts["PluginConfigRevisionStore.Save"]; ok {
+		childStore = view.PluginConfigRevision()
+	}
+	span := s.Root.span.StartChild("PluginConfigRevisionStore.Save", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.Save(revision)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginConfigRevisionStore.Save", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerPostStore) AnalyticsPostCount(teamId string, mustHaveFile bool, mustHaveHashtag bool) (int64, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.AnalyticsPostCount"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.AnalyticsPostCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.AnalyticsPostCount(teamId, mustHaveFile, mustHaveHashtag)
+	resultVar0, resultVar1 := childStore.AnalyticsPostCount(teamId, mustHaveFile, mustHaveHashtag)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3861,13 +5442,19 @@ func (s *TimerLayerPostStore) AnalyticsPostCount(teamId string, mustHaveFile boo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsPostCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.AnalyticsPostCountsByDay"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.AnalyticsPostCountsByDay", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.AnalyticsPostCountsByDay(options)
+	resultVar0, resultVar1 := childStore.AnalyticsPostCountsByDay(options)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3878,13 +5465,19 @@ func (s *TimerLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsP
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsPostCountsByDay", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (model.AnalyticsRows, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.AnalyticsUserCountsWithPostsByDay"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.AnalyticsUserCountsWithPostsByDay", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamId)
+	resultVar0, resultVar1 := childStore.AnalyticsUserCountsWithPostsByDay(teamId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3895,13 +5488,42 @@ func (s *TimerLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsUserCountsWithPostsByDay", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerPostStore) Archive(path string, posts []*model.Post, endTime int64) *model.AppError {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.Archive"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.Archive", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0 := childStore.Archive(path, posts, endTime)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Archive", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar0)
+	return resultVar0
+}
+
 func (s *TimerLayerPostStore) ClearCaches() {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.ClearCaches"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.ClearCaches", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.PostStore.ClearCaches()
+	childStore.ClearCaches()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3912,13 +5534,686 @@ func (s *TimerLayerPostStore) ClearCaches() {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.ClearCaches", success, float64(elapsed))
 	}
-	return
+	s.Root.finishSpan(span, nil)
+	return
+}
+
+func (s *TimerLayerPostStore) Delete(postId string, time int64, deleteByID string) *model.AppError {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.Delete"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.Delete", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0 := childStore.Delete(postId, time, deleteByID)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Delete", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar0)
+	return resultVar0
+}
+
+func (s *TimerLayerPostStore) Get(id string) (*model.PostList, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.Get"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.Get", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.Get(id)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Get", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) GetArchivePaths(postIds []string) (map[string]string, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetArchivePaths"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetArchivePaths", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetArchivePaths(postIds)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetArchivePaths", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) GetArchivePathsBefore(endTime int64, limit int) ([]string, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetArchivePathsBefore"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetArchivePathsBefore", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetArchivePathsBefore(endTime, limit)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetArchivePathsBefore", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) GetArchivePathsForChannel(channelId string) ([]string, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetArchivePathsForChannel"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetArchivePathsForChannel", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetArchivePathsForChannel(channelId)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetArchivePathsForChannel", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string) ([]*model.DirectPostForExport, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetDirectPostParentsForExportAfter"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetDirectPostParentsForExportAfter", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetDirectPostParentsForExportAfter(limit, afterId)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetDirectPostParentsForExportAfter", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) GetEtag(channelId string, allowFromCache bool) string {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetEtag"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetEtag", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0 := childStore.GetEtag(channelId, allowFromCache)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetEtag", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, nil)
+	return resultVar0
+}
+
+func (s *TimerLayerPostStore) GetFlaggedPosts(userId string, offset int, limit int) (*model.PostList, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetFlaggedPosts"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetFlaggedPosts", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetFlaggedPosts(userId, offset, limit)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPosts", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) GetFlaggedPostsForChannel(userId string, channelId string, offset int, limit int) (*model.PostList, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetFlaggedPostsForChannel"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetFlaggedPostsForChannel", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetFlaggedPostsForChannel(userId, channelId, offset, limit)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPostsForChannel", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) GetFlaggedPostsForTeam(userId string, teamId string, offset int, limit int) (*model.PostList, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetFlaggedPostsForTeam"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetFlaggedPostsForTeam", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetFlaggedPostsForTeam(userId, teamId, offset, limit)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPostsForTeam", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) GetMaxPostSize() int {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetMaxPostSize"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetMaxPostSize", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0 := childStore.GetMaxPostSize()
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetMaxPostSize", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, nil)
+	return resultVar0
+}
+
+func (s *TimerLayerPostStore) GetOldest() (*model.Post, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetOldest"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetOldest", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetOldest()
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetOldest", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) GetParentsForExportAfter(limit int, afterId string) ([]*model.PostForExport, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetParentsForExportAfter"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetParentsForExportAfter", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetParentsForExportAfter(limit, afterId)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetParentsForExportAfter", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) GetPostAfterTime(channelId string, time int64) (*model.Post, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetPostAfterTime"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetPostAfterTime", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetPostAfterTime(channelId, time)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostAfterTime", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) GetPostIdAfterTime(channelId string, time int64) (string, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetPostIdAfterTime"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetPostIdAfterTime", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetPostIdAfterTime(channelId, time)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostIdAfterTime", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) GetPostIdBeforeTime(channelId string, time int64) (string, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetPostIdBeforeTime"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetPostIdBeforeTime", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetPostIdBeforeTime(channelId, time)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostIdBeforeTime", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) GetPosts(channelId string, offset int, limit int, allowFromCache bool) (*model.PostList, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetPosts"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetPosts", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetPosts(channelId, offset, limit, allowFromCache)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPosts", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) GetPostsAfter(channelId string, postId string, numPosts int, offset int) (*model.PostList, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetPostsAfter"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetPostsAfter", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetPostsAfter(channelId, postId, numPosts, offset)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsAfter", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) GetPostsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.PostForIndexing, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetPostsBatchForIndexing"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetPostsBatchForIndexing", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetPostsBatchForIndexing(startTime, endTime, limit)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsBatchForIndexing", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) GetPostsBefore(channelId string, postId string, numPosts int, offset int) (*model.PostList, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetPostsBefore"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetPostsBefore", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetPostsBefore(channelId, postId, numPosts, offset)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsBefore", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetPostsByIds"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetPostsByIds", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetPostsByIds(postIds)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsByIds", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) GetPostsCreatedAt(channelId string, time int64) ([]*model.Post, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetPostsCreatedAt"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetPostsCreatedAt", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetPostsCreatedAt(channelId, time)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsCreatedAt", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) GetPostsSince(channelId string, time int64, allowFromCache bool) (*model.PostList, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetPostsSince"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetPostsSince", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetPostsSince(channelId, time, allowFromCache)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsSince", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) GetRepliesForExport(parentId string) ([]*model.ReplyForExport, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetRepliesForExport"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetRepliesForExport", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetRepliesForExport(parentId)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetRepliesForExport", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) GetSingle(id string) (*model.Post, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetSingle"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetSingle", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetSingle(id)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetSingle", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) GetThreadsForArchival(endTime int64, afterCreateAt int64, afterId string, limit int) ([]*model.Post, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.GetThreadsForArchival"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.GetThreadsForArchival", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetThreadsForArchival(endTime, afterCreateAt, afterId, limit)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetThreadsForArchival", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) InvalidateLastPostTimeCache(channelId string) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.InvalidateLastPostTimeCache"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.InvalidateLastPostTimeCache", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	childStore.InvalidateLastPostTimeCache(channelId)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if true {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.InvalidateLastPostTimeCache", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, nil)
+	return
+}
+
+func (s *TimerLayerPostStore) Overwrite(post *model.Post) (*model.Post, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.Overwrite"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.Overwrite", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.Overwrite(post)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Overwrite", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.PermanentDeleteBatch"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.PermanentDeleteBatch", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.PermanentDeleteBatch(endTime, limit)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteBatch", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) Delete(postId string, time int64, deleteByID string) *model.AppError {
+func (s *TimerLayerPostStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.PermanentDeleteByChannel"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.PermanentDeleteByChannel", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.PostStore.Delete(postId, time, deleteByID)
+	resultVar0 := childStore.PermanentDeleteByChannel(channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3927,66 +6222,90 @@ func (s *TimerLayerPostStore) Delete(postId string, time int64, deleteByID strin
 		if resultVar0 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Delete", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteByChannel", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
-func (s *TimerLayerPostStore) Get(id string) (*model.PostList, *model.AppError) {
+func (s *TimerLayerPostStore) PermanentDeleteByUser(userId string) *model.AppError {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.PermanentDeleteByUser"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.PermanentDeleteByUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.Get(id)
+	resultVar0 := childStore.PermanentDeleteByUser(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Get", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteByUser", success, float64(elapsed))
 	}
-	return resultVar0, resultVar1
+	s.Root.finishSpan(span, resultVar0)
+	return resultVar0
 }
 
-func (s *TimerLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string) ([]*model.DirectPostForExport, *model.AppError) {
+func (s *TimerLayerPostStore) ReplaceArchive(path string, newPath string, posts []*model.Post) *model.AppError {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.ReplaceArchive"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.ReplaceArchive", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterId)
+	resultVar0 := childStore.ReplaceArchive(path, newPath, posts)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetDirectPostParentsForExportAfter", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.ReplaceArchive", success, float64(elapsed))
 	}
-	return resultVar0, resultVar1
+	s.Root.finishSpan(span, resultVar0)
+	return resultVar0
 }
 
-func (s *TimerLayerPostStore) GetEtag(channelId string, allowFromCache bool) string {
+func (s *TimerLayerPostStore) ResolveSearchScope(teamId string, userId string, params *model.SearchParams) (*model.SearchParams, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.ResolveSearchScope"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.ResolveSearchScope", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.PostStore.GetEtag(channelId, allowFromCache)
+	resultVar0, resultVar1 := childStore.ResolveSearchScope(teamId, userId, params)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetEtag", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.ResolveSearchScope", success, float64(elapsed))
 	}
-	return resultVar0
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetFlaggedPosts(userId string, offset int, limit int) (*model.PostList, *model.AppError) {
+func (s *TimerLayerPostStore) Save(post *model.Post) (*model.Post, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.Save"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetFlaggedPosts(userId, offset, limit)
+	resultVar0, resultVar1 := childStore.Save(post)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -3995,15 +6314,21 @@ func (s *TimerLayerPostStore) GetFlaggedPosts(userId string, offset int, limit i
 		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPosts", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetFlaggedPostsForChannel(userId string, channelId string, offset int, limit int) (*model.PostList, *model.AppError) {
+func (s *TimerLayerPostStore) Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.Search"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.Search", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetFlaggedPostsForChannel(userId, channelId, offset, limit)
+	resultVar0, resultVar1 := childStore.Search(teamId, userId, params)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4012,15 +6337,21 @@ func (s *TimerLayerPostStore) GetFlaggedPostsForChannel(userId string, channelId
 		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPostsForChannel", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Search", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetFlaggedPostsForTeam(userId string, teamId string, offset int, limit int) (*model.PostList, *model.AppError) {
+func (s *TimerLayerPostStore) SearchArchive(teamId string, userId string, params *model.SearchParams) ([]*model.PostArchiveIndex, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.SearchArchive"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.SearchArchive", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetFlaggedPostsForTeam(userId, teamId, offset, limit)
+	resultVar0, resultVar1 := childStore.SearchArchive(teamId, userId, params)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4029,32 +6360,44 @@ func (s *TimerLayerPostStore) GetFlaggedPostsForTeam(userId string, teamId strin
 		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPostsForTeam", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.SearchArchive", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetMaxPostSize() int {
+func (s *TimerLayerPostStore) Unarchive(path string, posts []*model.Post) *model.AppError {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.Unarchive"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.Unarchive", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.PostStore.GetMaxPostSize()
+	resultVar0 := childStore.Unarchive(path, posts)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar0 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetMaxPostSize", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Unarchive", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
-func (s *TimerLayerPostStore) GetOldest() (*model.Post, *model.AppError) {
+func (s *TimerLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, *model.AppError) {
+	childStore := s.PostStore
+	if view, ok := s.Root.queryTimeouts["PostStore.Update"]; ok {
+		childStore = view.Post()
+	}
+	span := s.Root.span.StartChild("PostStore.Update", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetOldest()
+	resultVar0, resultVar1 := childStore.Update(newPost, oldPost)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4063,32 +6406,44 @@ func (s *TimerLayerPostStore) GetOldest() (*model.Post, *model.AppError) {
 		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetOldest", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Update", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetParentsForExportAfter(limit int, afterId string) ([]*model.PostForExport, *model.AppError) {
+func (s *TimerLayerPostAcknowledgementStore) Delete(postId string, userId string) *model.AppError {
+	childStore := s.PostAcknowledgementStore
+	if view, ok := s.Root.queryTimeouts["PostAcknowledgementStore.Delete"]; ok {
+		childStore = view.PostAcknowledgement()
+	}
+	span := s.Root.span.StartChild("PostAcknowledgementStore.Delete", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetParentsForExportAfter(limit, afterId)
+	resultVar0 := childStore.Delete(postId, userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetParentsForExportAfter", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostAcknowledgementStore.Delete", success, float64(elapsed))
 	}
-	return resultVar0, resultVar1
+	s.Root.finishSpan(span, resultVar0)
+	return resultVar0
 }
 
-func (s *TimerLayerPostStore) GetPostAfterTime(channelId string, time int64) (*model.Post, *model.AppError) {
+func (s *TimerLayerPostAcknowledgementStore) GetForPost(postId string) ([]*model.PostAcknowledgement, *model.AppError) {
+	childStore := s.PostAcknowledgementStore
+	if view, ok := s.Root.queryTimeouts["PostAcknowledgementStore.GetForPost"]; ok {
+		childStore = view.PostAcknowledgement()
+	}
+	span := s.Root.span.StartChild("PostAcknowledgementStore.GetForPost", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetPostAfterTime(channelId, time)
+	resultVar0, resultVar1 := childStore.GetForPost(postId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4097,15 +6452,21 @@ func (s *TimerLayerPostStore) GetPostAfterTime(channelId string, time int64) (*m
 		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostAfterTime", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostAcknowledgementStore.GetForPost", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetPostIdAfterTime(channelId string, time int64) (string, *model.AppError) {
+func (s *TimerLayerPostAcknowledgementStore) GetForPosts(postIds []string) ([]*model.PostAcknowledgement, *model.AppError) {
+	childStore := s.PostAcknowledgementStore
+	if view, ok := s.Root.queryTimeouts["PostAcknowledgementStore.GetForPosts"]; ok {
+		childStore = view.PostAcknowledgement()
+	}
+	span := s.Root.span.StartChild("PostAcknowledgementStore.GetForPosts", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetPostIdAfterTime(channelId, time)
+	resultVar0, resultVar1 := childStore.GetForPosts(postIds)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4114,15 +6475,21 @@ func (s *TimerLayerPostStore) GetPostIdAfterTime(channelId string, time int64) (
 		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostIdAfterTime", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostAcknowledgementStore.GetForPosts", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetPostIdBeforeTime(channelId string, time int64) (string, *model.AppError) {
+func (s *TimerLayerPostAcknowledgementStore) Save(acknowledgement *model.PostAcknowledgement) (*model.PostAcknowledgement, *model.AppError) {
+	childStore := s.PostAcknowledgementStore
+	if view, ok := s.Root.queryTimeouts["PostAcknowledgementStore.Save"]; ok {
+		childStore = view.PostAcknowledgement()
+	}
+	span := s.Root.span.StartChild("PostAcknowledgementStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetPostIdBeforeTime(channelId, time)
+	resultVar0, resultVar1 := childStore.Save(acknowledgement)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4131,15 +6498,21 @@ func (s *TimerLayerPostStore) GetPostIdBeforeTime(channelId string, time int64)
 		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostIdBeforeTime", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostAcknowledgementStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetPosts(channelId string, offset int, limit int, allowFromCache bool) (*model.PostList, *model.AppError) {
+func (s *TimerLayerPostPriorityStore) Get(postId string) (*model.PostPriority, *model.AppError) {
+	childStore := s.PostPriorityStore
+	if view, ok := s.Root.queryTimeouts["PostPriorityStore.Get"]; ok {
+		childStore = view.PostPriority()
+	}
+	span := s.Root.span.StartChild("PostPriorityStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetPosts(channelId, offset, limit, allowFromCache)
+	resultVar0, resultVar1 := childStore.Get(postId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4148,15 +6521,21 @@ func (s *TimerLayerPostStore) GetPosts(channelId string, offset int, limit int,
 		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPosts", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostPriorityStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetPostsAfter(channelId string, postId string, numPosts int, offset int) (*model.PostList, *model.AppError) {
+func (s *TimerLayerPostPriorityStore) GetForPersistentNotifications(notifiedBefore int64, maxCount int) ([]*model.PostPriority, *model.AppError) {
+	childStore := s.PostPriorityStore
+	if view, ok := s.Root.queryTimeouts["PostPriorityStore.GetForPersistentNotifications"]; ok {
+		childStore = view.PostPriority()
+	}
+	span := s.Root.span.StartChild("PostPriorityStore.GetForPersistentNotifications", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetPostsAfter(channelId, postId, numPosts, offset)
+	resultVar0, resultVar1 := childStore.GetForPersistentNotifications(notifiedBefore, maxCount)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4165,15 +6544,21 @@ func (s *TimerLayerPostStore) GetPostsAfter(channelId string, postId string, num
 		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsAfter", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostPriorityStore.GetForPersistentNotifications", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetPostsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.PostForIndexing, *model.AppError) {
+func (s *TimerLayerPostPriorityStore) GetForPosts(postIds []string) ([]*model.PostPriority, *model.AppError) {
+	childStore := s.PostPriorityStore
+	if view, ok := s.Root.queryTimeouts["PostPriorityStore.GetForPosts"]; ok {
+		childStore = view.PostPriority()
+	}
+	span := s.Root.span.StartChild("PostPriorityStore.GetForPosts", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetPostsBatchForIndexing(startTime, endTime, limit)
+	resultVar0, resultVar1 := childStore.GetForPosts(postIds)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4182,32 +6567,44 @@ func (s *TimerLayerPostStore) GetPostsBatchForIndexing(startTime int64, endTime
 		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsBatchForIndexing", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostPriorityStore.GetForPosts", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetPostsBefore(channelId string, postId string, numPosts int, offset int) (*model.PostList, *model.AppError) {
+func (s *TimerLayerPostPriorityStore) MarkNotified(postId string, notifiedAt int64) *model.AppError {
+	childStore := s.PostPriorityStore
+	if view, ok := s.Root.queryTimeouts["PostPriorityStore.MarkNotified"]; ok {
+		childStore = view.PostPriority()
+	}
+	span := s.Root.span.StartChild("PostPriorityStore.MarkNotified", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetPostsBefore(channelId, postId, numPosts, offset)
+	resultVar0 := childStore.MarkNotified(postId, notifiedAt)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsBefore", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostPriorityStore.MarkNotified", success, float64(elapsed))
 	}
-	return resultVar0, resultVar1
+	s.Root.finishSpan(span, resultVar0)
+	return resultVar0
 }
 
-func (s *TimerLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, *model.AppError) {
+func (s *TimerLayerPostPriorityStore) Save(priority *model.PostPriority) (*model.PostPriority, *model.AppError) {
+	childStore := s.PostPriorityStore
+	if view, ok := s.Root.queryTimeouts["PostPriorityStore.Save"]; ok {
+		childStore = view.PostPriority()
+	}
+	span := s.Root.span.StartChild("PostPriorityStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetPostsByIds(postIds)
+	resultVar0, resultVar1 := childStore.Save(priority)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4216,66 +6613,90 @@ func (s *TimerLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, *m
 		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsByIds", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostPriorityStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) GetPostsCreatedAt(channelId string, time int64) ([]*model.Post, *model.AppError) {
+func (s *TimerLayerPostShardStore) DeleteChannelData(channelId string) *model.AppError {
+	childStore := s.PostShardStore
+	if view, ok := s.Root.queryTimeouts["PostShardStore.DeleteChannelData"]; ok {
+		childStore = view.PostShard()
+	}
+	span := s.Root.span.StartChild("PostShardStore.DeleteChannelData", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetPostsCreatedAt(channelId, time)
+	resultVar0 := childStore.DeleteChannelData(channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsCreatedAt", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostShardStore.DeleteChannelData", success, float64(elapsed))
 	}
-	return resultVar0, resultVar1
+	s.Root.finishSpan(span, resultVar0)
+	return resultVar0
 }
 
-func (s *TimerLayerPostStore) GetPostsSince(channelId string, time int64, allowFromCache bool) (*model.PostList, *model.AppError) {
+func (s *TimerLayerPostShardStore) DeleteChannelMirror(channelId string) *model.AppError {
+	childStore := s.PostShardStore
+	if view, ok := s.Root.queryTimeouts["PostShardStore.DeleteChannelMirror"]; ok {
+		childStore = view.PostShard()
+	}
+	span := s.Root.span.StartChild("PostShardStore.DeleteChannelMirror", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetPostsSince(channelId, time, allowFromCache)
+	resultVar0 := childStore.DeleteChannelMirror(channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsSince", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostShardStore.DeleteChannelMirror", success, float64(elapsed))
 	}
-	return resultVar0, resultVar1
+	s.Root.finishSpan(span, resultVar0)
+	return resultVar0
 }
 
-func (s *TimerLayerPostStore) GetRepliesForExport(parentId string) ([]*model.ReplyForExport, *model.AppError) {
+func (s *TimerLayerPostShardStore) DeleteChannelShard(channelId string) *model.AppError {
+	childStore := s.PostShardStore
+	if view, ok := s.Root.queryTimeouts["PostShardStore.DeleteChannelShard"]; ok {
+		childStore = view.PostShard()
+	}
+	span := s.Root.span.StartChild("PostShardStore.DeleteChannelShard", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetRepliesForExport(parentId)
+	resultVar0 := childStore.DeleteChannelShard(channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetRepliesForExport", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostShardStore.DeleteChannelShard", success, float64(elapsed))
 	}
-	return resultVar0, resultVar1
+	s.Root.finishSpan(span, resultVar0)
+	return resultVar0
 }
 
-func (s *TimerLayerPostStore) GetSingle(id string) (*model.Post, *model.AppError) {
+func (s *TimerLayerPostShardStore) GetChannelData(channelId string, afterPostId string, limit int) (*model.PostShardData, *model.AppError) {
+	childStore := s.PostShardStore
+	if view, ok := s.Root.queryTimeouts["PostShardStore.GetChannelData"]; ok {
+		childStore = view.PostShard()
+	}
+	span := s.Root.span.StartChild("PostShardStore.GetChannelData", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.GetSingle(id)
+	resultVar0, resultVar1 := childStore.GetChannelData(channelId, afterPostId, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4284,32 +6705,44 @@ func (s *TimerLayerPostStore) GetSingle(id string) (*model.Post, *model.AppError
 		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetSingle", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostShardStore.GetChannelData", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) InvalidateLastPostTimeCache(channelId string) {
+func (s *TimerLayerPostShardStore) GetChannelShard(channelId string) (*model.ChannelShard, *model.AppError) {
+	childStore := s.PostShardStore
+	if view, ok := s.Root.queryTimeouts["PostShardStore.GetChannelShard"]; ok {
+		childStore = view.PostShard()
+	}
+	span := s.Root.span.StartChild("PostShardStore.GetChannelShard", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.PostStore.InvalidateLastPostTimeCache(channelId)
+	resultVar0, resultVar1 := childStore.GetChannelShard(channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.InvalidateLastPostTimeCache", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostShardStore.GetChannelShard", success, float64(elapsed))
 	}
-	return
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) Overwrite(post *model.Post) (*model.Post, *model.AppError) {
+func (s *TimerLayerPostShardStore) GetChannelShardsAfter(afterChannelId string, limit int) ([]*model.ChannelShard, *model.AppError) {
+	childStore := s.PostShardStore
+	if view, ok := s.Root.queryTimeouts["PostShardStore.GetChannelShardsAfter"]; ok {
+		childStore = view.PostShard()
+	}
+	span := s.Root.span.StartChild("PostShardStore.GetChannelShardsAfter", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.Overwrite(post)
+	resultVar0, resultVar1 := childStore.GetChannelShardsAfter(afterChannelId, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4318,15 +6751,21 @@ func (s *TimerLayerPostStore) Overwrite(post *model.Post) (*model.Post, *model.A
 		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Overwrite", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostShardStore.GetChannelShardsAfter", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+func (s *TimerLayerPostShardStore) GetMovedChannelShards() ([]*model.ChannelShard, *model.AppError) {
+	childStore := s.PostShardStore
+	if view, ok := s.Root.queryTimeouts["PostShardStore.GetMovedChannelShards"]; ok {
+		childStore = view.PostShard()
+	}
+	span := s.Root.span.StartChild("PostShardStore.GetMovedChannelShards", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.PermanentDeleteBatch(endTime, limit)
+	resultVar0, resultVar1 := childStore.GetMovedChannelShards()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4335,15 +6774,21 @@ func (s *TimerLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (
 		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteBatch", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostShardStore.GetMovedChannelShards", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) PermanentDeleteByChannel(channelId string) *model.AppError {
+func (s *TimerLayerPostShardStore) SaveChannelData(data *model.PostShardData, movedAt int64) *model.AppError {
+	childStore := s.PostShardStore
+	if view, ok := s.Root.queryTimeouts["PostShardStore.SaveChannelData"]; ok {
+		childStore = view.PostShard()
+	}
+	span := s.Root.span.StartChild("PostShardStore.SaveChannelData", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.PostStore.PermanentDeleteByChannel(channelId)
+	resultVar0 := childStore.SaveChannelData(data, movedAt)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4352,15 +6797,21 @@ func (s *TimerLayerPostStore) PermanentDeleteByChannel(channelId string) *model.
 		if resultVar0 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteByChannel", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostShardStore.SaveChannelData", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
-func (s *TimerLayerPostStore) PermanentDeleteByUser(userId string) *model.AppError {
+func (s *TimerLayerPostShardStore) SaveChannelMirror(channel *model.Channel) *model.AppError {
+	childStore := s.PostShardStore
+	if view, ok := s.Root.queryTimeouts["PostShardStore.SaveChannelMirror"]; ok {
+		childStore = view.PostShard()
+	}
+	span := s.Root.span.StartChild("PostShardStore.SaveChannelMirror", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.PostStore.PermanentDeleteByUser(userId)
+	resultVar0 := childStore.SaveChannelMirror(channel)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4369,32 +6820,21 @@ func (s *TimerLayerPostStore) PermanentDeleteByUser(userId string) *model.AppErr
 		if resultVar0 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteByUser", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostShardStore.SaveChannelMirror", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
-func (s *TimerLayerPostStore) Save(post *model.Post) (*model.Post, *model.AppError) {
-	start := timemodule.Now()
-
-	resultVar0, resultVar1 := s.PostStore.Save(post)
-
-	t := timemodule.Now()
-	elapsed := t.Sub(start)
-	if s.Root.Metrics != nil {
-		success := "false"
-		if resultVar1 == nil {
-			success = "true"
-		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Save", success, float64(elapsed))
+func (s *TimerLayerPostShardStore) SaveChannelShard(channelShard *model.ChannelShard) (*model.ChannelShard, *model.AppError) {
+	childStore := s.PostShardStore
+	if view, ok := s.Root.queryTimeouts["PostShardStore.SaveChannelShard"]; ok {
+		childStore = view.PostShard()
 	}
-	return resultVar0, resultVar1
-}
-
-func (s *TimerLayerPostStore) Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, *model.AppError) {
+	span := s.Root.span.StartChild("PostShardStore.SaveChannelShard", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.Search(teamId, userId, params)
+	resultVar0, resultVar1 := childStore.SaveChannelShard(channelShard)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4403,32 +6843,44 @@ func (s *TimerLayerPostStore) Search(teamId string, userId string, params *model
 		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Search", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostShardStore.SaveChannelShard", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, *model.AppError) {
+func (s *TimerLayerPostShardStore) UpdateChannelLastPostAt(channelId string, lastPostAt int64, countMessage bool) *model.AppError {
+	childStore := s.PostShardStore
+	if view, ok := s.Root.queryTimeouts["PostShardStore.UpdateChannelLastPostAt"]; ok {
+		childStore = view.PostShard()
+	}
+	span := s.Root.span.StartChild("PostShardStore.UpdateChannelLastPostAt", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PostStore.Update(newPost, oldPost)
+	resultVar0 := childStore.UpdateChannelLastPostAt(channelId, lastPostAt, countMessage)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Update", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("PostShardStore.UpdateChannelLastPostAt", success, float64(elapsed))
 	}
-	return resultVar0, resultVar1
+	s.Root.finishSpan(span, resultVar0)
+	return resultVar0
 }
 
 func (s *TimerLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, *model.AppError) {
+	childStore := s.PreferenceStore
+	if view, ok := s.Root.queryTimeouts["PreferenceStore.CleanupFlagsBatch"]; ok {
+		childStore = view.Preference()
+	}
+	span := s.Root.span.StartChild("PreferenceStore.CleanupFlagsBatch", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PreferenceStore.CleanupFlagsBatch(limit)
+	resultVar0, resultVar1 := childStore.CleanupFlagsBatch(limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4439,13 +6891,19 @@ func (s *TimerLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, *mode
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.CleanupFlagsBatch", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerPreferenceStore) Delete(userId string, category string, name string) *model.AppError {
+	childStore := s.PreferenceStore
+	if view, ok := s.Root.queryTimeouts["PreferenceStore.Delete"]; ok {
+		childStore = view.Preference()
+	}
+	span := s.Root.span.StartChild("PreferenceStore.Delete", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.PreferenceStore.Delete(userId, category, name)
+	resultVar0 := childStore.Delete(userId, category, name)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4456,13 +6914,19 @@ func (s *TimerLayerPreferenceStore) Delete(userId string, category string, name
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Delete", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerPreferenceStore) DeleteCategory(userId string, category string) *model.AppError {
+	childStore := s.PreferenceStore
+	if view, ok := s.Root.queryTimeouts["PreferenceStore.DeleteCategory"]; ok {
+		childStore = view.Preference()
+	}
+	span := s.Root.span.StartChild("PreferenceStore.DeleteCategory", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.PreferenceStore.DeleteCategory(userId, category)
+	resultVar0 := childStore.DeleteCategory(userId, category)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4473,13 +6937,19 @@ func (s *TimerLayerPreferenceStore) DeleteCategory(userId string, category strin
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteCategory", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerPreferenceStore) DeleteCategoryAndName(category string, name string) *model.AppError {
+	childStore := s.PreferenceStore
+	if view, ok := s.Root.queryTimeouts["PreferenceStore.DeleteCategoryAndName"]; ok {
+		childStore = view.Preference()
+	}
+	span := s.Root.span.StartChild("PreferenceStore.DeleteCategoryAndName", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.PreferenceStore.DeleteCategoryAndName(category, name)
+	resultVar0 := childStore.DeleteCategoryAndName(category, name)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4490,13 +6960,19 @@ func (s *TimerLayerPreferenceStore) DeleteCategoryAndName(category string, name
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteCategoryAndName", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerPreferenceStore) Get(userId string, category string, name string) (*model.Preference, *model.AppError) {
+	childStore := s.PreferenceStore
+	if view, ok := s.Root.queryTimeouts["PreferenceStore.Get"]; ok {
+		childStore = view.Preference()
+	}
+	span := s.Root.span.StartChild("PreferenceStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PreferenceStore.Get(userId, category, name)
+	resultVar0, resultVar1 := childStore.Get(userId, category, name)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4507,13 +6983,19 @@ func (s *TimerLayerPreferenceStore) Get(userId string, category string, name str
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerPreferenceStore) GetAll(userId string) (model.Preferences, *model.AppError) {
+	childStore := s.PreferenceStore
+	if view, ok := s.Root.queryTimeouts["PreferenceStore.GetAll"]; ok {
+		childStore = view.Preference()
+	}
+	span := s.Root.span.StartChild("PreferenceStore.GetAll", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PreferenceStore.GetAll(userId)
+	resultVar0, resultVar1 := childStore.GetAll(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4524,13 +7006,19 @@ func (s *TimerLayerPreferenceStore) GetAll(userId string) (model.Preferences, *m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetAll", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerPreferenceStore) GetCategory(userId string, category string) (model.Preferences, *model.AppError) {
+	childStore := s.PreferenceStore
+	if view, ok := s.Root.queryTimeouts["PreferenceStore.GetCategory"]; ok {
+		childStore = view.Preference()
+	}
+	span := s.Root.span.StartChild("PreferenceStore.GetCategory", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PreferenceStore.GetCategory(userId, category)
+	resultVar0, resultVar1 := childStore.GetCategory(userId, category)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4541,13 +7029,19 @@ func (s *TimerLayerPreferenceStore) GetCategory(userId string, category string)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetCategory", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerPreferenceStore) PermanentDeleteByUser(userId string) *model.AppError {
+	childStore := s.PreferenceStore
+	if view, ok := s.Root.queryTimeouts["PreferenceStore.PermanentDeleteByUser"]; ok {
+		childStore = view.Preference()
+	}
+	span := s.Root.span.StartChild("PreferenceStore.PermanentDeleteByUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.PreferenceStore.PermanentDeleteByUser(userId)
+	resultVar0 := childStore.PermanentDeleteByUser(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4558,13 +7052,19 @@ func (s *TimerLayerPreferenceStore) PermanentDeleteByUser(userId string) *model.
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.PermanentDeleteByUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerPreferenceStore) Save(preferences *model.Preferences) *model.AppError {
+	childStore := s.PreferenceStore
+	if view, ok := s.Root.queryTimeouts["PreferenceStore.Save"]; ok {
+		childStore = view.Preference()
+	}
+	span := s.Root.span.StartChild("PreferenceStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.PreferenceStore.Save(preferences)
+	resultVar0 := childStore.Save(preferences)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4575,13 +7075,19 @@ func (s *TimerLayerPreferenceStore) Save(preferences *model.Preferences) *model.
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, *model.AppError) {
+	childStore := s.ReactionStore
+	if view, ok := s.Root.queryTimeouts["ReactionStore.BulkGetForPosts"]; ok {
+		childStore = view.Reaction()
+	}
+	span := s.Root.span.StartChild("ReactionStore.BulkGetForPosts", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ReactionStore.BulkGetForPosts(postIds)
+	resultVar0, resultVar1 := childStore.BulkGetForPosts(postIds)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4592,13 +7098,19 @@ func (s *TimerLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Re
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.BulkGetForPosts", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, *model.AppError) {
+	childStore := s.ReactionStore
+	if view, ok := s.Root.queryTimeouts["ReactionStore.Delete"]; ok {
+		childStore = view.Reaction()
+	}
+	span := s.Root.span.StartChild("ReactionStore.Delete", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ReactionStore.Delete(reaction)
+	resultVar0, resultVar1 := childStore.Delete(reaction)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4609,13 +7121,19 @@ func (s *TimerLayerReactionStore) Delete(reaction *model.Reaction) (*model.React
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.Delete", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerReactionStore) DeleteAllWithEmojiName(emojiName string) *model.AppError {
+	childStore := s.ReactionStore
+	if view, ok := s.Root.queryTimeouts["ReactionStore.DeleteAllWithEmojiName"]; ok {
+		childStore = view.Reaction()
+	}
+	span := s.Root.span.StartChild("ReactionStore.DeleteAllWithEmojiName", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.ReactionStore.DeleteAllWithEmojiName(emojiName)
+	resultVar0 := childStore.DeleteAllWithEmojiName(emojiName)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4626,13 +7144,19 @@ func (s *TimerLayerReactionStore) DeleteAllWithEmojiName(emojiName string) *mode
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.DeleteAllWithEmojiName", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerReactionStore) GetForPost(postId string, allowFromCache bool) ([]*model.Reaction, *model.AppError) {
+	childStore := s.ReactionStore
+	if view, ok := s.Root.queryTimeouts["ReactionStore.GetForPost"]; ok {
+		childStore = view.Reaction()
+	}
+	span := s.Root.span.StartChild("ReactionStore.GetForPost", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ReactionStore.GetForPost(postId, allowFromCache)
+	resultVar0, resultVar1 := childStore.GetForPost(postId, allowFromCache)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4643,13 +7167,19 @@ func (s *TimerLayerReactionStore) GetForPost(postId string, allowFromCache bool)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetForPost", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	childStore := s.ReactionStore
+	if view, ok := s.Root.queryTimeouts["ReactionStore.PermanentDeleteBatch"]; ok {
+		childStore = view.Reaction()
+	}
+	span := s.Root.span.StartChild("ReactionStore.PermanentDeleteBatch", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ReactionStore.PermanentDeleteBatch(endTime, limit)
+	resultVar0, resultVar1 := childStore.PermanentDeleteBatch(endTime, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4660,13 +7190,19 @@ func (s *TimerLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int6
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.PermanentDeleteBatch", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, *model.AppError) {
+	childStore := s.ReactionStore
+	if view, ok := s.Root.queryTimeouts["ReactionStore.Save"]; ok {
+		childStore = view.Reaction()
+	}
+	span := s.Root.span.StartChild("ReactionStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.ReactionStore.Save(reaction)
+	resultVar0, resultVar1 := childStore.Save(reaction)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4677,13 +7213,19 @@ func (s *TimerLayerReactionStore) Save(reaction *model.Reaction) (*model.Reactio
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerRoleStore) Delete(roldId string) (*model.Role, *model.AppError) {
+	childStore := s.RoleStore
+	if view, ok := s.Root.queryTimeouts["RoleStore.Delete"]; ok {
+		childStore = view.Role()
+	}
+	span := s.Root.span.StartChild("RoleStore.Delete", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.RoleStore.Delete(roldId)
+	resultVar0, resultVar1 := childStore.Delete(roldId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4694,13 +7236,19 @@ func (s *TimerLayerRoleStore) Delete(roldId string) (*model.Role, *model.AppErro
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.Delete", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerRoleStore) Get(roleId string) (*model.Role, *model.AppError) {
+	childStore := s.RoleStore
+	if view, ok := s.Root.queryTimeouts["RoleStore.Get"]; ok {
+		childStore = view.Role()
+	}
+	span := s.Root.span.StartChild("RoleStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.RoleStore.Get(roleId)
+	resultVar0, resultVar1 := childStore.Get(roleId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4711,13 +7259,19 @@ func (s *TimerLayerRoleStore) Get(roleId string) (*model.Role, *model.AppError)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerRoleStore) GetAll() ([]*model.Role, *model.AppError) {
+	childStore := s.RoleStore
+	if view, ok := s.Root.queryTimeouts["RoleStore.GetAll"]; ok {
+		childStore = view.Role()
+	}
+	span := s.Root.span.StartChild("RoleStore.GetAll", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.RoleStore.GetAll()
+	resultVar0, resultVar1 := childStore.GetAll()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4728,13 +7282,19 @@ func (s *TimerLayerRoleStore) GetAll() ([]*model.Role, *model.AppError) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.GetAll", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerRoleStore) GetByName(name string) (*model.Role, *model.AppError) {
+	childStore := s.RoleStore
+	if view, ok := s.Root.queryTimeouts["RoleStore.GetByName"]; ok {
+		childStore = view.Role()
+	}
+	span := s.Root.span.StartChild("RoleStore.GetByName", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.RoleStore.GetByName(name)
+	resultVar0, resultVar1 := childStore.GetByName(name)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4745,13 +7305,19 @@ func (s *TimerLayerRoleStore) GetByName(name string) (*model.Role, *model.AppErr
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.GetByName", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerRoleStore) GetByNames(names []string) ([]*model.Role, *model.AppError) {
+	childStore := s.RoleStore
+	if view, ok := s.Root.queryTimeouts["RoleStore.GetByNames"]; ok {
+		childStore = view.Role()
+	}
+	span := s.Root.span.StartChild("RoleStore.GetByNames", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.RoleStore.GetByNames(names)
+	resultVar0, resultVar1 := childStore.GetByNames(names)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4762,13 +7328,19 @@ func (s *TimerLayerRoleStore) GetByNames(names []string) ([]*model.Role, *model.
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.GetByNames", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerRoleStore) PermanentDeleteAll() *model.AppError {
+	childStore := s.RoleStore
+	if view, ok := s.Root.queryTimeouts["RoleStore.PermanentDeleteAll"]; ok {
+		childStore = view.Role()
+	}
+	span := s.Root.span.StartChild("RoleStore.PermanentDeleteAll", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.RoleStore.PermanentDeleteAll()
+	resultVar0 := childStore.PermanentDeleteAll()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4779,13 +7351,19 @@ func (s *TimerLayerRoleStore) PermanentDeleteAll() *model.AppError {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.PermanentDeleteAll", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerRoleStore) Save(role *model.Role) (*model.Role, *model.AppError) {
+	childStore := s.RoleStore
+	if view, ok := s.Root.queryTimeouts["RoleStore.Save"]; ok {
+		childStore = view.Role()
+	}
+	span := s.Root.span.StartChild("RoleStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.RoleStore.Save(role)
+	resultVar0, resultVar1 := childStore.Save(role)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4796,13 +7374,19 @@ func (s *TimerLayerRoleStore) Save(role *model.Role) (*model.Role, *model.AppErr
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerSchemeStore) Delete(schemeId string) (*model.Scheme, *model.AppError) {
+	childStore := s.SchemeStore
+	if view, ok := s.Root.queryTimeouts["SchemeStore.Delete"]; ok {
+		childStore = view.Scheme()
+	}
+	span := s.Root.span.StartChild("SchemeStore.Delete", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SchemeStore.Delete(schemeId)
+	resultVar0, resultVar1 := childStore.Delete(schemeId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4813,13 +7397,19 @@ func (s *TimerLayerSchemeStore) Delete(schemeId string) (*model.Scheme, *model.A
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.Delete", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerSchemeStore) Get(schemeId string) (*model.Scheme, *model.AppError) {
+	childStore := s.SchemeStore
+	if view, ok := s.Root.queryTimeouts["SchemeStore.Get"]; ok {
+		childStore = view.Scheme()
+	}
+	span := s.Root.span.StartChild("SchemeStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SchemeStore.Get(schemeId)
+	resultVar0, resultVar1 := childStore.Get(schemeId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4830,13 +7420,19 @@ func (s *TimerLayerSchemeStore) Get(schemeId string) (*model.Scheme, *model.AppE
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerSchemeStore) GetAllPage(scope string, offset int, limit int) ([]*model.Scheme, *model.AppError) {
+	childStore := s.SchemeStore
+	if view, ok := s.Root.queryTimeouts["SchemeStore.GetAllPage"]; ok {
+		childStore = view.Scheme()
+	}
+	span := s.Root.span.StartChild("SchemeStore.GetAllPage", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SchemeStore.GetAllPage(scope, offset, limit)
+	resultVar0, resultVar1 := childStore.GetAllPage(scope, offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4847,13 +7443,19 @@ func (s *TimerLayerSchemeStore) GetAllPage(scope string, offset int, limit int)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.GetAllPage", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerSchemeStore) GetByName(schemeName string) (*model.Scheme, *model.AppError) {
+	childStore := s.SchemeStore
+	if view, ok := s.Root.queryTimeouts["SchemeStore.GetByName"]; ok {
+		childStore = view.Scheme()
+	}
+	span := s.Root.span.StartChild("SchemeStore.GetByName", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SchemeStore.GetByName(schemeName)
+	resultVar0, resultVar1 := childStore.GetByName(schemeName)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4864,13 +7466,19 @@ func (s *TimerLayerSchemeStore) GetByName(schemeName string) (*model.Scheme, *mo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.GetByName", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerSchemeStore) PermanentDeleteAll() *model.AppError {
+	childStore := s.SchemeStore
+	if view, ok := s.Root.queryTimeouts["SchemeStore.PermanentDeleteAll"]; ok {
+		childStore = view.Scheme()
+	}
+	span := s.Root.span.StartChild("SchemeStore.PermanentDeleteAll", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.SchemeStore.PermanentDeleteAll()
+	resultVar0 := childStore.PermanentDeleteAll()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4881,13 +7489,19 @@ func (s *TimerLayerSchemeStore) PermanentDeleteAll() *model.AppError {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.PermanentDeleteAll", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, *model.AppError) {
+	childStore := s.SchemeStore
+	if view, ok := s.Root.queryTimeouts["SchemeStore.Save"]; ok {
+		childStore = view.Scheme()
+	}
+	span := s.Root.span.StartChild("SchemeStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SchemeStore.Save(scheme)
+	resultVar0, resultVar1 := childStore.Save(scheme)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4898,13 +7512,19 @@ func (s *TimerLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, *mode
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerSessionStore) AnalyticsSessionCount() (int64, *model.AppError) {
+	childStore := s.SessionStore
+	if view, ok := s.Root.queryTimeouts["SessionStore.AnalyticsSessionCount"]; ok {
+		childStore = view.Session()
+	}
+	span := s.Root.span.StartChild("SessionStore.AnalyticsSessionCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SessionStore.AnalyticsSessionCount()
+	resultVar0, resultVar1 := childStore.AnalyticsSessionCount()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4915,13 +7535,19 @@ func (s *TimerLayerSessionStore) AnalyticsSessionCount() (int64, *model.AppError
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.AnalyticsSessionCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerSessionStore) Cleanup(expiryTime int64, batchSize int64) {
+	childStore := s.SessionStore
+	if view, ok := s.Root.queryTimeouts["SessionStore.Cleanup"]; ok {
+		childStore = view.Session()
+	}
+	span := s.Root.span.StartChild("SessionStore.Cleanup", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.SessionStore.Cleanup(expiryTime, batchSize)
+	childStore.Cleanup(expiryTime, batchSize)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4932,13 +7558,19 @@ func (s *TimerLayerSessionStore) Cleanup(expiryTime int64, batchSize int64) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Cleanup", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return
 }
 
 func (s *TimerLayerSessionStore) Get(sessionIdOrToken string) (*model.Session, *model.AppError) {
+	childStore := s.SessionStore
+	if view, ok := s.Root.queryTimeouts["SessionStore.Get"]; ok {
+		childStore = view.Session()
+	}
+	span := s.Root.span.StartChild("SessionStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SessionStore.Get(sessionIdOrToken)
+	resultVar0, resultVar1 := childStore.Get(sessionIdOrToken)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4949,13 +7581,19 @@ func (s *TimerLayerSessionStore) Get(sessionIdOrToken string) (*model.Session, *
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerSessionStore) GetSessions(userId string) ([]*model.Session, *model.AppError) {
+	childStore := s.SessionStore
+	if view, ok := s.Root.queryTimeouts["SessionStore.GetSessions"]; ok {
+		childStore = view.Session()
+	}
+	span := s.Root.span.StartChild("SessionStore.GetSessions", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SessionStore.GetSessions(userId)
+	resultVar0, resultVar1 := childStore.GetSessions(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4966,13 +7604,19 @@ func (s *TimerLayerSessionStore) GetSessions(userId string) ([]*model.Session, *
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.GetSessions", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerSessionStore) GetSessionsWithActiveDeviceIds(userId string) ([]*model.Session, *model.AppError) {
+	childStore := s.SessionStore
+	if view, ok := s.Root.queryTimeouts["SessionStore.GetSessionsWithActiveDeviceIds"]; ok {
+		childStore = view.Session()
+	}
+	span := s.Root.span.StartChild("SessionStore.GetSessionsWithActiveDeviceIds", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SessionStore.GetSessionsWithActiveDeviceIds(userId)
+	resultVar0, resultVar1 := childStore.GetSessionsWithActiveDeviceIds(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -4983,13 +7627,19 @@ func (s *TimerLayerSessionStore) GetSessionsWithActiveDeviceIds(userId string) (
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.GetSessionsWithActiveDeviceIds", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerSessionStore) PermanentDeleteSessionsByUser(teamId string) *model.AppError {
+	childStore := s.SessionStore
+	if view, ok := s.Root.queryTimeouts["SessionStore.PermanentDeleteSessionsByUser"]; ok {
+		childStore = view.Session()
+	}
+	span := s.Root.span.StartChild("SessionStore.PermanentDeleteSessionsByUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.SessionStore.PermanentDeleteSessionsByUser(teamId)
+	resultVar0 := childStore.PermanentDeleteSessionsByUser(teamId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5000,13 +7650,19 @@ func (s *TimerLayerSessionStore) PermanentDeleteSessionsByUser(teamId string) *m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.PermanentDeleteSessionsByUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerSessionStore) Remove(sessionIdOrToken string) *model.AppError {
+	childStore := s.SessionStore
+	if view, ok := s.Root.queryTimeouts["SessionStore.Remove"]; ok {
+		childStore = view.Session()
+	}
+	span := s.Root.span.StartChild("SessionStore.Remove", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.SessionStore.Remove(sessionIdOrToken)
+	resultVar0 := childStore.Remove(sessionIdOrToken)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5017,13 +7673,19 @@ func (s *TimerLayerSessionStore) Remove(sessionIdOrToken string) *model.AppError
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Remove", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerSessionStore) RemoveAllSessions() *model.AppError {
+	childStore := s.SessionStore
+	if view, ok := s.Root.queryTimeouts["SessionStore.RemoveAllSessions"]; ok {
+		childStore = view.Session()
+	}
+	span := s.Root.span.StartChild("SessionStore.RemoveAllSessions", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.SessionStore.RemoveAllSessions()
+	resultVar0 := childStore.RemoveAllSessions()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5034,13 +7696,19 @@ func (s *TimerLayerSessionStore) RemoveAllSessions() *model.AppError {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.RemoveAllSessions", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerSessionStore) Save(session *model.Session) (*model.Session, *model.AppError) {
+	childStore := s.SessionStore
+	if view, ok := s.Root.queryTimeouts["SessionStore.Save"]; ok {
+		childStore = view.Session()
+	}
+	span := s.Root.span.StartChild("SessionStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SessionStore.Save(session)
+	resultVar0, resultVar1 := childStore.Save(session)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5051,13 +7719,19 @@ func (s *TimerLayerSessionStore) Save(session *model.Session) (*model.Session, *
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerSessionStore) UpdateDeviceId(id string, deviceId string, expiresAt int64) (string, *model.AppError) {
+	childStore := s.SessionStore
+	if view, ok := s.Root.queryTimeouts["SessionStore.UpdateDeviceId"]; ok {
+		childStore = view.Session()
+	}
+	span := s.Root.span.StartChild("SessionStore.UpdateDeviceId", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SessionStore.UpdateDeviceId(id, deviceId, expiresAt)
+	resultVar0, resultVar1 := childStore.UpdateDeviceId(id, deviceId, expiresAt)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5068,13 +7742,19 @@ func (s *TimerLayerSessionStore) UpdateDeviceId(id string, deviceId string, expi
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateDeviceId", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerSessionStore) UpdateLastActivityAt(sessionId string, time int64) *model.AppError {
+	childStore := s.SessionStore
+	if view, ok := s.Root.queryTimeouts["SessionStore.UpdateLastActivityAt"]; ok {
+		childStore = view.Session()
+	}
+	span := s.Root.span.StartChild("SessionStore.UpdateLastActivityAt", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.SessionStore.UpdateLastActivityAt(sessionId, time)
+	resultVar0 := childStore.UpdateLastActivityAt(sessionId, time)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5085,13 +7765,19 @@ func (s *TimerLayerSessionStore) UpdateLastActivityAt(sessionId string, time int
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateLastActivityAt", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerSessionStore) UpdateProps(session *model.Session) *model.AppError {
+	childStore := s.SessionStore
+	if view, ok := s.Root.queryTimeouts["SessionStore.UpdateProps"]; ok {
+		childStore = view.Session()
+	}
+	span := s.Root.span.StartChild("SessionStore.UpdateProps", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.SessionStore.UpdateProps(session)
+	resultVar0 := childStore.UpdateProps(session)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5102,13 +7788,19 @@ func (s *TimerLayerSessionStore) UpdateProps(session *model.Session) *model.AppE
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateProps", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerSessionStore) UpdateRoles(userId string, roles string) (string, *model.AppError) {
+	childStore := s.SessionStore
+	if view, ok := s.Root.queryTimeouts["SessionStore.UpdateRoles"]; ok {
+		childStore = view.Session()
+	}
+	span := s.Root.span.StartChild("SessionStore.UpdateRoles", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SessionStore.UpdateRoles(userId, roles)
+	resultVar0, resultVar1 := childStore.UpdateRoles(userId, roles)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5119,13 +7811,19 @@ func (s *TimerLayerSessionStore) UpdateRoles(userId string, roles string) (strin
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateRoles", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerStatusStore) Get(userId string) (*model.Status, *model.AppError) {
+	childStore := s.StatusStore
+	if view, ok := s.Root.queryTimeouts["StatusStore.Get"]; ok {
+		childStore = view.Status()
+	}
+	span := s.Root.span.StartChild("StatusStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.StatusStore.Get(userId)
+	resultVar0, resultVar1 := childStore.Get(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5136,13 +7834,19 @@ func (s *TimerLayerStatusStore) Get(userId string) (*model.Status, *model.AppErr
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerStatusStore) GetByIds(userIds []string) ([]*model.Status, *model.AppError) {
+	childStore := s.StatusStore
+	if view, ok := s.Root.queryTimeouts["StatusStore.GetByIds"]; ok {
+		childStore = view.Status()
+	}
+	span := s.Root.span.StartChild("StatusStore.GetByIds", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.StatusStore.GetByIds(userIds)
+	resultVar0, resultVar1 := childStore.GetByIds(userIds)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5153,13 +7857,19 @@ func (s *TimerLayerStatusStore) GetByIds(userIds []string) ([]*model.Status, *mo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.GetByIds", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerStatusStore) GetTotalActiveUsersCount() (int64, *model.AppError) {
+	childStore := s.StatusStore
+	if view, ok := s.Root.queryTimeouts["StatusStore.GetTotalActiveUsersCount"]; ok {
+		childStore = view.Status()
+	}
+	span := s.Root.span.StartChild("StatusStore.GetTotalActiveUsersCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.StatusStore.GetTotalActiveUsersCount()
+	resultVar0, resultVar1 := childStore.GetTotalActiveUsersCount()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5170,13 +7880,19 @@ func (s *TimerLayerStatusStore) GetTotalActiveUsersCount() (int64, *model.AppErr
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.GetTotalActiveUsersCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerStatusStore) ResetAll() *model.AppError {
+	childStore := s.StatusStore
+	if view, ok := s.Root.queryTimeouts["StatusStore.ResetAll"]; ok {
+		childStore = view.Status()
+	}
+	span := s.Root.span.StartChild("StatusStore.ResetAll", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.StatusStore.ResetAll()
+	resultVar0 := childStore.ResetAll()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5187,13 +7903,19 @@ func (s *TimerLayerStatusStore) ResetAll() *model.AppError {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.ResetAll", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerStatusStore) SaveOrUpdate(status *model.Status) *model.AppError {
+	childStore := s.StatusStore
+	if view, ok := s.Root.queryTimeouts["StatusStore.SaveOrUpdate"]; ok {
+		childStore = view.Status()
+	}
+	span := s.Root.span.StartChild("StatusStore.SaveOrUpdate", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.StatusStore.SaveOrUpdate(status)
+	resultVar0 := childStore.SaveOrUpdate(status)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5204,13 +7926,42 @@ func (s *TimerLayerStatusStore) SaveOrUpdate(status *model.Status) *model.AppErr
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.SaveOrUpdate", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
+func (s *TimerLayerStatusStore) UpdateExpiredDNDStatuses(now int64) ([]*model.Status, *model.AppError) {
+	childStore := s.StatusStore
+	if view, ok := s.Root.queryTimeouts["StatusStore.UpdateExpiredDNDStatuses"]; ok {
+		childStore = view.Status()
+	}
+	span := s.Root.span.StartChild("StatusStore.UpdateExpiredDNDStatuses", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.UpdateExpiredDNDStatuses(now)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.UpdateExpiredDNDStatuses", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerStatusStore) UpdateLastActivityAt(userId string, lastActivityAt int64) *model.AppError {
+	childStore := s.StatusStore
+	if view, ok := s.Root.queryTimeouts["StatusStore.UpdateLastActivityAt"]; ok {
+		childStore = view.Status()
+	}
+	span := s.Root.span.StartChild("StatusStore.UpdateLastActivityAt", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.StatusStore.UpdateLastActivityAt(userId, lastActivityAt)
+	resultVar0 := childStore.UpdateLastActivityAt(userId, lastActivityAt)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5221,13 +7972,19 @@ func (s *TimerLayerStatusStore) UpdateLastActivityAt(userId string, lastActivity
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.UpdateLastActivityAt", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerSystemStore) Get() (model.StringMap, *model.AppError) {
+	childStore := s.SystemStore
+	if view, ok := s.Root.queryTimeouts["SystemStore.Get"]; ok {
+		childStore = view.System()
+	}
+	span := s.Root.span.StartChild("SystemStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SystemStore.Get()
+	resultVar0, resultVar1 := childStore.Get()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5238,13 +7995,19 @@ func (s *TimerLayerSystemStore) Get() (model.StringMap, *model.AppError) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerSystemStore) GetByName(name string) (*model.System, *model.AppError) {
+	childStore := s.SystemStore
+	if view, ok := s.Root.queryTimeouts["SystemStore.GetByName"]; ok {
+		childStore = view.System()
+	}
+	span := s.Root.span.StartChild("SystemStore.GetByName", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SystemStore.GetByName(name)
+	resultVar0, resultVar1 := childStore.GetByName(name)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5255,13 +8018,19 @@ func (s *TimerLayerSystemStore) GetByName(name string) (*model.System, *model.Ap
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.GetByName", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerSystemStore) PermanentDeleteByName(name string) (*model.System, *model.AppError) {
+	childStore := s.SystemStore
+	if view, ok := s.Root.queryTimeouts["SystemStore.PermanentDeleteByName"]; ok {
+		childStore = view.System()
+	}
+	span := s.Root.span.StartChild("SystemStore.PermanentDeleteByName", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.SystemStore.PermanentDeleteByName(name)
+	resultVar0, resultVar1 := childStore.PermanentDeleteByName(name)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5272,13 +8041,19 @@ func (s *TimerLayerSystemStore) PermanentDeleteByName(name string) (*model.Syste
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.PermanentDeleteByName", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerSystemStore) Save(system *model.System) *model.AppError {
+	childStore := s.SystemStore
+	if view, ok := s.Root.queryTimeouts["SystemStore.Save"]; ok {
+		childStore = view.System()
+	}
+	span := s.Root.span.StartChild("SystemStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.SystemStore.Save(system)
+	resultVar0 := childStore.Save(system)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5289,13 +8064,19 @@ func (s *TimerLayerSystemStore) Save(system *model.System) *model.AppError {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerSystemStore) SaveOrUpdate(system *model.System) *model.AppError {
+	childStore := s.SystemStore
+	if view, ok := s.Root.queryTimeouts["SystemStore.SaveOrUpdate"]; ok {
+		childStore = view.System()
+	}
+	span := s.Root.span.StartChild("SystemStore.SaveOrUpdate", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.SystemStore.SaveOrUpdate(system)
+	resultVar0 := childStore.SaveOrUpdate(system)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5306,30 +8087,88 @@ func (s *TimerLayerSystemStore) SaveOrUpdate(system *model.System) *model.AppErr
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.SaveOrUpdate", success, float64(elapsed))
 	}
-	return resultVar0
+	s.Root.finishSpan(span, resultVar0)
+	return resultVar0
+}
+
+func (s *TimerLayerSystemStore) Update(system *model.System) *model.AppError {
+	childStore := s.SystemStore
+	if view, ok := s.Root.queryTimeouts["SystemStore.Update"]; ok {
+		childStore = view.System()
+	}
+	span := s.Root.span.StartChild("SystemStore.Update", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0 := childStore.Update(system)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.Update", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar0)
+	return resultVar0
+}
+
+func (s *TimerLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeId string) (int64, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.AnalyticsGetTeamCountForScheme"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.AnalyticsGetTeamCountForScheme", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.AnalyticsGetTeamCountForScheme(schemeId)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.AnalyticsGetTeamCountForScheme", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerSystemStore) Update(system *model.System) *model.AppError {
+func (s *TimerLayerTeamStore) AnalyticsPrivateTeamCount() (int64, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.AnalyticsPrivateTeamCount"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.AnalyticsPrivateTeamCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.SystemStore.Update(system)
+	resultVar0, resultVar1 := childStore.AnalyticsPrivateTeamCount()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar0 == nil {
+		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.Update", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.AnalyticsPrivateTeamCount", success, float64(elapsed))
 	}
-	return resultVar0
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeId string) (int64, *model.AppError) {
+func (s *TimerLayerTeamStore) AnalyticsPublicTeamCount() (int64, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.AnalyticsPublicTeamCount"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.AnalyticsPublicTeamCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.AnalyticsGetTeamCountForScheme(schemeId)
+	resultVar0, resultVar1 := childStore.AnalyticsPublicTeamCount()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5338,15 +8177,21 @@ func (s *TimerLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeId string) (i
 		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.AnalyticsGetTeamCountForScheme", success, float64(elapsed))
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.AnalyticsPublicTeamCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) AnalyticsTeamCount() (int64, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.AnalyticsTeamCount"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.AnalyticsTeamCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.AnalyticsTeamCount()
+	resultVar0, resultVar1 := childStore.AnalyticsTeamCount()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5357,13 +8202,19 @@ func (s *TimerLayerTeamStore) AnalyticsTeamCount() (int64, *model.AppError) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.AnalyticsTeamCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) ClearAllCustomRoleAssignments() *model.AppError {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.ClearAllCustomRoleAssignments"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.ClearAllCustomRoleAssignments", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.TeamStore.ClearAllCustomRoleAssignments()
+	resultVar0 := childStore.ClearAllCustomRoleAssignments()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5374,13 +8225,19 @@ func (s *TimerLayerTeamStore) ClearAllCustomRoleAssignments() *model.AppError {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.ClearAllCustomRoleAssignments", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerTeamStore) ClearCaches() {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.ClearCaches"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.ClearCaches", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.TeamStore.ClearCaches()
+	childStore.ClearCaches()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5391,13 +8248,19 @@ func (s *TimerLayerTeamStore) ClearCaches() {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.ClearCaches", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return
 }
 
 func (s *TimerLayerTeamStore) Get(id string) (*model.Team, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.Get"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.Get(id)
+	resultVar0, resultVar1 := childStore.Get(id)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5408,13 +8271,19 @@ func (s *TimerLayerTeamStore) Get(id string) (*model.Team, *model.AppError) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) GetActiveMemberCount(teamId string, restrictions *model.ViewUsersRestrictions) (int64, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetActiveMemberCount"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetActiveMemberCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetActiveMemberCount(teamId, restrictions)
+	resultVar0, resultVar1 := childStore.GetActiveMemberCount(teamId, restrictions)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5425,13 +8294,19 @@ func (s *TimerLayerTeamStore) GetActiveMemberCount(teamId string, restrictions *
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetActiveMemberCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) GetAll() ([]*model.Team, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetAll"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetAll", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetAll()
+	resultVar0, resultVar1 := childStore.GetAll()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5442,13 +8317,19 @@ func (s *TimerLayerTeamStore) GetAll() ([]*model.Team, *model.AppError) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAll", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) GetAllForExportAfter(limit int, afterId string) ([]*model.TeamForExport, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetAllForExportAfter"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetAllForExportAfter", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetAllForExportAfter(limit, afterId)
+	resultVar0, resultVar1 := childStore.GetAllForExportAfter(limit, afterId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5459,13 +8340,19 @@ func (s *TimerLayerTeamStore) GetAllForExportAfter(limit int, afterId string) ([
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllForExportAfter", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) GetAllPage(offset int, limit int) ([]*model.Team, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetAllPage"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetAllPage", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetAllPage(offset, limit)
+	resultVar0, resultVar1 := childStore.GetAllPage(offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5476,13 +8363,19 @@ func (s *TimerLayerTeamStore) GetAllPage(offset int, limit int) ([]*model.Team,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllPage", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) GetAllPrivateTeamListing() ([]*model.Team, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetAllPrivateTeamListing"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetAllPrivateTeamListing", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetAllPrivateTeamListing()
+	resultVar0, resultVar1 := childStore.GetAllPrivateTeamListing()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5493,13 +8386,19 @@ func (s *TimerLayerTeamStore) GetAllPrivateTeamListing() ([]*model.Team, *model.
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllPrivateTeamListing", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) GetAllPrivateTeamPageListing(offset int, limit int) ([]*model.Team, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetAllPrivateTeamPageListing"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetAllPrivateTeamPageListing", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetAllPrivateTeamPageListing(offset, limit)
+	resultVar0, resultVar1 := childStore.GetAllPrivateTeamPageListing(offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5510,13 +8409,42 @@ func (s *TimerLayerTeamStore) GetAllPrivateTeamPageListing(offset int, limit int
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllPrivateTeamPageListing", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerTeamStore) GetAllPublicTeamPageListing(offset int, limit int) ([]*model.Team, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetAllPublicTeamPageListing"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetAllPublicTeamPageListing", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetAllPublicTeamPageListing(offset, limit)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllPublicTeamPageListing", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) GetAllTeamListing() ([]*model.Team, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetAllTeamListing"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetAllTeamListing", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetAllTeamListing()
+	resultVar0, resultVar1 := childStore.GetAllTeamListing()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5527,13 +8455,19 @@ func (s *TimerLayerTeamStore) GetAllTeamListing() ([]*model.Team, *model.AppErro
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllTeamListing", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) GetAllTeamPageListing(offset int, limit int) ([]*model.Team, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetAllTeamPageListing"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetAllTeamPageListing", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetAllTeamPageListing(offset, limit)
+	resultVar0, resultVar1 := childStore.GetAllTeamPageListing(offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5544,13 +8478,19 @@ func (s *TimerLayerTeamStore) GetAllTeamPageListing(offset int, limit int) ([]*m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllTeamPageListing", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) GetByInviteId(inviteId string) (*model.Team, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetByInviteId"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetByInviteId", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetByInviteId(inviteId)
+	resultVar0, resultVar1 := childStore.GetByInviteId(inviteId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5561,13 +8501,19 @@ func (s *TimerLayerTeamStore) GetByInviteId(inviteId string) (*model.Team, *mode
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetByInviteId", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) GetByName(name string) (*model.Team, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetByName"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetByName", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetByName(name)
+	resultVar0, resultVar1 := childStore.GetByName(name)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5578,13 +8524,19 @@ func (s *TimerLayerTeamStore) GetByName(name string) (*model.Team, *model.AppErr
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetByName", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) GetChannelUnreadsForAllTeams(excludeTeamId string, userId string) ([]*model.ChannelUnread, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetChannelUnreadsForAllTeams"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetChannelUnreadsForAllTeams", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetChannelUnreadsForAllTeams(excludeTeamId, userId)
+	resultVar0, resultVar1 := childStore.GetChannelUnreadsForAllTeams(excludeTeamId, userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5595,13 +8547,19 @@ func (s *TimerLayerTeamStore) GetChannelUnreadsForAllTeams(excludeTeamId string,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetChannelUnreadsForAllTeams", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) GetChannelUnreadsForTeam(teamId string, userId string) ([]*model.ChannelUnread, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetChannelUnreadsForTeam"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetChannelUnreadsForTeam", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetChannelUnreadsForTeam(teamId, userId)
+	resultVar0, resultVar1 := childStore.GetChannelUnreadsForTeam(teamId, userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5612,13 +8570,19 @@ func (s *TimerLayerTeamStore) GetChannelUnreadsForTeam(teamId string, userId str
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetChannelUnreadsForTeam", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) GetMember(teamId string, userId string) (*model.TeamMember, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetMember"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetMember", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetMember(teamId, userId)
+	resultVar0, resultVar1 := childStore.GetMember(teamId, userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5629,13 +8593,19 @@ func (s *TimerLayerTeamStore) GetMember(teamId string, userId string) (*model.Te
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMember", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) GetMembers(teamId string, offset int, limit int, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetMembers"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetMembers", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetMembers(teamId, offset, limit, restrictions)
+	resultVar0, resultVar1 := childStore.GetMembers(teamId, offset, limit, restrictions)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5646,13 +8616,19 @@ func (s *TimerLayerTeamStore) GetMembers(teamId string, offset int, limit int, r
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMembers", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) GetMembersByIds(teamId string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetMembersByIds"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetMembersByIds", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetMembersByIds(teamId, userIds, restrictions)
+	resultVar0, resultVar1 := childStore.GetMembersByIds(teamId, userIds, restrictions)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5663,13 +8639,19 @@ func (s *TimerLayerTeamStore) GetMembersByIds(teamId string, userIds []string, r
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMembersByIds", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) GetTeamMembersForExport(userId string) ([]*model.TeamMemberForExport, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetTeamMembersForExport"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetTeamMembersForExport", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetTeamMembersForExport(userId)
+	resultVar0, resultVar1 := childStore.GetTeamMembersForExport(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5680,13 +8662,19 @@ func (s *TimerLayerTeamStore) GetTeamMembersForExport(userId string) ([]*model.T
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamMembersForExport", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) GetTeamsByScheme(schemeId string, offset int, limit int) ([]*model.Team, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetTeamsByScheme"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetTeamsByScheme", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetTeamsByScheme(schemeId, offset, limit)
+	resultVar0, resultVar1 := childStore.GetTeamsByScheme(schemeId, offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5697,13 +8685,19 @@ func (s *TimerLayerTeamStore) GetTeamsByScheme(schemeId string, offset int, limi
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsByScheme", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) GetTeamsByUserId(userId string) ([]*model.Team, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetTeamsByUserId"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetTeamsByUserId", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetTeamsByUserId(userId)
+	resultVar0, resultVar1 := childStore.GetTeamsByUserId(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5714,13 +8708,19 @@ func (s *TimerLayerTeamStore) GetTeamsByUserId(userId string) ([]*model.Team, *m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsByUserId", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) GetTeamsForUser(userId string) ([]*model.TeamMember, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetTeamsForUser"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetTeamsForUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetTeamsForUser(userId)
+	resultVar0, resultVar1 := childStore.GetTeamsForUser(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5731,13 +8731,19 @@ func (s *TimerLayerTeamStore) GetTeamsForUser(userId string) ([]*model.TeamMembe
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsForUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) GetTeamsForUserWithPagination(userId string, page int, perPage int) ([]*model.TeamMember, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetTeamsForUserWithPagination"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetTeamsForUserWithPagination", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetTeamsForUserWithPagination(userId, page, perPage)
+	resultVar0, resultVar1 := childStore.GetTeamsForUserWithPagination(userId, page, perPage)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5748,13 +8754,19 @@ func (s *TimerLayerTeamStore) GetTeamsForUserWithPagination(userId string, page
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsForUserWithPagination", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) GetTotalMemberCount(teamId string, restrictions *model.ViewUsersRestrictions) (int64, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetTotalMemberCount"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetTotalMemberCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetTotalMemberCount(teamId, restrictions)
+	resultVar0, resultVar1 := childStore.GetTotalMemberCount(teamId, restrictions)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5765,13 +8777,19 @@ func (s *TimerLayerTeamStore) GetTotalMemberCount(teamId string, restrictions *m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTotalMemberCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) GetUserTeamIds(userId string, allowFromCache bool) ([]string, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.GetUserTeamIds"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.GetUserTeamIds", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.GetUserTeamIds(userId, allowFromCache)
+	resultVar0, resultVar1 := childStore.GetUserTeamIds(userId, allowFromCache)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5782,13 +8800,19 @@ func (s *TimerLayerTeamStore) GetUserTeamIds(userId string, allowFromCache bool)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetUserTeamIds", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) InvalidateAllTeamIdsForUser(userId string) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.InvalidateAllTeamIdsForUser"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.InvalidateAllTeamIdsForUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.TeamStore.InvalidateAllTeamIdsForUser(userId)
+	childStore.InvalidateAllTeamIdsForUser(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5799,13 +8823,19 @@ func (s *TimerLayerTeamStore) InvalidateAllTeamIdsForUser(userId string) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.InvalidateAllTeamIdsForUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return
 }
 
 func (s *TimerLayerTeamStore) MigrateTeamMembers(fromTeamId string, fromUserId string) (map[string]string, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.MigrateTeamMembers"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.MigrateTeamMembers", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.MigrateTeamMembers(fromTeamId, fromUserId)
+	resultVar0, resultVar1 := childStore.MigrateTeamMembers(fromTeamId, fromUserId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5816,13 +8846,19 @@ func (s *TimerLayerTeamStore) MigrateTeamMembers(fromTeamId string, fromUserId s
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.MigrateTeamMembers", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) PermanentDelete(teamId string) *model.AppError {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.PermanentDelete"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.PermanentDelete", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.TeamStore.PermanentDelete(teamId)
+	resultVar0 := childStore.PermanentDelete(teamId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5833,13 +8869,19 @@ func (s *TimerLayerTeamStore) PermanentDelete(teamId string) *model.AppError {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.PermanentDelete", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerTeamStore) RemoveAllMembersByTeam(teamId string) *model.AppError {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.RemoveAllMembersByTeam"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.RemoveAllMembersByTeam", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.TeamStore.RemoveAllMembersByTeam(teamId)
+	resultVar0 := childStore.RemoveAllMembersByTeam(teamId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5850,13 +8892,19 @@ func (s *TimerLayerTeamStore) RemoveAllMembersByTeam(teamId string) *model.AppEr
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.RemoveAllMembersByTeam", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerTeamStore) RemoveAllMembersByUser(userId string) *model.AppError {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.RemoveAllMembersByUser"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.RemoveAllMembersByUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.TeamStore.RemoveAllMembersByUser(userId)
+	resultVar0 := childStore.RemoveAllMembersByUser(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5867,13 +8915,19 @@ func (s *TimerLayerTeamStore) RemoveAllMembersByUser(userId string) *model.AppEr
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.RemoveAllMembersByUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerTeamStore) RemoveMember(teamId string, userId string) *model.AppError {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.RemoveMember"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.RemoveMember", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.TeamStore.RemoveMember(teamId, userId)
+	resultVar0 := childStore.RemoveMember(teamId, userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5884,13 +8938,19 @@ func (s *TimerLayerTeamStore) RemoveMember(teamId string, userId string) *model.
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.RemoveMember", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerTeamStore) ResetAllTeamSchemes() *model.AppError {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.ResetAllTeamSchemes"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.ResetAllTeamSchemes", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.TeamStore.ResetAllTeamSchemes()
+	resultVar0 := childStore.ResetAllTeamSchemes()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5901,13 +8961,19 @@ func (s *TimerLayerTeamStore) ResetAllTeamSchemes() *model.AppError {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.ResetAllTeamSchemes", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerTeamStore) Save(team *model.Team) (*model.Team, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.Save"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.Save(team)
+	resultVar0, resultVar1 := childStore.Save(team)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5918,13 +8984,19 @@ func (s *TimerLayerTeamStore) Save(team *model.Team) (*model.Team, *model.AppErr
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) SaveMember(member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.SaveMember"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.SaveMember", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.SaveMember(member, maxUsersPerTeam)
+	resultVar0, resultVar1 := childStore.SaveMember(member, maxUsersPerTeam)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5935,13 +9007,19 @@ func (s *TimerLayerTeamStore) SaveMember(member *model.TeamMember, maxUsersPerTe
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SaveMember", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) SearchAll(term string) ([]*model.Team, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.SearchAll"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.SearchAll", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.SearchAll(term)
+	resultVar0, resultVar1 := childStore.SearchAll(term)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5952,13 +9030,19 @@ func (s *TimerLayerTeamStore) SearchAll(term string) ([]*model.Team, *model.AppE
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SearchAll", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) SearchOpen(term string) ([]*model.Team, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.SearchOpen"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.SearchOpen", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.SearchOpen(term)
+	resultVar0, resultVar1 := childStore.SearchOpen(term)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5969,13 +9053,19 @@ func (s *TimerLayerTeamStore) SearchOpen(term string) ([]*model.Team, *model.App
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SearchOpen", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) SearchPrivate(term string) ([]*model.Team, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.SearchPrivate"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.SearchPrivate", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.SearchPrivate(term)
+	resultVar0, resultVar1 := childStore.SearchPrivate(term)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -5986,13 +9076,19 @@ func (s *TimerLayerTeamStore) SearchPrivate(term string) ([]*model.Team, *model.
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SearchPrivate", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) Update(team *model.Team) (*model.Team, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.Update"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.Update", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.Update(team)
+	resultVar0, resultVar1 := childStore.Update(team)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6003,13 +9099,19 @@ func (s *TimerLayerTeamStore) Update(team *model.Team) (*model.Team, *model.AppE
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.Update", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) UpdateLastTeamIconUpdate(teamId string, curTime int64) *model.AppError {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.UpdateLastTeamIconUpdate"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.UpdateLastTeamIconUpdate", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.TeamStore.UpdateLastTeamIconUpdate(teamId, curTime)
+	resultVar0 := childStore.UpdateLastTeamIconUpdate(teamId, curTime)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6020,13 +9122,19 @@ func (s *TimerLayerTeamStore) UpdateLastTeamIconUpdate(teamId string, curTime in
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UpdateLastTeamIconUpdate", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerTeamStore) UpdateMember(member *model.TeamMember) (*model.TeamMember, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.UpdateMember"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.UpdateMember", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.UpdateMember(member)
+	resultVar0, resultVar1 := childStore.UpdateMember(member)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6037,13 +9145,19 @@ func (s *TimerLayerTeamStore) UpdateMember(member *model.TeamMember) (*model.Tea
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UpdateMember", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTeamStore) UserBelongsToTeams(userId string, teamIds []string) (bool, *model.AppError) {
+	childStore := s.TeamStore
+	if view, ok := s.Root.queryTimeouts["TeamStore.UserBelongsToTeams"]; ok {
+		childStore = view.Team()
+	}
+	span := s.Root.span.StartChild("TeamStore.UserBelongsToTeams", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TeamStore.UserBelongsToTeams(userId, teamIds)
+	resultVar0, resultVar1 := childStore.UserBelongsToTeams(userId, teamIds)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6054,13 +9168,19 @@ func (s *TimerLayerTeamStore) UserBelongsToTeams(userId string, teamIds []string
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UserBelongsToTeams", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTermsOfServiceStore) Get(id string, allowFromCache bool) (*model.TermsOfService, *model.AppError) {
+	childStore := s.TermsOfServiceStore
+	if view, ok := s.Root.queryTimeouts["TermsOfServiceStore.Get"]; ok {
+		childStore = view.TermsOfService()
+	}
+	span := s.Root.span.StartChild("TermsOfServiceStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TermsOfServiceStore.Get(id, allowFromCache)
+	resultVar0, resultVar1 := childStore.Get(id, allowFromCache)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6071,13 +9191,19 @@ func (s *TimerLayerTermsOfServiceStore) Get(id string, allowFromCache bool) (*mo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TermsOfServiceStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTermsOfServiceStore) GetLatest(allowFromCache bool) (*model.TermsOfService, *model.AppError) {
+	childStore := s.TermsOfServiceStore
+	if view, ok := s.Root.queryTimeouts["TermsOfServiceStore.GetLatest"]; ok {
+		childStore = view.TermsOfService()
+	}
+	span := s.Root.span.StartChild("TermsOfServiceStore.GetLatest", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TermsOfServiceStore.GetLatest(allowFromCache)
+	resultVar0, resultVar1 := childStore.GetLatest(allowFromCache)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6088,13 +9214,19 @@ func (s *TimerLayerTermsOfServiceStore) GetLatest(allowFromCache bool) (*model.T
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TermsOfServiceStore.GetLatest", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTermsOfServiceStore) Save(termsOfService *model.TermsOfService) (*model.TermsOfService, *model.AppError) {
+	childStore := s.TermsOfServiceStore
+	if view, ok := s.Root.queryTimeouts["TermsOfServiceStore.Save"]; ok {
+		childStore = view.TermsOfService()
+	}
+	span := s.Root.span.StartChild("TermsOfServiceStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TermsOfServiceStore.Save(termsOfService)
+	resultVar0, resultVar1 := childStore.Save(termsOfService)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6105,13 +9237,19 @@ func (s *TimerLayerTermsOfServiceStore) Save(termsOfService *model.TermsOfServic
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TermsOfServiceStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTokenStore) Cleanup() {
+	childStore := s.TokenStore
+	if view, ok := s.Root.queryTimeouts["TokenStore.Cleanup"]; ok {
+		childStore = view.Token()
+	}
+	span := s.Root.span.StartChild("TokenStore.Cleanup", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.TokenStore.Cleanup()
+	childStore.Cleanup()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6122,13 +9260,19 @@ func (s *TimerLayerTokenStore) Cleanup() {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.Cleanup", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return
 }
 
 func (s *TimerLayerTokenStore) Delete(token string) *model.AppError {
+	childStore := s.TokenStore
+	if view, ok := s.Root.queryTimeouts["TokenStore.Delete"]; ok {
+		childStore = view.Token()
+	}
+	span := s.Root.span.StartChild("TokenStore.Delete", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.TokenStore.Delete(token)
+	resultVar0 := childStore.Delete(token)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6139,13 +9283,19 @@ func (s *TimerLayerTokenStore) Delete(token string) *model.AppError {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.Delete", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerTokenStore) GetByToken(token string) (*model.Token, *model.AppError) {
+	childStore := s.TokenStore
+	if view, ok := s.Root.queryTimeouts["TokenStore.GetByToken"]; ok {
+		childStore = view.Token()
+	}
+	span := s.Root.span.StartChild("TokenStore.GetByToken", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.TokenStore.GetByToken(token)
+	resultVar0, resultVar1 := childStore.GetByToken(token)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6156,13 +9306,19 @@ func (s *TimerLayerTokenStore) GetByToken(token string) (*model.Token, *model.Ap
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.GetByToken", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerTokenStore) RemoveAllTokensByType(tokenType string) *model.AppError {
+	childStore := s.TokenStore
+	if view, ok := s.Root.queryTimeouts["TokenStore.RemoveAllTokensByType"]; ok {
+		childStore = view.Token()
+	}
+	span := s.Root.span.StartChild("TokenStore.RemoveAllTokensByType", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.TokenStore.RemoveAllTokensByType(tokenType)
+	resultVar0 := childStore.RemoveAllTokensByType(tokenType)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6173,13 +9329,19 @@ func (s *TimerLayerTokenStore) RemoveAllTokensByType(tokenType string) *model.Ap
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.RemoveAllTokensByType", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerTokenStore) Save(recovery *model.Token) *model.AppError {
+	childStore := s.TokenStore
+	if view, ok := s.Root.queryTimeouts["TokenStore.Save"]; ok {
+		childStore = view.Token()
+	}
+	span := s.Root.span.StartChild("TokenStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.TokenStore.Save(recovery)
+	resultVar0 := childStore.Save(recovery)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6190,13 +9352,19 @@ func (s *TimerLayerTokenStore) Save(recovery *model.Token) *model.AppError {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerUserStore) AnalyticsActiveCount(time int64, options model.UserCountOptions) (int64, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.AnalyticsActiveCount"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.AnalyticsActiveCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.AnalyticsActiveCount(time, options)
+	resultVar0, resultVar1 := childStore.AnalyticsActiveCount(time, options)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6207,13 +9375,19 @@ func (s *TimerLayerUserStore) AnalyticsActiveCount(time int64, options model.Use
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.AnalyticsActiveCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) AnalyticsGetInactiveUsersCount() (int64, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.AnalyticsGetInactiveUsersCount"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.AnalyticsGetInactiveUsersCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.AnalyticsGetInactiveUsersCount()
+	resultVar0, resultVar1 := childStore.AnalyticsGetInactiveUsersCount()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6224,13 +9398,19 @@ func (s *TimerLayerUserStore) AnalyticsGetInactiveUsersCount() (int64, *model.Ap
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.AnalyticsGetInactiveUsersCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) AnalyticsGetSystemAdminCount() (int64, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.AnalyticsGetSystemAdminCount"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.AnalyticsGetSystemAdminCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.AnalyticsGetSystemAdminCount()
+	resultVar0, resultVar1 := childStore.AnalyticsGetSystemAdminCount()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6241,13 +9421,19 @@ func (s *TimerLayerUserStore) AnalyticsGetSystemAdminCount() (int64, *model.AppE
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.AnalyticsGetSystemAdminCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) ClearAllCustomRoleAssignments() *model.AppError {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.ClearAllCustomRoleAssignments"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.ClearAllCustomRoleAssignments", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.ClearAllCustomRoleAssignments()
+	resultVar0 := childStore.ClearAllCustomRoleAssignments()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6258,13 +9444,19 @@ func (s *TimerLayerUserStore) ClearAllCustomRoleAssignments() *model.AppError {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.ClearAllCustomRoleAssignments", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerUserStore) ClearCaches() {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.ClearCaches"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.ClearCaches", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.UserStore.ClearCaches()
+	childStore.ClearCaches()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6275,13 +9467,42 @@ func (s *TimerLayerUserStore) ClearCaches() {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.ClearCaches", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return
 }
 
+func (s *TimerLayerUserStore) ClearExpiredCustomStatuses(now int64, limit int) ([]string, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.ClearExpiredCustomStatuses"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.ClearExpiredCustomStatuses", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.ClearExpiredCustomStatuses(now, limit)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.ClearExpiredCustomStatuses", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerUserStore) Count(options model.UserCountOptions) (int64, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.Count"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.Count", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.Count(options)
+	resultVar0, resultVar1 := childStore.Count(options)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6292,13 +9513,19 @@ func (s *TimerLayerUserStore) Count(options model.UserCountOptions) (int64, *mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.Count", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) DemoteUserToGuest(userID string) *model.AppError {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.DemoteUserToGuest"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.DemoteUserToGuest", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.DemoteUserToGuest(userID)
+	resultVar0 := childStore.DemoteUserToGuest(userID)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6309,13 +9536,19 @@ func (s *TimerLayerUserStore) DemoteUserToGuest(userID string) *model.AppError {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.DemoteUserToGuest", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerUserStore) Get(id string) (*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.Get"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.Get(id)
+	resultVar0, resultVar1 := childStore.Get(id)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6326,13 +9559,19 @@ func (s *TimerLayerUserStore) Get(id string) (*model.User, *model.AppError) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetAll() ([]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetAll"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetAll", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetAll()
+	resultVar0, resultVar1 := childStore.GetAll()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6343,13 +9582,19 @@ func (s *TimerLayerUserStore) GetAll() ([]*model.User, *model.AppError) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAll", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetAllAfter(limit int, afterId string) ([]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetAllAfter"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetAllAfter", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetAllAfter(limit, afterId)
+	resultVar0, resultVar1 := childStore.GetAllAfter(limit, afterId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6360,13 +9605,19 @@ func (s *TimerLayerUserStore) GetAllAfter(limit int, afterId string) ([]*model.U
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAllAfter", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetAllProfiles(options *model.UserGetOptions) ([]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetAllProfiles"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetAllProfiles", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetAllProfiles(options)
+	resultVar0, resultVar1 := childStore.GetAllProfiles(options)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6377,13 +9628,19 @@ func (s *TimerLayerUserStore) GetAllProfiles(options *model.UserGetOptions) ([]*
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAllProfiles", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetAllProfilesInChannel(channelId string, allowFromCache bool) (map[string]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetAllProfilesInChannel"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetAllProfilesInChannel", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetAllProfilesInChannel(channelId, allowFromCache)
+	resultVar0, resultVar1 := childStore.GetAllProfilesInChannel(channelId, allowFromCache)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6394,13 +9651,19 @@ func (s *TimerLayerUserStore) GetAllProfilesInChannel(channelId string, allowFro
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAllProfilesInChannel", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetAllUsingAuthService(authService string) ([]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetAllUsingAuthService"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetAllUsingAuthService", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetAllUsingAuthService(authService)
+	resultVar0, resultVar1 := childStore.GetAllUsingAuthService(authService)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6411,13 +9674,19 @@ func (s *TimerLayerUserStore) GetAllUsingAuthService(authService string) ([]*mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAllUsingAuthService", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetAnyUnreadPostCountForChannel(userId string, channelId string) (int64, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetAnyUnreadPostCountForChannel"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetAnyUnreadPostCountForChannel", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetAnyUnreadPostCountForChannel(userId, channelId)
+	resultVar0, resultVar1 := childStore.GetAnyUnreadPostCountForChannel(userId, channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6428,13 +9697,19 @@ func (s *TimerLayerUserStore) GetAnyUnreadPostCountForChannel(userId string, cha
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAnyUnreadPostCountForChannel", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetByAuth(authData *string, authService string) (*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetByAuth"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetByAuth", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetByAuth(authData, authService)
+	resultVar0, resultVar1 := childStore.GetByAuth(authData, authService)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6445,13 +9720,19 @@ func (s *TimerLayerUserStore) GetByAuth(authData *string, authService string) (*
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetByAuth", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetByEmail(email string) (*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetByEmail"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetByEmail", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetByEmail(email)
+	resultVar0, resultVar1 := childStore.GetByEmail(email)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6462,13 +9743,19 @@ func (s *TimerLayerUserStore) GetByEmail(email string) (*model.User, *model.AppE
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetByEmail", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetByUsername(username string) (*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetByUsername"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetByUsername", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetByUsername(username)
+	resultVar0, resultVar1 := childStore.GetByUsername(username)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6479,13 +9766,19 @@ func (s *TimerLayerUserStore) GetByUsername(username string) (*model.User, *mode
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetByUsername", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetChannelGroupUsers(channelID string) ([]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetChannelGroupUsers"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetChannelGroupUsers", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetChannelGroupUsers(channelID)
+	resultVar0, resultVar1 := childStore.GetChannelGroupUsers(channelID)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6496,13 +9789,19 @@ func (s *TimerLayerUserStore) GetChannelGroupUsers(channelID string) ([]*model.U
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetChannelGroupUsers", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetEtagForAllProfiles() string {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetEtagForAllProfiles"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetEtagForAllProfiles", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.GetEtagForAllProfiles()
+	resultVar0 := childStore.GetEtagForAllProfiles()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6513,13 +9812,19 @@ func (s *TimerLayerUserStore) GetEtagForAllProfiles() string {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetEtagForAllProfiles", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return resultVar0
 }
 
 func (s *TimerLayerUserStore) GetEtagForProfiles(teamId string) string {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetEtagForProfiles"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetEtagForProfiles", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.GetEtagForProfiles(teamId)
+	resultVar0 := childStore.GetEtagForProfiles(teamId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6530,13 +9835,19 @@ func (s *TimerLayerUserStore) GetEtagForProfiles(teamId string) string {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetEtagForProfiles", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return resultVar0
 }
 
 func (s *TimerLayerUserStore) GetEtagForProfilesNotInTeam(teamId string) string {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetEtagForProfilesNotInTeam"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetEtagForProfilesNotInTeam", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.GetEtagForProfilesNotInTeam(teamId)
+	resultVar0 := childStore.GetEtagForProfilesNotInTeam(teamId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6547,13 +9858,19 @@ func (s *TimerLayerUserStore) GetEtagForProfilesNotInTeam(teamId string) string
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetEtagForProfilesNotInTeam", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return resultVar0
 }
 
 func (s *TimerLayerUserStore) GetForLogin(loginId string, allowSignInWithUsername bool, allowSignInWithEmail bool) (*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetForLogin"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetForLogin", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetForLogin(loginId, allowSignInWithUsername, allowSignInWithEmail)
+	resultVar0, resultVar1 := childStore.GetForLogin(loginId, allowSignInWithUsername, allowSignInWithEmail)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6564,13 +9881,19 @@ func (s *TimerLayerUserStore) GetForLogin(loginId string, allowSignInWithUsernam
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetForLogin", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetNewUsersForTeam(teamId string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetNewUsersForTeam"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetNewUsersForTeam", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetNewUsersForTeam(teamId, offset, limit, viewRestrictions)
+	resultVar0, resultVar1 := childStore.GetNewUsersForTeam(teamId, offset, limit, viewRestrictions)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6581,13 +9904,19 @@ func (s *TimerLayerUserStore) GetNewUsersForTeam(teamId string, offset int, limi
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetNewUsersForTeam", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetProfileByGroupChannelIdsForUser(userId string, channelIds []string) (map[string][]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetProfileByGroupChannelIdsForUser"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetProfileByGroupChannelIdsForUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetProfileByGroupChannelIdsForUser(userId, channelIds)
+	resultVar0, resultVar1 := childStore.GetProfileByGroupChannelIdsForUser(userId, channelIds)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6598,13 +9927,19 @@ func (s *TimerLayerUserStore) GetProfileByGroupChannelIdsForUser(userId string,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfileByGroupChannelIdsForUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetProfileByIds(userIds []string, options *UserGetByIdsOpts, allowFromCache bool) ([]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetProfileByIds"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetProfileByIds", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetProfileByIds(userIds, options, allowFromCache)
+	resultVar0, resultVar1 := childStore.GetProfileByIds(userIds, options, allowFromCache)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6615,13 +9950,19 @@ func (s *TimerLayerUserStore) GetProfileByIds(userIds []string, options *UserGet
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfileByIds", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetProfiles(options *model.UserGetOptions) ([]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetProfiles"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetProfiles", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetProfiles(options)
+	resultVar0, resultVar1 := childStore.GetProfiles(options)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6632,13 +9973,19 @@ func (s *TimerLayerUserStore) GetProfiles(options *model.UserGetOptions) ([]*mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfiles", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetProfilesByUsernames(usernames []string, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetProfilesByUsernames"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetProfilesByUsernames", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetProfilesByUsernames(usernames, viewRestrictions)
+	resultVar0, resultVar1 := childStore.GetProfilesByUsernames(usernames, viewRestrictions)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6649,13 +9996,19 @@ func (s *TimerLayerUserStore) GetProfilesByUsernames(usernames []string, viewRes
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesByUsernames", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetProfilesInChannel(channelId string, offset int, limit int) ([]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetProfilesInChannel"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetProfilesInChannel", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetProfilesInChannel(channelId, offset, limit)
+	resultVar0, resultVar1 := childStore.GetProfilesInChannel(channelId, offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6666,13 +10019,19 @@ func (s *TimerLayerUserStore) GetProfilesInChannel(channelId string, offset int,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesInChannel", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetProfilesInChannelByStatus(channelId string, offset int, limit int) ([]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetProfilesInChannelByStatus"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetProfilesInChannelByStatus", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetProfilesInChannelByStatus(channelId, offset, limit)
+	resultVar0, resultVar1 := childStore.GetProfilesInChannelByStatus(channelId, offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6683,13 +10042,19 @@ func (s *TimerLayerUserStore) GetProfilesInChannelByStatus(channelId string, off
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesInChannelByStatus", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetProfilesNotInChannel(teamId string, channelId string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetProfilesNotInChannel"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetProfilesNotInChannel", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetProfilesNotInChannel(teamId, channelId, groupConstrained, offset, limit, viewRestrictions)
+	resultVar0, resultVar1 := childStore.GetProfilesNotInChannel(teamId, channelId, groupConstrained, offset, limit, viewRestrictions)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6700,13 +10065,19 @@ func (s *TimerLayerUserStore) GetProfilesNotInChannel(teamId string, channelId s
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesNotInChannel", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetProfilesNotInTeam(teamId string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetProfilesNotInTeam"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetProfilesNotInTeam", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetProfilesNotInTeam(teamId, groupConstrained, offset, limit, viewRestrictions)
+	resultVar0, resultVar1 := childStore.GetProfilesNotInTeam(teamId, groupConstrained, offset, limit, viewRestrictions)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6717,13 +10088,19 @@ func (s *TimerLayerUserStore) GetProfilesNotInTeam(teamId string, groupConstrain
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesNotInTeam", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetProfilesWithoutTeam(offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetProfilesWithoutTeam"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetProfilesWithoutTeam", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetProfilesWithoutTeam(offset, limit, viewRestrictions)
+	resultVar0, resultVar1 := childStore.GetProfilesWithoutTeam(offset, limit, viewRestrictions)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6734,13 +10111,19 @@ func (s *TimerLayerUserStore) GetProfilesWithoutTeam(offset int, limit int, view
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesWithoutTeam", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetRecentlyActiveUsersForTeam(teamId string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetRecentlyActiveUsersForTeam"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetRecentlyActiveUsersForTeam", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetRecentlyActiveUsersForTeam(teamId, offset, limit, viewRestrictions)
+	resultVar0, resultVar1 := childStore.GetRecentlyActiveUsersForTeam(teamId, offset, limit, viewRestrictions)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6751,13 +10134,19 @@ func (s *TimerLayerUserStore) GetRecentlyActiveUsersForTeam(teamId string, offse
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetRecentlyActiveUsersForTeam", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetSystemAdminProfiles() (map[string]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetSystemAdminProfiles"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetSystemAdminProfiles", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetSystemAdminProfiles()
+	resultVar0, resultVar1 := childStore.GetSystemAdminProfiles()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6768,13 +10157,19 @@ func (s *TimerLayerUserStore) GetSystemAdminProfiles() (map[string]*model.User,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetSystemAdminProfiles", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetTeamGroupUsers(teamID string) ([]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetTeamGroupUsers"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetTeamGroupUsers", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetTeamGroupUsers(teamID)
+	resultVar0, resultVar1 := childStore.GetTeamGroupUsers(teamID)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6785,13 +10180,19 @@ func (s *TimerLayerUserStore) GetTeamGroupUsers(teamID string) ([]*model.User, *
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetTeamGroupUsers", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetUnreadCount(userId string) (int64, error) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetUnreadCount"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetUnreadCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetUnreadCount(userId)
+	resultVar0, resultVar1 := childStore.GetUnreadCount(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6802,13 +10203,19 @@ func (s *TimerLayerUserStore) GetUnreadCount(userId string) (int64, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetUnreadCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetUnreadCountForChannel(userId string, channelId string) (int64, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetUnreadCountForChannel"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetUnreadCountForChannel", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetUnreadCountForChannel(userId, channelId)
+	resultVar0, resultVar1 := childStore.GetUnreadCountForChannel(userId, channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6819,13 +10226,42 @@ func (s *TimerLayerUserStore) GetUnreadCountForChannel(userId string, channelId
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetUnreadCountForChannel", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerUserStore) GetUserIdsWithWorkingHoursEnded(now int64, limit int) ([]string, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetUserIdsWithWorkingHoursEnded"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetUserIdsWithWorkingHoursEnded", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := childStore.GetUserIdsWithWorkingHoursEnded(now, limit)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetUserIdsWithWorkingHoursEnded", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) GetUsersBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.UserForIndexing, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.GetUsersBatchForIndexing"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.GetUsersBatchForIndexing", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.GetUsersBatchForIndexing(startTime, endTime, limit)
+	resultVar0, resultVar1 := childStore.GetUsersBatchForIndexing(startTime, endTime, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6836,13 +10272,19 @@ func (s *TimerLayerUserStore) GetUsersBatchForIndexing(startTime int64, endTime
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetUsersBatchForIndexing", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) InferSystemInstallDate() (int64, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.InferSystemInstallDate"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.InferSystemInstallDate", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.InferSystemInstallDate()
+	resultVar0, resultVar1 := childStore.InferSystemInstallDate()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6853,13 +10295,19 @@ func (s *TimerLayerUserStore) InferSystemInstallDate() (int64, *model.AppError)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.InferSystemInstallDate", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) InvalidatProfileCacheForUser(userId string) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.InvalidatProfileCacheForUser"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.InvalidatProfileCacheForUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.UserStore.InvalidatProfileCacheForUser(userId)
+	childStore.InvalidatProfileCacheForUser(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6870,13 +10318,19 @@ func (s *TimerLayerUserStore) InvalidatProfileCacheForUser(userId string) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.InvalidatProfileCacheForUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return
 }
 
 func (s *TimerLayerUserStore) InvalidateProfilesInChannelCache(channelId string) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.InvalidateProfilesInChannelCache"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.InvalidateProfilesInChannelCache", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.UserStore.InvalidateProfilesInChannelCache(channelId)
+	childStore.InvalidateProfilesInChannelCache(channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6887,13 +10341,19 @@ func (s *TimerLayerUserStore) InvalidateProfilesInChannelCache(channelId string)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.InvalidateProfilesInChannelCache", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return
 }
 
 func (s *TimerLayerUserStore) InvalidateProfilesInChannelCacheByUser(userId string) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.InvalidateProfilesInChannelCacheByUser"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.InvalidateProfilesInChannelCacheByUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.UserStore.InvalidateProfilesInChannelCacheByUser(userId)
+	childStore.InvalidateProfilesInChannelCacheByUser(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6904,13 +10364,19 @@ func (s *TimerLayerUserStore) InvalidateProfilesInChannelCacheByUser(userId stri
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.InvalidateProfilesInChannelCacheByUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return
 }
 
 func (s *TimerLayerUserStore) PermanentDelete(userId string) *model.AppError {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.PermanentDelete"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.PermanentDelete", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.PermanentDelete(userId)
+	resultVar0 := childStore.PermanentDelete(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6921,13 +10387,19 @@ func (s *TimerLayerUserStore) PermanentDelete(userId string) *model.AppError {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.PermanentDelete", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerUserStore) PromoteGuestToUser(userID string) *model.AppError {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.PromoteGuestToUser"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.PromoteGuestToUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.PromoteGuestToUser(userID)
+	resultVar0 := childStore.PromoteGuestToUser(userID)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6938,13 +10410,19 @@ func (s *TimerLayerUserStore) PromoteGuestToUser(userID string) *model.AppError
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.PromoteGuestToUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerUserStore) ResetLastPictureUpdate(userId string) *model.AppError {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.ResetLastPictureUpdate"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.ResetLastPictureUpdate", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.ResetLastPictureUpdate(userId)
+	resultVar0 := childStore.ResetLastPictureUpdate(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6955,13 +10433,19 @@ func (s *TimerLayerUserStore) ResetLastPictureUpdate(userId string) *model.AppEr
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.ResetLastPictureUpdate", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerUserStore) Save(user *model.User) (*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.Save"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.Save(user)
+	resultVar0, resultVar1 := childStore.Save(user)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6972,13 +10456,19 @@ func (s *TimerLayerUserStore) Save(user *model.User) (*model.User, *model.AppErr
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) Search(teamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.Search"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.Search", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.Search(teamId, term, options)
+	resultVar0, resultVar1 := childStore.Search(teamId, term, options)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -6989,13 +10479,19 @@ func (s *TimerLayerUserStore) Search(teamId string, term string, options *model.
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.Search", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) SearchInChannel(channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.SearchInChannel"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.SearchInChannel", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.SearchInChannel(channelId, term, options)
+	resultVar0, resultVar1 := childStore.SearchInChannel(channelId, term, options)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7006,13 +10502,19 @@ func (s *TimerLayerUserStore) SearchInChannel(channelId string, term string, opt
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.SearchInChannel", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) SearchNotInChannel(teamId string, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.SearchNotInChannel"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.SearchNotInChannel", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.SearchNotInChannel(teamId, channelId, term, options)
+	resultVar0, resultVar1 := childStore.SearchNotInChannel(teamId, channelId, term, options)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7023,13 +10525,19 @@ func (s *TimerLayerUserStore) SearchNotInChannel(teamId string, channelId string
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.SearchNotInChannel", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) SearchNotInTeam(notInTeamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.SearchNotInTeam"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.SearchNotInTeam", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.SearchNotInTeam(notInTeamId, term, options)
+	resultVar0, resultVar1 := childStore.SearchNotInTeam(notInTeamId, term, options)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7040,13 +10548,19 @@ func (s *TimerLayerUserStore) SearchNotInTeam(notInTeamId string, term string, o
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.SearchNotInTeam", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) SearchWithoutTeam(term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.SearchWithoutTeam"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.SearchWithoutTeam", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.SearchWithoutTeam(term, options)
+	resultVar0, resultVar1 := childStore.SearchWithoutTeam(term, options)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7057,13 +10571,65 @@ func (s *TimerLayerUserStore) SearchWithoutTeam(term string, options *model.User
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.SearchWithoutTeam", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerUserStore) SetCustomStatusExpiry(userId string, expiresAt int64) *model.AppError {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.SetCustomStatusExpiry"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.SetCustomStatusExpiry", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0 := childStore.SetCustomStatusExpiry(userId, expiresAt)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.SetCustomStatusExpiry", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar0)
+	return resultVar0
+}
+
+func (s *TimerLayerUserStore) SetWorkingHoursEnd(userId string, endAt int64) *model.AppError {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.SetWorkingHoursEnd"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.SetWorkingHoursEnd", tracing.SPAN_KIND_CLIENT)
+	start := timemodule.Now()
+
+	resultVar0 := childStore.SetWorkingHoursEnd(userId, endAt)
+
+	t := timemodule.Now()
+	elapsed := t.Sub(start)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.SetWorkingHoursEnd", success, float64(elapsed))
+	}
+	s.Root.finishSpan(span, resultVar0)
+	return resultVar0
+}
+
 func (s *TimerLayerUserStore) Update(user *model.User, allowRoleUpdate bool) (*model.UserUpdate, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.Update"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.Update", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.Update(user, allowRoleUpdate)
+	resultVar0, resultVar1 := childStore.Update(user, allowRoleUpdate)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7074,13 +10640,19 @@ func (s *TimerLayerUserStore) Update(user *model.User, allowRoleUpdate bool) (*m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.Update", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) UpdateAuthData(userId string, service string, authData *string, email string, resetMfa bool) (string, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.UpdateAuthData"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.UpdateAuthData", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.UpdateAuthData(userId, service, authData, email, resetMfa)
+	resultVar0, resultVar1 := childStore.UpdateAuthData(userId, service, authData, email, resetMfa)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7091,13 +10663,19 @@ func (s *TimerLayerUserStore) UpdateAuthData(userId string, service string, auth
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateAuthData", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) UpdateFailedPasswordAttempts(userId string, attempts int) *model.AppError {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.UpdateFailedPasswordAttempts"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.UpdateFailedPasswordAttempts", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.UpdateFailedPasswordAttempts(userId, attempts)
+	resultVar0 := childStore.UpdateFailedPasswordAttempts(userId, attempts)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7108,13 +10686,19 @@ func (s *TimerLayerUserStore) UpdateFailedPasswordAttempts(userId string, attemp
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateFailedPasswordAttempts", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerUserStore) UpdateLastPictureUpdate(userId string) *model.AppError {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.UpdateLastPictureUpdate"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.UpdateLastPictureUpdate", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.UpdateLastPictureUpdate(userId)
+	resultVar0 := childStore.UpdateLastPictureUpdate(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7125,13 +10709,19 @@ func (s *TimerLayerUserStore) UpdateLastPictureUpdate(userId string) *model.AppE
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateLastPictureUpdate", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerUserStore) UpdateMfaActive(userId string, active bool) *model.AppError {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.UpdateMfaActive"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.UpdateMfaActive", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.UpdateMfaActive(userId, active)
+	resultVar0 := childStore.UpdateMfaActive(userId, active)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7142,13 +10732,19 @@ func (s *TimerLayerUserStore) UpdateMfaActive(userId string, active bool) *model
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateMfaActive", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerUserStore) UpdateMfaSecret(userId string, secret string) *model.AppError {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.UpdateMfaSecret"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.UpdateMfaSecret", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.UpdateMfaSecret(userId, secret)
+	resultVar0 := childStore.UpdateMfaSecret(userId, secret)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7159,13 +10755,19 @@ func (s *TimerLayerUserStore) UpdateMfaSecret(userId string, secret string) *mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateMfaSecret", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerUserStore) UpdatePassword(userId string, newPassword string) *model.AppError {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.UpdatePassword"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.UpdatePassword", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.UserStore.UpdatePassword(userId, newPassword)
+	resultVar0 := childStore.UpdatePassword(userId, newPassword)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7176,13 +10778,19 @@ func (s *TimerLayerUserStore) UpdatePassword(userId string, newPassword string)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdatePassword", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerUserStore) UpdateUpdateAt(userId string) (int64, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.UpdateUpdateAt"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.UpdateUpdateAt", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.UpdateUpdateAt(userId)
+	resultVar0, resultVar1 := childStore.UpdateUpdateAt(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7193,13 +10801,19 @@ func (s *TimerLayerUserStore) UpdateUpdateAt(userId string) (int64, *model.AppEr
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateUpdateAt", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserStore) VerifyEmail(userId string, email string) (string, *model.AppError) {
+	childStore := s.UserStore
+	if view, ok := s.Root.queryTimeouts["UserStore.VerifyEmail"]; ok {
+		childStore = view.User()
+	}
+	span := s.Root.span.StartChild("UserStore.VerifyEmail", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserStore.VerifyEmail(userId, email)
+	resultVar0, resultVar1 := childStore.VerifyEmail(userId, email)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7210,13 +10824,19 @@ func (s *TimerLayerUserStore) VerifyEmail(userId string, email string) (string,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.VerifyEmail", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserAccessTokenStore) Delete(tokenId string) *model.AppError {
+	childStore := s.UserAccessTokenStore
+	if view, ok := s.Root.queryTimeouts["UserAccessTokenStore.Delete"]; ok {
+		childStore = view.UserAccessToken()
+	}
+	span := s.Root.span.StartChild("UserAccessTokenStore.Delete", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.UserAccessTokenStore.Delete(tokenId)
+	resultVar0 := childStore.Delete(tokenId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7227,13 +10847,19 @@ func (s *TimerLayerUserAccessTokenStore) Delete(tokenId string) *model.AppError
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.Delete", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerUserAccessTokenStore) DeleteAllForUser(userId string) *model.AppError {
+	childStore := s.UserAccessTokenStore
+	if view, ok := s.Root.queryTimeouts["UserAccessTokenStore.DeleteAllForUser"]; ok {
+		childStore = view.UserAccessToken()
+	}
+	span := s.Root.span.StartChild("UserAccessTokenStore.DeleteAllForUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.UserAccessTokenStore.DeleteAllForUser(userId)
+	resultVar0 := childStore.DeleteAllForUser(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7244,13 +10870,19 @@ func (s *TimerLayerUserAccessTokenStore) DeleteAllForUser(userId string) *model.
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.DeleteAllForUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerUserAccessTokenStore) Get(tokenId string) (*model.UserAccessToken, *model.AppError) {
+	childStore := s.UserAccessTokenStore
+	if view, ok := s.Root.queryTimeouts["UserAccessTokenStore.Get"]; ok {
+		childStore = view.UserAccessToken()
+	}
+	span := s.Root.span.StartChild("UserAccessTokenStore.Get", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserAccessTokenStore.Get(tokenId)
+	resultVar0, resultVar1 := childStore.Get(tokenId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7261,13 +10893,19 @@ func (s *TimerLayerUserAccessTokenStore) Get(tokenId string) (*model.UserAccessT
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.Get", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserAccessTokenStore) GetAll(offset int, limit int) ([]*model.UserAccessToken, *model.AppError) {
+	childStore := s.UserAccessTokenStore
+	if view, ok := s.Root.queryTimeouts["UserAccessTokenStore.GetAll"]; ok {
+		childStore = view.UserAccessToken()
+	}
+	span := s.Root.span.StartChild("UserAccessTokenStore.GetAll", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserAccessTokenStore.GetAll(offset, limit)
+	resultVar0, resultVar1 := childStore.GetAll(offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7278,13 +10916,19 @@ func (s *TimerLayerUserAccessTokenStore) GetAll(offset int, limit int) ([]*model
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.GetAll", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserAccessTokenStore) GetByToken(tokenString string) (*model.UserAccessToken, *model.AppError) {
+	childStore := s.UserAccessTokenStore
+	if view, ok := s.Root.queryTimeouts["UserAccessTokenStore.GetByToken"]; ok {
+		childStore = view.UserAccessToken()
+	}
+	span := s.Root.span.StartChild("UserAccessTokenStore.GetByToken", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserAccessTokenStore.GetByToken(tokenString)
+	resultVar0, resultVar1 := childStore.GetByToken(tokenString)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7295,13 +10939,19 @@ func (s *TimerLayerUserAccessTokenStore) GetByToken(tokenString string) (*model.
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.GetByToken", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserAccessTokenStore) GetByUser(userId string, page int, perPage int) ([]*model.UserAccessToken, *model.AppError) {
+	childStore := s.UserAccessTokenStore
+	if view, ok := s.Root.queryTimeouts["UserAccessTokenStore.GetByUser"]; ok {
+		childStore = view.UserAccessToken()
+	}
+	span := s.Root.span.StartChild("UserAccessTokenStore.GetByUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserAccessTokenStore.GetByUser(userId, page, perPage)
+	resultVar0, resultVar1 := childStore.GetByUser(userId, page, perPage)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7312,13 +10962,19 @@ func (s *TimerLayerUserAccessTokenStore) GetByUser(userId string, page int, perP
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.GetByUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserAccessTokenStore) Save(token *model.UserAccessToken) (*model.UserAccessToken, *model.AppError) {
+	childStore := s.UserAccessTokenStore
+	if view, ok := s.Root.queryTimeouts["UserAccessTokenStore.Save"]; ok {
+		childStore = view.UserAccessToken()
+	}
+	span := s.Root.span.StartChild("UserAccessTokenStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserAccessTokenStore.Save(token)
+	resultVar0, resultVar1 := childStore.Save(token)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7329,13 +10985,19 @@ func (s *TimerLayerUserAccessTokenStore) Save(token *model.UserAccessToken) (*mo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserAccessTokenStore) Search(term string) ([]*model.UserAccessToken, *model.AppError) {
+	childStore := s.UserAccessTokenStore
+	if view, ok := s.Root.queryTimeouts["UserAccessTokenStore.Search"]; ok {
+		childStore = view.UserAccessToken()
+	}
+	span := s.Root.span.StartChild("UserAccessTokenStore.Search", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserAccessTokenStore.Search(term)
+	resultVar0, resultVar1 := childStore.Search(term)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7346,13 +11008,19 @@ func (s *TimerLayerUserAccessTokenStore) Search(term string) ([]*model.UserAcces
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.Search", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserAccessTokenStore) UpdateTokenDisable(tokenId string) *model.AppError {
+	childStore := s.UserAccessTokenStore
+	if view, ok := s.Root.queryTimeouts["UserAccessTokenStore.UpdateTokenDisable"]; ok {
+		childStore = view.UserAccessToken()
+	}
+	span := s.Root.span.StartChild("UserAccessTokenStore.UpdateTokenDisable", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.UserAccessTokenStore.UpdateTokenDisable(tokenId)
+	resultVar0 := childStore.UpdateTokenDisable(tokenId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7363,13 +11031,19 @@ func (s *TimerLayerUserAccessTokenStore) UpdateTokenDisable(tokenId string) *mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.UpdateTokenDisable", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerUserAccessTokenStore) UpdateTokenEnable(tokenId string) *model.AppError {
+	childStore := s.UserAccessTokenStore
+	if view, ok := s.Root.queryTimeouts["UserAccessTokenStore.UpdateTokenEnable"]; ok {
+		childStore = view.UserAccessToken()
+	}
+	span := s.Root.span.StartChild("UserAccessTokenStore.UpdateTokenEnable", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.UserAccessTokenStore.UpdateTokenEnable(tokenId)
+	resultVar0 := childStore.UpdateTokenEnable(tokenId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7380,13 +11054,19 @@ func (s *TimerLayerUserAccessTokenStore) UpdateTokenEnable(tokenId string) *mode
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.UpdateTokenEnable", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerUserTermsOfServiceStore) Delete(userId string, termsOfServiceId string) *model.AppError {
+	childStore := s.UserTermsOfServiceStore
+	if view, ok := s.Root.queryTimeouts["UserTermsOfServiceStore.Delete"]; ok {
+		childStore = view.UserTermsOfService()
+	}
+	span := s.Root.span.StartChild("UserTermsOfServiceStore.Delete", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.UserTermsOfServiceStore.Delete(userId, termsOfServiceId)
+	resultVar0 := childStore.Delete(userId, termsOfServiceId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7397,13 +11077,19 @@ func (s *TimerLayerUserTermsOfServiceStore) Delete(userId string, termsOfService
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserTermsOfServiceStore.Delete", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerUserTermsOfServiceStore) GetByUser(userId string) (*model.UserTermsOfService, *model.AppError) {
+	childStore := s.UserTermsOfServiceStore
+	if view, ok := s.Root.queryTimeouts["UserTermsOfServiceStore.GetByUser"]; ok {
+		childStore = view.UserTermsOfService()
+	}
+	span := s.Root.span.StartChild("UserTermsOfServiceStore.GetByUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserTermsOfServiceStore.GetByUser(userId)
+	resultVar0, resultVar1 := childStore.GetByUser(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7414,13 +11100,19 @@ func (s *TimerLayerUserTermsOfServiceStore) GetByUser(userId string) (*model.Use
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserTermsOfServiceStore.GetByUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerUserTermsOfServiceStore) Save(userTermsOfService *model.UserTermsOfService) (*model.UserTermsOfService, *model.AppError) {
+	childStore := s.UserTermsOfServiceStore
+	if view, ok := s.Root.queryTimeouts["UserTermsOfServiceStore.Save"]; ok {
+		childStore = view.UserTermsOfService()
+	}
+	span := s.Root.span.StartChild("UserTermsOfServiceStore.Save", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.UserTermsOfServiceStore.Save(userTermsOfService)
+	resultVar0, resultVar1 := childStore.Save(userTermsOfService)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7431,13 +11123,19 @@ func (s *TimerLayerUserTermsOfServiceStore) Save(userTermsOfService *model.UserT
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserTermsOfServiceStore.Save", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerWebhookStore) AnalyticsIncomingCount(teamId string) (int64, *model.AppError) {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.AnalyticsIncomingCount"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.AnalyticsIncomingCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.WebhookStore.AnalyticsIncomingCount(teamId)
+	resultVar0, resultVar1 := childStore.AnalyticsIncomingCount(teamId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7448,13 +11146,19 @@ func (s *TimerLayerWebhookStore) AnalyticsIncomingCount(teamId string) (int64, *
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.AnalyticsIncomingCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerWebhookStore) AnalyticsOutgoingCount(teamId string) (int64, *model.AppError) {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.AnalyticsOutgoingCount"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.AnalyticsOutgoingCount", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.WebhookStore.AnalyticsOutgoingCount(teamId)
+	resultVar0, resultVar1 := childStore.AnalyticsOutgoingCount(teamId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7465,13 +11169,19 @@ func (s *TimerLayerWebhookStore) AnalyticsOutgoingCount(teamId string) (int64, *
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.AnalyticsOutgoingCount", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerWebhookStore) ClearCaches() {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.ClearCaches"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.ClearCaches", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.WebhookStore.ClearCaches()
+	childStore.ClearCaches()
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7482,13 +11192,19 @@ func (s *TimerLayerWebhookStore) ClearCaches() {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.ClearCaches", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return
 }
 
 func (s *TimerLayerWebhookStore) DeleteIncoming(webhookId string, time int64) *model.AppError {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.DeleteIncoming"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.DeleteIncoming", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.WebhookStore.DeleteIncoming(webhookId, time)
+	resultVar0 := childStore.DeleteIncoming(webhookId, time)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7499,13 +11215,19 @@ func (s *TimerLayerWebhookStore) DeleteIncoming(webhookId string, time int64) *m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.DeleteIncoming", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerWebhookStore) DeleteOutgoing(webhookId string, time int64) *model.AppError {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.DeleteOutgoing"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.DeleteOutgoing", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.WebhookStore.DeleteOutgoing(webhookId, time)
+	resultVar0 := childStore.DeleteOutgoing(webhookId, time)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7516,13 +11238,19 @@ func (s *TimerLayerWebhookStore) DeleteOutgoing(webhookId string, time int64) *m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.DeleteOutgoing", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerWebhookStore) GetIncoming(id string, allowFromCache bool) (*model.IncomingWebhook, *model.AppError) {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.GetIncoming"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.GetIncoming", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.WebhookStore.GetIncoming(id, allowFromCache)
+	resultVar0, resultVar1 := childStore.GetIncoming(id, allowFromCache)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7533,13 +11261,19 @@ func (s *TimerLayerWebhookStore) GetIncoming(id string, allowFromCache bool) (*m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetIncoming", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerWebhookStore) GetIncomingByChannel(channelId string) ([]*model.IncomingWebhook, *model.AppError) {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.GetIncomingByChannel"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.GetIncomingByChannel", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.WebhookStore.GetIncomingByChannel(channelId)
+	resultVar0, resultVar1 := childStore.GetIncomingByChannel(channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7550,13 +11284,19 @@ func (s *TimerLayerWebhookStore) GetIncomingByChannel(channelId string) ([]*mode
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetIncomingByChannel", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerWebhookStore) GetIncomingByTeam(teamId string, offset int, limit int) ([]*model.IncomingWebhook, *model.AppError) {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.GetIncomingByTeam"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.GetIncomingByTeam", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.WebhookStore.GetIncomingByTeam(teamId, offset, limit)
+	resultVar0, resultVar1 := childStore.GetIncomingByTeam(teamId, offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7567,13 +11307,19 @@ func (s *TimerLayerWebhookStore) GetIncomingByTeam(teamId string, offset int, li
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetIncomingByTeam", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerWebhookStore) GetIncomingByTeamByUser(teamId string, userId string, offset int, limit int) ([]*model.IncomingWebhook, *model.AppError) {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.GetIncomingByTeamByUser"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.GetIncomingByTeamByUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.WebhookStore.GetIncomingByTeamByUser(teamId, userId, offset, limit)
+	resultVar0, resultVar1 := childStore.GetIncomingByTeamByUser(teamId, userId, offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7584,13 +11330,19 @@ func (s *TimerLayerWebhookStore) GetIncomingByTeamByUser(teamId string, userId s
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetIncomingByTeamByUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerWebhookStore) GetIncomingList(offset int, limit int) ([]*model.IncomingWebhook, *model.AppError) {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.GetIncomingList"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.GetIncomingList", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.WebhookStore.GetIncomingList(offset, limit)
+	resultVar0, resultVar1 := childStore.GetIncomingList(offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7601,13 +11353,19 @@ func (s *TimerLayerWebhookStore) GetIncomingList(offset int, limit int) ([]*mode
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetIncomingList", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerWebhookStore) GetIncomingListByUser(userId string, offset int, limit int) ([]*model.IncomingWebhook, *model.AppError) {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.GetIncomingListByUser"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.GetIncomingListByUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.WebhookStore.GetIncomingListByUser(userId, offset, limit)
+	resultVar0, resultVar1 := childStore.GetIncomingListByUser(userId, offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7618,13 +11376,19 @@ func (s *TimerLayerWebhookStore) GetIncomingListByUser(userId string, offset int
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetIncomingListByUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerWebhookStore) GetOutgoing(id string) (*model.OutgoingWebhook, *model.AppError) {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.GetOutgoing"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.GetOutgoing", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.WebhookStore.GetOutgoing(id)
+	resultVar0, resultVar1 := childStore.GetOutgoing(id)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7635,13 +11399,19 @@ func (s *TimerLayerWebhookStore) GetOutgoing(id string) (*model.OutgoingWebhook,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoing", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerWebhookStore) GetOutgoingByChannel(channelId string, offset int, limit int) ([]*model.OutgoingWebhook, *model.AppError) {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.GetOutgoingByChannel"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.GetOutgoingByChannel", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.WebhookStore.GetOutgoingByChannel(channelId, offset, limit)
+	resultVar0, resultVar1 := childStore.GetOutgoingByChannel(channelId, offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7652,13 +11422,19 @@ func (s *TimerLayerWebhookStore) GetOutgoingByChannel(channelId string, offset i
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingByChannel", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerWebhookStore) GetOutgoingByChannelByUser(channelId string, userId string, offset int, limit int) ([]*model.OutgoingWebhook, *model.AppError) {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.GetOutgoingByChannelByUser"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.GetOutgoingByChannelByUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.WebhookStore.GetOutgoingByChannelByUser(channelId, userId, offset, limit)
+	resultVar0, resultVar1 := childStore.GetOutgoingByChannelByUser(channelId, userId, offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7669,13 +11445,19 @@ func (s *TimerLayerWebhookStore) GetOutgoingByChannelByUser(channelId string, us
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingByChannelByUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerWebhookStore) GetOutgoingByTeam(teamId string, offset int, limit int) ([]*model.OutgoingWebhook, *model.AppError) {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.GetOutgoingByTeam"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.GetOutgoingByTeam", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.WebhookStore.GetOutgoingByTeam(teamId, offset, limit)
+	resultVar0, resultVar1 := childStore.GetOutgoingByTeam(teamId, offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7686,13 +11468,19 @@ func (s *TimerLayerWebhookStore) GetOutgoingByTeam(teamId string, offset int, li
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingByTeam", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerWebhookStore) GetOutgoingByTeamByUser(teamId string, userId string, offset int, limit int) ([]*model.OutgoingWebhook, *model.AppError) {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.GetOutgoingByTeamByUser"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.GetOutgoingByTeamByUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.WebhookStore.GetOutgoingByTeamByUser(teamId, userId, offset, limit)
+	resultVar0, resultVar1 := childStore.GetOutgoingByTeamByUser(teamId, userId, offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7703,13 +11491,19 @@ func (s *TimerLayerWebhookStore) GetOutgoingByTeamByUser(teamId string, userId s
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingByTeamByUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerWebhookStore) GetOutgoingList(offset int, limit int) ([]*model.OutgoingWebhook, *model.AppError) {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.GetOutgoingList"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.GetOutgoingList", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.WebhookStore.GetOutgoingList(offset, limit)
+	resultVar0, resultVar1 := childStore.GetOutgoingList(offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7720,13 +11514,19 @@ func (s *TimerLayerWebhookStore) GetOutgoingList(offset int, limit int) ([]*mode
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingList", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerWebhookStore) GetOutgoingListByUser(userId string, offset int, limit int) ([]*model.OutgoingWebhook, *model.AppError) {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.GetOutgoingListByUser"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.GetOutgoingListByUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.WebhookStore.GetOutgoingListByUser(userId, offset, limit)
+	resultVar0, resultVar1 := childStore.GetOutgoingListByUser(userId, offset, limit)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7737,13 +11537,19 @@ func (s *TimerLayerWebhookStore) GetOutgoingListByUser(userId string, offset int
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingListByUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerWebhookStore) InvalidateWebhookCache(webhook string) {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.InvalidateWebhookCache"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.InvalidateWebhookCache", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	s.WebhookStore.InvalidateWebhookCache(webhook)
+	childStore.InvalidateWebhookCache(webhook)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7754,13 +11560,19 @@ func (s *TimerLayerWebhookStore) InvalidateWebhookCache(webhook string) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.InvalidateWebhookCache", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, nil)
 	return
 }
 
 func (s *TimerLayerWebhookStore) PermanentDeleteIncomingByChannel(channelId string) *model.AppError {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.PermanentDeleteIncomingByChannel"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.PermanentDeleteIncomingByChannel", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.WebhookStore.PermanentDeleteIncomingByChannel(channelId)
+	resultVar0 := childStore.PermanentDeleteIncomingByChannel(channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7771,13 +11583,19 @@ func (s *TimerLayerWebhookStore) PermanentDeleteIncomingByChannel(channelId stri
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.PermanentDeleteIncomingByChannel", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerWebhookStore) PermanentDeleteIncomingByUser(userId string) *model.AppError {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.PermanentDeleteIncomingByUser"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.PermanentDeleteIncomingByUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.WebhookStore.PermanentDeleteIncomingByUser(userId)
+	resultVar0 := childStore.PermanentDeleteIncomingByUser(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7788,13 +11606,19 @@ func (s *TimerLayerWebhookStore) PermanentDeleteIncomingByUser(userId string) *m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.PermanentDeleteIncomingByUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerWebhookStore) PermanentDeleteOutgoingByChannel(channelId string) *model.AppError {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.PermanentDeleteOutgoingByChannel"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.PermanentDeleteOutgoingByChannel", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.WebhookStore.PermanentDeleteOutgoingByChannel(channelId)
+	resultVar0 := childStore.PermanentDeleteOutgoingByChannel(channelId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7805,13 +11629,19 @@ func (s *TimerLayerWebhookStore) PermanentDeleteOutgoingByChannel(channelId stri
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.PermanentDeleteOutgoingByChannel", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerWebhookStore) PermanentDeleteOutgoingByUser(userId string) *model.AppError {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.PermanentDeleteOutgoingByUser"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.PermanentDeleteOutgoingByUser", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0 := s.WebhookStore.PermanentDeleteOutgoingByUser(userId)
+	resultVar0 := childStore.PermanentDeleteOutgoingByUser(userId)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7822,13 +11652,19 @@ func (s *TimerLayerWebhookStore) PermanentDeleteOutgoingByUser(userId string) *m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.PermanentDeleteOutgoingByUser", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar0)
 	return resultVar0
 }
 
 func (s *TimerLayerWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, *model.AppError) {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.SaveIncoming"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.SaveIncoming", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.WebhookStore.SaveIncoming(webhook)
+	resultVar0, resultVar1 := childStore.SaveIncoming(webhook)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7839,13 +11675,19 @@ func (s *TimerLayerWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.SaveIncoming", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError) {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.SaveOutgoing"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.SaveOutgoing", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.WebhookStore.SaveOutgoing(webhook)
+	resultVar0, resultVar1 := childStore.SaveOutgoing(webhook)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7856,13 +11698,19 @@ func (s *TimerLayerWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.SaveOutgoing", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerWebhookStore) UpdateIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, *model.AppError) {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.UpdateIncoming"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.UpdateIncoming", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.WebhookStore.UpdateIncoming(webhook)
+	resultVar0, resultVar1 := childStore.UpdateIncoming(webhook)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7873,13 +11721,19 @@ func (s *TimerLayerWebhookStore) UpdateIncoming(webhook *model.IncomingWebhook)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.UpdateIncoming", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
 func (s *TimerLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError) {
+	childStore := s.WebhookStore
+	if view, ok := s.Root.queryTimeouts["WebhookStore.UpdateOutgoing"]; ok {
+		childStore = view.Webhook()
+	}
+	span := s.Root.span.StartChild("WebhookStore.UpdateOutgoing", tracing.SPAN_KIND_CLIENT)
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.WebhookStore.UpdateOutgoing(hook)
+	resultVar0, resultVar1 := childStore.UpdateOutgoing(hook)
 
 	t := timemodule.Now()
 	elapsed := t.Sub(start)
@@ -7890,6 +11744,7 @@ func (s *TimerLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.UpdateOutgoing", success, float64(elapsed))
 	}
+	s.Root.finishSpan(span, resultVar1)
 	return resultVar0, resultVar1
 }
 
@@ -7913,6 +11768,10 @@ func (s *TimerLayer) MarkSystemRanUnitTests() {
 	s.Store.MarkSystemRanUnitTests()
 }
 
+func (s *TimerLayer) ReplicaStatuses() []*model.ReplicaStatus {
+	return s.Store.ReplicaStatuses()
+}
+
 func (s *TimerLayer) TotalMasterDbConnections() int {
 	return s.Store.TotalMasterDbConnections()
 }
@@ -7929,13 +11788,64 @@ func (s *TimerLayer) UnlockFromMaster() {
 	s.Store.UnlockFromMaster()
 }
 
+func (s *TimerLayer) MasterOnly() Store {
+	return s.masterOnly
+}
+
+// Traced returns a view of this store reporting each of its calls as a span nested in the given
+// one, or this store itself if the span is nil.
+func (s *TimerLayer) Traced(span *tracing.Span) Store {
+	if span == nil {
+		return s
+	}
+
+	newStore := newTimerLayer(s.Store, s.Metrics)
+	newStore.span = span
+	newStore.queryTimeouts = s.queryTimeouts
+	if s.masterOnly == s {
+		newStore.masterOnly = newStore
+	} else {
+		newStore.masterOnly = s.masterOnly.Traced(span).(*TimerLayer)
+	}
+	return newStore
+}
+
+// SetQueryTimeouts makes the given store methods, such as PostStore.Search, run their queries with
+// the timeouts given for them, in seconds, if the wrapped store is able to. It must be called
+// before the store is used.
+func (s *TimerLayer) SetQueryTimeouts(timeouts map[string]int) {
+	s.queryTimeouts = queryTimeoutViews(s.Store, timeouts)
+	if s.masterOnly != s {
+		s.masterOnly.queryTimeouts = queryTimeoutViews(s.masterOnly.Store, timeouts)
+	}
+}
+
+func (s *TimerLayer) finishSpan(span *tracing.Span, err *model.AppError) {
+	if span == nil {
+		return
+	}
+
+	if err != nil {
+		span.SetError(err.Id)
+	}
+	span.Finish()
+}
+
 func NewTimerLayer(childStore Store, metrics einterfaces.MetricsInterface) *TimerLayer {
+	newStore := newTimerLayer(childStore, metrics)
+	newStore.masterOnly = newTimerLayer(childStore.MasterOnly(), metrics)
+	newStore.masterOnly.masterOnly = newStore.masterOnly
+	return newStore
+}
+
+func newTimerLayer(childStore Store, metrics einterfaces.MetricsInterface) *TimerLayer {
 	newStore := TimerLayer{
 		Store:   childStore,
 		Metrics: metrics,
 	}
 
 	newStore.AuditStore = &TimerLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.AuditLogStore = &TimerLayerAuditLogStore{AuditLogStore: childStore.AuditLog(), Root: &newStore}
 	newStore.BotStore = &TimerLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
 	newStore.ChannelStore = &TimerLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
 	newStore.ChannelMemberHistoryStore = &TimerLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
@@ -7951,7 +11861,11 @@ func NewTimerLayer(childStore Store, metrics einterfaces.MetricsInterface) *Time
 	newStore.LinkMetadataStore = &TimerLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
 	newStore.OAuthStore = &TimerLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
 	newStore.PluginStore = &TimerLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
+	newStore.PluginConfigRevisionStore = &TimerLayerPluginConfigRevisionStore{PluginConfigRevisionStore: childStore.PluginConfigRevision(), Root: &newStore}
 	newStore.PostStore = &TimerLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
+	newStore.PostAcknowledgementStore = &TimerLayerPostAcknowledgementStore{PostAcknowledgementStore: childStore.PostAcknowledgement(), Root: &newStore}
+	newStore.PostPriorityStore = &TimerLayerPostPriorityStore{PostPriorityStore: childStore.PostPriority(), Root: &newStore}
+	newStore.PostShardStore = &TimerLayerPostShardStore{PostShardStore: childStore.PostShard(), Root: &newStore}
 	newStore.PreferenceStore = &TimerLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
 	newStore.ReactionStore = &TimerLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
 	newStore.RoleStore = &TimerLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
@@ -0,0 +1,75 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package store_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/services/tracing"
+	"github.com/mattermost/mattermost-server/store"
+	"github.com/mattermost/mattermost-server/store/storetest"
+)
+
+// queryTimeoutStore records the views of itself returned by WithQueryTimeout.
+type queryTimeoutStore struct {
+	*storetest.Store
+	views map[time.Duration]*storetest.Store
+}
+
+func (s *queryTimeoutStore) WithQueryTimeout(timeout time.Duration) store.Store {
+	view := &storetest.Store{}
+	s.views[timeout] = view
+	return view
+}
+
+func TestTimerLayerQueryTimeouts(t *testing.T) {
+	baseStore := &queryTimeoutStore{Store: &storetest.Store{}, views: map[time.Duration]*storetest.Store{}}
+	timerLayer := store.NewTimerLayer(baseStore, nil)
+	timerLayer.SetQueryTimeouts(map[string]int{
+		"PostStore.Search":        120,
+		"PostStore.GetPostsSince": 120,
+		"PostStore.Unknown":       60,
+	})
+
+	require.Len(t, baseStore.views, 1, "the methods sharing a timeout must share a view")
+	view := baseStore.views[120*time.Second]
+	require.NotNil(t, view)
+
+	params := &model.SearchParams{Terms: "test"}
+	view.PostStore.On("Search", "team_id", "user_id", params).Return(model.NewPostList(), nil)
+	baseStore.PostStore.On("Get", "post_id").Return(model.NewPostList(), nil)
+
+	t.Run("overridden methods use the view", func(t *testing.T) {
+		_, err := timerLayer.Post().Search("team_id", "user_id", params)
+		require.Nil(t, err)
+		view.PostStore.AssertNumberOfCalls(t, "Search", 1)
+		baseStore.PostStore.AssertNotCalled(t, "Search", "team_id", "user_id", params)
+	})
+
+	t.Run("other methods use the wrapped store", func(t *testing.T) {
+		_, err := timerLayer.Post().Get("post_id")
+		require.Nil(t, err)
+		baseStore.PostStore.AssertNumberOfCalls(t, "Get", 1)
+	})
+
+	t.Run("traced views keep the overrides", func(t *testing.T) {
+		tracer := tracing.NewTracer(discardExporter{}, 1)
+		defer tracer.Close()
+		span := tracer.StartTrace("test", tracing.SPAN_KIND_SERVER)
+		defer span.Finish()
+
+		_, err := timerLayer.Traced(span).Post().Search("team_id", "user_id", params)
+		require.Nil(t, err)
+		view.PostStore.AssertNumberOfCalls(t, "Search", 2)
+	})
+}
+
+type discardExporter struct{}
+
+func (discardExporter) Export(spans []*tracing.Span) error { return nil }
+func (discardExporter) Close() error                       { return nil }
@@ -25,7 +25,7 @@ type Context struct {
 
 func (c *Context) LogAudit(extraInfo string) {
 	audit := &model.Audit{UserId: c.App.Session.UserId, IpAddress: c.App.IpAddress, Action: c.App.Path, ExtraInfo: extraInfo, SessionId: c.App.Session.Id}
-	if err := c.App.Srv.Store.Audit().Save(audit); err != nil {
+	if err := c.App.Store().Audit().Save(audit); err != nil {
 		c.LogError(err)
 	}
 }
@@ -37,7 +37,7 @@ func (c *Context) LogAuditWithUserId(userId, extraInfo string) {
 	}
 
 	audit := &model.Audit{UserId: userId, IpAddress: c.App.IpAddress, Action: c.App.Path, ExtraInfo: extraInfo, SessionId: c.App.Session.Id}
-	if err := c.App.Srv.Store.Audit().Save(audit); err != nil {
+	if err := c.App.Store().Audit().Save(audit); err != nil {
 		c.LogError(err)
 	}
 }
@@ -335,6 +335,18 @@ func (c *Context) RequirePluginId() *Context {
 	return c
 }
 
+func (c *Context) RequireRevisionId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.RevisionId) != 26 {
+		c.SetInvalidUrlParam("revision_id")
+	}
+
+	return c
+}
+
 func (c *Context) RequireReportId() *Context {
 	if c.Err != nil {
 		return c
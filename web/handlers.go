@@ -9,10 +9,12 @@ import (
 	"time"
 
 	"github.com/NYTimes/gziphandler"
+	"github.com/gorilla/mux"
 
 	"github.com/mattermost/mattermost-server/app"
 	"github.com/mattermost/mattermost-server/mlog"
 	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/services/tracing"
 	"github.com/mattermost/mattermost-server/utils"
 )
 
@@ -72,6 +74,13 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	c.App.Path = r.URL.Path
 	c.Log = c.App.Log
 
+	span := c.App.Srv.Tracer().StartTrace(r.Method+" "+routePath(r), tracing.SPAN_KIND_SERVER)
+	span.SetAttribute("http.method", r.Method)
+	span.SetAttribute("http.target", r.URL.Path)
+	span.SetAttribute("request_id", c.App.RequestId)
+	c.App.SetSpan(span)
+	defer span.Finish()
+
 	subpath, _ := utils.GetSubpathFromConfig(c.App.Config())
 	siteURLHeader := app.GetProtocol(r) + "://" + r.Host + subpath
 	c.SetSiteURLHeader(siteURLHeader)
@@ -126,6 +135,13 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.checkCSRFToken(c, r, token, tokenLocation, session)
 	}
 
+	// Let a session that just wrote read its own writes, even if the read replicas lag behind.
+	if c.App.SessionWroteRecently() {
+		c.App.ReadFromMaster()
+	}
+
+	span.SetAttribute("user_id", c.App.Session.UserId)
+
 	c.Log = c.App.Log.With(
 		mlog.String("path", c.App.Path),
 		mlog.String("request_id", c.App.RequestId),
@@ -146,11 +162,20 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.HandleFunc(c, w, r)
 	}
 
+	if c.Err == nil && r.Method != http.MethodGet && r.Method != http.MethodHead {
+		c.App.RecordSessionWrite()
+	}
+
 	// Handle errors that have occurred
 	if c.Err != nil {
 		c.Err.Translate(c.App.T)
 		c.Err.RequestId = c.App.RequestId
 
+		span.SetAttribute("http.status_code", c.Err.StatusCode)
+		if c.Err.StatusCode >= 500 {
+			span.SetError(c.Err.Id)
+		}
+
 		if c.Err.Id == "api.context.session_expired.app_error" {
 			c.LogInfo(c.Err)
 		} else {
@@ -196,6 +221,18 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// routePath returns the path template of the route matching the request, such as
+// /api/v4/users/{user_id}, to name the requests to the same endpoint alike.
+func routePath(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if path, err := route.GetPathTemplate(); err == nil {
+			return path
+		}
+	}
+
+	return r.URL.Path
+}
+
 // checkCSRFToken performs a CSRF check on the provided request with the given CSRF token. Returns whether or not
 // a CSRF check occurred and whether or not it succeeded.
 func (h *Handler) checkCSRFToken(c *Context, r *http.Request, token string, tokenLocation app.TokenLocation, session *model.Session) (checked bool, passed bool) {
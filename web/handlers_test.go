@@ -513,3 +513,66 @@ func TestCheckCSRFToken(t *testing.T) {
 		assert.Nil(t, c.Err)
 	})
 }
+
+func handlerForSessionWrites(c *Context, w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("fail") != "" {
+		c.Err = model.NewAppError("handlerForSessionWrites", "api.context.permissions.app_error", nil, "", http.StatusForbidden)
+	}
+}
+
+func TestHandlerServeHTTPRecordsSessionWrites(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	// The replicas are never changed by updating the config, so set them on the current one.
+	th.App.Config().SqlSettings.DataSourceReplicas = []string{"replica"}
+	defer func() { th.App.Config().SqlSettings.DataSourceReplicas = []string{} }()
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.SqlSettings.StickyMasterAfterWriteSeconds = 5 })
+
+	web := New(th.Server, th.Server.AppOptions, th.Server.Router)
+	handler := Handler{
+		GetGlobalAppOptions: web.GetGlobalAppOptions,
+		HandleFunc:          handlerForSessionWrites,
+		RequireSession:      true,
+		TrustRequester:      false,
+		RequireMfa:          false,
+		IsStatic:            false,
+	}
+
+	wroteRecently := func(session *model.Session) bool {
+		th.App.Session = *session
+		return th.App.SessionWroteRecently()
+	}
+
+	serve := func(method, url string, session *model.Session) int {
+		request := httptest.NewRequest(method, url, nil)
+		request.Header.Set(model.HEADER_AUTH, model.HEADER_BEARER+" "+session.Token)
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, request)
+		return response.Code
+	}
+
+	newSession := func() *model.Session {
+		session, err := th.App.CreateSession(&model.Session{UserId: th.BasicUser.Id, Roles: model.SYSTEM_USER_ROLE_ID})
+		require.Nil(t, err)
+		return session
+	}
+
+	t.Run("successful writes are recorded", func(t *testing.T) {
+		session := newSession()
+		require.Equal(t, http.StatusOK, serve("POST", "/api/v4/test", session))
+		assert.True(t, wroteRecently(session))
+	})
+
+	t.Run("failed writes are not recorded", func(t *testing.T) {
+		session := newSession()
+		require.Equal(t, http.StatusForbidden, serve("POST", "/api/v4/test?fail=true", session))
+		assert.False(t, wroteRecently(session))
+	})
+
+	t.Run("reads are not recorded", func(t *testing.T) {
+		session := newSession()
+		require.Equal(t, http.StatusOK, serve("GET", "/api/v4/test", session))
+		assert.False(t, wroteRecently(session))
+	})
+}
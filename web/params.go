@@ -32,6 +32,7 @@ type Params struct {
 	FileId                 string
 	Filename               string
 	PluginId               string
+	RevisionId             string
 	CommandId              string
 	HookId                 string
 	ReportId               string
@@ -118,6 +119,10 @@ func ParamsFromRequest(r *http.Request) *Params {
 		params.PluginId = val
 	}
 
+	if val, ok := props["revision_id"]; ok {
+		params.RevisionId = val
+	}
+
 	if val, ok := props["command_id"]; ok {
 		params.CommandId = val
 	}
@@ -4,7 +4,8 @@
 package web
 
 import (
-	"io"
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"strings"
 
@@ -24,6 +25,7 @@ func incomingWebhook(c *Context, w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	id := params["id"]
 
+	r.Body = http.MaxBytesReader(w, r.Body, model.INCOMING_WEBHOOK_REQUEST_MAX_SIZE)
 	r.ParseForm()
 
 	var err *model.AppError
@@ -38,10 +40,14 @@ func incomingWebhook(c *Context, w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 
-	if strings.Split(contentType, "; ")[0] == "application/x-www-form-urlencoded" {
-		payload := strings.NewReader(r.FormValue("payload"))
+	hook, err := c.App.GetIncomingWebhookForRequest(id)
+	if err != nil {
+		c.Err = err
+		return
+	}
 
-		incomingWebhookPayload, err = decodePayload(payload)
+	if strings.Split(contentType, "; ")[0] == "application/x-www-form-urlencoded" {
+		incomingWebhookPayload, err = c.App.RenderIncomingWebhookPayload(hook, []byte(r.FormValue("payload")))
 		if err != nil {
 			c.Err = err
 			return
@@ -49,22 +55,36 @@ func incomingWebhook(c *Context, w http.ResponseWriter, r *http.Request) {
 	} else if strings.HasPrefix(contentType, "multipart/form-data") {
 		r.ParseMultipartForm(0)
 
-		decoder := schema.NewDecoder()
-		err := decoder.Decode(incomingWebhookPayload, r.PostForm)
+		if len(hook.PayloadTemplate) > 0 {
+			incomingWebhookPayload, err = c.App.RenderIncomingWebhookPayload(hook, multipartWebhookPayload(r))
+			if err != nil {
+				c.Err = err
+				return
+			}
+		} else {
+			decoder := schema.NewDecoder()
+			err := decoder.Decode(incomingWebhookPayload, r.PostForm)
 
-		if err != nil {
-			c.Err = model.NewAppError("incomingWebhook", "api.webhook.incoming.error", nil, err.Error(), http.StatusBadRequest)
-			return
+			if err != nil {
+				c.Err = model.NewAppError("incomingWebhook", "api.webhook.incoming.error", nil, err.Error(), http.StatusBadRequest)
+				return
+			}
 		}
 	} else {
-		incomingWebhookPayload, err = decodePayload(r.Body)
+		body, readErr := ioutil.ReadAll(r.Body)
+		if readErr != nil {
+			c.Err = model.NewAppError("incomingWebhook", "web.incoming_webhook.parse.app_error", nil, readErr.Error(), http.StatusBadRequest)
+			return
+		}
+
+		incomingWebhookPayload, err = c.App.RenderIncomingWebhookPayload(hook, body)
 		if err != nil {
 			c.Err = err
 			return
 		}
 	}
 
-	err = c.App.HandleIncomingWebhook(id, incomingWebhookPayload)
+	err = c.App.HandleIncomingWebhook(hook, incomingWebhookPayload)
 	if err != nil {
 		c.Err = err
 		return
@@ -74,6 +94,26 @@ func incomingWebhook(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("ok"))
 }
 
+// multipartWebhookPayload returns the payload of a multipart request to render through a payload
+// template: its "payload" field if it has one, or else its fields as a JSON object.
+func multipartWebhookPayload(r *http.Request) []byte {
+	if payload := r.PostForm.Get("payload"); len(payload) > 0 {
+		return []byte(payload)
+	}
+
+	fields := make(map[string]interface{}, len(r.PostForm))
+	for name, values := range r.PostForm {
+		if len(values) == 1 {
+			fields[name] = values[0]
+		} else {
+			fields[name] = values
+		}
+	}
+
+	b, _ := json.Marshal(fields)
+	return b
+}
+
 func commandWebhook(c *Context, w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	id := params["id"]
@@ -93,13 +133,3 @@ func commandWebhook(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/plain")
 	w.Write([]byte("ok"))
 }
-
-func decodePayload(payload io.Reader) (*model.IncomingWebhookRequest, *model.AppError) {
-	incomingWebhookPayload, decodeError := model.IncomingWebhookRequestFromJson(payload)
-
-	if decodeError != nil {
-		return nil, decodeError
-	}
-
-	return incomingWebhookPayload, nil
-}
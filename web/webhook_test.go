@@ -223,6 +223,45 @@ func TestIncomingWebhook(t *testing.T) {
 		assert.True(t, resp.StatusCode == http.StatusForbidden)
 	})
 
+	t.Run("PayloadTemplateWebhook", func(t *testing.T) {
+		hook, err := th.App.CreateIncomingWebhookForChannel(th.BasicUser.Id, th.BasicChannel, &model.IncomingWebhook{
+			ChannelId:       th.BasicChannel.Id,
+			PayloadTemplate: `{"text": {{ json (printf "[%s] %s" .alert.severity .alert.summary) }}}`,
+		})
+		require.Nil(t, err)
+
+		apiHookUrl := ApiClient.Url + "/hooks/" + hook.Id
+
+		resp, err2 := http.Post(apiHookUrl, "application/json", strings.NewReader(`{"alert": {"severity": "critical", "summary": "database down"}}`))
+		require.Nil(t, err2)
+		assert.True(t, resp.StatusCode == http.StatusOK)
+
+		postList, err := th.App.GetPosts(th.BasicChannel.Id, 0, 1)
+		require.Nil(t, err)
+		require.Len(t, postList.Order, 1)
+		assert.Equal(t, "[critical] database down", postList.Posts[postList.Order[0]].Message)
+
+		resp, err2 = http.Post(apiHookUrl, "application/json", strings.NewReader(`{"alert": `))
+		require.Nil(t, err2)
+		assert.True(t, resp.StatusCode == http.StatusBadRequest)
+
+		payloadMultiPart := "------WebKitFormBoundary7MA4YWxkTrZu0gW\r\nContent-Disposition: form-data; name=\"payload\"\r\n\r\n{\"alert\": {\"severity\": \"minor\", \"summary\": \"disk filling up\"}}\r\n------WebKitFormBoundary7MA4YWxkTrZu0gW--"
+		resp, err2 = http.Post(apiHookUrl, "multipart/form-data; boundary=----WebKitFormBoundary7MA4YWxkTrZu0gW", strings.NewReader(payloadMultiPart))
+		require.Nil(t, err2)
+		assert.True(t, resp.StatusCode == http.StatusOK)
+
+		postList, err = th.App.GetPosts(th.BasicChannel.Id, 0, 1)
+		require.Nil(t, err)
+		require.Len(t, postList.Order, 1)
+		assert.Equal(t, "[minor] disk filling up", postList.Posts[postList.Order[0]].Message)
+
+		tooLarge := `{"alert": {"severity": "critical", "summary": "` + strings.Repeat("a", model.INCOMING_WEBHOOK_REQUEST_MAX_SIZE) + `"}}`
+		resp, err2 = http.Post(apiHookUrl, "application/json", strings.NewReader(tooLarge))
+		if err2 == nil {
+			assert.True(t, resp.StatusCode == http.StatusBadRequest)
+		}
+	})
+
 	t.Run("DisableWebhooks", func(t *testing.T) {
 		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableIncomingWebhooks = false })
 		resp, err := http.Post(url, "application/json", strings.NewReader("{\"text\":\"this is a test\"}"))